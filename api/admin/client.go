@@ -5,11 +5,18 @@ package admin
 
 import (
 	"context"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/webhook"
 )
 
 var _ Client = (*client)(nil)
@@ -28,6 +35,12 @@ type Client interface {
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	VerifyIntegrity(ctx context.Context, chainID string, repair bool, options ...rpc.Option) ([]string, error)
+	GetDatabaseUsage(ctx context.Context, chainID string, maxKeys uint64, options ...rpc.Option) (map[string]prefixdb.Usage, error)
+	ProfileChainVM(ctx context.Context, chainID string, profile string, duration time.Duration, options ...rpc.Option) ([]byte, error)
+	GetChainVMRuntimeMetrics(ctx context.Context, chainID string, options ...rpc.Option) ([]*dto.MetricFamily, error)
+	GetWebhookDeadLetters(context.Context, ...rpc.Option) ([]*webhook.DeadLetter, error)
+	RemoveWebhookDeadLetter(ctx context.Context, id ids.ID, options ...rpc.Option) error
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -140,3 +153,55 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "admin.getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) VerifyIntegrity(ctx context.Context, chain string, repair bool, options ...rpc.Option) ([]string, error) {
+	res := &VerifyIntegrityReply{}
+	err := c.requester.SendRequest(ctx, "admin.verifyIntegrity", &VerifyIntegrityArgs{
+		Chain:  chain,
+		Repair: repair,
+	}, res, options...)
+	return res.Discrepancies, err
+}
+
+func (c *client) GetDatabaseUsage(ctx context.Context, chain string, maxKeys uint64, options ...rpc.Option) (map[string]prefixdb.Usage, error) {
+	res := &GetDatabaseUsageReply{}
+	err := c.requester.SendRequest(ctx, "admin.getDatabaseUsage", &GetDatabaseUsageArgs{
+		Chain:   chain,
+		MaxKeys: json.Uint64(maxKeys),
+	}, res, options...)
+	return res.Namespaces, err
+}
+
+func (c *client) ProfileChainVM(ctx context.Context, chain string, profile string, duration time.Duration, options ...rpc.Option) ([]byte, error) {
+	res := &ProfileChainVMReply{}
+	err := c.requester.SendRequest(ctx, "admin.profileChainVM", &ProfileChainVMArgs{
+		Chain:    chain,
+		Profile:  profile,
+		Duration: json.Uint64(duration / time.Second),
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+	return formatting.Decode(res.Encoding, res.Profile)
+}
+
+func (c *client) GetChainVMRuntimeMetrics(ctx context.Context, chain string, options ...rpc.Option) ([]*dto.MetricFamily, error) {
+	res := &GetChainVMRuntimeMetricsReply{}
+	err := c.requester.SendRequest(ctx, "admin.getChainVMRuntimeMetrics", &GetChainVMRuntimeMetricsArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.MetricFamilies, err
+}
+
+func (c *client) GetWebhookDeadLetters(ctx context.Context, options ...rpc.Option) ([]*webhook.DeadLetter, error) {
+	res := &GetWebhookDeadLettersReply{}
+	err := c.requester.SendRequest(ctx, "admin.getWebhookDeadLetters", struct{}{}, res, options...)
+	return res.DeadLetters, err
+}
+
+func (c *client) RemoveWebhookDeadLetter(ctx context.Context, id ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.removeWebhookDeadLetter", &RemoveWebhookDeadLetterArgs{
+		ID: id,
+	}, &api.EmptyReply{}, options...)
+}
@@ -7,13 +7,21 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"google.golang.org/protobuf/proto"
+
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/webhook"
 )
 
 var errTest = errors.New("non-nil error")
@@ -66,6 +74,21 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *LoggerLevelReply:
 		response := mc.response.(*LoggerLevelReply)
 		*p = *response
+	case *VerifyIntegrityReply:
+		response := mc.response.(*VerifyIntegrityReply)
+		*p = *response
+	case *GetDatabaseUsageReply:
+		response := mc.response.(*GetDatabaseUsageReply)
+		*p = *response
+	case *ProfileChainVMReply:
+		response := mc.response.(*ProfileChainVMReply)
+		*p = *response
+	case *GetChainVMRuntimeMetricsReply:
+		response := mc.response.(*GetChainVMRuntimeMetricsReply)
+		*p = *response
+	case *GetWebhookDeadLettersReply:
+		response := mc.response.(*GetWebhookDeadLettersReply)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -371,3 +394,144 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyIntegrity(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := []string{"discrepancy1", "discrepancy2"}
+		mockClient := client{requester: NewMockClient(&VerifyIntegrityReply{
+			Discrepancies: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.VerifyIntegrity(context.Background(), "chain", true)
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&VerifyIntegrityReply{}, errTest)}
+		_, err := mockClient.VerifyIntegrity(context.Background(), "chain", false)
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestGetDatabaseUsage(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := map[string]prefixdb.Usage{
+			"vm": {
+				NumKeys:  2,
+				NumBytes: 64,
+			},
+			"vm/utxos": {
+				NumKeys:  1,
+				NumBytes: 32,
+				Partial:  true,
+			},
+		}
+		mockClient := client{requester: NewMockClient(&GetDatabaseUsageReply{
+			Namespaces: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.GetDatabaseUsage(context.Background(), "chain", 1)
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetDatabaseUsageReply{}, errTest)}
+		_, err := mockClient.GetDatabaseUsage(context.Background(), "chain", 0)
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestProfileChainVM(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedProfile := []byte("profile")
+		encodedProfile, err := formatting.Encode(formatting.Hex, expectedProfile)
+		require.NoError(err)
+
+		mockClient := client{requester: NewMockClient(&ProfileChainVMReply{
+			Profile:  encodedProfile,
+			Encoding: formatting.Hex,
+		}, nil)}
+
+		profile, err := mockClient.ProfileChainVM(context.Background(), "chain", "cpu", time.Second)
+		require.NoError(err)
+		require.Equal(expectedProfile, profile)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ProfileChainVMReply{}, errTest)}
+		_, err := mockClient.ProfileChainVM(context.Background(), "chain", "heap", 0)
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestGetChainVMRuntimeMetrics(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := []*dto.MetricFamily{
+			{
+				Name: proto.String("go_goroutines"),
+				Type: dto.MetricType_GAUGE.Enum(),
+			},
+		}
+		mockClient := client{requester: NewMockClient(&GetChainVMRuntimeMetricsReply{
+			MetricFamilies: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.GetChainVMRuntimeMetrics(context.Background(), "chain")
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetChainVMRuntimeMetricsReply{}, errTest)}
+		_, err := mockClient.GetChainVMRuntimeMetrics(context.Background(), "chain")
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestGetWebhookDeadLetters(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := []*webhook.DeadLetter{{
+			ID:       ids.GenerateTestID(),
+			URL:      "http://localhost:8080",
+			Attempts: 5,
+			Error:    "unexpected response status: 500",
+		}}
+		mockClient := client{requester: NewMockClient(&GetWebhookDeadLettersReply{
+			DeadLetters: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.GetWebhookDeadLetters(context.Background())
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetWebhookDeadLettersReply{}, errTest)}
+		_, err := mockClient.GetWebhookDeadLetters(context.Background())
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestRemoveWebhookDeadLetter(t *testing.T) {
+	require := require.New(t)
+
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.RemoveWebhookDeadLetter(context.Background(), ids.GenerateTestID())
+		require.ErrorIs(err, test.Err)
+	}
+}
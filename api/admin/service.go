@@ -5,26 +5,33 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
 	"go.uber.org/zap"
 
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/registry"
+	"github.com/ava-labs/avalanchego/webhook"
 )
 
 const (
@@ -32,11 +39,18 @@ const (
 
 	// Name of file that stacktraces are written to
 	stacktraceFile = "stacktrace.txt"
+
+	// maxProfileDuration is the longest a cpu profile of a chain's VM can be
+	// collected over
+	maxProfileDuration = 5 * time.Minute
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong     = errors.New("alias length is too long")
+	errNoLogLevel       = errors.New("need to specify either displayLevel or logLevel")
+	errWebhooksDisabled = errors.New("webhooks are disabled")
+
+	errInvalidProfileDuration = errors.New("invalid profile duration")
 )
 
 type Config struct {
@@ -48,6 +62,9 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	// Webhooks delivers address activity to webhook endpoints. Nil if
+	// webhooks are disabled.
+	Webhooks *webhook.Dispatcher
 }
 
 // Admin is the API service for node admin management
@@ -350,6 +367,54 @@ func (a *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply) error
 	return err
 }
 
+// VerifyIntegrityArgs are the arguments for calling VerifyIntegrity
+type VerifyIntegrityArgs struct {
+	Chain string `json:"chain"`
+	// Repair causes the derived indexes of the chain to be rebuilt to resolve
+	// the discrepancies found
+	Repair bool `json:"repair"`
+}
+
+// VerifyIntegrityReply are the discrepancies found in the chain's state
+type VerifyIntegrityReply struct {
+	Discrepancies []string `json:"discrepancies"`
+}
+
+// VerifyIntegrity verifies the consistency of the persisted state of the chain.
+//
+// The chain doesn't process blocks while its state is being verified.
+func (a *Admin) VerifyIntegrity(r *http.Request, args *VerifyIntegrityArgs, reply *VerifyIntegrityReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "verifyIntegrity"),
+		logging.UserString("chain", args.Chain),
+		zap.Bool("repair", args.Repair),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	discrepancies, err := a.ChainManager.VerifyIntegrity(r.Context(), chainID, args.Repair)
+	if err != nil {
+		return err
+	}
+	if len(discrepancies) > 0 {
+		a.Log.Warn("found discrepancies in chain state",
+			zap.Stringer("chainID", chainID),
+			zap.Int("numDiscrepancies", len(discrepancies)),
+			zap.Bool("repaired", args.Repair),
+		)
+	}
+
+	reply.Discrepancies = discrepancies
+	if reply.Discrepancies == nil {
+		reply.Discrepancies = []string{}
+	}
+	return nil
+}
+
 func (a *Admin) getLoggerNames(loggerName string) []string {
 	if len(loggerName) == 0 {
 		// Empty name means all loggers
@@ -376,3 +441,163 @@ func (a *Admin) getLogLevels(loggerNames []string) (map[string]LogAndDisplayLeve
 	}
 	return loggerLevels, nil
 }
+
+// GetDatabaseUsageArgs are the arguments for calling GetDatabaseUsage
+type GetDatabaseUsageArgs struct {
+	Chain string `json:"chain"`
+	// MaxKeys is the maximum number of keys counted per namespace. If zero,
+	// every key is counted.
+	MaxKeys json.Uint64 `json:"maxKeys"`
+}
+
+// GetDatabaseUsageReply is the storage used by each namespace of the chain's
+// databases
+type GetDatabaseUsageReply struct {
+	Namespaces map[string]prefixdb.Usage `json:"namespaces"`
+}
+
+// GetDatabaseUsage reports the storage used by each namespace of the databases
+// of the chain. The "vm" namespace includes every namespace nested under it.
+//
+// The chain doesn't process blocks while its databases are being iterated
+// over, so [MaxKeys] should be set for chains with a large state.
+func (a *Admin) GetDatabaseUsage(r *http.Request, args *GetDatabaseUsageArgs, reply *GetDatabaseUsageReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getDatabaseUsage"),
+		logging.UserString("chain", args.Chain),
+		zap.Uint64("maxKeys", uint64(args.MaxKeys)),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	reply.Namespaces, err = a.ChainManager.DatabaseUsage(r.Context(), chainID, uint64(args.MaxKeys))
+	return err
+}
+
+// ProfileChainVMArgs are the arguments for calling ProfileChainVM
+type ProfileChainVMArgs struct {
+	Chain string `json:"chain"`
+	// Profile is either "cpu" or the name of a runtime/pprof profile, such as
+	// "heap" or "goroutine"
+	Profile string `json:"profile"`
+	// Duration is the number of seconds the cpu profile is collected over
+	Duration json.Uint64         `json:"duration"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// ProfileChainVMReply is the encoded pprof profile of the chain's VM
+type ProfileChainVMReply struct {
+	Profile  string              `json:"profile"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// ProfileChainVM returns a pprof profile of the process running the VM of the
+// chain. Only VMs running in a separate process, such as plugins, can be
+// profiled.
+func (a *Admin) ProfileChainVM(r *http.Request, args *ProfileChainVMArgs, reply *ProfileChainVMReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "profileChainVM"),
+		logging.UserString("chain", args.Chain),
+		logging.UserString("profile", args.Profile),
+		zap.Uint64("duration", uint64(args.Duration)),
+	)
+
+	duration := time.Duration(args.Duration) * time.Second
+	if args.Profile == profiler.CPUProfile && (duration <= 0 || duration > maxProfileDuration) {
+		return fmt.Errorf("%w: %s not in (0, %s]", errInvalidProfileDuration, duration, maxProfileDuration)
+	}
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	profile, err := a.ChainManager.ProfileVM(r.Context(), chainID, args.Profile, duration)
+	if err != nil {
+		return err
+	}
+
+	reply.Profile, err = formatting.Encode(args.Encoding, profile)
+	if err != nil {
+		return fmt.Errorf("couldn't encode profile as %s: %w", args.Encoding, err)
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+// GetChainVMRuntimeMetricsArgs are the arguments for calling
+// GetChainVMRuntimeMetrics
+type GetChainVMRuntimeMetricsArgs struct {
+	Chain string `json:"chain"`
+}
+
+// GetChainVMRuntimeMetricsReply are the runtime metrics of the chain's VM
+type GetChainVMRuntimeMetricsReply struct {
+	MetricFamilies []*dto.MetricFamily `json:"metricFamilies"`
+}
+
+// GetChainVMRuntimeMetrics returns the Go runtime and process metrics of the
+// process running the VM of the chain. Only VMs running in a separate process,
+// such as plugins, report runtime metrics.
+func (a *Admin) GetChainVMRuntimeMetrics(r *http.Request, args *GetChainVMRuntimeMetricsArgs, reply *GetChainVMRuntimeMetricsReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getChainVMRuntimeMetrics"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	reply.MetricFamilies, err = a.ChainManager.VMRuntimeMetrics(r.Context(), chainID)
+	return err
+}
+
+// GetWebhookDeadLettersReply are the webhook events that couldn't be delivered
+type GetWebhookDeadLettersReply struct {
+	DeadLetters []*webhook.DeadLetter `json:"deadLetters"`
+}
+
+// GetWebhookDeadLetters returns the webhook events that couldn't be delivered
+func (a *Admin) GetWebhookDeadLetters(_ *http.Request, _ *struct{}, reply *GetWebhookDeadLettersReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getWebhookDeadLetters"),
+	)
+
+	if a.Webhooks == nil {
+		return errWebhooksDisabled
+	}
+
+	var err error
+	reply.DeadLetters, err = a.Webhooks.DeadLetters()
+	return err
+}
+
+// RemoveWebhookDeadLetterArgs are the arguments for calling
+// RemoveWebhookDeadLetter
+type RemoveWebhookDeadLetterArgs struct {
+	ID ids.ID `json:"id"`
+}
+
+// RemoveWebhookDeadLetter removes a webhook event that couldn't be delivered,
+// once it has been handled
+func (a *Admin) RemoveWebhookDeadLetter(_ *http.Request, args *RemoveWebhookDeadLetterArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "removeWebhookDeadLetter"),
+		zap.Stringer("id", args.ID),
+	)
+
+	if a.Webhooks == nil {
+		return errWebhooksDisabled
+	}
+	return a.Webhooks.RemoveDeadLetter(args.ID)
+}
@@ -27,6 +27,7 @@ type Client interface {
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ids.ID, ...rpc.Option) (*UptimeResponse, error)
+	UpgradeReadiness(context.Context, ids.ID, string, ...rpc.Option) (*UpgradeReadinessResponse, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
 }
 
@@ -108,6 +109,15 @@ func (c *client) Uptime(ctx context.Context, subnetID ids.ID, options ...rpc.Opt
 	return res, err
 }
 
+func (c *client) UpgradeReadiness(ctx context.Context, subnetID ids.ID, version string, options ...rpc.Option) (*UpgradeReadinessResponse, error) {
+	res := &UpgradeReadinessResponse{}
+	err := c.requester.SendRequest(ctx, "info.upgradeReadiness", &UpgradeReadinessRequest{
+		SubnetID: subnetID,
+		Version:  version,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, error) {
 	res := &GetVMsReply{}
 	err := c.requester.SendRequest(ctx, "info.getVMs", struct{}{}, res, options...)
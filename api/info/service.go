@@ -319,6 +319,65 @@ func (i *Info) Uptime(_ *http.Request, args *UptimeRequest, reply *UptimeRespons
 	return nil
 }
 
+type UpgradeReadinessRequest struct {
+	// if omitted, defaults to primary network
+	SubnetID ids.ID `json:"subnetID"`
+	// Version validators must be running to be considered ready, such as
+	// "avalanche/1.10.15". If omitted, defaults to the version of this node.
+	Version string `json:"version"`
+}
+
+// UpgradeReadinessResponse are the results from calling UpgradeReadiness
+type UpgradeReadinessResponse struct {
+	// Version validators must be running to be considered ready
+	Version string `json:"version"`
+
+	// ReadyStakePercentage shows what percent of the stake is held by
+	// validators that are connected and running at least [Version].
+	ReadyStakePercentage json.Float64 `json:"readyStakePercentage"`
+
+	// ConnectedStakePercentage shows what percent of the stake is held by
+	// validators that are connected. Validators that aren't connected are
+	// never counted as ready.
+	ConnectedStakePercentage json.Float64 `json:"connectedStakePercentage"`
+
+	// VersionStakePercentages shows what percent of the stake is held by the
+	// connected validators running each version.
+	VersionStakePercentages map[string]json.Float64 `json:"versionStakePercentages"`
+}
+
+// UpgradeReadiness reports how much stake is held by validators running at
+// least a version, to judge whether it is safe to schedule a network upgrade
+// that requires the version.
+func (i *Info) UpgradeReadiness(_ *http.Request, args *UpgradeReadinessRequest, reply *UpgradeReadinessResponse) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "upgradeReadiness"),
+	)
+
+	minVersion := i.Version
+	if args.Version != "" {
+		var err error
+		minVersion, err = version.ParseApplication(args.Version)
+		if err != nil {
+			return fmt.Errorf("couldn't parse version: %w", err)
+		}
+	}
+
+	result, err := i.networking.UpgradeReadiness(args.SubnetID, minVersion)
+	if err != nil {
+		return fmt.Errorf("couldn't get upgrade readiness: %w", err)
+	}
+	reply.Version = minVersion.String()
+	reply.ReadyStakePercentage = json.Float64(result.ReadyStakePercentage)
+	reply.ConnectedStakePercentage = json.Float64(result.ConnectedStakePercentage)
+	reply.VersionStakePercentages = make(map[string]json.Float64, len(result.VersionStakePercentages))
+	for peerVersion, percent := range result.VersionStakePercentages {
+		reply.VersionStakePercentages[peerVersion] = json.Float64(percent)
+	}
+	return nil
+}
+
 type GetTxFeeResponse struct {
 	TxFee                         json.Uint64 `json:"txFee"`
 	CreateAssetTxFee              json.Uint64 `json:"createAssetTxFee"`
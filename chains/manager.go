@@ -16,6 +16,10 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	dto "github.com/prometheus/client_model/go"
+
+	"go.opentelemetry.io/otel/attribute"
+
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/api/health"
@@ -38,6 +42,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common/tracker"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/syncer"
+	"github.com/ava-labs/avalanchego/snow/networking/frontier"
 	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
@@ -52,6 +57,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/metervm"
@@ -94,6 +100,9 @@ var (
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
 	errNoPrimaryNetworkConfig  = errors.New("no subnet config for primary network found")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
+	errUnknownChain            = errors.New("unknown chain")
+	errIntegrityNotSupported   = errors.New("chain doesn't support integrity verification")
+	errProfilingNotSupported   = errors.New("chain's VM doesn't support profiling")
 
 	_ Manager = (*manager)(nil)
 )
@@ -129,6 +138,25 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// VerifyIntegrity verifies the consistency of the persisted state of the
+	// chain with the given ID, returning the discrepancies found. If [repair]
+	// is true, the derived indexes are rebuilt to resolve the discrepancies.
+	VerifyIntegrity(ctx context.Context, chainID ids.ID, repair bool) ([]string, error)
+
+	// DatabaseUsage computes the storage used by each namespace of the
+	// databases of the chain with the given ID. If [maxKeys] is non-zero, at
+	// most [maxKeys] keys are counted per namespace.
+	DatabaseUsage(ctx context.Context, chainID ids.ID, maxKeys uint64) (map[string]prefixdb.Usage, error)
+
+	// ProfileVM returns the [name] pprof profile of the process running the
+	// VM of the chain with the given ID. The cpu profile is collected over
+	// [duration].
+	ProfileVM(ctx context.Context, chainID ids.ID, name string, duration time.Duration) ([]byte, error)
+
+	// VMRuntimeMetrics returns the Go runtime and process metrics of the
+	// process running the VM of the chain with the given ID.
+	VMRuntimeMetrics(ctx context.Context, chainID ids.ID) ([]*dto.MetricFamily, error)
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
@@ -158,6 +186,15 @@ type chain struct {
 	VM      common.VM
 	Handler handler.Handler
 	Beacons validators.Manager
+	// IntegrityVerifier verifies the consistency of the chain's persisted
+	// state
+	IntegrityVerifier block.IntegrityVerifierVM
+	// DatabaseNamespaces returns the databases of the chain whose usage can be
+	// reported, keyed by namespace
+	DatabaseNamespaces func() map[string]database.Iteratee
+	// Profiler collects the diagnostics of the process running the chain's VM,
+	// if the VM runs in a separate process
+	Profiler block.ProfilerVM
 }
 
 // ChainConfig is configuration settings for the current execution.
@@ -171,8 +208,11 @@ type ChainConfig struct {
 type ManagerConfig struct {
 	SybilProtectionEnabled bool
 	StakingTLSCert         tls.Certificate // needed to sign snowman++ blocks
-	StakingBLSKey          *bls.SecretKey
-	TracingEnabled         bool
+	// SecondaryStakingTLSCert, if non-nil, is the staking key being rotated
+	// to. snowman++ blocks are also signed with it during the rotation.
+	SecondaryStakingTLSCert *tls.Certificate
+	StakingBLSKey           *bls.SecretKey
+	TracingEnabled          bool
 	// Must not be used unless [TracingEnabled] is true as this may be nil.
 	Tracer                    trace.Tracer
 	Log                       logging.Logger
@@ -186,6 +226,7 @@ type ManagerConfig struct {
 	Router                    router.Router              // Routes incoming messages to the appropriate chain
 	Net                       network.Network            // Sends consensus messages to other validators
 	Validators                validators.Manager         // Validators validating on this chain
+	FrontierTracker           *frontier.Tracker          // Serves accepted frontier attestations to light clients
 	NodeID                    ids.NodeID                 // The ID of this node
 	NetworkID                 uint32                     // ID of the network this node is connected to
 	PartialSyncPrimaryNetwork bool
@@ -237,6 +278,10 @@ type manager struct {
 	stakingSigner crypto.Signer
 	stakingCert   *staking.Certificate
 
+	// Nil unless a staking key rotation is in progress
+	secondaryStakingSigner crypto.Signer
+	secondaryStakingCert   *staking.Certificate
+
 	// Those notified when a chain is created
 	registrants []Registrant
 
@@ -258,6 +303,15 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]handler.Handler
+	// Key: Chain's ID
+	// Value: Verifier of the chain's persisted state
+	integrityVerifiers map[ids.ID]block.IntegrityVerifierVM
+	// Key: Chain's ID
+	// Value: Namespaces of the chain's databases
+	databaseNamespaces map[ids.ID]func() map[string]database.Iteratee
+	// Key: Chain's ID
+	// Value: Profiler of the process running the chain's VM
+	profilers map[ids.ID]block.ProfilerVM
 
 	// snowman++ related interface to allow validators retrieval
 	validatorState validators.State
@@ -265,13 +319,27 @@ type manager struct {
 
 // New returns a new Manager
 func New(config *ManagerConfig) Manager {
+	var (
+		secondaryStakingSigner crypto.Signer
+		secondaryStakingCert   *staking.Certificate
+	)
+	if config.SecondaryStakingTLSCert != nil {
+		secondaryStakingSigner = config.SecondaryStakingTLSCert.PrivateKey.(crypto.Signer)
+		secondaryStakingCert = staking.CertificateFromX509(config.SecondaryStakingTLSCert.Leaf)
+	}
+
 	return &manager{
 		Aliaser:                ids.NewAliaser(),
 		ManagerConfig:          *config,
 		stakingSigner:          config.StakingTLSCert.PrivateKey.(crypto.Signer),
 		stakingCert:            staking.CertificateFromX509(config.StakingTLSCert.Leaf),
+		secondaryStakingSigner: secondaryStakingSigner,
+		secondaryStakingCert:   secondaryStakingCert,
 		subnets:                make(map[ids.ID]subnets.Subnet),
 		chains:                 make(map[ids.ID]handler.Handler),
+		integrityVerifiers:     make(map[ids.ID]block.IntegrityVerifierVM),
+		databaseNamespaces:     make(map[ids.ID]func() map[string]database.Iteratee),
+		profilers:              make(map[ids.ID]block.ProfilerVM),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
 		unblockChainCreatorCh:  make(chan struct{}),
 		chainCreatorShutdownCh: make(chan struct{}),
@@ -390,6 +458,15 @@ func (m *manager) createChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	if chain.IntegrityVerifier != nil {
+		m.integrityVerifiers[chainParams.ID] = chain.IntegrityVerifier
+	}
+	if chain.DatabaseNamespaces != nil {
+		m.databaseNamespaces[chainParams.ID] = chain.DatabaseNamespaces
+	}
+	if chain.Profiler != nil {
+		m.profilers[chainParams.ID] = chain.Profiler
+	}
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -566,6 +643,12 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		return nil, errUnknownVMType
 	}
 
+	// Only VMs running in a separate process can be profiled independently of
+	// the node, so the VM is checked before it is wrapped.
+	if profiler, ok := vm.(block.ProfilerVM); ok {
+		chain.Profiler = profiler
+	}
+
 	// Register the chain with the timeout manager
 	if err := m.TimeoutManager.RegisterChain(ctx); err != nil {
 		return nil, err
@@ -599,12 +682,18 @@ func (m *manager) createAvalancheChain(
 	if err != nil {
 		return nil, err
 	}
+	writeMetrics, err := meterdb.NewWriteMetrics("db_prefix", ctx.Registerer)
+	if err != nil {
+		return nil, err
+	}
 	prefixDB := prefixdb.New(ctx.ChainID[:], meterDB)
+	// The writes of the VM are attributed by the proposervm once the chain is
+	// linearized.
 	vmDB := prefixdb.New(vmDBPrefix, prefixDB)
-	vertexDB := prefixdb.New(vertexDBPrefix, prefixDB)
-	vertexBootstrappingDB := prefixdb.New(vertexBootstrappingDBPrefix, prefixDB)
-	txBootstrappingDB := prefixdb.New(txBootstrappingDBPrefix, prefixDB)
-	blockBootstrappingDB := prefixdb.New(blockBootstrappingDBPrefix, prefixDB)
+	vertexDB := writeMetrics.Wrap("vertex", prefixdb.New(vertexDBPrefix, prefixDB))
+	vertexBootstrappingDB := writeMetrics.Wrap("vertex_bootstrapping", prefixdb.New(vertexBootstrappingDBPrefix, prefixDB))
+	txBootstrappingDB := writeMetrics.Wrap("tx_bootstrapping", prefixdb.New(txBootstrappingDBPrefix, prefixDB))
+	blockBootstrappingDB := writeMetrics.Wrap("block_bootstrapping", prefixdb.New(blockBootstrappingDBPrefix, prefixDB))
 
 	vtxBlocker, err := queue.NewWithMissing(vertexBootstrappingDB, "vtx", ctx.AvalancheRegisterer)
 	if err != nil {
@@ -727,7 +816,7 @@ func (m *manager) createAvalancheChain(
 	err = dagVM.Initialize(
 		context.TODO(),
 		ctx.Context,
-		vmDB,
+		writeMetrics.Wrap("vm", vmDB),
 		genesisData,
 		chainConfig.Upgrade,
 		chainConfig.Config,
@@ -740,48 +829,40 @@ func (m *manager) createAvalancheChain(
 	}
 
 	// Initialize the ProposerVM and the vm wrapped inside it
-	var (
-		minBlockDelay       = proposervm.DefaultMinBlockDelay
-		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
-	)
-	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
-		minBlockDelay = subnetCfg.ProposerMinBlockDelay
-		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
-	}
-	m.Log.Info("creating proposervm wrapper",
-		zap.Time("activationTime", m.ApricotPhase4Time),
-		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
-		zap.Duration("minBlockDelay", minBlockDelay),
-		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
-	)
+	proposerVMConfig := m.proposerVMConfig(ctx.SubnetID)
+	proposerVMConfig.WriteMetrics = writeMetrics
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
 
 	// Note: this does not use [dagVM] to ensure we use the [vm]'s height index.
 	untracedVMWrappedInsideProposerVM := NewLinearizeOnInitializeVM(vm)
 
+	// Spans of the proposervm and the inner VM are scoped to this chain so
+	// that spans of different chains using the same VM can be told apart.
+	chainTracer := trace.WithAttributes(m.Tracer, attribute.Stringer("chainID", ctx.ChainID))
+
 	var vmWrappedInsideProposerVM block.ChainVM = untracedVMWrappedInsideProposerVM
 	if m.TracingEnabled {
-		vmWrappedInsideProposerVM = tracedvm.NewBlockVM(vmWrappedInsideProposerVM, chainAlias, m.Tracer)
+		vmWrappedInsideProposerVM = tracedvm.NewBlockVM(vmWrappedInsideProposerVM, chainAlias, chainTracer)
 	}
 
 	// Note: vmWrappingProposerVM is the VM that the Snowman engines should be
 	// using.
-	var vmWrappingProposerVM block.ChainVM = proposervm.New(
+	proposerVM := proposervm.New(
 		vmWrappedInsideProposerVM,
-		m.ApricotPhase4Time,
-		m.ApricotPhase4MinPChainHeight,
-		minBlockDelay,
-		numHistoricalBlocks,
-		m.stakingSigner,
-		m.stakingCert,
+		proposerVMConfig,
 	)
+	if err := m.registerProposerVMAcceptor(ctx.ChainID, proposerVM); err != nil {
+		return nil, err
+	}
+
+	var vmWrappingProposerVM block.ChainVM = proposerVM
 
 	if m.MeterVMEnabled {
 		vmWrappingProposerVM = metervm.NewBlockVM(vmWrappingProposerVM)
 	}
 	if m.TracingEnabled {
-		vmWrappingProposerVM = tracedvm.NewBlockVM(vmWrappingProposerVM, "proposervm", m.Tracer)
+		vmWrappingProposerVM = tracedvm.NewBlockVM(vmWrappingProposerVM, "proposervm", chainTracer)
 	}
 
 	// Note: linearizableVM is the VM that the Avalanche engines should be
@@ -824,7 +905,7 @@ func (m *manager) createAvalancheChain(
 		ctx,
 		vdrs,
 		msgChan,
-		m.FrontierPollFrequency,
+		m.frontierPollFrequency(sb),
 		m.ConsensusAppConcurrency,
 		m.ResourceTracker,
 		validators.UnhandledSubnetConnector, // avalanche chains don't use subnet connector
@@ -970,10 +1051,23 @@ func (m *manager) createAvalancheChain(
 	}
 
 	return &chain{
-		Name:    chainAlias,
-		Context: ctx,
-		VM:      dagVM,
-		Handler: h,
+		Name:              chainAlias,
+		Context:           ctx,
+		VM:                dagVM,
+		Handler:           h,
+		IntegrityVerifier: proposerVM,
+		DatabaseNamespaces: func() map[string]database.Iteratee {
+			namespaces := map[string]database.Iteratee{
+				"vm":                   vmDB,
+				"vertex":               vertexDB,
+				"vertex_bootstrapping": vertexBootstrappingDB,
+				"tx_bootstrapping":     txBootstrappingDB,
+				"block_bootstrapping":  blockBootstrappingDB,
+			}
+			addVMDatabaseNamespaces(namespaces, proposerVM)
+			addVMDatabaseNamespaces(namespaces, vm)
+			return namespaces
+		},
 	}, nil
 }
 
@@ -999,9 +1093,14 @@ func (m *manager) createSnowmanChain(
 	if err != nil {
 		return nil, err
 	}
+	writeMetrics, err := meterdb.NewWriteMetrics("db_prefix", ctx.Registerer)
+	if err != nil {
+		return nil, err
+	}
 	prefixDB := prefixdb.New(ctx.ChainID[:], meterDB)
+	// The writes of the VM are attributed by the proposervm.
 	vmDB := prefixdb.New(vmDBPrefix, prefixDB)
-	bootstrappingDB := prefixdb.New(bootstrappingDB, prefixDB)
+	bootstrappingDB := writeMetrics.Wrap("bootstrapping", prefixdb.New(bootstrappingDB, prefixDB))
 
 	blocked, err := queue.NewWithMissing(bootstrappingDB, "block", ctx.Registerer)
 	if err != nil {
@@ -1090,41 +1189,34 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("error while fetching chain config: %w", err)
 	}
 
-	var (
-		minBlockDelay       = proposervm.DefaultMinBlockDelay
-		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
-	)
-	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
-		minBlockDelay = subnetCfg.ProposerMinBlockDelay
-		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
-	}
-	m.Log.Info("creating proposervm wrapper",
-		zap.Time("activationTime", m.ApricotPhase4Time),
-		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
-		zap.Duration("minBlockDelay", minBlockDelay),
-		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
-	)
+	proposerVMConfig := m.proposerVMConfig(ctx.SubnetID)
+	proposerVMConfig.WriteMetrics = writeMetrics
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
+	// Spans of the proposervm and the inner VM are scoped to this chain so
+	// that spans of different chains using the same VM can be told apart.
+	chainTracer := trace.WithAttributes(m.Tracer, attribute.Stringer("chainID", ctx.ChainID))
+	// The wrappers of the VM don't report its database namespaces
+	innerVM := vm
 	if m.TracingEnabled {
-		vm = tracedvm.NewBlockVM(vm, chainAlias, m.Tracer)
+		vm = tracedvm.NewBlockVM(vm, chainAlias, chainTracer)
 	}
 
-	vm = proposervm.New(
+	proposerVM := proposervm.New(
 		vm,
-		m.ApricotPhase4Time,
-		m.ApricotPhase4MinPChainHeight,
-		minBlockDelay,
-		numHistoricalBlocks,
-		m.stakingSigner,
-		m.stakingCert,
+		proposerVMConfig,
 	)
+	if err := m.registerProposerVMAcceptor(ctx.ChainID, proposerVM); err != nil {
+		return nil, err
+	}
+
+	vm = proposerVM
 
 	if m.MeterVMEnabled {
 		vm = metervm.NewBlockVM(vm)
 	}
 	if m.TracingEnabled {
-		vm = tracedvm.NewBlockVM(vm, "proposervm", m.Tracer)
+		vm = tracedvm.NewBlockVM(vm, "proposervm", chainTracer)
 	}
 
 	// The channel through which a VM may send messages to the consensus engine
@@ -1145,6 +1237,20 @@ func (m *manager) createSnowmanChain(
 		return nil, err
 	}
 
+	frontierAcceptor, err := m.FrontierTracker.Register(context.TODO(), ctx.ChainID, vm)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't track accepted frontier: %w", err)
+	}
+	err = m.BlockAcceptorGroup.RegisterAcceptor(
+		ctx.ChainID,
+		"frontier",
+		frontierAcceptor,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register frontier acceptor: %w", err)
+	}
+
 	bootstrapWeight, err := beacons.TotalWeight(ctx.SubnetID)
 	if err != nil {
 		return nil, fmt.Errorf("error while fetching weight for subnet %s: %w", ctx.SubnetID, err)
@@ -1167,7 +1273,7 @@ func (m *manager) createSnowmanChain(
 		ctx,
 		vdrs,
 		msgChan,
-		m.FrontierPollFrequency,
+		m.frontierPollFrequency(sb),
 		m.ConsensusAppConcurrency,
 		m.ResourceTracker,
 		subnetConnector,
@@ -1288,13 +1394,139 @@ func (m *manager) createSnowmanChain(
 	}
 
 	return &chain{
-		Name:    chainAlias,
-		Context: ctx,
-		VM:      vm,
-		Handler: h,
+		Name:              chainAlias,
+		Context:           ctx,
+		VM:                vm,
+		Handler:           h,
+		IntegrityVerifier: proposerVM,
+		DatabaseNamespaces: func() map[string]database.Iteratee {
+			namespaces := map[string]database.Iteratee{
+				"vm":            vmDB,
+				"bootstrapping": bootstrappingDB,
+			}
+			addVMDatabaseNamespaces(namespaces, proposerVM)
+			addVMDatabaseNamespaces(namespaces, innerVM)
+			return namespaces
+		},
 	}, nil
 }
 
+// proposerVMConfig returns the config of the proposervm wrapping the chains of
+// [subnetID].
+func (m *manager) proposerVMConfig(subnetID ids.ID) proposervm.Config {
+	config := proposervm.Config{
+		ActivationTime:                    m.ApricotPhase4Time,
+		MinimumPChainHeight:               m.ApricotPhase4MinPChainHeight,
+		MinBlkDelay:                       proposervm.DefaultMinBlockDelay,
+		NumHistoricalBlocks:               proposervm.DefaultNumHistoricalBlocks,
+		BlockCacheSize:                    proposervm.DefaultBlockCacheSize,
+		AppConcurrency:                    m.ConsensusAppConcurrency,
+		EnforcedMinBlkDelayActivationTime: mockable.MaxTime,
+		CommitValidatorSetActivationTime:  mockable.MaxTime,
+		VRFActivationTime:                 mockable.MaxTime,
+		BlockExtensionsActivationTime:     mockable.MaxTime,
+		PChainHeightEpochActivationTime:   mockable.MaxTime,
+		StakingLeafSigner:                 m.stakingSigner,
+		StakingCertLeaf:                   m.stakingCert,
+		SecondaryStakingLeafSigner:        m.secondaryStakingSigner,
+		SecondaryStakingCertLeaf:          m.secondaryStakingCert,
+	}
+	if subnetCfg, ok := m.SubnetConfigs[subnetID]; ok {
+		config.MinBlkDelay = subnetCfg.ProposerMinBlockDelay
+		config.NumHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		config.CommitValidatorSet = subnetCfg.ProposerCommitValidatorSet
+		// VRF proofs are never required on subnets that don't enable them.
+		if subnetCfg.ProposerVRF {
+			config.VRFKey = m.StakingBLSKey
+			config.VRFActivationTime = subnetCfg.ProposerVRFActivationTime
+		}
+		if !subnetCfg.ProposerBlockExtensionsActivationTime.IsZero() {
+			config.BlockExtensionsActivationTime = subnetCfg.ProposerBlockExtensionsActivationTime
+		}
+		config.GossipEquivocations = subnetCfg.ProposerGossipEquivocations
+		config.BlockCacheSize = subnetCfg.ProposerBlockCacheSize
+		config.MaxClockSkew = subnetCfg.ProposerMaxClockSkew
+		config.CompressInnerBlocks = subnetCfg.ProposerCompressInnerBlocks
+		config.StuckBlockTimeout = subnetCfg.ProposerStuckBlockTimeout
+		config.RebuildOnStuckBlock = subnetCfg.ProposerRebuildOnStuckBlock
+		config.BackfillBlocks = subnetCfg.ProposerBackfillBlocks
+		config.DryRunActivation = subnetCfg.ProposerDryRunActivation
+		config.BuildPendingWorkThreshold = subnetCfg.ProposerBuildPendingWorkThreshold
+
+		upgrade := subnetCfg.Upgrade
+		if upgrade.ProposerActivationTime != nil {
+			config.ActivationTime = *upgrade.ProposerActivationTime
+			config.UpgradeOverrides.ActivationTime = true
+		}
+		if upgrade.ProposerMinPChainHeight != nil {
+			config.MinimumPChainHeight = *upgrade.ProposerMinPChainHeight
+			config.UpgradeOverrides.MinimumPChainHeight = true
+		}
+		config.PChainHeightEpoch = upgrade.ProposerPChainHeightEpoch
+		if upgrade.ProposerPChainHeightEpochActivationTime != nil {
+			config.PChainHeightEpochActivationTime = *upgrade.ProposerPChainHeightEpochActivationTime
+			config.UpgradeOverrides.PChainHeightEpochActivationTime = true
+		}
+		config.EnforcedMinBlkDelay = upgrade.ProposerEnforcedMinBlockDelay
+		if upgrade.ProposerEnforcedMinBlockDelayActivationTime != nil {
+			config.EnforcedMinBlkDelayActivationTime = *upgrade.ProposerEnforcedMinBlockDelayActivationTime
+			config.UpgradeOverrides.EnforcedMinBlkDelayActivationTime = true
+		}
+		if upgrade.ProposerCommitValidatorSetActivationTime != nil {
+			config.CommitValidatorSetActivationTime = *upgrade.ProposerCommitValidatorSetActivationTime
+			config.UpgradeOverrides.CommitValidatorSetActivationTime = true
+		}
+		if upgrade.ProposerVRFActivationTime != nil && subnetCfg.ProposerVRF {
+			config.VRFActivationTime = *upgrade.ProposerVRFActivationTime
+			config.UpgradeOverrides.VRFActivationTime = true
+		}
+		if upgrade.ProposerBlockExtensionsActivationTime != nil {
+			config.BlockExtensionsActivationTime = *upgrade.ProposerBlockExtensionsActivationTime
+			config.UpgradeOverrides.BlockExtensionsActivationTime = true
+		}
+	}
+	m.Log.Info("creating proposervm wrapper",
+		zap.Time("activationTime", config.ActivationTime),
+		zap.Uint64("minPChainHeight", config.MinimumPChainHeight),
+		zap.Duration("minBlockDelay", config.MinBlkDelay),
+		zap.Duration("enforcedMinBlockDelay", config.EnforcedMinBlkDelay),
+		zap.Time("enforcedMinBlockDelayActivationTime", config.EnforcedMinBlkDelayActivationTime),
+		zap.Uint64("numHistoricalBlocks", config.NumHistoricalBlocks),
+		zap.Bool("commitValidatorSet", config.CommitValidatorSet),
+		zap.Time("commitValidatorSetActivationTime", config.CommitValidatorSetActivationTime),
+		zap.Bool("vrf", config.VRFKey != nil),
+		zap.Time("vrfActivationTime", config.VRFActivationTime),
+		zap.Time("blockExtensionsActivationTime", config.BlockExtensionsActivationTime),
+		zap.Bool("gossipEquivocations", config.GossipEquivocations),
+		zap.Int("blockCacheSize", config.BlockCacheSize),
+		zap.Duration("maxClockSkew", config.MaxClockSkew),
+		zap.Bool("compressInnerBlocks", config.CompressInnerBlocks),
+		zap.Duration("stuckBlockTimeout", config.StuckBlockTimeout),
+		zap.Bool("rebuildOnStuckBlock", config.RebuildOnStuckBlock),
+		zap.Bool("backfillBlocks", config.BackfillBlocks),
+		zap.Bool("dryRunActivation", config.DryRunActivation),
+		zap.Uint64("pChainHeightEpoch", config.PChainHeightEpoch),
+		zap.Time("pChainHeightEpochActivationTime", config.PChainHeightEpochActivationTime),
+		zap.Uint64("buildPendingWorkThreshold", config.BuildPendingWorkThreshold),
+	)
+	return config
+}
+
+// registerProposerVMAcceptor notifies [proposerVM] of accepted P-chain blocks,
+// so that it can invalidate its cached P-chain height.
+func (m *manager) registerProposerVMAcceptor(chainID ids.ID, proposerVM *proposervm.VM) error {
+	err := m.BlockAcceptorGroup.RegisterAcceptor(
+		constants.PlatformChainID,
+		fmt.Sprintf("proposervm-%s", chainID),
+		proposerVM.PChainAcceptor(),
+		false,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't register proposervm acceptor: %w", err)
+	}
+	return nil
+}
+
 func (m *manager) IsBootstrapped(id ids.ID) bool {
 	m.chainsLock.Lock()
 	chain, exists := m.chains[id]
@@ -1306,6 +1538,96 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().State.Get().State == snow.NormalOp
 }
 
+func (m *manager) VerifyIntegrity(ctx context.Context, chainID ids.ID, repair bool) ([]string, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	verifier, supported := m.integrityVerifiers[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	if !supported {
+		return nil, fmt.Errorf("%w: %s", errIntegrityNotSupported, chainID)
+	}
+
+	chainCtx := chain.Context()
+	chainCtx.Lock.Lock()
+	defer chainCtx.Lock.Unlock()
+
+	return verifier.VerifyIntegrity(ctx, repair)
+}
+
+func (m *manager) DatabaseUsage(ctx context.Context, chainID ids.ID, maxKeys uint64) (map[string]prefixdb.Usage, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	namespacesFunc := m.databaseNamespaces[chainID]
+	m.chainsLock.Unlock()
+	if !exists || namespacesFunc == nil {
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	chainCtx := chain.Context()
+	chainCtx.Lock.Lock()
+	defer chainCtx.Lock.Unlock()
+
+	namespaces := namespacesFunc()
+	usages := make(map[string]prefixdb.Usage, len(namespaces))
+	for name, db := range namespaces {
+		usage, err := prefixdb.ComputeUsage(ctx, db, maxKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute usage of %q: %w", name, err)
+		}
+		usages[name] = usage
+	}
+	return usages, nil
+}
+
+func (m *manager) ProfileVM(ctx context.Context, chainID ids.ID, name string, duration time.Duration) ([]byte, error) {
+	profiler, err := m.getProfiler(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return profiler.Profile(ctx, name, duration)
+}
+
+func (m *manager) VMRuntimeMetrics(ctx context.Context, chainID ids.ID) ([]*dto.MetricFamily, error) {
+	profiler, err := m.getProfiler(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return profiler.RuntimeMetrics(ctx)
+}
+
+// getProfiler returns the profiler of the VM of the chain with the given ID.
+// The chain's context lock isn't held while profiling, as the VM's process is
+// profiled independently of the chain's state.
+func (m *manager) getProfiler(chainID ids.ID) (block.ProfilerVM, error) {
+	m.chainsLock.Lock()
+	_, exists := m.chains[chainID]
+	profiler, supported := m.profilers[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	if !supported {
+		return nil, fmt.Errorf("%w: %s", errProfilingNotSupported, chainID)
+	}
+	return profiler, nil
+}
+
+// addVMDatabaseNamespaces adds the namespaces of the databases of [vm] to
+// [namespaces], if [vm] reports them. The namespaces of the VM are nested
+// under the "vm" namespace.
+func addVMDatabaseNamespaces(namespaces map[string]database.Iteratee, vm interface{}) {
+	namespacesVM, ok := vm.(block.DatabaseNamespacesVM)
+	if !ok {
+		return
+	}
+	for name, db := range namespacesVM.DatabaseNamespaces() {
+		namespaces["vm/"+name] = db
+	}
+}
+
 func (m *manager) subnetsNotBootstrapped() []ids.ID {
 	m.subnetsLock.RLock()
 	defer m.subnetsLock.RUnlock()
@@ -1452,3 +1774,12 @@ func (m *manager) getChainConfig(id ids.ID) (ChainConfig, error) {
 
 	return ChainConfig{}, nil
 }
+
+// frontierPollFrequency returns how often the chains of [sb] poll for new
+// consensus frontiers.
+func (m *manager) frontierPollFrequency(sb subnets.Subnet) time.Duration {
+	if frequency := sb.Config().FrontierPollFrequency; frequency > 0 {
+		return frequency
+	}
+	return m.FrontierPollFrequency
+}
@@ -4,6 +4,12 @@
 package chains
 
 import (
+	"context"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 )
@@ -56,6 +62,22 @@ func (testManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
 
+func (testManager) VerifyIntegrity(context.Context, ids.ID, bool) ([]string, error) {
+	return nil, nil
+}
+
+func (testManager) DatabaseUsage(context.Context, ids.ID, uint64) (map[string]prefixdb.Usage, error) {
+	return nil, nil
+}
+
+func (testManager) ProfileVM(context.Context, ids.ID, string, time.Duration) ([]byte, error) {
+	return nil, nil
+}
+
+func (testManager) VMRuntimeMetrics(context.Context, ids.ID) ([]*dto.MetricFamily, error) {
+	return nil, nil
+}
+
 func (testManager) Lookup(s string) (ids.ID, error) {
 	return ids.FromString(s)
 }
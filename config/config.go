@@ -4,6 +4,7 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/base64"
@@ -52,12 +53,16 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/proposervm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
+	"github.com/ava-labs/avalanchego/webhook"
 )
 
 const (
 	chainConfigFileName  = "config"
 	chainUpgradeFileName = "upgrade"
 	subnetConfigFileExt  = ".json"
+	subnetUpgradeFileExt = ".upgrade.json"
 	ipResolutionTimeout  = 30 * time.Second
 
 	ipcDeprecationMsg                    = "IPC API is deprecated"
@@ -95,6 +100,9 @@ var (
 	errCannotTrackPrimaryNetwork              = errors.New("cannot track primary network")
 	errStakingKeyContentUnset                 = fmt.Errorf("%s key not set but %s set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset                = fmt.Errorf("%s key set but %s not set", StakingTLSKeyContentKey, StakingCertContentKey)
+	errSecondaryStakingCertUnset              = fmt.Errorf("%s set but %s not set", StakingSecondaryTLSKeyPathKey, StakingSecondaryCertPathKey)
+	errSecondaryStakingKeyUnset               = fmt.Errorf("%s not set but %s set", StakingSecondaryTLSKeyPathKey, StakingSecondaryCertPathKey)
+	errSecondaryStakingKeyIsPrimary           = errors.New("secondary staking key is the primary staking key")
 	errMissingStakingSigningKeyFile           = errors.New("missing staking signing key file")
 	errTracingEndpointEmpty                   = fmt.Errorf("%s cannot be empty", TracingEndpointKey)
 	errPluginDirNotADirectory                 = errors.New("plugin dir is not a directory")
@@ -114,12 +122,13 @@ func getConsensusConfig(v *viper.Viper) snowball.Parameters {
 		//
 		// TODO: After the X-chain linearization use the
 		// SnowVirtuousCommitThresholdKey as before.
-		BetaVirtuous:          v.GetInt(SnowRogueCommitThresholdKey),
-		BetaRogue:             v.GetInt(SnowRogueCommitThresholdKey),
-		ConcurrentRepolls:     v.GetInt(SnowConcurrentRepollsKey),
-		OptimalProcessing:     v.GetInt(SnowOptimalProcessingKey),
-		MaxOutstandingItems:   v.GetInt(SnowMaxProcessingKey),
-		MaxItemProcessingTime: v.GetDuration(SnowMaxTimeProcessingKey),
+		BetaVirtuous:            v.GetInt(SnowRogueCommitThresholdKey),
+		BetaRogue:               v.GetInt(SnowRogueCommitThresholdKey),
+		ConcurrentRepolls:       v.GetInt(SnowConcurrentRepollsKey),
+		OptimalProcessing:       v.GetInt(SnowOptimalProcessingKey),
+		MaxOutstandingItems:     v.GetInt(SnowMaxProcessingKey),
+		MaxItemProcessingTime:   v.GetDuration(SnowMaxTimeProcessingKey),
+		EarlyTermStakeThreshold: v.GetFloat64(SnowEarlyTermStakeThresholdKey),
 	}
 	if v.IsSet(SnowQuorumSizeKey) {
 		p.AlphaPreference = v.GetInt(SnowQuorumSizeKey)
@@ -398,7 +407,8 @@ func getNetworkConfig(
 			ConnectionTimeout: v.GetDuration(NetworkOutboundConnectionTimeoutKey),
 		},
 
-		TLSKeyLogFile: v.GetString(NetworkTLSKeyLogFileKey),
+		TLSKeyLogFile:       v.GetString(NetworkTLSKeyLogFileKey),
+		TLSSessionCacheSize: v.GetInt(NetworkTLSSessionCacheSizeKey),
 
 		TimeoutConfig: network.TimeoutConfig{
 			PingPongTimeout:      v.GetDuration(NetworkPingTimeoutKey),
@@ -411,6 +421,11 @@ func getNetworkConfig(
 			PeerListNonValidatorGossipSize: v.GetUint32(NetworkPeerListNonValidatorGossipSizeKey),
 			PeerListPeersGossipSize:        v.GetUint32(NetworkPeerListPeersGossipSizeKey),
 			PeerListGossipFreq:             v.GetDuration(NetworkPeerListGossipFreqKey),
+			PeerListMaxKnownValidators:     v.GetUint32(NetworkPeerListMaxKnownValidatorsKey),
+			PeerListReconnectKnownTTL:      v.GetDuration(NetworkPeerListReconnectKnownTTLKey),
+			PeerListUselessNumValidatorIPs: v.GetUint32(NetworkPeerListUselessNumValidatorIPsKey),
+			PeerListUsefulnessGracePeriod:  v.GetDuration(NetworkPeerListUsefulnessGracePeriodKey),
+			PeerListPrioritizeByStake:      v.GetBool(NetworkPeerListPrioritizeByStakeKey),
 		},
 
 		DelayConfig: network.DelayConfig{
@@ -443,6 +458,14 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%q must be >= 0", NetworkOutboundConnectionTimeoutKey)
 	case config.PeerListGossipFreq < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerListGossipFreqKey)
+	case config.PeerListMaxKnownValidators == 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkPeerListMaxKnownValidatorsKey)
+	case config.PeerListReconnectKnownTTL < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerListReconnectKnownTTLKey)
+	case config.PeerListUselessNumValidatorIPs > config.PeerListNumValidatorIPs:
+		return network.Config{}, fmt.Errorf("%s must be <= %s", NetworkPeerListUselessNumValidatorIPsKey, NetworkPeerListNumValidatorIPsKey)
+	case config.PeerListUsefulnessGracePeriod < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerListUsefulnessGracePeriodKey)
 	case config.ThrottlerConfig.InboundMsgThrottlerConfig.CPUThrottlerConfig.MaxRecheckDelay < constants.MinInboundThrottlerMaxRecheckDelay:
 		return network.Config{}, fmt.Errorf("%s must be >= %d", InboundThrottlerCPUMaxRecheckDelayKey, constants.MinInboundThrottlerMaxRecheckDelay)
 	case config.ThrottlerConfig.InboundMsgThrottlerConfig.DiskThrottlerConfig.MaxRecheckDelay < constants.MinInboundThrottlerMaxRecheckDelay:
@@ -463,6 +486,8 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.TLSSessionCacheSize < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkTLSSessionCacheSizeKey)
 	}
 	return config, nil
 }
@@ -737,6 +762,33 @@ func getStakingTLSCert(v *viper.Viper) (tls.Certificate, error) {
 	}
 }
 
+// getSecondaryStakingTLSCert returns the staking key/cert being rotated to. If
+// no key rotation is configured, nil is returned.
+func getSecondaryStakingTLSCert(v *viper.Viper, primary tls.Certificate) (*tls.Certificate, error) {
+	keyPath := GetExpandedArg(v, StakingSecondaryTLSKeyPathKey)
+	certPath := GetExpandedArg(v, StakingSecondaryCertPathKey)
+	switch {
+	case keyPath == "" && certPath == "":
+		return nil, nil
+	case certPath == "":
+		return nil, errSecondaryStakingCertUnset
+	case keyPath == "":
+		return nil, errSecondaryStakingKeyUnset
+	}
+
+	cert, err := staking.LoadTLSCertFromFiles(keyPath, certPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read secondary staking certificate: %w", err)
+	}
+	if err := staking.ValidateCertificate(staking.CertificateFromX509(cert.Leaf)); err != nil {
+		return nil, fmt.Errorf("invalid secondary staking certificate: %w", err)
+	}
+	if bytes.Equal(cert.Leaf.Raw, primary.Leaf.Raw) {
+		return nil, errSecondaryStakingKeyIsPrimary
+	}
+	return cert, nil
+}
+
 func getStakingSigner(v *viper.Viper) (*bls.SecretKey, error) {
 	if v.GetBool(StakingEphemeralSignerEnabledKey) {
 		key, err := bls.NewSecretKey()
@@ -804,6 +856,8 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 		StakingKeyPath:                GetExpandedArg(v, StakingTLSKeyPathKey),
 		StakingCertPath:               GetExpandedArg(v, StakingCertPathKey),
 		StakingSignerPath:             GetExpandedArg(v, StakingSignerKeyPathKey),
+		SecondaryStakingKeyPath:       GetExpandedArg(v, StakingSecondaryTLSKeyPathKey),
+		SecondaryStakingCertPath:      GetExpandedArg(v, StakingSecondaryCertPathKey),
 	}
 	if !config.SybilProtectionEnabled && config.SybilProtectionDisabledWeight == 0 {
 		return node.StakingConfig{}, errSybilProtectionDisabledStakerWeights
@@ -818,6 +872,10 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 	if err != nil {
 		return node.StakingConfig{}, err
 	}
+	config.SecondaryStakingTLSCert, err = getSecondaryStakingTLSCert(v, config.StakingTLSCert)
+	if err != nil {
+		return node.StakingConfig{}, err
+	}
 	config.StakingSigningKey, err = getStakingSigner(v)
 	if err != nil {
 		return node.StakingConfig{}, err
@@ -1155,27 +1213,33 @@ func getSubnetConfigsFromDir(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]sub
 
 	// reads subnet config files from a path and given subnetIDs and returns a map.
 	for _, subnetID := range subnetIDs {
-		filePath := filepath.Join(subnetConfigPath, subnetID.String()+subnetConfigFileExt)
-		fileInfo, err := os.Stat(filePath)
-		switch {
-		case errors.Is(err, os.ErrNotExist):
-			// this subnet config does not exist, move to the next one
-			continue
-		case err != nil:
+		// subnetConfigDir/subnetID.json
+		configFile, configExists, err := readSubnetFile(subnetConfigPath, subnetID.String()+subnetConfigFileExt)
+		if err != nil {
 			return nil, err
-		case fileInfo.IsDir():
-			return nil, fmt.Errorf("%q is a directory, expected a file", fileInfo.Name())
 		}
 
-		// subnetConfigDir/subnetID.json
-		file, err := os.ReadFile(filePath)
+		// subnetConfigDir/subnetID.upgrade.json
+		upgradeFile, upgradeExists, err := readSubnetFile(subnetConfigPath, subnetID.String()+subnetUpgradeFileExt)
 		if err != nil {
 			return nil, err
 		}
 
+		if !configExists && !upgradeExists {
+			// this subnet config does not exist, move to the next one
+			continue
+		}
+
 		config := getDefaultSubnetConfig(v)
-		if err := json.Unmarshal(file, &config); err != nil {
-			return nil, fmt.Errorf("%w: %w", errUnmarshalling, err)
+		if configExists {
+			if err := json.Unmarshal(configFile, &config); err != nil {
+				return nil, fmt.Errorf("%w: %w", errUnmarshalling, err)
+			}
+		}
+		if upgradeExists {
+			if err := json.Unmarshal(upgradeFile, &config.Upgrade); err != nil {
+				return nil, fmt.Errorf("%w upgrade: %w", errUnmarshalling, err)
+			}
 		}
 
 		if config.ConsensusParameters.Alpha != nil {
@@ -1193,6 +1257,25 @@ func getSubnetConfigsFromDir(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]sub
 	return subnetConfigs, nil
 }
 
+// readSubnetFile returns the content of the file [fileName] in the subnet
+// config directory [subnetConfigPath]. Returns false if the file doesn't
+// exist.
+func readSubnetFile(subnetConfigPath string, fileName string) ([]byte, bool, error) {
+	filePath := filepath.Join(subnetConfigPath, fileName)
+	fileInfo, err := os.Stat(filePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, err
+	case fileInfo.IsDir():
+		return nil, false, fmt.Errorf("%q is a directory, expected a file", fileInfo.Name())
+	}
+
+	file, err := os.ReadFile(filePath)
+	return file, true, err
+}
+
 func getDefaultSubnetConfig(v *viper.Viper) subnets.Config {
 	return subnets.Config{
 		ConsensusParameters:         getConsensusConfig(v),
@@ -1200,6 +1283,7 @@ func getDefaultSubnetConfig(v *viper.Viper) subnets.Config {
 		GossipConfig:                getGossipConfig(v),
 		ProposerMinBlockDelay:       proposervm.DefaultMinBlockDelay,
 		ProposerNumHistoricalBlocks: proposervm.DefaultNumHistoricalBlocks,
+		ProposerBlockCacheSize:      proposervm.DefaultBlockCacheSize,
 	}
 }
 
@@ -1287,6 +1371,56 @@ func getTraceConfig(v *viper.Viper) (trace.Config, error) {
 	}, nil
 }
 
+func getWebhookConfig(v *viper.Viper) (webhook.Config, error) {
+	var (
+		configBytes []byte
+		err         error
+	)
+	switch {
+	case v.IsSet(WebhookConfigContentKey):
+		webhookConfigContent := v.GetString(WebhookConfigContentKey)
+		configBytes, err = base64.StdEncoding.DecodeString(webhookConfigContent)
+		if err != nil {
+			return webhook.Config{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(WebhookConfigFileKey):
+		path := GetExpandedArg(v, WebhookConfigFileKey)
+		configBytes, err = os.ReadFile(path)
+		if err != nil {
+			return webhook.Config{}, err
+		}
+	default:
+		return webhook.DefaultConfig, nil
+	}
+
+	config := webhook.DefaultConfig
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return webhook.Config{}, fmt.Errorf("%w on webhook config: %w", errUnmarshalling, err)
+	}
+	if err := config.Verify(); err != nil {
+		return webhook.Config{}, fmt.Errorf("invalid webhook config: %w", err)
+	}
+	return config, nil
+}
+
+func getPluginCallTimeoutConfig(v *viper.Viper) (rpcchainvm.CallTimeoutConfig, error) {
+	config := rpcchainvm.CallTimeoutConfig{
+		DefaultTimeout: v.GetDuration(PluginCallTimeoutKey),
+		MethodTimeouts: make(map[string]time.Duration),
+	}
+	for method, timeoutStr := range v.GetStringMapString(PluginCallMethodTimeoutsKey) {
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			return rpcchainvm.CallTimeoutConfig{}, fmt.Errorf("couldn't parse %q timeout of %q: %w", method, PluginCallMethodTimeoutsKey, err)
+		}
+		config.MethodTimeouts[method] = timeout
+	}
+	if err := config.Verify(); err != nil {
+		return rpcchainvm.CallTimeoutConfig{}, fmt.Errorf("invalid plugin call timeout config: %w", err)
+	}
+	return config, nil
+}
+
 // Returns the path to the directory that contains VM binaries.
 func getPluginDir(v *viper.Viper) (string, error) {
 	pluginDir := GetExpandedString(v, v.GetString(PluginDirKey))
@@ -1321,11 +1455,40 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	nodeConfig.PluginConnPoolConfig = rpcchainvm.ConnPoolConfig{
+		NumConns:          v.GetInt(PluginConnPoolSizeKey),
+		NumBlockConns:     v.GetInt(PluginBlockConnPoolSizeKey),
+		NumStateSyncConns: v.GetInt(PluginStateSyncConnPoolSizeKey),
+	}
+	if err := nodeConfig.PluginConnPoolConfig.Verify(); err != nil {
+		return node.Config{}, fmt.Errorf("invalid plugin connection pool config: %w", err)
+	}
+
+	nodeConfig.PluginCallTimeoutConfig, err = getPluginCallTimeoutConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
+	nodeConfig.PluginSandboxConfig = subprocess.SandboxConfig{
+		MaxMemory:    v.GetUint64(PluginMaxMemoryKey),
+		CPUShares:    v.GetUint64(PluginCPUSharesKey),
+		MaxOpenFiles: v.GetUint64(PluginMaxOpenFilesKey),
+		CgroupDir:    GetExpandedString(v, v.GetString(PluginCgroupDirKey)),
+	}
+	if err := nodeConfig.PluginSandboxConfig.Verify(); err != nil {
+		return node.Config{}, fmt.Errorf("invalid plugin sandbox config: %w", err)
+	}
+
 	nodeConfig.ConsensusShutdownTimeout = v.GetDuration(ConsensusShutdownTimeoutKey)
 	if nodeConfig.ConsensusShutdownTimeout < 0 {
 		return node.Config{}, fmt.Errorf("%q must be >= 0", ConsensusShutdownTimeoutKey)
 	}
 
+	nodeConfig.ShutdownStageTimeout = v.GetDuration(ShutdownStageTimeoutKey)
+	if nodeConfig.ShutdownStageTimeout < 0 {
+		return node.Config{}, fmt.Errorf("%q must be >= 0", ShutdownStageTimeoutKey)
+	}
+
 	// Gossiping
 	nodeConfig.FrontierPollFrequency = v.GetDuration(ConsensusFrontierPollFrequencyKey)
 	if nodeConfig.FrontierPollFrequency < 0 {
@@ -1513,6 +1676,11 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	nodeConfig.WebhookConfig, err = getWebhookConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.ChainDataDir = GetExpandedArg(v, ChainDataDirKey)
 
 	nodeConfig.ProcessContextFilePath = GetExpandedArg(v, ProcessContextFileKey)
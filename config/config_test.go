@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -434,6 +435,77 @@ func TestGetSubnetConfigsFromFile(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		"wrong upgrade": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.upgrade.json",
+			givenJSON: `thisisnotjson`,
+			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
+				require.Nil(given)
+			},
+			expectedErr: errUnmarshalling,
+		},
+		"upgrade without config": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.upgrade.json",
+			givenJSON: `{"proposerActivationTime": "2030-01-01T00:00:00Z", "proposerMinPChainHeight": 5}`,
+			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				require.True(ok)
+
+				require.NotNil(config.Upgrade.ProposerActivationTime)
+				require.Equal(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), *config.Upgrade.ProposerActivationTime)
+				require.NotNil(config.Upgrade.ProposerMinPChainHeight)
+				require.Equal(uint64(5), *config.Upgrade.ProposerMinPChainHeight)
+				require.Nil(config.Upgrade.ProposerPChainHeightEpochActivationTime)
+				require.Nil(config.Upgrade.ProposerEnforcedMinBlockDelayActivationTime)
+				require.Nil(config.Upgrade.ProposerCommitValidatorSetActivationTime)
+				require.Nil(config.Upgrade.ProposerVRFActivationTime)
+				require.Nil(config.Upgrade.ProposerBlockExtensionsActivationTime)
+				// must still respect defaults
+				require.Equal(20, config.ConsensusParameters.K)
+			},
+			expectedErr: nil,
+		},
+		"upgrade with P-chain height epoch": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.upgrade.json",
+			givenJSON: `{"proposerPChainHeightEpoch": 10, "proposerPChainHeightEpochActivationTime": "2030-01-01T00:00:00Z"}`,
+			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				require.True(ok)
+
+				require.Equal(uint64(10), config.Upgrade.ProposerPChainHeightEpoch)
+				require.NotNil(config.Upgrade.ProposerPChainHeightEpochActivationTime)
+				require.Equal(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), *config.Upgrade.ProposerPChainHeightEpochActivationTime)
+			},
+			expectedErr: nil,
+		},
+		"upgrade with commit validator set": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.upgrade.json",
+			givenJSON: `{"proposerCommitValidatorSetActivationTime": "2030-01-01T00:00:00Z"}`,
+			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				require.True(ok)
+
+				require.NotNil(config.Upgrade.ProposerCommitValidatorSetActivationTime)
+				require.Equal(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), *config.Upgrade.ProposerCommitValidatorSetActivationTime)
+			},
+			expectedErr: nil,
+		},
+		"upgrade with enforced min block delay": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.upgrade.json",
+			givenJSON: `{"proposerEnforcedMinBlockDelay": 2000000000, "proposerEnforcedMinBlockDelayActivationTime": "2030-01-01T00:00:00Z"}`,
+			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				require.True(ok)
+
+				require.Equal(2*time.Second, config.Upgrade.ProposerEnforcedMinBlockDelay)
+				require.NotNil(config.Upgrade.ProposerEnforcedMinBlockDelayActivationTime)
+				require.Equal(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), *config.Upgrade.ProposerEnforcedMinBlockDelayActivationTime)
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for name, test := range tests {
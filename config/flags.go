@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ulimit"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
 )
 
 const (
@@ -78,6 +79,15 @@ func addNodeFlags(fs *pflag.FlagSet) {
 
 	// Plugin directory
 	fs.String(PluginDirKey, defaultPluginDir, "Path to the plugin directory")
+	fs.Int(PluginConnPoolSizeKey, rpcchainvm.DefaultConnPoolConfig.NumConns, "Number of connections used to make requests to a plugin that aren't sent over a dedicated connection")
+	fs.Int(PluginBlockConnPoolSizeKey, rpcchainvm.DefaultConnPoolConfig.NumBlockConns, "Number of connections dedicated to block requests made to a plugin. If 0, block requests share the default connections")
+	fs.Int(PluginStateSyncConnPoolSizeKey, rpcchainvm.DefaultConnPoolConfig.NumStateSyncConns, "Number of connections dedicated to state sync requests made to a plugin. If 0, state sync requests share the default connections")
+	fs.Duration(PluginCallTimeoutKey, rpcchainvm.DefaultCallTimeoutConfig.DefaultTimeout, fmt.Sprintf("Timeout of requests made to a plugin whose method isn't in %q. Non-idempotent requests, such as BlockAccept, are never timed out. If 0, requests are only bounded by the deadline provided by the caller", PluginCallMethodTimeoutsKey))
+	fs.StringToString(PluginCallMethodTimeoutsKey, durationsToStrings(rpcchainvm.DefaultCallTimeoutConfig.MethodTimeouts), "Timeouts of requests made to a plugin, by method name (e.g. BuildBlock=30s). A timeout of 0 means requests of the method are only bounded by the deadline provided by the caller. Non-idempotent methods, such as BlockAccept, can't be given a timeout")
+	fs.Uint64(PluginMaxMemoryKey, 0, fmt.Sprintf("Maximum number of bytes of memory of each plugin process. Limits the memory usage of the plugin's cgroup if %q is set, and the address space of the process otherwise. If 0, memory isn't limited", PluginCgroupDirKey))
+	fs.Uint64(PluginCPUSharesKey, 0, fmt.Sprintf("CPU weight of each plugin process relative to the other processes in %q, in [2, 262144] where 1024 is the default weight. If 0, the default weight is used", PluginCgroupDirKey))
+	fs.Uint64(PluginMaxOpenFilesKey, 0, "Maximum number of file descriptors each plugin process can have open. If 0, plugins inherit the limit of the node")
+	fs.String(PluginCgroupDirKey, "", "Path to a cgroup v2 directory delegated to the node, under which a cgroup is created for each plugin process. The directory must not contain any processes. If empty, plugins aren't placed in a cgroup. Only supported on Linux")
 
 	// Config File
 	fs.String(ConfigFileKey, "", fmt.Sprintf("Specifies a config file. Ignored if %s is specified", ConfigContentKey))
@@ -127,6 +137,11 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint(NetworkPeerListNonValidatorGossipSizeKey, constants.DefaultNetworkPeerListNonValidatorGossipSize, "Number of non-validators that the node will gossip peer list to")
 	fs.Uint(NetworkPeerListPeersGossipSizeKey, constants.DefaultNetworkPeerListPeersGossipSize, "Number of total peers (including non-validators and validators) that the node will gossip peer list to")
 	fs.Duration(NetworkPeerListGossipFreqKey, constants.DefaultNetworkPeerListGossipFreq, "Frequency to gossip peers to other nodes")
+	fs.Uint(NetworkPeerListMaxKnownValidatorsKey, constants.DefaultNetworkPeerListMaxKnownValidators, "Maximum number of validators each peer is remembered to know about. Once exceeded, the longest tracked validators are forgotten and may be gossiped to the peer again")
+	fs.Duration(NetworkPeerListReconnectKnownTTLKey, constants.DefaultNetworkPeerListReconnectKnownTTL, "Duration the validators a peer is remembered to know about are kept after it disconnects. If it reconnects within this duration, they aren't gossiped to it again. If 0, they are forgotten on disconnect")
+	fs.Uint(NetworkPeerListUselessNumValidatorIPsKey, constants.DefaultNetworkPeerListUselessNumValidatorIPs, fmt.Sprintf("Number of validator IPs to gossip to validators that haven't gossiped a useful validator IP within %s of uptime", NetworkPeerListUsefulnessGracePeriodKey))
+	fs.Duration(NetworkPeerListUsefulnessGracePeriodKey, constants.DefaultNetworkPeerListUsefulnessGracePeriod, fmt.Sprintf("Uptime a validator is given to gossip a useful validator IP before being gossiped only %s validator IPs. If 0, the number of gossiped validator IPs is never reduced", NetworkPeerListUselessNumValidatorIPsKey))
+	fs.Bool(NetworkPeerListPrioritizeByStakeKey, false, "If true, the validator IPs gossiped to a peer are the validators it doesn't know about with the most stake, rather than a random sample of them")
 
 	// Public IP Resolution
 	fs.String(PublicIPKey, "", "Public IP of this node for P2P communication. If empty, try to discover with NAT")
@@ -170,6 +185,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkTCPProxyReadTimeoutKey, constants.DefaultNetworkTCPProxyReadTimeout, "Maximum duration to wait for a TCP proxy header")
 
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
+	fs.Int(NetworkTLSSessionCacheSizeKey, constants.DefaultNetworkTLSSessionCacheSize, "Number of TLS sessions to cache for resuming outbound connections to peers. If 0, TLS session resumption is disabled")
 
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, constants.DefaultBenchlistFailThreshold, "Number of consecutive failed queries before benchlisting a node")
@@ -217,6 +233,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.StringSlice(HTTPAllowedHostsKey, []string{"localhost"}, "List of acceptable host names in API requests. Provide the wildcard ('*') to accept requests from all hosts. API requests where the Host field is empty or an IP address will always be accepted. An API call whose HTTP Host field isn't acceptable will receive a 403 error code")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
 	fs.Duration(HTTPShutdownTimeoutKey, 10*time.Second, "Maximum duration to wait for existing connections to complete during node shutdown")
+	fs.Duration(ShutdownStageTimeoutKey, time.Minute, "Duration, in addition to any component specific shutdown timeout, to wait for each stage of node shutdown to complete before abandoning it. If 0, stages are never abandoned")
 	fs.Duration(HTTPReadTimeoutKey, 30*time.Second, "Maximum duration for reading the entire request, including the body. A zero or negative value means there will be no timeout")
 	fs.Duration(HTTPReadHeaderTimeoutKey, 30*time.Second, fmt.Sprintf("Maximum duration to read request headers. The connection's read deadline is reset after reading the headers. If %s is zero, the value of %s is used. If both are zero, there is no timeout.", HTTPReadHeaderTimeoutKey, HTTPReadTimeoutKey))
 	fs.Duration(HTTPWriteTimeoutKey, 30*time.Second, "Maximum duration before timing out writes of the response. It is reset whenever a new request's header is read. A zero or negative value means there will be no timeout.")
@@ -257,6 +274,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(StakingTLSKeyContentKey, "", "Specifies base64 encoded TLS private key for staking")
 	fs.String(StakingCertPathKey, defaultStakingCertPath, fmt.Sprintf("Path to the TLS certificate for staking. Ignored if %s is specified", StakingCertContentKey))
 	fs.String(StakingCertContentKey, "", "Specifies base64 encoded TLS certificate for staking")
+	fs.String(StakingSecondaryTLSKeyPathKey, "", fmt.Sprintf("Path to the TLS private key being rotated to. If specified, snowman++ blocks are also signed with this key. Requires %s", StakingSecondaryCertPathKey))
+	fs.String(StakingSecondaryCertPathKey, "", fmt.Sprintf("Path to the TLS certificate being rotated to. Requires %s", StakingSecondaryTLSKeyPathKey))
 	fs.Bool(StakingEphemeralSignerEnabledKey, false, "If true, the node uses an ephemeral staking signer key")
 	fs.String(StakingSignerKeyPathKey, defaultStakingSignerKeyPath, fmt.Sprintf("Path to the signer private key for staking. Ignored if %s is specified", StakingSignerKeyContentKey))
 	fs.String(StakingSignerKeyContentKey, "", "Specifies base64 encoded signer private key for staking")
@@ -310,6 +329,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Int(SnowOptimalProcessingKey, snowball.DefaultParameters.OptimalProcessing, "Optimal number of processing containers in consensus")
 	fs.Int(SnowMaxProcessingKey, snowball.DefaultParameters.MaxOutstandingItems, "Maximum number of processing items to be considered healthy")
 	fs.Duration(SnowMaxTimeProcessingKey, snowball.DefaultParameters.MaxItemProcessingTime, "Maximum amount of time an item should be processing and still be healthy")
+	fs.Float64(SnowEarlyTermStakeThresholdKey, snowball.DefaultParameters.EarlyTermStakeThreshold, "Fraction of the polled stake that must agree on a preferred element to end a network poll without waiting for the remaining responses once no element can reach a confidence majority. If 0, polls are never ended based on stake")
 
 	// ProposerVM
 	fs.Bool(ProposerVMUseCurrentHeightKey, false, "Have the ProposerVM always report the last accepted P-chain block height")
@@ -378,9 +398,23 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.StringToString(TracingHeadersKey, map[string]string{}, "The headers to provide the trace indexer")
 
 	fs.String(ProcessContextFileKey, defaultProcessContextPath, "The path to write process context to (including PID, API URI, and staking address).")
+
+	// Webhooks
+	fs.String(WebhookConfigFileKey, "", fmt.Sprintf("Path to a JSON file specifying the webhook endpoints to notify of address activity. Ignored if %s is specified", WebhookConfigContentKey))
+	fs.String(WebhookConfigContentKey, "", "Specifies base64 encoded webhook config content")
 }
 
 // BuildFlagSet returns a complete set of flags for avalanchego
+// durationsToStrings formats [durations] as the default value of a string to
+// string flag.
+func durationsToStrings(durations map[string]time.Duration) map[string]string {
+	strs := make(map[string]string, len(durations))
+	for key, duration := range durations {
+		strs[key] = duration.String()
+	}
+	return strs
+}
+
 func BuildFlagSet() *pflag.FlagSet {
 	fs := pflag.NewFlagSet(constants.AppName, pflag.ContinueOnError)
 	addProcessFlags(fs)
@@ -52,6 +52,7 @@ const (
 	HTTPAllowedHostsKey                                = "http-allowed-hosts"
 	HTTPShutdownTimeoutKey                             = "http-shutdown-timeout"
 	HTTPShutdownWaitKey                                = "http-shutdown-wait"
+	ShutdownStageTimeoutKey                            = "shutdown-stage-timeout"
 	HTTPReadTimeoutKey                                 = "http-read-timeout"
 	HTTPReadHeaderTimeoutKey                           = "http-read-header-timeout"
 	HTTPWriteTimeoutKey                                = "http-write-timeout"
@@ -70,6 +71,8 @@ const (
 	StakingTLSKeyContentKey                            = "staking-tls-key-file-content"
 	StakingCertPathKey                                 = "staking-tls-cert-file"
 	StakingCertContentKey                              = "staking-tls-cert-file-content"
+	StakingSecondaryTLSKeyPathKey                      = "staking-secondary-tls-key-file"
+	StakingSecondaryCertPathKey                        = "staking-secondary-tls-cert-file"
 	StakingEphemeralSignerEnabledKey                   = "staking-ephemeral-signer-enabled"
 	StakingSignerKeyPathKey                            = "staking-signer-key-file"
 	StakingSignerKeyContentKey                         = "staking-signer-key-file-content"
@@ -92,6 +95,11 @@ const (
 	NetworkPeerListNonValidatorGossipSizeKey           = "network-peer-list-non-validator-gossip-size"
 	NetworkPeerListPeersGossipSizeKey                  = "network-peer-list-peers-gossip-size"
 	NetworkPeerListGossipFreqKey                       = "network-peer-list-gossip-frequency"
+	NetworkPeerListMaxKnownValidatorsKey               = "network-peer-list-max-known-validators"
+	NetworkPeerListReconnectKnownTTLKey                = "network-peer-list-reconnect-known-ttl"
+	NetworkPeerListUselessNumValidatorIPsKey           = "network-peer-list-useless-num-validator-ips"
+	NetworkPeerListUsefulnessGracePeriodKey            = "network-peer-list-usefulness-grace-period"
+	NetworkPeerListPrioritizeByStakeKey                = "network-peer-list-prioritize-by-stake"
 	NetworkInitialReconnectDelayKey                    = "network-initial-reconnect-delay"
 	NetworkReadHandshakeTimeoutKey                     = "network-read-handshake-timeout"
 	NetworkPingTimeoutKey                              = "network-ping-timeout"
@@ -106,6 +114,7 @@ const (
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
+	NetworkTLSSessionCacheSizeKey                      = "network-tls-session-cache-size"
 	NetworkInboundConnUpgradeThrottlerCooldownKey      = "network-inbound-connection-throttling-cooldown"
 	NetworkInboundThrottlerMaxConnsPerSecKey           = "network-inbound-connection-throttling-max-conns-per-sec"
 	NetworkOutboundConnectionThrottlingRpsKey          = "network-outbound-connection-throttling-rps"
@@ -132,6 +141,7 @@ const (
 	SnowOptimalProcessingKey                           = "snow-optimal-processing"
 	SnowMaxProcessingKey                               = "snow-max-processing"
 	SnowMaxTimeProcessingKey                           = "snow-max-time-processing"
+	SnowEarlyTermStakeThresholdKey                     = "snow-early-term-stake-threshold"
 	PartialSyncPrimaryNetworkKey                       = "partial-sync-primary-network"
 	TrackSubnetsKey                                    = "track-subnets"
 	AdminAPIEnabledKey                                 = "api-admin-enabled"
@@ -164,6 +174,15 @@ const (
 	HealthCheckFreqKey                                 = "health-check-frequency"
 	HealthCheckAveragerHalflifeKey                     = "health-check-averager-halflife"
 	PluginDirKey                                       = "plugin-dir"
+	PluginConnPoolSizeKey                              = "plugin-conn-pool-size"
+	PluginBlockConnPoolSizeKey                         = "plugin-block-conn-pool-size"
+	PluginStateSyncConnPoolSizeKey                     = "plugin-state-sync-conn-pool-size"
+	PluginCallTimeoutKey                               = "plugin-call-timeout"
+	PluginCallMethodTimeoutsKey                        = "plugin-call-method-timeouts"
+	PluginMaxMemoryKey                                 = "plugin-max-memory"
+	PluginCPUSharesKey                                 = "plugin-cpu-shares"
+	PluginMaxOpenFilesKey                              = "plugin-max-open-files"
+	PluginCgroupDirKey                                 = "plugin-cgroup-dir"
 	BootstrapBeaconConnectionTimeoutKey                = "bootstrap-beacon-connection-timeout"
 	BootstrapMaxTimeGetAncestorsKey                    = "bootstrap-max-time-get-ancestors"
 	BootstrapAncestorsMaxContainersSentKey             = "bootstrap-ancestors-max-containers-sent"
@@ -212,4 +231,6 @@ const (
 	TracingExporterTypeKey                             = "tracing-exporter-type"
 	TracingHeadersKey                                  = "tracing-headers"
 	ProcessContextFileKey                              = "process-context-file"
+	WebhookConfigFileKey                               = "webhook-config-file"
+	WebhookConfigContentKey                            = "webhook-config-file-content"
 )
@@ -41,6 +41,15 @@ type Batcher interface {
 	NewBatch() Batch
 }
 
+// SizeHintBatcher wraps the NewBatchWithSizeHint method of a backing data
+// store.
+type SizeHintBatcher interface {
+	// NewBatchWithSizeHint creates a write-only database that buffers changes
+	// to its host db until a final write is called. The batch is pre-allocated
+	// to hold approximately [size] bytes of keys and values.
+	NewBatchWithSizeHint(size int) Batch
+}
+
 type BatchOp struct {
 	Key    []byte
 	Value  []byte
@@ -163,6 +163,28 @@ func Size(db Iteratee) (int, error) {
 	return size, iterator.Error()
 }
 
+// NewBatchWithSizeHint returns a new batch of [db]. If [db] supports
+// pre-allocating batches, the batch is sized to hold [size] bytes.
+func NewBatchWithSizeHint(db Batcher, size int) Batch {
+	if db, ok := db.(SizeHintBatcher); ok {
+		return db.NewBatchWithSizeHint(size)
+	}
+	return db.NewBatch()
+}
+
+// NewSnapshot returns a snapshot of [db]. If [db] does not support snapshots,
+// the returned snapshot reads directly from [db] and will observe writes made
+// after it was created.
+func NewSnapshot(db Database) (Snapshot, error) {
+	if db, ok := db.(Snapshotter); ok {
+		return db.NewSnapshot()
+	}
+	return &liveSnapshot{
+		KeyValueReader: db,
+		Iteratee:       db,
+	}, nil
+}
+
 func IsEmpty(db Iteratee) (bool, error) {
 	iterator := db.NewIterator()
 	defer iterator.Release()
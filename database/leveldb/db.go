@@ -66,9 +66,12 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iter)(nil)
+	_ database.Database        = (*Database)(nil)
+	_ database.SizeHintBatcher = (*Database)(nil)
+	_ database.Snapshotter     = (*Database)(nil)
+	_ database.Batch           = (*batch)(nil)
+	_ database.Snapshot        = (*snapshot)(nil)
+	_ database.Iterator        = (*iter)(nil)
 
 	ErrInvalidConfig = errors.New("invalid config")
 	ErrCouldNotOpen  = errors.New("could not open")
@@ -300,6 +303,16 @@ func (db *Database) NewBatch() database.Batch {
 	return &batch{db: db}
 }
 
+// NewBatchWithSizeHint creates a write/delete-only buffer, with capacity for
+// [size] bytes of keys and values, that is atomically committed to the
+// database when write is called
+func (db *Database) NewBatchWithSizeHint(size int) database.Batch {
+	return &batch{
+		Batch: *leveldb.MakeBatch(size),
+		db:    db,
+	}
+}
+
 // NewIterator creates a lexicographically ordered iterator over the database
 func (db *Database) NewIterator() database.Iterator {
 	return &iter{
@@ -330,16 +343,25 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 // over the database starting at start and ignoring keys that do not start with
 // the provided prefix
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
-	iterRange := util.BytesPrefix(prefix)
-	if bytes.Compare(start, prefix) == 1 {
-		iterRange.Start = start
-	}
 	return &iter{
 		db:       db,
-		Iterator: db.DB.NewIterator(iterRange, nil),
+		Iterator: db.DB.NewIterator(keyRange(start, prefix), nil),
 	}
 }
 
+// NewSnapshot returns a read-only view of the current contents of the
+// database.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	s, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db:       db,
+		Snapshot: s,
+	}, nil
+}
+
 // This comment is basically copy pasted from the underlying levelDB library:
 
 // Compact the underlying DB for the given key range.
@@ -423,6 +445,42 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+// snapshot is a wrapper around a levelDB snapshot to convert its errors and
+// iterators.
+type snapshot struct {
+	db *Database
+	*leveldb.Snapshot
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	has, err := s.Snapshot.Has(key, nil)
+	return has, updateError(err)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.Snapshot.Get(key, nil)
+	return value, updateError(err)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(keyRange(start, prefix), nil),
+	}
+}
+
 type replayer struct {
 	writerDeleter database.KeyValueWriterDeleter
 	err           error
@@ -487,7 +545,7 @@ func (it *iter) Value() []byte {
 
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
@@ -495,3 +553,11 @@ func updateError(err error) error {
 		return err
 	}
 }
+
+func keyRange(start, prefix []byte) *util.Range {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return iterRange
+}
@@ -26,6 +26,18 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		folder := t.TempDir()
+		db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		require.NoError(t, err)
+
+		test(t, db)
+
+		_ = db.Close()
+	}
+}
+
 func newDB(t testing.TB) database.Database {
 	folder := t.TempDir()
 	db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -22,9 +23,11 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database    = (*Database)(nil)
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Batch       = (*batch)(nil)
+	_ database.Snapshot    = (*snapshot)(nil)
+	_ database.Iterator    = (*iterator)(nil)
 )
 
 // Database is an ephemeral key-value store that implements the Database
@@ -155,6 +158,22 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewSnapshot returns a read-only copy of the current contents of the
+// database.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	// Values are never modified after being inserted, so the values can be
+	// shared with the copy.
+	return &snapshot{
+		Database: &Database{db: maps.Clone(db.db)},
+	}, nil
+}
+
 func (db *Database) Compact(_, _ []byte) error {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -172,6 +191,15 @@ func (db *Database) HealthCheck(context.Context) (interface{}, error) {
 	return nil, nil
 }
 
+// snapshot is a read-only copy of a Database.
+type snapshot struct {
+	*Database
+}
+
+func (s *snapshot) Release() {
+	_ = s.Close()
+}
+
 type batch struct {
 	database.BatchOps
 
@@ -15,6 +15,12 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		test(t, New())
+	}
+}
+
 func FuzzKeyValue(f *testing.F) {
 	database.FuzzKeyValue(f, New())
 }
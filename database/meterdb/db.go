@@ -13,9 +13,12 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database        = (*Database)(nil)
+	_ database.SizeHintBatcher = (*Database)(nil)
+	_ database.Snapshotter     = (*Database)(nil)
+	_ database.Batch           = (*batch)(nil)
+	_ database.Snapshot        = (*snapshot)(nil)
+	_ database.Iterator        = (*iterator)(nil)
 )
 
 // Database tracks the amount of time each operation takes and how many bytes
@@ -90,6 +93,18 @@ func (db *Database) NewBatch() database.Batch {
 	return b
 }
 
+func (db *Database) NewBatchWithSizeHint(size int) database.Batch {
+	start := db.clock.Time()
+	b := &batch{
+		batch: database.NewBatchWithSizeHint(db.db, size),
+		db:    db,
+	}
+	end := db.clock.Time()
+	db.newBatchWithSizeHint.Observe(float64(end.Sub(start)))
+	db.newBatchWithSizeHintSize.Observe(float64(size))
+	return b
+}
+
 func (db *Database) NewIterator() database.Iterator {
 	return db.NewIteratorWithStartAndPrefix(nil, nil)
 }
@@ -116,6 +131,20 @@ func (db *Database) NewIteratorWithStartAndPrefix(
 	return it
 }
 
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	start := db.clock.Time()
+	s, err := database.NewSnapshot(db.db)
+	end := db.clock.Time()
+	db.newSnapshot.Observe(float64(end.Sub(start)))
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: s,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	startTime := db.clock.Time()
 	err := db.db.Compact(start, limit)
@@ -205,6 +234,39 @@ func (b *batch) Inner() database.Batch {
 	return inner
 }
 
+// snapshot tracks the iterators created from a snapshot of the underlying
+// database.
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(
+	start,
+	prefix []byte,
+) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.newIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
 type iterator struct {
 	iterator database.Iterator
 	db       *Database
@@ -24,6 +24,16 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		baseDB := memdb.New()
+		db, err := New("", prometheus.NewRegistry(), baseDB)
+		require.NoError(t, err)
+
+		test(t, db)
+	}
+}
+
 func newDB(t testing.TB) database.Database {
 	baseDB := memdb.New()
 	db, err := New("", prometheus.NewRegistry(), baseDB)
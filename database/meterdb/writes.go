@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package meterdb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+const prefixLabel = "prefix"
+
+var (
+	_ database.Database        = (*writeDatabase)(nil)
+	_ database.SizeHintBatcher = (*writeDatabase)(nil)
+	_ database.Snapshotter     = (*writeDatabase)(nil)
+	_ database.Batch           = (*writeBatch)(nil)
+)
+
+// WriteMetrics attributes the writes performed on a shared database to the
+// subsystems that issued them.
+//
+// Each subsystem should wrap the database it writes to with a distinct prefix
+// label. Comparing the per-prefix write volume against the compaction metrics
+// of the underlying database shows which subsystem is responsible for the
+// write amplification.
+type WriteMetrics struct {
+	writes         *prometheus.CounterVec
+	writeBytes     *prometheus.CounterVec
+	batchWrites    *prometheus.CounterVec
+	batchHintBytes *prometheus.CounterVec
+	batchOverBytes *prometheus.CounterVec
+}
+
+func NewWriteMetrics(namespace string, reg prometheus.Registerer) (*WriteMetrics, error) {
+	m := &WriteMetrics{
+		writes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "writes",
+				Help:      "number of puts and deletes written to the database",
+			},
+			[]string{prefixLabel},
+		),
+		writeBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "write_bytes",
+				Help:      "number of key and value bytes written to the database",
+			},
+			[]string{prefixLabel},
+		),
+		batchWrites: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "batch_writes",
+				Help:      "number of batches written to the database",
+			},
+			[]string{prefixLabel},
+		),
+		batchHintBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "batch_size_hint_bytes",
+				Help:      "number of bytes batches were pre-allocated to hold",
+			},
+			[]string{prefixLabel},
+		),
+		batchOverBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "batch_size_hint_exceeded_bytes",
+				Help:      "number of bytes written by batches beyond their size hint",
+			},
+			[]string{prefixLabel},
+		),
+	}
+	err := utils.Err(
+		reg.Register(m.writes),
+		reg.Register(m.writeBytes),
+		reg.Register(m.batchWrites),
+		reg.Register(m.batchHintBytes),
+		reg.Register(m.batchOverBytes),
+	)
+	return m, err
+}
+
+// Wrap returns a database that attributes all writes to [db] to [prefix].
+func (m *WriteMetrics) Wrap(prefix string, db database.Database) database.Database {
+	labels := prometheus.Labels{prefixLabel: prefix}
+	return &writeDatabase{
+		Database:       db,
+		writes:         m.writes.With(labels),
+		writeBytes:     m.writeBytes.With(labels),
+		batchWrites:    m.batchWrites.With(labels),
+		batchHintBytes: m.batchHintBytes.With(labels),
+		batchOverBytes: m.batchOverBytes.With(labels),
+	}
+}
+
+type writeDatabase struct {
+	database.Database
+
+	writes         prometheus.Counter
+	writeBytes     prometheus.Counter
+	batchWrites    prometheus.Counter
+	batchHintBytes prometheus.Counter
+	batchOverBytes prometheus.Counter
+}
+
+func (db *writeDatabase) Put(key, value []byte) error {
+	db.writes.Inc()
+	db.writeBytes.Add(float64(len(key) + len(value)))
+	return db.Database.Put(key, value)
+}
+
+func (db *writeDatabase) Delete(key []byte) error {
+	db.writes.Inc()
+	db.writeBytes.Add(float64(len(key)))
+	return db.Database.Delete(key)
+}
+
+func (db *writeDatabase) NewBatch() database.Batch {
+	return &writeBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+func (db *writeDatabase) NewBatchWithSizeHint(size int) database.Batch {
+	db.batchHintBytes.Add(float64(size))
+	return &writeBatch{
+		Batch:    database.NewBatchWithSizeHint(db.Database, size),
+		db:       db,
+		sizeHint: size,
+	}
+}
+
+func (db *writeDatabase) NewSnapshot() (database.Snapshot, error) {
+	return database.NewSnapshot(db.Database)
+}
+
+type writeBatch struct {
+	database.Batch
+
+	db       *writeDatabase
+	sizeHint int
+	numOps   int
+}
+
+func (b *writeBatch) Put(key, value []byte) error {
+	b.numOps++
+	return b.Batch.Put(key, value)
+}
+
+func (b *writeBatch) Delete(key []byte) error {
+	b.numOps++
+	return b.Batch.Delete(key)
+}
+
+func (b *writeBatch) Write() error {
+	size := b.Batch.Size()
+	b.db.writes.Add(float64(b.numOps))
+	b.db.writeBytes.Add(float64(size))
+	b.db.batchWrites.Inc()
+	if b.sizeHint > 0 && size > b.sizeHint {
+		b.db.batchOverBytes.Add(float64(size - b.sizeHint))
+	}
+	return b.Batch.Write()
+}
+
+func (b *writeBatch) Reset() {
+	b.numOps = 0
+	b.Batch.Reset()
+}
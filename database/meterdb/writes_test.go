@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package meterdb
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+func TestWriteMetricsInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		m, err := NewWriteMetrics("", prometheus.NewRegistry())
+		require.NoError(t, err)
+
+		test(t, m.Wrap("test", memdb.New()))
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	require := require.New(t)
+
+	m, err := NewWriteMetrics("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	baseDB := memdb.New()
+	db := m.Wrap("a", baseDB)
+	otherDB := m.Wrap("b", baseDB)
+
+	require.NoError(db.Put([]byte{1}, []byte{2, 3}))
+	require.NoError(db.Delete([]byte{1}))
+
+	batch := database.NewBatchWithSizeHint(otherDB, 2)
+	require.NoError(batch.Put([]byte{1}, []byte{2, 3}))
+	require.NoError(batch.Delete([]byte{4}))
+	require.NoError(batch.Write())
+
+	labels := prometheus.Labels{prefixLabel: "a"}
+	require.Equal(2.0, counterValue(t, m.writes.With(labels)))
+	require.Equal(4.0, counterValue(t, m.writeBytes.With(labels)))
+	require.Zero(counterValue(t, m.batchWrites.With(labels)))
+
+	labels = prometheus.Labels{prefixLabel: "b"}
+	require.Equal(2.0, counterValue(t, m.writes.With(labels)))
+	require.Equal(4.0, counterValue(t, m.writeBytes.With(labels)))
+	require.Equal(1.0, counterValue(t, m.batchWrites.With(labels)))
+	require.Equal(2.0, counterValue(t, m.batchHintBytes.With(labels)))
+	require.Equal(2.0, counterValue(t, m.batchOverBytes.With(labels)))
+}
+
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	metric := &dto.Metric{}
+	require.NoError(t, counter.Write(metric))
+	return metric.GetCounter().GetValue()
+}
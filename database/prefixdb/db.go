@@ -18,9 +18,12 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database        = (*Database)(nil)
+	_ database.SizeHintBatcher = (*Database)(nil)
+	_ database.Snapshotter     = (*Database)(nil)
+	_ database.Batch           = (*batch)(nil)
+	_ database.Snapshot        = (*snapshot)(nil)
+	_ database.Iterator        = (*iterator)(nil)
 )
 
 // Database partitions a database into a sub-database by prefixing all keys with
@@ -136,6 +139,15 @@ func (db *Database) NewBatch() database.Batch {
 	}
 }
 
+// NewBatchWithSizeHint does not account for the prefix that is added to each
+// key written into the batch.
+func (db *Database) NewBatchWithSizeHint(size int) database.Batch {
+	return &batch{
+		Batch: database.NewBatchWithSizeHint(db.db, size),
+		db:    db,
+	}
+}
+
 func (db *Database) NewIterator() database.Iterator {
 	return db.NewIteratorWithStartAndPrefix(nil, nil)
 }
@@ -170,6 +182,26 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return it
 }
 
+// NewSnapshot returns a read-only view of the current contents of the
+// database. The snapshot only provides isolation if the underlying database
+// supports snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	s, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: s,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -315,6 +347,54 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	return nil
 }
 
+// snapshot prefixes all the keys read from a snapshot of the underlying
+// database.
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// [key] may be modified after this method returns.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	prefixedKey := s.db.prefix(key)
+	has, err := s.Snapshot.Has(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return has, err
+}
+
+// [key] may be modified after this method returns.
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	prefixedKey := s.db.prefix(key)
+	val, err := s.Snapshot.Get(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return val, err
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	prefixedStart := s.db.prefix(start)
+	prefixedPrefix := s.db.prefix(prefix)
+	it := &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
+		db:       s.db,
+	}
+	s.db.bufferPool.Put(prefixedStart)
+	s.db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
@@ -22,6 +22,15 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		db := memdb.New()
+		test(t, New([]byte("hello"), db))
+		test(t, New([]byte("world"), db))
+		test(t, New([]byte("wor"), New([]byte("ld"), db)))
+	}
+}
+
 func FuzzKeyValue(f *testing.F) {
 	database.FuzzKeyValue(f, New([]byte(""), memdb.New()))
 }
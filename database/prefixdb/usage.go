@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package prefixdb
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// usageCheckFrequency is the number of keys iterated over between checks for
+// the cancellation of the context when computing the usage of a database.
+const usageCheckFrequency = 1024
+
+// Usage is the storage used by the keys of a database.
+type Usage struct {
+	// NumKeys is the number of keys in the database
+	NumKeys uint64 `json:"numKeys"`
+	// NumBytes is the number of bytes of the keys and values in the database.
+	// This doesn't account for the prefix of each key, nor for the compression
+	// or overhead of the underlying storage.
+	NumBytes uint64 `json:"numBytes"`
+	// Partial is true if only the first keys of the database were counted
+	Partial bool `json:"partial"`
+}
+
+// ComputeUsage iterates over the keys of [db] to compute the storage it uses.
+// If [maxKeys] is non-zero, at most [maxKeys] keys are counted, which bounds
+// the time taken for large databases.
+//
+// Prefixed databases created from a prefixed database with New don't share its
+// prefix in the underlying database, so their keys aren't counted in the usage
+// of the database they were created from.
+func ComputeUsage(ctx context.Context, db database.Iteratee, maxKeys uint64) (Usage, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var usage Usage
+	for it.Next() {
+		if maxKeys != 0 && usage.NumKeys >= maxKeys {
+			usage.Partial = true
+			break
+		}
+		if usage.NumKeys%usageCheckFrequency == 0 {
+			if err := ctx.Err(); err != nil {
+				return Usage{}, err
+			}
+		}
+
+		usage.NumKeys++
+		usage.NumBytes += uint64(len(it.Key()) + len(it.Value()))
+	}
+	return usage, it.Error()
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package prefixdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+func TestComputeUsage(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxKeys       uint64
+		expectedUsage Usage
+	}{
+		{
+			name:    "unbounded",
+			maxKeys: 0,
+			expectedUsage: Usage{
+				NumKeys:  2,
+				NumBytes: 8,
+			},
+		},
+		{
+			name:    "bound not reached",
+			maxKeys: 2,
+			expectedUsage: Usage{
+				NumKeys:  2,
+				NumBytes: 8,
+			},
+		},
+		{
+			name:    "bound reached",
+			maxKeys: 1,
+			expectedUsage: Usage{
+				NumKeys:  1,
+				NumBytes: 3,
+				Partial:  true,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			baseDB := memdb.New()
+			db := New([]byte("db"), baseDB)
+			otherDB := New([]byte("other"), baseDB)
+			nestedDB := New([]byte("nested"), db)
+
+			require.NoError(db.Put([]byte("a"), []byte("12")))
+			require.NoError(db.Put([]byte("bc"), []byte("345")))
+			require.NoError(otherDB.Put([]byte("d"), []byte("6")))
+			require.NoError(nestedDB.Put([]byte("e"), []byte("7")))
+
+			usage, err := ComputeUsage(context.Background(), db, test.maxKeys)
+			require.NoError(err)
+			require.Equal(test.expectedUsage, usage)
+		})
+	}
+}
+
+func TestComputeUsageCanceled(t *testing.T) {
+	require := require.New(t)
+
+	db := New([]byte("db"), memdb.New())
+	require.NoError(db.Put([]byte("a"), []byte("b")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ComputeUsage(ctx, db, 0)
+	require.ErrorIs(err, context.Canceled)
+}
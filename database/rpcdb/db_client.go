@@ -6,13 +6,21 @@ package rpcdb
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
 
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
 )
@@ -21,22 +29,110 @@ var (
 	_ database.Database = (*DatabaseClient)(nil)
 	_ database.Batch    = (*batch)(nil)
 	_ database.Iterator = (*iterator)(nil)
+
+	errUnexpectedNumValues = errors.New("unexpected number of values")
+
+	// DefaultConfig sends every write to the server immediately.
+	DefaultConfig = Config{
+		IteratorPageSize: 512 * units.KiB,
+	}
+
+	// DefaultCoalescingConfig coalesces up to 256 writes, or 4 MiB of keys and
+	// values, before they are sent to the server.
+	DefaultCoalescingConfig = Config{
+		MaxPendingWrites: 256,
+		MaxPendingBytes:  4 * units.MiB,
+		IteratorPageSize: 512 * units.KiB,
+	}
 )
 
+// Config configures the batching performed by a [DatabaseClient].
+type Config struct {
+	// MaxPendingWrites is the number of puts and deletes that are coalesced
+	// before they are written to the server in a single batch. If 0, puts and
+	// deletes are sent to the server immediately.
+	MaxPendingWrites int
+	// MaxPendingBytes is the number of bytes of coalesced keys and values
+	// that causes the pending writes to be written to the server.
+	MaxPendingBytes int
+	// IteratorPageSize is the maximum number of bytes of keys and values to
+	// fetch per iterator page. If 0, the server's default page size is used.
+	IteratorPageSize uint64
+}
+
 // DatabaseClient is an implementation of database that talks over RPC.
+//
+// If write coalescing is enabled, puts and deletes are buffered locally and
+// written to the server in a single batch. Reads observe the buffered writes.
+// Buffered writes are written before any iterator is created, any batch is
+// written, the database is compacted or the database is closed. Writes that
+// are buffered when the process exits are lost, so callers that require
+// durability should call [DatabaseClient.Flush].
 type DatabaseClient struct {
-	client rpcdbpb.DatabaseClient
+	client  rpcdbpb.DatabaseClient
+	config  Config
+	metrics metrics
 
 	closed utils.Atomic[bool]
+
+	// pendingLock is held while [pending] is being written to the server, so
+	// that reads never miss a write that has been removed from [pending] but
+	// hasn't been written to the server yet.
+	pendingLock sync.RWMutex
+	// pending maps keys to their buffered put or delete
+	pending map[string]pendingWrite
+	// pendingSize is the number of bytes of keys and values in [pending]
+	pendingSize int
+}
+
+type pendingWrite struct {
+	value  []byte
+	delete bool
 }
 
-// NewClient returns a database instance connected to a remote database instance
+// get returns the value a read of the key observes after this write.
+func (w pendingWrite) get() ([]byte, error) {
+	if w.delete {
+		return nil, database.ErrNotFound
+	}
+	return slices.Clone(w.value), nil
+}
+
+// NewClient returns a database instance connected to a remote database
+// instance that sends every write to the server immediately.
 func NewClient(client rpcdbpb.DatabaseClient) *DatabaseClient {
-	return &DatabaseClient{client: client}
+	return &DatabaseClient{
+		client:  client,
+		metrics: newNoMetrics(),
+	}
+}
+
+// NewClientWithConfig returns a database instance connected to a remote
+// database instance that batches requests as specified by [config].
+func NewClientWithConfig(
+	client rpcdbpb.DatabaseClient,
+	config Config,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*DatabaseClient, error) {
+	metrics, err := newMetrics(namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &DatabaseClient{
+		client:  client,
+		config:  config,
+		metrics: metrics,
+		pending: make(map[string]pendingWrite),
+	}, nil
 }
 
 // Has attempts to return if the database has a key with the provided value.
 func (db *DatabaseClient) Has(key []byte) (bool, error) {
+	if write, ok := db.getPending(key); ok {
+		return !write.delete, nil
+	}
+
 	resp, err := db.client.Has(context.Background(), &rpcdbpb.HasRequest{
 		Key: key,
 	})
@@ -48,6 +144,10 @@ func (db *DatabaseClient) Has(key []byte) (bool, error) {
 
 // Get attempts to return the value that was mapped to the key that was provided
 func (db *DatabaseClient) Get(key []byte) ([]byte, error) {
+	if write, ok := db.getPending(key); ok {
+		return write.get()
+	}
+
 	resp, err := db.client.Get(context.Background(), &rpcdbpb.GetRequest{
 		Key: key,
 	})
@@ -57,8 +157,59 @@ func (db *DatabaseClient) Get(key []byte) ([]byte, error) {
 	return resp.Value, errEnumToError[resp.Err]
 }
 
+// MultiGet returns the values that are mapped to each of the provided keys in
+// a single request. The returned errors are indexed like the keys. If a key
+// isn't in the database, its error is [database.ErrNotFound].
+func (db *DatabaseClient) MultiGet(keys [][]byte) ([][]byte, []error) {
+	var (
+		values = make([][]byte, len(keys))
+		errs   = make([]error, len(keys))
+
+		remoteKeys    = make([][]byte, 0, len(keys))
+		remoteIndices = make([]int, 0, len(keys))
+	)
+	for i, key := range keys {
+		if write, ok := db.getPending(key); ok {
+			values[i], errs[i] = write.get()
+			continue
+		}
+		remoteKeys = append(remoteKeys, key)
+		remoteIndices = append(remoteIndices, i)
+	}
+	if len(remoteKeys) == 0 {
+		return values, errs
+	}
+
+	db.metrics.multiGetSize.Observe(float64(len(remoteKeys)))
+	resp, err := db.client.MultiGet(context.Background(), &rpcdbpb.MultiGetRequest{
+		Keys: remoteKeys,
+	})
+	if err == nil && len(resp.Values) != len(remoteKeys) {
+		err = fmt.Errorf("%w: expected %d but got %d",
+			errUnexpectedNumValues,
+			len(remoteKeys),
+			len(resp.Values),
+		)
+	}
+	for j, i := range remoteIndices {
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		values[i] = resp.Values[j].Value
+		errs[i] = errEnumToError[resp.Values[j].Err]
+	}
+	return values, errs
+}
+
 // Put attempts to set the value this key maps to
 func (db *DatabaseClient) Put(key, value []byte) error {
+	if db.config.MaxPendingWrites > 0 {
+		return db.addPending(key, pendingWrite{
+			value: slices.Clone(value),
+		})
+	}
+
 	resp, err := db.client.Put(context.Background(), &rpcdbpb.PutRequest{
 		Key:   key,
 		Value: value,
@@ -71,6 +222,12 @@ func (db *DatabaseClient) Put(key, value []byte) error {
 
 // Delete attempts to remove any mapping from the key
 func (db *DatabaseClient) Delete(key []byte) error {
+	if db.config.MaxPendingWrites > 0 {
+		return db.addPending(key, pendingWrite{
+			delete: true,
+		})
+	}
+
 	resp, err := db.client.Delete(context.Background(), &rpcdbpb.DeleteRequest{
 		Key: key,
 	})
@@ -99,6 +256,12 @@ func (db *DatabaseClient) NewIteratorWithPrefix(prefix []byte) database.Iterator
 
 // NewIteratorWithStartAndPrefix returns a new empty iterator
 func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if err := db.Flush(); err != nil {
+		return &database.IteratorError{
+			Err: err,
+		}
+	}
+
 	resp, err := db.client.NewIteratorWithStartAndPrefix(context.Background(), &rpcdbpb.NewIteratorWithStartAndPrefixRequest{
 		Start:  start,
 		Prefix: prefix,
@@ -113,6 +276,10 @@ func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) da
 
 // Compact attempts to optimize the space utilization in the provided range
 func (db *DatabaseClient) Compact(start, limit []byte) error {
+	if err := db.Flush(); err != nil {
+		return err
+	}
+
 	resp, err := db.client.Compact(context.Background(), &rpcdbpb.CompactRequest{
 		Start: start,
 		Limit: limit,
@@ -125,6 +292,10 @@ func (db *DatabaseClient) Compact(start, limit []byte) error {
 
 // Close attempts to close the database
 func (db *DatabaseClient) Close() error {
+	if err := db.Flush(); err != nil {
+		return err
+	}
+
 	db.closed.Set(true)
 	resp, err := db.client.Close(context.Background(), &rpcdbpb.CloseRequest{})
 	if err != nil {
@@ -142,6 +313,83 @@ func (db *DatabaseClient) HealthCheck(ctx context.Context) (interface{}, error)
 	return json.RawMessage(health.Details), nil
 }
 
+// Flush writes all the coalesced puts and deletes to the server.
+func (db *DatabaseClient) Flush() error {
+	db.pendingLock.Lock()
+	defer db.pendingLock.Unlock()
+
+	return db.flush()
+}
+
+// getPending returns the coalesced write of [key], if there is one.
+func (db *DatabaseClient) getPending(key []byte) (pendingWrite, bool) {
+	db.pendingLock.RLock()
+	defer db.pendingLock.RUnlock()
+
+	write, ok := db.pending[string(key)]
+	return write, ok
+}
+
+// addPending coalesces [write] to [key] and flushes the pending writes if
+// either of the configured limits is reached.
+func (db *DatabaseClient) addPending(key []byte, write pendingWrite) error {
+	if db.closed.Get() {
+		return database.ErrClosed
+	}
+
+	db.pendingLock.Lock()
+	defer db.pendingLock.Unlock()
+
+	keyStr := string(key)
+	if previous, ok := db.pending[keyStr]; ok {
+		db.pendingSize -= len(keyStr) + len(previous.value)
+	}
+	db.pending[keyStr] = write
+	db.pendingSize += len(keyStr) + len(write.value)
+
+	if len(db.pending) < db.config.MaxPendingWrites && db.pendingSize < db.config.MaxPendingBytes {
+		return nil
+	}
+	return db.flush()
+}
+
+// Assumes [pendingLock] is held.
+func (db *DatabaseClient) flush() error {
+	if len(db.pending) == 0 {
+		return nil
+	}
+
+	request := &rpcdbpb.WriteBatchRequest{}
+	for key, write := range db.pending {
+		if write.delete {
+			request.Deletes = append(request.Deletes, &rpcdbpb.DeleteRequest{
+				Key: []byte(key),
+			})
+		} else {
+			request.Puts = append(request.Puts, &rpcdbpb.PutRequest{
+				Key:   []byte(key),
+				Value: write.value,
+			})
+		}
+	}
+	if err := db.writeBatch(request); err != nil {
+		return err
+	}
+
+	db.metrics.flushSize.Observe(float64(len(db.pending)))
+	maps.Clear(db.pending)
+	db.pendingSize = 0
+	return nil
+}
+
+func (db *DatabaseClient) writeBatch(request *rpcdbpb.WriteBatchRequest) error {
+	resp, err := db.client.WriteBatch(context.Background(), request)
+	if err != nil {
+		return err
+	}
+	return errEnumToError[resp.Err]
+}
+
 type batch struct {
 	database.BatchOps
 
@@ -171,11 +419,15 @@ func (b *batch) Write() error {
 		}
 	}
 
-	resp, err := b.db.client.WriteBatch(context.Background(), request)
-	if err != nil {
+	// Any coalesced writes must be written before the batch so that they don't
+	// overwrite the batch's writes.
+	b.db.pendingLock.Lock()
+	defer b.db.pendingLock.Unlock()
+
+	if err := b.db.flush(); err != nil {
 		return err
 	}
-	return errEnumToError[resp.Err]
+	return b.db.writeBatch(request)
 }
 
 func (b *batch) Inner() database.Batch {
@@ -233,7 +485,8 @@ func (it *iterator) fetch() {
 
 	for {
 		resp, err := it.db.client.IteratorNext(context.Background(), &rpcdbpb.IteratorNextRequest{
-			Id: it.id,
+			Id:       it.id,
+			PageSize: it.db.config.IteratorPageSize,
 		})
 		if err != nil {
 			it.setError(err)
@@ -243,6 +496,7 @@ func (it *iterator) fetch() {
 		if len(resp.Data) == 0 {
 			return
 		}
+		it.db.metrics.iteratorPageSize.Observe(float64(len(resp.Data)))
 
 		for {
 			select {
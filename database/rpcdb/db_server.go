@@ -12,12 +12,16 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/units"
 
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
 )
 
-const iterationBatchSize = 128 * units.KiB
+const (
+	iterationBatchSize    = 128 * units.KiB
+	maxIterationBatchSize = 16 * units.MiB
+)
 
 var errUnknownIterator = errors.New("unknown iterator")
 
@@ -63,6 +67,23 @@ func (db *DatabaseServer) Get(_ context.Context, req *rpcdbpb.GetRequest) (*rpcd
 	}, errorToRPCError(err)
 }
 
+// MultiGet delegates a Get call to the managed database for each of the
+// requested keys and returns the results
+func (db *DatabaseServer) MultiGet(_ context.Context, req *rpcdbpb.MultiGetRequest) (*rpcdbpb.MultiGetResponse, error) {
+	values := make([]*rpcdbpb.GetResponse, len(req.Keys))
+	for i, key := range req.Keys {
+		value, err := db.db.Get(key)
+		if err := errorToRPCError(err); err != nil {
+			return nil, err
+		}
+		values[i] = &rpcdbpb.GetResponse{
+			Value: value,
+			Err:   errorToErrEnum[err],
+		}
+	}
+	return &rpcdbpb.MultiGetResponse{Values: values}, nil
+}
+
 // Put delegates the Put call to the managed database and returns the result
 func (db *DatabaseServer) Put(_ context.Context, req *rpcdbpb.PutRequest) (*rpcdbpb.PutResponse, error) {
 	err := db.db.Put(req.Key, req.Value)
@@ -150,11 +171,16 @@ func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbpb.IteratorN
 		return nil, errUnknownIterator
 	}
 
+	pageSize := iterationBatchSize
+	if req.PageSize != 0 {
+		pageSize = int(math.Min(req.PageSize, maxIterationBatchSize))
+	}
+
 	var (
 		size int
 		data []*rpcdbpb.PutRequest
 	)
-	for size < iterationBatchSize && it.Next() {
+	for size < pageSize && it.Next() {
 		key := it.Key()
 		value := it.Value()
 		size += len(key) + len(value)
@@ -7,11 +7,14 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/corruptabledb"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
 
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
@@ -23,7 +26,25 @@ type testDatabase struct {
 	closeFn func()
 }
 
+var coalescingConfig = Config{
+	MaxPendingWrites: 4,
+	MaxPendingBytes:  units.KiB,
+	IteratorPageSize: 64,
+}
+
 func setupDB(t testing.TB) *testDatabase {
+	return setupDBWithClient(t, NewClient)
+}
+
+func setupDBWithConfig(t testing.TB, config Config) *testDatabase {
+	return setupDBWithClient(t, func(client rpcdbpb.DatabaseClient) *DatabaseClient {
+		db, err := NewClientWithConfig(client, config, "", prometheus.NewRegistry())
+		require.NoError(t, err)
+		return db
+	})
+}
+
+func setupDBWithClient(t testing.TB, newClient func(rpcdbpb.DatabaseClient) *DatabaseClient) *testDatabase {
 	require := require.New(t)
 
 	db := &testDatabase{
@@ -43,7 +64,7 @@ func setupDB(t testing.TB) *testDatabase {
 	conn, err := grpcutils.Dial(listener.Addr().String())
 	require.NoError(err)
 
-	db.client = NewClient(rpcdbpb.NewDatabaseClient(conn))
+	db.client = newClient(rpcdbpb.NewDatabaseClient(conn))
 	db.closeFn = func() {
 		serverCloser.Stop()
 		_ = conn.Close()
@@ -61,6 +82,92 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestInterfaceWithCoalescing(t *testing.T) {
+	for _, test := range database.Tests {
+		db := setupDBWithConfig(t, coalescingConfig)
+		test(t, db.client)
+
+		db.closeFn()
+	}
+}
+
+func TestMultiGet(t *testing.T) {
+	require := require.New(t)
+
+	db := setupDBWithConfig(t, coalescingConfig)
+	defer db.closeFn()
+
+	require.NoError(db.server.Put([]byte("flushed"), []byte("a")))
+	require.NoError(db.client.Put([]byte("pending"), []byte("b")))
+	require.NoError(db.server.Put([]byte("deleted"), []byte("c")))
+	require.NoError(db.client.Delete([]byte("deleted")))
+
+	values, errs := db.client.MultiGet([][]byte{
+		[]byte("flushed"),
+		[]byte("pending"),
+		[]byte("deleted"),
+		[]byte("missing"),
+	})
+	require.Equal([][]byte{[]byte("a"), []byte("b"), nil, nil}, values)
+	require.Len(errs, 4)
+	require.NoError(errs[0])
+	require.NoError(errs[1])
+	require.ErrorIs(errs[2], database.ErrNotFound)
+	require.ErrorIs(errs[3], database.ErrNotFound)
+}
+
+func TestCoalescedWrites(t *testing.T) {
+	require := require.New(t)
+
+	db := setupDBWithConfig(t, coalescingConfig)
+	defer db.closeFn()
+
+	// Writes below the limits are only buffered by the client
+	for i := 0; i < coalescingConfig.MaxPendingWrites-1; i++ {
+		require.NoError(db.client.Put([]byte{byte(i)}, []byte{byte(i)}))
+	}
+	require.NoError(db.client.Delete([]byte{0}))
+
+	has, err := db.server.Has([]byte{1})
+	require.NoError(err)
+	require.False(has)
+
+	// Reads observe the buffered writes
+	value, err := db.client.Get([]byte{1})
+	require.NoError(err)
+	require.Equal([]byte{1}, value)
+
+	_, err = db.client.Get([]byte{0})
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Reaching the write limit flushes the buffered writes
+	require.NoError(db.client.Put([]byte{3}, []byte{3}))
+	for i := 1; i < coalescingConfig.MaxPendingWrites; i++ {
+		value, err := db.server.Get([]byte{byte(i)})
+		require.NoError(err)
+		require.Equal([]byte{byte(i)}, value)
+	}
+
+	// Creating an iterator flushes the buffered writes
+	require.NoError(db.client.Delete([]byte{1}))
+	it := db.client.NewIterator()
+	defer it.Release()
+
+	has, err = db.server.Has([]byte{1})
+	require.NoError(err)
+	require.False(has)
+
+	// A batch is written after the buffered writes
+	require.NoError(db.client.Put([]byte{2}, []byte{4}))
+	batch := db.client.NewBatch()
+	require.NoError(batch.Put([]byte{2}, []byte{5}))
+	require.NoError(batch.Write())
+
+	value, err = db.server.Get([]byte{2})
+	require.NoError(err)
+	require.Equal([]byte{5}, value)
+}
+
 func FuzzKeyValue(f *testing.F) {
 	db := setupDB(f)
 	database.FuzzKeyValue(f, db.client)
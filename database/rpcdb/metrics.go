@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcdb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type metrics struct {
+	multiGetSize     metric.Averager
+	flushSize        metric.Averager
+	iteratorPageSize metric.Averager
+}
+
+func newMetrics(namespace string, reg prometheus.Registerer) (metrics, error) {
+	errs := wrappers.Errs{}
+	return metrics{
+		multiGetSize: metric.NewAveragerWithErrs(
+			namespace,
+			"multi_get_size",
+			"keys requested in a MultiGet call",
+			reg,
+			&errs,
+		),
+		flushSize: metric.NewAveragerWithErrs(
+			namespace,
+			"flush_size",
+			"coalesced puts and deletes written in a single batch",
+			reg,
+			&errs,
+		),
+		iteratorPageSize: metric.NewAveragerWithErrs(
+			namespace,
+			"iterator_page_size",
+			"key/value pairs returned in an iterator page",
+			reg,
+			&errs,
+		),
+	}, errs.Err
+}
+
+func newNoMetrics() metrics {
+	return metrics{
+		multiGetSize:     metric.NewNoAverager(),
+		flushSize:        metric.NewNoAverager(),
+		iteratorPageSize: metric.NewNoAverager(),
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+var _ Snapshot = (*liveSnapshot)(nil)
+
+// Snapshot is a read-only view of a database at the point in time the
+// snapshot was created. Writes made to the database after the snapshot was
+// created are not visible through the snapshot.
+//
+// A snapshot must be released after use. It is safe to read from a snapshot
+// concurrently, but Release must not be called concurrently with any other
+// method.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases associated resources. Release should always succeed
+	// and can be called multiple times without causing error. After Release is
+	// called, reads from the snapshot should return [ErrClosed].
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot creates a read-only view of the current contents of the
+	// data store.
+	NewSnapshot() (Snapshot, error)
+}
+
+// liveSnapshot is returned for databases that do not support snapshots. It
+// reads directly from the database, so it provides no isolation.
+type liveSnapshot struct {
+	KeyValueReader
+	Iteratee
+}
+
+func (liveSnapshot) Release() {}
@@ -64,6 +64,14 @@ var Tests = []func(t *testing.T, db Database){
 	TestPutGetEmpty,
 }
 
+// SnapshotTests is a list of all database tests that require the database to
+// support snapshots
+var SnapshotTests = []func(t *testing.T, db Database){
+	TestSnapshot,
+	TestSnapshotIteratorStartPrefix,
+	TestSnapshotRelease,
+}
+
 // TestSimpleKeyValue tests to make sure that simple Put + Get + Delete + Has
 // calls return the expected values.
 func TestSimpleKeyValue(t *testing.T, db Database) {
@@ -1185,6 +1193,131 @@ func TestPutGetEmpty(t *testing.T, db Database) {
 	require.Empty(value) // May be nil or empty byte slice.
 }
 
+// TestSnapshot tests to make sure that a snapshot does not observe writes made
+// after it was created.
+func TestSnapshot(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Put(key1, value2))
+	require.NoError(db.Delete(key2))
+	require.NoError(db.Put(key3, value3))
+
+	value, err := snapshot.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	has, err := snapshot.Has(key2)
+	require.NoError(err)
+	require.True(has)
+
+	_, err = snapshot.Get(key3)
+	require.ErrorIs(err, ErrNotFound)
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+	require.NoError(iterator.Error())
+
+	value, err = db.Get(key1)
+	require.NoError(err)
+	require.Equal(value2, value)
+}
+
+// TestSnapshotIteratorStartPrefix tests to make sure that an iterator over a
+// snapshot can start mid way through the snapshot while skipping a prefix.
+func TestSnapshotIteratorStartPrefix(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("z")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	key4 := []byte("hello4")
+	value4 := []byte("world4")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Put(key3, value3))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Put(key4, value4))
+	require.NoError(db.Delete(key3))
+
+	iterator := snapshot.NewIteratorWithStartAndPrefix(key1, []byte("h"))
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key3, iterator.Key())
+	require.Equal(value3, iterator.Value())
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+	require.NoError(iterator.Error())
+}
+
+// TestSnapshotRelease tests to make sure that a snapshot can not be read
+// after it was released.
+func TestSnapshotRelease(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	require.NoError(db.Put(key, value))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+
+	snapshot.Release()
+	snapshot.Release()
+
+	_, err = snapshot.Get(key)
+	require.ErrorIs(err, ErrClosed)
+
+	_, err = snapshot.Has(key)
+	require.ErrorIs(err, ErrClosed)
+}
+
 func FuzzKeyValue(f *testing.F, db Database) {
 	f.Fuzz(func(t *testing.T, key []byte, value []byte) {
 		require := require.New(t)
@@ -16,10 +16,12 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ Commitable        = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database    = (*Database)(nil)
+	_ database.Snapshotter = (*Database)(nil)
+	_ Commitable           = (*Database)(nil)
+	_ database.Batch       = (*batch)(nil)
+	_ database.Snapshot    = (*snapshot)(nil)
+	_ database.Iterator    = (*iterator)(nil)
 )
 
 // Commitable defines the interface that specifies that something may be
@@ -37,6 +39,8 @@ type Database struct {
 	mem   map[string]valueDelete
 	db    database.Database
 	batch database.Batch
+	// batchSize is the largest size hint that [batch] was allocated with.
+	batchSize int
 }
 
 type valueDelete struct {
@@ -130,26 +134,36 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		}
 	}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
+	return newIterator(
+		db,
+		db.mem,
+		db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		prefix,
+	)
+}
+
+// NewSnapshot returns a read-only view of the current contents of the
+// database, including the uncommitted operations. The snapshot only isolates
+// the committed contents if the underlying database supports snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
 	}
-	slices.Sort(keys) // Keys need to be in sorted order
-	values := make([]valueDelete, len(keys))
-	for i, key := range keys {
-		values[i] = db.mem[key]
+	s, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
 	}
-
-	return &iterator{
+	// Values are never modified after being inserted into [db.mem], so the
+	// values can be shared with the copy.
+	return &snapshot{
+		Snapshot: s,
 		db:       db,
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
-		keys:     keys,
-		values:   values,
-	}
+		mem:      maps.Clone(db.mem),
+	}, nil
 }
 
 func (db *Database) Compact(start, limit []byte) error {
@@ -231,6 +245,17 @@ func (db *Database) commitBatch() (database.Batch, error) {
 		return nil, database.ErrClosed
 	}
 
+	// Pre-size the batch if the pending changes are larger than any batch
+	// previously committed.
+	size := 0
+	for key, value := range db.mem {
+		size += len(key) + len(value.value)
+	}
+	if size > db.batchSize {
+		db.batch = database.NewBatchWithSizeHint(db.db, size)
+		db.batchSize = size
+	}
+
 	db.batch.Reset()
 	for key, value := range db.mem {
 		if value.delete {
@@ -302,6 +327,69 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+// snapshot overlays the uncommitted operations of a Database, as of the time
+// the snapshot was created, on top of a snapshot of the underlying database.
+type snapshot struct {
+	database.Snapshot
+	db  *Database
+	mem map[string]valueDelete
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.mem == nil {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	return s.Snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.mem == nil {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return slices.Clone(val.value), nil
+	}
+	return s.Snapshot.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.mem == nil {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return newIterator(
+		s.db,
+		s.mem,
+		s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		prefix,
+	)
+}
+
+func (s *snapshot) Release() {
+	s.mem = nil
+	s.Snapshot.Release()
+}
+
 // iterator walks over both the in memory database and the underlying database
 // at the same time.
 type iterator struct {
@@ -317,6 +405,37 @@ type iterator struct {
 	initialized, exhausted bool
 }
 
+// newIterator returns an iterator over the operations in [mem] with the
+// provided [start] and [prefix], merged with [it].
+func newIterator(
+	db *Database,
+	mem map[string]valueDelete,
+	it database.Iterator,
+	start []byte,
+	prefix []byte,
+) *iterator {
+	startString := string(start)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if strings.HasPrefix(key, prefixString) && key >= startString {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys) // Keys need to be in sorted order
+	values := make([]valueDelete, len(keys))
+	for i, key := range keys {
+		values[i] = mem[key]
+	}
+
+	return &iterator{
+		db:       db,
+		Iterator: it,
+		keys:     keys,
+		values:   values,
+	}
+}
+
 // Next moves the iterator to the next key/value pair. It returns whether the
 // iterator is exhausted. We must pay careful attention to set the proper values
 // based on if the in memory db or the underlying db should be read next
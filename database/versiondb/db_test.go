@@ -19,6 +19,13 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		baseDB := memdb.New()
+		test(t, New(baseDB))
+	}
+}
+
 func FuzzKeyValue(f *testing.F) {
 	database.FuzzKeyValue(f, New(memdb.New()))
 }
@@ -157,6 +164,46 @@ func TestCommit(t *testing.T) {
 	require.Equal(value1, value)
 }
 
+func TestSnapshotCommit(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	require.NoError(db.Put(key1, value1))
+
+	snapshot, err := db.NewSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Delete(key1))
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Commit())
+
+	value, err := snapshot.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	has, err := snapshot.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+	require.False(iterator.Next())
+	require.NoError(iterator.Error())
+}
+
 func TestCommitClosed(t *testing.T) {
 	require := require.New(t)
 
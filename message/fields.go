@@ -33,6 +33,8 @@ var (
 	_ chainIDGetter = (*p2p.AppRequest)(nil)
 	_ chainIDGetter = (*p2p.AppResponse)(nil)
 	_ chainIDGetter = (*p2p.AppGossip)(nil)
+	_ chainIDGetter = (*p2p.GetAcceptedFrontierAttestation)(nil)
+	_ chainIDGetter = (*p2p.AcceptedFrontierAttestation)(nil)
 
 	_ requestIDGetter = (*p2p.GetStateSummaryFrontier)(nil)
 	_ requestIDGetter = (*p2p.StateSummaryFrontier)(nil)
@@ -51,6 +53,8 @@ var (
 	_ requestIDGetter = (*p2p.Chits)(nil)
 	_ requestIDGetter = (*p2p.AppRequest)(nil)
 	_ requestIDGetter = (*p2p.AppResponse)(nil)
+	_ requestIDGetter = (*p2p.GetAcceptedFrontierAttestation)(nil)
+	_ requestIDGetter = (*p2p.AcceptedFrontierAttestation)(nil)
 
 	_ engineTypeGetter = (*p2p.GetAcceptedFrontier)(nil)
 	_ engineTypeGetter = (*p2p.GetAccepted)(nil)
@@ -824,6 +824,40 @@ func TestMessage(t *testing.T) {
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
+		{
+			desc: "get_accepted_frontier_attestation message with no compression",
+			op:   GetAcceptedFrontierAttestationOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_GetAcceptedFrontierAttestation{
+					GetAcceptedFrontierAttestation: &p2p.GetAcceptedFrontierAttestation{
+						ChainId:   testID[:],
+						RequestId: 1,
+					},
+				},
+			},
+			compressionType:  compression.TypeNone,
+			bypassThrottling: false,
+			bytesSaved:       false,
+		},
+		{
+			desc: "accepted_frontier_attestation message with no compression",
+			op:   AcceptedFrontierAttestationOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_AcceptedFrontierAttestation_{
+					AcceptedFrontierAttestation_: &p2p.AcceptedFrontierAttestation{
+						ChainId:     testID[:],
+						RequestId:   1,
+						ContainerId: testID[:],
+						Height:      1,
+						Timestamp:   1,
+						Signature:   []byte{'s', 'i', 'g'},
+					},
+				},
+			},
+			compressionType:  compression.TypeNone,
+			bypassThrottling: false,
+			bytesSaved:       false,
+		},
 	}
 
 	for _, tv := range tests {
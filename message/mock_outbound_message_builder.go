@@ -85,6 +85,21 @@ func (mr *MockOutboundMsgBuilderMockRecorder) AcceptedStateSummary(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptedStateSummary", reflect.TypeOf((*MockOutboundMsgBuilder)(nil).AcceptedStateSummary), arg0, arg1, arg2)
 }
 
+// AcceptedFrontierAttestation mocks base method.
+func (m *MockOutboundMsgBuilder) AcceptedFrontierAttestation(arg0 ids.ID, arg1 uint32, arg2 ids.ID, arg3, arg4 uint64, arg5 []byte) (OutboundMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptedFrontierAttestation", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(OutboundMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptedFrontierAttestation indicates an expected call of AcceptedFrontierAttestation.
+func (mr *MockOutboundMsgBuilderMockRecorder) AcceptedFrontierAttestation(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptedFrontierAttestation", reflect.TypeOf((*MockOutboundMsgBuilder)(nil).AcceptedFrontierAttestation), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // Ancestors mocks base method.
 func (m *MockOutboundMsgBuilder) Ancestors(arg0 ids.ID, arg1 uint32, arg2 [][]byte) (OutboundMessage, error) {
 	m.ctrl.T.Helper()
@@ -205,6 +220,21 @@ func (mr *MockOutboundMsgBuilderMockRecorder) GetAcceptedFrontier(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAcceptedFrontier", reflect.TypeOf((*MockOutboundMsgBuilder)(nil).GetAcceptedFrontier), arg0, arg1, arg2, arg3)
 }
 
+// GetAcceptedFrontierAttestation mocks base method.
+func (m *MockOutboundMsgBuilder) GetAcceptedFrontierAttestation(arg0 ids.ID, arg1 uint32) (OutboundMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAcceptedFrontierAttestation", arg0, arg1)
+	ret0, _ := ret[0].(OutboundMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAcceptedFrontierAttestation indicates an expected call of GetAcceptedFrontierAttestation.
+func (mr *MockOutboundMsgBuilderMockRecorder) GetAcceptedFrontierAttestation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAcceptedFrontierAttestation", reflect.TypeOf((*MockOutboundMsgBuilder)(nil).GetAcceptedFrontierAttestation), arg0, arg1)
+}
+
 // GetAcceptedStateSummary mocks base method.
 func (m *MockOutboundMsgBuilder) GetAcceptedStateSummary(arg0 ids.ID, arg1 uint32, arg2 time.Duration, arg3 []uint64) (OutboundMessage, error) {
 	m.ctrl.T.Helper()
@@ -371,16 +401,16 @@ func (mr *MockOutboundMsgBuilderMockRecorder) StateSummaryFrontier(arg0, arg1, a
 }
 
 // Version mocks base method.
-func (m *MockOutboundMsgBuilder) Version(arg0 uint32, arg1 uint64, arg2 ips.IPPort, arg3 string, arg4 uint64, arg5 []byte, arg6 []ids.ID) (OutboundMessage, error) {
+func (m *MockOutboundMsgBuilder) Version(arg0 uint32, arg1 uint64, arg2 ips.IPPort, arg3 string, arg4 uint64, arg5 []byte, arg6 []ids.ID, arg7, arg8 []byte) (OutboundMessage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Version", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret := m.ctrl.Call(m, "Version", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	ret0, _ := ret[0].(OutboundMessage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Version indicates an expected call of Version.
-func (mr *MockOutboundMsgBuilderMockRecorder) Version(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+func (mr *MockOutboundMsgBuilderMockRecorder) Version(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockOutboundMsgBuilder)(nil).Version), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockOutboundMsgBuilder)(nil).Version), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 }
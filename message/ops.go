@@ -58,6 +58,9 @@ const (
 	CrossChainAppRequestOp
 	CrossChainAppRequestFailedOp
 	CrossChainAppResponseOp
+	// Light client:
+	GetAcceptedFrontierAttestationOp
+	AcceptedFrontierAttestationOp
 	// Internal:
 	ConnectedOp
 	ConnectedSubnetOp
@@ -76,6 +79,13 @@ var (
 		PeerListAckOp,
 	}
 
+	// LightClientOps are answered by the networking layer without involving
+	// consensus
+	LightClientOps = []Op{
+		GetAcceptedFrontierAttestationOp,
+		AcceptedFrontierAttestationOp,
+	}
+
 	// List of all consensus request message types
 	ConsensusRequestOps = []Op{
 		GetStateSummaryFrontierOp,
@@ -128,7 +138,13 @@ var (
 	}
 	ConsensusOps = append(ConsensusExternalOps, ConsensusInternalOps...)
 
-	ExternalOps = append(ConsensusExternalOps, HandshakeOps...)
+	ExternalOps = append(
+		ConsensusExternalOps,
+		append(
+			HandshakeOps,
+			LightClientOps...,
+		)...,
+	)
 
 	SynchronousOps = []Op{
 		// State sync
@@ -278,6 +294,11 @@ func (op Op) String() string {
 		return "cross_chain_app_request_failed"
 	case CrossChainAppResponseOp:
 		return "cross_chain_app_response"
+	// Light client
+	case GetAcceptedFrontierAttestationOp:
+		return "get_accepted_frontier_attestation"
+	case AcceptedFrontierAttestationOp:
+		return "accepted_frontier_attestation"
 		// Internal
 	case ConnectedOp:
 		return "connected"
@@ -349,6 +370,11 @@ func Unwrap(m *p2p.Message) (fmt.Stringer, error) {
 		return msg.AppResponse, nil
 	case *p2p.Message_AppGossip:
 		return msg.AppGossip, nil
+	// Light client:
+	case *p2p.Message_GetAcceptedFrontierAttestation:
+		return msg.GetAcceptedFrontierAttestation, nil
+	case *p2p.Message_AcceptedFrontierAttestation_:
+		return msg.AcceptedFrontierAttestation_, nil
 	default:
 		return nil, fmt.Errorf("%w: %T", errUnknownMessageType, msg)
 	}
@@ -402,6 +428,10 @@ func ToOp(m *p2p.Message) (Op, error) {
 		return AppResponseOp, nil
 	case *p2p.Message_AppGossip:
 		return AppGossipOp, nil
+	case *p2p.Message_GetAcceptedFrontierAttestation:
+		return GetAcceptedFrontierAttestationOp, nil
+	case *p2p.Message_AcceptedFrontierAttestation_:
+		return AcceptedFrontierAttestationOp, nil
 	default:
 		return 0, fmt.Errorf("%w: %T", errUnknownMessageType, msg)
 	}
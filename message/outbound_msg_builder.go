@@ -26,6 +26,8 @@ type OutboundMsgBuilder interface {
 		myVersionTime uint64,
 		sig []byte,
 		trackedSubnets []ids.ID,
+		knownPeersFilter []byte,
+		knownPeersSalt []byte,
 	) (OutboundMessage, error)
 
 	PeerList(
@@ -171,6 +173,20 @@ type OutboundMsgBuilder interface {
 		chainID ids.ID,
 		msg []byte,
 	) (OutboundMessage, error)
+
+	GetAcceptedFrontierAttestation(
+		chainID ids.ID,
+		requestID uint32,
+	) (OutboundMessage, error)
+
+	AcceptedFrontierAttestation(
+		chainID ids.ID,
+		requestID uint32,
+		containerID ids.ID,
+		height uint64,
+		timestamp uint64,
+		signature []byte,
+	) (OutboundMessage, error)
 }
 
 type outMsgBuilder struct {
@@ -232,6 +248,8 @@ func (b *outMsgBuilder) Version(
 	myVersionTime uint64,
 	sig []byte,
 	trackedSubnets []ids.ID,
+	knownPeersFilter []byte,
+	knownPeersSalt []byte,
 ) (OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
 	encodeIDs(trackedSubnets, subnetIDBytes)
@@ -239,14 +257,16 @@ func (b *outMsgBuilder) Version(
 		&p2p.Message{
 			Message: &p2p.Message_Version{
 				Version: &p2p.Version{
-					NetworkId:      networkID,
-					MyTime:         myTime,
-					IpAddr:         ip.IP.To16(),
-					IpPort:         uint32(ip.Port),
-					MyVersion:      myVersion,
-					MyVersionTime:  myVersionTime,
-					Sig:            sig,
-					TrackedSubnets: subnetIDBytes,
+					NetworkId:        networkID,
+					MyTime:           myTime,
+					IpAddr:           ip.IP.To16(),
+					IpPort:           uint32(ip.Port),
+					MyVersion:        myVersion,
+					MyVersionTime:    myVersionTime,
+					Sig:              sig,
+					TrackedSubnets:   subnetIDBytes,
+					KnownPeersFilter: knownPeersFilter,
+					KnownPeersSalt:   knownPeersSalt,
 				},
 			},
 		},
@@ -686,3 +706,47 @@ func (b *outMsgBuilder) AppGossip(chainID ids.ID, msg []byte) (OutboundMessage,
 		false,
 	)
 }
+
+func (b *outMsgBuilder) GetAcceptedFrontierAttestation(
+	chainID ids.ID,
+	requestID uint32,
+) (OutboundMessage, error) {
+	return b.builder.createOutbound(
+		&p2p.Message{
+			Message: &p2p.Message_GetAcceptedFrontierAttestation{
+				GetAcceptedFrontierAttestation: &p2p.GetAcceptedFrontierAttestation{
+					ChainId:   chainID[:],
+					RequestId: requestID,
+				},
+			},
+		},
+		compression.TypeNone,
+		false,
+	)
+}
+
+func (b *outMsgBuilder) AcceptedFrontierAttestation(
+	chainID ids.ID,
+	requestID uint32,
+	containerID ids.ID,
+	height uint64,
+	timestamp uint64,
+	signature []byte,
+) (OutboundMessage, error) {
+	return b.builder.createOutbound(
+		&p2p.Message{
+			Message: &p2p.Message_AcceptedFrontierAttestation_{
+				AcceptedFrontierAttestation_: &p2p.AcceptedFrontierAttestation{
+					ChainId:     chainID[:],
+					RequestId:   requestID,
+					ContainerId: containerID[:],
+					Height:      height,
+					Timestamp:   timestamp,
+					Signature:   signature,
+				},
+			},
+		},
+		compression.TypeNone,
+		false,
+	)
+}
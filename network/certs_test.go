@@ -28,7 +28,7 @@ func getTLS(t *testing.T, index int) (ids.NodeID, *tls.Certificate, *tls.Config)
 	for len(tlsCerts) <= index {
 		cert, err := staking.NewTLSCert()
 		require.NoError(t, err)
-		tlsConfig := peer.TLSConfig(*cert, nil)
+		tlsConfig := peer.TLSConfig(*cert, nil, 0)
 
 		tlsCerts = append(tlsCerts, cert)
 		tlsConfigs = append(tlsConfigs, tlsConfig)
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/snow/networking/frontier"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -73,6 +74,33 @@ type PeerListGossipConfig struct {
 	// PeerListGossipFreq is the frequency that this node will attempt to gossip
 	// signed IPs to its peers.
 	PeerListGossipFreq time.Duration `json:"peerListGossipFreq"`
+
+	// PeerListMaxKnownValidators is the maximum number of validators that each
+	// peer is recorded as knowing about. Once exceeded, the validators that
+	// have been tracked the longest are forgotten and may be gossiped again.
+	PeerListMaxKnownValidators uint32 `json:"peerListMaxKnownValidators"`
+
+	// PeerListReconnectKnownTTL is how long the validators that a peer is
+	// recorded as knowing about are remembered after it disconnects. If the
+	// peer reconnects within this duration, the remembered validators aren't
+	// gossiped to it again.
+	PeerListReconnectKnownTTL time.Duration `json:"peerListReconnectKnownTTL"`
+
+	// PeerListUselessNumValidatorIPs is the number of validator IPs to gossip
+	// in every gossip event to a validator that has been up for at least
+	// [PeerListUsefulnessGracePeriod] without ever gossiping a validator IP
+	// that was useful to us.
+	PeerListUselessNumValidatorIPs uint32 `json:"peerListUselessNumValidatorIPs"`
+
+	// PeerListUsefulnessGracePeriod is the uptime a validator is given to
+	// gossip a useful validator IP before the number of validator IPs gossiped
+	// to it is reduced. If 0, the number of validator IPs is never reduced.
+	PeerListUsefulnessGracePeriod time.Duration `json:"peerListUsefulnessGracePeriod"`
+
+	// PeerListPrioritizeByStake specifies whether the validator IPs gossiped
+	// to a peer are the unknown validators with the most stake, rather than a
+	// random sample of the unknown validators.
+	PeerListPrioritizeByStake bool `json:"peerListPrioritizeByStake"`
 }
 
 type TimeoutConfig struct {
@@ -118,6 +146,11 @@ type Config struct {
 
 	TLSKeyLogFile string `json:"tlsKeyLogFile"`
 
+	// TLSSessionCacheSize is the number of TLS sessions that are cached to
+	// resume outbound connections to peers. If 0, TLS session resumption is
+	// disabled.
+	TLSSessionCacheSize int `json:"tlsSessionCacheSize"`
+
 	Namespace          string            `json:"namespace"`
 	MyNodeID           ids.NodeID        `json:"myNodeID"`
 	MyIPPort           ips.DynamicIPPort `json:"myIP"`
@@ -142,6 +175,10 @@ type Config struct {
 
 	UptimeCalculator uptime.Calculator `json:"-"`
 
+	// FrontierAttestor serves signed attestations of the accepted frontier of
+	// this node's chains to light clients.
+	FrontierAttestor frontier.Attestor `json:"-"`
+
 	// UptimeMetricFreq marks how frequently this node will recalculate the
 	// observed average uptime metrics.
 	UptimeMetricFreq time.Duration `json:"uptimeMetricFreq"`
@@ -16,6 +16,12 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
+const (
+	directionLabel = "direction"
+	inbound        = "inbound"
+	outbound       = "outbound"
+)
+
 type metrics struct {
 	numTracked                      prometheus.Gauge
 	numPeers                        prometheus.Gauge
@@ -29,7 +35,10 @@ type metrics struct {
 	inboundConnRateLimited          prometheus.Counter
 	inboundConnAllowed              prometheus.Counter
 	tlsConnRejected                 prometheus.Counter
+	tlsHandshakeLatency             *prometheus.HistogramVec
+	tlsHandshakeFailed              *prometheus.CounterVec
 	numUselessPeerListBytes         prometheus.Counter
+	numThrottledPeerLists           prometheus.Counter
 	nodeUptimeWeightedAverage       prometheus.Gauge
 	nodeUptimeRewardingStake        prometheus.Gauge
 	nodeSubnetUptimeWeightedAverage *prometheus.GaugeVec
@@ -101,11 +110,33 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 			Name:      "tls_conn_rejected",
 			Help:      "Times this node rejected a connection due to an unsupported TLS certificate",
 		}),
+		tlsHandshakeLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "tls_handshake_latency",
+				Help:      "Latency (in seconds) of the successful TLS handshakes with peers",
+				Buckets:   prometheus.DefBuckets,
+			},
+			[]string{directionLabel, peer.ResumedLabel},
+		),
+		tlsHandshakeFailed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "tls_handshake_failed",
+				Help:      "Times this node failed a TLS handshake with a peer",
+			},
+			[]string{directionLabel, peer.ReasonLabel},
+		),
 		numUselessPeerListBytes: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "num_useless_peerlist_bytes",
 			Help:      "Amount of useless bytes (i.e. information about nodes we already knew/don't want to connect to) received in PeerList messages",
 		}),
+		numThrottledPeerLists: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "num_throttled_peerlists",
+			Help:      "Number of PeerList messages built with fewer validator IPs because the receiving validator hasn't gossiped any useful validator IPs",
+		}),
 		inboundConnRateLimited: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "inbound_conn_throttler_rate_limited",
@@ -159,7 +190,10 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 		registerer.Register(m.acceptFailed),
 		registerer.Register(m.inboundConnAllowed),
 		registerer.Register(m.tlsConnRejected),
+		registerer.Register(m.tlsHandshakeLatency),
+		registerer.Register(m.tlsHandshakeFailed),
 		registerer.Register(m.numUselessPeerListBytes),
+		registerer.Register(m.numThrottledPeerLists),
 		registerer.Register(m.inboundConnRateLimited),
 		registerer.Register(m.nodeUptimeWeightedAverage),
 		registerer.Register(m.nodeUptimeRewardingStake),
@@ -184,6 +218,17 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 	return m, err
 }
 
+// upgraderMetrics returns the metrics of the Upgrader of the connections in
+// [direction].
+func (m *metrics) upgraderMetrics(direction string) *peer.UpgraderMetrics {
+	labels := prometheus.Labels{directionLabel: direction}
+	return &peer.UpgraderMetrics{
+		InvalidCerts:      m.tlsConnRejected,
+		HandshakeLatency:  m.tlsHandshakeLatency.MustCurryWith(labels),
+		HandshakeFailures: m.tlsHandshakeFailed.MustCurryWith(labels),
+	}
+}
+
 func (m *metrics) markConnected(peer peer.Peer) {
 	m.numPeers.Inc()
 	m.connected.Inc()
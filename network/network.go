@@ -34,6 +34,7 @@ import (
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 	"github.com/ava-labs/avalanchego/utils/set"
@@ -97,6 +98,10 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// UpgradeReadiness returns the distribution of the versions run by this
+	// node and the connected validators of [subnetID], weighted by stake.
+	UpgradeReadiness(subnetID ids.ID, minVersion *version.Application) (UpgradeReadinessResult, error)
 }
 
 type UptimeResult struct {
@@ -115,6 +120,22 @@ type UptimeResult struct {
 	WeightedAveragePercentage float64
 }
 
+type UpgradeReadinessResult struct {
+	// ReadyStakePercentage shows what percent of the stake is held by
+	// validators that are connected and running at least the requested
+	// version.
+	ReadyStakePercentage float64
+
+	// ConnectedStakePercentage shows what percent of the stake is held by
+	// validators that are connected. Validators that aren't connected are
+	// never counted as ready.
+	ConnectedStakePercentage float64
+
+	// VersionStakePercentages shows what percent of the stake is held by the
+	// connected validators running each version.
+	VersionStakePercentages map[string]float64
+}
+
 // To avoid potential deadlocks, we maintain that locks must be grabbed in the
 // following order:
 //
@@ -219,6 +240,7 @@ func NewNetwork(
 		}
 	}
 
+	reputationTracker := peer.NewReputationTracker(config.GossipTracker)
 	inboundMsgThrottler, err := throttling.NewInboundMsgThrottler(
 		log,
 		config.Namespace,
@@ -228,6 +250,7 @@ func NewNetwork(
 		config.ResourceTracker,
 		config.CPUTargeter,
 		config.DiskTargeter,
+		reputationTracker,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("initializing inbound message throttler failed with: %w", err)
@@ -272,8 +295,10 @@ func NewNetwork(
 		PongTimeout:          config.PingPongTimeout,
 		MaxClockDifference:   config.MaxClockDifference,
 		ResourceTracker:      config.ResourceTracker,
+		ReputationTracker:    reputationTracker,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey),
+		FrontierAttestor:     config.FrontierAttestor,
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -286,8 +311,8 @@ func NewNetwork(
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		listener:                    listener,
 		dialer:                      dialer,
-		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected),
-		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected),
+		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.upgraderMetrics(inbound)),
+		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.upgraderMetrics(outbound)),
 
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
@@ -501,6 +526,8 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 	newestTimestamp := make(map[ids.ID]uint64, ipLen)
 	// Information for us to update about them
 	txIDsWithUpToDateIP := make([]ids.ID, 0, ipLen)
+	// Number of IPs that updated our view of the validator set
+	numUsefulIPs := 0
 
 	// Atomically modify peer data
 	n.peersLock.Lock()
@@ -541,6 +568,7 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 			// this IP to us. We should not gossip our IP to them.
 			newestTimestamp[ip.TxID] = ip.Timestamp
 			txIDsWithUpToDateIP = append(txIDsWithUpToDateIP, ip.TxID)
+			numUsefulIPs++
 
 			// In the future, we should gossip this IP rather than the old IP.
 			n.peerIPs[nodeID] = ip
@@ -571,6 +599,7 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 			// This is the first we've heard of this IP and we want to connect
 			// to it. We should tell the peer not to gossip this IP to us again.
 			newestTimestamp[ip.TxID] = ip.Timestamp
+			numUsefulIPs++
 			// We should not gossip this IP back to them.
 			txIDsWithUpToDateIP = append(txIDsWithUpToDateIP, ip.TxID)
 
@@ -587,6 +616,8 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 		}
 	}
 
+	_ = n.gossipTracker.AddGossip(peerID, numUsefulIPs, ipLen)
+
 	txIDsToAck := maps.Keys(newestTimestamp)
 	txIDsToAck, ok := n.gossipTracker.AddKnown(peerID, txIDsWithUpToDateIP, txIDsToAck)
 	if !ok {
@@ -648,6 +679,30 @@ func (n *network) MarkTracked(peerID ids.NodeID, ips []*p2p.PeerAck) error {
 	return nil
 }
 
+func (n *network) KnownPeers() ([]byte, []byte, error) {
+	n.peersLock.RLock()
+	knownValidators := set.NewSet[ids.NodeID](len(n.peerIPs))
+	for nodeID := range n.peerIPs {
+		knownValidators.Add(nodeID)
+	}
+	n.peersLock.RUnlock()
+
+	return n.gossipTracker.BuildFilter(knownValidators)
+}
+
+func (n *network) MarkKnownPeers(peerID ids.NodeID, filter []byte, salt []byte) error {
+	ok, err := n.gossipTracker.ApplyFilter(peerID, filter, salt)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		n.peerConfig.Log.Error("failed to update known peers",
+			zap.Stringer("nodeID", peerID),
+		)
+	}
+	return nil
+}
+
 // Disconnected is called after the peer's handling has been shutdown.
 // It is not guaranteed that [Connected] was previously called with [nodeID].
 // It is guaranteed that [Connected] will not be called with [nodeID] after this
@@ -660,6 +715,7 @@ func (n *network) Disconnected(nodeID ids.NodeID) {
 			zap.Stringer("nodeID", nodeID),
 		)
 	}
+	n.peerConfig.ReputationTracker.RemovePeer(nodeID)
 
 	n.peersLock.RLock()
 	_, connecting := n.connectingPeers.GetByID(nodeID)
@@ -675,8 +731,17 @@ func (n *network) Disconnected(nodeID ids.NodeID) {
 }
 
 func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
+	// If enabled, order the validators by stake so that the validators that
+	// matter most for consensus are gossiped first.
+	var weight func(ids.NodeID) uint64
+	if n.config.PeerListPrioritizeByStake {
+		weight = func(nodeID ids.NodeID) uint64 {
+			return n.config.Validators.GetWeight(constants.PrimaryNetworkID, nodeID)
+		}
+	}
+
 	// Only select validators that we haven't already sent to this peer
-	unknownValidators, ok := n.gossipTracker.GetUnknown(peerID)
+	unknownValidators, ok := n.gossipTracker.GetUnknown(peerID, weight)
 	if !ok {
 		n.peerConfig.Log.Debug(
 			"unable to find peer to gossip to",
@@ -685,17 +750,26 @@ func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
 		return nil, nil
 	}
 
-	// We select a random sample of validators to gossip to avoid starving out a
-	// validator from being gossiped for an extended period of time.
-	s := sampler.NewUniform()
-	s.Initialize(uint64(len(unknownValidators)))
+	// Otherwise, we select a random sample of validators to gossip to avoid
+	// starving out a validator from being gossiped for an extended period of
+	// time.
+	var s sampler.Uniform
+	if weight == nil {
+		s = sampler.NewUniform()
+		s.Initialize(uint64(len(unknownValidators)))
+	}
 
 	// Calculate the unknown information we need to send to this peer.
-	validatorIPs := make([]ips.ClaimedIPPort, 0, int(n.config.PeerListNumValidatorIPs))
-	for i := 0; i < len(unknownValidators) && len(validatorIPs) < int(n.config.PeerListNumValidatorIPs); i++ {
-		drawn, err := s.Next()
-		if err != nil {
-			return nil, err
+	numValidatorIPs := n.numValidatorIPsToGossip(peerID)
+	validatorIPs := make([]ips.ClaimedIPPort, 0, numValidatorIPs)
+	for i := 0; i < len(unknownValidators) && len(validatorIPs) < numValidatorIPs; i++ {
+		drawn := uint64(i)
+		if s != nil {
+			var err error
+			drawn, err = s.Next()
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		validator := unknownValidators[drawn]
@@ -727,6 +801,32 @@ func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
 	return validatorIPs, nil
 }
 
+// numValidatorIPsToGossip returns the number of validator IPs to gossip to
+// [peerID].
+//
+// Validators that have been up for at least [PeerListUsefulnessGracePeriod],
+// as reported by the uptime calculator, without ever gossiping a useful
+// validator IP to us are gossiped fewer validator IPs. Non-validators don't
+// have a tracked uptime, so they are never throttled.
+func (n *network) numValidatorIPsToGossip(peerID ids.NodeID) int {
+	if n.config.PeerListUsefulnessGracePeriod == 0 {
+		return int(n.config.PeerListNumValidatorIPs)
+	}
+
+	numUseful, _, _ := n.gossipTracker.GossipUsefulness(peerID)
+	if numUseful > 0 {
+		return int(n.config.PeerListNumValidatorIPs)
+	}
+
+	uptime, _, err := n.config.UptimeCalculator.CalculateUptime(peerID, constants.PrimaryNetworkID)
+	if err != nil || uptime < n.config.PeerListUsefulnessGracePeriod {
+		return int(n.config.PeerListNumValidatorIPs)
+	}
+
+	n.metrics.numThrottledPeerLists.Inc()
+	return int(n.config.PeerListUselessNumValidatorIPs)
+}
+
 // Dispatch starts accepting connections from other nodes attempting to connect
 // to this node.
 func (n *network) Dispatch() error {
@@ -1299,10 +1399,19 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	n.peersLock.RLock()
 	defer n.peersLock.RUnlock()
 
+	var infos []peer.Info
 	if len(nodeIDs) == 0 {
-		return n.connectedPeers.AllInfo()
+		infos = n.connectedPeers.AllInfo()
+	} else {
+		infos = n.connectedPeers.Info(nodeIDs)
+	}
+	for i := range infos {
+		info := &infos[i]
+		numUseful, numGossiped, _ := n.gossipTracker.GossipUsefulness(info.ID)
+		info.NumUsefulGossipedIPs = json.Uint64(numUseful)
+		info.NumGossipedIPs = json.Uint64(numGossiped)
 	}
-	return n.connectedPeers.Info(nodeIDs)
+	return infos
 }
 
 func (n *network) StartClose() {
@@ -1394,6 +1503,53 @@ func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
 	}, nil
 }
 
+func (n *network) UpgradeReadiness(subnetID ids.ID, minVersion *version.Application) (UpgradeReadinessResult, error) {
+	if subnetID != constants.PrimaryNetworkID && !n.config.TrackedSubnets.Contains(subnetID) {
+		return UpgradeReadinessResult{}, errNotTracked
+	}
+
+	totalWeightInt, err := n.config.Validators.TotalWeight(subnetID)
+	if err != nil {
+		return UpgradeReadinessResult{}, fmt.Errorf("error while fetching weight for subnet %s: %w", subnetID, err)
+	}
+
+	result := UpgradeReadinessResult{
+		VersionStakePercentages: make(map[string]float64),
+	}
+	if totalWeightInt == 0 {
+		return result, nil
+	}
+
+	totalWeight := float64(totalWeightInt)
+	addValidator := func(weight uint64, peerVersion *version.Application) {
+		percent := 100 * float64(weight) / totalWeight
+		result.ConnectedStakePercentage += percent
+		result.VersionStakePercentages[peerVersion.String()] += percent
+		if peerVersion.Compare(minVersion) >= 0 {
+			result.ReadyStakePercentage += percent
+		}
+	}
+
+	if myStake := n.config.Validators.GetWeight(subnetID, n.config.MyNodeID); myStake != 0 {
+		addValidator(myStake, n.peerConfig.VersionCompatibility.Version())
+	}
+
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	for i := 0; i < n.connectedPeers.Len(); i++ {
+		peer, _ := n.connectedPeers.GetByIndex(i)
+
+		weight := n.config.Validators.GetWeight(subnetID, peer.ID())
+		if weight == 0 {
+			// this is not a validator skip it.
+			continue
+		}
+		addValidator(weight, peer.Version())
+	}
+	return result, nil
+}
+
 func (n *network) runTimers() {
 	gossipPeerlists := time.NewTicker(n.config.PeerListGossipFreq)
 	updateUptimes := time.NewTicker(n.config.UptimeMetricFreq)
@@ -215,7 +215,7 @@ func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler
 		msgCreator := newMessageCreator(t)
 		registry := prometheus.NewRegistry()
 
-		g, err := peer.NewGossipTracker(registry, "foobar")
+		g, err := peer.NewGossipTracker(registry, "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 		require.NoError(err)
 
 		log := logging.NoLog{}
@@ -310,6 +310,38 @@ func TestNewNetwork(t *testing.T) {
 	wg.Wait()
 }
 
+func TestUpgradeReadiness(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil, nil})
+	net0 := networks[0]
+
+	currentVersion := net0.peerConfig.VersionCompatibility.Version()
+	result, err := net0.UpgradeReadiness(constants.PrimaryNetworkID, currentVersion)
+	require.NoError(err)
+	require.InDelta(100, result.ReadyStakePercentage, 0.001)
+	require.InDelta(100, result.ConnectedStakePercentage, 0.001)
+	require.Len(result.VersionStakePercentages, 1)
+	require.InDelta(100, result.VersionStakePercentages[currentVersion.String()], 0.001)
+
+	nextVersion := &version.Application{
+		Major: currentVersion.Major,
+		Minor: currentVersion.Minor + 1,
+	}
+	result, err = net0.UpgradeReadiness(constants.PrimaryNetworkID, nextVersion)
+	require.NoError(err)
+	require.Zero(result.ReadyStakePercentage)
+	require.InDelta(100, result.ConnectedStakePercentage, 0.001)
+
+	_, err = net0.UpgradeReadiness(ids.GenerateTestID(), currentVersion)
+	require.ErrorIs(err, errNotTracked)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
 func TestSend(t *testing.T) {
 	require := require.New(t)
 
@@ -437,7 +469,7 @@ func TestTrackDoesNotDialPrivateIPs(t *testing.T) {
 		msgCreator := newMessageCreator(t)
 		registry := prometheus.NewRegistry()
 
-		g, err := peer.NewGossipTracker(registry, "foobar")
+		g, err := peer.NewGossipTracker(registry, "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 		require.NoError(err)
 
 		log := logging.NoLog{}
@@ -532,7 +564,7 @@ func TestDialDeletesNonValidators(t *testing.T) {
 		msgCreator := newMessageCreator(t)
 		registry := prometheus.NewRegistry()
 
-		g, err := peer.NewGossipTracker(registry, "foobar")
+		g, err := peer.NewGossipTracker(registry, "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 		require.NoError(err)
 
 		log := logging.NoLog{}
@@ -694,7 +726,7 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 		msgCreator := newMessageCreator(t)
 		registry := prometheus.NewRegistry()
 
-		g, err := peer.NewGossipTracker(registry, "foobar")
+		g, err := peer.NewGossipTracker(registry, "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 		require.NoError(err)
 
 		log := logging.NoLog{}
@@ -768,3 +800,66 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestNumValidatorIPsToGossip(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		numValidatorIPs        = 10
+		uselessNumValidatorIPs = 2
+		gracePeriod            = time.Hour
+	)
+
+	startTime := time.Now().Truncate(time.Second)
+	validatorID := ids.GenerateTestNodeID()
+	nonValidatorID := ids.GenerateTestNodeID()
+
+	uptimeState := uptime.NewTestState()
+	uptimeState.AddNode(validatorID, constants.PrimaryNetworkID, startTime)
+
+	clk := mockable.Clock{}
+	clk.Set(startTime)
+
+	gossipTracker, err := peer.NewGossipTracker(prometheus.NewRegistry(), "", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+	require.True(gossipTracker.StartTrackingPeer(validatorID))
+	require.True(gossipTracker.StartTrackingPeer(nonValidatorID))
+
+	metrics, err := newMetrics("", prometheus.NewRegistry(), nil)
+	require.NoError(err)
+
+	n := &network{
+		config: &Config{
+			PeerListGossipConfig: PeerListGossipConfig{
+				PeerListNumValidatorIPs:        numValidatorIPs,
+				PeerListUselessNumValidatorIPs: uselessNumValidatorIPs,
+				PeerListUsefulnessGracePeriod:  gracePeriod,
+			},
+			UptimeCalculator: uptime.NewManager(uptimeState, &clk),
+		},
+		gossipTracker: gossipTracker,
+		metrics:       metrics,
+	}
+
+	// The validator is still within its grace period.
+	require.Equal(numValidatorIPs, n.numValidatorIPsToGossip(validatorID))
+
+	// The validator has been up for the grace period without gossiping
+	// anything useful.
+	clk.Set(startTime.Add(gracePeriod))
+	require.Equal(uselessNumValidatorIPs, n.numValidatorIPsToGossip(validatorID))
+
+	// Non-validators don't have a tracked uptime.
+	require.Equal(numValidatorIPs, n.numValidatorIPsToGossip(nonValidatorID))
+
+	// Gossiping a useful validator IP restores the full quota.
+	require.True(gossipTracker.AddGossip(validatorID, 1, 1))
+	require.Equal(numValidatorIPs, n.numValidatorIPsToGossip(validatorID))
+
+	// Throttling is disabled without a grace period.
+	require.True(gossipTracker.StopTrackingPeer(validatorID))
+	require.True(gossipTracker.StartTrackingPeer(validatorID))
+	require.Equal(uselessNumValidatorIPs, n.numValidatorIPsToGossip(validatorID))
+	n.config.PeerListUsefulnessGracePeriod = 0
+	require.Equal(numValidatorIPs, n.numValidatorIPsToGossip(validatorID))
+}
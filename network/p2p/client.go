@@ -75,6 +75,7 @@ func (c *Client) AppRequest(
 	c.router.lock.Lock()
 	defer c.router.lock.Unlock()
 
+	onResponse = c.validateResponses(appRequestBytes, onResponse)
 	appRequestBytes = c.prefixMessage(appRequestBytes)
 	for nodeID := range nodeIDs {
 		requestID := c.router.requestID
@@ -167,6 +168,44 @@ func (c *Client) CrossChainAppRequest(
 	return nil
 }
 
+// validateResponses wraps [onResponse] so that responses to [request] are
+// validated by the configured response validators before [onResponse] is
+// invoked.
+func (c *Client) validateResponses(request []byte, onResponse AppResponseCallback) AppResponseCallback {
+	if len(c.options.responseValidators) == 0 {
+		return onResponse
+	}
+
+	return func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		if err == nil {
+			err = c.validateResponse(ctx, nodeID, request, responseBytes)
+			if err != nil {
+				responseBytes = nil
+			}
+		}
+		onResponse(ctx, nodeID, responseBytes, err)
+	}
+}
+
+// validateResponse returns an error if any configured response validator
+// rejects [response]. The peer that sent an invalid response is penalized.
+func (c *Client) validateResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	request []byte,
+	response []byte,
+) error {
+	for _, validator := range c.options.responseValidators {
+		if err := validator.ValidateResponse(ctx, nodeID, request, response); err != nil {
+			if c.options.responsePeers != nil {
+				c.options.responsePeers.RegisterInvalidResponse(nodeID)
+			}
+			return fmt.Errorf("%w from %s: %w", ErrInvalidResponse, nodeID, err)
+		}
+	}
+	return nil
+}
+
 // prefixMessage prefixes the original message with the handler identifier
 // corresponding to this client.
 //
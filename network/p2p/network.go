@@ -43,10 +43,64 @@ func WithValidatorSampling(validators *Validators) ClientOption {
 	})
 }
 
+// WithResponseValidation configures Client to validate responses to
+// AppRequests with [validators] before they are handed to the
+// AppResponseCallback. If a response is invalid, the callback is invoked with
+// ErrInvalidResponse and, if [peers] is non-nil, the peer that sent it is
+// penalized.
+func WithResponseValidation(peers *PeerTracker, validators ...ResponseValidator) ClientOption {
+	return clientOptionFunc(func(options *clientOptions) {
+		options.responsePeers = peers
+		options.responseValidators = append(options.responseValidators, validators...)
+	})
+}
+
+// WithPriority tags the messages handled by the protocol with [priority]. If
+// the Network has a Prioritizer, these messages are shed once it is over the
+// queue depth of [priority]. By default, messages are tagged with
+// ConsensusPriority.
+func WithPriority(priority Priority) ClientOption {
+	return clientOptionFunc(func(options *clientOptions) {
+		options.priority = priority
+	})
+}
+
 // clientOptions holds client-configurable values
 type clientOptions struct {
 	// nodeSampler is used to select nodes to route Client.AppRequestAny to
 	nodeSampler NodeSampler
+	// responseValidators validate responses to Client.AppRequest
+	responseValidators []ResponseValidator
+	// responsePeers, if non-nil, is notified of invalid responses
+	responsePeers *PeerTracker
+	// priority is the class of the messages handled by the protocol
+	priority Priority
+}
+
+// NetworkOption configures Network
+type NetworkOption interface {
+	apply(options *networkOptions)
+}
+
+type networkOptionFunc func(options *networkOptions)
+
+func (o networkOptionFunc) apply(options *networkOptions) {
+	o(options)
+}
+
+// WithPrioritizer configures Network to admit or shed the messages of every
+// protocol with [prioritizer], according to the priority the protocol was
+// registered with.
+func WithPrioritizer(prioritizer *Prioritizer) NetworkOption {
+	return networkOptionFunc(func(options *networkOptions) {
+		options.prioritizer = prioritizer
+	})
+}
+
+// networkOptions holds network-configurable values
+type networkOptions struct {
+	// prioritizer, if non-nil, sheds messages under load
+	prioritizer *Prioritizer
 }
 
 // NewNetwork returns an instance of Network
@@ -55,14 +109,21 @@ func NewNetwork(
 	sender common.AppSender,
 	metrics prometheus.Registerer,
 	namespace string,
+	options ...NetworkOption,
 ) *Network {
+	networkOptions := &networkOptions{}
+	for _, option := range options {
+		option.apply(networkOptions)
+	}
+
 	return &Network{
-		Peers:     &Peers{},
-		log:       log,
-		sender:    sender,
-		metrics:   metrics,
-		namespace: namespace,
-		router:    newRouter(log, sender, metrics, namespace),
+		Peers:       &Peers{},
+		log:         log,
+		sender:      sender,
+		metrics:     metrics,
+		namespace:   namespace,
+		prioritizer: networkOptions.prioritizer,
+		router:      newRouter(log, sender, metrics, namespace),
 	}
 }
 
@@ -76,6 +137,8 @@ type Network struct {
 	metrics   prometheus.Registerer
 	namespace string
 
+	prioritizer *Prioritizer
+
 	router *router
 }
 
@@ -121,27 +184,35 @@ func (n *Network) Disconnected(_ context.Context, nodeID ids.NodeID) error {
 // returns a Client that can be used to send messages for the corresponding
 // protocol.
 func (n *Network) NewAppProtocol(handlerID uint64, handler Handler, options ...ClientOption) (*Client, error) {
+	clientOptions := &clientOptions{
+		nodeSampler: &peerSampler{
+			peers: n.Peers,
+		},
+		priority: ConsensusPriority,
+	}
+	for _, option := range options {
+		option.apply(clientOptions)
+	}
+
+	if n.prioritizer != nil {
+		handler = PriorityHandler{
+			Handler:     handler,
+			Priority:    clientOptions.priority,
+			Prioritizer: n.prioritizer,
+			Log:         n.log,
+		}
+	}
 	if err := n.router.addHandler(handlerID, handler); err != nil {
 		return nil, err
 	}
 
-	client := &Client{
+	return &Client{
 		handlerID:     handlerID,
 		handlerPrefix: binary.AppendUvarint(nil, handlerID),
 		sender:        n.sender,
 		router:        n.router,
-		options: &clientOptions{
-			nodeSampler: &peerSampler{
-				peers: n.Peers,
-			},
-		},
-	}
-
-	for _, option := range options {
-		option.apply(client.options)
-	}
-
-	return client, nil
+		options:       clientOptions,
+	}, nil
 }
 
 // Peers contains metadata about the current set of connected peers
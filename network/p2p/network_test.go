@@ -312,6 +312,70 @@ func TestNetworkDropMessage(t *testing.T) {
 	}
 }
 
+// Messages of a protocol should be shed once the Network's Prioritizer is over
+// the queue depth of the priority the protocol was registered with.
+func TestNetworkPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []ClientOption
+		expected bool
+	}{
+		{
+			name:     "default priority",
+			expected: true,
+		},
+		{
+			name:     "consensus priority",
+			options:  []ClientOption{WithPriority(ConsensusPriority)},
+			expected: true,
+		},
+		{
+			name:    "sync priority",
+			options: []ClientOption{WithPriority(SyncPriority)},
+		},
+		{
+			name:    "gossip priority",
+			options: []ClientOption{WithPriority(GossipPriority)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			prioritizer, err := NewPrioritizer(
+				NewPriorityConfig(2),
+				"",
+				prometheus.NewRegistry(),
+			)
+			require.NoError(err)
+
+			network := NewNetwork(
+				logging.NoLog{},
+				&common.SenderTest{},
+				prometheus.NewRegistry(),
+				"",
+				WithPrioritizer(prioritizer),
+			)
+
+			handled := false
+			handler := testHandler{
+				appGossipF: func(context.Context, ids.NodeID, []byte) {
+					handled = true
+				},
+			}
+			_, err = network.NewAppProtocol(0x1, handler, tt.options...)
+			require.NoError(err)
+
+			// Occupy one of the two handlers
+			require.True(prioritizer.Acquire(ConsensusPriority))
+
+			require.NoError(network.AppGossip(context.Background(), ids.GenerateTestNodeID(), []byte{0x1}))
+			require.Equal(tt.expected, handled)
+		})
+	}
+}
+
 // It's possible for the request id to overflow and wrap around.
 // If there are still pending requests with the same request id, we should
 // not attempt to issue another request until the previous one has cleared.
@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	ErrPeerBusy                   = errors.New("peer has too many requests in flight")
+	ErrInvalidMaxInFlightRequests = errors.New("invalid max in-flight requests")
+
+	DefaultOutboundLimiterConfig = OutboundLimiterConfig{
+		MaxInFlightRequests: 4,
+	}
+)
+
+// OutboundLimiterConfig configures how many requests an OutboundLimiter allows
+// to be in flight to a single peer.
+type OutboundLimiterConfig struct {
+	// MaxInFlightRequests is the maximum number of requests sent to a peer
+	// that may be awaiting a response at the same time.
+	MaxInFlightRequests int `json:"maxInFlightRequests"`
+}
+
+func (c OutboundLimiterConfig) Verify() error {
+	if c.MaxInFlightRequests <= 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidMaxInFlightRequests, c.MaxInFlightRequests)
+	}
+	return nil
+}
+
+// OutboundLimiter limits the number of requests in flight to each peer of a
+// PeerTracker.
+//
+// Each chain tracks its peers with its own PeerTracker, so an OutboundLimiter
+// prevents a single chain from monopolizing a peer without limiting the
+// requests other chains send to it.
+type OutboundLimiter struct {
+	config OutboundLimiterConfig
+	peers  *PeerTracker
+
+	lock sync.Mutex
+	// Number of requests in flight to each peer that has any
+	inFlight map[ids.NodeID]int
+
+	numInFlightRequests prometheus.Gauge
+	numBusyPeers        prometheus.Counter
+}
+
+func NewOutboundLimiter(
+	config OutboundLimiterConfig,
+	peers *PeerTracker,
+	metricsNamespace string,
+	registerer prometheus.Registerer,
+) (*OutboundLimiter, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	l := &OutboundLimiter{
+		config:   config,
+		peers:    peers,
+		inFlight: make(map[ids.NodeID]int),
+		numInFlightRequests: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "num_in_flight_requests",
+				Help:      "number of requests sent to peers that are awaiting a response",
+			},
+		),
+		numBusyPeers: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "num_busy_peers",
+				Help:      "number of times a request wasn't sent to a peer because it had too many requests in flight",
+			},
+		),
+	}
+
+	err := utils.Err(
+		registerer.Register(l.numInFlightRequests),
+		registerer.Register(l.numBusyPeers),
+	)
+	return l, err
+}
+
+// Acquire reserves an in-flight request to [nodeID]. Returns [ErrPeerBusy] if
+// [nodeID] already has [MaxInFlightRequests] requests in flight. If Acquire
+// succeeds, Release must be called once the request completes.
+func (l *OutboundLimiter) Acquire(nodeID ids.NodeID) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	inFlight := l.inFlight[nodeID]
+	if inFlight >= l.config.MaxInFlightRequests {
+		l.numBusyPeers.Inc()
+		return fmt.Errorf("%w: %s has %d", ErrPeerBusy, nodeID, inFlight)
+	}
+
+	l.inFlight[nodeID] = inFlight + 1
+	l.numInFlightRequests.Inc()
+	return nil
+}
+
+// Release marks a request to [nodeID] reserved by Acquire as completed.
+func (l *OutboundLimiter) Release(nodeID ids.NodeID) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	inFlight, ok := l.inFlight[nodeID]
+	if !ok {
+		return
+	}
+	if inFlight <= 1 {
+		delete(l.inFlight, nodeID)
+	} else {
+		l.inFlight[nodeID] = inFlight - 1
+	}
+	l.numInFlightRequests.Dec()
+}
+
+// AcquireAnyPeer selects a peer from the PeerTracker, as GetAnyPeer does, and
+// reserves an in-flight request to it. Busy peers are skipped in favor of
+// another peer.
+//
+// Returns [ErrNoPeers] if no peer could be selected and [ErrPeerBusy] if every
+// selected peer was busy.
+func (l *OutboundLimiter) AcquireAnyPeer(minVersion *version.Application, opts ...PeerOption) (ids.NodeID, error) {
+	// The PeerTracker may select the same peer more than once, so as many
+	// peers are selected as there are connected peers.
+	numAttempts := l.peers.Size()
+	if numAttempts == 0 {
+		numAttempts = 1
+	}
+
+	var lastErr error
+	for i := 0; i < numAttempts; i++ {
+		nodeID, ok := l.peers.GetAnyPeer(minVersion, opts...)
+		if !ok {
+			break
+		}
+		if lastErr = l.Acquire(nodeID); lastErr == nil {
+			return nodeID, nil
+		}
+	}
+	if lastErr != nil {
+		return ids.EmptyNodeID, lastErr
+	}
+	return ids.EmptyNodeID, fmt.Errorf(
+		"%w: found none matching version %s out of %d peers",
+		ErrNoPeers,
+		minVersion,
+		l.peers.Size(),
+	)
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func newTestOutboundLimiter(t *testing.T, maxInFlightRequests int, peers ...ids.NodeID) *OutboundLimiter {
+	require := require.New(t)
+
+	peerTracker, err := NewPeerTracker(DefaultPeerTrackerConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	for _, nodeID := range peers {
+		peerTracker.Connected(nodeID, &version.Application{})
+	}
+
+	limiter, err := NewOutboundLimiter(
+		OutboundLimiterConfig{
+			MaxInFlightRequests: maxInFlightRequests,
+		},
+		peerTracker,
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	return limiter
+}
+
+func TestOutboundLimiterConfigVerify(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(DefaultOutboundLimiterConfig.Verify())
+
+	err := OutboundLimiterConfig{}.Verify()
+	require.ErrorIs(err, ErrInvalidMaxInFlightRequests)
+}
+
+func TestOutboundLimiterAcquire(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+		limiter = newTestOutboundLimiter(t, 2)
+	)
+
+	require.NoError(limiter.Acquire(nodeID0))
+	require.NoError(limiter.Acquire(nodeID0))
+	err := limiter.Acquire(nodeID0)
+	require.ErrorIs(err, ErrPeerBusy)
+
+	// Requests to other peers aren't limited by [nodeID0]
+	require.NoError(limiter.Acquire(nodeID1))
+
+	limiter.Release(nodeID0)
+	require.NoError(limiter.Acquire(nodeID0))
+
+	limiter.Release(nodeID0)
+	limiter.Release(nodeID0)
+	limiter.Release(nodeID1)
+	require.Empty(limiter.inFlight)
+
+	// Releasing a peer without requests in flight is a no-op
+	limiter.Release(nodeID0)
+	require.Empty(limiter.inFlight)
+}
+
+func TestOutboundLimiterAcquireAnyPeer(t *testing.T) {
+	require := require.New(t)
+
+	// No peers can be selected
+	limiter := newTestOutboundLimiter(t, 1)
+	_, err := limiter.AcquireAnyPeer(nil)
+	require.ErrorIs(err, ErrNoPeers)
+
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+	)
+	limiter = newTestOutboundLimiter(t, 1, nodeID0, nodeID1)
+
+	// Busy peers are skipped. Peers with requests in flight have been
+	// tracked, so they aren't selected as new peers.
+	require.NoError(limiter.Acquire(nodeID0))
+	limiter.peers.TrackPeer(nodeID0)
+	nodeID, err := limiter.AcquireAnyPeer(nil)
+	require.NoError(err)
+	require.Equal(nodeID1, nodeID)
+
+	// Every peer is busy
+	limiter.peers.TrackPeer(nodeID1)
+	_, err = limiter.AcquireAnyPeer(nil)
+	require.ErrorIs(err, ErrPeerBusy)
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"fmt"
+	"net"
+)
+
+const (
+	// Peers with IPv4 addresses in the same /24 are considered to be in the
+	// same network.
+	ipv4NetworkPrefixBits = 24
+	// Peers with IPv6 addresses in the same /48 are considered to be in the
+	// same network.
+	ipv6NetworkPrefixBits = 48
+)
+
+// PeerNetworkInfo describes where a peer is located on the internet
+type PeerNetworkInfo struct {
+	// IP is the IP address of the peer
+	IP net.IP
+	// ASN is the autonomous system number of the peer's IP, or 0 if unknown
+	ASN uint32
+}
+
+// networkGroup returns the group of peers that are likely to be hosted by the
+// same operator as a peer with [info]. Peers in the same autonomous system are
+// in the same group. If the autonomous system is unknown, peers in the same IP
+// subnet are in the same group. If neither is known, the empty string is
+// returned.
+func (info PeerNetworkInfo) networkGroup() string {
+	if info.ASN != 0 {
+		return fmt.Sprintf("asn:%d", info.ASN)
+	}
+	if ipv4 := info.IP.To4(); ipv4 != nil {
+		mask := net.CIDRMask(ipv4NetworkPrefixBits, 8*net.IPv4len)
+		return fmt.Sprintf("ipv4:%s", ipv4.Mask(mask))
+	}
+	if ipv6 := info.IP.To16(); ipv6 != nil {
+		mask := net.CIDRMask(ipv6NetworkPrefixBits, 8*net.IPv6len)
+		return fmt.Sprintf("ipv6:%s", ipv6.Mask(mask))
+	}
+	return ""
+}
+
+// PeerOption configures how PeerTracker.GetAnyPeer selects a peer
+type PeerOption interface {
+	apply(options *peerOptions)
+}
+
+type peerOptionFunc func(options *peerOptions)
+
+func (o peerOptionFunc) apply(options *peerOptions) {
+	o(options)
+}
+
+// WithNetworkDiversity configures PeerTracker.GetAnyPeer to spread the selected
+// peers across network groups, so that requests don't all depend on peers
+// hosted by the same operator. Peers without network info are treated as if
+// they were each in their own network group.
+func WithNetworkDiversity() PeerOption {
+	return peerOptionFunc(func(options *peerOptions) {
+		options.diverse = true
+	})
+}
+
+// peerOptions holds the values configured by PeerOptions
+type peerOptions struct {
+	// diverse prefers peers from network groups that are less represented
+	diverse bool
+}
+
+func newPeerOptions(opts []PeerOption) *peerOptions {
+	options := &peerOptions{}
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+	return options
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerNetworkInfoNetworkGroup(t *testing.T) {
+	tests := []struct {
+		name          string
+		info          PeerNetworkInfo
+		expectedGroup string
+	}{
+		{
+			name:          "unknown",
+			info:          PeerNetworkInfo{},
+			expectedGroup: "",
+		},
+		{
+			name: "asn",
+			info: PeerNetworkInfo{
+				IP:  net.IPv4(1, 2, 3, 4),
+				ASN: 16509,
+			},
+			expectedGroup: "asn:16509",
+		},
+		{
+			name: "ipv4",
+			info: PeerNetworkInfo{
+				IP: net.IPv4(1, 2, 3, 4),
+			},
+			expectedGroup: "ipv4:1.2.3.0",
+		},
+		{
+			name: "ipv6",
+			info: PeerNetworkInfo{
+				IP: net.ParseIP("2001:db8:1234:5678::1"),
+			},
+			expectedGroup: "ipv6:2001:db8:1234::",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedGroup, test.info.networkGroup())
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package p2p
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"math/rand"
 	"sync"
@@ -15,69 +17,186 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
-	"github.com/ava-labs/avalanchego/utils/heap"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
-const (
-	bandwidthHalflife = 5 * time.Minute
+var (
+	ErrInvalidDesiredMinResponsivePeers = errors.New("invalid desired min responsive peers")
+	ErrInvalidNewPeerConnectFactor      = errors.New("invalid new peer connect factor")
+	ErrInvalidRandomPeerProbability     = errors.New("invalid random peer probability")
+	ErrInvalidBandwidthHalflife         = errors.New("invalid bandwidth halflife")
+	ErrInvalidDisconnectBackoff         = errors.New("invalid disconnect backoff")
+	ErrInvalidMaxDisconnectBackoff      = errors.New("invalid max disconnect backoff")
+	ErrInvalidSamplingTemperature       = errors.New("invalid sampling temperature")
+	ErrInvalidMaxConsecutiveTimeouts    = errors.New("invalid max consecutive timeouts")
 
-	// controls how eagerly we connect to new peers vs. using
-	// peers with known good response bandwidth.
-	desiredMinResponsivePeers = 20
-	newPeerConnectFactor      = 0.1
-
-	// The probability that, when we select a peer, we select randomly rather
-	// than based on their performance.
-	randomPeerProbability = 0.2
+	DefaultPeerTrackerConfig = PeerTrackerConfig{
+		DesiredMinResponsivePeers: 20,
+		NewPeerConnectFactor:      0.1,
+		RandomPeerProbability:     0.2,
+		BandwidthHalflife:         5 * time.Minute,
+		DisconnectBackoff:         time.Second,
+		MaxDisconnectBackoff:      time.Minute,
+		SamplingTemperature:       1,
+		MaxConsecutiveTimeouts:    3,
+	}
 )
 
+// PeerTrackerConfig configures how a PeerTracker trades off exploring new
+// peers against exploiting peers with known good response bandwidth.
+type PeerTrackerConfig struct {
+	// DesiredMinResponsivePeers is the number of responsive peers below which
+	// a new peer is always tracked.
+	DesiredMinResponsivePeers int `json:"desiredMinResponsivePeers"`
+	// NewPeerConnectFactor controls how quickly the probability of tracking a
+	// new peer decays once there are [DesiredMinResponsivePeers] responsive
+	// peers. Larger values make tracking new peers less likely.
+	NewPeerConnectFactor float64 `json:"newPeerConnectFactor"`
+	// RandomPeerProbability is the probability that, when we select a peer,
+	// we select randomly rather than based on their performance.
+	RandomPeerProbability float64 `json:"randomPeerProbability"`
+	// BandwidthHalflife is the halflife of the bandwidth averages of peers.
+	BandwidthHalflife time.Duration `json:"bandwidthHalflife"`
+	// DisconnectBackoff is how long a peer is not selected as a new peer
+	// after it disconnects. The backoff doubles every time the peer
+	// disconnects again, up to [MaxDisconnectBackoff]. If zero, peers are not
+	// backed off.
+	DisconnectBackoff time.Duration `json:"disconnectBackoff"`
+	// MaxDisconnectBackoff is the maximum backoff of a peer. A peer that stays
+	// connected for at least [MaxDisconnectBackoff] is considered stable, and
+	// its backoff is reset the next time it disconnects.
+	MaxDisconnectBackoff time.Duration `json:"maxDisconnectBackoff"`
+	// SamplingTemperature controls how strongly peers with higher bandwidth
+	// are preferred when selecting a peer based on performance. Peers are
+	// sampled with probability proportional to their bandwidth raised to the
+	// power of 1/[SamplingTemperature]. If zero, the peer with the highest
+	// bandwidth is always selected. As it grows, selection approaches uniform.
+	SamplingTemperature float64 `json:"samplingTemperature"`
+	// MaxConsecutiveTimeouts is the number of requests to a peer that must
+	// time out in a row for the peer to be considered unresponsive. Every
+	// timeout decays the peer's bandwidth average regardless.
+	MaxConsecutiveTimeouts int `json:"maxConsecutiveTimeouts"`
+}
+
+func (c PeerTrackerConfig) Verify() error {
+	switch {
+	case c.DesiredMinResponsivePeers < 0:
+		return fmt.Errorf("%w: %d", ErrInvalidDesiredMinResponsivePeers, c.DesiredMinResponsivePeers)
+	case c.NewPeerConnectFactor < 0 || math.IsNaN(c.NewPeerConnectFactor):
+		return fmt.Errorf("%w: %f", ErrInvalidNewPeerConnectFactor, c.NewPeerConnectFactor)
+	case c.RandomPeerProbability < 0 || c.RandomPeerProbability > 1 || math.IsNaN(c.RandomPeerProbability):
+		return fmt.Errorf("%w: %f", ErrInvalidRandomPeerProbability, c.RandomPeerProbability)
+	case c.BandwidthHalflife <= 0:
+		return fmt.Errorf("%w: %s", ErrInvalidBandwidthHalflife, c.BandwidthHalflife)
+	case c.DisconnectBackoff < 0:
+		return fmt.Errorf("%w: %s", ErrInvalidDisconnectBackoff, c.DisconnectBackoff)
+	case c.MaxDisconnectBackoff < c.DisconnectBackoff:
+		return fmt.Errorf("%w: %s < %s", ErrInvalidMaxDisconnectBackoff, c.MaxDisconnectBackoff, c.DisconnectBackoff)
+	case c.SamplingTemperature < 0 || math.IsNaN(c.SamplingTemperature):
+		return fmt.Errorf("%w: %f", ErrInvalidSamplingTemperature, c.SamplingTemperature)
+	case c.MaxConsecutiveTimeouts < 1:
+		return fmt.Errorf("%w: %d", ErrInvalidMaxConsecutiveTimeouts, c.MaxConsecutiveTimeouts)
+	default:
+		return nil
+	}
+}
+
+// PeerTrackerCallbackListener is notified of changes to the peers tracked by
+// a PeerTracker.
+//
+// Callbacks are called while holding the lock of the PeerTracker, so they must
+// not call back into the PeerTracker.
+type PeerTrackerCallbackListener interface {
+	// OnConnected is called when [nodeID] connects to this node.
+	OnConnected(nodeID ids.NodeID, nodeVersion *version.Application)
+	// OnDisconnected is called when [nodeID] disconnects from this node.
+	OnDisconnected(nodeID ids.NodeID)
+	// OnResponsivenessChanged is called when [nodeID] becomes responsive or
+	// unresponsive. A peer becomes unresponsive before it is disconnected.
+	OnResponsivenessChanged(nodeID ids.NodeID, responsive bool)
+}
+
 // information we track on a given peer
 type peerInfo struct {
-	version   *version.Application
-	bandwidth safemath.Averager
+	version     *version.Application
+	bandwidth   safemath.Averager
+	connectedAt time.Time
+	// network group of the peer, or the empty string if unknown
+	networkGroup string
+	// number of requests to the peer that timed out since it last responded
+	consecutiveTimeouts int
+}
+
+// information we track on a peer that recently disconnected, which is kept
+// across reconnects to detect flapping peers
+type disconnectInfo struct {
+	// number of disconnects since the peer was last considered stable
+	numDisconnects int
+	// the peer is not selected as a new peer until this time
+	backoffUntil time.Time
 }
 
 // Tracks the bandwidth of responses coming from peers,
 // preferring to contact peers with known good bandwidth, connecting
 // to new peers with an exponentially decaying probability.
 type PeerTracker struct {
+	config PeerTrackerConfig
 	// Lock to protect concurrent access to the peer tracker
 	lock sync.Mutex
 	// All peers we are connected to
 	peers map[ids.NodeID]*peerInfo
+	// Peers that disconnected while unstable
+	disconnects map[ids.NodeID]*disconnectInfo
 	// Peers that we're connected to that we've sent a request to
 	// since we most recently connected to them.
 	trackedPeers set.Set[ids.NodeID]
 	// Peers that we're connected to that responded to the last request they were sent.
 	responsivePeers set.Set[ids.NodeID]
-	// Max heap that contains the average bandwidth of peers.
-	bandwidthHeap          heap.Map[ids.NodeID, safemath.Averager]
+	// Peers with a tracked bandwidth that can be selected based on their
+	// bandwidth. A peer is removed once it is selected and is added back once
+	// its bandwidth is tracked again.
+	bandwidthPeers         set.Set[ids.NodeID]
 	averageBandwidth       safemath.Averager
 	log                    logging.Logger
 	numTrackedPeers        prometheus.Gauge
 	numResponsivePeers     prometheus.Gauge
 	averageBandwidthMetric prometheus.Gauge
+	numDisconnects         prometheus.Counter
+	numBackedOffPeers      prometheus.Counter
+	numTimeouts            prometheus.Counter
+	numInvalidResponses    prometheus.Counter
+	callbackListeners      []PeerTrackerCallbackListener
+	clock                  mockable.Clock
+	// Source of randomness used to select peers. Candidates are sampled in
+	// node ID order, so that the selected peers only depend on this source.
+	rand *rand.Rand
 }
 
 func NewPeerTracker(
+	config PeerTrackerConfig,
 	log logging.Logger,
 	metricsNamespace string,
 	registerer prometheus.Registerer,
 ) (*PeerTracker, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
 	t := &PeerTracker{
-		peers:           make(map[ids.NodeID]*peerInfo),
-		trackedPeers:    make(set.Set[ids.NodeID]),
-		responsivePeers: make(set.Set[ids.NodeID]),
-		bandwidthHeap: heap.NewMap[ids.NodeID, safemath.Averager](func(a, b safemath.Averager) bool {
-			return a.Read() > b.Read()
-		}),
-		averageBandwidth: safemath.NewAverager(0, bandwidthHalflife, time.Now()),
+		config:           config,
+		peers:            make(map[ids.NodeID]*peerInfo),
+		disconnects:      make(map[ids.NodeID]*disconnectInfo),
+		trackedPeers:     make(set.Set[ids.NodeID]),
+		responsivePeers:  make(set.Set[ids.NodeID]),
+		bandwidthPeers:   make(set.Set[ids.NodeID]),
+		averageBandwidth: safemath.NewAverager(0, config.BandwidthHalflife, time.Now()),
 		log:              log,
+		rand:             rand.New(rand.NewSource(time.Now().UnixNano())), // #nosec G404
 		numTrackedPeers: prometheus.NewGauge(
 			prometheus.GaugeOpts{
 				Namespace: metricsNamespace,
@@ -99,12 +218,44 @@ func NewPeerTracker(
 				Help:      "average sync bandwidth used by peers",
 			},
 		),
+		numDisconnects: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "num_disconnects",
+				Help:      "number of times a peer disconnected",
+			},
+		),
+		numBackedOffPeers: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "num_backed_off_peers",
+				Help:      "number of times a peer was skipped because it recently disconnected",
+			},
+		),
+		numTimeouts: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "num_timeouts",
+				Help:      "number of requests to peers that timed out",
+			},
+		),
+		numInvalidResponses: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "num_invalid_responses",
+				Help:      "number of responses from peers that failed validation",
+			},
+		),
 	}
 
 	err := utils.Err(
 		registerer.Register(t.numTrackedPeers),
 		registerer.Register(t.numResponsivePeers),
 		registerer.Register(t.averageBandwidthMetric),
+		registerer.Register(t.numDisconnects),
+		registerer.Register(t.numBackedOffPeers),
+		registerer.Register(t.numTimeouts),
+		registerer.Register(t.numInvalidResponses),
 	)
 	return t, err
 }
@@ -114,7 +265,7 @@ func NewPeerTracker(
 // Assumes p.lock is held.
 func (p *PeerTracker) shouldTrackNewPeer() bool {
 	numResponsivePeers := p.responsivePeers.Len()
-	if numResponsivePeers < desiredMinResponsivePeers {
+	if numResponsivePeers < p.config.DesiredMinResponsivePeers {
 		return true
 	}
 	if len(p.trackedPeers) >= len(p.peers) {
@@ -122,7 +273,7 @@ func (p *PeerTracker) shouldTrackNewPeer() bool {
 		return false
 	}
 	// TODO danlaine: we should consider tuning this probability function.
-	// With [newPeerConnectFactor] as 0.1 the probabilities are:
+	// With [NewPeerConnectFactor] as 0.1 the probabilities are:
 	//
 	// numResponsivePeers | probability
 	// 100                | 4.5399929762484854e-05
@@ -133,29 +284,27 @@ func (p *PeerTracker) shouldTrackNewPeer() bool {
 	// 5000               | 7.124576406741286e-218
 	//
 	// In other words, the probability drops off extremely quickly.
-	newPeerProbability := math.Exp(-float64(numResponsivePeers) * newPeerConnectFactor)
-	return rand.Float64() < newPeerProbability // #nosec G404
+	newPeerProbability := math.Exp(-float64(numResponsivePeers) * p.config.NewPeerConnectFactor)
+	return p.rand.Float64() < newPeerProbability
 }
 
 // TODO get rid of minVersion
 // Returns a peer that we're connected to.
 // If we should track more peers, returns a random peer with version >= [minVersion], if any exist.
-// Otherwise, with probability [randomPeerProbability] returns a random peer from [p.responsivePeers].
-// With probability [1-randomPeerProbability] returns the peer in [p.bandwidthHeap] with the highest bandwidth.
-func (p *PeerTracker) GetAnyPeer(minVersion *version.Application) (ids.NodeID, bool) {
+// Otherwise, with probability [RandomPeerProbability] returns a random peer from [p.responsivePeers].
+// With probability [1-RandomPeerProbability] samples a peer from [p.bandwidthPeers] weighted by bandwidth.
+//
+// If [WithNetworkDiversity] is provided, new peers are tracked from the network
+// groups with the fewest tracked peers, random peers are sampled uniformly by
+// network group, and the bandwidth weight of a network group is shared by its
+// peers.
+func (p *PeerTracker) GetAnyPeer(minVersion *version.Application, opts ...PeerOption) (ids.NodeID, bool) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
+	options := newPeerOptions(opts)
 	if p.shouldTrackNewPeer() {
-		for nodeID := range p.peers {
-			// if minVersion is specified and peer's version is less, skip
-			if minVersion != nil && p.peers[nodeID].version.Compare(minVersion) < 0 {
-				continue
-			}
-			// skip peers already tracked
-			if p.trackedPeers.Contains(nodeID) {
-				continue
-			}
+		if nodeID, ok := p.selectNewPeer(minVersion, options.diverse); ok {
 			p.log.Debug(
 				"tracking peer",
 				zap.Int("trackedPeers", len(p.trackedPeers)),
@@ -169,24 +318,202 @@ func (p *PeerTracker) GetAnyPeer(minVersion *version.Application) (ids.NodeID, b
 		nodeID ids.NodeID
 		ok     bool
 	)
-	useRand := rand.Float64() < randomPeerProbability // #nosec G404
+	useRand := p.rand.Float64() < p.config.RandomPeerProbability
 	if useRand {
-		nodeID, ok = p.responsivePeers.Peek()
+		nodeID, ok = p.sampleResponsivePeer(options.diverse)
 	} else {
-		nodeID, _, ok = p.bandwidthHeap.Pop()
+		nodeID, ok = p.sampleBandwidthPeer(options.diverse)
 	}
 	if !ok {
-		// if no nodes found in the bandwidth heap, return a tracked node at random
-		return p.trackedPeers.Peek()
+		// if no nodes have a tracked bandwidth, return a tracked node at random
+		return p.samplePeer(p.trackedPeers)
 	}
 	p.log.Debug(
-		"peer tracking: popping peer",
+		"peer tracking: selecting peer",
 		zap.Stringer("nodeID", nodeID),
 		zap.Bool("random", useRand),
 	)
 	return nodeID, true
 }
 
+// selectNewPeer returns a random connected peer with version >= [minVersion]
+// that isn't tracked and isn't backed off. If [diverse] is true, a peer from
+// the network group with the fewest tracked peers is returned.
+// Assumes p.lock is held.
+func (p *PeerTracker) selectNewPeer(minVersion *version.Application, diverse bool) (ids.NodeID, bool) {
+	var groupSizes map[string]int
+	if diverse {
+		groupSizes = p.networkGroupSizes(p.trackedPeers)
+	}
+
+	var (
+		now          = p.clock.Time()
+		candidates   []ids.NodeID
+		selectedSize = -1
+	)
+	for nodeID, peer := range p.peers {
+		// if minVersion is specified and peer's version is less, skip
+		if minVersion != nil && peer.version.Compare(minVersion) < 0 {
+			continue
+		}
+		// skip peers already tracked
+		if p.trackedPeers.Contains(nodeID) {
+			continue
+		}
+		// skip peers that are flapping
+		if info, ok := p.disconnects[nodeID]; ok && now.Before(info.backoffUntil) {
+			p.numBackedOffPeers.Inc()
+			continue
+		}
+
+		size := 0
+		if diverse {
+			size = groupSizes[p.networkGroup(nodeID)]
+		}
+		switch {
+		case selectedSize == -1 || size < selectedSize:
+			candidates = append(candidates[:0], nodeID)
+			selectedSize = size
+		case size == selectedSize:
+			candidates = append(candidates, nodeID)
+		}
+	}
+	utils.Sort(candidates)
+	return p.sample(candidates)
+}
+
+// sampleResponsivePeer returns a random peer from [p.responsivePeers]. If
+// [diverse] is true, a network group is sampled uniformly before sampling a
+// peer from the group.
+// Assumes p.lock is held.
+func (p *PeerTracker) sampleResponsivePeer(diverse bool) (ids.NodeID, bool) {
+	if !diverse {
+		return p.samplePeer(p.responsivePeers)
+	}
+
+	var (
+		groupNames []string
+		groups     = make(map[string][]ids.NodeID)
+	)
+	for _, nodeID := range sortedNodeIDs(p.responsivePeers) {
+		group := p.networkGroup(nodeID)
+		if _, ok := groups[group]; !ok {
+			groupNames = append(groupNames, group)
+		}
+		groups[group] = append(groups[group], nodeID)
+	}
+	if len(groupNames) == 0 {
+		return ids.EmptyNodeID, false
+	}
+
+	group := groupNames[p.rand.Intn(len(groupNames))]
+	return p.sample(groups[group])
+}
+
+// sampleBandwidthPeer removes and returns a peer from [p.bandwidthPeers],
+// sampled with probability proportional to its bandwidth raised to the power of
+// 1/[SamplingTemperature]. If [SamplingTemperature] is zero, the peer with the
+// highest bandwidth is returned. If [diverse] is true, the weight of each peer
+// is divided by the number of peers in [p.bandwidthPeers] in its network
+// group.
+// Assumes p.lock is held.
+func (p *PeerTracker) sampleBandwidthPeer(diverse bool) (ids.NodeID, bool) {
+	if p.bandwidthPeers.Len() == 0 {
+		return ids.EmptyNodeID, false
+	}
+
+	var (
+		nodeIDs      = sortedNodeIDs(p.bandwidthPeers)
+		bandwidths   = make([]float64, len(nodeIDs))
+		selected     ids.NodeID
+		maxBandwidth = -1.0
+	)
+	for i, nodeID := range nodeIDs {
+		bandwidths[i] = p.peers[nodeID].bandwidth.Read()
+		if bandwidths[i] > maxBandwidth {
+			selected = nodeID
+			maxBandwidth = bandwidths[i]
+		}
+	}
+
+	if p.config.SamplingTemperature > 0 && maxBandwidth > 0 {
+		// Weights are normalized by the highest bandwidth to avoid overflowing
+		// when the exponent is large.
+		var (
+			exponent    = 1 / p.config.SamplingTemperature
+			weights     = bandwidths
+			totalWeight float64
+		)
+		var groupSizes map[string]int
+		if diverse {
+			groupSizes = p.networkGroupSizes(p.bandwidthPeers)
+		}
+		for i, bandwidth := range bandwidths {
+			weights[i] = math.Pow(bandwidth/maxBandwidth, exponent)
+			if diverse {
+				weights[i] /= float64(groupSizes[p.networkGroup(nodeIDs[i])])
+			}
+			totalWeight += weights[i]
+		}
+
+		sample := p.rand.Float64() * totalWeight
+		for i, weight := range weights {
+			sample -= weight
+			if sample < 0 {
+				selected = nodeIDs[i]
+				break
+			}
+		}
+	}
+
+	p.bandwidthPeers.Remove(selected)
+	return selected, true
+}
+
+// samplePeer returns a random peer from [nodeIDs], if any.
+// Assumes p.lock is held.
+func (p *PeerTracker) samplePeer(nodeIDs set.Set[ids.NodeID]) (ids.NodeID, bool) {
+	return p.sample(sortedNodeIDs(nodeIDs))
+}
+
+// sample returns a random peer from [nodeIDs], if any.
+// Assumes p.lock is held.
+func (p *PeerTracker) sample(nodeIDs []ids.NodeID) (ids.NodeID, bool) {
+	if len(nodeIDs) == 0 {
+		return ids.EmptyNodeID, false
+	}
+	return nodeIDs[p.rand.Intn(len(nodeIDs))], true
+}
+
+// sortedNodeIDs returns the elements of [nodeIDs] in order, so that sampling
+// them doesn't depend on the iteration order of the set.
+func sortedNodeIDs(nodeIDs set.Set[ids.NodeID]) []ids.NodeID {
+	list := nodeIDs.List()
+	utils.Sort(list)
+	return list
+}
+
+// networkGroup returns the network group of [nodeID]. Peers with an unknown
+// network group are each placed in their own group.
+// Assumes p.lock is held.
+func (p *PeerTracker) networkGroup(nodeID ids.NodeID) string {
+	if peer, ok := p.peers[nodeID]; ok && peer.networkGroup != "" {
+		return peer.networkGroup
+	}
+	return "node:" + nodeID.String()
+}
+
+// networkGroupSizes returns the number of peers in [nodeIDs] in each network
+// group.
+// Assumes p.lock is held.
+func (p *PeerTracker) networkGroupSizes(nodeIDs set.Set[ids.NodeID]) map[string]int {
+	sizes := make(map[string]int)
+	for nodeID := range nodeIDs {
+		sizes[p.networkGroup(nodeID)]++
+	}
+	return sizes
+}
+
 // Record that we sent a request to [nodeID].
 func (p *PeerTracker) TrackPeer(nodeID ids.NodeID) {
 	p.lock.Lock()
@@ -209,18 +536,17 @@ func (p *PeerTracker) TrackBandwidth(nodeID ids.NodeID, bandwidth float64) {
 		return
 	}
 
-	now := time.Now()
-	if peer.bandwidth == nil {
-		peer.bandwidth = safemath.NewAverager(bandwidth, bandwidthHalflife, now)
-	} else {
-		peer.bandwidth.Observe(bandwidth, now)
-	}
-	p.bandwidthHeap.Push(nodeID, peer.bandwidth)
+	now := p.clock.Time()
+	p.observeBandwidth(nodeID, peer, bandwidth, now)
 
 	if bandwidth == 0 {
-		p.responsivePeers.Remove(nodeID)
+		p.markUnresponsive(nodeID)
 	} else {
-		p.responsivePeers.Add(nodeID)
+		peer.consecutiveTimeouts = 0
+		if !p.responsivePeers.Contains(nodeID) {
+			p.responsivePeers.Add(nodeID)
+			p.callbackOnResponsivenessChanged(nodeID, true)
+		}
 		// TODO danlaine: shouldn't we add the observation of 0
 		// to the average bandwidth in the if statement?
 		p.averageBandwidth.Observe(bandwidth, now)
@@ -229,6 +555,78 @@ func (p *PeerTracker) TrackBandwidth(nodeID ids.NodeID, bandwidth float64) {
 	p.numResponsivePeers.Set(float64(p.responsivePeers.Len()))
 }
 
+// RegisterTimeout records that a request sent to [nodeID] timed out.
+// The timeout decays the peer's bandwidth average as if a bandwidth of 0 was
+// tracked, but the peer is only considered unresponsive once
+// [MaxConsecutiveTimeouts] requests sent to it timed out in a row.
+func (p *PeerTracker) RegisterTimeout(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	peer := p.peers[nodeID]
+	if peer == nil {
+		// we're not connected to this peer, nothing to do here
+		p.log.Debug("registering timeout for untracked peer", zap.Stringer("nodeID", nodeID))
+		return
+	}
+
+	p.numTimeouts.Inc()
+	p.observeBandwidth(nodeID, peer, 0, p.clock.Time())
+
+	peer.consecutiveTimeouts++
+	if peer.consecutiveTimeouts < p.config.MaxConsecutiveTimeouts {
+		return
+	}
+
+	p.log.Debug("peer timed out too many times",
+		zap.Stringer("nodeID", nodeID),
+		zap.Int("consecutiveTimeouts", peer.consecutiveTimeouts),
+	)
+	p.markUnresponsive(nodeID)
+	p.numResponsivePeers.Set(float64(p.responsivePeers.Len()))
+}
+
+// RegisterInvalidResponse records that [nodeID] sent a response that failed
+// validation. Unlike a timeout, a single invalid response marks the peer as
+// unresponsive, and its bandwidth average is decayed as if a bandwidth of 0
+// was tracked.
+func (p *PeerTracker) RegisterInvalidResponse(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	peer := p.peers[nodeID]
+	if peer == nil {
+		// we're not connected to this peer, nothing to do here
+		p.log.Debug("registering invalid response for untracked peer", zap.Stringer("nodeID", nodeID))
+		return
+	}
+
+	p.numInvalidResponses.Inc()
+	p.observeBandwidth(nodeID, peer, 0, p.clock.Time())
+	p.markUnresponsive(nodeID)
+	p.numResponsivePeers.Set(float64(p.responsivePeers.Len()))
+}
+
+// observeBandwidth adds [bandwidth] to the bandwidth average of [peer] and
+// allows it to be selected based on its bandwidth.
+// Assumes p.lock is held.
+func (p *PeerTracker) observeBandwidth(nodeID ids.NodeID, peer *peerInfo, bandwidth float64, now time.Time) {
+	if peer.bandwidth == nil {
+		peer.bandwidth = safemath.NewAverager(bandwidth, p.config.BandwidthHalflife, now)
+	} else {
+		peer.bandwidth.Observe(bandwidth, now)
+	}
+	p.bandwidthPeers.Add(nodeID)
+}
+
+// Assumes p.lock is held.
+func (p *PeerTracker) markUnresponsive(nodeID ids.NodeID) {
+	if p.responsivePeers.Contains(nodeID) {
+		p.responsivePeers.Remove(nodeID)
+		p.callbackOnResponsivenessChanged(nodeID, false)
+	}
+}
+
 // Connected should be called when [nodeID] connects to this node
 func (p *PeerTracker) Connected(nodeID ids.NodeID, nodeVersion *version.Application) {
 	p.lock.Lock()
@@ -237,8 +635,10 @@ func (p *PeerTracker) Connected(nodeID ids.NodeID, nodeVersion *version.Applicat
 	peer := p.peers[nodeID]
 	if peer == nil {
 		p.peers[nodeID] = &peerInfo{
-			version: nodeVersion,
+			version:     nodeVersion,
+			connectedAt: p.clock.Time(),
 		}
+		p.callbackOnConnected(nodeID, nodeVersion)
 		return
 	}
 
@@ -247,8 +647,11 @@ func (p *PeerTracker) Connected(nodeID ids.NodeID, nodeVersion *version.Applicat
 	// that we have already marked as Connected.
 	if nodeVersion.Compare(peer.version) != 0 {
 		p.peers[nodeID] = &peerInfo{
-			version:   nodeVersion,
-			bandwidth: peer.bandwidth,
+			version:             nodeVersion,
+			bandwidth:           peer.bandwidth,
+			connectedAt:         peer.connectedAt,
+			networkGroup:        peer.networkGroup,
+			consecutiveTimeouts: peer.consecutiveTimeouts,
 		}
 		p.log.Warn(
 			"updating node version of already connected peer",
@@ -264,17 +667,73 @@ func (p *PeerTracker) Connected(nodeID ids.NodeID, nodeVersion *version.Applicat
 	}
 }
 
+// SetNetworkInfo records where the connected peer [nodeID] is located on the
+// internet, which is used to diversify the selected peers across network
+// groups. It should be called after Connected.
+func (p *PeerTracker) SetNetworkInfo(nodeID ids.NodeID, info PeerNetworkInfo) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	peer, ok := p.peers[nodeID]
+	if !ok {
+		p.log.Debug("setting network info of unconnected peer", zap.Stringer("nodeID", nodeID))
+		return
+	}
+	peer.networkGroup = info.networkGroup()
+}
+
 // Disconnected should be called when [nodeID] disconnects from this node
 func (p *PeerTracker) Disconnected(nodeID ids.NodeID) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	p.bandwidthHeap.Remove(nodeID)
+	p.bandwidthPeers.Remove(nodeID)
 	p.trackedPeers.Remove(nodeID)
 	p.numTrackedPeers.Set(float64(p.trackedPeers.Len()))
-	p.responsivePeers.Remove(nodeID)
+	p.markUnresponsive(nodeID)
 	p.numResponsivePeers.Set(float64(p.responsivePeers.Len()))
-	delete(p.peers, nodeID)
+	if peer, ok := p.peers[nodeID]; ok {
+		delete(p.peers, nodeID)
+		p.numDisconnects.Inc()
+		p.backoff(nodeID, peer)
+		p.callbackOnDisconnected(nodeID)
+	}
+}
+
+// backoff prevents [nodeID] from being selected as a new peer for a duration
+// that grows exponentially with the number of times it recently disconnected.
+// Assumes p.lock is held.
+func (p *PeerTracker) backoff(nodeID ids.NodeID, peer *peerInfo) {
+	if p.config.DisconnectBackoff == 0 {
+		return
+	}
+
+	now := p.clock.Time()
+	if now.Sub(peer.connectedAt) >= p.config.MaxDisconnectBackoff {
+		// The peer was stable, so it isn't penalized for disconnecting.
+		delete(p.disconnects, nodeID)
+		return
+	}
+
+	info, ok := p.disconnects[nodeID]
+	if !ok {
+		info = &disconnectInfo{}
+		p.disconnects[nodeID] = info
+	}
+	info.numDisconnects++
+
+	backoff := p.config.DisconnectBackoff
+	for i := 1; i < info.numDisconnects && backoff < p.config.MaxDisconnectBackoff; i++ {
+		backoff = safemath.Min(2*backoff, p.config.MaxDisconnectBackoff)
+	}
+	info.backoffUntil = now.Add(backoff)
+
+	p.log.Debug(
+		"backing off disconnected peer",
+		zap.Stringer("nodeID", nodeID),
+		zap.Int("numDisconnects", info.numDisconnects),
+		zap.Duration("backoff", backoff),
+	)
 }
 
 // Returns the number of peers the node is connected to.
@@ -284,3 +743,61 @@ func (p *PeerTracker) Size() int {
 
 	return len(p.peers)
 }
+
+// Bandwidth returns the average bandwidth of [nodeID]. Returns false if
+// [nodeID] isn't connected or no bandwidth has been tracked for it.
+func (p *PeerTracker) Bandwidth(nodeID ids.NodeID) (float64, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	peer, ok := p.peers[nodeID]
+	if !ok || peer.bandwidth == nil {
+		return 0, false
+	}
+	return peer.bandwidth.Read(), true
+}
+
+// AverageBandwidth returns the average bandwidth across all peers.
+func (p *PeerTracker) AverageBandwidth() float64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.averageBandwidth.Read()
+}
+
+// RegisterCallbackListener registers [listener] to be notified of changes to
+// the tracked peers. [listener] is immediately notified of the peers that are
+// currently connected and responsive.
+func (p *PeerTracker) RegisterCallbackListener(listener PeerTrackerCallbackListener) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.callbackListeners = append(p.callbackListeners, listener)
+	for nodeID, peer := range p.peers {
+		listener.OnConnected(nodeID, peer.version)
+	}
+	for nodeID := range p.responsivePeers {
+		listener.OnResponsivenessChanged(nodeID, true)
+	}
+}
+
+// Assumes p.lock is held.
+func (p *PeerTracker) callbackOnConnected(nodeID ids.NodeID, nodeVersion *version.Application) {
+	for _, listener := range p.callbackListeners {
+		listener.OnConnected(nodeID, nodeVersion)
+	}
+}
+
+// Assumes p.lock is held.
+func (p *PeerTracker) callbackOnDisconnected(nodeID ids.NodeID) {
+	for _, listener := range p.callbackListeners {
+		listener.OnDisconnected(nodeID)
+	}
+}
+
+// Assumes p.lock is held.
+func (p *PeerTracker) callbackOnResponsivenessChanged(nodeID ids.NodeID, responsive bool) {
+	for _, listener := range p.callbackListeners {
+		listener.OnResponsivenessChanged(nodeID, responsive)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// BenchmarkPeerTrackerRequestDistribution reports the share of requests sent
+// to the peers with the highest and second highest bandwidths for a range of
+// sampling temperatures.
+func BenchmarkPeerTrackerRequestDistribution(b *testing.B) {
+	temperatures := []float64{
+		0,
+		0.5,
+		1,
+		2,
+		math.Inf(1),
+	}
+	for _, temperature := range temperatures {
+		b.Run(fmt.Sprintf("temperature %.1f", temperature), func(b *testing.B) {
+			benchmarkPeerTrackerRequestDistribution(b, temperature)
+		})
+	}
+}
+
+func benchmarkPeerTrackerRequestDistribution(b *testing.B, temperature float64) {
+	require := require.New(b)
+
+	config := DefaultPeerTrackerConfig
+	config.DesiredMinResponsivePeers = 0
+	config.NewPeerConnectFactor = math.Inf(1)
+	config.RandomPeerProbability = 0
+	config.SamplingTemperature = temperature
+	p, err := NewPeerTracker(config, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	peerVersion := &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+
+	// Peer i has a bandwidth of i+1, so the last peer is the best.
+	const numPeers = 10
+	nodeIDs := make([]ids.NodeID, numPeers)
+	bandwidths := make(map[ids.NodeID]float64, numPeers)
+	for i := range nodeIDs {
+		nodeID := ids.GenerateTestNodeID()
+		nodeIDs[i] = nodeID
+		bandwidths[nodeID] = float64(i + 1)
+
+		p.Connected(nodeID, peerVersion)
+		p.TrackPeer(nodeID)
+		p.TrackBandwidth(nodeID, bandwidths[nodeID])
+	}
+
+	numRequests := make(map[ids.NodeID]int, numPeers)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		nodeID, ok := p.GetAnyPeer(nil)
+		require.True(ok)
+		numRequests[nodeID]++
+		p.TrackBandwidth(nodeID, bandwidths[nodeID])
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(numRequests[nodeIDs[numPeers-1]])/float64(b.N), "best-share")
+	b.ReportMetric(float64(numRequests[nodeIDs[numPeers-2]])/float64(b.N), "second-share")
+}
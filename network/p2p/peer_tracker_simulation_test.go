@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	slowSimPeer = simPeer{
+		bandwidth:       1_000_000,
+		bandwidthJitter: 0.2,
+		latency:         10 * time.Millisecond,
+	}
+	fastSimPeer = simPeer{
+		bandwidth:       10_000_000,
+		bandwidthJitter: 0.2,
+		latency:         10 * time.Millisecond,
+	}
+)
+
+func newSimConfig(peers ...simPeer) simConfig {
+	return simConfig{
+		seed:           1,
+		trackerConfig:  DefaultPeerTrackerConfig,
+		peers:          peers,
+		numRequests:    5000,
+		responseSize:   100_000,
+		requestTimeout: 2 * time.Second,
+	}
+}
+
+func TestPeerTrackerSimulationDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	peers := append(simPeers(5, fastSimPeer), simPeers(15, slowSimPeer)...)
+	peers[0].timeoutProbability = 0.1
+	peers[1].failureProbability = 0.1
+	peers[2].disconnectProbability = 0.1
+	peers[2].reconnectDelay = time.Second
+	config := newSimConfig(peers...)
+
+	require.Equal(simulate(t, config), simulate(t, config))
+
+	otherConfig := config
+	otherConfig.seed++
+	require.NotEqual(simulate(t, config).selected, simulate(t, otherConfig).selected)
+}
+
+// The peers with the highest bandwidth receive a larger share of the requests
+// as the sampling temperature decreases, at the expense of the slower peers.
+func TestPeerTrackerSimulationSamplingTemperature(t *testing.T) {
+	require := require.New(t)
+
+	const numFastPeers = 2
+	var (
+		peers        = append(simPeers(numFastPeers, fastSimPeer), simPeers(18, slowSimPeer)...)
+		fastPeers    = simPeerRange(0, numFastPeers)
+		temperatures = []float64{
+			0,
+			0.5,
+			1,
+			2,
+			math.Inf(1),
+		}
+		prevFastShare  = 1.0
+		prevThroughput = math.Inf(1)
+	)
+	for _, temperature := range temperatures {
+		config := newSimConfig(peers...)
+		config.trackerConfig.SamplingTemperature = temperature
+		result := simulate(t, config)
+
+		fastShare := result.share(0, fastPeers...)
+		require.Less(fastShare, prevFastShare, "temperature %f", temperature)
+		require.Less(result.throughput(), prevThroughput, "temperature %f", temperature)
+		prevFastShare = fastShare
+		prevThroughput = result.throughput()
+
+		if temperature <= 0.5 {
+			// Requests converge to the fast peers within the first window.
+			converged, ok := result.convergence(500, 0.5, fastPeers...)
+			require.True(ok, "temperature %f", temperature)
+			require.Zero(converged, "temperature %f", temperature)
+		}
+	}
+}
+
+// Peers that respond identically receive the same share of the requests.
+func TestPeerTrackerSimulationFairness(t *testing.T) {
+	require := require.New(t)
+
+	const numPeers = 20
+	result := simulate(t, newSimConfig(simPeers(numPeers, slowSimPeer)...))
+	require.Greater(result.fairness(simPeerRange(0, numPeers)...), 0.95)
+}
+
+// A peer that often times out receives fewer requests than a reliable peer,
+// even though it is faster when it responds.
+func TestPeerTrackerSimulationUnreliablePeer(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		unreliablePeer = 0
+		reliablePeer   = 1
+	)
+	peers := simPeers(10, slowSimPeer)
+	peers[unreliablePeer] = fastSimPeer
+	peers[unreliablePeer].timeoutProbability = 0.5
+	peers[reliablePeer].bandwidth = fastSimPeer.bandwidth / 2
+
+	result := simulate(t, newSimConfig(peers...))
+	require.Less(result.share(0, unreliablePeer), result.share(0, reliablePeer))
+}
+
+// A peer that keeps disconnecting is backed off, so it receives fewer
+// requests than it would without the backoff.
+func TestPeerTrackerSimulationFlappingPeer(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		numPeers     = 10
+		flappingPeer = 0
+	)
+	peers := simPeers(numPeers, slowSimPeer)
+	peers[flappingPeer].disconnectProbability = 0.5
+	peers[flappingPeer].reconnectDelay = 100 * time.Millisecond
+
+	config := newSimConfig(peers...)
+	config.trackerConfig.DisconnectBackoff = time.Second
+	config.trackerConfig.MaxDisconnectBackoff = time.Minute
+	backoffShare := simulate(t, config).share(0, flappingPeer)
+
+	config.trackerConfig.DisconnectBackoff = 0
+	noBackoffShare := simulate(t, config).share(0, flappingPeer)
+
+	require.Less(backoffShare, 0.5/numPeers)
+	require.Less(backoffShare, noBackoffShare)
+}
+
+// Requests are spread evenly across autonomous systems when network diversity
+// is requested, and evenly across peers otherwise.
+func TestPeerTrackerSimulationNetworkDiversity(t *testing.T) {
+	tests := []struct {
+		name          string
+		peerOptions   []PeerOption
+		expectedShare float64
+	}{
+		{
+			name:          "without diversity",
+			expectedShare: 2.0 / 12,
+		},
+		{
+			name:          "with diversity",
+			peerOptions:   []PeerOption{WithNetworkDiversity()},
+			expectedShare: 0.5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			peers := simPeers(12, slowSimPeer)
+			for i := range peers {
+				peers[i].networkInfo = PeerNetworkInfo{ASN: 1}
+			}
+			peers[10].networkInfo.ASN = 2
+			peers[11].networkInfo.ASN = 2
+
+			config := newSimConfig(peers...)
+			config.peerOptions = test.peerOptions
+			result := simulate(t, config)
+			require.InDelta(test.expectedShare, result.share(0, 10, 11), 0.05)
+		})
+	}
+}
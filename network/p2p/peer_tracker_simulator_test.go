@@ -0,0 +1,263 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	// simStartTime is the time of the virtual clock when a simulation starts
+	simStartTime = time.Unix(1_000_000, 0)
+	// simIdleDuration is how long a simulation waits for a peer to reconnect
+	// when no peer can be selected
+	simIdleDuration = time.Second
+
+	simPeerVersion = &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+)
+
+// simPeer describes how a synthetic peer responds to requests
+type simPeer struct {
+	// bandwidth is the mean rate, in bytes per second, at which the peer
+	// sends responses
+	bandwidth float64
+	// bandwidthJitter is the fraction by which the bandwidth of each response
+	// uniformly varies around [bandwidth]
+	bandwidthJitter float64
+	// latency is the delay before the peer starts sending a response, or
+	// fails the request
+	latency time.Duration
+	// failureProbability is the probability that a request fails
+	failureProbability float64
+	// timeoutProbability is the probability that a request times out
+	timeoutProbability float64
+	// disconnectProbability is the probability that the peer disconnects
+	// after responding to a request
+	disconnectProbability float64
+	// reconnectDelay is how long the peer stays disconnected
+	reconnectDelay time.Duration
+	networkInfo    PeerNetworkInfo
+}
+
+// simConfig describes a scenario in which requests are sent, one at a time,
+// to the peers selected by a PeerTracker
+type simConfig struct {
+	// seed determines every random choice of the simulation, including the
+	// choices of the PeerTracker
+	seed          int64
+	trackerConfig PeerTrackerConfig
+	// options passed to GetAnyPeer
+	peerOptions    []PeerOption
+	peers          []simPeer
+	numRequests    int
+	responseSize   int
+	requestTimeout time.Duration
+}
+
+// simPeerStats are the outcomes of the requests sent to a peer
+type simPeerStats struct {
+	numRequests    int
+	numFailures    int
+	numTimeouts    int
+	numBytes       int
+	numDisconnects int
+}
+
+type simResult struct {
+	peers []simPeerStats
+	// selected is the index of the peer each request was sent to, or -1 if no
+	// peer could be selected
+	selected []int
+	elapsed  time.Duration
+}
+
+// simulate runs the scenario described by [config] against a PeerTracker
+// driven by a virtual clock. Given the same [config], the result is always
+// the same.
+func simulate(t testing.TB, config simConfig) *simResult {
+	require := require.New(t)
+
+	tracker, err := NewPeerTracker(config.trackerConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	rng := rand.New(rand.NewSource(config.seed))         // #nosec G404
+	tracker.rand = rand.New(rand.NewSource(rng.Int63())) // #nosec G404
+
+	var (
+		now         = simStartTime
+		nodeIDs     = make([]ids.NodeID, len(config.peers))
+		indices     = make(map[ids.NodeID]int, len(config.peers))
+		reconnectAt = make(map[int]time.Time)
+		result      = &simResult{
+			peers:    make([]simPeerStats, len(config.peers)),
+			selected: make([]int, 0, config.numRequests),
+		}
+	)
+	connect := func(i int) {
+		tracker.Connected(nodeIDs[i], simPeerVersion)
+		tracker.SetNetworkInfo(nodeIDs[i], config.peers[i].networkInfo)
+	}
+
+	tracker.clock.Set(now)
+	for i := range config.peers {
+		nodeIDBytes := make([]byte, ids.NodeIDLen)
+		binary.BigEndian.PutUint64(nodeIDBytes, uint64(i+1))
+		nodeIDs[i] = ids.BuildTestNodeID(nodeIDBytes)
+		indices[nodeIDs[i]] = i
+		connect(i)
+	}
+
+	for len(result.selected) < config.numRequests {
+		for i := range config.peers {
+			if reconnectTime, ok := reconnectAt[i]; ok && !now.Before(reconnectTime) {
+				delete(reconnectAt, i)
+				connect(i)
+			}
+		}
+
+		nodeID, ok := tracker.GetAnyPeer(nil, config.peerOptions...)
+		if !ok {
+			result.selected = append(result.selected, -1)
+			now = now.Add(simIdleDuration)
+			tracker.clock.Set(now)
+			continue
+		}
+
+		i := indices[nodeID]
+		peer := config.peers[i]
+		stats := &result.peers[i]
+		result.selected = append(result.selected, i)
+		stats.numRequests++
+		tracker.TrackPeer(nodeID)
+
+		outcome := rng.Float64()
+		bandwidth := peer.bandwidth * (1 + peer.bandwidthJitter*(2*rng.Float64()-1))
+		duration := peer.latency
+		if bandwidth > 0 {
+			duration += time.Duration(float64(config.responseSize) / bandwidth * float64(time.Second))
+		}
+		switch {
+		case outcome < peer.failureProbability:
+			stats.numFailures++
+			now = now.Add(peer.latency)
+			tracker.clock.Set(now)
+			tracker.TrackBandwidth(nodeID, 0)
+		case outcome < peer.failureProbability+peer.timeoutProbability || bandwidth <= 0 || duration > config.requestTimeout:
+			stats.numTimeouts++
+			now = now.Add(config.requestTimeout)
+			tracker.clock.Set(now)
+			tracker.RegisterTimeout(nodeID)
+		default:
+			stats.numBytes += config.responseSize
+			now = now.Add(duration)
+			tracker.clock.Set(now)
+			tracker.TrackBandwidth(nodeID, float64(config.responseSize)/duration.Seconds()+epsilon)
+		}
+
+		if rng.Float64() < peer.disconnectProbability {
+			stats.numDisconnects++
+			tracker.Disconnected(nodeID)
+			reconnectAt[i] = now.Add(peer.reconnectDelay)
+		}
+	}
+
+	result.elapsed = now.Sub(simStartTime)
+	return result
+}
+
+// share returns the fraction of the requests, starting from the request at
+// index [from], that were sent to any of the peers in [peers]
+func (r *simResult) share(from int, peers ...int) float64 {
+	requests := r.selected[from:]
+	if len(requests) == 0 {
+		return 0
+	}
+
+	numSelected := 0
+	for _, selected := range requests {
+		for _, peer := range peers {
+			if selected == peer {
+				numSelected++
+				break
+			}
+		}
+	}
+	return float64(numSelected) / float64(len(requests))
+}
+
+// throughput returns the number of response bytes received per second of the
+// simulation
+func (r *simResult) throughput() float64 {
+	numBytes := 0
+	for _, stats := range r.peers {
+		numBytes += stats.numBytes
+	}
+	return float64(numBytes) / r.elapsed.Seconds()
+}
+
+// fairness returns Jain's fairness index of the number of requests sent to
+// [peers], which is 1 if every peer received the same number of requests and
+// 1/len(peers) if a single peer received every request
+func (r *simResult) fairness(peers ...int) float64 {
+	var sum, sumOfSquares float64
+	for _, peer := range peers {
+		numRequests := float64(r.peers[peer].numRequests)
+		sum += numRequests
+		sumOfSquares += numRequests * numRequests
+	}
+	if sumOfSquares == 0 {
+		return 1
+	}
+	return sum * sum / (float64(len(peers)) * sumOfSquares)
+}
+
+// convergence returns the number of requests after which the share of the
+// requests sent to [peers] is at least [threshold] in every subsequent window
+// of [window] requests. Returns false if the share is below [threshold] in the
+// last window.
+func (r *simResult) convergence(window int, threshold float64, peers ...int) (int, bool) {
+	converged := 0
+	for start := 0; start+window <= len(r.selected); start += window {
+		windowResult := &simResult{
+			selected: r.selected[start : start+window],
+		}
+		if windowResult.share(0, peers...) < threshold {
+			converged = start + window
+		}
+	}
+	return converged, converged+window <= len(r.selected)
+}
+
+// simPeers returns [n] peers that respond to requests like [peer]
+func simPeers(n int, peer simPeer) []simPeer {
+	peers := make([]simPeer, n)
+	for i := range peers {
+		peers[i] = peer
+	}
+	return peers
+}
+
+// simPeerRange returns the indices of the peers in [start, end)
+func simPeerRange(start int, end int) []int {
+	peers := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		peers = append(peers, i)
+	}
+	return peers
+}
@@ -4,7 +4,10 @@
 package p2p
 
 import (
+	"math"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -15,12 +18,273 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 )
 
+func TestPeerTrackerConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      func(*PeerTrackerConfig)
+		expectedErr error
+	}{
+		{
+			name:   "default",
+			config: func(*PeerTrackerConfig) {},
+		},
+		{
+			name: "zero values",
+			config: func(c *PeerTrackerConfig) {
+				c.DesiredMinResponsivePeers = 0
+				c.NewPeerConnectFactor = 0
+				c.RandomPeerProbability = 0
+				c.SamplingTemperature = 0
+			},
+		},
+		{
+			name: "negative desired min responsive peers",
+			config: func(c *PeerTrackerConfig) {
+				c.DesiredMinResponsivePeers = -1
+			},
+			expectedErr: ErrInvalidDesiredMinResponsivePeers,
+		},
+		{
+			name: "negative new peer connect factor",
+			config: func(c *PeerTrackerConfig) {
+				c.NewPeerConnectFactor = -0.1
+			},
+			expectedErr: ErrInvalidNewPeerConnectFactor,
+		},
+		{
+			name: "NaN new peer connect factor",
+			config: func(c *PeerTrackerConfig) {
+				c.NewPeerConnectFactor = math.NaN()
+			},
+			expectedErr: ErrInvalidNewPeerConnectFactor,
+		},
+		{
+			name: "random peer probability above one",
+			config: func(c *PeerTrackerConfig) {
+				c.RandomPeerProbability = 1.1
+			},
+			expectedErr: ErrInvalidRandomPeerProbability,
+		},
+		{
+			name: "negative random peer probability",
+			config: func(c *PeerTrackerConfig) {
+				c.RandomPeerProbability = -0.1
+			},
+			expectedErr: ErrInvalidRandomPeerProbability,
+		},
+		{
+			name: "zero bandwidth halflife",
+			config: func(c *PeerTrackerConfig) {
+				c.BandwidthHalflife = 0
+			},
+			expectedErr: ErrInvalidBandwidthHalflife,
+		},
+		{
+			name: "disabled disconnect backoff",
+			config: func(c *PeerTrackerConfig) {
+				c.DisconnectBackoff = 0
+			},
+		},
+		{
+			name: "negative disconnect backoff",
+			config: func(c *PeerTrackerConfig) {
+				c.DisconnectBackoff = -time.Second
+			},
+			expectedErr: ErrInvalidDisconnectBackoff,
+		},
+		{
+			name: "max disconnect backoff below disconnect backoff",
+			config: func(c *PeerTrackerConfig) {
+				c.MaxDisconnectBackoff = c.DisconnectBackoff - 1
+			},
+			expectedErr: ErrInvalidMaxDisconnectBackoff,
+		},
+		{
+			name: "negative sampling temperature",
+			config: func(c *PeerTrackerConfig) {
+				c.SamplingTemperature = -1
+			},
+			expectedErr: ErrInvalidSamplingTemperature,
+		},
+		{
+			name: "NaN sampling temperature",
+			config: func(c *PeerTrackerConfig) {
+				c.SamplingTemperature = math.NaN()
+			},
+			expectedErr: ErrInvalidSamplingTemperature,
+		},
+		{
+			name: "zero max consecutive timeouts",
+			config: func(c *PeerTrackerConfig) {
+				c.MaxConsecutiveTimeouts = 0
+			},
+			expectedErr: ErrInvalidMaxConsecutiveTimeouts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := DefaultPeerTrackerConfig
+			tt.config(&config)
+			require.ErrorIs(config.Verify(), tt.expectedErr)
+
+			_, err := NewPeerTracker(config, logging.NoLog{}, "", prometheus.NewRegistry())
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestPeerTrackerAlwaysExploit(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultPeerTrackerConfig
+	config.DesiredMinResponsivePeers = 0
+	config.NewPeerConnectFactor = math.Inf(1)
+	config.RandomPeerProbability = 0
+	config.BandwidthHalflife = time.Minute
+	config.SamplingTemperature = 0
+	p, err := NewPeerTracker(config, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	peerVersion := &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+	slowPeer := ids.GenerateTestNodeID()
+	fastPeer := ids.GenerateTestNodeID()
+	newPeer := ids.GenerateTestNodeID()
+	for _, nodeID := range []ids.NodeID{slowPeer, fastPeer, newPeer} {
+		p.Connected(nodeID, peerVersion)
+	}
+	p.TrackPeer(slowPeer)
+	p.TrackBandwidth(slowPeer, 1)
+	p.TrackPeer(fastPeer)
+	p.TrackBandwidth(fastPeer, 10)
+
+	// With exploration disabled, the untracked peer is never selected and the
+	// peer with the highest bandwidth is always preferred.
+	peer, ok := p.GetAnyPeer(nil)
+	require.True(ok)
+	require.Equal(fastPeer, peer)
+}
+
+func TestPeerTrackerWeightedSampling(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultPeerTrackerConfig
+	config.DesiredMinResponsivePeers = 0
+	config.NewPeerConnectFactor = math.Inf(1)
+	config.RandomPeerProbability = 0
+	config.SamplingTemperature = 1
+	p, err := NewPeerTracker(config, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	peerVersion := &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+	slowPeer := ids.GenerateTestNodeID()
+	fastPeer := ids.GenerateTestNodeID()
+	bandwidths := map[ids.NodeID]float64{
+		slowPeer: 1,
+		fastPeer: 3,
+	}
+	for nodeID, bandwidth := range bandwidths {
+		p.Connected(nodeID, peerVersion)
+		p.TrackPeer(nodeID)
+		p.TrackBandwidth(nodeID, bandwidth)
+	}
+
+	// Peers are selected proportionally to their bandwidth, so the slower
+	// peer still receives requests.
+	const numRequests = 2000
+	selected := make(map[ids.NodeID]int)
+	for i := 0; i < numRequests; i++ {
+		peer, ok := p.GetAnyPeer(nil)
+		require.True(ok)
+		selected[peer]++
+		p.TrackBandwidth(peer, bandwidths[peer])
+	}
+	require.Len(selected, 2)
+	fastShare := float64(selected[fastPeer]) / numRequests
+	require.InDelta(0.75, fastShare, 0.1)
+}
+
+func TestPeerTrackerDisconnectBackoff(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultPeerTrackerConfig
+	config.DisconnectBackoff = time.Second
+	config.MaxDisconnectBackoff = 4 * time.Second
+	p, err := NewPeerTracker(config, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	now := time.Now()
+	p.clock.Set(now)
+
+	peerVersion := &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+	nodeID := ids.GenerateTestNodeID()
+
+	// The peer is backed off for [DisconnectBackoff] after disconnecting
+	// shortly after connecting.
+	p.Connected(nodeID, peerVersion)
+	p.Disconnected(nodeID)
+	p.Connected(nodeID, peerVersion)
+
+	_, ok := p.GetAnyPeer(nil)
+	require.False(ok)
+
+	now = now.Add(time.Second)
+	p.clock.Set(now)
+
+	peer, ok := p.GetAnyPeer(nil)
+	require.True(ok)
+	require.Equal(nodeID, peer)
+
+	// The backoff doubles every time the peer disconnects again.
+	p.Disconnected(nodeID)
+	p.Connected(nodeID, peerVersion)
+
+	now = now.Add(time.Second)
+	p.clock.Set(now)
+
+	_, ok = p.GetAnyPeer(nil)
+	require.False(ok)
+
+	now = now.Add(time.Second)
+	p.clock.Set(now)
+
+	peer, ok = p.GetAnyPeer(nil)
+	require.True(ok)
+	require.Equal(nodeID, peer)
+
+	// The backoff is reset once the peer stays connected for
+	// [MaxDisconnectBackoff].
+	now = now.Add(config.MaxDisconnectBackoff)
+	p.clock.Set(now)
+
+	p.Disconnected(nodeID)
+	p.Connected(nodeID, peerVersion)
+
+	peer, ok = p.GetAnyPeer(nil)
+	require.True(ok)
+	require.Equal(nodeID, peer)
+}
+
 func TestPeerTracker(t *testing.T) {
 	require := require.New(t)
-	p, err := NewPeerTracker(logging.NoLog{}, "", prometheus.NewRegistry())
+	p, err := NewPeerTracker(DefaultPeerTrackerConfig, logging.NoLog{}, "", prometheus.NewRegistry())
 	require.NoError(err)
 
 	// Connect some peers
+	desiredMinResponsivePeers := DefaultPeerTrackerConfig.DesiredMinResponsivePeers
 	numExtraPeers := 10
 	numPeers := desiredMinResponsivePeers + numExtraPeers
 	peerIDs := make([]ids.NodeID, numPeers)
@@ -97,3 +361,245 @@ func TestPeerTracker(t *testing.T) {
 	require.True(ok)
 	require.Falsef(responsive, "expected connecting to a non-responsive peer, but got a peer that was responsive: peer %s", peer)
 }
+
+type peerEvent struct {
+	nodeID     ids.NodeID
+	event      string
+	responsive bool
+}
+
+type recordingListener struct {
+	events []peerEvent
+}
+
+func (l *recordingListener) OnConnected(nodeID ids.NodeID, _ *version.Application) {
+	l.events = append(l.events, peerEvent{nodeID: nodeID, event: "connected"})
+}
+
+func (l *recordingListener) OnDisconnected(nodeID ids.NodeID) {
+	l.events = append(l.events, peerEvent{nodeID: nodeID, event: "disconnected"})
+}
+
+func (l *recordingListener) OnResponsivenessChanged(nodeID ids.NodeID, responsive bool) {
+	l.events = append(l.events, peerEvent{nodeID: nodeID, event: "responsiveness", responsive: responsive})
+}
+
+func TestPeerTrackerCallbackListener(t *testing.T) {
+	require := require.New(t)
+	p, err := NewPeerTracker(DefaultPeerTrackerConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	peerVersion := &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	// Registering a listener replays the current peers.
+	p.Connected(nodeID0, peerVersion)
+	p.TrackBandwidth(nodeID0, 10)
+
+	listener := &recordingListener{}
+	p.RegisterCallbackListener(listener)
+	require.Equal([]peerEvent{
+		{nodeID: nodeID0, event: "connected"},
+		{nodeID: nodeID0, event: "responsiveness", responsive: true},
+	}, listener.events)
+	listener.events = nil
+
+	p.Connected(nodeID1, peerVersion)
+	// Reconnecting an already connected peer isn't reported.
+	p.Connected(nodeID1, peerVersion)
+	p.TrackBandwidth(nodeID1, 10)
+	// Only changes in responsiveness are reported.
+	p.TrackBandwidth(nodeID1, 20)
+	p.TrackBandwidth(nodeID1, 0)
+	p.TrackBandwidth(nodeID1, 0)
+	require.Equal([]peerEvent{
+		{nodeID: nodeID1, event: "connected"},
+		{nodeID: nodeID1, event: "responsiveness", responsive: true},
+		{nodeID: nodeID1, event: "responsiveness", responsive: false},
+	}, listener.events)
+	listener.events = nil
+
+	// A responsive peer becomes unresponsive before it is disconnected.
+	p.Disconnected(nodeID0)
+	p.Disconnected(nodeID1)
+	// Disconnecting an unknown peer isn't reported.
+	p.Disconnected(nodeID1)
+	require.Equal([]peerEvent{
+		{nodeID: nodeID0, event: "responsiveness", responsive: false},
+		{nodeID: nodeID0, event: "disconnected"},
+		{nodeID: nodeID1, event: "disconnected"},
+	}, listener.events)
+}
+
+func TestPeerTrackerRegisterTimeout(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultPeerTrackerConfig
+	config.MaxConsecutiveTimeouts = 2
+	p, err := NewPeerTracker(config, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	peerVersion := &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+	nodeID := ids.GenerateTestNodeID()
+	p.Connected(nodeID, peerVersion)
+	p.TrackBandwidth(nodeID, 10)
+
+	listener := &recordingListener{}
+	p.RegisterCallbackListener(listener)
+	listener.events = nil
+
+	// A single timeout decays the bandwidth, but the peer stays responsive.
+	p.RegisterTimeout(nodeID)
+	bandwidth, ok := p.Bandwidth(nodeID)
+	require.True(ok)
+	require.Less(bandwidth, 10.0)
+	require.Contains(p.responsivePeers, nodeID)
+
+	// A response resets the consecutive timeouts.
+	p.TrackBandwidth(nodeID, 10)
+	p.RegisterTimeout(nodeID)
+	require.Contains(p.responsivePeers, nodeID)
+	require.Empty(listener.events)
+
+	// The peer becomes unresponsive after [MaxConsecutiveTimeouts] timeouts
+	// in a row.
+	p.RegisterTimeout(nodeID)
+	require.NotContains(p.responsivePeers, nodeID)
+	require.Equal([]peerEvent{
+		{nodeID: nodeID, event: "responsiveness", responsive: false},
+	}, listener.events)
+
+	// Timeouts of unconnected peers are ignored.
+	p.RegisterTimeout(ids.GenerateTestNodeID())
+	require.Len(p.peers, 1)
+}
+
+func TestPeerTrackerRegisterInvalidResponse(t *testing.T) {
+	require := require.New(t)
+
+	p, err := NewPeerTracker(DefaultPeerTrackerConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	peerVersion := &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+	nodeID := ids.GenerateTestNodeID()
+	p.Connected(nodeID, peerVersion)
+	p.TrackBandwidth(nodeID, 10)
+
+	listener := &recordingListener{}
+	p.RegisterCallbackListener(listener)
+	listener.events = nil
+
+	// A single invalid response decays the bandwidth and marks the peer as
+	// unresponsive.
+	p.RegisterInvalidResponse(nodeID)
+	bandwidth, ok := p.Bandwidth(nodeID)
+	require.True(ok)
+	require.Less(bandwidth, 10.0)
+	require.NotContains(p.responsivePeers, nodeID)
+	require.Equal([]peerEvent{
+		{nodeID: nodeID, event: "responsiveness", responsive: false},
+	}, listener.events)
+
+	// A valid response makes the peer responsive again.
+	p.TrackBandwidth(nodeID, 10)
+	require.Contains(p.responsivePeers, nodeID)
+
+	// Invalid responses of unconnected peers are ignored.
+	p.RegisterInvalidResponse(ids.GenerateTestNodeID())
+	require.Len(p.peers, 1)
+}
+
+func TestPeerTrackerNetworkDiversityNewPeer(t *testing.T) {
+	require := require.New(t)
+
+	p, err := NewPeerTracker(DefaultPeerTrackerConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	peerVersion := &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+	trackedPeer := ids.GenerateTestNodeID()
+	sameSubnetPeers := []ids.NodeID{
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+	}
+	otherSubnetPeer := ids.GenerateTestNodeID()
+
+	p.Connected(trackedPeer, peerVersion)
+	p.SetNetworkInfo(trackedPeer, PeerNetworkInfo{IP: net.IPv4(10, 0, 0, 1)})
+	p.TrackPeer(trackedPeer)
+	for i, nodeID := range sameSubnetPeers {
+		p.Connected(nodeID, peerVersion)
+		p.SetNetworkInfo(nodeID, PeerNetworkInfo{IP: net.IPv4(10, 0, 0, byte(i+2))})
+	}
+	p.Connected(otherSubnetPeer, peerVersion)
+	p.SetNetworkInfo(otherSubnetPeer, PeerNetworkInfo{IP: net.IPv4(10, 0, 1, 1)})
+
+	// The subnet of the tracked peer is already represented, so the peer in
+	// the other subnet is always tracked next.
+	for i := 0; i < 10; i++ {
+		peer, ok := p.GetAnyPeer(nil, WithNetworkDiversity())
+		require.True(ok)
+		require.Equal(otherSubnetPeer, peer)
+	}
+}
+
+func TestPeerTrackerNetworkDiversitySampling(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultPeerTrackerConfig
+	config.DesiredMinResponsivePeers = 0
+	config.NewPeerConnectFactor = math.Inf(1)
+	p, err := NewPeerTracker(config, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	peerVersion := &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+	otherASNPeer := ids.GenerateTestNodeID()
+	nodeIDs := []ids.NodeID{otherASNPeer}
+	p.Connected(otherASNPeer, peerVersion)
+	p.SetNetworkInfo(otherASNPeer, PeerNetworkInfo{ASN: 2})
+	for i := 0; i < 9; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		nodeIDs = append(nodeIDs, nodeID)
+		p.Connected(nodeID, peerVersion)
+		p.SetNetworkInfo(nodeID, PeerNetworkInfo{ASN: 1})
+	}
+	for _, nodeID := range nodeIDs {
+		p.TrackPeer(nodeID)
+		p.TrackBandwidth(nodeID, 1)
+	}
+
+	// Both autonomous systems are selected equally often, even though most
+	// peers are in the same autonomous system.
+	const numRequests = 2000
+	numOtherASNSelected := 0
+	for i := 0; i < numRequests; i++ {
+		peer, ok := p.GetAnyPeer(nil, WithNetworkDiversity())
+		require.True(ok)
+		if peer == otherASNPeer {
+			numOtherASNSelected++
+		}
+		p.TrackBandwidth(peer, 1)
+	}
+	require.InDelta(0.5, float64(numOtherASNSelected)/numRequests, 0.1)
+}
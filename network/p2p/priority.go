@@ -0,0 +1,263 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	// GossipPriority is the lowest priority class and is shed first
+	GossipPriority Priority = iota
+	// SyncPriority is for state and block sync traffic
+	SyncPriority
+	// ConsensusPriority is for traffic required by consensus to make progress
+	// and is shed last
+	ConsensusPriority
+
+	numPriorities = int(ConsensusPriority) + 1
+
+	priorityLabel = "priority"
+)
+
+var (
+	ErrShed                 = errors.New("shed")
+	ErrInvalidQueueDepth    = errors.New("invalid queue depth")
+	ErrQueueDepthsUnordered = errors.New("lower priority queue depth exceeds higher priority queue depth")
+
+	_ Handler = (*PriorityHandler)(nil)
+)
+
+// Priority is the class an application message is tagged with. Under load,
+// messages with a lower priority are shed before messages with a higher
+// priority.
+type Priority int
+
+func (p Priority) String() string {
+	switch p {
+	case GossipPriority:
+		return "gossip"
+	case SyncPriority:
+		return "sync"
+	case ConsensusPriority:
+		return "consensus"
+	default:
+		return "unknown"
+	}
+}
+
+// PriorityConfig configures the queue depth of each priority class. A message
+// of a given class is only admitted while the total number of messages being
+// processed, across all classes, is below that class's queue depth. Lower
+// priority classes must therefore have smaller queue depths so that they are
+// shed first.
+type PriorityConfig struct {
+	ConsensusQueueDepth int `json:"consensusQueueDepth"`
+	SyncQueueDepth      int `json:"syncQueueDepth"`
+	GossipQueueDepth    int `json:"gossipQueueDepth"`
+}
+
+// NewPriorityConfig returns the queue depths for a chain that handles up to
+// [concurrency] application messages at once. Because no more than
+// [concurrency] messages are ever in flight, consensus traffic is never shed,
+// sync traffic is shed once half of the handlers are busy, and gossip is shed
+// once a quarter of them are.
+func NewPriorityConfig(concurrency int) PriorityConfig {
+	return PriorityConfig{
+		ConsensusQueueDepth: concurrency,
+		SyncQueueDepth:      math.Max(1, concurrency/2),
+		GossipQueueDepth:    math.Max(1, concurrency/4),
+	}
+}
+
+func (c PriorityConfig) Verify() error {
+	switch {
+	case c.ConsensusQueueDepth <= 0:
+		return fmt.Errorf("%w: consensus queue depth %d", ErrInvalidQueueDepth, c.ConsensusQueueDepth)
+	case c.SyncQueueDepth <= 0:
+		return fmt.Errorf("%w: sync queue depth %d", ErrInvalidQueueDepth, c.SyncQueueDepth)
+	case c.GossipQueueDepth <= 0:
+		return fmt.Errorf("%w: gossip queue depth %d", ErrInvalidQueueDepth, c.GossipQueueDepth)
+	case c.SyncQueueDepth > c.ConsensusQueueDepth:
+		return fmt.Errorf("%w: sync (%d) > consensus (%d)", ErrQueueDepthsUnordered, c.SyncQueueDepth, c.ConsensusQueueDepth)
+	case c.GossipQueueDepth > c.SyncQueueDepth:
+		return fmt.Errorf("%w: gossip (%d) > sync (%d)", ErrQueueDepthsUnordered, c.GossipQueueDepth, c.SyncQueueDepth)
+	default:
+		return nil
+	}
+}
+
+// Prioritizer tracks the number of in-flight messages of each priority class
+// and decides whether a new message should be admitted or shed. A single
+// Prioritizer is expected to be shared across the handlers of a Network so
+// that load from every protocol is taken into account.
+type Prioritizer struct {
+	queueDepths [numPriorities]int
+
+	lock       sync.Mutex
+	processing [numPriorities]int
+	total      int
+
+	admitted         *prometheus.CounterVec
+	shed             *prometheus.CounterVec
+	processingMetric *prometheus.GaugeVec
+}
+
+func NewPrioritizer(
+	config PriorityConfig,
+	metricsNamespace string,
+	registerer prometheus.Registerer,
+) (*Prioritizer, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	p := &Prioritizer{
+		queueDepths: [numPriorities]int{
+			GossipPriority:    config.GossipQueueDepth,
+			SyncPriority:      config.SyncQueueDepth,
+			ConsensusPriority: config.ConsensusQueueDepth,
+		},
+		admitted: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "priority_admitted",
+				Help:      "number of messages admitted for processing (n)",
+			},
+			[]string{priorityLabel},
+		),
+		shed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "priority_shed",
+				Help:      "number of messages dropped due to load (n)",
+			},
+			[]string{priorityLabel},
+		),
+		processingMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "priority_processing",
+				Help:      "number of messages currently being processed (n)",
+			},
+			[]string{priorityLabel},
+		),
+	}
+
+	err := utils.Err(
+		registerer.Register(p.admitted),
+		registerer.Register(p.shed),
+		registerer.Register(p.processingMetric),
+	)
+	return p, err
+}
+
+// Acquire returns true if a message of [priority] should be processed. If
+// true is returned, Release must be called once the message has been handled.
+func (p *Prioritizer) Acquire(priority Priority) bool {
+	priority = normalize(priority)
+	label := priority.String()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.total >= p.queueDepths[priority] {
+		p.shed.WithLabelValues(label).Inc()
+		return false
+	}
+
+	p.processing[priority]++
+	p.total++
+	p.admitted.WithLabelValues(label).Inc()
+	p.processingMetric.WithLabelValues(label).Inc()
+	return true
+}
+
+// Release marks a previously acquired message of [priority] as handled.
+func (p *Prioritizer) Release(priority Priority) {
+	priority = normalize(priority)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.processing[priority] == 0 {
+		return
+	}
+
+	p.processing[priority]--
+	p.total--
+	p.processingMetric.WithLabelValues(priority.String()).Dec()
+}
+
+// Processing returns the number of in-flight messages of [priority]
+func (p *Prioritizer) Processing(priority Priority) int {
+	priority = normalize(priority)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.processing[priority]
+}
+
+// normalize treats unknown priorities as the lowest priority class
+func normalize(priority Priority) Priority {
+	if priority < 0 || int(priority) >= numPriorities {
+		return GossipPriority
+	}
+	return priority
+}
+
+// PriorityHandler tags every message sent to the wrapped Handler with
+// [Priority] and sheds the message if [Prioritizer] is over the queue depth of
+// that class.
+type PriorityHandler struct {
+	Handler
+	Priority    Priority
+	Prioritizer *Prioritizer
+	Log         logging.Logger
+}
+
+func (p PriorityHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	if !p.Prioritizer.Acquire(p.Priority) {
+		p.Log.Debug("dropping message",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("priority", p.Priority),
+		)
+		return
+	}
+	defer p.Prioritizer.Release(p.Priority)
+
+	p.Handler.AppGossip(ctx, nodeID, gossipBytes)
+}
+
+func (p PriorityHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	if !p.Prioritizer.Acquire(p.Priority) {
+		return nil, fmt.Errorf("dropping %s message from %s: %w", p.Priority, nodeID, ErrShed)
+	}
+	defer p.Prioritizer.Release(p.Priority)
+
+	return p.Handler.AppRequest(ctx, nodeID, deadline, requestBytes)
+}
+
+func (p PriorityHandler) CrossChainAppRequest(ctx context.Context, chainID ids.ID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	if !p.Prioritizer.Acquire(p.Priority) {
+		return nil, fmt.Errorf("dropping %s message from %s: %w", p.Priority, chainID, ErrShed)
+	}
+	defer p.Prioritizer.Release(p.Priority)
+
+	return p.Handler.CrossChainAppRequest(ctx, chainID, deadline, requestBytes)
+}
@@ -0,0 +1,267 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestPriorityConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      PriorityConfig
+		expectedErr error
+	}{
+		{
+			name:   "default concurrency",
+			config: NewPriorityConfig(constants.DefaultConsensusAppConcurrency),
+		},
+		{
+			name:   "single handler",
+			config: NewPriorityConfig(1),
+		},
+		{
+			name: "equal depths",
+			config: PriorityConfig{
+				ConsensusQueueDepth: 1,
+				SyncQueueDepth:      1,
+				GossipQueueDepth:    1,
+			},
+		},
+		{
+			name: "zero consensus depth",
+			config: PriorityConfig{
+				SyncQueueDepth:   1,
+				GossipQueueDepth: 1,
+			},
+			expectedErr: ErrInvalidQueueDepth,
+		},
+		{
+			name: "negative gossip depth",
+			config: PriorityConfig{
+				ConsensusQueueDepth: 1,
+				SyncQueueDepth:      1,
+				GossipQueueDepth:    -1,
+			},
+			expectedErr: ErrInvalidQueueDepth,
+		},
+		{
+			name: "sync exceeds consensus",
+			config: PriorityConfig{
+				ConsensusQueueDepth: 1,
+				SyncQueueDepth:      2,
+				GossipQueueDepth:    1,
+			},
+			expectedErr: ErrQueueDepthsUnordered,
+		},
+		{
+			name: "gossip exceeds sync",
+			config: PriorityConfig{
+				ConsensusQueueDepth: 2,
+				SyncQueueDepth:      1,
+				GossipQueueDepth:    2,
+			},
+			expectedErr: ErrQueueDepthsUnordered,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.config.Verify(), tt.expectedErr)
+		})
+	}
+}
+
+func TestPrioritizerShedsLowPriorityFirst(t *testing.T) {
+	require := require.New(t)
+
+	p, err := NewPrioritizer(
+		PriorityConfig{
+			ConsensusQueueDepth: 3,
+			SyncQueueDepth:      2,
+			GossipQueueDepth:    1,
+		},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	require.True(p.Acquire(GossipPriority))
+	require.False(p.Acquire(GossipPriority))
+
+	require.True(p.Acquire(SyncPriority))
+	require.False(p.Acquire(SyncPriority))
+	require.False(p.Acquire(GossipPriority))
+
+	require.True(p.Acquire(ConsensusPriority))
+	require.False(p.Acquire(ConsensusPriority))
+
+	require.Equal(1, p.Processing(GossipPriority))
+	require.Equal(1, p.Processing(SyncPriority))
+	require.Equal(1, p.Processing(ConsensusPriority))
+
+	p.Release(GossipPriority)
+	require.Zero(p.Processing(GossipPriority))
+	require.False(p.Acquire(GossipPriority))
+	require.False(p.Acquire(SyncPriority))
+	require.True(p.Acquire(ConsensusPriority))
+
+	// Releasing a class that isn't processing anything is a no-op
+	p.Release(GossipPriority)
+	require.False(p.Acquire(ConsensusPriority))
+}
+
+func TestPrioritizerMetrics(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	p, err := NewPrioritizer(
+		PriorityConfig{
+			ConsensusQueueDepth: 1,
+			SyncQueueDepth:      1,
+			GossipQueueDepth:    1,
+		},
+		"",
+		registry,
+	)
+	require.NoError(err)
+
+	require.True(p.Acquire(ConsensusPriority))
+	require.False(p.Acquire(GossipPriority))
+	require.False(p.Acquire(GossipPriority))
+
+	families, err := registry.Gather()
+	require.NoError(err)
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			name := family.GetName() + "_" + metric.GetLabel()[0].GetValue()
+			switch {
+			case metric.Counter != nil:
+				values[name] = metric.Counter.GetValue()
+			case metric.Gauge != nil:
+				values[name] = metric.Gauge.GetValue()
+			}
+		}
+	}
+	require.Equal(float64(1), values["priority_admitted_consensus"])
+	require.Equal(float64(1), values["priority_processing_consensus"])
+	require.Equal(float64(2), values["priority_shed_gossip"])
+}
+
+func TestPriorityHandlerAppGossip(t *testing.T) {
+	tests := []struct {
+		name     string
+		inFlight int
+		expected bool
+	}{
+		{
+			name:     "admitted",
+			expected: true,
+		},
+		{
+			name:     "shed",
+			inFlight: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			p, err := NewPrioritizer(
+				PriorityConfig{
+					ConsensusQueueDepth: 2,
+					SyncQueueDepth:      2,
+					GossipQueueDepth:    1,
+				},
+				"",
+				prometheus.NewRegistry(),
+			)
+			require.NoError(err)
+			for i := 0; i < tt.inFlight; i++ {
+				require.True(p.Acquire(ConsensusPriority))
+			}
+
+			called := false
+			handler := PriorityHandler{
+				Handler: testHandler{
+					appGossipF: func(context.Context, ids.NodeID, []byte) {
+						called = true
+					},
+				},
+				Priority:    GossipPriority,
+				Prioritizer: p,
+				Log:         logging.NoLog{},
+			}
+
+			handler.AppGossip(context.Background(), ids.GenerateTestNodeID(), []byte("foobar"))
+			require.Equal(tt.expected, called)
+			require.Zero(p.Processing(GossipPriority))
+		})
+	}
+}
+
+func TestPriorityHandlerAppRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		priority    Priority
+		expectedErr error
+	}{
+		{
+			name:     "consensus admitted",
+			priority: ConsensusPriority,
+		},
+		{
+			name:        "sync shed",
+			priority:    SyncPriority,
+			expectedErr: ErrShed,
+		},
+		{
+			name:        "gossip shed",
+			priority:    GossipPriority,
+			expectedErr: ErrShed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			p, err := NewPrioritizer(
+				PriorityConfig{
+					ConsensusQueueDepth: 2,
+					SyncQueueDepth:      1,
+					GossipQueueDepth:    1,
+				},
+				"",
+				prometheus.NewRegistry(),
+			)
+			require.NoError(err)
+			require.True(p.Acquire(SyncPriority))
+
+			handler := PriorityHandler{
+				Handler: testHandler{
+					appRequestF: func(context.Context, ids.NodeID, time.Time, []byte) ([]byte, error) {
+						require.Equal(1, p.Processing(tt.priority))
+						return nil, nil
+					},
+				},
+				Priority:    tt.priority,
+				Prioritizer: p,
+				Log:         logging.NoLog{},
+			}
+			_, err = handler.AppRequest(context.Background(), ids.GenerateTestNodeID(), time.Time{}, []byte("foobar"))
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
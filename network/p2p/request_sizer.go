@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	_ PeerTrackerCallbackListener = (*RequestSizer)(nil)
+
+	ErrInvalidMinRequestSize     = errors.New("invalid min request size")
+	ErrInvalidMaxRequestSize     = errors.New("invalid max request size")
+	ErrInvalidInitialRequestSize = errors.New("invalid initial request size")
+	ErrInvalidRequestSizeStep    = errors.New("invalid request size step")
+	ErrInvalidMaxBandwidthScale  = errors.New("invalid max bandwidth scale")
+
+	DefaultRequestSizerConfig = RequestSizerConfig{
+		MinSize:           16,
+		MaxSize:           1024,
+		InitialSize:       128,
+		IncreaseStep:      32,
+		MaxBandwidthScale: 4,
+	}
+)
+
+// RequestSizerConfig configures how a RequestSizer adapts request sizes.
+//
+// Sizes are in whatever unit the caller requests (e.g. blocks or leafs per
+// request).
+type RequestSizerConfig struct {
+	// MinSize is the smallest size that will be requested from a peer.
+	MinSize int `json:"minSize"`
+	// MaxSize is the largest size that will be requested from a peer.
+	MaxSize int `json:"maxSize"`
+	// InitialSize is the size requested from a peer before any of its requests
+	// have completed.
+	InitialSize int `json:"initialSize"`
+	// IncreaseStep is added to the size of a peer after each successful
+	// request.
+	IncreaseStep int `json:"increaseStep"`
+	// MaxBandwidthScale bounds how much the bandwidth of a peer, relative to
+	// the average bandwidth of all peers, can scale its size. A peer's size is
+	// scaled by a factor in [1/MaxBandwidthScale, MaxBandwidthScale].
+	MaxBandwidthScale float64 `json:"maxBandwidthScale"`
+}
+
+func (c RequestSizerConfig) Verify() error {
+	switch {
+	case c.MinSize <= 0:
+		return fmt.Errorf("%w: %d", ErrInvalidMinRequestSize, c.MinSize)
+	case c.MaxSize < c.MinSize:
+		return fmt.Errorf("%w: %d < %d", ErrInvalidMaxRequestSize, c.MaxSize, c.MinSize)
+	case c.InitialSize < c.MinSize || c.InitialSize > c.MaxSize:
+		return fmt.Errorf("%w: %d not in [%d, %d]", ErrInvalidInitialRequestSize, c.InitialSize, c.MinSize, c.MaxSize)
+	case c.IncreaseStep <= 0:
+		return fmt.Errorf("%w: %d", ErrInvalidRequestSizeStep, c.IncreaseStep)
+	case c.MaxBandwidthScale < 1 || math.IsInf(c.MaxBandwidthScale, 0) || math.IsNaN(c.MaxBandwidthScale):
+		return fmt.Errorf("%w: %f", ErrInvalidMaxBandwidthScale, c.MaxBandwidthScale)
+	default:
+		return nil
+	}
+}
+
+// RequestSizer adapts the size of the requests sent to each peer to the
+// throughput that peer has sustained.
+//
+// Every peer has a base size that grows by [IncreaseStep] after each
+// successful request and is halved after each timeout. The size requested
+// from a peer is its base size scaled by the ratio of the peer's bandwidth to
+// the average bandwidth tracked by the PeerTracker, so faster peers are asked
+// for more at a time than slower ones.
+type RequestSizer struct {
+	config      RequestSizerConfig
+	peerTracker *PeerTracker
+
+	lock sync.Mutex
+	// Base size of each peer that has completed at least one request
+	sizes map[ids.NodeID]int
+}
+
+// NewRequestSizer returns a RequestSizer that reads peer bandwidths from
+// [peerTracker]. The sizer is registered with [peerTracker] so that the sizes
+// of disconnected peers are forgotten.
+func NewRequestSizer(config RequestSizerConfig, peerTracker *PeerTracker) (*RequestSizer, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	r := &RequestSizer{
+		config:      config,
+		peerTracker: peerTracker,
+		sizes:       make(map[ids.NodeID]int),
+	}
+	peerTracker.RegisterCallbackListener(r)
+	return r, nil
+}
+
+// Size returns the size to request from [nodeID].
+func (r *RequestSizer) Size(nodeID ids.NodeID) int {
+	// The bandwidths are read before grabbing [r.lock] because the
+	// PeerTracker calls into the sizer while holding its own lock.
+	scale := r.bandwidthScale(nodeID)
+
+	r.lock.Lock()
+	size := r.baseSize(nodeID)
+	r.lock.Unlock()
+
+	return r.clamp(int(math.Round(float64(size) * scale)))
+}
+
+// OnSuccess records that a request to [nodeID] succeeded, increasing the size
+// of its future requests.
+func (r *RequestSizer) OnSuccess(nodeID ids.NodeID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.sizes[nodeID] = r.clamp(r.baseSize(nodeID) + r.config.IncreaseStep)
+}
+
+// OnTimeout records that a request to [nodeID] timed out, halving the size of
+// its future requests.
+func (r *RequestSizer) OnTimeout(nodeID ids.NodeID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.sizes[nodeID] = r.clamp(r.baseSize(nodeID) / 2)
+}
+
+func (*RequestSizer) OnConnected(ids.NodeID, *version.Application) {}
+
+func (r *RequestSizer) OnDisconnected(nodeID ids.NodeID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.sizes, nodeID)
+}
+
+func (*RequestSizer) OnResponsivenessChanged(ids.NodeID, bool) {}
+
+// Assumes r.lock is held.
+func (r *RequestSizer) baseSize(nodeID ids.NodeID) int {
+	size, ok := r.sizes[nodeID]
+	if !ok {
+		return r.config.InitialSize
+	}
+	return size
+}
+
+// bandwidthScale returns the ratio of the bandwidth of [nodeID] to the average
+// bandwidth, bounded by [MaxBandwidthScale]. Returns 1 if either bandwidth is
+// unknown.
+func (r *RequestSizer) bandwidthScale(nodeID ids.NodeID) float64 {
+	bandwidth, ok := r.peerTracker.Bandwidth(nodeID)
+	if !ok {
+		return 1
+	}
+	averageBandwidth := r.peerTracker.AverageBandwidth()
+	if averageBandwidth <= 0 {
+		return 1
+	}
+
+	scale := bandwidth / averageBandwidth
+	switch {
+	case scale > r.config.MaxBandwidthScale:
+		return r.config.MaxBandwidthScale
+	case scale < 1/r.config.MaxBandwidthScale:
+		return 1 / r.config.MaxBandwidthScale
+	default:
+		return scale
+	}
+}
+
+func (r *RequestSizer) clamp(size int) int {
+	switch {
+	case size < r.config.MinSize:
+		return r.config.MinSize
+	case size > r.config.MaxSize:
+		return r.config.MaxSize
+	default:
+		return size
+	}
+}
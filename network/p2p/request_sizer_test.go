@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestRequestSizerConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      func(*RequestSizerConfig)
+		expectedErr error
+	}{
+		{
+			name:   "default",
+			config: func(*RequestSizerConfig) {},
+		},
+		{
+			name: "fixed size",
+			config: func(c *RequestSizerConfig) {
+				c.MinSize = 10
+				c.MaxSize = 10
+				c.InitialSize = 10
+				c.MaxBandwidthScale = 1
+			},
+		},
+		{
+			name: "zero min size",
+			config: func(c *RequestSizerConfig) {
+				c.MinSize = 0
+			},
+			expectedErr: ErrInvalidMinRequestSize,
+		},
+		{
+			name: "max size below min size",
+			config: func(c *RequestSizerConfig) {
+				c.MaxSize = c.MinSize - 1
+			},
+			expectedErr: ErrInvalidMaxRequestSize,
+		},
+		{
+			name: "initial size below min size",
+			config: func(c *RequestSizerConfig) {
+				c.InitialSize = c.MinSize - 1
+			},
+			expectedErr: ErrInvalidInitialRequestSize,
+		},
+		{
+			name: "initial size above max size",
+			config: func(c *RequestSizerConfig) {
+				c.InitialSize = c.MaxSize + 1
+			},
+			expectedErr: ErrInvalidInitialRequestSize,
+		},
+		{
+			name: "zero increase step",
+			config: func(c *RequestSizerConfig) {
+				c.IncreaseStep = 0
+			},
+			expectedErr: ErrInvalidRequestSizeStep,
+		},
+		{
+			name: "bandwidth scale below one",
+			config: func(c *RequestSizerConfig) {
+				c.MaxBandwidthScale = 0.5
+			},
+			expectedErr: ErrInvalidMaxBandwidthScale,
+		},
+		{
+			name: "infinite bandwidth scale",
+			config: func(c *RequestSizerConfig) {
+				c.MaxBandwidthScale = math.Inf(1)
+			},
+			expectedErr: ErrInvalidMaxBandwidthScale,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultRequestSizerConfig
+			tt.config(&config)
+			err := config.Verify()
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestRequestSizerAdaptsToOutcomes(t *testing.T) {
+	require := require.New(t)
+
+	peerTracker, err := NewPeerTracker(DefaultPeerTrackerConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	config := RequestSizerConfig{
+		MinSize:           10,
+		MaxSize:           100,
+		InitialSize:       40,
+		IncreaseStep:      20,
+		MaxBandwidthScale: 2,
+	}
+	sizer, err := NewRequestSizer(config, peerTracker)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	peerTracker.Connected(nodeID, &version.Application{})
+	require.Equal(40, sizer.Size(nodeID))
+
+	sizer.OnSuccess(nodeID)
+	require.Equal(60, sizer.Size(nodeID))
+
+	sizer.OnSuccess(nodeID)
+	sizer.OnSuccess(nodeID)
+	require.Equal(100, sizer.Size(nodeID))
+
+	sizer.OnTimeout(nodeID)
+	require.Equal(50, sizer.Size(nodeID))
+
+	sizer.OnTimeout(nodeID)
+	sizer.OnTimeout(nodeID)
+	sizer.OnTimeout(nodeID)
+	require.Equal(10, sizer.Size(nodeID))
+
+	// Disconnecting forgets the peer's size
+	peerTracker.Disconnected(nodeID)
+	require.Equal(40, sizer.Size(nodeID))
+}
+
+func TestRequestSizerScalesByBandwidth(t *testing.T) {
+	require := require.New(t)
+
+	peerTracker, err := NewPeerTracker(DefaultPeerTrackerConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	config := RequestSizerConfig{
+		MinSize:           1,
+		MaxSize:           1000,
+		InitialSize:       100,
+		IncreaseStep:      1,
+		MaxBandwidthScale: 4,
+	}
+	sizer, err := NewRequestSizer(config, peerTracker)
+	require.NoError(err)
+
+	fastNodeID := ids.GenerateTestNodeID()
+	slowNodeID := ids.GenerateTestNodeID()
+	unknownNodeID := ids.GenerateTestNodeID()
+	for _, nodeID := range []ids.NodeID{fastNodeID, slowNodeID, unknownNodeID} {
+		peerTracker.Connected(nodeID, &version.Application{})
+	}
+	peerTracker.TrackBandwidth(fastNodeID, 1_000)
+	peerTracker.TrackBandwidth(slowNodeID, 1)
+
+	fastSize := sizer.Size(fastNodeID)
+	slowSize := sizer.Size(slowNodeID)
+	require.Greater(fastSize, config.InitialSize)
+	require.Less(slowSize, config.InitialSize)
+
+	// The bandwidth scale is bounded
+	require.LessOrEqual(fastSize, 4*config.InitialSize)
+	require.GreaterOrEqual(slowSize, config.InitialSize/4)
+
+	// Peers without a tracked bandwidth aren't scaled
+	require.Equal(config.InitialSize, sizer.Size(unknownNodeID))
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+var (
+	ErrInvalidResponse        = errors.New("invalid response")
+	ErrResponseTooLarge       = errors.New("response too large")
+	ErrUnexpectedResponseHash = errors.New("unexpected response hash")
+
+	_ ResponseValidator = ResponseValidatorFunc(nil)
+)
+
+// ResponseValidator validates a response to an AppRequest before it is handed
+// to the AppResponseCallback of the request.
+type ResponseValidator interface {
+	// ValidateResponse returns an error if [response], sent by [nodeID] for
+	// [request], is invalid.
+	ValidateResponse(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		request []byte,
+		response []byte,
+	) error
+}
+
+// ResponseValidatorFunc is an adapter to use a function as a
+// ResponseValidator.
+type ResponseValidatorFunc func(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	request []byte,
+	response []byte,
+) error
+
+func (f ResponseValidatorFunc) ValidateResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	request []byte,
+	response []byte,
+) error {
+	return f(ctx, nodeID, request, response)
+}
+
+// MaxResponseSize returns a ResponseValidator that rejects responses that are
+// larger than [maxSize] bytes.
+func MaxResponseSize(maxSize int) ResponseValidator {
+	return ResponseValidatorFunc(func(_ context.Context, _ ids.NodeID, _ []byte, response []byte) error {
+		if len(response) > maxSize {
+			return fmt.Errorf("%w: %d > %d", ErrResponseTooLarge, len(response), maxSize)
+		}
+		return nil
+	})
+}
+
+// ResponseHash returns a ResponseValidator that rejects responses whose hash
+// isn't the hash that [expectedHash] returns for the request. This is useful
+// for protocols that request content by its hash.
+func ResponseHash(expectedHash func(request []byte) (ids.ID, error)) ResponseValidator {
+	return ResponseValidatorFunc(func(_ context.Context, _ ids.NodeID, request []byte, response []byte) error {
+		expected, err := expectedHash(request)
+		if err != nil {
+			return err
+		}
+		if hash := ids.ID(hashing.ComputeHash256Array(response)); hash != expected {
+			return fmt.Errorf("%w: expected %s but got %s", ErrUnexpectedResponseHash, expected, hash)
+		}
+		return nil
+	})
+}
+
+// ResponseSchema returns a ResponseValidator that rejects responses that
+// [parse] fails to parse.
+func ResponseSchema(parse func(response []byte) error) ResponseValidator {
+	return ResponseValidatorFunc(func(_ context.Context, _ ids.NodeID, _ []byte, response []byte) error {
+		return parse(response)
+	})
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var errTestParse = errors.New("failed to parse")
+
+func TestResponseValidators(t *testing.T) {
+	request := []byte("request")
+	response := []byte("response")
+	responseHash := ids.ID(hashing.ComputeHash256Array(response))
+
+	tests := []struct {
+		name        string
+		validator   ResponseValidator
+		expectedErr error
+	}{
+		{
+			name:        "size within limit",
+			validator:   MaxResponseSize(len(response)),
+			expectedErr: nil,
+		},
+		{
+			name:        "size exceeds limit",
+			validator:   MaxResponseSize(len(response) - 1),
+			expectedErr: ErrResponseTooLarge,
+		},
+		{
+			name: "expected hash",
+			validator: ResponseHash(func([]byte) (ids.ID, error) {
+				return responseHash, nil
+			}),
+			expectedErr: nil,
+		},
+		{
+			name: "unexpected hash",
+			validator: ResponseHash(func([]byte) (ids.ID, error) {
+				return ids.GenerateTestID(), nil
+			}),
+			expectedErr: ErrUnexpectedResponseHash,
+		},
+		{
+			name: "unknown expected hash",
+			validator: ResponseHash(func([]byte) (ids.ID, error) {
+				return ids.Empty, errTestParse
+			}),
+			expectedErr: errTestParse,
+		},
+		{
+			name: "schema",
+			validator: ResponseSchema(func([]byte) error {
+				return nil
+			}),
+			expectedErr: nil,
+		},
+		{
+			name: "invalid schema",
+			validator: ResponseSchema(func([]byte) error {
+				return errTestParse
+			}),
+			expectedErr: errTestParse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validator.ValidateResponse(context.Background(), ids.GenerateTestNodeID(), request, response)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestClientResponseValidation(t *testing.T) {
+	request := []byte("request")
+	nodeID := ids.GenerateTestNodeID()
+
+	tests := []struct {
+		name             string
+		response         []byte
+		expectedResponse []byte
+		expectedErr      error
+	}{
+		{
+			name:             "valid response",
+			response:         []byte("ok"),
+			expectedResponse: []byte("ok"),
+			expectedErr:      nil,
+		},
+		{
+			name:             "invalid response",
+			response:         []byte("too large"),
+			expectedResponse: nil,
+			expectedErr:      ErrResponseTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			peers, err := NewPeerTracker(DefaultPeerTrackerConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+			require.NoError(err)
+			peers.Connected(nodeID, &version.Application{})
+			peers.TrackBandwidth(nodeID, 10)
+
+			sender := &common.SenderTest{}
+			n := NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+			client, err := n.NewAppProtocol(0, &NoOpHandler{}, WithResponseValidation(
+				peers,
+				MaxResponseSize(2),
+			))
+			require.NoError(err)
+
+			sender.SendAppRequestF = func(ctx context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+				go func() {
+					require.NoError(n.AppResponse(ctx, nodeID, requestID, tt.response))
+				}()
+				return nil
+			}
+
+			type result struct {
+				response []byte
+				err      error
+			}
+			resultChan := make(chan result, 1)
+			onResponse := func(_ context.Context, _ ids.NodeID, response []byte, err error) {
+				resultChan <- result{
+					response: response,
+					err:      err,
+				}
+			}
+			require.NoError(client.AppRequest(context.Background(), set.Of(nodeID), request, onResponse))
+
+			res := <-resultChan
+			require.ErrorIs(res.err, tt.expectedErr)
+			require.Equal(tt.expectedResponse, res.response)
+
+			// Only the peer that sent an invalid response is penalized
+			valid := tt.expectedErr == nil
+			require.Equal(valid, peers.responsivePeers.Contains(nodeID))
+			if !valid {
+				require.ErrorIs(res.err, ErrInvalidResponse)
+				require.Equal(1.0, testutil.ToFloat64(peers.numInvalidResponses))
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// epsilon is added to the observed bandwidth of a successful response so that
+// an empty response doesn't mark the peer as unresponsive.
+const epsilon = 1e-6
+
+var (
+	ErrInvalidMaxAttempts    = errors.New("invalid max attempts")
+	ErrInvalidRequestTimeout = errors.New("invalid request timeout")
+	ErrInvalidInitialBackoff = errors.New("invalid initial backoff")
+	ErrInvalidMaxBackoff     = errors.New("invalid max backoff")
+	ErrInvalidBackoffJitter  = errors.New("invalid backoff jitter")
+	ErrRetriesExhausted      = errors.New("request retries exhausted")
+
+	errRequestTimeout = errors.New("request timed out")
+
+	DefaultRetryConfig = RetryConfig{
+		MaxAttempts:    5,
+		RequestTimeout: 10 * time.Second,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		BackoffJitter:  0.5,
+	}
+)
+
+// RetryConfig configures how a RetryClient retries failed requests.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of peers a request is sent to before
+	// giving up.
+	MaxAttempts int `json:"maxAttempts"`
+	// RequestTimeout is how long to wait for a response from a peer before
+	// trying another peer.
+	RequestTimeout time.Duration `json:"requestTimeout"`
+	// InitialBackoff is how long to wait before the second attempt. The
+	// backoff doubles after every failed attempt, up to [MaxBackoff].
+	InitialBackoff time.Duration `json:"initialBackoff"`
+	// MaxBackoff is the maximum time to wait between two attempts.
+	MaxBackoff time.Duration `json:"maxBackoff"`
+	// BackoffJitter is the fraction of the backoff that is randomly removed
+	// to avoid many clients retrying in lockstep. Must be in [0, 1].
+	BackoffJitter float64 `json:"backoffJitter"`
+}
+
+func (c RetryConfig) Verify() error {
+	switch {
+	case c.MaxAttempts <= 0:
+		return fmt.Errorf("%w: %d", ErrInvalidMaxAttempts, c.MaxAttempts)
+	case c.RequestTimeout <= 0:
+		return fmt.Errorf("%w: %s", ErrInvalidRequestTimeout, c.RequestTimeout)
+	case c.InitialBackoff < 0:
+		return fmt.Errorf("%w: %s", ErrInvalidInitialBackoff, c.InitialBackoff)
+	case c.MaxBackoff < c.InitialBackoff:
+		return fmt.Errorf("%w: %s < %s", ErrInvalidMaxBackoff, c.MaxBackoff, c.InitialBackoff)
+	case c.BackoffJitter < 0 || c.BackoffJitter > 1 || math.IsNaN(c.BackoffJitter):
+		return fmt.Errorf("%w: %f", ErrInvalidBackoffJitter, c.BackoffJitter)
+	default:
+		return nil
+	}
+}
+
+// RetryClient issues requests through a Client, retrying against a different
+// peer selected by a PeerTracker until a response is received.
+type RetryClient struct {
+	client *Client
+	peers  *PeerTracker
+	// If non-nil, limits the number of requests in flight to each peer
+	limiter *OutboundLimiter
+	config  RetryConfig
+	log     logging.Logger
+}
+
+// NewRetryClient returns a RetryClient that selects peers from [peers]. If
+// [limiter] is non-nil, peers that have too many requests in flight are
+// skipped.
+func NewRetryClient(
+	client *Client,
+	peers *PeerTracker,
+	limiter *OutboundLimiter,
+	config RetryConfig,
+	log logging.Logger,
+) (*RetryClient, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	return &RetryClient{
+		client:  client,
+		peers:   peers,
+		limiter: limiter,
+		config:  config,
+		log:     log,
+	}, nil
+}
+
+// AppRequestAny sends [request] to a peer with version >= [minVersion] and
+// blocks until a response is received. If the request fails or times out, it
+// is retried against a different peer after a jittered backoff.
+//
+// Returns the peer that responded and its response. Returns
+// [ErrRetriesExhausted] if no peer responded after [MaxAttempts] attempts.
+func (r *RetryClient) AppRequestAny(
+	ctx context.Context,
+	minVersion *version.Application,
+	request []byte,
+) (ids.NodeID, []byte, error) {
+	var (
+		tried   set.Set[ids.NodeID]
+		lastErr error
+	)
+	for attempt := 0; attempt < r.config.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			if err := r.wait(ctx, attempt); err != nil {
+				return ids.EmptyNodeID, nil, err
+			}
+		}
+
+		nodeID, err := r.selectPeer(minVersion, tried)
+		if errors.Is(err, ErrPeerBusy) {
+			// Every selected peer is busy, so the request is retried once
+			// some of their requests may have completed.
+			r.log.Debug("retrying request to busy peers",
+				zap.Int("attempt", attempt+1),
+				zap.Error(err),
+			)
+			lastErr = err
+			continue
+		}
+		if err != nil {
+			return ids.EmptyNodeID, nil, err
+		}
+		tried.Add(nodeID)
+
+		response, err := r.request(ctx, nodeID, request)
+		r.release(nodeID)
+		if err == nil {
+			return nodeID, response, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ids.EmptyNodeID, nil, ctxErr
+		}
+
+		r.log.Debug("retrying failed request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Int("attempt", attempt+1),
+			zap.Error(err),
+		)
+		lastErr = err
+	}
+	return ids.EmptyNodeID, nil, fmt.Errorf(
+		"%w after %d attempts: %w",
+		ErrRetriesExhausted,
+		r.config.MaxAttempts,
+		lastErr,
+	)
+}
+
+// selectPeer returns a peer that hasn't been [tried] yet, if one can be found.
+// Otherwise, a previously tried peer is returned. If [r.limiter] is non-nil,
+// busy peers are skipped and an in-flight request to the returned peer is
+// reserved.
+//
+// Returns [ErrPeerBusy] if every selected peer was busy and [ErrNoPeers] if no
+// peer could be selected.
+func (r *RetryClient) selectPeer(
+	minVersion *version.Application,
+	tried set.Set[ids.NodeID],
+) (ids.NodeID, error) {
+	var (
+		triedPeer    ids.NodeID
+		hasTriedPeer bool
+		busyErr      error
+	)
+	// Every peer we've already tried may be returned before an untried one,
+	// so [tried.Len()]+1 samples are needed before giving up.
+	for i := 0; i <= tried.Len(); i++ {
+		peer, ok := r.peers.GetAnyPeer(minVersion)
+		if !ok {
+			break
+		}
+		if tried.Contains(peer) {
+			triedPeer, hasTriedPeer = peer, true
+			continue
+		}
+		if err := r.acquire(peer); err != nil {
+			busyErr = err
+			continue
+		}
+		return peer, nil
+	}
+	if hasTriedPeer {
+		err := r.acquire(triedPeer)
+		if err == nil {
+			return triedPeer, nil
+		}
+		busyErr = err
+	}
+	if busyErr != nil {
+		return ids.EmptyNodeID, busyErr
+	}
+	return ids.EmptyNodeID, fmt.Errorf(
+		"%w: found none matching version %s out of %d peers",
+		ErrNoPeers,
+		minVersion,
+		r.peers.Size(),
+	)
+}
+
+// acquire reserves an in-flight request to [nodeID] if requests are limited.
+func (r *RetryClient) acquire(nodeID ids.NodeID) error {
+	if r.limiter == nil {
+		return nil
+	}
+	return r.limiter.Acquire(nodeID)
+}
+
+// release releases the in-flight request to [nodeID] reserved by acquire.
+func (r *RetryClient) release(nodeID ids.NodeID) {
+	if r.limiter != nil {
+		r.limiter.Release(nodeID)
+	}
+}
+
+// request sends [request] to [nodeID] and blocks until a response is received,
+// the request fails, or [RequestTimeout] elapses. The observed bandwidth of
+// [nodeID] is reported to the PeerTracker.
+func (r *RetryClient) request(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	request []byte,
+) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.config.RequestTimeout)
+	defer cancel()
+
+	type result struct {
+		response []byte
+		err      error
+	}
+	// Buffered so that a response arriving after the timeout doesn't block
+	// the router.
+	resultChan := make(chan result, 1)
+	onResponse := func(_ context.Context, _ ids.NodeID, response []byte, err error) {
+		resultChan <- result{
+			response: response,
+			err:      err,
+		}
+	}
+
+	r.peers.TrackPeer(nodeID)
+	startTime := time.Now()
+	if err := r.client.AppRequest(ctx, set.Of(nodeID), request, onResponse); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		r.peers.RegisterTimeout(nodeID)
+		return nil, fmt.Errorf("%w: %w", errRequestTimeout, ctx.Err())
+	case res := <-resultChan:
+		if res.err != nil {
+			r.peers.TrackBandwidth(nodeID, 0)
+			return nil, res.err
+		}
+		elapsedSeconds := time.Since(startTime).Seconds()
+		bandwidth := float64(len(res.response))/elapsedSeconds + epsilon
+		r.peers.TrackBandwidth(nodeID, bandwidth)
+		return res.response, nil
+	}
+}
+
+// wait blocks for the backoff before the given [attempt], or until [ctx] is
+// canceled.
+func (r *RetryClient) wait(ctx context.Context, attempt int) error {
+	backoff := r.config.InitialBackoff
+	for i := 1; i < attempt && backoff < r.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > r.config.MaxBackoff {
+		backoff = r.config.MaxBackoff
+	}
+	jitter := rand.Float64() * r.config.BackoffJitter // #nosec G404
+	backoff -= time.Duration(jitter * float64(backoff))
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
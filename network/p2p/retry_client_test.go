@@ -0,0 +1,277 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestRetryConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*RetryConfig)
+		expectedErr error
+	}{
+		{
+			name:   "default",
+			modify: func(*RetryConfig) {},
+		},
+		{
+			name: "zero max attempts",
+			modify: func(c *RetryConfig) {
+				c.MaxAttempts = 0
+			},
+			expectedErr: ErrInvalidMaxAttempts,
+		},
+		{
+			name: "zero request timeout",
+			modify: func(c *RetryConfig) {
+				c.RequestTimeout = 0
+			},
+			expectedErr: ErrInvalidRequestTimeout,
+		},
+		{
+			name: "negative initial backoff",
+			modify: func(c *RetryConfig) {
+				c.InitialBackoff = -1
+			},
+			expectedErr: ErrInvalidInitialBackoff,
+		},
+		{
+			name: "max backoff less than initial backoff",
+			modify: func(c *RetryConfig) {
+				c.MaxBackoff = c.InitialBackoff - 1
+			},
+			expectedErr: ErrInvalidMaxBackoff,
+		},
+		{
+			name: "jitter greater than 1",
+			modify: func(c *RetryConfig) {
+				c.BackoffJitter = 1.1
+			},
+			expectedErr: ErrInvalidBackoffJitter,
+		},
+		{
+			name: "NaN jitter",
+			modify: func(c *RetryConfig) {
+				c.BackoffJitter = math.NaN()
+			},
+			expectedErr: ErrInvalidBackoffJitter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultRetryConfig
+			tt.modify(&config)
+			err := config.Verify()
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+// newTestRetryClient returns a RetryClient connected to [peers] whose requests
+// are answered by [respond]. If [respond] returns false, the request fails.
+func newTestRetryClient(
+	t *testing.T,
+	config RetryConfig,
+	peers []ids.NodeID,
+	respond func(nodeID ids.NodeID) ([]byte, bool),
+) *RetryClient {
+	require := require.New(t)
+
+	var network *Network
+	sender := &common.SenderTest{
+		SendAppRequestF: func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+			for nodeID := range nodeIDs {
+				nodeID := nodeID
+				go func() {
+					response, ok := respond(nodeID)
+					if !ok {
+						require.NoError(network.AppRequestFailed(ctx, nodeID, requestID))
+						return
+					}
+					if response != nil {
+						require.NoError(network.AppResponse(ctx, nodeID, requestID, response))
+					}
+				}()
+			}
+			return nil
+		},
+	}
+	network = NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	client, err := network.NewAppProtocol(1, nil)
+	require.NoError(err)
+
+	peerTracker, err := NewPeerTracker(DefaultPeerTrackerConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	for _, nodeID := range peers {
+		peerTracker.Connected(nodeID, &version.Application{})
+	}
+
+	retryClient, err := NewRetryClient(client, peerTracker, nil, config, logging.NoLog{})
+	require.NoError(err)
+	return retryClient
+}
+
+func TestRetryClientRotatesPeers(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultRetryConfig
+	config.InitialBackoff = time.Millisecond
+	config.MaxBackoff = time.Millisecond
+
+	var (
+		lock      sync.Mutex
+		requested []ids.NodeID
+	)
+	peers := []ids.NodeID{
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+	}
+	response := []byte("response")
+	retryClient := newTestRetryClient(t, config, peers, func(nodeID ids.NodeID) ([]byte, bool) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		requested = append(requested, nodeID)
+		// Only the second peer that is requested responds.
+		return response, len(requested) > 1
+	})
+
+	nodeID, gotResponse, err := retryClient.AppRequestAny(context.Background(), nil, []byte("request"))
+	require.NoError(err)
+	require.Equal(response, gotResponse)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	require.Len(requested, 2)
+	require.NotEqual(requested[0], requested[1])
+	require.Equal(requested[1], nodeID)
+}
+
+func TestRetryClientTimeout(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultRetryConfig
+	config.RequestTimeout = time.Millisecond
+	config.InitialBackoff = 0
+	config.MaxBackoff = 0
+
+	var (
+		lock         sync.Mutex
+		numRequested int
+	)
+	response := []byte("response")
+	retryClient := newTestRetryClient(t, config, []ids.NodeID{ids.GenerateTestNodeID()}, func(ids.NodeID) ([]byte, bool) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		numRequested++
+		if numRequested == 1 {
+			// Never respond to the first request.
+			return nil, true
+		}
+		return response, true
+	})
+
+	_, gotResponse, err := retryClient.AppRequestAny(context.Background(), nil, []byte("request"))
+	require.NoError(err)
+	require.Equal(response, gotResponse)
+}
+
+func TestRetryClientRetriesExhausted(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultRetryConfig
+	config.MaxAttempts = 3
+	config.InitialBackoff = 0
+	config.MaxBackoff = 0
+
+	var (
+		lock         sync.Mutex
+		numRequested int
+	)
+	retryClient := newTestRetryClient(t, config, []ids.NodeID{ids.GenerateTestNodeID()}, func(ids.NodeID) ([]byte, bool) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		numRequested++
+		return nil, false
+	})
+
+	_, _, err := retryClient.AppRequestAny(context.Background(), nil, []byte("request"))
+	require.ErrorIs(err, ErrRetriesExhausted)
+	require.ErrorIs(err, ErrAppRequestFailed)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	require.Equal(config.MaxAttempts, numRequested)
+}
+
+func TestRetryClientNoPeers(t *testing.T) {
+	require := require.New(t)
+
+	retryClient := newTestRetryClient(t, DefaultRetryConfig, nil, func(ids.NodeID) ([]byte, bool) {
+		require.FailNow("unexpected request")
+		return nil, false
+	})
+
+	_, _, err := retryClient.AppRequestAny(context.Background(), nil, []byte("request"))
+	require.ErrorIs(err, ErrNoPeers)
+}
+
+func TestRetryClientSkipsBusyPeers(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultRetryConfig
+	config.InitialBackoff = time.Millisecond
+	config.MaxBackoff = time.Millisecond
+
+	nodeID := ids.GenerateTestNodeID()
+	response := []byte("response")
+	retryClient := newTestRetryClient(t, config, []ids.NodeID{nodeID}, func(ids.NodeID) ([]byte, bool) {
+		return response, true
+	})
+
+	limiter, err := NewOutboundLimiter(
+		OutboundLimiterConfig{MaxInFlightRequests: 1},
+		retryClient.peers,
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	retryClient.limiter = limiter
+
+	// The only peer is busy, so the request is never sent
+	require.NoError(limiter.Acquire(nodeID))
+	_, _, err = retryClient.AppRequestAny(context.Background(), nil, []byte("request"))
+	require.ErrorIs(err, ErrRetriesExhausted)
+	require.ErrorIs(err, ErrPeerBusy)
+
+	// Once the peer is no longer busy, the request is sent to it
+	limiter.Release(nodeID)
+	respondedNodeID, gotResponse, err := retryClient.AppRequestAny(context.Background(), nil, []byte("request"))
+	require.NoError(err)
+	require.Equal(nodeID, respondedNodeID)
+	require.Equal(response, gotResponse)
+
+	// The request is no longer in flight once it completed
+	require.Empty(limiter.inFlight)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/snow/networking/frontier"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
@@ -47,9 +48,16 @@ type Config struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker tracker.ResourceTracker
 
+	// Tracks the validity of the messages sent by each peer.
+	ReputationTracker ReputationTracker
+
 	// Calculates uptime of peers
 	UptimeCalculator uptime.Calculator
 
 	// Signs my IP so I can send my signed IP address in the Version message
 	IPSigner *IPSigner
+
+	// Provides signed attestations of the accepted frontier of my chains to
+	// light clients
+	FrontierAttestor frontier.Attestor
 }
@@ -4,13 +4,41 @@
 package peer
 
 import (
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
+
+	bloomfilter "github.com/holiman/bloomfilter/v2"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errInvalidMaxKnownPerPeer = errors.New("max known validators per peer must be positive")
+	errInvalidReconnectTTL    = errors.New("reconnect TTL must be non-negative")
+)
+
+const (
+	// validatorOverhead is the estimated number of bytes used to track a
+	// validator. Each validator is stored in [validatorIDs], [txIDsToNodeIDs]
+	// and [nodeIDsToIndices], along with its entries in [knownBy] and
+	// [addedAt].
+	validatorOverhead = 2*(ids.NodeIDLen+ids.IDLen) + ids.NodeIDLen + 3*constants.PointerOverhead
+	// peerOverhead is the estimated number of bytes used to track a peer,
+	// excluding the contents of its bitset.
+	peerOverhead = 2*ids.NodeIDLen + 4*constants.PointerOverhead
 )
 
 // GossipTracker tracks the validators that we're currently aware of, as well as
@@ -39,6 +67,16 @@ import (
 // already know about. The only case where we'll send a redundant set of
 // bytes is if another remote peer gossips to the same peer we're trying to
 // gossip to first.
+//
+// To bound memory, each peer is recorded as knowing about at most a configured
+// number of validators. Once a peer exceeds this limit, which can happen as
+// the validator set churns, the validators that have been tracked the longest
+// are forgotten first and may be gossiped to the peer again.
+//
+// When a peer stops being tracked, the validators it knows about are
+// remembered for a configured TTL. If the peer starts being tracked again
+// within the TTL, only the validators that were added or reset since it
+// stopped being tracked are gossiped to it again.
 type GossipTracker interface {
 	// Tracked returns if a peer is being tracked
 	// Returns:
@@ -80,11 +118,50 @@ type GossipTracker interface {
 		knownTxIDs []ids.ID,
 		txIDs []ids.ID,
 	) ([]ids.ID, bool)
-	// GetUnknown gets the peers that we haven't sent to this peer
+	// AddGossip records that [peerID] gossiped [numGossiped] validator IPs to
+	// us, of which [numUseful] updated our view of the validator set.
+	// Returns:
+	// 	bool: False if [peerID] is not tracked. True otherwise.
+	AddGossip(peerID ids.NodeID, numUseful int, numGossiped int) bool
+	// GossipUsefulness returns how useful the gossip of [peerID] has been.
+	// Returns:
+	// 	uint64: the number of validator IPs gossiped by [peerID] that were
+	// 	useful.
+	// 	uint64: the number of validator IPs gossiped by [peerID].
+	// 	bool: False if [peerID] is not tracked. True otherwise.
+	GossipUsefulness(peerID ids.NodeID) (uint64, uint64, bool)
+
+	// GetUnknown gets the peers that we haven't sent to this peer. If [weight]
+	// is non-nil, the validators are ordered by descending weight, as reported
+	// by [weight], so that the validators with the most stake are gossiped
+	// first. Otherwise, the order is unspecified.
 	// Returns:
 	// 	[]ValidatorID: a slice of ValidatorIDs that [peerID] doesn't know about.
 	// 	bool: False if [peerID] is not tracked. True otherwise.
-	GetUnknown(peerID ids.NodeID) ([]ValidatorID, bool)
+	GetUnknown(peerID ids.NodeID, weight func(ids.NodeID) uint64) ([]ValidatorID, bool)
+
+	// BuildFilter builds a bloom filter of the txIDs of the validators in
+	// [knownValidators] to be sent to peers during the handshake.
+	// Returns:
+	// 	[]byte: the marshalled bloom filter.
+	// 	[]byte: the salt used when populating the bloom filter.
+	BuildFilter(knownValidators set.Set[ids.NodeID]) ([]byte, []byte, error)
+	// ApplyFilter marks the validators whose txIDs are in the bloom filter
+	// built by [peerID] as known by [peerID].
+	//
+	// Due to false positives, [peerID] may not actually know about all of the
+	// marked validators. They will be gossiped to [peerID] once they are
+	// reset.
+	// Returns:
+	// 	bool: False if [peerID] is not tracked. True otherwise.
+	ApplyFilter(peerID ids.NodeID, filter []byte, salt []byte) (bool, error)
+}
+
+// gossipUsefulness is the number of validator IPs gossiped by a peer and how
+// many of them were useful.
+type gossipUsefulness struct {
+	numUseful   uint64
+	numGossiped uint64
 }
 
 type gossipTracker struct {
@@ -96,27 +173,83 @@ type gossipTracker struct {
 	nodeIDsToIndices map[ids.NodeID]int
 	// each validator in the index it occupies in the bitset
 	validatorIDs []ValidatorID
+	// the order in which the validator in each index of the bitsets was added
+	addedAt []uint64
+	// the order that will be assigned to the next added validator
+	nextAddedAt uint64
+	// the maximum number of validators that each peer is recorded as knowing
+	maxKnownPerPeer int
 	// a mapping of each peer => the validators they know about
 	trackedPeers map[ids.NodeID]set.Bits
+	// a mapping of each peer => the usefulness of the validator IPs they
+	// gossiped to us
+	peerGossip map[ids.NodeID]gossipUsefulness
+
+	// the number of tracked peers that know about the validator in each index
+	// of the bitsets
+	knownBy []int
+	// the total number of bits set across all the bitsets in [trackedPeers]
+	numKnown int
+	// the number of validators that no tracked peer knows about
+	numUncovered int
+
+	// the number of gossip messages received from tracked peers
+	numMessages uint64
+	// the number of gossip messages received from tracked peers that marked
+	// at least one validator as newly known
+	numNewInfoMessages uint64
+
+	// how long the validators known by a peer are remembered after the peer
+	// stops being tracked
+	reconnectTTL time.Duration
+	// a mapping of each peer that recently stopped being tracked => the
+	// validators they knew about, ordered by when they stopped being tracked
+	disconnectedPeers linkedhashmap.LinkedHashmap[ids.NodeID, *disconnectedPeer]
+	clock             mockable.Clock
 
 	metrics gossipTrackerMetrics
 }
 
-// NewGossipTracker returns an instance of gossipTracker
+// disconnectedPeer is what a peer knew about when it stopped being tracked.
+type disconnectedPeer struct {
+	// the validators the peer knew about. Unlike the bitsets of tracked peers,
+	// these aren't counted in [knownBy] and [numKnown].
+	known set.Bits
+	// the time after which the known validators are forgotten
+	expiry time.Time
+}
+
+// NewGossipTracker returns an instance of gossipTracker that records each
+// peer as knowing about at most [maxKnownPerPeer] validators. The validators
+// known by a peer are remembered for [reconnectTTL] after it stops being
+// tracked. If [reconnectTTL] is 0, they are forgotten immediately.
 func NewGossipTracker(
 	registerer prometheus.Registerer,
 	namespace string,
+	maxKnownPerPeer int,
+	reconnectTTL time.Duration,
 ) (GossipTracker, error) {
+	if maxKnownPerPeer <= 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidMaxKnownPerPeer, maxKnownPerPeer)
+	}
+	if reconnectTTL < 0 {
+		return nil, fmt.Errorf("%w: %s", errInvalidReconnectTTL, reconnectTTL)
+	}
+
 	m, err := newGossipTrackerMetrics(registerer, fmt.Sprintf("%s_gossip_tracker", namespace))
 	if err != nil {
 		return nil, err
 	}
 
 	return &gossipTracker{
-		txIDsToNodeIDs:   make(map[ids.ID]ids.NodeID),
-		nodeIDsToIndices: make(map[ids.NodeID]int),
-		trackedPeers:     make(map[ids.NodeID]set.Bits),
-		metrics:          m,
+		txIDsToNodeIDs:    make(map[ids.ID]ids.NodeID),
+		nodeIDsToIndices:  make(map[ids.NodeID]int),
+		trackedPeers:      make(map[ids.NodeID]set.Bits),
+		peerGossip:        make(map[ids.NodeID]gossipUsefulness),
+		maxKnownPerPeer:   maxKnownPerPeer,
+		reconnectTTL:      reconnectTTL,
+		disconnectedPeers: linkedhashmap.New[ids.NodeID, *disconnectedPeer](),
+		metrics:           m,
 	}, nil
 }
 
@@ -138,11 +271,26 @@ func (g *gossipTracker) StartTrackingPeer(peerID ids.NodeID) bool {
 	}
 
 	// start tracking the peer. Initialize their bitset to zero since we
-	// haven't sent them anything yet.
-	g.trackedPeers[peerID] = set.NewBits()
+	// haven't sent them anything yet, unless they recently stopped being
+	// tracked.
+	knownPeers := set.NewBits()
+	g.trackedPeers[peerID] = knownPeers
+
+	g.pruneDisconnectedPeers()
+	if disconnected, ok := g.disconnectedPeers.Get(peerID); ok {
+		g.disconnectedPeers.Delete(peerID)
+		for i := range g.validatorIDs {
+			if disconnected.known.Contains(i) {
+				g.markKnown(knownPeers, i)
+			}
+		}
+		g.metrics.resumedPeers.Inc()
+	}
 
 	// emit metrics
 	g.metrics.trackedPeersSize.Set(float64(len(g.trackedPeers)))
+	g.metrics.disconnectedPeersSize.Set(float64(g.disconnectedPeers.Len()))
+	g.updateCoverageMetrics()
 
 	return true
 }
@@ -152,13 +300,33 @@ func (g *gossipTracker) StopTrackingPeer(peerID ids.NodeID) bool {
 	defer g.lock.Unlock()
 
 	// only stop tracking peers that are actually being tracked
-	if _, ok := g.trackedPeers[peerID]; !ok {
+	knownPeers, ok := g.trackedPeers[peerID]
+	if !ok {
 		return false
 	}
 
+	// remember what the peer knew about in case they reconnect soon
+	g.pruneDisconnectedPeers()
+	if g.reconnectTTL > 0 {
+		known := set.NewBits()
+		known.Union(knownPeers)
+		g.disconnectedPeers.Put(peerID, &disconnectedPeer{
+			known:  known,
+			expiry: g.clock.Time().Add(g.reconnectTTL),
+		})
+	}
+
+	// forget everything the peer knew about before removing them
+	for i := range g.validatorIDs {
+		g.markUnknown(knownPeers, i)
+	}
+
 	// stop tracking the peer by removing them
 	delete(g.trackedPeers, peerID)
+	delete(g.peerGossip, peerID)
 	g.metrics.trackedPeersSize.Set(float64(len(g.trackedPeers)))
+	g.metrics.disconnectedPeersSize.Set(float64(g.disconnectedPeers.Len()))
+	g.updateCoverageMetrics()
 
 	return true
 }
@@ -180,9 +348,14 @@ func (g *gossipTracker) AddValidator(validator ValidatorID) bool {
 	g.txIDsToNodeIDs[validator.TxID] = validator.NodeID
 	g.nodeIDsToIndices[validator.NodeID] = msb
 	g.validatorIDs = append(g.validatorIDs, validator)
+	g.addedAt = append(g.addedAt, g.nextAddedAt)
+	g.nextAddedAt++
+	g.knownBy = append(g.knownBy, 0)
+	g.numUncovered++
 
 	// emit metrics
 	g.metrics.validatorsSize.Set(float64(len(g.validatorIDs)))
+	g.updateCoverageMetrics()
 
 	return true
 }
@@ -206,6 +379,12 @@ func (g *gossipTracker) RemoveValidator(validatorID ids.NodeID) bool {
 	}
 	validatorToRemove := g.validatorIDs[indexToRemove]
 
+	// the validator is no longer known by anyone once it's removed
+	g.numKnown -= g.knownBy[indexToRemove]
+	if g.knownBy[indexToRemove] == 0 {
+		g.numUncovered--
+	}
+
 	// swap the validator-to-be-removed with the validator in the last index
 	// if the element we're swapping with is ourselves, we can skip this swap
 	// since we only need to delete instead
@@ -215,29 +394,30 @@ func (g *gossipTracker) RemoveValidator(validatorID ids.NodeID) bool {
 
 		g.nodeIDsToIndices[lastValidator.NodeID] = indexToRemove
 		g.validatorIDs[indexToRemove] = lastValidator
+		g.addedAt[indexToRemove] = g.addedAt[lastIndex]
+		g.knownBy[indexToRemove] = g.knownBy[lastIndex]
 	}
 
 	delete(g.txIDsToNodeIDs, validatorToRemove.TxID)
 	delete(g.nodeIDsToIndices, validatorID)
 	g.validatorIDs = g.validatorIDs[:lastIndex]
+	g.addedAt = g.addedAt[:lastIndex]
+	g.knownBy = g.knownBy[:lastIndex]
 
 	// Invariant: We must remove the validator from everyone else's validator
 	// bitsets to make sure that each validator occupies the same position in
 	// each bitset.
 	for _, knownPeers := range g.trackedPeers {
-		// swap the element to be removed with the msb
-		if indexToRemove != lastIndex {
-			if knownPeers.Contains(lastIndex) {
-				knownPeers.Add(indexToRemove)
-			} else {
-				knownPeers.Remove(indexToRemove)
-			}
-		}
-		knownPeers.Remove(lastIndex)
+		swapRemove(knownPeers, indexToRemove, lastIndex)
+	}
+	it := g.disconnectedPeers.NewIterator()
+	for it.Next() {
+		swapRemove(it.Value().known, indexToRemove, lastIndex)
 	}
 
 	// emit metrics
 	g.metrics.validatorsSize.Set(float64(len(g.validatorIDs)))
+	g.updateCoverageMetrics()
 
 	return true
 }
@@ -253,9 +433,14 @@ func (g *gossipTracker) ResetValidator(validatorID ids.NodeID) bool {
 	}
 
 	for _, knownPeers := range g.trackedPeers {
-		knownPeers.Remove(indexToReset)
+		g.markUnknown(knownPeers, indexToReset)
+	}
+	it := g.disconnectedPeers.NewIterator()
+	for it.Next() {
+		it.Value().known.Remove(indexToReset)
 	}
 
+	g.updateCoverageMetrics()
 	return true
 }
 
@@ -275,6 +460,8 @@ func (g *gossipTracker) AddKnown(
 	if !ok {
 		return nil, false
 	}
+
+	newInfo := false
 	for _, txID := range knownTxIDs {
 		nodeID, ok := g.txIDsToNodeIDs[txID]
 		if !ok {
@@ -287,8 +474,18 @@ func (g *gossipTracker) AddKnown(
 		// Because we fetched the nodeID from [g.txIDsToNodeIDs], we are
 		// guaranteed that the index is populated.
 		index := g.nodeIDsToIndices[nodeID]
-		knownPeers.Add(index)
+		if g.markKnown(knownPeers, index) {
+			newInfo = true
+		}
 	}
+	g.evictOldest(knownPeers)
+
+	g.numMessages++
+	if newInfo {
+		g.numNewInfoMessages++
+	}
+	g.metrics.gossipEfficiency.Set(float64(g.numNewInfoMessages) / float64(g.numMessages))
+	g.updateCoverageMetrics()
 
 	validatorTxIDs := make([]ids.ID, 0, len(txIDs))
 	for _, txID := range txIDs {
@@ -299,7 +496,34 @@ func (g *gossipTracker) AddKnown(
 	return validatorTxIDs, true
 }
 
-func (g *gossipTracker) GetUnknown(peerID ids.NodeID) ([]ValidatorID, bool) {
+func (g *gossipTracker) AddGossip(peerID ids.NodeID, numUseful int, numGossiped int) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if _, ok := g.trackedPeers[peerID]; !ok {
+		return false
+	}
+
+	usefulness := g.peerGossip[peerID]
+	usefulness.numUseful += uint64(numUseful)
+	usefulness.numGossiped += uint64(numGossiped)
+	g.peerGossip[peerID] = usefulness
+	return true
+}
+
+func (g *gossipTracker) GossipUsefulness(peerID ids.NodeID) (uint64, uint64, bool) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.trackedPeers[peerID]; !ok {
+		return 0, 0, false
+	}
+
+	usefulness := g.peerGossip[peerID]
+	return usefulness.numUseful, usefulness.numGossiped, true
+}
+
+func (g *gossipTracker) GetUnknown(peerID ids.NodeID, weight func(ids.NodeID) uint64) ([]ValidatorID, bool) {
 	g.lock.RLock()
 	defer g.lock.RUnlock()
 
@@ -318,6 +542,191 @@ func (g *gossipTracker) GetUnknown(peerID ids.NodeID) ([]ValidatorID, bool) {
 			result = append(result, validatorID)
 		}
 	}
+	if weight == nil {
+		return result, true
+	}
 
+	// Fetch the weights up front so that [weight] is called only once per
+	// validator.
+	weights := make(map[ids.NodeID]uint64, len(result))
+	for _, validatorID := range result {
+		weights[validatorID.NodeID] = weight(validatorID.NodeID)
+	}
+	slices.SortStableFunc(result, func(i, j ValidatorID) bool {
+		return weights[i.NodeID] > weights[j.NodeID]
+	})
 	return result, true
 }
+
+func (g *gossipTracker) BuildFilter(knownValidators set.Set[ids.NodeID]) ([]byte, []byte, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	filter, err := bloomfilter.NewOptimal(
+		safemath.Max(uint64(len(g.validatorIDs)), 1),
+		knownPeersFalsePositiveProbability,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var salt ids.ID
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, nil, err
+	}
+
+	for _, validatorID := range g.validatorIDs {
+		if knownValidators.Contains(validatorID.NodeID) {
+			filter.AddHash(saltedHash(salt[:], validatorID.TxID))
+		}
+	}
+
+	filterBytes, err := filter.MarshalBinary()
+	return filterBytes, salt[:], err
+}
+
+func (g *gossipTracker) ApplyFilter(peerID ids.NodeID, filterBytes []byte, salt []byte) (bool, error) {
+	filter, err := parseFilter(filterBytes)
+	if err != nil {
+		return false, err
+	}
+	if len(salt) > ids.IDLen {
+		return false, fmt.Errorf("%w: %d > %d", errSaltTooLarge, len(salt), ids.IDLen)
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	knownPeers, ok := g.trackedPeers[peerID]
+	if !ok {
+		return false, nil
+	}
+	for i, validatorID := range g.validatorIDs {
+		if filter.ContainsHash(saltedHash(salt, validatorID.TxID)) {
+			g.markKnown(knownPeers, i)
+		}
+	}
+	g.evictOldest(knownPeers)
+
+	g.updateCoverageMetrics()
+	return true, nil
+}
+
+// markKnown marks the validator at [index] as known in [knownPeers].
+//
+// Returns true if the validator wasn't previously known.
+//
+// Assumes [g.lock] is held.
+func (g *gossipTracker) markKnown(knownPeers set.Bits, index int) bool {
+	if knownPeers.Contains(index) {
+		return false
+	}
+
+	knownPeers.Add(index)
+	if g.knownBy[index] == 0 {
+		g.numUncovered--
+	}
+	g.knownBy[index]++
+	g.numKnown++
+	return true
+}
+
+// markUnknown marks the validator at [index] as unknown in [knownPeers].
+//
+// Assumes [g.lock] is held.
+func (g *gossipTracker) markUnknown(knownPeers set.Bits, index int) {
+	if !knownPeers.Contains(index) {
+		return
+	}
+
+	knownPeers.Remove(index)
+	g.knownBy[index]--
+	if g.knownBy[index] == 0 {
+		g.numUncovered++
+	}
+	g.numKnown--
+}
+
+// evictOldest marks the validators that have been tracked the longest as
+// unknown in [knownPeers] until at most [g.maxKnownPerPeer] validators are
+// known.
+//
+// Assumes [g.lock] is held.
+func (g *gossipTracker) evictOldest(knownPeers set.Bits) {
+	numKnown := knownPeers.Len()
+	numToEvict := numKnown - g.maxKnownPerPeer
+	if numToEvict <= 0 {
+		return
+	}
+
+	knownIndices := make([]int, 0, numKnown)
+	for i := range g.validatorIDs {
+		if knownPeers.Contains(i) {
+			knownIndices = append(knownIndices, i)
+		}
+	}
+	slices.SortFunc(knownIndices, func(i, j int) bool {
+		return g.addedAt[i] < g.addedAt[j]
+	})
+	for _, index := range knownIndices[:numToEvict] {
+		g.markUnknown(knownPeers, index)
+	}
+	g.metrics.evictions.Add(float64(numToEvict))
+}
+
+// pruneDisconnectedPeers forgets the validators known by the peers that
+// stopped being tracked more than [g.reconnectTTL] ago.
+//
+// Assumes [g.lock] is held.
+func (g *gossipTracker) pruneDisconnectedPeers() {
+	now := g.clock.Time()
+	for {
+		peerID, disconnected, ok := g.disconnectedPeers.Oldest()
+		if !ok || now.Before(disconnected.expiry) {
+			return
+		}
+		g.disconnectedPeers.Delete(peerID)
+	}
+}
+
+// swapRemove removes the validator at [lastIndex] from [knownPeers] after
+// moving it to [index].
+func swapRemove(knownPeers set.Bits, index int, lastIndex int) {
+	if index != lastIndex {
+		if knownPeers.Contains(lastIndex) {
+			knownPeers.Add(index)
+		} else {
+			knownPeers.Remove(index)
+		}
+	}
+	knownPeers.Remove(lastIndex)
+}
+
+// Assumes [g.lock] is held.
+func (g *gossipTracker) updateCoverageMetrics() {
+	averageKnownFraction := 0.0
+	if numPairs := len(g.trackedPeers) * len(g.validatorIDs); numPairs > 0 {
+		averageKnownFraction = float64(g.numKnown) / float64(numPairs)
+	}
+	g.metrics.averageKnownFraction.Set(averageKnownFraction)
+	g.metrics.validatorsWithNoCoverage.Set(float64(g.numUncovered))
+	g.metrics.memoryUsage.Set(float64(g.memoryUsage()))
+}
+
+// memoryUsage returns the estimated number of bytes used to track the current
+// validators and peers, including the peers that recently stopped being
+// tracked.
+//
+// Assumes [g.lock] is held.
+func (g *gossipTracker) memoryUsage() int {
+	usage := len(g.validatorIDs)*validatorOverhead + len(g.trackedPeers)*peerOverhead
+	for _, knownPeers := range g.trackedPeers {
+		usage += (knownPeers.BitLen() + 7) / 8
+	}
+	usage += g.disconnectedPeers.Len() * peerOverhead
+	it := g.disconnectedPeers.NewIterator()
+	for it.Next() {
+		usage += (it.Value().known.BitLen() + 7) / 8
+	}
+	return usage
+}
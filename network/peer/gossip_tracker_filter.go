@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bloomfilter "github.com/holiman/bloomfilter/v2"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const (
+	// knownPeersFalsePositiveProbability is the target false positive
+	// probability of the filters built by the GossipTracker. A false positive
+	// causes a validator to not be gossiped to a peer until it is reset.
+	knownPeersFalsePositiveProbability = 0.001
+
+	// The marshalled filter is composed of:
+	// - 12 bytes of header magic
+	// - 3 uint64s of k, n, and m
+	// - k uint64 keys
+	// - (m+63)/64 uint64s of bits
+	// - a 48 byte hash
+	filterMagicLen  = 12
+	filterHeaderLen = filterMagicLen + 3*8
+	filterHashLen   = 48
+)
+
+var (
+	errSaltTooLarge      = errors.New("salt too large")
+	errInvalidFilterSize = errors.New("invalid filter size")
+)
+
+// saltedHash returns the hash of [txID] used to populate a filter with [salt].
+func saltedHash(salt []byte, txID ids.ID) uint64 {
+	msg := make([]byte, 0, len(salt)+ids.IDLen)
+	msg = append(msg, salt...)
+	msg = append(msg, txID[:]...)
+	hash := hashing.ComputeHash256(msg)
+	return binary.BigEndian.Uint64(hash)
+}
+
+// parseFilter unmarshals a filter after verifying that the sizes in its header
+// are consistent with the length of [filterBytes]. This prevents a peer from
+// causing large allocations with a small message.
+func parseFilter(filterBytes []byte) (*bloomfilter.Filter, error) {
+	if len(filterBytes) < filterHeaderLen+filterHashLen {
+		return nil, fmt.Errorf("%w: %d bytes", errInvalidFilterSize, len(filterBytes))
+	}
+
+	var (
+		k         = binary.LittleEndian.Uint64(filterBytes[filterMagicLen:])
+		m         = binary.LittleEndian.Uint64(filterBytes[filterMagicLen+2*8:])
+		maxWords  = uint64(len(filterBytes)-filterHeaderLen-filterHashLen) / 8
+		bitsWords = m/64 + 1
+	)
+	if m%64 == 0 {
+		bitsWords--
+	}
+	if k > maxWords || bitsWords > maxWords-k || k+bitsWords != maxWords {
+		return nil, fmt.Errorf("%w: %d bytes with k=%d and m=%d", errInvalidFilterSize, len(filterBytes), k, m)
+	}
+
+	filter := &bloomfilter.Filter{}
+	return filter, filter.UnmarshalBinary(filterBytes)
+}
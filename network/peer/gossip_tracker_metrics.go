@@ -12,6 +12,16 @@ import (
 type gossipTrackerMetrics struct {
 	trackedPeersSize prometheus.Gauge
 	validatorsSize   prometheus.Gauge
+
+	averageKnownFraction     prometheus.Gauge
+	validatorsWithNoCoverage prometheus.Gauge
+	gossipEfficiency         prometheus.Gauge
+
+	evictions   prometheus.Counter
+	memoryUsage prometheus.Gauge
+
+	disconnectedPeersSize prometheus.Gauge
+	resumedPeers          prometheus.Counter
 }
 
 func newGossipTrackerMetrics(registerer prometheus.Registerer, namespace string) (gossipTrackerMetrics, error) {
@@ -30,11 +40,67 @@ func newGossipTrackerMetrics(registerer prometheus.Registerer, namespace string)
 				Help:      "number of validators this node is tracking",
 			},
 		),
+		averageKnownFraction: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "average_known_fraction",
+				Help:      "average fraction of the tracked validators known by each tracked peer",
+			},
+		),
+		validatorsWithNoCoverage: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "validators_with_no_coverage",
+				Help:      "number of tracked validators that aren't known by any tracked peer",
+			},
+		),
+		gossipEfficiency: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "gossip_efficiency",
+				Help:      "fraction of gossip messages that informed a peer of at least one validator it didn't know about",
+			},
+		),
+		evictions: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "evictions",
+				Help:      "number of validators forgotten as known by a peer because the peer exceeded the max number of known validators",
+			},
+		),
+		memoryUsage: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "memory_usage",
+				Help:      "estimated number of bytes used to track the validators and peers",
+			},
+		),
+		disconnectedPeersSize: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "disconnected_peers_size",
+				Help:      "amount of peers that recently stopped being tracked whose known validators are remembered",
+			},
+		),
+		resumedPeers: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "resumed_peers",
+				Help:      "number of times a peer started being tracked again with the validators it knew about before it stopped being tracked",
+			},
+		),
 	}
 
 	err := utils.Err(
 		registerer.Register(m.trackedPeersSize),
 		registerer.Register(m.validatorsSize),
+		registerer.Register(m.averageKnownFraction),
+		registerer.Register(m.validatorsWithNoCoverage),
+		registerer.Register(m.gossipEfficiency),
+		registerer.Register(m.evictions),
+		registerer.Register(m.memoryUsage),
+		registerer.Register(m.disconnectedPeersSize),
+		registerer.Register(m.resumedPeers),
 	)
 	return m, err
 }
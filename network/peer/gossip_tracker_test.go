@@ -4,13 +4,21 @@
 package peer
 
 import (
+	"encoding/binary"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/stretchr/testify/require"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 var (
@@ -65,7 +73,7 @@ func TestGossipTracker_Contains(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar")
+			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 			require.NoError(err)
 
 			for _, add := range test.track {
@@ -101,7 +109,7 @@ func TestGossipTracker_StartTrackingPeer(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar")
+			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 			require.NoError(err)
 
 			for i, p := range test.toStartTracking {
@@ -148,7 +156,7 @@ func TestGossipTracker_StopTrackingPeer(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar")
+			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 			require.NoError(err)
 
 			for _, add := range test.toStartTracking {
@@ -210,7 +218,7 @@ func TestGossipTracker_AddValidator(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar")
+			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 			require.NoError(err)
 
 			for _, v := range test.validators {
@@ -251,7 +259,7 @@ func TestGossipTracker_RemoveValidator(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar")
+			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 			require.NoError(err)
 
 			for _, v := range test.validators {
@@ -292,7 +300,7 @@ func TestGossipTracker_ResetValidator(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar")
+			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 			require.NoError(err)
 
 			require.True(g.StartTrackingPeer(p1))
@@ -301,7 +309,7 @@ func TestGossipTracker_ResetValidator(t *testing.T) {
 				require.True(g.AddValidator(v))
 				g.AddKnown(p1, []ids.ID{v.TxID}, nil)
 
-				unknown, ok := g.GetUnknown(p1)
+				unknown, ok := g.GetUnknown(p1, nil)
 				require.True(ok)
 				require.NotContains(unknown, v)
 			}
@@ -309,7 +317,7 @@ func TestGossipTracker_ResetValidator(t *testing.T) {
 			require.Equal(test.expected, g.ResetValidator(test.args.id))
 
 			for _, v := range test.validators {
-				unknown, ok := g.GetUnknown(p1)
+				unknown, ok := g.GetUnknown(p1, nil)
 				require.True(ok)
 				require.Contains(unknown, v)
 			}
@@ -382,7 +390,7 @@ func TestGossipTracker_AddKnown(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar")
+			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 			require.NoError(err)
 
 			for _, p := range test.trackedPeers {
@@ -456,7 +464,7 @@ func TestGossipTracker_GetUnknown(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar")
+			g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 			require.NoError(err)
 
 			// add our validators
@@ -471,7 +479,7 @@ func TestGossipTracker_GetUnknown(t *testing.T) {
 			}
 
 			// get the unknown peers for this peer
-			result, ok := g.GetUnknown(test.peerID)
+			result, ok := g.GetUnknown(test.peerID, nil)
 			require.Equal(test.expectedOk, ok)
 			require.Len(result, len(test.expectedUnknown))
 			for _, v := range test.expectedUnknown {
@@ -481,10 +489,112 @@ func TestGossipTracker_GetUnknown(t *testing.T) {
 	}
 }
 
+func TestGossipTracker_GetUnknownByWeight(t *testing.T) {
+	require := require.New(t)
+
+	g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+
+	require.True(g.AddValidator(v1))
+	require.True(g.AddValidator(v2))
+	require.True(g.AddValidator(v3))
+	require.True(g.StartTrackingPeer(p1))
+
+	weights := map[ids.NodeID]uint64{
+		v1.NodeID: 1,
+		v2.NodeID: 3,
+		v3.NodeID: 2,
+	}
+	weight := func(nodeID ids.NodeID) uint64 {
+		return weights[nodeID]
+	}
+
+	unknown, ok := g.GetUnknown(p1, weight)
+	require.True(ok)
+	require.Equal([]ValidatorID{v2, v3, v1}, unknown)
+
+	// Known validators are excluded regardless of their weight
+	_, ok = g.AddKnown(p1, []ids.ID{v2.TxID}, nil)
+	require.True(ok)
+
+	unknown, ok = g.GetUnknown(p1, weight)
+	require.True(ok)
+	require.Equal([]ValidatorID{v3, v1}, unknown)
+}
+
+func TestGossipTracker_Filter(t *testing.T) {
+	require := require.New(t)
+
+	sender, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+	receiver, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+
+	for _, validator := range []ValidatorID{v1, v2, v3} {
+		require.True(sender.AddValidator(validator))
+		require.True(receiver.AddValidator(validator))
+	}
+
+	filter, salt, err := sender.BuildFilter(set.Of(v1.NodeID, v2.NodeID))
+	require.NoError(err)
+
+	// The filter can't be applied to untracked peers
+	ok, err := receiver.ApplyFilter(p1, filter, salt)
+	require.NoError(err)
+	require.False(ok)
+
+	require.True(receiver.StartTrackingPeer(p1))
+	ok, err = receiver.ApplyFilter(p1, filter, salt)
+	require.NoError(err)
+	require.True(ok)
+
+	// Bloom filters don't have false negatives, so the validators known by
+	// the sender must not be gossiped to it.
+	unknown, ok := receiver.GetUnknown(p1, nil)
+	require.True(ok)
+	require.NotContains(unknown, v1)
+	require.NotContains(unknown, v2)
+
+	// Resetting a validator causes it to be gossiped again
+	require.True(receiver.ResetValidator(v1.NodeID))
+	unknown, ok = receiver.GetUnknown(p1, nil)
+	require.True(ok)
+	require.Contains(unknown, v1)
+}
+
+func TestGossipTracker_ApplyInvalidFilter(t *testing.T) {
+	require := require.New(t)
+
+	g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+	require.True(g.AddValidator(v1))
+	require.True(g.StartTrackingPeer(p1))
+
+	filter, salt, err := g.BuildFilter(set.Of(v1.NodeID))
+	require.NoError(err)
+
+	// Claim that the filter has a large number of keys without including them
+	largeFilter := slices.Clone(filter)
+	binary.LittleEndian.PutUint64(largeFilter[filterMagicLen:], 1<<40)
+	_, err = g.ApplyFilter(p1, largeFilter, salt)
+	require.ErrorIs(err, errInvalidFilterSize)
+
+	_, err = g.ApplyFilter(p1, filter[:len(filter)-1], salt)
+	require.ErrorIs(err, errInvalidFilterSize)
+
+	_, err = g.ApplyFilter(p1, filter, make([]byte, ids.IDLen+1))
+	require.ErrorIs(err, errSaltTooLarge)
+
+	// Nothing should have been marked as known
+	unknown, ok := g.GetUnknown(p1, nil)
+	require.True(ok)
+	require.Equal([]ValidatorID{v1}, unknown)
+}
+
 func TestGossipTracker_E2E(t *testing.T) {
 	require := require.New(t)
 
-	g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar")
+	g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 	require.NoError(err)
 
 	// [v1, v2, v3] are validators
@@ -492,7 +602,7 @@ func TestGossipTracker_E2E(t *testing.T) {
 	require.True(g.AddValidator(v2))
 
 	// we should get an empty unknown since we're not tracking anything
-	unknown, ok := g.GetUnknown(p1)
+	unknown, ok := g.GetUnknown(p1, nil)
 	require.False(ok)
 	require.Nil(unknown)
 
@@ -501,7 +611,7 @@ func TestGossipTracker_E2E(t *testing.T) {
 	require.True(g.Tracked(p1))
 
 	// check p1's unknown
-	unknown, ok = g.GetUnknown(p1)
+	unknown, ok = g.GetUnknown(p1, nil)
 	require.True(ok)
 	require.Contains(unknown, v1)
 	require.Contains(unknown, v2)
@@ -509,7 +619,7 @@ func TestGossipTracker_E2E(t *testing.T) {
 
 	// Check p2's unknown. We should get nothing since we're not tracking it
 	// yet.
-	unknown, ok = g.GetUnknown(p2)
+	unknown, ok = g.GetUnknown(p2, nil)
 	require.False(ok)
 	require.Nil(unknown)
 
@@ -517,7 +627,7 @@ func TestGossipTracker_E2E(t *testing.T) {
 	require.True(g.StartTrackingPeer(p2))
 
 	// check p2's unknown
-	unknown, ok = g.GetUnknown(p2)
+	unknown, ok = g.GetUnknown(p2, nil)
 	require.True(ok)
 	require.Contains(unknown, v1)
 	require.Contains(unknown, v2)
@@ -530,13 +640,13 @@ func TestGossipTracker_E2E(t *testing.T) {
 	require.Equal([]ids.ID{v1.TxID}, txIDs)
 
 	// p1 should have an unknown of [v2], since it knows v1
-	unknown, ok = g.GetUnknown(p1)
+	unknown, ok = g.GetUnknown(p1, nil)
 	require.True(ok)
 	require.Contains(unknown, v2)
 	require.Len(unknown, 1)
 
 	// p2 should have a unknown of [v1, v2], since it knows nothing
-	unknown, ok = g.GetUnknown(p2)
+	unknown, ok = g.GetUnknown(p2, nil)
 	require.True(ok)
 	require.Contains(unknown, v1)
 	require.Contains(unknown, v2)
@@ -554,14 +664,14 @@ func TestGossipTracker_E2E(t *testing.T) {
 	require.Equal([]ids.ID{v1.TxID, v2.TxID, v3.TxID}, txIDs)
 
 	// p1 doesn't know about [v2, v3]
-	unknown, ok = g.GetUnknown(p1)
+	unknown, ok = g.GetUnknown(p1, nil)
 	require.True(ok)
 	require.Contains(unknown, v2)
 	require.Contains(unknown, v3)
 	require.Len(unknown, 2)
 
 	// p2 doesn't know about [v1, v2, v3]
-	unknown, ok = g.GetUnknown(p2)
+	unknown, ok = g.GetUnknown(p2, nil)
 	require.True(ok)
 	require.Contains(unknown, v1)
 	require.Contains(unknown, v2)
@@ -569,19 +679,19 @@ func TestGossipTracker_E2E(t *testing.T) {
 	require.Len(unknown, 3)
 
 	// p3 knows about everyone
-	unknown, ok = g.GetUnknown(p3)
+	unknown, ok = g.GetUnknown(p3, nil)
 	require.True(ok)
 	require.Empty(unknown)
 
 	// stop tracking p2
 	require.True(g.StopTrackingPeer(p2))
-	unknown, ok = g.GetUnknown(p2)
+	unknown, ok = g.GetUnknown(p2, nil)
 	require.False(ok)
 	require.Nil(unknown)
 
 	// p1 doesn't know about [v2, v3] because v2 is still registered as
 	// a validator
-	unknown, ok = g.GetUnknown(p1)
+	unknown, ok = g.GetUnknown(p1, nil)
 	require.True(ok)
 	require.Contains(unknown, v2)
 	require.Contains(unknown, v3)
@@ -591,13 +701,13 @@ func TestGossipTracker_E2E(t *testing.T) {
 	require.True(g.RemoveValidator(v2.NodeID))
 
 	// p1 doesn't know about [v3] since v2 left the validator set
-	unknown, ok = g.GetUnknown(p1)
+	unknown, ok = g.GetUnknown(p1, nil)
 	require.True(ok)
 	require.Contains(unknown, v3)
 	require.Len(unknown, 1)
 
 	// p3 knows about everyone since it learned about v1 and v3 earlier.
-	unknown, ok = g.GetUnknown(p3)
+	unknown, ok = g.GetUnknown(p3, nil)
 	require.Empty(unknown)
 	require.True(ok)
 }
@@ -605,7 +715,7 @@ func TestGossipTracker_E2E(t *testing.T) {
 func TestGossipTracker_Regression_IncorrectTxIDDeletion(t *testing.T) {
 	require := require.New(t)
 
-	g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar")
+	g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
 	require.NoError(err)
 
 	require.True(g.AddValidator(v1))
@@ -618,3 +728,211 @@ func TestGossipTracker_Regression_IncorrectTxIDDeletion(t *testing.T) {
 		TxID:   v2.TxID,
 	}))
 }
+
+func TestGossipTracker_CoverageMetrics(t *testing.T) {
+	require := require.New(t)
+
+	gIntf, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+	g := gIntf.(*gossipTracker)
+
+	requireMetrics := func(averageKnownFraction, validatorsWithNoCoverage, gossipEfficiency float64) {
+		for metric, expected := range map[prometheus.Gauge]float64{
+			g.metrics.averageKnownFraction:     averageKnownFraction,
+			g.metrics.validatorsWithNoCoverage: validatorsWithNoCoverage,
+			g.metrics.gossipEfficiency:         gossipEfficiency,
+		} {
+			m := &dto.Metric{}
+			require.NoError(metric.Write(m))
+			require.InDelta(expected, m.Gauge.GetValue(), 0.0001)
+		}
+	}
+
+	require.True(g.AddValidator(v1))
+	require.True(g.AddValidator(v2))
+	require.True(g.StartTrackingPeer(p1))
+	require.True(g.StartTrackingPeer(p2))
+	requireMetrics(0, 2, 0)
+
+	// p1 learns about v1
+	_, ok := g.AddKnown(p1, []ids.ID{v1.TxID}, nil)
+	require.True(ok)
+	requireMetrics(0.25, 1, 1)
+
+	// p1 is told about v1 again, which isn't new information
+	_, ok = g.AddKnown(p1, []ids.ID{v1.TxID}, nil)
+	require.True(ok)
+	requireMetrics(0.25, 1, 0.5)
+
+	// p2 learns about v1 and v2
+	_, ok = g.AddKnown(p2, []ids.ID{v1.TxID, v2.TxID}, nil)
+	require.True(ok)
+	requireMetrics(0.75, 0, 2.0/3)
+
+	// v1 is no longer known by anyone
+	require.True(g.ResetValidator(v1.NodeID))
+	requireMetrics(0.25, 1, 2.0/3)
+
+	// removing v1 leaves only v2, which p2 knows about
+	require.True(g.RemoveValidator(v1.NodeID))
+	requireMetrics(0.5, 0, 2.0/3)
+
+	// p2 was the only peer that knew about v2
+	require.True(g.StopTrackingPeer(p2))
+	requireMetrics(0, 1, 2.0/3)
+}
+
+func TestGossipTracker_AddGossip(t *testing.T) {
+	require := require.New(t)
+
+	g, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+
+	// Untracked peers can't gossip
+	require.False(g.AddGossip(p1, 1, 1))
+	_, _, ok := g.GossipUsefulness(p1)
+	require.False(ok)
+
+	require.True(g.StartTrackingPeer(p1))
+	numUseful, numGossiped, ok := g.GossipUsefulness(p1)
+	require.True(ok)
+	require.Zero(numUseful)
+	require.Zero(numGossiped)
+
+	require.True(g.AddGossip(p1, 1, 3))
+	require.True(g.AddGossip(p1, 0, 2))
+	numUseful, numGossiped, ok = g.GossipUsefulness(p1)
+	require.True(ok)
+	require.Equal(uint64(1), numUseful)
+	require.Equal(uint64(5), numGossiped)
+
+	// The usefulness is forgotten once the peer is no longer tracked
+	require.True(g.StopTrackingPeer(p1))
+	require.True(g.StartTrackingPeer(p1))
+	numUseful, numGossiped, ok = g.GossipUsefulness(p1)
+	require.True(ok)
+	require.Zero(numUseful)
+	require.Zero(numGossiped)
+}
+
+func TestGossipTracker_InvalidMaxKnownPerPeer(t *testing.T) {
+	_, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", 0, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.ErrorIs(t, err, errInvalidMaxKnownPerPeer)
+}
+
+func TestGossipTracker_EvictOldest(t *testing.T) {
+	require := require.New(t)
+
+	gIntf, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", 2, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+	g := gIntf.(*gossipTracker)
+
+	require.True(g.AddValidator(v1))
+	require.True(g.AddValidator(v2))
+	require.True(g.StartTrackingPeer(p1))
+
+	_, ok := g.AddKnown(p1, []ids.ID{v1.TxID, v2.TxID}, nil)
+	require.True(ok)
+	unknown, ok := g.GetUnknown(p1, nil)
+	require.True(ok)
+	require.Empty(unknown)
+
+	// The validator set churns. Learning about v3 causes p1 to forget about
+	// v1, which has been tracked the longest.
+	require.True(g.RemoveValidator(v2.NodeID))
+	require.True(g.AddValidator(v2))
+	require.True(g.AddValidator(v3))
+	_, ok = g.AddKnown(p1, []ids.ID{v2.TxID, v3.TxID}, nil)
+	require.True(ok)
+
+	unknown, ok = g.GetUnknown(p1, nil)
+	require.True(ok)
+	require.Equal([]ValidatorID{v1}, unknown)
+
+	m := &dto.Metric{}
+	require.NoError(g.metrics.evictions.Write(m))
+	require.Equal(1.0, m.Counter.GetValue())
+}
+
+func TestGossipTracker_MemoryUsage(t *testing.T) {
+	require := require.New(t)
+
+	gIntf, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+	g := gIntf.(*gossipTracker)
+
+	requireMemoryUsage := func(expected int) {
+		m := &dto.Metric{}
+		require.NoError(g.metrics.memoryUsage.Write(m))
+		require.Equal(float64(expected), m.Gauge.GetValue())
+	}
+
+	requireMemoryUsage(0)
+
+	require.True(g.AddValidator(v1))
+	require.True(g.AddValidator(v2))
+	require.True(g.StartTrackingPeer(p1))
+	requireMemoryUsage(2*validatorOverhead + peerOverhead)
+
+	// p1's bitset requires a byte once it knows about a validator
+	_, ok := g.AddKnown(p1, []ids.ID{v2.TxID}, nil)
+	require.True(ok)
+	requireMemoryUsage(2*validatorOverhead + peerOverhead + 1)
+
+	// p1's bitset is remembered in case it reconnects
+	require.True(g.StopTrackingPeer(p1))
+	requireMemoryUsage(2*validatorOverhead + peerOverhead + 1)
+
+	g.clock.Set(g.clock.Time().Add(constants.DefaultNetworkPeerListReconnectKnownTTL))
+	require.True(g.StartTrackingPeer(p2))
+	require.True(g.StopTrackingPeer(p2))
+	requireMemoryUsage(2*validatorOverhead + peerOverhead)
+}
+
+func TestGossipTracker_InvalidReconnectTTL(t *testing.T) {
+	_, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, -1)
+	require.ErrorIs(t, err, errInvalidReconnectTTL)
+}
+
+func TestGossipTracker_Reconnect(t *testing.T) {
+	require := require.New(t)
+
+	gIntf, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, time.Minute)
+	require.NoError(err)
+	g := gIntf.(*gossipTracker)
+
+	require.True(g.AddValidator(v1))
+	require.True(g.AddValidator(v2))
+	require.True(g.AddValidator(v3))
+	require.True(g.StartTrackingPeer(p1))
+	_, ok := g.AddKnown(p1, []ids.ID{v1.TxID, v2.TxID, v3.TxID}, nil)
+	require.True(ok)
+	require.True(g.StopTrackingPeer(p1))
+
+	// While p1 is disconnected, v1 is removed and added back and v2
+	// re-registers.
+	require.True(g.RemoveValidator(v1.NodeID))
+	require.True(g.AddValidator(v1))
+	require.True(g.ResetValidator(v2.NodeID))
+
+	// Reconnecting within the TTL only requires the delta to be gossiped.
+	g.clock.Set(g.clock.Time().Add(time.Minute - time.Second))
+	require.True(g.StartTrackingPeer(p1))
+	unknown, ok := g.GetUnknown(p1, nil)
+	require.True(ok)
+	require.ElementsMatch([]ValidatorID{v1, v2}, unknown)
+	require.Equal(2, g.numUncovered)
+
+	m := &dto.Metric{}
+	require.NoError(g.metrics.resumedPeers.Write(m))
+	require.Equal(1.0, m.Counter.GetValue())
+
+	// Reconnecting after the TTL requires the full validator set to be
+	// gossiped.
+	require.True(g.StopTrackingPeer(p1))
+	g.clock.Set(g.clock.Time().Add(time.Minute))
+	require.True(g.StartTrackingPeer(p1))
+	unknown, ok = g.GetUnknown(p1, nil)
+	require.True(ok)
+	require.ElementsMatch([]ValidatorID{v1, v2, v3}, unknown)
+}
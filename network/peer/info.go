@@ -20,4 +20,8 @@ type Info struct {
 	ObservedUptime        json.Uint32            `json:"observedUptime"`
 	ObservedSubnetUptimes map[ids.ID]json.Uint32 `json:"observedSubnetUptimes"`
 	TrackedSubnets        []ids.ID               `json:"trackedSubnets"`
+	// NumGossipedIPs is the number of validator IPs the peer gossiped to us,
+	// of which NumUsefulGossipedIPs updated our view of the validator set.
+	NumGossipedIPs       json.Uint64 `json:"numGossipedIPs"`
+	NumUsefulGossipedIPs json.Uint64 `json:"numUsefulGossipedIPs"`
 }
@@ -11,6 +11,7 @@ import (
 	reflect "reflect"
 
 	ids "github.com/ava-labs/avalanchego/ids"
+	set "github.com/ava-labs/avalanchego/utils/set"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -37,6 +38,20 @@ func (m *MockGossipTracker) EXPECT() *MockGossipTrackerMockRecorder {
 	return m.recorder
 }
 
+// AddGossip mocks base method.
+func (m *MockGossipTracker) AddGossip(arg0 ids.NodeID, arg1, arg2 int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGossip", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// AddGossip indicates an expected call of AddGossip.
+func (mr *MockGossipTrackerMockRecorder) AddGossip(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGossip", reflect.TypeOf((*MockGossipTracker)(nil).AddGossip), arg0, arg1, arg2)
+}
+
 // AddKnown mocks base method.
 func (m *MockGossipTracker) AddKnown(arg0 ids.NodeID, arg1, arg2 []ids.ID) ([]ids.ID, bool) {
 	m.ctrl.T.Helper()
@@ -66,6 +81,37 @@ func (mr *MockGossipTrackerMockRecorder) AddValidator(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddValidator", reflect.TypeOf((*MockGossipTracker)(nil).AddValidator), arg0)
 }
 
+// ApplyFilter mocks base method.
+func (m *MockGossipTracker) ApplyFilter(arg0 ids.NodeID, arg1, arg2 []byte) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyFilter", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyFilter indicates an expected call of ApplyFilter.
+func (mr *MockGossipTrackerMockRecorder) ApplyFilter(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyFilter", reflect.TypeOf((*MockGossipTracker)(nil).ApplyFilter), arg0, arg1, arg2)
+}
+
+// BuildFilter mocks base method.
+func (m *MockGossipTracker) BuildFilter(arg0 set.Set[ids.NodeID]) ([]byte, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildFilter", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BuildFilter indicates an expected call of BuildFilter.
+func (mr *MockGossipTrackerMockRecorder) BuildFilter(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildFilter", reflect.TypeOf((*MockGossipTracker)(nil).BuildFilter), arg0)
+}
+
 // GetNodeID mocks base method.
 func (m *MockGossipTracker) GetNodeID(arg0 ids.ID) (ids.NodeID, bool) {
 	m.ctrl.T.Helper()
@@ -82,18 +128,34 @@ func (mr *MockGossipTrackerMockRecorder) GetNodeID(arg0 interface{}) *gomock.Cal
 }
 
 // GetUnknown mocks base method.
-func (m *MockGossipTracker) GetUnknown(arg0 ids.NodeID) ([]ValidatorID, bool) {
+func (m *MockGossipTracker) GetUnknown(arg0 ids.NodeID, arg1 func(ids.NodeID) uint64) ([]ValidatorID, bool) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUnknown", arg0)
+	ret := m.ctrl.Call(m, "GetUnknown", arg0, arg1)
 	ret0, _ := ret[0].([]ValidatorID)
 	ret1, _ := ret[1].(bool)
 	return ret0, ret1
 }
 
 // GetUnknown indicates an expected call of GetUnknown.
-func (mr *MockGossipTrackerMockRecorder) GetUnknown(arg0 interface{}) *gomock.Call {
+func (mr *MockGossipTrackerMockRecorder) GetUnknown(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnknown", reflect.TypeOf((*MockGossipTracker)(nil).GetUnknown), arg0, arg1)
+}
+
+// GossipUsefulness mocks base method.
+func (m *MockGossipTracker) GossipUsefulness(arg0 ids.NodeID) (uint64, uint64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GossipUsefulness", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(bool)
+	return ret0, ret1, ret2
+}
+
+// GossipUsefulness indicates an expected call of GossipUsefulness.
+func (mr *MockGossipTrackerMockRecorder) GossipUsefulness(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnknown", reflect.TypeOf((*MockGossipTracker)(nil).GetUnknown), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GossipUsefulness", reflect.TypeOf((*MockGossipTracker)(nil).GossipUsefulness), arg0)
 }
 
 // RemoveValidator mocks base method.
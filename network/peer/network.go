@@ -29,6 +29,15 @@ type Network interface {
 	// MarkTracked stops sending gossip about [ips] to [peerID].
 	MarkTracked(peerID ids.NodeID, ips []*p2p.PeerAck) error
 
+	// KnownPeers returns a bloom filter of the validators whose IPs are
+	// known, along with the salt used to populate it. The filter is sent
+	// during the peer handshake so that known IPs aren't gossiped back.
+	KnownPeers() ([]byte, []byte, error)
+
+	// MarkKnownPeers stops sending gossip to [peerID] about the validators in
+	// the bloom filter [peerID] sent during the peer handshake.
+	MarkKnownPeers(peerID ids.NodeID, filter []byte, salt []byte) error
+
 	// Disconnected is called when the peer finishes shutting down. It is not
 	// guaranteed that [Connected] was called for the provided peer. However, it
 	// is guaranteed that [Connected] will not be called after [Disconnected]
@@ -461,6 +461,7 @@ func (p *peer) readMessages() {
 			)
 
 			p.Metrics.FailedToParse.Inc()
+			p.ReputationTracker.RegisterMessage(p.id, false)
 
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
@@ -471,6 +472,7 @@ func (p *peer) readMessages() {
 		now := p.Clock.Time()
 		p.storeLastReceived(now)
 		p.Metrics.Received(msg, msgLen)
+		p.ReputationTracker.RegisterMessage(p.id, true)
 
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
@@ -497,6 +499,15 @@ func (p *peer) writeMessages() {
 		return
 	}
 
+	knownPeersFilter, knownPeersSalt, err := p.Network.KnownPeers()
+	if err != nil {
+		p.Log.Error("failed to get known peers filter",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return
+	}
+
 	msg, err := p.MessageCreator.Version(
 		p.NetworkID,
 		p.Clock.Unix(),
@@ -505,6 +516,8 @@ func (p *peer) writeMessages() {
 		mySignedIP.Timestamp,
 		mySignedIP.Signature,
 		p.MySubnets.List(),
+		knownPeersFilter,
+		knownPeersSalt,
 	)
 	if err != nil {
 		p.Log.Error("failed to create message",
@@ -702,10 +715,69 @@ func (p *peer) handle(msg message.InboundMessage) {
 		return
 	}
 
+	switch m := msg.Message().(type) { // Light client message types
+	case *p2p.GetAcceptedFrontierAttestation:
+		p.handleGetAcceptedFrontierAttestation(m)
+		msg.OnFinishedHandling()
+		return
+	case *p2p.AcceptedFrontierAttestation:
+		// This node never requests attestations, so any response is
+		// unrequested.
+		p.Log.Debug(
+			"dropping message",
+			zap.String("reason", "unrequested"),
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", msg.Op()),
+		)
+		msg.OnFinishedHandling()
+		return
+	}
+
 	// Consensus and app-level messages
 	p.Router.HandleInbound(context.Background(), msg)
 }
 
+func (p *peer) handleGetAcceptedFrontierAttestation(msg *p2p.GetAcceptedFrontierAttestation) {
+	chainID, err := ids.ToID(msg.ChainId)
+	if err != nil {
+		p.Log.Debug("message with invalid field",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.GetAcceptedFrontierAttestationOp),
+			zap.String("field", "ChainID"),
+			zap.Error(err),
+		)
+		return
+	}
+
+	attestation, err := p.FrontierAttestor.Attest(chainID)
+	if err != nil {
+		p.Log.Debug("failed to attest to accepted frontier",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("chainID", chainID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	attestationMsg, err := p.MessageCreator.AcceptedFrontierAttestation(
+		chainID,
+		msg.RequestId,
+		attestation.BlockID,
+		attestation.Height,
+		attestation.Timestamp,
+		attestation.Signature,
+	)
+	if err != nil {
+		p.Log.Error("failed to create message",
+			zap.Stringer("messageOp", message.AcceptedFrontierAttestationOp),
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return
+	}
+	p.Send(p.onClosingCtx, attestationMsg)
+}
+
 func (p *peer) handlePing(msg *p2p.Ping) {
 	p.observeUptimes(msg.Uptime, msg.SubnetUptimes)
 
@@ -965,6 +1037,19 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 		return
 	}
 
+	// Older peers don't send a filter of the validators they know about.
+	if len(msg.KnownPeersFilter) > 0 {
+		err := p.Network.MarkKnownPeers(p.id, msg.KnownPeersFilter, msg.KnownPeersSalt)
+		if err != nil {
+			p.Log.Debug("failed to apply known peers filter",
+				zap.Stringer("nodeID", p.id),
+				zap.Error(err),
+			)
+			p.StartClose()
+			return
+		}
+	}
+
 	p.gotVersion.Set(true)
 
 	peerIPs, err := p.Network.Peers(p.id)
@@ -94,6 +94,9 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 	)
 	require.NoError(err)
 
+	gossipTracker, err := NewGossipTracker(prometheus.NewRegistry(), "", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+
 	sharedConfig := Config{
 		Metrics:              metrics,
 		MessageCreator:       mc,
@@ -108,6 +111,7 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 		PongTimeout:          constants.DefaultPingPongTimeout,
 		MaxClockDifference:   time.Minute,
 		ResourceTracker:      resourceTracker,
+		ReputationTracker:    NewReputationTracker(gossipTracker),
 	}
 	peerConfig0 := sharedConfig
 	peerConfig1 := sharedConfig
@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/throttling"
+)
+
+// reputationPrior is the number of useful gossiped IPs and valid messages a
+// peer is assumed to have sent before any are observed. This prevents a peer
+// from being deprioritized based on only a few observations.
+const reputationPrior = 16
+
+var _ ReputationTracker = (*reputationTracker)(nil)
+
+// ReputationTracker scores peers by combining the usefulness of the validator
+// IPs they gossip, as tracked by a GossipTracker, with the validity of the
+// messages they send.
+//
+// A peer that only gossips stale validator IPs or sends messages that can't be
+// parsed will have its reputation approach 0.
+type ReputationTracker interface {
+	throttling.Reputations
+
+	// RegisterMessage records that a message was received from [nodeID].
+	// [valid] is false if the message couldn't be parsed.
+	RegisterMessage(nodeID ids.NodeID, valid bool)
+
+	// RemovePeer forgets the messages received from [nodeID].
+	RemovePeer(nodeID ids.NodeID)
+}
+
+type reputationTracker struct {
+	gossipTracker GossipTracker
+
+	lock sync.Mutex
+	// a mapping of each peer => the validity of the messages they sent
+	peerMessages map[ids.NodeID]messageValidity
+}
+
+// messageValidity is the number of messages sent by a peer and how many of
+// them were valid.
+type messageValidity struct {
+	numValid    uint64
+	numMessages uint64
+}
+
+// NewReputationTracker returns a ReputationTracker that uses [gossipTracker]
+// to score the usefulness of the gossip of peers.
+func NewReputationTracker(gossipTracker GossipTracker) ReputationTracker {
+	return &reputationTracker{
+		gossipTracker: gossipTracker,
+		peerMessages:  make(map[ids.NodeID]messageValidity),
+	}
+}
+
+func (r *reputationTracker) Reputation(nodeID ids.NodeID) float64 {
+	numUseful, numGossiped, _ := r.gossipTracker.GossipUsefulness(nodeID)
+
+	r.lock.Lock()
+	validity := r.peerMessages[nodeID]
+	r.lock.Unlock()
+
+	return score(numUseful, numGossiped) * score(validity.numValid, validity.numMessages)
+}
+
+func (r *reputationTracker) RegisterMessage(nodeID ids.NodeID, valid bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	validity := r.peerMessages[nodeID]
+	validity.numMessages++
+	if valid {
+		validity.numValid++
+	}
+	r.peerMessages[nodeID] = validity
+}
+
+func (r *reputationTracker) RemovePeer(nodeID ids.NodeID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.peerMessages, nodeID)
+}
+
+// score returns the fraction of [total] observations that were [good], biased
+// towards 1 by [reputationPrior] good observations.
+func score(good uint64, total uint64) float64 {
+	return float64(good+reputationPrior) / float64(total+reputationPrior)
+}
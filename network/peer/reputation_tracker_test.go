@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestReputationTracker(t *testing.T) {
+	require := require.New(t)
+
+	gossipTracker, err := NewGossipTracker(prometheus.NewRegistry(), "foobar", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	require.NoError(err)
+	r := NewReputationTracker(gossipTracker)
+
+	// Peers start with the best reputation
+	require.Equal(1.0, r.Reputation(p1))
+
+	require.True(gossipTracker.StartTrackingPeer(p1))
+	require.True(gossipTracker.StartTrackingPeer(p2))
+	require.Equal(1.0, r.Reputation(p1))
+
+	// Gossiping only stale IPs lowers the reputation
+	require.True(gossipTracker.AddGossip(p1, 0, reputationPrior))
+	require.Equal(0.5, r.Reputation(p1))
+
+	// Useful gossip doesn't lower the reputation
+	require.True(gossipTracker.AddGossip(p2, reputationPrior, reputationPrior))
+	require.Equal(1.0, r.Reputation(p2))
+
+	// Invalid messages lower the reputation
+	for i := 0; i < reputationPrior; i++ {
+		r.RegisterMessage(p1, false)
+		r.RegisterMessage(p2, true)
+	}
+	require.Equal(0.25, r.Reputation(p1))
+	require.Equal(1.0, r.Reputation(p2))
+
+	// Removing the peer forgets its messages
+	r.RemovePeer(p1)
+	require.Equal(0.5, r.Reputation(p1))
+}
@@ -27,6 +27,14 @@ func (testNetwork) MarkTracked(ids.NodeID, []*p2p.PeerAck) error {
 	return nil
 }
 
+func (testNetwork) KnownPeers() ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func (testNetwork) MarkKnownPeers(ids.NodeID, []byte, []byte) error {
+	return nil
+}
+
 func (testNetwork) Disconnected(ids.NodeID) {}
 
 func (testNetwork) Peers(ids.NodeID) ([]ips.ClaimedIPPort, error) {
@@ -61,10 +61,14 @@ func StartTestPeer(
 		return nil, err
 	}
 
-	tlsConfg := TLSConfig(*tlsCert, nil)
+	tlsConfg := TLSConfig(*tlsCert, nil, 0)
 	clientUpgrader := NewTLSClientUpgrader(
 		tlsConfg,
-		prometheus.NewCounter(prometheus.CounterOpts{}),
+		&UpgraderMetrics{
+			InvalidCerts:      prometheus.NewCounter(prometheus.CounterOpts{}),
+			HandshakeLatency:  prometheus.NewHistogramVec(prometheus.HistogramOpts{}, []string{ResumedLabel}),
+			HandshakeFailures: prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{ReasonLabel}),
+		},
 	)
 
 	peerID, conn, cert, err := clientUpgrader.Upgrade(conn)
@@ -102,6 +106,11 @@ func StartTestPeer(
 		return nil, err
 	}
 
+	gossipTracker, err := NewGossipTracker(prometheus.NewRegistry(), "", constants.DefaultNetworkPeerListMaxKnownValidators, constants.DefaultNetworkPeerListReconnectKnownTTL)
+	if err != nil {
+		return nil, err
+	}
+
 	signerIP := ips.NewDynamicIPPort(net.IPv6zero, 0)
 	tls := tlsCert.PrivateKey.(crypto.Signer)
 
@@ -121,6 +130,7 @@ func StartTestPeer(
 			PongTimeout:          constants.DefaultPingPongTimeout,
 			MaxClockDifference:   time.Minute,
 			ResourceTracker:      resourceTracker,
+			ReputationTracker:    NewReputationTracker(gossipTracker),
 			UptimeCalculator:     uptime.NoOpCalculator,
 			IPSigner:             NewIPSigner(signerIP, tls),
 		},
@@ -13,9 +13,13 @@ import (
 //
 // It is safe, and typically expected, for [keyLogWriter] to be [nil].
 // [keyLogWriter] should only be enabled for debugging.
-func TLSConfig(cert tls.Certificate, keyLogWriter io.Writer) *tls.Config {
+//
+// Up to [sessionCacheSize] TLS sessions are cached to allow outbound
+// connections to resume them. If [sessionCacheSize] is 0, TLS session
+// resumption is disabled.
+func TLSConfig(cert tls.Certificate, keyLogWriter io.Writer, sessionCacheSize int) *tls.Config {
 	// #nosec G402
-	return &tls.Config{
+	config := &tls.Config{
 		Certificates: []tls.Certificate{cert},
 		ClientAuth:   tls.RequireAnyClientCert,
 		// We do not use the TLS CA functionality to authenticate a
@@ -28,4 +32,15 @@ func TLSConfig(cert tls.Certificate, keyLogWriter io.Writer) *tls.Config {
 		MinVersion:         tls.VersionTLS13,
 		KeyLogWriter:       keyLogWriter,
 	}
+
+	// Resuming a session doesn't weaken the authentication of the peer.
+	// Session tickets are encrypted by the server that issued them and they
+	// include the certificates that were presented during the full handshake.
+	// Those certificates are then verified again by the Upgrader.
+	if sessionCacheSize > 0 {
+		config.ClientSessionCache = tls.NewLRUClientSessionCache(sessionCacheSize)
+	} else {
+		config.SessionTicketsDisabled = true
+	}
+	return config
 }
@@ -7,6 +7,9 @@ import (
 	"crypto/tls"
 	"errors"
 	"net"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -14,6 +17,20 @@ import (
 	"github.com/ava-labs/avalanchego/staking"
 )
 
+const (
+	// ResumedLabel is the label of [UpgraderMetrics.HandshakeLatency] that
+	// reports whether the TLS session was resumed.
+	ResumedLabel = "resumed"
+	// ReasonLabel is the label of [UpgraderMetrics.HandshakeFailures] that
+	// reports why the handshake failed.
+	ReasonLabel = "reason"
+
+	reasonTimeout     = "timeout"
+	reasonHandshake   = "handshake"
+	reasonNoCert      = "no_cert"
+	reasonInvalidCert = "invalid_cert"
+)
+
 var (
 	errNoCert = errors.New("tls handshake finished with no peer certificate")
 
@@ -26,45 +43,66 @@ type Upgrader interface {
 	Upgrade(net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error)
 }
 
+// UpgraderMetrics reports the outcome of the TLS handshakes performed by an
+// Upgrader.
+type UpgraderMetrics struct {
+	// InvalidCerts counts the handshakes that failed due to an unsupported
+	// peer certificate.
+	InvalidCerts prometheus.Counter
+	// HandshakeLatency observes the duration, in seconds, of the successful
+	// handshakes. It must have the [ResumedLabel] label.
+	HandshakeLatency prometheus.ObserverVec
+	// HandshakeFailures counts the failed handshakes. It must have the
+	// [ReasonLabel] label.
+	HandshakeFailures *prometheus.CounterVec
+}
+
 type tlsServerUpgrader struct {
-	config       *tls.Config
-	invalidCerts prometheus.Counter
+	config  *tls.Config
+	metrics *UpgraderMetrics
 }
 
-func NewTLSServerUpgrader(config *tls.Config, invalidCerts prometheus.Counter) Upgrader {
+func NewTLSServerUpgrader(config *tls.Config, metrics *UpgraderMetrics) Upgrader {
 	return &tlsServerUpgrader{
-		config:       config,
-		invalidCerts: invalidCerts,
+		config:  config,
+		metrics: metrics,
 	}
 }
 
 func (t *tlsServerUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
-	return connToIDAndCert(tls.Server(conn, t.config), t.invalidCerts)
+	return connToIDAndCert(tls.Server(conn, t.config), t.metrics)
 }
 
 type tlsClientUpgrader struct {
-	config       *tls.Config
-	invalidCerts prometheus.Counter
+	config  *tls.Config
+	metrics *UpgraderMetrics
 }
 
-func NewTLSClientUpgrader(config *tls.Config, invalidCerts prometheus.Counter) Upgrader {
+func NewTLSClientUpgrader(config *tls.Config, metrics *UpgraderMetrics) Upgrader {
 	return &tlsClientUpgrader{
-		config:       config,
-		invalidCerts: invalidCerts,
+		config:  config,
+		metrics: metrics,
 	}
 }
 
 func (t *tlsClientUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
-	return connToIDAndCert(tls.Client(conn, t.config), t.invalidCerts)
+	return connToIDAndCert(tls.Client(conn, t.config), t.metrics)
 }
 
-func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+func connToIDAndCert(conn *tls.Conn, metrics *UpgraderMetrics) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	start := time.Now()
 	if err := conn.Handshake(); err != nil {
+		reason := reasonHandshake
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			reason = reasonTimeout
+		}
+		metrics.HandshakeFailures.WithLabelValues(reason).Inc()
 		return ids.EmptyNodeID, nil, nil, err
 	}
 
 	state := conn.ConnectionState()
 	if len(state.PeerCertificates) == 0 {
+		metrics.HandshakeFailures.WithLabelValues(reasonNoCert).Inc()
 		return ids.EmptyNodeID, nil, nil, errNoCert
 	}
 
@@ -74,7 +112,8 @@ func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeI
 	// parseable according the staking package's parser.
 	peerCert, err := staking.ParseCertificate(tlsCert.Raw)
 	if err != nil {
-		invalidCerts.Inc()
+		metrics.InvalidCerts.Inc()
+		metrics.HandshakeFailures.WithLabelValues(reasonInvalidCert).Inc()
 		return ids.EmptyNodeID, nil, nil, err
 	}
 
@@ -83,10 +122,13 @@ func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeI
 	// prior version using an invalid certificate should not be able to report
 	// healthy.
 	if err := staking.ValidateCertificate(peerCert); err != nil {
-		invalidCerts.Inc()
+		metrics.InvalidCerts.Inc()
+		metrics.HandshakeFailures.WithLabelValues(reasonInvalidCert).Inc()
 		return ids.EmptyNodeID, nil, nil, err
 	}
 
+	metrics.HandshakeLatency.WithLabelValues(strconv.FormatBool(state.DidResume)).Observe(time.Since(start).Seconds())
+
 	nodeID := ids.NodeIDFromCert(peerCert)
 	return nodeID, conn, peerCert, nil
 }
@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+type upgradeResult struct {
+	nodeID ids.NodeID
+	conn   net.Conn
+	err    error
+}
+
+func newTestUpgraderMetrics() *UpgraderMetrics {
+	return &UpgraderMetrics{
+		InvalidCerts: prometheus.NewCounter(prometheus.CounterOpts{}),
+		HandshakeLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{Name: "handshake_latency"},
+			[]string{ResumedLabel},
+		),
+		HandshakeFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{Name: "handshake_failed"},
+			[]string{ReasonLabel},
+		),
+	}
+}
+
+func newTestTLSCert(require *require.Assertions) (*tls.Certificate, ids.NodeID) {
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	return tlsCert, ids.NodeIDFromCert(staking.CertificateFromX509(tlsCert.Leaf))
+}
+
+// upgradeConns upgrades both ends of a new TCP connection to [listener] and
+// returns the results of the server and of the client.
+func upgradeConns(
+	require *require.Assertions,
+	listener net.Listener,
+	server Upgrader,
+	client Upgrader,
+) (upgradeResult, upgradeResult) {
+	serverResultChan := make(chan upgradeResult, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverResultChan <- upgradeResult{err: err}
+			return
+		}
+
+		nodeID, tlsConn, _, err := server.Upgrade(conn)
+		if err == nil {
+			// The client only processes the session ticket sent by the server
+			// after the handshake once it reads from the connection.
+			_, err = tlsConn.Write([]byte{0})
+		}
+		serverResultChan <- upgradeResult{
+			nodeID: nodeID,
+			conn:   tlsConn,
+			err:    err,
+		}
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(err)
+
+	nodeID, tlsConn, _, err := client.Upgrade(conn)
+	if err == nil {
+		_, err = tlsConn.Read(make([]byte, 1))
+	}
+	clientResult := upgradeResult{
+		nodeID: nodeID,
+		conn:   tlsConn,
+		err:    err,
+	}
+	return <-serverResultChan, clientResult
+}
+
+func TestUpgraderSessionResumption(t *testing.T) {
+	require := require.New(t)
+
+	serverCert, serverNodeID := newTestTLSCert(require)
+	clientCert, clientNodeID := newTestTLSCert(require)
+
+	serverMetrics := newTestUpgraderMetrics()
+	server := NewTLSServerUpgrader(TLSConfig(*serverCert, nil, 1), serverMetrics)
+	clientMetrics := newTestUpgraderMetrics()
+	client := NewTLSClientUpgrader(TLSConfig(*clientCert, nil, 1), clientMetrics)
+
+	// Sessions are cached by the address of the server
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	// The first connection performs a full handshake. The second connection
+	// resumes the session of the first one and must still authenticate both
+	// peers.
+	for _, resumed := range []bool{false, true} {
+		serverResult, clientResult := upgradeConns(require, listener, server, client)
+		require.NoError(serverResult.err)
+		require.NoError(clientResult.err)
+
+		require.Equal(clientNodeID, serverResult.nodeID)
+		require.Equal(serverNodeID, clientResult.nodeID)
+		require.Equal(resumed, serverResult.conn.(*tls.Conn).ConnectionState().DidResume)
+		require.Equal(resumed, clientResult.conn.(*tls.Conn).ConnectionState().DidResume)
+
+		require.NoError(serverResult.conn.Close())
+		require.NoError(clientResult.conn.Close())
+	}
+
+	// Both the full and the resumed handshakes were observed
+	require.Equal(2, testutil.CollectAndCount(serverMetrics.HandshakeLatency))
+	require.Equal(2, testutil.CollectAndCount(clientMetrics.HandshakeLatency))
+}
+
+func TestUpgraderSessionResumptionDisabled(t *testing.T) {
+	require := require.New(t)
+
+	serverCert, _ := newTestTLSCert(require)
+	clientCert, _ := newTestTLSCert(require)
+
+	server := NewTLSServerUpgrader(TLSConfig(*serverCert, nil, 0), newTestUpgraderMetrics())
+	client := NewTLSClientUpgrader(TLSConfig(*clientCert, nil, 0), newTestUpgraderMetrics())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	for i := 0; i < 2; i++ {
+		serverResult, clientResult := upgradeConns(require, listener, server, client)
+		require.NoError(serverResult.err)
+		require.NoError(clientResult.err)
+
+		require.False(serverResult.conn.(*tls.Conn).ConnectionState().DidResume)
+		require.False(clientResult.conn.(*tls.Conn).ConnectionState().DidResume)
+
+		require.NoError(serverResult.conn.Close())
+		require.NoError(clientResult.conn.Close())
+	}
+}
+
+func TestUpgraderHandshakeFailures(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupConn      func(*require.Assertions, net.Conn, net.Conn)
+		expectedReason string
+	}{
+		{
+			name: "timeout",
+			setupConn: func(require *require.Assertions, serverConn net.Conn, _ net.Conn) {
+				require.NoError(serverConn.SetReadDeadline(time.Now()))
+			},
+			expectedReason: reasonTimeout,
+		},
+		{
+			name: "closed",
+			setupConn: func(require *require.Assertions, _ net.Conn, clientConn net.Conn) {
+				require.NoError(clientConn.Close())
+			},
+			expectedReason: reasonHandshake,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			serverCert, _ := newTestTLSCert(require)
+			metrics := newTestUpgraderMetrics()
+			server := NewTLSServerUpgrader(TLSConfig(*serverCert, nil, 0), metrics)
+
+			serverConn, clientConn := net.Pipe()
+			test.setupConn(require, serverConn, clientConn)
+
+			_, _, _, err := server.Upgrade(serverConn)
+			require.Error(err) //nolint:forbidigo // The error comes from crypto/tls
+
+			require.Equal(1.0, testutil.ToFloat64(metrics.HandshakeFailures.WithLabelValues(test.expectedReason)))
+			require.Zero(testutil.CollectAndCount(metrics.HandshakeLatency))
+		})
+	}
+}
@@ -157,6 +157,10 @@ func NewTestNetwork(
 			PeerListNonValidatorGossipSize: constants.DefaultNetworkPeerListNonValidatorGossipSize,
 			PeerListPeersGossipSize:        constants.DefaultNetworkPeerListPeersGossipSize,
 			PeerListGossipFreq:             constants.DefaultNetworkPeerListGossipFreq,
+			PeerListMaxKnownValidators:     constants.DefaultNetworkPeerListMaxKnownValidators,
+			PeerListReconnectKnownTTL:      constants.DefaultNetworkPeerListReconnectKnownTTL,
+			PeerListUselessNumValidatorIPs: constants.DefaultNetworkPeerListUselessNumValidatorIPs,
+			PeerListUsefulnessGracePeriod:  constants.DefaultNetworkPeerListUsefulnessGracePeriod,
 		},
 
 		DelayConfig: DelayConfig{
@@ -183,7 +187,7 @@ func NewTestNetwork(
 	if err != nil {
 		return nil, err
 	}
-	tlsConfig := peer.TLSConfig(*tlsCert, nil)
+	tlsConfig := peer.TLSConfig(*tlsCert, nil, 0)
 	networkConfig.TLSConfig = tlsConfig
 	networkConfig.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
 
@@ -229,7 +233,12 @@ func NewTestNetwork(
 
 	networkConfig.MyIPPort = ips.NewDynamicIPPort(net.IPv4zero, 0)
 
-	networkConfig.GossipTracker, err = peer.NewGossipTracker(metrics, "")
+	networkConfig.GossipTracker, err = peer.NewGossipTracker(
+		metrics,
+		"",
+		int(networkConfig.PeerListMaxKnownValidators),
+		networkConfig.PeerListReconnectKnownTTL,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
@@ -21,9 +22,11 @@ func newInboundMsgBufferThrottler(
 	namespace string,
 	registerer prometheus.Registerer,
 	maxProcessingMsgsPerNode uint64,
+	reputations Reputations,
 ) (*inboundMsgBufferThrottler, error) {
 	t := &inboundMsgBufferThrottler{
 		maxProcessingMsgsPerNode: maxProcessingMsgsPerNode,
+		reputations:              reputations,
 		awaitingAcquire:          make(map[ids.NodeID]chan struct{}),
 		nodeToNumProcessingMsgs:  make(map[ids.NodeID]uint64),
 	}
@@ -44,6 +47,8 @@ type inboundMsgBufferThrottler struct {
 	// but the corresponding call to Release() has not happened.
 	// TODO: Different values for validators / non-validators?
 	maxProcessingMsgsPerNode uint64
+	// Scales [maxProcessingMsgsPerNode] down for nodes with a low reputation.
+	reputations Reputations
 	// Node ID --> Number of messages from this node we're currently processing.
 	// Must only be accessed when [lock] is held.
 	nodeToNumProcessingMsgs map[ids.NodeID]uint64
@@ -67,7 +72,7 @@ func (t *inboundMsgBufferThrottler) Acquire(ctx context.Context, nodeID ids.Node
 	}()
 
 	t.lock.Lock()
-	if t.nodeToNumProcessingMsgs[nodeID] < t.maxProcessingMsgsPerNode {
+	if t.nodeToNumProcessingMsgs[nodeID] < t.maxProcessingMsgs(nodeID) {
 		t.nodeToNumProcessingMsgs[nodeID]++
 		t.lock.Unlock()
 		return func() {
@@ -119,12 +124,25 @@ func (t *inboundMsgBufferThrottler) release(nodeID ids.NodeID) {
 	// If we're waiting to acquire space on the inbound message
 	// buffer for messages from [nodeID], allow it to proceed
 	// (i.e. for its call to Acquire to return.)
-	if waiting, ok := t.awaitingAcquire[nodeID]; ok {
+	//
+	// If the reputation of [nodeID] has dropped, we may still be processing
+	// too many messages from [nodeID] to allow it to proceed.
+	waiting, ok := t.awaitingAcquire[nodeID]
+	if ok && t.nodeToNumProcessingMsgs[nodeID] < t.maxProcessingMsgs(nodeID) {
 		close(waiting)
 		delete(t.awaitingAcquire, nodeID)
 	}
 }
 
+// maxProcessingMsgs returns the max number of messages from [nodeID] that may
+// be processing concurrently. Every node is allowed to have at least one
+// message processing.
+func (t *inboundMsgBufferThrottler) maxProcessingMsgs(nodeID ids.NodeID) uint64 {
+	reputation := t.reputations.Reputation(nodeID)
+	maxProcessingMsgs := uint64(reputation * float64(t.maxProcessingMsgsPerNode))
+	return math.Max(maxProcessingMsgs, 1)
+}
+
 type inboundMsgBufferThrottlerMetrics struct {
 	acquireLatency  metric.Averager
 	awaitingAcquire prometheus.Gauge
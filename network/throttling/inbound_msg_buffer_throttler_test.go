@@ -18,7 +18,7 @@ import (
 // Test inboundMsgBufferThrottler
 func TestMsgBufferThrottler(t *testing.T) {
 	require := require.New(t)
-	throttler, err := newInboundMsgBufferThrottler("", prometheus.NewRegistry(), 3)
+	throttler, err := newInboundMsgBufferThrottler("", prometheus.NewRegistry(), 3, testReputations{})
 	require.NoError(err)
 
 	nodeID1, nodeID2 := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
@@ -70,7 +70,7 @@ func TestMsgBufferThrottler(t *testing.T) {
 // Test inboundMsgBufferThrottler when an acquire is cancelled
 func TestMsgBufferThrottlerContextCancelled(t *testing.T) {
 	require := require.New(t)
-	throttler, err := newInboundMsgBufferThrottler("", prometheus.NewRegistry(), 3)
+	throttler, err := newInboundMsgBufferThrottler("", prometheus.NewRegistry(), 3, testReputations{})
 	require.NoError(err)
 
 	vdr1Context, vdr1ContextCancelFunc := context.WithCancel(context.Background())
@@ -119,3 +119,53 @@ func TestMsgBufferThrottlerContextCancelled(t *testing.T) {
 		require.FailNow("should be blocked")
 	}
 }
+
+// Test inboundMsgBufferThrottler gives less space to nodes with a low
+// reputation
+func TestMsgBufferThrottlerReputation(t *testing.T) {
+	require := require.New(t)
+
+	nodeID1, nodeID2 := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	reputations := testReputations{
+		nodeID1: 0.5,
+		nodeID2: 0,
+	}
+	throttler, err := newInboundMsgBufferThrottler("", prometheus.NewRegistry(), 4, reputations)
+	require.NoError(err)
+
+	require.Equal(uint64(2), throttler.maxProcessingMsgs(nodeID1))
+	// Every node is allowed to process at least one message
+	require.Equal(uint64(1), throttler.maxProcessingMsgs(nodeID2))
+	require.Equal(uint64(4), throttler.maxProcessingMsgs(ids.GenerateTestNodeID()))
+
+	// Acquire shouldn't block for first 2
+	throttler.Acquire(context.Background(), nodeID1)
+	throttler.Acquire(context.Background(), nodeID1)
+
+	// Acquire should block for 3rd acquire
+	done := make(chan struct{})
+	go func() {
+		throttler.Acquire(context.Background(), nodeID1)
+		close(done)
+	}()
+	select {
+	case <-done:
+		require.FailNow("should block on acquiring")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	throttler.release(nodeID1)
+	// third acquire should be unblocked
+	<-done
+	require.Equal(uint64(2), throttler.nodeToNumProcessingMsgs[nodeID1])
+}
+
+// testReputations gives the best reputation to nodes that aren't in the map
+type testReputations map[ids.NodeID]float64
+
+func (r testReputations) Reputation(nodeID ids.NodeID) float64 {
+	if reputation, ok := r[nodeID]; ok {
+		return reputation
+	}
+	return 1
+}
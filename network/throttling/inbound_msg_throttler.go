@@ -43,6 +43,14 @@ type InboundMsgThrottler interface {
 	RemoveNode(nodeID ids.NodeID)
 }
 
+// Reputations reports the reputation of peers.
+type Reputations interface {
+	// Reputation returns the reputation of [nodeID] in [0, 1], where 1 is the
+	// best reputation. Fewer messages from nodes with a lower reputation are
+	// processed concurrently.
+	Reputation(nodeID ids.NodeID) float64
+}
+
 type InboundMsgThrottlerConfig struct {
 	MsgByteThrottlerConfig   `json:"byteThrottlerConfig"`
 	BandwidthThrottlerConfig `json:"bandwidthThrottlerConfig"`
@@ -61,6 +69,7 @@ func NewInboundMsgThrottler(
 	resourceTracker tracker.ResourceTracker,
 	cpuTargeter tracker.Targeter,
 	diskTargeter tracker.Targeter,
+	reputations Reputations,
 ) (InboundMsgThrottler, error) {
 	byteThrottler, err := newInboundMsgByteThrottler(
 		log,
@@ -76,6 +85,7 @@ func NewInboundMsgThrottler(
 		namespace,
 		registerer,
 		throttlerConfig.MaxProcessingMsgsPerNode,
+		reputations,
 	)
 	if err != nil {
 		return nil, err
@@ -124,7 +134,8 @@ func NewInboundMsgThrottler(
 // The three resources considered are:
 //
 //  1. An inbound message buffer, where each message that we're currently
-//     processing takes up 1 unit of space on the buffer.
+//     processing takes up 1 unit of space on the buffer. Nodes with a lower
+//     reputation are given less space.
 //  2. An inbound message byte buffer, where a message of length n
 //     that we're currently processing takes up n units of space on the buffer.
 //  3. Bandwidth. The bandwidth rate-limiting is implemented using a token
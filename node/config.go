@@ -25,6 +25,9 @@ import (
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
+	"github.com/ava-labs/avalanchego/webhook"
 )
 
 type IPCConfig struct {
@@ -99,6 +102,12 @@ type StakingConfig struct {
 	StakingKeyPath                string          `json:"stakingKeyPath"`
 	StakingCertPath               string          `json:"stakingCertPath"`
 	StakingSignerPath             string          `json:"stakingSignerPath"`
+	SecondaryStakingKeyPath       string          `json:"secondaryStakingKeyPath"`
+	SecondaryStakingCertPath      string          `json:"secondaryStakingCertPath"`
+
+	// SecondaryStakingTLSCert, if non-nil, is the staking key this node is
+	// rotating to. It is only used to sign snowman++ blocks.
+	SecondaryStakingTLSCert *tls.Certificate `json:"-"`
 }
 
 type StateSyncConfig struct {
@@ -170,6 +179,14 @@ type Config struct {
 	LoggingConfig logging.Config `json:"loggingConfig"`
 
 	PluginDir string `json:"pluginDir"`
+	// PluginConnPoolConfig configures the connections used to make requests
+	// to VM plugins.
+	PluginConnPoolConfig rpcchainvm.ConnPoolConfig `json:"pluginConnPoolConfig"`
+	// PluginCallTimeoutConfig configures the deadlines of the requests made
+	// to VM plugins.
+	PluginCallTimeoutConfig rpcchainvm.CallTimeoutConfig `json:"pluginCallTimeoutConfig"`
+	// PluginSandboxConfig limits the resources available to VM plugins.
+	PluginSandboxConfig subprocess.SandboxConfig `json:"pluginSandboxConfig"`
 
 	// File Descriptor Limit
 	FdLimit uint64 `json:"fdLimit"`
@@ -181,6 +198,10 @@ type Config struct {
 	ConsensusRouter          router.Router       `json:"-"`
 	RouterHealthConfig       router.HealthConfig `json:"routerHealthConfig"`
 	ConsensusShutdownTimeout time.Duration       `json:"consensusShutdownTimeout"`
+	// ShutdownStageTimeout is the additional time each stage of the node's
+	// shutdown is given before it is abandoned. If 0, stages are never
+	// abandoned.
+	ShutdownStageTimeout time.Duration `json:"shutdownStageTimeout"`
 	// Poll for new frontiers every [FrontierPollFrequency]
 	FrontierPollFrequency time.Duration `json:"consensusGossipFreq"`
 	// ConsensusAppConcurrency defines the maximum number of goroutines to
@@ -222,6 +243,8 @@ type Config struct {
 
 	TraceConfig trace.Config `json:"traceConfig"`
 
+	WebhookConfig webhook.Config `json:"webhookConfig"`
+
 	// See comment on [UseCurrentHeight] in platformvm.Config
 	UseCurrentHeight bool `json:"useCurrentHeight"`
 
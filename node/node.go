@@ -53,7 +53,9 @@ import (
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/frontier"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -73,19 +75,24 @@ import (
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/shutdown"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/freezefx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/registry"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/webhook"
 
 	ipcsapi "github.com/ava-labs/avalanchego/api/ipcs"
+	avmblock "github.com/ava-labs/avalanchego/vms/avm/block"
 	avmconfig "github.com/ava-labs/avalanchego/vms/avm/config"
 	platformconfig "github.com/ava-labs/avalanchego/vms/platformvm/config"
 )
@@ -96,6 +103,10 @@ var (
 
 	indexerDBPrefix  = []byte{0x00}
 	keystoreDBPrefix = []byte("keystore")
+	webhookDBPrefix  = []byte("webhook")
+
+	webhookDeadLettersDBPrefix = []byte("deadLetters")
+	webhookUTXOsDBPrefix       = []byte("utxos")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -220,6 +231,12 @@ func New(
 	if err := n.initVMs(); err != nil { // Initialize the VM registry.
 		return nil, fmt.Errorf("couldn't initialize VM registry: %w", err)
 	}
+	if err := n.initChainAliases(n.Config.GenesisBytes); err != nil {
+		return nil, fmt.Errorf("couldn't initialize chain aliases: %w", err)
+	}
+	if err := n.initWebhooks(); err != nil {
+		return nil, fmt.Errorf("couldn't initialize webhooks: %w", err)
+	}
 	if err := n.initAdminAPI(); err != nil { // Start the Admin API
 		return nil, fmt.Errorf("couldn't initialize admin API: %w", err)
 	}
@@ -232,9 +249,6 @@ func New(
 	if err := n.initIPCAPI(); err != nil { // Start the IPC API
 		return nil, fmt.Errorf("couldn't initialize the IPC API: %w", err)
 	}
-	if err := n.initChainAliases(n.Config.GenesisBytes); err != nil {
-		return nil, fmt.Errorf("couldn't initialize chain aliases: %w", err)
-	}
 	if err := n.initAPIAliases(n.Config.GenesisBytes); err != nil {
 		return nil, fmt.Errorf("couldn't initialize API aliases: %w", err)
 	}
@@ -271,6 +285,10 @@ type Node struct {
 	// Indexes blocks, transactions and blocks
 	indexer indexer.Indexer
 
+	// Delivers address activity to webhook endpoints. Nil if no endpoints are
+	// configured.
+	webhooks *webhook.Dispatcher
+
 	// Handles calls to Keystore API
 	keystore keystore.Keystore
 
@@ -294,6 +312,10 @@ type Node struct {
 
 	uptimeCalculator uptime.LockedCalculator
 
+	// Serves signed attestations of the accepted frontier of chains to light
+	// clients
+	frontierTracker *frontier.Tracker
+
 	// dispatcher for events as they happen in consensus
 	BlockAcceptorGroup  snow.AcceptorGroup
 	TxAcceptorGroup     snow.AcceptorGroup
@@ -430,6 +452,8 @@ func (n *Node) initNetworking() error {
 		return errInvalidTLSKey
 	}
 
+	n.frontierTracker = frontier.NewTracker(tlsKey)
+
 	if n.Config.NetworkConfig.TLSKeyLogFile != "" {
 		n.tlsKeyLogWriterCloser, err = perms.Create(n.Config.NetworkConfig.TLSKeyLogFile, perms.ReadWrite)
 		if err != nil {
@@ -440,7 +464,11 @@ func (n *Node) initNetworking() error {
 		)
 	}
 
-	tlsConfig := peer.TLSConfig(n.Config.StakingTLSCert, n.tlsKeyLogWriterCloser)
+	tlsConfig := peer.TLSConfig(
+		n.Config.StakingTLSCert,
+		n.tlsKeyLogWriterCloser,
+		n.Config.NetworkConfig.TLSSessionCacheSize,
+	)
 
 	// Configure benchlist
 	n.Config.BenchlistConfig.Validators = n.vdrs
@@ -505,7 +533,12 @@ func (n *Node) initNetworking() error {
 	}
 
 	// initialize gossip tracker
-	gossipTracker, err := peer.NewGossipTracker(n.MetricsRegisterer, n.networkNamespace)
+	gossipTracker, err := peer.NewGossipTracker(
+		n.MetricsRegisterer,
+		n.networkNamespace,
+		int(n.Config.NetworkConfig.PeerListMaxKnownValidators),
+		n.Config.NetworkConfig.PeerListReconnectKnownTTL,
+	)
 	if err != nil {
 		return err
 	}
@@ -527,6 +560,7 @@ func (n *Node) initNetworking() error {
 	n.Config.NetworkConfig.TLSKey = tlsKey
 	n.Config.NetworkConfig.TrackedSubnets = n.Config.TrackedSubnets
 	n.Config.NetworkConfig.UptimeCalculator = n.uptimeCalculator
+	n.Config.NetworkConfig.FrontierAttestor = n.frontierTracker
 	n.Config.NetworkConfig.UptimeRequirement = n.Config.UptimeRequirement
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
@@ -815,6 +849,116 @@ func (n *Node) initIndexer() error {
 	return nil
 }
 
+// Initialize [n.webhooks].
+// Should only be called after [n.DB], [n.BlockAcceptorGroup], [n.VMManager],
+// [n.chainManager]'s chain aliases, and [n.Log] are initialized
+func (n *Node) initWebhooks() error {
+	if !n.Config.WebhookConfig.Enabled() {
+		return nil
+	}
+
+	createAVMTx, err := genesis.VMGenesis(n.Config.GenesisBytes, constants.AVMID)
+	if err != nil {
+		return err
+	}
+	xChainParser, err := n.newXChainParser(createAVMTx)
+	if err != nil {
+		return fmt.Errorf("couldn't create X-chain parser: %w", err)
+	}
+
+	webhookDB := prefixdb.New(webhookDBPrefix, n.DB)
+	utxoDB := prefixdb.New(webhookUTXOsDBPrefix, webhookDB)
+	n.webhooks = webhook.NewDispatcher(
+		n.Log,
+		n.Config.WebhookConfig,
+		prefixdb.New(webhookDeadLettersDBPrefix, webhookDB),
+	)
+
+	hrp := constants.GetHRP(n.Config.NetworkID)
+	chains := []struct {
+		chainID ids.ID
+		alias   string
+		parser  webhook.ActivityParser
+	}{
+		{
+			chainID: constants.PlatformChainID,
+			alias:   "P",
+			parser:  webhook.PChainParser,
+		},
+		{
+			chainID: createAVMTx.ID(),
+			alias:   "X",
+			parser:  webhook.NewXChainParser(xChainParser),
+		},
+	}
+	for _, chain := range chains {
+		acceptor, err := webhook.NewAcceptor(
+			n.webhooks,
+			chain.parser,
+			prefixdb.New(chain.chainID[:], utxoDB),
+			chain.alias,
+			hrp,
+			n.Config.WebhookConfig.Endpoints,
+		)
+		if err != nil {
+			return err
+		}
+		if !acceptor.Watching() {
+			continue
+		}
+
+		// Failing to notify a webhook should never halt the chain.
+		err = n.BlockAcceptorGroup.RegisterAcceptor(chain.chainID, "webhook", acceptor, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	n.webhooks.Start()
+	return nil
+}
+
+// newXChainParser returns a parser of the blocks of the X-chain created by
+// [createAVMTx], which supports the fxs that the X-chain is run with.
+func (n *Node) newXChainParser(createAVMTx *txs.Tx) (avmblock.Parser, error) {
+	createChainTx, ok := createAVMTx.Unsigned.(*txs.CreateChainTx)
+	if !ok {
+		return nil, fmt.Errorf("expected *txs.CreateChainTx but got %T", createAVMTx.Unsigned)
+	}
+
+	fxs := make([]*common.Fx, len(createChainTx.FxIDs))
+	for i, fxID := range createChainTx.FxIDs {
+		factory, err := n.VMManager.GetFactory(fxID)
+		if err != nil {
+			return nil, err
+		}
+		fx, err := factory.New(n.Log)
+		if err != nil {
+			return nil, err
+		}
+		fxs[i] = &common.Fx{
+			ID: fxID,
+			Fx: fx,
+		}
+	}
+
+	xChainID := createAVMTx.ID()
+	keys, err := n.chainManager.Aliases(xChainID)
+	if err != nil {
+		return nil, err
+	}
+	// The config of a chain is keyed by its ID, or else by its aliases.
+	keys = append([]string{xChainID.String()}, keys...)
+	var upgrade []byte
+	for _, key := range keys {
+		if chainConfig, ok := n.Config.ChainConfigs[key]; ok {
+			upgrade = chainConfig.Upgrade
+			break
+		}
+	}
+	return avm.NewChainParser(n.Log, n.VMManager, fxs, upgrade)
+}
+
 // Initializes the Platform chain.
 // Its genesis data specifies the other chains that should be created.
 func (n *Node) initChains(genesisBytes []byte) error {
@@ -985,6 +1129,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	n.chainManager = chains.New(&chains.ManagerConfig{
 		SybilProtectionEnabled:                  n.Config.SybilProtectionEnabled,
 		StakingTLSCert:                          n.Config.StakingTLSCert,
+		SecondaryStakingTLSCert:                 n.Config.SecondaryStakingTLSCert,
 		StakingBLSKey:                           n.Config.StakingSigningKey,
 		Log:                                     n.Log,
 		LogFactory:                              n.LogFactory,
@@ -997,6 +1142,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		Router:                                  n.Config.ConsensusRouter,
 		Net:                                     n.Net,
 		Validators:                              n.vdrs,
+		FrontierTracker:                         n.frontierTracker,
 		PartialSyncPrimaryNetwork:               n.Config.PartialSyncPrimaryNetwork,
 		NodeID:                                  n.ID,
 		NetworkID:                               n.Config.NetworkID,
@@ -1093,11 +1239,13 @@ func (n *Node) initVMs() error {
 				TxFee:            n.Config.TxFee,
 				CreateAssetTxFee: n.Config.CreateAssetTxFee,
 			},
+			FxFactories: n.VMManager,
 		}),
 		vmRegisterer.Register(context.TODO(), constants.EVMID, &coreth.Factory{}),
 		n.VMManager.RegisterFactory(context.TODO(), secp256k1fx.ID, &secp256k1fx.Factory{}),
 		n.VMManager.RegisterFactory(context.TODO(), nftfx.ID, &nftfx.Factory{}),
 		n.VMManager.RegisterFactory(context.TODO(), propertyfx.ID, &propertyfx.Factory{}),
+		n.VMManager.RegisterFactory(context.TODO(), freezefx.ID, &freezefx.Factory{}),
 	)
 	if err != nil {
 		return err
@@ -1114,6 +1262,9 @@ func (n *Node) initVMs() error {
 			PluginDirectory: n.Config.PluginDir,
 			CPUTracker:      n.resourceManager,
 			RuntimeTracker:  n.runtimeManager,
+			ConnPoolConfig:  n.Config.PluginConnPoolConfig,
+			CallTimeouts:    n.Config.PluginCallTimeoutConfig,
+			Sandbox:         n.Config.PluginSandboxConfig,
 		}),
 		VMRegisterer: vmRegisterer,
 	})
@@ -1207,6 +1358,7 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
+			Webhooks:     n.webhooks,
 		},
 	)
 	if err != nil {
@@ -1523,70 +1675,152 @@ func (n *Node) shutdown() {
 		time.Sleep(n.Config.ShutdownWait)
 	}
 
-	if n.resourceManager != nil {
-		n.resourceManager.Shutdown()
-	}
-	if n.IPCs != nil {
-		if err := n.IPCs.Shutdown(); err != nil {
-			n.Log.Debug("error during IPC shutdown",
-				zap.Error(err),
-			)
-		}
-	}
-	n.timeoutManager.Stop()
-	if n.chainManager != nil {
-		n.chainManager.Shutdown()
-	}
-	if n.profiler != nil {
-		n.profiler.Shutdown()
-	}
-	if n.Net != nil {
-		n.Net.StartClose()
-	}
-	if err := n.APIServer.Shutdown(); err != nil {
-		n.Log.Debug("error during API shutdown",
-			zap.Error(err),
-		)
-	}
-	if err := n.indexer.Close(); err != nil {
-		n.Log.Debug("error closing tx indexer",
-			zap.Error(err),
-		)
-	}
-
-	// Ensure all runtimes are shutdown
-	n.Log.Info("cleaning up plugin runtimes")
-	n.runtimeManager.Stop(context.TODO())
-
-	if n.DB != nil {
-		if err := n.DB.Delete(ungracefulShutdown); err != nil {
-			n.Log.Error(
-				"failed to delete ungraceful shutdown key",
-				zap.Error(err),
-			)
-		}
+	// Components are shut down in dependency order, so that no component is
+	// stopped while a component that depends on it is still running.
+	coordinator := shutdown.NewCoordinator(n.Log)
+	coordinator.AddStage("api", n.shutdownStageTimeout(n.Config.ShutdownTimeout),
+		shutdown.Component{
+			Name: "ipcs",
+			Shutdown: func(context.Context) error {
+				if n.IPCs == nil {
+					return nil
+				}
+				return n.IPCs.Shutdown()
+			},
+		},
+		shutdown.Component{
+			Name: "apiServer",
+			Shutdown: func(context.Context) error {
+				return n.APIServer.Shutdown()
+			},
+		},
+	)
+	coordinator.AddStage("chains", n.shutdownStageTimeout(n.Config.ConsensusShutdownTimeout),
+		shutdown.Component{
+			Name: "chainManager",
+			Shutdown: func(context.Context) error {
+				if n.chainManager != nil {
+					n.chainManager.Shutdown()
+				}
+				return nil
+			},
+		},
+		shutdown.Component{
+			Name: "indexer",
+			Shutdown: func(context.Context) error {
+				return n.indexer.Close()
+			},
+		},
+		shutdown.Component{
+			Name: "webhooks",
+			Shutdown: func(context.Context) error {
+				if n.webhooks != nil {
+					n.webhooks.Shutdown()
+				}
+				return nil
+			},
+		},
+	)
+	coordinator.AddStage("vms", n.shutdownStageTimeout(0),
+		shutdown.Component{
+			Name: "pluginRuntimes",
+			Shutdown: func(ctx context.Context) error {
+				n.runtimeManager.Stop(ctx)
+				return nil
+			},
+		},
+	)
+	coordinator.AddStage("network", n.shutdownStageTimeout(0),
+		shutdown.Component{
+			Name: "resourceManager",
+			Shutdown: func(context.Context) error {
+				if n.resourceManager != nil {
+					n.resourceManager.Shutdown()
+				}
+				return nil
+			},
+		},
+		shutdown.Component{
+			Name: "timeoutManager",
+			Shutdown: func(context.Context) error {
+				n.timeoutManager.Stop()
+				return nil
+			},
+		},
+		shutdown.Component{
+			Name: "profiler",
+			Shutdown: func(context.Context) error {
+				if n.profiler != nil {
+					n.profiler.Shutdown()
+				}
+				return nil
+			},
+		},
+		shutdown.Component{
+			Name: "network",
+			Shutdown: func(context.Context) error {
+				if n.Net != nil {
+					n.Net.StartClose()
+				}
+				return nil
+			},
+		},
+	)
+	// The database is left open if a previous stage was aborted, because the
+	// abandoned components, such as the chains, may still be writing to it.
+	// The ungraceful shutdown key is then left in place, so the next startup
+	// is treated as recovering from an ungraceful shutdown.
+	coordinator.AddStageRequiringCompletion("database", n.shutdownStageTimeout(0),
+		shutdown.Component{
+			Name: "database",
+			Shutdown: func(context.Context) error {
+				if n.DB == nil {
+					return nil
+				}
+				if err := n.DB.Delete(ungracefulShutdown); err != nil {
+					n.Log.Error(
+						"failed to delete ungraceful shutdown key",
+						zap.Error(err),
+					)
+				}
+				return n.DB.Close()
+			},
+		},
+	)
+	coordinator.AddStage("tracer", n.shutdownStageTimeout(0),
+		shutdown.Component{
+			Name: "tracer",
+			Shutdown: func(context.Context) error {
+				if n.Config.TraceConfig.Enabled {
+					n.Log.Info("shutting down tracing")
+				}
+				return n.tracer.Close()
+			},
+		},
+	)
 
-		if err := n.DB.Close(); err != nil {
-			n.Log.Warn("error during DB shutdown",
-				zap.Error(err),
+	for _, report := range coordinator.Shutdown(context.TODO()) {
+		if report.Aborted || report.Skipped {
+			n.Log.Error("node shutdown was forced",
+				zap.String("stage", report.Name),
+				zap.Strings("abandonedComponents", report.Pending),
 			)
 		}
 	}
 
-	if n.Config.TraceConfig.Enabled {
-		n.Log.Info("shutting down tracing")
-	}
-
-	if err := n.tracer.Close(); err != nil {
-		n.Log.Warn("error during tracer shutdown",
-			zap.Error(err),
-		)
-	}
-
 	n.DoneShuttingDown.Done()
 	n.Log.Info("finished node shutdown")
 }
 
+// shutdownStageTimeout returns the amount of time a shutdown stage, whose
+// components enforce [componentTimeout] internally, is given to complete.
+func (n *Node) shutdownStageTimeout(componentTimeout time.Duration) time.Duration {
+	if n.Config.ShutdownStageTimeout == 0 {
+		return 0
+	}
+	return componentTimeout + n.Config.ShutdownStageTimeout
+}
+
 func (n *Node) ExitCode() int {
 	return n.shuttingDownExitCode.Get()
 }
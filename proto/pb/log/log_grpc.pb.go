@@ -0,0 +1,186 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: log/log.proto
+
+package log
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Log_Level_FullMethodName = "/log.Log/Level"
+	Log_Write_FullMethodName = "/log.Log/Write"
+)
+
+// LogClient is the client API for Log service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LogClient interface {
+	// Level returns the minimum level of the records that will be written.
+	Level(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LevelResponse, error)
+	// Write writes a stream of records to the log.
+	Write(ctx context.Context, opts ...grpc.CallOption) (Log_WriteClient, error)
+}
+
+type logClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLogClient(cc grpc.ClientConnInterface) LogClient {
+	return &logClient{cc}
+}
+
+func (c *logClient) Level(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LevelResponse, error) {
+	out := new(LevelResponse)
+	err := c.cc.Invoke(ctx, Log_Level_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logClient) Write(ctx context.Context, opts ...grpc.CallOption) (Log_WriteClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Log_ServiceDesc.Streams[0], Log_Write_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &logWriteClient{stream}
+	return x, nil
+}
+
+type Log_WriteClient interface {
+	Send(*WriteRequest) error
+	CloseAndRecv() (*emptypb.Empty, error)
+	grpc.ClientStream
+}
+
+type logWriteClient struct {
+	grpc.ClientStream
+}
+
+func (x *logWriteClient) Send(m *WriteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *logWriteClient) CloseAndRecv() (*emptypb.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(emptypb.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LogServer is the server API for Log service.
+// All implementations must embed UnimplementedLogServer
+// for forward compatibility
+type LogServer interface {
+	// Level returns the minimum level of the records that will be written.
+	Level(context.Context, *emptypb.Empty) (*LevelResponse, error)
+	// Write writes a stream of records to the log.
+	Write(Log_WriteServer) error
+	mustEmbedUnimplementedLogServer()
+}
+
+// UnimplementedLogServer must be embedded to have forward compatible implementations.
+type UnimplementedLogServer struct {
+}
+
+func (UnimplementedLogServer) Level(context.Context, *emptypb.Empty) (*LevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Level not implemented")
+}
+func (UnimplementedLogServer) Write(Log_WriteServer) error {
+	return status.Errorf(codes.Unimplemented, "method Write not implemented")
+}
+func (UnimplementedLogServer) mustEmbedUnimplementedLogServer() {}
+
+// UnsafeLogServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LogServer will
+// result in compilation errors.
+type UnsafeLogServer interface {
+	mustEmbedUnimplementedLogServer()
+}
+
+func RegisterLogServer(s grpc.ServiceRegistrar, srv LogServer) {
+	s.RegisterService(&Log_ServiceDesc, srv)
+}
+
+func _Log_Level_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogServer).Level(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Log_Level_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogServer).Level(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Log_Write_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LogServer).Write(&logWriteServer{stream})
+}
+
+type Log_WriteServer interface {
+	SendAndClose(*emptypb.Empty) error
+	Recv() (*WriteRequest, error)
+	grpc.ServerStream
+}
+
+type logWriteServer struct {
+	grpc.ServerStream
+}
+
+func (x *logWriteServer) SendAndClose(m *emptypb.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *logWriteServer) Recv() (*WriteRequest, error) {
+	m := new(WriteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Log_ServiceDesc is the grpc.ServiceDesc for Log service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Log_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "log.Log",
+	HandlerType: (*LogServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Level",
+			Handler:    _Log_Level_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Write",
+			Handler:       _Log_Write_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "log/log.proto",
+}
@@ -0,0 +1,151 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: profiler/profiler.proto
+
+package profiler
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Profiler_Profile_FullMethodName        = "/profiler.Profiler/Profile"
+	Profiler_RuntimeMetrics_FullMethodName = "/profiler.Profiler/RuntimeMetrics"
+)
+
+// ProfilerClient is the client API for Profiler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProfilerClient interface {
+	// Profile collects a pprof profile of the process.
+	Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
+	// RuntimeMetrics returns the Go runtime and process metrics of the process.
+	RuntimeMetrics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RuntimeMetricsResponse, error)
+}
+
+type profilerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProfilerClient(cc grpc.ClientConnInterface) ProfilerClient {
+	return &profilerClient{cc}
+}
+
+func (c *profilerClient) Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error) {
+	out := new(ProfileResponse)
+	err := c.cc.Invoke(ctx, Profiler_Profile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profilerClient) RuntimeMetrics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RuntimeMetricsResponse, error) {
+	out := new(RuntimeMetricsResponse)
+	err := c.cc.Invoke(ctx, Profiler_RuntimeMetrics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProfilerServer is the server API for Profiler service.
+// All implementations must embed UnimplementedProfilerServer
+// for forward compatibility
+type ProfilerServer interface {
+	// Profile collects a pprof profile of the process.
+	Profile(context.Context, *ProfileRequest) (*ProfileResponse, error)
+	// RuntimeMetrics returns the Go runtime and process metrics of the process.
+	RuntimeMetrics(context.Context, *emptypb.Empty) (*RuntimeMetricsResponse, error)
+	mustEmbedUnimplementedProfilerServer()
+}
+
+// UnimplementedProfilerServer must be embedded to have forward compatible implementations.
+type UnimplementedProfilerServer struct {
+}
+
+func (UnimplementedProfilerServer) Profile(context.Context, *ProfileRequest) (*ProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Profile not implemented")
+}
+func (UnimplementedProfilerServer) RuntimeMetrics(context.Context, *emptypb.Empty) (*RuntimeMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RuntimeMetrics not implemented")
+}
+func (UnimplementedProfilerServer) mustEmbedUnimplementedProfilerServer() {}
+
+// UnsafeProfilerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProfilerServer will
+// result in compilation errors.
+type UnsafeProfilerServer interface {
+	mustEmbedUnimplementedProfilerServer()
+}
+
+func RegisterProfilerServer(s grpc.ServiceRegistrar, srv ProfilerServer) {
+	s.RegisterService(&Profiler_ServiceDesc, srv)
+}
+
+func _Profiler_Profile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfilerServer).Profile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Profiler_Profile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfilerServer).Profile(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profiler_RuntimeMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfilerServer).RuntimeMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Profiler_RuntimeMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfilerServer).RuntimeMetrics(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Profiler_ServiceDesc is the grpc.ServiceDesc for Profiler service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Profiler_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "profiler.Profiler",
+	HandlerType: (*ProfilerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Profile",
+			Handler:    _Profiler_Profile_Handler,
+		},
+		{
+			MethodName: "RuntimeMetrics",
+			Handler:    _Profiler_RuntimeMetrics_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "profiler/profiler.proto",
+}
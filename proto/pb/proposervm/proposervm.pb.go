@@ -0,0 +1,224 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: proposervm/proposervm.proto
+
+package proposervm
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GetAncestorsRequest requests an accepted post-fork block and its accepted
+// ancestors.
+type GetAncestorsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the first block to return
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	// Maximum number of blocks to return
+	MaxBlocks uint32 `protobuf:"varint,2,opt,name=max_blocks,json=maxBlocks,proto3" json:"max_blocks,omitempty"`
+}
+
+func (x *GetAncestorsRequest) Reset() {
+	*x = GetAncestorsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proposervm_proposervm_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAncestorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAncestorsRequest) ProtoMessage() {}
+
+func (x *GetAncestorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proposervm_proposervm_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAncestorsRequest.ProtoReflect.Descriptor instead.
+func (*GetAncestorsRequest) Descriptor() ([]byte, []int) {
+	return file_proposervm_proposervm_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetAncestorsRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetAncestorsRequest) GetMaxBlocks() uint32 {
+	if x != nil {
+		return x.MaxBlocks
+	}
+	return 0
+}
+
+type GetAncestorsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Blocks in decreasing height order, starting with the requested block
+	Blocks [][]byte `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+}
+
+func (x *GetAncestorsResponse) Reset() {
+	*x = GetAncestorsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proposervm_proposervm_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAncestorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAncestorsResponse) ProtoMessage() {}
+
+func (x *GetAncestorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proposervm_proposervm_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAncestorsResponse.ProtoReflect.Descriptor instead.
+func (*GetAncestorsResponse) Descriptor() ([]byte, []int) {
+	return file_proposervm_proposervm_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetAncestorsResponse) GetBlocks() [][]byte {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+var File_proposervm_proposervm_proto protoreflect.FileDescriptor
+
+var file_proposervm_proposervm_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x76, 0x6d, 0x2f, 0x70, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x65, 0x72, 0x76, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x70,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x76, 0x6d, 0x22, 0x4f, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x41, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x61, 0x78, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x2e, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x41, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x76, 0x61, 0x2d, 0x6c, 0x61, 0x62,
+	0x73, 0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x76,
+	0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proposervm_proposervm_proto_rawDescOnce sync.Once
+	file_proposervm_proposervm_proto_rawDescData = file_proposervm_proposervm_proto_rawDesc
+)
+
+func file_proposervm_proposervm_proto_rawDescGZIP() []byte {
+	file_proposervm_proposervm_proto_rawDescOnce.Do(func() {
+		file_proposervm_proposervm_proto_rawDescData = protoimpl.X.CompressGZIP(file_proposervm_proposervm_proto_rawDescData)
+	})
+	return file_proposervm_proposervm_proto_rawDescData
+}
+
+var file_proposervm_proposervm_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proposervm_proposervm_proto_goTypes = []interface{}{
+	(*GetAncestorsRequest)(nil),  // 0: proposervm.GetAncestorsRequest
+	(*GetAncestorsResponse)(nil), // 1: proposervm.GetAncestorsResponse
+}
+var file_proposervm_proposervm_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_proposervm_proposervm_proto_init() }
+func file_proposervm_proposervm_proto_init() {
+	if File_proposervm_proposervm_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proposervm_proposervm_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAncestorsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proposervm_proposervm_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAncestorsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proposervm_proposervm_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proposervm_proposervm_proto_goTypes,
+		DependencyIndexes: file_proposervm_proposervm_proto_depIdxs,
+		MessageInfos:      file_proposervm_proposervm_proto_msgTypes,
+	}.Build()
+	File_proposervm_proposervm_proto = out.File
+	file_proposervm_proposervm_proto_rawDesc = nil
+	file_proposervm_proposervm_proto_goTypes = nil
+	file_proposervm_proposervm_proto_depIdxs = nil
+}
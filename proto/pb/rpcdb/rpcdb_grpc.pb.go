@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	Database_Has_FullMethodName                           = "/rpcdb.Database/Has"
 	Database_Get_FullMethodName                           = "/rpcdb.Database/Get"
+	Database_MultiGet_FullMethodName                      = "/rpcdb.Database/MultiGet"
 	Database_Put_FullMethodName                           = "/rpcdb.Database/Put"
 	Database_Delete_FullMethodName                        = "/rpcdb.Database/Delete"
 	Database_Compact_FullMethodName                       = "/rpcdb.Database/Compact"
@@ -40,6 +41,7 @@ const (
 type DatabaseClient interface {
 	Has(ctx context.Context, in *HasRequest, opts ...grpc.CallOption) (*HasResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	MultiGet(ctx context.Context, in *MultiGetRequest, opts ...grpc.CallOption) (*MultiGetResponse, error)
 	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error)
@@ -78,6 +80,15 @@ func (c *databaseClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *databaseClient) MultiGet(ctx context.Context, in *MultiGetRequest, opts ...grpc.CallOption) (*MultiGetResponse, error) {
+	out := new(MultiGetResponse)
+	err := c.cc.Invoke(ctx, Database_MultiGet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error) {
 	out := new(PutResponse)
 	err := c.cc.Invoke(ctx, Database_Put_FullMethodName, in, out, opts...)
@@ -174,6 +185,7 @@ func (c *databaseClient) IteratorRelease(ctx context.Context, in *IteratorReleas
 type DatabaseServer interface {
 	Has(context.Context, *HasRequest) (*HasResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
+	MultiGet(context.Context, *MultiGetRequest) (*MultiGetResponse, error)
 	Put(context.Context, *PutRequest) (*PutResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	Compact(context.Context, *CompactRequest) (*CompactResponse, error)
@@ -197,6 +209,9 @@ func (UnimplementedDatabaseServer) Has(context.Context, *HasRequest) (*HasRespon
 func (UnimplementedDatabaseServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedDatabaseServer) MultiGet(context.Context, *MultiGetRequest) (*MultiGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MultiGet not implemented")
+}
 func (UnimplementedDatabaseServer) Put(context.Context, *PutRequest) (*PutResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
 }
@@ -276,6 +291,24 @@ func _Database_Get_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_MultiGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).MultiGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_MultiGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).MultiGet(ctx, req.(*MultiGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PutRequest)
 	if err := dec(in); err != nil {
@@ -471,6 +504,10 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _Database_Get_Handler,
 		},
+		{
+			MethodName: "MultiGet",
+			Handler:    _Database_MultiGet_Handler,
+		},
 		{
 			MethodName: "Put",
 			Handler:    _Database_Put_Handler,
@@ -80,6 +80,14 @@ type Parameters struct {
 	// Reports unhealthy if there is an item processing for longer than this
 	// duration.
 	MaxItemProcessingTime time.Duration `json:"maxItemProcessingTime" yaml:"maxItemProcessingTime"`
+
+	// EarlyTermStakeThreshold is the fraction of the stake of the polled
+	// validators that must agree on an element, which has at least an
+	// AlphaPreference majority, for a poll to terminate without waiting for
+	// the remaining responses once no element can reach an AlphaConfidence
+	// majority after applying transitive voting. If 0, polls are never
+	// terminated based on stake.
+	EarlyTermStakeThreshold float64 `json:"earlyTermStakeThreshold,omitempty" yaml:"earlyTermStakeThreshold,omitempty"`
 }
 
 // Verify returns nil if the parameters describe a valid initialization.
@@ -92,6 +100,7 @@ type Parameters struct {
 // - 0 < OptimalProcessing
 // - 0 < MaxOutstandingItems
 // - 0 < MaxItemProcessingTime
+// - 0 <= EarlyTermStakeThreshold <= 1
 //
 // Note: K/2 < K implies that 0 <= K/2, so we don't need an explicit check that
 // AlphaPreference is positive.
@@ -119,6 +128,8 @@ func (p Parameters) Verify() error {
 		return fmt.Errorf("%w: maxOutstandingItems = %d: fails the condition that: 0 < maxOutstandingItems", ErrParametersInvalid, p.MaxOutstandingItems)
 	case p.MaxItemProcessingTime <= 0:
 		return fmt.Errorf("%w: maxItemProcessingTime = %d: fails the condition that: 0 < maxItemProcessingTime", ErrParametersInvalid, p.MaxItemProcessingTime)
+	case p.EarlyTermStakeThreshold < 0 || p.EarlyTermStakeThreshold > 1:
+		return fmt.Errorf("%w: earlyTermStakeThreshold = %f: fails the condition that: 0 <= earlyTermStakeThreshold <= 1", ErrParametersInvalid, p.EarlyTermStakeThreshold)
 	default:
 		return nil
 	}
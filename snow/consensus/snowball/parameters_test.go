@@ -240,6 +240,38 @@ func TestParametersVerify(t *testing.T) {
 			},
 			expectedError: ErrParametersInvalid,
 		},
+		{
+			name: "invalid EarlyTermStakeThreshold negative",
+			params: Parameters{
+				K:                       1,
+				AlphaPreference:         1,
+				AlphaConfidence:         1,
+				BetaVirtuous:            1,
+				BetaRogue:               1,
+				ConcurrentRepolls:       1,
+				OptimalProcessing:       1,
+				MaxOutstandingItems:     1,
+				MaxItemProcessingTime:   1,
+				EarlyTermStakeThreshold: -0.1,
+			},
+			expectedError: ErrParametersInvalid,
+		},
+		{
+			name: "invalid EarlyTermStakeThreshold too large",
+			params: Parameters{
+				K:                       1,
+				AlphaPreference:         1,
+				AlphaConfidence:         1,
+				BetaVirtuous:            1,
+				BetaRogue:               1,
+				ConcurrentRepolls:       1,
+				OptimalProcessing:       1,
+				MaxOutstandingItems:     1,
+				MaxItemProcessingTime:   1,
+				EarlyTermStakeThreshold: 1.1,
+			},
+			expectedError: ErrParametersInvalid,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
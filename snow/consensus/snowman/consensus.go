@@ -41,6 +41,10 @@ type Consensus interface {
 	// Processing returns true if the block ID is currently processing.
 	Processing(ids.ID) bool
 
+	// GetParent returns the ID of the parent of the block if the block is
+	// currently processing.
+	GetParent(ids.ID) (ids.ID, bool)
+
 	// IsPreferred returns true if the block is currently on the preferred
 	// chain.
 	IsPreferred(Block) bool
@@ -46,6 +46,7 @@ var (
 		StatusOrProcessingPreviouslyRejectedTest,
 		StatusOrProcessingUnissuedTest,
 		StatusOrProcessingIssuedTest,
+		GetParentTest,
 		RecordPollAcceptSingleBlockTest,
 		RecordPollAcceptAndRejectTest,
 		RecordPollSplitVoteNoChangeTest,
@@ -424,6 +425,47 @@ func StatusOrProcessingIssuedTest(t *testing.T, factory Factory) {
 	require.Equal(block.ID(), pref)
 }
 
+func GetParentTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	ctx := snow.DefaultConsensusContextTest()
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		BetaVirtuous:          3,
+		BetaRogue:             5,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	block := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+
+	_, ok := sm.GetParent(block.ID())
+	require.False(ok)
+
+	require.NoError(sm.Add(context.Background(), block))
+
+	parentID, ok := sm.GetParent(block.ID())
+	require.True(ok)
+	require.Equal(Genesis.IDV, parentID)
+
+	_, ok = sm.GetParent(GenesisID)
+	require.False(ok)
+}
+
 func RecordPollAcceptSingleBlockTest(t *testing.T, factory Factory) {
 	require := require.New(t)
 
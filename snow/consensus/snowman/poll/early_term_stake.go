@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+var errFailedEarlyTermStakeMetrics = errors.New("failed to register early termination metrics")
+
+type earlyTermStakeFactory struct {
+	alphaPreference int
+	alphaConfidence int
+	stakeThreshold  float64
+	weight          func(ids.NodeID) uint64
+	getParent       func(ids.ID) (ids.ID, bool)
+
+	numTerminated     prometheus.Counter
+	numSkippedSamples prometheus.Counter
+}
+
+// NewEarlyTermStakeFactory returns a factory that returns polls with early
+// termination. In addition to the conditions of the polls returned by
+// NewEarlyTermNoTraversalFactory, the polls finish once validators holding at
+// least [stakeThreshold] of the stake of the polled validators have voted for
+// an element, or its descendants, that has an alphaPreference majority, and no
+// element can achieve an alphaConfidence majority after applying transitive
+// voting.
+//
+// [weight] returns the current stake of a validator. [getParent] returns the
+// parent of a processing block, or false if the block isn't processing.
+func NewEarlyTermStakeFactory(
+	alphaPreference int,
+	alphaConfidence int,
+	stakeThreshold float64,
+	weight func(ids.NodeID) uint64,
+	getParent func(ids.ID) (ids.ID, bool),
+	namespace string,
+	reg prometheus.Registerer,
+) (Factory, error) {
+	f := &earlyTermStakeFactory{
+		alphaPreference: alphaPreference,
+		alphaConfidence: alphaConfidence,
+		stakeThreshold:  stakeThreshold,
+		weight:          weight,
+		getParent:       getParent,
+		numTerminated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "polls_stake_terminated",
+			Help:      "Number of polls that finished early due to the agreeing stake",
+		}),
+		numSkippedSamples: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "polls_stake_terminated_skipped_samples",
+			Help:      "Number of sampled responses that weren't waited on due to polls finishing early",
+		}),
+	}
+
+	if err := reg.Register(f.numTerminated); err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedEarlyTermStakeMetrics, err)
+	}
+	if err := reg.Register(f.numSkippedSamples); err != nil {
+		return nil, fmt.Errorf("%w: %w", errFailedEarlyTermStakeMetrics, err)
+	}
+	return f, nil
+}
+
+func (f *earlyTermStakeFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
+	// The weights are fixed when the poll is created so that changes to the
+	// validator set can't change the result of the poll. The polled validators
+	// are a subset of the validator set, whose total weight is guaranteed not
+	// to overflow.
+	var (
+		weights      = make(map[ids.NodeID]uint64, vdrs.Len())
+		polledWeight uint64
+	)
+	for _, vdr := range vdrs.List() {
+		weight := f.weight(vdr)
+		weights[vdr] = weight
+		polledWeight += weight
+	}
+	return &earlyTermStakePoll{
+		earlyTermNoTraversalPoll: earlyTermNoTraversalPoll{
+			polled:          vdrs,
+			alphaPreference: f.alphaPreference,
+			alphaConfidence: f.alphaConfidence,
+		},
+		factory:      f,
+		weights:      weights,
+		polledWeight: polledWeight,
+		voteWeights:  make(map[ids.ID]uint64),
+	}
+}
+
+// earlyTermStakePoll finishes when any remaining validators can't change the
+// result of the poll, or once enough stake agrees on an element that has an
+// alphaPreference majority while no element can reach an alphaConfidence
+// majority.
+type earlyTermStakePoll struct {
+	earlyTermNoTraversalPoll
+
+	factory      *earlyTermStakeFactory
+	weights      map[ids.NodeID]uint64
+	polledWeight uint64
+	// vote --> weight of the validators that responded with the vote
+	voteWeights map[ids.ID]uint64
+	terminated  bool
+}
+
+// Vote registers a response for this poll
+func (p *earlyTermStakePoll) Vote(vdr ids.NodeID, vote ids.ID) {
+	// Only the first response of a polled validator is counted
+	if p.polled.Count(vdr) > 0 {
+		p.voteWeights[vote] += p.weights[vdr]
+	}
+	p.earlyTermNoTraversalPoll.Vote(vdr, vote)
+}
+
+// Finished returns true if the poll would have finished without considering
+// stake, or if:
+//
+//  1. An element has achieved an alphaPreference majority after applying
+//     transitive voting.
+//  2. Validators holding at least the stake threshold of the polled stake
+//     voted for the element or its descendants.
+//  3. It is impossible for any element to achieve an alphaConfidence majority
+//     after applying transitive voting.
+//
+// Votes for blocks that aren't processing may be for descendants of any
+// element that haven't been issued yet, so they are counted towards every
+// element when checking that no alphaConfidence majority is possible.
+func (p *earlyTermStakePoll) Finished() bool {
+	if p.terminated || p.earlyTermNoTraversalPoll.Finished() {
+		return true
+	}
+	if p.polledWeight == 0 || p.factory.stakeThreshold <= 0 {
+		return false
+	}
+
+	var (
+		// element --> votes and stake after applying transitive voting
+		counts  = make(map[ids.ID]int)
+		weights = make(map[ids.ID]uint64)
+		// number of votes for blocks that aren't processing
+		numUnknown int
+	)
+	for _, vote := range p.votes.List() {
+		var (
+			count  = p.votes.Count(vote)
+			weight = p.voteWeights[vote]
+			blkID  = vote
+		)
+		for {
+			parentID, ok := p.factory.getParent(blkID)
+			if !ok {
+				break
+			}
+			counts[blkID] += count
+			weights[blkID] += weight
+			blkID = parentID
+		}
+		if blkID == vote {
+			numUnknown += count
+		}
+	}
+
+	var (
+		requiredWeight = p.factory.stakeThreshold * float64(p.polledWeight)
+		maxCount       int
+		agreed         bool
+	)
+	for blkID, count := range counts {
+		maxCount = math.Max(maxCount, count)
+		agreed = agreed || count >= p.alphaPreference && float64(weights[blkID]) >= requiredWeight
+	}
+	remaining := p.polled.Len()
+	if !agreed || maxCount+numUnknown+remaining >= p.alphaConfidence {
+		return false
+	}
+
+	p.terminated = true
+	p.factory.numTerminated.Inc()
+	p.factory.numSkippedSamples.Add(float64(remaining))
+	return true
+}
@@ -0,0 +1,322 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+)
+
+var (
+	vdr6 = ids.BuildTestNodeID([]byte{0x06})
+
+	// lastAcceptedID isn't processing, blkID1 and blkID2 are conflicting
+	// children of it, and blkID3 is a child of blkID1. blkID4 isn't known.
+	lastAcceptedID = ids.ID{0xff}
+	testParents    = map[ids.ID]ids.ID{
+		blkID1: lastAcceptedID,
+		blkID2: lastAcceptedID,
+		blkID3: blkID1,
+	}
+)
+
+func newTestEarlyTermStakeFactory(
+	t *testing.T,
+	alphaPreference int,
+	alphaConfidence int,
+	stakeThreshold float64,
+	weights map[ids.NodeID]uint64,
+) *earlyTermStakeFactory {
+	factory, err := NewEarlyTermStakeFactory(
+		alphaPreference,
+		alphaConfidence,
+		stakeThreshold,
+		func(nodeID ids.NodeID) uint64 {
+			return weights[nodeID]
+		},
+		func(blkID ids.ID) (ids.ID, bool) {
+			parentID, ok := testParents[blkID]
+			return parentID, ok
+		},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	return factory.(*earlyTermStakeFactory)
+}
+
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	metric := &dto.Metric{}
+	require.NoError(t, counter.Write(metric))
+	return metric.GetCounter().GetValue()
+}
+
+// Once validators holding the stake threshold agree on an element with an
+// alphaPreference majority, the poll finishes as soon as no element can reach
+// an alphaConfidence majority, even if the number of outstanding responses
+// would allow the received votes to reach one.
+func TestEarlyTermStakeTerminatesWithAgreeingStake(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5, vdr6) // k = 6
+	weights := map[ids.NodeID]uint64{
+		vdr1: 30,
+		vdr2: 30,
+		vdr3: 30,
+		vdr4: 4,
+		vdr5: 3,
+		vdr6: 3,
+	}
+
+	factory := newTestEarlyTermStakeFactory(t, 3, 5, .75, weights)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr4, blkID2)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr5, blkID2)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr2, blkID1)
+	require.False(poll.Finished())
+
+	// blkID1 has an alphaPreference majority and 90% of the stake voted for
+	// it. Neither blkID1 nor blkID2 can reach an alphaConfidence majority, but
+	// the received votes plus the outstanding response could have.
+	poll.Vote(vdr3, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(3, result.Count(blkID1))
+	require.Equal(2, result.Count(blkID2))
+
+	require.Equal(float64(1), counterValue(t, factory.numTerminated))
+	require.Equal(float64(1), counterValue(t, factory.numSkippedSamples))
+}
+
+// Votes for a block count towards its ancestors, so votes for the child of an
+// element must keep the poll open while the element can still reach an
+// alphaConfidence majority.
+func TestEarlyTermStakeCountsChildAndAncestorVotes(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5, vdr6) // k = 6
+	weights := map[ids.NodeID]uint64{
+		vdr1: 30,
+		vdr2: 30,
+		vdr3: 30,
+		vdr4: 4,
+		vdr5: 3,
+		vdr6: 3,
+	}
+
+	factory := newTestEarlyTermStakeFactory(t, 3, 5, .75, weights)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr4, blkID3)
+	poll.Vote(vdr5, blkID3)
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID1)
+	poll.Vote(vdr3, blkID1)
+
+	// blkID1 has 5 votes after applying transitive voting, so it already
+	// achieved an alphaConfidence majority.
+	require.False(poll.Finished())
+
+	poll.Vote(vdr6, blkID3)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(3, result.Count(blkID1))
+	require.Equal(3, result.Count(blkID3))
+	require.Zero(counterValue(t, factory.numTerminated))
+}
+
+// Votes for blocks that aren't processing may be for descendants of any
+// element, so they must keep the poll open while they could result in an
+// alphaConfidence majority.
+func TestEarlyTermStakeCountsUnknownVotes(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5, vdr6) // k = 6
+	weights := map[ids.NodeID]uint64{
+		vdr1: 30,
+		vdr2: 30,
+		vdr3: 30,
+		vdr4: 4,
+		vdr5: 3,
+		vdr6: 3,
+	}
+
+	factory := newTestEarlyTermStakeFactory(t, 3, 5, .75, weights)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr4, blkID4)
+	poll.Vote(vdr5, blkID4)
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID1)
+	poll.Vote(vdr3, blkID1)
+	require.False(poll.Finished())
+
+	poll.Drop(vdr6)
+	require.True(poll.Finished())
+	require.Zero(counterValue(t, factory.numTerminated))
+}
+
+// Validators holding less than the stake threshold agreeing on an element
+// isn't enough to terminate the poll.
+func TestEarlyTermStakeRequiresStakeThreshold(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5, vdr6) // k = 6
+	weights := map[ids.NodeID]uint64{
+		vdr1: 10,
+		vdr2: 10,
+		vdr3: 10,
+		vdr4: 30,
+		vdr5: 30,
+		vdr6: 10,
+	}
+
+	factory := newTestEarlyTermStakeFactory(t, 3, 5, .5, weights)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr4, blkID2)
+	poll.Vote(vdr5, blkID2)
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID1)
+	poll.Vote(vdr3, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr6, blkID1)
+	require.True(poll.Finished())
+	require.Zero(counterValue(t, factory.numTerminated))
+}
+
+// Validators holding the majority of the stake agreeing isn't enough to
+// terminate the poll if the element doesn't have an alphaPreference majority.
+func TestEarlyTermStakeRequiresAlphaPreference(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5, vdr6) // k = 6
+	weights := map[ids.NodeID]uint64{
+		vdr1: 95,
+		vdr2: 1,
+		vdr3: 1,
+		vdr4: 1,
+		vdr5: 1,
+		vdr6: 1,
+	}
+
+	factory := newTestEarlyTermStakeFactory(t, 3, 5, .5, weights)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr4, blkID2)
+	poll.Vote(vdr5, blkID2)
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr3, blkID1)
+	require.True(poll.Finished())
+	require.Equal(float64(1), counterValue(t, factory.numTerminated))
+}
+
+// A validator responding multiple times, or a validator that wasn't polled
+// responding, must not contribute stake towards early termination.
+func TestEarlyTermStakeIgnoresDuplicateAndUnpolledVotes(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr5, vdr6) // k = 5
+	weights := map[ids.NodeID]uint64{
+		vdr1: 10,
+		vdr2: 1,
+		vdr3: 10,
+		vdr4: 1_000,
+		vdr5: 5,
+		vdr6: 5,
+	}
+
+	factory := newTestEarlyTermStakeFactory(t, 2, 4, .5, weights)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr5, blkID2)
+	poll.Vote(vdr6, blkID2)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr4, blkID1)
+	require.False(poll.Finished())
+
+	// blkID1 has an alphaPreference majority and no element can reach an
+	// alphaConfidence majority, but only 11 of the 31 polled stake voted for
+	// it.
+	poll.Vote(vdr2, blkID1)
+	require.False(poll.Finished())
+
+	poll.Drop(vdr3)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(2, result.Count(blkID1))
+	require.Zero(counterValue(t, factory.numTerminated))
+}
+
+// Changes to the validator set during the poll must not change the result.
+func TestEarlyTermStakeUsesWeightsAtCreation(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5) // k = 5
+	weights := map[ids.NodeID]uint64{
+		vdr1: 10,
+		vdr2: 10,
+		vdr3: 10,
+		vdr4: 10,
+		vdr5: 10,
+	}
+
+	factory := newTestEarlyTermStakeFactory(t, 2, 4, .5, weights)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr4, blkID2)
+	poll.Vote(vdr5, blkID2)
+	require.False(poll.Finished())
+
+	weights[vdr1] = 1_000
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	// blkID1 has an alphaPreference majority and no element can reach an
+	// alphaConfidence majority, but only 40% of the stake voted for it.
+	poll.Vote(vdr2, blkID1)
+	require.False(poll.Finished())
+	require.Zero(counterValue(t, factory.numTerminated))
+}
+
+func TestEarlyTermStakeFallsBackToNoTraversal(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2) // k = 2
+	factory := newTestEarlyTermStakeFactory(t, 2, 2, 1, nil)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	poll.Drop(vdr2)
+	require.True(poll.Finished())
+	require.Zero(counterValue(t, factory.numTerminated))
+}
@@ -223,6 +223,17 @@ func (ts *Topological) Processing(blkID ids.ID) bool {
 	return ok
 }
 
+func (ts *Topological) GetParent(blkID ids.ID) (ids.ID, bool) {
+	if blkID == ts.lastAcceptedID {
+		return ids.Empty, false
+	}
+	n, ok := ts.blocks[blkID]
+	if !ok {
+		return ids.Empty, false
+	}
+	return n.blk.Parent(), true
+}
+
 func (ts *Topological) IsPreferred(blk Block) bool {
 	// If the block is accepted, then it must be transitively preferred.
 	if blk.Status() == choices.Accepted {
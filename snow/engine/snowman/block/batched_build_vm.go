@@ -0,0 +1,29 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+)
+
+// BatchedBuildChainVM defines the interface a ChainVM can optionally implement
+// to build multiple blocks in a single call. This allows high throughput VMs to
+// avoid a round-trip through the consensus engine for every block they build.
+type BatchedBuildChainVM interface {
+	// Attempt to build a chain of at most [maxBlocks] new blocks on top of the
+	// preferred block. The first returned block must be a child of the
+	// preferred block and every following block must be a child of the block
+	// before it. If a nil error is returned, at least one block must be
+	// returned.
+	//
+	// Every returned block will be verified with a P-Chain height of
+	// [blockCtx.PChainHeight].
+	//
+	// This method will be called if and only if the proposervm is activated.
+	// If it isn't, or if the proposervm is building the first post-fork block,
+	// [BuildBlock] will be called.
+	BatchedBuildBlock(ctx context.Context, blockCtx *Context, maxBlocks int) ([]snowman.Block, error)
+}
@@ -6,6 +6,7 @@ package block
 import (
 	"context"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 )
 
@@ -20,6 +21,15 @@ type Context struct {
 	// Because PreForkBlocks and PostForkOptions do not verify their execution
 	// against the P-chain's state, this context is undefined for those blocks.
 	PChainHeight uint64
+	// VRFOutput is the randomness provided by the proposer of this block. It
+	// is derived from the proposer's BLS key and the parent block, so the
+	// proposer can't choose it. The proposer can only withhold the block. If
+	// the block doesn't provide a VRF proof, VRFOutput is ids.Empty.
+	//
+	// An inner block may be wrapped by multiple proposervm blocks, so this
+	// value may differ between calls to [VerifyWithContext]. It isn't provided
+	// when building a batch of blocks or to VMs run over the rpcchainvm.
+	VRFOutput ids.ID
 }
 
 // BuildBlockWithContextChainVM defines the interface a ChainVM can optionally
@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import "github.com/ava-labs/avalanchego/database"
+
+// DatabaseNamespacesVM defines the interface a VM can optionally implement to
+// report the storage used by each of its subsystems.
+//
+// This isn't supported for VMs run over the rpcchainvm.
+type DatabaseNamespacesVM interface {
+	// DatabaseNamespaces returns the databases of the VM's subsystems, keyed
+	// by the name of the subsystem. Iterating over a returned database must
+	// only yield the keys of its subsystem.
+	//
+	// This method is called after the VM is initialized. The chain's context
+	// lock must be held while the returned databases are used.
+	DatabaseNamespaces() map[string]database.Iteratee
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrIntegrityVerifierVMNotImplemented = errors.New("vm does not implement IntegrityVerifierVM interface")
+
+// IntegrityVerifierVM defines the interface a ChainVM can optionally implement
+// to check the consistency of its persisted state while the node is running.
+type IntegrityVerifierVM interface {
+	// VerifyIntegrity walks the VM's persisted indexes and returns a
+	// description of every discrepancy found between them. If [repair] is
+	// true, the indexes that can be derived from other persisted data are
+	// rebuilt to resolve the reported discrepancies.
+	//
+	// The returned error is only non-nil if the walk couldn't be completed,
+	// not if discrepancies were found.
+	//
+	// The chain's context lock must be held when this method is called.
+	VerifyIntegrity(ctx context.Context, repair bool) ([]string, error)
+}
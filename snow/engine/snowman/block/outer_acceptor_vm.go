@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// OuterAcceptorChainVM defines the interface a ChainVM can optionally
+// implement to be notified of the proposervm block that caused one of its
+// blocks to be accepted.
+//
+// This allows a ChainVM to attribute its blocks to their proposers without
+// parsing the proposervm blocks itself. It isn't supported for VMs run over
+// the rpcchainvm.
+type OuterAcceptorChainVM interface {
+	// OnOuterAccept is called after the proposervm block [outerID] was
+	// accepted and immediately before its inner block [innerID] is accepted.
+	//
+	// [proposer] is the node that proposed [outerID], or ids.EmptyNodeID if
+	// the block was unsigned. If [outerID] is an option, [proposer] is the
+	// proposer of the block that produced the option. [timestamp] is the
+	// timestamp of [outerID].
+	//
+	// This method will be called if and only if the proposervm is activated.
+	// Blocks accepted before the activation are not reported.
+	OnOuterAccept(
+		ctx context.Context,
+		outerID ids.ID,
+		innerID ids.ID,
+		proposer ids.NodeID,
+		timestamp time.Time,
+	) error
+}
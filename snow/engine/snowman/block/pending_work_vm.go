@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import "context"
+
+// PendingWorkChainVM defines the interface a ChainVM can optionally implement
+// to report how much work is waiting to be included in its next block. This
+// allows the proposervm to delay building a block until enough work is
+// pending, rather than building a block as soon as it is allowed to, which
+// reduces the number of nearly empty blocks.
+//
+// This isn't supported for VMs run over the rpcchainvm.
+type PendingWorkChainVM interface {
+	// PendingWork returns a hint of the amount of work waiting to be included
+	// in the next block, for example the number of bytes of transactions in
+	// the mempool. The unit is defined by the VM and must match the unit of
+	// the configured threshold.
+	//
+	// PendingWork may be called concurrently with other methods and without
+	// the chain's context lock held.
+	PendingWork(ctx context.Context) uint64
+}
@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// ProfilerVM defines the interface a VM running in a separate process can
+// optionally implement to expose the diagnostics of its process to the node.
+type ProfilerVM interface {
+	// Profile returns the [name] pprof profile of the VM's process, where
+	// [name] is either "cpu" or the name of a runtime/pprof profile, such as
+	// "heap" or "goroutine". The cpu profile is collected over [duration].
+	Profile(ctx context.Context, name string, duration time.Duration) ([]byte, error)
+
+	// RuntimeMetrics returns the Go runtime and process metrics of the VM's
+	// process.
+	RuntimeMetrics(ctx context.Context) ([]*dto.MetricFamily, error)
+}
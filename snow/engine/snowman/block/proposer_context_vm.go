@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+type proposerContextKey struct{}
+
+// ProposerContext describes the proposervm block wrapping a block that is
+// being built or verified.
+type ProposerContext struct {
+	// Proposer is the node that proposed the block, or ids.EmptyNodeID if the
+	// block is unsigned.
+	Proposer ids.NodeID
+	// WindowIndex is the index of the proposer window that the block's
+	// timestamp falls into, relative to its parent's timestamp. Unsigned
+	// blocks fall into a window after all the proposer windows.
+	WindowIndex uint64
+	// PChainHeight is the P-chain height recorded in the block. Unlike
+	// [Context.PChainHeight], this is not the parent's P-chain height.
+	PChainHeight uint64
+}
+
+// ProposerContextChainVM defines the interface a ChainVM can optionally
+// implement to be provided the [ProposerContext] of the blocks it builds and
+// verifies. This allows a ChainVM to make its blocks depend on their proposer,
+// for example to pay fees to them.
+//
+// The [ProposerContext] is attached to the context.Context passed to
+// BuildBlock, BuildBlockWithContext, Verify and VerifyWithContext, and can be
+// retrieved with [GetProposerContext]. It is provided if and only if the
+// proposervm is activated. It isn't provided to options, when building a
+// batch of blocks or to VMs run over the rpcchainvm.
+//
+// As with [Context], an inner block may be wrapped by multiple proposervm
+// blocks, so the [ProposerContext] may differ between calls to
+// VerifyWithContext.
+type ProposerContextChainVM interface {
+	// Returns true if the [ProposerContext] should be provided.
+	ShouldProvideProposerContext() bool
+}
+
+// WithProposerContext returns a copy of [ctx] that carries [proposerCtx].
+func WithProposerContext(ctx context.Context, proposerCtx *ProposerContext) context.Context {
+	return context.WithValue(ctx, proposerContextKey{}, proposerCtx)
+}
+
+// GetProposerContext returns the [ProposerContext] carried by [ctx], if any.
+func GetProposerContext(ctx context.Context) (*ProposerContext, bool) {
+	proposerCtx, ok := ctx.Value(proposerContextKey{}).(*ProposerContext)
+	return proposerCtx, ok
+}
@@ -129,6 +129,22 @@ func newTransitive(config Config) (*Transitive, error) {
 		config.Params.AlphaPreference,
 		config.Params.AlphaConfidence,
 	)
+	if config.Params.EarlyTermStakeThreshold > 0 {
+		factory, err = poll.NewEarlyTermStakeFactory(
+			config.Params.AlphaPreference,
+			config.Params.AlphaConfidence,
+			config.Params.EarlyTermStakeThreshold,
+			func(nodeID ids.NodeID) uint64 {
+				return config.Validators.GetWeight(config.Ctx.SubnetID, nodeID)
+			},
+			config.Consensus.GetParent,
+			"",
+			config.Ctx.Registerer,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
 	polls, err := poll.NewSet(
 		factory,
 		config.Ctx.Log,
@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package frontier
+
+import (
+	"crypto"
+	"crypto/rand"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const unsignedAttestationLen = 2*ids.IDLen + 2*wrappers.LongLen
+
+// UnsignedAttestation is a claim that [BlockID] is the last accepted block of
+// [ChainID].
+type UnsignedAttestation struct {
+	ChainID   ids.ID
+	BlockID   ids.ID
+	Height    uint64
+	Timestamp uint64
+}
+
+// Sign this attestation with the provided signer and return the signed
+// attestation.
+func (a *UnsignedAttestation) Sign(signer crypto.Signer) (*Attestation, error) {
+	sig, err := signer.Sign(
+		rand.Reader,
+		hashing.ComputeHash256(a.Bytes()),
+		crypto.SHA256,
+	)
+	return &Attestation{
+		UnsignedAttestation: *a,
+		Signature:           sig,
+	}, err
+}
+
+// Bytes returns the canonical byte representation that is signed.
+func (a *UnsignedAttestation) Bytes() []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, unsignedAttestationLen),
+	}
+	p.PackFixedBytes(a.ChainID[:])
+	p.PackFixedBytes(a.BlockID[:])
+	p.PackLong(a.Height)
+	p.PackLong(a.Timestamp)
+	return p.Bytes
+}
+
+// Attestation is a wrapper of an UnsignedAttestation with the signature from a
+// node's staking key.
+type Attestation struct {
+	UnsignedAttestation
+	Signature []byte
+}
+
+// Verify that the attestation was signed by the owner of [cert].
+func (a *Attestation) Verify(cert *staking.Certificate) error {
+	return staking.CheckSignature(
+		cert,
+		a.UnsignedAttestation.Bytes(),
+		a.Signature,
+	)
+}
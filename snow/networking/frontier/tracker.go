@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package frontier
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var (
+	_ Attestor      = (*Tracker)(nil)
+	_ snow.Acceptor = (*acceptor)(nil)
+
+	errUnknownChain   = errors.New("unknown chain")
+	errDuplicateChain = errors.New("chain already registered")
+)
+
+// Attestor provides signed attestations of the accepted frontier of chains.
+type Attestor interface {
+	// Attest returns an attestation of the last accepted block of [chainID],
+	// signed by this node's staking key.
+	Attest(chainID ids.ID) (*Attestation, error)
+}
+
+// Tracker maintains the last accepted block of each registered chain and
+// serves signed attestations of it. This allows light clients to follow the
+// tip of a chain without participating in consensus.
+type Tracker struct {
+	signer crypto.Signer
+
+	lock sync.RWMutex
+	// Key: Chain ID
+	// Value: The frontier of the chain
+	chains map[ids.ID]*chainFrontier
+}
+
+type chainFrontier struct {
+	unsigned UnsignedAttestation
+	// signed is lazily populated by Attest and cleared whenever a new block
+	// is accepted.
+	signed *Attestation
+}
+
+func NewTracker(signer crypto.Signer) *Tracker {
+	return &Tracker{
+		signer: signer,
+		chains: make(map[ids.ID]*chainFrontier),
+	}
+}
+
+// Register starts tracking the accepted frontier of [chainID]. The returned
+// acceptor must be notified of every block accepted by [vm].
+//
+// Invariant: [vm] must be initialized and the chain's context lock must be
+// held.
+func (t *Tracker) Register(ctx context.Context, chainID ids.ID, vm block.ChainVM) (snow.Acceptor, error) {
+	lastAcceptedID, err := vm.LastAccepted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastAccepted, err := vm.GetBlock(ctx, lastAcceptedID)
+	if err != nil {
+		return nil, err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.chains[chainID]; ok {
+		return nil, fmt.Errorf("%w: %s", errDuplicateChain, chainID)
+	}
+	t.chains[chainID] = &chainFrontier{
+		unsigned: newUnsignedAttestation(chainID, lastAccepted),
+	}
+	return &acceptor{
+		tracker: t,
+		chainID: chainID,
+		vm:      vm,
+	}, nil
+}
+
+func (t *Tracker) Attest(chainID ids.ID) (*Attestation, error) {
+	t.lock.RLock()
+	frontier, ok := t.chains[chainID]
+	if !ok {
+		t.lock.RUnlock()
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	signed := frontier.signed
+	t.lock.RUnlock()
+
+	if signed != nil {
+		return signed, nil
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// The frontier may have been signed, or advanced, while the lock wasn't
+	// held.
+	if frontier.signed != nil {
+		return frontier.signed, nil
+	}
+
+	signed, err := frontier.unsigned.Sign(t.signer)
+	if err != nil {
+		return nil, err
+	}
+	frontier.signed = signed
+	return signed, nil
+}
+
+func (t *Tracker) accept(chainID ids.ID, blk snowman.Block) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	frontier := t.chains[chainID]
+	frontier.unsigned = newUnsignedAttestation(chainID, blk)
+	frontier.signed = nil
+}
+
+func newUnsignedAttestation(chainID ids.ID, blk snowman.Block) UnsignedAttestation {
+	return UnsignedAttestation{
+		ChainID:   chainID,
+		BlockID:   blk.ID(),
+		Height:    blk.Height(),
+		Timestamp: uint64(blk.Timestamp().Unix()),
+	}
+}
+
+type acceptor struct {
+	tracker *Tracker
+	chainID ids.ID
+	vm      block.ChainVM
+}
+
+func (a *acceptor) Accept(_ *snow.ConsensusContext, _ ids.ID, container []byte) error {
+	blk, err := a.vm.ParseBlock(context.TODO(), container)
+	if err != nil {
+		return err
+	}
+	a.tracker.accept(a.chainID, blk)
+	return nil
+}
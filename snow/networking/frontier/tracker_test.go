@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package frontier
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+var errUnknownBlock = errors.New("unknown block")
+
+func TestTracker(t *testing.T) {
+	require := require.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	cert := staking.CertificateFromX509(tlsCert.Leaf)
+
+	genesis := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV:    0,
+		TimestampV: time.Unix(1, 0),
+		BytesV:     []byte{0},
+	}
+	child := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		ParentV:    genesis.ID(),
+		HeightV:    1,
+		TimestampV: time.Unix(2, 0),
+		BytesV:     []byte{1},
+	}
+	blocks := []*snowman.TestBlock{genesis, child}
+
+	vm := &block.TestVM{
+		LastAcceptedF: func(context.Context) (ids.ID, error) {
+			return genesis.ID(), nil
+		},
+		GetBlockF: func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+			for _, blk := range blocks {
+				if blk.ID() == blkID {
+					return blk, nil
+				}
+			}
+			return nil, database.ErrNotFound
+		},
+		ParseBlockF: func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+			for _, blk := range blocks {
+				if bytes.Equal(blk.Bytes(), blkBytes) {
+					return blk, nil
+				}
+			}
+			return nil, errUnknownBlock
+		},
+	}
+
+	chainID := ids.GenerateTestID()
+	tracker := NewTracker(tlsCert.PrivateKey.(crypto.Signer))
+
+	_, err = tracker.Attest(chainID)
+	require.ErrorIs(err, errUnknownChain)
+
+	acceptor, err := tracker.Register(context.Background(), chainID, vm)
+	require.NoError(err)
+
+	_, err = tracker.Register(context.Background(), chainID, vm)
+	require.ErrorIs(err, errDuplicateChain)
+
+	attestation, err := tracker.Attest(chainID)
+	require.NoError(err)
+	require.Equal(UnsignedAttestation{
+		ChainID:   chainID,
+		BlockID:   genesis.ID(),
+		Height:    0,
+		Timestamp: 1,
+	}, attestation.UnsignedAttestation)
+	require.NoError(attestation.Verify(cert))
+
+	// The signature should be reused until a new block is accepted.
+	cachedAttestation, err := tracker.Attest(chainID)
+	require.NoError(err)
+	require.Same(attestation, cachedAttestation)
+
+	require.NoError(acceptor.Accept(nil, child.ID(), child.Bytes()))
+
+	attestation, err = tracker.Attest(chainID)
+	require.NoError(err)
+	require.Equal(UnsignedAttestation{
+		ChainID:   chainID,
+		BlockID:   child.ID(),
+		Height:    1,
+		Timestamp: 2,
+	}, attestation.UnsignedAttestation)
+	require.NoError(attestation.Verify(cert))
+
+	// Tampering with the attestation should invalidate the signature.
+	attestation.Height++
+	require.ErrorIs(attestation.Verify(cert), rsa.ErrVerification)
+}
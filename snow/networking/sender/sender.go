@@ -1494,9 +1494,12 @@ func (s *sender) SendAppGossip(_ context.Context, appGossipBytes []byte) error {
 	}
 
 	gossipConfig := s.subnet.Config().GossipConfig
-	validatorSize := int(gossipConfig.AppGossipValidatorSize)
-	nonValidatorSize := int(gossipConfig.AppGossipNonValidatorSize)
-	peerSize := int(gossipConfig.AppGossipPeerSize)
+	validatorSize, nonValidatorSize, peerSize := gossipSizes(
+		gossipConfig,
+		gossipConfig.AppGossipValidatorSize,
+		gossipConfig.AppGossipNonValidatorSize,
+		gossipConfig.AppGossipPeerSize,
+	)
 
 	sentTo := s.sender.Gossip(
 		outMsg,
@@ -1542,12 +1545,19 @@ func (s *sender) SendGossip(_ context.Context, container []byte) {
 	}
 
 	gossipConfig := s.subnet.Config().GossipConfig
+	validatorSize, nonValidatorSize, peerSize := gossipSizes(
+		gossipConfig,
+		gossipConfig.AcceptedFrontierValidatorSize,
+		gossipConfig.AcceptedFrontierNonValidatorSize,
+		gossipConfig.AcceptedFrontierPeerSize,
+	)
+
 	sentTo := s.sender.Gossip(
 		outMsg,
 		s.ctx.SubnetID,
-		int(gossipConfig.AcceptedFrontierValidatorSize),
-		int(gossipConfig.AcceptedFrontierNonValidatorSize),
-		int(gossipConfig.AcceptedFrontierPeerSize),
+		validatorSize,
+		nonValidatorSize,
+		peerSize,
 		s.subnet,
 	)
 	if sentTo.Len() == 0 {
@@ -1591,12 +1601,19 @@ func (s *sender) Accept(ctx *snow.ConsensusContext, _ ids.ID, container []byte)
 	}
 
 	gossipConfig := s.subnet.Config().GossipConfig
+	validatorSize, nonValidatorSize, peerSize := gossipSizes(
+		gossipConfig,
+		gossipConfig.OnAcceptValidatorSize,
+		gossipConfig.OnAcceptNonValidatorSize,
+		gossipConfig.OnAcceptPeerSize,
+	)
+
 	sentTo := s.sender.Gossip(
 		outMsg,
 		s.ctx.SubnetID,
-		int(gossipConfig.OnAcceptValidatorSize),
-		int(gossipConfig.OnAcceptNonValidatorSize),
-		int(gossipConfig.OnAcceptPeerSize),
+		validatorSize,
+		nonValidatorSize,
+		peerSize,
 		s.subnet,
 	)
 	if sentTo.Len() == 0 {
@@ -1615,3 +1632,18 @@ func (s *sender) Accept(ctx *snow.ConsensusContext, _ ids.ID, container []byte)
 	}
 	return nil
 }
+
+// gossipSizes returns the number of validators, non-validators and peers to
+// gossip a message to. If the subnet only gossips to validators, the peers are
+// sampled from the validators.
+func gossipSizes(
+	config subnets.GossipConfig,
+	validatorSize uint,
+	nonValidatorSize uint,
+	peerSize uint,
+) (int, int, int) {
+	if config.GossipValidatorsOnly {
+		return int(validatorSize + peerSize), 0, 0
+	}
+	return int(validatorSize), int(nonValidatorSize), int(peerSize)
+}
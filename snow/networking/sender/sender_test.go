@@ -1325,3 +1325,54 @@ func TestSender_Single_Request(t *testing.T) {
 		})
 	}
 }
+
+func TestSender_Gossip_ValidatorsOnly(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var (
+		ctx            = snow.DefaultContextTest()
+		msgCreator     = message.NewMockOutboundMsgBuilder(ctrl)
+		externalSender = NewMockExternalSender(ctrl)
+		timeoutManager = timeout.NewMockManager(ctrl)
+		router         = router.NewMockRouter(ctrl)
+		engineType     = p2p.EngineType_ENGINE_TYPE_SNOWMAN
+		appGossipBytes = []byte{1, 2, 3}
+	)
+	ctx.SubnetID = ids.GenerateTestID()
+	snowCtx := &snow.ConsensusContext{
+		Context:             ctx,
+		Registerer:          prometheus.NewRegistry(),
+		AvalancheRegisterer: prometheus.NewRegistry(),
+	}
+
+	config := defaultSubnetConfig
+	config.AppGossipPeerSize = 3
+	config.GossipValidatorsOnly = true
+	subnet := subnets.New(ctx.NodeID, config)
+
+	sender, err := New(
+		snowCtx,
+		msgCreator,
+		externalSender,
+		router,
+		timeoutManager,
+		engineType,
+		subnet,
+	)
+	require.NoError(err)
+
+	// The peers are sampled from the validators and no non-validators are
+	// gossiped to.
+	msgCreator.EXPECT().AppGossip(ctx.ChainID, appGossipBytes).Return(nil, nil)
+	externalSender.EXPECT().Gossip(
+		gomock.Any(), // Outbound message
+		ctx.SubnetID,
+		5, // Validator size
+		0, // Non-validator size
+		0, // Peer size
+		subnet,
+	).Return(set.Of(ids.GenerateTestNodeID()))
+
+	require.NoError(sender.SendAppGossip(context.Background(), appGossipBytes))
+}
@@ -13,7 +13,13 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
-var errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
+var (
+	errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
+	errNegativeBlockCacheSize           = errors.New("proposerBlockCacheSize must be non-negative")
+	errNegativeMaxClockSkew             = errors.New("proposerMaxClockSkew must be non-negative")
+	errNegativeFrontierPollFrequency    = errors.New("consensusFrontierPollFrequency must be non-negative")
+	errNegativeStuckBlockTimeout        = errors.New("proposerStuckBlockTimeout must be non-negative")
+)
 
 type GossipConfig struct {
 	AcceptedFrontierValidatorSize    uint `json:"gossipAcceptedFrontierValidatorSize"    yaml:"gossipAcceptedFrontierValidatorSize"`
@@ -25,6 +31,16 @@ type GossipConfig struct {
 	AppGossipValidatorSize           uint `json:"appGossipValidatorSize"                 yaml:"appGossipValidatorSize"`
 	AppGossipNonValidatorSize        uint `json:"appGossipNonValidatorSize"              yaml:"appGossipNonValidatorSize"`
 	AppGossipPeerSize                uint `json:"appGossipPeerSize"                      yaml:"appGossipPeerSize"`
+	// GossipValidatorsOnly causes this subnet's chains to only gossip to
+	// validators of the subnet. The number of peers that would have been
+	// sampled regardless of whether they are validators are sampled from the
+	// validators instead.
+	GossipValidatorsOnly bool `json:"gossipValidatorsOnly" yaml:"gossipValidatorsOnly"`
+	// FrontierPollFrequency is how often this subnet's chains poll for new
+	// consensus frontiers. Polling more frequently reduces the latency to
+	// learn about new containers at the cost of bandwidth. If 0, the node's
+	// default frequency is used.
+	FrontierPollFrequency time.Duration `json:"consensusFrontierPollFrequency" yaml:"consensusFrontierPollFrequency"`
 }
 
 type Config struct {
@@ -63,6 +79,91 @@ type Config struct {
 	// TODO: Move this flag once the proposervm is configurable on a per-chain
 	// basis.
 	ProposerNumHistoricalBlocks uint64 `json:"proposerNumHistoricalBlocks" yaml:"proposerNumHistoricalBlocks"`
+	// ProposerCommitValidatorSet causes snowman++ blocks built by this node to
+	// commit to the hash of the validator set at their P-chain height.
+	//
+	// Note: Blocks only commit to the validator set once the
+	// proposerCommitValidatorSetActivationTime of the subnet's upgrade file
+	// and ProposerBlockExtensionsActivationTime have passed.
+	ProposerCommitValidatorSet bool `json:"proposerCommitValidatorSet" yaml:"proposerCommitValidatorSet"`
+	// ProposerVRF enables VRF proofs on the subnet. Once
+	// ProposerVRFActivationTime has passed, signed snowman++ blocks must
+	// provide a VRF proof generated with their proposer's BLS key, if it has
+	// one registered. The VRF output is provided to the inner VM as an
+	// unbiased source of randomness.
+	//
+	// Note: This value must be the same for all the validators of the subnet.
+	ProposerVRF bool `json:"proposerVRF" yaml:"proposerVRF"`
+	// ProposerVRFActivationTime is the time after which VRF proofs are
+	// required, if ProposerVRF is enabled and
+	// ProposerBlockExtensionsActivationTime has passed. Blocks that provide a VRF proof can
+	// only be parsed by nodes that support VRF proofs, so this should only be
+	// scheduled once all the validators of the subnet support it.
+	//
+	// Note: This value must be the same for all the validators of the subnet.
+	ProposerVRFActivationTime time.Time `json:"proposerVRFActivationTime" yaml:"proposerVRFActivationTime"`
+	// ProposerBlockExtensionsActivationTime is the time after which snowman++
+	// blocks may be encoded with extensions, which carry validator set
+	// commitments, VRF proofs, and compressed inner blocks. Blocks encoded
+	// with extensions can only be parsed by nodes that support them, so this
+	// should only be scheduled once all the validators of the subnet support
+	// it. If zero, extensions are never activated.
+	//
+	// Note: This value must be the same for all the validators of the subnet.
+	ProposerBlockExtensionsActivationTime time.Time `json:"proposerBlockExtensionsActivationTime" yaml:"proposerBlockExtensionsActivationTime"`
+	// ProposerGossipEquivocations causes proofs of proposer equivocations
+	// detected by this node to be gossiped to peers. Received proofs are
+	// delivered to the inner VM as AppGossip messages, which allows the inner
+	// VM to parse them with the proposervm's ParseEquivocationProof and implement
+	// slashing.
+	ProposerGossipEquivocations bool `json:"proposerGossipEquivocations" yaml:"proposerGossipEquivocations"`
+	// ProposerBlockCacheSize is the maximum number of bytes of parsed
+	// snowman++ blocks the proposervm keeps in memory. Defaults to
+	// proposervm.DefaultBlockCacheSize.
+	ProposerBlockCacheSize int `json:"proposerBlockCacheSize" yaml:"proposerBlockCacheSize"`
+	// ProposerMaxClockSkew is the maximum estimated skew between the local
+	// clock and the clocks of the other proposers for this node to build
+	// snowman++ blocks. The skew is estimated from the timestamps of the
+	// blocks built by other nodes. If 0, blocks are built regardless of the
+	// skew.
+	ProposerMaxClockSkew time.Duration `json:"proposerMaxClockSkew" yaml:"proposerMaxClockSkew"`
+	// ProposerCompressInnerBlocks causes snowman++ blocks built by this node
+	// to compress their inner block with zstd on the wire, which reduces the
+	// bandwidth used to gossip chains with compressible blocks.
+	//
+	// Note: Inner blocks are only compressed once
+	// ProposerBlockExtensionsActivationTime has passed.
+	ProposerCompressInnerBlocks bool `json:"proposerCompressInnerBlocks" yaml:"proposerCompressInnerBlocks"`
+	// ProposerStuckBlockTimeout is how long a verified snowman++ block can be
+	// processing before it is reported as stuck. Stuck blocks are logged along
+	// with the state needed to diagnose the stall. If 0, processing blocks
+	// aren't monitored.
+	ProposerStuckBlockTimeout time.Duration `json:"proposerStuckBlockTimeout" yaml:"proposerStuckBlockTimeout"`
+	// ProposerRebuildOnStuckBlock causes the consensus engine to be notified
+	// to build a block while blocks are stuck. It has no effect if
+	// ProposerStuckBlockTimeout is 0.
+	ProposerRebuildOnStuckBlock bool `json:"proposerRebuildOnStuckBlock" yaml:"proposerRebuildOnStuckBlock"`
+	// ProposerBackfillBlocks causes the snowman++ blocks that are missing
+	// after state syncing to be fetched from peers in the background, so that
+	// block indices can be rebuilt.
+	ProposerBackfillBlocks bool `json:"proposerBackfillBlocks" yaml:"proposerBackfillBlocks"`
+	// ProposerDryRunActivation causes the blocks built and accepted before
+	// the snowman++ activation time to be logged and reported in metrics along
+	// with the proposer windows they would have been assigned after
+	// activation. This allows the configuration to be validated before the
+	// activation time is scheduled, without changing consensus.
+	ProposerDryRunActivation bool `json:"proposerDryRunActivation" yaml:"proposerDryRunActivation"`
+	// ProposerBuildPendingWorkThreshold is the amount of pending work,
+	// reported by VMs that support it, at which this node builds snowman++
+	// blocks during its proposer window. With less pending work, this node
+	// waits until its proposer window is about to end to build a block, which
+	// reduces the number of nearly empty blocks. The unit is defined by the
+	// VM. If 0, blocks are built as soon as possible.
+	ProposerBuildPendingWorkThreshold uint64 `json:"proposerBuildPendingWorkThreshold" yaml:"proposerBuildPendingWorkThreshold"`
+
+	// Upgrade schedules the forks of the subnet. It is read from the subnet's
+	// upgrade file rather than from the subnet config.
+	Upgrade UpgradeConfig `json:"-" yaml:"-"`
 }
 
 func (c *Config) Valid() error {
@@ -72,5 +173,20 @@ func (c *Config) Valid() error {
 	if !c.ValidatorOnly && c.AllowedNodes.Len() > 0 {
 		return errAllowedNodesWhenNotValidatorOnly
 	}
+	if c.FrontierPollFrequency < 0 {
+		return fmt.Errorf("%w: %s", errNegativeFrontierPollFrequency, c.FrontierPollFrequency)
+	}
+	if c.ProposerBlockCacheSize < 0 {
+		return fmt.Errorf("%w: %d", errNegativeBlockCacheSize, c.ProposerBlockCacheSize)
+	}
+	if c.ProposerMaxClockSkew < 0 {
+		return fmt.Errorf("%w: %s", errNegativeMaxClockSkew, c.ProposerMaxClockSkew)
+	}
+	if c.ProposerStuckBlockTimeout < 0 {
+		return fmt.Errorf("%w: %s", errNegativeStuckBlockTimeout, c.ProposerStuckBlockTimeout)
+	}
+	if err := c.Upgrade.Verify(); err != nil {
+		return fmt.Errorf("upgrade %w", err)
+	}
 	return nil
 }
@@ -5,6 +5,7 @@ package subnets
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -26,6 +27,8 @@ var validParameters = snowball.Parameters{
 }
 
 func TestValid(t *testing.T) {
+	minPChainHeight := uint64(1)
+
 	tests := []struct {
 		name        string
 		s           Config
@@ -50,6 +53,80 @@ func TestValid(t *testing.T) {
 			},
 			expectedErr: errAllowedNodesWhenNotValidatorOnly,
 		},
+		{
+			name: "negative frontier poll frequency",
+			s: Config{
+				ConsensusParameters: validParameters,
+				GossipConfig: GossipConfig{
+					FrontierPollFrequency: -1,
+				},
+			},
+			expectedErr: errNegativeFrontierPollFrequency,
+		},
+		{
+			name: "negative enforced min block delay",
+			s: Config{
+				ConsensusParameters: validParameters,
+				Upgrade: UpgradeConfig{
+					ProposerEnforcedMinBlockDelay: -1,
+				},
+			},
+			expectedErr: errNegativeEnforcedMinBlockDelay,
+		},
+		{
+			name: "enforced min block delay without activation time",
+			s: Config{
+				ConsensusParameters: validParameters,
+				Upgrade: UpgradeConfig{
+					ProposerEnforcedMinBlockDelay: time.Second,
+				},
+			},
+			expectedErr: errEnforcedMinBlockDelayWithoutActivationTime,
+		},
+		{
+			name: "P-chain height epoch without activation time",
+			s: Config{
+				ConsensusParameters: validParameters,
+				Upgrade: UpgradeConfig{
+					ProposerPChainHeightEpoch: 10,
+				},
+			},
+			expectedErr: errPChainHeightEpochWithoutActivationTime,
+		},
+		{
+			name: "negative block cache size",
+			s: Config{
+				ConsensusParameters:    validParameters,
+				ProposerBlockCacheSize: -1,
+			},
+			expectedErr: errNegativeBlockCacheSize,
+		},
+		{
+			name: "negative max clock skew",
+			s: Config{
+				ConsensusParameters:  validParameters,
+				ProposerMaxClockSkew: -1,
+			},
+			expectedErr: errNegativeMaxClockSkew,
+		},
+		{
+			name: "negative stuck block timeout",
+			s: Config{
+				ConsensusParameters:       validParameters,
+				ProposerStuckBlockTimeout: -1,
+			},
+			expectedErr: errNegativeStuckBlockTimeout,
+		},
+		{
+			name: "min P-chain height without activation time",
+			s: Config{
+				ConsensusParameters: validParameters,
+				Upgrade: UpgradeConfig{
+					ProposerMinPChainHeight: &minPChainHeight,
+				},
+			},
+			expectedErr: errMinPChainHeightWithoutActivationTime,
+		},
 		{
 			name: "valid",
 			s: Config{
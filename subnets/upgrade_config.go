@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnets
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	errMinPChainHeightWithoutActivationTime       = errors.New("proposerMinPChainHeight can only be set along with proposerActivationTime")
+	errNegativeEnforcedMinBlockDelay              = errors.New("proposerEnforcedMinBlockDelay must be non-negative")
+	errEnforcedMinBlockDelayWithoutActivationTime = errors.New("proposerEnforcedMinBlockDelay can only be set along with proposerEnforcedMinBlockDelayActivationTime")
+	errPChainHeightEpochWithoutActivationTime     = errors.New("proposerPChainHeightEpoch can only be set along with proposerPChainHeightEpochActivationTime")
+)
+
+// UpgradeConfig schedules the forks of a subnet at runtime, overriding the
+// fork times compiled into the node. Unset fields keep their default values.
+//
+// Note: The fork times must be the same for all the validators of the subnet.
+// Once a fork has activated, its time can no longer be changed. The proposer
+// fork times and minimum P-chain height must be in the future the first time
+// a chain is started with them.
+type UpgradeConfig struct {
+	// ProposerActivationTime is the time after which the subnet's chains
+	// build snowman++ blocks.
+	ProposerActivationTime *time.Time `json:"proposerActivationTime" yaml:"proposerActivationTime"`
+	// ProposerMinPChainHeight is the minimum P-chain height referenced by the
+	// first snowman++ blocks of the subnet's chains.
+	ProposerMinPChainHeight *uint64 `json:"proposerMinPChainHeight" yaml:"proposerMinPChainHeight"`
+	// ProposerPChainHeightEpoch is the number of P-chain heights per epoch.
+	// If non-zero, snowman++ blocks reference P-chain heights at epoch
+	// boundaries, so that the validator set used to verify blocks and warp
+	// signatures is stable within an epoch.
+	ProposerPChainHeightEpoch uint64 `json:"proposerPChainHeightEpoch" yaml:"proposerPChainHeightEpoch"`
+	// ProposerPChainHeightEpochActivationTime is the time after which
+	// snowman++ blocks must reference P-chain heights at epoch boundaries. If
+	// unset, epochs are never enforced.
+	ProposerPChainHeightEpochActivationTime *time.Time `json:"proposerPChainHeightEpochActivationTime" yaml:"proposerPChainHeightEpochActivationTime"`
+	// ProposerEnforcedMinBlockDelay is the minimum delay between the
+	// timestamps of a snowman++ block and its parent. Blocks built sooner are
+	// considered invalid, which rate-limits block production regardless of
+	// the proposer's minimum block delay.
+	ProposerEnforcedMinBlockDelay time.Duration `json:"proposerEnforcedMinBlockDelay" yaml:"proposerEnforcedMinBlockDelay"`
+	// ProposerEnforcedMinBlockDelayActivationTime is the time after which
+	// snowman++ blocks must be built at least ProposerEnforcedMinBlockDelay
+	// after their parent. If unset, the delay is never enforced.
+	ProposerEnforcedMinBlockDelayActivationTime *time.Time `json:"proposerEnforcedMinBlockDelayActivationTime" yaml:"proposerEnforcedMinBlockDelayActivationTime"`
+	// ProposerCommitValidatorSetActivationTime is the time after which
+	// snowman++ blocks may commit to the validator set at their P-chain
+	// height. Blocks that commit to the validator set can only be parsed by
+	// nodes that support the commitment, so this should only be scheduled
+	// once all the validators of the subnet support it. If unset, blocks
+	// never commit to the validator set.
+	ProposerCommitValidatorSetActivationTime *time.Time `json:"proposerCommitValidatorSetActivationTime" yaml:"proposerCommitValidatorSetActivationTime"`
+	// ProposerVRFActivationTime overrides the proposerVRFActivationTime of the
+	// subnet config.
+	ProposerVRFActivationTime *time.Time `json:"proposerVRFActivationTime" yaml:"proposerVRFActivationTime"`
+	// ProposerBlockExtensionsActivationTime overrides the
+	// proposerBlockExtensionsActivationTime of the subnet config.
+	ProposerBlockExtensionsActivationTime *time.Time `json:"proposerBlockExtensionsActivationTime" yaml:"proposerBlockExtensionsActivationTime"`
+}
+
+func (c *UpgradeConfig) Verify() error {
+	if c.ProposerMinPChainHeight != nil && c.ProposerActivationTime == nil {
+		return errMinPChainHeightWithoutActivationTime
+	}
+	if c.ProposerEnforcedMinBlockDelay < 0 {
+		return fmt.Errorf("%w: %s", errNegativeEnforcedMinBlockDelay, c.ProposerEnforcedMinBlockDelay)
+	}
+	if c.ProposerEnforcedMinBlockDelay != 0 && c.ProposerEnforcedMinBlockDelayActivationTime == nil {
+		return errEnforcedMinBlockDelayWithoutActivationTime
+	}
+	if c.ProposerPChainHeightEpoch != 0 && c.ProposerPChainHeightEpochActivationTime == nil {
+		return errPChainHeightEpochWithoutActivationTime
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ Tracer = (*attributesTracer)(nil)
+
+// attributesTracer adds a fixed set of attributes to every span it starts.
+type attributesTracer struct {
+	Tracer
+	opt trace.SpanStartOption
+}
+
+// WithAttributes returns a Tracer that adds [attrs] to every span started by
+// [tracer]. This allows spans to be scoped, for example, to a chain.
+func WithAttributes(tracer Tracer, attrs ...attribute.KeyValue) Tracer {
+	return &attributesTracer{
+		Tracer: tracer,
+		opt:    trace.WithAttributes(attrs...),
+	}
+}
+
+func (t *attributesTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	// Copy [opts] to avoid modifying the caller's backing array.
+	allOpts := make([]trace.SpanStartOption, 0, len(opts)+1)
+	allOpts = append(allOpts, t.opt)
+	allOpts = append(allOpts, opts...)
+	return t.Tracer.Start(ctx, spanName, allOpts...)
+}
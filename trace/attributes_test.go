@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ Tracer = (*testTracer)(nil)
+
+type testTracer struct {
+	Tracer
+	config trace.SpanConfig
+}
+
+func (t *testTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t.config = trace.NewSpanStartConfig(opts...)
+	return t.Tracer.Start(ctx, spanName, opts...)
+}
+
+func TestWithAttributes(t *testing.T) {
+	require := require.New(t)
+
+	inner := &testTracer{
+		Tracer: Noop,
+	}
+	tracer := WithAttributes(inner, attribute.String("chainID", "chain"))
+
+	_, span := tracer.Start(
+		context.Background(),
+		"span",
+		trace.WithAttributes(attribute.Int("height", 1)),
+	)
+	span.End()
+
+	require.Equal(
+		[]attribute.KeyValue{
+			attribute.String("chainID", "chain"),
+			attribute.Int("height", 1),
+		},
+		inner.config.Attributes(),
+	)
+}
@@ -32,6 +32,10 @@ const (
 	DefaultNetworkPeerListNonValidatorGossipSize = 0
 	DefaultNetworkPeerListPeersGossipSize        = 10
 	DefaultNetworkPeerListGossipFreq             = time.Minute
+	DefaultNetworkPeerListMaxKnownValidators     = 8192
+	DefaultNetworkPeerListReconnectKnownTTL      = time.Minute
+	DefaultNetworkPeerListUselessNumValidatorIPs = 3
+	DefaultNetworkPeerListUsefulnessGracePeriod  = 30 * time.Minute
 
 	// Inbound Connection Throttling
 	DefaultInboundConnUpgradeThrottlerCooldown = 10 * time.Second
@@ -65,6 +69,8 @@ const (
 	// a timeout of 0 should generally not be provided.
 	DefaultNetworkTCPProxyReadTimeout = 3 * time.Second
 
+	DefaultNetworkTLSSessionCacheSize = 1024
+
 	// Benchlist
 	DefaultBenchlistFailThreshold      = 10
 	DefaultBenchlistDuration           = 15 * time.Minute
@@ -0,0 +1,179 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package glogging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	logpb "github.com/ava-labs/avalanchego/proto/pb/log"
+)
+
+var (
+	_ io.WriteCloser = (*Client)(nil)
+	_ zapcore.Core   = (*core)(nil)
+)
+
+// Client writes log records to a remote logger over a single stream.
+type Client struct {
+	client logpb.LogClient
+
+	lock sync.Mutex
+	// stream is opened on the first write and re-opened after a failed write.
+	stream logpb.Log_WriteClient
+}
+
+func NewClient(client logpb.LogClient) *Client {
+	return &Client{client: client}
+}
+
+// Level returns the minimum level of the records that the remote logger will
+// write.
+func (c *Client) Level(ctx context.Context) (logging.Level, error) {
+	resp, err := c.client.Level(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, err
+	}
+	return logging.Level(resp.Level), nil
+}
+
+// Write writes the pre-formatted message [p] at the info level.
+func (c *Client) Write(p []byte) (int, error) {
+	err := c.send(&logpb.WriteRequest{
+		Level:   int32(logging.Info),
+		Message: strings.TrimSuffix(string(p), "\n"),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the stream, if it is open.
+func (c *Client) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.stream == nil {
+		return nil
+	}
+	_, err := c.stream.CloseAndRecv()
+	c.stream = nil
+	return err
+}
+
+func (c *Client) send(req *logpb.WriteRequest) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.stream == nil {
+		stream, err := c.client.Write(context.Background())
+		if err != nil {
+			return err
+		}
+		c.stream = stream
+	}
+
+	if err := c.stream.Send(req); err != nil {
+		// The stream has been terminated, so a new stream will be opened on
+		// the next write.
+		c.stream = nil
+		return err
+	}
+	return nil
+}
+
+// NewWrappedCore returns a core that writes log records at or above [level] to
+// [client].
+func NewWrappedCore(level logging.Level, client *Client) logging.WrappedCore {
+	atomicLevel := zap.NewAtomicLevelAt(zapcore.Level(level))
+
+	core := &core{
+		LevelEnabler: atomicLevel,
+		client:       client,
+	}
+	return logging.WrappedCore{AtomicLevel: atomicLevel, Core: core, Writer: client}
+}
+
+type core struct {
+	zapcore.LevelEnabler
+
+	client *Client
+	fields []*logpb.Field
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{
+		LevelEnabler: c.LevelEnabler,
+		client:       c.client,
+		fields:       appendFields(c.fields, fields),
+	}
+}
+
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.client.send(&logpb.WriteRequest{
+		Level:   int32(entry.Level),
+		Message: entry.Message,
+		Fields:  appendFields(c.fields, fields),
+	})
+}
+
+func (*core) Sync() error {
+	return nil
+}
+
+// appendFields returns a new slice containing [pbFields] followed by the
+// encoding of [fields].
+func appendFields(pbFields []*logpb.Field, fields []zapcore.Field) []*logpb.Field {
+	encodedFields := make([]*logpb.Field, len(pbFields), len(pbFields)+len(fields))
+	copy(encodedFields, pbFields)
+	for _, field := range fields {
+		// A single field may be encoded into multiple keys. For example, errors
+		// may be encoded with their verbose description.
+		enc := zapcore.NewMapObjectEncoder()
+		field.AddTo(enc)
+
+		keys := make([]string, 0, len(enc.Fields))
+		for key := range enc.Fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			encodedFields = append(encodedFields, &logpb.Field{
+				Key:   key,
+				Value: encodeValue(enc.Fields[key]),
+			})
+		}
+	}
+	return encodedFields
+}
+
+func encodeValue(value interface{}) []byte {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		// Strings can always be marshalled.
+		valueBytes, _ = json.Marshal(fmt.Sprint(value))
+	}
+	return valueBytes
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package glogging
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"go.uber.org/zap"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	logpb "github.com/ava-labs/avalanchego/proto/pb/log"
+)
+
+var _ logpb.LogServer = (*Server)(nil)
+
+// Server writes log records received over RPC to a logger.
+type Server struct {
+	logpb.UnsafeLogServer
+	log logging.Logger
+}
+
+func NewServer(log logging.Logger) *Server {
+	return &Server{log: log}
+}
+
+func (s *Server) Level(context.Context, *emptypb.Empty) (*logpb.LevelResponse, error) {
+	level := logging.Verbo
+	for level < logging.Off && !s.log.Enabled(level) {
+		level++
+	}
+	return &logpb.LevelResponse{
+		Level: int32(level),
+	}, nil
+}
+
+func (s *Server) Write(stream logpb.Log_WriteServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&emptypb.Empty{})
+		}
+		if err != nil {
+			return err
+		}
+		s.write(req)
+	}
+}
+
+func (s *Server) write(req *logpb.WriteRequest) {
+	level := logging.Level(req.Level)
+	switch {
+	case level < logging.Verbo:
+		level = logging.Verbo
+	case level > logging.Fatal:
+		level = logging.Fatal
+	}
+	if !s.log.Enabled(level) {
+		return
+	}
+
+	fields := make([]zap.Field, len(req.Fields))
+	for i, field := range req.Fields {
+		if json.Valid(field.Value) {
+			fields[i] = zap.Reflect(field.Key, json.RawMessage(field.Value))
+		} else {
+			fields[i] = zap.ByteString(field.Key, field.Value)
+		}
+	}
+
+	switch level {
+	case logging.Verbo:
+		s.log.Verbo(req.Message, fields...)
+	case logging.Debug:
+		s.log.Debug(req.Message, fields...)
+	case logging.Trace:
+		s.log.Trace(req.Message, fields...)
+	case logging.Info:
+		s.log.Info(req.Message, fields...)
+	case logging.Warn:
+		s.log.Warn(req.Message, fields...)
+	case logging.Error:
+		s.log.Error(req.Message, fields...)
+	default:
+		s.log.Fatal(req.Message, fields...)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package glogging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	logpb "github.com/ava-labs/avalanchego/proto/pb/log"
+)
+
+var errTest = errors.New("non-nil error")
+
+type buffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *buffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (*buffer) Close() error {
+	return nil
+}
+
+func (b *buffer) records(t *testing.T) []map[string]interface{} {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func setupLog(t *testing.T, level logging.Level) (*Client, *buffer, func()) {
+	require := require.New(t)
+
+	buf := &buffer{}
+	serverLog := logging.NewLogger(
+		"<X Chain>",
+		logging.NewWrappedCore(level, buf, logging.JSON.FileEncoder()),
+	)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	serverCloser := grpcutils.ServerCloser{}
+
+	server := grpcutils.NewServer()
+	logpb.RegisterLogServer(server, NewServer(serverLog))
+	serverCloser.Add(server)
+
+	go grpcutils.Serve(listener, server)
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+
+	client := NewClient(logpb.NewLogClient(conn))
+	return client, buf, func() {
+		serverCloser.Stop()
+		_ = conn.Close()
+		_ = listener.Close()
+	}
+}
+
+func TestLevel(t *testing.T) {
+	for _, level := range []logging.Level{
+		logging.Verbo,
+		logging.Debug,
+		logging.Info,
+		logging.Fatal,
+		logging.Off,
+	} {
+		t.Run(level.String(), func(t *testing.T) {
+			require := require.New(t)
+
+			client, _, closeFn := setupLog(t, level)
+			defer closeFn()
+
+			gotLevel, err := client.Level(context.Background())
+			require.NoError(err)
+			require.Equal(level, gotLevel)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	require := require.New(t)
+
+	client, buf, closeFn := setupLog(t, logging.Debug)
+	defer closeFn()
+
+	level, err := client.Level(context.Background())
+	require.NoError(err)
+
+	log := logging.NewLogger("", NewWrappedCore(level, client))
+	log.Verbo("dropped by the client")
+	log.Debug("hello",
+		zap.Stringer("id", ids.Empty),
+		zap.Uint64("height", 1337),
+		zap.Error(errTest),
+	)
+	log.SetLevel(logging.Verbo)
+	log.Verbo("dropped by the server")
+	_, err = log.Write([]byte("pre-formatted\n"))
+	require.NoError(err)
+	log.Stop()
+
+	records := buf.records(t)
+	require.Len(records, 2)
+
+	require.Equal("debug", records[0]["level"])
+	require.Equal("<X Chain>", records[0]["logger"])
+	require.Equal("hello", records[0]["msg"])
+	require.Equal(ids.Empty.String(), records[0]["id"])
+	require.Equal(float64(1337), records[0]["height"])
+	require.Equal(errTest.Error(), records[0]["error"])
+
+	require.Equal("info", records[1]["level"])
+	require.Equal("pre-formatted", records[1]["msg"])
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package profiler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"runtime/pprof"
+	"time"
+)
+
+// CPUProfile is the name of the profile of the cpu utilization, which is
+// collected over a duration rather than being a snapshot.
+const CPUProfile = "cpu"
+
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// Collect returns the [name] profile of this process in the pprof format.
+// [name] is either [CPUProfile] or the name of a runtime/pprof profile, such as
+// "heap" or "goroutine". The cpu profile is collected over [duration].
+func Collect(ctx context.Context, name string, duration time.Duration) ([]byte, error) {
+	var buf bytes.Buffer
+	if name == CPUProfile {
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			return nil, err
+		}
+
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+		pprof.StopCPUProfile()
+		return buf.Bytes(), ctx.Err()
+	}
+
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProfile, name)
+	}
+	if err := profile.WriteTo(&buf, 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package profiler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name        string
+		expectedErr error
+	}{
+		{
+			name: CPUProfile,
+		},
+		{
+			name: "heap",
+		},
+		{
+			name: "goroutine",
+		},
+		{
+			name:        "unknown",
+			expectedErr: ErrUnknownProfile,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			profile, err := Collect(context.Background(), test.name, 10*time.Millisecond)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.NotEmpty(profile)
+			}
+		})
+	}
+}
+
+func TestCollectCPUProfileCanceled(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Collect(ctx, CPUProfile, time.Hour)
+	require.ErrorIs(err, context.Canceled)
+}
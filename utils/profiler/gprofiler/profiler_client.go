@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gprofiler
+
+import (
+	"context"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	profilerpb "github.com/ava-labs/avalanchego/proto/pb/profiler"
+)
+
+// Client collects the diagnostics of a remote process.
+type Client struct {
+	client profilerpb.ProfilerClient
+}
+
+func NewClient(client profilerpb.ProfilerClient) *Client {
+	return &Client{client: client}
+}
+
+// Profile returns the [name] profile of the remote process in the pprof
+// format. The cpu profile is collected over [duration].
+func (c *Client) Profile(ctx context.Context, name string, duration time.Duration) ([]byte, error) {
+	resp, err := c.client.Profile(ctx, &profilerpb.ProfileRequest{
+		Name:     name,
+		Duration: int64(duration),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Profile, nil
+}
+
+// RuntimeMetrics returns the Go runtime and process metrics of the remote
+// process.
+func (c *Client) RuntimeMetrics(ctx context.Context) ([]*dto.MetricFamily, error) {
+	resp, err := c.client.RuntimeMetrics(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.MetricFamilies, nil
+}
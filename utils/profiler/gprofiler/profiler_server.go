@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gprofiler
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/utils/profiler"
+
+	profilerpb "github.com/ava-labs/avalanchego/proto/pb/profiler"
+)
+
+var _ profilerpb.ProfilerServer = (*Server)(nil)
+
+// Server collects the diagnostics of this process on behalf of a remote
+// caller.
+type Server struct {
+	profilerpb.UnsafeProfilerServer
+}
+
+func NewServer() *Server {
+	return &Server{}
+}
+
+func (*Server) Profile(ctx context.Context, req *profilerpb.ProfileRequest) (*profilerpb.ProfileResponse, error) {
+	profile, err := profiler.Collect(ctx, req.Name, time.Duration(req.Duration))
+	if err != nil {
+		return nil, err
+	}
+	return &profilerpb.ProfileResponse{
+		Profile: profile,
+	}, nil
+}
+
+func (*Server) RuntimeMetrics(context.Context, *emptypb.Empty) (*profilerpb.RuntimeMetricsResponse, error) {
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collectors.NewGoCollector()); err != nil {
+		return nil, err
+	}
+	if err := registry.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})); err != nil {
+		return nil, err
+	}
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		return nil, err
+	}
+	return &profilerpb.RuntimeMetricsResponse{
+		MetricFamilies: mfs,
+	}, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gprofiler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/profiler"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	profilerpb "github.com/ava-labs/avalanchego/proto/pb/profiler"
+)
+
+func setupProfiler(t *testing.T) *Client {
+	require := require.New(t)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	serverCloser := grpcutils.ServerCloser{}
+
+	server := grpcutils.NewServer()
+	profilerpb.RegisterProfilerServer(server, NewServer())
+	serverCloser.Add(server)
+
+	go grpcutils.Serve(listener, server)
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+
+	t.Cleanup(func() {
+		serverCloser.Stop()
+		_ = conn.Close()
+		_ = listener.Close()
+	})
+	return NewClient(profilerpb.NewProfilerClient(conn))
+}
+
+func TestProfile(t *testing.T) {
+	require := require.New(t)
+
+	client := setupProfiler(t)
+
+	for _, name := range []string{profiler.CPUProfile, "heap", "goroutine"} {
+		profile, err := client.Profile(context.Background(), name, 10*time.Millisecond)
+		require.NoError(err, name)
+		require.NotEmpty(profile, name)
+	}
+
+	_, err := client.Profile(context.Background(), "unknown", 0)
+	require.ErrorContains(err, profiler.ErrUnknownProfile.Error())
+}
+
+func TestRuntimeMetrics(t *testing.T) {
+	require := require.New(t)
+
+	client := setupProfiler(t)
+
+	mfs, err := client.RuntimeMetrics(context.Background())
+	require.NoError(err)
+
+	names := make(map[string]bool, len(mfs))
+	for _, mf := range mfs {
+		names[mf.GetName()] = true
+	}
+	require.Contains(names, "go_goroutines")
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package shutdown
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// Component is a part of the application that must be shut down.
+type Component struct {
+	Name string
+	// Shutdown should return once the component has stopped or [ctx] is
+	// cancelled.
+	Shutdown func(ctx context.Context) error
+}
+
+// StageReport describes the result of shutting down a stage.
+type StageReport struct {
+	Name     string
+	Duration time.Duration
+	// Aborted is true if the stage didn't complete before its timeout.
+	Aborted bool
+	// Skipped is true if the stage required every previous stage to complete,
+	// but a previous stage was aborted.
+	Skipped bool
+	// Pending is the list of components that hadn't finished shutting down
+	// when the stage was aborted or skipped.
+	Pending []string
+	// Failed is the list of components that returned an error while shutting
+	// down.
+	Failed []string
+}
+
+type stage struct {
+	name       string
+	timeout    time.Duration
+	components []Component
+	// requiresCompletion is true if the stage must be skipped when a
+	// previous stage was aborted.
+	requiresCompletion bool
+}
+
+// Coordinator shuts down stages of components in the order they were added.
+// Components within a stage are shut down sequentially. If a stage doesn't
+// complete within its timeout, the remaining components of the stage are
+// abandoned and the next stage is started. Abandoned components may still be
+// running while the following stages are shut down, unless those stages were
+// added with AddStageRequiringCompletion.
+type Coordinator struct {
+	log    logging.Logger
+	stages []stage
+}
+
+func NewCoordinator(log logging.Logger) *Coordinator {
+	return &Coordinator{
+		log: log,
+	}
+}
+
+// AddStage registers a stage to be shut down after all the previously added
+// stages. If [timeout] is 0, the stage will never be aborted.
+func (c *Coordinator) AddStage(name string, timeout time.Duration, components ...Component) {
+	c.stages = append(c.stages, stage{
+		name:       name,
+		timeout:    timeout,
+		components: components,
+	})
+}
+
+// AddStageRequiringCompletion registers a stage like AddStage, but the stage
+// is skipped if any previous stage was aborted. This prevents shutting down
+// components, such as a database, that abandoned components may still be
+// using.
+func (c *Coordinator) AddStageRequiringCompletion(name string, timeout time.Duration, components ...Component) {
+	c.stages = append(c.stages, stage{
+		name:               name,
+		timeout:            timeout,
+		components:         components,
+		requiresCompletion: true,
+	})
+}
+
+// Shutdown shuts down all the registered stages and returns a report for each
+// of them.
+func (c *Coordinator) Shutdown(ctx context.Context) []StageReport {
+	var (
+		reports = make([]StageReport, len(c.stages))
+		aborted bool
+	)
+	for i, stage := range c.stages {
+		if aborted && stage.requiresCompletion {
+			reports[i] = c.skipStage(stage)
+			continue
+		}
+
+		reports[i] = c.shutdownStage(ctx, stage)
+		aborted = aborted || reports[i].Aborted
+	}
+	return reports
+}
+
+func (c *Coordinator) skipStage(s stage) StageReport {
+	report := StageReport{
+		Name:    s.name,
+		Skipped: true,
+	}
+	for _, component := range s.components {
+		report.Pending = append(report.Pending, component.Name)
+	}
+
+	c.log.Error("skipped shutdown stage after a previous stage was aborted",
+		zap.String("stage", s.name),
+		zap.Strings("pendingComponents", report.Pending),
+	)
+	return report
+}
+
+func (c *Coordinator) shutdownStage(ctx context.Context, s stage) StageReport {
+	c.log.Info("shutting down stage",
+		zap.String("stage", s.name),
+		zap.Duration("timeout", s.timeout),
+	)
+
+	start := time.Now()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var (
+		lock sync.Mutex
+		// next is the index of the first component that hasn't finished
+		// shutting down.
+		next   int
+		failed []string
+		done   = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+
+		for i, component := range s.components {
+			if ctx.Err() != nil {
+				// The stage was aborted, so the remaining components are
+				// abandoned.
+				return
+			}
+
+			err := component.Shutdown(ctx)
+
+			lock.Lock()
+			next = i + 1
+			if err != nil {
+				failed = append(failed, component.Name)
+			}
+			lock.Unlock()
+
+			if err != nil {
+				c.log.Warn("failed to shut down component",
+					zap.String("stage", s.name),
+					zap.String("component", component.Name),
+					zap.Error(err),
+				)
+			}
+		}
+	}()
+
+	report := StageReport{
+		Name: s.name,
+	}
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// Prefer reporting completion if the stage finished at the deadline.
+		select {
+		case <-done:
+		default:
+			report.Aborted = true
+		}
+	}
+
+	lock.Lock()
+	if report.Aborted {
+		for _, component := range s.components[next:] {
+			report.Pending = append(report.Pending, component.Name)
+		}
+	}
+	report.Failed = failed
+	lock.Unlock()
+
+	report.Duration = time.Since(start)
+	if report.Aborted {
+		c.log.Error("aborted shutdown stage",
+			zap.String("stage", s.name),
+			zap.Duration("duration", report.Duration),
+			zap.Strings("pendingComponents", report.Pending),
+			zap.Error(ctx.Err()),
+		)
+	} else {
+		c.log.Info("finished shutdown stage",
+			zap.String("stage", s.name),
+			zap.Duration("duration", report.Duration),
+		)
+	}
+	return report
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errTest = errors.New("non-nil error")
+
+func TestCoordinatorOrder(t *testing.T) {
+	require := require.New(t)
+
+	var order []string
+	component := func(name string, err error) Component {
+		return Component{
+			Name: name,
+			Shutdown: func(context.Context) error {
+				order = append(order, name)
+				return err
+			},
+		}
+	}
+
+	c := NewCoordinator(logging.NoLog{})
+	c.AddStage("first", time.Minute,
+		component("a", nil),
+		component("b", errTest),
+	)
+	c.AddStage("second", 0,
+		component("c", nil),
+	)
+
+	reports := c.Shutdown(context.Background())
+	require.Equal([]string{"a", "b", "c"}, order)
+	require.Len(reports, 2)
+
+	require.Equal("first", reports[0].Name)
+	require.False(reports[0].Aborted)
+	require.Empty(reports[0].Pending)
+	require.Equal([]string{"b"}, reports[0].Failed)
+
+	require.Equal("second", reports[1].Name)
+	require.False(reports[1].Aborted)
+	require.Empty(reports[1].Failed)
+}
+
+func TestCoordinatorAbortsStuckStage(t *testing.T) {
+	require := require.New(t)
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+
+	var shutdownAfterStuck bool
+	c := NewCoordinator(logging.NoLog{})
+	c.AddStage("stuck", 10*time.Millisecond,
+		Component{
+			Name: "ignoresContext",
+			Shutdown: func(context.Context) error {
+				<-stuck
+				return nil
+			},
+		},
+		Component{
+			Name: "neverStarted",
+			Shutdown: func(context.Context) error {
+				return nil
+			},
+		},
+	)
+	c.AddStage("next", time.Minute,
+		Component{
+			Name: "db",
+			Shutdown: func(context.Context) error {
+				shutdownAfterStuck = true
+				return nil
+			},
+		},
+	)
+
+	reports := c.Shutdown(context.Background())
+	require.Len(reports, 2)
+	require.True(reports[0].Aborted)
+	require.Equal([]string{"ignoresContext", "neverStarted"}, reports[0].Pending)
+	require.False(reports[1].Aborted)
+	require.True(shutdownAfterStuck)
+}
+
+func TestCoordinatorSkipsStageRequiringCompletion(t *testing.T) {
+	require := require.New(t)
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+
+	var (
+		dbClosed      bool
+		tracerStopped bool
+	)
+	c := NewCoordinator(logging.NoLog{})
+	c.AddStage("chains", 10*time.Millisecond,
+		Component{
+			Name: "ignoresContext",
+			Shutdown: func(context.Context) error {
+				<-stuck
+				return nil
+			},
+		},
+	)
+	c.AddStageRequiringCompletion("database", time.Minute,
+		Component{
+			Name: "db",
+			Shutdown: func(context.Context) error {
+				dbClosed = true
+				return nil
+			},
+		},
+	)
+	c.AddStage("tracer", time.Minute,
+		Component{
+			Name: "tracer",
+			Shutdown: func(context.Context) error {
+				tracerStopped = true
+				return nil
+			},
+		},
+	)
+
+	reports := c.Shutdown(context.Background())
+	require.Len(reports, 3)
+	require.True(reports[0].Aborted)
+
+	require.True(reports[1].Skipped)
+	require.False(reports[1].Aborted)
+	require.Equal([]string{"db"}, reports[1].Pending)
+	require.False(dbClosed)
+
+	require.False(reports[2].Skipped)
+	require.True(tracerStopped)
+}
+
+func TestCoordinatorRunsStageRequiringCompletion(t *testing.T) {
+	require := require.New(t)
+
+	var dbClosed bool
+	c := NewCoordinator(logging.NoLog{})
+	c.AddStage("chains", time.Minute)
+	c.AddStageRequiringCompletion("database", time.Minute,
+		Component{
+			Name: "db",
+			Shutdown: func(context.Context) error {
+				dbClosed = true
+				return nil
+			},
+		},
+	)
+
+	reports := c.Shutdown(context.Background())
+	require.Len(reports, 2)
+	require.False(reports[1].Skipped)
+	require.True(dbClosed)
+}
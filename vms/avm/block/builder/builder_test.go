@@ -27,6 +27,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/avm/block"
+	"github.com/ava-labs/avalanchego/vms/avm/config"
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 	"github.com/ava-labs/avalanchego/vms/avm/metrics"
 	"github.com/ava-labs/avalanchego/vms/avm/state"
@@ -504,7 +505,7 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 
 	registerer := prometheus.NewRegistry()
 	toEngine := make(chan common.Message, 100)
-	mempool, err := mempool.New("mempool", registerer, toEngine)
+	mempool, err := mempool.New("mempool", registerer, toEngine, ids.Empty)
 	require.NoError(err)
 	// add a tx to the mempool
 	tx := transactions[0]
@@ -521,7 +522,8 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 		Ctx: &snow.Context{
 			Log: logging.NoLog{},
 		},
-		Codec: parser.Codec(),
+		Config: &config.Config{},
+		Codec:  parser.Codec(),
 	}
 
 	baseDB := versiondb.New(memdb.New())
@@ -545,7 +547,7 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 	metrics, err := metrics.New("", registerer)
 	require.NoError(err)
 
-	manager := blkexecutor.NewManager(mempool, metrics, state, backend, clk, onAccept)
+	manager := blkexecutor.NewManager(mempool, metrics, state, backend, clk, onAccept, false)
 
 	manager.SetPreference(parentBlk.ID())
 
@@ -77,8 +77,9 @@ func (b *Block) Verify(context.Context) error {
 	// before performing any possible DB reads.
 	for _, tx := range txs {
 		err := tx.Unsigned.Visit(&executor.SyntacticVerifier{
-			Backend: b.manager.backend,
-			Tx:      tx,
+			Backend:   b.manager.backend,
+			Tx:        tx,
+			Timestamp: newChainTime,
 		})
 		if err != nil {
 			txID := tx.ID()
@@ -245,6 +246,12 @@ func (b *Block) Accept(context.Context) error {
 		return fmt.Errorf("failed to apply state diff to shared memory: %w", err)
 	}
 
+	if b.manager.checkInvariants {
+		if err := b.manager.verifyInvariants(b); err != nil {
+			return err
+		}
+	}
+
 	if err := b.manager.metrics.MarkBlockAccepted(b); err != nil {
 		return err
 	}
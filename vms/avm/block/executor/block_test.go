@@ -869,6 +869,7 @@ func TestBlockReject(t *testing.T) {
 						preferred: preferredID,
 						mempool:   mempool,
 						metrics:   metrics.NewMockMetrics(ctrl),
+						clk:       &mockable.Clock{},
 						backend: &executor.Backend{
 							Bootstrapped: true,
 							Ctx: &snow.Context{
@@ -928,6 +929,7 @@ func TestBlockReject(t *testing.T) {
 						preferred: preferredID,
 						mempool:   mempool,
 						metrics:   metrics.NewMockMetrics(ctrl),
+						clk:       &mockable.Clock{},
 						backend: &executor.Backend{
 							Bootstrapped: true,
 							Ctx: &snow.Context{
@@ -0,0 +1,230 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/avm/block"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var ErrInvariantViolation = errors.New("state invariant violated")
+
+// verifyInvariants verifies the consistency of the persisted state after [blk]
+// has been accepted. This is expensive and is only intended to be used to
+// catch state corruption at the block that caused it.
+func (m *manager) verifyInvariants(blk block.Block) error {
+	var violations []string
+	violations = append(violations, m.checkHeightIndex(blk)...)
+	for _, tx := range blk.Txs() {
+		violations = append(violations, checkConservation(tx)...)
+	}
+	violations = append(violations, m.checkUTXOs(blk.Txs())...)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	blkID := blk.ID()
+	m.backend.Ctx.Log.Fatal("state invariant violated",
+		zap.Stringer("blkID", blkID),
+		zap.Uint64("height", blk.Height()),
+		zap.Stringer("parentID", blk.Parent()),
+		zap.Strings("violations", violations),
+	)
+	return fmt.Errorf("%w after accepting block %s: %s",
+		ErrInvariantViolation,
+		blkID,
+		strings.Join(violations, "; "),
+	)
+}
+
+func (m *manager) checkHeightIndex(blk block.Block) []string {
+	var (
+		violations []string
+		blkID      = blk.ID()
+		height     = blk.Height()
+	)
+	if lastAccepted := m.state.GetLastAccepted(); lastAccepted != blkID {
+		violations = append(violations, fmt.Sprintf(
+			"last accepted block is %s",
+			lastAccepted,
+		))
+	}
+
+	indexedID, err := m.state.GetBlockIDAtHeight(height)
+	switch {
+	case err != nil:
+		violations = append(violations, fmt.Sprintf(
+			"failed to get block ID at height %d: %s",
+			height,
+			err,
+		))
+	case indexedID != blkID:
+		violations = append(violations, fmt.Sprintf(
+			"height %d is indexed to block %s",
+			height,
+			indexedID,
+		))
+	}
+
+	parentBlk, err := m.state.GetBlock(blk.Parent())
+	switch {
+	case err != nil:
+		violations = append(violations, fmt.Sprintf(
+			"failed to get parent block %s: %s",
+			blk.Parent(),
+			err,
+		))
+	case parentBlk.Height()+1 != height:
+		violations = append(violations, fmt.Sprintf(
+			"parent block %s is at height %d",
+			blk.Parent(),
+			parentBlk.Height(),
+		))
+	}
+	return violations
+}
+
+// checkUTXOs verifies that every UTXO consumed by [txs] was removed from the
+// state and that every UTXO produced by [txs], that wasn't consumed by a later
+// tx, was added to the state.
+func (m *manager) checkUTXOs(txs []*txs.Tx) []string {
+	consumed := set.Set[ids.ID]{}
+	for _, tx := range txs {
+		consumed.Union(tx.Unsigned.InputIDs())
+	}
+
+	var violations []string
+	for utxoID := range consumed {
+		_, err := m.state.GetUTXO(utxoID)
+		switch {
+		case err == nil:
+			violations = append(violations, fmt.Sprintf(
+				"consumed UTXO %s wasn't removed",
+				utxoID,
+			))
+		case err != database.ErrNotFound:
+			violations = append(violations, fmt.Sprintf(
+				"failed to get consumed UTXO %s: %s",
+				utxoID,
+				err,
+			))
+		}
+	}
+
+	for _, tx := range txs {
+		for _, utxo := range tx.UTXOs() {
+			utxoID := utxo.InputID()
+			if consumed.Contains(utxoID) {
+				continue
+			}
+
+			stateUTXO, err := m.state.GetUTXO(utxoID)
+			switch {
+			case err != nil:
+				violations = append(violations, fmt.Sprintf(
+					"produced UTXO %s wasn't added: %s",
+					utxoID,
+					err,
+				))
+			case stateUTXO.AssetID() != utxo.AssetID():
+				violations = append(violations, fmt.Sprintf(
+					"produced UTXO %s has asset %s but expected %s",
+					utxoID,
+					stateUTXO.AssetID(),
+					utxo.AssetID(),
+				))
+			}
+		}
+	}
+	return violations
+}
+
+// checkConservation verifies that no asset is transferred out of [tx] in
+// excess of the amount transferred into it. Assets minted by operations or
+// created by the tx aren't transferred and are therefore not included.
+func checkConservation(tx *txs.Tx) []string {
+	ins, outs := transfers(tx.Unsigned)
+
+	var (
+		violations []string
+		consumed   = make(map[ids.ID]uint64)
+		produced   = make(map[ids.ID]uint64)
+	)
+	for _, in := range ins {
+		assetID := in.AssetID()
+		amount, err := safemath.Add64(consumed[assetID], in.Input().Amount())
+		if err != nil {
+			violations = append(violations, fmt.Sprintf(
+				"tx %s consumes an overflowing amount of asset %s",
+				tx.ID(),
+				assetID,
+			))
+		}
+		consumed[assetID] = amount
+	}
+	for _, out := range outs {
+		assetID := out.AssetID()
+		amount, err := safemath.Add64(produced[assetID], out.Output().Amount())
+		if err != nil {
+			violations = append(violations, fmt.Sprintf(
+				"tx %s produces an overflowing amount of asset %s",
+				tx.ID(),
+				assetID,
+			))
+		}
+		produced[assetID] = amount
+	}
+
+	for assetID, producedAmount := range produced {
+		if consumedAmount := consumed[assetID]; producedAmount > consumedAmount {
+			violations = append(violations, fmt.Sprintf(
+				"tx %s produces %d of asset %s but only consumes %d",
+				tx.ID(),
+				producedAmount,
+				assetID,
+				consumedAmount,
+			))
+		}
+	}
+	return violations
+}
+
+// transfers returns the inputs and outputs of the assets that are transferred
+// by [utx].
+func transfers(utx txs.UnsignedTx) ([]*avax.TransferableInput, []*avax.TransferableOutput) {
+	switch utx := utx.(type) {
+	case *txs.BaseTx:
+		return utx.Ins, utx.Outs
+	case *txs.CreateAssetTx:
+		return utx.Ins, utx.Outs
+	case *txs.OperationTx:
+		return utx.Ins, utx.Outs
+	case *txs.ImportTx:
+		ins := make([]*avax.TransferableInput, 0, len(utx.Ins)+len(utx.ImportedIns))
+		ins = append(ins, utx.Ins...)
+		ins = append(ins, utx.ImportedIns...)
+		return ins, utx.Outs
+	case *txs.ExportTx:
+		outs := make([]*avax.TransferableOutput, 0, len(utx.Outs)+len(utx.ExportedOuts))
+		outs = append(outs, utx.Outs...)
+		outs = append(outs, utx.ExportedOuts...)
+		return utx.Ins, outs
+	case *txs.FeeRateTx:
+		return utx.Ins, utx.Outs
+	default:
+		return nil, nil
+	}
+}
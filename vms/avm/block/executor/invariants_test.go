@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestCheckConservation(t *testing.T) {
+	assetID := ids.GenerateTestID()
+	in := func(amount uint64) *avax.TransferableInput {
+		return &avax.TransferableInput{
+			Asset: avax.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: amount,
+			},
+		}
+	}
+	out := func(amount uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+			},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		tx                 txs.UnsignedTx
+		expectedViolations int
+	}{
+		{
+			name: "burns",
+			tx: &txs.BaseTx{BaseTx: avax.BaseTx{
+				Ins:  []*avax.TransferableInput{in(10)},
+				Outs: []*avax.TransferableOutput{out(9)},
+			}},
+			expectedViolations: 0,
+		},
+		{
+			name: "inflates",
+			tx: &txs.BaseTx{BaseTx: avax.BaseTx{
+				Ins:  []*avax.TransferableInput{in(10)},
+				Outs: []*avax.TransferableOutput{out(11)},
+			}},
+			expectedViolations: 1,
+		},
+		{
+			name: "imported inputs",
+			tx: &txs.ImportTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					Ins:  []*avax.TransferableInput{in(10)},
+					Outs: []*avax.TransferableOutput{out(15)},
+				}},
+				ImportedIns: []*avax.TransferableInput{in(5)},
+			},
+			expectedViolations: 0,
+		},
+		{
+			name: "exported outputs",
+			tx: &txs.ExportTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					Ins:  []*avax.TransferableInput{in(10)},
+					Outs: []*avax.TransferableOutput{out(5)},
+				}},
+				ExportedOuts: []*avax.TransferableOutput{out(6)},
+			},
+			expectedViolations: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations := checkConservation(&txs.Tx{Unsigned: test.tx})
+			require.Len(t, violations, test.expectedViolations)
+		})
+	}
+}
@@ -55,18 +55,20 @@ func NewManager(
 	backend *executor.Backend,
 	clk *mockable.Clock,
 	onAccept func(*txs.Tx) error,
+	checkInvariants bool,
 ) Manager {
 	lastAccepted := state.GetLastAccepted()
 	return &manager{
-		backend:      backend,
-		state:        state,
-		metrics:      metrics,
-		mempool:      mempool,
-		clk:          clk,
-		onAccept:     onAccept,
-		blkIDToState: map[ids.ID]*blockState{},
-		lastAccepted: lastAccepted,
-		preferred:    lastAccepted,
+		backend:         backend,
+		state:           state,
+		metrics:         metrics,
+		mempool:         mempool,
+		clk:             clk,
+		onAccept:        onAccept,
+		checkInvariants: checkInvariants,
+		blkIDToState:    map[ids.ID]*blockState{},
+		lastAccepted:    lastAccepted,
+		preferred:       lastAccepted,
 	}
 }
 
@@ -80,6 +82,8 @@ type manager struct {
 	// before its state changes are applied.
 	// Invariant: any error returned by onAccept should be considered fatal.
 	onAccept func(*txs.Tx) error
+	// If true, the state is verified after every accepted block.
+	checkInvariants bool
 
 	// blkIDToState is a map from a block's ID to the state of the block.
 	// Blocks are put into this map when they are verified.
@@ -148,8 +152,9 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 	}
 
 	err := tx.Unsigned.Visit(&executor.SyntacticVerifier{
-		Backend: m.backend,
-		Tx:      tx,
+		Backend:   m.backend,
+		Tx:        tx,
+		Timestamp: m.clk.Time(),
 	})
 	if err != nil {
 		return err
@@ -14,6 +14,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/state"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
@@ -144,6 +145,7 @@ func TestManagerVerifyTx(t *testing.T) {
 					backend: &executor.Backend{
 						Bootstrapped: true,
 					},
+					clk: &mockable.Clock{},
 				}
 			},
 			expectedErr: errTestSyntacticVerifyFail,
@@ -172,6 +174,7 @@ func TestManagerVerifyTx(t *testing.T) {
 					backend: &executor.Backend{
 						Bootstrapped: true,
 					},
+					clk:          &mockable.Clock{},
 					state:        state,
 					lastAccepted: preferred,
 					preferred:    preferred,
@@ -205,6 +208,7 @@ func TestManagerVerifyTx(t *testing.T) {
 					backend: &executor.Backend{
 						Bootstrapped: true,
 					},
+					clk:          &mockable.Clock{},
 					state:        state,
 					lastAccepted: preferred,
 					preferred:    preferred,
@@ -245,6 +249,7 @@ func TestManagerVerifyTx(t *testing.T) {
 					backend: &executor.Backend{
 						Bootstrapped: true,
 					},
+					clk: &mockable.Clock{},
 					blkIDToState: map[ids.ID]*blockState{
 						preferredID: {
 							statelessBlock: preferred,
@@ -284,6 +289,7 @@ func TestManagerVerifyTx(t *testing.T) {
 					backend: &executor.Backend{
 						Bootstrapped: true,
 					},
+					clk:          &mockable.Clock{},
 					state:        state,
 					lastAccepted: preferred,
 					preferred:    preferred,
@@ -6,6 +6,7 @@ package block
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/utils"
@@ -35,29 +36,33 @@ type parser struct {
 }
 
 func NewParser(fxs []fxs.Fx) (Parser, error) {
-	p, err := txs.NewParser(fxs)
-	if err != nil {
-		return nil, err
-	}
-	c := p.CodecRegistry()
-	gc := p.GenesisCodecRegistry()
-
-	err = utils.Err(
-		c.RegisterType(&StandardBlock{}),
-		gc.RegisterType(&StandardBlock{}),
+	return NewCustomParser(
+		make(map[reflect.Type]int),
+		&mockable.Clock{},
+		logging.NoLog{},
+		time.Time{},
+		fxs,
 	)
-	return &parser{
-		Parser: p,
-	}, err
 }
 
+// NewCustomParser returns a parser of the blocks and txs of a chain with
+// [fxs].
+//
+// Types are appended to the codec as they are added, so that the type IDs of
+// previously registered types never change: the txs and types of [fxs] are
+// followed by the blocks, then by the txs added since, and finally by the
+// types added to [fxs] since they were first deployed. Each fx registers its
+// added types into its own namespace, so new types can be added to the avm and
+// to each fx independently. The types added to [fxs] are only registered if
+// [fxExtensionsActivationTime] isn't [mockable.MaxTime].
 func NewCustomParser(
 	typeToFxIndex map[reflect.Type]int,
 	clock *mockable.Clock,
 	log logging.Logger,
+	fxExtensionsActivationTime time.Time,
 	fxs []fxs.Fx,
 ) (Parser, error) {
-	p, err := txs.NewCustomParser(typeToFxIndex, clock, log, fxs)
+	p, err := txs.NewCustomParser(typeToFxIndex, clock, log, fxExtensionsActivationTime, fxs)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +72,14 @@ func NewCustomParser(
 	err = utils.Err(
 		c.RegisterType(&StandardBlock{}),
 		gc.RegisterType(&StandardBlock{}),
+
+		c.RegisterType(&txs.FeeRateTx{}),
+		gc.RegisterType(&txs.FeeRateTx{}),
+
+		c.RegisterType(&txs.CreateCappedAssetTx{}),
+		gc.RegisterType(&txs.CreateCappedAssetTx{}),
+
+		p.RegisterFxExtensions(),
 	)
 	return &parser{
 		Parser: p,
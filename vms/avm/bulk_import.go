@@ -0,0 +1,308 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	defaultImportBatchSize  = 256
+	defaultMaxImportBatches = 16
+)
+
+var errInvalidImportBatchSize = errors.New("invalid import batch size")
+
+// BulkImportArgs are arguments for passing into BulkImport requests
+type BulkImportArgs struct {
+	// User that controls To
+	api.UserPass
+
+	// Chain the funds are coming from
+	SourceChain string `json:"sourceChain"`
+
+	// Address receiving the imported funds
+	To string `json:"to"`
+
+	// Maximum number of atomic UTXOs imported by each tx. Defaults to
+	// [defaultImportBatchSize].
+	BatchSize json.Uint32 `json:"batchSize"`
+
+	// Maximum number of batches processed by this request. Defaults to
+	// [defaultMaxImportBatches].
+	MaxBatches json.Uint32 `json:"maxBatches"`
+
+	// Index of the last atomic UTXO processed by a previous request. If
+	// provided, the import resumes after this UTXO.
+	StartIndex api.Index `json:"startIndex"`
+}
+
+// ImportBatch is the outcome of importing a batch of atomic UTXOs
+type ImportBatch struct {
+	// ID of the issued tx. Empty if the batch failed.
+	TxID ids.ID `json:"txID"`
+
+	// Number of atomic UTXOs in the batch
+	NumUTXOs json.Uint32 `json:"numUTXOs"`
+
+	// Number of atomic UTXOs spent by the issued tx
+	NumImported json.Uint32 `json:"numImported"`
+
+	// Reason the batch failed. Empty if the tx was issued.
+	Error string `json:"error,omitempty"`
+}
+
+// BulkImportReply is the response from calling BulkImport
+type BulkImportReply struct {
+	// Outcomes of the processed batches, in the order they were processed
+	Batches []ImportBatch `json:"batches"`
+
+	// Total number of atomic UTXOs spent by the issued txs
+	NumImported json.Uint64 `json:"numImported"`
+
+	// Number of batches that failed
+	NumFailed json.Uint32 `json:"numFailed"`
+
+	// Index of the last atomic UTXO processed. Passed as the StartIndex of the
+	// next request to continue the import.
+	EndIndex api.Index `json:"endIndex"`
+
+	// True if every atomic UTXO after StartIndex was processed
+	Done bool `json:"done"`
+}
+
+// bulkImport imports the atomic UTXOs held by the user on the source chain in
+// batches of at most [BatchSize] UTXOs. Each batch is imported by its own tx,
+// so a batch failing doesn't prevent the remaining batches from being imported.
+// At most [MaxBatches] batches are processed, after which the import can be
+// resumed from the returned EndIndex.
+//
+// Assumes the context lock is held.
+func (vm *VM) bulkImport(args *BulkImportArgs, reply *BulkImportReply) error {
+	batchSize := int(args.BatchSize)
+	if batchSize == 0 {
+		batchSize = defaultImportBatchSize
+	}
+	if batchSize > int(maxPageSize) {
+		return fmt.Errorf("%w: %d > %d", errInvalidImportBatchSize, batchSize, maxPageSize)
+	}
+	maxBatches := int(args.MaxBatches)
+	if maxBatches == 0 {
+		maxBatches = defaultMaxImportBatches
+	}
+
+	chainID, err := vm.ctx.BCLookup.Lookup(args.SourceChain)
+	if err != nil {
+		return fmt.Errorf("problem parsing chainID %q: %w", args.SourceChain, err)
+	}
+
+	to, err := avax.ParseServiceAddress(vm, args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
+	}
+
+	var (
+		startAddr   = ids.ShortEmpty
+		startUTXOID = ids.Empty
+		// The atomic UTXOs are indexed starting at, rather than after, the
+		// provided index. When resuming, the UTXO at the index was already
+		// processed and must be skipped.
+		skipStart = args.StartIndex.Address != "" || args.StartIndex.UTXO != ""
+	)
+	if skipStart {
+		startAddr, err = avax.ParseServiceAddress(vm, args.StartIndex.Address)
+		if err != nil {
+			return fmt.Errorf("couldn't parse start index address %q: %w", args.StartIndex.Address, err)
+		}
+		startUTXOID, err = ids.FromString(args.StartIndex.UTXO)
+		if err != nil {
+			return fmt.Errorf("couldn't parse start index utxo: %w", err)
+		}
+	}
+
+	utxos, kc, err := vm.LoadUser(args.Username, args.Password, nil)
+	if err != nil {
+		return err
+	}
+
+	for len(reply.Batches) < maxBatches {
+		limit := batchSize
+		if skipStart {
+			limit++
+		}
+		atomicUTXOs, endAddr, endUTXOID, err := vm.GetAtomicUTXOs(
+			chainID,
+			kc.Addrs,
+			startAddr,
+			startUTXOID,
+			limit,
+		)
+		if err != nil {
+			return fmt.Errorf("problem retrieving user's atomic UTXOs: %w", err)
+		}
+		done := len(atomicUTXOs) < limit
+		if skipStart && len(atomicUTXOs) > 0 && atomicUTXOs[0].InputID() == startUTXOID {
+			atomicUTXOs = atomicUTXOs[1:]
+		}
+		if len(atomicUTXOs) > batchSize {
+			// The UTXO at the start index was removed, so the index was
+			// restarted. The remaining UTXOs are processed by the next batch.
+			atomicUTXOs = atomicUTXOs[:batchSize]
+			endUTXOID = atomicUTXOs[batchSize-1].InputID()
+		}
+		if len(atomicUTXOs) == 0 {
+			reply.Done = true
+			break
+		}
+		startAddr = endAddr
+		startUTXOID = endUTXOID
+		skipStart = true
+
+		batch := ImportBatch{
+			NumUTXOs: json.Uint32(len(atomicUTXOs)),
+		}
+		tx, err := vm.buildImportTx(chainID, atomicUTXOs, utxos, kc, to)
+		if err == nil {
+			batch.TxID, err = vm.IssueTx(tx.Bytes())
+		}
+		if err != nil {
+			batch.Error = err.Error()
+			reply.NumFailed++
+
+			vm.ctx.Log.Debug("failed to import batch of atomic UTXOs",
+				zap.Stringer("sourceChainID", chainID),
+				zap.Int("batch", len(reply.Batches)),
+				zap.Int("numUTXOs", len(atomicUTXOs)),
+				zap.Error(err),
+			)
+		} else {
+			importTx := tx.Unsigned.(*txs.ImportTx)
+			batch.NumImported = json.Uint32(len(importTx.ImportedIns))
+			reply.NumImported += json.Uint64(batch.NumImported)
+
+			// The local UTXOs that paid the fee must not be spent by the
+			// following batches.
+			utxos = removeSpentUTXOs(utxos, importTx.Ins)
+
+			vm.ctx.Log.Debug("imported batch of atomic UTXOs",
+				zap.Stringer("sourceChainID", chainID),
+				zap.Stringer("txID", batch.TxID),
+				zap.Int("batch", len(reply.Batches)),
+				zap.Int("numUTXOs", len(atomicUTXOs)),
+				zap.Uint64("numImported", uint64(reply.NumImported)),
+			)
+		}
+		reply.Batches = append(reply.Batches, batch)
+
+		if done {
+			reply.Done = true
+			break
+		}
+	}
+
+	reply.EndIndex.Address, err = vm.FormatLocalAddress(startAddr)
+	if err != nil {
+		return fmt.Errorf("problem formatting address: %w", err)
+	}
+	reply.EndIndex.UTXO = startUTXOID.String()
+	return nil
+}
+
+// buildImportTx returns a signed tx importing [atomicUTXOs] from [chainID] to
+// [to]. If the imported UTXOs can't pay the tx fee, the remainder is spent from
+// [utxos].
+func (vm *VM) buildImportTx(
+	chainID ids.ID,
+	atomicUTXOs []*avax.UTXO,
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	to ids.ShortID,
+) (*txs.Tx, error) {
+	amountsSpent, importInputs, importKeys, err := vm.SpendAll(atomicUTXOs, kc)
+	if err != nil {
+		return nil, err
+	}
+
+	ins := []*avax.TransferableInput{}
+	keys := [][]*secp256k1.PrivateKey{}
+
+	if amountSpent := amountsSpent[vm.feeAssetID]; amountSpent < vm.TxFee {
+		var localAmountsSpent map[ids.ID]uint64
+		localAmountsSpent, ins, keys, err = vm.Spend(
+			utxos,
+			kc,
+			map[ids.ID]uint64{
+				vm.feeAssetID: vm.TxFee - amountSpent,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		for asset, amount := range localAmountsSpent {
+			newAmount, err := math.Add64(amountsSpent[asset], amount)
+			if err != nil {
+				return nil, fmt.Errorf("problem calculating required spend amount: %w", err)
+			}
+			amountsSpent[asset] = newAmount
+		}
+	}
+
+	// Because we ensured that we had enough inputs for the fee, we can
+	// safely just remove it without concern for underflow.
+	amountsSpent[vm.feeAssetID] -= vm.TxFee
+
+	keys = append(keys, importKeys...)
+
+	outs := []*avax.TransferableOutput{}
+	for assetID, amount := range amountsSpent {
+		if amount > 0 {
+			outs = append(outs, newTransferOutput(assetID, amount, to))
+		}
+	}
+	avax.SortTransferableOutputs(outs, vm.parser.Codec())
+
+	tx := &txs.Tx{Unsigned: &txs.ImportTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		SourceChain: chainID,
+		ImportedIns: importInputs,
+	}}
+	return tx, tx.SignSECP256K1Fx(vm.parser.Codec(), keys)
+}
+
+// removeSpentUTXOs returns the UTXOs in [utxos] that aren't spent by [ins].
+func removeSpentUTXOs(utxos []*avax.UTXO, ins []*avax.TransferableInput) []*avax.UTXO {
+	if len(ins) == 0 {
+		return utxos
+	}
+
+	spent := set.NewSet[ids.ID](len(ins))
+	for _, in := range ins {
+		spent.Add(in.InputID())
+	}
+	remaining := make([]*avax.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !spent.Contains(utxo.InputID()) {
+			remaining = append(remaining, utxo)
+		}
+	}
+	return remaining
+}
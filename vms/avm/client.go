@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 )
 
 var _ Client = (*client)(nil)
@@ -30,6 +31,47 @@ type Client interface {
 	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
 	// GetHeight returns the height of the last accepted block.
 	GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error)
+	// DryRunTx verifies [tx] against the currently preferred state without
+	// issuing it
+	DryRunTx(ctx context.Context, tx []byte, options ...rpc.Option) (*DryRunTxReply, error)
+	// IssueTxWithIdempotencyKey issues [tx] unless a tx was already issued
+	// with [idempotencyKey], in which case the ID of that tx is returned
+	IssueTxWithIdempotencyKey(ctx context.Context, tx []byte, idempotencyKey string, options ...rpc.Option) (ids.ID, error)
+	// GetMempool returns up to [limit] txs in the mempool, ordered by
+	// decreasing fee rate
+	GetMempool(ctx context.Context, limit uint32, options ...rpc.Option) ([]MempoolTx, error)
+	// GetTxGraph returns the dependencies and conflicts between [txs] and an
+	// order they can be issued in
+	GetTxGraph(ctx context.Context, txs [][]byte, options ...rpc.Option) (*GetTxGraphReply, error)
+	// GetMempoolStats returns a summary of the contents of the mempool
+	GetMempoolStats(ctx context.Context, options ...rpc.Option) (*GetMempoolStatsReply, error)
+	// GetFeeConversion returns how fees may be paid in an asset other than
+	// the fee asset
+	GetFeeConversion(ctx context.Context, options ...rpc.Option) (*GetFeeConversionReply, error)
+	// CreateSigningSession starts collecting the signatures of [tx] from
+	// multiple parties. Any credentials of [tx] are ignored.
+	CreateSigningSession(ctx context.Context, tx []byte, options ...rpc.Option) (*SigningSessionReply, error)
+	// GetSigningSession returns which signatures of [sessionID] have been
+	// provided
+	GetSigningSession(ctx context.Context, sessionID ids.ID, options ...rpc.Option) (*SigningSessionReply, error)
+	// AddSigningSessionSignatures adds [signatures] to [sessionID]
+	AddSigningSessionSignatures(
+		ctx context.Context,
+		sessionID ids.ID,
+		signatures []SigningSessionSignature,
+		options ...rpc.Option,
+	) (*SigningSessionReply, error)
+	// IssueSigningSession issues the tx of [sessionID] once all of its
+	// signatures have been provided
+	IssueSigningSession(ctx context.Context, sessionID ids.ID, options ...rpc.Option) (ids.ID, error)
+	// ScheduleTx persists [tx] to be issued once the node's clock reaches
+	// [issueTime]
+	ScheduleTx(ctx context.Context, tx []byte, issueTime time.Time, options ...rpc.Option) (ids.ID, error)
+	// CancelScheduledTx unschedules [txID] if it hasn't been issued yet
+	CancelScheduledTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error
+	// GetScheduledTxs returns the txs that are waiting to be issued, sorted by
+	// their issue time
+	GetScheduledTxs(ctx context.Context, options ...rpc.Option) ([]ScheduledTx, error)
 	// GetTxStatus returns the status of [txID]
 	//
 	// Deprecated: GetTxStatus only returns Accepted or Unknown, GetTx should be
@@ -74,6 +116,15 @@ type Client interface {
 	//
 	// Deprecated: GetUTXOs should be used instead.
 	GetAllBalances(ctx context.Context, addr ids.ShortID, includePartial bool, options ...rpc.Option) ([]Balance, error)
+	// GetSpendability returns, for each asset held by [addrs], the amount that
+	// [addrs] can spend now, the amount that is locked by locktime, and the
+	// amount that requires signatures from other addresses. If [assetIDs] is
+	// non-empty, only those assets are reported.
+	GetSpendability(ctx context.Context, addrs []ids.ShortID, assetIDs []string, options ...rpc.Option) (*GetSpendabilityReply, error)
+	// GetUTXOProof returns the bytes of the UTXO [utxoID] produced for [addr],
+	// a proof of its inclusion, and the root the proof is relative to. The
+	// proof should be verified against a root that is trusted.
+	GetUTXOProof(ctx context.Context, addr ids.ShortID, utxoID ids.ID, options ...rpc.Option) ([]byte, *index.UTXOProof, ids.ID, error)
 	// CreateAsset creates a new asset and returns its assetID
 	//
 	// Deprecated: Transactions should be issued using the
@@ -197,7 +248,25 @@ type Client interface {
 	//
 	// Deprecated: Transactions should be issued using the
 	// `avalanchego/wallet/chain/x.Wallet` utility.
-	Import(ctx context.Context, user api.UserPass, to ids.ShortID, sourceChain string, options ...rpc.Option) (ids.ID, error) // Export sends an asset from this chain to the P/C-Chain.
+	Import(ctx context.Context, user api.UserPass, to ids.ShortID, sourceChain string, options ...rpc.Option) (ids.ID, error)
+	// BulkImport imports the atomic UTXOs of [user] from [sourceChain] to [to]
+	// in batches of at most [batchSize] UTXOs, processing at most [maxBatches]
+	// batches. The import resumes after [startIndex] and can be continued from
+	// the returned EndIndex.
+	//
+	// Deprecated: Transactions should be issued using the
+	// `avalanchego/wallet/chain/x.Wallet` utility.
+	BulkImport(
+		ctx context.Context,
+		user api.UserPass,
+		to ids.ShortID,
+		sourceChain string,
+		batchSize uint32,
+		maxBatches uint32,
+		startIndex api.Index,
+		options ...rpc.Option,
+	) (*BulkImportReply, error)
+	// Export sends an asset from this chain to the P/C-Chain.
 	// After this tx is accepted, the AVAX must be imported to the P/C-chain with an importTx.
 	// Returns the ID of the newly created atomic transaction
 	//
@@ -277,6 +346,145 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) IssueTxWithIdempotencyKey(ctx context.Context, txBytes []byte, idempotencyKey string, options ...rpc.Option) (ids.ID, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return ids.ID{}, err
+	}
+	res := &api.JSONTxID{}
+	err = c.requester.SendRequest(ctx, "avm.issueTx", &IssueTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		IdempotencyKey: idempotencyKey,
+	}, res, options...)
+	return res.TxID, err
+}
+
+func (c *client) DryRunTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*DryRunTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &DryRunTxReply{}
+	err = c.requester.SendRequest(ctx, "avm.dryRunTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetMempool(ctx context.Context, limit uint32, options ...rpc.Option) ([]MempoolTx, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "avm.getMempool", &GetMempoolArgs{
+		Limit: json.Uint32(limit),
+	}, res, options...)
+	return res.Txs, err
+}
+
+func (c *client) GetTxGraph(ctx context.Context, txs [][]byte, options ...rpc.Option) (*GetTxGraphReply, error) {
+	txStrs := make([]string, len(txs))
+	for i, txBytes := range txs {
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		if err != nil {
+			return nil, err
+		}
+		txStrs[i] = txStr
+	}
+	res := &GetTxGraphReply{}
+	err := c.requester.SendRequest(ctx, "avm.getTxGraph", &GetTxGraphArgs{
+		Txs:      txStrs,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetMempoolStats(ctx context.Context, options ...rpc.Option) (*GetMempoolStatsReply, error) {
+	res := &GetMempoolStatsReply{}
+	err := c.requester.SendRequest(ctx, "avm.getMempoolStats", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) GetFeeConversion(ctx context.Context, options ...rpc.Option) (*GetFeeConversionReply, error) {
+	res := &GetFeeConversionReply{}
+	err := c.requester.SendRequest(ctx, "avm.getFeeConversion", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) CreateSigningSession(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SigningSessionReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &SigningSessionReply{}
+	err = c.requester.SendRequest(ctx, "avm.createSigningSession", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetSigningSession(ctx context.Context, sessionID ids.ID, options ...rpc.Option) (*SigningSessionReply, error) {
+	res := &SigningSessionReply{}
+	err := c.requester.SendRequest(ctx, "avm.getSigningSession", &SigningSessionArgs{
+		SessionID: sessionID,
+	}, res, options...)
+	return res, err
+}
+
+// AddSigningSessionSignatures expects the signatures to be hex encoded.
+func (c *client) AddSigningSessionSignatures(
+	ctx context.Context,
+	sessionID ids.ID,
+	signatures []SigningSessionSignature,
+	options ...rpc.Option,
+) (*SigningSessionReply, error) {
+	res := &SigningSessionReply{}
+	err := c.requester.SendRequest(ctx, "avm.addSigningSessionSignatures", &AddSigningSessionSignaturesArgs{
+		SessionID:  sessionID,
+		Signatures: signatures,
+		Encoding:   formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) IssueSigningSession(ctx context.Context, sessionID ids.ID, options ...rpc.Option) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "avm.issueSigningSession", &SigningSessionArgs{
+		SessionID: sessionID,
+	}, res, options...)
+	return res.TxID, err
+}
+
+func (c *client) ScheduleTx(ctx context.Context, txBytes []byte, issueTime time.Time, options ...rpc.Option) (ids.ID, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return ids.ID{}, err
+	}
+	res := &api.JSONTxID{}
+	err = c.requester.SendRequest(ctx, "avm.scheduleTx", &ScheduleTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		IssueTime: json.Uint64(issueTime.Unix()),
+	}, res, options...)
+	return res.TxID, err
+}
+
+func (c *client) CancelScheduledTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "avm.cancelScheduledTx", &api.JSONTxID{
+		TxID: txID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetScheduledTxs(ctx context.Context, options ...rpc.Option) ([]ScheduledTx, error) {
+	res := &GetScheduledTxsReply{}
+	err := c.requester.SendRequest(ctx, "avm.getScheduledTxs", struct{}{}, res, options...)
+	return res.Txs, err
+}
+
 func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error) {
 	res := &GetTxStatusReply{}
 	err := c.requester.SendRequest(ctx, "avm.getTxStatus", &api.JSONTxID{
@@ -406,6 +614,48 @@ func (c *client) GetAllBalances(
 	return res.Balances, err
 }
 
+func (c *client) GetSpendability(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	assetIDs []string,
+	options ...rpc.Option,
+) (*GetSpendabilityReply, error) {
+	res := &GetSpendabilityReply{}
+	err := c.requester.SendRequest(ctx, "avm.getSpendability", &GetSpendabilityArgs{
+		JSONAddresses: api.JSONAddresses{Addresses: ids.ShortIDsToStrings(addrs)},
+		AssetIDs:      assetIDs,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetUTXOProof(
+	ctx context.Context,
+	addr ids.ShortID,
+	utxoID ids.ID,
+	options ...rpc.Option,
+) ([]byte, *index.UTXOProof, ids.ID, error) {
+	res := &GetUTXOProofReply{}
+	err := c.requester.SendRequest(ctx, "avm.getUTXOProof", &GetUTXOProofArgs{
+		JSONAddress: api.JSONAddress{Address: addr.String()},
+		UTXOID:      utxoID,
+		Encoding:    formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, ids.Empty, err
+	}
+
+	utxoBytes, err := formatting.Decode(res.Encoding, res.UTXO)
+	if err != nil {
+		return nil, nil, ids.Empty, err
+	}
+	return utxoBytes, &index.UTXOProof{
+		Index:    uint64(res.Index),
+		NumUTXOs: uint64(res.NumUTXOs),
+		Path:     res.Path,
+		Peaks:    res.Peaks,
+	}, res.Root, nil
+}
+
 // ClientHolder describes how much an address owns of an asset
 type ClientHolder struct {
 	Amount  uint64
@@ -654,6 +904,42 @@ func (c *client) SendMultiple(
 	return res.TxID, err
 }
 
+func (c *client) Consolidate(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	assetID string,
+	maxInputsPerTx uint32,
+	feeBudget uint64,
+	options ...rpc.Option,
+) ([][]byte, error) {
+	res := &ConsolidateReply{}
+	err := c.requester.SendRequest(ctx, "avm.consolidate", &ConsolidateArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		AssetID:        assetID,
+		MaxInputsPerTx: json.Uint32(maxInputsPerTx),
+		FeeBudget:      json.Uint64(feeBudget),
+		Encoding:       formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([][]byte, len(res.Txs))
+	for i, txStr := range res.Txs {
+		txs[i], err = formatting.Decode(res.Encoding, txStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
+
 func (c *client) Mint(
 	ctx context.Context,
 	user api.UserPass,
@@ -741,6 +1027,28 @@ func (c *client) Import(ctx context.Context, user api.UserPass, to ids.ShortID,
 	return res.TxID, err
 }
 
+func (c *client) BulkImport(
+	ctx context.Context,
+	user api.UserPass,
+	to ids.ShortID,
+	sourceChain string,
+	batchSize uint32,
+	maxBatches uint32,
+	startIndex api.Index,
+	options ...rpc.Option,
+) (*BulkImportReply, error) {
+	res := &BulkImportReply{}
+	err := c.requester.SendRequest(ctx, "avm.bulkImport", &BulkImportArgs{
+		UserPass:    user,
+		SourceChain: sourceChain,
+		To:          to.String(),
+		BatchSize:   json.Uint32(batchSize),
+		MaxBatches:  json.Uint32(maxBatches),
+		StartIndex:  startIndex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) Export(
 	ctx context.Context,
 	user api.UserPass,
@@ -0,0 +1,19 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import "time"
+
+// CappedAssets allows assets to be created with a maximum supply.
+type CappedAssets struct {
+	// ActivationTime is the chain time from which CreateCappedAssetTxs are
+	// accepted
+	ActivationTime time.Time `json:"activationTime"`
+}
+
+// IsActivated returns true if CreateCappedAssetTxs are accepted at
+// [timestamp].
+func (c *CappedAssets) IsActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.ActivationTime)
+}
@@ -10,4 +10,15 @@ type Config struct {
 
 	// Fee that must be burned by every asset creating transaction
 	CreateAssetTxFee uint64
+
+	// If non-nil, fees may alternatively be paid in the fee conversion asset
+	// once activated
+	FeeConversion *FeeConversion
+
+	// If non-nil, fees scale with the size and complexity of txs once
+	// activated
+	DynamicFees *DynamicFees
+
+	// If non-nil, assets may be created with a maximum supply once activated
+	CappedAssets *CappedAssets
 }
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+// DynamicFees prices txs by the resources they consume. Once activated, the
+// fee that must be burned by a tx is the static fee of its type plus:
+//
+//	BytesFee*numBytes + InputFee*numInputs + SignatureFee*numSignatures
+type DynamicFees struct {
+	// ActivationTime is the chain time from which dynamic fees are enforced
+	ActivationTime time.Time `json:"activationTime"`
+
+	// BytesFee is charged for every byte of the signed tx
+	BytesFee uint64 `json:"bytesFee"`
+
+	// InputFee is charged for every UTXO consumed by the tx
+	InputFee uint64 `json:"inputFee"`
+
+	// SignatureFee is charged for every signature in the tx's credentials
+	SignatureFee uint64 `json:"signatureFee"`
+}
+
+// IsActivated returns true if dynamic fees are enforced at [timestamp].
+func (f *DynamicFees) IsActivated(timestamp time.Time) bool {
+	return !timestamp.Before(f.ActivationTime)
+}
+
+// Fee returns the fee that must be burned by a tx with the provided
+// complexity, given the [staticFee] of its type.
+func (f *DynamicFees) Fee(staticFee, numBytes, numInputs, numSignatures uint64) (uint64, error) {
+	bytesFee, err := math.Mul64(f.BytesFee, numBytes)
+	if err != nil {
+		return 0, err
+	}
+	inputsFee, err := math.Mul64(f.InputFee, numInputs)
+	if err != nil {
+		return 0, err
+	}
+	signaturesFee, err := math.Mul64(f.SignatureFee, numSignatures)
+	if err != nil {
+		return 0, err
+	}
+
+	fee, err := math.Add64(staticFee, bytesFee)
+	if err != nil {
+		return 0, err
+	}
+	fee, err = math.Add64(fee, inputsFee)
+	if err != nil {
+		return 0, err
+	}
+	return math.Add64(fee, signaturesFee)
+}
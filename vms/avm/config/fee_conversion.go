@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errEmptyFeeConversionAsset = errors.New("fee conversion asset must be specified")
+	errMissingFeeRateOracle    = errors.New("fee rate oracle must be specified")
+)
+
+// FeeConversion allows transaction fees to be paid in an asset other than the
+// fee asset. The conversion rate is published on-chain by the oracle.
+type FeeConversion struct {
+	// ActivationTime is the chain time from which FeeRateTxs are accepted and
+	// fees may be paid in the fee conversion asset
+	ActivationTime time.Time `json:"activationTime"`
+
+	// Asset that fees may be paid in
+	AssetID ids.ID `json:"assetID"`
+
+	// Owner that is authorized to issue FeeRateTxs
+	Oracle *secp256k1fx.OutputOwners `json:"oracle"`
+}
+
+func (c *FeeConversion) Verify() error {
+	switch {
+	case c.AssetID == ids.Empty:
+		return errEmptyFeeConversionAsset
+	case c.Oracle == nil:
+		return errMissingFeeRateOracle
+	default:
+		return c.Oracle.Verify()
+	}
+}
+
+// IsActivated returns true if fees may be converted at [timestamp].
+func (c *FeeConversion) IsActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.ActivationTime)
+}
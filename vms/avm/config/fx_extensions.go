@@ -0,0 +1,14 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import "time"
+
+// FxExtensions schedules the types that were added to the fxs of the chain
+// after they were first deployed, such as the secp256k1fx operations that
+// rotate owners, update asset metadata and extend locktimes.
+type FxExtensions struct {
+	// ActivationTime is the time from which the fx extensions may be used
+	ActivationTime time.Time `json:"activationTime"`
+}
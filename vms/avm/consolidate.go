@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errInvalidMaxInputsPerTx = errors.New("max inputs per tx must be at least 2")
+	errFeeBudgetTooLow       = errors.New("fee budget is less than the tx fee")
+	errNothingToConsolidate  = errors.New("no utxos to consolidate")
+)
+
+// ConsolidateArgs are arguments for passing into Consolidate requests
+type ConsolidateArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+
+	// ID of the asset whose UTXOs are merged
+	AssetID string `json:"assetID"`
+
+	// Maximum number of inputs, including any inputs paying the fee, of each
+	// tx
+	MaxInputsPerTx json.Uint32 `json:"maxInputsPerTx"`
+
+	// Maximum total fee paid by the txs
+	FeeBudget json.Uint64 `json:"feeBudget"`
+
+	// Encoding of the returned txs
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// ConsolidateReply is the response from calling Consolidate
+type ConsolidateReply struct {
+	// IDs of the txs, in the order they must be issued
+	TxIDs []ids.ID `json:"txIDs"`
+
+	// Signed txs, in the order they must be issued
+	Txs []string `json:"txs"`
+
+	Encoding formatting.Encoding `json:"encoding"`
+
+	// Address that owns the consolidated UTXOs
+	ChangeAddr string `json:"changeAddr"`
+}
+
+// consolidationInput is an input spending a UTXO being consolidated
+type consolidationInput struct {
+	input   *avax.TransferableInput
+	signers []*secp256k1.PrivateKey
+}
+
+// consolidate builds a chain of txs that merge the UTXOs of an asset held by
+// the user into a single UTXO owned by the change address. Each tx consumes at
+// most [MaxInputsPerTx] inputs and the txs pay at most [FeeBudget] in fees in
+// total. The smallest UTXOs are consolidated first.
+//
+// The txs are signed but not issued. Each tx spends the consolidated output of
+// the previous tx, so the txs must be issued in the order they are returned.
+// If the change address isn't controlled by the user, the txs instead
+// consolidate disjoint sets of UTXOs.
+//
+// If [update] isn't nil, it is applied to the user's UTXOs before they are
+// consolidated.
+//
+// Assumes the context lock is held.
+func (vm *VM) consolidate(
+	args *ConsolidateArgs,
+	update func([]*avax.UTXO) ([]*avax.UTXO, error),
+	reply *ConsolidateReply,
+) error {
+	maxInputs := int(args.MaxInputsPerTx)
+	if maxInputs < 2 {
+		return fmt.Errorf("%w: %d", errInvalidMaxInputsPerTx, maxInputs)
+	}
+	if uint64(args.FeeBudget) < vm.TxFee {
+		return fmt.Errorf("%w: %d < %d", errFeeBudgetTooLow, args.FeeBudget, vm.TxFee)
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := avax.ParseServiceAddresses(vm, args.From)
+	if err != nil {
+		return fmt.Errorf("couldn't parse 'From' addresses: %w", err)
+	}
+
+	assetID, err := vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	// Load user's UTXOs/keys
+	utxos, kc, err := vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	if update != nil {
+		utxos, err = update(utxos)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Parse the change address.
+	if len(kc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	var (
+		now      = vm.clock.Unix()
+		dust     []*consolidationInput
+		feeUTXOs []*avax.UTXO
+	)
+	for _, utxo := range utxos {
+		switch utxo.AssetID() {
+		case assetID:
+			if input, ok := spendableInput(kc, utxo, now); ok {
+				dust = append(dust, input)
+			}
+		case vm.feeAssetID:
+			feeUTXOs = append(feeUTXOs, utxo)
+		}
+	}
+	slices.SortFunc(dust, func(a, b *consolidationInput) bool {
+		return a.input.In.Amount() < b.input.In.Amount()
+	})
+
+	var (
+		codec           = vm.parser.Codec()
+		consolidatesFee = assetID == vm.feeAssetID
+		consolidated    []*txs.Tx
+		feesPaid        uint64
+		previousOutput  *consolidationInput
+	)
+	for len(dust) > 0 {
+		newFeesPaid, err := math.Add64(feesPaid, vm.TxFee)
+		if err != nil || newFeesPaid > uint64(args.FeeBudget) {
+			break
+		}
+
+		var (
+			ins  []*avax.TransferableInput
+			keys [][]*secp256k1.PrivateKey
+			outs []*avax.TransferableOutput
+		)
+		if !consolidatesFee {
+			amountsSpent, feeIns, feeKeys, err := vm.Spend(
+				feeUTXOs,
+				kc,
+				map[ids.ID]uint64{vm.feeAssetID: vm.TxFee},
+			)
+			if err != nil {
+				if len(consolidated) == 0 {
+					return err
+				}
+				break
+			}
+			ins = feeIns
+			keys = feeKeys
+
+			if change := amountsSpent[vm.feeAssetID] - vm.TxFee; change > 0 {
+				outs = append(outs, newTransferOutput(vm.feeAssetID, change, changeAddr))
+			}
+		}
+
+		// Merge the output of the previous tx with as many of the remaining
+		// UTXOs as fit in this tx.
+		inputs := make([]*consolidationInput, 0, maxInputs)
+		if previousOutput != nil {
+			inputs = append(inputs, previousOutput)
+		}
+		for len(ins)+len(inputs) < maxInputs && len(dust) > 0 {
+			inputs = append(inputs, dust[0])
+			dust = dust[1:]
+		}
+		if len(inputs) < 2 {
+			break
+		}
+
+		var amount uint64
+		for _, input := range inputs {
+			amount, err = math.Add64(amount, input.input.In.Amount())
+			if err != nil {
+				return fmt.Errorf("problem calculating consolidated amount: %w", err)
+			}
+			ins = append(ins, input.input)
+			keys = append(keys, input.signers)
+		}
+		if consolidatesFee {
+			if amount <= vm.TxFee {
+				// Consolidating these UTXOs would burn all of their value.
+				break
+			}
+			amount -= vm.TxFee
+		}
+		outs = append(outs, newTransferOutput(assetID, amount, changeAddr))
+
+		avax.SortTransferableInputsWithSigners(ins, keys)
+		avax.SortTransferableOutputs(outs, codec)
+
+		tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}}}
+		if err := tx.SignSECP256K1Fx(codec, keys); err != nil {
+			return err
+		}
+		consolidated = append(consolidated, tx)
+		feesPaid = newFeesPaid
+
+		// Chain the next tx off of the outputs of this tx.
+		spent := set.NewSet[ids.ID](len(ins))
+		for _, in := range ins {
+			spent.Add(in.InputID())
+		}
+		remainingFeeUTXOs := feeUTXOs[:0]
+		for _, utxo := range feeUTXOs {
+			if !spent.Contains(utxo.InputID()) {
+				remainingFeeUTXOs = append(remainingFeeUTXOs, utxo)
+			}
+		}
+		feeUTXOs = remainingFeeUTXOs
+
+		previousOutput = nil
+		for _, utxo := range tx.UTXOs() {
+			switch utxo.AssetID() {
+			case assetID:
+				if input, ok := spendableInput(kc, utxo, now); ok {
+					previousOutput = input
+				}
+			case vm.feeAssetID:
+				feeUTXOs = append(feeUTXOs, utxo)
+			}
+		}
+	}
+	if len(consolidated) == 0 {
+		return fmt.Errorf("%w of asset %s", errNothingToConsolidate, assetID)
+	}
+
+	reply.TxIDs = make([]ids.ID, len(consolidated))
+	reply.Txs = make([]string, len(consolidated))
+	for i, tx := range consolidated {
+		reply.TxIDs[i] = tx.ID()
+		reply.Txs[i], err = formatting.Encode(args.Encoding, tx.Bytes())
+		if err != nil {
+			return fmt.Errorf("couldn't encode tx as string: %w", err)
+		}
+	}
+	reply.Encoding = args.Encoding
+	reply.ChangeAddr, err = vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
+// spendableInput returns the input spending [utxo] if it can be spent by [kc]
+// at [time] and has an amount.
+func spendableInput(kc *secp256k1fx.Keychain, utxo *avax.UTXO, time uint64) (*consolidationInput, bool) {
+	inputIntf, signers, err := kc.Spend(utxo.Out, time)
+	if err != nil {
+		return nil, false
+	}
+	input, ok := inputIntf.(avax.TransferableIn)
+	if !ok {
+		return nil, false
+	}
+	return &consolidationInput{
+		input: &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: utxo.AssetID()},
+			In:     input,
+		},
+		signers: signers,
+	}, true
+}
+
+func newTransferOutput(assetID ids.ID, amount uint64, addr ids.ShortID) *avax.TransferableOutput {
+	return &avax.TransferableOutput{
+		Asset: avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Locktime:  0,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+}
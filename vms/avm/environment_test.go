@@ -100,7 +100,9 @@ type envConfig struct {
 	keystoreUsers    []*user
 	vmStaticConfig   *config.Config
 	vmDynamicConfig  *Config
+	vmUpgradeConfig  *UpgradeConfig
 	additionalFxs    []*common.Fx
+	fxFactories      FxFactories
 	notLinearized    bool
 	notBootstrapped  bool
 }
@@ -164,11 +166,13 @@ func setup(tb testing.TB, c *envConfig) *environment {
 	}
 
 	vm := &VM{
-		Config: vmStaticConfig,
+		Config:      vmStaticConfig,
+		fxFactories: c.fxFactories,
 	}
 
 	vmDynamicConfig := Config{
-		IndexTransactions: true,
+		IndexTransactions:      true,
+		InvariantChecksEnabled: true,
 	}
 	if c.vmDynamicConfig != nil {
 		vmDynamicConfig = *c.vmDynamicConfig
@@ -176,12 +180,18 @@ func setup(tb testing.TB, c *envConfig) *environment {
 	configBytes, err := stdjson.Marshal(vmDynamicConfig)
 	require.NoError(err)
 
+	var upgradeBytes []byte
+	if c.vmUpgradeConfig != nil {
+		upgradeBytes, err = stdjson.Marshal(c.vmUpgradeConfig)
+		require.NoError(err)
+	}
+
 	require.NoError(vm.Initialize(
 		context.Background(),
 		ctx,
 		prefixdb.New([]byte{1}, baseDB),
 		genesisBytes,
-		nil,
+		upgradeBytes,
 		configBytes,
 		nil,
 		append(
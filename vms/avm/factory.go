@@ -13,8 +13,14 @@ var _ vms.Factory = (*Factory)(nil)
 
 type Factory struct {
 	config.Config
+
+	// FxFactories looks up the fxs enabled by the VM config
+	FxFactories FxFactories
 }
 
 func (f *Factory) New(logging.Logger) (interface{}, error) {
-	return &VM{Config: f.Config}, nil
+	return &VM{
+		Config:      f.Config,
+		fxFactories: f.FxFactories,
+	}, nil
 }
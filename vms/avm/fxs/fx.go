@@ -4,6 +4,8 @@
 package fxs
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -23,6 +25,14 @@ var (
 type ParsedFx struct {
 	ID ids.ID
 	Fx Fx
+	// ActivationTime is the chain time from which the fx may be used. The fxs
+	// the chain was created with have always been activated.
+	ActivationTime time.Time
+}
+
+// IsActivated returns true if the fx may be used at [timestamp].
+func (fx *ParsedFx) IsActivated(timestamp time.Time) bool {
+	return !timestamp.Before(fx.ActivationTime)
 }
 
 // Fx is the interface a feature extension must implement to support the AVM.
@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// maxIdempotencyKeyLen is the maximum length of an idempotency key.
+	maxIdempotencyKeyLen = 256
+	// maxIdempotencyKeys is the maximum number of idempotency keys that are
+	// remembered. The oldest keys are forgotten first.
+	maxIdempotencyKeys = 65536
+	// idempotencyKeyTTL is how long an idempotency key is remembered after the
+	// tx was issued.
+	idempotencyKeyTTL = 24 * time.Hour
+)
+
+var (
+	errIdempotencyKeyTooLong = errors.New("idempotency key is too long")
+	errIdempotencyKeyReused  = errors.New("idempotency key was used to issue a different tx")
+)
+
+type idempotencyKey struct {
+	txID      ids.ID
+	issueTime time.Time
+}
+
+// idempotencyKeys persists the IDs of the txs issued with an idempotency key,
+// so that retried submissions return the ID of the tx that was already issued.
+// The database structure is:
+// [key] => issue time (unix seconds) ++ txID
+type idempotencyKeys struct {
+	db database.Database
+	// key -> issued tx, ordered by issue time
+	keys linkedhashmap.LinkedHashmap[string, *idempotencyKey]
+}
+
+// newIdempotencyKeys loads the idempotency keys that were persisted in [db]
+// and haven't expired by [now].
+func newIdempotencyKeys(db database.Database, now time.Time) (*idempotencyKeys, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var (
+		keys    []string
+		entries = make(map[string]*idempotencyKey)
+	)
+	for it.Next() {
+		p := wrappers.Packer{Bytes: it.Value()}
+		issueTime := p.UnpackLong()
+		txIDBytes := p.UnpackFixedBytes(ids.IDLen)
+		if p.Err != nil {
+			return nil, fmt.Errorf("failed to parse idempotency key: %w", p.Err)
+		}
+
+		key := string(it.Key())
+		keys = append(keys, key)
+		entries[key] = &idempotencyKey{
+			txID:      ids.ID(txIDBytes),
+			issueTime: time.Unix(int64(issueTime), 0),
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(keys, func(a, b string) bool {
+		return entries[a].issueTime.Before(entries[b].issueTime)
+	})
+
+	k := &idempotencyKeys{
+		db:   db,
+		keys: linkedhashmap.New[string, *idempotencyKey](),
+	}
+	for _, key := range keys {
+		k.keys.Put(key, entries[key])
+	}
+	return k, k.prune(now)
+}
+
+// Get returns the ID of the tx issued with [key], if it hasn't expired by
+// [now].
+func (k *idempotencyKeys) Get(key string, now time.Time) (ids.ID, bool) {
+	entry, ok := k.keys.Get(key)
+	if !ok || now.Sub(entry.issueTime) >= idempotencyKeyTTL {
+		return ids.Empty, false
+	}
+	return entry.txID, true
+}
+
+// Put records that [txID] was issued with [key] at [now].
+func (k *idempotencyKeys) Put(key string, txID ids.ID, now time.Time) error {
+	p := wrappers.Packer{
+		Bytes: make([]byte, wrappers.LongLen+ids.IDLen),
+	}
+	p.PackLong(uint64(now.Unix()))
+	p.PackFixedBytes(txID[:])
+	if err := k.db.Put([]byte(key), p.Bytes); err != nil {
+		return err
+	}
+
+	// Re-inserting the key moves it to the back of the issue time order.
+	k.keys.Delete(key)
+	k.keys.Put(key, &idempotencyKey{
+		txID:      txID,
+		issueTime: now,
+	})
+	return k.prune(now)
+}
+
+// prune forgets the keys that expired by [now] and the oldest keys beyond
+// [maxIdempotencyKeys].
+func (k *idempotencyKeys) prune(now time.Time) error {
+	for {
+		key, entry, ok := k.keys.Oldest()
+		if !ok {
+			return nil
+		}
+		if k.keys.Len() <= maxIdempotencyKeys && now.Sub(entry.issueTime) < idempotencyKeyTTL {
+			return nil
+		}
+
+		if err := k.db.Delete([]byte(key)); err != nil {
+			return err
+		}
+		k.keys.Delete(key)
+	}
+}
+
+// issueTxWithIdempotencyKey issues [txBytes] unless a tx was already issued
+// with [key], in which case the ID of that tx is returned. Retrying a
+// submission with the same key is therefore safe, even if the original
+// response was lost. Reusing [key] for a different tx is an error.
+//
+// vm.ctx.Lock should be held
+func (vm *VM) issueTxWithIdempotencyKey(txBytes []byte, key string) (ids.ID, error) {
+	if len(key) > maxIdempotencyKeyLen {
+		return ids.Empty, fmt.Errorf("%w: %d > %d", errIdempotencyKeyTooLong, len(key), maxIdempotencyKeyLen)
+	}
+
+	now := vm.clock.Time()
+	issuedTxID, ok := vm.idempotencyKeys.Get(key, now)
+	if !ok {
+		txID, err := vm.IssueTx(txBytes)
+		if err != nil {
+			return ids.Empty, err
+		}
+		return txID, vm.idempotencyKeys.Put(key, txID, now)
+	}
+
+	tx, err := vm.parser.ParseTx(txBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if txID := tx.ID(); txID != issuedTxID {
+		return ids.Empty, fmt.Errorf("%w: %s != %s", errIdempotencyKeyReused, txID, issuedTxID)
+	}
+
+	// The tx is issued again in case it was dropped from the mempool. It is
+	// expected to fail if the tx is still processing or was already decided.
+	if _, err := vm.IssueTx(txBytes); err != nil {
+		vm.ctx.Log.Debug("failed to reissue tx with idempotency key",
+			zap.Stringer("txID", issuedTxID),
+			zap.Error(err),
+		)
+	}
+	return issuedTxID, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	now := time.Unix(1_000_000, 0)
+	k, err := newIdempotencyKeys(db, now)
+	require.NoError(err)
+
+	txID0 := ids.GenerateTestID()
+	txID1 := ids.GenerateTestID()
+	require.NoError(k.Put("key0", txID0, now))
+	require.NoError(k.Put("key1", txID1, now.Add(time.Hour)))
+
+	txID, ok := k.Get("key0", now)
+	require.True(ok)
+	require.Equal(txID0, txID)
+
+	_, ok = k.Get("unknown", now)
+	require.False(ok)
+
+	// Keys are persisted across restarts
+	reloaded, err := newIdempotencyKeys(db, now)
+	require.NoError(err)
+	txID, ok = reloaded.Get("key1", now)
+	require.True(ok)
+	require.Equal(txID1, txID)
+
+	// Expired keys are forgotten
+	expiry := now.Add(idempotencyKeyTTL)
+	_, ok = k.Get("key0", expiry)
+	require.False(ok)
+
+	reloaded, err = newIdempotencyKeys(db, expiry)
+	require.NoError(err)
+	require.Equal(1, reloaded.keys.Len())
+	has, err := db.Has([]byte("key0"))
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestServiceIssueTxIdempotencyKey(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	tx := newTx(t, env.genesisBytes, env.vm, "AVAX")
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	args := &IssueTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		IdempotencyKey: "key",
+	}
+	reply := &api.JSONTxID{}
+	require.NoError(env.service.IssueTx(nil, args, reply))
+	require.Equal(tx.ID(), reply.TxID)
+
+	// Retrying the submission returns the same tx ID
+	reply = &api.JSONTxID{}
+	require.NoError(env.service.IssueTx(nil, args, reply))
+	require.Equal(tx.ID(), reply.TxID)
+
+	// The key is persisted across restarts
+	env.vm.ctx.Lock.Lock()
+	reloaded, err := newIdempotencyKeys(prefixdb.New(idempotencyKeysPrefix, env.vm.baseDB), env.vm.clock.Time())
+	require.NoError(err)
+	txID, ok := reloaded.Get("key", env.vm.clock.Time())
+	require.True(ok)
+	require.Equal(tx.ID(), txID)
+	env.vm.ctx.Lock.Unlock()
+
+	// The key can't be reused for a different tx
+	otherTx := newTx(t, env.genesisBytes, env.vm, "AVAX")
+	otherTx.Unsigned.(*txs.BaseTx).Memo = []byte{1}
+	require.NoError(otherTx.SignSECP256K1Fx(env.vm.parser.Codec(), [][]*secp256k1.PrivateKey{{keys[0]}}))
+	args.Tx, err = formatting.Encode(formatting.Hex, otherTx.Bytes())
+	require.NoError(err)
+	err = env.service.IssueTx(nil, args, &api.JSONTxID{})
+	require.ErrorIs(err, errIdempotencyKeyReused)
+
+	args.IdempotencyKey = strings.Repeat("a", maxIdempotencyKeyLen+1)
+	err = env.service.IssueTx(nil, args, &api.JSONTxID{})
+	require.ErrorIs(err, errIdempotencyKeyTooLong)
+}
@@ -19,7 +19,8 @@ type txMetrics struct {
 	numCreateAssetTxs,
 	numOperationTxs,
 	numImportTxs,
-	numExportTxs prometheus.Counter
+	numExportTxs,
+	numFeeRateTxs prometheus.Counter
 }
 
 func newTxMetrics(
@@ -33,6 +34,7 @@ func newTxMetrics(
 		numOperationTxs:   newTxMetric(namespace, "operation", registerer, &errs),
 		numImportTxs:      newTxMetric(namespace, "import", registerer, &errs),
 		numExportTxs:      newTxMetric(namespace, "export", registerer, &errs),
+		numFeeRateTxs:     newTxMetric(namespace, "fee_rate", registerer, &errs),
 	}
 	return m, errs.Err
 }
@@ -62,6 +64,10 @@ func (m *txMetrics) CreateAssetTx(*txs.CreateAssetTx) error {
 	return nil
 }
 
+func (m *txMetrics) CreateCappedAssetTx(tx *txs.CreateCappedAssetTx) error {
+	return m.CreateAssetTx(&tx.CreateAssetTx)
+}
+
 func (m *txMetrics) OperationTx(*txs.OperationTx) error {
 	m.numOperationTxs.Inc()
 	return nil
@@ -76,3 +82,8 @@ func (m *txMetrics) ExportTx(*txs.ExportTx) error {
 	m.numExportTxs.Inc()
 	return nil
 }
+
+func (m *txMetrics) FeeRateTx(*txs.FeeRateTx) error {
+	m.numFeeRateTxs.Inc()
+	return nil
+}
@@ -4,26 +4,74 @@
 package avm
 
 import (
-	"github.com/ava-labs/avalanchego/api"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/pubsub"
+	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 )
 
 var _ pubsub.Filterer = (*connector)(nil)
 
+// PubSubNotification is sent to the subscribers of the events endpoint when an
+// accepted tx spends or creates a UTXO that references one of their addresses.
+type PubSubNotification struct {
+	TxID ids.ID `json:"txID"`
+	// UTXOs consumed by the tx
+	SpentUTXOs []*avax.UTXOID `json:"spentUTXOs"`
+	// Hex encoded UTXOs produced by the tx
+	CreatedUTXOs []string `json:"createdUTXOs"`
+}
+
 type connector struct {
-	tx *txs.Tx
+	// UTXOs whose addresses are checked against the subscribers' filters
+	utxos        []*avax.UTXO
+	notification *PubSubNotification
 }
 
-func NewPubSubFilterer(tx *txs.Tx) pubsub.Filterer {
-	return &connector{tx: tx}
+// NewPubSubFilterer returns a filterer that notifies the subscribers that
+// reference an address of [spentUTXOs] or of the UTXOs created by [tx].
+func NewPubSubFilterer(
+	codec codec.Manager,
+	tx *txs.Tx,
+	spentUTXOs []*avax.UTXO,
+) (pubsub.Filterer, error) {
+	createdUTXOs := tx.UTXOs()
+	notification := &PubSubNotification{
+		TxID:         tx.ID(),
+		SpentUTXOs:   make([]*avax.UTXOID, len(spentUTXOs)),
+		CreatedUTXOs: make([]string, len(createdUTXOs)),
+	}
+	for i, utxo := range spentUTXOs {
+		notification.SpentUTXOs[i] = &utxo.UTXOID
+	}
+	for i, utxo := range createdUTXOs {
+		b, err := codec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return nil, fmt.Errorf("problem marshalling UTXO: %w", err)
+		}
+		notification.CreatedUTXOs[i], err = formatting.Encode(formatting.Hex, b)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode UTXO %s as string: %w", utxo.InputID(), err)
+		}
+	}
+
+	utxos := make([]*avax.UTXO, 0, len(spentUTXOs)+len(createdUTXOs))
+	utxos = append(utxos, spentUTXOs...)
+	utxos = append(utxos, createdUTXOs...)
+	return &connector{
+		utxos:        utxos,
+		notification: notification,
+	}, nil
 }
 
 // Apply the filter on the addresses.
 func (f *connector) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
 	resp := make([]bool, len(filters))
-	for _, utxo := range f.tx.UTXOs() {
+	for _, utxo := range f.utxos {
 		addressable, ok := utxo.Out.(avax.Addressable)
 		if !ok {
 			continue
@@ -38,7 +86,5 @@ func (f *connector) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
 			}
 		}
 	}
-	return resp, api.JSONTxID{
-		TxID: f.tx.ID(),
-	}
+	return resp, f.notification
 }
@@ -27,6 +27,9 @@ func (f *mockFilter) Check(addr []byte) bool {
 func TestFilter(t *testing.T) {
 	require := require.New(t)
 
+	parser, err := newStaticParser()
+	require.NoError(err)
+
 	addrID := ids.ShortID{1}
 	tx := txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
 		Outs: []*avax.TransferableOutput{
@@ -39,12 +42,74 @@ func TestFilter(t *testing.T) {
 			},
 		},
 	}}}
+	require.NoError(tx.Initialize(parser.Codec()))
 	addrBytes := addrID[:]
 
 	fp := pubsub.NewFilterParam()
 	require.NoError(fp.Add(addrBytes))
 
-	parser := NewPubSubFilterer(&tx)
-	fr, _ := parser.Filter([]pubsub.Filter{&mockFilter{addr: addrBytes}})
+	filterer, err := NewPubSubFilterer(parser.Codec(), &tx, nil)
+	require.NoError(err)
+	fr, msg := filterer.Filter([]pubsub.Filter{&mockFilter{addr: addrBytes}})
 	require.Equal([]bool{true}, fr)
+
+	notification := msg.(*PubSubNotification)
+	require.Equal(tx.ID(), notification.TxID)
+	require.Empty(notification.SpentUTXOs)
+	require.Len(notification.CreatedUTXOs, 1)
+}
+
+func TestFilterSpentUTXOs(t *testing.T) {
+	require := require.New(t)
+
+	parser, err := newStaticParser()
+	require.NoError(err)
+
+	var (
+		spenderAddrID   = ids.ShortID{1}
+		recipientAddrID = ids.ShortID{2}
+		otherAddrID     = ids.ShortID{3}
+	)
+	spentUTXO := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        ids.GenerateTestID(),
+			OutputIndex: 1,
+		},
+		Out: &secp256k1fx.TransferOutput{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Addrs: []ids.ShortID{spenderAddrID},
+			},
+		},
+	}
+	tx := txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		Ins: []*avax.TransferableInput{
+			{
+				UTXOID: spentUTXO.UTXOID,
+				In:     &secp256k1fx.TransferInput{},
+			},
+		},
+		Outs: []*avax.TransferableOutput{
+			{
+				Out: &secp256k1fx.TransferOutput{
+					OutputOwners: secp256k1fx.OutputOwners{
+						Addrs: []ids.ShortID{recipientAddrID},
+					},
+				},
+			},
+		},
+	}}}
+	require.NoError(tx.Initialize(parser.Codec()))
+
+	filterer, err := NewPubSubFilterer(parser.Codec(), &tx, []*avax.UTXO{spentUTXO})
+	require.NoError(err)
+	fr, msg := filterer.Filter([]pubsub.Filter{
+		&mockFilter{addr: spenderAddrID[:]},
+		&mockFilter{addr: recipientAddrID[:]},
+		&mockFilter{addr: otherAddrID[:]},
+	})
+	require.Equal([]bool{true, true, false}, fr)
+
+	notification := msg.(*PubSubNotification)
+	require.Equal([]*avax.UTXOID{&spentUTXO.UTXOID}, notification.SpentUTXOs)
+	require.Len(notification.CreatedUTXOs, 1)
 }
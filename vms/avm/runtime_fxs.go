@@ -0,0 +1,217 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	stdjson "encoding/json"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/avm/block"
+
+	extensions "github.com/ava-labs/avalanchego/vms/avm/fxs"
+)
+
+var (
+	errNoFxFactories        = errors.New("no fx factories to look up configured fxs in")
+	errDuplicateFx          = errors.New("duplicate feature extension")
+	errDuplicateFxNamespace = errors.New("duplicate fx namespace")
+)
+
+// FxFactories looks up the factories of the fxs that can be enabled by the
+// upgrade config. The VM manager, which contains the built-in fxs and the fxs
+// registered by plugins, implements FxFactories.
+type FxFactories interface {
+	// Lookup returns the ID of the fx named [alias]
+	Lookup(alias string) (ids.ID, error)
+	// GetFactory returns the factory of the fx with ID [fxID]
+	GetFactory(fxID ids.ID) (vms.Factory, error)
+}
+
+// FxConfig enables an fx that the chain wasn't created with. It must be the
+// same across all the nodes validating the chain.
+type FxConfig struct {
+	// Name, or ID, of the fx
+	Name string `json:"name"`
+	// Namespace of the codec type IDs of the fx's types. Each configured fx
+	// must have its own namespace. Changing the namespace of an fx changes the
+	// serialization of its types.
+	Namespace uint16 `json:"namespace"`
+	// ActivationTime is the chain time from which the fx may be used
+	ActivationTime time.Time `json:"activationTime"`
+}
+
+// newConfiguredFxs creates the fxs enabled by [configs], in order of their
+// namespaces. The fxs are indexed after the [chainFxs].
+func newConfiguredFxs(
+	log logging.Logger,
+	factories FxFactories,
+	configs []FxConfig,
+	chainFxs []*extensions.ParsedFx,
+) ([]*extensions.ParsedFx, []uint16, error) {
+	if len(configs) == 0 {
+		return nil, nil, nil
+	}
+	if factories == nil {
+		return nil, nil, errNoFxFactories
+	}
+
+	configs = slices.Clone(configs)
+	slices.SortFunc(configs, func(a, b FxConfig) bool {
+		return a.Namespace < b.Namespace
+	})
+
+	fxIDs := set.NewSet[ids.ID](len(chainFxs) + len(configs))
+	for _, fx := range chainFxs {
+		fxIDs.Add(fx.ID)
+	}
+
+	var (
+		fxs        = make([]*extensions.ParsedFx, len(configs))
+		namespaces = make([]uint16, len(configs))
+	)
+	for i, config := range configs {
+		if i > 0 && config.Namespace == namespaces[i-1] {
+			return nil, nil, fmt.Errorf("%w: %d", errDuplicateFxNamespace, config.Namespace)
+		}
+
+		fxID, err := factories.Lookup(config.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w %q: %w", errUnknownFx, config.Name, err)
+		}
+		if fxIDs.Contains(fxID) {
+			return nil, nil, fmt.Errorf("%w: %q", errDuplicateFx, config.Name)
+		}
+		fxIDs.Add(fxID)
+
+		factory, err := factories.GetFactory(fxID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w %q: %w", errUnknownFx, config.Name, err)
+		}
+		fxIntf, err := factory.New(log)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create fx %q: %w", config.Name, err)
+		}
+		fx, ok := fxIntf.(extensions.Fx)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %q", errIncompatibleFx, config.Name)
+		}
+
+		fxs[i] = &extensions.ParsedFx{
+			ID:             fxID,
+			Fx:             fx,
+			ActivationTime: config.ActivationTime,
+		}
+		namespaces[i] = config.Namespace
+	}
+	return fxs, namespaces, nil
+}
+
+// registerConfiguredFxs registers the types of [fxs] into their [namespaces].
+// The fxs are indexed after the [numChainFxs].
+func registerConfiguredFxs(
+	parser block.Parser,
+	numChainFxs int,
+	fxs []*extensions.ParsedFx,
+	namespaces []uint16,
+) error {
+	for i, fx := range fxs {
+		if err := parser.RegisterNamespacedFx(numChainFxs+i, fx.Fx, namespaces[i]); err != nil {
+			return fmt.Errorf("failed to register fx %s: %w", fx.ID, err)
+		}
+	}
+	return nil
+}
+
+// NewChainParser returns a parser of the blocks of a chain that was created
+// with [chainFxs] and is run with the upgrade config [upgradeBytes], so that
+// the blocks of the chain can be parsed outside of the VM.
+func NewChainParser(
+	log logging.Logger,
+	factories FxFactories,
+	chainFxs []*common.Fx,
+	upgradeBytes []byte,
+) (block.Parser, error) {
+	config := UpgradeConfig{}
+	if len(upgradeBytes) > 0 {
+		if err := stdjson.Unmarshal(upgradeBytes, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse upgrade config: %w", err)
+		}
+	}
+	// Accepted blocks only contain fx extensions once they were activated,
+	// so they are always registered. The types of the configured fxs are
+	// registered regardless of their activation times.
+	parser, _, err := newParser(
+		log,
+		&mockable.Clock{},
+		make(map[reflect.Type]int),
+		time.Time{},
+		factories,
+		chainFxs,
+		config.Fxs,
+	)
+	return parser, err
+}
+
+// newParser returns a parser of the blocks of a chain that was created with
+// [chainFxs] and enables the fxs of [configs], along with all the fxs of the
+// chain. The types added to the fxs after they were first deployed may only be
+// used from [fxExtensionsActivationTime].
+func newParser(
+	log logging.Logger,
+	clock *mockable.Clock,
+	typeToFxIndex map[reflect.Type]int,
+	fxExtensionsActivationTime time.Time,
+	factories FxFactories,
+	chainFxs []*common.Fx,
+	configs []FxConfig,
+) (block.Parser, []*extensions.ParsedFx, error) {
+	typedFxs := make([]extensions.Fx, len(chainFxs))
+	fxs := make([]*extensions.ParsedFx, len(chainFxs))
+	for i, fxContainer := range chainFxs {
+		if fxContainer == nil {
+			return nil, nil, errIncompatibleFx
+		}
+		fx, ok := fxContainer.Fx.(extensions.Fx)
+		if !ok {
+			return nil, nil, errIncompatibleFx
+		}
+		typedFxs[i] = fx
+		fxs[i] = &extensions.ParsedFx{
+			ID: fxContainer.ID,
+			Fx: fx,
+		}
+	}
+
+	configuredFxs, namespaces, err := newConfiguredFxs(log, factories, configs, fxs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parser, err := block.NewCustomParser(
+		typeToFxIndex,
+		clock,
+		log,
+		fxExtensionsActivationTime,
+		typedFxs,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := registerConfiguredFxs(parser, len(fxs), configuredFxs, namespaces); err != nil {
+		return nil, nil, err
+	}
+	return parser, append(fxs, configuredFxs...), nil
+}
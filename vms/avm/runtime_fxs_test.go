@@ -0,0 +1,199 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	extensions "github.com/ava-labs/avalanchego/vms/avm/fxs"
+)
+
+func newTestFxFactories(t *testing.T) vms.Manager {
+	require := require.New(t)
+
+	factories := vms.NewManager(logging.NoLog{}, ids.NewAliaser())
+	require.NoError(factories.RegisterFactory(context.Background(), nftfx.ID, &nftfx.Factory{}))
+	require.NoError(factories.RegisterFactory(context.Background(), propertyfx.ID, &propertyfx.Factory{}))
+	require.NoError(factories.Alias(nftfx.ID, "nftfx"))
+	require.NoError(factories.Alias(propertyfx.ID, "propertyfx"))
+	return factories
+}
+
+func TestConfiguredFxs(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		vmUpgradeConfig: &UpgradeConfig{
+			Fxs: []FxConfig{
+				{
+					Name:      "propertyfx",
+					Namespace: 1,
+				},
+			},
+		},
+		fxFactories: newTestFxFactories(t),
+	})
+	defer func() {
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	// The configured fx is indexed after the fxs the chain was created with
+	require.Len(env.vm.fxs, 3)
+	require.Equal(propertyfx.ID, env.vm.fxs[2].ID)
+	require.Equal(2, env.vm.typeToFxIndex[reflect.TypeOf(&propertyfx.MintOutput{})])
+}
+
+func TestNewConfiguredFxs(t *testing.T) {
+	chainFxs := []*extensions.ParsedFx{
+		{
+			ID: secp256k1fx.ID,
+			Fx: &secp256k1fx.Fx{},
+		},
+		{
+			ID: nftfx.ID,
+			Fx: &nftfx.Fx{},
+		},
+	}
+
+	tests := []struct {
+		name                    string
+		factories               FxFactories
+		configs                 []FxConfig
+		expectedFxIDs           []ids.ID
+		expectedNamespaces      []uint16
+		expectedActivationTimes []time.Time
+		expectedErr             error
+	}{
+		{
+			name:                    "no configured fxs",
+			factories:               nil,
+			configs:                 nil,
+			expectedFxIDs:           []ids.ID{},
+			expectedActivationTimes: []time.Time{},
+		},
+		{
+			name:      "no factories",
+			factories: nil,
+			configs: []FxConfig{
+				{Name: "propertyfx"},
+			},
+			expectedErr: errNoFxFactories,
+		},
+		{
+			name:      "unknown fx",
+			factories: newTestFxFactories(t),
+			configs: []FxConfig{
+				{Name: "unknownfx"},
+			},
+			expectedErr: errUnknownFx,
+		},
+		{
+			name:      "fx the chain was created with",
+			factories: newTestFxFactories(t),
+			configs: []FxConfig{
+				{Name: "nftfx"},
+			},
+			expectedErr: errDuplicateFx,
+		},
+		{
+			name:      "duplicate fx",
+			factories: newTestFxFactories(t),
+			configs: []FxConfig{
+				{Name: "propertyfx", Namespace: 0},
+				{Name: propertyfx.ID.String(), Namespace: 1},
+			},
+			expectedErr: errDuplicateFx,
+		},
+		{
+			name:      "duplicate namespace",
+			factories: newTestFxFactories(t),
+			configs: []FxConfig{
+				{Name: "propertyfx", Namespace: 3},
+				{Name: "nftfx", Namespace: 3},
+			},
+			expectedErr: errDuplicateFxNamespace,
+		},
+		{
+			name:      "configured fx",
+			factories: newTestFxFactories(t),
+			configs: []FxConfig{
+				{Name: "propertyfx", Namespace: 3, ActivationTime: time.Unix(1_700_000_000, 0)},
+			},
+			expectedFxIDs:           []ids.ID{propertyfx.ID},
+			expectedNamespaces:      []uint16{3},
+			expectedActivationTimes: []time.Time{time.Unix(1_700_000_000, 0)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			fxs, namespaces, err := newConfiguredFxs(logging.NoLog{}, test.factories, test.configs, chainFxs)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			var (
+				fxIDs           = make([]ids.ID, len(fxs))
+				activationTimes = make([]time.Time, len(fxs))
+			)
+			for i, fx := range fxs {
+				fxIDs[i] = fx.ID
+				activationTimes[i] = fx.ActivationTime
+			}
+			require.Equal(test.expectedFxIDs, fxIDs)
+			require.Equal(test.expectedNamespaces, namespaces)
+			require.Equal(test.expectedActivationTimes, activationTimes)
+		})
+	}
+}
+
+type stateWrapper struct {
+	State verify.State `serialize:"true"`
+}
+
+func TestNewChainParser(t *testing.T) {
+	require := require.New(t)
+
+	parser, err := NewChainParser(
+		logging.NoLog{},
+		newTestFxFactories(t),
+		[]*common.Fx{
+			{
+				ID: secp256k1fx.ID,
+				Fx: &secp256k1fx.Fx{},
+			},
+		},
+		[]byte(`{"fxs":[{"name":"propertyfx","namespace":1}]}`),
+	)
+	require.NoError(err)
+
+	// The types of the configured fx can be parsed
+	bytes, err := parser.Codec().Marshal(txs.CodecVersion, &stateWrapper{
+		State: &propertyfx.MintOutput{},
+	})
+	require.NoError(err)
+
+	parsed := &stateWrapper{}
+	_, err = parser.Codec().Unmarshal(bytes, parsed)
+	require.NoError(err)
+	require.IsType(&propertyfx.MintOutput{}, parsed.State)
+}
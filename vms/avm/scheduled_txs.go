@@ -0,0 +1,311 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/avm/block"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+
+	txexecutor "github.com/ava-labs/avalanchego/vms/avm/txs/executor"
+)
+
+// maxScheduledTxs is the maximum number of txs that can be waiting to be
+// issued at once.
+const maxScheduledTxs = 1024
+
+var (
+	errTxAlreadyScheduled  = errors.New("tx is already scheduled")
+	errTxNotScheduled      = errors.New("tx isn't scheduled")
+	errTooManyScheduledTxs = errors.New("too many scheduled txs")
+)
+
+type scheduledTx struct {
+	tx        *txs.Tx
+	issueTime time.Time
+}
+
+// scheduledTxs persists signed txs that should only be issued once their issue
+// time has passed.
+// The database structure is:
+// [txID] => issue time (unix seconds) ++ tx bytes
+type scheduledTxs struct {
+	db database.Database
+	// txID -> tx waiting to be issued
+	txs map[ids.ID]*scheduledTx
+}
+
+// newScheduledTxs loads the txs that were scheduled in [db].
+func newScheduledTxs(db database.Database, parser block.Parser) (*scheduledTxs, error) {
+	s := &scheduledTxs{
+		db:  db,
+		txs: make(map[ids.ID]*scheduledTx),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		p := wrappers.Packer{Bytes: it.Value()}
+		issueTime := p.UnpackLong()
+		if p.Err != nil {
+			return nil, fmt.Errorf("failed to parse scheduled tx issue time: %w", p.Err)
+		}
+
+		tx, err := parser.ParseTx(p.Bytes[p.Offset:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse scheduled tx: %w", err)
+		}
+		s.txs[tx.ID()] = &scheduledTx{
+			tx:        tx,
+			issueTime: time.Unix(int64(issueTime), 0),
+		}
+	}
+	return s, it.Error()
+}
+
+// Add schedules [tx] to be issued once [issueTime] has passed.
+func (s *scheduledTxs) Add(tx *txs.Tx, issueTime time.Time) error {
+	txID := tx.ID()
+	if _, ok := s.txs[txID]; ok {
+		return fmt.Errorf("%w: %s", errTxAlreadyScheduled, txID)
+	}
+	if len(s.txs) >= maxScheduledTxs {
+		return fmt.Errorf("%w: %d", errTooManyScheduledTxs, maxScheduledTxs)
+	}
+
+	txBytes := tx.Bytes()
+	p := wrappers.Packer{
+		Bytes: make([]byte, wrappers.LongLen+len(txBytes)),
+	}
+	p.PackLong(uint64(issueTime.Unix()))
+	p.PackFixedBytes(txBytes)
+	if err := s.db.Put(txID[:], p.Bytes); err != nil {
+		return err
+	}
+
+	s.txs[txID] = &scheduledTx{
+		tx:        tx,
+		issueTime: issueTime,
+	}
+	return nil
+}
+
+// Remove unschedules [txID].
+func (s *scheduledTxs) Remove(txID ids.ID) error {
+	if _, ok := s.txs[txID]; !ok {
+		return fmt.Errorf("%w: %s", errTxNotScheduled, txID)
+	}
+	if err := s.db.Delete(txID[:]); err != nil {
+		return err
+	}
+
+	delete(s.txs, txID)
+	return nil
+}
+
+// NextIssueTime returns the earliest issue time of the scheduled txs. Returns
+// false if no txs are scheduled.
+func (s *scheduledTxs) NextIssueTime() (time.Time, bool) {
+	var (
+		next  time.Time
+		found bool
+	)
+	for _, stx := range s.txs {
+		if !found || stx.issueTime.Before(next) {
+			next = stx.issueTime
+			found = true
+		}
+	}
+	return next, found
+}
+
+// resetScheduledTxTimer wakes the issuer when the next scheduled tx can be
+// issued.
+//
+// vm.ctx.Lock should be held
+func (vm *VM) resetScheduledTxTimer() {
+	// Txs are only issued after the VM has been linearized and bootstrapped.
+	if vm.scheduledTxTimer == nil || !vm.bootstrapped {
+		return
+	}
+
+	nextIssueTime, ok := vm.scheduledTxs.NextIssueTime()
+	if !ok {
+		vm.scheduledTxTimer.Cancel()
+		return
+	}
+	vm.scheduledTxTimer.SetTimeoutIn(nextIssueTime.Sub(vm.clock.Time()))
+}
+
+// issueScheduledTxs issues every scheduled tx whose issue time has passed.
+// Because blocks are never timestamped before the local time, an issued tx can
+// only be accepted in a block at or after its issue time.
+//
+// A tx is unscheduled once it is issued, even if it fails verification, as
+// its inputs may have already been consumed.
+func (vm *VM) issueScheduledTxs() {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if !vm.bootstrapped {
+		return
+	}
+
+	now := vm.clock.Time()
+	for txID, stx := range vm.scheduledTxs.txs {
+		if stx.issueTime.After(now) {
+			continue
+		}
+
+		if err := vm.scheduledTxs.Remove(txID); err != nil {
+			vm.ctx.Log.Error("failed to unschedule tx",
+				zap.Stringer("txID", txID),
+				zap.Error(err),
+			)
+			return
+		}
+
+		if err := vm.network.IssueTx(context.TODO(), stx.tx); err != nil {
+			vm.ctx.Log.Warn("failed to issue scheduled tx",
+				zap.Stringer("txID", txID),
+				zap.Time("issueTime", stx.issueTime),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		vm.ctx.Log.Info("issued scheduled tx",
+			zap.Stringer("txID", txID),
+			zap.Time("issueTime", stx.issueTime),
+		)
+	}
+	vm.resetScheduledTxTimer()
+}
+
+// ScheduleTxArgs are arguments for passing into ScheduleTx requests
+type ScheduleTxArgs struct {
+	api.FormattedTx
+	// IssueTime is the unix time, in seconds, after which the tx is issued
+	IssueTime json.Uint64 `json:"issueTime"`
+}
+
+// ScheduleTx persists a signed tx to be issued once the node's clock reaches
+// the issue time. Scheduled txs are only verified syntactically, so a tx that
+// spends time-locked UTXOs can be scheduled before the UTXOs unlock.
+func (s *Service) ScheduleTx(_ *http.Request, args *ScheduleTxArgs, reply *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "scheduleTx"),
+		logging.UserString("tx", args.Tx),
+		zap.Uint64("issueTime", uint64(args.IssueTime)),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	tx, err := s.vm.parser.ParseTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing transaction: %w", err)
+	}
+
+	issueTime := time.Unix(int64(args.IssueTime), 0)
+	err = tx.Unsigned.Visit(&txexecutor.SyntacticVerifier{
+		Backend:   s.vm.txBackend,
+		Tx:        tx,
+		Timestamp: issueTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.vm.scheduledTxs.Add(tx, issueTime); err != nil {
+		return err
+	}
+	s.vm.resetScheduledTxTimer()
+
+	reply.TxID = tx.ID()
+	return nil
+}
+
+// CancelScheduledTx unschedules a tx that hasn't been issued yet.
+func (s *Service) CancelScheduledTx(_ *http.Request, args *api.JSONTxID, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "cancelScheduledTx"),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if err := s.vm.scheduledTxs.Remove(args.TxID); err != nil {
+		return err
+	}
+	s.vm.resetScheduledTxTimer()
+	return nil
+}
+
+// ScheduledTx is a tx that is waiting to be issued
+type ScheduledTx struct {
+	TxID      ids.ID      `json:"txID"`
+	IssueTime json.Uint64 `json:"issueTime"`
+}
+
+// GetScheduledTxsReply defines the GetScheduledTxs replies returned from the
+// API
+type GetScheduledTxsReply struct {
+	// Txs are sorted by their issue time
+	Txs []ScheduledTx `json:"txs"`
+}
+
+// GetScheduledTxs returns the txs that are waiting to be issued.
+func (s *Service) GetScheduledTxs(_ *http.Request, _ *struct{}, reply *GetScheduledTxsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getScheduledTxs"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	scheduled := maps.Values(s.vm.scheduledTxs.txs)
+	slices.SortFunc(scheduled, func(a, b *scheduledTx) bool {
+		if !a.issueTime.Equal(b.issueTime) {
+			return a.issueTime.Before(b.issueTime)
+		}
+		return a.tx.ID().Less(b.tx.ID())
+	})
+
+	reply.Txs = make([]ScheduledTx, len(scheduled))
+	for i, stx := range scheduled {
+		reply.Txs[i] = ScheduledTx{
+			TxID:      stx.tx.ID(),
+			IssueTime: json.Uint64(stx.issueTime.Unix()),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+func TestServiceScheduleTx(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	tx := newTx(t, env.genesisBytes, env.vm, "AVAX")
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	issueTime := env.vm.clock.Time().Add(time.Hour)
+	args := &ScheduleTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		IssueTime: json.Uint64(issueTime.Unix()),
+	}
+	reply := &api.JSONTxID{}
+	require.NoError(env.service.ScheduleTx(nil, args, reply))
+	require.Equal(tx.ID(), reply.TxID)
+
+	err = env.service.ScheduleTx(nil, args, &api.JSONTxID{})
+	require.ErrorIs(err, errTxAlreadyScheduled)
+
+	scheduledReply := &GetScheduledTxsReply{}
+	require.NoError(env.service.GetScheduledTxs(nil, nil, scheduledReply))
+	require.Equal([]ScheduledTx{{
+		TxID:      tx.ID(),
+		IssueTime: json.Uint64(issueTime.Unix()),
+	}}, scheduledReply.Txs)
+
+	// Scheduled txs are persisted across restarts
+	env.vm.ctx.Lock.Lock()
+	reloaded, err := newScheduledTxs(prefixdb.New(scheduledTxsPrefix, env.vm.baseDB), env.vm.parser)
+	require.NoError(err)
+	require.Contains(reloaded.txs, tx.ID())
+
+	// The tx isn't issued before its issue time
+	env.vm.clock.Set(issueTime.Add(-time.Second))
+	env.vm.ctx.Lock.Unlock()
+	env.vm.issueScheduledTxs()
+	env.vm.ctx.Lock.Lock()
+	require.False(env.vm.mempool.Has(tx.ID()))
+
+	env.vm.clock.Set(issueTime)
+	env.vm.ctx.Lock.Unlock()
+	env.vm.issueScheduledTxs()
+	env.vm.ctx.Lock.Lock()
+	require.True(env.vm.mempool.Has(tx.ID()))
+	require.Empty(env.vm.scheduledTxs.txs)
+	env.vm.ctx.Lock.Unlock()
+
+	err = env.service.CancelScheduledTx(nil, &api.JSONTxID{TxID: tx.ID()}, &api.EmptyReply{})
+	require.ErrorIs(err, errTxNotScheduled)
+}
+
+func TestServiceCancelScheduledTx(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	tx := newTx(t, env.genesisBytes, env.vm, "AVAX")
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	issueTime := env.vm.clock.Time().Add(time.Hour)
+	require.NoError(env.service.ScheduleTx(nil, &ScheduleTxArgs{
+		FormattedTx: api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		},
+		IssueTime: json.Uint64(issueTime.Unix()),
+	}, &api.JSONTxID{}))
+	require.NoError(env.service.CancelScheduledTx(nil, &api.JSONTxID{TxID: tx.ID()}, &api.EmptyReply{}))
+
+	scheduledReply := &GetScheduledTxsReply{}
+	require.NoError(env.service.GetScheduledTxs(nil, nil, scheduledReply))
+	require.Empty(scheduledReply.Txs)
+
+	// Cancelled txs are removed from disk
+	env.vm.ctx.Lock.Lock()
+	reloaded, err := newScheduledTxs(prefixdb.New(scheduledTxsPrefix, env.vm.baseDB), env.vm.parser)
+	require.NoError(err)
+	require.Empty(reloaded.txs)
+
+	// Cancelled txs are never issued
+	env.vm.clock.Set(issueTime)
+	env.vm.ctx.Lock.Unlock()
+	env.vm.issueScheduledTxs()
+	env.vm.ctx.Lock.Lock()
+	require.False(env.vm.mempool.Has(tx.ID()))
+	env.vm.ctx.Lock.Unlock()
+}
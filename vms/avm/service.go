@@ -13,6 +13,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"golang.org/x/exp/maps"
+
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
@@ -24,6 +26,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/avm/txs/executor"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
@@ -53,6 +56,7 @@ var (
 	errNoKeys             = errors.New("from addresses have no keys or funds")
 	errMissingPrivateKey  = errors.New("argument 'privateKey' not given")
 	errNotLinearized      = errors.New("chain is not linearized")
+	errTooManyTxs         = errors.New("too many txs provided")
 )
 
 // FormattedAssetID defines a JSON formatted struct containing an assetID as a string
@@ -193,12 +197,22 @@ func (s *Service) GetHeight(_ *http.Request, _ *struct{}, reply *api.GetHeightRe
 	return nil
 }
 
+// IssueTxArgs are arguments for passing into IssueTx requests
+type IssueTxArgs struct {
+	api.FormattedTx
+	// IdempotencyKey, if non-empty, is remembered with the ID of the issued
+	// tx. Retrying the request with the same key returns the same tx ID rather
+	// than a conflict error.
+	IdempotencyKey string `json:"idempotencyKey"`
+}
+
 // IssueTx attempts to issue a transaction into consensus
-func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, reply *api.JSONTxID) error {
+func (s *Service) IssueTx(_ *http.Request, args *IssueTxArgs, reply *api.JSONTxID) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "avm"),
 		zap.String("method", "issueTx"),
 		logging.UserString("tx", args.Tx),
+		logging.UserString("idempotencyKey", args.IdempotencyKey),
 	)
 
 	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
@@ -209,7 +223,12 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, reply *api.JSO
 	s.vm.ctx.Lock.Lock()
 	defer s.vm.ctx.Lock.Unlock()
 
-	txID, err := s.vm.IssueTx(txBytes)
+	var txID ids.ID
+	if args.IdempotencyKey == "" {
+		txID, err = s.vm.IssueTx(txBytes)
+	} else {
+		txID, err = s.vm.issueTxWithIdempotencyKey(txBytes, args.IdempotencyKey)
+	}
 	if err != nil {
 		return err
 	}
@@ -218,6 +237,286 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, reply *api.JSO
 	return nil
 }
 
+// DryRunTxReply defines the DryRunTx replies returned from the API
+type DryRunTxReply struct {
+	TxID ids.ID `json:"txID"`
+	// Valid is true if the transaction could currently be issued.
+	Valid bool `json:"valid"`
+	// Error is the reason the transaction failed verification, if any.
+	Error string `json:"error,omitempty"`
+	// FailedInput describes the input that failed verification, if the
+	// failure was caused by a specific input.
+	FailedInput *DryRunInputError `json:"failedInput,omitempty"`
+}
+
+// DryRunInputError describes an input that failed verification
+type DryRunInputError struct {
+	// Kind is one of "input", "importedInput", or "operation"
+	Kind            string      `json:"kind"`
+	Index           json.Uint32 `json:"index"`
+	CredentialIndex json.Uint32 `json:"credentialIndex"`
+}
+
+// DryRunTx verifies a transaction against the currently preferred state
+// without issuing it.
+func (s *Service) DryRunTx(_ *http.Request, args *api.FormattedTx, reply *DryRunTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "dryRunTx"),
+		logging.UserString("tx", args.Tx),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.chainManager == nil {
+		return errNotLinearized
+	}
+
+	tx, err := s.vm.parser.ParseTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing transaction: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+	err = s.vm.chainManager.VerifyTx(tx)
+	if err == nil {
+		reply.Valid = true
+		return nil
+	}
+
+	reply.Error = err.Error()
+	var inputErr *executor.InputError
+	if errors.As(err, &inputErr) {
+		reply.FailedInput = &DryRunInputError{
+			Kind:            string(inputErr.Kind),
+			Index:           json.Uint32(inputErr.Index),
+			CredentialIndex: json.Uint32(inputErr.CredentialIndex),
+		}
+	}
+	return nil
+}
+
+// GetMempoolArgs are the arguments for GetMempool
+type GetMempoolArgs struct {
+	// Limit is the maximum number of txs to return. If 0 or greater than
+	// the maximum page size, the maximum page size is used.
+	Limit json.Uint32 `json:"limit"`
+}
+
+// MempoolTx describes a tx in the mempool
+type MempoolTx struct {
+	TxID ids.ID      `json:"txID"`
+	Size json.Uint32 `json:"size"`
+	// Fee is the amount of the fee asset burned by the tx
+	Fee json.Uint64 `json:"fee"`
+}
+
+// GetMempoolReply is the response from GetMempool
+type GetMempoolReply struct {
+	// Txs are ordered by decreasing fee rate, which is the order they would
+	// be issued in.
+	Txs []MempoolTx `json:"txs"`
+}
+
+// GetMempool returns the txs in the mempool with the highest fee rates
+func (s *Service) GetMempool(_ *http.Request, args *GetMempoolArgs, reply *GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getMempool"),
+		zap.Uint32("limit", uint32(args.Limit)),
+	)
+
+	limit := int(args.Limit)
+	if limit <= 0 || int(maxPageSize) < limit {
+		limit = int(maxPageSize)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.mempool == nil {
+		return errNotLinearized
+	}
+
+	reply.Txs = []MempoolTx{}
+	s.vm.mempool.Iterate(func(tx *txs.Tx, fee uint64) bool {
+		reply.Txs = append(reply.Txs, MempoolTx{
+			TxID: tx.ID(),
+			Size: json.Uint32(len(tx.Bytes())),
+			Fee:  json.Uint64(fee),
+		})
+		return len(reply.Txs) < limit
+	})
+	return nil
+}
+
+// GetMempoolStatsReply is the response from GetMempoolStats
+type GetMempoolStatsReply struct {
+	NumTxs         json.Uint32 `json:"numTxs"`
+	Bytes          json.Uint32 `json:"bytes"`
+	BytesAvailable json.Uint32 `json:"bytesAvailable"`
+}
+
+// GetMempoolStats returns a summary of the contents of the mempool
+func (s *Service) GetMempoolStats(_ *http.Request, _ *struct{}, reply *GetMempoolStatsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getMempoolStats"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.mempool == nil {
+		return errNotLinearized
+	}
+
+	stats := s.vm.mempool.Stats()
+	reply.NumTxs = json.Uint32(stats.NumTxs)
+	reply.Bytes = json.Uint32(stats.Bytes)
+	reply.BytesAvailable = json.Uint32(stats.BytesAvailable)
+	return nil
+}
+
+// GetFeeConversionReply is the response from GetFeeConversion
+type GetFeeConversionReply struct {
+	// Enabled is false if fees can only be paid in the fee asset
+	Enabled bool `json:"enabled"`
+	// ActivationTime is the unix time from which fees may be converted
+	ActivationTime json.Uint64 `json:"activationTime"`
+	// AssetID is the asset that fees may be paid in
+	AssetID ids.ID `json:"assetID"`
+	// The oracle is authorized to publish the conversion rate
+	OracleLocktime  json.Uint64 `json:"oracleLocktime"`
+	OracleThreshold json.Uint32 `json:"oracleThreshold"`
+	OracleAddresses []string    `json:"oracleAddresses"`
+	// Rate is the most recently published conversion rate. If nil, no rate
+	// has been published.
+	Rate *txs.FeeRate `json:"rate"`
+}
+
+// GetFeeConversion returns how fees may be paid in an asset other than the
+// fee asset
+func (s *Service) GetFeeConversion(_ *http.Request, _ *struct{}, reply *GetFeeConversionReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getFeeConversion"),
+	)
+
+	conversion := s.vm.Config.FeeConversion
+	if conversion == nil {
+		return nil
+	}
+
+	reply.Enabled = true
+	reply.ActivationTime = json.Uint64(conversion.ActivationTime.Unix())
+	reply.AssetID = conversion.AssetID
+	reply.OracleLocktime = json.Uint64(conversion.Oracle.Locktime)
+	reply.OracleThreshold = json.Uint32(conversion.Oracle.Threshold)
+	reply.OracleAddresses = make([]string, len(conversion.Oracle.Addrs))
+	for i, addr := range conversion.Oracle.Addrs {
+		addrStr, err := s.vm.FormatLocalAddress(addr)
+		if err != nil {
+			return err
+		}
+		reply.OracleAddresses[i] = addrStr
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	rate, err := s.vm.state.GetFeeRate()
+	switch {
+	case err == database.ErrNotFound:
+		return nil
+	case err != nil:
+		return err
+	}
+	reply.Rate = rate
+	return nil
+}
+
+// GetTxGraphArgs are the arguments for GetTxGraph
+type GetTxGraphArgs struct {
+	Txs      []string            `json:"txs"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// TxGraphNode describes how a tx relates to the other provided txs
+type TxGraphNode struct {
+	TxID ids.ID `json:"txID"`
+	// Dependencies are the txs that must be accepted before this tx
+	Dependencies []ids.ID `json:"dependencies"`
+	// Conflicts are the txs that consume at least one of the same UTXOs as
+	// this tx
+	Conflicts []ids.ID `json:"conflicts"`
+}
+
+// GetTxGraphReply is the response from GetTxGraph
+type GetTxGraphReply struct {
+	// Txs are sorted by ID
+	Txs []TxGraphNode `json:"txs"`
+	// Order is an order the txs can be issued in such that every tx is
+	// issued after its dependencies
+	Order []ids.ID `json:"order"`
+}
+
+// GetTxGraph returns the dependencies and conflicts between the provided txs,
+// which allows block builders to pack non-conflicting txs. The txs aren't
+// verified.
+func (s *Service) GetTxGraph(_ *http.Request, args *GetTxGraphArgs, reply *GetTxGraphReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getTxGraph"),
+		zap.Int("numTxs", len(args.Txs)),
+	)
+
+	if uint64(len(args.Txs)) > maxPageSize {
+		return fmt.Errorf("%w: %d > %d", errTooManyTxs, len(args.Txs), maxPageSize)
+	}
+
+	parsedTxs := make([]*txs.Tx, len(args.Txs))
+	for i, txStr := range args.Txs {
+		txBytes, err := formatting.Decode(args.Encoding, txStr)
+		if err != nil {
+			return fmt.Errorf("problem decoding transaction %d: %w", i, err)
+		}
+		parsedTxs[i], err = s.vm.parser.ParseTx(txBytes)
+		if err != nil {
+			return fmt.Errorf("problem parsing transaction %d: %w", i, err)
+		}
+	}
+
+	graph := txs.NewGraph(parsedTxs)
+	order, err := graph.TopologicalOrder()
+	if err != nil {
+		return fmt.Errorf("couldn't order transactions: %w", err)
+	}
+
+	txIDs := maps.Keys(graph.Dependencies)
+	utils.Sort(txIDs)
+	reply.Txs = make([]TxGraphNode, len(txIDs))
+	for i, txID := range txIDs {
+		dependencyIDs := graph.Dependencies[txID].List()
+		utils.Sort(dependencyIDs)
+		conflictIDs := graph.Conflicts[txID].List()
+		utils.Sort(conflictIDs)
+		reply.Txs[i] = TxGraphNode{
+			TxID:         txID,
+			Dependencies: dependencyIDs,
+			Conflicts:    conflictIDs,
+		}
+	}
+	reply.Order = order
+	return nil
+}
+
 // GetTxStatusReply defines the GetTxStatus replies returned from the API
 type GetTxStatusReply struct {
 	Status choices.Status `json:"status"`
@@ -482,6 +781,11 @@ type GetAssetDescriptionReply struct {
 	Name         string     `json:"name"`
 	Symbol       string     `json:"symbol"`
 	Denomination json.Uint8 `json:"denomination"`
+	// MaxSupply and Issued are only set if the asset has a max supply
+	MaxSupply *json.Uint64 `json:"maxSupply,omitempty"`
+	Issued    *json.Uint64 `json:"issued,omitempty"`
+	// URI is only set if the asset's metadata was updated on-chain
+	URI string `json:"uri,omitempty"`
 }
 
 // GetAssetDescription creates an empty account with the name passed in
@@ -504,7 +808,7 @@ func (s *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescription
 	if err != nil {
 		return err
 	}
-	createAssetTx, ok := tx.Unsigned.(*txs.CreateAssetTx)
+	createAssetTx, ok := txs.GetCreateAssetTx(tx.Unsigned)
 	if !ok {
 		return errTxNotCreateAsset
 	}
@@ -514,6 +818,31 @@ func (s *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescription
 	reply.Symbol = createAssetTx.Symbol
 	reply.Denomination = json.Uint8(createAssetTx.Denomination)
 
+	supply, err := s.vm.state.GetAssetSupply(assetID)
+	switch err {
+	case nil:
+		maxSupply := json.Uint64(supply.MaxSupply)
+		issued := json.Uint64(supply.Issued)
+		reply.MaxSupply = &maxSupply
+		reply.Issued = &issued
+	case database.ErrNotFound:
+		// The asset doesn't have a max supply.
+	default:
+		return err
+	}
+
+	metadata, err := s.vm.state.GetAssetMetadata(assetID)
+	switch err {
+	case nil:
+		reply.Name = metadata.Name
+		reply.Symbol = metadata.Symbol
+		reply.Denomination = json.Uint8(metadata.Denomination)
+		reply.URI = metadata.URI
+	case database.ErrNotFound:
+		// The asset's metadata was never updated.
+	default:
+		return err
+	}
 	return nil
 }
 
@@ -671,6 +1000,184 @@ func (s *Service) GetAllBalances(_ *http.Request, args *GetAllBalancesArgs, repl
 	return nil
 }
 
+// GetSpendabilityArgs are arguments for calling GetSpendability
+type GetSpendabilityArgs struct {
+	api.JSONAddresses
+	// If provided, only the spendability of these assets is reported
+	AssetIDs []string `json:"assetIDs"`
+}
+
+// LockedBalance is the amount of an asset that is locked until [Locktime]
+type LockedBalance struct {
+	Locktime json.Uint64 `json:"locktime"`
+	Amount   json.Uint64 `json:"amount"`
+}
+
+// AssetSpendability describes how much of an asset a set of addresses can
+// spend
+type AssetSpendability struct {
+	AssetID string `json:"asset"`
+	// Spendable is the amount that the addresses can spend now
+	Spendable json.Uint64 `json:"spendable"`
+	// Locked is the amount that the addresses will be able to spend once the
+	// locktime of the outputs has passed
+	Locked json.Uint64 `json:"locked"`
+	// LockedByLocktime is the breakdown of [Locked] by locktime, in ascending
+	// order of locktime
+	LockedByLocktime []LockedBalance `json:"lockedByLocktime"`
+	// PartiallyOwned is the amount that the addresses can't spend without
+	// signatures from addresses outside of the set
+	PartiallyOwned json.Uint64 `json:"partiallyOwned"`
+}
+
+// GetSpendabilityReply is the response from a call to GetSpendability
+type GetSpendabilityReply struct {
+	// Timestamp is the chain time the locktimes were compared against
+	Timestamp json.Uint64         `json:"timestamp"`
+	Assets    []AssetSpendability `json:"assets"`
+}
+
+// GetSpendability reports, for each asset held by [args.Addresses], how much
+// the addresses can spend now, how much is locked and until when, and how much
+// requires signatures from addresses outside of [args.Addresses].
+//
+// An output can be spent by the addresses if at least its threshold of owners
+// are in [args.Addresses] and its locktime isn't after the current chain time.
+func (s *Service) GetSpendability(_ *http.Request, args *GetSpendabilityArgs, reply *GetSpendabilityReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getSpendability"),
+		logging.UserStrings("addresses", args.Addresses),
+	)
+
+	if len(args.Addresses) == 0 {
+		return errNoAddresses
+	}
+	if len(args.Addresses) > maxGetUTXOsAddrs {
+		return fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(args.Addresses), maxGetUTXOsAddrs)
+	}
+
+	addrSet, err := avax.ParseServiceAddresses(s.vm, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	assetIDFilter := set.NewSet[ids.ID](len(args.AssetIDs))
+	for _, assetIDStr := range args.AssetIDs {
+		assetID, err := s.vm.lookupAssetID(assetIDStr)
+		if err != nil {
+			return err
+		}
+		assetIDFilter.Add(assetID)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	utxos, err := avax.GetAllUTXOs(s.vm.state, addrSet)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses' UTXOs: %w", err)
+	}
+
+	now := s.vm.clock.Unix()
+	spendabilities := make(map[ids.ID]*spendability)
+	for _, utxo := range utxos {
+		// TODO make this not specific to *secp256k1fx.TransferOutput
+		transferable, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+		assetID := utxo.AssetID()
+		if assetIDFilter.Len() > 0 && !assetIDFilter.Contains(assetID) {
+			continue
+		}
+
+		assetSpendability, ok := spendabilities[assetID]
+		if !ok {
+			assetSpendability = &spendability{
+				lockedByLocktime: make(map[uint64]uint64),
+			}
+			spendabilities[assetID] = assetSpendability
+		}
+		if err := assetSpendability.add(transferable, addrSet, now); err != nil {
+			return err
+		}
+	}
+
+	assetIDs := maps.Keys(spendabilities)
+	utils.Sort(assetIDs)
+
+	reply.Timestamp = json.Uint64(now)
+	reply.Assets = make([]AssetSpendability, len(assetIDs))
+	for i, assetID := range assetIDs {
+		reply.Assets[i] = spendabilities[assetID].toAPI(s.vm.PrimaryAliasOrDefault(assetID))
+	}
+	return nil
+}
+
+// GetUTXOProofArgs are arguments for calling GetUTXOProof
+type GetUTXOProofArgs struct {
+	api.JSONAddress
+	UTXOID   ids.ID              `json:"utxoID"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetUTXOProofReply is the response from calling GetUTXOProof
+type GetUTXOProofReply struct {
+	// UTXO is the encoded bytes of the UTXO
+	UTXO     string              `json:"utxo"`
+	Encoding formatting.Encoding `json:"encoding"`
+	// Index of the UTXO among the UTXOs produced for the address
+	Index json.Uint64 `json:"index"`
+	// NumUTXOs is the number of UTXOs produced for the address
+	NumUTXOs json.Uint64 `json:"numUTXOs"`
+	// Path and Peaks prove that the UTXO is included in [Root]
+	Path  []ids.ID `json:"path"`
+	Peaks []ids.ID `json:"peaks"`
+	// Root commits to every UTXO produced for the address
+	Root ids.ID `json:"root"`
+}
+
+// GetUTXOProof returns a UTXO produced for an address along with a proof that
+// it is included in the root that commits to every UTXO produced for the
+// address. UTXOs can be proven after they are consumed.
+//
+// The root only depends on the accepted transactions, so a client that doesn't
+// trust this node can compare it to the root reported by other nodes.
+func (s *Service) GetUTXOProof(_ *http.Request, args *GetUTXOProofArgs, reply *GetUTXOProofReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getUTXOProof"),
+		logging.UserString("address", args.Address),
+		zap.Stringer("utxoID", args.UTXOID),
+	)
+
+	address, err := avax.ParseServiceAddress(s.vm, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	utxoBytes, proof, root, err := s.vm.utxoIndexer.GetProof(address[:], args.UTXOID)
+	if err != nil {
+		return err
+	}
+
+	reply.UTXO, err = formatting.Encode(args.Encoding, utxoBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't encode UTXO %s as %s: %w", args.UTXOID, args.Encoding, err)
+	}
+	reply.Encoding = args.Encoding
+	reply.Index = json.Uint64(proof.Index)
+	reply.NumUTXOs = json.Uint64(proof.NumUTXOs)
+	reply.Path = proof.Path
+	reply.Peaks = proof.Peaks
+	reply.Root = root
+	return nil
+}
+
 // Holder describes how much an address owns of an asset
 type Holder struct {
 	Amount  json.Uint64 `json:"amount"`
@@ -691,6 +1198,9 @@ type CreateAssetArgs struct {
 	Denomination        byte      `json:"denomination"`
 	InitialHolders      []*Holder `json:"initialHolders"`
 	MinterSets          []Owners  `json:"minterSets"`
+	// If non-zero, the maximum amount of the asset that may ever be issued,
+	// including the initial holdings
+	MaxSupply json.Uint64 `json:"maxSupply"`
 }
 
 // AssetIDChangeAddr is an asset ID and a change address
@@ -798,7 +1308,7 @@ func (s *Service) CreateAsset(_ *http.Request, args *CreateAssetArgs, reply *Ass
 	}
 	initialState.Sort(s.vm.parser.Codec())
 
-	tx := txs.Tx{Unsigned: &txs.CreateAssetTx{
+	createAssetTx := txs.CreateAssetTx{
 		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    s.vm.ctx.NetworkID,
 			BlockchainID: s.vm.ctx.ChainID,
@@ -809,7 +1319,14 @@ func (s *Service) CreateAsset(_ *http.Request, args *CreateAssetArgs, reply *Ass
 		Symbol:       args.Symbol,
 		Denomination: args.Denomination,
 		States:       []*txs.InitialState{initialState},
-	}}
+	}
+	tx := txs.Tx{Unsigned: &createAssetTx}
+	if args.MaxSupply != 0 {
+		tx.Unsigned = &txs.CreateCappedAssetTx{
+			CreateAssetTx: createAssetTx,
+			MaxSupply:     uint64(args.MaxSupply),
+		}
+	}
 	if err := tx.SignSECP256K1Fx(s.vm.parser.Codec(), keys); err != nil {
 		return err
 	}
@@ -1164,6 +1681,22 @@ type SendMultipleArgs struct {
 	Memo string `json:"memo"`
 }
 
+// Consolidate returns a chain of signed txs that merge the user's UTXOs of an
+// asset into a single UTXO.
+func (s *Service) Consolidate(_ *http.Request, args *ConsolidateArgs, reply *ConsolidateReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "consolidate"),
+		logging.UserString("username", args.Username),
+		logging.UserString("assetID", args.AssetID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	return s.vm.consolidate(args, nil, reply)
+}
+
 // Send returns the ID of the newly created transaction
 func (s *Service) Send(r *http.Request, args *SendArgs, reply *api.JSONTxIDChangeAddr) error {
 	return s.SendMultiple(r, &SendMultipleArgs{
@@ -1727,73 +2260,11 @@ func (s *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSONTxID)
 		return fmt.Errorf("problem retrieving user's atomic UTXOs: %w", err)
 	}
 
-	amountsSpent, importInputs, importKeys, err := s.vm.SpendAll(atomicUTXOs, kc)
+	tx, err := s.vm.buildImportTx(chainID, atomicUTXOs, utxos, kc, to)
 	if err != nil {
 		return err
 	}
 
-	ins := []*avax.TransferableInput{}
-	keys := [][]*secp256k1.PrivateKey{}
-
-	if amountSpent := amountsSpent[s.vm.feeAssetID]; amountSpent < s.vm.TxFee {
-		var localAmountsSpent map[ids.ID]uint64
-		localAmountsSpent, ins, keys, err = s.vm.Spend(
-			utxos,
-			kc,
-			map[ids.ID]uint64{
-				s.vm.feeAssetID: s.vm.TxFee - amountSpent,
-			},
-		)
-		if err != nil {
-			return err
-		}
-		for asset, amount := range localAmountsSpent {
-			newAmount, err := safemath.Add64(amountsSpent[asset], amount)
-			if err != nil {
-				return fmt.Errorf("problem calculating required spend amount: %w", err)
-			}
-			amountsSpent[asset] = newAmount
-		}
-	}
-
-	// Because we ensured that we had enough inputs for the fee, we can
-	// safely just remove it without concern for underflow.
-	amountsSpent[s.vm.feeAssetID] -= s.vm.TxFee
-
-	keys = append(keys, importKeys...)
-
-	outs := []*avax.TransferableOutput{}
-	for assetID, amount := range amountsSpent {
-		if amount > 0 {
-			outs = append(outs, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: assetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: amount,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{to},
-					},
-				},
-			})
-		}
-	}
-	avax.SortTransferableOutputs(outs, s.vm.parser.Codec())
-
-	tx := txs.Tx{Unsigned: &txs.ImportTx{
-		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
-			NetworkID:    s.vm.ctx.NetworkID,
-			BlockchainID: s.vm.ctx.ChainID,
-			Outs:         outs,
-			Ins:          ins,
-		}},
-		SourceChain: chainID,
-		ImportedIns: importInputs,
-	}}
-	if err := tx.SignSECP256K1Fx(s.vm.parser.Codec(), keys); err != nil {
-		return err
-	}
-
 	txID, err := s.vm.IssueTx(tx.Bytes())
 	if err != nil {
 		return fmt.Errorf("problem issuing transaction: %w", err)
@@ -1803,6 +2274,22 @@ func (s *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSONTxID)
 	return nil
 }
 
+// BulkImport imports the user's atomic UTXOs from the P/C-Chain in batches,
+// issuing a tx per batch. Large imports can be split across multiple requests
+// by resuming from the returned EndIndex.
+func (s *Service) BulkImport(_ *http.Request, args *BulkImportArgs, reply *BulkImportReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "bulkImport"),
+		logging.UserString("username", args.Username),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	return s.vm.bulkImport(args, reply)
+}
+
 // ExportArgs are arguments for passing into ExportAVA requests
 type ExportArgs struct {
 	// User, password, from addrs, change addr
@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
@@ -44,6 +45,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	txexecutor "github.com/ava-labs/avalanchego/vms/avm/txs/executor"
 )
 
 func TestServiceIssueTx(t *testing.T) {
@@ -58,7 +61,7 @@ func TestServiceIssueTx(t *testing.T) {
 		env.vm.ctx.Lock.Unlock()
 	}()
 
-	txArgs := &api.FormattedTx{}
+	txArgs := &IssueTxArgs{}
 	txReply := &api.JSONTxID{}
 	err := env.service.IssueTx(nil, txArgs, txReply)
 	require.ErrorIs(err, codec.ErrCantUnpackVersion)
@@ -72,6 +75,192 @@ func TestServiceIssueTx(t *testing.T) {
 	require.Equal(tx.ID(), txReply.TxID)
 }
 
+func TestServiceDryRunTx(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	tx := newTx(t, env.genesisBytes, env.vm, "AVAX")
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+	reply := &DryRunTxReply{}
+	require.NoError(env.service.DryRunTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, reply))
+	require.Equal(tx.ID(), reply.TxID)
+	require.True(reply.Valid)
+	require.Nil(reply.FailedInput)
+
+	// Sign the same transaction with a key that doesn't own the input
+	badTx := &txs.Tx{Unsigned: tx.Unsigned}
+	require.NoError(badTx.SignSECP256K1Fx(env.vm.parser.Codec(), [][]*secp256k1.PrivateKey{{keys[1]}}))
+	txStr, err = formatting.Encode(formatting.Hex, badTx.Bytes())
+	require.NoError(err)
+	reply = &DryRunTxReply{}
+	require.NoError(env.service.DryRunTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, reply))
+	require.Equal(badTx.ID(), reply.TxID)
+	require.False(reply.Valid)
+	require.NotEmpty(reply.Error)
+	require.Equal(&DryRunInputError{
+		Kind:            string(txexecutor.InputKind),
+		Index:           0,
+		CredentialIndex: 0,
+	}, reply.FailedInput)
+
+	// Dry running a transaction must not issue it
+	_, err = env.vm.state.GetTx(tx.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestServiceGetTxGraph(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	// tx0 and tx1 consume the same UTXO
+	tx0 := newTx(t, env.genesisBytes, env.vm, "AVAX")
+	tx1 := &txs.Tx{Unsigned: tx0.Unsigned}
+	require.NoError(tx1.SignSECP256K1Fx(env.vm.parser.Codec(), [][]*secp256k1.PrivateKey{{keys[1]}}))
+
+	// tx2 consumes a UTXO produced by tx0
+	tx2 := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: env.vm.ctx.XChainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{TxID: tx0.ID()},
+			Asset:  avax.Asset{ID: env.genesisTx.ID()},
+			In: &secp256k1fx.TransferInput{
+				Amt: startBalance,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+	}}}
+	require.NoError(tx2.SignSECP256K1Fx(env.vm.parser.Codec(), [][]*secp256k1.PrivateKey{{keys[0]}}))
+
+	args := &GetTxGraphArgs{Encoding: formatting.Hex}
+	for _, tx := range []*txs.Tx{tx2, tx1, tx0} {
+		txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+		require.NoError(err)
+		args.Txs = append(args.Txs, txStr)
+	}
+
+	reply := &GetTxGraphReply{}
+	require.NoError(env.service.GetTxGraph(nil, args, reply))
+
+	nodes := map[ids.ID]TxGraphNode{}
+	for _, node := range reply.Txs {
+		nodes[node.TxID] = node
+	}
+	require.Len(nodes, 3)
+	require.Equal([]ids.ID{}, nodes[tx0.ID()].Dependencies)
+	require.Equal([]ids.ID{tx1.ID()}, nodes[tx0.ID()].Conflicts)
+	require.Equal([]ids.ID{tx0.ID()}, nodes[tx1.ID()].Conflicts)
+	require.Equal([]ids.ID{tx0.ID()}, nodes[tx2.ID()].Dependencies)
+	require.Equal([]ids.ID{}, nodes[tx2.ID()].Conflicts)
+
+	require.Len(reply.Order, 3)
+	require.Equal(tx2.ID(), reply.Order[2])
+}
+
+func TestServiceGetMempool(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	statsReply := &GetMempoolStatsReply{}
+	require.NoError(env.service.GetMempoolStats(nil, nil, statsReply))
+	require.Zero(statsReply.NumTxs)
+
+	tx := newTx(t, env.genesisBytes, env.vm, "AVAX")
+	env.vm.ctx.Lock.Lock()
+	_, err := env.vm.IssueTx(tx.Bytes())
+	env.vm.ctx.Lock.Unlock()
+	require.NoError(err)
+
+	reply := &GetMempoolReply{}
+	require.NoError(env.service.GetMempool(nil, &GetMempoolArgs{}, reply))
+	require.Equal(
+		[]MempoolTx{{
+			TxID: tx.ID(),
+			Size: json.Uint32(len(tx.Bytes())),
+			Fee:  json.Uint64(startBalance),
+		}},
+		reply.Txs,
+	)
+
+	statsReply = &GetMempoolStatsReply{}
+	require.NoError(env.service.GetMempoolStats(nil, nil, statsReply))
+	require.Equal(json.Uint32(1), statsReply.NumTxs)
+	require.Equal(json.Uint32(len(tx.Bytes())), statsReply.Bytes)
+}
+
+func TestServiceGetFeeConversion(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := &GetFeeConversionReply{}
+	require.NoError(env.service.GetFeeConversion(nil, nil, reply))
+	require.False(reply.Enabled)
+
+	oracleAddr := keys[0].Address()
+	conversion := &config.FeeConversion{
+		ActivationTime: time.Unix(1_700_000_000, 0),
+		AssetID:        ids.GenerateTestID(),
+		Oracle: &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{oracleAddr},
+		},
+	}
+	env.vm.Config.FeeConversion = conversion
+
+	reply = &GetFeeConversionReply{}
+	require.NoError(env.service.GetFeeConversion(nil, nil, reply))
+	oracleAddrStr, err := env.vm.FormatLocalAddress(oracleAddr)
+	require.NoError(err)
+	require.Equal(&GetFeeConversionReply{
+		Enabled:         true,
+		ActivationTime:  json.Uint64(conversion.ActivationTime.Unix()),
+		AssetID:         conversion.AssetID,
+		OracleThreshold: 1,
+		OracleAddresses: []string{oracleAddrStr},
+	}, reply)
+}
+
 func TestServiceGetTxStatus(t *testing.T) {
 	require := require.New(t)
 
@@ -309,6 +498,68 @@ func TestServiceGetTxs(t *testing.T) {
 	require.Equal(getTxsReply.TxIDs, testTxs[10:20])
 }
 
+func TestServiceGetUTXOProof(t *testing.T) {
+	require := require.New(t)
+	env := setup(t, &envConfig{})
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	addr := ids.GenerateTestShortID()
+	addrStr, err := env.vm.FormatLocalAddress(addr)
+	require.NoError(err)
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: avax.Asset{ID: env.genesisTx.ID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+	args := &GetUTXOProofArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+		UTXOID:      utxo.InputID(),
+		Encoding:    formatting.Hex,
+	}
+
+	// Proofs aren't available unless the UTXOs are indexed
+	require.NoError(env.vm.utxoIndexer.Accept([]*avax.UTXO{utxo}))
+	env.vm.ctx.Lock.Unlock()
+	err = env.service.GetUTXOProof(nil, args, &GetUTXOProofReply{})
+	require.ErrorIs(err, index.ErrUTXOProofIndexDisabled)
+	env.vm.ctx.Lock.Lock()
+
+	env.vm.utxoIndexer, err = index.NewUTXOIndexer(prefixdb.New(utxoIndexPrefix, env.vm.db), env.vm.parser.Codec(), txs.CodecVersion, true)
+	require.NoError(err)
+	require.NoError(env.vm.utxoIndexer.Accept([]*avax.UTXO{utxo}))
+	env.vm.ctx.Lock.Unlock()
+
+	reply := &GetUTXOProofReply{}
+	require.NoError(env.service.GetUTXOProof(nil, args, reply))
+
+	utxoBytes, err := formatting.Decode(reply.Encoding, reply.UTXO)
+	require.NoError(err)
+	expectedUTXOBytes, err := env.vm.parser.Codec().Marshal(txs.CodecVersion, utxo)
+	require.NoError(err)
+	require.Equal(expectedUTXOBytes, utxoBytes)
+
+	proof := &index.UTXOProof{
+		Index:    uint64(reply.Index),
+		NumUTXOs: uint64(reply.NumUTXOs),
+		Path:     reply.Path,
+		Peaks:    reply.Peaks,
+	}
+	require.NoError(proof.Verify(reply.Root, utxoBytes))
+}
+
 func TestServiceGetAllBalances(t *testing.T) {
 	require := require.New(t)
 
@@ -507,6 +758,106 @@ func TestServiceGetAllBalances(t *testing.T) {
 	require.Empty(reply.Balances)
 }
 
+func TestServiceGetSpendability(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	now := time.Unix(1_000_000, 0)
+	env.vm.clock.Set(now)
+	unixNow := uint64(now.Unix())
+
+	assetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+	addrStr, err := env.vm.FormatLocalAddress(addr)
+	require.NoError(err)
+
+	newUTXO := func(assetID ids.ID, amount uint64, locktime uint64, threshold uint32, addrs ...ids.ShortID) *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        ids.GenerateTestID(),
+				OutputIndex: 0,
+			},
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  locktime,
+					Threshold: threshold,
+					Addrs:     addrs,
+				},
+			},
+		}
+	}
+	utxos := []*avax.UTXO{
+		// Spendable: the locktime has passed
+		newUTXO(assetID, 100, unixNow, 1, addr),
+		// Spendable: only one of the owners is needed
+		newUTXO(assetID, 10, 0, 1, addr, ids.GenerateTestShortID()),
+		// Locked until now + 10
+		newUTXO(assetID, 200, unixNow+10, 1, addr),
+		newUTXO(assetID, 50, unixNow+10, 1, addr),
+		// Locked until now + 20
+		newUTXO(assetID, 30, unixNow+20, 1, addr),
+		// Partially owned, regardless of the locktime
+		newUTXO(assetID, 7, unixNow+30, 2, addr, ids.GenerateTestShortID()),
+		newUTXO(otherAssetID, 5, 0, 1, addr),
+	}
+	for _, utxo := range utxos {
+		env.vm.state.AddUTXO(utxo)
+	}
+	require.NoError(env.vm.state.Commit())
+
+	env.vm.ctx.Lock.Unlock()
+
+	expectedAssetSpendability := AssetSpendability{
+		AssetID:   assetID.String(),
+		Spendable: 110,
+		Locked:    280,
+		LockedByLocktime: []LockedBalance{
+			{
+				Locktime: json.Uint64(unixNow + 10),
+				Amount:   250,
+			},
+			{
+				Locktime: json.Uint64(unixNow + 20),
+				Amount:   30,
+			},
+		},
+		PartiallyOwned: 7,
+	}
+
+	reply := &GetSpendabilityReply{}
+	require.NoError(env.service.GetSpendability(nil, &GetSpendabilityArgs{
+		JSONAddresses: api.JSONAddresses{Addresses: []string{addrStr}},
+		AssetIDs:      []string{assetID.String()},
+	}, reply))
+	require.Equal(json.Uint64(unixNow), reply.Timestamp)
+	require.Equal([]AssetSpendability{expectedAssetSpendability}, reply.Assets)
+
+	// Without a filter, all assets held by the addresses are reported
+	reply = &GetSpendabilityReply{}
+	require.NoError(env.service.GetSpendability(nil, &GetSpendabilityArgs{
+		JSONAddresses: api.JSONAddresses{Addresses: []string{addrStr}},
+	}, reply))
+	require.Len(reply.Assets, 2)
+	require.Contains(reply.Assets, expectedAssetSpendability)
+	require.Contains(reply.Assets, AssetSpendability{
+		AssetID:          otherAssetID.String(),
+		Spendable:        5,
+		LockedByLocktime: []LockedBalance{},
+	})
+
+	err = env.service.GetSpendability(nil, &GetSpendabilityArgs{}, &GetSpendabilityReply{})
+	require.ErrorIs(err, errNoAddresses)
+}
+
 func TestServiceGetTx(t *testing.T) {
 	require := require.New(t)
 
@@ -1447,6 +1798,38 @@ func TestGetAssetDescription(t *testing.T) {
 
 	require.Equal("AVAX", reply.Name)
 	require.Equal("SYMB", reply.Symbol)
+	require.Empty(reply.URI)
+}
+
+func TestGetAssetDescriptionWithMetadata(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	avaxAssetID := env.genesisTx.ID()
+	env.vm.state.SetAssetMetadata(avaxAssetID, &state.AssetMetadata{
+		Name:         "Avalanche",
+		Symbol:       "AVAX",
+		Denomination: 9,
+		URI:          "https://example.com/avax.json",
+	})
+	require.NoError(env.vm.state.Commit())
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := GetAssetDescriptionReply{}
+	require.NoError(env.service.GetAssetDescription(nil, &GetAssetDescriptionArgs{
+		AssetID: avaxAssetID.String(),
+	}, &reply))
+
+	require.Equal("Avalanche", reply.Name)
+	require.Equal("AVAX", reply.Symbol)
+	require.Equal(json.Uint8(9), reply.Denomination)
+	require.Equal("https://example.com/avax.json", reply.URI)
 }
 
 func TestGetBalance(t *testing.T) {
@@ -2070,6 +2453,115 @@ func TestImport(t *testing.T) {
 	}
 }
 
+func TestBulkImport(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		keystoreUsers: []*user{{
+			username:    username,
+			password:    password,
+			initialKeys: keys,
+		}},
+	})
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := env.genesisTx.ID()
+	addr0 := keys[0].PublicKey().Address()
+
+	const numUTXOs = 5
+	elems := make([]*atomic.Element, numUTXOs)
+	for i := range elems {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 2 * env.vm.TxFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr0},
+				},
+			},
+		}
+		utxoBytes, err := env.vm.parser.Codec().Marshal(txs.CodecVersion, utxo)
+		require.NoError(err)
+
+		utxoID := utxo.InputID()
+		elems[i] = &atomic.Element{
+			Key:   utxoID[:],
+			Value: utxoBytes,
+			Traits: [][]byte{
+				addr0.Bytes(),
+			},
+		}
+	}
+	peerSharedMemory := env.sharedMemory.NewSharedMemory(constants.PlatformChainID)
+	require.NoError(peerSharedMemory.Apply(map[ids.ID]*atomic.Requests{
+		env.vm.ctx.ChainID: {
+			PutRequests: elems,
+		},
+	}))
+
+	env.vm.ctx.Lock.Unlock()
+
+	addrStr, err := env.vm.FormatLocalAddress(addr0)
+	require.NoError(err)
+	args := &BulkImportArgs{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		SourceChain: "P",
+		To:          addrStr,
+		BatchSize:   2,
+		MaxBatches:  2,
+	}
+
+	// The first request stops after [MaxBatches] batches
+	reply := &BulkImportReply{}
+	require.NoError(env.service.BulkImport(nil, args, reply))
+	require.Len(reply.Batches, 2)
+	for _, batch := range reply.Batches {
+		require.Empty(batch.Error)
+		require.NotEqual(ids.Empty, batch.TxID)
+		require.Equal(json.Uint32(2), batch.NumUTXOs)
+		require.Equal(json.Uint32(2), batch.NumImported)
+	}
+	require.Equal(json.Uint64(4), reply.NumImported)
+	require.Zero(reply.NumFailed)
+	require.False(reply.Done)
+
+	// The import resumes after the last processed UTXO
+	args.StartIndex = reply.EndIndex
+	reply = &BulkImportReply{}
+	require.NoError(env.service.BulkImport(nil, args, reply))
+	require.Len(reply.Batches, 1)
+	require.Equal(json.Uint32(1), reply.Batches[0].NumImported)
+	require.Equal(json.Uint64(1), reply.NumImported)
+	require.True(reply.Done)
+
+	// Batches spending UTXOs that are already being imported fail without
+	// preventing the request from completing
+	args.StartIndex = api.Index{}
+	args.BatchSize = numUTXOs
+	reply = &BulkImportReply{}
+	require.NoError(env.service.BulkImport(nil, args, reply))
+	require.Len(reply.Batches, 1)
+	require.Equal(ids.Empty, reply.Batches[0].TxID)
+	require.Equal(json.Uint32(numUTXOs), reply.Batches[0].NumUTXOs)
+	require.NotEmpty(reply.Batches[0].Error)
+	require.Zero(reply.NumImported)
+	require.Equal(json.Uint32(1), reply.NumFailed)
+	require.True(reply.Done)
+
+	args.BatchSize = json.Uint32(maxPageSize + 1)
+	err = env.service.BulkImport(nil, args, &BulkImportReply{})
+	require.ErrorIs(err, errInvalidImportBatchSize)
+}
+
 func TestServiceGetBlock(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
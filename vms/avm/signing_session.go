@@ -0,0 +1,395 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// maxSigningSessions is the maximum number of signing sessions that are kept.
+// Once exceeded, the least recently used session is dropped.
+const maxSigningSessions = 1024
+
+var (
+	errUnsupportedSigningTx      = errors.New("tx type doesn't support signing sessions")
+	errUnsupportedSigningInput   = errors.New("input isn't a secp256k1fx transfer input")
+	errUnsupportedSigningOutput  = errors.New("UTXO isn't a secp256k1fx transfer output")
+	errSignatureIndexOutOfBounds = errors.New("signature index references a missing address")
+	errUnknownSigningSession     = errors.New("unknown signing session")
+	errUnknownSignatureSlot      = errors.New("unknown signature slot")
+	errInvalidSignatureLength    = errors.New("invalid signature length")
+	errWrongSigner               = errors.New("signature wasn't produced by the slot's address")
+	errSigningSessionIncomplete  = errors.New("signing session is missing signatures")
+)
+
+// signingSession collects the signatures of an unsigned tx from multiple
+// parties until every signature the tx's credentials require is provided.
+type signingSession struct {
+	utx txs.UnsignedTx
+	// hash of the unsigned tx bytes, which is what the signatures sign
+	hash []byte
+	// signers[credIndex][sigIndex] is the address that must produce the
+	// signature at [sigIndex] of the credential at [credIndex]
+	signers [][]ids.ShortID
+	// sigs[credIndex][sigIndex] is the provided signature, if any
+	sigs [][][secp256k1.SignatureLen]byte
+	// signed[credIndex][sigIndex] is true if the signature was provided
+	signed [][]bool
+}
+
+// newSigningSession creates a session to sign [tx]. Only txs whose credentials
+// are all secp256k1fx credentials spending secp256k1fx transfer outputs are
+// supported.
+//
+// vm.ctx.Lock should be held
+func (vm *VM) newSigningSession(tx *txs.Tx) (*signingSession, error) {
+	var (
+		ins         []*avax.TransferableInput
+		importedIns []*avax.TransferableInput
+		sourceChain ids.ID
+	)
+	switch utx := tx.Unsigned.(type) {
+	case *txs.BaseTx:
+		ins = utx.Ins
+	case *txs.CreateAssetTx:
+		ins = utx.Ins
+	case *txs.ExportTx:
+		ins = utx.Ins
+	case *txs.ImportTx:
+		ins = utx.Ins
+		importedIns = utx.ImportedIns
+		sourceChain = utx.SourceChain
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedSigningTx, tx.Unsigned)
+	}
+
+	utxos := make([]*avax.UTXO, 0, len(ins)+len(importedIns))
+	for _, in := range ins {
+		utxo, err := vm.state.GetUTXO(in.InputID())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get UTXO %s: %w", in.InputID(), err)
+		}
+		utxos = append(utxos, utxo)
+	}
+	if len(importedIns) > 0 {
+		utxoIDs := make([][]byte, len(importedIns))
+		for i, in := range importedIns {
+			inputID := in.InputID()
+			utxoIDs[i] = inputID[:]
+		}
+		allUTXOBytes, err := vm.ctx.SharedMemory.Get(sourceChain, utxoIDs)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get imported UTXOs: %w", err)
+		}
+		for _, utxoBytes := range allUTXOBytes {
+			utxo := &avax.UTXO{}
+			if _, err := vm.parser.Codec().Unmarshal(utxoBytes, utxo); err != nil {
+				return nil, fmt.Errorf("couldn't parse imported UTXO: %w", err)
+			}
+			utxos = append(utxos, utxo)
+		}
+	}
+
+	allIns := make([]*avax.TransferableInput, 0, len(utxos))
+	allIns = append(allIns, ins...)
+	allIns = append(allIns, importedIns...)
+
+	session := &signingSession{
+		utx:     tx.Unsigned,
+		hash:    hashing.ComputeHash256(tx.Unsigned.Bytes()),
+		signers: make([][]ids.ShortID, len(allIns)),
+		sigs:    make([][][secp256k1.SignatureLen]byte, len(allIns)),
+		signed:  make([][]bool, len(allIns)),
+	}
+	for i, in := range allIns {
+		input, ok := in.In.(*secp256k1fx.TransferInput)
+		if !ok {
+			return nil, fmt.Errorf("%w: input %d", errUnsupportedSigningInput, i)
+		}
+		out, ok := utxos[i].Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return nil, fmt.Errorf("%w: input %d", errUnsupportedSigningOutput, i)
+		}
+
+		signers := make([]ids.ShortID, len(input.SigIndices))
+		for j, addrIndex := range input.SigIndices {
+			if int(addrIndex) >= len(out.Addrs) {
+				return nil, fmt.Errorf("%w: input %d", errSignatureIndexOutOfBounds, i)
+			}
+			signers[j] = out.Addrs[addrIndex]
+		}
+		session.signers[i] = signers
+		session.sigs[i] = make([][secp256k1.SignatureLen]byte, len(signers))
+		session.signed[i] = make([]bool, len(signers))
+	}
+	return session, nil
+}
+
+// VerifySignature verifies that [sig] was produced by the address of the
+// [sigIndex] signature of the [credIndex] credential.
+func (s *signingSession) VerifySignature(credIndex, sigIndex int, sig []byte) error {
+	if credIndex >= len(s.signers) || sigIndex >= len(s.signers[credIndex]) {
+		return fmt.Errorf("%w: credential %d signature %d", errUnknownSignatureSlot, credIndex, sigIndex)
+	}
+	if len(sig) != secp256k1.SignatureLen {
+		return fmt.Errorf("%w: credential %d signature %d has length %d", errInvalidSignatureLength, credIndex, sigIndex, len(sig))
+	}
+
+	pk, err := secp256k1.RecoverPublicKeyFromHash(s.hash, sig)
+	if err != nil {
+		return err
+	}
+	if pk.Address() != s.signers[credIndex][sigIndex] {
+		return fmt.Errorf("%w: credential %d signature %d", errWrongSigner, credIndex, sigIndex)
+	}
+	return nil
+}
+
+// SetSignature records [sig] as the [sigIndex] signature of the [credIndex]
+// credential.
+//
+// Invariant: [sig] was verified with VerifySignature.
+func (s *signingSession) SetSignature(credIndex, sigIndex int, sig []byte) {
+	copy(s.sigs[credIndex][sigIndex][:], sig)
+	s.signed[credIndex][sigIndex] = true
+}
+
+// Complete returns true if every signature has been provided.
+func (s *signingSession) Complete() bool {
+	for _, credSigned := range s.signed {
+		for _, signed := range credSigned {
+			if !signed {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SignedTx returns the tx with the provided signatures as its credentials.
+func (s *signingSession) SignedTx() *txs.Tx {
+	creds := make([]*fxs.FxCredential, len(s.sigs))
+	for i, sigs := range s.sigs {
+		creds[i] = &fxs.FxCredential{
+			Credential: &secp256k1fx.Credential{
+				Sigs: sigs,
+			},
+		}
+	}
+	return &txs.Tx{
+		Unsigned: s.utx,
+		Creds:    creds,
+	}
+}
+
+// SigningSlot is a signature that a signing session's tx requires
+type SigningSlot struct {
+	CredentialIndex json.Uint32 `json:"credentialIndex"`
+	SignatureIndex  json.Uint32 `json:"signatureIndex"`
+	// Address that must produce the signature
+	Address string `json:"address"`
+	// Signed is true if the signature has been provided
+	Signed bool `json:"signed"`
+}
+
+// SigningSessionReply describes the state of a signing session
+type SigningSessionReply struct {
+	SessionID ids.ID        `json:"sessionID"`
+	Slots     []SigningSlot `json:"slots"`
+	// Complete is true if every signature has been provided
+	Complete bool `json:"complete"`
+}
+
+// SigningSessionArgs are arguments that reference a signing session
+type SigningSessionArgs struct {
+	SessionID ids.ID `json:"sessionID"`
+}
+
+// CreateSigningSession starts collecting the signatures of an unsigned tx.
+// Any credentials included in the provided tx are ignored. The session ID is
+// derived from the unsigned tx, so creating a session for a tx that already
+// has one returns the existing session.
+func (s *Service) CreateSigningSession(_ *http.Request, args *api.FormattedTx, reply *SigningSessionReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "createSigningSession"),
+		logging.UserString("tx", args.Tx),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	tx, err := s.vm.parser.ParseTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing transaction: %w", err)
+	}
+
+	sessionID := hashing.ComputeHash256Array(tx.Unsigned.Bytes())
+	session, ok := s.vm.signingSessions.Get(sessionID)
+	if !ok {
+		session, err = s.vm.newSigningSession(tx)
+		if err != nil {
+			return err
+		}
+		s.vm.signingSessions.Put(sessionID, session)
+	}
+	return s.populateSigningSessionReply(sessionID, session, reply)
+}
+
+// GetSigningSession returns which signatures of a signing session have been
+// provided.
+func (s *Service) GetSigningSession(_ *http.Request, args *SigningSessionArgs, reply *SigningSessionReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getSigningSession"),
+		zap.Stringer("sessionID", args.SessionID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	session, ok := s.vm.signingSessions.Get(args.SessionID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownSigningSession, args.SessionID)
+	}
+	return s.populateSigningSessionReply(args.SessionID, session, reply)
+}
+
+// SigningSessionSignature is a signature of a signing session's unsigned tx
+type SigningSessionSignature struct {
+	CredentialIndex json.Uint32 `json:"credentialIndex"`
+	SignatureIndex  json.Uint32 `json:"signatureIndex"`
+	Signature       string      `json:"signature"`
+}
+
+// AddSigningSessionSignaturesArgs are arguments for passing into
+// AddSigningSessionSignatures requests
+type AddSigningSessionSignaturesArgs struct {
+	SessionID  ids.ID                    `json:"sessionID"`
+	Signatures []SigningSessionSignature `json:"signatures"`
+	// Encoding of the signatures
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// AddSigningSessionSignatures adds the partial credentials of a party to a
+// signing session. Each signature is verified to be produced by the address of
+// its slot. If any signature is invalid, none of the signatures are added.
+func (s *Service) AddSigningSessionSignatures(_ *http.Request, args *AddSigningSessionSignaturesArgs, reply *SigningSessionReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "addSigningSessionSignatures"),
+		zap.Stringer("sessionID", args.SessionID),
+		zap.Int("numSignatures", len(args.Signatures)),
+	)
+
+	sigs := make([][]byte, len(args.Signatures))
+	for i, sig := range args.Signatures {
+		sigBytes, err := formatting.Decode(args.Encoding, sig.Signature)
+		if err != nil {
+			return fmt.Errorf("problem decoding signature %d: %w", i, err)
+		}
+		sigs[i] = sigBytes
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	session, ok := s.vm.signingSessions.Get(args.SessionID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownSigningSession, args.SessionID)
+	}
+
+	// Verify every signature before recording any of them.
+	for i, sig := range args.Signatures {
+		if err := session.VerifySignature(int(sig.CredentialIndex), int(sig.SignatureIndex), sigs[i]); err != nil {
+			return err
+		}
+	}
+	for i, sig := range args.Signatures {
+		session.SetSignature(int(sig.CredentialIndex), int(sig.SignatureIndex), sigs[i])
+	}
+	return s.populateSigningSessionReply(args.SessionID, session, reply)
+}
+
+// IssueSigningSession assembles the credentials of a signing session whose
+// signatures have all been provided and issues the signed tx. The session is
+// removed once the tx is issued.
+func (s *Service) IssueSigningSession(_ *http.Request, args *SigningSessionArgs, reply *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "issueSigningSession"),
+		zap.Stringer("sessionID", args.SessionID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	session, ok := s.vm.signingSessions.Get(args.SessionID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownSigningSession, args.SessionID)
+	}
+	if !session.Complete() {
+		return fmt.Errorf("%w: %s", errSigningSessionIncomplete, args.SessionID)
+	}
+
+	tx := session.SignedTx()
+	if err := tx.Initialize(s.vm.parser.Codec()); err != nil {
+		return err
+	}
+
+	txID, err := s.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return err
+	}
+
+	s.vm.signingSessions.Evict(args.SessionID)
+	reply.TxID = txID
+	return nil
+}
+
+func (s *Service) populateSigningSessionReply(
+	sessionID ids.ID,
+	session *signingSession,
+	reply *SigningSessionReply,
+) error {
+	reply.SessionID = sessionID
+	reply.Slots = nil
+	for credIndex, signers := range session.signers {
+		for sigIndex, signer := range signers {
+			addr, err := s.vm.FormatLocalAddress(signer)
+			if err != nil {
+				return fmt.Errorf("problem formatting address: %w", err)
+			}
+			reply.Slots = append(reply.Slots, SigningSlot{
+				CredentialIndex: json.Uint32(credIndex),
+				SignatureIndex:  json.Uint32(sigIndex),
+				Address:         addr,
+				Signed:          session.signed[credIndex][sigIndex],
+			})
+		}
+	}
+	reply.Complete = session.Complete()
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestServiceSigningSession(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	// Fund a 2-of-2 multisig owned by keys[0] and keys[1]
+	signers := []*secp256k1.PrivateKey{keys[0], keys[1]}
+	if signers[1].Address().Less(signers[0].Address()) {
+		signers[0], signers[1] = signers[1], signers[0]
+	}
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: env.genesisTx.ID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: startBalance,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 2,
+				Addrs: []ids.ShortID{
+					signers[0].Address(),
+					signers[1].Address(),
+				},
+			},
+		},
+	}
+	env.vm.state.AddUTXO(utxo)
+	require.NoError(env.vm.state.Commit())
+	env.vm.ctx.Lock.Unlock()
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: utxo.UTXOID,
+				Asset:  utxo.Asset,
+				In: &secp256k1fx.TransferInput{
+					Amt: startBalance,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0, 1},
+					},
+				},
+			}},
+		},
+	}}
+	require.NoError(tx.Initialize(env.vm.parser.Codec()))
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	reply := &SigningSessionReply{}
+	require.NoError(env.service.CreateSigningSession(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, reply))
+	sessionID := reply.SessionID
+	require.Len(reply.Slots, 2)
+	require.False(reply.Complete)
+	for i, slot := range reply.Slots {
+		addr, err := env.vm.FormatLocalAddress(signers[i].Address())
+		require.NoError(err)
+		require.Equal(SigningSlot{
+			CredentialIndex: 0,
+			SignatureIndex:  json.Uint32(i),
+			Address:         addr,
+		}, slot)
+	}
+
+	issueArgs := &SigningSessionArgs{SessionID: sessionID}
+	err = env.service.IssueSigningSession(nil, issueArgs, &api.JSONTxID{})
+	require.ErrorIs(err, errSigningSessionIncomplete)
+
+	hash := hashing.ComputeHash256(tx.Unsigned.Bytes())
+	sign := func(key *secp256k1.PrivateKey, sigIndex uint32) *AddSigningSessionSignaturesArgs {
+		sig, err := key.SignHash(hash)
+		require.NoError(err)
+		sigStr, err := formatting.Encode(formatting.Hex, sig)
+		require.NoError(err)
+		return &AddSigningSessionSignaturesArgs{
+			SessionID: sessionID,
+			Signatures: []SigningSessionSignature{{
+				SignatureIndex: json.Uint32(sigIndex),
+				Signature:      sigStr,
+			}},
+			Encoding: formatting.Hex,
+		}
+	}
+
+	// A signature from a key that doesn't own the slot is rejected
+	err = env.service.AddSigningSessionSignatures(nil, sign(signers[1], 0), &SigningSessionReply{})
+	require.ErrorIs(err, errWrongSigner)
+
+	// The first party provides their signature
+	reply = &SigningSessionReply{}
+	require.NoError(env.service.AddSigningSessionSignatures(nil, sign(signers[0], 0), reply))
+	require.True(reply.Slots[0].Signed)
+	require.False(reply.Slots[1].Signed)
+	require.False(reply.Complete)
+
+	// Creating the session again returns the existing session
+	reply = &SigningSessionReply{}
+	require.NoError(env.service.CreateSigningSession(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, reply))
+	require.Equal(sessionID, reply.SessionID)
+	require.True(reply.Slots[0].Signed)
+
+	// The second party provides their signature
+	reply = &SigningSessionReply{}
+	require.NoError(env.service.AddSigningSessionSignatures(nil, sign(signers[1], 1), reply))
+	require.True(reply.Complete)
+
+	txReply := &api.JSONTxID{}
+	require.NoError(env.service.IssueSigningSession(nil, issueArgs, txReply))
+
+	expectedTx := &txs.Tx{Unsigned: tx.Unsigned}
+	require.NoError(expectedTx.SignSECP256K1Fx(env.vm.parser.Codec(), [][]*secp256k1.PrivateKey{signers}))
+	require.Equal(expectedTx.ID(), txReply.TxID)
+
+	// The session is removed once its tx is issued
+	err = env.service.GetSigningSession(nil, issueArgs, &SigningSessionReply{})
+	require.ErrorIs(err, errUnknownSigningSession)
+}
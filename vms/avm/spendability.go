@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// spendability tracks how much of an asset a set of addresses can spend
+type spendability struct {
+	spendable uint64
+	locked    uint64
+	// Key: locktime
+	// Value: amount locked until the locktime
+	lockedByLocktime map[uint64]uint64
+	partiallyOwned   uint64
+}
+
+// add classifies [out] according to whether [addrs] can spend it at time
+// [now], using the same rules as the secp256k1fx does when verifying a spend.
+func (s *spendability) add(out *secp256k1fx.TransferOutput, addrs set.Set[ids.ShortID], now uint64) error {
+	var (
+		amount = out.Amount()
+		owners = &out.OutputOwners
+		err    error
+	)
+	if !canSign(owners, addrs) {
+		s.partiallyOwned, err = safemath.Add64(s.partiallyOwned, amount)
+		return err
+	}
+	if owners.Locktime <= now {
+		s.spendable, err = safemath.Add64(s.spendable, amount)
+		return err
+	}
+
+	s.locked, err = safemath.Add64(s.locked, amount)
+	if err != nil {
+		return err
+	}
+	// The locktime bucket can't overflow because it's bounded by [s.locked].
+	s.lockedByLocktime[owners.Locktime] += amount
+	return nil
+}
+
+func (s *spendability) toAPI(assetID string) AssetSpendability {
+	locktimes := maps.Keys(s.lockedByLocktime)
+	slices.Sort(locktimes)
+
+	lockedByLocktime := make([]LockedBalance, len(locktimes))
+	for i, locktime := range locktimes {
+		lockedByLocktime[i] = LockedBalance{
+			Locktime: json.Uint64(locktime),
+			Amount:   json.Uint64(s.lockedByLocktime[locktime]),
+		}
+	}
+	return AssetSpendability{
+		AssetID:          assetID,
+		Spendable:        json.Uint64(s.spendable),
+		Locked:           json.Uint64(s.locked),
+		LockedByLocktime: lockedByLocktime,
+		PartiallyOwned:   json.Uint64(s.partiallyOwned),
+	}
+}
+
+// canSign returns true if [addrs] contains at least the threshold of the
+// addresses of [owners].
+func canSign(owners *secp256k1fx.OutputOwners, addrs set.Set[ids.ShortID]) bool {
+	numSigners := uint32(0)
+	for _, addr := range owners.Addrs {
+		if addrs.Contains(addr) {
+			numSigners++
+		}
+	}
+	return numSigners >= owners.Threshold
+}
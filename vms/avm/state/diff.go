@@ -37,8 +37,13 @@ type diff struct {
 	addedBlockIDs map[uint64]ids.ID      // map of height -> blockID
 	addedBlocks   map[ids.ID]block.Block // map of blockID -> block
 
+	modifiedFrozenAssets  map[ids.ID]bool           // map of assetID -> frozen
+	modifiedAssetSupplies map[ids.ID]*AssetSupply   // map of assetID -> supply
+	modifiedAssetMetadata map[ids.ID]*AssetMetadata // map of assetID -> metadata
+
 	lastAccepted ids.ID
 	timestamp    time.Time
+	feeRate      *txs.FeeRate // nil if not modified
 }
 
 func NewDiff(
@@ -56,8 +61,13 @@ func NewDiff(
 		addedTxs:      make(map[ids.ID]*txs.Tx),
 		addedBlockIDs: make(map[uint64]ids.ID),
 		addedBlocks:   make(map[ids.ID]block.Block),
-		lastAccepted:  parentState.GetLastAccepted(),
-		timestamp:     parentState.GetTimestamp(),
+
+		modifiedFrozenAssets:  make(map[ids.ID]bool),
+		modifiedAssetSupplies: make(map[ids.ID]*AssetSupply),
+		modifiedAssetMetadata: make(map[ids.ID]*AssetMetadata),
+
+		lastAccepted: parentState.GetLastAccepted(),
+		timestamp:    parentState.GetTimestamp(),
 	}, nil
 }
 
@@ -146,6 +156,70 @@ func (d *diff) SetTimestamp(t time.Time) {
 	d.timestamp = t
 }
 
+func (d *diff) GetFeeRate() (*txs.FeeRate, error) {
+	if d.feeRate != nil {
+		return d.feeRate, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetFeeRate()
+}
+
+func (d *diff) SetFeeRate(rate *txs.FeeRate) {
+	d.feeRate = rate
+}
+
+func (d *diff) IsAssetFrozen(assetID ids.ID) (bool, error) {
+	if frozen, modified := d.modifiedFrozenAssets[assetID]; modified {
+		return frozen, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return false, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.IsAssetFrozen(assetID)
+}
+
+func (d *diff) SetAssetFrozen(assetID ids.ID, frozen bool) {
+	d.modifiedFrozenAssets[assetID] = frozen
+}
+
+func (d *diff) GetAssetSupply(assetID ids.ID) (*AssetSupply, error) {
+	if supply, modified := d.modifiedAssetSupplies[assetID]; modified {
+		return supply, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetAssetSupply(assetID)
+}
+
+func (d *diff) SetAssetSupply(assetID ids.ID, supply *AssetSupply) {
+	d.modifiedAssetSupplies[assetID] = supply
+}
+
+func (d *diff) GetAssetMetadata(assetID ids.ID) (*AssetMetadata, error) {
+	if metadata, modified := d.modifiedAssetMetadata[assetID]; modified {
+		return metadata, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetAssetMetadata(assetID)
+}
+
+func (d *diff) SetAssetMetadata(assetID ids.ID, metadata *AssetMetadata) {
+	d.modifiedAssetMetadata[assetID] = metadata
+}
+
 func (d *diff) Apply(state Chain) {
 	for utxoID, utxo := range d.modifiedUTXOs {
 		if utxo != nil {
@@ -163,6 +237,19 @@ func (d *diff) Apply(state Chain) {
 		state.AddBlock(blk)
 	}
 
+	for assetID, frozen := range d.modifiedFrozenAssets {
+		state.SetAssetFrozen(assetID, frozen)
+	}
+	for assetID, supply := range d.modifiedAssetSupplies {
+		state.SetAssetSupply(assetID, supply)
+	}
+	for assetID, metadata := range d.modifiedAssetMetadata {
+		state.SetAssetMetadata(assetID, metadata)
+	}
+
 	state.SetLastAccepted(d.lastAccepted)
 	state.SetTimestamp(d.timestamp)
+	if d.feeRate != nil {
+		state.SetFeeRate(d.feeRate)
+	}
 }
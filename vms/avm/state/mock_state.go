@@ -92,6 +92,36 @@ func (mr *MockChainMockRecorder) DeleteUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockChain)(nil).DeleteUTXO), arg0)
 }
 
+// GetAssetMetadata mocks base method.
+func (m *MockChain) GetAssetMetadata(arg0 ids.ID) (*AssetMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetMetadata", arg0)
+	ret0, _ := ret[0].(*AssetMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetMetadata indicates an expected call of GetAssetMetadata.
+func (mr *MockChainMockRecorder) GetAssetMetadata(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetMetadata", reflect.TypeOf((*MockChain)(nil).GetAssetMetadata), arg0)
+}
+
+// GetAssetSupply mocks base method.
+func (m *MockChain) GetAssetSupply(arg0 ids.ID) (*AssetSupply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetSupply", arg0)
+	ret0, _ := ret[0].(*AssetSupply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetSupply indicates an expected call of GetAssetSupply.
+func (mr *MockChainMockRecorder) GetAssetSupply(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetSupply", reflect.TypeOf((*MockChain)(nil).GetAssetSupply), arg0)
+}
+
 // GetBlock mocks base method.
 func (m *MockChain) GetBlock(arg0 ids.ID) (block.Block, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +152,21 @@ func (mr *MockChainMockRecorder) GetBlockIDAtHeight(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*MockChain)(nil).GetBlockIDAtHeight), arg0)
 }
 
+// GetFeeRate mocks base method.
+func (m *MockChain) GetFeeRate() (*txs.FeeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeRate")
+	ret0, _ := ret[0].(*txs.FeeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeRate indicates an expected call of GetFeeRate.
+func (mr *MockChainMockRecorder) GetFeeRate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeRate", reflect.TypeOf((*MockChain)(nil).GetFeeRate))
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockChain) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -180,6 +225,69 @@ func (mr *MockChainMockRecorder) GetUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*MockChain)(nil).GetUTXO), arg0)
 }
 
+// IsAssetFrozen mocks base method.
+func (m *MockChain) IsAssetFrozen(arg0 ids.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAssetFrozen", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAssetFrozen indicates an expected call of IsAssetFrozen.
+func (mr *MockChainMockRecorder) IsAssetFrozen(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAssetFrozen", reflect.TypeOf((*MockChain)(nil).IsAssetFrozen), arg0)
+}
+
+// SetAssetFrozen mocks base method.
+func (m *MockChain) SetAssetFrozen(arg0 ids.ID, arg1 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAssetFrozen", arg0, arg1)
+}
+
+// SetAssetFrozen indicates an expected call of SetAssetFrozen.
+func (mr *MockChainMockRecorder) SetAssetFrozen(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssetFrozen", reflect.TypeOf((*MockChain)(nil).SetAssetFrozen), arg0, arg1)
+}
+
+// SetAssetMetadata mocks base method.
+func (m *MockChain) SetAssetMetadata(arg0 ids.ID, arg1 *AssetMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAssetMetadata", arg0, arg1)
+}
+
+// SetAssetMetadata indicates an expected call of SetAssetMetadata.
+func (mr *MockChainMockRecorder) SetAssetMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssetMetadata", reflect.TypeOf((*MockChain)(nil).SetAssetMetadata), arg0, arg1)
+}
+
+// SetAssetSupply mocks base method.
+func (m *MockChain) SetAssetSupply(arg0 ids.ID, arg1 *AssetSupply) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAssetSupply", arg0, arg1)
+}
+
+// SetAssetSupply indicates an expected call of SetAssetSupply.
+func (mr *MockChainMockRecorder) SetAssetSupply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssetSupply", reflect.TypeOf((*MockChain)(nil).SetAssetSupply), arg0, arg1)
+}
+
+// SetFeeRate mocks base method.
+func (m *MockChain) SetFeeRate(arg0 *txs.FeeRate) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeRate", arg0)
+}
+
+// SetFeeRate indicates an expected call of SetFeeRate.
+func (mr *MockChainMockRecorder) SetFeeRate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeRate", reflect.TypeOf((*MockChain)(nil).SetFeeRate), arg0)
+}
+
 // SetLastAccepted mocks base method.
 func (m *MockChain) SetLastAccepted(arg0 ids.ID) {
 	m.ctrl.T.Helper()
@@ -345,6 +453,36 @@ func (mr *MockStateMockRecorder) DeleteUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockState)(nil).DeleteUTXO), arg0)
 }
 
+// GetAssetMetadata mocks base method.
+func (m *MockState) GetAssetMetadata(arg0 ids.ID) (*AssetMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetMetadata", arg0)
+	ret0, _ := ret[0].(*AssetMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetMetadata indicates an expected call of GetAssetMetadata.
+func (mr *MockStateMockRecorder) GetAssetMetadata(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetMetadata", reflect.TypeOf((*MockState)(nil).GetAssetMetadata), arg0)
+}
+
+// GetAssetSupply mocks base method.
+func (m *MockState) GetAssetSupply(arg0 ids.ID) (*AssetSupply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetSupply", arg0)
+	ret0, _ := ret[0].(*AssetSupply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetSupply indicates an expected call of GetAssetSupply.
+func (mr *MockStateMockRecorder) GetAssetSupply(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetSupply", reflect.TypeOf((*MockState)(nil).GetAssetSupply), arg0)
+}
+
 // GetBlock mocks base method.
 func (m *MockState) GetBlock(arg0 ids.ID) (block.Block, error) {
 	m.ctrl.T.Helper()
@@ -375,6 +513,21 @@ func (mr *MockStateMockRecorder) GetBlockIDAtHeight(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*MockState)(nil).GetBlockIDAtHeight), arg0)
 }
 
+// GetFeeRate mocks base method.
+func (m *MockState) GetFeeRate() (*txs.FeeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeRate")
+	ret0, _ := ret[0].(*txs.FeeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeRate indicates an expected call of GetFeeRate.
+func (mr *MockStateMockRecorder) GetFeeRate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeRate", reflect.TypeOf((*MockState)(nil).GetFeeRate))
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockState) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -447,6 +600,21 @@ func (mr *MockStateMockRecorder) InitializeChainState(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitializeChainState", reflect.TypeOf((*MockState)(nil).InitializeChainState), arg0, arg1)
 }
 
+// IsAssetFrozen mocks base method.
+func (m *MockState) IsAssetFrozen(arg0 ids.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAssetFrozen", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAssetFrozen indicates an expected call of IsAssetFrozen.
+func (mr *MockStateMockRecorder) IsAssetFrozen(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAssetFrozen", reflect.TypeOf((*MockState)(nil).IsAssetFrozen), arg0)
+}
+
 // IsInitialized mocks base method.
 func (m *MockState) IsInitialized() (bool, error) {
 	m.ctrl.T.Helper()
@@ -476,6 +644,54 @@ func (mr *MockStateMockRecorder) Prune(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockState)(nil).Prune), arg0, arg1)
 }
 
+// SetAssetFrozen mocks base method.
+func (m *MockState) SetAssetFrozen(arg0 ids.ID, arg1 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAssetFrozen", arg0, arg1)
+}
+
+// SetAssetFrozen indicates an expected call of SetAssetFrozen.
+func (mr *MockStateMockRecorder) SetAssetFrozen(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssetFrozen", reflect.TypeOf((*MockState)(nil).SetAssetFrozen), arg0, arg1)
+}
+
+// SetAssetMetadata mocks base method.
+func (m *MockState) SetAssetMetadata(arg0 ids.ID, arg1 *AssetMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAssetMetadata", arg0, arg1)
+}
+
+// SetAssetMetadata indicates an expected call of SetAssetMetadata.
+func (mr *MockStateMockRecorder) SetAssetMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssetMetadata", reflect.TypeOf((*MockState)(nil).SetAssetMetadata), arg0, arg1)
+}
+
+// SetAssetSupply mocks base method.
+func (m *MockState) SetAssetSupply(arg0 ids.ID, arg1 *AssetSupply) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAssetSupply", arg0, arg1)
+}
+
+// SetAssetSupply indicates an expected call of SetAssetSupply.
+func (mr *MockStateMockRecorder) SetAssetSupply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssetSupply", reflect.TypeOf((*MockState)(nil).SetAssetSupply), arg0, arg1)
+}
+
+// SetFeeRate mocks base method.
+func (m *MockState) SetFeeRate(arg0 *txs.FeeRate) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeRate", arg0)
+}
+
+// SetFeeRate indicates an expected call of SetFeeRate.
+func (mr *MockStateMockRecorder) SetFeeRate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeRate", reflect.TypeOf((*MockState)(nil).SetFeeRate), arg0)
+}
+
 // SetInitialized mocks base method.
 func (m *MockState) SetInitialized() error {
 	m.ctrl.T.Helper()
@@ -612,6 +828,36 @@ func (mr *MockDiffMockRecorder) DeleteUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockDiff)(nil).DeleteUTXO), arg0)
 }
 
+// GetAssetMetadata mocks base method.
+func (m *MockDiff) GetAssetMetadata(arg0 ids.ID) (*AssetMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetMetadata", arg0)
+	ret0, _ := ret[0].(*AssetMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetMetadata indicates an expected call of GetAssetMetadata.
+func (mr *MockDiffMockRecorder) GetAssetMetadata(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetMetadata", reflect.TypeOf((*MockDiff)(nil).GetAssetMetadata), arg0)
+}
+
+// GetAssetSupply mocks base method.
+func (m *MockDiff) GetAssetSupply(arg0 ids.ID) (*AssetSupply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetSupply", arg0)
+	ret0, _ := ret[0].(*AssetSupply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetSupply indicates an expected call of GetAssetSupply.
+func (mr *MockDiffMockRecorder) GetAssetSupply(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetSupply", reflect.TypeOf((*MockDiff)(nil).GetAssetSupply), arg0)
+}
+
 // GetBlock mocks base method.
 func (m *MockDiff) GetBlock(arg0 ids.ID) (block.Block, error) {
 	m.ctrl.T.Helper()
@@ -642,6 +888,21 @@ func (mr *MockDiffMockRecorder) GetBlockIDAtHeight(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIDAtHeight", reflect.TypeOf((*MockDiff)(nil).GetBlockIDAtHeight), arg0)
 }
 
+// GetFeeRate mocks base method.
+func (m *MockDiff) GetFeeRate() (*txs.FeeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeRate")
+	ret0, _ := ret[0].(*txs.FeeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeRate indicates an expected call of GetFeeRate.
+func (mr *MockDiffMockRecorder) GetFeeRate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeRate", reflect.TypeOf((*MockDiff)(nil).GetFeeRate))
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockDiff) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -700,6 +961,69 @@ func (mr *MockDiffMockRecorder) GetUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*MockDiff)(nil).GetUTXO), arg0)
 }
 
+// IsAssetFrozen mocks base method.
+func (m *MockDiff) IsAssetFrozen(arg0 ids.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAssetFrozen", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAssetFrozen indicates an expected call of IsAssetFrozen.
+func (mr *MockDiffMockRecorder) IsAssetFrozen(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAssetFrozen", reflect.TypeOf((*MockDiff)(nil).IsAssetFrozen), arg0)
+}
+
+// SetAssetFrozen mocks base method.
+func (m *MockDiff) SetAssetFrozen(arg0 ids.ID, arg1 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAssetFrozen", arg0, arg1)
+}
+
+// SetAssetFrozen indicates an expected call of SetAssetFrozen.
+func (mr *MockDiffMockRecorder) SetAssetFrozen(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssetFrozen", reflect.TypeOf((*MockDiff)(nil).SetAssetFrozen), arg0, arg1)
+}
+
+// SetAssetMetadata mocks base method.
+func (m *MockDiff) SetAssetMetadata(arg0 ids.ID, arg1 *AssetMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAssetMetadata", arg0, arg1)
+}
+
+// SetAssetMetadata indicates an expected call of SetAssetMetadata.
+func (mr *MockDiffMockRecorder) SetAssetMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssetMetadata", reflect.TypeOf((*MockDiff)(nil).SetAssetMetadata), arg0, arg1)
+}
+
+// SetAssetSupply mocks base method.
+func (m *MockDiff) SetAssetSupply(arg0 ids.ID, arg1 *AssetSupply) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAssetSupply", arg0, arg1)
+}
+
+// SetAssetSupply indicates an expected call of SetAssetSupply.
+func (mr *MockDiffMockRecorder) SetAssetSupply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssetSupply", reflect.TypeOf((*MockDiff)(nil).SetAssetSupply), arg0, arg1)
+}
+
+// SetFeeRate mocks base method.
+func (m *MockDiff) SetFeeRate(arg0 *txs.FeeRate) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeRate", arg0)
+}
+
+// SetFeeRate indicates an expected call of SetFeeRate.
+func (mr *MockDiffMockRecorder) SetFeeRate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeRate", reflect.TypeOf((*MockDiff)(nil).SetFeeRate), arg0)
+}
+
 // SetLastAccepted mocks base method.
 func (m *MockDiff) SetLastAccepted(arg0 ids.ID) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// Namespaces returns the databases of the UTXOs, the txs, and the blocks of
+// the state created from [db], keyed by name.
+func Namespaces(db database.Database) map[string]database.Iteratee {
+	namespaces := avax.UTXOStateNamespaces(prefixdb.New(utxoPrefix, db))
+	namespaces["txs"] = prefixdb.New(txPrefix, db)
+	namespaces["blocks"] = prefixdb.New(blockPrefix, db)
+	namespaces["block_ids"] = prefixdb.New(blockIDPrefix, db)
+	return namespaces
+}
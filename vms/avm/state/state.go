@@ -51,16 +51,35 @@ var (
 	blockIDPrefix   = []byte("blockID")
 	blockPrefix     = []byte("block")
 	singletonPrefix = []byte("singleton")
+	frozenPrefix    = []byte("frozen")
+	supplyPrefix    = []byte("supply")
+	metadataPrefix  = []byte("metadata")
 
 	isInitializedKey = []byte{0x00}
 	timestampKey     = []byte{0x01}
 	lastAcceptedKey  = []byte{0x02}
+	feeRateKey       = []byte{0x03}
 
 	errStatusWithoutTx = errors.New("unexpected status without transactions")
 
 	_ State = (*state)(nil)
 )
 
+// AssetSupply tracks the cumulative issuance of an asset with a max supply.
+type AssetSupply struct {
+	MaxSupply uint64 `serialize:"true" json:"maxSupply"`
+	Issued    uint64 `serialize:"true" json:"issued"`
+}
+
+// AssetMetadata is the on-chain description of an asset. It overrides the
+// description provided when the asset was created.
+type AssetMetadata struct {
+	Name         string `serialize:"true" json:"name"`
+	Symbol       string `serialize:"true" json:"symbol"`
+	Denomination byte   `serialize:"true" json:"denomination"`
+	URI          string `serialize:"true" json:"uri"`
+}
+
 type ReadOnlyChain interface {
 	avax.UTXOGetter
 
@@ -69,6 +88,18 @@ type ReadOnlyChain interface {
 	GetBlock(blkID ids.ID) (block.Block, error)
 	GetLastAccepted() ids.ID
 	GetTimestamp() time.Time
+	// GetFeeRate returns the most recently published fee conversion rate. If
+	// no rate has been published, database.ErrNotFound is returned.
+	GetFeeRate() (*txs.FeeRate, error)
+	// IsAssetFrozen returns true if transfers of [assetID] are currently
+	// frozen.
+	IsAssetFrozen(assetID ids.ID) (bool, error)
+	// GetAssetSupply returns the issuance of [assetID]. If the asset doesn't
+	// have a max supply, database.ErrNotFound is returned.
+	GetAssetSupply(assetID ids.ID) (*AssetSupply, error)
+	// GetAssetMetadata returns the on-chain metadata of [assetID]. If the
+	// metadata was never updated, database.ErrNotFound is returned.
+	GetAssetMetadata(assetID ids.ID) (*AssetMetadata, error)
 }
 
 type Chain interface {
@@ -80,6 +111,10 @@ type Chain interface {
 	AddBlock(block block.Block)
 	SetLastAccepted(blkID ids.ID)
 	SetTimestamp(t time.Time)
+	SetFeeRate(rate *txs.FeeRate)
+	SetAssetFrozen(assetID ids.ID, frozen bool)
+	SetAssetSupply(assetID ids.ID, supply *AssetSupply)
+	SetAssetMetadata(assetID ids.ID, metadata *AssetMetadata)
 }
 
 // State persistently maintains a set of UTXOs, transaction, statuses, and
@@ -140,10 +175,17 @@ type State interface {
  * | '-- height -> blockID
  * |-. blocks
  * | '-- blockID -> block bytes
+ * |-. frozen
+ * | '-- assetID -> nil
+ * |-. supply
+ * | '-- assetID -> asset supply
+ * |-. metadata
+ * | '-- assetID -> asset metadata
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- timestampKey -> timestamp
- *   '-- lastAcceptedKey -> lastAccepted
+ *   |-- lastAcceptedKey -> lastAccepted
+ *   '-- feeRateKey -> feeRate
  */
 type state struct {
 	parser block.Parser
@@ -169,9 +211,20 @@ type state struct {
 	blockCache  cache.Cacher[ids.ID, block.Block] // cache of blockID -> Block. If the entry is nil, it is not in the database
 	blockDB     database.Database
 
+	modifiedFrozenAssets map[ids.ID]bool // map of assetID -> frozen
+	frozenDB             database.Database
+
+	modifiedAssetSupplies map[ids.ID]*AssetSupply // map of assetID -> supply
+	supplyDB              database.Database
+
+	modifiedAssetMetadata map[ids.ID]*AssetMetadata // map of assetID -> metadata
+	metadataDB            database.Database
+
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	timestamp, persistedTimestamp       time.Time
+	feeRate                             *txs.FeeRate // nil if not loaded
+	feeRateModified                     bool
 	singletonDB                         database.Database
 
 	trackChecksum bool
@@ -190,6 +243,9 @@ func New(
 	blockIDDB := prefixdb.New(blockIDPrefix, db)
 	blockDB := prefixdb.New(blockPrefix, db)
 	singletonDB := prefixdb.New(singletonPrefix, db)
+	frozenDB := prefixdb.New(frozenPrefix, db)
+	supplyDB := prefixdb.New(supplyPrefix, db)
+	metadataDB := prefixdb.New(metadataPrefix, db)
 
 	statusCache, err := metercacher.New[ids.ID, *choices.Status](
 		"status_cache",
@@ -255,6 +311,15 @@ func New(
 		blockCache:  blockCache,
 		blockDB:     blockDB,
 
+		modifiedFrozenAssets: make(map[ids.ID]bool),
+		frozenDB:             frozenDB,
+
+		modifiedAssetSupplies: make(map[ids.ID]*AssetSupply),
+		supplyDB:              supplyDB,
+
+		modifiedAssetMetadata: make(map[ids.ID]*AssetMetadata),
+		metadataDB:            metadataDB,
+
 		singletonDB: singletonDB,
 
 		trackChecksum: trackChecksums,
@@ -501,6 +566,82 @@ func (s *state) SetTimestamp(t time.Time) {
 	s.timestamp = t
 }
 
+func (s *state) GetFeeRate() (*txs.FeeRate, error) {
+	if s.feeRate != nil {
+		return s.feeRate, nil
+	}
+
+	rateBytes, err := s.singletonDB.Get(feeRateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := &txs.FeeRate{}
+	if _, err := s.parser.Codec().Unmarshal(rateBytes, rate); err != nil {
+		return nil, err
+	}
+	s.feeRate = rate
+	return rate, nil
+}
+
+func (s *state) SetFeeRate(rate *txs.FeeRate) {
+	s.feeRate = rate
+	s.feeRateModified = true
+}
+
+func (s *state) IsAssetFrozen(assetID ids.ID) (bool, error) {
+	if frozen, modified := s.modifiedFrozenAssets[assetID]; modified {
+		return frozen, nil
+	}
+	return s.frozenDB.Has(assetID[:])
+}
+
+func (s *state) SetAssetFrozen(assetID ids.ID, frozen bool) {
+	s.modifiedFrozenAssets[assetID] = frozen
+}
+
+func (s *state) GetAssetSupply(assetID ids.ID) (*AssetSupply, error) {
+	if supply, modified := s.modifiedAssetSupplies[assetID]; modified {
+		return supply, nil
+	}
+
+	supplyBytes, err := s.supplyDB.Get(assetID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	supply := &AssetSupply{}
+	if _, err := s.parser.Codec().Unmarshal(supplyBytes, supply); err != nil {
+		return nil, err
+	}
+	return supply, nil
+}
+
+func (s *state) SetAssetSupply(assetID ids.ID, supply *AssetSupply) {
+	s.modifiedAssetSupplies[assetID] = supply
+}
+
+func (s *state) GetAssetMetadata(assetID ids.ID) (*AssetMetadata, error) {
+	if metadata, modified := s.modifiedAssetMetadata[assetID]; modified {
+		return metadata, nil
+	}
+
+	metadataBytes, err := s.metadataDB.Get(assetID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &AssetMetadata{}
+	if _, err := s.parser.Codec().Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func (s *state) SetAssetMetadata(assetID ids.ID, metadata *AssetMetadata) {
+	s.modifiedAssetMetadata[assetID] = metadata
+}
+
 func (s *state) Commit() error {
 	defer s.Abort()
 	batch, err := s.CommitBatch()
@@ -528,6 +669,9 @@ func (s *state) Close() error {
 		s.txDB.Close(),
 		s.blockIDDB.Close(),
 		s.blockDB.Close(),
+		s.frozenDB.Close(),
+		s.supplyDB.Close(),
+		s.metadataDB.Close(),
 		s.singletonDB.Close(),
 		s.db.Close(),
 	)
@@ -539,6 +683,9 @@ func (s *state) write() error {
 		s.writeTxs(),
 		s.writeBlockIDs(),
 		s.writeBlocks(),
+		s.writeFrozenAssets(),
+		s.writeAssetSupplies(),
+		s.writeAssetMetadata(),
 		s.writeMetadata(),
 	)
 }
@@ -605,6 +752,56 @@ func (s *state) writeBlocks() error {
 	return nil
 }
 
+func (s *state) writeFrozenAssets() error {
+	for assetID, frozen := range s.modifiedFrozenAssets {
+		assetID := assetID
+
+		delete(s.modifiedFrozenAssets, assetID)
+		if frozen {
+			if err := s.frozenDB.Put(assetID[:], nil); err != nil {
+				return fmt.Errorf("failed to freeze asset: %w", err)
+			}
+		} else {
+			if err := s.frozenDB.Delete(assetID[:]); err != nil {
+				return fmt.Errorf("failed to unfreeze asset: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *state) writeAssetSupplies() error {
+	for assetID, supply := range s.modifiedAssetSupplies {
+		assetID := assetID
+
+		delete(s.modifiedAssetSupplies, assetID)
+		supplyBytes, err := s.parser.Codec().Marshal(txs.CodecVersion, supply)
+		if err != nil {
+			return fmt.Errorf("failed to marshal asset supply: %w", err)
+		}
+		if err := s.supplyDB.Put(assetID[:], supplyBytes); err != nil {
+			return fmt.Errorf("failed to write asset supply: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeAssetMetadata() error {
+	for assetID, metadata := range s.modifiedAssetMetadata {
+		assetID := assetID
+
+		delete(s.modifiedAssetMetadata, assetID)
+		metadataBytes, err := s.parser.Codec().Marshal(txs.CodecVersion, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal asset metadata: %w", err)
+		}
+		if err := s.metadataDB.Put(assetID[:], metadataBytes); err != nil {
+			return fmt.Errorf("failed to write asset metadata: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeMetadata() error {
 	if !s.persistedTimestamp.Equal(s.timestamp) {
 		if err := database.PutTimestamp(s.singletonDB, timestampKey, s.timestamp); err != nil {
@@ -618,6 +815,16 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedLastAccepted = s.lastAccepted
 	}
+	if s.feeRateModified {
+		rateBytes, err := s.parser.Codec().Marshal(txs.CodecVersion, s.feeRate)
+		if err != nil {
+			return fmt.Errorf("failed to marshal fee rate: %w", err)
+		}
+		if err := s.singletonDB.Put(feeRateKey, rateBytes); err != nil {
+			return fmt.Errorf("failed to write fee rate: %w", err)
+		}
+		s.feeRateModified = false
+	}
 	return nil
 }
 
@@ -34,6 +34,18 @@ var (
 	populatedBlk       block.Block
 	populatedBlkHeight uint64
 	populatedBlkID     ids.ID
+	populatedAssetID   = ids.GenerateTestID()
+
+	populatedAssetSupply = &AssetSupply{
+		MaxSupply: 100,
+		Issued:    10,
+	}
+	populatedAssetMetadata = &AssetMetadata{
+		Name:         "Team Rocket",
+		Symbol:       "TR",
+		Denomination: 9,
+		URI:          "https://example.com/tr.json",
+	}
 )
 
 func init() {
@@ -107,6 +119,9 @@ func TestState(t *testing.T) {
 	s.AddUTXO(populatedUTXO)
 	s.AddTx(populatedTx)
 	s.AddBlock(populatedBlk)
+	s.SetAssetFrozen(populatedAssetID, true)
+	s.SetAssetSupply(populatedAssetID, populatedAssetSupply)
+	s.SetAssetMetadata(populatedAssetID, populatedAssetMetadata)
 	require.NoError(s.Commit())
 
 	s, err = New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
@@ -115,6 +130,9 @@ func TestState(t *testing.T) {
 	ChainUTXOTest(t, s)
 	ChainTxTest(t, s)
 	ChainBlockTest(t, s)
+	ChainFrozenAssetTest(t, s)
+	ChainAssetSupplyTest(t, s)
+	ChainAssetMetadataTest(t, s)
 }
 
 func TestDiff(t *testing.T) {
@@ -128,6 +146,9 @@ func TestDiff(t *testing.T) {
 	s.AddUTXO(populatedUTXO)
 	s.AddTx(populatedTx)
 	s.AddBlock(populatedBlk)
+	s.SetAssetFrozen(populatedAssetID, true)
+	s.SetAssetSupply(populatedAssetID, populatedAssetSupply)
+	s.SetAssetMetadata(populatedAssetID, populatedAssetMetadata)
 	require.NoError(s.Commit())
 
 	parentID := ids.GenerateTestID()
@@ -141,6 +162,9 @@ func TestDiff(t *testing.T) {
 	ChainUTXOTest(t, d)
 	ChainTxTest(t, d)
 	ChainBlockTest(t, d)
+	ChainFrozenAssetTest(t, d)
+	ChainAssetSupplyTest(t, d)
+	ChainAssetMetadataTest(t, d)
 }
 
 func ChainUTXOTest(t *testing.T, c Chain) {
@@ -277,6 +301,72 @@ func ChainBlockTest(t *testing.T, c Chain) {
 	require.Equal(blk, fetchedBlk)
 }
 
+func ChainFrozenAssetTest(t *testing.T, c Chain) {
+	require := require.New(t)
+
+	frozen, err := c.IsAssetFrozen(populatedAssetID)
+	require.NoError(err)
+	require.True(frozen)
+
+	assetID := ids.GenerateTestID()
+	frozen, err = c.IsAssetFrozen(assetID)
+	require.NoError(err)
+	require.False(frozen)
+
+	c.SetAssetFrozen(assetID, true)
+	frozen, err = c.IsAssetFrozen(assetID)
+	require.NoError(err)
+	require.True(frozen)
+
+	c.SetAssetFrozen(populatedAssetID, false)
+	frozen, err = c.IsAssetFrozen(populatedAssetID)
+	require.NoError(err)
+	require.False(frozen)
+}
+
+func ChainAssetSupplyTest(t *testing.T, c Chain) {
+	require := require.New(t)
+
+	supply, err := c.GetAssetSupply(populatedAssetID)
+	require.NoError(err)
+	require.Equal(populatedAssetSupply, supply)
+
+	assetID := ids.GenerateTestID()
+	_, err = c.GetAssetSupply(assetID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	newSupply := &AssetSupply{
+		MaxSupply: 100,
+		Issued:    20,
+	}
+	c.SetAssetSupply(populatedAssetID, newSupply)
+	supply, err = c.GetAssetSupply(populatedAssetID)
+	require.NoError(err)
+	require.Equal(newSupply, supply)
+}
+
+func ChainAssetMetadataTest(t *testing.T, c Chain) {
+	require := require.New(t)
+
+	metadata, err := c.GetAssetMetadata(populatedAssetID)
+	require.NoError(err)
+	require.Equal(populatedAssetMetadata, metadata)
+
+	assetID := ids.GenerateTestID()
+	_, err = c.GetAssetMetadata(assetID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	newMetadata := &AssetMetadata{
+		Name:         "Team Rocket Token",
+		Symbol:       "TRT",
+		Denomination: 18,
+	}
+	c.SetAssetMetadata(populatedAssetID, newMetadata)
+	metadata, err = c.GetAssetMetadata(populatedAssetID)
+	require.NoError(err)
+	require.Equal(newMetadata, metadata)
+}
+
 func TestInitializeChainState(t *testing.T) {
 	require := require.New(t)
 
@@ -14,6 +14,9 @@ var _ StaticClient = (*staticClient)(nil)
 // StaticClient for interacting with the AVM static api
 type StaticClient interface {
 	BuildGenesis(ctx context.Context, args *BuildGenesisArgs, options ...rpc.Option) (*BuildGenesisReply, error)
+	BuildBaseTx(ctx context.Context, args *BuildBaseTxArgs, options ...rpc.Option) (*BuildUnsignedTxReply, error)
+	BuildExportTx(ctx context.Context, args *BuildExportTxArgs, options ...rpc.Option) (*BuildUnsignedTxReply, error)
+	BuildImportTx(ctx context.Context, args *BuildImportTxArgs, options ...rpc.Option) (*BuildUnsignedTxReply, error)
 }
 
 // staticClient is an implementation of an AVM client for interacting with the
@@ -34,3 +37,21 @@ func (c *staticClient) BuildGenesis(ctx context.Context, args *BuildGenesisArgs,
 	err = c.requester.SendRequest(ctx, "avm.buildGenesis", args, resp, options...)
 	return resp, err
 }
+
+func (c *staticClient) BuildBaseTx(ctx context.Context, args *BuildBaseTxArgs, options ...rpc.Option) (resp *BuildUnsignedTxReply, err error) {
+	resp = &BuildUnsignedTxReply{}
+	err = c.requester.SendRequest(ctx, "avm.buildBaseTx", args, resp, options...)
+	return resp, err
+}
+
+func (c *staticClient) BuildExportTx(ctx context.Context, args *BuildExportTxArgs, options ...rpc.Option) (resp *BuildUnsignedTxReply, err error) {
+	resp = &BuildUnsignedTxReply{}
+	err = c.requester.SendRequest(ctx, "avm.buildExportTx", args, resp, options...)
+	return resp, err
+}
+
+func (c *staticClient) BuildImportTx(ctx context.Context, args *BuildImportTxArgs, options ...rpc.Option) (resp *BuildUnsignedTxReply, err error) {
+	resp = &BuildUnsignedTxReply{}
+	err = c.requester.SendRequest(ctx, "avm.buildImportTx", args, resp, options...)
+	return resp, err
+}
@@ -10,6 +10,7 @@ import (
 
 	stdjson "encoding/json"
 
+	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -77,11 +78,7 @@ type BuildGenesisReply struct {
 // BuildGenesis returns the UTXOs such that at least one address in [args.Addresses] is
 // referenced in the UTXO.
 func (*StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, reply *BuildGenesisReply) error {
-	parser, err := txs.NewParser([]fxs.Fx{
-		&secp256k1fx.Fx{},
-		&nftfx.Fx{},
-		&propertyfx.Fx{},
-	})
+	parser, err := newStaticParser()
 	if err != nil {
 		return err
 	}
@@ -193,3 +190,292 @@ func (*StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, repl
 	reply.Encoding = args.Encoding
 	return nil
 }
+
+// StaticInput describes a secp256k1fx UTXO that is consumed by a transaction
+// built with the static API.
+type StaticInput struct {
+	TxID        ids.ID      `json:"txID"`
+	OutputIndex json.Uint32 `json:"outputIndex"`
+	AssetID     ids.ID      `json:"assetID"`
+	Amount      json.Uint64 `json:"amount"`
+	// SignatureIndices are the indices of the UTXO's addresses whose keys
+	// will sign for this input.
+	SignatureIndices []json.Uint32 `json:"signatureIndices"`
+}
+
+func (in *StaticInput) transferableInput() *avax.TransferableInput {
+	sigIndices := make([]uint32, len(in.SignatureIndices))
+	for i, sigIndex := range in.SignatureIndices {
+		sigIndices[i] = uint32(sigIndex)
+	}
+	return &avax.TransferableInput{
+		UTXOID: avax.UTXOID{
+			TxID:        in.TxID,
+			OutputIndex: uint32(in.OutputIndex),
+		},
+		Asset: avax.Asset{ID: in.AssetID},
+		In: &secp256k1fx.TransferInput{
+			Amt: uint64(in.Amount),
+			Input: secp256k1fx.Input{
+				SigIndices: sigIndices,
+			},
+		},
+	}
+}
+
+// StaticOutput describes a secp256k1fx output that is produced by a
+// transaction built with the static API.
+type StaticOutput struct {
+	AssetID   ids.ID      `json:"assetID"`
+	Amount    json.Uint64 `json:"amount"`
+	Locktime  json.Uint64 `json:"locktime"`
+	Threshold json.Uint32 `json:"threshold"`
+	Addresses []string    `json:"addresses"`
+}
+
+func (out *StaticOutput) transferableOutput() (*avax.TransferableOutput, error) {
+	addrs, err := address.ParseToIDs(out.Addresses)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing output address: %w", err)
+	}
+	owners := secp256k1fx.OutputOwners{
+		Locktime:  uint64(out.Locktime),
+		Threshold: uint32(out.Threshold),
+		Addrs:     addrs,
+	}
+	owners.Sort()
+	return &avax.TransferableOutput{
+		Asset: avax.Asset{ID: out.AssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          uint64(out.Amount),
+			OutputOwners: owners,
+		},
+	}, nil
+}
+
+// BuildBaseTxArgs are arguments for BuildBaseTx
+type BuildBaseTxArgs struct {
+	NetworkID    json.Uint32         `json:"networkID"`
+	BlockchainID ids.ID              `json:"blockchainID"`
+	Inputs       []StaticInput       `json:"inputs"`
+	Outputs      []StaticOutput      `json:"outputs"`
+	Memo         string              `json:"memo"`
+	Encoding     formatting.Encoding `json:"encoding"`
+}
+
+// BuildExportTxArgs are arguments for BuildExportTx
+type BuildExportTxArgs struct {
+	BuildBaseTxArgs
+	DestinationChain ids.ID         `json:"destinationChain"`
+	ExportedOutputs  []StaticOutput `json:"exportedOutputs"`
+}
+
+// BuildImportTxArgs are arguments for BuildImportTx
+type BuildImportTxArgs struct {
+	BuildBaseTxArgs
+	SourceChain    ids.ID        `json:"sourceChain"`
+	ImportedInputs []StaticInput `json:"importedInputs"`
+}
+
+// StaticCredential describes a credential that must be attached to a
+// transaction built with the static API.
+type StaticCredential struct {
+	TxID             ids.ID        `json:"txID"`
+	OutputIndex      json.Uint32   `json:"outputIndex"`
+	SignatureIndices []json.Uint32 `json:"signatureIndices"`
+}
+
+// BuildUnsignedTxReply is the reply from BuildBaseTx, BuildExportTx and
+// BuildImportTx
+type BuildUnsignedTxReply struct {
+	// UnsignedTx is the canonical encoding of the unsigned transaction. Its
+	// SHA256 hash is what must be signed.
+	UnsignedTx string `json:"unsignedTx"`
+	// Credentials are the credentials of the transaction, in the order they
+	// must be attached to it.
+	Credentials []StaticCredential  `json:"credentials"`
+	Encoding    formatting.Encoding `json:"encoding"`
+}
+
+// BuildBaseTx returns the unsigned bytes of a BaseTx described by [args],
+// without requiring access to any chain state.
+func (*StaticService) BuildBaseTx(_ *http.Request, args *BuildBaseTxArgs, reply *BuildUnsignedTxReply) error {
+	parser, err := newStaticParser()
+	if err != nil {
+		return err
+	}
+
+	baseTx, err := args.baseTx(parser.Codec())
+	if err != nil {
+		return err
+	}
+	if err := avax.VerifyTx(
+		0,
+		ids.Empty,
+		[][]*avax.TransferableInput{baseTx.Ins},
+		[][]*avax.TransferableOutput{baseTx.Outs},
+		parser.Codec(),
+	); err != nil {
+		return err
+	}
+	return reply.setTx(parser.Codec(), &baseTx, args.Encoding, baseTx.Ins)
+}
+
+// BuildExportTx returns the unsigned bytes of an ExportTx described by [args],
+// without requiring access to any chain state.
+func (*StaticService) BuildExportTx(_ *http.Request, args *BuildExportTxArgs, reply *BuildUnsignedTxReply) error {
+	parser, err := newStaticParser()
+	if err != nil {
+		return err
+	}
+
+	baseTx, err := args.baseTx(parser.Codec())
+	if err != nil {
+		return err
+	}
+	exportedOuts, err := transferableOutputs(args.ExportedOutputs, parser.Codec())
+	if err != nil {
+		return err
+	}
+	if err := avax.VerifyTx(
+		0,
+		ids.Empty,
+		[][]*avax.TransferableInput{baseTx.Ins},
+		[][]*avax.TransferableOutput{baseTx.Outs, exportedOuts},
+		parser.Codec(),
+	); err != nil {
+		return err
+	}
+
+	utx := &txs.ExportTx{
+		BaseTx:           baseTx,
+		DestinationChain: args.DestinationChain,
+		ExportedOuts:     exportedOuts,
+	}
+	return reply.setTx(parser.Codec(), utx, args.Encoding, baseTx.Ins)
+}
+
+// BuildImportTx returns the unsigned bytes of an ImportTx described by [args],
+// without requiring access to any chain state.
+func (*StaticService) BuildImportTx(_ *http.Request, args *BuildImportTxArgs, reply *BuildUnsignedTxReply) error {
+	parser, err := newStaticParser()
+	if err != nil {
+		return err
+	}
+
+	baseTx, err := args.baseTx(parser.Codec())
+	if err != nil {
+		return err
+	}
+	importedIns := transferableInputs(args.ImportedInputs)
+	if err := avax.VerifyTx(
+		0,
+		ids.Empty,
+		[][]*avax.TransferableInput{baseTx.Ins, importedIns},
+		[][]*avax.TransferableOutput{baseTx.Outs},
+		parser.Codec(),
+	); err != nil {
+		return err
+	}
+
+	utx := &txs.ImportTx{
+		BaseTx:      baseTx,
+		SourceChain: args.SourceChain,
+		ImportedIns: importedIns,
+	}
+	return reply.setTx(parser.Codec(), utx, args.Encoding, baseTx.Ins, importedIns)
+}
+
+func (args *BuildBaseTxArgs) baseTx(c codec.Manager) (txs.BaseTx, error) {
+	memo, err := formatting.Decode(args.Encoding, args.Memo)
+	if err != nil {
+		return txs.BaseTx{}, fmt.Errorf("problem decoding memo: %w", err)
+	}
+	if len(memo) > avax.MaxMemoSize {
+		return txs.BaseTx{}, fmt.Errorf(
+			"%w: %d > %d",
+			avax.ErrMemoTooLarge,
+			len(memo),
+			avax.MaxMemoSize,
+		)
+	}
+
+	outs, err := transferableOutputs(args.Outputs, c)
+	if err != nil {
+		return txs.BaseTx{}, err
+	}
+	return txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    uint32(args.NetworkID),
+		BlockchainID: args.BlockchainID,
+		Outs:         outs,
+		Ins:          transferableInputs(args.Inputs),
+		Memo:         memo,
+	}}, nil
+}
+
+func (reply *BuildUnsignedTxReply) setTx(
+	c codec.Manager,
+	utx txs.UnsignedTx,
+	encoding formatting.Encoding,
+	allIns ...[]*avax.TransferableInput,
+) error {
+	unsignedBytes, err := c.Marshal(txs.CodecVersion, &utx)
+	if err != nil {
+		return fmt.Errorf("problem marshaling tx: %w", err)
+	}
+	reply.UnsignedTx, err = formatting.Encode(encoding, unsignedBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't encode tx as string: %w", err)
+	}
+
+	for _, ins := range allIns {
+		for _, in := range ins {
+			input := in.In.(*secp256k1fx.TransferInput)
+			sigIndices := make([]json.Uint32, len(input.SigIndices))
+			for i, sigIndex := range input.SigIndices {
+				sigIndices[i] = json.Uint32(sigIndex)
+			}
+			reply.Credentials = append(reply.Credentials, StaticCredential{
+				TxID:             in.TxID,
+				OutputIndex:      json.Uint32(in.OutputIndex),
+				SignatureIndices: sigIndices,
+			})
+		}
+	}
+	reply.Encoding = encoding
+	return nil
+}
+
+// transferableInputs returns the inputs described by [ins], in canonical
+// order.
+func transferableInputs(ins []StaticInput) []*avax.TransferableInput {
+	transferableIns := make([]*avax.TransferableInput, len(ins))
+	for i := range ins {
+		transferableIns[i] = ins[i].transferableInput()
+	}
+	utils.Sort(transferableIns)
+	return transferableIns
+}
+
+// transferableOutputs returns the outputs described by [outs], in canonical
+// order.
+func transferableOutputs(outs []StaticOutput, c codec.Manager) ([]*avax.TransferableOutput, error) {
+	transferableOuts := make([]*avax.TransferableOutput, len(outs))
+	for i := range outs {
+		out, err := outs[i].transferableOutput()
+		if err != nil {
+			return nil, err
+		}
+		transferableOuts[i] = out
+	}
+	avax.SortTransferableOutputs(transferableOuts, c)
+	return transferableOuts, nil
+}
+
+func newStaticParser() (txs.Parser, error) {
+	return txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	})
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var addrStrArray = []string{
@@ -101,3 +104,171 @@ func TestBuildGenesis(t *testing.T) {
 	reply := BuildGenesisReply{}
 	require.NoError(ss.BuildGenesis(nil, &args, &reply))
 }
+
+func TestBuildImportTx(t *testing.T) {
+	require := require.New(t)
+
+	addr := ids.GenerateTestShortID()
+	addrStr, err := address.Format("X", constants.UnitTestHRP, addr[:])
+	require.NoError(err)
+
+	var (
+		chainID       = ids.GenerateTestID()
+		sourceChainID = ids.GenerateTestID()
+		assetID       = ids.GenerateTestID()
+		// The inputs are provided out of order to ensure they are sorted.
+		inputs = []StaticInput{
+			{
+				TxID:             ids.ID{2},
+				AssetID:          assetID,
+				Amount:           1,
+				SignatureIndices: []json.Uint32{1},
+			},
+			{
+				TxID:             ids.ID{1},
+				AssetID:          assetID,
+				Amount:           2,
+				SignatureIndices: []json.Uint32{0},
+			},
+		}
+		importedInputs = []StaticInput{
+			{
+				TxID:             ids.ID{3},
+				AssetID:          assetID,
+				Amount:           3,
+				SignatureIndices: []json.Uint32{0, 1},
+			},
+		}
+	)
+
+	ss := CreateStaticService()
+	args := BuildImportTxArgs{
+		BuildBaseTxArgs: BuildBaseTxArgs{
+			NetworkID:    json.Uint32(constants.UnitTestID),
+			BlockchainID: chainID,
+			Inputs:       inputs,
+			Outputs: []StaticOutput{
+				{
+					AssetID:   assetID,
+					Amount:    5,
+					Threshold: 1,
+					Addresses: []string{addrStr},
+				},
+			},
+			Encoding: formatting.Hex,
+		},
+		SourceChain:    sourceChainID,
+		ImportedInputs: importedInputs,
+	}
+	reply := BuildUnsignedTxReply{}
+	require.NoError(ss.BuildImportTx(nil, &args, &reply))
+
+	require.Equal([]StaticCredential{
+		{
+			TxID:             ids.ID{1},
+			SignatureIndices: []json.Uint32{0},
+		},
+		{
+			TxID:             ids.ID{2},
+			SignatureIndices: []json.Uint32{1},
+		},
+		{
+			TxID:             ids.ID{3},
+			SignatureIndices: []json.Uint32{0, 1},
+		},
+	}, reply.Credentials)
+
+	unsignedBytes, err := formatting.Decode(reply.Encoding, reply.UnsignedTx)
+	require.NoError(err)
+
+	parser, err := newStaticParser()
+	require.NoError(err)
+
+	var utx txs.UnsignedTx
+	_, err = parser.Codec().Unmarshal(unsignedBytes, &utx)
+	require.NoError(err)
+	require.IsType(&txs.ImportTx{}, utx)
+
+	importTx := utx.(*txs.ImportTx)
+	require.Equal(constants.UnitTestID, importTx.NetworkID)
+	require.Equal(chainID, importTx.BlockchainID)
+	require.Equal(sourceChainID, importTx.SourceChain)
+	require.Len(importTx.Ins, 2)
+	require.Len(importTx.ImportedIns, 1)
+	require.Equal([]*avax.TransferableOutput{
+		{
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 5,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		},
+	}, importTx.Outs)
+}
+
+func TestBuildUnsignedTxInvalid(t *testing.T) {
+	addr := ids.GenerateTestShortID()
+	addrStr, err := address.Format("X", constants.UnitTestHRP, addr[:])
+	require.NoError(t, err)
+
+	largeMemo, err := formatting.Encode(formatting.Hex, make([]byte, avax.MaxMemoSize+1))
+	require.NoError(t, err)
+
+	assetID := ids.GenerateTestID()
+	input := StaticInput{
+		TxID:             ids.GenerateTestID(),
+		AssetID:          assetID,
+		Amount:           1,
+		SignatureIndices: []json.Uint32{0},
+	}
+	output := StaticOutput{
+		AssetID:   assetID,
+		Amount:    1,
+		Threshold: 1,
+		Addresses: []string{addrStr},
+	}
+
+	tests := []struct {
+		name        string
+		args        BuildBaseTxArgs
+		expectedErr error
+	}{
+		{
+			name: "insufficient funds",
+			args: BuildBaseTxArgs{
+				Outputs: []StaticOutput{output, output},
+				Inputs:  []StaticInput{input},
+			},
+			expectedErr: avax.ErrInsufficientFunds,
+		},
+		{
+			name: "duplicate inputs",
+			args: BuildBaseTxArgs{
+				Outputs: []StaticOutput{output},
+				Inputs:  []StaticInput{input, input},
+			},
+			expectedErr: avax.ErrInputsNotSortedUnique,
+		},
+		{
+			name: "memo too large",
+			args: BuildBaseTxArgs{
+				Outputs:  []StaticOutput{output},
+				Inputs:   []StaticInput{input},
+				Memo:     largeMemo,
+				Encoding: formatting.Hex,
+			},
+			expectedErr: avax.ErrMemoTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := CreateStaticService()
+			reply := BuildUnsignedTxReply{}
+			err := ss.BuildBaseTx(nil, &tt.args, &reply)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
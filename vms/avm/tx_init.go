@@ -96,6 +96,10 @@ func (t *txInit) CreateAssetTx(tx *txs.CreateAssetTx) error {
 	return t.BaseTx(&tx.BaseTx)
 }
 
+func (t *txInit) CreateCappedAssetTx(tx *txs.CreateCappedAssetTx) error {
+	return t.CreateAssetTx(&tx.CreateAssetTx)
+}
+
 func (t *txInit) ImportTx(tx *txs.ImportTx) error {
 	if err := t.init(); err != nil {
 		return err
@@ -126,6 +130,10 @@ func (t *txInit) ExportTx(tx *txs.ExportTx) error {
 	return t.BaseTx(&tx.BaseTx)
 }
 
+func (t *txInit) FeeRateTx(tx *txs.FeeRateTx) error {
+	return t.BaseTx(&tx.BaseTx)
+}
+
 func (t *txInit) OperationTx(tx *txs.OperationTx) error {
 	if err := t.init(); err != nil {
 		return err
@@ -5,6 +5,7 @@ package txs
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -14,19 +15,23 @@ import (
 )
 
 var (
-	_ codec.Registry = (*codecRegistry)(nil)
-	_ secp256k1fx.VM = (*fxVM)(nil)
+	_ codec.Registry           = (*codecRegistry)(nil)
+	_ codec.Registry           = (*deferredRegistry)(nil)
+	_ secp256k1fx.ExtensionsVM = (*fxVM)(nil)
 )
 
 type codecRegistry struct {
 	codecs      []codec.Registry
 	index       int
 	typeToIndex map[reflect.Type]int
+	// numTypes is the number of types registered through this registry
+	numTypes int
 }
 
 func (cr *codecRegistry) RegisterType(val interface{}) error {
 	valType := reflect.TypeOf(val)
 	cr.typeToIndex[valType] = cr.index
+	cr.numTypes++
 
 	errs := wrappers.Errs{}
 	for _, c := range cr.codecs {
@@ -35,12 +40,29 @@ func (cr *codecRegistry) RegisterType(val interface{}) error {
 	return errs.Err
 }
 
+// deferredRegistry records the types registered into it, so that they can be
+// registered after the types that they must be registered after.
+type deferredRegistry struct {
+	types []interface{}
+}
+
+func (dr *deferredRegistry) RegisterType(val interface{}) error {
+	dr.types = append(dr.types, val)
+	return nil
+}
+
 type fxVM struct {
 	typeToFxIndex map[reflect.Type]int
 
 	clock         *mockable.Clock
 	log           logging.Logger
 	codecRegistry codec.Registry
+	// extensionsRegistry is the registry of the types that were added to an fx
+	// after it was first deployed
+	extensionsRegistry codec.Registry
+	// extensionsActivationTime is the time from which the types that were
+	// added to an fx after it was first deployed may be used
+	extensionsActivationTime time.Time
 }
 
 func (vm *fxVM) Clock() *mockable.Clock {
@@ -51,6 +73,14 @@ func (vm *fxVM) CodecRegistry() codec.Registry {
 	return vm.codecRegistry
 }
 
+func (vm *fxVM) ExtensionsCodecRegistry() codec.Registry {
+	return vm.extensionsRegistry
+}
+
+func (vm *fxVM) ExtensionsActivationTime() time.Time {
+	return vm.extensionsActivationTime
+}
+
 func (vm *fxVM) Logger() logging.Logger {
 	return vm.log
 }
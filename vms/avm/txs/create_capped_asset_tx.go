@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	_ UnsignedTx             = (*CreateCappedAssetTx)(nil)
+	_ secp256k1fx.UnsignedTx = (*CreateCappedAssetTx)(nil)
+
+	ErrZeroMaxSupply         = errors.New("max supply must be non-zero")
+	ErrMaxSupplyExceeded     = errors.New("issuance exceeds max supply")
+	errInitialSupplyOverflow = errors.New("initial supply overflows")
+)
+
+// CreateCappedAssetTx is a transaction that creates a new asset whose
+// cumulative issuance, including its initial supply, can never exceed
+// [MaxSupply].
+type CreateCappedAssetTx struct {
+	CreateAssetTx `serialize:"true"`
+
+	// Maximum amount of the asset that may ever be issued
+	MaxSupply uint64 `serialize:"true" json:"maxSupply"`
+}
+
+// InitialSupply returns the amount of the asset issued by the initial states.
+func (t *CreateCappedAssetTx) InitialSupply() (uint64, error) {
+	var supply uint64
+	for _, state := range t.States {
+		for _, out := range state.Outs {
+			amounter, ok := out.(avax.Amounter)
+			if !ok {
+				continue
+			}
+			var err error
+			supply, err = safemath.Add64(supply, amounter.Amount())
+			if err != nil {
+				return 0, fmt.Errorf("%w: %w", errInitialSupplyOverflow, err)
+			}
+		}
+	}
+	return supply, nil
+}
+
+func (t *CreateCappedAssetTx) Visit(v Visitor) error {
+	return v.CreateCappedAssetTx(t)
+}
+
+// GetCreateAssetTx returns the CreateAssetTx that defines the asset created by
+// [tx]. Returns false if [tx] doesn't create an asset.
+func GetCreateAssetTx(tx UnsignedTx) (*CreateAssetTx, bool) {
+	switch tx := tx.(type) {
+	case *CreateAssetTx:
+		return tx, true
+	case *CreateCappedAssetTx:
+		return &tx.CreateAssetTx, true
+	default:
+		return nil, false
+	}
+}
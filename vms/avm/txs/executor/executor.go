@@ -13,6 +13,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/state"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/freezefx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var _ txs.Visitor = (*Executor)(nil)
@@ -57,11 +59,35 @@ func (e *Executor) CreateAssetTx(tx *txs.CreateAssetTx) error {
 	return nil
 }
 
+func (e *Executor) CreateCappedAssetTx(tx *txs.CreateCappedAssetTx) error {
+	if err := e.CreateAssetTx(&tx.CreateAssetTx); err != nil {
+		return err
+	}
+
+	initialSupply, err := tx.InitialSupply()
+	if err != nil {
+		return err
+	}
+	e.State.SetAssetSupply(e.Tx.ID(), &state.AssetSupply{
+		MaxSupply: tx.MaxSupply,
+		Issued:    initialSupply,
+	})
+	return nil
+}
+
 func (e *Executor) OperationTx(tx *txs.OperationTx) error {
 	if err := e.BaseTx(&tx.BaseTx); err != nil {
 		return err
 	}
 
+	supplies, err := mintedSupplies(e.State, tx)
+	if err != nil {
+		return err
+	}
+	for assetID, supply := range supplies {
+		e.State.SetAssetSupply(assetID, supply)
+	}
+
 	txID := e.Tx.ID()
 	index := len(tx.Outs)
 	for _, op := range tx.Ops {
@@ -80,7 +106,27 @@ func (e *Executor) OperationTx(tx *txs.OperationTx) error {
 			})
 			index++
 		}
+		switch op := op.Op.(type) {
+		case *freezefx.FreezeOperation:
+			e.State.SetAssetFrozen(asset, op.Frozen)
+		case *secp256k1fx.UpdateMetadataOperation:
+			e.State.SetAssetMetadata(asset, &state.AssetMetadata{
+				Name:         op.Name,
+				Symbol:       op.Symbol,
+				Denomination: op.Denomination,
+				URI:          op.URI,
+			})
+		}
+	}
+	return nil
+}
+
+func (e *Executor) FeeRateTx(tx *txs.FeeRateTx) error {
+	if err := e.BaseTx(&tx.BaseTx); err != nil {
+		return err
 	}
+
+	e.State.SetFeeRate(&tx.Rate)
 	return nil
 }
 
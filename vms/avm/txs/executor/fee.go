@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/avm/config"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/freezefx"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// txFee returns the fee that [tx] must burn at [timestamp], given the
+// [staticFee] of its type. Before dynamic fees are activated, this is the
+// static fee.
+func txFee(dynamicFees *config.DynamicFees, tx *txs.Tx, staticFee uint64, timestamp time.Time) (uint64, error) {
+	if dynamicFees == nil || !dynamicFees.IsActivated(timestamp) {
+		return staticFee, nil
+	}
+	return dynamicFees.Fee(
+		staticFee,
+		uint64(len(tx.Bytes())),
+		uint64(tx.Unsigned.InputIDs().Len()),
+		uint64(numSignatures(tx)),
+	)
+}
+
+// numSignatures returns the number of signatures included in the credentials
+// of [tx].
+func numSignatures(tx *txs.Tx) int {
+	numSigs := 0
+	for _, cred := range tx.Creds {
+		switch cred := cred.Credential.(type) {
+		case *secp256k1fx.Credential:
+			numSigs += len(cred.Sigs)
+		case *nftfx.Credential:
+			numSigs += len(cred.Sigs)
+		case *propertyfx.Credential:
+			numSigs += len(cred.Sigs)
+		case *freezefx.Credential:
+			numSigs += len(cred.Sigs)
+		}
+	}
+	return numSigs
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import "fmt"
+
+const (
+	InputKind         InputErrorKind = "input"
+	ImportedInputKind InputErrorKind = "importedInput"
+	OperationKind     InputErrorKind = "operation"
+)
+
+// InputErrorKind describes which list of a transaction an input is in.
+type InputErrorKind string
+
+// InputError is returned during semantic verification when an input, or an
+// operation, of a transaction can't be spent.
+type InputError struct {
+	Kind InputErrorKind
+	// Index is the index of the input in the list described by [Kind].
+	Index int
+	// CredentialIndex is the index of the credential that was used to spend
+	// the input.
+	CredentialIndex int
+	Err             error
+}
+
+func (e *InputError) Error() string {
+	return fmt.Sprintf("failed to verify %s %d with credential %d: %s",
+		e.Kind,
+		e.Index,
+		e.CredentialIndex,
+		e.Err,
+	)
+}
+
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
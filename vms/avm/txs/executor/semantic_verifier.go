@@ -6,24 +6,39 @@ package executor
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/avm/state"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/freezefx"
 )
 
 var (
 	_ txs.Visitor = (*SemanticVerifier)(nil)
 
+	ErrAssetFrozen = errors.New("asset is frozen")
+
 	errAssetIDMismatch = errors.New("asset IDs in the input don't match the utxo")
 	errNotAnAsset      = errors.New("not an asset")
 	errIncompatibleFx  = errors.New("incompatible feature extension")
 	errUnknownFx       = errors.New("unknown feature extension")
+	errFxNotActivated  = errors.New("feature extension isn't activated")
+	errNoFeeRate       = errors.New("no fee rate has been published")
+	errInsufficientFee = errors.New("insufficient fee")
+	errNotPermissioned = errors.New("feature extension doesn't support permissions")
 )
 
+// permissionVerifier is implemented by the fxs that are able to verify that a
+// tx was authorized by an owner.
+type permissionVerifier interface {
+	VerifyPermission(tx, in, cred, owner interface{}) error
+}
+
 type SemanticVerifier struct {
 	*Backend
 	State state.ReadOnlyChain
@@ -31,12 +46,29 @@ type SemanticVerifier struct {
 }
 
 func (v *SemanticVerifier) BaseTx(tx *txs.BaseTx) error {
+	err := v.verifyFee(
+		v.Config.TxFee,
+		[][]*avax.TransferableInput{tx.Ins},
+		[][]*avax.TransferableOutput{tx.Outs},
+	)
+	if err != nil {
+		return err
+	}
+	return v.verifyBaseTx(tx)
+}
+
+func (v *SemanticVerifier) verifyBaseTx(tx *txs.BaseTx) error {
 	for i, in := range tx.Ins {
 		// Note: Verification of the length of [t.tx.Creds] happens during
 		// syntactic verification, which happens before semantic verification.
 		cred := v.Tx.Creds[i].Credential
 		if err := v.verifyTransfer(tx, in, cred); err != nil {
-			return err
+			return &InputError{
+				Kind:            InputKind,
+				Index:           i,
+				CredentialIndex: i,
+				Err:             err,
+			}
 		}
 	}
 
@@ -56,11 +88,48 @@ func (v *SemanticVerifier) BaseTx(tx *txs.BaseTx) error {
 }
 
 func (v *SemanticVerifier) CreateAssetTx(tx *txs.CreateAssetTx) error {
-	return v.BaseTx(&tx.BaseTx)
+	err := v.verifyFee(
+		v.Config.CreateAssetTxFee,
+		[][]*avax.TransferableInput{tx.Ins},
+		[][]*avax.TransferableOutput{tx.Outs},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Syntactic verification only checks that the fxs of the initial states
+	// exist, so it is checked here that they may be used at the chain time.
+	for _, state := range tx.States {
+		if err := v.verifyFxActivated(int(state.FxIndex)); err != nil {
+			return err
+		}
+	}
+	return v.verifyBaseTx(&tx.BaseTx)
+}
+
+func (v *SemanticVerifier) CreateCappedAssetTx(tx *txs.CreateCappedAssetTx) error {
+	// Syntactic verification only checks that capped assets were activated
+	// at the expected chain time, so it is checked again against the chain
+	// time the tx is executed at.
+	if cappedAssets := v.Config.CappedAssets; cappedAssets == nil || !cappedAssets.IsActivated(v.State.GetTimestamp()) {
+		return errCappedAssetsDisabled
+	}
+	return v.CreateAssetTx(&tx.CreateAssetTx)
 }
 
 func (v *SemanticVerifier) OperationTx(tx *txs.OperationTx) error {
-	if err := v.BaseTx(&tx.BaseTx); err != nil {
+	err := v.verifyFee(
+		v.Config.TxFee,
+		[][]*avax.TransferableInput{tx.Ins},
+		[][]*avax.TransferableOutput{tx.Outs},
+	)
+	if err != nil {
+		return err
+	}
+	if err := v.verifyBaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	if _, err := mintedSupplies(v.State, tx); err != nil {
 		return err
 	}
 
@@ -74,14 +143,30 @@ func (v *SemanticVerifier) OperationTx(tx *txs.OperationTx) error {
 		// syntactic verification, which happens before semantic verification.
 		cred := v.Tx.Creds[i+offset].Credential
 		if err := v.verifyOperation(tx, op, cred); err != nil {
-			return err
+			return &InputError{
+				Kind:            OperationKind,
+				Index:           i,
+				CredentialIndex: i + offset,
+				Err:             err,
+			}
 		}
 	}
 	return nil
 }
 
 func (v *SemanticVerifier) ImportTx(tx *txs.ImportTx) error {
-	if err := v.BaseTx(&tx.BaseTx); err != nil {
+	err := v.verifyFee(
+		v.Config.TxFee,
+		[][]*avax.TransferableInput{
+			tx.Ins,
+			tx.ImportedIns,
+		},
+		[][]*avax.TransferableOutput{tx.Outs},
+	)
+	if err != nil {
+		return err
+	}
+	if err := v.verifyBaseTx(&tx.BaseTx); err != nil {
 		return err
 	}
 
@@ -115,14 +200,30 @@ func (v *SemanticVerifier) ImportTx(tx *txs.ImportTx) error {
 		// syntactic verification, which happens before semantic verification.
 		cred := v.Tx.Creds[i+offset].Credential
 		if err := v.verifyTransferOfUTXO(tx, in, cred, &utxo); err != nil {
-			return err
+			return &InputError{
+				Kind:            ImportedInputKind,
+				Index:           i,
+				CredentialIndex: i + offset,
+				Err:             err,
+			}
 		}
 	}
 	return nil
 }
 
 func (v *SemanticVerifier) ExportTx(tx *txs.ExportTx) error {
-	if err := v.BaseTx(&tx.BaseTx); err != nil {
+	err := v.verifyFee(
+		v.Config.TxFee,
+		[][]*avax.TransferableInput{tx.Ins},
+		[][]*avax.TransferableOutput{
+			tx.Outs,
+			tx.ExportedOuts,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if err := v.verifyBaseTx(&tx.BaseTx); err != nil {
 		return err
 	}
 
@@ -146,6 +247,82 @@ func (v *SemanticVerifier) ExportTx(tx *txs.ExportTx) error {
 	return nil
 }
 
+func (v *SemanticVerifier) FeeRateTx(tx *txs.FeeRateTx) error {
+	if err := v.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+
+	// Syntactic verification only checks that fee conversion was activated
+	// at the expected chain time, so it is checked again against the chain
+	// time the tx is executed at.
+	if !v.Config.FeeConversion.IsActivated(v.State.GetTimestamp()) {
+		return errFeeConversionDisabled
+	}
+
+	// Note: Verification of the length of [t.tx.Creds] and that fee
+	// conversion is configured happens during syntactic verification, which
+	// happens before semantic verification.
+	credIndex := len(tx.Ins)
+	cred := v.Tx.Creds[credIndex].Credential
+	fxIndex, err := v.getFx(cred)
+	if err != nil {
+		return err
+	}
+
+	fx, ok := v.Fxs[fxIndex].Fx.(permissionVerifier)
+	if !ok {
+		return errNotPermissioned
+	}
+	return fx.VerifyPermission(tx, &tx.OracleAuth, cred, v.Config.FeeConversion.Oracle)
+}
+
+// verifyFee verifies that the tx burns [fee], increased by the dynamic fee if
+// it is activated, of the fee asset or, once fee conversion is activated, the
+// equivalent amount of the fee conversion asset at the most recently published
+// rate. If fee conversion isn't configured, the fee was verified during
+// syntactic verification.
+func (v *SemanticVerifier) verifyFee(
+	fee uint64,
+	ins [][]*avax.TransferableInput,
+	outs [][]*avax.TransferableOutput,
+) error {
+	conversion := v.Config.FeeConversion
+	if conversion == nil {
+		return nil
+	}
+
+	timestamp := v.State.GetTimestamp()
+	if v.Config.DynamicFees != nil {
+		dynamicFee, err := txFee(v.Config.DynamicFees, v.Tx, fee, timestamp)
+		if err != nil {
+			return err
+		}
+		fee = dynamicFee
+	}
+
+	err := avax.VerifyTx(fee, v.FeeAssetID, ins, outs, v.Codec)
+	if err == nil || !conversion.IsActivated(timestamp) {
+		return err
+	}
+
+	rate, err := v.State.GetFeeRate()
+	if err == database.ErrNotFound {
+		return fmt.Errorf("%w: fee asset must be burned", errNoFeeRate)
+	}
+	if err != nil {
+		return err
+	}
+
+	convertedFee, err := rate.Convert(fee)
+	if err != nil {
+		return err
+	}
+	if err := avax.VerifyTx(convertedFee, conversion.AssetID, ins, outs, v.Codec); err != nil {
+		return fmt.Errorf("%w: %w", errInsufficientFee, err)
+	}
+	return nil
+}
+
 func (v *SemanticVerifier) verifyTransfer(
 	tx txs.UnsignedTx,
 	in *avax.TransferableInput,
@@ -170,6 +347,10 @@ func (v *SemanticVerifier) verifyTransferOfUTXO(
 		return errAssetIDMismatch
 	}
 
+	if err := v.verifyNotFrozen(inAssetID); err != nil {
+		return err
+	}
+
 	fxIndex, err := v.getFx(cred)
 	if err != nil {
 		return err
@@ -206,6 +387,14 @@ func (v *SemanticVerifier) verifyOperation(
 		utxos[i] = utxo.Out
 	}
 
+	// Freeze operations must be allowed on frozen assets so that they can be
+	// unfrozen.
+	if _, ok := op.Op.(*freezefx.FreezeOperation); !ok {
+		if err := v.verifyNotFrozen(opAssetID); err != nil {
+			return err
+		}
+	}
+
 	fxIndex, err := v.getFx(op.Op)
 	if err != nil {
 		return err
@@ -219,6 +408,17 @@ func (v *SemanticVerifier) verifyOperation(
 	return fx.VerifyOperation(tx, op.Op, cred, utxos)
 }
 
+func (v *SemanticVerifier) verifyNotFrozen(assetID ids.ID) error {
+	frozen, err := v.State.IsAssetFrozen(assetID)
+	if err != nil {
+		return err
+	}
+	if frozen {
+		return fmt.Errorf("%w: %s", ErrAssetFrozen, assetID)
+	}
+	return nil
+}
+
 func (v *SemanticVerifier) verifyFxUsage(
 	fxID int,
 	assetID ids.ID,
@@ -228,7 +428,7 @@ func (v *SemanticVerifier) verifyFxUsage(
 		return err
 	}
 
-	createAssetTx, ok := tx.Unsigned.(*txs.CreateAssetTx)
+	createAssetTx, ok := txs.GetCreateAssetTx(tx.Unsigned)
 	if !ok {
 		return errNotAnAsset
 	}
@@ -248,5 +448,20 @@ func (v *SemanticVerifier) getFx(val interface{}) (int, error) {
 	if !exists {
 		return 0, errUnknownFx
 	}
-	return fx, nil
+	return fx, v.verifyFxActivated(fx)
+}
+
+// verifyFxActivated verifies that the fx at [fxIndex] may be used at the chain
+// time.
+func (v *SemanticVerifier) verifyFxActivated(fxIndex int) error {
+	fx := v.Fxs[fxIndex]
+	// The fxs the chain was created with don't have an activation time, so
+	// the chain time doesn't need to be read for them.
+	if fx.ActivationTime.IsZero() {
+		return nil
+	}
+	if !fx.IsActivated(v.State.GetTimestamp()) {
+		return fmt.Errorf("%w: %s activates at %s", errFxNotActivated, fx.ID, fx.ActivationTime)
+	}
+	return nil
 }
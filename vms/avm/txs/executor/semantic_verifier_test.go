@@ -6,6 +6,7 @@ package executor
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -21,6 +22,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/avm/block"
+	"github.com/ava-labs/avalanchego/vms/avm/config"
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 	"github.com/ava-labs/avalanchego/vms/avm/state"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
@@ -38,6 +41,7 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 		typeToFxIndex,
 		new(mockable.Clock),
 		logging.NoWarn{},
+		time.Time{},
 		[]fxs.Fx{
 			secpFx,
 		},
@@ -125,6 +129,7 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 			name: "valid",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil)
 				state.EXPECT().GetTx(asset.ID).Return(&createAssetTx, nil)
@@ -145,10 +150,35 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "frozen asset",
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				state := state.NewMockChain(ctrl)
+
+				state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil)
+				state.EXPECT().IsAssetFrozen(asset.ID).Return(true, nil)
+
+				return state
+			},
+			txFunc: func(require *require.Assertions) *txs.Tx {
+				tx := &txs.Tx{
+					Unsigned: &baseTx,
+				}
+				require.NoError(tx.SignSECP256K1Fx(
+					codec,
+					[][]*secp256k1.PrivateKey{
+						{keys[0]},
+					},
+				))
+				return tx
+			},
+			err: ErrAssetFrozen,
+		},
 		{
 			name: "assetID mismatch",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				utxo := utxo
 				utxo.Asset.ID = ids.GenerateTestID()
@@ -175,6 +205,7 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 			name: "not allowed input feature extension",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				unsignedCreateAssetTx := unsignedCreateAssetTx
 				unsignedCreateAssetTx.States = nil
@@ -206,6 +237,7 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 			name: "invalid signature",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil)
 				state.EXPECT().GetTx(asset.ID).Return(&createAssetTx, nil)
@@ -230,6 +262,7 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 			name: "missing UTXO",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(nil, database.ErrNotFound)
 
@@ -253,6 +286,7 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 			name: "invalid UTXO amount",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				output := output
 				output.Amt--
@@ -283,6 +317,7 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 			name: "not allowed output feature extension",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				unsignedCreateAssetTx := unsignedCreateAssetTx
 				unsignedCreateAssetTx.States = nil
@@ -319,6 +354,7 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 			name: "unknown asset",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil)
 				state.EXPECT().GetTx(asset.ID).Return(nil, database.ErrNotFound)
@@ -343,6 +379,7 @@ func TestSemanticVerifierBaseTx(t *testing.T) {
 			name: "not an asset",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				tx := txs.Tx{
 					Unsigned: &baseTx,
@@ -400,6 +437,7 @@ func TestSemanticVerifierExportTx(t *testing.T) {
 		typeToFxIndex,
 		new(mockable.Clock),
 		logging.NoWarn{},
+		time.Time{},
 		[]fxs.Fx{
 			secpFx,
 		},
@@ -491,6 +529,7 @@ func TestSemanticVerifierExportTx(t *testing.T) {
 			name: "valid",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil)
 				state.EXPECT().GetTx(asset.ID).Return(&createAssetTx, nil)
@@ -515,6 +554,7 @@ func TestSemanticVerifierExportTx(t *testing.T) {
 			name: "assetID mismatch",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				utxo := utxo
 				utxo.Asset.ID = ids.GenerateTestID()
@@ -541,6 +581,7 @@ func TestSemanticVerifierExportTx(t *testing.T) {
 			name: "not allowed input feature extension",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				unsignedCreateAssetTx := unsignedCreateAssetTx
 				unsignedCreateAssetTx.States = nil
@@ -572,6 +613,7 @@ func TestSemanticVerifierExportTx(t *testing.T) {
 			name: "invalid signature",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil)
 				state.EXPECT().GetTx(asset.ID).Return(&createAssetTx, nil)
@@ -596,6 +638,7 @@ func TestSemanticVerifierExportTx(t *testing.T) {
 			name: "missing UTXO",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(nil, database.ErrNotFound)
 
@@ -619,6 +662,7 @@ func TestSemanticVerifierExportTx(t *testing.T) {
 			name: "invalid UTXO amount",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				output := output
 				output.Amt--
@@ -649,6 +693,7 @@ func TestSemanticVerifierExportTx(t *testing.T) {
 			name: "not allowed output feature extension",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				unsignedCreateAssetTx := unsignedCreateAssetTx
 				unsignedCreateAssetTx.States = nil
@@ -685,6 +730,7 @@ func TestSemanticVerifierExportTx(t *testing.T) {
 			name: "unknown asset",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil)
 				state.EXPECT().GetTx(asset.ID).Return(nil, database.ErrNotFound)
@@ -709,6 +755,7 @@ func TestSemanticVerifierExportTx(t *testing.T) {
 			name: "not an asset",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 				tx := txs.Tx{
 					Unsigned: &baseTx,
@@ -768,6 +815,7 @@ func TestSemanticVerifierExportTxDifferentSubnet(t *testing.T) {
 		typeToFxIndex,
 		new(mockable.Clock),
 		logging.NoWarn{},
+		time.Time{},
 		[]fxs.Fx{
 			secpFx,
 		},
@@ -850,6 +898,7 @@ func TestSemanticVerifierExportTxDifferentSubnet(t *testing.T) {
 	}
 
 	state := state.NewMockChain(ctrl)
+	state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 
 	state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil)
 	state.EXPECT().GetTx(asset.ID).Return(&createAssetTx, nil)
@@ -890,6 +939,7 @@ func TestSemanticVerifierImportTx(t *testing.T) {
 		typeToFxIndex,
 		new(mockable.Clock),
 		logging.NoWarn{},
+		time.Time{},
 		[]fxs.Fx{
 			fx,
 		},
@@ -1007,6 +1057,7 @@ func TestSemanticVerifierImportTx(t *testing.T) {
 			name: "valid",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil).AnyTimes()
 				state.EXPECT().GetTx(asset.ID).Return(&createAssetTx, nil).AnyTimes()
 				return state
@@ -1020,6 +1071,7 @@ func TestSemanticVerifierImportTx(t *testing.T) {
 			name: "not allowed input feature extension",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 				unsignedCreateAssetTx := unsignedCreateAssetTx
 				unsignedCreateAssetTx.States = nil
 				createAssetTx := txs.Tx{
@@ -1038,6 +1090,7 @@ func TestSemanticVerifierImportTx(t *testing.T) {
 			name: "invalid signature",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil).AnyTimes()
 				state.EXPECT().GetTx(asset.ID).Return(&createAssetTx, nil).AnyTimes()
 				return state
@@ -1060,6 +1113,7 @@ func TestSemanticVerifierImportTx(t *testing.T) {
 			name: "not allowed output feature extension",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 				unsignedCreateAssetTx := unsignedCreateAssetTx
 				unsignedCreateAssetTx.States = nil
 				createAssetTx := txs.Tx{
@@ -1089,6 +1143,7 @@ func TestSemanticVerifierImportTx(t *testing.T) {
 			name: "unknown asset",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 				state.EXPECT().GetUTXO(utxoID.InputID()).Return(&utxo, nil).AnyTimes()
 				state.EXPECT().GetTx(asset.ID).Return(nil, database.ErrNotFound)
 				return state
@@ -1102,6 +1157,7 @@ func TestSemanticVerifierImportTx(t *testing.T) {
 			name: "not an asset",
 			stateFunc: func(ctrl *gomock.Controller) state.Chain {
 				state := state.NewMockChain(ctrl)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
 				tx := txs.Tx{
 					Unsigned: &baseTx,
 				}
@@ -1131,3 +1187,348 @@ func TestSemanticVerifierImportTx(t *testing.T) {
 		})
 	}
 }
+
+func TestSemanticVerifierFeeConversion(t *testing.T) {
+	ctx := newContext(t)
+
+	parser, err := txs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+	})
+	require.NoError(t, err)
+
+	feeAssetID := ids.GenerateTestID()
+	conversionAssetID := ids.GenerateTestID()
+	activationTime := time.Unix(1_700_000_000, 0)
+	conversionConfig := config.Config{
+		TxFee: 10,
+		FeeConversion: &config.FeeConversion{
+			ActivationTime: activationTime,
+			AssetID:        conversionAssetID,
+			Oracle:         &secp256k1fx.OutputOwners{},
+		},
+	}
+	rate := &txs.FeeRate{
+		Numerator:   3,
+		Denominator: 2,
+	}
+
+	burn := func(assetID ids.ID, amount uint64) [][]*avax.TransferableInput {
+		return [][]*avax.TransferableInput{{
+			{
+				Asset: avax.Asset{ID: assetID},
+				In: &secp256k1fx.TransferInput{
+					Amt: amount,
+				},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		config      *config.Config
+		stateFunc   func(*gomock.Controller) state.Chain
+		ins         [][]*avax.TransferableInput
+		expectedErr error
+	}{
+		{
+			name:   "conversion disabled",
+			config: &feeConfig,
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				return state.NewMockChain(ctrl)
+			},
+			ins:         burn(conversionAssetID, 15),
+			expectedErr: nil,
+		},
+		{
+			name:   "fee asset burned",
+			config: &conversionConfig,
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				state := state.NewMockChain(ctrl)
+				state.EXPECT().GetTimestamp().Return(activationTime)
+				return state
+			},
+			ins:         burn(feeAssetID, 10),
+			expectedErr: nil,
+		},
+		{
+			name:   "conversion asset burned",
+			config: &conversionConfig,
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				state := state.NewMockChain(ctrl)
+				state.EXPECT().GetTimestamp().Return(activationTime)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
+				state.EXPECT().GetFeeRate().Return(rate, nil)
+				return state
+			},
+			ins:         burn(conversionAssetID, 15),
+			expectedErr: nil,
+		},
+		{
+			name:   "insufficient conversion asset burned",
+			config: &conversionConfig,
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				state := state.NewMockChain(ctrl)
+				state.EXPECT().GetTimestamp().Return(activationTime)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
+				state.EXPECT().GetFeeRate().Return(rate, nil)
+				return state
+			},
+			ins:         burn(conversionAssetID, 14),
+			expectedErr: errInsufficientFee,
+		},
+		{
+			name:   "no published rate",
+			config: &conversionConfig,
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				state := state.NewMockChain(ctrl)
+				state.EXPECT().GetTimestamp().Return(activationTime)
+				state.EXPECT().IsAssetFrozen(gomock.Any()).Return(false, nil).AnyTimes()
+				state.EXPECT().GetFeeRate().Return(nil, database.ErrNotFound)
+				return state
+			},
+			ins:         burn(conversionAssetID, 15),
+			expectedErr: errNoFeeRate,
+		},
+		{
+			name:   "conversion asset burned before activation",
+			config: &conversionConfig,
+			stateFunc: func(ctrl *gomock.Controller) state.Chain {
+				state := state.NewMockChain(ctrl)
+				state.EXPECT().GetTimestamp().Return(activationTime.Add(-time.Second))
+				return state
+			},
+			ins:         burn(conversionAssetID, 15),
+			expectedErr: avax.ErrInsufficientFunds,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			verifier := &SemanticVerifier{
+				Backend: &Backend{
+					Ctx:        ctx,
+					Config:     test.config,
+					Codec:      parser.Codec(),
+					FeeAssetID: feeAssetID,
+				},
+				State: test.stateFunc(ctrl),
+			}
+			err := verifier.verifyFee(
+				test.config.TxFee,
+				test.ins,
+				nil,
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestSemanticVerifierCreateAssetTxFxNotActivated(t *testing.T) {
+	activationTime := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name        string
+		timestamp   time.Time
+		expectedErr error
+	}{
+		{
+			name:        "before activation",
+			timestamp:   activationTime.Add(-time.Second),
+			expectedErr: errFxNotActivated,
+		},
+		{
+			name:        "at activation",
+			timestamp:   activationTime,
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			state := state.NewMockChain(ctrl)
+			state.EXPECT().GetTimestamp().Return(test.timestamp).AnyTimes()
+
+			tx := &txs.Tx{Unsigned: &txs.CreateAssetTx{
+				States: []*txs.InitialState{
+					{
+						FxIndex: 1,
+					},
+				},
+			}}
+			err := tx.Unsigned.Visit(&SemanticVerifier{
+				Backend: &Backend{
+					Ctx:    newContext(t),
+					Config: &config.Config{},
+					Fxs: []*fxs.ParsedFx{
+						{
+							ID: secp256k1fx.ID,
+							Fx: &secp256k1fx.Fx{},
+						},
+						{
+							ID:             ids.GenerateTestID(),
+							Fx:             &secp256k1fx.Fx{},
+							ActivationTime: activationTime,
+						},
+					},
+				},
+				State: state,
+				Tx:    tx,
+			})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestSemanticVerifierCreateCappedAssetTxNotActivated(t *testing.T) {
+	activationTime := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name   string
+		config *config.Config
+	}{
+		{
+			name:   "capped assets disabled",
+			config: &feeConfig,
+		},
+		{
+			name: "before activation",
+			config: &config.Config{
+				CappedAssets: &config.CappedAssets{
+					ActivationTime: activationTime,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			state := state.NewMockChain(ctrl)
+			state.EXPECT().GetTimestamp().Return(activationTime.Add(-time.Second)).AnyTimes()
+
+			tx := &txs.Tx{Unsigned: &txs.CreateCappedAssetTx{
+				MaxSupply: 1,
+			}}
+			err := tx.Unsigned.Visit(&SemanticVerifier{
+				Backend: &Backend{
+					Ctx:    newContext(t),
+					Config: test.config,
+				},
+				State: state,
+				Tx:    tx,
+			})
+			require.ErrorIs(t, err, errCappedAssetsDisabled)
+		})
+	}
+}
+
+func TestSemanticVerifierFeeRateTx(t *testing.T) {
+	ctx := newContext(t)
+
+	typeToFxIndex := make(map[reflect.Type]int)
+	secpFx := &secp256k1fx.Fx{}
+	parser, err := block.NewCustomParser(
+		typeToFxIndex,
+		new(mockable.Clock),
+		logging.NoWarn{},
+		time.Time{},
+		[]fxs.Fx{
+			secpFx,
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, secpFx.Bootstrapped())
+
+	codec := parser.Codec()
+	activationTime := time.Unix(1_700_000_000, 0)
+	backend := &Backend{
+		Ctx: ctx,
+		Config: &config.Config{
+			FeeConversion: &config.FeeConversion{
+				ActivationTime: activationTime,
+				AssetID:        ids.GenerateTestID(),
+				Oracle: &secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs: []ids.ShortID{
+						keys[0].Address(),
+					},
+				},
+			},
+		},
+		Fxs: []*fxs.ParsedFx{
+			{
+				ID: secp256k1fx.ID,
+				Fx: secpFx,
+			},
+		},
+		TypeToFxIndex: typeToFxIndex,
+		Codec:         codec,
+		FeeAssetID:    ids.GenerateTestID(),
+		Bootstrapped:  true,
+	}
+
+	tests := []struct {
+		name        string
+		key         *secp256k1.PrivateKey
+		timestamp   time.Time
+		expectedErr error
+	}{
+		{
+			name:        "signed by oracle",
+			key:         keys[0],
+			timestamp:   activationTime,
+			expectedErr: nil,
+		},
+		{
+			name:        "not signed by oracle",
+			key:         keys[1],
+			timestamp:   activationTime,
+			expectedErr: secp256k1fx.ErrWrongSig,
+		},
+		{
+			name:        "before activation",
+			key:         keys[0],
+			timestamp:   activationTime.Add(-time.Second),
+			expectedErr: errFeeConversionDisabled,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tx := &txs.Tx{
+				Unsigned: &txs.FeeRateTx{
+					BaseTx: txs.BaseTx{
+						BaseTx: avax.BaseTx{
+							NetworkID:    constants.UnitTestID,
+							BlockchainID: ctx.ChainID,
+						},
+					},
+					Rate: txs.FeeRate{
+						Numerator:   1,
+						Denominator: 1,
+					},
+					OracleAuth: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				},
+			}
+			require.NoError(tx.SignSECP256K1Fx(
+				codec,
+				[][]*secp256k1.PrivateKey{
+					{test.key},
+				},
+			))
+
+			state := state.NewMockChain(gomock.NewController(t))
+			state.EXPECT().GetTimestamp().Return(test.timestamp).AnyTimes()
+
+			err := tx.Unsigned.Visit(&SemanticVerifier{
+				Backend: backend,
+				State:   state,
+				Tx:      tx,
+			})
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
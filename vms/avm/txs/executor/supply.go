@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm/state"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// mintedSupplies returns the supplies, after [tx] is executed, of the assets
+// with a max supply that are minted by [tx]. Returns an error if [tx] would
+// issue more than the max supply of an asset.
+func mintedSupplies(chain state.ReadOnlyChain, tx *txs.OperationTx) (map[ids.ID]*state.AssetSupply, error) {
+	supplies := make(map[ids.ID]*state.AssetSupply)
+	for _, op := range tx.Ops {
+		mintOp, ok := op.Op.(*secp256k1fx.MintOperation)
+		if !ok {
+			continue
+		}
+
+		assetID := op.AssetID()
+		supply, ok := supplies[assetID]
+		if !ok {
+			parentSupply, err := chain.GetAssetSupply(assetID)
+			if err == database.ErrNotFound {
+				// The asset doesn't have a max supply.
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			supplyCopy := *parentSupply
+			supply = &supplyCopy
+			supplies[assetID] = supply
+		}
+
+		amount := mintOp.TransferOutput.Amt
+		issued, err := safemath.Add64(supply.Issued, amount)
+		if err != nil || issued > supply.MaxSupply {
+			return nil, fmt.Errorf("%w: minting %d of %s with %d of %d already issued",
+				txs.ErrMaxSupplyExceeded,
+				amount,
+				assetID,
+				supply.Issued,
+				supply.MaxSupply,
+			)
+		}
+		supply.Issued = issued
+	}
+	return supplies, nil
+}
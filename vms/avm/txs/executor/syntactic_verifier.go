@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -14,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 const (
@@ -43,11 +45,16 @@ var (
 	errDoubleSpend                  = errors.New("inputs attempt to double spend an input")
 	errNoImportInputs               = errors.New("no import inputs")
 	errNoExportOutputs              = errors.New("no export outputs")
+	errFeeConversionDisabled        = errors.New("fee conversion is disabled")
+	errCappedAssetsDisabled         = errors.New("capped assets are disabled")
 )
 
 type SyntacticVerifier struct {
 	*Backend
 	Tx *txs.Tx
+	// Timestamp is the chain time at which the tx is expected to be accepted.
+	// It determines whether dynamic fees are enforced.
+	Timestamp time.Time
 }
 
 func (v *SyntacticVerifier) BaseTx(tx *txs.BaseTx) error {
@@ -55,8 +62,13 @@ func (v *SyntacticVerifier) BaseTx(tx *txs.BaseTx) error {
 		return err
 	}
 
-	err := avax.VerifyTx(
-		v.Config.TxFee,
+	fee, err := v.syntacticFee(v.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
+	err = avax.VerifyTx(
+		fee,
 		v.FeeAssetID,
 		[][]*avax.TransferableInput{tx.Ins},
 		[][]*avax.TransferableOutput{tx.Outs},
@@ -86,40 +98,24 @@ func (v *SyntacticVerifier) BaseTx(tx *txs.BaseTx) error {
 }
 
 func (v *SyntacticVerifier) CreateAssetTx(tx *txs.CreateAssetTx) error {
-	switch {
-	case len(tx.Name) < minNameLen:
-		return errNameTooShort
-	case len(tx.Name) > maxNameLen:
-		return errNameTooLong
-	case len(tx.Symbol) < minSymbolLen:
-		return errSymbolTooShort
-	case len(tx.Symbol) > maxSymbolLen:
-		return errSymbolTooLong
-	case len(tx.States) == 0:
+	if len(tx.States) == 0 {
 		return errNoFxs
-	case tx.Denomination > maxDenomination:
-		return errDenominationTooLarge
-	case strings.TrimSpace(tx.Name) != tx.Name:
-		return errUnexpectedWhitespace
-	}
-
-	for _, r := range tx.Name {
-		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsNumber(r) || r == ' ') {
-			return errIllegalNameCharacter
-		}
 	}
-	for _, r := range tx.Symbol {
-		if r > unicode.MaxASCII || !unicode.IsUpper(r) {
-			return errIllegalSymbolCharacter
-		}
+	if err := verifyAssetDescription(tx.Name, tx.Symbol, tx.Denomination); err != nil {
+		return err
 	}
 
 	if err := tx.BaseTx.BaseTx.Verify(v.Ctx); err != nil {
 		return err
 	}
 
-	err := avax.VerifyTx(
-		v.Config.CreateAssetTxFee,
+	fee, err := v.syntacticFee(v.Config.CreateAssetTxFee)
+	if err != nil {
+		return err
+	}
+
+	err = avax.VerifyTx(
+		fee,
 		v.FeeAssetID,
 		[][]*avax.TransferableInput{tx.Ins},
 		[][]*avax.TransferableOutput{tx.Outs},
@@ -157,6 +153,32 @@ func (v *SyntacticVerifier) CreateAssetTx(tx *txs.CreateAssetTx) error {
 	return nil
 }
 
+func (v *SyntacticVerifier) CreateCappedAssetTx(tx *txs.CreateCappedAssetTx) error {
+	if cappedAssets := v.Config.CappedAssets; cappedAssets == nil || !cappedAssets.IsActivated(v.Timestamp) {
+		return errCappedAssetsDisabled
+	}
+
+	if err := v.CreateAssetTx(&tx.CreateAssetTx); err != nil {
+		return err
+	}
+
+	if tx.MaxSupply == 0 {
+		return txs.ErrZeroMaxSupply
+	}
+	initialSupply, err := tx.InitialSupply()
+	if err != nil {
+		return err
+	}
+	if initialSupply > tx.MaxSupply {
+		return fmt.Errorf("%w: initial supply %d > %d",
+			txs.ErrMaxSupplyExceeded,
+			initialSupply,
+			tx.MaxSupply,
+		)
+	}
+	return nil
+}
+
 func (v *SyntacticVerifier) OperationTx(tx *txs.OperationTx) error {
 	if len(tx.Ops) == 0 {
 		return errNoOperations
@@ -166,8 +188,13 @@ func (v *SyntacticVerifier) OperationTx(tx *txs.OperationTx) error {
 		return err
 	}
 
-	err := avax.VerifyTx(
-		v.Config.TxFee,
+	fee, err := v.syntacticFee(v.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
+	err = avax.VerifyTx(
+		fee,
 		v.FeeAssetID,
 		[][]*avax.TransferableInput{tx.Ins},
 		[][]*avax.TransferableOutput{tx.Outs},
@@ -186,6 +213,11 @@ func (v *SyntacticVerifier) OperationTx(tx *txs.OperationTx) error {
 		if err := op.Verify(); err != nil {
 			return err
 		}
+		if op, ok := op.Op.(*secp256k1fx.UpdateMetadataOperation); ok {
+			if err := verifyAssetDescription(op.Name, op.Symbol, op.Denomination); err != nil {
+				return err
+			}
+		}
 		for _, utxoID := range op.UTXOIDs {
 			inputID := utxoID.InputID()
 			if inputs.Contains(inputID) {
@@ -226,8 +258,13 @@ func (v *SyntacticVerifier) ImportTx(tx *txs.ImportTx) error {
 		return err
 	}
 
-	err := avax.VerifyTx(
-		v.Config.TxFee,
+	fee, err := v.syntacticFee(v.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
+	err = avax.VerifyTx(
+		fee,
 		v.FeeAssetID,
 		[][]*avax.TransferableInput{
 			tx.Ins,
@@ -268,8 +305,13 @@ func (v *SyntacticVerifier) ExportTx(tx *txs.ExportTx) error {
 		return err
 	}
 
-	err := avax.VerifyTx(
-		v.Config.TxFee,
+	fee, err := v.syntacticFee(v.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
+	err = avax.VerifyTx(
+		fee,
 		v.FeeAssetID,
 		[][]*avax.TransferableInput{tx.Ins},
 		[][]*avax.TransferableOutput{
@@ -300,3 +342,98 @@ func (v *SyntacticVerifier) ExportTx(tx *txs.ExportTx) error {
 
 	return nil
 }
+
+func (v *SyntacticVerifier) FeeRateTx(tx *txs.FeeRateTx) error {
+	if conversion := v.Config.FeeConversion; conversion == nil || !conversion.IsActivated(v.Timestamp) {
+		return errFeeConversionDisabled
+	}
+
+	if err := tx.Rate.Verify(); err != nil {
+		return err
+	}
+
+	if err := tx.BaseTx.BaseTx.Verify(v.Ctx); err != nil {
+		return err
+	}
+
+	fee, err := v.syntacticFee(v.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
+	err = avax.VerifyTx(
+		fee,
+		v.FeeAssetID,
+		[][]*avax.TransferableInput{tx.Ins},
+		[][]*avax.TransferableOutput{tx.Outs},
+		v.Codec,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.OracleAuth.Verify(); err != nil {
+		return err
+	}
+
+	for _, cred := range v.Tx.Creds {
+		if err := cred.Verify(); err != nil {
+			return err
+		}
+	}
+
+	numCreds := len(v.Tx.Creds)
+	numInputs := len(tx.Ins) + 1
+	if numCreds != numInputs {
+		return fmt.Errorf("%w: %d != %d",
+			errWrongNumberOfCredentials,
+			numCreds,
+			numInputs,
+		)
+	}
+
+	return nil
+}
+
+// syntacticFee returns the amount of the fee asset that must be burned for a
+// tx with [fee] to be syntactically valid. If fee conversion is configured,
+// the fee is enforced during semantic verification instead, as whether it may
+// be converted depends on the chain time and the published rate.
+func (v *SyntacticVerifier) syntacticFee(fee uint64) (uint64, error) {
+	if v.Config.FeeConversion != nil {
+		return 0, nil
+	}
+	return txFee(v.Config.DynamicFees, v.Tx, fee, v.Timestamp)
+}
+
+// verifyAssetDescription verifies the human readable description of an asset,
+// which is provided either when the asset is created or when its metadata is
+// updated.
+func verifyAssetDescription(name, symbol string, denomination byte) error {
+	switch {
+	case len(name) < minNameLen:
+		return errNameTooShort
+	case len(name) > maxNameLen:
+		return errNameTooLong
+	case len(symbol) < minSymbolLen:
+		return errSymbolTooShort
+	case len(symbol) > maxSymbolLen:
+		return errSymbolTooLong
+	case denomination > maxDenomination:
+		return errDenominationTooLarge
+	case strings.TrimSpace(name) != name:
+		return errUnexpectedWhitespace
+	}
+
+	for _, r := range name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsNumber(r) || r == ' ') {
+			return errIllegalNameCharacter
+		}
+	}
+	for _, r := range symbol {
+		if r > unicode.MaxASCII || !unicode.IsUpper(r) {
+			return errIllegalSymbolCharacter
+		}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -493,10 +494,16 @@ func TestSyntacticVerifierCreateAssetTx(t *testing.T) {
 		&cred,
 	}
 
+	activationTime := time.Unix(1_700_000_000, 0)
+	cappedAssetsConfig := feeConfig
+	cappedAssetsConfig.CappedAssets = &config.CappedAssets{
+		ActivationTime: activationTime,
+	}
+
 	codec := parser.Codec()
 	backend := &Backend{
 		Ctx:    ctx,
-		Config: &feeConfig,
+		Config: &cappedAssetsConfig,
 		Fxs: []*fxs.ParsedFx{
 			{
 				ID: secp256k1fx.ID,
@@ -1012,18 +1019,73 @@ func TestSyntacticVerifierCreateAssetTx(t *testing.T) {
 			},
 			err: avax.ErrInsufficientFunds,
 		},
+		{
+			name: "valid max supply",
+			txFunc: func() *txs.Tx {
+				return &txs.Tx{
+					Unsigned: &txs.CreateCappedAssetTx{
+						CreateAssetTx: tx,
+						MaxSupply:     fxOutput.Amt,
+					},
+					Creds: creds,
+				}
+			},
+			err: nil,
+		},
+		{
+			name: "zero max supply",
+			txFunc: func() *txs.Tx {
+				return &txs.Tx{
+					Unsigned: &txs.CreateCappedAssetTx{
+						CreateAssetTx: tx,
+						MaxSupply:     0,
+					},
+					Creds: creds,
+				}
+			},
+			err: txs.ErrZeroMaxSupply,
+		},
+		{
+			name: "initial supply exceeds max supply",
+			txFunc: func() *txs.Tx {
+				return &txs.Tx{
+					Unsigned: &txs.CreateCappedAssetTx{
+						CreateAssetTx: tx,
+						MaxSupply:     fxOutput.Amt - 1,
+					},
+					Creds: creds,
+				}
+			},
+			err: txs.ErrMaxSupplyExceeded,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			tx := test.txFunc()
 			verifier := &SyntacticVerifier{
-				Backend: backend,
-				Tx:      tx,
+				Backend:   backend,
+				Tx:        tx,
+				Timestamp: activationTime,
 			}
 			err := tx.Unsigned.Visit(verifier)
 			require.ErrorIs(t, err, test.err)
 		})
 	}
+
+	// Capped assets can't be created before they are activated.
+	cappedTx := &txs.Tx{
+		Unsigned: &txs.CreateCappedAssetTx{
+			CreateAssetTx: tx,
+			MaxSupply:     fxOutput.Amt,
+		},
+		Creds: creds,
+	}
+	err = cappedTx.Unsigned.Visit(&SyntacticVerifier{
+		Backend:   backend,
+		Tx:        cappedTx,
+		Timestamp: activationTime.Add(-time.Second),
+	})
+	require.ErrorIs(t, err, errCappedAssetsDisabled)
 }
 
 func TestSyntacticVerifierOperationTx(t *testing.T) {
@@ -1380,6 +1442,52 @@ func TestSyntacticVerifierOperationTx(t *testing.T) {
 			},
 			err: txs.ErrNilFxOperation,
 		},
+		{
+			name: "valid update metadata op",
+			txFunc: func() *txs.Tx {
+				op := op
+				op.Op = &secp256k1fx.UpdateMetadataOperation{
+					MintInput:    inputSigners,
+					MintOutput:   fxOp.MintOutput,
+					Name:         "Team Rocket",
+					Symbol:       "TR",
+					Denomination: 9,
+				}
+
+				tx := tx
+				tx.Ops = []*txs.Operation{
+					&op,
+				}
+				return &txs.Tx{
+					Unsigned: &tx,
+					Creds:    creds,
+				}
+			},
+			err: nil,
+		},
+		{
+			name: "illegal character in updated symbol",
+			txFunc: func() *txs.Tx {
+				op := op
+				op.Op = &secp256k1fx.UpdateMetadataOperation{
+					MintInput:    inputSigners,
+					MintOutput:   fxOp.MintOutput,
+					Name:         "Team Rocket",
+					Symbol:       "tr",
+					Denomination: 9,
+				}
+
+				tx := tx
+				tx.Ops = []*txs.Operation{
+					&op,
+				}
+				return &txs.Tx{
+					Unsigned: &tx,
+					Creds:    creds,
+				}
+			},
+			err: errIllegalSymbolCharacter,
+		},
 		{
 			name: "invalid duplicated op UTXOs",
 			txFunc: func() *txs.Tx {
@@ -2320,3 +2428,267 @@ func TestSyntacticVerifierExportTx(t *testing.T) {
 		})
 	}
 }
+
+func TestSyntacticVerifierFeeRateTx(t *testing.T) {
+	ctx := newContext(t)
+
+	fx := &secp256k1fx.Fx{}
+	parser, err := txs.NewParser([]fxs.Fx{
+		fx,
+	})
+	require.NoError(t, err)
+
+	feeAssetID := ids.GenerateTestID()
+	conversionConfig := config.Config{
+		TxFee: feeConfig.TxFee,
+		FeeConversion: &config.FeeConversion{
+			AssetID: ids.GenerateTestID(),
+			Oracle:  &secp256k1fx.OutputOwners{},
+		},
+	}
+	unactivatedConfig := conversionConfig
+	unactivatedConfig.FeeConversion = &config.FeeConversion{
+		ActivationTime: time.Unix(1_700_000_000, 0),
+		AssetID:        conversionConfig.FeeConversion.AssetID,
+		Oracle:         conversionConfig.FeeConversion.Oracle,
+	}
+	feeRateTx := txs.FeeRateTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: ctx.ChainID,
+			},
+		},
+		Rate: txs.FeeRate{
+			Numerator:   1,
+			Denominator: 2,
+		},
+		OracleAuth: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+	}
+	creds := []*fxs.FxCredential{
+		{
+			Credential: &secp256k1fx.Credential{},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config *config.Config
+		txFunc func() *txs.Tx
+		err    error
+	}{
+		{
+			name:   "valid",
+			config: &conversionConfig,
+			txFunc: func() *txs.Tx {
+				return &txs.Tx{
+					Unsigned: &feeRateTx,
+					Creds:    creds,
+				}
+			},
+			err: nil,
+		},
+		{
+			name:   "fee conversion disabled",
+			config: &feeConfig,
+			txFunc: func() *txs.Tx {
+				return &txs.Tx{
+					Unsigned: &feeRateTx,
+					Creds:    creds,
+				}
+			},
+			err: errFeeConversionDisabled,
+		},
+		{
+			name:   "fee conversion not activated",
+			config: &unactivatedConfig,
+			txFunc: func() *txs.Tx {
+				return &txs.Tx{
+					Unsigned: &feeRateTx,
+					Creds:    creds,
+				}
+			},
+			err: errFeeConversionDisabled,
+		},
+		{
+			name:   "zero rate",
+			config: &conversionConfig,
+			txFunc: func() *txs.Tx {
+				tx := feeRateTx
+				tx.Rate.Numerator = 0
+				return &txs.Tx{
+					Unsigned: &tx,
+					Creds:    creds,
+				}
+			},
+			err: txs.ErrZeroFeeRate,
+		},
+		{
+			name:   "unsorted oracle signature indices",
+			config: &conversionConfig,
+			txFunc: func() *txs.Tx {
+				tx := feeRateTx
+				tx.OracleAuth = secp256k1fx.Input{
+					SigIndices: []uint32{1, 0},
+				}
+				return &txs.Tx{
+					Unsigned: &tx,
+					Creds:    creds,
+				}
+			},
+			err: secp256k1fx.ErrInputIndicesNotSortedUnique,
+		},
+		{
+			name:   "missing oracle credential",
+			config: &conversionConfig,
+			txFunc: func() *txs.Tx {
+				return &txs.Tx{
+					Unsigned: &feeRateTx,
+				}
+			},
+			err: errWrongNumberOfCredentials,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := test.txFunc()
+			verifier := &SyntacticVerifier{
+				Backend: &Backend{
+					Ctx:    ctx,
+					Config: test.config,
+					Fxs: []*fxs.ParsedFx{
+						{
+							ID: secp256k1fx.ID,
+							Fx: fx,
+						},
+					},
+					Codec:      parser.Codec(),
+					FeeAssetID: feeAssetID,
+				},
+				Tx: tx,
+			}
+			err := tx.Unsigned.Visit(verifier)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestSyntacticVerifierDynamicFees(t *testing.T) {
+	ctx := newContext(t)
+
+	fx := &secp256k1fx.Fx{}
+	parser, err := txs.NewParser([]fxs.Fx{
+		fx,
+	})
+	require.NoError(t, err)
+	codec := parser.Codec()
+
+	activationTime := time.Unix(1_000_000, 0)
+	dynamicFeeConfig := config.Config{
+		TxFee:            feeConfig.TxFee,
+		CreateAssetTxFee: feeConfig.CreateAssetTxFee,
+		DynamicFees: &config.DynamicFees{
+			ActivationTime: activationTime,
+			BytesFee:       1,
+			InputFee:       100,
+			SignatureFee:   1_000,
+		},
+	}
+
+	feeAssetID := ids.GenerateTestID()
+	backend := &Backend{
+		Ctx:    ctx,
+		Config: &dynamicFeeConfig,
+		Fxs: []*fxs.ParsedFx{
+			{
+				ID: secp256k1fx.ID,
+				Fx: fx,
+			},
+		},
+		Codec:      codec,
+		FeeAssetID: feeAssetID,
+	}
+
+	// newTx returns a signed tx that burns [burned] of the fee asset.
+	const inputAmount = 1_000_000
+	newTx := func(require *require.Assertions, burned uint64) *txs.Tx {
+		tx := &txs.Tx{
+			Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: ctx.ChainID,
+				Outs: []*avax.TransferableOutput{{
+					Asset: avax.Asset{ID: feeAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt: inputAmount - burned,
+						OutputOwners: secp256k1fx.OutputOwners{
+							Threshold: 1,
+							Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+						},
+					},
+				}},
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+					Asset:  avax.Asset{ID: feeAssetID},
+					In: &secp256k1fx.TransferInput{
+						Amt: inputAmount,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				}},
+			}},
+		}
+		require.NoError(tx.SignSECP256K1Fx(codec, [][]*secp256k1.PrivateKey{{keys[0]}}))
+		return tx
+	}
+
+	// The size of the tx doesn't depend on the amount burned.
+	txSize := uint64(len(newTx(require.New(t), 0).Bytes()))
+	dynamicFee := feeConfig.TxFee + txSize + 100 + 1_000
+
+	tests := []struct {
+		name      string
+		burned    uint64
+		timestamp time.Time
+		err       error
+	}{
+		{
+			name:      "static fee before activation",
+			burned:    feeConfig.TxFee,
+			timestamp: activationTime.Add(-time.Second),
+			err:       nil,
+		},
+		{
+			name:      "static fee after activation",
+			burned:    feeConfig.TxFee,
+			timestamp: activationTime,
+			err:       avax.ErrInsufficientFunds,
+		},
+		{
+			name:      "dynamic fee after activation",
+			burned:    dynamicFee,
+			timestamp: activationTime,
+			err:       nil,
+		},
+		{
+			name:      "barely insufficient dynamic fee after activation",
+			burned:    dynamicFee - 1,
+			timestamp: activationTime.Add(time.Second),
+			err:       avax.ErrInsufficientFunds,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := newTx(require.New(t), test.burned)
+			verifier := &SyntacticVerifier{
+				Backend:   backend,
+				Tx:        tx,
+				Timestamp: test.timestamp,
+			}
+			err := tx.Unsigned.Visit(verifier)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	_ UnsignedTx             = (*FeeRateTx)(nil)
+	_ secp256k1fx.UnsignedTx = (*FeeRateTx)(nil)
+
+	ErrZeroFeeRate = errors.New("fee rate must be non-zero")
+)
+
+// FeeRate is the number of units of the fee conversion asset that are
+// equivalent to a unit of the fee asset, expressed as a fraction.
+type FeeRate struct {
+	Numerator   uint64 `serialize:"true" json:"numerator"`
+	Denominator uint64 `serialize:"true" json:"denominator"`
+}
+
+func (r *FeeRate) Verify() error {
+	if r.Numerator == 0 || r.Denominator == 0 {
+		return ErrZeroFeeRate
+	}
+	return nil
+}
+
+// Convert returns the amount of the fee conversion asset that must be burned
+// to pay [fee] units of the fee asset. The result is rounded up.
+func (r *FeeRate) Convert(fee uint64) (uint64, error) {
+	if err := r.Verify(); err != nil {
+		return 0, err
+	}
+	product, err := safemath.Mul64(fee, r.Numerator)
+	if err != nil {
+		return 0, err
+	}
+	converted := product / r.Denominator
+	if product%r.Denominator != 0 {
+		converted++
+	}
+	return converted, nil
+}
+
+// FeeRateTx is a transaction that publishes the rate at which fees may be paid
+// in the fee conversion asset.
+type FeeRateTx struct {
+	BaseTx `serialize:"true"`
+
+	// The new conversion rate
+	Rate FeeRate `serialize:"true" json:"rate"`
+
+	// Proves that the issuer is the fee rate oracle
+	OracleAuth secp256k1fx.Input `serialize:"true" json:"oracleAuthorization"`
+}
+
+func (t *FeeRateTx) InitCtx(ctx *snow.Context) {
+	t.BaseTx.InitCtx(ctx)
+}
+
+// NumCredentials returns the number of expected credentials
+func (t *FeeRateTx) NumCredentials() int {
+	return t.BaseTx.NumCredentials() + 1
+}
+
+func (t *FeeRateTx) Visit(v Visitor) error {
+	return v.FeeRateTx(t)
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+func TestFeeRateConvert(t *testing.T) {
+	tests := []struct {
+		name        string
+		rate        FeeRate
+		fee         uint64
+		expected    uint64
+		expectedErr error
+	}{
+		{
+			name: "exact",
+			rate: FeeRate{
+				Numerator:   3,
+				Denominator: 1,
+			},
+			fee:      10,
+			expected: 30,
+		},
+		{
+			name: "rounded up",
+			rate: FeeRate{
+				Numerator:   1,
+				Denominator: 3,
+			},
+			fee:      10,
+			expected: 4,
+		},
+		{
+			name: "zero fee",
+			rate: FeeRate{
+				Numerator:   1,
+				Denominator: 3,
+			},
+			fee:      0,
+			expected: 0,
+		},
+		{
+			name: "zero numerator",
+			rate: FeeRate{
+				Numerator:   0,
+				Denominator: 1,
+			},
+			fee:         10,
+			expectedErr: ErrZeroFeeRate,
+		},
+		{
+			name: "zero denominator",
+			rate: FeeRate{
+				Numerator:   1,
+				Denominator: 0,
+			},
+			fee:         10,
+			expectedErr: ErrZeroFeeRate,
+		},
+		{
+			name: "overflow",
+			rate: FeeRate{
+				Numerator:   2,
+				Denominator: 1,
+			},
+			fee:         math.MaxUint64,
+			expectedErr: safemath.ErrOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			converted, err := test.rate.Convert(test.fee)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, converted)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	_ Visitor = (*assetIDGetter)(nil)
+
+	ErrDependencyCycle = errors.New("txs have cyclic dependencies")
+)
+
+// Graph describes how a set of txs relate to each other, which allows the txs
+// to be packed into blocks without including conflicting txs or including a
+// tx before the txs it depends on.
+type Graph struct {
+	// Dependencies maps each tx to the txs in the graph that must be accepted
+	// before it. A tx depends on another tx if it consumes a UTXO produced by
+	// the other tx or if it uses an asset created by the other tx.
+	Dependencies map[ids.ID]set.Set[ids.ID]
+	// Conflicts maps each tx to the txs in the graph that consume at least
+	// one of the same UTXOs. At most one of a set of conflicting txs can be
+	// accepted.
+	Conflicts map[ids.ID]set.Set[ids.ID]
+}
+
+// NewGraph returns the graph of [txs]. The graph only depends on the set of
+// provided txs, not on their order. Duplicate txs are ignored.
+func NewGraph(txs []*Tx) *Graph {
+	g := &Graph{
+		Dependencies: make(map[ids.ID]set.Set[ids.ID], len(txs)),
+		Conflicts:    make(map[ids.ID]set.Set[ids.ID], len(txs)),
+	}
+	for _, tx := range txs {
+		txID := tx.ID()
+		g.Dependencies[txID] = set.Set[ids.ID]{}
+		g.Conflicts[txID] = set.Set[ids.ID]{}
+	}
+
+	consumers := make(map[ids.ID]set.Set[ids.ID])
+	for _, tx := range txs {
+		txID := tx.ID()
+		dependencies := g.Dependencies[txID]
+		for _, utxoID := range tx.Unsigned.InputUTXOs() {
+			if _, ok := g.Dependencies[utxoID.TxID]; ok && utxoID.TxID != txID {
+				dependencies.Add(utxoID.TxID)
+			}
+		}
+
+		assetIDs := assetIDGetter{}
+		// The visit error is explicitly dropped here because no error is ever
+		// returned from the assetIDGetter.
+		_ = tx.Unsigned.Visit(&assetIDs)
+		for assetID := range assetIDs.assetIDs {
+			if _, ok := g.Dependencies[assetID]; ok && assetID != txID {
+				dependencies.Add(assetID)
+			}
+		}
+
+		for inputID := range tx.Unsigned.InputIDs() {
+			txIDs, ok := consumers[inputID]
+			if !ok {
+				txIDs = set.Set[ids.ID]{}
+				consumers[inputID] = txIDs
+			}
+			txIDs.Add(txID)
+		}
+	}
+
+	for _, txIDs := range consumers {
+		for txID := range txIDs {
+			conflicts := g.Conflicts[txID]
+			conflicts.Union(txIDs)
+			conflicts.Remove(txID)
+		}
+	}
+	return g
+}
+
+// TopologicalOrder returns the txs in the graph ordered such that every tx is
+// after the txs it depends on. Txs that can be ordered in multiple ways are
+// ordered by their IDs, so the order is deterministic.
+func (g *Graph) TopologicalOrder() ([]ids.ID, error) {
+	var (
+		order           = make([]ids.ID, 0, len(g.Dependencies))
+		numDependencies = make(map[ids.ID]int, len(g.Dependencies))
+		dependents      = make(map[ids.ID][]ids.ID, len(g.Dependencies))
+		ready           []ids.ID
+	)
+	for txID, dependencies := range g.Dependencies {
+		numDependencies[txID] = dependencies.Len()
+		if dependencies.Len() == 0 {
+			ready = append(ready, txID)
+		}
+		for dependency := range dependencies {
+			dependents[dependency] = append(dependents[dependency], txID)
+		}
+	}
+
+	for len(ready) > 0 {
+		utils.Sort(ready)
+		order = append(order, ready...)
+
+		var next []ids.ID
+		for _, txID := range ready {
+			for _, dependent := range dependents[txID] {
+				numDependencies[dependent]--
+				if numDependencies[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		ready = next
+	}
+
+	if len(order) != len(g.Dependencies) {
+		return nil, ErrDependencyCycle
+	}
+	return order, nil
+}
+
+// assetIDGetter returns the IDs of the assets a transaction uses.
+type assetIDGetter struct {
+	assetIDs set.Set[ids.ID]
+}
+
+func (a *assetIDGetter) BaseTx(tx *BaseTx) error {
+	for _, in := range tx.Ins {
+		a.assetIDs.Add(in.AssetID())
+	}
+	for _, out := range tx.Outs {
+		a.assetIDs.Add(out.AssetID())
+	}
+	return nil
+}
+
+func (a *assetIDGetter) CreateAssetTx(tx *CreateAssetTx) error {
+	return a.BaseTx(&tx.BaseTx)
+}
+
+func (a *assetIDGetter) CreateCappedAssetTx(tx *CreateCappedAssetTx) error {
+	return a.CreateAssetTx(&tx.CreateAssetTx)
+}
+
+func (a *assetIDGetter) OperationTx(tx *OperationTx) error {
+	for _, op := range tx.Ops {
+		a.assetIDs.Add(op.AssetID())
+	}
+	return a.BaseTx(&tx.BaseTx)
+}
+
+func (a *assetIDGetter) ImportTx(tx *ImportTx) error {
+	for _, in := range tx.ImportedIns {
+		a.assetIDs.Add(in.AssetID())
+	}
+	return a.BaseTx(&tx.BaseTx)
+}
+
+func (a *assetIDGetter) ExportTx(tx *ExportTx) error {
+	for _, out := range tx.ExportedOuts {
+		a.assetIDs.Add(out.AssetID())
+	}
+	return a.BaseTx(&tx.BaseTx)
+}
+
+func (a *assetIDGetter) FeeRateTx(tx *FeeRateTx) error {
+	return a.BaseTx(&tx.BaseTx)
+}
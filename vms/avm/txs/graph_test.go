@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newGraphTestTx(txID ids.ID, assetID ids.ID, utxoIDs ...avax.UTXOID) *Tx {
+	ins := make([]*avax.TransferableInput, len(utxoIDs))
+	for i, utxoID := range utxoIDs {
+		ins[i] = &avax.TransferableInput{
+			UTXOID: utxoID,
+			Asset:  avax.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: 1,
+			},
+		}
+	}
+	return &Tx{
+		Unsigned: &BaseTx{BaseTx: avax.BaseTx{
+			Ins: ins,
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: 1,
+				},
+			}},
+		}},
+		TxID: txID,
+	}
+}
+
+func TestGraph(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		assetID = ids.GenerateTestID()
+		utxoID  = avax.UTXOID{TxID: ids.GenerateTestID()}
+
+		// tx0 and tx1 spend the same UTXO
+		tx0 = newGraphTestTx(ids.ID{0}, assetID, utxoID)
+		tx1 = newGraphTestTx(ids.ID{1}, assetID, utxoID)
+		// tx2 spends the output of tx1
+		tx2 = newGraphTestTx(ids.ID{2}, assetID, avax.UTXOID{TxID: tx1.ID()})
+		// tx3 uses the asset created by tx0
+		tx3 = newGraphTestTx(ids.ID{3}, tx0.ID(), avax.UTXOID{TxID: ids.GenerateTestID()})
+		// tx4 is independent
+		tx4 = newGraphTestTx(ids.ID{4}, assetID, avax.UTXOID{TxID: ids.GenerateTestID()})
+	)
+
+	g := NewGraph([]*Tx{tx4, tx3, tx2, tx1, tx0})
+	require.Equal(map[ids.ID]set.Set[ids.ID]{
+		tx0.ID(): {},
+		tx1.ID(): {},
+		tx2.ID(): set.Of(tx1.ID()),
+		tx3.ID(): set.Of(tx0.ID()),
+		tx4.ID(): {},
+	}, g.Dependencies)
+	require.Equal(map[ids.ID]set.Set[ids.ID]{
+		tx0.ID(): set.Of(tx1.ID()),
+		tx1.ID(): set.Of(tx0.ID()),
+		tx2.ID(): {},
+		tx3.ID(): {},
+		tx4.ID(): {},
+	}, g.Conflicts)
+
+	order, err := g.TopologicalOrder()
+	require.NoError(err)
+	require.Equal([]ids.ID{
+		tx0.ID(),
+		tx1.ID(),
+		tx4.ID(),
+		tx2.ID(),
+		tx3.ID(),
+	}, order)
+}
+
+func TestGraphTopologicalOrderCycle(t *testing.T) {
+	require := require.New(t)
+
+	txID0 := ids.ID{0}
+	txID1 := ids.ID{1}
+	g := &Graph{
+		Dependencies: map[ids.ID]set.Set[ids.ID]{
+			txID0: set.Of(txID1),
+			txID1: set.Of(txID0),
+		},
+	}
+	_, err := g.TopologicalOrder()
+	require.ErrorIs(err, ErrDependencyCycle)
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"math/bits"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var _ txs.Visitor = (*feeCalculator)(nil)
+
+// feeCalculator calculates the amount of the fee asset burned by a tx.
+//
+// Fees paid in the fee conversion asset aren't included, as the conversion
+// rate depends on the chain state.
+type feeCalculator struct {
+	feeAssetID ids.ID
+
+	consumed uint64
+	produced uint64
+}
+
+// burned returns the amount of [feeAssetID] that is burned by [tx].
+func burned(feeAssetID ids.ID, tx *txs.Tx) (uint64, error) {
+	c := &feeCalculator{
+		feeAssetID: feeAssetID,
+	}
+	if err := tx.Unsigned.Visit(c); err != nil {
+		return 0, err
+	}
+	if c.produced > c.consumed {
+		return 0, nil
+	}
+	return c.consumed - c.produced, nil
+}
+
+func (c *feeCalculator) BaseTx(tx *txs.BaseTx) error {
+	if err := c.consume(tx.Ins); err != nil {
+		return err
+	}
+	return c.produce(tx.Outs)
+}
+
+func (c *feeCalculator) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) CreateCappedAssetTx(tx *txs.CreateCappedAssetTx) error {
+	return c.CreateAssetTx(&tx.CreateAssetTx)
+}
+
+func (c *feeCalculator) OperationTx(tx *txs.OperationTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) ImportTx(tx *txs.ImportTx) error {
+	if err := c.consume(tx.ImportedIns); err != nil {
+		return err
+	}
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) ExportTx(tx *txs.ExportTx) error {
+	if err := c.produce(tx.ExportedOuts); err != nil {
+		return err
+	}
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) FeeRateTx(tx *txs.FeeRateTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) consume(ins []*avax.TransferableInput) error {
+	for _, in := range ins {
+		if in.AssetID() != c.feeAssetID {
+			continue
+		}
+
+		var err error
+		c.consumed, err = safemath.Add64(c.consumed, in.Input().Amount())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *feeCalculator) produce(outs []*avax.TransferableOutput) error {
+	for _, out := range outs {
+		if out.AssetID() != c.feeAssetID {
+			continue
+		}
+
+		var err error
+		c.produced, err = safemath.Add64(c.produced, out.Output().Amount())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compareFeeRates returns a positive number if [feeA] / [sizeA] is greater than
+// [feeB] / [sizeB], a negative number if it is less, and 0 if they are equal.
+func compareFeeRates(feeA uint64, sizeA int, feeB uint64, sizeB int) int {
+	hiA, loA := bits.Mul64(feeA, uint64(sizeB))
+	hiB, loB := bits.Mul64(feeB, uint64(sizeA))
+	switch {
+	case hiA != hiB:
+		if hiA > hiB {
+			return 1
+		}
+		return -1
+	case loA != loB:
+		if loA > loB {
+			return 1
+		}
+		return -1
+	default:
+		return 0
+	}
+}
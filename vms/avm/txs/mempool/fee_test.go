@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestBurned(t *testing.T) {
+	otherAssetID := ids.ID{4, 5, 6}
+	in := func(assetID ids.ID, amount uint64) *avax.TransferableInput {
+		return &avax.TransferableInput{
+			Asset: avax.Asset{ID: assetID},
+			In:    &secp256k1fx.TransferInput{Amt: amount},
+		}
+	}
+	out := func(assetID ids.ID, amount uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Asset: avax.Asset{ID: assetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		tx          txs.UnsignedTx
+		expectedFee uint64
+	}{
+		{
+			name: "base tx",
+			tx: &txs.BaseTx{BaseTx: avax.BaseTx{
+				Ins: []*avax.TransferableInput{
+					in(assetID, 10),
+					in(otherAssetID, 100),
+				},
+				Outs: []*avax.TransferableOutput{
+					out(assetID, 7),
+					out(otherAssetID, 50),
+				},
+			}},
+			expectedFee: 3,
+		},
+		{
+			name: "import tx",
+			tx: &txs.ImportTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					Outs: []*avax.TransferableOutput{
+						out(assetID, 7),
+					},
+				}},
+				ImportedIns: []*avax.TransferableInput{
+					in(assetID, 10),
+				},
+			},
+			expectedFee: 3,
+		},
+		{
+			name: "export tx",
+			tx: &txs.ExportTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					Ins: []*avax.TransferableInput{
+						in(assetID, 10),
+					},
+				}},
+				ExportedOuts: []*avax.TransferableOutput{
+					out(assetID, 7),
+				},
+			},
+			expectedFee: 3,
+		},
+		{
+			name: "produces more than consumed",
+			tx: &txs.BaseTx{BaseTx: avax.BaseTx{
+				Outs: []*avax.TransferableOutput{
+					out(assetID, 7),
+				},
+			}},
+			expectedFee: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			fee, err := burned(assetID, &txs.Tx{Unsigned: test.tx})
+			require.NoError(err)
+			require.Equal(test.expectedFee, fee)
+		})
+	}
+}
+
+func TestCompareFeeRates(t *testing.T) {
+	tests := []struct {
+		name     string
+		feeA     uint64
+		sizeA    int
+		feeB     uint64
+		sizeB    int
+		expected int
+	}{
+		{
+			name:     "equal",
+			feeA:     10,
+			sizeA:    5,
+			feeB:     20,
+			sizeB:    10,
+			expected: 0,
+		},
+		{
+			name:     "higher",
+			feeA:     11,
+			sizeA:    5,
+			feeB:     20,
+			sizeB:    10,
+			expected: 1,
+		},
+		{
+			name:     "lower",
+			feeA:     9,
+			sizeA:    5,
+			feeB:     20,
+			sizeB:    10,
+			expected: -1,
+		},
+		{
+			name:     "overflows uint64",
+			feeA:     math.MaxUint64,
+			sizeA:    MaxTxSize,
+			feeB:     math.MaxUint64 - 1,
+			sizeB:    MaxTxSize,
+			expected: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, compareFeeRates(test.feeA, test.sizeA, test.feeB, test.sizeB))
+		})
+	}
+}
@@ -7,12 +7,13 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/btree"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
-	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
@@ -30,6 +31,8 @@ const (
 
 	// maxMempoolSize is the maximum number of bytes allowed in the mempool
 	maxMempoolSize = 64 * units.MiB
+
+	txTreeDegree = 2
 )
 
 var (
@@ -39,18 +42,33 @@ var (
 	errTxTooLarge           = errors.New("tx too large")
 	errMempoolFull          = errors.New("mempool is full")
 	errConflictsWithOtherTx = errors.New("tx conflicts with other tx")
+	errReplaced             = errors.New("replaced by a conflicting tx paying a higher fee")
+	errEvicted              = errors.New("evicted by a tx paying a higher fee rate")
 )
 
 // Mempool contains transactions that have not yet been put into a block.
+//
+// Transactions are ordered by the amount of the fee asset they burn per byte.
+// A transaction that conflicts with transactions in the mempool replaces them
+// if it burns more in total and per byte than each of them. When the mempool
+// is full, transactions with the lowest fee rates are evicted to make room for
+// transactions with higher fee rates.
 type Mempool interface {
 	Add(tx *txs.Tx) error
 	Has(txID ids.ID) bool
 	Get(txID ids.ID) *txs.Tx
 	Remove(txs []*txs.Tx)
 
-	// Peek returns the first tx in the mempool whose size is <= [maxTxSize].
+	// Peek returns the tx in the mempool with the highest fee rate whose size
+	// is <= [maxTxSize].
 	Peek(maxTxSize int) *txs.Tx
 
+	// Iterate calls [f] with each tx in the mempool, and the fee it burns, in
+	// order of decreasing fee rate until [f] returns false.
+	Iterate(f func(tx *txs.Tx, fee uint64) bool)
+	// Stats returns a summary of the contents of the mempool.
+	Stats() Stats
+
 	// RequestBuildBlock notifies the consensus engine that a block should be
 	// built if there is at least one transaction in the mempool.
 	RequestBuildBlock()
@@ -61,11 +79,43 @@ type Mempool interface {
 	GetDropReason(txID ids.ID) error
 }
 
+// Stats summarizes the contents of the mempool.
+type Stats struct {
+	NumTxs         int
+	Bytes          int
+	BytesAvailable int
+}
+
+type txEntry struct {
+	tx   *txs.Tx
+	fee  uint64
+	size int
+	// seq orders txs with the same fee rate by the order they were added
+	seq uint64
+}
+
+// Less orders txs by decreasing fee rate. Txs with the same fee rate are
+// ordered by the order they were added.
+func (e *txEntry) Less(than *txEntry) bool {
+	switch cmp := compareFeeRates(e.fee, e.size, than.fee, than.size); {
+	case cmp > 0:
+		return true
+	case cmp < 0:
+		return false
+	default:
+		return e.seq < than.seq
+	}
+}
+
 type mempool struct {
 	bytesAvailableMetric prometheus.Gauge
 	bytesAvailable       int
 
-	unissuedTxs linkedhashmap.LinkedHashmap[ids.ID, *txs.Tx]
+	feeAssetID ids.ID
+	nextSeq    uint64
+
+	unissuedTxs map[ids.ID]*txEntry
+	orderedTxs  *btree.BTreeG[*txEntry]
 	numTxs      prometheus.Gauge
 
 	toEngine chan<- common.Message
@@ -74,13 +124,16 @@ type mempool struct {
 	// Value: Verification error
 	droppedTxIDs *cache.LRU[ids.ID, error]
 
-	consumedUTXOs set.Set[ids.ID]
+	// Key: UTXO ID
+	// Value: Tx ID of the tx in the mempool consuming the UTXO
+	consumedUTXOs map[ids.ID]ids.ID
 }
 
 func New(
 	namespace string,
 	registerer prometheus.Registerer,
 	toEngine chan<- common.Message,
+	feeAssetID ids.ID,
 ) (Mempool, error) {
 	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
@@ -104,11 +157,13 @@ func New(
 	return &mempool{
 		bytesAvailableMetric: bytesAvailableMetric,
 		bytesAvailable:       maxMempoolSize,
-		unissuedTxs:          linkedhashmap.New[ids.ID, *txs.Tx](),
+		feeAssetID:           feeAssetID,
+		unissuedTxs:          make(map[ids.ID]*txEntry),
+		orderedTxs:           btree.NewG(txTreeDegree, (*txEntry).Less),
 		numTxs:               numTxsMetric,
 		toEngine:             toEngine,
 		droppedTxIDs:         &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
-		consumedUTXOs:        set.NewSet[ids.ID](initialConsumedUTXOsSize),
+		consumedUTXOs:        make(map[ids.ID]ids.ID, initialConsumedUTXOsSize),
 	}, nil
 }
 
@@ -128,28 +183,51 @@ func (m *mempool) Add(tx *txs.Tx) error {
 			MaxTxSize,
 		)
 	}
-	if txSize > m.bytesAvailable {
-		return fmt.Errorf("%w: %s size (%d) > available space (%d)",
-			errMempoolFull,
-			txID,
-			txSize,
-			m.bytesAvailable,
-		)
+
+	fee, err := burned(m.feeAssetID, tx)
+	if err != nil {
+		return fmt.Errorf("failed to calculate fee of %s: %w", txID, err)
+	}
+	entry := &txEntry{
+		tx:   tx,
+		fee:  fee,
+		size: txSize,
 	}
 
 	inputs := tx.Unsigned.InputIDs()
-	if m.consumedUTXOs.Overlaps(inputs) {
-		return fmt.Errorf("%w: %s", errConflictsWithOtherTx, txID)
+	conflicts, err := m.replaceableConflicts(entry, inputs)
+	if err != nil {
+		return err
+	}
+
+	evictions, err := m.evictions(entry, conflicts)
+	if err != nil {
+		return err
 	}
 
+	for conflictTxID, conflict := range conflicts {
+		m.remove(conflict)
+		m.droppedTxIDs.Put(conflictTxID, fmt.Errorf("%w: %s", errReplaced, txID))
+	}
+	for _, eviction := range evictions {
+		m.remove(eviction)
+		m.droppedTxIDs.Put(eviction.tx.ID(), fmt.Errorf("%w: %s", errEvicted, txID))
+	}
+
+	entry.seq = m.nextSeq
+	m.nextSeq++
+
 	m.bytesAvailable -= txSize
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 
-	m.unissuedTxs.Put(txID, tx)
+	m.unissuedTxs[txID] = entry
+	m.orderedTxs.ReplaceOrInsert(entry)
 	m.numTxs.Inc()
 
 	// Mark these UTXOs as consumed in the mempool
-	m.consumedUTXOs.Union(inputs)
+	for utxoID := range inputs {
+		m.consumedUTXOs[utxoID] = txID
+	}
 
 	// An explicitly added tx must not be marked as dropped.
 	m.droppedTxIDs.Evict(txID)
@@ -161,41 +239,51 @@ func (m *mempool) Has(txID ids.ID) bool {
 }
 
 func (m *mempool) Get(txID ids.ID) *txs.Tx {
-	tx, _ := m.unissuedTxs.Get(txID)
-	return tx
+	entry, ok := m.unissuedTxs[txID]
+	if !ok {
+		return nil
+	}
+	return entry.tx
 }
 
 func (m *mempool) Remove(txsToRemove []*txs.Tx) {
 	for _, tx := range txsToRemove {
-		txID := tx.ID()
-		if !m.unissuedTxs.Delete(txID) {
+		entry, ok := m.unissuedTxs[tx.ID()]
+		if !ok {
 			continue
 		}
-
-		m.bytesAvailable += len(tx.Bytes())
-		m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
-
-		m.numTxs.Dec()
-
-		inputs := tx.Unsigned.InputIDs()
-		m.consumedUTXOs.Difference(inputs)
+		m.remove(entry)
 	}
 }
 
 func (m *mempool) Peek(maxTxSize int) *txs.Tx {
-	txIter := m.unissuedTxs.NewIterator()
-	for txIter.Next() {
-		tx := txIter.Value()
-		txSize := len(tx.Bytes())
-		if txSize <= maxTxSize {
-			return tx
+	var tx *txs.Tx
+	m.orderedTxs.Ascend(func(entry *txEntry) bool {
+		if entry.size <= maxTxSize {
+			tx = entry.tx
+			return false
 		}
+		return true
+	})
+	return tx
+}
+
+func (m *mempool) Iterate(f func(tx *txs.Tx, fee uint64) bool) {
+	m.orderedTxs.Ascend(func(entry *txEntry) bool {
+		return f(entry.tx, entry.fee)
+	})
+}
+
+func (m *mempool) Stats() Stats {
+	return Stats{
+		NumTxs:         len(m.unissuedTxs),
+		Bytes:          maxMempoolSize - m.bytesAvailable,
+		BytesAvailable: m.bytesAvailable,
 	}
-	return nil
 }
 
 func (m *mempool) RequestBuildBlock() {
-	if m.unissuedTxs.Len() == 0 {
+	if len(m.unissuedTxs) == 0 {
 		return
 	}
 
@@ -213,3 +301,95 @@ func (m *mempool) GetDropReason(txID ids.ID) error {
 	err, _ := m.droppedTxIDs.Get(txID)
 	return err
 }
+
+// replaceableConflicts returns the txs in the mempool that consume any of
+// [inputs]. An error is returned if [entry] doesn't pay enough to replace
+// them.
+//
+// To replace its conflicts, [entry] must burn more than all of its conflicts
+// combined, and must have a higher fee rate than each of its conflicts.
+func (m *mempool) replaceableConflicts(entry *txEntry, inputs set.Set[ids.ID]) (map[ids.ID]*txEntry, error) {
+	conflicts := make(map[ids.ID]*txEntry)
+	for utxoID := range inputs {
+		conflictTxID, ok := m.consumedUTXOs[utxoID]
+		if !ok {
+			continue
+		}
+		conflicts[conflictTxID] = m.unissuedTxs[conflictTxID]
+	}
+
+	var conflictingFees uint64
+	for conflictTxID, conflict := range conflicts {
+		if compareFeeRates(entry.fee, entry.size, conflict.fee, conflict.size) <= 0 {
+			return nil, fmt.Errorf("%w: %s doesn't pay a higher fee rate than %s",
+				errConflictsWithOtherTx,
+				entry.tx.ID(),
+				conflictTxID,
+			)
+		}
+		// The fee rate of [entry] is higher than each of its conflicts, so
+		// this can't overflow unless [entry] spends more than the supply.
+		conflictingFees += conflict.fee
+	}
+	if len(conflicts) > 0 && entry.fee <= conflictingFees {
+		return nil, fmt.Errorf("%w: %s fee (%d) <= conflicting fees (%d)",
+			errConflictsWithOtherTx,
+			entry.tx.ID(),
+			entry.fee,
+			conflictingFees,
+		)
+	}
+	return conflicts, nil
+}
+
+// evictions returns the txs with the lowest fee rates that must be removed to
+// make room for [entry], after [conflicts] have been removed. An error is
+// returned if room can't be made for [entry] by evicting txs with lower fee
+// rates.
+func (m *mempool) evictions(entry *txEntry, conflicts map[ids.ID]*txEntry) ([]*txEntry, error) {
+	bytesAvailable := m.bytesAvailable
+	for _, conflict := range conflicts {
+		bytesAvailable += conflict.size
+	}
+
+	var evictions []*txEntry
+	m.orderedTxs.Descend(func(lowest *txEntry) bool {
+		if bytesAvailable >= entry.size {
+			return false
+		}
+		if compareFeeRates(entry.fee, entry.size, lowest.fee, lowest.size) <= 0 {
+			return false
+		}
+		if _, ok := conflicts[lowest.tx.ID()]; ok {
+			return true
+		}
+
+		evictions = append(evictions, lowest)
+		bytesAvailable += lowest.size
+		return true
+	})
+	if bytesAvailable < entry.size {
+		return nil, fmt.Errorf("%w: %s size (%d) > available space (%d)",
+			errMempoolFull,
+			entry.tx.ID(),
+			entry.size,
+			bytesAvailable,
+		)
+	}
+	return evictions, nil
+}
+
+func (m *mempool) remove(entry *txEntry) {
+	txID := entry.tx.ID()
+	delete(m.unissuedTxs, txID)
+	m.orderedTxs.Delete(entry)
+
+	m.bytesAvailable += entry.size
+	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
+
+	m.numTxs.Dec()
+
+	for utxoID := range entry.tx.Unsigned.InputIDs() {
+		delete(m.consumedUTXOs, utxoID)
+	}
+}
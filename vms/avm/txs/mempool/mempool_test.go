@@ -33,7 +33,7 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mempoolIntf, err := New("mempool", registerer, nil)
+	mempoolIntf, err := New("mempool", registerer, nil, assetID)
 	require.NoError(err)
 
 	mempool := mempoolIntf.(*mempool)
@@ -58,7 +58,7 @@ func TestTxsInMempool(t *testing.T) {
 
 	registerer := prometheus.NewRegistry()
 	toEngine := make(chan common.Message, 100)
-	mempool, err := New("mempool", registerer, toEngine)
+	mempool, err := New("mempool", registerer, toEngine, assetID)
 	require.NoError(err)
 
 	testTxs := createTestTxs(2)
@@ -170,3 +170,170 @@ func createTestTxs(count int) []*txs.Tx {
 	}
 	return testTxs
 }
+
+func TestMempoolOrdersByFeeRate(t *testing.T) {
+	require := require.New(t)
+
+	mempool, err := New("mempool", prometheus.NewRegistry(), nil, assetID)
+	require.NoError(err)
+
+	lowFeeTx := newTestTx(0, 100, 100)
+	highFeeTx := newTestTx(1, 300, 100)
+	// [largeTx] burns the most, but has the lowest fee rate.
+	largeTx := newTestTx(2, 400, 1000)
+	require.NoError(mempool.Add(lowFeeTx))
+	require.NoError(mempool.Add(largeTx))
+	require.NoError(mempool.Add(highFeeTx))
+
+	var (
+		ordered []*txs.Tx
+		fees    []uint64
+	)
+	mempool.Iterate(func(tx *txs.Tx, fee uint64) bool {
+		ordered = append(ordered, tx)
+		fees = append(fees, fee)
+		return true
+	})
+	require.Equal([]*txs.Tx{highFeeTx, lowFeeTx, largeTx}, ordered)
+	require.Equal([]uint64{300, 100, 400}, fees)
+
+	require.Equal(highFeeTx, mempool.Peek(MaxTxSize))
+	// Txs that don't fit are skipped.
+	require.Equal(highFeeTx, mempool.Peek(100))
+	require.Nil(mempool.Peek(99))
+
+	mempool.Remove([]*txs.Tx{highFeeTx})
+	require.Equal(lowFeeTx, mempool.Peek(MaxTxSize))
+
+	require.Equal(
+		Stats{
+			NumTxs:         2,
+			Bytes:          1100,
+			BytesAvailable: maxMempoolSize - 1100,
+		},
+		mempool.Stats(),
+	)
+}
+
+func TestMempoolReplaceByFee(t *testing.T) {
+	tests := []struct {
+		name        string
+		replacement *txs.Tx
+		expectedErr error
+	}{
+		{
+			name:        "higher fee and fee rate",
+			replacement: newTestTx(0, 200, 100),
+			expectedErr: nil,
+		},
+		{
+			name:        "same fee",
+			replacement: newTestTx(0, 100, 100),
+			expectedErr: errConflictsWithOtherTx,
+		},
+		{
+			name:        "higher fee but lower fee rate",
+			replacement: newTestTx(0, 150, 200),
+			expectedErr: errConflictsWithOtherTx,
+		},
+		{
+			name:        "higher fee rate but not higher fee",
+			replacement: newTestTx(0, 100, 50),
+			expectedErr: errConflictsWithOtherTx,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			mempool, err := New("mempool", prometheus.NewRegistry(), nil, assetID)
+			require.NoError(err)
+
+			original := newTestTx(0, 100, 100)
+			require.NoError(mempool.Add(original))
+
+			err = mempool.Add(test.replacement)
+			require.ErrorIs(err, test.expectedErr)
+
+			replaced := test.expectedErr == nil
+			require.Equal(!replaced, mempool.Has(original.ID()))
+			require.Equal(replaced, mempool.Has(test.replacement.ID()))
+			if replaced {
+				require.ErrorIs(mempool.GetDropReason(original.ID()), errReplaced)
+				require.Equal(maxMempoolSize-len(test.replacement.Bytes()), mempool.Stats().BytesAvailable)
+			}
+		})
+	}
+}
+
+func TestMempoolEvictsLowestFeeRate(t *testing.T) {
+	require := require.New(t)
+
+	mempoolIntf, err := New("mempool", prometheus.NewRegistry(), nil, assetID)
+	require.NoError(err)
+	mempool := mempoolIntf.(*mempool)
+
+	lowFeeTx := newTestTx(0, 100, 100)
+	highFeeTx := newTestTx(1, 300, 100)
+	require.NoError(mempool.Add(lowFeeTx))
+	require.NoError(mempool.Add(highFeeTx))
+
+	// shortcut to simulate a full mempool
+	mempool.bytesAvailable = 0
+
+	// A tx that doesn't pay a higher fee rate than any tx in the mempool
+	// can't evict them.
+	err = mempool.Add(newTestTx(2, 100, 100))
+	require.ErrorIs(err, errMempoolFull)
+
+	// A tx with a higher fee rate evicts only as many txs as needed.
+	midFeeTx := newTestTx(3, 200, 100)
+	require.NoError(mempool.Add(midFeeTx))
+	require.False(mempool.Has(lowFeeTx.ID()))
+	require.ErrorIs(mempool.GetDropReason(lowFeeTx.ID()), errEvicted)
+	require.True(mempool.Has(highFeeTx.ID()))
+	require.True(mempool.Has(midFeeTx.ID()))
+	require.Zero(mempool.bytesAvailable)
+
+	// A larger tx can't be added if it would need to evict txs with higher
+	// fee rates.
+	err = mempool.Add(newTestTx(4, 500, 200))
+	require.ErrorIs(err, errMempoolFull)
+	require.True(mempool.Has(highFeeTx.ID()))
+	require.True(mempool.Has(midFeeTx.ID()))
+}
+
+// newTestTx returns a tx of [size] bytes that consumes the UTXO at
+// [utxoIndex] and burns [fee] of [assetID].
+func newTestTx(utxoIndex uint32, fee uint64, size int) *txs.Tx {
+	addr := keys[0].PublicKey().Address()
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: chainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        ids.ID{'t', 'x', 'I', 'D'},
+				OutputIndex: utxoIndex,
+			},
+			Asset: avax.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: 1000 + fee,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1000,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}},
+	}}}
+	tx.SetBytes(utils.RandomBytes(size), utils.RandomBytes(size))
+	return tx
+}
@@ -4,9 +4,11 @@
 package txs
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
+	"time"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
@@ -17,10 +19,22 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 )
 
-// CodecVersion is the current default codec version
-const CodecVersion = 0
+const (
+	// CodecVersion is the current default codec version
+	CodecVersion = 0
 
-var _ Parser = (*parser)(nil)
+	// FxNamespaceSize is the number of codec type IDs reserved for each fx
+	// registered into a namespace.
+	FxNamespaceSize = 64
+)
+
+var (
+	_ Parser = (*parser)(nil)
+
+	errFxNamespaceOrder  = errors.New("fx namespaces must be registered in increasing order")
+	errFxNamespaceFull   = errors.New("fx registered more types than fit in its namespace")
+	errFxExtensionsOrder = errors.New("fx extensions must be registered before fx namespaces")
+)
 
 type Parser interface {
 	Codec() codec.Manager
@@ -34,6 +48,28 @@ type Parser interface {
 
 	InitializeTx(tx *Tx) error
 	InitializeGenesisTx(tx *Tx) error
+
+	// RegisterFxExtensions registers the types that were added to the fxs
+	// the parser was created with after they were first deployed. The
+	// extension types of the fx at index i are registered into the i-th of a
+	// sequence of [FxNamespaceSize] type IDs blocks, which starts after the
+	// last type registered before the call, so that the type IDs of the
+	// extensions of an fx don't depend on the extensions of the other fxs.
+	//
+	// RegisterFxExtensions must be called after all the other types of the VM
+	// have been registered, and before RegisterNamespacedFx.
+	RegisterFxExtensions() error
+
+	// RegisterNamespacedFx initializes [fx] as the fx at [fxIndex] and
+	// registers its types into the codec type IDs of [namespace].
+	//
+	// The first namespace starts after the last type registered before the
+	// first call to RegisterNamespacedFx. Each namespace contains
+	// [FxNamespaceSize] type IDs, so the type IDs of an fx only depend on its
+	// namespace rather than on which other fxs are registered. Namespaces must
+	// be registered in increasing order and no other types may be registered
+	// once the first namespace has been.
+	RegisterNamespacedFx(fxIndex int, fx fxs.Fx, namespace uint16) error
 }
 
 type parser struct {
@@ -41,21 +77,36 @@ type parser struct {
 	gcm codec.Manager
 	c   linearcodec.Codec
 	gc  linearcodec.Codec
+
+	vm *fxVM
+	// extensions are the types that were added to each fx after it was first
+	// deployed
+	extensions []*deferredRegistry
+	// nextNamespace is the first namespace that can still be registered
+	nextNamespace uint32
 }
 
+// NewParser returns a parser of the txs of a chain with [fxs]. The types that
+// were added to [fxs] after they were first deployed are always registered.
 func NewParser(fxs []fxs.Fx) (Parser, error) {
 	return NewCustomParser(
 		make(map[reflect.Type]int),
 		&mockable.Clock{},
 		logging.NoLog{},
+		time.Time{},
 		fxs,
 	)
 }
 
+// NewCustomParser returns a parser of the txs of a chain with [fxs]. The types
+// that were added to [fxs] after they were first deployed may only be used
+// from [fxExtensionsActivationTime], and aren't registered if it is
+// [mockable.MaxTime].
 func NewCustomParser(
 	typeToFxIndex map[reflect.Type]int,
 	clock *mockable.Clock,
 	log logging.Logger,
+	fxExtensionsActivationTime time.Time,
 	fxs []fxs.Fx,
 ) (Parser, error) {
 	gc := linearcodec.New([]string{reflectcodec.DefaultTagName}, 1<<20)
@@ -84,28 +135,104 @@ func NewCustomParser(
 	}
 
 	vm := &fxVM{
-		typeToFxIndex: typeToFxIndex,
-		clock:         clock,
-		log:           log,
+		typeToFxIndex:            typeToFxIndex,
+		clock:                    clock,
+		log:                      log,
+		extensionsActivationTime: fxExtensionsActivationTime,
 	}
+	extensions := make([]*deferredRegistry, len(fxs))
 	for i, fx := range fxs {
 		vm.codecRegistry = &codecRegistry{
 			codecs:      []codec.Registry{gc, c},
 			index:       i,
 			typeToIndex: vm.typeToFxIndex,
 		}
+		extensions[i] = &deferredRegistry{}
+		vm.extensionsRegistry = extensions[i]
 		if err := fx.Initialize(vm); err != nil {
 			return nil, err
 		}
 	}
 	return &parser{
-		cm:  cm,
-		gcm: gcm,
-		c:   c,
-		gc:  gc,
+		cm:         cm,
+		gcm:        gcm,
+		c:          c,
+		gc:         gc,
+		vm:         vm,
+		extensions: extensions,
 	}, nil
 }
 
+func (p *parser) RegisterFxExtensions() error {
+	if p.nextNamespace != 0 {
+		return errFxExtensionsOrder
+	}
+
+	for i, extensions := range p.extensions {
+		err := p.registerNamespace(i, func() error {
+			c := p.vm.CodecRegistry()
+			for _, val := range extensions.types {
+				if err := c.RegisterType(val); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to register extensions of fx %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p *parser) RegisterNamespacedFx(fxIndex int, fx fxs.Fx, namespace uint16) error {
+	if uint32(namespace) < p.nextNamespace {
+		return fmt.Errorf("%w: %d after %d", errFxNamespaceOrder, namespace, p.nextNamespace-1)
+	}
+
+	// Skip the type IDs of the namespaces that aren't used
+	skipped := int(uint32(namespace)-p.nextNamespace) * FxNamespaceSize
+	p.c.SkipRegistrations(skipped)
+	p.gc.SkipRegistrations(skipped)
+
+	err := p.registerNamespace(fxIndex, func() error {
+		return fx.Initialize(p.vm)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register namespace %d: %w", namespace, err)
+	}
+	p.nextNamespace = uint32(namespace) + 1
+	return nil
+}
+
+// registerNamespace registers the types registered by [initialize], as types
+// of the fx at [fxIndex], into the next [FxNamespaceSize] type IDs. The
+// extension types of the fx are registered into the same type IDs.
+func (p *parser) registerNamespace(fxIndex int, initialize func() error) error {
+	registry := &codecRegistry{
+		codecs:      []codec.Registry{p.gc, p.c},
+		index:       fxIndex,
+		typeToIndex: p.vm.typeToFxIndex,
+	}
+	p.vm.codecRegistry = registry
+	p.vm.extensionsRegistry = registry
+	if err := initialize(); err != nil {
+		return err
+	}
+	if registry.numTypes > FxNamespaceSize {
+		return fmt.Errorf("%w: registered %d types",
+			errFxNamespaceFull,
+			registry.numTypes,
+		)
+	}
+
+	// Skip the type IDs of the namespace that the fx didn't use
+	unused := FxNamespaceSize - registry.numTypes
+	p.c.SkipRegistrations(unused)
+	p.gc.SkipRegistrations(unused)
+	return nil
+}
+
 func (p *parser) Codec() codec.Manager {
 	return p.cm
 }
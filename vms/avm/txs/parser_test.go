@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+type wrapper struct {
+	Value interface{} `serialize:"true"`
+}
+
+// typeID returns the codec type ID that [value] is serialized with.
+func typeID(t *testing.T, p Parser, value interface{}) uint32 {
+	bytes, err := p.Codec().Marshal(CodecVersion, &wrapper{Value: value})
+	require.NoError(t, err)
+	// Skip the codec version
+	return binary.BigEndian.Uint32(bytes[2:])
+}
+
+func TestRegisterNamespacedFx(t *testing.T) {
+	require := require.New(t)
+
+	typeToFxIndex := make(map[reflect.Type]int)
+	p, err := NewCustomParser(
+		typeToFxIndex,
+		&mockable.Clock{},
+		logging.NoLog{},
+		time.Time{},
+		[]fxs.Fx{
+			&secp256k1fx.Fx{},
+		},
+	)
+	require.NoError(err)
+
+	// The namespaces start after the 5 txs and the 5 secp256k1fx types
+	const firstNamespaceTypeID = 10
+	require.Equal(uint32(firstNamespaceTypeID-3), typeID(t, p, &secp256k1fx.TransferOutput{}))
+
+	require.NoError(p.RegisterNamespacedFx(1, &nftfx.Fx{}, 2))
+	require.Equal(uint32(firstNamespaceTypeID+2*FxNamespaceSize), typeID(t, p, &nftfx.MintOutput{}))
+	require.Equal(uint32(firstNamespaceTypeID+2*FxNamespaceSize+1), typeID(t, p, &nftfx.TransferOutput{}))
+	require.Equal(1, typeToFxIndex[reflect.TypeOf(&nftfx.MintOutput{})])
+
+	err = p.RegisterNamespacedFx(2, &propertyfx.Fx{}, 2)
+	require.ErrorIs(err, errFxNamespaceOrder)
+
+	require.NoError(p.RegisterNamespacedFx(2, &propertyfx.Fx{}, 4))
+	require.Equal(uint32(firstNamespaceTypeID+4*FxNamespaceSize), typeID(t, p, &propertyfx.MintOutput{}))
+	require.Equal(2, typeToFxIndex[reflect.TypeOf(&propertyfx.MintOutput{})])
+}
+
+func TestRegisterFxExtensions(t *testing.T) {
+	require := require.New(t)
+
+	typeToFxIndex := make(map[reflect.Type]int)
+	p, err := NewCustomParser(
+		typeToFxIndex,
+		&mockable.Clock{},
+		logging.NoLog{},
+		time.Time{},
+		[]fxs.Fx{
+			&nftfx.Fx{},
+			&secp256k1fx.Fx{},
+		},
+	)
+	require.NoError(err)
+
+	require.NoError(p.RegisterFxExtensions())
+
+	// The extensions start after the 5 txs, the 5 nftfx types and the 5
+	// secp256k1fx types, and the secp256k1fx is the second fx.
+	const firstExtensionTypeID = 15 + FxNamespaceSize
+	require.Equal(uint32(firstExtensionTypeID), typeID(t, p, &secp256k1fx.RotateOwnersOperation{}))
+	require.Equal(uint32(firstExtensionTypeID+1), typeID(t, p, &secp256k1fx.UpdateMetadataOperation{}))
+	require.Equal(uint32(firstExtensionTypeID+2), typeID(t, p, &secp256k1fx.ExtendLocktimeOperation{}))
+	require.Equal(1, typeToFxIndex[reflect.TypeOf(&secp256k1fx.RotateOwnersOperation{})])
+
+	// Namespaces start after the extensions of every fx
+	require.NoError(p.RegisterNamespacedFx(2, &propertyfx.Fx{}, 0))
+	require.Equal(uint32(15+2*FxNamespaceSize), typeID(t, p, &propertyfx.MintOutput{}))
+
+	err = p.RegisterFxExtensions()
+	require.ErrorIs(err, errFxExtensionsOrder)
+}
+
+func TestRegisterFxExtensionsNotScheduled(t *testing.T) {
+	require := require.New(t)
+
+	typeToFxIndex := make(map[reflect.Type]int)
+	p, err := NewCustomParser(
+		typeToFxIndex,
+		&mockable.Clock{},
+		logging.NoLog{},
+		mockable.MaxTime,
+		[]fxs.Fx{
+			&secp256k1fx.Fx{},
+		},
+	)
+	require.NoError(err)
+
+	require.NoError(p.RegisterFxExtensions())
+
+	// The extensions aren't registered, but their type IDs are still
+	// reserved, so the namespaces start at the same type ID.
+	require.NotContains(typeToFxIndex, reflect.TypeOf(&secp256k1fx.RotateOwnersOperation{}))
+
+	require.NoError(p.RegisterNamespacedFx(1, &propertyfx.Fx{}, 0))
+	require.Equal(uint32(10+FxNamespaceSize), typeID(t, p, &propertyfx.MintOutput{}))
+}
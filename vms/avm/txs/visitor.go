@@ -11,9 +11,11 @@ var _ Visitor = (*utxoGetter)(nil)
 type Visitor interface {
 	BaseTx(*BaseTx) error
 	CreateAssetTx(*CreateAssetTx) error
+	CreateCappedAssetTx(*CreateCappedAssetTx) error
 	OperationTx(*OperationTx) error
 	ImportTx(*ImportTx) error
 	ExportTx(*ExportTx) error
+	FeeRateTx(*FeeRateTx) error
 }
 
 // utxoGetter returns the UTXOs transaction is producing.
@@ -46,6 +48,10 @@ func (u *utxoGetter) ExportTx(tx *ExportTx) error {
 	return u.BaseTx(&tx.BaseTx)
 }
 
+func (u *utxoGetter) FeeRateTx(tx *FeeRateTx) error {
+	return u.BaseTx(&tx.BaseTx)
+}
+
 func (u *utxoGetter) CreateAssetTx(t *CreateAssetTx) error {
 	if err := u.BaseTx(&t.BaseTx); err != nil {
 		return err
@@ -69,6 +75,10 @@ func (u *utxoGetter) CreateAssetTx(t *CreateAssetTx) error {
 	return nil
 }
 
+func (u *utxoGetter) CreateCappedAssetTx(t *CreateCappedAssetTx) error {
+	return u.CreateAssetTx(&t.CreateAssetTx)
+}
+
 func (u *utxoGetter) OperationTx(t *OperationTx) error {
 	// The error is explicitly dropped here because no error is ever returned
 	// from the utxoGetter.
@@ -20,6 +20,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/pubsub"
@@ -32,6 +33,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/avm/block"
@@ -47,6 +49,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
+	snowmanblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	blockbuilder "github.com/ava-labs/avalanchego/vms/avm/block/builder"
 	blockexecutor "github.com/ava-labs/avalanchego/vms/avm/block/executor"
 	extensions "github.com/ava-labs/avalanchego/vms/avm/fxs"
@@ -56,12 +59,17 @@ import (
 const assetToFxCacheSize = 1024
 
 var (
+	utxoIndexPrefix       = []byte("utxoIndex")
+	scheduledTxsPrefix    = []byte("scheduledTxs")
+	idempotencyKeysPrefix = []byte("idempotencyKeys")
+
 	errIncompatibleFx            = errors.New("incompatible feature extension")
 	errUnknownFx                 = errors.New("unknown feature extension")
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
 
-	_ vertex.LinearizableVMWithEngine = (*VM)(nil)
+	_ vertex.LinearizableVMWithEngine   = (*VM)(nil)
+	_ snowmanblock.DatabaseNamespacesVM = (*VM)(nil)
 )
 
 type VM struct {
@@ -102,22 +110,41 @@ type VM struct {
 	// Asset ID --> Bit set with fx IDs the asset supports
 	assetToFxCache *cache.LRU[ids.ID, set.Bits64]
 
+	// Session ID --> Signatures collected for the session's unsigned tx
+	signingSessions *cache.LRU[ids.ID, *signingSession]
+
+	// Signed txs waiting for their issue time to pass
+	scheduledTxs *scheduledTxs
+	// Issues the scheduled txs whose issue time has passed
+	scheduledTxTimer *timer.Timer
+
+	// Idempotency key --> ID of the tx issued with the key
+	idempotencyKeys *idempotencyKeys
+
 	baseDB database.Database
 	db     *versiondb.Database
 
 	typeToFxIndex map[reflect.Type]int
 	fxs           []*extensions.ParsedFx
+	// fxFactories looks up the fxs enabled by the VM config
+	fxFactories FxFactories
 
 	walletService WalletService
 
 	addressTxsIndexer index.AddressTxsIndexer
+	// utxoIndexer proves which UTXOs were produced for which addresses
+	utxoIndexer index.UTXOIndexer
 
 	txBackend *txexecutor.Backend
 
+	// Set to true if the state should be verified after every accepted block
+	checkInvariants bool
+
 	// These values are only initialized after the chain has been linearized.
 	blockbuilder.Builder
 	chainManager blockexecutor.Manager
 	network      network.Network
+	mempool      mempool.Mempool
 }
 
 func (*VM) Connected(context.Context, ids.NodeID, *version.Application) error {
@@ -135,9 +162,35 @@ func (*VM) Disconnected(context.Context, ids.NodeID) error {
  */
 
 type Config struct {
-	IndexTransactions    bool `json:"index-transactions"`
-	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
-	ChecksumsEnabled     bool `json:"checksums-enabled"`
+	IndexTransactions      bool `json:"index-transactions"`
+	IndexAllowIncomplete   bool `json:"index-allow-incomplete"`
+	IndexUTXOProofs        bool `json:"index-utxo-proofs"`
+	ChecksumsEnabled       bool `json:"checksums-enabled"`
+	InvariantChecksEnabled bool `json:"invariant-checks-enabled"`
+}
+
+// UpgradeConfig contains the network upgrades of the chain. It must be the
+// same across all the nodes validating the chain.
+type UpgradeConfig struct {
+	// If non-nil, fees scale with the size and complexity of txs from the
+	// activation time onwards.
+	DynamicFees *config.DynamicFees `json:"dynamicFees"`
+
+	// If non-nil, fees may alternatively be paid in the specified asset at
+	// the rate published by the oracle from the activation time onwards.
+	FeeConversion *config.FeeConversion `json:"feeConversion"`
+
+	// If non-nil, the types that were added to the fxs of the chain after
+	// they were first deployed may be used from the activation time onwards.
+	FxExtensions *config.FxExtensions `json:"fxExtensions"`
+
+	// If non-nil, assets may be created with a maximum supply from the
+	// activation time onwards.
+	CappedAssets *config.CappedAssets `json:"cappedAssets"`
+
+	// Fxs enables fxs in addition to the fxs the chain was created with. Each
+	// fx may be used from its activation time onwards.
+	Fxs []FxConfig `json:"fxs"`
 }
 
 func (vm *VM) Initialize(
@@ -145,7 +198,7 @@ func (vm *VM) Initialize(
 	ctx *snow.Context,
 	db database.Database,
 	genesisBytes []byte,
-	_ []byte,
+	upgradeBytes []byte,
 	configBytes []byte,
 	_ chan<- common.Message,
 	fxs []*common.Fx,
@@ -164,6 +217,53 @@ func (vm *VM) Initialize(
 		)
 	}
 
+	var (
+		fxExtensionsActivationTime = mockable.MaxTime
+		fxConfigs                  []FxConfig
+	)
+	if len(upgradeBytes) > 0 {
+		upgradeConfig := UpgradeConfig{}
+		if err := stdjson.Unmarshal(upgradeBytes, &upgradeConfig); err != nil {
+			return fmt.Errorf("failed to parse upgrade config: %w", err)
+		}
+		if upgradeConfig.DynamicFees != nil {
+			vm.Config.DynamicFees = upgradeConfig.DynamicFees
+			ctx.Log.Info("dynamic fees configured",
+				zap.Reflect("dynamicFees", upgradeConfig.DynamicFees),
+			)
+		}
+		if upgradeConfig.FeeConversion != nil {
+			if err := upgradeConfig.FeeConversion.Verify(); err != nil {
+				return fmt.Errorf("invalid fee conversion config: %w", err)
+			}
+			upgradeConfig.FeeConversion.Oracle.InitCtx(ctx)
+			vm.Config.FeeConversion = upgradeConfig.FeeConversion
+			ctx.Log.Info("fee conversion configured",
+				zap.Reflect("feeConversion", upgradeConfig.FeeConversion),
+			)
+		}
+		if upgradeConfig.CappedAssets != nil {
+			vm.Config.CappedAssets = upgradeConfig.CappedAssets
+			ctx.Log.Info("capped assets configured",
+				zap.Reflect("cappedAssets", upgradeConfig.CappedAssets),
+			)
+		}
+		if upgradeConfig.FxExtensions != nil {
+			fxExtensionsActivationTime = upgradeConfig.FxExtensions.ActivationTime
+			ctx.Log.Info("fx extensions configured",
+				zap.Time("activationTime", fxExtensionsActivationTime),
+			)
+		}
+		if len(upgradeConfig.Fxs) > 0 {
+			fxConfigs = upgradeConfig.Fxs
+			ctx.Log.Info("fxs configured",
+				zap.Reflect("fxs", fxConfigs),
+			)
+		}
+	}
+
+	vm.checkInvariants = avmConfig.InvariantChecksEnabled
+
 	registerer := prometheus.NewRegistry()
 	if err := ctx.Metrics.Register(registerer); err != nil {
 		return err
@@ -185,32 +285,19 @@ func (vm *VM) Initialize(
 	vm.baseDB = db
 	vm.db = versiondb.New(db)
 	vm.assetToFxCache = &cache.LRU[ids.ID, set.Bits64]{Size: assetToFxCacheSize}
+	vm.signingSessions = &cache.LRU[ids.ID, *signingSession]{Size: maxSigningSessions}
 
 	vm.pubsub = pubsub.New(ctx.Log)
 
-	typedFxs := make([]extensions.Fx, len(fxs))
-	vm.fxs = make([]*extensions.ParsedFx, len(fxs))
-	for i, fxContainer := range fxs {
-		if fxContainer == nil {
-			return errIncompatibleFx
-		}
-		fx, ok := fxContainer.Fx.(extensions.Fx)
-		if !ok {
-			return errIncompatibleFx
-		}
-		typedFxs[i] = fx
-		vm.fxs[i] = &extensions.ParsedFx{
-			ID: fxContainer.ID,
-			Fx: fx,
-		}
-	}
-
 	vm.typeToFxIndex = map[reflect.Type]int{}
-	vm.parser, err = block.NewCustomParser(
-		vm.typeToFxIndex,
-		&vm.clock,
+	vm.parser, vm.fxs, err = newParser(
 		ctx.Log,
-		typedFxs,
+		&vm.clock,
+		vm.typeToFxIndex,
+		fxExtensionsActivationTime,
+		vm.fxFactories,
+		fxs,
+		fxConfigs,
 	)
 	if err != nil {
 		return err
@@ -254,6 +341,30 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	utxoIndexDB := prefixdb.New(utxoIndexPrefix, vm.db)
+	if avmConfig.IndexUTXOProofs {
+		vm.ctx.Log.Info("utxo proof indexing is enabled")
+		vm.utxoIndexer, err = index.NewUTXOIndexer(utxoIndexDB, vm.parser.Codec(), txs.CodecVersion, avmConfig.IndexAllowIncomplete)
+		if err != nil {
+			return fmt.Errorf("failed to initialize utxo proof indexer: %w", err)
+		}
+	} else {
+		vm.utxoIndexer, err = index.NewNoUTXOIndexer(utxoIndexDB, avmConfig.IndexAllowIncomplete)
+		if err != nil {
+			return fmt.Errorf("failed to initialize disabled utxo proof indexer: %w", err)
+		}
+	}
+
+	vm.scheduledTxs, err = newScheduledTxs(prefixdb.New(scheduledTxsPrefix, vm.baseDB), vm.parser)
+	if err != nil {
+		return fmt.Errorf("failed to load scheduled txs: %w", err)
+	}
+
+	vm.idempotencyKeys, err = newIdempotencyKeys(prefixdb.New(idempotencyKeysPrefix, vm.baseDB), vm.clock.Time())
+	if err != nil {
+		return fmt.Errorf("failed to load idempotency keys: %w", err)
+	}
+
 	vm.txBackend = &txexecutor.Backend{
 		Ctx:           ctx,
 		Config:        &vm.Config,
@@ -287,6 +398,7 @@ func (vm *VM) onNormalOperationsStarted() error {
 	}
 
 	vm.bootstrapped = true
+	vm.resetScheduledTxTimer()
 	return nil
 }
 
@@ -306,6 +418,14 @@ func (vm *VM) Shutdown(context.Context) error {
 		return nil
 	}
 
+	if vm.scheduledTxTimer != nil {
+		// There is a potential deadlock if the timer is about to execute a
+		// timeout. So, the lock must be released before stopping the timer.
+		vm.ctx.Lock.Unlock()
+		vm.scheduledTxTimer.Stop()
+		vm.ctx.Lock.Lock()
+	}
+
 	return utils.Err(
 		vm.state.Close(),
 		vm.baseDB.Close(),
@@ -390,6 +510,14 @@ func (*VM) VerifyHeightIndex(context.Context) error {
 	return nil
 }
 
+// DatabaseNamespaces returns the databases of the UTXOs, the txs, and the
+// blocks.
+//
+// vm.ctx.Lock should be held while the returned databases are used
+func (vm *VM) DatabaseNamespaces() map[string]database.Iteratee {
+	return state.Namespaces(vm.db)
+}
+
 /*
  ******************************************************************************
  *********************************** DAG VM ***********************************
@@ -403,10 +531,11 @@ func (vm *VM) Linearize(_ context.Context, stopVertexID ids.ID, toEngine chan<-
 		return err
 	}
 
-	mempool, err := mempool.New("mempool", vm.registerer, toEngine)
+	mempool, err := mempool.New("mempool", vm.registerer, toEngine, vm.feeAssetID)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
+	vm.mempool = mempool
 
 	vm.chainManager = blockexecutor.NewManager(
 		mempool,
@@ -415,6 +544,7 @@ func (vm *VM) Linearize(_ context.Context, stopVertexID ids.ID, toEngine chan<-
 		vm.txBackend,
 		&vm.clock,
 		vm.onAccept,
+		vm.checkInvariants,
 	)
 
 	vm.Builder = blockbuilder.New(
@@ -437,6 +567,10 @@ func (vm *VM) Linearize(_ context.Context, stopVertexID ids.ID, toEngine chan<-
 	// handled asynchronously.
 	vm.Atomic.Set(vm.network)
 
+	vm.scheduledTxTimer = timer.NewTimer(vm.issueScheduledTxs)
+	go vm.ctx.Log.RecoverAndPanic(vm.scheduledTxTimer.Dispatch)
+	vm.resetScheduledTxTimer()
+
 	go func() {
 		err := vm.state.Prune(&vm.ctx.Lock, vm.ctx.Log)
 		if err != nil {
@@ -457,6 +591,8 @@ func (vm *VM) ParseTx(_ context.Context, bytes []byte) (snowstorm.Tx, error) {
 		return nil, err
 	}
 
+	// Txs issued into the DAG predate dynamic fees, so they are verified
+	// against the static fees.
 	err = tx.Unsigned.Visit(&txexecutor.SyntacticVerifier{
 		Backend: vm.txBackend,
 		Tx:      tx,
@@ -670,8 +806,15 @@ func (vm *VM) onAccept(tx *txs.Tx) error {
 	if err := vm.addressTxsIndexer.Accept(txID, inputUTXOs, outputUTXOs); err != nil {
 		return fmt.Errorf("error indexing tx: %w", err)
 	}
+	if err := vm.utxoIndexer.Accept(outputUTXOs); err != nil {
+		return fmt.Errorf("error indexing utxos: %w", err)
+	}
 
-	vm.pubsub.Publish(NewPubSubFilterer(tx))
+	filterer, err := NewPubSubFilterer(vm.parser.Codec(), tx, inputUTXOs)
+	if err != nil {
+		return fmt.Errorf("error creating pubsub notification: %w", err)
+	}
+	vm.pubsub.Publish(filterer)
 	vm.walletService.decided(txID)
 	return nil
 }
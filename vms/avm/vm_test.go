@@ -7,6 +7,7 @@ import (
 	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -20,12 +21,16 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/vms/avm/config"
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/avm/state"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/freezefx"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	txexecutor "github.com/ava-labs/avalanchego/vms/avm/txs/executor"
 )
 
 func TestInvalidGenesis(t *testing.T) {
@@ -318,6 +323,616 @@ func TestIssueProperty(t *testing.T) {
 	issueAndAccept(require, env.vm, env.issuer, burnPropertyTx)
 }
 
+func TestIssueUpdateAssetMetadata(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		vmStaticConfig: &config.Config{},
+		vmUpgradeConfig: &UpgradeConfig{
+			FxExtensions: &config.FxExtensions{},
+		},
+	})
+	defer func() {
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	minterOwners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+	}
+	createAssetTx := &txs.Tx{Unsigned: &txs.CreateAssetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: chainID,
+		}},
+		Name:         "Team Rocket",
+		Symbol:       "TR",
+		Denomination: 0,
+		States: []*txs.InitialState{{
+			FxIndex: 0,
+			Outs: []verify.State{
+				&secp256k1fx.MintOutput{
+					OutputOwners: minterOwners,
+				},
+			},
+		}},
+	}}
+	require.NoError(env.vm.parser.InitializeTx(createAssetTx))
+	issueAndAccept(require, env.vm, env.issuer, createAssetTx)
+
+	assetID := createAssetTx.ID()
+	_, err := env.vm.state.GetAssetMetadata(assetID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	codec := env.vm.parser.Codec()
+	newUpdateMetadataTx := func(mintUTXOID avax.UTXOID, symbol string, signer *secp256k1.PrivateKey) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.OperationTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: chainID,
+			}},
+			Ops: []*txs.Operation{{
+				Asset:   avax.Asset{ID: assetID},
+				UTXOIDs: []*avax.UTXOID{&mintUTXOID},
+				Op: &secp256k1fx.UpdateMetadataOperation{
+					MintInput: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+					MintOutput: secp256k1fx.MintOutput{
+						OutputOwners: minterOwners,
+					},
+					Name:         "Team Rocket",
+					Symbol:       symbol,
+					Denomination: 9,
+					URI:          "https://example.com/tr.json",
+				},
+			}},
+		}}
+		require.NoError(tx.SignSECP256K1Fx(codec, [][]*secp256k1.PrivateKey{{signer}}))
+		return tx
+	}
+
+	updateTx := newUpdateMetadataTx(
+		avax.UTXOID{
+			TxID:        assetID,
+			OutputIndex: 0,
+		},
+		"TRT",
+		keys[0],
+	)
+	issueAndAccept(require, env.vm, env.issuer, updateTx)
+
+	metadata, err := env.vm.state.GetAssetMetadata(assetID)
+	require.NoError(err)
+	require.Equal(&state.AssetMetadata{
+		Name:         "Team Rocket",
+		Symbol:       "TRT",
+		Denomination: 9,
+		URI:          "https://example.com/tr.json",
+	}, metadata)
+
+	// The recreated mint output must remain owned by the minters.
+	mintUTXOID := avax.UTXOID{
+		TxID:        updateTx.ID(),
+		OutputIndex: 0,
+	}
+	mintUTXO, err := env.vm.state.GetUTXO(mintUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(
+		&secp256k1fx.MintOutput{
+			OutputOwners: minterOwners,
+		},
+		mintUTXO.Out,
+	)
+
+	// Only the minters are able to update the metadata.
+	updateTx = newUpdateMetadataTx(mintUTXOID, "TR", keys[1])
+	_, err = env.vm.IssueTx(updateTx.Bytes())
+	require.ErrorIs(err, secp256k1fx.ErrWrongSig)
+}
+
+func TestIssueRotateOwners(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		vmStaticConfig: &config.Config{},
+		vmUpgradeConfig: &UpgradeConfig{
+			FxExtensions: &config.FxExtensions{},
+		},
+	})
+	defer func() {
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	avaxTx := getCreateTxFromGenesisTest(t, env.genesisBytes, "AVAX")
+	assetID := avaxTx.ID()
+
+	// Rotate the genesis output to be owned by a 2-of-2 multisig.
+	signers := []*secp256k1.PrivateKey{keys[1], keys[2]}
+	if keys[2].PublicKey().Address().Less(keys[1].PublicKey().Address()) {
+		signers[0], signers[1] = signers[1], signers[0]
+	}
+	multisigOwners := secp256k1fx.OutputOwners{
+		Threshold: 2,
+		Addrs: []ids.ShortID{
+			signers[0].PublicKey().Address(),
+			signers[1].PublicKey().Address(),
+		},
+	}
+
+	rotateTx := &txs.Tx{Unsigned: &txs.OperationTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: chainID,
+		}},
+		Ops: []*txs.Operation{{
+			Asset: avax.Asset{ID: assetID},
+			UTXOIDs: []*avax.UTXOID{{
+				TxID:        assetID,
+				OutputIndex: 2,
+			}},
+			Op: &secp256k1fx.RotateOwnersOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: secp256k1fx.TransferOutput{
+					Amt:          startBalance,
+					OutputOwners: multisigOwners,
+				},
+			},
+		}},
+	}}
+	codec := env.vm.parser.Codec()
+	require.NoError(rotateTx.SignSECP256K1Fx(codec, [][]*secp256k1.PrivateKey{{keys[0]}}))
+	issueAndAccept(require, env.vm, env.issuer, rotateTx)
+
+	rotatedUTXOID := avax.UTXOID{
+		TxID:        rotateTx.ID(),
+		OutputIndex: 0,
+	}
+	rotatedUTXO, err := env.vm.state.GetUTXO(rotatedUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(assetID, rotatedUTXO.AssetID())
+	require.Equal(
+		&secp256k1fx.TransferOutput{
+			Amt:          startBalance,
+			OutputOwners: multisigOwners,
+		},
+		rotatedUTXO.Out,
+	)
+
+	// The multisig must be able to rotate the output back to a single owner.
+	rotateBackTx := &txs.Tx{Unsigned: &txs.OperationTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: chainID,
+		}},
+		Ops: []*txs.Operation{{
+			Asset:   avax.Asset{ID: assetID},
+			UTXOIDs: []*avax.UTXOID{&rotatedUTXOID},
+			Op: &secp256k1fx.RotateOwnersOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0, 1},
+				},
+				TransferOutput: secp256k1fx.TransferOutput{
+					Amt: startBalance,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+					},
+				},
+			},
+		}},
+	}}
+	require.NoError(rotateBackTx.SignSECP256K1Fx(codec, [][]*secp256k1.PrivateKey{signers}))
+	issueAndAccept(require, env.vm, env.issuer, rotateBackTx)
+
+	_, err = env.vm.state.GetUTXO(rotatedUTXOID.InputID())
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestIssueExtendLocktime(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		vmStaticConfig: &config.Config{},
+		vmUpgradeConfig: &UpgradeConfig{
+			FxExtensions: &config.FxExtensions{},
+		},
+	})
+	defer func() {
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	avaxTx := getCreateTxFromGenesisTest(t, env.genesisBytes, "AVAX")
+	assetID := avaxTx.ID()
+	codec := env.vm.parser.Codec()
+
+	newExtendLocktimeTx := func(utxoID avax.UTXOID, locktime uint64) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.OperationTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: chainID,
+			}},
+			Ops: []*txs.Operation{{
+				Asset:   avax.Asset{ID: assetID},
+				UTXOIDs: []*avax.UTXOID{&utxoID},
+				Op: &secp256k1fx.ExtendLocktimeOperation{
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+					TransferOutput: secp256k1fx.TransferOutput{
+						Amt: startBalance,
+						OutputOwners: secp256k1fx.OutputOwners{
+							Locktime:  locktime,
+							Threshold: 1,
+							Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+						},
+					},
+				},
+			}},
+		}}
+		require.NoError(tx.SignSECP256K1Fx(codec, [][]*secp256k1.PrivateKey{{keys[0]}}))
+		return tx
+	}
+
+	// Lock the genesis output.
+	locktime := env.vm.clock.Unix() + 3600
+	lockTx := newExtendLocktimeTx(
+		avax.UTXOID{
+			TxID:        assetID,
+			OutputIndex: 2,
+		},
+		locktime,
+	)
+	issueAndAccept(require, env.vm, env.issuer, lockTx)
+
+	lockedUTXOID := avax.UTXOID{
+		TxID:        lockTx.ID(),
+		OutputIndex: 0,
+	}
+	lockedUTXO, err := env.vm.state.GetUTXO(lockedUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(
+		&secp256k1fx.TransferOutput{
+			Amt: startBalance,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Locktime:  locktime,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+			},
+		},
+		lockedUTXO.Out,
+	)
+
+	// The locktime can't be shortened.
+	shortenTx := newExtendLocktimeTx(lockedUTXOID, locktime-1)
+	_, err = env.vm.IssueTx(shortenTx.Bytes())
+	require.ErrorIs(err, secp256k1fx.ErrLocktimeShortened)
+
+	// The locktime of the still locked output can be extended.
+	extendTx := newExtendLocktimeTx(lockedUTXOID, locktime+3600)
+	issueAndAccept(require, env.vm, env.issuer, extendTx)
+
+	_, err = env.vm.state.GetUTXO(lockedUTXOID.InputID())
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestIssueExtendLocktimeBeforeActivation(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		vmStaticConfig: &config.Config{},
+		vmUpgradeConfig: &UpgradeConfig{
+			FxExtensions: &config.FxExtensions{
+				ActivationTime: time.Now().Add(time.Hour),
+			},
+		},
+	})
+	defer func() {
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	avaxTx := getCreateTxFromGenesisTest(t, env.genesisBytes, "AVAX")
+	assetID := avaxTx.ID()
+
+	lockTx := &txs.Tx{Unsigned: &txs.OperationTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: chainID,
+		}},
+		Ops: []*txs.Operation{{
+			Asset: avax.Asset{ID: assetID},
+			UTXOIDs: []*avax.UTXOID{{
+				TxID:        assetID,
+				OutputIndex: 2,
+			}},
+			Op: &secp256k1fx.ExtendLocktimeOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: secp256k1fx.TransferOutput{
+					Amt: startBalance,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  env.vm.clock.Unix() + 3600,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+					},
+				},
+			},
+		}},
+	}}
+	require.NoError(lockTx.SignSECP256K1Fx(env.vm.parser.Codec(), [][]*secp256k1.PrivateKey{{keys[0]}}))
+
+	_, err := env.vm.IssueTx(lockTx.Bytes())
+	require.ErrorIs(err, secp256k1fx.ErrExtensionNotActivated)
+}
+
+func TestIssueFreeze(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		vmStaticConfig: &config.Config{},
+		additionalFxs: []*common.Fx{{
+			ID: freezefx.ID,
+			Fx: &freezefx.Fx{},
+		}},
+	})
+	defer func() {
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	managerOwners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{keys[1].PublicKey().Address()},
+	}
+	createAssetTx := &txs.Tx{Unsigned: &txs.CreateAssetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: chainID,
+		}},
+		Name:         "Team Rocket",
+		Symbol:       "TR",
+		Denomination: 0,
+		States: []*txs.InitialState{
+			{
+				FxIndex: 0,
+				Outs: []verify.State{
+					&secp256k1fx.TransferOutput{
+						Amt: startBalance,
+						OutputOwners: secp256k1fx.OutputOwners{
+							Threshold: 1,
+							Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+						},
+					},
+				},
+			},
+			{
+				FxIndex: 2,
+				Outs: []verify.State{
+					&freezefx.ManagerOutput{
+						OutputOwners: managerOwners,
+					},
+				},
+			},
+		},
+	}}
+	require.NoError(env.vm.parser.InitializeTx(createAssetTx))
+	issueAndAccept(require, env.vm, env.issuer, createAssetTx)
+
+	assetID := createAssetTx.ID()
+	codec := env.vm.parser.Codec()
+	newFreezeTx := func(managerUTXOID avax.UTXOID, frozen bool) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.OperationTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: chainID,
+			}},
+			Ops: []*txs.Operation{{
+				Asset:   avax.Asset{ID: assetID},
+				UTXOIDs: []*avax.UTXOID{&managerUTXOID},
+				Op: &freezefx.FreezeOperation{
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+					ManagerOutput: freezefx.ManagerOutput{
+						OutputOwners: managerOwners,
+					},
+					Frozen: frozen,
+				},
+			}},
+		}}
+		require.NoError(tx.SignFreezeFx(codec, [][]*secp256k1.PrivateKey{{keys[1]}}))
+		return tx
+	}
+
+	freezeTx := newFreezeTx(
+		avax.UTXOID{
+			TxID:        assetID,
+			OutputIndex: 1,
+		},
+		true,
+	)
+	issueAndAccept(require, env.vm, env.issuer, freezeTx)
+
+	frozen, err := env.vm.state.IsAssetFrozen(assetID)
+	require.NoError(err)
+	require.True(frozen)
+
+	newTransferTx := func(to ids.ShortID) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{
+					TxID:        assetID,
+					OutputIndex: 0,
+				},
+				Asset: avax.Asset{ID: assetID},
+				In: &secp256k1fx.TransferInput{
+					Amt: startBalance,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: startBalance,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{to},
+					},
+				},
+			}},
+		}}}
+		require.NoError(tx.SignSECP256K1Fx(codec, [][]*secp256k1.PrivateKey{{keys[0]}}))
+		return tx
+	}
+
+	// Transfers of the asset are rejected while it is frozen.
+	transferTx := newTransferTx(keys[2].PublicKey().Address())
+	_, err = env.vm.IssueTx(transferTx.Bytes())
+	require.ErrorIs(err, txexecutor.ErrAssetFrozen)
+
+	unfreezeTx := newFreezeTx(
+		avax.UTXOID{
+			TxID:        freezeTx.ID(),
+			OutputIndex: 0,
+		},
+		false,
+	)
+	issueAndAccept(require, env.vm, env.issuer, unfreezeTx)
+
+	frozen, err = env.vm.state.IsAssetFrozen(assetID)
+	require.NoError(err)
+	require.False(frozen)
+
+	// The rejected tx is remembered by the mempool, so a different transfer is
+	// issued once the asset is unfrozen.
+	transferTx = newTransferTx(keys[1].PublicKey().Address())
+	issueAndAccept(require, env.vm, env.issuer, transferTx)
+}
+
+func TestIssueCappedAsset(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		vmStaticConfig: &config.Config{},
+		vmUpgradeConfig: &UpgradeConfig{
+			CappedAssets: &config.CappedAssets{},
+		},
+	})
+	defer func() {
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	const (
+		initialSupply = 100
+		maxSupply     = 150
+	)
+	minterOwners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+	}
+	createAssetTx := &txs.Tx{Unsigned: &txs.CreateCappedAssetTx{
+		CreateAssetTx: txs.CreateAssetTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: chainID,
+			}},
+			Name:         "Team Rocket",
+			Symbol:       "TR",
+			Denomination: 0,
+			States: []*txs.InitialState{{
+				FxIndex: 0,
+				Outs: []verify.State{
+					&secp256k1fx.MintOutput{
+						OutputOwners: minterOwners,
+					},
+					&secp256k1fx.TransferOutput{
+						Amt:          initialSupply,
+						OutputOwners: minterOwners,
+					},
+				},
+			}},
+		},
+		MaxSupply: maxSupply,
+	}}
+	require.NoError(env.vm.parser.InitializeTx(createAssetTx))
+	issueAndAccept(require, env.vm, env.issuer, createAssetTx)
+
+	assetID := createAssetTx.ID()
+	supply, err := env.vm.state.GetAssetSupply(assetID)
+	require.NoError(err)
+	require.Equal(&state.AssetSupply{
+		MaxSupply: maxSupply,
+		Issued:    initialSupply,
+	}, supply)
+
+	codec := env.vm.parser.Codec()
+	newMintTx := func(mintUTXOID avax.UTXOID, amount uint64) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.OperationTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: chainID,
+			}},
+			Ops: []*txs.Operation{{
+				Asset:   avax.Asset{ID: assetID},
+				UTXOIDs: []*avax.UTXOID{&mintUTXOID},
+				Op: &secp256k1fx.MintOperation{
+					MintInput: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+					MintOutput: secp256k1fx.MintOutput{
+						OutputOwners: minterOwners,
+					},
+					TransferOutput: secp256k1fx.TransferOutput{
+						Amt:          amount,
+						OutputOwners: minterOwners,
+					},
+				},
+			}},
+		}}
+		require.NoError(tx.SignSECP256K1Fx(codec, [][]*secp256k1.PrivateKey{{keys[0]}}))
+		return tx
+	}
+
+	mintTx := newMintTx(
+		avax.UTXOID{
+			TxID:        assetID,
+			OutputIndex: 0,
+		},
+		maxSupply-initialSupply,
+	)
+	issueAndAccept(require, env.vm, env.issuer, mintTx)
+
+	supply, err = env.vm.state.GetAssetSupply(assetID)
+	require.NoError(err)
+	require.Equal(&state.AssetSupply{
+		MaxSupply: maxSupply,
+		Issued:    maxSupply,
+	}, supply)
+
+	// Minting past the max supply is rejected.
+	mintTx = newMintTx(
+		avax.UTXOID{
+			TxID:        mintTx.ID(),
+			OutputIndex: 0,
+		},
+		1,
+	)
+	_, err = env.vm.IssueTx(mintTx.Bytes())
+	require.ErrorIs(err, txs.ErrMaxSupplyExceeded)
+}
+
 func TestIssueTxWithFeeAsset(t *testing.T) {
 	require := require.New(t)
 
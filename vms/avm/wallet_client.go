@@ -49,6 +49,19 @@ type WalletClient interface {
 		memo string,
 		options ...rpc.Option,
 	) (ids.ID, error)
+	// Consolidate returns a chain of signed txs, in the order they must be
+	// issued, that merge the UTXOs of [assetID] held by [from] into a single
+	// UTXO owned by [changeAddr]
+	Consolidate(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		assetID string,
+		maxInputsPerTx uint32,
+		feeBudget uint64,
+		options ...rpc.Option,
+	) ([][]byte, error)
 }
 
 // implementation of an AVM wallet client for interacting with avm managed wallet on [chain]
@@ -152,3 +165,39 @@ func (c *walletClient) SendMultiple(
 	}, res, options...)
 	return res.TxID, err
 }
+
+func (c *walletClient) Consolidate(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	assetID string,
+	maxInputsPerTx uint32,
+	feeBudget uint64,
+	options ...rpc.Option,
+) ([][]byte, error) {
+	res := &ConsolidateReply{}
+	err := c.requester.SendRequest(ctx, "wallet.consolidate", &ConsolidateArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		AssetID:        assetID,
+		MaxInputsPerTx: json.Uint32(maxInputsPerTx),
+		FeeBudget:      json.Uint64(feeBudget),
+		Encoding:       formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([][]byte, len(res.Txs))
+	for i, txStr := range res.Txs {
+		txs[i], err = formatting.Decode(res.Encoding, txStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
@@ -311,3 +311,20 @@ func (w *WalletService) SendMultiple(_ *http.Request, args *SendMultipleArgs, re
 	reply.ChangeAddr, err = w.vm.FormatLocalAddress(changeAddr)
 	return err
 }
+
+// Consolidate returns a chain of signed txs that merge the user's UTXOs of an
+// asset into a single UTXO. UTXOs spent by txs pending over the wallet API are
+// excluded.
+func (w *WalletService) Consolidate(_ *http.Request, args *ConsolidateArgs, reply *ConsolidateReply) error {
+	w.vm.ctx.Log.Debug("API called",
+		zap.String("service", "wallet"),
+		zap.String("method", "consolidate"),
+		logging.UserString("username", args.Username),
+		logging.UserString("assetID", args.AssetID),
+	)
+
+	w.vm.ctx.Lock.Lock()
+	defer w.vm.ctx.Lock.Unlock()
+
+	return w.vm.consolidate(args, w.update, reply)
+}
@@ -10,6 +10,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 func TestWalletService_SendMultiple(t *testing.T) {
@@ -76,3 +83,114 @@ func TestWalletService_SendMultiple(t *testing.T) {
 		})
 	}
 }
+
+func TestWalletService_Consolidate(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		keystoreUsers: []*user{{
+			username:    username,
+			password:    password,
+			initialKeys: keys,
+		}},
+	})
+	defer func() {
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := env.genesisTx.ID()
+	addr := keys[0].PublicKey().Address()
+
+	// Add dust UTXOs that are smaller than any UTXO in genesis, so that they
+	// are consolidated first.
+	dustIDs := set.Set[ids.ID]{}
+	for i := 0; i < 4; i++ {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 2 * testTxFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+		env.vm.state.AddUTXO(utxo)
+		dustIDs.Add(utxo.InputID())
+	}
+	require.NoError(env.vm.state.Commit())
+
+	env.vm.ctx.Lock.Unlock()
+
+	addrStr, err := env.vm.FormatLocalAddress(addr)
+	require.NoError(err)
+
+	args := &ConsolidateArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStr},
+		},
+		AssetID:        assetID.String(),
+		MaxInputsPerTx: 1,
+		FeeBudget:      json.Uint64(2 * testTxFee),
+	}
+	reply := &ConsolidateReply{}
+	err = env.walletService.Consolidate(nil, args, reply)
+	require.ErrorIs(err, errInvalidMaxInputsPerTx)
+
+	args.MaxInputsPerTx = 3
+	args.FeeBudget = json.Uint64(testTxFee - 1)
+	err = env.walletService.Consolidate(nil, args, reply)
+	require.ErrorIs(err, errFeeBudgetTooLow)
+
+	// The fee budget only allows for 2 txs.
+	args.FeeBudget = json.Uint64(2*testTxFee + 1)
+	require.NoError(env.walletService.Consolidate(nil, args, reply))
+	require.Equal(addrStr, reply.ChangeAddr)
+	require.Len(reply.TxIDs, 2)
+	require.Len(reply.Txs, 2)
+
+	consolidated := make([]*txs.Tx, len(reply.Txs))
+	for i, txStr := range reply.Txs {
+		txBytes, err := formatting.Decode(reply.Encoding, txStr)
+		require.NoError(err)
+		consolidated[i], err = env.vm.parser.ParseTx(txBytes)
+		require.NoError(err)
+		require.Equal(reply.TxIDs[i], consolidated[i].ID())
+	}
+
+	// The first tx merges the 3 smallest UTXOs.
+	firstTx := consolidated[0].Unsigned.(*txs.BaseTx)
+	require.Len(firstTx.Ins, 3)
+	for _, in := range firstTx.Ins {
+		require.Contains(dustIDs, in.InputID())
+	}
+	require.Len(firstTx.Outs, 1)
+	require.Equal(5*testTxFee, firstTx.Outs[0].Out.Amount())
+
+	// The second tx merges the output of the first tx with the remaining UTXOs.
+	secondTx := consolidated[1].Unsigned.(*txs.BaseTx)
+	require.Len(secondTx.Ins, 3)
+	consolidatedUTXOID := avax.UTXOID{TxID: reply.TxIDs[0]}
+	require.Contains(
+		[]ids.ID{secondTx.Ins[0].InputID(), secondTx.Ins[1].InputID(), secondTx.Ins[2].InputID()},
+		consolidatedUTXOID.InputID(),
+	)
+	require.Len(secondTx.Outs, 1)
+
+	env.vm.ctx.Lock.Lock()
+
+	// The txs are valid when issued in order.
+	for _, tx := range consolidated {
+		_, err := env.vm.IssueTx(tx.Bytes())
+		require.NoError(err)
+		buildAndAccept(require, env.vm, env.issuer, tx.ID())
+	}
+}
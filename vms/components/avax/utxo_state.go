@@ -33,6 +33,10 @@ type UTXOState interface {
 
 	// Checksum returns the current UTXOChecksum.
 	Checksum() ids.ID
+
+	// ForEachUTXO calls [f] with every UTXO in storage, sorted by UTXO ID.
+	// Iteration stops at the first error returned by [f].
+	ForEachUTXO(f func(*UTXO) error) error
 }
 
 // UTXOReader is a thin wrapper around a database to provide fetching of UTXOs.
@@ -143,6 +147,15 @@ func NewMeteredUTXOState(
 	return s, s.initChecksum()
 }
 
+// UTXOStateNamespaces returns the databases of the UTXOs and of the address
+// index of the UTXO state created from [db], keyed by the name of the index.
+func UTXOStateNamespaces(db database.Database) map[string]database.Iteratee {
+	return map[string]database.Iteratee{
+		"utxos":      prefixdb.New(utxoPrefix, db),
+		"utxo_index": prefixdb.New(indexPrefix, db),
+	}
+}
+
 func (s *utxoState) GetUTXO(utxoID ids.ID) (*UTXO, error) {
 	if utxo, found := s.utxoCache.Get(utxoID); found {
 		if utxo == nil {
@@ -255,6 +268,22 @@ func (s *utxoState) Checksum() ids.ID {
 	return s.checksum
 }
 
+func (s *utxoState) ForEachUTXO(f func(*UTXO) error) error {
+	it := s.utxoDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		utxo := &UTXO{}
+		if _, err := s.codec.Unmarshal(it.Value(), utxo); err != nil {
+			return err
+		}
+		if err := f(utxo); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 func (s *utxoState) getIndexDB(addr []byte) linkeddb.LinkedDB {
 	addrStr := string(addr)
 	if indexList, exists := s.indexCache.Get(addrStr); exists {
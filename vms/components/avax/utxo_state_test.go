@@ -91,4 +91,11 @@ func TestUTXOState(t *testing.T) {
 	utxoIDs, err = s.UTXOIDs(addr[:], ids.Empty, 5)
 	require.NoError(err)
 	require.Equal([]ids.ID{utxoID}, utxoIDs)
+
+	utxoIDs = nil
+	require.NoError(s.ForEachUTXO(func(utxo *UTXO) error {
+		utxoIDs = append(utxoIDs, utxo.InputID())
+		return nil
+	}))
+	require.Equal([]ids.ID{utxoID}, utxoIDs)
 }
@@ -0,0 +1,246 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+var (
+	ErrUTXONotIndexed         = errors.New("utxo not indexed for address")
+	ErrUTXOProofIndexDisabled = errors.New("utxo proof indexing is disabled")
+
+	nodePrefix  = []byte{0}
+	utxoPrefix  = []byte{1}
+	numUTXOsKey = []byte{2}
+
+	_ UTXOIndexer = (*utxoIndexer)(nil)
+	_ UTXOIndexer = (*noUTXOIndexer)(nil)
+)
+
+// UTXOIndexer maintains which UTXOs were produced for which addresses, along
+// with a Merkle root per address that commits to them.
+//
+// A UTXO is said to be produced for an address if the address at least
+// partially owns it. UTXOs remain indexed after they are consumed.
+//
+// The root of an address only depends on the accepted transactions, so it is
+// the same on every node that indexed every UTXO produced for the address.
+// A client that doesn't trust a node can verify a proof against a root that
+// it obtained from multiple nodes.
+type UTXOIndexer interface {
+	// Accept is called when the transaction producing [outputUTXOs] is
+	// accepted.
+	// If the error is non-nil, do not persist the transaction to disk as
+	// accepted in the VM.
+	Accept(outputUTXOs []*avax.UTXO) error
+
+	// GetProof returns the bytes of the UTXO [utxoID] produced for [address],
+	// a proof of its inclusion in the UTXOs produced for [address], and the
+	// root the proof is relative to.
+	GetProof(address []byte, utxoID ids.ID) ([]byte, *UTXOProof, ids.ID, error)
+}
+
+type utxoIndexer struct {
+	db           database.Database
+	codec        codec.Manager
+	codecVersion uint16
+}
+
+// NewUTXOIndexer returns a new UTXOIndexer that serializes UTXOs with [codec]
+// at [codecVersion].
+// The returned indexer ignores UTXOs that are not avax.Addressable.
+func NewUTXOIndexer(
+	db database.Database,
+	codec codec.Manager,
+	codecVersion uint16,
+	allowIncompleteIndices bool,
+) (UTXOIndexer, error) {
+	i := &utxoIndexer{
+		db:           db,
+		codec:        codec,
+		codecVersion: codecVersion,
+	}
+	return i, checkIndexStatus(db, true, allowIncompleteIndices)
+}
+
+// Accept appends each of [outputUTXOs] to the UTXOs of the addresses that own
+// it.
+// The database structure is:
+// [address]
+// |  [0x00][height][index] => node of a perfect binary Merkle tree
+// |  [0x01][utxoID]        => index of the UTXO ++ UTXO bytes
+// |  [0x02]                => number of UTXOs
+// See interface documentation UTXOIndexer.Accept
+func (i *utxoIndexer) Accept(outputUTXOs []*avax.UTXO) error {
+	for _, utxo := range outputUTXOs {
+		out, ok := utxo.Out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+
+		utxoBytes, err := i.codec.Marshal(i.codecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("failed to marshal UTXO %s: %w", utxo.InputID(), err)
+		}
+
+		addresses := set.Set[string]{}
+		for _, address := range out.Addresses() {
+			addresses.Add(string(address))
+		}
+		for address := range addresses {
+			if err := i.append(prefixdb.New([]byte(address), i.db), utxo.InputID(), utxoBytes); err != nil {
+				return fmt.Errorf("failed to index UTXO %s: %w", utxo.InputID(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// append adds the UTXO [utxoID] to the UTXOs of the address of [db]. The
+// nodes of every perfect binary Merkle tree that the UTXO completes are
+// written, so that proofs never need to hash more than one path.
+func (*utxoIndexer) append(db database.Database, utxoID ids.ID, utxoBytes []byte) error {
+	numUTXOs, err := getNumUTXOs(db)
+	if err != nil {
+		return err
+	}
+
+	p := wrappers.Packer{
+		Bytes: make([]byte, wrappers.LongLen+len(utxoBytes)),
+	}
+	p.PackLong(numUTXOs)
+	p.PackFixedBytes(utxoBytes)
+	if err := db.Put(utxoKey(utxoID), p.Bytes); err != nil {
+		return err
+	}
+
+	var (
+		height = 0
+		index  = numUTXOs
+		node   = leafHash(utxoBytes)
+	)
+	if err := db.Put(nodeKey(height, index), node[:]); err != nil {
+		return err
+	}
+	for index%2 == 1 {
+		left, err := getNode(db, height, index-1)
+		if err != nil {
+			return err
+		}
+		node = parentHash(left, node)
+		height++
+		index /= 2
+		if err := db.Put(nodeKey(height, index), node[:]); err != nil {
+			return err
+		}
+	}
+	return database.PutUInt64(db, numUTXOsKey, numUTXOs+1)
+}
+
+func (i *utxoIndexer) GetProof(address []byte, utxoID ids.ID) ([]byte, *UTXOProof, ids.ID, error) {
+	db := prefixdb.New(address, i.db)
+	entry, err := db.Get(utxoKey(utxoID))
+	if err == database.ErrNotFound {
+		return nil, nil, ids.Empty, fmt.Errorf("%w: %s", ErrUTXONotIndexed, utxoID)
+	}
+	if err != nil {
+		return nil, nil, ids.Empty, err
+	}
+	p := wrappers.Packer{Bytes: entry}
+	index := p.UnpackLong()
+	if p.Err != nil {
+		return nil, nil, ids.Empty, p.Err
+	}
+	utxoBytes := entry[p.Offset:]
+
+	numUTXOs, err := getNumUTXOs(db)
+	if err != nil {
+		return nil, nil, ids.Empty, err
+	}
+
+	proof := &UTXOProof{
+		Index:    index,
+		NumUTXOs: numUTXOs,
+	}
+	var offset uint64
+	for height := 63; height >= 0; height-- {
+		size := uint64(1) << height
+		if numUTXOs&size == 0 {
+			continue
+		}
+		peak, err := getNode(db, height, offset>>height)
+		if err != nil {
+			return nil, nil, ids.Empty, err
+		}
+		proof.Peaks = append(proof.Peaks, peak)
+
+		if offset <= index && index < offset+size {
+			for pathHeight := 0; pathHeight < height; pathHeight++ {
+				sibling, err := getNode(db, pathHeight, (index>>pathHeight)^1)
+				if err != nil {
+					return nil, nil, ids.Empty, err
+				}
+				proof.Path = append(proof.Path, sibling)
+			}
+		}
+		offset += size
+	}
+	return utxoBytes, proof, bagPeaks(proof.Peaks), nil
+}
+
+func getNumUTXOs(db database.KeyValueReader) (uint64, error) {
+	numUTXOs, err := database.GetUInt64(db, numUTXOsKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return numUTXOs, err
+}
+
+func getNode(db database.KeyValueReader, height int, index uint64) (ids.ID, error) {
+	nodeBytes, err := db.Get(nodeKey(height, index))
+	if err != nil {
+		return ids.Empty, err
+	}
+	return ids.ToID(nodeBytes)
+}
+
+func nodeKey(height int, index uint64) []byte {
+	key := make([]byte, len(nodePrefix)+1+wrappers.LongLen)
+	copy(key, nodePrefix)
+	key[len(nodePrefix)] = byte(height)
+	binary.BigEndian.PutUint64(key[len(nodePrefix)+1:], index)
+	return key
+}
+
+func utxoKey(utxoID ids.ID) []byte {
+	key := make([]byte, len(utxoPrefix)+ids.IDLen)
+	copy(key, utxoPrefix)
+	copy(key[len(utxoPrefix):], utxoID[:])
+	return key
+}
+
+type noUTXOIndexer struct{}
+
+func NewNoUTXOIndexer(db database.Database, allowIncomplete bool) (UTXOIndexer, error) {
+	return &noUTXOIndexer{}, checkIndexStatus(db, false, allowIncomplete)
+}
+
+func (*noUTXOIndexer) Accept([]*avax.UTXO) error {
+	return nil
+}
+
+func (*noUTXOIndexer) GetProof([]byte, ids.ID) ([]byte, *UTXOProof, ids.ID, error) {
+	return nil, nil, ids.Empty, ErrUTXOProofIndexDisabled
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const testCodecVersion = 0
+
+func newTestUTXO(amount uint64, addrs ...ids.ShortID) *avax.UTXO {
+	return &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: avax.Asset{
+			ID: ids.GenerateTestID(),
+		},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     addrs,
+			},
+		},
+	}
+}
+
+func newTestUTXOIndexer(t *testing.T) UTXOIndexer {
+	require := require.New(t)
+
+	c := linearcodec.NewDefault()
+	manager := codec.NewDefaultManager()
+	require.NoError(c.RegisterType(&secp256k1fx.TransferOutput{}))
+	require.NoError(manager.RegisterCodec(testCodecVersion, c))
+
+	indexer, err := NewUTXOIndexer(memdb.New(), manager, testCodecVersion, false)
+	require.NoError(err)
+	return indexer
+}
+
+func TestUTXOIndexerProofs(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		indexer = newTestUTXOIndexer(t)
+		addr    = ids.GenerateTestShortID()
+		utxos   []*avax.UTXO
+	)
+	for numUTXOs := 1; numUTXOs <= 19; numUTXOs++ {
+		utxo := newTestUTXO(uint64(numUTXOs), addr)
+		require.NoError(indexer.Accept([]*avax.UTXO{utxo}))
+		utxos = append(utxos, utxo)
+
+		// Every UTXO produced so far can be proven against the latest root.
+		var expectedRoot ids.ID
+		for i, utxo := range utxos {
+			utxoBytes, proof, root, err := indexer.GetProof(addr[:], utxo.InputID())
+			require.NoError(err)
+			require.Equal(uint64(i), proof.Index)
+			require.Equal(uint64(numUTXOs), proof.NumUTXOs)
+			require.NoError(proof.Verify(root, utxoBytes))
+
+			if i == 0 {
+				expectedRoot = root
+			}
+			require.Equal(expectedRoot, root)
+		}
+	}
+}
+
+func TestUTXOIndexerProofRejectsTampering(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		indexer = newTestUTXOIndexer(t)
+		addr    = ids.GenerateTestShortID()
+		utxos   = []*avax.UTXO{
+			newTestUTXO(1, addr),
+			newTestUTXO(2, addr),
+			newTestUTXO(3, addr),
+		}
+	)
+	require.NoError(indexer.Accept(utxos))
+
+	utxoBytes, proof, root, err := indexer.GetProof(addr[:], utxos[0].InputID())
+	require.NoError(err)
+	require.NoError(proof.Verify(root, utxoBytes))
+
+	otherBytes, _, _, err := indexer.GetProof(addr[:], utxos[1].InputID())
+	require.NoError(err)
+	err = proof.Verify(root, otherBytes)
+	require.ErrorIs(err, errPeakMismatch)
+
+	err = proof.Verify(ids.GenerateTestID(), utxoBytes)
+	require.ErrorIs(err, errRootMismatch)
+
+	wrongIndex := *proof
+	wrongIndex.Index = 1
+	err = wrongIndex.Verify(root, utxoBytes)
+	require.ErrorIs(err, errPeakMismatch)
+
+	outOfRange := *proof
+	outOfRange.Index = 3
+	err = outOfRange.Verify(root, utxoBytes)
+	require.ErrorIs(err, errIndexOutOfRange)
+
+	missingPeak := *proof
+	missingPeak.Peaks = proof.Peaks[:1]
+	err = missingPeak.Verify(root, utxoBytes)
+	require.ErrorIs(err, errWrongNumPeaks)
+
+	shortPath := *proof
+	shortPath.Path = nil
+	err = shortPath.Verify(root, utxoBytes)
+	require.ErrorIs(err, errWrongPathLength)
+}
+
+func TestUTXOIndexerMultipleOwners(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		indexer = newTestUTXOIndexer(t)
+		addr0   = ids.GenerateTestShortID()
+		addr1   = ids.GenerateTestShortID()
+		shared  = newTestUTXO(1, addr0, addr1)
+		owned   = newTestUTXO(2, addr0)
+	)
+	require.NoError(indexer.Accept([]*avax.UTXO{owned, shared}))
+
+	_, proof, _, err := indexer.GetProof(addr0[:], shared.InputID())
+	require.NoError(err)
+	require.Equal(uint64(1), proof.Index)
+
+	utxoBytes, proof, root, err := indexer.GetProof(addr1[:], shared.InputID())
+	require.NoError(err)
+	require.Zero(proof.Index)
+	require.Equal(uint64(1), proof.NumUTXOs)
+	require.NoError(proof.Verify(root, utxoBytes))
+
+	_, _, _, err = indexer.GetProof(addr1[:], owned.InputID())
+	require.ErrorIs(err, ErrUTXONotIndexed)
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const (
+	leafHashPrefix byte = iota
+	nodeHashPrefix
+)
+
+var (
+	errIndexOutOfRange = errors.New("index out of range")
+	errWrongNumPeaks   = errors.New("wrong number of peaks")
+	errWrongPathLength = errors.New("wrong path length")
+	errPeakMismatch    = errors.New("peak mismatch")
+	errRootMismatch    = errors.New("root mismatch")
+)
+
+// UTXOProof proves that a UTXO is the [Index]th of the [NumUTXOs] UTXOs that
+// were produced for an address.
+//
+// The UTXOs produced for an address are the leaves of a Merkle mountain range:
+// a list of perfect binary Merkle trees, the peaks, whose sizes are the powers
+// of two that sum to [NumUTXOs], from largest to smallest. The root commits to
+// all the peaks.
+type UTXOProof struct {
+	// Index of the UTXO in the order the UTXOs were produced
+	Index uint64
+	// NumUTXOs is the number of UTXOs that were produced for the address
+	NumUTXOs uint64
+	// Path is the siblings of the nodes on the path from the UTXO's leaf to
+	// the peak that contains it, from the leaf upwards
+	Path []ids.ID
+	// Peaks is the roots of the perfect binary Merkle trees, from largest to
+	// smallest
+	Peaks []ids.ID
+}
+
+// Verify returns nil if [utxoBytes] is included in the UTXOs with root
+// [expectedRoot].
+func (p *UTXOProof) Verify(expectedRoot ids.ID, utxoBytes []byte) error {
+	if p.Index >= p.NumUTXOs {
+		return fmt.Errorf("%w: %d >= %d", errIndexOutOfRange, p.Index, p.NumUTXOs)
+	}
+	if numPeaks := bits.OnesCount64(p.NumUTXOs); len(p.Peaks) != numPeaks {
+		return fmt.Errorf("%w: %d != %d", errWrongNumPeaks, len(p.Peaks), numPeaks)
+	}
+
+	peakIndex, height, offset := findPeak(p.NumUTXOs, p.Index)
+	if len(p.Path) != height {
+		return fmt.Errorf("%w: %d != %d", errWrongPathLength, len(p.Path), height)
+	}
+
+	node := leafHash(utxoBytes)
+	index := p.Index - offset
+	for _, sibling := range p.Path {
+		if index%2 == 0 {
+			node = parentHash(node, sibling)
+		} else {
+			node = parentHash(sibling, node)
+		}
+		index /= 2
+	}
+	if node != p.Peaks[peakIndex] {
+		return errPeakMismatch
+	}
+	if root := bagPeaks(p.Peaks); root != expectedRoot {
+		return fmt.Errorf("%w: %s != %s", errRootMismatch, root, expectedRoot)
+	}
+	return nil
+}
+
+// findPeak returns the index, height, and first leaf of the peak that contains
+// the leaf at [index] when there are [numLeaves] leaves.
+//
+// Assumes [index] < [numLeaves].
+func findPeak(numLeaves uint64, index uint64) (int, int, uint64) {
+	var (
+		peakIndex int
+		offset    uint64
+	)
+	for height := 63; height >= 0; height-- {
+		size := uint64(1) << height
+		if numLeaves&size == 0 {
+			continue
+		}
+		if index < offset+size {
+			return peakIndex, height, offset
+		}
+		peakIndex++
+		offset += size
+	}
+	return peakIndex, 0, offset
+}
+
+// bagPeaks returns the root that commits to [peaks]. The root of no peaks is
+// ids.Empty.
+func bagPeaks(peaks []ids.ID) ids.ID {
+	if len(peaks) == 0 {
+		return ids.Empty
+	}
+	root := peaks[len(peaks)-1]
+	for i := len(peaks) - 2; i >= 0; i-- {
+		root = parentHash(peaks[i], root)
+	}
+	return root
+}
+
+func leafHash(utxoBytes []byte) ids.ID {
+	preimage := make([]byte, 1+len(utxoBytes))
+	preimage[0] = leafHashPrefix
+	copy(preimage[1:], utxoBytes)
+	return hashing.ComputeHash256Array(preimage)
+}
+
+func parentHash(left, right ids.ID) ids.ID {
+	preimage := make([]byte, 1+2*ids.IDLen)
+	preimage[0] = nodeHashPrefix
+	copy(preimage[1:], left[:])
+	copy(preimage[1+ids.IDLen:], right[:])
+	return hashing.ComputeHash256Array(preimage)
+}
@@ -0,0 +1,23 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms"
+)
+
+var (
+	_ vms.Factory = (*Factory)(nil)
+
+	// ID that this Fx uses when labeled
+	ID = ids.ID{'f', 'r', 'e', 'e', 'z', 'e', 'f', 'x'}
+)
+
+type Factory struct{}
+
+func (*Factory) New(logging.Logger) (interface{}, error) {
+	return &Fx{}, nil
+}
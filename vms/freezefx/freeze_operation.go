@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var errNilFreezeOperation = errors.New("nil freeze operation")
+
+// FreezeOperation consumes a ManagerOutput to set whether transfers of its
+// asset are frozen. The ManagerOutput is recreated so that the asset can be
+// managed again later.
+type FreezeOperation struct {
+	Input         secp256k1fx.Input `serialize:"true" json:"input"`
+	ManagerOutput ManagerOutput     `serialize:"true" json:"managerOutput"`
+	Frozen        bool              `serialize:"true" json:"frozen"`
+}
+
+func (op *FreezeOperation) InitCtx(ctx *snow.Context) {
+	op.ManagerOutput.OutputOwners.InitCtx(ctx)
+}
+
+func (op *FreezeOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *FreezeOperation) Outs() []verify.State {
+	return []verify.State{&op.ManagerOutput}
+}
+
+func (op *FreezeOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilFreezeOperation
+	default:
+		return verify.All(&op.Input, &op.ManagerOutput)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestFreezeOperationVerifyNil(t *testing.T) {
+	op := (*FreezeOperation)(nil)
+	err := op.Verify()
+	require.ErrorIs(t, err, errNilFreezeOperation)
+}
+
+func TestFreezeOperationVerifyInvalidInput(t *testing.T) {
+	op := FreezeOperation{
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{1, 0},
+		},
+	}
+	err := op.Verify()
+	require.ErrorIs(t, err, secp256k1fx.ErrInputIndicesNotSortedUnique)
+}
+
+func TestFreezeOperationVerifyInvalidOutput(t *testing.T) {
+	op := FreezeOperation{
+		ManagerOutput: ManagerOutput{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+			},
+		},
+	}
+	err := op.Verify()
+	require.ErrorIs(t, err, secp256k1fx.ErrOutputUnspendable)
+}
+
+func TestFreezeOperationOuts(t *testing.T) {
+	op := FreezeOperation{}
+	require.Len(t, op.Outs(), 1)
+}
+
+func TestFreezeOperationState(t *testing.T) {
+	intf := interface{}(&FreezeOperation{})
+	_, ok := intf.(verify.State)
+	require.False(t, ok)
+}
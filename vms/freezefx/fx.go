@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errWrongTxType         = errors.New("wrong tx type")
+	errWrongUTXOType       = errors.New("wrong utxo type")
+	errWrongOperationType  = errors.New("wrong operation type")
+	errWrongCredentialType = errors.New("wrong credential type")
+	errWrongNumberOfUTXOs  = errors.New("wrong number of UTXOs for the operation")
+	errWrongManagerOutput  = errors.New("wrong manager output provided")
+	errCantTransfer        = errors.New("cant transfer with this fx")
+)
+
+// Fx allows the managers of an asset to freeze and unfreeze transfers of the
+// asset. The frozen status of an asset is tracked and enforced by the VM.
+type Fx struct{ secp256k1fx.Fx }
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+
+	log := fx.VM.Logger()
+	log.Debug("initializing freeze fx")
+
+	c := fx.VM.CodecRegistry()
+	return utils.Err(
+		c.RegisterType(&ManagerOutput{}),
+		c.RegisterType(&FreezeOperation{}),
+		c.RegisterType(&Credential{}),
+	)
+}
+
+func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.UnsignedTx)
+	switch {
+	case !ok:
+		return errWrongTxType
+	case len(utxosIntf) != 1:
+		return errWrongNumberOfUTXOs
+	}
+
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+
+	op, ok := opIntf.(*FreezeOperation)
+	if !ok {
+		return errWrongOperationType
+	}
+	return fx.VerifyFreezeOperation(tx, op, cred, utxosIntf[0])
+}
+
+func (fx *Fx) VerifyFreezeOperation(tx secp256k1fx.UnsignedTx, op *FreezeOperation, cred *Credential, utxoIntf interface{}) error {
+	out, ok := utxoIntf.(*ManagerOutput)
+	if !ok {
+		return errWrongUTXOType
+	}
+
+	if err := verify.All(op, cred, out); err != nil {
+		return err
+	}
+
+	switch {
+	case !out.OutputOwners.Equals(&op.ManagerOutput.OutputOwners):
+		return errWrongManagerOutput
+	default:
+		return fx.Fx.VerifyCredentials(tx, &op.Input, &cred.Credential, &out.OutputOwners)
+	}
+}
+
+func (*Fx) VerifyTransfer(_, _, _, _ interface{}) error {
+	return errCantTransfer
+}
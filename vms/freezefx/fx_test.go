@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	txBytes  = []byte{0, 1, 2, 3, 4, 5}
+	sigBytes = [secp256k1.SignatureLen]byte{
+		0x0e, 0x33, 0x4e, 0xbc, 0x67, 0xa7, 0x3f, 0xe8,
+		0x24, 0x33, 0xac, 0xa3, 0x47, 0x88, 0xa6, 0x3d,
+		0x58, 0xe5, 0x8e, 0xf0, 0x3a, 0xd5, 0x84, 0xf1,
+		0xbc, 0xa3, 0xb2, 0xd2, 0x5d, 0x51, 0xd6, 0x9b,
+		0x0f, 0x28, 0x5d, 0xcd, 0x3f, 0x71, 0x17, 0x0a,
+		0xf9, 0xbf, 0x2d, 0xb1, 0x10, 0x26, 0x5c, 0xe9,
+		0xdc, 0xc3, 0x9d, 0x7a, 0x01, 0x50, 0x9d, 0xe8,
+		0x35, 0xbd, 0xcb, 0x29, 0x3a, 0xd1, 0x49, 0x32,
+		0x00,
+	}
+	addr = [hashing.AddrLen]byte{
+		0x01, 0x5c, 0xce, 0x6c, 0x55, 0xd6, 0xb5, 0x09,
+		0x84, 0x5c, 0x8c, 0x4e, 0x30, 0xbe, 0xd9, 0x8d,
+		0x39, 0x1a, 0xe7, 0xf0,
+	}
+)
+
+func TestFxInitialize(t *testing.T) {
+	vm := secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := Fx{}
+	require.NoError(t, fx.Initialize(&vm))
+}
+
+func TestFxInitializeInvalid(t *testing.T) {
+	fx := Fx{}
+	err := fx.Initialize(nil)
+	require.ErrorIs(t, err, secp256k1fx.ErrWrongVMType)
+}
+
+func TestFxVerifyOperation(t *testing.T) {
+	managerOwners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs: []ids.ShortID{
+			addr,
+		},
+	}
+	newOp := func() *FreezeOperation {
+		return &FreezeOperation{
+			Input: secp256k1fx.Input{
+				SigIndices: []uint32{0},
+			},
+			ManagerOutput: ManagerOutput{
+				OutputOwners: managerOwners,
+			},
+			Frozen: true,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		tx          interface{}
+		op          interface{}
+		cred        interface{}
+		utxos       []interface{}
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(),
+			cred:        &Credential{Credential: secp256k1fx.Credential{Sigs: [][secp256k1.SignatureLen]byte{sigBytes}}},
+			utxos:       []interface{}{&ManagerOutput{OutputOwners: managerOwners}},
+			expectedErr: nil,
+		},
+		{
+			name:        "wrong tx type",
+			tx:          nil,
+			op:          newOp(),
+			cred:        &Credential{Credential: secp256k1fx.Credential{Sigs: [][secp256k1.SignatureLen]byte{sigBytes}}},
+			utxos:       []interface{}{&ManagerOutput{OutputOwners: managerOwners}},
+			expectedErr: errWrongTxType,
+		},
+		{
+			name:        "wrong number of utxos",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(),
+			cred:        &Credential{Credential: secp256k1fx.Credential{Sigs: [][secp256k1.SignatureLen]byte{sigBytes}}},
+			utxos:       nil,
+			expectedErr: errWrongNumberOfUTXOs,
+		},
+		{
+			name:        "wrong credential type",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(),
+			cred:        nil,
+			utxos:       []interface{}{&ManagerOutput{OutputOwners: managerOwners}},
+			expectedErr: errWrongCredentialType,
+		},
+		{
+			name:        "wrong operation type",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          nil,
+			cred:        &Credential{Credential: secp256k1fx.Credential{Sigs: [][secp256k1.SignatureLen]byte{sigBytes}}},
+			utxos:       []interface{}{&ManagerOutput{OutputOwners: managerOwners}},
+			expectedErr: errWrongOperationType,
+		},
+		{
+			name:        "wrong utxo type",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(),
+			cred:        &Credential{Credential: secp256k1fx.Credential{Sigs: [][secp256k1.SignatureLen]byte{sigBytes}}},
+			utxos:       []interface{}{&secp256k1fx.TransferOutput{OutputOwners: managerOwners}},
+			expectedErr: errWrongUTXOType,
+		},
+		{
+			name: "wrong manager output",
+			tx:   &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:   newOp(),
+			cred: &Credential{Credential: secp256k1fx.Credential{Sigs: [][secp256k1.SignatureLen]byte{sigBytes}}},
+			utxos: []interface{}{&ManagerOutput{OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					ids.ShortEmpty,
+				},
+			}}},
+			expectedErr: errWrongManagerOutput,
+		},
+		{
+			name:        "missing signature",
+			tx:          &secp256k1fx.TestTx{UnsignedBytes: txBytes},
+			op:          newOp(),
+			cred:        &Credential{},
+			utxos:       []interface{}{&ManagerOutput{OutputOwners: managerOwners}},
+			expectedErr: secp256k1fx.ErrInputCredentialSignersMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vm := secp256k1fx.TestVM{
+				Codec: linearcodec.NewDefault(),
+				Log:   logging.NoLog{},
+			}
+			fx := Fx{}
+			require.NoError(fx.Initialize(&vm))
+			require.NoError(fx.Bootstrapped())
+
+			err := fx.VerifyOperation(test.tx, test.op, test.cred, test.utxos)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestFxVerifyTransfer(t *testing.T) {
+	require := require.New(t)
+
+	vm := secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := Fx{}
+	require.NoError(fx.Initialize(&vm))
+	err := fx.VerifyTransfer(nil, nil, nil, nil)
+	require.ErrorIs(err, errCantTransfer)
+}
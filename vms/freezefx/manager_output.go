@@ -0,0 +1,19 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var _ verify.State = (*ManagerOutput)(nil)
+
+// ManagerOutput designates the owners that are allowed to freeze and unfreeze
+// transfers of the asset it was created with.
+type ManagerOutput struct {
+	verify.IsState `json:"-"`
+
+	secp256k1fx.OutputOwners `serialize:"true"`
+}
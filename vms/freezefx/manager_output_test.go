@@ -0,0 +1,18 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezefx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+func TestManagerOutputState(t *testing.T) {
+	intf := interface{}(&ManagerOutput{})
+	_, ok := intf.(verify.State)
+	require.True(t, ok)
+}
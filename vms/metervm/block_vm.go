@@ -23,6 +23,7 @@ var (
 	_ block.BuildBlockWithContextChainVM = (*blockVM)(nil)
 	_ block.BatchedChainVM               = (*blockVM)(nil)
 	_ block.StateSyncableVM              = (*blockVM)(nil)
+	_ block.IntegrityVerifierVM          = (*blockVM)(nil)
 )
 
 type blockVM struct {
@@ -30,6 +31,7 @@ type blockVM struct {
 	buildBlockVM block.BuildBlockWithContextChainVM
 	batchedVM    block.BatchedChainVM
 	ssVM         block.StateSyncableVM
+	integrityVM  block.IntegrityVerifierVM
 
 	blockMetrics
 	clock mockable.Clock
@@ -39,11 +41,13 @@ func NewBlockVM(vm block.ChainVM) block.ChainVM {
 	buildBlockVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	integrityVM, _ := vm.(block.IntegrityVerifierVM)
 	return &blockVM{
 		ChainVM:      vm,
 		buildBlockVM: buildBlockVM,
 		batchedVM:    batchedVM,
 		ssVM:         ssVM,
+		integrityVM:  integrityVM,
 	}
 }
 
@@ -0,0 +1,17 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metervm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) VerifyIntegrity(ctx context.Context, repair bool) ([]string, error) {
+	if vm.integrityVM == nil {
+		return nil, block.ErrIntegrityVerifierVMNotImplemented
+	}
+	return vm.integrityVM.VerifyIntegrity(ctx, repair)
+}
@@ -151,6 +151,8 @@ type PermissionedValidator struct {
 	// The owner the staking reward, if applicable, will go to
 	Connected bool          `json:"connected"`
 	Uptime    *json.Float32 `json:"uptime,omitempty"`
+	// The Unix time at which the validator is scheduled to be removed, if any
+	RemovalTime *json.Uint64 `json:"removalTime,omitempty"`
 }
 
 // PrimaryDelegator is the repr. of a primary network delegator sent over APIs.
@@ -178,6 +178,7 @@ func newEnvironment(t *testing.T) *environment {
 		res.state,
 		&res.backend,
 		pvalidators.TestManager,
+		true, // checkInvariants
 	)
 
 	res.network = network.New(
@@ -30,6 +30,8 @@ type acceptor struct {
 	metrics      metrics.Metrics
 	validators   validators.Manager
 	bootstrapped *utils.Atomic[bool]
+	// invariants is nil if invariant checking is disabled
+	invariants *invariantChecker
 }
 
 func (a *acceptor) BanffAbortBlock(b *block.BanffAbortBlock) error {
@@ -104,6 +106,10 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 		)
 	}
 
+	if err := a.checkInvariants(b); err != nil {
+		return err
+	}
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", "apricot atomic"),
@@ -184,6 +190,10 @@ func (a *acceptor) optionBlock(b, parent block.Block, blockType string) error {
 		return err
 	}
 
+	if err := a.checkInvariants(b); err != nil {
+		return err
+	}
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", blockType),
@@ -263,6 +273,10 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 		onAcceptFunc()
 	}
 
+	if err := a.checkInvariants(b); err != nil {
+		return err
+	}
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", blockType),
@@ -289,3 +303,12 @@ func (a *acceptor) commonAccept(b block.Block) error {
 	a.validators.OnAcceptedBlockID(blkID)
 	return nil
 }
+
+// checkInvariants verifies the consistency of the state after [b] has been
+// committed, if invariant checking is enabled.
+func (a *acceptor) checkInvariants(b block.Block) error {
+	if a.invariants == nil {
+		return nil
+	}
+	return a.invariants.check(b)
+}
@@ -205,6 +205,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 			res.state,
 			res.backend,
 			pvalidators.TestManager,
+			true, // checkInvariants
 		)
 		addSubnet(res)
 	} else {
@@ -214,6 +215,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 			res.mockedState,
 			res.backend,
 			pvalidators.TestManager,
+			false, // checkInvariants
 		)
 		// we do not add any subnet to state, since we can mock
 		// whatever we need
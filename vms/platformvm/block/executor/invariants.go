@@ -0,0 +1,186 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var ErrInvariantViolation = errors.New("state invariant violated")
+
+// invariantChecker verifies the consistency of the persisted state after a
+// block is accepted. This is expensive and is only intended to be used to
+// catch state corruption at the block that caused it.
+type invariantChecker struct {
+	log        logging.Logger
+	state      state.State
+	validators validators.Manager
+}
+
+type subnetNode struct {
+	subnetID ids.ID
+	nodeID   ids.NodeID
+}
+
+// check returns an error describing every violated invariant.
+func (c *invariantChecker) check(b block.Block) error {
+	var violations []string
+	violations = append(violations, c.checkHeightIndex(b)...)
+	violations = append(violations, c.checkStakerWeights()...)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	blkID := b.ID()
+	c.log.Fatal("state invariant violated",
+		zap.Stringer("blkID", blkID),
+		zap.Uint64("height", b.Height()),
+		zap.Stringer("parentID", b.Parent()),
+		zap.Strings("violations", violations),
+	)
+	return fmt.Errorf("%w after accepting block %s: %s",
+		ErrInvariantViolation,
+		blkID,
+		strings.Join(violations, "; "),
+	)
+}
+
+func (c *invariantChecker) checkHeightIndex(b block.Block) []string {
+	var (
+		violations []string
+		blkID      = b.ID()
+		height     = b.Height()
+	)
+	if lastAccepted := c.state.GetLastAccepted(); lastAccepted != blkID {
+		violations = append(violations, fmt.Sprintf(
+			"last accepted block is %s",
+			lastAccepted,
+		))
+	}
+
+	indexedID, err := c.state.GetBlockIDAtHeight(height)
+	switch {
+	case err != nil:
+		violations = append(violations, fmt.Sprintf(
+			"failed to get block ID at height %d: %s",
+			height,
+			err,
+		))
+	case indexedID != blkID:
+		violations = append(violations, fmt.Sprintf(
+			"height %d is indexed to block %s",
+			height,
+			indexedID,
+		))
+	}
+
+	parentBlk, err := c.state.GetStatelessBlock(b.Parent())
+	switch {
+	case err != nil:
+		violations = append(violations, fmt.Sprintf(
+			"failed to get parent block %s: %s",
+			b.Parent(),
+			err,
+		))
+	case parentBlk.Height()+1 != height:
+		violations = append(violations, fmt.Sprintf(
+			"parent block %s is at height %d",
+			b.Parent(),
+			parentBlk.Height(),
+		))
+	}
+	return violations
+}
+
+// checkStakerWeights verifies that the weights of the current stakers match
+// the validator sets tracked by the validators manager.
+func (c *invariantChecker) checkStakerWeights() []string {
+	stakerIterator, err := c.state.GetCurrentStakerIterator()
+	if err != nil {
+		return []string{fmt.Sprintf("failed to iterate current stakers: %s", err)}
+	}
+	defer stakerIterator.Release()
+
+	var (
+		violations    []string
+		subnetWeights = make(map[ids.ID]uint64)
+		subnetCounts  = make(map[ids.ID]int)
+		nodeWeights   = make(map[subnetNode]uint64)
+	)
+	for stakerIterator.Next() {
+		staker := stakerIterator.Value()
+		key := subnetNode{
+			subnetID: staker.SubnetID,
+			nodeID:   staker.NodeID,
+		}
+
+		subnetWeight, err := safemath.Add64(subnetWeights[staker.SubnetID], staker.Weight)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf(
+				"weight of subnet %s overflows",
+				staker.SubnetID,
+			))
+		}
+		subnetWeights[staker.SubnetID] = subnetWeight
+		nodeWeights[key] += staker.Weight
+		if staker.Priority.IsCurrentValidator() {
+			subnetCounts[staker.SubnetID]++
+		}
+	}
+
+	for subnetID, expectedWeight := range subnetWeights {
+		weight, err := c.validators.TotalWeight(subnetID)
+		switch {
+		case err != nil:
+			violations = append(violations, fmt.Sprintf(
+				"failed to get total weight of subnet %s: %s",
+				subnetID,
+				err,
+			))
+		case weight != expectedWeight:
+			violations = append(violations, fmt.Sprintf(
+				"subnet %s has weight %d but stakers have weight %d",
+				subnetID,
+				weight,
+				expectedWeight,
+			))
+		}
+
+		expectedCount := subnetCounts[subnetID]
+		if count := c.validators.Count(subnetID); count != expectedCount {
+			violations = append(violations, fmt.Sprintf(
+				"subnet %s has %d validators but there are %d current validators",
+				subnetID,
+				count,
+				expectedCount,
+			))
+		}
+	}
+
+	for key, expectedWeight := range nodeWeights {
+		weight := c.validators.GetWeight(key.subnetID, key.nodeID)
+		if weight != expectedWeight {
+			violations = append(violations, fmt.Sprintf(
+				"validator %s of subnet %s has weight %d but stakers have weight %d",
+				key.nodeID,
+				key.subnetID,
+				weight,
+				expectedWeight,
+			))
+		}
+	}
+	return violations
+}
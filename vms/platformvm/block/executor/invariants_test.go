@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestInvariantCheckerDetectsCorruption(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, nil)
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	checker := &invariantChecker{
+		log:        env.ctx.Log,
+		state:      env.state,
+		validators: env.config.Validators,
+	}
+
+	lastAccepted, err := env.state.GetStatelessBlock(env.state.GetLastAccepted())
+	require.NoError(err)
+	require.Empty(checker.checkStakerWeights())
+
+	nodeID := genesisNodeIDs[0]
+	require.NoError(env.config.Validators.AddWeight(constants.PrimaryNetworkID, nodeID, 1))
+	require.Len(checker.checkStakerWeights(), 2) // total weight and node weight
+
+	err = checker.check(lastAccepted)
+	require.ErrorIs(err, ErrInvariantViolation)
+}
@@ -47,6 +47,7 @@ func NewManager(
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	validatorManager validators.Manager,
+	checkInvariants bool,
 ) Manager {
 	lastAccepted := s.GetLastAccepted()
 	backend := &backend{
@@ -57,18 +58,27 @@ func NewManager(
 		blkIDToState: map[ids.ID]*blockState{},
 	}
 
+	acceptor := &acceptor{
+		backend:      backend,
+		metrics:      metrics,
+		validators:   validatorManager,
+		bootstrapped: txExecutorBackend.Bootstrapped,
+	}
+	if checkInvariants {
+		acceptor.invariants = &invariantChecker{
+			log:        txExecutorBackend.Ctx.Log,
+			state:      s,
+			validators: txExecutorBackend.Config.Validators,
+		}
+	}
+
 	return &manager{
 		backend: backend,
 		verifier: &verifier{
 			backend:           backend,
 			txExecutorBackend: txExecutorBackend,
 		},
-		acceptor: &acceptor{
-			backend:      backend,
-			metrics:      metrics,
-			validators:   validatorManager,
-			bootstrapped: txExecutorBackend.Bootstrapped,
-		},
+		acceptor: acceptor,
 		rejector: &rejector{
 			backend:         backend,
 			addTxsToMempool: !txExecutorBackend.Config.PartialSyncPrimaryNetwork,
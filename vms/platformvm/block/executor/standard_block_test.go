@@ -140,6 +140,9 @@ func TestBanffStandardBlockTimeVerification(t *testing.T) {
 	pendingIt.EXPECT().Release().Return().AnyTimes()
 	onParentAccept.EXPECT().GetPendingStakerIterator().Return(pendingIt, nil).AnyTimes()
 
+	// no scheduled subnet validator removals
+	onParentAccept.EXPECT().GetSubnetValidatorRemovals().Return(nil, nil).AnyTimes()
+
 	onParentAccept.EXPECT().GetTimestamp().Return(chainTime).AnyTimes()
 
 	txID := ids.GenerateTestID()
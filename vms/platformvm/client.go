@@ -11,12 +11,14 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
 )
@@ -232,6 +234,8 @@ type Client interface {
 	GetMinStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error)
 	// GetTotalStake returns the total amount (in nAVAX) staked on the network
 	GetTotalStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, error)
+	// GetStakerStats returns aggregates of the current stakers of [subnetID]
+	GetStakerStats(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*GetStakerStatsReply, error)
 	// GetMaxStakeAmount returns the maximum amount of nAVAX staking to the named
 	// node during the time period.
 	//
@@ -245,6 +249,40 @@ type Client interface {
 		endTime uint64,
 		options ...rpc.Option,
 	) (uint64, error)
+	// GetDelegationCapacity returns the amount of nAVAX that can be delegated
+	// to the named node during the time period.
+	GetDelegationCapacity(
+		ctx context.Context,
+		subnetID ids.ID,
+		nodeID ids.NodeID,
+		startTime uint64,
+		endTime uint64,
+		options ...rpc.Option,
+	) (*GetDelegationCapacityReply, error)
+	// GetRewardPreview returns the projected and accrued rewards of the staker
+	// added by [txID]
+	GetRewardPreview(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetRewardPreviewReply, error)
+	// GetValidatorUptimes returns the uptimes of up to [limit] current
+	// validators of [subnetID], starting at [startIndex] once sorted by
+	// [sortBy]
+	GetValidatorUptimes(
+		ctx context.Context,
+		subnetID ids.ID,
+		sortBy string,
+		descending bool,
+		startIndex uint32,
+		limit uint32,
+		options ...rpc.Option,
+	) (*GetValidatorUptimesReply, error)
+	// GetStakingHistory returns up to [limit] staking periods whose rewards
+	// are owned by [addr], starting after [startTxID]
+	GetStakingHistory(
+		ctx context.Context,
+		addr ids.ShortID,
+		startTxID ids.ID,
+		limit uint32,
+		options ...rpc.Option,
+	) (*GetStakingHistoryReply, error)
 	// GetRewardUTXOs returns the reward UTXOs for a transaction
 	//
 	// Deprecated: GetRewardUTXOs should be fetched from a dedicated indexer.
@@ -259,6 +297,24 @@ type Client interface {
 		height uint64,
 		options ...rpc.Option,
 	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+	// GetValidatorDiffs returns the validator weight changes of a provided
+	// subnet applied by each block in [startHeight, endHeight].
+	GetValidatorDiffs(
+		ctx context.Context,
+		subnetID ids.ID,
+		startHeight uint64,
+		endHeight uint64,
+		options ...rpc.Option,
+	) ([]APIValidatorDiff, error)
+	// ExportValidatorSet returns the canonical encoding of the validator set
+	// of a provided subnet at the specified height, along with the node's BLS
+	// signature of a warp message committing to it.
+	ExportValidatorSet(
+		ctx context.Context,
+		subnetID ids.ID,
+		height uint64,
+		options ...rpc.Option,
+	) (*ExportedValidatorSet, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetBlockByHeight returns the block at the given [height].
@@ -821,6 +877,14 @@ func (c *client) GetTotalStake(ctx context.Context, subnetID ids.ID, options ...
 	return uint64(amount), err
 }
 
+func (c *client) GetStakerStats(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*GetStakerStatsReply, error) {
+	res := &GetStakerStatsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getStakerStats", &GetStakerStatsArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetMaxStakeAmount(ctx context.Context, subnetID ids.ID, nodeID ids.NodeID, startTime, endTime uint64, options ...rpc.Option) (uint64, error) {
 	res := &GetMaxStakeAmountReply{}
 	err := c.requester.SendRequest(ctx, "platform.getMaxStakeAmount", &GetMaxStakeAmountArgs{
@@ -832,6 +896,61 @@ func (c *client) GetMaxStakeAmount(ctx context.Context, subnetID ids.ID, nodeID
 	return uint64(res.Amount), err
 }
 
+func (c *client) GetDelegationCapacity(ctx context.Context, subnetID ids.ID, nodeID ids.NodeID, startTime, endTime uint64, options ...rpc.Option) (*GetDelegationCapacityReply, error) {
+	res := &GetDelegationCapacityReply{}
+	err := c.requester.SendRequest(ctx, "platform.getDelegationCapacity", &GetDelegationCapacityArgs{
+		SubnetID:  subnetID,
+		NodeID:    nodeID,
+		StartTime: json.Uint64(startTime),
+		EndTime:   json.Uint64(endTime),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetRewardPreview(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetRewardPreviewReply, error) {
+	res := &GetRewardPreviewReply{}
+	err := c.requester.SendRequest(ctx, "platform.getRewardPreview", &GetRewardPreviewArgs{
+		TxID: txID,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetValidatorUptimes(
+	ctx context.Context,
+	subnetID ids.ID,
+	sortBy string,
+	descending bool,
+	startIndex uint32,
+	limit uint32,
+	options ...rpc.Option,
+) (*GetValidatorUptimesReply, error) {
+	res := &GetValidatorUptimesReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorUptimes", &GetValidatorUptimesArgs{
+		SubnetID:   subnetID,
+		SortBy:     sortBy,
+		Descending: descending,
+		StartIndex: json.Uint32(startIndex),
+		Limit:      json.Uint32(limit),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetStakingHistory(
+	ctx context.Context,
+	addr ids.ShortID,
+	startTxID ids.ID,
+	limit uint32,
+	options ...rpc.Option,
+) (*GetStakingHistoryReply, error) {
+	res := &GetStakingHistoryReply{}
+	err := c.requester.SendRequest(ctx, "platform.getStakingHistory", &GetStakingHistoryArgs{
+		Address:   addr.String(),
+		StartTxID: startTxID,
+		Limit:     json.Uint32(limit),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, options ...rpc.Option) ([][]byte, error) {
 	res := &GetRewardUTXOsReply{}
 	err := c.requester.SendRequest(ctx, "platform.getRewardUTXOs", args, res, options...)
@@ -869,6 +988,95 @@ func (c *client) GetValidatorsAt(
 	return res.Validators, err
 }
 
+func (c *client) GetValidatorDiffs(
+	ctx context.Context,
+	subnetID ids.ID,
+	startHeight uint64,
+	endHeight uint64,
+	options ...rpc.Option,
+) ([]APIValidatorDiff, error) {
+	res := &GetValidatorDiffsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorDiffs", &GetValidatorDiffsArgs{
+		SubnetID:    subnetID,
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, res, options...)
+	return res.Diffs, err
+}
+
+// ExportedValidatorSet is a validator set exported by a node, along with the
+// node's signature of a warp message committing to it.
+type ExportedValidatorSet struct {
+	ValidatorSet *warp.ValidatorSet
+	// UnsignedMessage is the warp message, sent from the P-chain, whose payload
+	// is the hash of [ValidatorSet]
+	UnsignedMessage *warp.UnsignedMessage
+	// Signature is the node's signature of [UnsignedMessage]
+	Signature *bls.Signature
+	// PublicKey is the node's BLS public key
+	PublicKey *bls.PublicKey
+}
+
+func (c *client) ExportValidatorSet(
+	ctx context.Context,
+	subnetID ids.ID,
+	height uint64,
+	options ...rpc.Option,
+) (*ExportedValidatorSet, error) {
+	res := &ExportValidatorSetReply{}
+	err := c.requester.SendRequest(ctx, "platform.exportValidatorSet", &ExportValidatorSetArgs{
+		SubnetID: subnetID,
+		Height:   json.Uint64(height),
+		Encoding: formatting.HexNC,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	vdrSetBytes, err := formatting.Decode(formatting.HexNC, res.ValidatorSet)
+	if err != nil {
+		return nil, err
+	}
+	vdrSet, err := warp.ParseValidatorSet(vdrSetBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedMsgBytes, err := formatting.Decode(formatting.HexNC, res.UnsignedMessage)
+	if err != nil {
+		return nil, err
+	}
+	unsignedMsg, err := warp.ParseUnsignedMessage(unsignedMsgBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	sigBytes, err := formatting.Decode(formatting.HexNC, res.Signature)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pkBytes, err := formatting.Decode(formatting.HexNC, res.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := bls.PublicKeyFromBytes(pkBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportedValidatorSet{
+		ValidatorSet:    vdrSet,
+		UnsignedMessage: unsignedMsg,
+		Signature:       sig,
+		PublicKey:       pk,
+	}, nil
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "platform.getBlock", &api.GetBlockArgs{
@@ -19,6 +19,7 @@ var DefaultExecutionConfig = ExecutionConfig{
 	BlockIDCacheSize:             8192,
 	FxOwnerCacheSize:             4 * units.MiB,
 	ChecksumsEnabled:             false,
+	InvariantChecksEnabled:       false,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
@@ -32,6 +33,7 @@ type ExecutionConfig struct {
 	BlockIDCacheSize             int  `json:"block-id-cache-size"`
 	FxOwnerCacheSize             int  `json:"fx-owner-cache-size"`
 	ChecksumsEnabled             bool `json:"checksums-enabled"`
+	InvariantChecksEnabled       bool `json:"invariant-checks-enabled"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
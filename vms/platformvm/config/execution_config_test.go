@@ -47,7 +47,8 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			"chain-db-cache-size": 7,
 			"block-id-cache-size": 8,
 			"fx-owner-cache-size": 9,
-			"checksums-enabled": true
+			"checksums-enabled": true,
+			"invariant-checks-enabled": true
 		}`)
 		ec, err := GetExecutionConfig(b)
 		require.NoError(err)
@@ -61,6 +62,7 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			BlockIDCacheSize:             8,
 			FxOwnerCacheSize:             9,
 			ChecksumsEnabled:             true,
+			InvariantChecksEnabled:       true,
 		}
 		require.Equal(expected, ec)
 	})
@@ -29,7 +29,8 @@ type txMetrics struct {
 	numAddPermissionlessValidatorTxs,
 	numAddPermissionlessDelegatorTxs,
 	numTransferSubnetOwnershipTxs,
-	numBaseTxs prometheus.Counter
+	numBaseTxs,
+	numScheduleSubnetValidatorRemovalTxs prometheus.Counter
 }
 
 func newTxMetrics(
@@ -38,21 +39,22 @@ func newTxMetrics(
 ) (*txMetrics, error) {
 	errs := wrappers.Errs{}
 	m := &txMetrics{
-		numAddDelegatorTxs:               newTxMetric(namespace, "add_delegator", registerer, &errs),
-		numAddSubnetValidatorTxs:         newTxMetric(namespace, "add_subnet_validator", registerer, &errs),
-		numAddValidatorTxs:               newTxMetric(namespace, "add_validator", registerer, &errs),
-		numAdvanceTimeTxs:                newTxMetric(namespace, "advance_time", registerer, &errs),
-		numCreateChainTxs:                newTxMetric(namespace, "create_chain", registerer, &errs),
-		numCreateSubnetTxs:               newTxMetric(namespace, "create_subnet", registerer, &errs),
-		numExportTxs:                     newTxMetric(namespace, "export", registerer, &errs),
-		numImportTxs:                     newTxMetric(namespace, "import", registerer, &errs),
-		numRewardValidatorTxs:            newTxMetric(namespace, "reward_validator", registerer, &errs),
-		numRemoveSubnetValidatorTxs:      newTxMetric(namespace, "remove_subnet_validator", registerer, &errs),
-		numTransformSubnetTxs:            newTxMetric(namespace, "transform_subnet", registerer, &errs),
-		numAddPermissionlessValidatorTxs: newTxMetric(namespace, "add_permissionless_validator", registerer, &errs),
-		numAddPermissionlessDelegatorTxs: newTxMetric(namespace, "add_permissionless_delegator", registerer, &errs),
-		numTransferSubnetOwnershipTxs:    newTxMetric(namespace, "transfer_subnet_ownership", registerer, &errs),
-		numBaseTxs:                       newTxMetric(namespace, "base", registerer, &errs),
+		numAddDelegatorTxs:                   newTxMetric(namespace, "add_delegator", registerer, &errs),
+		numAddSubnetValidatorTxs:             newTxMetric(namespace, "add_subnet_validator", registerer, &errs),
+		numAddValidatorTxs:                   newTxMetric(namespace, "add_validator", registerer, &errs),
+		numAdvanceTimeTxs:                    newTxMetric(namespace, "advance_time", registerer, &errs),
+		numCreateChainTxs:                    newTxMetric(namespace, "create_chain", registerer, &errs),
+		numCreateSubnetTxs:                   newTxMetric(namespace, "create_subnet", registerer, &errs),
+		numExportTxs:                         newTxMetric(namespace, "export", registerer, &errs),
+		numImportTxs:                         newTxMetric(namespace, "import", registerer, &errs),
+		numRewardValidatorTxs:                newTxMetric(namespace, "reward_validator", registerer, &errs),
+		numRemoveSubnetValidatorTxs:          newTxMetric(namespace, "remove_subnet_validator", registerer, &errs),
+		numTransformSubnetTxs:                newTxMetric(namespace, "transform_subnet", registerer, &errs),
+		numAddPermissionlessValidatorTxs:     newTxMetric(namespace, "add_permissionless_validator", registerer, &errs),
+		numAddPermissionlessDelegatorTxs:     newTxMetric(namespace, "add_permissionless_delegator", registerer, &errs),
+		numTransferSubnetOwnershipTxs:        newTxMetric(namespace, "transfer_subnet_ownership", registerer, &errs),
+		numBaseTxs:                           newTxMetric(namespace, "base", registerer, &errs),
+		numScheduleSubnetValidatorRemovalTxs: newTxMetric(namespace, "schedule_subnet_validator_removal", registerer, &errs),
 	}
 	return m, errs.Err
 }
@@ -146,3 +148,8 @@ func (m *txMetrics) BaseTx(*txs.BaseTx) error {
 	m.numBaseTxs.Inc()
 	return nil
 }
+
+func (m *txMetrics) ScheduleSubnetValidatorRemovalTx(*txs.ScheduleSubnetValidatorRemovalTx) error {
+	m.numScheduleSubnetValidatorRemovalTxs.Inc()
+	return nil
+}
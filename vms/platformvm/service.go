@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"net/http"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"go.uber.org/zap"
 
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/cache"
@@ -41,6 +43,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
@@ -61,6 +65,29 @@ const (
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
+
+	// Max number of heights that can be queried in a single call to
+	// GetValidatorDiffs
+	maxValidatorDiffsHeightRange = 1024
+
+	// Max number of validators returned in a single call to
+	// GetValidatorUptimes
+	maxValidatorUptimesPageSize = 1024
+
+	// Orders that the validators returned by GetValidatorUptimes can be sorted
+	// in
+	SortByNodeID = "nodeID"
+	SortByUptime = "uptime"
+	SortByStake  = "stake"
+
+	// Max number of staking periods returned in a single call to
+	// GetStakingHistory
+	maxStakingHistoryPageSize = 1024
+
+	// Statuses of the staking periods returned by GetStakingHistory
+	StakingStatusPending   = "pending"
+	StakingStatusCurrent   = "current"
+	StakingStatusCompleted = "completed"
 )
 
 var (
@@ -80,6 +107,11 @@ var (
 	errMissingPrivateKey        = errors.New("argument 'privateKey' not given")
 	errStartAfterEndTime        = errors.New("start time must be before end time")
 	errStartTimeInThePast       = errors.New("start time in the past")
+	errStartAfterEndHeight      = errors.New("start height must not be after end height")
+	errHeightRangeTooLarge      = errors.New("height range is too large")
+	errStakerNotFound           = errors.New("staker isn't a current or pending staker")
+	errInvalidSortBy            = errors.New("argument 'sortBy' must be one of \"nodeID\", \"uptime\", or \"stake\"")
+	errNoWarpSigner             = errors.New("node doesn't have a warp signer")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -929,10 +961,20 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 				return err
 			}
 			connected := s.vm.uptimeManager.IsConnected(nodeID, args.SubnetID)
+			var removalTime *json.Uint64
+			scheduledTime, err := s.vm.state.GetSubnetValidatorRemoval(args.SubnetID, nodeID)
+			switch {
+			case err == nil:
+				unixTime := json.Uint64(scheduledTime.Unix())
+				removalTime = &unixTime
+			case err != database.ErrNotFound:
+				return err
+			}
 			reply.Validators = append(reply.Validators, platformapi.PermissionedValidator{
-				Staker:    apiStaker,
-				Connected: connected,
-				Uptime:    uptime,
+				Staker:      apiStaker,
+				Connected:   connected,
+				Uptime:      uptime,
+				RemovalTime: removalTime,
 			})
 
 		default:
@@ -2327,39 +2369,27 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 	if err != nil {
 		return err
 	}
-	defer currentStakerIterator.Release()
-
-	var (
-		totalAmountStaked = make(map[ids.ID]uint64)
-		stakedOuts        []avax.TransferableOutput
-	)
-	for currentStakerIterator.Next() { // Iterates over current stakers
-		staker := currentStakerIterator.Value()
-
-		if args.ValidatorsOnly && !staker.Priority.IsValidator() {
-			continue
-		}
-
-		tx, _, err := s.vm.state.GetTx(staker.TxID)
-		if err != nil {
-			return err
-		}
-
-		stakedOuts = append(stakedOuts, getStakeHelper(tx, addrs, totalAmountStaked)...)
-	}
-
 	pendingStakerIterator, err := s.vm.state.GetPendingStakerIterator()
 	if err != nil {
+		currentStakerIterator.Release()
 		return err
 	}
-	defer pendingStakerIterator.Release()
 
-	for pendingStakerIterator.Next() { // Iterates over pending stakers
-		staker := pendingStakerIterator.Value()
+	// Iterates over the current stakers followed by the pending stakers
+	stakerIterator := state.NewConcatIterator(currentStakerIterator, pendingStakerIterator)
+	if args.ValidatorsOnly {
+		stakerIterator = state.NewFilterIterator(stakerIterator, func(staker *state.Staker) bool {
+			return staker.Priority.IsValidator()
+		})
+	}
+	defer stakerIterator.Release()
 
-		if args.ValidatorsOnly && !staker.Priority.IsValidator() {
-			continue
-		}
+	var (
+		totalAmountStaked = make(map[ids.ID]uint64)
+		stakedOuts        []avax.TransferableOutput
+	)
+	for stakerIterator.Next() {
+		staker := stakerIterator.Value()
 
 		tx, _, err := s.vm.state.GetTx(staker.TxID)
 		if err != nil {
@@ -2470,6 +2500,56 @@ func (s *Service) GetTotalStake(_ *http.Request, args *GetTotalStakeArgs, reply
 	return nil
 }
 
+// GetStakerStatsArgs are the arguments for calling GetStakerStats
+type GetStakerStatsArgs struct {
+	// Subnet we're getting the staker statistics of
+	// If omitted returns the Primary Network statistics
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// GetStakerStatsReply is the response from calling GetStakerStats
+type GetStakerStatsReply struct {
+	NumValidators json.Uint64 `json:"numValidators"`
+	NumDelegators json.Uint64 `json:"numDelegators"`
+	// ValidatorWeight excludes the weight delegated to the validators
+	ValidatorWeight json.Uint64 `json:"validatorWeight"`
+	DelegatorWeight json.Uint64 `json:"delegatorWeight"`
+	TotalWeight     json.Uint64 `json:"totalWeight"`
+	// WeightHistogram[i] is the number of validators whose weight, excluding
+	// the weight delegated to them, is in [2^i, 2^(i+1)). Trailing empty
+	// buckets are omitted.
+	WeightHistogram []json.Uint64 `json:"weightHistogram"`
+}
+
+// GetStakerStats returns aggregates of the current stakers of a subnet
+func (s *Service) GetStakerStats(_ *http.Request, args *GetStakerStatsArgs, reply *GetStakerStatsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getStakerStats"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	stats := s.vm.state.GetCurrentStakerStats(args.SubnetID)
+	reply.NumValidators = json.Uint64(stats.NumValidators)
+	reply.NumDelegators = json.Uint64(stats.NumDelegators)
+	reply.ValidatorWeight = json.Uint64(stats.ValidatorWeight)
+	reply.DelegatorWeight = json.Uint64(stats.DelegatorWeight)
+	reply.TotalWeight = json.Uint64(stats.TotalWeight())
+
+	numBuckets := len(stats.WeightHistogram)
+	for numBuckets > 0 && stats.WeightHistogram[numBuckets-1] == 0 {
+		numBuckets--
+	}
+	reply.WeightHistogram = make([]json.Uint64, numBuckets)
+	for i := range reply.WeightHistogram {
+		reply.WeightHistogram[i] = json.Uint64(stats.WeightHistogram[i])
+	}
+	return nil
+}
+
 // GetMaxStakeAmountArgs is the request for calling GetMaxStakeAmount.
 type GetMaxStakeAmountArgs struct {
 	SubnetID  ids.ID      `json:"subnetID"`
@@ -2526,6 +2606,642 @@ func (s *Service) GetMaxStakeAmount(_ *http.Request, args *GetMaxStakeAmountArgs
 	return err
 }
 
+// GetDelegationCapacityArgs is the request for calling GetDelegationCapacity.
+type GetDelegationCapacityArgs struct {
+	SubnetID  ids.ID      `json:"subnetID"`
+	NodeID    ids.NodeID  `json:"nodeID"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+}
+
+// APIDelegationCapacity is the delegatable stake of a validator from
+// [Timestamp] until the timestamp of the next APIDelegationCapacity.
+type APIDelegationCapacity struct {
+	Timestamp json.Uint64 `json:"timestamp"`
+	// Weight is the total weight of the validator, including its own weight.
+	Weight json.Uint64 `json:"weight"`
+	// Capacity is the amount that can be delegated to the validator.
+	Capacity json.Uint64 `json:"capacity"`
+}
+
+// GetDelegationCapacityReply is the response from calling
+// GetDelegationCapacity.
+type GetDelegationCapacityReply struct {
+	// MaxWeight is the maximum total weight of the validator.
+	MaxWeight json.Uint64 `json:"maxWeight"`
+	// Capacity is the largest delegation that can be made to the validator
+	// over the entire period.
+	Capacity json.Uint64 `json:"capacity"`
+	// Changes is the capacity of the validator at the start of the period and
+	// after every change during the period.
+	Changes []APIDelegationCapacity `json:"changes"`
+}
+
+// GetDelegationCapacity returns the amount of stake that can be delegated to
+// the named node during the time period.
+func (s *Service) GetDelegationCapacity(_ *http.Request, args *GetDelegationCapacityArgs, reply *GetDelegationCapacityReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getDelegationCapacity"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	startTime := time.Unix(int64(args.StartTime), 0)
+	endTime := time.Unix(int64(args.EndTime), 0)
+
+	if !startTime.Before(endTime) {
+		return errStartAfterEndTime
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	now := s.vm.state.GetTimestamp()
+	if !startTime.After(now) {
+		return errStartTimeInThePast
+	}
+
+	validator, err := executor.GetValidator(s.vm.state, args.SubnetID, args.NodeID)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to fetch the validator for %s on %s: %w",
+			args.NodeID,
+			args.SubnetID,
+			err,
+		)
+	}
+	if !txs.BoundedBy(startTime, endTime, validator.StartTime, validator.EndTime) {
+		return fmt.Errorf(
+			"%w: validating from %s to %s",
+			executor.ErrPeriodMismatch,
+			validator.StartTime,
+			validator.EndTime,
+		)
+	}
+
+	backend := &executor.Backend{
+		Config: &s.vm.Config,
+		Ctx:    s.vm.ctx,
+	}
+	maxWeight, err := executor.GetMaxDelegatedWeight(backend, s.vm.state, validator)
+	if err != nil {
+		return fmt.Errorf("couldn't get max weight: %w", err)
+	}
+
+	changes, err := executor.GetWeightChanges(s.vm.state, validator, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("couldn't get weight changes: %w", err)
+	}
+
+	reply.MaxWeight = json.Uint64(maxWeight)
+	reply.Changes = make([]APIDelegationCapacity, len(changes))
+	var maxChangeWeight uint64
+	for i, change := range changes {
+		reply.Changes[i] = APIDelegationCapacity{
+			Timestamp: json.Uint64(change.Time.Unix()),
+			Weight:    json.Uint64(change.Weight),
+			Capacity:  json.Uint64(delegationCapacity(maxWeight, change.Weight)),
+		}
+		maxChangeWeight = safemath.Max(maxChangeWeight, change.Weight)
+	}
+	reply.Capacity = json.Uint64(delegationCapacity(maxWeight, maxChangeWeight))
+	return nil
+}
+
+// delegationCapacity returns the amount that can be delegated to a validator
+// with [weight] without exceeding [maxWeight].
+func delegationCapacity(maxWeight, weight uint64) uint64 {
+	if weight >= maxWeight {
+		return 0
+	}
+	return maxWeight - weight
+}
+
+// GetRewardPreviewArgs is the request for calling GetRewardPreview.
+type GetRewardPreviewArgs struct {
+	// TxID is the ID of the tx that added the staker.
+	TxID ids.ID `json:"txID"`
+}
+
+// GetRewardPreviewReply is the response from calling GetRewardPreview.
+type GetRewardPreviewReply struct {
+	NodeID    ids.NodeID  `json:"nodeID"`
+	SubnetID  ids.ID      `json:"subnetID"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// Pending is true if the staking period hasn't started. The rewards of
+	// pending stakers are projected with the current supply, so the reward
+	// they are issued once they start may differ.
+	Pending bool `json:"pending"`
+	// PotentialReward is the reward minted for the staker at [EndTime] if the
+	// uptime requirement is met.
+	PotentialReward json.Uint64 `json:"potentialReward"`
+	// ProjectedReward is the part of [PotentialReward] paid to the staker's
+	// rewards owner. For delegators, the delegation fee is deducted.
+	ProjectedReward json.Uint64 `json:"projectedReward"`
+	// AccruedReward is the part of [ProjectedReward] accrued so far,
+	// proportional to the elapsed part of the staking period.
+	AccruedReward json.Uint64 `json:"accruedReward"`
+	// ProjectedDelegationFees is, for validators, the delegation fees paid to
+	// the delegation rewards owner at [EndTime]. This includes the fees of
+	// delegators that were already rewarded and of the current delegators,
+	// assuming they are rewarded.
+	ProjectedDelegationFees json.Uint64 `json:"projectedDelegationFees"`
+	// Uptime is the percentage (0-100) of time the staker's node has been
+	// observed to be online since it started validating the primary network.
+	// It is omitted if the node isn't currently validating the primary
+	// network.
+	Uptime *json.Float32 `json:"uptime,omitempty"`
+	// UptimeRequirement is the minimum uptime percentage (0-100) required to
+	// be rewarded.
+	UptimeRequirement json.Float32 `json:"uptimeRequirement"`
+	// MeetsUptimeRequirement is true if the staker would be rewarded if its
+	// staking period ended with the current uptime. It is omitted if [Uptime]
+	// is omitted.
+	MeetsUptimeRequirement *bool `json:"meetsUptimeRequirement,omitempty"`
+}
+
+// GetRewardPreview returns the reward that the staker added by the provided tx
+// is projected to receive at the end of its staking period, and the part of it
+// accrued so far.
+func (s *Service) GetRewardPreview(_ *http.Request, args *GetRewardPreviewArgs, reply *GetRewardPreviewReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getRewardPreview"),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	tx, _, err := s.vm.state.GetTx(args.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get tx %s: %w", args.TxID, err)
+	}
+	stakerTx, ok := tx.Unsigned.(txs.Staker)
+	if !ok {
+		return fmt.Errorf("%w: tx %s has type %T", errStakerNotFound, args.TxID, tx.Unsigned)
+	}
+	_, isDelegator := stakerTx.(txs.DelegatorTx)
+
+	staker, pending, err := s.getStaker(args.TxID, stakerTx.SubnetID(), stakerTx.NodeID(), isDelegator)
+	if err != nil {
+		return err
+	}
+
+	backend := &executor.Backend{
+		Config:  &s.vm.Config,
+		Ctx:     s.vm.ctx,
+		Rewards: reward.NewCalculator(s.vm.RewardConfig),
+	}
+
+	potentialReward := staker.PotentialReward
+	if pending && staker.Priority != txs.SubnetPermissionedValidatorPendingPriority {
+		supply, err := s.vm.state.GetCurrentSupply(staker.SubnetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get current supply of %s: %w", staker.SubnetID, err)
+		}
+		rewards, err := executor.GetRewardsCalculator(backend, s.vm.state, staker.SubnetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get rewards calculator of %s: %w", staker.SubnetID, err)
+		}
+		potentialReward = rewards.Calculate(staker.EndTime.Sub(staker.StartTime), staker.Weight, supply)
+	}
+
+	projectedReward := potentialReward
+	if isDelegator {
+		validator, err := executor.GetValidator(s.vm.state, staker.SubnetID, staker.NodeID)
+		if err != nil {
+			return fmt.Errorf("couldn't get validator of delegator %s: %w", args.TxID, err)
+		}
+		attr, err := s.loadStakerTxAttributes(validator.TxID)
+		if err != nil {
+			return fmt.Errorf("couldn't get attributes of validator %s: %w", validator.TxID, err)
+		}
+		_, projectedReward = reward.Split(potentialReward, attr.shares)
+	} else if !pending {
+		delegationFees, err := s.getProjectedDelegationFees(staker, stakerTx)
+		if err != nil {
+			return err
+		}
+		reply.ProjectedDelegationFees = json.Uint64(delegationFees)
+	}
+
+	uptimeRequirement, err := executor.GetUptimeRequirement(backend, s.vm.state, staker.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get uptime requirement of %s: %w", staker.SubnetID, err)
+	}
+
+	// Stakers are rewarded based on the uptime of their node on the primary
+	// network.
+	primaryNetworkValidator, err := s.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, staker.NodeID)
+	switch {
+	case err == database.ErrNotFound:
+		// The node isn't validating, so its uptime isn't known.
+	case err != nil:
+		return fmt.Errorf("couldn't get primary network validator %s: %w", staker.NodeID, err)
+	default:
+		rawUptime, err := s.vm.uptimeManager.CalculateUptimePercentFrom(
+			primaryNetworkValidator.NodeID,
+			constants.PrimaryNetworkID,
+			primaryNetworkValidator.StartTime,
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't calculate uptime of %s: %w", staker.NodeID, err)
+		}
+		uptime := json.Float32(rawUptime * 100)
+		meetsUptimeRequirement := rawUptime >= uptimeRequirement
+		reply.Uptime = &uptime
+		reply.MeetsUptimeRequirement = &meetsUptimeRequirement
+	}
+
+	reply.NodeID = staker.NodeID
+	reply.SubnetID = staker.SubnetID
+	reply.StartTime = json.Uint64(staker.StartTime.Unix())
+	reply.EndTime = json.Uint64(staker.EndTime.Unix())
+	reply.Pending = pending
+	reply.PotentialReward = json.Uint64(potentialReward)
+	reply.ProjectedReward = json.Uint64(projectedReward)
+	reply.AccruedReward = json.Uint64(accruedReward(
+		projectedReward,
+		staker.StartTime,
+		staker.EndTime,
+		s.vm.state.GetTimestamp(),
+	))
+	reply.UptimeRequirement = json.Float32(uptimeRequirement * 100)
+	return nil
+}
+
+// getStaker returns the current or pending staker added by [txID]. Returns
+// true if the staker is pending.
+func (s *Service) getStaker(txID ids.ID, subnetID ids.ID, nodeID ids.NodeID, isDelegator bool) (*state.Staker, bool, error) {
+	if !isDelegator {
+		validator, err := s.vm.state.GetCurrentValidator(subnetID, nodeID)
+		if err == nil && validator.TxID == txID {
+			return validator, false, nil
+		}
+		if err != nil && err != database.ErrNotFound {
+			return nil, false, err
+		}
+
+		validator, err = s.vm.state.GetPendingValidator(subnetID, nodeID)
+		if err == nil && validator.TxID == txID {
+			return validator, true, nil
+		}
+		if err != nil && err != database.ErrNotFound {
+			return nil, false, err
+		}
+		return nil, false, fmt.Errorf("%w: %s", errStakerNotFound, txID)
+	}
+
+	currentIterator, err := s.vm.state.GetCurrentDelegatorIterator(subnetID, nodeID)
+	if err != nil {
+		return nil, false, err
+	}
+	delegator, found := findStaker(currentIterator, txID)
+	if found {
+		return delegator, false, nil
+	}
+
+	pendingIterator, err := s.vm.state.GetPendingDelegatorIterator(subnetID, nodeID)
+	if err != nil {
+		return nil, false, err
+	}
+	delegator, found = findStaker(pendingIterator, txID)
+	if found {
+		return delegator, true, nil
+	}
+	return nil, false, fmt.Errorf("%w: %s", errStakerNotFound, txID)
+}
+
+// findStaker returns the staker in [it] added by [txID]. [it] is released.
+func findStaker(it state.StakerIterator, txID ids.ID) (*state.Staker, bool) {
+	defer it.Release()
+
+	for it.Next() {
+		staker := it.Value()
+		if staker.TxID == txID {
+			return staker, true
+		}
+	}
+	return nil, false
+}
+
+// getProjectedDelegationFees returns the delegation fees that are projected to
+// be paid to [validator] when its staking period ends.
+func (s *Service) getProjectedDelegationFees(validator *state.Staker, stakerTx txs.Staker) (uint64, error) {
+	validatorTx, ok := stakerTx.(txs.ValidatorTx)
+	if !ok {
+		// Permissioned subnet validators can't be delegated to.
+		return 0, nil
+	}
+
+	// After Cortina, the delegatee rewards of finished delegations are deferred
+	// until the validator's staking period ends.
+	delegationFees, err := s.vm.state.GetDelegateeReward(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get delegatee reward of %s: %w", validator.NodeID, err)
+	}
+
+	it, err := s.vm.state.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Release()
+
+	for it.Next() {
+		delegateeReward, _ := reward.Split(it.Value().PotentialReward, validatorTx.Shares())
+		delegationFees, err = safemath.Add64(delegationFees, delegateeReward)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return delegationFees, nil
+}
+
+// accruedReward returns the part of [reward] accrued at [now] by a staker that
+// stakes from [startTime] until [endTime].
+func accruedReward(reward uint64, startTime, endTime, now time.Time) uint64 {
+	switch {
+	case !now.After(startTime):
+		return 0
+	case !now.Before(endTime):
+		return reward
+	}
+
+	// The product may overflow a uint64, so it is calculated with big ints.
+	accrued := new(big.Int).SetUint64(reward)
+	accrued.Mul(accrued, big.NewInt(int64(now.Sub(startTime))))
+	accrued.Div(accrued, big.NewInt(int64(endTime.Sub(startTime))))
+	return accrued.Uint64()
+}
+
+// GetValidatorUptimesArgs are the arguments for calling GetValidatorUptimes
+type GetValidatorUptimesArgs struct {
+	// SubnetID of the validators. Defaults to the primary network.
+	SubnetID ids.ID `json:"subnetID"`
+	// SortBy is the order the validators are returned in. Must be one of
+	// SortByNodeID, SortByUptime, or SortByStake. Defaults to SortByNodeID.
+	// Validators with the same value are ordered by node ID.
+	SortBy string `json:"sortBy"`
+	// Descending reverses the order the validators are returned in
+	Descending bool `json:"descending"`
+	// StartIndex is the index, in the sorted validators, of the first
+	// validator to return
+	StartIndex json.Uint32 `json:"startIndex"`
+	// Limit is the maximum number of validators to return. Defaults to, and is
+	// at most, 1024.
+	Limit json.Uint32 `json:"limit"`
+}
+
+// ValidatorUptime is the uptime of a current validator
+type ValidatorUptime struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Weight is the stake of the validator, excluding its delegators
+	Weight json.Uint64 `json:"weight"`
+	// Uptime is the percentage (0-100) of time the validator's node has been
+	// observed to be online since it started validating. It is omitted if
+	// this node doesn't track the subnet.
+	Uptime *json.Float32 `json:"uptime,omitempty"`
+	// Connected is true if this node is currently connected to the
+	// validator's node
+	Connected bool `json:"connected"`
+}
+
+// GetValidatorUptimesReply is the response from calling GetValidatorUptimes
+type GetValidatorUptimesReply struct {
+	Validators []ValidatorUptime `json:"validators"`
+	// NumValidators is the number of current validators of the subnet, across
+	// all pages
+	NumValidators json.Uint32 `json:"numValidators"`
+}
+
+// GetValidatorUptimes returns a page of the uptimes of the current validators
+// of a subnet, sorted in the requested order.
+func (s *Service) GetValidatorUptimes(_ *http.Request, args *GetValidatorUptimesArgs, reply *GetValidatorUptimesReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorUptimes"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	var less func(a, b ValidatorUptime) bool
+	switch args.SortBy {
+	case "", SortByNodeID:
+		less = func(ValidatorUptime, ValidatorUptime) bool {
+			return false
+		}
+	case SortByUptime:
+		less = func(a, b ValidatorUptime) bool {
+			// Validators with an unknown uptime are ordered first
+			switch {
+			case a.Uptime == nil:
+				return b.Uptime != nil
+			case b.Uptime == nil:
+				return false
+			default:
+				return *a.Uptime < *b.Uptime
+			}
+		}
+	case SortByStake:
+		less = func(a, b ValidatorUptime) bool {
+			return a.Weight < b.Weight
+		}
+	default:
+		return fmt.Errorf("%w: %q", errInvalidSortBy, args.SortBy)
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || maxValidatorUptimesPageSize < limit {
+		limit = maxValidatorUptimesPageSize
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	defer currentStakerIterator.Release()
+
+	var validators []ValidatorUptime
+	for currentStakerIterator.Next() {
+		staker := currentStakerIterator.Value()
+		if staker.SubnetID != args.SubnetID || !staker.Priority.IsValidator() {
+			continue
+		}
+
+		uptime, err := s.getAPIUptime(staker)
+		if err != nil {
+			return err
+		}
+		validators = append(validators, ValidatorUptime{
+			NodeID:    staker.NodeID,
+			Weight:    json.Uint64(staker.Weight),
+			Uptime:    uptime,
+			Connected: s.vm.uptimeManager.IsConnected(staker.NodeID, staker.SubnetID),
+		})
+	}
+
+	slices.SortFunc(validators, func(a, b ValidatorUptime) bool {
+		if args.Descending {
+			a, b = b, a
+		}
+		switch {
+		case less(a, b):
+			return true
+		case less(b, a):
+			return false
+		default:
+			return a.NodeID.Less(b.NodeID)
+		}
+	})
+
+	reply.NumValidators = json.Uint32(len(validators))
+	startIndex := safemath.Min(int(args.StartIndex), len(validators))
+	endIndex := safemath.Min(startIndex+limit, len(validators))
+	reply.Validators = validators[startIndex:endIndex]
+	return nil
+}
+
+// GetStakingHistoryArgs are the arguments for calling GetStakingHistory
+type GetStakingHistoryArgs struct {
+	// Address that owns the rewards of the staking periods
+	Address string `json:"address"`
+	// StartTxID is the tx ID after which to start returning staking periods.
+	// It should be the [EndTxID] of the previous page, if any.
+	StartTxID ids.ID `json:"startTxID"`
+	// Limit is the maximum number of staking periods to return. Defaults to,
+	// and is at most, 1024.
+	Limit json.Uint32 `json:"limit"`
+}
+
+// StakingPeriod is the staking period of a validator or delegator
+type StakingPeriod struct {
+	// TxID is the ID of the tx that added the staker
+	TxID      ids.ID      `json:"txID"`
+	NodeID    ids.NodeID  `json:"nodeID"`
+	SubnetID  ids.ID      `json:"subnetID"`
+	Validator bool        `json:"validator"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// StakeAmount is the stake of the staker, excluding its delegators
+	StakeAmount json.Uint64 `json:"stakeAmount"`
+	// Status is one of StakingStatusPending, StakingStatusCurrent, or
+	// StakingStatusCompleted
+	Status string `json:"status"`
+	// RewardsReceived is the amount of the reward UTXOs issued for the staker
+	// that are owned by the address. For validators, this includes the
+	// delegation fees paid to the address.
+	RewardsReceived json.Uint64 `json:"rewardsReceived"`
+}
+
+// GetStakingHistoryReply is the response from calling GetStakingHistory
+type GetStakingHistoryReply struct {
+	// StakingPeriods are sorted by tx ID
+	StakingPeriods []StakingPeriod `json:"stakingPeriods"`
+	// EndTxID is the tx ID of the last returned staking period. It should be
+	// used as the [StartTxID] of the next page.
+	EndTxID ids.ID `json:"endTxID"`
+}
+
+// GetStakingHistory returns a page of the staking periods whose rewards are
+// owned by an address, along with the rewards the address received from them.
+func (s *Service) GetStakingHistory(_ *http.Request, args *GetStakingHistoryArgs, reply *GetStakingHistoryReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getStakingHistory"),
+		logging.UserString("address", args.Address),
+	)
+
+	addr, err := avax.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return err
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || maxStakingHistoryPageSize < limit {
+		limit = maxStakingHistoryPageSize
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	txIDs, err := s.vm.state.GetStakingTxIDs(addr, args.StartTxID, limit)
+	if err != nil {
+		return fmt.Errorf("couldn't get staking txs of %s: %w", args.Address, err)
+	}
+
+	reply.StakingPeriods = make([]StakingPeriod, 0, len(txIDs))
+	for _, txID := range txIDs {
+		period, err := s.getStakingPeriod(addr, txID)
+		if err != nil {
+			return err
+		}
+		reply.StakingPeriods = append(reply.StakingPeriods, period)
+	}
+
+	reply.EndTxID = args.StartTxID
+	if len(txIDs) > 0 {
+		reply.EndTxID = txIDs[len(txIDs)-1]
+	}
+	return nil
+}
+
+// getStakingPeriod returns the staking period of the staker added by [txID],
+// along with the rewards that [addr] received from it.
+func (s *Service) getStakingPeriod(addr ids.ShortID, txID ids.ID) (StakingPeriod, error) {
+	tx, _, err := s.vm.state.GetTx(txID)
+	if err != nil {
+		return StakingPeriod{}, fmt.Errorf("couldn't get tx %s: %w", txID, err)
+	}
+	stakerTx, ok := tx.Unsigned.(txs.Staker)
+	if !ok {
+		return StakingPeriod{}, fmt.Errorf("%w: tx %s has type %T", errStakerNotFound, txID, tx.Unsigned)
+	}
+	_, isDelegator := stakerTx.(txs.DelegatorTx)
+
+	stakingStatus := StakingStatusCompleted
+	_, pending, err := s.getStaker(txID, stakerTx.SubnetID(), stakerTx.NodeID(), isDelegator)
+	switch {
+	case err == nil && pending:
+		stakingStatus = StakingStatusPending
+	case err == nil:
+		stakingStatus = StakingStatusCurrent
+	case !errors.Is(err, errStakerNotFound):
+		return StakingPeriod{}, err
+	}
+
+	rewardUTXOs, err := s.vm.state.GetRewardUTXOs(txID)
+	if err != nil {
+		return StakingPeriod{}, fmt.Errorf("couldn't get reward UTXOs of %s: %w", txID, err)
+	}
+	var rewards uint64
+	for _, utxo := range rewardUTXOs {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok || !slices.Contains(out.Addrs, addr) {
+			continue
+		}
+		rewards, err = safemath.Add64(rewards, out.Amt)
+		if err != nil {
+			return StakingPeriod{}, err
+		}
+	}
+
+	return StakingPeriod{
+		TxID:            txID,
+		NodeID:          stakerTx.NodeID(),
+		SubnetID:        stakerTx.SubnetID(),
+		Validator:       !isDelegator,
+		StartTime:       json.Uint64(stakerTx.StartTime().Unix()),
+		EndTime:         json.Uint64(stakerTx.EndTime().Unix()),
+		StakeAmount:     json.Uint64(stakerTx.Weight()),
+		Status:          stakingStatus,
+		RewardsReceived: json.Uint64(rewards),
+	}, nil
+}
+
 // GetRewardUTXOsReply defines the GetRewardUTXOs replies returned from the API
 type GetRewardUTXOsReply struct {
 	// Number of UTXOs returned
@@ -2683,6 +3399,160 @@ func (s *Service) GetValidatorsAt(r *http.Request, args *GetValidatorsAtArgs, re
 	return nil
 }
 
+// GetValidatorDiffsArgs are the arguments for calling GetValidatorDiffs
+type GetValidatorDiffsArgs struct {
+	SubnetID    ids.ID      `json:"subnetID"`
+	StartHeight json.Uint64 `json:"startHeight"`
+	EndHeight   json.Uint64 `json:"endHeight"`
+}
+
+// APIValidatorDiff is the representation of a validator weight change at a
+// single height returned by the API
+type APIValidatorDiff struct {
+	Height     json.Uint64 `json:"height"`
+	NodeID     ids.NodeID  `json:"nodeID"`
+	Status     string      `json:"status"`
+	PrevWeight json.Uint64 `json:"prevWeight"`
+	Weight     json.Uint64 `json:"weight"`
+}
+
+// GetValidatorDiffsReply is the response from GetValidatorDiffs
+type GetValidatorDiffsReply struct {
+	Diffs []APIValidatorDiff `json:"diffs"`
+}
+
+// GetValidatorDiffs returns the validators that were added, removed, or had
+// their weight changed in the provided subnet by each block in
+// [startHeight, endHeight].
+func (s *Service) GetValidatorDiffs(r *http.Request, args *GetValidatorDiffsArgs, reply *GetValidatorDiffsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorDiffs"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+	)
+
+	if args.EndHeight < args.StartHeight {
+		return errStartAfterEndHeight
+	}
+	if uint64(args.EndHeight-args.StartHeight) >= maxValidatorDiffsHeightRange {
+		return fmt.Errorf("%w: %d", errHeightRangeTooLarge, maxValidatorDiffsHeightRange)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	diffs, err := s.vm.state.GetValidatorDiffs(
+		r.Context(),
+		args.SubnetID,
+		uint64(args.StartHeight),
+		uint64(args.EndHeight),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get validator diffs: %w", err)
+	}
+
+	reply.Diffs = make([]APIValidatorDiff, len(diffs))
+	for i, diff := range diffs {
+		reply.Diffs[i] = APIValidatorDiff{
+			Height:     json.Uint64(diff.Height),
+			NodeID:     diff.NodeID,
+			Status:     diff.Status.String(),
+			PrevWeight: json.Uint64(diff.PrevWeight),
+			Weight:     json.Uint64(diff.Weight),
+		}
+	}
+	return nil
+}
+
+// ExportValidatorSetArgs are the arguments for calling ExportValidatorSet
+type ExportValidatorSetArgs struct {
+	SubnetID ids.ID              `json:"subnetID"`
+	Height   json.Uint64         `json:"height"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// ExportValidatorSetReply is the response from ExportValidatorSet
+type ExportValidatorSetReply struct {
+	// ValidatorSet is the canonical encoding of the validator set
+	ValidatorSet string `json:"validatorSet"`
+	// ValidatorSetID is the SHA-256 hash of the canonical encoding
+	ValidatorSetID ids.ID `json:"validatorSetID"`
+	// UnsignedMessage is the warp message, sent from the P-chain, whose
+	// payload is the hash of the validator set
+	UnsignedMessage string `json:"unsignedMessage"`
+	// Signature is this node's BLS signature of UnsignedMessage
+	Signature string `json:"signature"`
+	// PublicKey is this node's BLS public key
+	PublicKey string `json:"publicKey"`
+}
+
+// ExportValidatorSet returns the canonical encoding of the validator set of a
+// provided subnet at the specified height, along with this node's BLS
+// signature of a warp message committing to it.
+func (s *Service) ExportValidatorSet(r *http.Request, args *ExportValidatorSetArgs, reply *ExportValidatorSetReply) error {
+	height := uint64(args.Height)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "exportValidatorSet"),
+		zap.Uint64("height", height),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Stringer("encoding", args.Encoding),
+	)
+
+	if s.vm.ctx.WarpSigner == nil {
+		return errNoWarpSigner
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	vdrSet, err := warp.GetValidatorSet(r.Context(), s.vm, height, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("failed to get validator set: %w", err)
+	}
+
+	vdrSetID := vdrSet.ID()
+	hashPayload, err := payload.NewHash(vdrSetID)
+	if err != nil {
+		return fmt.Errorf("failed to create payload: %w", err)
+	}
+
+	unsignedMsg, err := warp.NewUnsignedMessage(
+		s.vm.ctx.NetworkID,
+		s.vm.ctx.ChainID,
+		hashPayload.Bytes(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create warp message: %w", err)
+	}
+
+	sig, err := s.vm.ctx.WarpSigner.Sign(unsignedMsg)
+	if err != nil {
+		return fmt.Errorf("failed to sign warp message: %w", err)
+	}
+
+	reply.ValidatorSetID = vdrSetID
+	reply.ValidatorSet, err = formatting.Encode(args.Encoding, vdrSet.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode validator set as %s: %w", args.Encoding, err)
+	}
+	reply.UnsignedMessage, err = formatting.Encode(args.Encoding, unsignedMsg.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode warp message as %s: %w", args.Encoding, err)
+	}
+	reply.Signature, err = formatting.Encode(formatting.HexNC, sig)
+	if err != nil {
+		return fmt.Errorf("couldn't encode signature: %w", err)
+	}
+	reply.PublicKey, err = formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(s.vm.ctx.PublicKey))
+	if err != nil {
+		return fmt.Errorf("couldn't encode public key: %w", err)
+	}
+	return nil
+}
+
 func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
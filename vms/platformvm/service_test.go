@@ -8,7 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/bits"
 	"math/rand"
+	"net/http"
 	"testing"
 	"time"
 
@@ -37,9 +39,12 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	vmkeystore "github.com/ava-labs/avalanchego/vms/components/keystore"
@@ -417,6 +422,45 @@ func TestGetBalance(t *testing.T) {
 	}
 }
 
+func TestGetStakerStats(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	genesis, _ := defaultGenesis(t)
+	numValidators := uint64(len(genesis.Validators))
+
+	reply := GetStakerStatsReply{}
+	require.NoError(service.GetStakerStats(nil, &GetStakerStatsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply))
+	require.Equal(json.Uint64(numValidators), reply.NumValidators)
+	require.Zero(reply.NumDelegators)
+	require.Equal(json.Uint64(numValidators*defaultWeight), reply.ValidatorWeight)
+	require.Zero(reply.DelegatorWeight)
+
+	totalWeight, err := service.vm.Validators.TotalWeight(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(json.Uint64(totalWeight), reply.TotalWeight)
+
+	// Every genesis validator has the same weight, so only the last bucket is
+	// populated.
+	require.Len(reply.WeightHistogram, bits.Len64(defaultWeight))
+	require.Equal(json.Uint64(numValidators), reply.WeightHistogram[len(reply.WeightHistogram)-1])
+
+	reply = GetStakerStatsReply{}
+	require.NoError(service.GetStakerStats(nil, &GetStakerStatsArgs{
+		SubnetID: ids.GenerateTestID(),
+	}, &reply))
+	require.Zero(reply.NumValidators)
+	require.Zero(reply.TotalWeight)
+	require.Empty(reply.WeightHistogram)
+}
+
 func TestGetStake(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t)
@@ -719,6 +763,406 @@ func TestGetCurrentValidators(t *testing.T) {
 	}
 }
 
+func TestGetDelegationCapacity(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	now := service.vm.state.GetTimestamp()
+
+	var (
+		validatorNodeID    = genesisNodeIDs[1]
+		delegatorWeight    = uint64(12345)
+		delegatorStartTime = defaultValidateStartTime
+		delegatorEndTime   = now.Add(defaultMinStakingDuration)
+		maxWeight          = txexecutor.MaxValidatorWeightFactor * defaultWeight
+	)
+
+	service.vm.state.PutCurrentDelegator(&state.Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    validatorNodeID,
+		SubnetID:  constants.PrimaryNetworkID,
+		Weight:    delegatorWeight,
+		StartTime: delegatorStartTime,
+		EndTime:   delegatorEndTime,
+		NextTime:  delegatorEndTime,
+		Priority:  txs.PrimaryNetworkDelegatorCurrentPriority,
+	})
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	startTime := now.Add(time.Hour)
+	args := GetDelegationCapacityArgs{
+		SubnetID:  constants.PrimaryNetworkID,
+		NodeID:    validatorNodeID,
+		StartTime: json.Uint64(startTime.Unix()),
+		EndTime:   json.Uint64(defaultValidateEndTime.Unix()),
+	}
+	reply := GetDelegationCapacityReply{}
+	require.NoError(service.GetDelegationCapacity(nil, &args, &reply))
+	require.Equal(GetDelegationCapacityReply{
+		MaxWeight: json.Uint64(maxWeight),
+		Capacity:  json.Uint64(maxWeight - defaultWeight - delegatorWeight),
+		Changes: []APIDelegationCapacity{
+			{
+				Timestamp: json.Uint64(startTime.Unix()),
+				Weight:    json.Uint64(defaultWeight + delegatorWeight),
+				Capacity:  json.Uint64(maxWeight - defaultWeight - delegatorWeight),
+			},
+			{
+				Timestamp: json.Uint64(delegatorEndTime.Unix()),
+				Weight:    json.Uint64(defaultWeight),
+				Capacity:  json.Uint64(maxWeight - defaultWeight),
+			},
+		},
+	}, reply)
+
+	// The period must be within the validation period.
+	args.EndTime = json.Uint64(defaultValidateEndTime.Add(time.Second).Unix())
+	err := service.GetDelegationCapacity(nil, &args, &reply)
+	require.ErrorIs(err, txexecutor.ErrPeriodMismatch)
+
+	// The period must start in the future.
+	args.StartTime = json.Uint64(now.Unix())
+	args.EndTime = json.Uint64(defaultValidateEndTime.Unix())
+	err = service.GetDelegationCapacity(nil, &args, &reply)
+	require.ErrorIs(err, errStartTimeInThePast)
+}
+
+func TestGetRewardPreview(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+
+	// Add a validator that charges a 20% delegation fee
+	var (
+		validatorNodeID = ids.GenerateTestNodeID()
+		startTime       = defaultGenesisTime
+		shares          = uint32(reward.PercentDenominator / 5)
+	)
+	validatorTx, err := service.vm.txBuilder.NewAddValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(startTime.Add(2*defaultMinStakingDuration).Unix()),
+		validatorNodeID,
+		ids.GenerateTestShortID(),
+		shares,
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	validator, err := state.NewCurrentStaker(
+		validatorTx.ID(),
+		validatorTx.Unsigned.(*txs.AddValidatorTx),
+		2_000_000,
+	)
+	require.NoError(err)
+
+	service.vm.state.PutCurrentValidator(validator)
+	service.vm.state.AddTx(validatorTx, status.Committed)
+
+	// Add a delegator to the validator
+	delegatorStartTime := startTime
+	delegatorEndTime := delegatorStartTime.Add(defaultMinStakingDuration)
+	delegatorTx, err := service.vm.txBuilder.NewAddDelegatorTx(
+		service.vm.MinDelegatorStake,
+		uint64(delegatorStartTime.Unix()),
+		uint64(delegatorEndTime.Unix()),
+		validatorNodeID,
+		ids.GenerateTestShortID(),
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	potentialReward := uint64(1_000_000)
+	delegator, err := state.NewCurrentStaker(
+		delegatorTx.ID(),
+		delegatorTx.Unsigned.(*txs.AddDelegatorTx),
+		potentialReward,
+	)
+	require.NoError(err)
+
+	service.vm.state.PutCurrentDelegator(delegator)
+	service.vm.state.AddTx(delegatorTx, status.Committed)
+	service.vm.state.SetTimestamp(delegatorStartTime.Add(defaultMinStakingDuration / 4))
+	require.NoError(service.vm.state.Commit())
+
+	service.vm.ctx.Lock.Unlock()
+
+	delegateeReward, delegatorReward := reward.Split(potentialReward, shares)
+
+	// A quarter of the delegation period has elapsed
+	reply := GetRewardPreviewReply{}
+	require.NoError(service.GetRewardPreview(nil, &GetRewardPreviewArgs{TxID: delegatorTx.ID()}, &reply))
+	require.Equal(validatorNodeID, reply.NodeID)
+	require.Equal(constants.PrimaryNetworkID, reply.SubnetID)
+	require.Equal(json.Uint64(delegatorStartTime.Unix()), reply.StartTime)
+	require.Equal(json.Uint64(delegatorEndTime.Unix()), reply.EndTime)
+	require.False(reply.Pending)
+	require.Equal(json.Uint64(potentialReward), reply.PotentialReward)
+	require.Equal(json.Uint64(delegatorReward), reply.ProjectedReward)
+	require.Equal(json.Uint64(delegatorReward/4), reply.AccruedReward)
+	require.Zero(reply.ProjectedDelegationFees)
+	require.NotNil(reply.Uptime)
+	require.NotNil(reply.MeetsUptimeRequirement)
+	require.Equal(json.Float32(service.vm.UptimePercentage*100), reply.UptimeRequirement)
+
+	// The validator is projected to receive the delegation fee
+	reply = GetRewardPreviewReply{}
+	require.NoError(service.GetRewardPreview(nil, &GetRewardPreviewArgs{TxID: validator.TxID}, &reply))
+	require.Equal(json.Uint64(validator.PotentialReward), reply.PotentialReward)
+	require.Equal(json.Uint64(validator.PotentialReward), reply.ProjectedReward)
+	require.Equal(json.Uint64(delegateeReward), reply.ProjectedDelegationFees)
+
+	// Only stakers can be previewed
+	err = service.GetRewardPreview(nil, &GetRewardPreviewArgs{TxID: ids.GenerateTestID()}, &reply)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestGetValidatorUptimes(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	genesis, _ := defaultGenesis(t)
+
+	// By default, the validators are sorted by node ID
+	reply := GetValidatorUptimesReply{}
+	require.NoError(service.GetValidatorUptimes(nil, &GetValidatorUptimesArgs{}, &reply))
+	require.Equal(json.Uint32(len(genesis.Validators)), reply.NumValidators)
+	require.Len(reply.Validators, len(genesis.Validators))
+	allValidators := reply.Validators
+	for i, vdr := range allValidators {
+		require.NotNil(vdr.Uptime)
+		if i > 0 {
+			require.True(allValidators[i-1].NodeID.Less(vdr.NodeID))
+		}
+	}
+
+	// Pages are taken from the sorted validators
+	reply = GetValidatorUptimesReply{}
+	require.NoError(service.GetValidatorUptimes(nil, &GetValidatorUptimesArgs{
+		StartIndex: 1,
+		Limit:      2,
+	}, &reply))
+	require.Equal(json.Uint32(len(genesis.Validators)), reply.NumValidators)
+	require.Equal(allValidators[1:3], reply.Validators)
+
+	reply = GetValidatorUptimesReply{}
+	require.NoError(service.GetValidatorUptimes(nil, &GetValidatorUptimesArgs{
+		StartIndex: json.Uint32(len(genesis.Validators)),
+	}, &reply))
+	require.Empty(reply.Validators)
+
+	// Add a validator with more stake than the genesis validators
+	service.vm.ctx.Lock.Lock()
+	validatorNodeID := ids.GenerateTestNodeID()
+	validatorTx, err := service.vm.txBuilder.NewAddValidatorTx(
+		service.vm.MaxValidatorStake,
+		uint64(defaultGenesisTime.Unix()),
+		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()),
+		validatorNodeID,
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	validator, err := state.NewCurrentStaker(
+		validatorTx.ID(),
+		validatorTx.Unsigned.(*txs.AddValidatorTx),
+		0,
+	)
+	require.NoError(err)
+
+	service.vm.state.PutCurrentValidator(validator)
+	service.vm.state.AddTx(validatorTx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	reply = GetValidatorUptimesReply{}
+	require.NoError(service.GetValidatorUptimes(nil, &GetValidatorUptimesArgs{
+		SortBy:     SortByStake,
+		Descending: true,
+		Limit:      1,
+	}, &reply))
+	require.Equal(json.Uint32(len(genesis.Validators)+1), reply.NumValidators)
+	require.Len(reply.Validators, 1)
+	require.Equal(validatorNodeID, reply.Validators[0].NodeID)
+	require.Equal(json.Uint64(service.vm.MaxValidatorStake), reply.Validators[0].Weight)
+
+	// Subnets without validators have no uptimes
+	reply = GetValidatorUptimesReply{}
+	require.NoError(service.GetValidatorUptimes(nil, &GetValidatorUptimesArgs{
+		SubnetID: ids.GenerateTestID(),
+	}, &reply))
+	require.Zero(reply.NumValidators)
+	require.Empty(reply.Validators)
+
+	err = service.GetValidatorUptimes(nil, &GetValidatorUptimesArgs{
+		SortBy: "weight",
+	}, &GetValidatorUptimesReply{})
+	require.ErrorIs(err, errInvalidSortBy)
+}
+
+func TestGetStakingHistory(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	rewardAddr := ids.GenerateTestShortID()
+	rewardAddrStr, err := service.addrManager.FormatLocalAddress(rewardAddr)
+	require.NoError(err)
+
+	// Addresses without staking history have no staking periods
+	reply := GetStakingHistoryReply{}
+	require.NoError(service.GetStakingHistory(nil, &GetStakingHistoryArgs{
+		Address: rewardAddrStr,
+	}, &reply))
+	require.Empty(reply.StakingPeriods)
+	require.Equal(ids.Empty, reply.EndTxID)
+
+	service.vm.ctx.Lock.Lock()
+	newValidatorTx := func(status status.Status) *txs.Tx {
+		tx, err := service.vm.txBuilder.NewAddValidatorTx(
+			service.vm.MinValidatorStake,
+			uint64(defaultGenesisTime.Unix()),
+			uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()),
+			ids.GenerateTestNodeID(),
+			rewardAddr,
+			reward.PercentDenominator,
+			[]*secp256k1.PrivateKey{keys[0]},
+			keys[0].PublicKey().Address(), // change addr
+		)
+		require.NoError(err)
+
+		service.vm.state.AddTx(tx, status)
+		return tx
+	}
+
+	currentValidatorTx := newValidatorTx(status.Committed)
+	currentValidator, err := state.NewCurrentStaker(
+		currentValidatorTx.ID(),
+		currentValidatorTx.Unsigned.(*txs.AddValidatorTx),
+		0,
+	)
+	require.NoError(err)
+	service.vm.state.PutCurrentValidator(currentValidator)
+
+	completedValidatorTx := newValidatorTx(status.Committed)
+	for i, utxo := range []struct {
+		amount uint64
+		owner  ids.ShortID
+	}{
+		{amount: 2, owner: rewardAddr},
+		{amount: 3, owner: rewardAddr},
+		{amount: 5, owner: ids.GenerateTestShortID()},
+	} {
+		service.vm.state.AddRewardUTXO(completedValidatorTx.ID(), &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        completedValidatorTx.ID(),
+				OutputIndex: uint32(i),
+			},
+			Asset: avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: utxo.amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{utxo.owner},
+				},
+			},
+		})
+	}
+
+	// Aborted staker txs aren't part of the staking history
+	_ = newValidatorTx(status.Aborted)
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	expectedPeriods := []StakingPeriod{
+		{
+			TxID:            currentValidatorTx.ID(),
+			NodeID:          currentValidator.NodeID,
+			SubnetID:        constants.PrimaryNetworkID,
+			Validator:       true,
+			StartTime:       json.Uint64(defaultGenesisTime.Unix()),
+			EndTime:         json.Uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()),
+			StakeAmount:     json.Uint64(service.vm.MinValidatorStake),
+			Status:          StakingStatusCurrent,
+			RewardsReceived: 0,
+		},
+		{
+			TxID:            completedValidatorTx.ID(),
+			NodeID:          completedValidatorTx.Unsigned.(*txs.AddValidatorTx).NodeID(),
+			SubnetID:        constants.PrimaryNetworkID,
+			Validator:       true,
+			StartTime:       json.Uint64(defaultGenesisTime.Unix()),
+			EndTime:         json.Uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()),
+			StakeAmount:     json.Uint64(service.vm.MinValidatorStake),
+			Status:          StakingStatusCompleted,
+			RewardsReceived: 5,
+		},
+	}
+	if expectedPeriods[1].TxID.Less(expectedPeriods[0].TxID) {
+		expectedPeriods[0], expectedPeriods[1] = expectedPeriods[1], expectedPeriods[0]
+	}
+
+	reply = GetStakingHistoryReply{}
+	require.NoError(service.GetStakingHistory(nil, &GetStakingHistoryArgs{
+		Address: rewardAddrStr,
+	}, &reply))
+	require.Equal(expectedPeriods, reply.StakingPeriods)
+	require.Equal(expectedPeriods[1].TxID, reply.EndTxID)
+
+	// Pages continue after the end of the previous page
+	reply = GetStakingHistoryReply{}
+	require.NoError(service.GetStakingHistory(nil, &GetStakingHistoryArgs{
+		Address: rewardAddrStr,
+		Limit:   1,
+	}, &reply))
+	require.Equal(expectedPeriods[:1], reply.StakingPeriods)
+	require.Equal(expectedPeriods[0].TxID, reply.EndTxID)
+
+	reply = GetStakingHistoryReply{}
+	require.NoError(service.GetStakingHistory(nil, &GetStakingHistoryArgs{
+		Address:   rewardAddrStr,
+		StartTxID: expectedPeriods[0].TxID,
+		Limit:     1,
+	}, &reply))
+	require.Equal(expectedPeriods[1:], reply.StakingPeriods)
+	require.Equal(expectedPeriods[1].TxID, reply.EndTxID)
+
+	reply = GetStakingHistoryReply{}
+	require.NoError(service.GetStakingHistory(nil, &GetStakingHistoryArgs{
+		Address:   rewardAddrStr,
+		StartTxID: expectedPeriods[1].TxID,
+	}, &reply))
+	require.Empty(reply.StakingPeriods)
+	require.Equal(expectedPeriods[1].TxID, reply.EndTxID)
+}
+
 func TestGetTimestamp(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t)
@@ -862,6 +1306,61 @@ func TestGetValidatorsAtReplyMarshalling(t *testing.T) {
 	require.Equal(reply, &parsedReply)
 }
 
+func TestExportValidatorSet(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	args := ExportValidatorSetArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		Height:   0,
+		Encoding: formatting.Hex,
+	}
+	reply := ExportValidatorSetReply{}
+	err := service.ExportValidatorSet(&http.Request{}, &args, &reply)
+	require.ErrorIs(err, errNoWarpSigner)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	service.vm.ctx.PublicKey = bls.PublicFromSecretKey(sk)
+	service.vm.ctx.WarpSigner = warp.NewSigner(sk, service.vm.ctx.NetworkID, service.vm.ctx.ChainID)
+
+	require.NoError(service.ExportValidatorSet(&http.Request{}, &args, &reply))
+
+	vdrSetBytes, err := formatting.Decode(args.Encoding, reply.ValidatorSet)
+	require.NoError(err)
+	vdrSet, err := warp.ParseValidatorSet(vdrSetBytes)
+	require.NoError(err)
+	require.Equal(reply.ValidatorSetID, vdrSet.ID())
+	require.Equal(constants.PrimaryNetworkID, vdrSet.SubnetID)
+
+	service.vm.ctx.Lock.Lock()
+	expectedVdrSet, err := warp.GetValidatorSet(context.Background(), service.vm, 0, constants.PrimaryNetworkID)
+	service.vm.ctx.Lock.Unlock()
+	require.NoError(err)
+	require.Equal(expectedVdrSet.Bytes(), vdrSetBytes)
+
+	unsignedMsgBytes, err := formatting.Decode(args.Encoding, reply.UnsignedMessage)
+	require.NoError(err)
+	unsignedMsg, err := warp.ParseUnsignedMessage(unsignedMsgBytes)
+	require.NoError(err)
+	require.Equal(service.vm.ctx.ChainID, unsignedMsg.SourceChainID)
+
+	hashPayload, err := payload.ParseHash(unsignedMsg.Payload)
+	require.NoError(err)
+	require.Equal(vdrSet.ID(), hashPayload.Hash)
+
+	sigBytes, err := formatting.Decode(formatting.HexNC, reply.Signature)
+	require.NoError(err)
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	require.NoError(err)
+	require.True(bls.Verify(service.vm.ctx.PublicKey, sig, unsignedMsgBytes))
+}
+
 func TestServiceGetBlockByHeight(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
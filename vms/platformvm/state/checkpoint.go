@@ -0,0 +1,493 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// maxCheckpointRecordSize is the maximum size of a single record of a
+// checkpoint. This matches the maximum size of the genesis codec.
+const maxCheckpointRecordSize = math.MaxInt32
+
+var (
+	errAlreadyInitialized        = errors.New("database is already initialized")
+	errCheckpointRecordTooLarge  = errors.New("checkpoint record too large")
+	errUnexpectedCheckpointStake = errors.New("unexpected staker in checkpoint")
+)
+
+// checkpointHeader contains everything in a checkpoint other than the UTXOs.
+//
+// A checkpoint is encoded as a sequence of records, each of which is prefixed
+// by its uint32 length. The first record is the header. Each following record
+// is a UTXO. The checkpoint is terminated by an empty record.
+type checkpointHeader struct {
+	// LastAcceptedBlock is the block the checkpoint was taken at.
+	LastAcceptedBlock []byte                   `serialize:"true"`
+	Timestamp         uint64                   `serialize:"true"`
+	Supplies          []checkpointSupply       `serialize:"true"`
+	Subnets           [][]byte                 `serialize:"true"`
+	SubnetOwners      []checkpointSubnetOwner  `serialize:"true"`
+	Transformations   [][]byte                 `serialize:"true"`
+	Chains            [][]byte                 `serialize:"true"`
+	CurrentStakers    []checkpointCurrentStake `serialize:"true"`
+	PendingStakers    [][]byte                 `serialize:"true"`
+}
+
+type checkpointSupply struct {
+	SubnetID ids.ID `serialize:"true"`
+	Supply   uint64 `serialize:"true"`
+}
+
+type checkpointSubnetOwner struct {
+	SubnetID ids.ID   `serialize:"true"`
+	Owner    fx.Owner `serialize:"true"`
+}
+
+type checkpointCurrentStake struct {
+	Tx              []byte `serialize:"true"`
+	PotentialReward uint64 `serialize:"true"`
+	// The following fields are only populated for validators.
+	DelegateeReward uint64 `serialize:"true"`
+	UpDuration      uint64 `serialize:"true"`
+	LastUpdated     uint64 `serialize:"true"`
+}
+
+// Checkpoint writes the staker set, the UTXO set, and the chain metadata at the
+// last accepted block to [w]. Historical blocks, txs, reward UTXOs, and
+// validator set diffs are not included.
+//
+// Invariant: There are no uncommitted changes.
+func (s *state) Checkpoint(w io.Writer) error {
+	header, err := s.checkpointHeader()
+	if err != nil {
+		return err
+	}
+	headerBytes, err := block.GenesisCodec.Marshal(block.Version, header)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint header: %w", err)
+	}
+	if err := writeCheckpointRecord(w, headerBytes); err != nil {
+		return err
+	}
+
+	err = s.utxoState.ForEachUTXO(func(utxo *avax.UTXO) error {
+		utxoBytes, err := block.GenesisCodec.Marshal(block.Version, utxo)
+		if err != nil {
+			return fmt.Errorf("failed to marshal UTXO %s: %w", utxo.InputID(), err)
+		}
+		return writeCheckpointRecord(w, utxoBytes)
+	})
+	if err != nil {
+		return err
+	}
+	return writeCheckpointRecord(w, nil)
+}
+
+func (s *state) checkpointHeader() (*checkpointHeader, error) {
+	lastAcceptedBlk, err := s.GetStatelessBlock(s.lastAccepted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last accepted block: %w", err)
+	}
+
+	header := &checkpointHeader{
+		LastAcceptedBlock: lastAcceptedBlk.Bytes(),
+		Timestamp:         uint64(s.timestamp.Unix()),
+		Supplies: []checkpointSupply{{
+			SubnetID: constants.PrimaryNetworkID,
+			Supply:   s.currentSupply,
+		}},
+	}
+
+	chains, err := s.GetChains(constants.PrimaryNetworkID)
+	if err != nil {
+		return nil, err
+	}
+	header.Chains = appendTxBytes(header.Chains, chains)
+
+	subnets, err := s.GetSubnets()
+	if err != nil {
+		return nil, err
+	}
+	header.Subnets = appendTxBytes(header.Subnets, subnets)
+	for _, subnet := range subnets {
+		subnetID := subnet.ID()
+		owner, err := s.GetSubnetOwner(subnetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get owner of subnet %s: %w", subnetID, err)
+		}
+		header.SubnetOwners = append(header.SubnetOwners, checkpointSubnetOwner{
+			SubnetID: subnetID,
+			Owner:    owner,
+		})
+
+		chains, err := s.GetChains(subnetID)
+		if err != nil {
+			return nil, err
+		}
+		header.Chains = appendTxBytes(header.Chains, chains)
+
+		transformation, err := s.GetSubnetTransformation(subnetID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		header.Transformations = append(header.Transformations, transformation.Bytes())
+
+		supply, err := s.GetCurrentSupply(subnetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get supply of subnet %s: %w", subnetID, err)
+		}
+		header.Supplies = append(header.Supplies, checkpointSupply{
+			SubnetID: subnetID,
+			Supply:   supply,
+		})
+	}
+
+	currentIt, err := s.GetCurrentStakerIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer currentIt.Release()
+	for currentIt.Next() {
+		staker := currentIt.Value()
+		tx, _, err := s.GetTx(staker.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get staker tx %s: %w", staker.TxID, err)
+		}
+
+		stake := checkpointCurrentStake{
+			Tx:              tx.Bytes(),
+			PotentialReward: staker.PotentialReward,
+		}
+		if staker.Priority.IsCurrentValidator() {
+			upDuration, lastUpdated, err := s.GetUptime(staker.NodeID, staker.SubnetID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get uptime of %s: %w", staker.NodeID, err)
+			}
+			delegateeReward, err := s.GetDelegateeReward(staker.SubnetID, staker.NodeID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get delegatee reward of %s: %w", staker.NodeID, err)
+			}
+			stake.DelegateeReward = delegateeReward
+			stake.UpDuration = uint64(upDuration)
+			stake.LastUpdated = uint64(lastUpdated.Unix())
+		}
+		header.CurrentStakers = append(header.CurrentStakers, stake)
+	}
+
+	pendingIt, err := s.GetPendingStakerIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer pendingIt.Release()
+	for pendingIt.Next() {
+		staker := pendingIt.Value()
+		tx, _, err := s.GetTx(staker.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get staker tx %s: %w", staker.TxID, err)
+		}
+		header.PendingStakers = append(header.PendingStakers, tx.Bytes())
+	}
+	return header, nil
+}
+
+// RestoreFromCheckpoint populates [db] with the checkpoint read from [r] and
+// returns the resulting state. [db] must not have been initialized. The
+// validator sets prior to the height of the checkpoint can't be calculated
+// from the returned state.
+func RestoreFromCheckpoint(
+	db database.Database,
+	r io.Reader,
+	metricsReg prometheus.Registerer,
+	validators validators.Manager,
+	execCfg *config.ExecutionConfig,
+	ctx *snow.Context,
+	metrics metrics.Metrics,
+	rewards reward.Calculator,
+) (State, error) {
+	s, err := newState(
+		db,
+		metrics,
+		validators,
+		execCfg,
+		ctx,
+		metricsReg,
+		rewards,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.restore(r); err != nil {
+		// Drop any errors on close to return the first error
+		_ = s.Close()
+
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *state) restore(r io.Reader) error {
+	shouldInit, err := s.shouldInit()
+	if err != nil {
+		return fmt.Errorf(
+			"failed to check if the database is initialized: %w",
+			err,
+		)
+	}
+	if !shouldInit {
+		return errAlreadyInitialized
+	}
+
+	headerBytes, err := readCheckpointRecord(r)
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint header: %w", err)
+	}
+	header := &checkpointHeader{}
+	if _, err := block.GenesisCodec.Unmarshal(headerBytes, header); err != nil {
+		return fmt.Errorf("failed to unmarshal checkpoint header: %w", err)
+	}
+
+	uptimes, err := s.restoreHeader(header)
+	if err != nil {
+		return err
+	}
+
+	for {
+		utxoBytes, err := readCheckpointRecord(r)
+		if err != nil {
+			return fmt.Errorf("failed to read checkpoint UTXO: %w", err)
+		}
+		if len(utxoBytes) == 0 {
+			break
+		}
+
+		utxo := &avax.UTXO{}
+		if _, err := block.GenesisCodec.Unmarshal(utxoBytes, utxo); err != nil {
+			return fmt.Errorf("failed to unmarshal checkpoint UTXO: %w", err)
+		}
+		s.AddUTXO(utxo)
+	}
+
+	// updateValidators is set to false here to maintain the invariant that the
+	// validator sets are empty before they are initialized.
+	if err := s.write(false /*=updateValidators*/, s.currentHeight); err != nil {
+		return err
+	}
+
+	// The validator metadata is populated when the validators are written, so
+	// the uptimes can only be restored afterwards.
+	for _, uptime := range uptimes {
+		if err := s.SetUptime(uptime.nodeID, uptime.subnetID, uptime.upDuration, uptime.lastUpdated); err != nil {
+			return err
+		}
+		if err := s.SetDelegateeReward(uptime.subnetID, uptime.nodeID, uptime.delegateeReward); err != nil {
+			return err
+		}
+	}
+
+	if err := s.doneInit(); err != nil {
+		return err
+	}
+	if err := s.Commit(); err != nil {
+		return err
+	}
+
+	if err := s.load(); err != nil {
+		return fmt.Errorf(
+			"failed to load the database state: %w",
+			err,
+		)
+	}
+	return nil
+}
+
+type checkpointUptime struct {
+	nodeID          ids.NodeID
+	subnetID        ids.ID
+	upDuration      time.Duration
+	lastUpdated     time.Time
+	delegateeReward uint64
+}
+
+// restoreHeader adds the contents of [header] to the state and returns the
+// uptimes of the current validators.
+func (s *state) restoreHeader(header *checkpointHeader) ([]checkpointUptime, error) {
+	lastAcceptedBlk, err := block.Parse(block.GenesisCodec, header.LastAcceptedBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse last accepted block: %w", err)
+	}
+	s.AddStatelessBlock(lastAcceptedBlk)
+	s.SetLastAccepted(lastAcceptedBlk.ID())
+	s.SetHeight(lastAcceptedBlk.Height())
+	s.SetTimestamp(time.Unix(int64(header.Timestamp), 0))
+
+	for _, supply := range header.Supplies {
+		s.SetCurrentSupply(supply.SubnetID, supply.Supply)
+	}
+
+	subnets, err := parseCheckpointTxs(header.Subnets)
+	if err != nil {
+		return nil, err
+	}
+	for _, subnet := range subnets {
+		s.AddSubnet(subnet)
+		s.AddTx(subnet, status.Committed)
+	}
+	for _, owner := range header.SubnetOwners {
+		s.SetSubnetOwner(owner.SubnetID, owner.Owner)
+	}
+
+	transformations, err := parseCheckpointTxs(header.Transformations)
+	if err != nil {
+		return nil, err
+	}
+	for _, transformation := range transformations {
+		if _, ok := transformation.Unsigned.(*txs.TransformSubnetTx); !ok {
+			return nil, fmt.Errorf("expected tx type *txs.TransformSubnetTx but got %T", transformation.Unsigned)
+		}
+		s.AddSubnetTransformation(transformation)
+		s.AddTx(transformation, status.Committed)
+	}
+
+	chains, err := parseCheckpointTxs(header.Chains)
+	if err != nil {
+		return nil, err
+	}
+	for _, chain := range chains {
+		if _, ok := chain.Unsigned.(*txs.CreateChainTx); !ok {
+			return nil, fmt.Errorf("expected tx type *txs.CreateChainTx but got %T", chain.Unsigned)
+		}
+		s.AddChain(chain)
+		s.AddTx(chain, status.Committed)
+	}
+
+	var uptimes []checkpointUptime
+	for _, stake := range header.CurrentStakers {
+		tx, err := txs.Parse(txs.GenesisCodec, stake.Tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse staker tx: %w", err)
+		}
+		stakerTx, ok := tx.Unsigned.(txs.Staker)
+		if !ok {
+			return nil, fmt.Errorf("expected tx type txs.Staker but got %T", tx.Unsigned)
+		}
+
+		staker, err := NewCurrentStaker(tx.ID(), stakerTx, stake.PotentialReward)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case staker.Priority.IsCurrentValidator():
+			s.PutCurrentValidator(staker)
+			uptimes = append(uptimes, checkpointUptime{
+				nodeID:          staker.NodeID,
+				subnetID:        staker.SubnetID,
+				upDuration:      time.Duration(stake.UpDuration),
+				lastUpdated:     time.Unix(int64(stake.LastUpdated), 0),
+				delegateeReward: stake.DelegateeReward,
+			})
+		case staker.Priority.IsCurrentDelegator():
+			s.PutCurrentDelegator(staker)
+		default:
+			return nil, fmt.Errorf("%w: %s is pending", errUnexpectedCheckpointStake, staker.TxID)
+		}
+		s.AddTx(tx, status.Committed)
+	}
+
+	pendingStakers, err := parseCheckpointTxs(header.PendingStakers)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range pendingStakers {
+		stakerTx, ok := tx.Unsigned.(txs.Staker)
+		if !ok {
+			return nil, fmt.Errorf("expected tx type txs.Staker but got %T", tx.Unsigned)
+		}
+
+		staker, err := NewPendingStaker(tx.ID(), stakerTx)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case staker.Priority.IsPendingValidator():
+			s.PutPendingValidator(staker)
+		case staker.Priority.IsPendingDelegator():
+			s.PutPendingDelegator(staker)
+		default:
+			return nil, fmt.Errorf("%w: %s is current", errUnexpectedCheckpointStake, staker.TxID)
+		}
+		s.AddTx(tx, status.Committed)
+	}
+	return uptimes, nil
+}
+
+func appendTxBytes(txBytes [][]byte, txs []*txs.Tx) [][]byte {
+	for _, tx := range txs {
+		txBytes = append(txBytes, tx.Bytes())
+	}
+	return txBytes
+}
+
+func parseCheckpointTxs(txBytes [][]byte) ([]*txs.Tx, error) {
+	parsedTxs := make([]*txs.Tx, len(txBytes))
+	for i, bytes := range txBytes {
+		tx, err := txs.Parse(txs.GenesisCodec, bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse checkpoint tx: %w", err)
+		}
+		parsedTxs[i] = tx
+	}
+	return parsedTxs, nil
+}
+
+func writeCheckpointRecord(w io.Writer, record []byte) error {
+	var lenBytes [wrappers.IntLen]byte
+	binary.BigEndian.PutUint32(lenBytes[:], uint32(len(record)))
+	if _, err := w.Write(lenBytes[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(record)
+	return err
+}
+
+func readCheckpointRecord(r io.Reader) ([]byte, error) {
+	var lenBytes [wrappers.IntLen]byte
+	if _, err := io.ReadFull(r, lenBytes[:]); err != nil {
+		return nil, err
+	}
+	recordLen := binary.BigEndian.Uint32(lenBytes[:])
+	if recordLen > maxCheckpointRecordSize {
+		return nil, fmt.Errorf("%w: %d > %d", errCheckpointRecordTooLarge, recordLen, maxCheckpointRecordSize)
+	}
+
+	record := make([]byte, recordLen)
+	_, err := io.ReadFull(r, record)
+	return record, err
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+)
+
+func TestCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	original, _ := newInitializedState(require)
+
+	upDuration := 5 * time.Minute
+	lastUpdated := initialTime.Add(10 * time.Minute)
+	require.NoError(original.SetUptime(initialNodeID, constants.PrimaryNetworkID, upDuration, lastUpdated))
+	require.NoError(original.SetDelegateeReward(constants.PrimaryNetworkID, initialNodeID, units.Avax))
+	require.NoError(original.Commit())
+
+	checkpoint := &bytes.Buffer{}
+	require.NoError(original.Checkpoint(checkpoint))
+	checkpointBytes := checkpoint.Bytes()
+
+	restored, err := restoreFromCheckpoint(memdb.New(), bytes.NewReader(checkpointBytes))
+	require.NoError(err)
+
+	require.Equal(original.GetLastAccepted(), restored.GetLastAccepted())
+	require.Equal(original.GetTimestamp(), restored.GetTimestamp())
+	require.Equal(original.Checksum(), restored.Checksum())
+
+	originalSupply, err := original.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	restoredSupply, err := restored.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(originalSupply, restoredSupply)
+
+	originalChains, err := original.GetChains(constants.PrimaryNetworkID)
+	require.NoError(err)
+	restoredChains, err := restored.GetChains(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(originalChains, restoredChains)
+
+	originalStaker, err := original.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+	restoredStaker, err := restored.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+	require.Equal(originalStaker, restoredStaker)
+
+	restoredUpDuration, restoredLastUpdated, err := restored.GetUptime(initialNodeID, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(upDuration, restoredUpDuration)
+	require.Equal(lastUpdated, restoredLastUpdated)
+
+	delegateeReward, err := restored.GetDelegateeReward(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+	require.Equal(units.Avax, delegateeReward)
+
+	utxo, err := restored.GetUTXO(initialTxID.Prefix(0))
+	require.NoError(err)
+	require.Equal(initialTxID, utxo.TxID)
+
+	// Checkpointing the restored state should produce the same checkpoint.
+	recheckpoint := &bytes.Buffer{}
+	require.NoError(restored.Checkpoint(recheckpoint))
+	require.Equal(checkpointBytes, recheckpoint.Bytes())
+
+	// Truncated checkpoints must be rejected.
+	_, err = restoreFromCheckpoint(memdb.New(), bytes.NewReader(checkpointBytes[:len(checkpointBytes)-1]))
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+}
+
+func TestRestoreFromCheckpointInitialized(t *testing.T) {
+	require := require.New(t)
+
+	original, db := newInitializedState(require)
+	require.NoError(original.(*state).doneInit())
+	require.NoError(original.Commit())
+
+	checkpoint := &bytes.Buffer{}
+	require.NoError(original.Checkpoint(checkpoint))
+
+	_, err := restoreFromCheckpoint(db, checkpoint)
+	require.ErrorIs(err, errAlreadyInitialized)
+}
+
+func restoreFromCheckpoint(db database.Database, r io.Reader) (State, error) {
+	execCfg, _ := config.GetExecutionConfig(nil)
+	return RestoreFromCheckpoint(
+		db,
+		r,
+		prometheus.NewRegistry(),
+		validators.NewManager(),
+		execCfg,
+		&snow.Context{},
+		metrics.Noop,
+		reward.NewCalculator(reward.Config{}),
+	)
+}
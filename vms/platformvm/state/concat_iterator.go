@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+var _ StakerIterator = (*concatIterator)(nil)
+
+type concatIterator struct {
+	iterators []StakerIterator
+}
+
+// NewConcatIterator returns a new iterator that returns all the stakers in the
+// first of [iterators], followed by all the stakers in the second, and so on.
+// Unlike NewMergedIterator, the stakers aren't sorted across [iterators].
+//
+// Each of [iterators] is released once it is exhausted.
+func NewConcatIterator(iterators ...StakerIterator) StakerIterator {
+	return &concatIterator{
+		iterators: iterators,
+	}
+}
+
+func (i *concatIterator) Next() bool {
+	for len(i.iterators) > 0 {
+		if i.iterators[0].Next() {
+			return true
+		}
+		i.iterators[0].Release()
+		i.iterators = i.iterators[1:]
+	}
+	return false
+}
+
+func (i *concatIterator) Value() *Staker {
+	return i.iterators[0].Value()
+}
+
+func (i *concatIterator) Release() {
+	for _, it := range i.iterators {
+		it.Release()
+	}
+	i.iterators = nil
+}
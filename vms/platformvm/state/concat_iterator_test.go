@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestConcatIterator(t *testing.T) {
+	stakers := []*Staker{
+		{
+			TxID: ids.GenerateTestID(),
+		},
+		{
+			TxID: ids.GenerateTestID(),
+		},
+		{
+			TxID: ids.GenerateTestID(),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		iterators []StakerIterator
+		expected  []*Staker
+	}{
+		{
+			name:      "no iterators",
+			iterators: nil,
+			expected:  nil,
+		},
+		{
+			name: "only empty iterators",
+			iterators: []StakerIterator{
+				EmptyIterator,
+				NewSliceIterator(),
+			},
+			expected: nil,
+		},
+		{
+			name: "one iterator",
+			iterators: []StakerIterator{
+				NewSliceIterator(stakers...),
+			},
+			expected: stakers,
+		},
+		{
+			name: "multiple iterators",
+			iterators: []StakerIterator{
+				NewSliceIterator(stakers[0]),
+				NewSliceIterator(stakers[1:]...),
+			},
+			expected: stakers,
+		},
+		{
+			name: "empty iterators are skipped",
+			iterators: []StakerIterator{
+				EmptyIterator,
+				NewSliceIterator(stakers[0]),
+				EmptyIterator,
+				NewSliceIterator(stakers[1:]...),
+				EmptyIterator,
+			},
+			expected: stakers,
+		},
+		{
+			name: "order isn't sorted",
+			iterators: []StakerIterator{
+				NewSliceIterator(stakers[2]),
+				NewSliceIterator(stakers[0], stakers[1]),
+			},
+			expected: []*Staker{stakers[2], stakers[0], stakers[1]},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			it := NewConcatIterator(test.iterators...)
+			for _, expected := range test.expected {
+				require.True(it.Next())
+				require.Equal(expected, it.Value())
+			}
+			require.False(it.Next())
+			it.Release()
+			require.False(it.Next())
+		})
+	}
+}
+
+func TestConcatIteratorReleasesIterators(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	staker := &Staker{
+		TxID: ids.GenerateTestID(),
+	}
+
+	// The first iterator is released as soon as it is exhausted.
+	exhaustedIterator := NewMockStakerIterator(ctrl)
+	exhaustedIterator.EXPECT().Next().Return(false)
+	exhaustedIterator.EXPECT().Release()
+
+	// The remaining iterators are released by Release.
+	currentIterator := NewMockStakerIterator(ctrl)
+	currentIterator.EXPECT().Next().Return(true)
+	currentIterator.EXPECT().Value().Return(staker)
+	currentIterator.EXPECT().Release()
+
+	unreadIterator := NewMockStakerIterator(ctrl)
+	unreadIterator.EXPECT().Release()
+
+	it := NewConcatIterator(exhaustedIterator, currentIterator, unreadIterator)
+	require.True(it.Next())
+	require.Equal(staker, it.Value())
+	it.Release()
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
@@ -45,6 +46,9 @@ type diff struct {
 	addedSubnets []*txs.Tx
 	// Subnet ID --> Owner of the subnet
 	subnetOwners map[ids.ID]fx.Owner
+	// Subnet ID --> Node ID --> time the validator is scheduled to be removed.
+	// If the time is nil, the scheduled removal was deleted.
+	modifiedSubnetValidatorRemovals map[ids.ID]map[ids.NodeID]*time.Time
 	// Subnet ID --> Tx that transforms the subnet
 	transformedSubnets map[ids.ID]*txs.Tx
 
@@ -194,6 +198,20 @@ func (d *diff) GetCurrentStakerIterator() (StakerIterator, error) {
 	return d.currentStakerDiffs.GetStakerIterator(parentIterator), nil
 }
 
+func (d *diff) GetCurrentNodeStakerIterator(nodeID ids.NodeID) (StakerIterator, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentIterator, err := parentState.GetCurrentNodeStakerIterator(nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.currentStakerDiffs.GetNodeStakerIterator(parentIterator, nodeID), nil
+}
+
 func (d *diff) GetPendingValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
 	// If the validator was modified in this diff, return the modified
 	// validator.
@@ -257,6 +275,20 @@ func (d *diff) GetPendingStakerIterator() (StakerIterator, error) {
 	return d.pendingStakerDiffs.GetStakerIterator(parentIterator), nil
 }
 
+func (d *diff) GetPendingNodeStakerIterator(nodeID ids.NodeID) (StakerIterator, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentIterator, err := parentState.GetPendingNodeStakerIterator(nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.pendingStakerDiffs.GetNodeStakerIterator(parentIterator, nodeID), nil
+}
+
 func (d *diff) AddSubnet(createSubnetTx *txs.Tx) {
 	d.addedSubnets = append(d.addedSubnets, createSubnetTx)
 }
@@ -279,6 +311,77 @@ func (d *diff) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
 	d.subnetOwners[subnetID] = owner
 }
 
+func (d *diff) GetSubnetValidatorRemovals() ([]*SubnetValidatorRemoval, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	parentRemovals, err := parentState.GetSubnetValidatorRemovals()
+	if err != nil {
+		return nil, err
+	}
+	if len(d.modifiedSubnetValidatorRemovals) == 0 {
+		return parentRemovals, nil
+	}
+
+	removals := make([]*SubnetValidatorRemoval, 0, len(parentRemovals))
+	for _, removal := range parentRemovals {
+		if _, modified := d.modifiedSubnetValidatorRemovals[removal.SubnetID][removal.NodeID]; !modified {
+			removals = append(removals, removal)
+		}
+	}
+	for subnetID, subnetRemovals := range d.modifiedSubnetValidatorRemovals {
+		for nodeID, removalTime := range subnetRemovals {
+			if removalTime == nil {
+				continue
+			}
+			removals = append(removals, &SubnetValidatorRemoval{
+				SubnetID: subnetID,
+				NodeID:   nodeID,
+				Time:     *removalTime,
+			})
+		}
+	}
+	utils.Sort(removals)
+	return removals, nil
+}
+
+func (d *diff) GetSubnetValidatorRemoval(subnetID ids.ID, nodeID ids.NodeID) (time.Time, error) {
+	if removalTime, modified := d.modifiedSubnetValidatorRemovals[subnetID][nodeID]; modified {
+		if removalTime == nil {
+			return time.Time{}, database.ErrNotFound
+		}
+		return *removalTime, nil
+	}
+
+	// If the removal wasn't modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetSubnetValidatorRemoval(subnetID, nodeID)
+}
+
+func (d *diff) SetSubnetValidatorRemoval(subnetID ids.ID, nodeID ids.NodeID, removalTime time.Time) {
+	d.setSubnetValidatorRemoval(subnetID, nodeID, &removalTime)
+}
+
+func (d *diff) DeleteSubnetValidatorRemoval(subnetID ids.ID, nodeID ids.NodeID) {
+	d.setSubnetValidatorRemoval(subnetID, nodeID, nil)
+}
+
+func (d *diff) setSubnetValidatorRemoval(subnetID ids.ID, nodeID ids.NodeID, removalTime *time.Time) {
+	if d.modifiedSubnetValidatorRemovals == nil {
+		d.modifiedSubnetValidatorRemovals = make(map[ids.ID]map[ids.NodeID]*time.Time)
+	}
+	subnetRemovals, ok := d.modifiedSubnetValidatorRemovals[subnetID]
+	if !ok {
+		subnetRemovals = make(map[ids.NodeID]*time.Time)
+		d.modifiedSubnetValidatorRemovals[subnetID] = subnetRemovals
+	}
+	subnetRemovals[nodeID] = removalTime
+}
+
 func (d *diff) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 	tx, exists := d.transformedSubnets[subnetID]
 	if exists {
@@ -465,5 +568,14 @@ func (d *diff) Apply(baseState Chain) error {
 	for subnetID, owner := range d.subnetOwners {
 		baseState.SetSubnetOwner(subnetID, owner)
 	}
+	for subnetID, subnetRemovals := range d.modifiedSubnetValidatorRemovals {
+		for nodeID, removalTime := range subnetRemovals {
+			if removalTime == nil {
+				baseState.DeleteSubnetValidatorRemoval(subnetID, nodeID)
+			} else {
+				baseState.SetSubnetValidatorRemoval(subnetID, nodeID, *removalTime)
+			}
+		}
+	}
 	return nil
 }
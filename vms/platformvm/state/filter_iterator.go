@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+var _ StakerIterator = (*filterIterator)(nil)
+
+type filterIterator struct {
+	parentIterator StakerIterator
+	filter         func(*Staker) bool
+}
+
+// NewFilterIterator returns a new iterator that only returns the stakers in
+// [parentIterator] for which [filter] returns true.
+func NewFilterIterator(parentIterator StakerIterator, filter func(*Staker) bool) StakerIterator {
+	return &filterIterator{
+		parentIterator: parentIterator,
+		filter:         filter,
+	}
+}
+
+func (i *filterIterator) Next() bool {
+	for i.parentIterator.Next() {
+		if i.filter(i.parentIterator.Value()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *filterIterator) Value() *Staker {
+	return i.parentIterator.Value()
+}
+
+func (i *filterIterator) Release() {
+	i.parentIterator.Release()
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestFilterIterator(t *testing.T) {
+	stakers := []*Staker{
+		{
+			TxID:   ids.GenerateTestID(),
+			Weight: 1,
+		},
+		{
+			TxID:   ids.GenerateTestID(),
+			Weight: 2,
+		},
+		{
+			TxID:   ids.GenerateTestID(),
+			Weight: 3,
+		},
+		{
+			TxID:   ids.GenerateTestID(),
+			Weight: 4,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		stakers  []*Staker
+		filter   func(*Staker) bool
+		expected []*Staker
+	}{
+		{
+			name:     "empty",
+			stakers:  nil,
+			filter:   func(*Staker) bool { return true },
+			expected: nil,
+		},
+		{
+			name:     "keep all",
+			stakers:  stakers,
+			filter:   func(*Staker) bool { return true },
+			expected: stakers,
+		},
+		{
+			name:     "keep none",
+			stakers:  stakers,
+			filter:   func(*Staker) bool { return false },
+			expected: nil,
+		},
+		{
+			name:    "keep some",
+			stakers: stakers,
+			filter: func(s *Staker) bool {
+				return s.Weight%2 == 0
+			},
+			expected: []*Staker{stakers[1], stakers[3]},
+		},
+		{
+			name:    "keep first and last",
+			stakers: stakers,
+			filter: func(s *Staker) bool {
+				return s == stakers[0] || s == stakers[3]
+			},
+			expected: []*Staker{stakers[0], stakers[3]},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			it := NewFilterIterator(
+				NewSliceIterator(test.stakers...),
+				test.filter,
+			)
+			for _, expected := range test.expected {
+				require.True(it.Next())
+				require.Equal(expected, it.Value())
+			}
+			require.False(it.Next())
+			it.Release()
+			require.False(it.Next())
+		})
+	}
+}
+
+func TestFilterIteratorReleasesParent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	parentIterator := NewMockStakerIterator(ctrl)
+	parentIterator.EXPECT().Release()
+
+	it := NewFilterIterator(parentIterator, func(*Staker) bool { return true })
+	it.Release()
+}
@@ -0,0 +1,286 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// VerifyIntegrity checks that:
+//   - Every current and pending staker was added by a committed staker tx
+//     whose inputs are no longer in the UTXO set.
+//   - The validator manager's weights match the current stakers.
+//   - Every scheduled subnet validator removal references a current
+//     permissioned subnet validator that ends after the removal.
+//
+// If [repair] is true, the validator manager is updated to match the current
+// stakers and invalid scheduled removals are deleted.
+//
+// Invariant: There are no uncommitted changes.
+func (s *state) VerifyIntegrity(ctx context.Context, repair bool) ([]string, error) {
+	currentDiscrepancies, err := s.verifyStakers(ctx, "current", s.currentStakers)
+	if err != nil {
+		return nil, err
+	}
+	pendingDiscrepancies, err := s.verifyStakers(ctx, "pending", s.pendingStakers)
+	if err != nil {
+		return nil, err
+	}
+	validatorDiscrepancies, err := s.verifyValidatorSets(repair)
+	if err != nil {
+		return nil, err
+	}
+	removalDiscrepancies, err := s.verifySubnetValidatorRemovals(repair)
+	if err != nil {
+		return nil, err
+	}
+
+	discrepancies := make(
+		[]string,
+		0,
+		len(currentDiscrepancies)+len(pendingDiscrepancies)+len(validatorDiscrepancies)+len(removalDiscrepancies),
+	)
+	discrepancies = append(discrepancies, currentDiscrepancies...)
+	discrepancies = append(discrepancies, pendingDiscrepancies...)
+	discrepancies = append(discrepancies, validatorDiscrepancies...)
+	discrepancies = append(discrepancies, removalDiscrepancies...)
+	return discrepancies, nil
+}
+
+// verifyStakers checks that every staker in [stakers] was added by a committed
+// staker tx that spent its inputs.
+func (s *state) verifyStakers(ctx context.Context, setName string, stakers *baseStakers) ([]string, error) {
+	it := stakers.GetStakerIterator()
+	defer it.Release()
+
+	var discrepancies []string
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		staker := it.Value()
+		tx, txStatus, err := s.GetTx(staker.TxID)
+		if err == database.ErrNotFound {
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"%s staker %s of %s on subnet %s references missing tx",
+				setName,
+				staker.TxID,
+				staker.NodeID,
+				staker.SubnetID,
+			))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tx %s: %w", staker.TxID, err)
+		}
+		if txStatus != status.Committed {
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"%s staker %s has status %s",
+				setName,
+				staker.TxID,
+				txStatus,
+			))
+		}
+
+		stakerTx, ok := tx.Unsigned.(txs.Staker)
+		if !ok {
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"%s staker %s references tx of type %T",
+				setName,
+				staker.TxID,
+				tx.Unsigned,
+			))
+			continue
+		}
+		if stakerTx.NodeID() != staker.NodeID || stakerTx.SubnetID() != staker.SubnetID {
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"%s staker %s is indexed as %s on subnet %s but its tx adds %s on subnet %s",
+				setName,
+				staker.TxID,
+				staker.NodeID,
+				staker.SubnetID,
+				stakerTx.NodeID(),
+				stakerTx.SubnetID(),
+			))
+		}
+
+		for inputID := range tx.Unsigned.InputIDs() {
+			_, err := s.GetUTXO(inputID)
+			if err == database.ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get UTXO %s: %w", inputID, err)
+			}
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"%s staker %s consumed UTXO %s which is still in the UTXO set",
+				setName,
+				staker.TxID,
+				inputID,
+			))
+		}
+	}
+	return discrepancies, nil
+}
+
+// verifyValidatorSets checks that the validator manager's weights match the
+// weights of the current stakers. If [repair] is true, the validator manager is
+// updated to match the current stakers.
+func (s *state) verifyValidatorSets(repair bool) ([]string, error) {
+	// subnetID --> nodeID --> expected weight
+	expected := make(map[ids.ID]map[ids.NodeID]uint64)
+	validatorStakers := make(map[ids.ID]map[ids.NodeID]*Staker)
+	for subnetID, validators := range s.currentStakers.validators {
+		subnetWeights := make(map[ids.NodeID]uint64, len(validators))
+		subnetStakers := make(map[ids.NodeID]*Staker, len(validators))
+		for nodeID, validator := range validators {
+			weight := validator.validator.Weight
+			delegatorIterator := NewTreeIterator(validator.delegators)
+			for delegatorIterator.Next() {
+				var err error
+				weight, err = safemath.Add64(weight, delegatorIterator.Value().Weight)
+				if err != nil {
+					delegatorIterator.Release()
+					return nil, err
+				}
+			}
+			delegatorIterator.Release()
+
+			subnetWeights[nodeID] = weight
+			subnetStakers[nodeID] = validator.validator
+		}
+		expected[subnetID] = subnetWeights
+		validatorStakers[subnetID] = subnetStakers
+	}
+
+	subnetIDs := set.Of(constants.PrimaryNetworkID)
+	for subnetID := range expected {
+		subnetIDs.Add(subnetID)
+	}
+	subnets, err := s.GetSubnets()
+	if err != nil {
+		return nil, err
+	}
+	for _, subnet := range subnets {
+		subnetIDs.Add(subnet.ID())
+	}
+
+	var discrepancies []string
+	for subnetID := range subnetIDs {
+		expectedWeights := expected[subnetID]
+		for nodeID, vdr := range s.validators.GetMap(subnetID) {
+			if _, ok := expectedWeights[nodeID]; ok {
+				continue
+			}
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"validator manager contains %s on subnet %s which isn't a current validator",
+				nodeID,
+				subnetID,
+			))
+			if !repair {
+				continue
+			}
+			if err := s.validators.RemoveWeight(subnetID, nodeID, vdr.Weight); err != nil {
+				return nil, err
+			}
+		}
+
+		for nodeID, expectedWeight := range expectedWeights {
+			weight := s.validators.GetWeight(subnetID, nodeID)
+			if weight == expectedWeight {
+				continue
+			}
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"validator manager reports weight %d for %s on subnet %s rather than %d",
+				weight,
+				nodeID,
+				subnetID,
+				expectedWeight,
+			))
+			if !repair {
+				continue
+			}
+
+			switch {
+			case weight == 0:
+				staker := validatorStakers[subnetID][nodeID]
+				err = s.validators.AddStaker(subnetID, nodeID, staker.PublicKey, staker.TxID, expectedWeight)
+			case weight < expectedWeight:
+				err = s.validators.AddWeight(subnetID, nodeID, expectedWeight-weight)
+			default:
+				err = s.validators.RemoveWeight(subnetID, nodeID, weight-expectedWeight)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !repair || len(discrepancies) == 0 {
+		return discrepancies, nil
+	}
+
+	s.metrics.SetLocalStake(s.validators.GetWeight(constants.PrimaryNetworkID, s.ctx.NodeID))
+	totalWeight, err := s.validators.TotalWeight(constants.PrimaryNetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total weight of primary network validators: %w", err)
+	}
+	s.metrics.SetTotalStake(totalWeight)
+	return discrepancies, nil
+}
+
+// verifySubnetValidatorRemovals checks that every scheduled removal references
+// a current permissioned subnet validator that ends after the removal. If
+// [repair] is true, the invalid removals are deleted.
+func (s *state) verifySubnetValidatorRemovals(repair bool) ([]string, error) {
+	removals, err := s.GetSubnetValidatorRemovals()
+	if err != nil {
+		return nil, err
+	}
+
+	var discrepancies []string
+	for _, removal := range removals {
+		validator, err := s.currentStakers.GetValidator(removal.SubnetID, removal.NodeID)
+		switch {
+		case err == database.ErrNotFound, err == nil && validator.Priority != txs.SubnetPermissionedValidatorCurrentPriority:
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"removal of %s from subnet %s is scheduled but it isn't a current permissioned validator",
+				removal.NodeID,
+				removal.SubnetID,
+			))
+		case err != nil:
+			return nil, err
+		case !removal.Time.Before(validator.EndTime):
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"removal of %s from subnet %s is scheduled at %s which isn't before its end time %s",
+				removal.NodeID,
+				removal.SubnetID,
+				removal.Time,
+				validator.EndTime,
+			))
+		default:
+			continue
+		}
+
+		if repair {
+			s.DeleteSubnetValidatorRemoval(removal.SubnetID, removal.NodeID)
+		}
+	}
+
+	if !repair || len(discrepancies) == 0 {
+		return discrepancies, nil
+	}
+	return discrepancies, s.Commit()
+}
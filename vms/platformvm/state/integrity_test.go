@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestStateVerifyIntegrity(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newInitializedState(require)
+	vdrs := s.(*state).validators
+
+	// The genesis validator was never added to the validator manager.
+	discrepancies, err := s.VerifyIntegrity(context.Background(), false)
+	require.NoError(err)
+	require.Len(discrepancies, 1)
+
+	// Schedule the removal of a node that isn't a subnet validator.
+	var (
+		subnetID = ids.GenerateTestID()
+		nodeID   = ids.GenerateTestNodeID()
+	)
+	s.SetSubnetValidatorRemoval(subnetID, nodeID, initialTime.Add(time.Hour))
+	require.NoError(s.Commit())
+
+	discrepancies, err = s.VerifyIntegrity(context.Background(), false)
+	require.NoError(err)
+	require.Len(discrepancies, 2)
+
+	// Verifying without repairing doesn't modify the state
+	require.Zero(vdrs.GetWeight(constants.PrimaryNetworkID, initialNodeID))
+	_, err = s.GetSubnetValidatorRemoval(subnetID, nodeID)
+	require.NoError(err)
+
+	discrepancies, err = s.VerifyIntegrity(context.Background(), true)
+	require.NoError(err)
+	require.Len(discrepancies, 2)
+
+	require.Equal(units.Avax, vdrs.GetWeight(constants.PrimaryNetworkID, initialNodeID))
+	_, err = s.GetSubnetValidatorRemoval(subnetID, nodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	discrepancies, err = s.VerifyIntegrity(context.Background(), false)
+	require.NoError(err)
+	require.Empty(discrepancies)
+
+	// Weight in the validator manager that isn't backed by a staker is
+	// removed.
+	require.NoError(vdrs.AddWeight(constants.PrimaryNetworkID, initialNodeID, units.Avax))
+
+	discrepancies, err = s.VerifyIntegrity(context.Background(), true)
+	require.NoError(err)
+	require.Len(discrepancies, 1)
+	require.Equal(units.Avax, vdrs.GetWeight(constants.PrimaryNetworkID, initialNodeID))
+}
@@ -0,0 +1,16 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+// MapToSlice returns the result of calling [f] on each of the stakers in [it],
+// in order. [it] is released.
+func MapToSlice[T any](it StakerIterator, f func(*Staker) T) []T {
+	defer it.Release()
+
+	var values []T
+	for it.Next() {
+		values = append(values, f(it.Value()))
+	}
+	return values
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestMapToSlice(t *testing.T) {
+	stakers := []*Staker{
+		{
+			TxID: ids.GenerateTestID(),
+		},
+		{
+			TxID: ids.GenerateTestID(),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		it       StakerIterator
+		expected []ids.ID
+	}{
+		{
+			name:     "empty",
+			it:       EmptyIterator,
+			expected: nil,
+		},
+		{
+			name:     "stakers",
+			it:       NewSliceIterator(stakers...),
+			expected: []ids.ID{stakers[0].TxID, stakers[1].TxID},
+		},
+		{
+			name: "composed iterators",
+			it: NewTakeIterator(
+				NewConcatIterator(
+					NewSliceIterator(stakers[1]),
+					NewSliceIterator(stakers...),
+				),
+				2,
+			),
+			expected: []ids.ID{stakers[1].TxID, stakers[0].TxID},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			txIDs := MapToSlice(test.it, func(s *Staker) ids.ID {
+				return s.TxID
+			})
+			require.Equal(test.expected, txIDs)
+		})
+	}
+}
+
+func TestMapToSliceReleasesIterator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	it := NewMockStakerIterator(ctrl)
+	it.EXPECT().Next().Return(false)
+	it.EXPECT().Release()
+
+	_ = MapToSlice(it, func(s *Staker) *Staker {
+		return s
+	})
+}
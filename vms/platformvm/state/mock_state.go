@@ -9,6 +9,7 @@ package state
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	sync "sync"
 	time "time"
@@ -168,6 +169,18 @@ func (mr *MockChainMockRecorder) DeletePendingValidator(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingValidator", reflect.TypeOf((*MockChain)(nil).DeletePendingValidator), arg0)
 }
 
+// DeleteSubnetValidatorRemoval mocks base method.
+func (m *MockChain) DeleteSubnetValidatorRemoval(arg0 ids.ID, arg1 ids.NodeID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteSubnetValidatorRemoval", arg0, arg1)
+}
+
+// DeleteSubnetValidatorRemoval indicates an expected call of DeleteSubnetValidatorRemoval.
+func (mr *MockChainMockRecorder) DeleteSubnetValidatorRemoval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnetValidatorRemoval", reflect.TypeOf((*MockChain)(nil).DeleteSubnetValidatorRemoval), arg0, arg1)
+}
+
 // DeleteUTXO mocks base method.
 func (m *MockChain) DeleteUTXO(arg0 ids.ID) {
 	m.ctrl.T.Helper()
@@ -195,6 +208,21 @@ func (mr *MockChainMockRecorder) GetCurrentDelegatorIterator(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentDelegatorIterator", reflect.TypeOf((*MockChain)(nil).GetCurrentDelegatorIterator), arg0, arg1)
 }
 
+// GetCurrentNodeStakerIterator mocks base method.
+func (m *MockChain) GetCurrentNodeStakerIterator(arg0 ids.NodeID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentNodeStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentNodeStakerIterator indicates an expected call of GetCurrentNodeStakerIterator.
+func (mr *MockChainMockRecorder) GetCurrentNodeStakerIterator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentNodeStakerIterator", reflect.TypeOf((*MockChain)(nil).GetCurrentNodeStakerIterator), arg0)
+}
+
 // GetCurrentStakerIterator mocks base method.
 func (m *MockChain) GetCurrentStakerIterator() (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -270,6 +298,21 @@ func (mr *MockChainMockRecorder) GetPendingDelegatorIterator(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingDelegatorIterator", reflect.TypeOf((*MockChain)(nil).GetPendingDelegatorIterator), arg0, arg1)
 }
 
+// GetPendingNodeStakerIterator mocks base method.
+func (m *MockChain) GetPendingNodeStakerIterator(arg0 ids.NodeID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingNodeStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingNodeStakerIterator indicates an expected call of GetPendingNodeStakerIterator.
+func (mr *MockChainMockRecorder) GetPendingNodeStakerIterator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNodeStakerIterator", reflect.TypeOf((*MockChain)(nil).GetPendingNodeStakerIterator), arg0)
+}
+
 // GetPendingStakerIterator mocks base method.
 func (m *MockChain) GetPendingStakerIterator() (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -330,6 +373,36 @@ func (mr *MockChainMockRecorder) GetSubnetTransformation(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockChain)(nil).GetSubnetTransformation), arg0)
 }
 
+// GetSubnetValidatorRemoval mocks base method.
+func (m *MockChain) GetSubnetValidatorRemoval(arg0 ids.ID, arg1 ids.NodeID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorRemoval", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorRemoval indicates an expected call of GetSubnetValidatorRemoval.
+func (mr *MockChainMockRecorder) GetSubnetValidatorRemoval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorRemoval", reflect.TypeOf((*MockChain)(nil).GetSubnetValidatorRemoval), arg0, arg1)
+}
+
+// GetSubnetValidatorRemovals mocks base method.
+func (m *MockChain) GetSubnetValidatorRemovals() ([]*SubnetValidatorRemoval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorRemovals")
+	ret0, _ := ret[0].([]*SubnetValidatorRemoval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorRemovals indicates an expected call of GetSubnetValidatorRemovals.
+func (mr *MockChainMockRecorder) GetSubnetValidatorRemovals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorRemovals", reflect.TypeOf((*MockChain)(nil).GetSubnetValidatorRemovals))
+}
+
 // GetTimestamp mocks base method.
 func (m *MockChain) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -461,6 +534,18 @@ func (mr *MockChainMockRecorder) SetSubnetOwner(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockChain)(nil).SetSubnetOwner), arg0, arg1)
 }
 
+// SetSubnetValidatorRemoval mocks base method.
+func (m *MockChain) SetSubnetValidatorRemoval(arg0 ids.ID, arg1 ids.NodeID, arg2 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetValidatorRemoval", arg0, arg1, arg2)
+}
+
+// SetSubnetValidatorRemoval indicates an expected call of SetSubnetValidatorRemoval.
+func (mr *MockChainMockRecorder) SetSubnetValidatorRemoval(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetValidatorRemoval", reflect.TypeOf((*MockChain)(nil).SetSubnetValidatorRemoval), arg0, arg1, arg2)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockChain) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -630,6 +715,18 @@ func (mr *MockDiffMockRecorder) DeletePendingValidator(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingValidator", reflect.TypeOf((*MockDiff)(nil).DeletePendingValidator), arg0)
 }
 
+// DeleteSubnetValidatorRemoval mocks base method.
+func (m *MockDiff) DeleteSubnetValidatorRemoval(arg0 ids.ID, arg1 ids.NodeID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteSubnetValidatorRemoval", arg0, arg1)
+}
+
+// DeleteSubnetValidatorRemoval indicates an expected call of DeleteSubnetValidatorRemoval.
+func (mr *MockDiffMockRecorder) DeleteSubnetValidatorRemoval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnetValidatorRemoval", reflect.TypeOf((*MockDiff)(nil).DeleteSubnetValidatorRemoval), arg0, arg1)
+}
+
 // DeleteUTXO mocks base method.
 func (m *MockDiff) DeleteUTXO(arg0 ids.ID) {
 	m.ctrl.T.Helper()
@@ -657,6 +754,21 @@ func (mr *MockDiffMockRecorder) GetCurrentDelegatorIterator(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentDelegatorIterator", reflect.TypeOf((*MockDiff)(nil).GetCurrentDelegatorIterator), arg0, arg1)
 }
 
+// GetCurrentNodeStakerIterator mocks base method.
+func (m *MockDiff) GetCurrentNodeStakerIterator(arg0 ids.NodeID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentNodeStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentNodeStakerIterator indicates an expected call of GetCurrentNodeStakerIterator.
+func (mr *MockDiffMockRecorder) GetCurrentNodeStakerIterator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentNodeStakerIterator", reflect.TypeOf((*MockDiff)(nil).GetCurrentNodeStakerIterator), arg0)
+}
+
 // GetCurrentStakerIterator mocks base method.
 func (m *MockDiff) GetCurrentStakerIterator() (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -732,6 +844,21 @@ func (mr *MockDiffMockRecorder) GetPendingDelegatorIterator(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingDelegatorIterator", reflect.TypeOf((*MockDiff)(nil).GetPendingDelegatorIterator), arg0, arg1)
 }
 
+// GetPendingNodeStakerIterator mocks base method.
+func (m *MockDiff) GetPendingNodeStakerIterator(arg0 ids.NodeID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingNodeStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingNodeStakerIterator indicates an expected call of GetPendingNodeStakerIterator.
+func (mr *MockDiffMockRecorder) GetPendingNodeStakerIterator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNodeStakerIterator", reflect.TypeOf((*MockDiff)(nil).GetPendingNodeStakerIterator), arg0)
+}
+
 // GetPendingStakerIterator mocks base method.
 func (m *MockDiff) GetPendingStakerIterator() (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -792,6 +919,36 @@ func (mr *MockDiffMockRecorder) GetSubnetTransformation(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockDiff)(nil).GetSubnetTransformation), arg0)
 }
 
+// GetSubnetValidatorRemoval mocks base method.
+func (m *MockDiff) GetSubnetValidatorRemoval(arg0 ids.ID, arg1 ids.NodeID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorRemoval", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorRemoval indicates an expected call of GetSubnetValidatorRemoval.
+func (mr *MockDiffMockRecorder) GetSubnetValidatorRemoval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorRemoval", reflect.TypeOf((*MockDiff)(nil).GetSubnetValidatorRemoval), arg0, arg1)
+}
+
+// GetSubnetValidatorRemovals mocks base method.
+func (m *MockDiff) GetSubnetValidatorRemovals() ([]*SubnetValidatorRemoval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorRemovals")
+	ret0, _ := ret[0].([]*SubnetValidatorRemoval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorRemovals indicates an expected call of GetSubnetValidatorRemovals.
+func (mr *MockDiffMockRecorder) GetSubnetValidatorRemovals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorRemovals", reflect.TypeOf((*MockDiff)(nil).GetSubnetValidatorRemovals))
+}
+
 // GetTimestamp mocks base method.
 func (m *MockDiff) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -923,6 +1080,18 @@ func (mr *MockDiffMockRecorder) SetSubnetOwner(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockDiff)(nil).SetSubnetOwner), arg0, arg1)
 }
 
+// SetSubnetValidatorRemoval mocks base method.
+func (m *MockDiff) SetSubnetValidatorRemoval(arg0 ids.ID, arg1 ids.NodeID, arg2 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetValidatorRemoval", arg0, arg1, arg2)
+}
+
+// SetSubnetValidatorRemoval indicates an expected call of SetSubnetValidatorRemoval.
+func (mr *MockDiffMockRecorder) SetSubnetValidatorRemoval(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetValidatorRemoval", reflect.TypeOf((*MockDiff)(nil).SetSubnetValidatorRemoval), arg0, arg1, arg2)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockDiff) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -1082,6 +1251,20 @@ func (mr *MockStateMockRecorder) ApplyValidatorWeightDiffs(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyValidatorWeightDiffs", reflect.TypeOf((*MockState)(nil).ApplyValidatorWeightDiffs), arg0, arg1, arg2, arg3, arg4)
 }
 
+// Checkpoint mocks base method.
+func (m *MockState) Checkpoint(arg0 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkpoint", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Checkpoint indicates an expected call of Checkpoint.
+func (mr *MockStateMockRecorder) Checkpoint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkpoint", reflect.TypeOf((*MockState)(nil).Checkpoint), arg0)
+}
+
 // Checksum mocks base method.
 func (m *MockState) Checksum() ids.ID {
 	m.ctrl.T.Helper()
@@ -1139,6 +1322,20 @@ func (mr *MockStateMockRecorder) CommitBatch() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBatch", reflect.TypeOf((*MockState)(nil).CommitBatch))
 }
 
+// DatabaseNamespaces mocks base method.
+func (m *MockState) DatabaseNamespaces() map[string]database.Iteratee {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DatabaseNamespaces")
+	ret0, _ := ret[0].(map[string]database.Iteratee)
+	return ret0
+}
+
+// DatabaseNamespaces indicates an expected call of DatabaseNamespaces.
+func (mr *MockStateMockRecorder) DatabaseNamespaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DatabaseNamespaces", reflect.TypeOf((*MockState)(nil).DatabaseNamespaces))
+}
+
 // DeleteCurrentDelegator mocks base method.
 func (m *MockState) DeleteCurrentDelegator(arg0 *Staker) {
 	m.ctrl.T.Helper()
@@ -1187,6 +1384,18 @@ func (mr *MockStateMockRecorder) DeletePendingValidator(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingValidator", reflect.TypeOf((*MockState)(nil).DeletePendingValidator), arg0)
 }
 
+// DeleteSubnetValidatorRemoval mocks base method.
+func (m *MockState) DeleteSubnetValidatorRemoval(arg0 ids.ID, arg1 ids.NodeID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteSubnetValidatorRemoval", arg0, arg1)
+}
+
+// DeleteSubnetValidatorRemoval indicates an expected call of DeleteSubnetValidatorRemoval.
+func (mr *MockStateMockRecorder) DeleteSubnetValidatorRemoval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnetValidatorRemoval", reflect.TypeOf((*MockState)(nil).DeleteSubnetValidatorRemoval), arg0, arg1)
+}
+
 // DeleteUTXO mocks base method.
 func (m *MockState) DeleteUTXO(arg0 ids.ID) {
 	m.ctrl.T.Helper()
@@ -1244,6 +1453,21 @@ func (mr *MockStateMockRecorder) GetCurrentDelegatorIterator(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentDelegatorIterator", reflect.TypeOf((*MockState)(nil).GetCurrentDelegatorIterator), arg0, arg1)
 }
 
+// GetCurrentNodeStakerIterator mocks base method.
+func (m *MockState) GetCurrentNodeStakerIterator(arg0 ids.NodeID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentNodeStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentNodeStakerIterator indicates an expected call of GetCurrentNodeStakerIterator.
+func (mr *MockStateMockRecorder) GetCurrentNodeStakerIterator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentNodeStakerIterator", reflect.TypeOf((*MockState)(nil).GetCurrentNodeStakerIterator), arg0)
+}
+
 // GetCurrentStakerIterator mocks base method.
 func (m *MockState) GetCurrentStakerIterator() (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -1259,6 +1483,20 @@ func (mr *MockStateMockRecorder) GetCurrentStakerIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentStakerIterator", reflect.TypeOf((*MockState)(nil).GetCurrentStakerIterator))
 }
 
+// GetCurrentStakerStats mocks base method.
+func (m *MockState) GetCurrentStakerStats(arg0 ids.ID) StakerStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentStakerStats", arg0)
+	ret0, _ := ret[0].(StakerStats)
+	return ret0
+}
+
+// GetCurrentStakerStats indicates an expected call of GetCurrentStakerStats.
+func (mr *MockStateMockRecorder) GetCurrentStakerStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentStakerStats", reflect.TypeOf((*MockState)(nil).GetCurrentStakerStats), arg0)
+}
+
 // GetCurrentSupply mocks base method.
 func (m *MockState) GetCurrentSupply(arg0 ids.ID) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -1333,6 +1571,21 @@ func (mr *MockStateMockRecorder) GetPendingDelegatorIterator(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingDelegatorIterator", reflect.TypeOf((*MockState)(nil).GetPendingDelegatorIterator), arg0, arg1)
 }
 
+// GetPendingNodeStakerIterator mocks base method.
+func (m *MockState) GetPendingNodeStakerIterator(arg0 ids.NodeID) (StakerIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingNodeStakerIterator", arg0)
+	ret0, _ := ret[0].(StakerIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingNodeStakerIterator indicates an expected call of GetPendingNodeStakerIterator.
+func (mr *MockStateMockRecorder) GetPendingNodeStakerIterator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNodeStakerIterator", reflect.TypeOf((*MockState)(nil).GetPendingNodeStakerIterator), arg0)
+}
+
 // GetPendingStakerIterator mocks base method.
 func (m *MockState) GetPendingStakerIterator() (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -1378,6 +1631,21 @@ func (mr *MockStateMockRecorder) GetRewardUTXOs(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockState)(nil).GetRewardUTXOs), arg0)
 }
 
+// GetStakingTxIDs mocks base method.
+func (m *MockState) GetStakingTxIDs(arg0 ids.ShortID, arg1 ids.ID, arg2 int) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakingTxIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakingTxIDs indicates an expected call of GetStakingTxIDs.
+func (mr *MockStateMockRecorder) GetStakingTxIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakingTxIDs", reflect.TypeOf((*MockState)(nil).GetStakingTxIDs), arg0, arg1, arg2)
+}
+
 // GetStartTime mocks base method.
 func (m *MockState) GetStartTime(arg0 ids.NodeID, arg1 ids.ID) (time.Time, error) {
 	m.ctrl.T.Helper()
@@ -1438,6 +1706,36 @@ func (mr *MockStateMockRecorder) GetSubnetTransformation(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockState)(nil).GetSubnetTransformation), arg0)
 }
 
+// GetSubnetValidatorRemoval mocks base method.
+func (m *MockState) GetSubnetValidatorRemoval(arg0 ids.ID, arg1 ids.NodeID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorRemoval", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorRemoval indicates an expected call of GetSubnetValidatorRemoval.
+func (mr *MockStateMockRecorder) GetSubnetValidatorRemoval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorRemoval", reflect.TypeOf((*MockState)(nil).GetSubnetValidatorRemoval), arg0, arg1)
+}
+
+// GetSubnetValidatorRemovals mocks base method.
+func (m *MockState) GetSubnetValidatorRemovals() ([]*SubnetValidatorRemoval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorRemovals")
+	ret0, _ := ret[0].([]*SubnetValidatorRemoval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorRemovals indicates an expected call of GetSubnetValidatorRemovals.
+func (mr *MockStateMockRecorder) GetSubnetValidatorRemovals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorRemovals", reflect.TypeOf((*MockState)(nil).GetSubnetValidatorRemovals))
+}
+
 // GetSubnets mocks base method.
 func (m *MockState) GetSubnets() ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -1514,6 +1812,35 @@ func (mr *MockStateMockRecorder) GetUptime(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUptime", reflect.TypeOf((*MockState)(nil).GetUptime), arg0, arg1)
 }
 
+// GetValidatorDiffs mocks base method.
+func (m *MockState) GetValidatorDiffs(arg0 context.Context, arg1 ids.ID, arg2, arg3 uint64) ([]*ValidatorDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorDiffs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*ValidatorDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorDiffs indicates an expected call of GetValidatorDiffs.
+func (mr *MockStateMockRecorder) GetValidatorDiffs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorDiffs", reflect.TypeOf((*MockState)(nil).GetValidatorDiffs), arg0, arg1, arg2, arg3)
+}
+
+// IndexStakingHistory mocks base method.
+func (m *MockState) IndexStakingHistory(arg0 sync.Locker, arg1 logging.Logger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexStakingHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexStakingHistory indicates an expected call of IndexStakingHistory.
+func (mr *MockStateMockRecorder) IndexStakingHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexStakingHistory", reflect.TypeOf((*MockState)(nil).IndexStakingHistory), arg0, arg1)
+}
+
 // PruneAndIndex mocks base method.
 func (m *MockState) PruneAndIndex(arg0 sync.Locker, arg1 logging.Logger) error {
 	m.ctrl.T.Helper()
@@ -1638,6 +1965,18 @@ func (mr *MockStateMockRecorder) SetSubnetOwner(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockState)(nil).SetSubnetOwner), arg0, arg1)
 }
 
+// SetSubnetValidatorRemoval mocks base method.
+func (m *MockState) SetSubnetValidatorRemoval(arg0 ids.ID, arg1 ids.NodeID, arg2 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetValidatorRemoval", arg0, arg1, arg2)
+}
+
+// SetSubnetValidatorRemoval indicates an expected call of SetSubnetValidatorRemoval.
+func (mr *MockStateMockRecorder) SetSubnetValidatorRemoval(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetValidatorRemoval", reflect.TypeOf((*MockState)(nil).SetSubnetValidatorRemoval), arg0, arg1, arg2)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockState) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -1664,6 +2003,21 @@ func (mr *MockStateMockRecorder) SetUptime(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUptime", reflect.TypeOf((*MockState)(nil).SetUptime), arg0, arg1, arg2, arg3)
 }
 
+// ShouldIndexStakingHistory mocks base method.
+func (m *MockState) ShouldIndexStakingHistory() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldIndexStakingHistory")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShouldIndexStakingHistory indicates an expected call of ShouldIndexStakingHistory.
+func (mr *MockStateMockRecorder) ShouldIndexStakingHistory() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldIndexStakingHistory", reflect.TypeOf((*MockState)(nil).ShouldIndexStakingHistory))
+}
+
 // ShouldPrune mocks base method.
 func (m *MockState) ShouldPrune() (bool, error) {
 	m.ctrl.T.Helper()
@@ -1694,6 +2048,21 @@ func (mr *MockStateMockRecorder) UTXOIDs(arg0, arg1, arg2 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UTXOIDs", reflect.TypeOf((*MockState)(nil).UTXOIDs), arg0, arg1, arg2)
 }
 
+// VerifyIntegrity mocks base method.
+func (m *MockState) VerifyIntegrity(arg0 context.Context, arg1 bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyIntegrity", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyIntegrity indicates an expected call of VerifyIntegrity.
+func (mr *MockStateMockRecorder) VerifyIntegrity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyIntegrity", reflect.TypeOf((*MockState)(nil).VerifyIntegrity), arg0, arg1)
+}
+
 // MockVersions is a mock of Versions interface.
 type MockVersions struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// DatabaseNamespaces doesn't include the nested validator diffs nor the reward
+// UTXOs, as they are stored in a database per height and per tx respectively.
+func (s *state) DatabaseNamespaces() map[string]database.Iteratee {
+	namespaces := map[string]database.Iteratee{
+		"current_validators":         s.currentValidatorBaseDB,
+		"current_delegators":         s.currentDelegatorBaseDB,
+		"current_subnet_validators":  s.currentSubnetValidatorBaseDB,
+		"current_subnet_delegators":  s.currentSubnetDelegatorBaseDB,
+		"pending_validators":         s.pendingValidatorBaseDB,
+		"pending_delegators":         s.pendingDelegatorBaseDB,
+		"pending_subnet_validators":  s.pendingSubnetValidatorBaseDB,
+		"pending_subnet_delegators":  s.pendingSubnetDelegatorBaseDB,
+		"validator_weight_diffs":     s.flatValidatorWeightDiffsDB,
+		"validator_public_key_diffs": s.flatValidatorPublicKeyDiffsDB,
+		"blocks":                     s.blockDB,
+		"block_ids":                  s.blockIDDB,
+		"txs":                        s.txDB,
+		"staking_history":            s.stakingHistoryDB,
+	}
+	for name, db := range avax.UTXOStateNamespaces(s.utxoDB) {
+		namespaces[name] = db
+	}
+	return namespaces
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import "math/bits"
+
+// StakerStats aggregates the stakers of a subnet.
+type StakerStats struct {
+	NumValidators uint64
+	NumDelegators uint64
+	// ValidatorWeight is the sum of the weights of the validators, excluding
+	// the weight delegated to them
+	ValidatorWeight uint64
+	// DelegatorWeight is the sum of the weights of the delegators
+	DelegatorWeight uint64
+	// WeightHistogram[i] is the number of validators whose weight, excluding
+	// the weight delegated to them, is in [2^i, 2^(i+1))
+	WeightHistogram [64]uint64
+}
+
+// TotalWeight returns the sum of the weights of the validators and
+// delegators.
+func (s *StakerStats) TotalWeight() uint64 {
+	return s.ValidatorWeight + s.DelegatorWeight
+}
+
+// IsEmpty returns true if there are no stakers.
+func (s *StakerStats) IsEmpty() bool {
+	return s.NumValidators == 0 && s.NumDelegators == 0
+}
+
+func (s *StakerStats) addValidator(weight uint64) {
+	s.NumValidators++
+	s.ValidatorWeight += weight
+	if weight > 0 {
+		s.WeightHistogram[weightBucket(weight)]++
+	}
+}
+
+func (s *StakerStats) removeValidator(weight uint64) {
+	s.NumValidators--
+	s.ValidatorWeight -= weight
+	if weight > 0 {
+		s.WeightHistogram[weightBucket(weight)]--
+	}
+}
+
+func (s *StakerStats) addDelegator(weight uint64) {
+	s.NumDelegators++
+	s.DelegatorWeight += weight
+}
+
+func (s *StakerStats) removeDelegator(weight uint64) {
+	s.NumDelegators--
+	s.DelegatorWeight -= weight
+}
+
+// weightBucket returns the histogram bucket of [weight].
+//
+// Assumes [weight] > 0.
+func weightBucket(weight uint64) int {
+	return bits.Len64(weight) - 1
+}
@@ -61,6 +61,11 @@ type CurrentStakers interface {
 	// GetCurrentStakerIterator returns stakers in order of their removal from
 	// the current staker set.
 	GetCurrentStakerIterator() (StakerIterator, error)
+
+	// GetCurrentNodeStakerIterator returns the validators and delegators of
+	// [nodeID] on every subnet, in order of their removal from the current
+	// staker set.
+	GetCurrentNodeStakerIterator(nodeID ids.NodeID) (StakerIterator, error)
 }
 
 type PendingStakers interface {
@@ -93,14 +98,40 @@ type PendingStakers interface {
 	// GetPendingStakerIterator returns stakers in order of their removal from
 	// the pending staker set.
 	GetPendingStakerIterator() (StakerIterator, error)
+
+	// GetPendingNodeStakerIterator returns the validators and delegators of
+	// [nodeID] on every subnet, in order of their removal from the pending
+	// staker set.
+	GetPendingNodeStakerIterator(nodeID ids.NodeID) (StakerIterator, error)
+}
+
+// GetNodeStakerIterator returns the current stakers of [nodeID] on every
+// subnet followed by its pending stakers.
+func GetNodeStakerIterator(stakers Stakers, nodeID ids.NodeID) (StakerIterator, error) {
+	currentStakerIterator, err := stakers.GetCurrentNodeStakerIterator(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	pendingStakerIterator, err := stakers.GetPendingNodeStakerIterator(nodeID)
+	if err != nil {
+		currentStakerIterator.Release()
+		return nil, err
+	}
+	return NewConcatIterator(currentStakerIterator, pendingStakerIterator), nil
 }
 
 type baseStakers struct {
 	// subnetID --> nodeID --> current state for the validator of the subnet
 	validators map[ids.ID]map[ids.NodeID]*baseStaker
-	stakers    *btree.BTreeG[*Staker]
+	// nodeID --> subnetID --> the same entries as [validators], to find the
+	// stakers of a node without iterating over every subnet
+	nodes   map[ids.NodeID]map[ids.ID]*baseStaker
+	stakers *btree.BTreeG[*Staker]
 	// subnetID --> nodeID --> diff for that validator since the last db write
 	validatorDiffs map[ids.ID]map[ids.NodeID]*diffValidator
+	// subnetID --> aggregates of the subnet's stakers, updated as stakers are
+	// added and removed. Subnets without stakers are omitted.
+	stats map[ids.ID]StakerStats
 }
 
 type baseStaker struct {
@@ -111,8 +142,10 @@ type baseStaker struct {
 func newBaseStakers() *baseStakers {
 	return &baseStakers{
 		validators:     make(map[ids.ID]map[ids.NodeID]*baseStaker),
+		nodes:          make(map[ids.NodeID]map[ids.ID]*baseStaker),
 		stakers:        btree.NewG(defaultTreeDegree, (*Staker).Less),
 		validatorDiffs: make(map[ids.ID]map[ids.NodeID]*diffValidator),
+		stats:          make(map[ids.ID]StakerStats),
 	}
 }
 
@@ -132,18 +165,20 @@ func (v *baseStakers) GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 }
 
 func (v *baseStakers) PutValidator(staker *Staker) {
-	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
-	validator.validator = staker
+	v.loadValidator(staker)
 
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
 	validatorDiff.validatorStatus = added
 	validatorDiff.validator = staker
-
-	v.stakers.ReplaceOrInsert(staker)
 }
 
 func (v *baseStakers) DeleteValidator(staker *Staker) {
 	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+	if validator.validator != nil {
+		v.updateStats(staker.SubnetID, func(stats *StakerStats) {
+			stats.removeValidator(validator.validator.Weight)
+		})
+	}
 	validator.validator = nil
 	v.pruneValidator(staker.SubnetID, staker.NodeID)
 
@@ -167,25 +202,23 @@ func (v *baseStakers) GetDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) S
 }
 
 func (v *baseStakers) PutDelegator(staker *Staker) {
-	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
-	if validator.delegators == nil {
-		validator.delegators = btree.NewG(defaultTreeDegree, (*Staker).Less)
-	}
-	validator.delegators.ReplaceOrInsert(staker)
+	v.loadDelegator(staker)
 
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
 	if validatorDiff.addedDelegators == nil {
 		validatorDiff.addedDelegators = btree.NewG(defaultTreeDegree, (*Staker).Less)
 	}
 	validatorDiff.addedDelegators.ReplaceOrInsert(staker)
-
-	v.stakers.ReplaceOrInsert(staker)
 }
 
 func (v *baseStakers) DeleteDelegator(staker *Staker) {
 	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 	if validator.delegators != nil {
-		validator.delegators.Delete(staker)
+		if deleted, ok := validator.delegators.Delete(staker); ok {
+			v.updateStats(staker.SubnetID, func(stats *StakerStats) {
+				stats.removeDelegator(deleted.Weight)
+			})
+		}
 	}
 	v.pruneValidator(staker.SubnetID, staker.NodeID)
 
@@ -202,6 +235,79 @@ func (v *baseStakers) GetStakerIterator() StakerIterator {
 	return NewTreeIterator(v.stakers)
 }
 
+// GetNodeStakerIterator returns the stakers of [nodeID] on every subnet. The
+// cost is proportional to the number of stakers of [nodeID] rather than to the
+// size of the staker set.
+func (v *baseStakers) GetNodeStakerIterator(nodeID ids.NodeID) StakerIterator {
+	nodeValidators, ok := v.nodes[nodeID]
+	if !ok {
+		return EmptyIterator
+	}
+
+	stakers := btree.NewG(defaultTreeDegree, (*Staker).Less)
+	for _, validator := range nodeValidators {
+		if validator.validator != nil {
+			stakers.ReplaceOrInsert(validator.validator)
+		}
+		if validator.delegators != nil {
+			validator.delegators.Ascend(func(delegator *Staker) bool {
+				stakers.ReplaceOrInsert(delegator)
+				return true
+			})
+		}
+	}
+	return NewTreeIterator(stakers)
+}
+
+// loadValidator adds the validator [staker] without recording it in
+// [validatorDiffs].
+func (v *baseStakers) loadValidator(staker *Staker) {
+	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+	v.updateStats(staker.SubnetID, func(stats *StakerStats) {
+		if validator.validator != nil {
+			stats.removeValidator(validator.validator.Weight)
+		}
+		stats.addValidator(staker.Weight)
+	})
+	validator.validator = staker
+
+	v.stakers.ReplaceOrInsert(staker)
+}
+
+// loadDelegator adds the delegator [staker] without recording it in
+// [validatorDiffs].
+func (v *baseStakers) loadDelegator(staker *Staker) {
+	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+	if validator.delegators == nil {
+		validator.delegators = btree.NewG(defaultTreeDegree, (*Staker).Less)
+	}
+	replaced, ok := validator.delegators.ReplaceOrInsert(staker)
+	v.updateStats(staker.SubnetID, func(stats *StakerStats) {
+		if ok {
+			stats.removeDelegator(replaced.Weight)
+		}
+		stats.addDelegator(staker.Weight)
+	})
+
+	v.stakers.ReplaceOrInsert(staker)
+}
+
+// GetStats returns the aggregates of the stakers of [subnetID].
+func (v *baseStakers) GetStats(subnetID ids.ID) StakerStats {
+	return v.stats[subnetID]
+}
+
+// updateStats applies [update] to the aggregates of [subnetID].
+func (v *baseStakers) updateStats(subnetID ids.ID, update func(*StakerStats)) {
+	stats := v.stats[subnetID]
+	update(&stats)
+	if stats.IsEmpty() {
+		delete(v.stats, subnetID)
+		return
+	}
+	v.stats[subnetID] = stats
+}
+
 func (v *baseStakers) getOrCreateValidator(subnetID ids.ID, nodeID ids.NodeID) *baseStaker {
 	subnetValidators, ok := v.validators[subnetID]
 	if !ok {
@@ -212,6 +318,13 @@ func (v *baseStakers) getOrCreateValidator(subnetID ids.ID, nodeID ids.NodeID) *
 	if !ok {
 		validator = &baseStaker{}
 		subnetValidators[nodeID] = validator
+
+		nodeValidators, ok := v.nodes[nodeID]
+		if !ok {
+			nodeValidators = make(map[ids.ID]*baseStaker)
+			v.nodes[nodeID] = nodeValidators
+		}
+		nodeValidators[subnetID] = validator
 	}
 	return validator
 }
@@ -231,6 +344,12 @@ func (v *baseStakers) pruneValidator(subnetID ids.ID, nodeID ids.NodeID) {
 	if len(subnetValidators) == 0 {
 		delete(v.validators, subnetID)
 	}
+
+	nodeValidators := v.nodes[nodeID]
+	delete(nodeValidators, subnetID)
+	if len(nodeValidators) == 0 {
+		delete(v.nodes, nodeID)
+	}
 }
 
 func (v *baseStakers) getOrCreateValidatorDiff(subnetID ids.ID, nodeID ids.NodeID) *diffValidator {
@@ -378,6 +497,38 @@ func (s *diffStakers) GetStakerIterator(parentIterator StakerIterator) StakerIte
 	)
 }
 
+// GetNodeStakerIterator returns the stakers of [nodeID] on every subnet, where
+// [parentIterator] iterates over the stakers of [nodeID] in the parent state.
+func (s *diffStakers) GetNodeStakerIterator(parentIterator StakerIterator, nodeID ids.NodeID) StakerIterator {
+	var addedStakers *btree.BTreeG[*Staker]
+	for _, subnetValidatorDiffs := range s.validatorDiffs {
+		validatorDiff, ok := subnetValidatorDiffs[nodeID]
+		if !ok {
+			continue
+		}
+		if addedStakers == nil {
+			addedStakers = btree.NewG(defaultTreeDegree, (*Staker).Less)
+		}
+		if validatorDiff.validatorStatus == added {
+			addedStakers.ReplaceOrInsert(validatorDiff.validator)
+		}
+		if validatorDiff.addedDelegators != nil {
+			validatorDiff.addedDelegators.Ascend(func(delegator *Staker) bool {
+				addedStakers.ReplaceOrInsert(delegator)
+				return true
+			})
+		}
+	}
+
+	return NewMaskedIterator(
+		NewMergedIterator(
+			parentIterator,
+			NewTreeIterator(addedStakers),
+		),
+		s.deletedStakers,
+	)
+}
+
 func (s *diffStakers) getOrCreateDiff(subnetID ids.ID, nodeID ids.NodeID) *diffValidator {
 	if s.validatorDiffs == nil {
 		s.validatorDiffs = make(map[ids.ID]map[ids.NodeID]*diffValidator)
@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -137,6 +139,84 @@ func TestBaseStakersDelegator(t *testing.T) {
 	assertIteratorsEqual(t, EmptyIterator, delegatorIterator)
 }
 
+func TestBaseStakersStats(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+	staker.Weight = 5
+	otherStaker := newTestStaker()
+	otherStaker.SubnetID = staker.SubnetID
+	otherStaker.Weight = 8
+	delegator := newTestStaker()
+	delegator.SubnetID = staker.SubnetID
+	delegator.NodeID = staker.NodeID
+	delegator.Weight = 3
+
+	v := newBaseStakers()
+	v.PutValidator(staker)
+	v.PutValidator(otherStaker)
+	v.PutDelegator(delegator)
+
+	expectedStats := StakerStats{
+		NumValidators:   2,
+		NumDelegators:   1,
+		ValidatorWeight: 13,
+		DelegatorWeight: 3,
+	}
+	expectedStats.WeightHistogram[2] = 1 // 5 is in [4, 8)
+	expectedStats.WeightHistogram[3] = 1 // 8 is in [8, 16)
+	require.Equal(expectedStats, v.GetStats(staker.SubnetID))
+	require.Equal(uint64(16), expectedStats.TotalWeight())
+
+	v.DeleteDelegator(delegator)
+	v.DeleteValidator(otherStaker)
+
+	expectedStats = StakerStats{
+		NumValidators:   1,
+		ValidatorWeight: 5,
+	}
+	expectedStats.WeightHistogram[2] = 1
+	require.Equal(expectedStats, v.GetStats(staker.SubnetID))
+
+	v.DeleteValidator(staker)
+	require.Empty(v.stats)
+}
+
+func TestBaseStakersNodeStakerIterator(t *testing.T) {
+	require := require.New(t)
+	nodeID := ids.GenerateTestNodeID()
+
+	primaryValidator := newTestStaker()
+	primaryValidator.NodeID = nodeID
+	primaryDelegator := newTestStaker()
+	primaryDelegator.NodeID = nodeID
+	primaryDelegator.SubnetID = primaryValidator.SubnetID
+	subnetValidator := newTestStaker()
+	subnetValidator.NodeID = nodeID
+	otherValidator := newTestStaker()
+
+	v := newBaseStakers()
+	v.PutValidator(primaryValidator)
+	v.PutDelegator(primaryDelegator)
+	v.PutValidator(subnetValidator)
+	v.PutValidator(otherValidator)
+
+	expectedStakers := []*Staker{primaryValidator, primaryDelegator, subnetValidator}
+	slices.SortFunc(expectedStakers, (*Staker).Less)
+	assertIteratorsEqual(t, NewSliceIterator(expectedStakers...), v.GetNodeStakerIterator(nodeID))
+	assertIteratorsEqual(t, NewSliceIterator(otherValidator), v.GetNodeStakerIterator(otherValidator.NodeID))
+	assertIteratorsEqual(t, EmptyIterator, v.GetNodeStakerIterator(ids.GenerateTestNodeID()))
+
+	v.DeleteValidator(primaryValidator)
+	v.DeleteValidator(subnetValidator)
+
+	// The delegator remains until it is removed as well
+	assertIteratorsEqual(t, NewSliceIterator(primaryDelegator), v.GetNodeStakerIterator(nodeID))
+
+	v.DeleteDelegator(primaryDelegator)
+	assertIteratorsEqual(t, EmptyIterator, v.GetNodeStakerIterator(nodeID))
+	require.Len(v.nodes, 1)
+}
+
 func TestDiffStakersValidator(t *testing.T) {
 	require := require.New(t)
 	staker := newTestStaker()
@@ -217,6 +297,54 @@ func TestDiffStakersDelegator(t *testing.T) {
 	assertIteratorsEqual(t, EmptyIterator, delegatorIterator)
 }
 
+func TestDiffStakersNodeStakerIterator(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+
+	parentValidator := newTestStaker()
+	parentValidator.NodeID = nodeID
+	parentDelegator := newTestStaker()
+	parentDelegator.NodeID = nodeID
+	addedValidator := newTestStaker()
+	addedValidator.NodeID = nodeID
+	addedDelegator := newTestStaker()
+	addedDelegator.NodeID = nodeID
+	addedDelegator.SubnetID = addedValidator.SubnetID
+	otherValidator := newTestStaker()
+
+	v := diffStakers{}
+
+	// Without any changes to [nodeID], the parent stakers are returned
+	assertIteratorsEqual(
+		t,
+		NewSliceIterator(parentValidator),
+		v.GetNodeStakerIterator(NewSliceIterator(parentValidator), nodeID),
+	)
+
+	v.PutValidator(addedValidator)
+	v.PutDelegator(addedDelegator)
+	v.PutValidator(otherValidator)
+	v.DeleteDelegator(parentDelegator)
+
+	parentStakers := []*Staker{parentValidator, parentDelegator}
+	slices.SortFunc(parentStakers, (*Staker).Less)
+	expectedStakers := []*Staker{parentValidator, addedValidator, addedDelegator}
+	slices.SortFunc(expectedStakers, (*Staker).Less)
+	assertIteratorsEqual(
+		t,
+		NewSliceIterator(expectedStakers...),
+		v.GetNodeStakerIterator(NewSliceIterator(parentStakers...), nodeID),
+	)
+
+	// Validators created and deleted in the same diff aren't returned
+	v.DeleteValidator(addedValidator)
+	v.DeleteValidator(parentValidator)
+	assertIteratorsEqual(
+		t,
+		NewSliceIterator(addedDelegator),
+		v.GetNodeStakerIterator(NewSliceIterator(parentStakers...), nodeID),
+	)
+}
+
 func newTestStaker() *Staker {
 	startTime := time.Now().Round(time.Second)
 	endTime := startTime.Add(28 * 24 * time.Hour)
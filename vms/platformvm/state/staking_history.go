@@ -0,0 +1,169 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// RewardOwnerAddrs returns the addresses that own the rewards of the staker
+// added by [utx]. Returns an empty set if [utx] doesn't add a staker that can
+// be rewarded.
+func RewardOwnerAddrs(utx txs.UnsignedTx) set.Set[ids.ShortID] {
+	var owners []interface{}
+	switch utx := utx.(type) {
+	case txs.ValidatorTx:
+		owners = []interface{}{
+			utx.ValidationRewardsOwner(),
+			utx.DelegationRewardsOwner(),
+		}
+	case txs.DelegatorTx:
+		owners = []interface{}{
+			utx.RewardsOwner(),
+		}
+	}
+
+	var addrs set.Set[ids.ShortID]
+	for _, owner := range owners {
+		if owner, ok := owner.(*secp256k1fx.OutputOwners); ok {
+			addrs.Add(owner.Addrs...)
+		}
+	}
+	return addrs
+}
+
+// GetStakingTxIDs returns up to [limit] IDs of the committed staker txs whose
+// rewards are owned by [addr]. The IDs are sorted and only include IDs greater
+// than [start].
+func (s *state) GetStakingTxIDs(addr ids.ShortID, start ids.ID, limit int) ([]ids.ID, error) {
+	addrDB := prefixdb.New(addr[:], s.stakingHistoryDB)
+	iter := addrDB.NewIteratorWithStart(start[:])
+	defer iter.Release()
+
+	txIDs := []ids.ID(nil)
+	for len(txIDs) < limit && iter.Next() {
+		txID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if txID == start {
+			continue
+		}
+
+		start = ids.Empty
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, iter.Error()
+}
+
+// putStakingHistory indexes [tx] by the addresses that own its rewards, if it
+// was committed.
+func (s *state) putStakingHistory(tx *txs.Tx, txStatus status.Status) error {
+	if txStatus != status.Committed {
+		return nil
+	}
+
+	txID := tx.ID()
+	for addr := range RewardOwnerAddrs(tx.Unsigned) {
+		addrDB := prefixdb.New(addr[:], s.stakingHistoryDB)
+		if err := addrDB.Put(txID[:], nil); err != nil {
+			return fmt.Errorf("failed to index staking tx: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) ShouldIndexStakingHistory() (bool, error) {
+	has, err := s.singletonDB.Has(stakingHistoryIndexedKey)
+	return !has, err
+}
+
+func (s *state) IndexStakingHistory(lock sync.Locker, log logging.Logger) error {
+	lock.Lock()
+	// Txs that are added after grabbing this iterator are indexed when they
+	// are written, so we don't need to check them.
+	txIterator := s.txDB.NewIterator()
+	lock.Unlock()
+	// Releasing is done using a closure to ensure that updating txIterator
+	// will result in having the most recent iterator released when executing
+	// the deferred function.
+	defer func() {
+		txIterator.Release()
+	}()
+
+	log.Info("starting staking history indexing")
+
+	var (
+		startTime  = time.Now()
+		numScanned = 0
+	)
+	for txIterator.Next() {
+		stx := txBytesAndStatus{}
+		if _, err := txs.GenesisCodec.Unmarshal(txIterator.Value(), &stx); err != nil {
+			return err
+		}
+
+		tx, err := txs.Parse(txs.GenesisCodec, stx.Tx)
+		if err != nil {
+			return err
+		}
+
+		if err := s.putStakingHistory(tx, stx.Status); err != nil {
+			return err
+		}
+
+		numScanned++
+		if numScanned%pruneCommitLimit != 0 {
+			continue
+		}
+
+		// We must hold the lock during committing to make sure we don't
+		// attempt to commit to disk while a block is concurrently being
+		// accepted.
+		lock.Lock()
+		err = s.Commit()
+		lock.Unlock()
+		if err != nil {
+			return err
+		}
+
+		// We release the iterator here to allow the underlying database to
+		// clean up deleted state.
+		txID := tx.ID()
+		txIterator.Release()
+		txIterator = s.txDB.NewIteratorWithStart(txID[:])
+	}
+
+	// Ensure we fully iterated over all txs before writing that indexing has
+	// finished.
+	if err := txIterator.Error(); err != nil {
+		return err
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := s.singletonDB.Put(stakingHistoryIndexedKey, nil); err != nil {
+		return err
+	}
+
+	log.Info("finished staking history indexing",
+		zap.Int("numScanned", numScanned),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+
+	return s.Commit()
+}
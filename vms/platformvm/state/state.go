@@ -7,14 +7,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"sync"
 	"time"
 
-	"github.com/google/btree"
-
 	"go.uber.org/zap"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
@@ -33,6 +34,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -60,6 +62,8 @@ var (
 
 	errValidatorSetAlreadyPopulated = errors.New("validator set already populated")
 	errIsNotSubnet                  = errors.New("is not a subnet")
+	errInvalidHeightRange           = errors.New("start height is greater than end height")
+	errHeightNotIndexed             = errors.New("height is not indexed")
 
 	blockIDPrefix                       = []byte("blockID")
 	blockPrefix                         = []byte("block")
@@ -76,9 +80,11 @@ var (
 	flatValidatorPublicKeyDiffsPrefix   = []byte("flatPublicKeyDiffs")
 	txPrefix                            = []byte("tx")
 	rewardUTXOsPrefix                   = []byte("rewardUTXOs")
+	stakingHistoryPrefix                = []byte("stakingHistory")
 	utxoPrefix                          = []byte("utxo")
 	subnetPrefix                        = []byte("subnet")
 	subnetOwnerPrefix                   = []byte("subnetOwner")
+	subnetValidatorRemovalPrefix        = []byte("subnetValidatorRemoval")
 	transformedSubnetPrefix             = []byte("transformedSubnet")
 	supplyPrefix                        = []byte("supply")
 	chainPrefix                         = []byte("chain")
@@ -90,6 +96,8 @@ var (
 	heightsIndexedKey = []byte("heights indexed")
 	initializedKey    = []byte("initialized")
 	prunedKey         = []byte("pruned")
+
+	stakingHistoryIndexedKey = []byte("staking history indexed")
 )
 
 // Chain collects all methods to manage the state of the chain for block
@@ -113,6 +121,16 @@ type Chain interface {
 	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
 	SetSubnetOwner(subnetID ids.ID, owner fx.Owner)
 
+	// GetSubnetValidatorRemovals returns the scheduled removals of subnet
+	// validators sorted by increasing removal time.
+	GetSubnetValidatorRemovals() ([]*SubnetValidatorRemoval, error)
+	// GetSubnetValidatorRemoval returns the time [nodeID] is scheduled to be
+	// removed as a validator of [subnetID]. Returns [database.ErrNotFound] if
+	// no removal is scheduled.
+	GetSubnetValidatorRemoval(subnetID ids.ID, nodeID ids.NodeID) (time.Time, error)
+	SetSubnetValidatorRemoval(subnetID ids.ID, nodeID ids.NodeID, removalTime time.Time)
+	DeleteSubnetValidatorRemoval(subnetID ids.ID, nodeID ids.NodeID)
+
 	GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error)
 	AddSubnetTransformation(transformSubnetTx *txs.Tx)
 
@@ -138,9 +156,20 @@ type State interface {
 	GetBlockIDAtHeight(height uint64) (ids.ID, error)
 
 	GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error)
+
+	// GetStakingTxIDs returns up to [limit] IDs of the committed staker txs
+	// whose rewards are owned by [addr]. The IDs are sorted and only include
+	// IDs greater than [start].
+	GetStakingTxIDs(addr ids.ShortID, start ids.ID, limit int) ([]ids.ID, error)
+
 	GetSubnets() ([]*txs.Tx, error)
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
 
+	// GetCurrentStakerStats returns the aggregates of the current stakers of
+	// [subnetID]. The aggregates are maintained as stakers are added and
+	// removed, so they don't require iterating over the stakers.
+	GetCurrentStakerStats(subnetID ids.ID) StakerStats
+
 	// ApplyValidatorWeightDiffs iterates from [startHeight] towards the genesis
 	// block until it has applied all of the diffs up to and including
 	// [endHeight]. Applying the diffs modifies [validators].
@@ -178,6 +207,20 @@ type State interface {
 		endHeight uint64,
 	) error
 
+	// GetValidatorDiffs returns the validator weight changes of [subnetID]
+	// that were applied by the blocks in [startHeight, endHeight]. The diffs
+	// are sorted by increasing height. Returns database.ErrNotFound if
+	// [subnetID] doesn't exist.
+	//
+	// Invariant: All heights in [startHeight, endHeight] must have been
+	// accepted after the flat diff index was populated.
+	GetValidatorDiffs(
+		ctx context.Context,
+		subnetID ids.ID,
+		startHeight uint64,
+		endHeight uint64,
+	) ([]*ValidatorDiff, error)
+
 	SetHeight(height uint64)
 
 	// Discard uncommitted changes to the database.
@@ -195,6 +238,15 @@ type State interface {
 	// TODO: Remove after v1.11.x is activated
 	PruneAndIndex(sync.Locker, logging.Logger) error
 
+	// Returns if the staker txs committed before the staking history index
+	// was introduced still need to be indexed.
+	ShouldIndexStakingHistory() (bool, error)
+
+	// Indexes the staker txs committed before the staking history index was
+	// introduced by the addresses that own their rewards. This function
+	// supports being (and is recommended to be) called asynchronously.
+	IndexStakingHistory(sync.Locker, logging.Logger) error
+
 	// Commit changes to the base database.
 	Commit() error
 
@@ -202,8 +254,27 @@ type State interface {
 	// pending changes to the base database.
 	CommitBatch() (database.Batch, error)
 
+	// Checkpoint writes the current state to [w] in a format that can be
+	// restored with [RestoreFromCheckpoint].
+	//
+	// Invariant: There are no uncommitted changes.
+	Checkpoint(w io.Writer) error
+
 	Checksum() ids.ID
 
+	// VerifyIntegrity checks the consistency of the staker indexes with the
+	// txs, the UTXO set, and the validator manager. A description of every
+	// discrepancy found is returned. If [repair] is true, the validator
+	// manager and the scheduled subnet validator removals are updated to
+	// resolve the discrepancies.
+	//
+	// Invariant: There are no uncommitted changes.
+	VerifyIntegrity(ctx context.Context, repair bool) ([]string, error)
+
+	// DatabaseNamespaces returns the databases of the staker indexes, the
+	// validator diffs, the blocks, the txs, and the UTXOs, keyed by name.
+	DatabaseNamespaces() map[string]database.Iteratee
+
 	Close() error
 }
 
@@ -265,6 +336,9 @@ type stateBlk struct {
  * | '-. txID
  * |   '-. list
  * |     '-- utxoID -> utxo bytes
+ * |-. stakingHistory
+ * | '-. addr
+ * |   '-- txID -> nil
  * |- utxos
  * | '-- utxoDB
  * |-. subnets
@@ -272,6 +346,8 @@ type stateBlk struct {
  * |   '-- txID -> nil
  * |-. subnetOwners
  * | '-. subnetID -> owner
+ * |-. subnetValidatorRemovals
+ * | '-. subnetID + nodeID -> removal time
  * |-. chains
  * | '-. subnetID
  * |   '-. list
@@ -279,6 +355,7 @@ type stateBlk struct {
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- prunedKey -> nil
+ *   |-- stakingHistoryIndexedKey -> nil
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
  *   |-- lastAcceptedKey -> lastAccepted
@@ -340,6 +417,8 @@ type state struct {
 	rewardUTXOsCache cache.Cacher[ids.ID, []*avax.UTXO] // txID -> []*UTXO
 	rewardUTXODB     database.Database
 
+	stakingHistoryDB database.Database // addr || txID -> nil
+
 	modifiedUTXOs map[ids.ID]*avax.UTXO // map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
 	utxoDB        database.Database
 	utxoState     avax.UTXOState
@@ -354,6 +433,12 @@ type state struct {
 	subnetOwnerCache cache.Cacher[ids.ID, fxOwnerAndSize] // cache of subnetID -> owner if the entry is nil, it is not in the database
 	subnetOwnerDB    database.Database
 
+	// Subnet ID --> Node ID --> time the validator is scheduled to be removed
+	subnetValidatorRemovals map[ids.ID]map[ids.NodeID]time.Time
+	// Subnet ID --> Node IDs whose scheduled removal was modified
+	modifiedSubnetValidatorRemovals map[ids.ID]set.Set[ids.NodeID]
+	subnetValidatorRemovalDB        database.Database
+
 	transformedSubnets     map[ids.ID]*txs.Tx            // map of subnetID -> transformSubnetTx
 	transformedSubnetCache cache.Cacher[ids.ID, *txs.Tx] // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
 	transformedSubnetDB    database.Database
@@ -407,6 +492,41 @@ func (v *ValidatorWeightDiff) Add(negative bool, amount uint64) error {
 	return nil
 }
 
+// ValidatorDiffStatus describes how a validator's membership in a validator
+// set was modified by a block.
+type ValidatorDiffStatus byte
+
+const (
+	ValidatorWeightChanged ValidatorDiffStatus = iota
+	ValidatorAdded
+	ValidatorRemoved
+)
+
+func (s ValidatorDiffStatus) String() string {
+	switch s {
+	case ValidatorWeightChanged:
+		return "weightChanged"
+	case ValidatorAdded:
+		return "added"
+	case ValidatorRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// ValidatorDiff describes the change of a validator's weight at a single
+// height.
+type ValidatorDiff struct {
+	Height uint64
+	NodeID ids.NodeID
+	Status ValidatorDiffStatus
+	// PrevWeight is the weight of the validator prior to [Height].
+	PrevWeight uint64
+	// Weight is the weight of the validator after [Height] was accepted.
+	Weight uint64
+}
+
 type heightWithSubnet struct {
 	Height   uint64 `serialize:"true"`
 	SubnetID ids.ID `serialize:"true"`
@@ -677,6 +797,8 @@ func newState(
 		rewardUTXODB:     rewardUTXODB,
 		rewardUTXOsCache: rewardUTXOsCache,
 
+		stakingHistoryDB: prefixdb.New(stakingHistoryPrefix, baseDB),
+
 		modifiedUTXOs: make(map[ids.ID]*avax.UTXO),
 		utxoDB:        utxoDB,
 		utxoState:     utxoState,
@@ -688,6 +810,10 @@ func newState(
 		subnetOwnerDB:    subnetOwnerDB,
 		subnetOwnerCache: subnetOwnerCache,
 
+		subnetValidatorRemovals:         make(map[ids.ID]map[ids.NodeID]time.Time),
+		modifiedSubnetValidatorRemovals: make(map[ids.ID]set.Set[ids.NodeID]),
+		subnetValidatorRemovalDB:        prefixdb.New(subnetValidatorRemovalPrefix, baseDB),
+
 		transformedSubnets:     make(map[ids.ID]*txs.Tx),
 		transformedSubnetCache: transformedSubnetCache,
 		transformedSubnetDB:    prefixdb.New(transformedSubnetPrefix, baseDB),
@@ -733,6 +859,14 @@ func (s *state) GetCurrentStakerIterator() (StakerIterator, error) {
 	return s.currentStakers.GetStakerIterator(), nil
 }
 
+func (s *state) GetCurrentNodeStakerIterator(nodeID ids.NodeID) (StakerIterator, error) {
+	return s.currentStakers.GetNodeStakerIterator(nodeID), nil
+}
+
+func (s *state) GetCurrentStakerStats(subnetID ids.ID) StakerStats {
+	return s.currentStakers.GetStats(subnetID)
+}
+
 func (s *state) GetPendingValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
 	return s.pendingStakers.GetValidator(subnetID, nodeID)
 }
@@ -761,6 +895,10 @@ func (s *state) GetPendingStakerIterator() (StakerIterator, error) {
 	return s.pendingStakers.GetStakerIterator(), nil
 }
 
+func (s *state) GetPendingNodeStakerIterator(nodeID ids.NodeID) (StakerIterator, error) {
+	return s.pendingStakers.GetNodeStakerIterator(nodeID), nil
+}
+
 func (s *state) shouldInit() (bool, error) {
 	has, err := s.singletonDB.Has(initializedKey)
 	return !has, err
@@ -886,6 +1024,63 @@ func (s *state) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
 	s.subnetOwners[subnetID] = owner
 }
 
+func (s *state) GetSubnetValidatorRemovals() ([]*SubnetValidatorRemoval, error) {
+	var removals []*SubnetValidatorRemoval
+	for subnetID, subnetRemovals := range s.subnetValidatorRemovals {
+		for nodeID, removalTime := range subnetRemovals {
+			removals = append(removals, &SubnetValidatorRemoval{
+				SubnetID: subnetID,
+				NodeID:   nodeID,
+				Time:     removalTime,
+			})
+		}
+	}
+	utils.Sort(removals)
+	return removals, nil
+}
+
+func (s *state) GetSubnetValidatorRemoval(subnetID ids.ID, nodeID ids.NodeID) (time.Time, error) {
+	removalTime, ok := s.subnetValidatorRemovals[subnetID][nodeID]
+	if !ok {
+		return time.Time{}, database.ErrNotFound
+	}
+	return removalTime, nil
+}
+
+func (s *state) SetSubnetValidatorRemoval(subnetID ids.ID, nodeID ids.NodeID, removalTime time.Time) {
+	subnetRemovals, ok := s.subnetValidatorRemovals[subnetID]
+	if !ok {
+		subnetRemovals = make(map[ids.NodeID]time.Time)
+		s.subnetValidatorRemovals[subnetID] = subnetRemovals
+	}
+	subnetRemovals[nodeID] = removalTime
+	s.markSubnetValidatorRemovalModified(subnetID, nodeID)
+}
+
+func (s *state) DeleteSubnetValidatorRemoval(subnetID ids.ID, nodeID ids.NodeID) {
+	subnetRemovals, ok := s.subnetValidatorRemovals[subnetID]
+	if !ok {
+		return
+	}
+	if _, ok := subnetRemovals[nodeID]; !ok {
+		return
+	}
+	delete(subnetRemovals, nodeID)
+	if len(subnetRemovals) == 0 {
+		delete(s.subnetValidatorRemovals, subnetID)
+	}
+	s.markSubnetValidatorRemovalModified(subnetID, nodeID)
+}
+
+func (s *state) markSubnetValidatorRemovalModified(subnetID ids.ID, nodeID ids.NodeID) {
+	modifiedNodeIDs, ok := s.modifiedSubnetValidatorRemovals[subnetID]
+	if !ok {
+		modifiedNodeIDs = set.NewSet[ids.NodeID](1)
+		s.modifiedSubnetValidatorRemovals[subnetID] = modifiedNodeIDs
+	}
+	modifiedNodeIDs.Add(nodeID)
+}
+
 func (s *state) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 	if tx, exists := s.transformedSubnets[subnetID]; exists {
 		return tx, nil
@@ -1138,7 +1333,15 @@ func (s *state) ApplyValidatorWeightDiffs(
 	endHeight uint64,
 	subnetID ids.ID,
 ) error {
-	diffIter := s.flatValidatorWeightDiffsDB.NewIteratorWithStartAndPrefix(
+	// The diffs are read from a snapshot so that diffs written during the
+	// iteration are not observed.
+	snapshot, err := database.NewSnapshot(s.flatValidatorWeightDiffsDB)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	diffIter := snapshot.NewIteratorWithStartAndPrefix(
 		marshalStartDiffKey(subnetID, startHeight),
 		subnetID[:],
 	)
@@ -1262,7 +1465,13 @@ func (s *state) ApplyValidatorPublicKeyDiffs(
 	startHeight uint64,
 	endHeight uint64,
 ) error {
-	diffIter := s.flatValidatorPublicKeyDiffsDB.NewIteratorWithStartAndPrefix(
+	snapshot, err := database.NewSnapshot(s.flatValidatorPublicKeyDiffsDB)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	diffIter := snapshot.NewIteratorWithStartAndPrefix(
 		marshalStartDiffKey(constants.PrimaryNetworkID, startHeight),
 		constants.PrimaryNetworkID[:],
 	)
@@ -1305,6 +1514,129 @@ func (s *state) ApplyValidatorPublicKeyDiffs(
 	return diffIter.Error()
 }
 
+func (s *state) GetValidatorDiffs(
+	ctx context.Context,
+	subnetID ids.ID,
+	startHeight uint64,
+	endHeight uint64,
+) ([]*ValidatorDiff, error) {
+	if startHeight > endHeight {
+		return nil, fmt.Errorf("%w: %d > %d", errInvalidHeightRange, startHeight, endHeight)
+	}
+	if s.indexedHeights == nil {
+		return nil, fmt.Errorf("%w: %d", errHeightNotIndexed, startHeight)
+	}
+	if startHeight < s.indexedHeights.LowerBound || endHeight > s.indexedHeights.UpperBound {
+		return nil, fmt.Errorf("%w: [%d, %d] is not in [%d, %d]",
+			errHeightNotIndexed,
+			startHeight,
+			endHeight,
+			s.indexedHeights.LowerBound,
+			s.indexedHeights.UpperBound,
+		)
+	}
+
+	// The current validator set of a subnet that doesn't exist is unknown, so
+	// its diffs can't be walked.
+	if subnetID != constants.PrimaryNetworkID {
+		if err := s.verifySubnetExists(subnetID); err != nil {
+			return nil, err
+		}
+	}
+
+	// To classify each diff, we walk backwards from the current validator set
+	// so that the weight of every validator is known on both sides of each
+	// diff.
+	currentHeight := s.indexedHeights.UpperBound
+	vdrs := s.validators.GetMap(subnetID)
+
+	snapshot, err := database.NewSnapshot(s.flatValidatorWeightDiffsDB)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Release()
+
+	diffIter := snapshot.NewIteratorWithStartAndPrefix(
+		marshalStartDiffKey(subnetID, currentHeight),
+		subnetID[:],
+	)
+	defer diffIter.Release()
+
+	var diffs []*ValidatorDiff
+	for diffIter.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		_, parsedHeight, nodeID, err := unmarshalDiffKey(diffIter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if parsedHeight < startHeight {
+			break
+		}
+
+		weightDiff, err := unmarshalWeightDiff(diffIter.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		var weight uint64
+		if vdr, ok := vdrs[nodeID]; ok {
+			weight = vdr.Weight
+		}
+		if err := applyWeightDiff(vdrs, nodeID, weightDiff); err != nil {
+			return nil, err
+		}
+		if parsedHeight > endHeight {
+			continue
+		}
+
+		var prevWeight uint64
+		if vdr, ok := vdrs[nodeID]; ok {
+			prevWeight = vdr.Weight
+		}
+
+		diff := &ValidatorDiff{
+			Height:     parsedHeight,
+			NodeID:     nodeID,
+			Status:     ValidatorWeightChanged,
+			PrevWeight: prevWeight,
+			Weight:     weight,
+		}
+		switch {
+		case prevWeight == 0:
+			diff.Status = ValidatorAdded
+		case weight == 0:
+			diff.Status = ValidatorRemoved
+		}
+		diffs = append(diffs, diff)
+	}
+	if err := diffIter.Error(); err != nil {
+		return nil, err
+	}
+
+	// The diffs were iterated in order of decreasing height, so the heights
+	// are reversed here. Diffs at the same height remain sorted by nodeID.
+	slices.SortStableFunc(diffs, func(a, b *ValidatorDiff) bool {
+		return a.Height < b.Height
+	})
+	return diffs, nil
+}
+
+// verifySubnetExists returns database.ErrNotFound if [subnetID] wasn't created
+// by a CreateSubnetTx.
+func (s *state) verifySubnetExists(subnetID ids.ID) error {
+	tx, _, err := s.GetTx(subnetID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch subnet %s: %w", subnetID, err)
+	}
+	if _, ok := tx.Unsigned.(*txs.CreateSubnetTx); !ok {
+		return fmt.Errorf("%w: %s %w", database.ErrNotFound, subnetID, errIsNotSubnet)
+	}
+	return nil
+}
+
 func (s *state) syncGenesis(genesisBlk block.Block, genesis *genesis.Genesis) error {
 	genesisBlkID := genesisBlk.ID()
 	s.SetLastAccepted(genesisBlkID)
@@ -1380,6 +1712,7 @@ func (s *state) load() error {
 		s.loadMetadata(),
 		s.loadCurrentValidators(),
 		s.loadPendingValidators(),
+		s.loadSubnetValidatorRemovals(),
 		s.initValidatorSets(),
 	)
 }
@@ -1471,10 +1804,7 @@ func (s *state) loadCurrentValidators() error {
 			return err
 		}
 
-		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
-		validator.validator = staker
-
-		s.currentStakers.stakers.ReplaceOrInsert(staker)
+		s.currentStakers.loadValidator(staker)
 
 		s.validatorState.LoadValidatorMetadata(staker.NodeID, staker.SubnetID, metadata)
 	}
@@ -1512,10 +1842,7 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
-		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
-		validator.validator = staker
-
-		s.currentStakers.stakers.ReplaceOrInsert(staker)
+		s.currentStakers.loadValidator(staker)
 
 		s.validatorState.LoadValidatorMetadata(staker.NodeID, staker.SubnetID, metadata)
 	}
@@ -1556,13 +1883,7 @@ func (s *state) loadCurrentValidators() error {
 				return err
 			}
 
-			validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
-			if validator.delegators == nil {
-				validator.delegators = btree.NewG(defaultTreeDegree, (*Staker).Less)
-			}
-			validator.delegators.ReplaceOrInsert(staker)
-
-			s.currentStakers.stakers.ReplaceOrInsert(staker)
+			s.currentStakers.loadDelegator(staker)
 		}
 	}
 
@@ -1605,10 +1926,7 @@ func (s *state) loadPendingValidators() error {
 				return err
 			}
 
-			validator := s.pendingStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
-			validator.validator = staker
-
-			s.pendingStakers.stakers.ReplaceOrInsert(staker)
+			s.pendingStakers.loadValidator(staker)
 		}
 	}
 
@@ -1640,13 +1958,7 @@ func (s *state) loadPendingValidators() error {
 				return err
 			}
 
-			validator := s.pendingStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
-			if validator.delegators == nil {
-				validator.delegators = btree.NewG(defaultTreeDegree, (*Staker).Less)
-			}
-			validator.delegators.ReplaceOrInsert(staker)
-
-			s.pendingStakers.stakers.ReplaceOrInsert(staker)
+			s.pendingStakers.loadDelegator(staker)
 		}
 	}
 
@@ -1658,6 +1970,30 @@ func (s *state) loadPendingValidators() error {
 	)
 }
 
+func (s *state) loadSubnetValidatorRemovals() error {
+	it := s.subnetValidatorRemovalDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		subnetID, nodeID, err := parseSubnetValidatorRemovalKey(it.Key())
+		if err != nil {
+			return err
+		}
+		removalTime, err := database.ParseTimestamp(it.Value())
+		if err != nil {
+			return err
+		}
+
+		subnetRemovals, ok := s.subnetValidatorRemovals[subnetID]
+		if !ok {
+			subnetRemovals = make(map[ids.NodeID]time.Time)
+			s.subnetValidatorRemovals[subnetID] = subnetRemovals
+		}
+		subnetRemovals[nodeID] = removalTime
+	}
+	return it.Error()
+}
+
 // Invariant: initValidatorSets requires loadCurrentValidators to have already
 // been called.
 func (s *state) initValidatorSets() error {
@@ -1705,6 +2041,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		s.writeUTXOs(),
 		s.writeSubnets(),
 		s.writeSubnetOwners(),
+		s.writeSubnetValidatorRemovals(),
 		s.writeTransformedSubnets(),
 		s.writeSubnetSupplies(),
 		s.writeChains(),
@@ -1727,8 +2064,10 @@ func (s *state) Close() error {
 		s.validatorsDB.Close(),
 		s.txDB.Close(),
 		s.rewardUTXODB.Close(),
+		s.stakingHistoryDB.Close(),
 		s.utxoDB.Close(),
 		s.subnetBaseDB.Close(),
+		s.subnetValidatorRemovalDB.Close(),
 		s.transformedSubnetDB.Close(),
 		s.supplyDB.Close(),
 		s.chainDB.Close(),
@@ -1789,6 +2128,11 @@ func (s *state) init(genesisBytes []byte) error {
 		return err
 	}
 
+	// The txs of a new chain are indexed as they are written.
+	if err := s.singletonDB.Put(stakingHistoryIndexedKey, nil); err != nil {
+		return err
+	}
+
 	return s.Commit()
 }
 
@@ -2238,6 +2582,9 @@ func (s *state) writeTXs() error {
 		if err := s.txDB.Put(txID[:], txBytes); err != nil {
 			return fmt.Errorf("failed to add tx: %w", err)
 		}
+		if err := s.putStakingHistory(txStatus.tx, txStatus.status); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -2315,6 +2662,27 @@ func (s *state) writeSubnetOwners() error {
 	return nil
 }
 
+func (s *state) writeSubnetValidatorRemovals() error {
+	for subnetID, nodeIDs := range s.modifiedSubnetValidatorRemovals {
+		delete(s.modifiedSubnetValidatorRemovals, subnetID)
+
+		for nodeID := range nodeIDs {
+			key := subnetValidatorRemovalKey(subnetID, nodeID)
+			removalTime, ok := s.subnetValidatorRemovals[subnetID][nodeID]
+			if !ok {
+				if err := s.subnetValidatorRemovalDB.Delete(key); err != nil {
+					return fmt.Errorf("failed to delete subnet validator removal: %w", err)
+				}
+				continue
+			}
+			if err := database.PutTimestamp(s.subnetValidatorRemovalDB, key, removalTime); err != nil {
+				return fmt.Errorf("failed to write subnet validator removal: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
 func (s *state) writeTransformedSubnets() error {
 	for subnetID, tx := range s.transformedSubnets {
 		txID := tx.ID()
@@ -6,6 +6,7 @@ package state
 import (
 	"context"
 	"math"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,12 +18,14 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -32,6 +35,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
@@ -551,6 +555,106 @@ func requireEqualPublicKeysValidatorSet(
 	}
 }
 
+func TestStateGetValidatorDiffs(t *testing.T) {
+	require := require.New(t)
+
+	state, _ := newInitializedState(require)
+
+	var (
+		createSubnetTx = &txs.Tx{
+			Unsigned: &txs.CreateSubnetTx{
+				Owner: &secp256k1fx.OutputOwners{},
+			},
+		}
+		subnetID  = createSubnetTx.ID()
+		startTime = time.Now()
+		endTime   = startTime.Add(24 * time.Hour)
+		validator = Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    ids.GenerateTestNodeID(),
+			SubnetID:  subnetID,
+			Weight:    1,
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
+		delegator = Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    validator.NodeID,
+			SubnetID:  subnetID,
+			Weight:    5,
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
+	)
+
+	state.AddSubnet(createSubnetTx)
+	state.AddTx(createSubnetTx, status.Committed)
+	state.PutCurrentValidator(&validator)
+	state.SetHeight(1)
+	require.NoError(state.Commit())
+
+	state.PutCurrentDelegator(&delegator)
+	state.SetHeight(2)
+	require.NoError(state.Commit())
+
+	// Height 3 doesn't modify the validator set.
+	state.SetHeight(3)
+	require.NoError(state.Commit())
+
+	state.DeleteCurrentDelegator(&delegator)
+	state.DeleteCurrentValidator(&validator)
+	state.SetHeight(4)
+	require.NoError(state.Commit())
+
+	diffs, err := state.GetValidatorDiffs(context.Background(), subnetID, 1, 4)
+	require.NoError(err)
+	require.Equal(
+		[]*ValidatorDiff{
+			{
+				Height:     1,
+				NodeID:     validator.NodeID,
+				Status:     ValidatorAdded,
+				PrevWeight: 0,
+				Weight:     1,
+			},
+			{
+				Height:     2,
+				NodeID:     validator.NodeID,
+				Status:     ValidatorWeightChanged,
+				PrevWeight: 1,
+				Weight:     6,
+			},
+			{
+				Height:     4,
+				NodeID:     validator.NodeID,
+				Status:     ValidatorRemoved,
+				PrevWeight: 6,
+				Weight:     0,
+			},
+		},
+		diffs,
+	)
+
+	diffs, err = state.GetValidatorDiffs(context.Background(), subnetID, 2, 3)
+	require.NoError(err)
+	require.Len(diffs, 1)
+	require.Equal(uint64(2), diffs[0].Height)
+
+	_, err = state.GetValidatorDiffs(context.Background(), subnetID, 3, 2)
+	require.ErrorIs(err, errInvalidHeightRange)
+
+	_, err = state.GetValidatorDiffs(context.Background(), subnetID, 1, 5)
+	require.ErrorIs(err, errHeightNotIndexed)
+
+	_, err = state.GetValidatorDiffs(context.Background(), ids.GenerateTestID(), 1, 4)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The diffs of the primary network are walked back from the primary
+	// network validator set.
+	_, err = state.GetValidatorDiffs(context.Background(), constants.PrimaryNetworkID, 1, 4)
+	require.NoError(err)
+}
+
 func TestParsedStateBlock(t *testing.T) {
 	require := require.New(t)
 
@@ -692,3 +796,157 @@ func TestStateSubnetOwner(t *testing.T) {
 	require.NoError(err)
 	require.Equal(owner2, owner)
 }
+
+func TestStateSubnetValidatorRemovals(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+
+	var (
+		subnetID    = ids.GenerateTestID()
+		nodeID1     = ids.GenerateTestNodeID()
+		nodeID2     = ids.GenerateTestNodeID()
+		removalTime = initialTime.Add(time.Hour)
+	)
+
+	_, err := s.GetSubnetValidatorRemoval(subnetID, nodeID1)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	s.SetSubnetValidatorRemoval(subnetID, nodeID1, removalTime.Add(time.Second))
+	s.SetSubnetValidatorRemoval(subnetID, nodeID2, removalTime)
+
+	removals, err := s.GetSubnetValidatorRemovals()
+	require.NoError(err)
+	require.Equal(
+		[]*SubnetValidatorRemoval{
+			{
+				SubnetID: subnetID,
+				NodeID:   nodeID2,
+				Time:     removalTime,
+			},
+			{
+				SubnetID: subnetID,
+				NodeID:   nodeID1,
+				Time:     removalTime.Add(time.Second),
+			},
+		},
+		removals,
+	)
+
+	s.DeleteSubnetValidatorRemoval(subnetID, nodeID2)
+	require.NoError(s.Commit())
+
+	// Removals are loaded from disk
+	reloadedState := newStateFromDB(require, db)
+	require.NoError(reloadedState.(*state).loadSubnetValidatorRemovals())
+
+	scheduledTime, err := reloadedState.GetSubnetValidatorRemoval(subnetID, nodeID1)
+	require.NoError(err)
+	require.Equal(removalTime.Add(time.Second).Unix(), scheduledTime.Unix())
+
+	_, err = reloadedState.GetSubnetValidatorRemoval(subnetID, nodeID2)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStateDatabaseNamespaces(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newInitializedState(require)
+
+	// Namespaces that aren't listed are expected to be empty
+	expectedNumKeys := map[string]uint64{
+		"current_validators":     2, // The validator and the head of the list
+		"validator_weight_diffs": 1,
+		"utxos":                  1,
+		"blocks":                 1,
+		"block_ids":              1,
+		"txs":                    2,
+	}
+	for name, db := range s.DatabaseNamespaces() {
+		usage, err := prefixdb.ComputeUsage(context.Background(), db, 0)
+		require.NoError(err)
+		require.Equal(expectedNumKeys[name], usage.NumKeys, name)
+	}
+}
+
+func TestStateStakingHistory(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newInitializedState(require)
+
+	var (
+		rewardAddr   = ids.GenerateTestShortID()
+		rewardsOwner = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardAddr},
+		}
+		stakeOuts = []*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{ID: initialTxID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: units.Avax,
+				},
+			},
+		}
+		validator = txs.Validator{
+			NodeID: initialNodeID,
+			Start:  uint64(initialTime.Unix()),
+			End:    uint64(initialValidatorEndTime.Unix()),
+			Wght:   units.Avax,
+		}
+	)
+
+	delegatorTx := &txs.Tx{Unsigned: &txs.AddDelegatorTx{
+		Validator:              validator,
+		StakeOuts:              stakeOuts,
+		DelegationRewardsOwner: rewardsOwner,
+	}}
+	require.NoError(delegatorTx.Initialize(txs.Codec))
+
+	// Aborted staker txs aren't indexed
+	abortedValidatorTx := &txs.Tx{Unsigned: &txs.AddValidatorTx{
+		Validator:        validator,
+		StakeOuts:        stakeOuts,
+		RewardsOwner:     rewardsOwner,
+		DelegationShares: reward.PercentDenominator,
+	}}
+	require.NoError(abortedValidatorTx.Initialize(txs.Codec))
+
+	s.AddTx(delegatorTx, status.Committed)
+	s.AddTx(abortedValidatorTx, status.Aborted)
+	require.NoError(s.Commit())
+
+	txIDs, err := s.GetStakingTxIDs(rewardAddr, ids.Empty, math.MaxInt)
+	require.NoError(err)
+	require.Equal([]ids.ID{delegatorTx.ID()}, txIDs)
+
+	txIDs, err = s.GetStakingTxIDs(rewardAddr, delegatorTx.ID(), math.MaxInt)
+	require.NoError(err)
+	require.Empty(txIDs)
+
+	// Txs that were committed before the index was introduced are indexed by
+	// IndexStakingHistory
+	delegatorTxID := delegatorTx.ID()
+	stateImpl := s.(*state)
+	require.NoError(prefixdb.New(rewardAddr[:], stateImpl.stakingHistoryDB).Delete(delegatorTxID[:]))
+	require.NoError(stateImpl.singletonDB.Delete(stakingHistoryIndexedKey))
+	require.NoError(s.Commit())
+
+	shouldIndex, err := s.ShouldIndexStakingHistory()
+	require.NoError(err)
+	require.True(shouldIndex)
+
+	txIDs, err = s.GetStakingTxIDs(rewardAddr, ids.Empty, math.MaxInt)
+	require.NoError(err)
+	require.Empty(txIDs)
+
+	require.NoError(s.IndexStakingHistory(&sync.Mutex{}, logging.NoLog{}))
+
+	txIDs, err = s.GetStakingTxIDs(rewardAddr, ids.Empty, math.MaxInt)
+	require.NoError(err)
+	require.Equal([]ids.ID{delegatorTxID}, txIDs)
+
+	shouldIndex, err = s.ShouldIndexStakingHistory()
+	require.NoError(err)
+	require.False(shouldIndex)
+}
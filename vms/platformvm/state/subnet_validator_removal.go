@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+var (
+	_ utils.Sortable[*SubnetValidatorRemoval] = (*SubnetValidatorRemoval)(nil)
+
+	errUnexpectedRemovalKeyLength = errors.New("unexpected subnet validator removal key length")
+)
+
+// SubnetValidatorRemoval is the scheduled removal of a permissioned subnet
+// validator.
+type SubnetValidatorRemoval struct {
+	SubnetID ids.ID
+	NodeID   ids.NodeID
+	// Time at which the validator is removed from the subnet
+	Time time.Time
+}
+
+// Less orders removals by increasing removal time. Removals scheduled at the
+// same time are ordered by subnet ID and then by node ID.
+func (r *SubnetValidatorRemoval) Less(other *SubnetValidatorRemoval) bool {
+	switch {
+	case r.Time.Before(other.Time):
+		return true
+	case other.Time.Before(r.Time):
+		return false
+	case r.SubnetID != other.SubnetID:
+		return r.SubnetID.Less(other.SubnetID)
+	default:
+		return r.NodeID.Less(other.NodeID)
+	}
+}
+
+func subnetValidatorRemovalKey(subnetID ids.ID, nodeID ids.NodeID) []byte {
+	key := make([]byte, ids.IDLen+ids.NodeIDLen)
+	copy(key, subnetID[:])
+	copy(key[ids.IDLen:], nodeID[:])
+	return key
+}
+
+func parseSubnetValidatorRemovalKey(key []byte) (ids.ID, ids.NodeID, error) {
+	if len(key) != ids.IDLen+ids.NodeIDLen {
+		return ids.Empty, ids.EmptyNodeID, fmt.Errorf("%w: %d", errUnexpectedRemovalKeyLength, len(key))
+	}
+	subnetID, err := ids.ToID(key[:ids.IDLen])
+	if err != nil {
+		return ids.Empty, ids.EmptyNodeID, err
+	}
+	nodeID, err := ids.ToNodeID(key[ids.IDLen:])
+	return subnetID, nodeID, err
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+var _ StakerIterator = (*takeIterator)(nil)
+
+type takeIterator struct {
+	parentIterator StakerIterator
+	remaining      int
+}
+
+// NewTakeIterator returns a new iterator that returns at most the first [n]
+// stakers in [parentIterator]. Once [n] stakers have been returned,
+// [parentIterator] is released.
+func NewTakeIterator(parentIterator StakerIterator, n int) StakerIterator {
+	return &takeIterator{
+		parentIterator: parentIterator,
+		remaining:      n,
+	}
+}
+
+func (i *takeIterator) Next() bool {
+	if i.remaining <= 0 {
+		i.Release()
+		return false
+	}
+	i.remaining--
+	if i.parentIterator.Next() {
+		return true
+	}
+	i.Release()
+	return false
+}
+
+func (i *takeIterator) Value() *Staker {
+	return i.parentIterator.Value()
+}
+
+func (i *takeIterator) Release() {
+	i.remaining = 0
+	i.parentIterator.Release()
+}
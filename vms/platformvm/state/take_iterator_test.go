@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestTakeIterator(t *testing.T) {
+	stakers := []*Staker{
+		{
+			TxID: ids.GenerateTestID(),
+		},
+		{
+			TxID: ids.GenerateTestID(),
+		},
+		{
+			TxID: ids.GenerateTestID(),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		stakers  []*Staker
+		n        int
+		expected []*Staker
+	}{
+		{
+			name:     "empty",
+			stakers:  nil,
+			n:        1,
+			expected: nil,
+		},
+		{
+			name:     "take none",
+			stakers:  stakers,
+			n:        0,
+			expected: nil,
+		},
+		{
+			name:     "take negative",
+			stakers:  stakers,
+			n:        -1,
+			expected: nil,
+		},
+		{
+			name:     "take some",
+			stakers:  stakers,
+			n:        2,
+			expected: stakers[:2],
+		},
+		{
+			name:     "take all",
+			stakers:  stakers,
+			n:        len(stakers),
+			expected: stakers,
+		},
+		{
+			name:     "take more than available",
+			stakers:  stakers,
+			n:        len(stakers) + 1,
+			expected: stakers,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			it := NewTakeIterator(
+				NewSliceIterator(test.stakers...),
+				test.n,
+			)
+			for _, expected := range test.expected {
+				require.True(it.Next())
+				require.Equal(expected, it.Value())
+			}
+			require.False(it.Next())
+			it.Release()
+			require.False(it.Next())
+		})
+	}
+}
+
+func TestTakeIteratorReleasesParent(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	staker := &Staker{
+		TxID: ids.GenerateTestID(),
+	}
+	parentIterator := NewMockStakerIterator(ctrl)
+	parentIterator.EXPECT().Next().Return(true)
+	parentIterator.EXPECT().Value().Return(staker)
+	// The parent is released once the limit is reached, without being
+	// advanced past the last returned staker.
+	parentIterator.EXPECT().Release().MinTimes(1)
+
+	it := NewTakeIterator(parentIterator, 1)
+	require.True(it.Next())
+	require.Equal(staker, it.Value())
+	require.False(it.Next())
+	it.Release()
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import "time"
+
+var _ StakerIterator = (*untilIterator)(nil)
+
+type untilIterator struct {
+	parentIterator StakerIterator
+	until          time.Time
+	exhausted      bool
+}
+
+// NewUntilIterator returns a new iterator that returns the stakers in
+// [parentIterator] until a staker whose NextTime is after [until] is reached.
+//
+// Because [parentIterator] is sorted by NextTime, this advances the staker set
+// to [until] while only visiting the stakers that change by then. Once the
+// bound is reached, [parentIterator] is released so that any resources held by
+// the remaining stakers are freed immediately.
+func NewUntilIterator(parentIterator StakerIterator, until time.Time) StakerIterator {
+	return &untilIterator{
+		parentIterator: parentIterator,
+		until:          until,
+	}
+}
+
+func (i *untilIterator) Next() bool {
+	if i.exhausted {
+		return false
+	}
+	if i.parentIterator.Next() && !i.parentIterator.Value().NextTime.After(i.until) {
+		return true
+	}
+	i.exhausted = true
+	i.parentIterator.Release()
+	return false
+}
+
+func (i *untilIterator) Value() *Staker {
+	return i.parentIterator.Value()
+}
+
+func (i *untilIterator) Release() {
+	i.exhausted = true
+	i.parentIterator.Release()
+}
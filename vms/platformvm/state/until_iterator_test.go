@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestUntilIterator(t *testing.T) {
+	stakers := []*Staker{
+		{
+			TxID:     ids.GenerateTestID(),
+			NextTime: time.Unix(0, 0),
+		},
+		{
+			TxID:     ids.GenerateTestID(),
+			NextTime: time.Unix(1, 0),
+		},
+		{
+			TxID:     ids.GenerateTestID(),
+			NextTime: time.Unix(1, 0),
+		},
+		{
+			TxID:     ids.GenerateTestID(),
+			NextTime: time.Unix(2, 0),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		until    time.Time
+		expected []*Staker
+	}{
+		{
+			name:     "before all stakers",
+			until:    time.Unix(-1, 0),
+			expected: nil,
+		},
+		{
+			name:     "inclusive bound",
+			until:    time.Unix(1, 0),
+			expected: stakers[:3],
+		},
+		{
+			name:     "after all stakers",
+			until:    time.Unix(3, 0),
+			expected: stakers,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			it := NewUntilIterator(
+				NewSliceIterator(stakers...),
+				test.until,
+			)
+			for _, expected := range test.expected {
+				require.True(it.Next())
+				require.Equal(expected, it.Value())
+			}
+			require.False(it.Next())
+			it.Release()
+			require.False(it.Next())
+		})
+	}
+}
+
+func TestUntilIteratorReleasesParent(t *testing.T) {
+	require := require.New(t)
+
+	tree := newBaseStakers().stakers
+	stakers := []*Staker{
+		{
+			TxID:     ids.GenerateTestID(),
+			NextTime: time.Unix(0, 0),
+		},
+		{
+			TxID:     ids.GenerateTestID(),
+			NextTime: time.Unix(1, 0),
+		},
+	}
+	for _, staker := range stakers {
+		tree.ReplaceOrInsert(staker)
+	}
+
+	it := NewUntilIterator(NewTreeIterator(tree), time.Unix(0, 0))
+	require.True(it.Next())
+	require.Equal(stakers[0], it.Value())
+
+	// Reaching the bound releases the tree iterator, so the tree can be
+	// modified before the iterator is explicitly released.
+	require.False(it.Next())
+	tree.Delete(stakers[0])
+	it.Release()
+}
@@ -170,6 +170,19 @@ type ProposalTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// Creates a transaction that removes [nodeID] as a validator from
+	// [subnetID] once the chain time reaches [removalTime]
+	// removalTime: unix time at which the validator is removed
+	// keys: keys to use for removing the validator
+	// changeAddr: address to send change to, if there is any
+	NewScheduleSubnetValidatorRemovalTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		removalTime uint64,
+		keys []*secp256k1.PrivateKey,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// Creates a transaction that transfers ownership of [subnetID]
 	// threshold: [threshold] of [ownerAddrs] needed to manage this subnet
 	// ownerAddrs: control addresses for the new subnet
@@ -615,6 +628,44 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	return tx, tx.SyntacticVerify(b.ctx)
 }
 
+func (b *builder) NewScheduleSubnetValidatorRemovalTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	removalTime uint64,
+	keys []*secp256k1.PrivateKey,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &txs.ScheduleSubnetValidatorRemovalTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		NodeID:      nodeID,
+		Subnet:      subnetID,
+		RemovalTime: removalTime,
+		SubnetAuth:  subnetAuth,
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
 func (b *builder) NewAdvanceTimeTx(timestamp time.Time) (*txs.Tx, error) {
 	utx := &txs.AdvanceTimeTx{Time: uint64(timestamp.Unix())}
 	tx, err := txs.NewSigned(utx, txs.Codec, nil)
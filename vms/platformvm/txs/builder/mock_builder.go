@@ -206,6 +206,21 @@ func (mr *MockBuilderMockRecorder) NewRewardValidatorTx(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRewardValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewRewardValidatorTx), arg0)
 }
 
+// NewScheduleSubnetValidatorRemovalTx mocks base method.
+func (m *MockBuilder) NewScheduleSubnetValidatorRemovalTx(arg0 ids.NodeID, arg1 ids.ID, arg2 uint64, arg3 []*secp256k1.PrivateKey, arg4 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewScheduleSubnetValidatorRemovalTx", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewScheduleSubnetValidatorRemovalTx indicates an expected call of NewScheduleSubnetValidatorRemovalTx.
+func (mr *MockBuilderMockRecorder) NewScheduleSubnetValidatorRemovalTx(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewScheduleSubnetValidatorRemovalTx", reflect.TypeOf((*MockBuilder)(nil).NewScheduleSubnetValidatorRemovalTx), arg0, arg1, arg2, arg3, arg4)
+}
+
 // NewTransferSubnetOwnershipTx mocks base method.
 func (m *MockBuilder) NewTransferSubnetOwnershipTx(arg0 ids.ID, arg1 uint32, arg2 []ids.ShortID, arg3 []*secp256k1.PrivateKey, arg4 ids.ShortID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
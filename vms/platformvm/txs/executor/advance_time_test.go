@@ -549,6 +549,123 @@ func TestAdvanceTimeTxRemoveSubnetValidator(t *testing.T) {
 	require.False(ok)
 }
 
+func TestAdvanceTimeTxScheduledSubnetValidatorRemoval(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, false /*=postBanff*/, false /*=postCortina*/)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	subnetID := testSubnet1.ID()
+	env.config.TrackedSubnets.Add(subnetID)
+
+	dummyHeight := uint64(1)
+	// Add a subnet validator to the staker set
+	subnetValidatorNodeID := genesisNodeIDs[0]
+	subnetVdrStartTime := defaultValidateStartTime
+	subnetVdrEndTime := defaultValidateStartTime.Add(defaultMinStakingDuration)
+	tx, err := env.txBuilder.NewAddSubnetValidatorTx(
+		1,                                 // Weight
+		uint64(subnetVdrStartTime.Unix()), // Start time
+		uint64(subnetVdrEndTime.Unix()),   // end time
+		subnetValidatorNodeID,             // Node ID
+		subnetID,                          // Subnet ID
+		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	staker, err := state.NewCurrentStaker(
+		tx.ID(),
+		tx.Unsigned.(*txs.AddSubnetValidatorTx),
+		0,
+	)
+	require.NoError(err)
+
+	env.state.PutCurrentValidator(staker)
+	env.state.AddTx(tx, status.Committed)
+	env.state.SetHeight(dummyHeight)
+	require.NoError(env.state.Commit())
+
+	// Schedule the removal of the above validator before its end time
+	removalTime := subnetVdrEndTime.Add(-time.Hour)
+	tx, err = env.txBuilder.NewScheduleSubnetValidatorRemovalTx(
+		subnetValidatorNodeID,
+		subnetID,
+		uint64(removalTime.Unix()),
+		[]*secp256k1.PrivateKey{preFundedKeys[0], preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	require.NoError(tx.Unsigned.Visit(&StandardTxExecutor{
+		Backend: &env.backend,
+		State:   onAcceptState,
+		Tx:      tx,
+	}))
+
+	// A removal can't be scheduled twice
+	err = tx.Unsigned.Visit(&StandardTxExecutor{
+		Backend: &env.backend,
+		State:   onAcceptState,
+		Tx:      tx,
+	})
+	require.ErrorIs(err, ErrRemovalAlreadyScheduled)
+
+	require.NoError(onAcceptState.Apply(env.state))
+	env.state.SetHeight(dummyHeight)
+	require.NoError(env.state.Commit())
+
+	scheduledTime, err := env.state.GetSubnetValidatorRemoval(subnetID, subnetValidatorNodeID)
+	require.NoError(err)
+	require.Equal(removalTime.Unix(), scheduledTime.Unix())
+
+	nextChangeTime, err := GetNextStakerChangeTime(env.state)
+	require.NoError(err)
+	require.Equal(removalTime.Unix(), nextChangeTime.Unix())
+
+	// Advance time to the scheduled removal time.
+	env.clk.Set(removalTime)
+	tx, err = env.txBuilder.NewAdvanceTimeTx(removalTime)
+	require.NoError(err)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	executor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&executor))
+
+	_, err = executor.OnCommitState.GetCurrentValidator(subnetID, subnetValidatorNodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	_, err = executor.OnCommitState.GetSubnetValidatorRemoval(subnetID, subnetValidatorNodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Check VM Validators are removed successfully
+	require.NoError(executor.OnCommitState.Apply(env.state))
+
+	env.state.SetHeight(dummyHeight)
+	require.NoError(env.state.Commit())
+	_, ok := env.config.Validators.GetValidator(subnetID, subnetValidatorNodeID)
+	require.False(ok)
+
+	removals, err := env.state.GetSubnetValidatorRemovals()
+	require.NoError(err)
+	require.Empty(removals)
+}
+
 func TestTrackedSubnet(t *testing.T) {
 	for _, tracked := range []bool{true, false} {
 		t.Run(fmt.Sprintf("tracked %t", tracked), func(t *testing.T) {
@@ -68,6 +68,10 @@ func (*AtomicTxExecutor) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipT
 	return ErrWrongTxType
 }
 
+func (*AtomicTxExecutor) ScheduleSubnetValidatorRemovalTx(*txs.ScheduleSubnetValidatorRemovalTx) error {
+	return ErrWrongTxType
+}
+
 func (*AtomicTxExecutor) AddPermissionlessValidatorTx(*txs.AddPermissionlessValidatorTx) error {
 	return ErrWrongTxType
 }
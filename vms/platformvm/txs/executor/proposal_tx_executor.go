@@ -101,6 +101,10 @@ func (*ProposalTxExecutor) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershi
 	return ErrWrongTxType
 }
 
+func (*ProposalTxExecutor) ScheduleSubnetValidatorRemovalTx(*txs.ScheduleSubnetValidatorRemovalTx) error {
+	return ErrWrongTxType
+}
+
 func (*ProposalTxExecutor) BaseTx(*txs.BaseTx) error {
 	return ErrWrongTxType
 }
@@ -647,14 +651,9 @@ func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, del
 }
 
 func (e *ProposalTxExecutor) shouldBeRewarded(stakerToReward, primaryNetworkValidator *state.Staker) (bool, error) {
-	expectedUptimePercentage := e.Config.UptimePercentage
-	if stakerToReward.SubnetID != constants.PrimaryNetworkID {
-		transformSubnet, err := GetTransformSubnetTx(e.OnCommitState, stakerToReward.SubnetID)
-		if err != nil {
-			return false, fmt.Errorf("failed to calculate uptime: %w", err)
-		}
-
-		expectedUptimePercentage = float64(transformSubnet.UptimeRequirement) / reward.PercentDenominator
+	expectedUptimePercentage, err := GetUptimeRequirement(e.Backend, e.OnCommitState, stakerToReward.SubnetID)
+	if err != nil {
+		return false, fmt.Errorf("failed to calculate uptime: %w", err)
 	}
 
 	// TODO: calculate subnet uptimes
@@ -38,6 +38,9 @@ var (
 	ErrDelegateToPermissionedValidator = errors.New("delegation to permissioned validator")
 	ErrWrongStakedAssetID              = errors.New("incorrect staked assetID")
 	ErrDurangoUpgradeNotActive         = errors.New("attempting to use a Durango-upgrade feature prior to activation")
+	ErrRemovalTimeNotAfterChainTime    = errors.New("removal time is not after the chain time")
+	ErrRemovalTimeNotBeforeEndTime     = errors.New("removal time is not before the validator's end time")
+	ErrRemovalAlreadyScheduled         = errors.New("validator removal is already scheduled")
 )
 
 // verifySubnetValidatorPrimaryNetworkRequirements verifies the primary
@@ -332,6 +335,96 @@ func verifyRemoveSubnetValidatorTx(
 	return vdr, isCurrentValidator, nil
 }
 
+// verifyScheduleSubnetValidatorRemovalTx carries out the validation for a
+// ScheduleSubnetValidatorRemovalTx. The validator must be a current
+// permissioned validator of the subnet whose removal hasn't already been
+// scheduled, and the removal time must be after the chain time and before the
+// validator's end time.
+func verifyScheduleSubnetValidatorRemovalTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.ScheduleSubnetValidatorRemovalTx,
+) error {
+	currentTimestamp := chainState.GetTimestamp()
+	if !backend.Config.IsDurangoActivated(currentTimestamp) {
+		return ErrDurangoUpgradeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return err
+	}
+
+	vdr, err := chainState.GetCurrentValidator(tx.Subnet, tx.NodeID)
+	if err != nil {
+		return fmt.Errorf(
+			"%s %w of %s: %w",
+			tx.NodeID,
+			ErrNotValidator,
+			tx.Subnet,
+			err,
+		)
+	}
+
+	if !vdr.Priority.IsPermissionedValidator() {
+		return ErrRemovePermissionlessValidator
+	}
+
+	removalTime := tx.RemovalTimestamp()
+	if !removalTime.After(currentTimestamp) {
+		return fmt.Errorf(
+			"%w: %s <= %s",
+			ErrRemovalTimeNotAfterChainTime,
+			removalTime,
+			currentTimestamp,
+		)
+	}
+	if !removalTime.Before(vdr.EndTime) {
+		return fmt.Errorf(
+			"%w: %s >= %s",
+			ErrRemovalTimeNotBeforeEndTime,
+			removalTime,
+			vdr.EndTime,
+		)
+	}
+
+	_, err = chainState.GetSubnetValidatorRemoval(tx.Subnet, tx.NodeID)
+	switch err {
+	case nil:
+		return fmt.Errorf("%w: %s of %s", ErrRemovalAlreadyScheduled, tx.NodeID, tx.Subnet)
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return nil
+	}
+
+	baseTxCreds, err := verifySubnetAuthorization(backend, chainState, sTx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return nil
+}
+
 // verifyAddDelegatorTx carries out the validation for an AddDelegatorTx.
 // It returns the tx outputs that should be returned if this delegator is not
 // added to the staking set.
@@ -95,7 +95,8 @@ func getDelegatorRules(
 }
 
 // GetNextStakerChangeTime returns the next time a staker will be either added
-// or removed to/from the current validator set.
+// or removed to/from the current validator set, including the scheduled
+// removals of subnet validators.
 func GetNextStakerChangeTime(state state.Chain) (time.Time, error) {
 	currentStakerIterator, err := state.GetCurrentStakerIterator()
 	if err != nil {
@@ -109,6 +110,7 @@ func GetNextStakerChangeTime(state state.Chain) (time.Time, error) {
 	}
 	defer pendingStakerIterator.Release()
 
+	var nextChangeTime time.Time
 	hasCurrentStaker := currentStakerIterator.Next()
 	hasPendingStaker := pendingStakerIterator.Next()
 	switch {
@@ -116,16 +118,28 @@ func GetNextStakerChangeTime(state state.Chain) (time.Time, error) {
 		nextCurrentTime := currentStakerIterator.Value().NextTime
 		nextPendingTime := pendingStakerIterator.Value().NextTime
 		if nextCurrentTime.Before(nextPendingTime) {
-			return nextCurrentTime, nil
+			nextChangeTime = nextCurrentTime
+		} else {
+			nextChangeTime = nextPendingTime
 		}
-		return nextPendingTime, nil
 	case hasCurrentStaker:
-		return currentStakerIterator.Value().NextTime, nil
+		nextChangeTime = currentStakerIterator.Value().NextTime
 	case hasPendingStaker:
-		return pendingStakerIterator.Value().NextTime, nil
+		nextChangeTime = pendingStakerIterator.Value().NextTime
 	default:
+		// Invariant: Removals are only scheduled for current validators, so
+		//            there are no scheduled removals.
 		return time.Time{}, database.ErrNotFound
 	}
+
+	removals, err := state.GetSubnetValidatorRemovals()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(removals) > 0 && removals[0].Time.Before(nextChangeTime) {
+		nextChangeTime = removals[0].Time
+	}
+	return nextChangeTime, nil
 }
 
 // GetValidator returns information about the given validator, which may be a
@@ -249,6 +263,133 @@ func GetMaxWeight(
 	return math.Max(currentMax, currentWeight), nil
 }
 
+// GetMaxDelegatedWeight returns the maximum total weight, including its own
+// weight, that [validator] may have once delegations are added to it.
+func GetMaxDelegatedWeight(
+	backend *Backend,
+	chainState state.Chain,
+	validator *state.Staker,
+) (uint64, error) {
+	delegatorRules, err := getDelegatorRules(backend, chainState, validator.SubnetID)
+	if err != nil {
+		return 0, err
+	}
+
+	maximumWeight, err := math.Mul64(
+		uint64(delegatorRules.maxValidatorWeightFactor),
+		validator.Weight,
+	)
+	if err != nil {
+		// The weight factor overflowed, so the stake cap is the only limit.
+		return delegatorRules.maxValidatorStake, nil
+	}
+	return math.Min(maximumWeight, delegatorRules.maxValidatorStake), nil
+}
+
+// WeightChange is the total weight of a validator from [Time] until the time
+// of the next WeightChange.
+type WeightChange struct {
+	Time time.Time
+	// Weight is the largest total weight of the validator at any point in the
+	// interval. If multiple stakers change at [Time], this includes the
+	// intermediate weights, matching the checks performed by GetMaxWeight.
+	Weight uint64
+}
+
+// GetWeightChanges returns the total weight of the [validator], including its
+// own weight, at [startTime] and after every change between [startTime] and
+// [endTime].
+// The maximum Weight of the returned changes equals the result of
+// GetMaxWeight.
+// Invariant:
+// - [validator.StartTime] <= [startTime] < [endTime] <= [validator.EndTime]
+func GetWeightChanges(
+	chainState state.Chain,
+	validator *state.Staker,
+	startTime time.Time,
+	endTime time.Time,
+) ([]WeightChange, error) {
+	currentDelegatorIterator, err := chainState.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentWeight := validator.Weight
+	for currentDelegatorIterator.Next() {
+		currentDelegator := currentDelegatorIterator.Value()
+
+		currentWeight, err = math.Add64(currentWeight, currentDelegator.Weight)
+		if err != nil {
+			currentDelegatorIterator.Release()
+			return nil, err
+		}
+	}
+	currentDelegatorIterator.Release()
+
+	currentDelegatorIterator, err = chainState.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	pendingDelegatorIterator, err := chainState.GetPendingDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		currentDelegatorIterator.Release()
+		return nil, err
+	}
+	delegatorChangesIterator := state.NewStakerDiffIterator(currentDelegatorIterator, pendingDelegatorIterator)
+	defer delegatorChangesIterator.Release()
+
+	var changes []WeightChange
+	for delegatorChangesIterator.Next() {
+		delegator, isAdded := delegatorChangesIterator.Value()
+		// [delegator.NextTime] > [endTime]
+		if delegator.NextTime.After(endTime) {
+			break
+		}
+
+		// [delegator.NextTime] >= [startTime]
+		if !delegator.NextTime.Before(startTime) && len(changes) == 0 {
+			// Record the weight at [startTime] before applying the first
+			// change inside of the window.
+			changes = append(changes, WeightChange{
+				Time:   startTime,
+				Weight: currentWeight,
+			})
+		}
+
+		var op func(uint64, uint64) (uint64, error)
+		if isAdded {
+			op = math.Add64
+		} else {
+			op = math.Sub[uint64]
+		}
+		currentWeight, err = op(currentWeight, delegator.Weight)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(changes) == 0 {
+			continue
+		}
+
+		lastChange := &changes[len(changes)-1]
+		if lastChange.Time.Equal(delegator.NextTime) {
+			lastChange.Weight = math.Max(lastChange.Weight, currentWeight)
+			continue
+		}
+		changes = append(changes, WeightChange{
+			Time:   delegator.NextTime,
+			Weight: currentWeight,
+		})
+	}
+	if len(changes) == 0 {
+		changes = append(changes, WeightChange{
+			Time:   startTime,
+			Weight: currentWeight,
+		})
+	}
+	return changes, nil
+}
+
 func GetTransformSubnetTx(chain state.Chain, subnetID ids.ID) (*txs.TransformSubnetTx, error) {
 	transformSubnetIntf, err := chain.GetSubnetTransformation(subnetID)
 	if err != nil {
@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/btree"
+
 	"github.com/stretchr/testify/require"
 
 	"go.uber.org/mock/gomock"
@@ -16,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
@@ -781,3 +784,114 @@ func TestGetDelegatorRules(t *testing.T) {
 		})
 	}
 }
+
+func TestGetWeightChanges(t *testing.T) {
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		validator = &state.Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    nodeID,
+			SubnetID:  constants.PrimaryNetworkID,
+			Weight:    10,
+			StartTime: time.Unix(0, 0),
+			EndTime:   time.Unix(100, 0),
+		}
+		newDelegator = func(weight uint64, startTime, endTime int64, priority txs.Priority, nextTime int64) *state.Staker {
+			return &state.Staker{
+				TxID:      ids.GenerateTestID(),
+				NodeID:    nodeID,
+				SubnetID:  constants.PrimaryNetworkID,
+				Weight:    weight,
+				StartTime: time.Unix(startTime, 0),
+				EndTime:   time.Unix(endTime, 0),
+				NextTime:  time.Unix(nextTime, 0),
+				Priority:  priority,
+			}
+		}
+		currentDelegators = []*state.Staker{
+			newDelegator(5, 0, 40, txs.PrimaryNetworkDelegatorCurrentPriority, 40),
+		}
+		pendingDelegators = []*state.Staker{
+			newDelegator(3, 20, 60, txs.PrimaryNetworkDelegatorBanffPendingPriority, 20),
+			// Added at the same time that the current delegator is removed.
+			newDelegator(4, 40, 80, txs.PrimaryNetworkDelegatorBanffPendingPriority, 40),
+		}
+	)
+
+	tests := []struct {
+		name            string
+		startTime       int64
+		endTime         int64
+		expectedChanges []WeightChange
+	}{
+		{
+			name:      "changes during period",
+			startTime: 10,
+			endTime:   70,
+			expectedChanges: []WeightChange{
+				{Time: time.Unix(10, 0), Weight: 15},
+				{Time: time.Unix(20, 0), Weight: 18},
+				{Time: time.Unix(40, 0), Weight: 22},
+				{Time: time.Unix(60, 0), Weight: 14},
+			},
+		},
+		{
+			name:      "change at start of period",
+			startTime: 20,
+			endTime:   30,
+			expectedChanges: []WeightChange{
+				{Time: time.Unix(20, 0), Weight: 18},
+			},
+		},
+		{
+			name:      "no changes during period",
+			startTime: 45,
+			endTime:   55,
+			expectedChanges: []WeightChange{
+				{Time: time.Unix(45, 0), Weight: 17},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			chainState := state.NewMockChain(ctrl)
+			chainState.EXPECT().GetCurrentDelegatorIterator(constants.PrimaryNetworkID, nodeID).DoAndReturn(
+				func(ids.ID, ids.NodeID) (state.StakerIterator, error) {
+					return newTestStakerIterator(currentDelegators...), nil
+				},
+			).AnyTimes()
+			chainState.EXPECT().GetPendingDelegatorIterator(constants.PrimaryNetworkID, nodeID).DoAndReturn(
+				func(ids.ID, ids.NodeID) (state.StakerIterator, error) {
+					return newTestStakerIterator(pendingDelegators...), nil
+				},
+			).AnyTimes()
+
+			startTime := time.Unix(tt.startTime, 0)
+			endTime := time.Unix(tt.endTime, 0)
+			changes, err := GetWeightChanges(chainState, validator, startTime, endTime)
+			require.NoError(err)
+			require.Equal(tt.expectedChanges, changes)
+
+			// The largest weight must match the weight used to verify
+			// delegations.
+			maxWeight, err := GetMaxWeight(chainState, validator, startTime, endTime)
+			require.NoError(err)
+			var maxChangeWeight uint64
+			for _, change := range changes {
+				maxChangeWeight = math.Max(maxChangeWeight, change.Weight)
+			}
+			require.Equal(maxWeight, maxChangeWeight)
+		})
+	}
+}
+
+func newTestStakerIterator(stakers ...*state.Staker) state.StakerIterator {
+	tree := btree.NewG(2, (*state.Staker).Less)
+	for _, staker := range stakers {
+		tree.ReplaceOrInsert(staker)
+	}
+	return state.NewTreeIterator(tree)
+}
@@ -375,6 +375,7 @@ func (e *StandardTxExecutor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidat
 
 	if isCurrentValidator {
 		e.State.DeleteCurrentValidator(staker)
+		e.State.DeleteSubnetValidatorRemoval(tx.Subnet, tx.NodeID)
 	} else {
 		e.State.DeletePendingValidator(staker)
 	}
@@ -515,6 +516,31 @@ func (e *StandardTxExecutor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwn
 	return nil
 }
 
+// Verifies a [*txs.ScheduleSubnetValidatorRemovalTx] and, if it passes,
+// executes it on [e.State]. For verification rules, see
+// [verifyScheduleSubnetValidatorRemovalTx]. This transaction will result in
+// [tx.NodeID] being removed as a validator of [tx.Subnet] once the chain time
+// reaches [tx.RemovalTime].
+func (e *StandardTxExecutor) ScheduleSubnetValidatorRemovalTx(tx *txs.ScheduleSubnetValidatorRemovalTx) error {
+	err := verifyScheduleSubnetValidatorRemovalTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	e.State.SetSubnetValidatorRemoval(tx.Subnet, tx.NodeID, tx.RemovalTimestamp())
+
+	txID := e.Tx.ID()
+	avax.Consume(e.State, tx.Ins)
+	avax.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
+
 func (e *StandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
 	if !e.Backend.Config.IsDurangoActivated(e.State.GetTimestamp()) {
 		return ErrDurangoUpgradeNotActive
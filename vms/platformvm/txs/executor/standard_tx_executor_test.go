@@ -1088,6 +1088,7 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 					env.unsignedTx, env.state, env.unsignedTx.Ins, env.unsignedTx.Outs, env.tx.Creds[:len(env.tx.Creds)-1], gomock.Any(),
 				).Return(nil).Times(1)
 				env.state.EXPECT().DeleteCurrentValidator(env.staker)
+				env.state.EXPECT().DeleteSubnetValidatorRemoval(env.unsignedTx.Subnet, env.unsignedTx.NodeID)
 				env.state.EXPECT().DeleteUTXO(gomock.Any()).Times(len(env.unsignedTx.Ins))
 				env.state.EXPECT().AddUTXO(gomock.Any()).Times(len(env.unsignedTx.Outs))
 				e := &StandardTxExecutor{
@@ -69,6 +69,8 @@ type stateChanges struct {
 	pendingValidatorsToRemove []*state.Staker
 	pendingDelegatorsToRemove []*state.Staker
 	currentValidatorsToRemove []*state.Staker
+	// Scheduled removals that are executed by this change
+	subnetValidatorRemovals []*state.SubnetValidatorRemoval
 }
 
 func (s *stateChanges) Apply(stateDiff state.Diff) {
@@ -91,12 +93,15 @@ func (s *stateChanges) Apply(stateDiff state.Diff) {
 	for _, currentValidatorToRemove := range s.currentValidatorsToRemove {
 		stateDiff.DeleteCurrentValidator(currentValidatorToRemove)
 	}
+	for _, removal := range s.subnetValidatorRemovals {
+		stateDiff.DeleteSubnetValidatorRemoval(removal.SubnetID, removal.NodeID)
+	}
 }
 
 func (s *stateChanges) Len() int {
 	return len(s.currentValidatorsToAdd) + len(s.currentDelegatorsToAdd) +
 		len(s.pendingValidatorsToRemove) + len(s.pendingDelegatorsToRemove) +
-		len(s.currentValidatorsToRemove)
+		len(s.currentValidatorsToRemove) + len(s.subnetValidatorRemovals)
 }
 
 // AdvanceTimeTo does not modify [parentState].
@@ -107,10 +112,14 @@ func AdvanceTimeTo(
 	parentState state.Chain,
 	newChainTime time.Time,
 ) (StateChanges, error) {
-	pendingStakerIterator, err := parentState.GetPendingStakerIterator()
+	parentPendingStakerIterator, err := parentState.GetPendingStakerIterator()
 	if err != nil {
 		return nil, err
 	}
+	// Only the pending stakers that start at or before the new timestamp are
+	// visited, so the work done here is proportional to the number of stakers
+	// being promoted rather than the size of the pending set.
+	pendingStakerIterator := state.NewUntilIterator(parentPendingStakerIterator, newChainTime)
 	defer pendingStakerIterator.Release()
 
 	changes := &stateChanges{
@@ -131,10 +140,6 @@ func AdvanceTimeTo(
 
 	for pendingStakerIterator.Next() {
 		stakerToRemove := pendingStakerIterator.Value()
-		if stakerToRemove.StartTime.After(newChainTime) {
-			break
-		}
-
 		stakerToAdd := *stakerToRemove
 		stakerToAdd.NextTime = stakerToRemove.EndTime
 		stakerToAdd.Priority = txs.PendingToCurrentPriorities[stakerToRemove.Priority]
@@ -205,6 +210,33 @@ func AdvanceTimeTo(
 
 		changes.currentValidatorsToRemove = append(changes.currentValidatorsToRemove, stakerToRemove)
 	}
+
+	// Remove the subnet validators whose scheduled removal time is at or
+	// before the new timestamp.
+	//
+	// Invariant: A removal is scheduled before the validator's end time, so
+	//            these validators weren't removed above.
+	removals, err := parentState.GetSubnetValidatorRemovals()
+	if err != nil {
+		return nil, err
+	}
+	for _, removal := range removals {
+		if removal.Time.After(newChainTime) {
+			break
+		}
+
+		stakerToRemove, err := parentState.GetCurrentValidator(removal.SubnetID, removal.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get validator %s of %s scheduled for removal: %w",
+				removal.NodeID,
+				removal.SubnetID,
+				err,
+			)
+		}
+		changes.currentValidatorsToRemove = append(changes.currentValidatorsToRemove, stakerToRemove)
+		changes.subnetValidatorRemovals = append(changes.subnetValidatorRemovals, removal)
+	}
 	return changes, nil
 }
 
@@ -229,3 +261,21 @@ func GetRewardsCalculator(
 		SupplyCap:          transformSubnet.MaximumSupply,
 	}), nil
 }
+
+// GetUptimeRequirement returns the minimum uptime, as a fraction, that stakers
+// of [subnetID] must have to be rewarded.
+func GetUptimeRequirement(
+	backend *Backend,
+	parentState state.Chain,
+	subnetID ids.ID,
+) (float64, error) {
+	if subnetID == constants.PrimaryNetworkID {
+		return backend.Config.UptimePercentage, nil
+	}
+
+	transformSubnet, err := GetTransformSubnetTx(parentState, subnetID)
+	if err != nil {
+		return 0, err
+	}
+	return float64(transformSubnet.UptimeRequirement) / reward.PercentDenominator, nil
+}
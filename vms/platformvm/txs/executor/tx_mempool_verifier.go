@@ -82,6 +82,10 @@ func (v *MempoolTxVerifier) BaseTx(tx *txs.BaseTx) error {
 	return v.standardTx(tx)
 }
 
+func (v *MempoolTxVerifier) ScheduleSubnetValidatorRemovalTx(tx *txs.ScheduleSubnetValidatorRemovalTx) error {
+	return v.standardTx(tx)
+}
+
 func (v *MempoolTxVerifier) standardTx(tx txs.UnsignedTx) error {
 	baseState, err := v.standardBaseState()
 	if err != nil {
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	_ UnsignedTx = (*ScheduleSubnetValidatorRemovalTx)(nil)
+
+	ErrScheduleRemovalOfPrimaryNetworkValidator = errors.New("can't schedule the removal of a primary network validator")
+	ErrZeroRemovalTime                          = errors.New("removal time must be non-zero")
+)
+
+// Schedules the removal of a validator from a subnet. Unlike a
+// RemoveSubnetValidatorTx, the validator isn't removed until the chain time
+// reaches [RemovalTime].
+type ScheduleSubnetValidatorRemovalTx struct {
+	BaseTx `serialize:"true"`
+	// The node to remove from the subnet.
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// The subnet to remove the node from.
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Unix time, in seconds, at which the node is removed from the subnet.
+	RemovalTime uint64 `serialize:"true" json:"removalTime"`
+	// Proves that the issuer has the right to remove the node from the subnet.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+// RemovalTimestamp returns the time at which the node is removed from the
+// subnet.
+func (tx *ScheduleSubnetValidatorRemovalTx) RemovalTimestamp() time.Time {
+	return time.Unix(int64(tx.RemovalTime), 0)
+}
+
+func (tx *ScheduleSubnetValidatorRemovalTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return ErrScheduleRemovalOfPrimaryNetworkValidator
+	case tx.RemovalTime == 0:
+		return ErrZeroRemovalTime
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *ScheduleSubnetValidatorRemovalTx) Visit(visitor Visitor) error {
+	return visitor.ScheduleSubnetValidatorRemovalTx(tx)
+}
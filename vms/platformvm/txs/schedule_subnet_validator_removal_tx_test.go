@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestScheduleSubnetValidatorRemovalTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		txFunc      func(*gomock.Controller) *ScheduleSubnetValidatorRemovalTx
+		expectedErr error
+	}{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *ScheduleSubnetValidatorRemovalTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			txFunc: func(*gomock.Controller) *ScheduleSubnetValidatorRemovalTx {
+				return &ScheduleSubnetValidatorRemovalTx{
+					BaseTx: BaseTx{
+						SyntacticallyVerified: true,
+					},
+				}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "primary network",
+			txFunc: func(*gomock.Controller) *ScheduleSubnetValidatorRemovalTx {
+				return &ScheduleSubnetValidatorRemovalTx{
+					BaseTx:      validBaseTx,
+					NodeID:      ids.GenerateTestNodeID(),
+					Subnet:      constants.PrimaryNetworkID,
+					RemovalTime: 1,
+				}
+			},
+			expectedErr: ErrScheduleRemovalOfPrimaryNetworkValidator,
+		},
+		{
+			name: "zero removal time",
+			txFunc: func(*gomock.Controller) *ScheduleSubnetValidatorRemovalTx {
+				return &ScheduleSubnetValidatorRemovalTx{
+					BaseTx: validBaseTx,
+					NodeID: ids.GenerateTestNodeID(),
+					Subnet: ids.GenerateTestID(),
+				}
+			},
+			expectedErr: ErrZeroRemovalTime,
+		},
+		{
+			name: "invalid BaseTx",
+			txFunc: func(*gomock.Controller) *ScheduleSubnetValidatorRemovalTx {
+				return &ScheduleSubnetValidatorRemovalTx{
+					NodeID:      ids.GenerateTestNodeID(),
+					Subnet:      ids.GenerateTestID(),
+					RemovalTime: 1,
+				}
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "invalid subnetAuth",
+			txFunc: func(ctrl *gomock.Controller) *ScheduleSubnetValidatorRemovalTx {
+				invalidSubnetAuth := verify.NewMockVerifiable(ctrl)
+				invalidSubnetAuth.EXPECT().Verify().Return(errInvalidSubnetAuth)
+				return &ScheduleSubnetValidatorRemovalTx{
+					BaseTx:      validBaseTx,
+					NodeID:      ids.GenerateTestNodeID(),
+					Subnet:      ids.GenerateTestID(),
+					RemovalTime: 1,
+					SubnetAuth:  invalidSubnetAuth,
+				}
+			},
+			expectedErr: errInvalidSubnetAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(*gomock.Controller) *ScheduleSubnetValidatorRemovalTx {
+				return &ScheduleSubnetValidatorRemovalTx{
+					BaseTx:      validBaseTx,
+					NodeID:      ids.GenerateTestNodeID(),
+					Subnet:      ids.GenerateTestID(),
+					RemovalTime: 1,
+					SubnetAuth: &secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
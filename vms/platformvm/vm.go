@@ -53,10 +53,12 @@ import (
 )
 
 var (
-	_ snowmanblock.ChainVM       = (*VM)(nil)
-	_ secp256k1fx.VM             = (*VM)(nil)
-	_ validators.State           = (*VM)(nil)
-	_ validators.SubnetConnector = (*VM)(nil)
+	_ snowmanblock.ChainVM              = (*VM)(nil)
+	_ snowmanblock.IntegrityVerifierVM  = (*VM)(nil)
+	_ snowmanblock.DatabaseNamespacesVM = (*VM)(nil)
+	_ secp256k1fx.VM                    = (*VM)(nil)
+	_ validators.State                  = (*VM)(nil)
+	_ validators.SubnetConnector        = (*VM)(nil)
 )
 
 type VM struct {
@@ -188,6 +190,7 @@ func (vm *VM) Initialize(
 		vm.state,
 		txExecutorBackend,
 		validatorManager,
+		execConfig.InvariantChecksEnabled,
 	)
 	vm.Network = network.New(
 		txExecutorBackend.Ctx,
@@ -219,6 +222,24 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	shouldIndexStakingHistory, err := vm.state.ShouldIndexStakingHistory()
+	if err != nil {
+		return fmt.Errorf(
+			"failed to check if the staking history should be indexed: %w",
+			err,
+		)
+	}
+	if shouldIndexStakingHistory {
+		go func() {
+			err := vm.state.IndexStakingHistory(&vm.ctx.Lock, vm.ctx.Log)
+			if err != nil {
+				vm.ctx.Log.Error("staking history indexing failed",
+					zap.Error(err),
+				)
+			}
+		}()
+	}
+
 	shouldPrune, err := vm.state.ShouldPrune()
 	if err != nil {
 		return fmt.Errorf(
@@ -480,3 +501,18 @@ func (vm *VM) VerifyHeightIndex(_ context.Context) error {
 func (vm *VM) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, error) {
 	return vm.state.GetBlockIDAtHeight(height)
 }
+
+// VerifyIntegrity verifies the consistency of the staker indexes.
+//
+// vm.ctx.Lock should be held
+func (vm *VM) VerifyIntegrity(ctx context.Context, repair bool) ([]string, error) {
+	return vm.state.VerifyIntegrity(ctx, repair)
+}
+
+// DatabaseNamespaces returns the databases of the staker indexes, the
+// validator diffs, the blocks, the txs, and the UTXOs.
+//
+// vm.ctx.Lock should be held while the returned databases are used
+func (vm *VM) DatabaseNamespaces() map[string]database.Iteratee {
+	return vm.state.DatabaseNamespaces()
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// ValidatorSet is the canonical encoding of the validator set of a subnet at a
+// P-chain height, as used to verify warp messages. Its ID commits to the
+// validator set, so it can be attested to and consumed without parsing the
+// P-chain state.
+type ValidatorSet struct {
+	SubnetID     ids.ID `serialize:"true"`
+	PChainHeight uint64 `serialize:"true"`
+	// TotalWeight is the weight of every validator of the subnet, including
+	// the validators without a BLS public key.
+	TotalWeight uint64 `serialize:"true"`
+	// Validators are the validators with a BLS public key, in the order
+	// returned by GetCanonicalValidatorSet. Validators that share a public key
+	// are merged.
+	Validators []ValidatorSetEntry `serialize:"true"`
+
+	bytes []byte
+	id    ids.ID
+}
+
+// ValidatorSetEntry is a validator in a ValidatorSet.
+type ValidatorSetEntry struct {
+	// PublicKey is the compressed BLS public key of the validator
+	PublicKey []byte `serialize:"true"`
+	Weight    uint64 `serialize:"true"`
+}
+
+// GetValidatorSet returns the canonical validator set of [subnetID] at
+// [pChainHeight].
+func GetValidatorSet(
+	ctx context.Context,
+	pChainState ValidatorState,
+	pChainHeight uint64,
+	subnetID ids.ID,
+) (*ValidatorSet, error) {
+	vdrs, totalWeight, err := GetCanonicalValidatorSet(ctx, pChainState, pChainHeight, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ValidatorSetEntry, len(vdrs))
+	for i, vdr := range vdrs {
+		entries[i] = ValidatorSetEntry{
+			PublicKey: bls.PublicKeyToBytes(vdr.PublicKey),
+			Weight:    vdr.Weight,
+		}
+	}
+	vdrSet := &ValidatorSet{
+		SubnetID:     subnetID,
+		PChainHeight: pChainHeight,
+		TotalWeight:  totalWeight,
+		Validators:   entries,
+	}
+	return vdrSet, vdrSet.Initialize()
+}
+
+// ParseValidatorSet converts a slice of bytes into an initialized
+// *ValidatorSet.
+func ParseValidatorSet(b []byte) (*ValidatorSet, error) {
+	vdrSet := &ValidatorSet{
+		bytes: b,
+		id:    hashing.ComputeHash256Array(b),
+	}
+	_, err := c.Unmarshal(b, vdrSet)
+	return vdrSet, err
+}
+
+// Initialize recalculates the result of Bytes().
+func (v *ValidatorSet) Initialize() error {
+	bytes, err := c.Marshal(codecVersion, v)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal validator set: %w", err)
+	}
+	v.bytes = bytes
+	v.id = hashing.ComputeHash256Array(v.bytes)
+	return nil
+}
+
+// Bytes returns the binary representation of this validator set. It assumes
+// that the validator set is initialized from either GetValidatorSet, Parse, or
+// an explicit call to Initialize.
+func (v *ValidatorSet) Bytes() []byte {
+	return v.bytes
+}
+
+// ID returns the SHA-256 hash of the binary representation of this validator
+// set. It assumes that the validator set is initialized from either
+// GetValidatorSet, Parse, or an explicit call to Initialize.
+func (v *ValidatorSet) ID() ids.ID {
+	return v.id
+}
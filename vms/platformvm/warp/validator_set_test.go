@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func TestGetValidatorSet(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	state := validators.NewMockState(ctrl)
+	state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, subnetID).Return(
+		map[ids.NodeID]*validators.GetValidatorOutput{
+			testVdrs[1].nodeID: {
+				NodeID:    testVdrs[1].nodeID,
+				PublicKey: testVdrs[1].vdr.PublicKey,
+				Weight:    testVdrs[1].vdr.Weight,
+			},
+			testVdrs[0].nodeID: {
+				NodeID:    testVdrs[0].nodeID,
+				PublicKey: testVdrs[0].vdr.PublicKey,
+				Weight:    testVdrs[0].vdr.Weight,
+			},
+			nodeID: {
+				NodeID: nodeID,
+				Weight: 5,
+			},
+		},
+		nil,
+	)
+
+	vdrSet, err := GetValidatorSet(context.Background(), state, pChainHeight, subnetID)
+	require.NoError(err)
+	require.Equal(subnetID, vdrSet.SubnetID)
+	require.Equal(pChainHeight, vdrSet.PChainHeight)
+	require.Equal(uint64(11), vdrSet.TotalWeight)
+	require.Equal(
+		[]ValidatorSetEntry{
+			{
+				PublicKey: bls.PublicKeyToBytes(testVdrs[0].vdr.PublicKey),
+				Weight:    testVdrs[0].vdr.Weight,
+			},
+			{
+				PublicKey: bls.PublicKeyToBytes(testVdrs[1].vdr.PublicKey),
+				Weight:    testVdrs[1].vdr.Weight,
+			},
+		},
+		vdrSet.Validators,
+	)
+
+	parsedVdrSet, err := ParseValidatorSet(vdrSet.Bytes())
+	require.NoError(err)
+	require.Equal(vdrSet, parsedVdrSet)
+	require.Equal(vdrSet.ID(), parsedVdrSet.ID())
+}
+
+func TestGetValidatorSetError(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	state := validators.NewMockState(ctrl)
+	state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, subnetID).Return(nil, errTest)
+
+	_, err := GetValidatorSet(context.Background(), state, pChainHeight, subnetID)
+	require.ErrorIs(err, errTest)
+}
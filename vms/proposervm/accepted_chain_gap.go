@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+)
+
+var errUnrepairableGap = errors.New("accepted chain gap can't be repaired")
+
+// repairAcceptedChainGap rolls the proposervm forward when the inner VM has
+// accepted blocks above [proLastAccepted], which is the proposervm's last
+// accepted block. This happens if the inner VM recovers blocks that were
+// previously rolled back, or if the proposervm's last accepted block wasn't
+// persisted before the node stopped.
+//
+// The proposervm blocks that wrap the inner blocks in the gap must still be
+// stored. They are accepted again, in height order, without notifying the
+// inner VM, until the proposervm reaches [innerLastAccepted].
+func (vm *VM) repairAcceptedChainGap(
+	ctx context.Context,
+	proLastAccepted PostForkBlock,
+	innerLastAccepted snowman.Block,
+) error {
+	proLastAcceptedHeight := proLastAccepted.Height()
+	innerLastAcceptedHeight := innerLastAccepted.Height()
+
+	vm.ctx.Log.Info("repairing accepted chain gap",
+		zap.Uint64("outerHeight", proLastAcceptedHeight),
+		zap.Uint64("innerHeight", innerLastAcceptedHeight),
+	)
+
+	// Collect the inner blocks that were accepted above the proposervm's last
+	// accepted block, and make sure that they extend it.
+	innerBlkIDs := make([]ids.ID, innerLastAcceptedHeight-proLastAcceptedHeight)
+	innerBlk := innerLastAccepted
+	for i := len(innerBlkIDs) - 1; i >= 0; i-- {
+		innerBlkIDs[i] = innerBlk.ID()
+
+		parentID := innerBlk.Parent()
+		parent, err := vm.ChainVM.GetBlock(ctx, parentID)
+		if err != nil {
+			return fmt.Errorf("failed to get inner block %s: %w", parentID, err)
+		}
+		innerBlk = parent
+	}
+	if expectedID := proLastAccepted.getInnerBlk().ID(); innerBlk.ID() != expectedID {
+		return fmt.Errorf("%w: inner block %s at height %d conflicts with the accepted inner block %s",
+			errUnrepairableGap,
+			innerBlk.ID(),
+			proLastAcceptedHeight,
+			expectedID,
+		)
+	}
+
+	lastAcceptedID := proLastAccepted.ID()
+	for i, innerBlkID := range innerBlkIDs {
+		height := proLastAcceptedHeight + uint64(i) + 1
+		blk, err := vm.getGapBlock(ctx, lastAcceptedID, innerBlkID)
+		if err != nil {
+			return fmt.Errorf("failed to repair accepted chain gap at height %d: %w", height, err)
+		}
+
+		blk.setStatus(choices.Accepted)
+		if err := vm.acceptPostForkBlock(blk); err != nil {
+			return err
+		}
+		vm.metrics.rolledForwardBlocks.Inc()
+		lastAcceptedID = blk.ID()
+	}
+
+	vm.ctx.Log.Info("repaired accepted chain gap",
+		zap.Int("numBlocks", len(innerBlkIDs)),
+		zap.Stringer("lastAcceptedID", lastAcceptedID),
+	)
+	return nil
+}
+
+// getGapBlock returns the stored proposervm block that wraps [innerBlkID] and
+// whose parent is [parentID].
+func (vm *VM) getGapBlock(ctx context.Context, parentID ids.ID, innerBlkID ids.ID) (PostForkBlock, error) {
+	blkID, err := vm.State.GetOuterBlockID(innerBlkID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get the block wrapping inner block %s: %w", errUnrepairableGap, innerBlkID, err)
+	}
+	blk, err := vm.getPostForkBlock(ctx, blkID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get block %s: %w", errUnrepairableGap, blkID, err)
+	}
+	if blk.Parent() != parentID || blk.getInnerBlk().ID() != innerBlkID {
+		return nil, fmt.Errorf("%w: block %s doesn't extend %s with inner block %s",
+			errUnrepairableGap,
+			blkID,
+			parentID,
+			innerBlkID,
+		)
+	}
+	return blk, nil
+}
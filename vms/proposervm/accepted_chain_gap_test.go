@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func TestRepairAcceptedChainGap(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	// Make this node the only proposer, so that it can always build in the
+	// first window.
+	valState.GetValidatorSetF = func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+		return map[ids.NodeID]*validators.GetValidatorOutput{
+			proVM.ctx.NodeID: {
+				NodeID: proVM.ctx.NodeID,
+				Weight: 1,
+			},
+		}, nil
+	}
+
+	m, err := newBlockMetrics(prometheus.NewRegistry())
+	require.NoError(err)
+	proVM.metrics = m
+
+	coreBlks := map[ids.ID]snowman.Block{
+		coreGenBlk.ID(): coreGenBlk,
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		blk, ok := coreBlks[blkID]
+		if !ok {
+			return nil, errUnknownBlock
+		}
+		return blk, nil
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		for _, blk := range coreBlks {
+			if bytes.Equal(b, blk.Bytes()) {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	var (
+		coreLastAccepted snowman.Block = coreGenBlk
+		proBlks          []snowman.Block
+	)
+	coreVM.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return coreLastAccepted.ID(), nil
+	}
+
+	require.NoError(proVM.SetPreference(context.Background(), coreGenBlk.ID()))
+	for i := uint64(1); i <= 3; i++ {
+		coreBlk := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			BytesV:     []byte{byte(i)},
+			ParentV:    coreLastAccepted.ID(),
+			HeightV:    coreGenBlk.Height() + i,
+			TimestampV: coreGenBlk.Timestamp(),
+		}
+		coreBlks[coreBlk.ID()] = coreBlk
+		coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+			return coreBlk, nil
+		}
+
+		blk, err := proVM.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(proVM.SetPreference(context.Background(), blk.ID()))
+
+		coreLastAccepted = coreBlk
+		proBlks = append(proBlks, blk)
+		proVM.Set(proVM.Time().Add(proposer.WindowDuration / 2))
+	}
+
+	// Simulate the proposervm's last accepted block falling behind the inner
+	// VM's last accepted block.
+	require.NoError(proVM.State.SetLastAccepted(proBlks[0].ID()))
+	require.NoError(proVM.db.Commit())
+
+	require.NoError(proVM.repairAcceptedChainByHeight(context.Background()))
+
+	lastAcceptedID, err := proVM.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(proBlks[2].ID(), lastAcceptedID)
+	require.Equal(proBlks[2].Height(), proVM.lastAcceptedHeight)
+	require.Equal(float64(2), testutil.ToFloat64(proVM.metrics.rolledForwardBlocks))
+
+	for _, proBlk := range proBlks {
+		blk, err := proVM.GetBlock(context.Background(), proBlk.ID())
+		require.NoError(err)
+		require.Equal(choices.Accepted, blk.Status())
+
+		blkID, err := proVM.GetBlockIDAtHeight(context.Background(), proBlk.Height())
+		require.NoError(err)
+		require.Equal(proBlk.ID(), blkID)
+	}
+
+	// Once the heights match, there is nothing to repair.
+	require.NoError(proVM.repairAcceptedChainByHeight(context.Background()))
+	require.Equal(float64(2), testutil.ToFloat64(proVM.metrics.rolledForwardBlocks))
+
+	// The gap can't be repaired if a block wrapping an inner block in the gap
+	// isn't stored.
+	require.NoError(proVM.State.SetLastAccepted(proBlks[0].ID()))
+	require.NoError(proVM.State.DeleteInnerBlockID(proBlks[1].ID()))
+	require.NoError(proVM.db.Commit())
+
+	err = proVM.repairAcceptedChainByHeight(context.Background())
+	require.ErrorIs(err, errUnrepairableGap)
+
+	// The gap can't be repaired if the inner VM accepted a conflicting block.
+	conflictingCoreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV:     []byte{4},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreBlks[conflictingCoreBlk.ID()] = conflictingCoreBlk
+	coreLastAccepted = &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV:     []byte{5},
+		ParentV:    conflictingCoreBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 2,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreBlks[coreLastAccepted.ID()] = coreLastAccepted
+
+	err = proVM.repairAcceptedChainByHeight(context.Background())
+	require.ErrorIs(err, errUnrepairableGap)
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var _ common.AppSender = (*muxedAppSender)(nil)
+
+type appRequest struct {
+	nodeID    ids.NodeID
+	requestID uint32
+}
+
+// muxedAppRequest is an app request that was sent by one of the multiplexed
+// senders, along with the request ID that sender chose.
+type muxedAppRequest struct {
+	fromInnerVM bool
+	requestID   uint32
+}
+
+// appRequestMux allows both the inner VM and the proposervm to send app
+// requests without their request IDs colliding. Each sent request is assigned
+// a new request ID, which is mapped back to the request ID chosen by the
+// sender when the response, or failure, is received.
+type appRequestMux struct {
+	lock          sync.Mutex
+	nextRequestID uint32
+	requests      map[appRequest]muxedAppRequest
+}
+
+func newAppRequestMux() *appRequestMux {
+	return &appRequestMux{
+		requests: make(map[appRequest]muxedAppRequest),
+	}
+}
+
+// Sender returns an AppSender whose app requests are multiplexed by [m]. All
+// other messages are sent as is.
+func (m *appRequestMux) Sender(sender common.AppSender, fromInnerVM bool) common.AppSender {
+	return &muxedAppSender{
+		AppSender:   sender,
+		mux:         m,
+		fromInnerVM: fromInnerVM,
+	}
+}
+
+// Resolve returns the request that was sent to [nodeID] with [requestID] and
+// stops tracking it. Returns false if no such request is pending.
+func (m *appRequestMux) Resolve(nodeID ids.NodeID, requestID uint32) (muxedAppRequest, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	key := appRequest{
+		nodeID:    nodeID,
+		requestID: requestID,
+	}
+	request, ok := m.requests[key]
+	delete(m.requests, key)
+	return request, ok
+}
+
+func (m *appRequestMux) register(nodeIDs set.Set[ids.NodeID], request muxedAppRequest) uint32 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	requestID := m.nextRequestID
+	m.nextRequestID++
+	for nodeID := range nodeIDs {
+		m.requests[appRequest{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}] = request
+	}
+	return requestID
+}
+
+func (m *appRequestMux) unregister(nodeIDs set.Set[ids.NodeID], requestID uint32) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for nodeID := range nodeIDs {
+		delete(m.requests, appRequest{
+			nodeID:    nodeID,
+			requestID: requestID,
+		})
+	}
+}
+
+type muxedAppSender struct {
+	common.AppSender
+
+	mux         *appRequestMux
+	fromInnerVM bool
+}
+
+func (s *muxedAppSender) SendAppRequest(
+	ctx context.Context,
+	nodeIDs set.Set[ids.NodeID],
+	requestID uint32,
+	appRequestBytes []byte,
+) error {
+	muxedRequestID := s.mux.register(nodeIDs, muxedAppRequest{
+		fromInnerVM: s.fromInnerVM,
+		requestID:   requestID,
+	})
+	err := s.AppSender.SendAppRequest(ctx, nodeIDs, muxedRequestID, appRequestBytes)
+	if err != nil {
+		s.mux.unregister(nodeIDs, muxedRequestID)
+	}
+	return err
+}
@@ -0,0 +1,456 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/proposervm"
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+const (
+	// backfillHandlerID is the p2p handler ID of the backfill protocol. It is
+	// chosen to be far from the handler IDs registered by inner VMs.
+	backfillHandlerID = 1<<32 - 1
+	// maxBackfillBlocks is the maximum number of blocks requested, or served,
+	// by a single backfill request.
+	maxBackfillBlocks = 256
+	// backfillRetryDelay is how long to wait before retrying a failed backfill
+	// request.
+	backfillRetryDelay = time.Second
+)
+
+var (
+	_ p2p.Handler = (*backfillHandler)(nil)
+
+	errEmptyAncestors       = errors.New("no ancestors returned")
+	errUnexpectedAncestor   = errors.New("unexpected ancestor")
+	errHeightIndexRepairing = errors.New("height index is being repaired")
+)
+
+// backfillHandler serves accepted post-fork blocks to peers that are
+// backfilling their history.
+type backfillHandler struct {
+	p2p.NoOpHandler
+
+	vm *VM
+}
+
+func (h *backfillHandler) AppRequest(
+	_ context.Context,
+	_ ids.NodeID,
+	_ time.Time,
+	requestBytes []byte,
+) ([]byte, error) {
+	request := &pb.GetAncestorsRequest{}
+	if err := proto.Unmarshal(requestBytes, request); err != nil {
+		return nil, err
+	}
+	blkID, err := ids.ToID(request.BlockId)
+	if err != nil {
+		return nil, err
+	}
+	maxBlocks := int(request.MaxBlocks)
+	if maxBlocks > maxBackfillBlocks {
+		maxBlocks = maxBackfillBlocks
+	}
+
+	h.vm.ctx.Lock.Lock()
+	blks := h.vm.getAcceptedAncestors(blkID, maxBlocks)
+	h.vm.ctx.Lock.Unlock()
+
+	return proto.Marshal(&pb.GetAncestorsResponse{
+		Blocks: blks,
+	})
+}
+
+// getAcceptedAncestors returns the bytes of up to [maxBlocks] accepted
+// post-fork blocks, starting with [blkID] and followed by its ancestors. Fewer
+// blocks are returned if an ancestor isn't stored or the response would be too
+// large.
+//
+// Assumes [vm.ctx.Lock] is held.
+func (vm *VM) getAcceptedAncestors(blkID ids.ID, maxBlocks int) [][]byte {
+	var (
+		blks     [][]byte
+		numBytes int
+	)
+	for len(blks) < maxBlocks {
+		blk, _, err := vm.State.GetBlock(blkID)
+		if err != nil {
+			// Either the block was pruned or the fork was reached.
+			break
+		}
+
+		blkBytes := blk.Bytes()
+		numBytes += wrappers.IntLen + len(blkBytes)
+		if numBytes > constants.MaxContainersLen {
+			break
+		}
+
+		blks = append(blks, blkBytes)
+		blkID = blk.ParentID()
+	}
+	return blks
+}
+
+// AppRequest handles requests for the backfill protocol and forwards all other
+// requests to the inner VM.
+func (vm *VM) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
+	if handlerID, n := binary.Uvarint(request); n > 0 && handlerID == backfillHandlerID {
+		return vm.network.AppRequest(ctx, nodeID, requestID, deadline, request)
+	}
+
+	release := vm.acquireInnerVM()
+	defer release()
+
+	return vm.ChainVM.AppRequest(ctx, nodeID, requestID, deadline, request)
+}
+
+// AppGossip delivers [msg] to the inner VM.
+func (vm *VM) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	release := vm.acquireInnerVM()
+	defer release()
+
+	return vm.ChainVM.AppGossip(ctx, nodeID, msg)
+}
+
+// acquireInnerVM counts an app message handled by the inner VM against
+// [prioritizer], so the proposervm's lower priority messages are shed while
+// the inner VM is busy. The inner VM's messages are treated as consensus
+// traffic and are always handled. The returned function must be called once
+// the message has been handled.
+func (vm *VM) acquireInnerVM() func() {
+	if vm.prioritizer == nil || !vm.prioritizer.Acquire(p2p.ConsensusPriority) {
+		return func() {}
+	}
+	return func() {
+		vm.prioritizer.Release(p2p.ConsensusPriority)
+	}
+}
+
+// AppResponse delivers [response] to whichever of the inner VM or the
+// proposervm sent the request.
+func (vm *VM) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+	request, ok := vm.appRequests.Resolve(nodeID, requestID)
+	if !ok {
+		vm.ctx.Log.Debug("dropping unrequested app response",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	if request.fromInnerVM {
+		return vm.ChainVM.AppResponse(ctx, nodeID, request.requestID, response)
+	}
+	return vm.network.AppResponse(ctx, nodeID, request.requestID, response)
+}
+
+// AppRequestFailed notifies whichever of the inner VM or the proposervm sent
+// the request that it failed.
+func (vm *VM) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	request, ok := vm.appRequests.Resolve(nodeID, requestID)
+	if !ok {
+		vm.ctx.Log.Debug("dropping unrequested app request failure",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	if request.fromInnerVM {
+		return vm.ChainVM.AppRequestFailed(ctx, nodeID, request.requestID)
+	}
+	return vm.network.AppRequestFailed(ctx, nodeID, request.requestID)
+}
+
+func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	if err := vm.network.Connected(ctx, nodeID, nodeVersion); err != nil {
+		return err
+	}
+	return vm.ChainVM.Connected(ctx, nodeID, nodeVersion)
+}
+
+func (vm *VM) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	if err := vm.network.Disconnected(ctx, nodeID); err != nil {
+		return err
+	}
+	return vm.ChainVM.Disconnected(ctx, nodeID)
+}
+
+// backfillGap describes the accepted blocks missing below the lowest indexed
+// block, which is the case after state syncing.
+type backfillGap struct {
+	// blkID is the ID of the highest missing block.
+	blkID ids.ID
+	// height is the height of [blkID].
+	height uint64
+	// minHeight is the height of the lowest missing block that should be
+	// stored.
+	minHeight uint64
+	// childIsOption is true if the block above [blkID] is an option, which
+	// must be attributed to the proposer of [blkID].
+	childIsOption bool
+}
+
+// backfill fetches the accepted blocks that are missing below the lowest
+// indexed block from peers until the fork, or the oldest block that should be
+// kept, is reached. Because the progress is tracked by the height index,
+// backfilling resumes where it left off after a restart.
+func (vm *VM) backfill() {
+	vm.ctx.Log.Info("starting to backfill blocks")
+	for {
+		vm.ctx.Lock.Lock()
+		gap, ok, err := vm.getBackfillGap()
+		vm.ctx.Lock.Unlock()
+		if err == nil && !ok {
+			vm.ctx.Log.Info("finished backfilling blocks")
+			return
+		}
+
+		var blks [][]byte
+		if err == nil {
+			blks, err = vm.requestAncestors(gap.blkID)
+		}
+		if err == nil {
+			vm.ctx.Lock.Lock()
+			err = vm.storeBackfilledBlocks(vm.context, gap, blks)
+			vm.ctx.Lock.Unlock()
+		}
+		if err == nil {
+			continue
+		}
+
+		vm.ctx.Log.Debug("failed to backfill blocks",
+			zap.Stringer("blkID", gap.blkID),
+			zap.Uint64("height", gap.height),
+			zap.Error(err),
+		)
+		select {
+		case <-vm.context.Done():
+			return
+		case <-time.After(backfillRetryDelay):
+		}
+	}
+}
+
+// getBackfillGap returns the highest accepted block that should be backfilled.
+// Returns false if there is nothing to backfill.
+//
+// Assumes [vm.ctx.Lock] is held.
+func (vm *VM) getBackfillGap() (backfillGap, bool, error) {
+	if err := vm.context.Err(); err != nil {
+		return backfillGap{}, false, err
+	}
+	if !vm.hIndexer.IsRepaired() {
+		return backfillGap{}, false, errHeightIndexRepairing
+	}
+
+	forkHeight, err := vm.State.GetForkHeight()
+	if err == database.ErrNotFound {
+		// There aren't any post-fork blocks to backfill.
+		return backfillGap{}, false, nil
+	}
+	if err != nil {
+		return backfillGap{}, false, err
+	}
+	minIndexedHeight, err := vm.State.GetMinimumHeight()
+	if err == database.ErrNotFound {
+		return backfillGap{}, false, nil
+	}
+	if err != nil {
+		return backfillGap{}, false, err
+	}
+
+	minHeight := forkHeight
+	if vm.NumHistoricalBlocks != 0 && vm.lastAcceptedHeight > vm.NumHistoricalBlocks {
+		// Blocks that would immediately be pruned aren't backfilled.
+		minHeight = math.Max(minHeight, vm.lastAcceptedHeight-vm.NumHistoricalBlocks)
+	}
+	if minIndexedHeight <= minHeight {
+		vm.metrics.backfillRemaining.Set(0)
+		return backfillGap{}, false, nil
+	}
+	vm.metrics.backfillRemaining.Set(float64(minIndexedHeight - minHeight))
+
+	lowestBlkID, err := vm.State.GetBlockIDAtHeight(minIndexedHeight)
+	if err != nil {
+		return backfillGap{}, false, err
+	}
+	lowestBlk, _, err := vm.State.GetBlock(lowestBlkID)
+	if err != nil {
+		return backfillGap{}, false, err
+	}
+	_, lowestIsSigned := lowestBlk.(statelessblock.SignedBlock)
+	return backfillGap{
+		blkID:         lowestBlk.ParentID(),
+		height:        minIndexedHeight - 1,
+		minHeight:     minHeight,
+		childIsOption: !lowestIsSigned,
+	}, true, nil
+}
+
+// requestAncestors requests [blkID] and its ancestors from a peer.
+func (vm *VM) requestAncestors(blkID ids.ID) ([][]byte, error) {
+	requestBytes, err := proto.Marshal(&pb.GetAncestorsRequest{
+		BlockId:   blkID[:],
+		MaxBlocks: maxBackfillBlocks,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type ancestorsResponse struct {
+		nodeID        ids.NodeID
+		responseBytes []byte
+		err           error
+	}
+	responses := make(chan ancestorsResponse, 1)
+	err = vm.backfillClient.AppRequestAny(
+		vm.context,
+		requestBytes,
+		func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+			responses <- ancestorsResponse{
+				nodeID:        nodeID,
+				responseBytes: responseBytes,
+				err:           err,
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var response ancestorsResponse
+	select {
+	case <-vm.context.Done():
+		return nil, vm.context.Err()
+	case response = <-responses:
+	}
+	if response.err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", response.nodeID, response.err)
+	}
+
+	ancestors := &pb.GetAncestorsResponse{}
+	if err := proto.Unmarshal(response.responseBytes, ancestors); err != nil {
+		return nil, fmt.Errorf("failed to parse response from %s: %w", response.nodeID, err)
+	}
+	if len(ancestors.Blocks) == 0 {
+		return nil, fmt.Errorf("%w by %s", errEmptyAncestors, response.nodeID)
+	}
+	return ancestors.Blocks, nil
+}
+
+// storeBackfilledBlocks stores and indexes the blocks in [blks], which must
+// be the missing blocks described by [gap] in decreasing height order. Blocks
+// are authenticated by their IDs, so valid blocks before the first invalid
+// block are stored even if an error is returned.
+//
+// Assumes [vm.ctx.Lock] is held.
+func (vm *VM) storeBackfilledBlocks(ctx context.Context, gap backfillGap, blks [][]byte) error {
+	if err := vm.context.Err(); err != nil {
+		return err
+	}
+
+	var (
+		expectedID    = gap.blkID
+		height        = gap.height
+		childIsOption = gap.childIsOption
+		numStored     int
+		err           error
+	)
+	for _, blkBytes := range blks {
+		var blk statelessblock.Block
+		blk, err = vm.storeBackfilledBlock(ctx, expectedID, height, childIsOption, blkBytes)
+		if err != nil {
+			break
+		}
+		numStored++
+
+		_, isSigned := blk.(statelessblock.SignedBlock)
+		childIsOption = !isSigned
+		expectedID = blk.ParentID()
+		if height == gap.minHeight {
+			break
+		}
+		height--
+	}
+
+	vm.metrics.backfilledBlocks.Add(float64(numStored))
+	vm.ctx.Log.Debug("backfilled blocks",
+		zap.Int("numBlocks", numStored),
+		zap.Uint64("lowestHeight", height),
+		zap.Uint64("minHeight", gap.minHeight),
+	)
+	if commitErr := vm.db.Commit(); commitErr != nil {
+		return commitErr
+	}
+	return err
+}
+
+// storeBackfilledBlock verifies that [blkBytes] is the accepted block
+// [expectedID] at [height] and stores it along with its indices.
+//
+// Assumes [vm.ctx.Lock] is held.
+func (vm *VM) storeBackfilledBlock(
+	ctx context.Context,
+	expectedID ids.ID,
+	height uint64,
+	childIsOption bool,
+	blkBytes []byte,
+) (statelessblock.Block, error) {
+	blk, err := vm.parseStatelessBlock(blkBytes)
+	if err != nil {
+		return nil, err
+	}
+	blkID := blk.ID()
+	if blkID != expectedID {
+		return nil, fmt.Errorf("%w: expected %s but got %s", errUnexpectedAncestor, expectedID, blkID)
+	}
+
+	innerBlk, err := vm.ChainVM.ParseBlock(ctx, blk.Block())
+	if err != nil {
+		return nil, err
+	}
+	if innerHeight := innerBlk.Height(); innerHeight != height {
+		return nil, fmt.Errorf("%w: expected height %d but got %d", errUnexpectedAncestor, height, innerHeight)
+	}
+
+	if err := vm.State.PutBlock(blk, choices.Accepted); err != nil {
+		return nil, err
+	}
+	if err := vm.State.PutInnerBlockID(blkID, innerBlk.ID()); err != nil {
+		return nil, err
+	}
+	if signedBlk, ok := blk.(statelessblock.SignedBlock); ok {
+		proposer := signedBlk.Proposer()
+		if err := vm.State.PutProposerAt(height, proposer); err != nil {
+			return nil, err
+		}
+		// Options are attributed to the proposer of their parent.
+		if childIsOption {
+			if err := vm.State.PutProposerAt(height+1, proposer); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return blk, vm.State.SetBlockIDAtHeight(height, blkID)
+}
@@ -0,0 +1,297 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/proposervm"
+)
+
+func TestAppRequestMux(t *testing.T) {
+	require := require.New(t)
+
+	sent := make(map[uint32][]byte)
+	sender := &common.SenderTest{
+		SendAppRequestF: func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, msg []byte) error {
+			sent[requestID] = msg
+			return nil
+		},
+	}
+
+	mux := newAppRequestMux()
+	innerSender := mux.Sender(sender, true)
+	outerSender := mux.Sender(sender, false)
+
+	nodeID := ids.GenerateTestNodeID()
+	nodeIDs := set.Of(nodeID)
+	require.NoError(innerSender.SendAppRequest(context.Background(), nodeIDs, 1, []byte{1}))
+	require.NoError(outerSender.SendAppRequest(context.Background(), nodeIDs, 1, []byte{2}))
+
+	// The requests were sent with different request IDs
+	require.Len(sent, 2)
+	for requestID, msg := range sent {
+		request, ok := mux.Resolve(nodeID, requestID)
+		require.True(ok)
+		require.Equal(uint32(1), request.requestID)
+		require.Equal(msg[0] == 1, request.fromInnerVM)
+
+		// Requests are only resolved once
+		_, ok = mux.Resolve(nodeID, requestID)
+		require.False(ok)
+	}
+}
+
+func TestBackfillBlocks(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	coreBlks := []*snowman.TestBlock{coreGenBlk}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range coreBlks {
+			if blk.ID() == blkID {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		for _, blk := range coreBlks {
+			if bytes.Equal(b, blk.Bytes()) {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	// Accept a chain of post-fork blocks
+	const numBlks = 4
+	proBlks := make([]snowman.Block, 0, numBlks)
+	for i := 0; i < numBlks; i++ {
+		parent := coreBlks[len(coreBlks)-1]
+		coreBlk := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			BytesV:     []byte{byte(i + 1)},
+			ParentV:    parent.ID(),
+			HeightV:    parent.Height() + 1,
+			TimestampV: parent.Timestamp(),
+		}
+		coreBlks = append(coreBlks, coreBlk)
+		coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+			return coreBlk, nil
+		}
+
+		proBlk, err := proVM.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(proBlk.Verify(context.Background()))
+		require.NoError(proBlk.Accept(context.Background()))
+		require.NoError(proVM.SetPreference(context.Background(), proBlk.ID()))
+		proBlks = append(proBlks, proBlk)
+		proVM.Set(proBlk.Timestamp().Add(proposer.MaxVerifyDelay))
+	}
+
+	// Nothing needs to be backfilled while all the blocks are stored
+	_, ok, err := proVM.getBackfillGap()
+	require.NoError(err)
+	require.False(ok)
+
+	// Serve the ancestors of the last accepted block
+	handler := &backfillHandler{vm: proVM}
+	lastBlkID := proBlks[numBlks-1].ID()
+	requestBytes, err := proto.Marshal(&pb.GetAncestorsRequest{
+		BlockId:   lastBlkID[:],
+		MaxBlocks: maxBackfillBlocks,
+	})
+	require.NoError(err)
+	responseBytes, err := handler.AppRequest(context.Background(), ids.EmptyNodeID, time.Time{}, requestBytes)
+	require.NoError(err)
+	response := &pb.GetAncestorsResponse{}
+	require.NoError(proto.Unmarshal(responseBytes, response))
+	require.Len(response.Blocks, numBlks)
+
+	// Drop all the blocks except for the last accepted block, as if this node
+	// had state synced to it.
+	for _, blk := range proBlks[:numBlks-1] {
+		require.NoError(proVM.State.DeleteBlockIDAtHeight(blk.Height()))
+		require.NoError(proVM.State.DeleteBlock(blk.ID()))
+		require.NoError(proVM.State.DeleteInnerBlockID(blk.ID()))
+	}
+
+	gap, ok, err := proVM.getBackfillGap()
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(proBlks[numBlks-2].ID(), gap.blkID)
+	require.Equal(proBlks[numBlks-2].Height(), gap.height)
+	require.Equal(proBlks[0].Height(), gap.minHeight)
+
+	// Blocks that don't match the gap are rejected
+	err = proVM.storeBackfilledBlocks(context.Background(), gap, response.Blocks)
+	require.ErrorIs(err, errUnexpectedAncestor)
+
+	// Valid blocks before the first invalid block are still stored
+	invalidBlks := [][]byte{response.Blocks[1], response.Blocks[3]}
+	err = proVM.storeBackfilledBlocks(context.Background(), gap, invalidBlks)
+	require.ErrorIs(err, errUnexpectedAncestor)
+
+	gap, ok, err = proVM.getBackfillGap()
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(proBlks[numBlks-3].ID(), gap.blkID)
+
+	require.NoError(proVM.storeBackfilledBlocks(context.Background(), gap, response.Blocks[2:]))
+
+	_, ok, err = proVM.getBackfillGap()
+	require.NoError(err)
+	require.False(ok)
+
+	for i, blk := range proBlks {
+		blkID, err := proVM.GetBlockIDAtHeight(context.Background(), blk.Height())
+		require.NoError(err)
+		require.Equal(blk.ID(), blkID)
+
+		innerBlkID, err := proVM.State.GetInnerBlockID(blk.ID())
+		require.NoError(err)
+		require.Equal(coreBlks[i+1].ID(), innerBlkID)
+	}
+}
+
+// Backfill requests should be shed while the inner VM's app handlers are busy,
+// but the inner VM's messages should always be handled.
+func TestBackfillRequestsShedUnderLoad(t *testing.T) {
+	require := require.New(t)
+
+	coreGenBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV:    0,
+		TimestampV: genesisTimestamp,
+		BytesV:     []byte{0},
+	}
+
+	coreVM := &block.TestVM{}
+	coreVM.T = t
+	coreVM.InitializeF = func(
+		context.Context,
+		*snow.Context,
+		database.Database,
+		[]byte,
+		[]byte,
+		[]byte,
+		chan<- common.Message,
+		[]*common.Fx,
+		common.AppSender,
+	) error {
+		return nil
+	}
+	coreVM.VerifyHeightIndexF = func(context.Context) error {
+		return nil
+	}
+	coreVM.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return coreGenBlk.ID(), nil
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		if blkID == coreGenBlk.ID() {
+			return coreGenBlk, nil
+		}
+		return nil, errUnknownBlock
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		if bytes.Equal(b, coreGenBlk.Bytes()) {
+			return coreGenBlk, nil
+		}
+		return nil, errUnknownBlock
+	}
+
+	proVM := New(
+		coreVM,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			AppConcurrency:      2,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
+	)
+
+	numResponses := 0
+	sender := &common.SenderTest{
+		T: t,
+		SendAppResponseF: func(context.Context, ids.NodeID, uint32, []byte) error {
+			numResponses++
+			return nil
+		},
+	}
+
+	ctx := snow.DefaultContextTest()
+	ctx.NodeID = ids.NodeIDFromCert(pTestCert)
+	require.NoError(proVM.Initialize(
+		context.Background(),
+		ctx,
+		memdb.New(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		sender,
+	))
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	genesisID := coreGenBlk.ID()
+	requestBytes, err := proto.Marshal(&pb.GetAncestorsRequest{
+		BlockId:   genesisID[:],
+		MaxBlocks: 1,
+	})
+	require.NoError(err)
+	request := binary.AppendUvarint(nil, backfillHandlerID)
+	request = append(request, requestBytes...)
+
+	// Backfill requests are served while the inner VM is idle
+	require.NoError(proVM.AppRequest(context.Background(), ids.EmptyNodeID, 1, time.Time{}, request))
+	require.Equal(1, numResponses)
+
+	// Backfill requests are shed while the inner VM is handling a message
+	innerHandled := false
+	coreVM.AppRequestF = func(ctx context.Context, nodeID ids.NodeID, _ uint32, _ time.Time, _ []byte) error {
+		innerHandled = true
+		require.Equal(1, proVM.prioritizer.Processing(p2p.ConsensusPriority))
+		return proVM.AppRequest(ctx, nodeID, 2, time.Time{}, request)
+	}
+	require.NoError(proVM.AppRequest(context.Background(), ids.EmptyNodeID, 3, time.Time{}, []byte{0}))
+	require.True(innerHandled)
+	require.Equal(1, numResponses)
+	require.Zero(proVM.prioritizer.Processing(p2p.ConsensusPriority))
+}
@@ -0,0 +1,219 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+
+	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+// maxBatchSize is the maximum number of blocks that will be requested from an
+// inner VM that supports batched block building.
+const maxBatchSize = 16
+
+var (
+	errEmptyBatch         = errors.New("inner VM built an empty batch")
+	errBatchTooLarge      = errors.New("inner VM built too many blocks")
+	errBatchNotContiguous = errors.New("inner VM built a batch that isn't a chain")
+)
+
+// buildBatch builds a chain of children of this block using an inner VM that
+// supports batched block building. Either every inner block is wrapped, or an
+// error is returned. The first block of the chain is returned and the remaining
+// blocks are returned by subsequent calls to BuildBlock.
+//
+// Only the first block of the chain may be built outside of the first proposer
+// window. Every following block is built at the same timestamp as its parent,
+// so the batch is limited to the heights at which the node ID of [key] is the
+// first proposer. Every block is signed with [key].
+//
+// To verify every inner block with the P-chain height provided to the inner
+// VM, every block in the batch other than the last is built at
+// [parentPChainHeight]. The last block is built at [pChainHeight].
+func (p *postForkCommonComponents) buildBatch(
+	ctx context.Context,
+	parentID ids.ID,
+	parentTimestamp time.Time,
+	newTimestamp time.Time,
+	parentPChainHeight uint64,
+	pChainHeight uint64,
+	key *stakingKey,
+) (Block, error) {
+	parentHeight := p.innerBlk.Height()
+	maxBlocks, err := p.vm.maxBatchSize(ctx, key.nodeID, newTimestamp, parentHeight, parentPChainHeight)
+	if err != nil {
+		p.vm.ctx.Log.Error("unexpected build block failure",
+			zap.String("reason", "failed to calculate batch size"),
+			zap.Stringer("parentID", parentID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	innerBlks, err := p.vm.batchedBuildVM.BatchedBuildBlock(
+		ctx,
+		&smblock.Context{
+			PChainHeight: parentPChainHeight,
+		},
+		maxBlocks,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	numBlks := len(innerBlks)
+	switch {
+	case numBlks == 0:
+		return nil, errEmptyBatch
+	case numBlks > maxBlocks:
+		return nil, fmt.Errorf("%w: %d > %d", errBatchTooLarge, numBlks, maxBlocks)
+	}
+
+	var (
+		delay       = newTimestamp.Sub(parentTimestamp)
+		children    = make([]*postForkBlock, numBlks)
+		prevID      = parentID
+		prevInnerID = p.innerBlk.ID()
+	)
+	for i, innerBlk := range innerBlks {
+		if innerParentID := innerBlk.Parent(); innerParentID != prevInnerID {
+			return nil, fmt.Errorf("%w: block %d has parent %s but expected %s",
+				errBatchNotContiguous,
+				i,
+				innerParentID,
+				prevInnerID,
+			)
+		}
+
+		childPChainHeight := parentPChainHeight
+		if i == numBlks-1 {
+			childPChainHeight = pChainHeight
+		}
+
+		// Only the first block may be built after the proposer windows have
+		// ended. Every other block has the same timestamp as its parent.
+		signed := i > 0 || delay < proposer.MaxVerifyDelay
+		var vrfProof []byte
+		if signed {
+			vrfProof, err = p.vm.vrfProof(ctx, key.nodeID, prevID, parentPChainHeight, newTimestamp)
+			if err != nil {
+				p.vm.ctx.Log.Error("unexpected build block failure",
+					zap.String("reason", "failed to generate VRF proof"),
+					zap.Stringer("parentID", prevID),
+					zap.Error(err),
+				)
+				return nil, err
+			}
+		}
+
+		statelessChild, err := p.vm.buildStatelessBlock(
+			ctx,
+			prevID,
+			newTimestamp,
+			childPChainHeight,
+			key,
+			signed,
+			vrfProof,
+			innerBlk.Bytes(),
+		)
+		if err != nil {
+			p.vm.ctx.Log.Error("unexpected build block failure",
+				zap.String("reason", "failed to generate proposervm block header"),
+				zap.Stringer("parentID", prevID),
+				zap.Stringer("blkID", innerBlk.ID()),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+
+		children[i] = &postForkBlock{
+			SignedBlock: statelessChild,
+			postForkCommonComponents: postForkCommonComponents{
+				vm:       p.vm,
+				innerBlk: innerBlk,
+				status:   choices.Processing,
+			},
+		}
+		prevID = statelessChild.ID()
+		prevInnerID = innerBlk.ID()
+	}
+
+	p.vm.pendingBatch = children[1:]
+	if len(p.vm.pendingBatch) > 0 {
+		p.vm.notifyInnerBlockReady()
+	}
+
+	child := children[0]
+	p.vm.ctx.Log.Info("built block batch",
+		zap.Stringer("blkID", child.ID()),
+		zap.Stringer("innerBlkID", child.innerBlk.ID()),
+		zap.Uint64("height", child.Height()),
+		zap.Int("numBlocks", numBlks),
+		zap.Time("parentTimestamp", parentTimestamp),
+		zap.Time("blockTimestamp", newTimestamp),
+	)
+	return child, nil
+}
+
+// maxBatchSize returns the number of consecutive blocks, starting at
+// [parentHeight]+1, that [nodeID] may build at the same timestamp. The first
+// block is assumed to be buildable.
+//
+// If a minimum block delay is enforced at [timestamp], blocks can't share a
+// timestamp with their parent, so only a single block may be built.
+func (vm *VM) maxBatchSize(ctx context.Context, nodeID ids.NodeID, timestamp time.Time, parentHeight uint64, pChainHeight uint64) (int, error) {
+	if vm.enforcedMinBlkDelay(timestamp) > 0 {
+		return 1, nil
+	}
+
+	size := 1
+	for size < maxBatchSize {
+		height := parentHeight + uint64(size) + 1
+		delay, err := vm.Windower.Delay(ctx, height, pChainHeight, nodeID, proposer.MaxBuildWindows)
+		if err != nil {
+			return 0, err
+		}
+		if delay > 0 {
+			break
+		}
+		size++
+	}
+	return size, nil
+}
+
+// nextBatchedBlock returns the next block of a previously built batch if it is
+// a child of the preferred block. If the preference has moved away from the
+// batch, the remaining blocks are dropped.
+func (vm *VM) nextBatchedBlock() (Block, bool) {
+	if len(vm.pendingBatch) == 0 {
+		return nil, false
+	}
+
+	blk := vm.pendingBatch[0]
+	if blk.Parent() != vm.preferred {
+		vm.ctx.Log.Debug("dropping batched blocks",
+			zap.Stringer("preferredID", vm.preferred),
+			zap.Stringer("parentID", blk.Parent()),
+			zap.Int("numBlocks", len(vm.pendingBatch)),
+		)
+		vm.pendingBatch = nil
+		return nil, false
+	}
+
+	vm.pendingBatch = vm.pendingBatch[1:]
+	if len(vm.pendingBatch) > 0 {
+		vm.notifyInnerBlockReady()
+	}
+	return blk, true
+}
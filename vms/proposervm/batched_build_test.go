@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+var _ block.BatchedBuildChainVM = (*testBatchedBuildVM)(nil)
+
+type testBatchedBuildVM struct {
+	batchedBuildBlockF func(context.Context, *block.Context, int) ([]snowman.Block, error)
+}
+
+func (vm *testBatchedBuildVM) BatchedBuildBlock(ctx context.Context, blockCtx *block.Context, maxBlocks int) ([]snowman.Block, error) {
+	return vm.batchedBuildBlockF(ctx, blockCtx, maxBlocks)
+}
+
+// initBatchedBuildTest initializes a proposervm whose only validator is the
+// local node and builds, verifies, and accepts the first post-fork block. The
+// returned map is used by the inner VM to get and parse blocks.
+func initBatchedBuildTest(t *testing.T) (*VM, map[ids.ID]snowman.Block, snowman.Block) {
+	require := require.New(t)
+
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	t.Cleanup(func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	})
+
+	valState.GetValidatorSetF = func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+		return map[ids.NodeID]*validators.GetValidatorOutput{
+			proVM.ctx.NodeID: {
+				NodeID: proVM.ctx.NodeID,
+				Weight: 1,
+			},
+		}, nil
+	}
+
+	coreBlks := map[ids.ID]snowman.Block{
+		coreGenBlk.ID(): coreGenBlk,
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		blk, ok := coreBlks[blkID]
+		if !ok {
+			return nil, errUnknownBlock
+		}
+		return blk, nil
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		for _, blk := range coreBlks {
+			if bytes.Equal(b, blk.Bytes()) {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	coreBlk := newBatchedTestBlock(coreGenBlk, 1)
+	coreBlks[coreBlk.ID()] = coreBlk
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk, nil
+	}
+
+	require.NoError(proVM.SetPreference(context.Background(), coreGenBlk.ID()))
+	blk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(proVM.SetPreference(context.Background(), blk.ID()))
+
+	coreVM.BuildBlockF = nil
+	proVM.Set(proVM.Time().Add(proposer.WindowDuration / 2))
+	return proVM, coreBlks, coreBlk
+}
+
+func newBatchedTestBlock(parent snowman.Block, i byte) *snowman.TestBlock {
+	return &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{i},
+		ParentV:    parent.ID(),
+		HeightV:    parent.Height() + 1,
+		TimestampV: parent.Timestamp(),
+	}
+}
+
+func TestBatchedBuildBlock(t *testing.T) {
+	require := require.New(t)
+
+	proVM, coreBlks, coreParent := initBatchedBuildTest(t)
+
+	var (
+		parent    = coreParent
+		innerBlks = make([]snowman.Block, 3)
+	)
+	for i := range innerBlks {
+		innerBlk := newBatchedTestBlock(parent, byte(i+2))
+		coreBlks[innerBlk.ID()] = innerBlk
+		innerBlks[i] = innerBlk
+		parent = innerBlk
+	}
+
+	numCalls := 0
+	proVM.batchedBuildVM = &testBatchedBuildVM{
+		batchedBuildBlockF: func(_ context.Context, _ *block.Context, maxBlocks int) ([]snowman.Block, error) {
+			numCalls++
+			require.Equal(maxBatchSize, maxBlocks)
+			return innerBlks, nil
+		},
+	}
+
+	for i, innerBlk := range innerBlks {
+		blk, err := proVM.BuildBlock(context.Background())
+		require.NoError(err)
+
+		postForkBlk, ok := blk.(*postForkBlock)
+		require.True(ok)
+		require.Equal(innerBlk.ID(), postForkBlk.innerBlk.ID())
+		require.Equal(proVM.preferred, blk.Parent())
+		require.Equal(proVM.ctx.NodeID, postForkBlk.Proposer())
+		require.Equal(len(innerBlks)-i-1, len(proVM.pendingBatch))
+
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(proVM.SetPreference(context.Background(), blk.ID()))
+	}
+	require.Equal(1, numCalls)
+}
+
+func TestBatchedBuildBlockDropsStaleBatch(t *testing.T) {
+	require := require.New(t)
+
+	proVM, coreBlks, coreParent := initBatchedBuildTest(t)
+
+	innerBlk0 := newBatchedTestBlock(coreParent, 2)
+	innerBlk1 := newBatchedTestBlock(innerBlk0, 3)
+	coreBlks[innerBlk0.ID()] = innerBlk0
+	coreBlks[innerBlk1.ID()] = innerBlk1
+
+	numCalls := 0
+	proVM.batchedBuildVM = &testBatchedBuildVM{
+		batchedBuildBlockF: func(context.Context, *block.Context, int) ([]snowman.Block, error) {
+			numCalls++
+			return []snowman.Block{innerBlk0, innerBlk1}, nil
+		},
+	}
+
+	blk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.Len(proVM.pendingBatch, 1)
+
+	// The preference remains on the parent of the batch, so the rest of the
+	// batch can no longer be issued.
+	_, err = proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.Equal(2, numCalls)
+	require.Len(proVM.pendingBatch, 1)
+}
+
+func TestBatchedBuildBlockInvalidBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		innerBlks   func(parent snowman.Block) []snowman.Block
+		expectedErr error
+	}{
+		{
+			name: "empty",
+			innerBlks: func(snowman.Block) []snowman.Block {
+				return nil
+			},
+			expectedErr: errEmptyBatch,
+		},
+		{
+			name: "too large",
+			innerBlks: func(parent snowman.Block) []snowman.Block {
+				blks := make([]snowman.Block, maxBatchSize+1)
+				for i := range blks {
+					blk := newBatchedTestBlock(parent, byte(i+2))
+					blks[i] = blk
+					parent = blk
+				}
+				return blks
+			},
+			expectedErr: errBatchTooLarge,
+		},
+		{
+			name: "not contiguous",
+			innerBlks: func(parent snowman.Block) []snowman.Block {
+				return []snowman.Block{
+					newBatchedTestBlock(parent, 2),
+					newBatchedTestBlock(parent, 3),
+				}
+			},
+			expectedErr: errBatchNotContiguous,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			proVM, _, coreParent := initBatchedBuildTest(t)
+			proVM.batchedBuildVM = &testBatchedBuildVM{
+				batchedBuildBlockF: func(context.Context, *block.Context, int) ([]snowman.Block, error) {
+					return tt.innerBlks(coreParent), nil
+				},
+			}
+
+			_, err := proVM.BuildBlock(context.Background())
+			require.ErrorIs(err, tt.expectedErr)
+			require.Empty(proVM.pendingBatch)
+		})
+	}
+}
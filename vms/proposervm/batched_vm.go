@@ -9,6 +9,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
@@ -101,7 +102,7 @@ func (vm *VM) BatchedParseBlock(ctx context.Context, blks [][]byte) ([]snowman.B
 	)
 	for ; blocksIndex < len(blks); blocksIndex++ {
 		blkBytes := blks[blocksIndex]
-		statelessBlock, err := statelessblock.Parse(blkBytes)
+		statelessBlock, err := vm.parseStatelessBlock(blkBytes)
 		if err != nil {
 			break
 		}
@@ -126,6 +127,13 @@ func (vm *VM) BatchedParseBlock(ctx context.Context, blks [][]byte) ([]snowman.B
 	if err != nil {
 		return nil, err
 	}
+
+	// Signatures are only checked during normal operations, so only then are
+	// the signatures of the processing blocks checked ahead of verification.
+	var toVerify []statelessblock.SignedBlock
+	if vm.consensusState == snow.NormalOp {
+		toVerify = make([]statelessblock.SignedBlock, 0, len(statelessBlockDescs))
+	}
 	for ; innerBlocksIndex < len(statelessBlockDescs); innerBlocksIndex++ {
 		statelessBlockDesc := statelessBlockDescs[innerBlocksIndex]
 		statelessBlk := statelessBlockDesc.block
@@ -139,6 +147,9 @@ func (vm *VM) BatchedParseBlock(ctx context.Context, blks [][]byte) ([]snowman.B
 		}
 
 		if statelessSignedBlock, ok := statelessBlk.(statelessblock.SignedBlock); ok {
+			if toVerify != nil && status == choices.Processing {
+				toVerify = append(toVerify, statelessSignedBlock)
+			}
 			blocks[statelessBlockDesc.index] = &postForkBlock{
 				SignedBlock: statelessSignedBlock,
 				postForkCommonComponents: postForkCommonComponents{
@@ -158,6 +169,8 @@ func (vm *VM) BatchedParseBlock(ctx context.Context, blks [][]byte) ([]snowman.B
 			}
 		}
 	}
+	vm.signatureVerifier.Submit(toVerify...)
+
 	for ; blocksIndex < len(blocks); blocksIndex, innerBlocksIndex = blocksIndex+1, innerBlocksIndex+1 {
 		blocks[blocksIndex] = &preForkBlock{
 			Block: innerBlks[innerBlocksIndex],
@@ -35,6 +35,7 @@ var (
 	errPChainHeightNotReached   = errors.New("block P-chain height larger than current P-chain height")
 	errTimeTooAdvanced          = errors.New("time is too far advanced")
 	errProposerWindowNotStarted = errors.New("proposer window hasn't started")
+	errBlockDelayTooShort       = errors.New("block delay is less than the minimum block delay")
 	errProposersNotActivated    = errors.New("proposers haven't been activated yet")
 	errPChainHeightTooLow       = errors.New("block P-chain height is too low")
 )
@@ -85,11 +86,19 @@ func (p *postForkCommonComponents) Height() uint64 {
 // 2) [child]'s P-Chain height >= [parentPChainHeight]
 // 3) [p]'s inner block is the parent of [c]'s inner block
 // 4) [child]'s timestamp isn't before [p]'s timestamp
-// 5) [child]'s timestamp is within the skew bound
-// 6) [childPChainHeight] <= the current P-Chain height
-// 7) [child]'s timestamp is within its proposer's window
-// 8) [child] has a valid signature from its proposer
-// 9) [child]'s inner block is valid
+// 5) [child]'s timestamp is at least the enforced minimum block delay after
+// [p]'s timestamp
+// 6) [child]'s timestamp is within the skew bound
+// 7) [childPChainHeight] is at an epoch boundary, if epochs are enforced
+// 8) [child] only commits to a validator set once commitments are activated
+// 9) [childPChainHeight] <= the current P-Chain height
+// 10) [child]'s timestamp is within its proposer's window
+// 11) [child] has a valid signature from its proposer
+// 12) [child]'s committed validator set, if provided, is the validator set at
+// [childPChainHeight]
+// 13) [child] provides a valid VRF proof if VRF proofs are active and its
+// proposer has a registered BLS key. Otherwise, it doesn't provide a VRF proof.
+// 14) [child]'s inner block is valid
 func (p *postForkCommonComponents) Verify(
 	ctx context.Context,
 	parentTimestamp time.Time,
@@ -116,16 +125,33 @@ func (p *postForkCommonComponents) Verify(
 		return errTimeNotMonotonic
 	}
 
+	enforcedMinDelay := p.vm.enforcedMinBlkDelay(childTimestamp)
+	if delay := childTimestamp.Sub(parentTimestamp); delay < enforcedMinDelay {
+		return fmt.Errorf("%w: %s < %s",
+			errBlockDelayTooShort,
+			delay,
+			enforcedMinDelay,
+		)
+	}
+
 	maxTimestamp := p.vm.Time().Add(maxSkew)
 	if childTimestamp.After(maxTimestamp) {
 		return errTimeTooAdvanced
 	}
 
+	if err := p.vm.verifyPChainHeightEpoch(childTimestamp, parentPChainHeight, childPChainHeight); err != nil {
+		return err
+	}
+
+	if err := p.vm.verifyValidatorSetHashActivated(child.SignedBlock); err != nil {
+		return err
+	}
+
 	// If the node is currently syncing - we don't assume that the P-chain has
 	// been synced up to this point yet.
 	if p.vm.consensusState == snow.NormalOp {
 		childID := child.ID()
-		currentPChainHeight, err := p.vm.ctx.ValidatorState.GetCurrentHeight(ctx)
+		currentPChainHeight, err := p.vm.validatorState.GetCurrentHeight(ctx)
 		if err != nil {
 			p.vm.ctx.Log.Error("block verification failed",
 				zap.String("reason", "failed to get current P-Chain height"),
@@ -156,10 +182,38 @@ func (p *postForkCommonComponents) Verify(
 
 		// Verify the signature of the node
 		shouldHaveProposer := delay < proposer.MaxVerifyDelay
-		if err := child.SignedBlock.Verify(shouldHaveProposer, p.vm.ctx.ChainID); err != nil {
+		if shouldHaveProposer {
+			// The signature may have already been checked when the block was
+			// parsed.
+			if err := p.vm.signatureVerifier.Verify(child.SignedBlock); err != nil {
+				return err
+			}
+		} else if err := child.SignedBlock.Verify(false, p.vm.ctx.ChainID); err != nil {
+			return err
+		}
+
+		// The validator set is only fetched once the block is known to be
+		// signed by its proposer, if it should have one.
+		if err := p.vm.verifyValidatorSetHash(ctx, child.SignedBlock); err != nil {
+			return err
+		}
+
+		// Verify the VRF proof of the node
+		if err := p.vm.verifyVRFProof(ctx, parentPChainHeight, child.SignedBlock); err != nil {
 			return err
 		}
 
+		if shouldHaveProposer {
+			windowIndex := uint64(delay / proposer.WindowDuration)
+			p.vm.trackProposal(ctx, child.SignedBlock, windowIndex)
+		}
+
+		// Blocks built by this node would only compare the local clock with
+		// itself.
+		if !p.vm.isLocalProposer(proposerID) {
+			p.vm.clockSkew.Observe(p.vm.Time(), childTimestamp)
+		}
+
 		p.vm.ctx.Log.Debug("verified post-fork block",
 			zap.Stringer("blkID", childID),
 			zap.Time("parentTimestamp", parentTimestamp),
@@ -168,10 +222,17 @@ func (p *postForkCommonComponents) Verify(
 		)
 	}
 
+	ctx = p.vm.withProposerContext(
+		ctx,
+		child.Proposer(),
+		childTimestamp.Sub(parentTimestamp),
+		childPChainHeight,
+	)
 	return p.vm.verifyAndRecordInnerBlk(
 		ctx,
 		&smblock.Context{
 			PChainHeight: parentPChainHeight,
+			VRFOutput:    vrfOutput(child.SignedBlock),
 		},
 		child,
 	)
@@ -184,12 +245,39 @@ func (p *postForkCommonComponents) buildChild(
 	parentTimestamp time.Time,
 	parentPChainHeight uint64,
 ) (Block, error) {
+	// A block built with a skewed clock would likely be rejected by the other
+	// validators.
+	if err := p.vm.clockSkew.Verify(p.vm.MaxClockSkew); err != nil {
+		p.vm.ctx.Log.Warn("build block dropped",
+			zap.String("reason", "local clock is skewed"),
+			zap.Stringer("parentID", parentID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
 	// Child's timestamp is the later of now and this block's timestamp
 	newTimestamp := p.vm.Time().Truncate(time.Second)
 	if newTimestamp.Before(parentTimestamp) {
 		newTimestamp = parentTimestamp
 	}
 
+	// Building the child before the enforced minimum block delay has passed
+	// would produce an invalid block.
+	enforcedMinDelay := p.vm.enforcedMinBlkDelay(newTimestamp)
+	if newTimestamp.Sub(parentTimestamp) < enforcedMinDelay {
+		p.vm.ctx.Log.Debug("build block dropped",
+			zap.String("reason", "minimum block delay hasn't passed"),
+			zap.Time("parentTimestamp", parentTimestamp),
+			zap.Duration("minBlockDelay", enforcedMinDelay),
+			zap.Time("blockTimestamp", newTimestamp),
+		)
+
+		// Attempt to build the block again once the delay has passed.
+		p.vm.notifyInnerBlockReady()
+		return nil, errBlockDelayTooShort
+	}
+
 	// The child's P-Chain height is proposed as the optimal P-Chain height that
 	// is at least the parent's P-Chain height
 	pChainHeight, err := p.vm.optimalPChainHeight(ctx, parentPChainHeight)
@@ -202,11 +290,12 @@ func (p *postForkCommonComponents) buildChild(
 		return nil, err
 	}
 
+	key := p.vm.preferredStakingKey()
 	delay := newTimestamp.Sub(parentTimestamp)
 	if delay < proposer.MaxBuildDelay {
 		parentHeight := p.innerBlk.Height()
-		proposerID := p.vm.ctx.NodeID
-		minDelay, err := p.vm.Windower.Delay(ctx, parentHeight+1, parentPChainHeight, proposerID, proposer.MaxBuildWindows)
+		var minDelay time.Duration
+		key, minDelay, err = p.vm.buildStakingKey(ctx, parentHeight+1, parentPChainHeight)
 		if err != nil {
 			p.vm.ctx.Log.Error("unexpected build block failure",
 				zap.String("reason", "failed to calculate required timestamp delay"),
@@ -235,10 +324,37 @@ func (p *postForkCommonComponents) buildChild(
 		}
 	}
 
+	if p.vm.batchedBuildVM != nil {
+		return p.buildBatch(ctx, parentID, parentTimestamp, newTimestamp, parentPChainHeight, pChainHeight, key)
+	}
+
+	// The VRF proof only depends on the parent, so it is generated before the
+	// inner block is built to provide its output to the inner VM.
+	signed := delay < proposer.MaxVerifyDelay
+	var vrfProof []byte
+	if signed {
+		vrfProof, err = p.vm.vrfProof(ctx, key.nodeID, parentID, parentPChainHeight, newTimestamp)
+		if err != nil {
+			p.vm.ctx.Log.Error("unexpected build block failure",
+				zap.String("reason", "failed to generate VRF proof"),
+				zap.Stringer("parentID", parentID),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+	}
+
+	proposerID := ids.EmptyNodeID
+	if signed {
+		proposerID = key.nodeID
+	}
+	ctx = p.vm.withProposerContext(ctx, proposerID, delay, pChainHeight)
+
 	var innerBlock snowman.Block
 	if p.vm.blockBuilderVM != nil {
 		innerBlock, err = p.vm.blockBuilderVM.BuildBlockWithContext(ctx, &smblock.Context{
 			PChainHeight: parentPChainHeight,
+			VRFOutput:    vrfOutputFromProof(vrfProof),
 		})
 	} else {
 		innerBlock, err = p.vm.ChainVM.BuildBlock(ctx)
@@ -248,25 +364,16 @@ func (p *postForkCommonComponents) buildChild(
 	}
 
 	// Build the child
-	var statelessChild block.SignedBlock
-	if delay >= proposer.MaxVerifyDelay {
-		statelessChild, err = block.BuildUnsigned(
-			parentID,
-			newTimestamp,
-			pChainHeight,
-			innerBlock.Bytes(),
-		)
-	} else {
-		statelessChild, err = block.Build(
-			parentID,
-			newTimestamp,
-			pChainHeight,
-			p.vm.stakingCertLeaf,
-			innerBlock.Bytes(),
-			p.vm.ctx.ChainID,
-			p.vm.stakingLeafSigner,
-		)
-	}
+	statelessChild, err := p.vm.buildStatelessBlock(
+		ctx,
+		parentID,
+		newTimestamp,
+		pChainHeight,
+		key,
+		signed,
+		vrfProof,
+		innerBlock.Bytes(),
+	)
 	if err != nil {
 		p.vm.ctx.Log.Error("unexpected build block failure",
 			zap.String("reason", "failed to generate proposervm block header"),
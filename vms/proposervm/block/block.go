@@ -4,6 +4,7 @@
 package block
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"time"
@@ -37,6 +38,18 @@ type SignedBlock interface {
 	PChainHeight() uint64
 	Timestamp() time.Time
 	Proposer() ids.NodeID
+	// ValidatorSetHash returns the committed hash of the validator set at
+	// PChainHeight. If the block doesn't commit to the validator set,
+	// ids.Empty is returned.
+	ValidatorSetHash() ids.ID
+	// Version returns the codec version the block was encoded with.
+	Version() uint16
+	// Extensions returns the extensions of the block. Only blocks encoded with
+	// CodecVersion1 or later may have extensions.
+	Extensions() []byte
+	// VRFProof returns the proposer's VRF proof. Only blocks encoded with
+	// CodecVersion1 or later may have a VRF proof.
+	VRFProof() []byte
 
 	Verify(shouldHaveProposer bool, chainID ids.ID) error
 }
@@ -113,6 +126,22 @@ func (b *statelessBlock) Proposer() ids.NodeID {
 	return b.proposer
 }
 
+func (*statelessBlock) ValidatorSetHash() ids.ID {
+	return ids.Empty
+}
+
+func (b *statelessBlock) Version() uint16 {
+	return binary.BigEndian.Uint16(b.bytes)
+}
+
+func (*statelessBlock) Extensions() []byte {
+	return nil
+}
+
+func (*statelessBlock) VRFProof() []byte {
+	return nil
+}
+
 func (b *statelessBlock) Verify(shouldHaveProposer bool, chainID ids.ID) error {
 	if !shouldHaveProposer {
 		if len(b.Signature) > 0 || len(b.StatelessBlock.Certificate) > 0 {
@@ -21,6 +21,8 @@ func equal(require *require.Assertions, chainID ids.ID, want, have SignedBlock)
 	require.Equal(want.Timestamp(), have.Timestamp())
 	require.Equal(want.Block(), have.Block())
 	require.Equal(want.Proposer(), have.Proposer())
+	require.Equal(want.Version(), have.Version())
+	require.True(bytes.Equal(want.Extensions(), have.Extensions()))
 	require.Equal(want.Bytes(), have.Bytes())
 	require.Equal(want.Verify(false, chainID), have.Verify(false, chainID))
 	require.Equal(want.Verify(true, chainID), have.Verify(true, chainID))
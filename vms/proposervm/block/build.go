@@ -6,6 +6,8 @@ package block
 import (
 	"crypto"
 	"crypto/rand"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -14,6 +16,11 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+var (
+	errUnsupportedCodecVersion = errors.New("unsupported codec version")
+	errExtensionsNotSupported  = errors.New("extensions aren't supported")
+)
+
 func BuildUnsigned(
 	parentID ids.ID,
 	timestamp time.Time,
@@ -59,12 +66,168 @@ func Build(
 		cert:      cert,
 		proposer:  ids.NodeIDFromCert(cert),
 	}
-	var blockIntf SignedBlock = block
+	return block, sign(codecVersion, block, parentID, &block.Signature, &block.id, &block.bytes, chainID, key)
+}
 
-	unsignedBytesWithEmptySignature, err := c.Marshal(codecVersion, &blockIntf)
+// BuildUnsignedExtended is the same as BuildUnsigned, but the returned block is
+// encoded with [CodecVersion1] and carries [extensions].
+func BuildUnsignedExtended(
+	parentID ids.ID,
+	timestamp time.Time,
+	pChainHeight uint64,
+	extensions Extensions,
+	blockBytes []byte,
+) (SignedBlock, error) {
+	blockBytes, err := encodeInnerBlock(extensions, blockBytes)
+	if err != nil {
+		return nil, err
+	}
+	return BuildUnsignedVersioned(
+		CodecVersion1,
+		parentID,
+		timestamp,
+		pChainHeight,
+		extensions.Bytes(),
+		blockBytes,
+	)
+}
+
+// BuildExtended is the same as Build, but the returned block is encoded with
+// [CodecVersion1] and carries [extensions].
+func BuildExtended(
+	parentID ids.ID,
+	timestamp time.Time,
+	pChainHeight uint64,
+	extensions Extensions,
+	cert *staking.Certificate,
+	blockBytes []byte,
+	chainID ids.ID,
+	key crypto.Signer,
+) (SignedBlock, error) {
+	blockBytes, err := encodeInnerBlock(extensions, blockBytes)
 	if err != nil {
 		return nil, err
 	}
+	return BuildVersioned(
+		CodecVersion1,
+		parentID,
+		timestamp,
+		pChainHeight,
+		extensions.Bytes(),
+		cert,
+		blockBytes,
+		chainID,
+		key,
+	)
+}
+
+// encodeInnerBlock returns [blockBytes] as they are encoded in a block with
+// [extensions].
+func encodeInnerBlock(extensions Extensions, blockBytes []byte) ([]byte, error) {
+	if !extensions.Compress {
+		return blockBytes, nil
+	}
+	compressedBlockBytes, err := zstdCompressor.Compress(blockBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress inner block: %w", err)
+	}
+	return compressedBlockBytes, nil
+}
+
+// BuildUnsignedVersioned is the same as BuildUnsigned, but the returned block
+// is encoded with codec [version]. Extensions are only supported by
+// [CodecVersion1] and later.
+func BuildUnsignedVersioned(
+	version uint16,
+	parentID ids.ID,
+	timestamp time.Time,
+	pChainHeight uint64,
+	extensions []byte,
+	blockBytes []byte,
+) (SignedBlock, error) {
+	switch version {
+	case CodecVersion0:
+		if len(extensions) != 0 {
+			return nil, fmt.Errorf("%w: codec version %d", errExtensionsNotSupported, version)
+		}
+		return BuildUnsigned(parentID, timestamp, pChainHeight, blockBytes)
+	case CodecVersion1:
+		var block SignedBlock = &statelessExtendedBlock{
+			StatelessBlock: statelessUnsignedExtendedBlock{
+				ParentID:     parentID,
+				Timestamp:    timestamp.Unix(),
+				PChainHeight: pChainHeight,
+				Certificate:  nil,
+				Block:        blockBytes,
+				Extensions:   extensions,
+			},
+		}
+
+		bytes, err := c.Marshal(version, &block)
+		if err != nil {
+			return nil, err
+		}
+		return block, block.initialize(bytes)
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnsupportedCodecVersion, version)
+	}
+}
+
+// BuildVersioned is the same as Build, but the returned block is encoded with
+// codec [version]. Extensions are only supported by [CodecVersion1] and later.
+func BuildVersioned(
+	version uint16,
+	parentID ids.ID,
+	timestamp time.Time,
+	pChainHeight uint64,
+	extensions []byte,
+	cert *staking.Certificate,
+	blockBytes []byte,
+	chainID ids.ID,
+	key crypto.Signer,
+) (SignedBlock, error) {
+	switch version {
+	case CodecVersion0:
+		if len(extensions) != 0 {
+			return nil, fmt.Errorf("%w: codec version %d", errExtensionsNotSupported, version)
+		}
+		return Build(parentID, timestamp, pChainHeight, cert, blockBytes, chainID, key)
+	case CodecVersion1:
+		block := &statelessExtendedBlock{
+			StatelessBlock: statelessUnsignedExtendedBlock{
+				ParentID:     parentID,
+				Timestamp:    timestamp.Unix(),
+				PChainHeight: pChainHeight,
+				Certificate:  cert.Raw,
+				Block:        blockBytes,
+				Extensions:   extensions,
+			},
+		}
+		if err := sign(version, block, parentID, &block.Signature, &block.id, &block.bytes, chainID, key); err != nil {
+			return nil, err
+		}
+		return block, block.initialize(block.bytes)
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnsupportedCodecVersion, version)
+	}
+}
+
+// sign populates [signature], [id], and [bytes] of [block] by signing the
+// header of [block] with [key]. [block] is encoded with codec [version].
+func sign(
+	version uint16,
+	block SignedBlock,
+	parentID ids.ID,
+	signature *[]byte,
+	id *ids.ID,
+	bytes *[]byte,
+	chainID ids.ID,
+	key crypto.Signer,
+) error {
+	unsignedBytesWithEmptySignature, err := c.Marshal(version, &block)
+	if err != nil {
+		return err
+	}
 
 	// The serialized form of the block is the unsignedBytes followed by the
 	// signature, which is prefixed by a uint32. Because we are marshalling the
@@ -72,21 +235,21 @@ func Build(
 	// prefix to get the unsigned bytes.
 	lenUnsignedBytes := len(unsignedBytesWithEmptySignature) - wrappers.IntLen
 	unsignedBytes := unsignedBytesWithEmptySignature[:lenUnsignedBytes]
-	block.id = hashing.ComputeHash256Array(unsignedBytes)
+	*id = hashing.ComputeHash256Array(unsignedBytes)
 
-	header, err := BuildHeader(chainID, parentID, block.id)
+	header, err := BuildHeader(chainID, parentID, *id)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	headerHash := hashing.ComputeHash256(header.Bytes())
-	block.Signature, err = key.Sign(rand.Reader, headerHash, crypto.SHA256)
+	*signature, err = key.Sign(rand.Reader, headerHash, crypto.SHA256)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	block.bytes, err = c.Marshal(codecVersion, &blockIntf)
-	return block, err
+	*bytes, err = c.Marshal(version, &block)
+	return err
 }
 
 func BuildHeader(
@@ -105,6 +268,21 @@ func BuildHeader(
 	return &header, err
 }
 
+// BuildEquivocationProof builds a proof that the proposer of [first] and
+// [second] signed both blocks.
+func BuildEquivocationProof(first, second SignedBlock) (EquivocationProof, error) {
+	proof := &statelessEquivocationProof{
+		FirstBlock:  first.Bytes(),
+		SecondBlock: second.Bytes(),
+		first:       first,
+		second:      second,
+	}
+
+	bytes, err := c.Marshal(codecVersion, proof)
+	proof.bytes = bytes
+	return proof, err
+}
+
 // BuildOption the option block
 // [parentID] is the ID of this option's wrapper parent block
 // [innerBytes] is the byte representation of a child option block
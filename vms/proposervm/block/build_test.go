@@ -4,6 +4,7 @@
 package block
 
 import (
+	"bytes"
 	"crypto"
 	"testing"
 	"time"
@@ -105,3 +106,264 @@ func TestBuildOption(t *testing.T) {
 	require.Equal(parentID, builtOption.ParentID())
 	require.Equal(innerBlockBytes, builtOption.Block())
 }
+
+func TestBuildExtended(t *testing.T) {
+	require := require.New(t)
+
+	parentID := ids.ID{1}
+	timestamp := time.Unix(123, 0)
+	pChainHeight := uint64(2)
+	innerBlockBytes := bytes.Repeat([]byte("inner block "), 100)
+	chainID := ids.ID{4}
+	extensions := Extensions{
+		ValidatorSetHash: ids.ID{5},
+		VRFProof:         []byte{6},
+		Compress:         true,
+	}
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	cert := staking.CertificateFromX509(tlsCert.Leaf)
+	key := tlsCert.PrivateKey.(crypto.Signer)
+
+	builtBlock, err := BuildExtended(
+		parentID,
+		timestamp,
+		pChainHeight,
+		extensions,
+		cert,
+		innerBlockBytes,
+		chainID,
+		key,
+	)
+	require.NoError(err)
+
+	require.Equal(CodecVersion1, builtBlock.Version())
+	require.Equal(parentID, builtBlock.ParentID())
+	require.Equal(pChainHeight, builtBlock.PChainHeight())
+	require.Equal(timestamp, builtBlock.Timestamp())
+	require.Equal(extensions.ValidatorSetHash, builtBlock.ValidatorSetHash())
+	require.Equal(extensions.VRFProof, builtBlock.VRFProof())
+	require.Equal(innerBlockBytes, builtBlock.Block())
+	require.Equal(ids.NodeIDFromCert(cert), builtBlock.Proposer())
+
+	require.NoError(builtBlock.Verify(true, chainID))
+
+	err = builtBlock.Verify(false, chainID)
+	require.ErrorIs(err, errUnexpectedVRFProof)
+
+	parsedBlockIntf, err := Parse(builtBlock.Bytes())
+	require.NoError(err)
+
+	parsedBlock, ok := parsedBlockIntf.(SignedBlock)
+	require.True(ok)
+	require.Equal(extensions.ValidatorSetHash, parsedBlock.ValidatorSetHash())
+	require.Equal(extensions.VRFProof, parsedBlock.VRFProof())
+	equal(require, chainID, builtBlock, parsedBlock)
+
+	// The inner block is compressed on the wire.
+	uncompressedExtensions := extensions
+	uncompressedExtensions.Compress = false
+	uncompressedBlock, err := BuildExtended(
+		parentID,
+		timestamp,
+		pChainHeight,
+		uncompressedExtensions,
+		cert,
+		innerBlockBytes,
+		chainID,
+		key,
+	)
+	require.NoError(err)
+	require.Equal(innerBlockBytes, uncompressedBlock.Block())
+	require.Less(len(builtBlock.Bytes()), len(uncompressedBlock.Bytes()))
+
+	// The extensions must be committed to by the block ID.
+	otherProofExtensions := extensions
+	otherProofExtensions.VRFProof = []byte{7}
+	builtBlockWithOtherProof, err := BuildExtended(
+		parentID,
+		timestamp,
+		pChainHeight,
+		otherProofExtensions,
+		cert,
+		innerBlockBytes,
+		chainID,
+		key,
+	)
+	require.NoError(err)
+	require.NotEqual(builtBlockWithOtherProof.ID(), builtBlock.ID())
+}
+
+func TestBuildUnsignedExtended(t *testing.T) {
+	require := require.New(t)
+
+	parentID := ids.ID{1}
+	timestamp := time.Unix(123, 0)
+	pChainHeight := uint64(2)
+	innerBlockBytes := []byte{3}
+	extensions := Extensions{
+		ValidatorSetHash: ids.ID{5},
+		Compress:         true,
+	}
+
+	builtBlock, err := BuildUnsignedExtended(
+		parentID,
+		timestamp,
+		pChainHeight,
+		extensions,
+		innerBlockBytes,
+	)
+	require.NoError(err)
+
+	require.Equal(CodecVersion1, builtBlock.Version())
+	require.Equal(parentID, builtBlock.ParentID())
+	require.Equal(pChainHeight, builtBlock.PChainHeight())
+	require.Equal(timestamp, builtBlock.Timestamp())
+	require.Equal(extensions.ValidatorSetHash, builtBlock.ValidatorSetHash())
+	require.Empty(builtBlock.VRFProof())
+	require.Equal(innerBlockBytes, builtBlock.Block())
+	require.Equal(ids.EmptyNodeID, builtBlock.Proposer())
+
+	require.NoError(builtBlock.Verify(false, ids.Empty))
+
+	err = builtBlock.Verify(true, ids.Empty)
+	require.ErrorIs(err, errMissingProposer)
+}
+
+func TestBuildVersioned(t *testing.T) {
+	require := require.New(t)
+
+	parentID := ids.ID{1}
+	timestamp := time.Unix(123, 0)
+	pChainHeight := uint64(2)
+	extensions := unknownExtension
+	innerBlockBytes := []byte{3}
+	chainID := ids.ID{4}
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	cert := staking.CertificateFromX509(tlsCert.Leaf)
+	key := tlsCert.PrivateKey.(crypto.Signer)
+
+	builtBlock, err := BuildVersioned(
+		CodecVersion1,
+		parentID,
+		timestamp,
+		pChainHeight,
+		extensions,
+		cert,
+		innerBlockBytes,
+		chainID,
+		key,
+	)
+	require.NoError(err)
+
+	require.Equal(CodecVersion1, builtBlock.Version())
+	require.Equal(extensions, builtBlock.Extensions())
+	require.Equal(parentID, builtBlock.ParentID())
+	require.Equal(pChainHeight, builtBlock.PChainHeight())
+	require.Equal(timestamp, builtBlock.Timestamp())
+	require.Equal(innerBlockBytes, builtBlock.Block())
+	require.Equal(ids.NodeIDFromCert(cert), builtBlock.Proposer())
+
+	require.NoError(builtBlock.Verify(true, chainID))
+
+	err = builtBlock.Verify(false, chainID)
+	require.ErrorIs(err, errUnexpectedProposer)
+
+	parsedBlockIntf, err := Parse(builtBlock.Bytes())
+	require.NoError(err)
+
+	parsedBlock, ok := parsedBlockIntf.(SignedBlock)
+	require.True(ok)
+
+	equal(require, chainID, builtBlock, parsedBlock)
+
+	// The extensions must be committed to by the block ID.
+	builtBlockWithoutExtensions, err := BuildVersioned(
+		CodecVersion1,
+		parentID,
+		timestamp,
+		pChainHeight,
+		nil,
+		cert,
+		innerBlockBytes,
+		chainID,
+		key,
+	)
+	require.NoError(err)
+	require.NotEqual(builtBlockWithoutExtensions.ID(), builtBlock.ID())
+}
+
+func TestBuildUnsignedVersioned(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     uint16
+		extensions  []byte
+		expectedErr error
+	}{
+		{
+			name:    "version 0",
+			version: CodecVersion0,
+		},
+		{
+			name:        "version 0 with extensions",
+			version:     CodecVersion0,
+			extensions:  unknownExtension,
+			expectedErr: errExtensionsNotSupported,
+		},
+		{
+			name:    "version 1",
+			version: CodecVersion1,
+		},
+		{
+			name:       "version 1 with extensions",
+			version:    CodecVersion1,
+			extensions: unknownExtension,
+		},
+		{
+			name:        "unknown version",
+			version:     CodecVersion1 + 1,
+			expectedErr: errUnsupportedCodecVersion,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			parentID := ids.ID{1}
+			timestamp := time.Unix(123, 0)
+			pChainHeight := uint64(2)
+			innerBlockBytes := []byte{3}
+
+			builtBlock, err := BuildUnsignedVersioned(
+				test.version,
+				parentID,
+				timestamp,
+				pChainHeight,
+				test.extensions,
+				innerBlockBytes,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			require.Equal(test.version, builtBlock.Version())
+			require.True(bytes.Equal(test.extensions, builtBlock.Extensions()))
+			require.Equal(ids.EmptyNodeID, builtBlock.Proposer())
+			require.NoError(builtBlock.Verify(false, ids.Empty))
+
+			parsedBlockIntf, err := Parse(builtBlock.Bytes())
+			require.NoError(err)
+
+			parsedBlock, ok := parsedBlockIntf.(SignedBlock)
+			require.True(ok)
+
+			equal(require, ids.Empty, builtBlock, parsedBlock)
+		})
+	}
+}
@@ -9,9 +9,22 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
-const codecVersion = 0
+const (
+	// CodecVersion0 is the original block format.
+	CodecVersion0 uint16 = 0
+	// CodecVersion1 wraps signed blocks in an envelope that carries
+	// [Extensions], which allows new fields to be added to blocks without
+	// breaking parsers.
+	CodecVersion1 uint16 = 1
+
+	// codecVersion is the version that blocks are built with unless a version
+	// is explicitly provided.
+	codecVersion = CodecVersion0
+)
 
 // The maximum block size is enforced by the p2p message size limit.
 // See: [constants.DefaultMaxMessageSize]
@@ -20,16 +33,30 @@ const codecVersion = 0
 // `[]byte`. Otherwise a malicious payload could cause an OOM.
 var c codec.Manager
 
+// zstdCompressor compresses the inner blocks of blocks whose [Extensions]
+// request compression. Inner blocks are bounded by the p2p message size limit, so
+// decompressed inner blocks larger than the limit are rejected.
+var zstdCompressor compression.Compressor
+
 func init() {
 	linearCodec := linearcodec.NewCustomMaxLength(math.MaxUint32)
+	extendedCodec := linearcodec.NewCustomMaxLength(math.MaxUint32)
 	c = codec.NewManager(math.MaxInt)
 
 	err := utils.Err(
 		linearCodec.RegisterType(&statelessBlock{}),
 		linearCodec.RegisterType(&option{}),
-		c.RegisterCodec(codecVersion, linearCodec),
+		c.RegisterCodec(CodecVersion0, linearCodec),
+
+		extendedCodec.RegisterType(&statelessExtendedBlock{}),
+		c.RegisterCodec(CodecVersion1, extendedCodec),
 	)
 	if err != nil {
 		panic(err)
 	}
+
+	zstdCompressor, err = compression.NewZstdCompressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		panic(err)
+	}
 }
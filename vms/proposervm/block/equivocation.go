@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	errUnsignedBlock      = errors.New("expected a signed block")
+	errIdenticalBlocks    = errors.New("blocks are identical")
+	errDifferentParents   = errors.New("blocks have different parents")
+	errDifferentProposers = errors.New("blocks have different proposers")
+)
+
+// EquivocationProof shows that a proposer signed two different blocks with the
+// same parent.
+type EquivocationProof interface {
+	First() SignedBlock
+	Second() SignedBlock
+	Bytes() []byte
+
+	// Verify returns nil if the blocks are distinct, share the same parent,
+	// and were both signed by the same proposer for [chainID].
+	//
+	// The proposer windows of the blocks are not verified, as that requires
+	// the timestamp of their parent.
+	Verify(chainID ids.ID) error
+}
+
+type statelessEquivocationProof struct {
+	FirstBlock  []byte `serialize:"true"`
+	SecondBlock []byte `serialize:"true"`
+
+	first  SignedBlock
+	second SignedBlock
+	bytes  []byte
+}
+
+func (p *statelessEquivocationProof) First() SignedBlock {
+	return p.first
+}
+
+func (p *statelessEquivocationProof) Second() SignedBlock {
+	return p.second
+}
+
+func (p *statelessEquivocationProof) Bytes() []byte {
+	return p.bytes
+}
+
+func (p *statelessEquivocationProof) Verify(chainID ids.ID) error {
+	switch {
+	case p.first.ID() == p.second.ID():
+		return errIdenticalBlocks
+	case p.first.ParentID() != p.second.ParentID():
+		return errDifferentParents
+	case p.first.Proposer() != p.second.Proposer():
+		return errDifferentProposers
+	}
+
+	if err := p.first.Verify(true, chainID); err != nil {
+		return fmt.Errorf("invalid first block: %w", err)
+	}
+	if err := p.second.Verify(true, chainID); err != nil {
+		return fmt.Errorf("invalid second block: %w", err)
+	}
+	return nil
+}
+
+func (p *statelessEquivocationProof) initialize(bytes []byte) error {
+	var err error
+	p.first, err = parseSignedBlock(p.FirstBlock)
+	if err != nil {
+		return fmt.Errorf("failed to parse first block: %w", err)
+	}
+	p.second, err = parseSignedBlock(p.SecondBlock)
+	if err != nil {
+		return fmt.Errorf("failed to parse second block: %w", err)
+	}
+	p.bytes = bytes
+	return nil
+}
+
+func parseSignedBlock(bytes []byte) (SignedBlock, error) {
+	blk, err := Parse(bytes)
+	if err != nil {
+		return nil, err
+	}
+	signedBlk, ok := blk.(SignedBlock)
+	if !ok {
+		return nil, fmt.Errorf("%w but got %T", errUnsignedBlock, blk)
+	}
+	return signedBlk, nil
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+func TestEquivocationProof(t *testing.T) {
+	parentID := ids.ID{1}
+	timestamp := time.Unix(123, 0)
+	pChainHeight := uint64(2)
+	chainID := ids.ID{4}
+
+	newSigner := func(require *require.Assertions) (*staking.Certificate, crypto.Signer) {
+		tlsCert, err := staking.NewTLSCert()
+		require.NoError(err)
+		return staking.CertificateFromX509(tlsCert.Leaf), tlsCert.PrivateKey.(crypto.Signer)
+	}
+	build := func(require *require.Assertions, parentID ids.ID, innerBlockBytes []byte, cert *staking.Certificate, key crypto.Signer) SignedBlock {
+		blk, err := Build(
+			parentID,
+			timestamp,
+			pChainHeight,
+			cert,
+			innerBlockBytes,
+			chainID,
+			key,
+		)
+		require.NoError(err)
+		return blk
+	}
+
+	tests := []struct {
+		name        string
+		blocks      func(*require.Assertions) (SignedBlock, SignedBlock)
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			blocks: func(require *require.Assertions) (SignedBlock, SignedBlock) {
+				cert, key := newSigner(require)
+				return build(require, parentID, []byte{3}, cert, key),
+					build(require, parentID, []byte{4}, cert, key)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "identical blocks",
+			blocks: func(require *require.Assertions) (SignedBlock, SignedBlock) {
+				cert, key := newSigner(require)
+				blk := build(require, parentID, []byte{3}, cert, key)
+				return blk, blk
+			},
+			expectedErr: errIdenticalBlocks,
+		},
+		{
+			name: "different parents",
+			blocks: func(require *require.Assertions) (SignedBlock, SignedBlock) {
+				cert, key := newSigner(require)
+				return build(require, parentID, []byte{3}, cert, key),
+					build(require, ids.ID{5}, []byte{4}, cert, key)
+			},
+			expectedErr: errDifferentParents,
+		},
+		{
+			name: "different proposers",
+			blocks: func(require *require.Assertions) (SignedBlock, SignedBlock) {
+				cert0, key0 := newSigner(require)
+				cert1, key1 := newSigner(require)
+				return build(require, parentID, []byte{3}, cert0, key0),
+					build(require, parentID, []byte{4}, cert1, key1)
+			},
+			expectedErr: errDifferentProposers,
+		},
+		{
+			name: "unsigned blocks",
+			blocks: func(require *require.Assertions) (SignedBlock, SignedBlock) {
+				first, err := BuildUnsigned(parentID, timestamp, pChainHeight, []byte{3})
+				require.NoError(err)
+				second, err := BuildUnsigned(parentID, timestamp, pChainHeight, []byte{4})
+				require.NoError(err)
+				return first, second
+			},
+			expectedErr: errMissingProposer,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			first, second := test.blocks(require)
+			builtProof, err := BuildEquivocationProof(first, second)
+			require.NoError(err)
+
+			parsedProof, err := ParseEquivocationProof(builtProof.Bytes())
+			require.NoError(err)
+			require.Equal(builtProof.Bytes(), parsedProof.Bytes())
+			require.Equal(first.ID(), parsedProof.First().ID())
+			require.Equal(second.ID(), parsedProof.Second().ID())
+
+			err = parsedProof.Verify(chainID)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestParseEquivocationProofOption(t *testing.T) {
+	require := require.New(t)
+
+	opt, err := BuildOption(ids.ID{1}, []byte{2})
+	require.NoError(err)
+
+	proof := &statelessEquivocationProof{
+		FirstBlock:  opt.Bytes(),
+		SecondBlock: opt.Bytes(),
+	}
+	proofBytes, err := c.Marshal(codecVersion, proof)
+	require.NoError(err)
+
+	_, err = ParseEquivocationProof(proofBytes)
+	require.ErrorIs(err, errUnsignedBlock)
+}
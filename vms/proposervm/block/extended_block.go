@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	_ SignedBlock = (*statelessExtendedBlock)(nil)
+
+	errUnexpectedVRFProof = errors.New("expected no VRF proof but one was provided")
+)
+
+type statelessUnsignedExtendedBlock struct {
+	ParentID     ids.ID `serialize:"true"`
+	Timestamp    int64  `serialize:"true"`
+	PChainHeight uint64 `serialize:"true"`
+	Certificate  []byte `serialize:"true"`
+	// Block is the inner block, which is compressed if the extensions specify
+	// a compression algorithm.
+	Block []byte `serialize:"true"`
+	// Extensions holds fields that were added to blocks after
+	// [CodecVersion0]. Parsers must accept extensions they don't understand.
+	Extensions []byte `serialize:"true"`
+}
+
+// statelessExtendedBlock is the envelope that signed blocks are wrapped in
+// when encoded with [CodecVersion1].
+type statelessExtendedBlock struct {
+	StatelessBlock statelessUnsignedExtendedBlock `serialize:"true"`
+	Signature      []byte                         `serialize:"true"`
+
+	statelessBlock
+
+	extensions Extensions
+}
+
+func (b *statelessExtendedBlock) ValidatorSetHash() ids.ID {
+	return b.extensions.ValidatorSetHash
+}
+
+func (b *statelessExtendedBlock) Extensions() []byte {
+	return b.StatelessBlock.Extensions
+}
+
+func (b *statelessExtendedBlock) VRFProof() []byte {
+	return b.extensions.VRFProof
+}
+
+func (b *statelessExtendedBlock) initialize(bytes []byte) error {
+	extensions, err := parseExtensions(b.StatelessBlock.Extensions)
+	if err != nil {
+		return err
+	}
+	b.extensions = extensions
+
+	innerBlkBytes := b.StatelessBlock.Block
+	if extensions.Compress {
+		innerBlkBytes, err = zstdCompressor.Decompress(innerBlkBytes)
+		if err != nil {
+			return fmt.Errorf("failed to decompress inner block: %w", err)
+		}
+	}
+
+	b.statelessBlock.StatelessBlock = statelessUnsignedBlock{
+		ParentID:     b.StatelessBlock.ParentID,
+		Timestamp:    b.StatelessBlock.Timestamp,
+		PChainHeight: b.StatelessBlock.PChainHeight,
+		Certificate:  b.StatelessBlock.Certificate,
+		Block:        innerBlkBytes,
+	}
+	b.statelessBlock.Signature = b.Signature
+	return b.statelessBlock.initialize(bytes)
+}
+
+// Verify verifies the signature of the block. The VRF proof can only be
+// verified against the proposer's BLS key, which isn't known to the block, so
+// it is only verified to be absent if the block shouldn't have a proposer.
+func (b *statelessExtendedBlock) Verify(shouldHaveProposer bool, chainID ids.ID) error {
+	if !shouldHaveProposer && len(b.extensions.VRFProof) > 0 {
+		return errUnexpectedVRFProof
+	}
+	return b.statelessBlock.Verify(shouldHaveProposer, chainID)
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// Extension types of blocks encoded with [CodecVersion1].
+const (
+	// validatorSetHashExtension commits to the validator set at the block's
+	// P-chain height.
+	validatorSetHashExtension uint16 = iota
+	// vrfProofExtension is the proposer's BLS signature over the VRF input of
+	// the block's parent.
+	vrfProofExtension
+	// compressionExtension is the algorithm the inner block is compressed with
+	// on the wire.
+	compressionExtension
+)
+
+var (
+	errExtensionsNotSorted     = errors.New("extensions aren't sorted by type")
+	errInvalidValidatorSetHash = errors.New("invalid validator set hash extension")
+	errEmptyVRFProof           = errors.New("empty VRF proof extension")
+	errInvalidCompression      = errors.New("invalid compression extension")
+	errUnsupportedCompression  = errors.New("unsupported inner block compression")
+)
+
+// Extensions are the fields of a block that were added after [CodecVersion0].
+// They are encoded in the envelope of blocks encoded with [CodecVersion1] as a
+// sequence of typed values, sorted by type. Values of unknown types are
+// ignored, so that new extensions can be added without breaking parsers.
+//
+// The zero value doesn't encode any extensions.
+type Extensions struct {
+	// ValidatorSetHash commits to the validator set at the block's P-chain
+	// height. If ids.Empty, the block doesn't commit to the validator set.
+	ValidatorSetHash ids.ID
+	// VRFProof is the proposer's BLS signature over the VRF input of the
+	// block's parent. If empty, the block doesn't provide a VRF proof.
+	VRFProof []byte
+	// Compress causes the inner block to be compressed on the wire. The inner
+	// block is decompressed when the block is parsed.
+	Compress bool
+}
+
+// Bytes returns the encoding of [e].
+func (e *Extensions) Bytes() []byte {
+	size := 0
+	if e.ValidatorSetHash != ids.Empty {
+		size += wrappers.ShortLen + wrappers.IntLen + ids.IDLen
+	}
+	if len(e.VRFProof) != 0 {
+		size += wrappers.ShortLen + wrappers.IntLen + len(e.VRFProof)
+	}
+	if e.Compress {
+		size += wrappers.ShortLen + wrappers.IntLen + wrappers.ByteLen
+	}
+	if size == 0 {
+		return nil
+	}
+
+	p := wrappers.Packer{
+		Bytes: make([]byte, size),
+	}
+	if e.ValidatorSetHash != ids.Empty {
+		p.PackShort(validatorSetHashExtension)
+		p.PackBytes(e.ValidatorSetHash[:])
+	}
+	if len(e.VRFProof) != 0 {
+		p.PackShort(vrfProofExtension)
+		p.PackBytes(e.VRFProof)
+	}
+	if e.Compress {
+		p.PackShort(compressionExtension)
+		p.PackBytes([]byte{byte(compression.TypeZstd)})
+	}
+	return p.Bytes
+}
+
+// parseExtensions parses the extensions encoded in [bytes].
+func parseExtensions(bytes []byte) (Extensions, error) {
+	var (
+		extensions Extensions
+		p          = wrappers.Packer{Bytes: bytes}
+		prevType   uint16
+	)
+	for i := 0; p.Offset < len(bytes); i++ {
+		extensionType := p.UnpackShort()
+		value := p.UnpackBytes()
+		if p.Errored() {
+			return Extensions{}, fmt.Errorf("failed to parse extensions: %w", p.Err)
+		}
+		if i > 0 && extensionType <= prevType {
+			return Extensions{}, fmt.Errorf("%w: %d after %d", errExtensionsNotSorted, extensionType, prevType)
+		}
+		prevType = extensionType
+
+		switch extensionType {
+		case validatorSetHashExtension:
+			validatorSetHash, err := ids.ToID(value)
+			if err != nil || validatorSetHash == ids.Empty {
+				return Extensions{}, errInvalidValidatorSetHash
+			}
+			extensions.ValidatorSetHash = validatorSetHash
+		case vrfProofExtension:
+			if len(value) == 0 {
+				return Extensions{}, errEmptyVRFProof
+			}
+			extensions.VRFProof = value
+		case compressionExtension:
+			if len(value) != wrappers.ByteLen {
+				return Extensions{}, errInvalidCompression
+			}
+			if compressionType := compression.Type(value[0]); compressionType != compression.TypeZstd {
+				return Extensions{}, fmt.Errorf("%w: %s", errUnsupportedCompression, compressionType)
+			}
+			extensions.Compress = true
+		}
+	}
+	return extensions, nil
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
+)
+
+// unknownExtension encodes an extension of a type that isn't known to this
+// version of the parser.
+var unknownExtension = []byte{
+	// type:
+	0xff, 0xff,
+	// value length:
+	0x00, 0x00, 0x00, 0x01,
+	// value:
+	0x06,
+}
+
+func TestExtensionsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions Extensions
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "validator set hash",
+			extensions: Extensions{
+				ValidatorSetHash: ids.ID{1},
+			},
+		},
+		{
+			name: "vrf proof",
+			extensions: Extensions{
+				VRFProof: []byte{2},
+			},
+		},
+		{
+			name: "compression",
+			extensions: Extensions{
+				Compress: true,
+			},
+		},
+		{
+			name: "all",
+			extensions: Extensions{
+				ValidatorSetHash: ids.ID{1},
+				VRFProof:         []byte{2},
+				Compress:         true,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			extensions, err := parseExtensions(test.extensions.Bytes())
+			require.NoError(err)
+			require.Equal(test.extensions, extensions)
+		})
+	}
+}
+
+func TestParseExtensions(t *testing.T) {
+	tests := []struct {
+		name               string
+		bytes              []byte
+		expectedExtensions Extensions
+		expectedErr        error
+	}{
+		{
+			name:  "unknown extension",
+			bytes: unknownExtension,
+		},
+		{
+			name: "known and unknown extensions",
+			bytes: append(
+				(&Extensions{VRFProof: []byte{2}}).Bytes(),
+				unknownExtension...,
+			),
+			expectedExtensions: Extensions{
+				VRFProof: []byte{2},
+			},
+		},
+		{
+			name: "unsorted extensions",
+			bytes: append(
+				(&Extensions{VRFProof: []byte{2}}).Bytes(),
+				(&Extensions{ValidatorSetHash: ids.ID{1}}).Bytes()...,
+			),
+			expectedErr: errExtensionsNotSorted,
+		},
+		{
+			name: "duplicate extensions",
+			bytes: append(
+				(&Extensions{VRFProof: []byte{2}}).Bytes(),
+				(&Extensions{VRFProof: []byte{2}}).Bytes()...,
+			),
+			expectedErr: errExtensionsNotSorted,
+		},
+		{
+			name: "short validator set hash",
+			bytes: []byte{
+				0x00, 0x00,
+				0x00, 0x00, 0x00, 0x01,
+				0x01,
+			},
+			expectedErr: errInvalidValidatorSetHash,
+		},
+		{
+			name: "empty vrf proof",
+			bytes: []byte{
+				0x00, 0x01,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			expectedErr: errEmptyVRFProof,
+		},
+		{
+			name: "invalid compression",
+			bytes: []byte{
+				0x00, 0x02,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			expectedErr: errInvalidCompression,
+		},
+		{
+			name: "unsupported compression",
+			bytes: []byte{
+				0x00, 0x02,
+				0x00, 0x00, 0x00, 0x01,
+				byte(compression.TypeGzip),
+			},
+			expectedErr: errUnsupportedCompression,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			extensions, err := parseExtensions(test.bytes)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedExtensions, extensions)
+		})
+	}
+}
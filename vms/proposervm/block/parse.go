@@ -5,15 +5,14 @@ package block
 
 import "fmt"
 
+// Parse parses [bytes] with the codec version [bytes] were encoded with. Any
+// supported codec version is accepted. Callers are responsible for verifying
+// that the version of the returned block is allowed.
 func Parse(bytes []byte) (Block, error) {
 	var block Block
-	parsedVersion, err := c.Unmarshal(bytes, &block)
-	if err != nil {
+	if _, err := c.Unmarshal(bytes, &block); err != nil {
 		return nil, err
 	}
-	if parsedVersion != codecVersion {
-		return nil, fmt.Errorf("expected codec version %d but got %d", codecVersion, parsedVersion)
-	}
 	return block, block.initialize(bytes)
 }
 
@@ -29,3 +28,17 @@ func ParseHeader(bytes []byte) (Header, error) {
 	header.bytes = bytes
 	return &header, nil
 }
+
+// ParseEquivocationProof parses [bytes] into an equivocation proof. Callers are
+// responsible for verifying the returned proof.
+func ParseEquivocationProof(bytes []byte) (EquivocationProof, error) {
+	proof := statelessEquivocationProof{}
+	parsedVersion, err := c.Unmarshal(bytes, &proof)
+	if err != nil {
+		return nil, err
+	}
+	if parsedVersion != codecVersion {
+		return nil, fmt.Errorf("expected codec version %d but got %d", codecVersion, parsedVersion)
+	}
+	return &proof, proof.initialize(bytes)
+}
@@ -128,8 +128,30 @@ func TestParseUnsigned(t *testing.T) {
 func TestParseGibberish(t *testing.T) {
 	require := require.New(t)
 
-	bytes := []byte{0, 1, 2, 3, 4, 5}
+	bytes := []byte{0, 9, 3, 4, 5}
 
 	_, err := Parse(bytes)
 	require.ErrorIs(err, codec.ErrUnknownVersion)
 }
+
+func TestParseVersioned(t *testing.T) {
+	require := require.New(t)
+
+	parentID := ids.ID{1}
+	timestamp := time.Unix(123, 0)
+	pChainHeight := uint64(2)
+	innerBlockBytes := []byte{3}
+
+	for _, version := range []uint16{CodecVersion0, CodecVersion1} {
+		builtBlock, err := BuildUnsignedVersioned(version, parentID, timestamp, pChainHeight, nil, innerBlockBytes)
+		require.NoError(err)
+
+		parsedBlockIntf, err := Parse(builtBlock.Bytes())
+		require.NoError(err)
+
+		parsedBlock, ok := parsedBlockIntf.(SignedBlock)
+		require.True(ok)
+		require.Equal(version, parsedBlock.Version())
+		equal(require, ids.Empty, builtBlock, parsedBlock)
+	}
+}
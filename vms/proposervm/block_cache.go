@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/metercacher"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// DefaultBlockCacheSize is the default maximum number of bytes of parsed
+	// blocks kept in the block cache.
+	DefaultBlockCacheSize = 64 * units.MiB
+
+	// The inner ID --> outer ID index is allocated
+	// 1/[innerIDIndexSizeDivisor] of the block cache size.
+	innerIDIndexSizeDivisor = 16
+	cachedIDMappingSize     = 2*ids.IDLen + constants.PointerOverhead
+)
+
+func cachedBlockSize(_ ids.ID, blk snowman.Block) int {
+	return ids.IDLen + len(blk.Bytes()) + constants.PointerOverhead
+}
+
+func cachedIDMappingSizeFunc(ids.ID, ids.ID) int {
+	return cachedIDMappingSize
+}
+
+// blockCache caches the parsed inner blocks of post-fork blocks so that
+// blocks that are repeatedly fetched during consensus polling don't need to be
+// re-parsed by the inner VM.
+//
+// Blocks are evicted in LRU order once the size of the cached blocks exceeds
+// the configured number of bytes.
+type blockCache struct {
+	// Outer block ID --> inner block
+	blocks cache.Cacher[ids.ID, snowman.Block]
+	// Inner block ID --> outer block ID
+	outerIDs cache.Cacher[ids.ID, ids.ID]
+}
+
+func newBlockCache(size int, registerer prometheus.Registerer) (*blockCache, error) {
+	blocks, err := metercacher.New[ids.ID, snowman.Block](
+		"block_cache",
+		registerer,
+		cache.NewSizedLRU(size, cachedBlockSize),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	outerIDs, err := metercacher.New[ids.ID, ids.ID](
+		"block_cache_inner_id_index",
+		registerer,
+		cache.NewSizedLRU(size/innerIDIndexSizeDivisor, cachedIDMappingSizeFunc),
+	)
+	return &blockCache{
+		blocks:   blocks,
+		outerIDs: outerIDs,
+	}, err
+}
+
+// Get returns the inner block wrapped by the block [outerBlkID].
+func (c *blockCache) Get(outerBlkID ids.ID) (snowman.Block, bool) {
+	return c.blocks.Get(outerBlkID)
+}
+
+// GetOuterID returns the ID of the cached block that wraps the inner block
+// [innerBlkID].
+func (c *blockCache) GetOuterID(innerBlkID ids.ID) (ids.ID, bool) {
+	return c.outerIDs.Get(innerBlkID)
+}
+
+// Put caches [innerBlk] as the inner block wrapped by the block [outerBlkID].
+func (c *blockCache) Put(outerBlkID ids.ID, innerBlk snowman.Block) {
+	c.blocks.Put(outerBlkID, innerBlk)
+	c.outerIDs.Put(innerBlk.ID(), outerBlkID)
+}
+
+func (c *blockCache) Flush() {
+	c.blocks.Flush()
+	c.outerIDs.Flush()
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+)
+
+func TestBlockCache(t *testing.T) {
+	require := require.New(t)
+
+	newInnerBlk := func() snowman.Block {
+		return &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV: ids.GenerateTestID(),
+			},
+			BytesV: make([]byte, 10_000),
+		}
+	}
+
+	// Enough space for exactly two blocks
+	innerBlk0 := newInnerBlk()
+	size := 2 * cachedBlockSize(ids.Empty, innerBlk0)
+	c, err := newBlockCache(size, prometheus.NewRegistry())
+	require.NoError(err)
+
+	outerBlkID0 := ids.GenerateTestID()
+	c.Put(outerBlkID0, innerBlk0)
+
+	gotInnerBlk, ok := c.Get(outerBlkID0)
+	require.True(ok)
+	require.Equal(innerBlk0, gotInnerBlk)
+
+	gotOuterBlkID, ok := c.GetOuterID(innerBlk0.ID())
+	require.True(ok)
+	require.Equal(outerBlkID0, gotOuterBlkID)
+
+	innerBlk1 := newInnerBlk()
+	outerBlkID1 := ids.GenerateTestID()
+	c.Put(outerBlkID1, innerBlk1)
+
+	innerBlk2 := newInnerBlk()
+	outerBlkID2 := ids.GenerateTestID()
+	c.Put(outerBlkID2, innerBlk2)
+
+	// The least recently used block is evicted once the cache is full
+	_, ok = c.Get(outerBlkID0)
+	require.False(ok)
+	_, ok = c.Get(outerBlkID1)
+	require.True(ok)
+	_, ok = c.Get(outerBlkID2)
+	require.True(ok)
+
+	c.Flush()
+	_, ok = c.Get(outerBlkID2)
+	require.False(ok)
+	_, ok = c.GetOuterID(innerBlk2.ID())
+	require.False(ok)
+}
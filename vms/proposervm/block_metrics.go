@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type blockMetrics struct {
+	// buildLatency tracks the time, in nanoseconds, spent building blocks.
+	buildLatency metric.Averager
+	// verifyLatency tracks the time, in nanoseconds, spent verifying post-fork
+	// blocks.
+	verifyLatency metric.Averager
+	// windowIndex tracks the proposer window that accepted blocks were
+	// issued in.
+	windowIndex metric.Averager
+	// unsignedBlocks tracks the number of accepted blocks that were built
+	// after all the proposer windows had passed.
+	unsignedBlocks prometheus.Counter
+	// missedSlots tracks the number of proposer windows that passed without
+	// their proposer proposing the accepted block.
+	missedSlots prometheus.Counter
+	// timestampSkew tracks the difference, in nanoseconds, between the local
+	// time and the timestamp of accepted blocks.
+	timestampSkew metric.Averager
+	// equivocations tracks the number of times a proposer was seen signing
+	// two different blocks with the same parent during the same window.
+	equivocations prometheus.Counter
+	// clockSkew tracks the estimated time, in nanoseconds, that the local
+	// clock is ahead of the clocks of the other proposers.
+	clockSkew prometheus.Gauge
+	// rejectedBlocks tracks the number of post-fork blocks that were rejected.
+	rejectedBlocks prometheus.Counter
+	// rolledBackBlocks tracks the number of accepted post-fork blocks that
+	// were rolled back to match the inner VM's last accepted block.
+	rolledBackBlocks prometheus.Counter
+	// rolledForwardBlocks tracks the number of stored post-fork blocks that
+	// were accepted again to match the inner VM's last accepted block.
+	rolledForwardBlocks prometheus.Counter
+	// stuckBlocks tracks the number of verified blocks that have been
+	// processing for longer than the stuck block timeout.
+	stuckBlocks prometheus.Gauge
+	// backfilledBlocks tracks the number of accepted blocks that were fetched
+	// from peers after state syncing.
+	backfilledBlocks prometheus.Counter
+	// backfillRemaining tracks the number of accepted blocks that still need
+	// to be backfilled.
+	backfillRemaining prometheus.Gauge
+	// dryRunBuiltBlocks tracks the number of pre-fork blocks built by this
+	// node that it would have been allowed to build after the fork.
+	dryRunBuiltBlocks prometheus.Counter
+	// dryRunDroppedBlocks tracks the number of pre-fork blocks built by this
+	// node before its proposer window would have started after the fork.
+	dryRunDroppedBlocks prometheus.Counter
+	// dryRunWindowIndex tracks the proposer window that accepted pre-fork
+	// blocks would have been issued in after the fork.
+	dryRunWindowIndex metric.Averager
+	// dryRunUnsignedBlocks tracks the number of accepted pre-fork blocks that
+	// would have been built without a proposer after the fork.
+	dryRunUnsignedBlocks prometheus.Counter
+	// dryRunLocalWindows tracks the number of accepted pre-fork blocks that
+	// this node would have been allowed to propose after the fork.
+	dryRunLocalWindows prometheus.Counter
+}
+
+func newBlockMetrics(registerer prometheus.Registerer) (*blockMetrics, error) {
+	errs := wrappers.Errs{}
+	m := &blockMetrics{
+		buildLatency: metric.NewAveragerWithErrs(
+			"",
+			"build_latency",
+			"time (in ns) spent building blocks",
+			registerer,
+			&errs,
+		),
+		verifyLatency: metric.NewAveragerWithErrs(
+			"",
+			"verify_latency",
+			"time (in ns) spent verifying post-fork blocks",
+			registerer,
+			&errs,
+		),
+		windowIndex: metric.NewAveragerWithErrs(
+			"",
+			"accepted_window_index",
+			"proposer window index of accepted blocks",
+			registerer,
+			&errs,
+		),
+		unsignedBlocks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "accepted_unsigned_blocks",
+			Help: "number of accepted blocks that were built without a proposer",
+		}),
+		missedSlots: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "missed_slots",
+			Help: "number of proposer windows that passed without their proposer proposing the accepted block",
+		}),
+		timestampSkew: metric.NewAveragerWithErrs(
+			"",
+			"accepted_timestamp_skew",
+			"time (in ns) between the local time and the timestamp of accepted blocks",
+			registerer,
+			&errs,
+		),
+		equivocations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "equivocations",
+			Help: "number of times a proposer signed conflicting blocks during the same proposer window",
+		}),
+		clockSkew: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "clock_skew",
+			Help: "estimated time (in ns) that the local clock is ahead of the clocks of other proposers",
+		}),
+		rejectedBlocks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "rejected_blocks",
+			Help: "number of post-fork blocks that were rejected",
+		}),
+		rolledBackBlocks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "rolled_back_blocks",
+			Help: "number of accepted post-fork blocks that were rolled back to match the inner VM",
+		}),
+		rolledForwardBlocks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "rolled_forward_blocks",
+			Help: "number of stored post-fork blocks that were accepted again to match the inner VM",
+		}),
+		stuckBlocks: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "stuck_blocks",
+			Help: "number of verified blocks that have been processing for longer than the stuck block timeout",
+		}),
+		backfilledBlocks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "backfilled_blocks",
+			Help: "number of accepted blocks that were fetched from peers after state syncing",
+		}),
+		backfillRemaining: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "backfill_remaining_blocks",
+			Help: "number of accepted blocks that still need to be fetched from peers",
+		}),
+		dryRunBuiltBlocks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dry_run_built_blocks",
+			Help: "number of pre-fork blocks built by this node that it would have been allowed to build after the fork",
+		}),
+		dryRunDroppedBlocks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dry_run_dropped_blocks",
+			Help: "number of pre-fork blocks built by this node before its proposer window would have started after the fork",
+		}),
+		dryRunWindowIndex: metric.NewAveragerWithErrs(
+			"",
+			"dry_run_accepted_window_index",
+			"proposer window index that accepted pre-fork blocks would have been issued in after the fork",
+			registerer,
+			&errs,
+		),
+		dryRunUnsignedBlocks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dry_run_accepted_unsigned_blocks",
+			Help: "number of accepted pre-fork blocks that would have been built without a proposer after the fork",
+		}),
+		dryRunLocalWindows: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dry_run_accepted_local_windows",
+			Help: "number of accepted pre-fork blocks that this node would have been allowed to propose after the fork",
+		}),
+	}
+	errs.Add(
+		registerer.Register(m.unsignedBlocks),
+		registerer.Register(m.missedSlots),
+		registerer.Register(m.equivocations),
+		registerer.Register(m.clockSkew),
+		registerer.Register(m.rejectedBlocks),
+		registerer.Register(m.rolledBackBlocks),
+		registerer.Register(m.rolledForwardBlocks),
+		registerer.Register(m.stuckBlocks),
+		registerer.Register(m.backfilledBlocks),
+		registerer.Register(m.backfillRemaining),
+		registerer.Register(m.dryRunBuiltBlocks),
+		registerer.Register(m.dryRunDroppedBlocks),
+		registerer.Register(m.dryRunUnsignedBlocks),
+		registerer.Register(m.dryRunLocalWindows),
+	)
+	return m, errs.Err
+}
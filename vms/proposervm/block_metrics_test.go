@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func TestBlockMetrics(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	// Make this node the only proposer, so that it can always build in the
+	// first window.
+	valState.GetValidatorSetF = func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+		return map[ids.NodeID]*validators.GetValidatorOutput{
+			proVM.ctx.NodeID: {
+				NodeID: proVM.ctx.NodeID,
+				Weight: 1,
+			},
+		}, nil
+	}
+
+	registry := prometheus.NewRegistry()
+	m, err := newBlockMetrics(registry)
+	require.NoError(err)
+	proVM.metrics = m
+
+	// The first post-fork block is always unsigned. The second block is
+	// signed and built in the first proposer window.
+	coreBlks := map[ids.ID]snowman.Block{
+		coreGenBlk.ID(): coreGenBlk,
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		blk, ok := coreBlks[blkID]
+		if !ok {
+			return nil, errUnknownBlock
+		}
+		return blk, nil
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		for _, blk := range coreBlks {
+			if bytes.Equal(b, blk.Bytes()) {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	parentID := coreGenBlk.ID()
+	require.NoError(proVM.SetPreference(context.Background(), parentID))
+	for i := uint64(1); i <= 2; i++ {
+		coreBlk := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			BytesV:     []byte{byte(i)},
+			ParentV:    parentID,
+			HeightV:    coreGenBlk.Height() + i,
+			TimestampV: coreGenBlk.Timestamp(),
+		}
+		coreBlks[coreBlk.ID()] = coreBlk
+		coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+			return coreBlk, nil
+		}
+
+		blk, err := proVM.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(proVM.SetPreference(context.Background(), blk.ID()))
+
+		parentID = coreBlk.ID()
+		proVM.Set(proVM.Time().Add(proposer.WindowDuration / 2))
+	}
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+
+	values := make(map[string]float64)
+	for _, family := range metrics {
+		metric := family.GetMetric()[0]
+		switch {
+		case metric.Counter != nil:
+			values[family.GetName()] = metric.Counter.GetValue()
+		case metric.Gauge != nil:
+			values[family.GetName()] = metric.Gauge.GetValue()
+		}
+	}
+	require.Equal(float64(2), values["build_latency_count"])
+	require.Equal(float64(2), values["verify_latency_count"])
+	require.Equal(float64(2), values["accepted_timestamp_skew_count"])
+	require.Equal(float64(1), values["accepted_unsigned_blocks"])
+	require.Equal(float64(1), values["accepted_window_index_count"])
+	require.Zero(values["accepted_window_index_sum"])
+}
@@ -66,9 +66,12 @@ func TestPostForkCommonComponents_buildChild(t *testing.T) {
 			ValidatorState: vdrState,
 			Log:            logging.NoLog{},
 		},
-		Windower:          windower,
-		stakingCertLeaf:   &staking.Certificate{},
-		stakingLeafSigner: pk,
+		validatorState: newValidatorStateCache(vdrState),
+		Windower:       windower,
+		Config: Config{
+			StakingCertLeaf:   &staking.Certificate{},
+			StakingLeafSigner: pk,
+		},
 	}
 
 	blk := &postForkCommonComponents{
@@ -157,6 +160,8 @@ func TestValidatorNodeBlockBuiltDelaysTests(t *testing.T) {
 			},
 		}, nil
 	}
+	// The validator set was modified at already cached heights
+	proVM.validatorState.validatorSets.Flush()
 
 	coreChildBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -292,6 +297,8 @@ func TestNonValidatorNodeBlockBuiltDelaysTests(t *testing.T) {
 			},
 		}, nil
 	}
+	// The validator set was modified at already cached heights
+	proVM.validatorState.validatorSets.Flush()
 
 	coreChildBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+// buildDeadlineMargin is how long before the end of this node's proposer
+// window the engine is notified to build a block, regardless of the pending
+// work of the inner VM.
+const buildDeadlineMargin = time.Second
+
+// pacingEnabled returns true if building blocks is delayed until the inner VM
+// has enough pending work.
+func (vm *VM) pacingEnabled() bool {
+	return vm.pendingWorkVM != nil && vm.BuildPendingWorkThreshold > 0
+}
+
+// hasPendingWork returns true if the inner VM has enough pending work to build
+// a block.
+func (vm *VM) hasPendingWork() bool {
+	return vm.pendingWorkVM.PendingWork(vm.context) >= vm.BuildPendingWorkThreshold
+}
+
+// buildDeadline returns the time after which the engine is notified to build
+// a block on a parent with [parentTimestamp], even if the inner VM doesn't
+// have enough pending work. [proposerDelay] is the delay after which this node
+// may propose a child of the parent, and [startTime] is the time after which
+// the engine may be notified.
+//
+// The deadline is shortly before the next proposer may build a block, so that
+// this node doesn't give up its proposer window while waiting for work.
+func (vm *VM) buildDeadline(
+	parentTimestamp time.Time,
+	proposerDelay time.Duration,
+	startTime time.Time,
+) time.Time {
+	if !vm.pacingEnabled() {
+		return startTime
+	}
+
+	deadline := parentTimestamp.Add(proposerDelay + proposer.WindowDuration - buildDeadlineMargin)
+	if deadline.Before(startTime) {
+		return startTime
+	}
+	return deadline
+}
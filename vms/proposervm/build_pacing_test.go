@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+type testPendingWorkVM struct {
+	pendingWork uint64
+}
+
+func (vm *testPendingWorkVM) PendingWork(context.Context) uint64 {
+	return vm.pendingWork
+}
+
+func TestBuildDeadline(t *testing.T) {
+	parentTimestamp := time.Unix(1000, 0)
+
+	tests := []struct {
+		name             string
+		pendingWorkVM    *testPendingWorkVM
+		threshold        uint64
+		proposerDelay    time.Duration
+		startTime        time.Time
+		expectedDeadline time.Time
+	}{
+		{
+			name:             "pacing disabled",
+			pendingWorkVM:    &testPendingWorkVM{},
+			threshold:        0,
+			proposerDelay:    proposer.WindowDuration,
+			startTime:        parentTimestamp.Add(proposer.WindowDuration),
+			expectedDeadline: parentTimestamp.Add(proposer.WindowDuration),
+		},
+		{
+			name:             "pending work not supported",
+			pendingWorkVM:    nil,
+			threshold:        10,
+			proposerDelay:    proposer.WindowDuration,
+			startTime:        parentTimestamp.Add(proposer.WindowDuration),
+			expectedDeadline: parentTimestamp.Add(proposer.WindowDuration),
+		},
+		{
+			name:             "before the end of the proposer window",
+			pendingWorkVM:    &testPendingWorkVM{},
+			threshold:        10,
+			proposerDelay:    proposer.WindowDuration,
+			startTime:        parentTimestamp.Add(proposer.WindowDuration),
+			expectedDeadline: parentTimestamp.Add(2*proposer.WindowDuration - buildDeadlineMargin),
+		},
+		{
+			name:             "start after the end of the proposer window",
+			pendingWorkVM:    &testPendingWorkVM{},
+			threshold:        10,
+			proposerDelay:    0,
+			startTime:        parentTimestamp.Add(2 * proposer.WindowDuration),
+			expectedDeadline: parentTimestamp.Add(2 * proposer.WindowDuration),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vm := &VM{
+				Config: Config{
+					BuildPendingWorkThreshold: test.threshold,
+				},
+			}
+			if test.pendingWorkVM != nil {
+				vm.pendingWorkVM = test.pendingWorkVM
+			}
+			deadline := vm.buildDeadline(parentTimestamp, test.proposerDelay, test.startTime)
+			require.Equal(t, test.expectedDeadline, deadline)
+		})
+	}
+}
+
+func TestHasPendingWork(t *testing.T) {
+	require := require.New(t)
+
+	pendingWorkVM := &testPendingWorkVM{}
+	vm := &VM{
+		Config: Config{
+			BuildPendingWorkThreshold: 10,
+		},
+		pendingWorkVM: pendingWorkVM,
+		context:       context.Background(),
+	}
+	require.True(vm.pacingEnabled())
+
+	pendingWorkVM.pendingWork = 9
+	require.False(vm.hasPendingWork())
+
+	pendingWorkVM.pendingWork = 10
+	require.True(vm.hasPendingWork())
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// numClockSkewSamples is the number of recent block timestamps the clock
+	// skew is estimated from.
+	numClockSkewSamples = 64
+	// minClockSkewSamples is the number of block timestamps that must be
+	// observed before the clock skew estimate is used to refuse to build
+	// blocks.
+	minClockSkewSamples = 8
+)
+
+var errClockSkewTooLarge = errors.New("local clock skew is too large")
+
+// clockSkewMonitor estimates how far the local clock is ahead of the clocks of
+// the other proposers.
+//
+// A block's timestamp is the local time of its proposer when the block was
+// built. The local time when a block proposed by another node is verified
+// minus the block's timestamp is therefore the skew of the local clock plus
+// the time it took the block to reach this node. The median of the recent
+// samples is used so that a minority of proposers with bad clocks can't move
+// the estimate.
+type clockSkewMonitor struct {
+	// samples is a ring buffer of the most recent observed skews
+	samples []time.Duration
+	// next is the index in [samples] that the next observed skew is written
+	// to once [samples] is full
+	next int
+	// skew is the current estimate of the local clock skew
+	skew time.Duration
+
+	skewGauge prometheus.Gauge
+}
+
+func newClockSkewMonitor(skewGauge prometheus.Gauge) *clockSkewMonitor {
+	return &clockSkewMonitor{
+		samples:   make([]time.Duration, 0, numClockSkewSamples),
+		skewGauge: skewGauge,
+	}
+}
+
+// Observe records that a block proposed by another node with [timestamp] was
+// verified at the local time [now].
+func (c *clockSkewMonitor) Observe(now time.Time, timestamp time.Time) {
+	sample := now.Sub(timestamp)
+	if len(c.samples) < numClockSkewSamples {
+		c.samples = append(c.samples, sample)
+	} else {
+		c.samples[c.next] = sample
+		c.next = (c.next + 1) % numClockSkewSamples
+	}
+
+	sorted := slices.Clone(c.samples)
+	slices.Sort(sorted)
+	c.skew = sorted[len(sorted)/2]
+	c.skewGauge.Set(float64(c.skew))
+}
+
+// Skew returns the estimated amount of time that the local clock is ahead of
+// the other proposers' clocks. A negative skew means that the local clock is
+// behind. Returns false if too few blocks have been observed to estimate the
+// skew.
+func (c *clockSkewMonitor) Skew() (time.Duration, bool) {
+	return c.skew, len(c.samples) >= minClockSkewSamples
+}
+
+// Verify returns an error if the local clock is estimated to be skewed by more
+// than [maxSkew]. If [maxSkew] is 0, the skew is never considered too large.
+func (c *clockSkewMonitor) Verify(maxSkew time.Duration) error {
+	if maxSkew == 0 {
+		return nil
+	}
+	skew, ok := c.Skew()
+	if !ok {
+		return nil
+	}
+	if skew > maxSkew || skew < -maxSkew {
+		return fmt.Errorf("%w: |%s| > %s", errClockSkewTooLarge, skew, maxSkew)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkewMonitor(t *testing.T) {
+	require := require.New(t)
+
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{})
+	c := newClockSkewMonitor(gauge)
+
+	maxSkew := 5 * time.Second
+	now := time.Unix(1_000_000, 0)
+
+	// Too few samples have been observed to estimate the skew
+	for i := 0; i < minClockSkewSamples-1; i++ {
+		c.Observe(now, now.Add(-time.Minute))
+	}
+	_, ok := c.Skew()
+	require.False(ok)
+	require.NoError(c.Verify(maxSkew))
+
+	// The local clock is a minute ahead of the other proposers
+	c.Observe(now, now.Add(-time.Minute))
+	skew, ok := c.Skew()
+	require.True(ok)
+	require.Equal(time.Minute, skew)
+	require.ErrorIs(c.Verify(maxSkew), errClockSkewTooLarge)
+
+	// A max skew of 0 disables the check
+	require.NoError(c.Verify(0))
+
+	m := &dto.Metric{}
+	require.NoError(gauge.Write(m))
+	require.Equal(float64(time.Minute), m.Gauge.GetValue())
+
+	// Once the local clock is fixed, the old samples are evicted and the
+	// median recovers.
+	for i := 0; i < numClockSkewSamples/2+1; i++ {
+		c.Observe(now, now.Add(-time.Second))
+	}
+	skew, ok = c.Skew()
+	require.True(ok)
+	require.Equal(time.Second, skew)
+	require.NoError(c.Verify(maxSkew))
+
+	// The local clock is behind the other proposers
+	for i := 0; i < numClockSkewSamples; i++ {
+		c.Observe(now, now.Add(time.Minute))
+	}
+	skew, ok = c.Skew()
+	require.True(ok)
+	require.Equal(-time.Minute, skew)
+	require.ErrorIs(c.Verify(maxSkew), errClockSkewTooLarge)
+}
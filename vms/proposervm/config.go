@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"crypto"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/meterdb"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+// Config configures the proposervm.
+//
+// The delays perform best when they are whole seconds. This is because block
+// timestamps are only specific to the second.
+type Config struct {
+	// ActivationTime is the time after which blocks are wrapped by the
+	// proposervm
+	ActivationTime time.Time
+	// MinimumPChainHeight is the minimum P-chain height referenced by the first
+	// post-fork block
+	MinimumPChainHeight uint64
+	// UpgradeOverrides marks the fork settings that were scheduled by the
+	// subnet's upgrade config
+	UpgradeOverrides UpgradeOverrides
+	// MinBlkDelay is the minimum delay this node waits between the timestamps
+	// of a block and its parent before building a child
+	MinBlkDelay time.Duration
+	// EnforcedMinBlkDelay is the minimum delay between the timestamps of a
+	// post-fork block and its parent. Unlike [MinBlkDelay], this is verified
+	// for every block, so it must be the same across all validators. If 0,
+	// the delay isn't verified.
+	EnforcedMinBlkDelay time.Duration
+	// EnforcedMinBlkDelayActivationTime is the time after which post-fork
+	// blocks are only valid if they were built at least
+	// [EnforcedMinBlkDelay] after their parent
+	EnforcedMinBlkDelayActivationTime time.Time
+	// NumHistoricalBlocks is the number of accepted blocks kept before they
+	// are pruned. If 0, blocks are never pruned.
+	NumHistoricalBlocks uint64
+	// CommitValidatorSet causes built blocks to commit to the validator set at
+	// their P-chain height, once [CommitValidatorSetActivationTime] and
+	// [BlockExtensionsActivationTime] have passed
+	CommitValidatorSet bool
+	// CommitValidatorSetActivationTime is the time after which post-fork
+	// blocks may commit to the validator set at their P-chain height. Blocks
+	// built before then that commit to a validator set are invalid.
+	CommitValidatorSetActivationTime time.Time
+	// VRFKey is used to provide VRF proofs in built blocks, once
+	// [VRFActivationTime] has passed. If nil, built blocks don't provide VRF
+	// proofs.
+	VRFKey *bls.SecretKey
+	// VRFActivationTime is the time after which signed post-fork blocks must
+	// provide a VRF proof if their proposer has a registered BLS key. Blocks
+	// built before then, or before [BlockExtensionsActivationTime], must not
+	// provide a VRF proof.
+	VRFActivationTime time.Time
+	// BlockExtensionsActivationTime is the time after which post-fork blocks
+	// may be encoded with extensions, which carry validator set commitments,
+	// VRF proofs, and compressed inner blocks. Blocks built before then that
+	// are encoded with extensions are rejected when parsed.
+	BlockExtensionsActivationTime time.Time
+	// GossipEquivocations causes detected equivocation proofs to be gossiped
+	// to peers, where they are delivered to the inner VM
+	GossipEquivocations bool
+	// BlockCacheSize is the maximum number of bytes of parsed blocks that are
+	// cached
+	BlockCacheSize int
+	// MaxClockSkew is the maximum estimated skew of the local clock relative
+	// to the other proposers' clocks for this node to build post-fork blocks.
+	// If 0, blocks are built regardless of the skew.
+	MaxClockSkew time.Duration
+	// CompressInnerBlocks causes post-fork blocks built after
+	// [BlockExtensionsActivationTime] to compress their inner block on the
+	// wire. Compressed blocks are parsed regardless of this flag.
+	CompressInnerBlocks bool
+	// StuckBlockTimeout is how long a verified block can be processing before
+	// it is logged along with the state needed to diagnose why it is stuck. If
+	// 0, processing blocks aren't monitored.
+	StuckBlockTimeout time.Duration
+	// RebuildOnStuckBlock causes the engine to be notified to build a block
+	// while blocks are stuck
+	RebuildOnStuckBlock bool
+	// BackfillBlocks causes the accepted blocks that are missing after state
+	// syncing to be fetched from peers in the background once the chain is
+	// bootstrapped. Blocks are served to peers regardless of this flag.
+	BackfillBlocks bool
+	// DryRunActivation causes the pre-fork blocks built and accepted before
+	// [ActivationTime] to be logged and reported in metrics along with the
+	// proposer windows they would have been assigned after the fork. This
+	// doesn't change how blocks are built or verified.
+	DryRunActivation bool
+	// PChainHeightEpoch is the number of P-chain heights per epoch. If
+	// non-zero, built post-fork blocks reference P-chain heights that are
+	// multiples of [PChainHeightEpoch], so that blocks within an epoch share
+	// a validator set.
+	PChainHeightEpoch uint64
+	// PChainHeightEpochActivationTime is the time after which post-fork blocks
+	// are only valid if they reference a P-chain height at an epoch boundary
+	// or their parent's P-chain height
+	PChainHeightEpochActivationTime time.Time
+	// BuildPendingWorkThreshold is the pending work reported by an inner VM
+	// implementing [block.PendingWorkChainVM] at which the engine is notified
+	// to build a block before this node's proposer window is about to end. If
+	// 0, blocks are built as soon as possible.
+	BuildPendingWorkThreshold uint64
+	// AppConcurrency is the number of app messages the chain handles at once.
+	// Low priority p2p messages, such as backfill requests, are shed when too
+	// many of these handlers are busy. If 0, messages are never shed.
+	AppConcurrency int
+	// WriteMetrics, if non-nil, attributes the writes to the proposervm's
+	// state and the writes of the inner VM to the "proposervm" and "vm"
+	// prefixes
+	WriteMetrics *meterdb.WriteMetrics
+	// StakingLeafSigner signs the blocks built by this node
+	StakingLeafSigner crypto.Signer
+	// StakingCertLeaf is the certificate of the blocks built by this node
+	StakingCertLeaf *staking.Certificate
+	// SecondaryStakingLeafSigner and SecondaryStakingCertLeaf are the key
+	// being rotated to, if a staking key rotation is in progress. Blocks are
+	// built with the secondary key unless the proposer window of the primary
+	// key starts earlier, so the node keeps proposing blocks until the
+	// rotation is complete.
+	SecondaryStakingLeafSigner crypto.Signer
+	SecondaryStakingCertLeaf   *staking.Certificate
+}
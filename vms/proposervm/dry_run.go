@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+// dryRunBuild reports whether this node would have been allowed to build
+// [child] on top of [parent] if the fork had activated. Failures are only
+// logged, as the dry run must never impact consensus.
+func (vm *VM) dryRunBuild(ctx context.Context, parent *preForkBlock, child snowman.Block) {
+	if !vm.DryRunActivation {
+		return
+	}
+
+	pChainHeight, err := vm.optimalPChainHeight(ctx, vm.MinimumPChainHeight)
+	if err != nil {
+		vm.ctx.Log.Warn("activation dry run failed",
+			zap.String("reason", "failed to calculate optimal P-chain height"),
+			zap.Stringer("blkID", child.ID()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	key, minDelay, err := vm.buildStakingKey(ctx, child.Height(), pChainHeight)
+	if err != nil {
+		vm.ctx.Log.Warn("activation dry run failed",
+			zap.String("reason", "failed to calculate required timestamp delay"),
+			zap.Stringer("blkID", child.ID()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	parentTimestamp := parent.Timestamp()
+	timestamp := vm.Time().Truncate(time.Second)
+	if timestamp.Before(parentTimestamp) {
+		timestamp = parentTimestamp
+	}
+	delay := timestamp.Sub(parentTimestamp)
+	allowed := delay >= proposer.MaxBuildDelay || delay >= minDelay
+	if allowed {
+		vm.metrics.dryRunBuiltBlocks.Inc()
+	} else {
+		vm.metrics.dryRunDroppedBlocks.Inc()
+	}
+
+	vm.ctx.Log.Info("activation dry run of built block",
+		zap.Stringer("blkID", child.ID()),
+		zap.Uint64("height", child.Height()),
+		zap.Uint64("pChainHeight", pChainHeight),
+		zap.Time("parentTimestamp", parentTimestamp),
+		zap.Time("blockTimestamp", timestamp),
+		zap.Duration("minDelay", minDelay),
+		zap.Bool("allowed", allowed),
+		zap.Bool("signed", delay < proposer.MaxVerifyDelay),
+		zap.Stringer("proposer", key.nodeID),
+	)
+}
+
+// dryRunAccept reports which proposers would have been allowed to propose the
+// accepted block [blk] if the fork had activated. Failures are only logged, as
+// the dry run must never impact consensus.
+func (vm *VM) dryRunAccept(ctx context.Context, blk *preForkBlock) {
+	if !vm.DryRunActivation {
+		return
+	}
+
+	parent, err := vm.getPreForkBlock(ctx, blk.Parent())
+	if err != nil {
+		vm.ctx.Log.Warn("activation dry run failed",
+			zap.String("reason", "failed to get parent block"),
+			zap.Stringer("blkID", blk.ID()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	parentTimestamp := parent.Timestamp()
+	if !parentTimestamp.Before(vm.ActivationTime) {
+		// The fork has activated, so there is nothing to simulate.
+		return
+	}
+
+	timestamp := blk.Timestamp()
+	delay := timestamp.Sub(parentTimestamp)
+	if delay >= proposer.MaxVerifyDelay {
+		vm.metrics.dryRunUnsignedBlocks.Inc()
+		vm.ctx.Log.Info("activation dry run of accepted block",
+			zap.Stringer("blkID", blk.ID()),
+			zap.Uint64("height", blk.Height()),
+			zap.Time("parentTimestamp", parentTimestamp),
+			zap.Time("blockTimestamp", timestamp),
+			zap.Bool("signed", false),
+		)
+		return
+	}
+
+	pChainHeight, err := vm.optimalPChainHeight(ctx, vm.MinimumPChainHeight)
+	if err != nil {
+		vm.ctx.Log.Warn("activation dry run failed",
+			zap.String("reason", "failed to calculate optimal P-chain height"),
+			zap.Stringer("blkID", blk.ID()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	proposers, err := vm.Windower.Proposers(ctx, blk.Height(), pChainHeight, proposer.MaxVerifyWindows)
+	if err != nil {
+		vm.ctx.Log.Warn("activation dry run failed",
+			zap.String("reason", "failed to calculate proposers"),
+			zap.Stringer("blkID", blk.ID()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	// Every proposer whose window started by the block's timestamp would have
+	// been allowed to propose the block.
+	windowIndex := int(delay / proposer.WindowDuration)
+	allowedProposers := proposers
+	if windowIndex+1 < len(allowedProposers) {
+		allowedProposers = allowedProposers[:windowIndex+1]
+	}
+	localWindow := false
+	for _, nodeID := range allowedProposers {
+		if vm.isLocalProposer(nodeID) {
+			localWindow = true
+			break
+		}
+	}
+
+	vm.metrics.dryRunWindowIndex.Observe(float64(windowIndex))
+	if localWindow {
+		vm.metrics.dryRunLocalWindows.Inc()
+	}
+
+	vm.ctx.Log.Info("activation dry run of accepted block",
+		zap.Stringer("blkID", blk.ID()),
+		zap.Uint64("height", blk.Height()),
+		zap.Uint64("pChainHeight", pChainHeight),
+		zap.Time("parentTimestamp", parentTimestamp),
+		zap.Time("blockTimestamp", timestamp),
+		zap.Bool("signed", true),
+		zap.Int("windowIndex", windowIndex),
+		zap.Stringers("allowedProposers", allowedProposers),
+		zap.Bool("localWindow", localWindow),
+	)
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func TestDryRunActivation(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, mockable.MaxTime, 0) // disable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(ctx))
+	}()
+	proVM.DryRunActivation = true
+
+	registry := prometheus.NewRegistry()
+	m, err := newBlockMetrics(registry)
+	require.NoError(err)
+	proVM.metrics = m
+
+	// setProposer makes [nodeID] the only validator, so that it is the only
+	// proposer allowed to build in the first window.
+	setProposer := func(nodeID ids.NodeID) {
+		valState.GetValidatorSetF = func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {
+					NodeID: nodeID,
+					Weight: 1,
+				},
+			}, nil
+		}
+		proVM.validatorState.validatorSets.Flush()
+	}
+	setProposer(proVM.ctx.NodeID)
+
+	coreBlks := map[ids.ID]snowman.Block{
+		coreGenBlk.ID(): coreGenBlk,
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		blk, ok := coreBlks[blkID]
+		if !ok {
+			return nil, errUnknownBlock
+		}
+		return blk, nil
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		for _, blk := range coreBlks {
+			if bytes.Equal(b, blk.Bytes()) {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	var parent snowman.Block = coreGenBlk
+	buildAndAccept := func(delay time.Duration) {
+		coreBlk := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			BytesV:     []byte{byte(parent.Height() + 1)},
+			ParentV:    parent.ID(),
+			HeightV:    parent.Height() + 1,
+			TimestampV: parent.Timestamp().Add(delay),
+		}
+		coreBlks[coreBlk.ID()] = coreBlk
+		coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+			return coreBlk, nil
+		}
+		proVM.Set(coreBlk.Timestamp())
+
+		require.NoError(proVM.SetPreference(ctx, parent.ID()))
+		blk, err := proVM.BuildBlock(ctx)
+		require.NoError(err)
+		require.IsType(&preForkBlock{}, blk)
+		require.NoError(blk.Verify(ctx))
+		require.NoError(blk.Accept(ctx))
+		parent = coreBlk
+	}
+
+	// Signed in the first window by this node
+	buildAndAccept(time.Second)
+	// Unsigned
+	buildAndAccept(proposer.MaxVerifyDelay)
+	// Signed in the first window by another node
+	setProposer(ids.GenerateTestNodeID())
+	buildAndAccept(0)
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+
+	values := make(map[string]float64)
+	for _, family := range metrics {
+		metric := family.GetMetric()[0]
+		switch {
+		case metric.Counter != nil:
+			values[family.GetName()] = metric.Counter.GetValue()
+		case metric.Gauge != nil:
+			values[family.GetName()] = metric.Gauge.GetValue()
+		}
+	}
+	require.Equal(float64(2), values["dry_run_built_blocks"])
+	require.Equal(float64(1), values["dry_run_dropped_blocks"])
+	require.Equal(float64(1), values["dry_run_accepted_unsigned_blocks"])
+	require.Equal(float64(1), values["dry_run_accepted_local_windows"])
+	require.Equal(float64(2), values["dry_run_accepted_window_index_count"])
+	require.Zero(values["dry_run_accepted_window_index_sum"])
+
+	// The dry run doesn't change how blocks are handled
+	require.Zero(values["accepted_unsigned_blocks"])
+	require.Zero(values["accepted_window_index_count"])
+}
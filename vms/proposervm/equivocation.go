@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+const (
+	// proposalCacheSize is the number of (parent, proposer, window) tuples
+	// whose first signed block is remembered to detect equivocations.
+	proposalCacheSize = 4096
+	// maxEquivocations is the number of the most recent equivocations that
+	// are reported by the API.
+	maxEquivocations = 128
+)
+
+// Equivocation is a pair of distinct blocks signed by the same proposer with
+// the same parent during the same proposer window.
+type Equivocation struct {
+	Proposer    ids.NodeID
+	ParentID    ids.ID
+	WindowIndex uint64
+	Proof       statelessblock.EquivocationProof
+}
+
+type proposalKey struct {
+	parentID    ids.ID
+	proposer    ids.NodeID
+	windowIndex uint64
+}
+
+// equivocationTracker remembers the first signed block verified for each
+// (parent, proposer, window) and reports any later block that conflicts with
+// it.
+type equivocationTracker struct {
+	proposals     cache.Cacher[proposalKey, statelessblock.SignedBlock]
+	equivocations []*Equivocation
+}
+
+func newEquivocationTracker() *equivocationTracker {
+	return &equivocationTracker{
+		proposals: &cache.LRU[proposalKey, statelessblock.SignedBlock]{
+			Size: proposalCacheSize,
+		},
+	}
+}
+
+// Track records that [blk] was proposed during the proposer window
+// [windowIndex]. If a different block was previously proposed by the same
+// proposer, with the same parent, during the same window, the equivocation is
+// returned. Otherwise, nil is returned.
+func (t *equivocationTracker) Track(blk statelessblock.SignedBlock, windowIndex uint64) (*Equivocation, error) {
+	key := proposalKey{
+		parentID:    blk.ParentID(),
+		proposer:    blk.Proposer(),
+		windowIndex: windowIndex,
+	}
+	firstBlk, ok := t.proposals.Get(key)
+	if !ok {
+		t.proposals.Put(key, blk)
+		return nil, nil
+	}
+	if firstBlk.ID() == blk.ID() {
+		return nil, nil
+	}
+
+	proof, err := statelessblock.BuildEquivocationProof(firstBlk, blk)
+	if err != nil {
+		return nil, err
+	}
+
+	equivocation := &Equivocation{
+		Proposer:    key.proposer,
+		ParentID:    key.parentID,
+		WindowIndex: windowIndex,
+		Proof:       proof,
+	}
+	if len(t.equivocations) == maxEquivocations {
+		copy(t.equivocations, t.equivocations[1:])
+		t.equivocations = t.equivocations[:maxEquivocations-1]
+	}
+	t.equivocations = append(t.equivocations, equivocation)
+	return equivocation, nil
+}
+
+// Equivocations returns the most recently detected equivocations, oldest
+// first.
+func (t *equivocationTracker) Equivocations() []*Equivocation {
+	return t.equivocations
+}
+
+// trackProposal records the signed block [blk], which was proposed during the
+// proposer window [windowIndex], and reports it if it equivocates.
+func (vm *VM) trackProposal(ctx context.Context, blk statelessblock.SignedBlock, windowIndex uint64) {
+	equivocation, err := vm.equivocations.Track(blk, windowIndex)
+	if err != nil {
+		vm.ctx.Log.Warn("failed to track proposal",
+			zap.Stringer("blkID", blk.ID()),
+			zap.Error(err),
+		)
+		return
+	}
+	if equivocation == nil {
+		return
+	}
+
+	vm.metrics.equivocations.Inc()
+	vm.ctx.Log.Warn("detected proposer equivocation",
+		zap.Stringer("proposer", equivocation.Proposer),
+		zap.Stringer("parentID", equivocation.ParentID),
+		zap.Uint64("windowIndex", equivocation.WindowIndex),
+		zap.Stringer("firstBlkID", equivocation.Proof.First().ID()),
+		zap.Stringer("secondBlkID", equivocation.Proof.Second().ID()),
+	)
+
+	if !vm.GossipEquivocations {
+		return
+	}
+	if err := vm.appSender.SendAppGossip(ctx, equivocation.Proof.Bytes()); err != nil {
+		vm.ctx.Log.Warn("failed to gossip equivocation proof",
+			zap.Stringer("proposer", equivocation.Proposer),
+			zap.Error(err),
+		)
+	}
+}
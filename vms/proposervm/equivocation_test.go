@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"crypto"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/staking"
+
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+func TestEquivocationTracker(t *testing.T) {
+	require := require.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	cert := staking.CertificateFromX509(tlsCert.Leaf)
+	key := tlsCert.PrivateKey.(crypto.Signer)
+
+	parentID := ids.GenerateTestID()
+	build := func(innerBlkBytes []byte) statelessblock.SignedBlock {
+		blk, err := statelessblock.Build(
+			parentID,
+			time.Unix(123, 0),
+			1,
+			cert,
+			innerBlkBytes,
+			ids.Empty,
+			key,
+		)
+		require.NoError(err)
+		return blk
+	}
+
+	tracker := newEquivocationTracker()
+	blk0 := build([]byte{0})
+	blk1 := build([]byte{1})
+
+	equivocation, err := tracker.Track(blk0, 0)
+	require.NoError(err)
+	require.Nil(equivocation)
+
+	// Verifying the same block again isn't an equivocation.
+	equivocation, err = tracker.Track(blk0, 0)
+	require.NoError(err)
+	require.Nil(equivocation)
+
+	// Proposing in a later window isn't an equivocation.
+	equivocation, err = tracker.Track(blk1, 1)
+	require.NoError(err)
+	require.Nil(equivocation)
+
+	equivocation, err = tracker.Track(blk1, 0)
+	require.NoError(err)
+	require.NotNil(equivocation)
+	require.Equal(blk0.Proposer(), equivocation.Proposer)
+	require.Equal(parentID, equivocation.ParentID)
+	require.Zero(equivocation.WindowIndex)
+	require.Equal(blk0.ID(), equivocation.Proof.First().ID())
+	require.Equal(blk1.ID(), equivocation.Proof.Second().ID())
+	require.NoError(equivocation.Proof.Verify(ids.Empty))
+	require.Equal([]*Equivocation{equivocation}, tracker.Equivocations())
+
+	// Only the most recent equivocations are kept.
+	for i := 0; i < maxEquivocations; i++ {
+		_, err := tracker.Track(build([]byte{byte(i), 2}), 0)
+		require.NoError(err)
+	}
+	equivocations := tracker.Equivocations()
+	require.Len(equivocations, maxEquivocations)
+	require.NotContains(equivocations, equivocation)
+}
+
+func TestEquivocationDetectedOnVerify(t *testing.T) {
+	require := require.New(t)
+
+	proVM, coreBlks, coreParent := initBatchedBuildTest(t)
+
+	var gossiped [][]byte
+	proVM.GossipEquivocations = true
+	proVM.appSender = &common.SenderTest{
+		T: t,
+		SendAppGossipF: func(_ context.Context, msg []byte) error {
+			gossiped = append(gossiped, msg)
+			return nil
+		},
+	}
+
+	innerBlk0 := newBatchedTestBlock(coreParent, 2)
+	innerBlk1 := newBatchedTestBlock(coreParent, 3)
+	coreBlks[innerBlk0.ID()] = innerBlk0
+	coreBlks[innerBlk1.ID()] = innerBlk1
+	proVM.ChainVM.(*fullVM).BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return innerBlk0, nil
+	}
+
+	blk0, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk0.Verify(context.Background()))
+
+	postForkBlk0, ok := blk0.(*postForkBlock)
+	require.True(ok)
+
+	// Sign a conflicting block in the same window.
+	statelessBlk1, err := statelessblock.Build(
+		postForkBlk0.ParentID(),
+		postForkBlk0.Timestamp(),
+		postForkBlk0.PChainHeight(),
+		proVM.StakingCertLeaf,
+		innerBlk1.Bytes(),
+		proVM.ctx.ChainID,
+		proVM.StakingLeafSigner,
+	)
+	require.NoError(err)
+
+	blk1, err := proVM.ParseBlock(context.Background(), statelessBlk1.Bytes())
+	require.NoError(err)
+	require.NoError(blk1.Verify(context.Background()))
+
+	metric := &dto.Metric{}
+	require.NoError(proVM.metrics.equivocations.Write(metric))
+	require.Equal(float64(1), metric.Counter.GetValue())
+
+	require.Len(gossiped, 1)
+	proof, err := statelessblock.ParseEquivocationProof(gossiped[0])
+	require.NoError(err)
+	require.NoError(proof.Verify(proVM.ctx.ChainID))
+	require.Equal(blk0.ID(), proof.First().ID())
+	require.Equal(blk1.ID(), proof.Second().ID())
+
+	service := &Service{vm: proVM}
+	reply := GetEquivocationsReply{}
+	require.NoError(service.GetEquivocations(nil, nil, &reply))
+	require.Len(reply.Equivocations, 1)
+	require.Equal(proVM.ctx.NodeID, reply.Equivocations[0].Proposer)
+	require.Equal(blk0.Parent(), reply.Equivocations[0].ParentID)
+	require.Equal([]ids.ID{blk0.ID(), blk1.ID()}, reply.Equivocations[0].BlockIDs)
+}
@@ -0,0 +1,307 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package forktest provides a harness to exercise a VM, wrapped by the
+// proposervm, across a network upgrade activation.
+package forktest
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/vms/proposervm"
+
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+var (
+	_ validators.State = (*soloValidatorState)(nil)
+
+	errNoVM                   = errors.New("no VM provided")
+	errNoBlocks               = errors.New("no blocks to build")
+	errBlockIntervalTooShort  = fmt.Errorf("block interval must be at least %s", proposervm.DefaultMinBlockDelay)
+	errWrongParent            = errors.New("block does not build on the last accepted block")
+	errWrongHeight            = errors.New("block height is not one greater than its parent")
+	errTimestampDecreased     = errors.New("block timestamp is before its parent")
+	errWrongTimestamp         = errors.New("post-fork block timestamp does not match the clock")
+	errUnexpectedForkState    = errors.New("block was wrapped incorrectly for the proposervm activation")
+	errReparsedBlockMismatch  = errors.New("re-parsed block has a different ID")
+	errNotLastAccepted        = errors.New("accepted block is not the last accepted block")
+	errNeverCrossedActivation = errors.New("clock never crossed the activation time")
+)
+
+// Invariant is checked against every accepted block. [activated] reports if
+// the block's timestamp is at or after the activation time of the upgrade
+// under test.
+type Invariant func(ctx context.Context, blk snowman.Block, activated bool) error
+
+type Config struct {
+	// VM is the inner VM under test.
+	VM block.ChainVM
+	// Genesis, Upgrade, and Config bytes are passed to the VM during
+	// initialization.
+	Genesis []byte
+	Upgrade []byte
+	Config  []byte
+
+	// StartTime is the initial time of the clock.
+	StartTime time.Time
+	// ActivationTime is the time the upgrade under test activates.
+	ActivationTime time.Time
+	// ProposerActivationTime is the time the proposervm starts wrapping
+	// blocks. If zero, the proposervm is active from [StartTime].
+	ProposerActivationTime time.Time
+
+	// NumBlocks is the number of blocks to build and accept.
+	NumBlocks int
+	// BlockInterval is the amount of time that the clock is advanced before
+	// every block is built.
+	BlockInterval time.Duration
+
+	// SetTime is called with the new time whenever the clock is advanced, so
+	// that the inner VM can observe the same clock as the proposervm.
+	SetTime func(time.Time)
+
+	// Invariants are checked against every accepted block.
+	Invariants []Invariant
+}
+
+// BlockResult describes a block that was built and accepted by the harness.
+type BlockResult struct {
+	ID        ids.ID
+	Height    uint64
+	Timestamp time.Time
+	// Activated is true if the block was built after the upgrade under test
+	// activated.
+	Activated bool
+	// PostFork is true if the block was wrapped by the proposervm.
+	PostFork bool
+}
+
+// Run builds, verifies, and accepts [config.NumBlocks] blocks while advancing
+// the clock by [config.BlockInterval] before each block. Besides the provided
+// invariants, Run checks that every block:
+//   - builds on the last accepted block
+//   - increments the height by one
+//   - does not decrease the timestamp
+//   - is wrapped by the proposervm iff the proposervm is activated
+//   - is parsed back into the same block
+func Run(ctx context.Context, config Config) ([]BlockResult, error) {
+	switch {
+	case config.VM == nil:
+		return nil, errNoVM
+	case config.NumBlocks <= 0:
+		return nil, errNoBlocks
+	case config.BlockInterval < proposervm.DefaultMinBlockDelay:
+		return nil, errBlockIntervalTooShort
+	}
+
+	proposerActivationTime := config.ProposerActivationTime
+	if proposerActivationTime.IsZero() {
+		proposerActivationTime = config.StartTime
+	}
+
+	tlsCert, err := staking.NewTLSCert()
+	if err != nil {
+		return nil, err
+	}
+	cert := staking.CertificateFromX509(tlsCert.Leaf)
+	vm := proposervm.New(
+		config.VM,
+		proposervm.Config{
+			ActivationTime:      proposerActivationTime,
+			MinBlkDelay:         proposervm.DefaultMinBlockDelay,
+			NumHistoricalBlocks: proposervm.DefaultNumHistoricalBlocks,
+			BlockCacheSize:      proposervm.DefaultBlockCacheSize,
+			StakingLeafSigner:   tlsCert.PrivateKey.(crypto.Signer),
+			StakingCertLeaf:     cert,
+		},
+	)
+
+	now := config.StartTime
+	setTime := func(t time.Time) {
+		now = t
+		vm.Clock.Set(t)
+		if config.SetTime != nil {
+			config.SetTime(t)
+		}
+	}
+	setTime(now)
+
+	snowCtx := snow.DefaultContextTest()
+	snowCtx.ChainID = ids.GenerateTestID()
+	snowCtx.NodeID = ids.NodeIDFromCert(cert)
+	snowCtx.ValidatorState = &soloValidatorState{
+		nodeID: snowCtx.NodeID,
+	}
+
+	toEngine := make(chan common.Message, 1)
+	err = vm.Initialize(
+		ctx,
+		snowCtx,
+		memdb.New(),
+		config.Genesis,
+		config.Upgrade,
+		config.Config,
+		toEngine,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize VM: %w", err)
+	}
+	defer func() {
+		_ = vm.Shutdown(ctx)
+	}()
+
+	if err := vm.SetState(ctx, snow.NormalOp); err != nil {
+		return nil, err
+	}
+
+	lastAcceptedID, err := vm.LastAccepted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastAccepted, err := vm.GetBlock(ctx, lastAcceptedID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results         = make([]BlockResult, 0, config.NumBlocks)
+		seenPreUpgrade  bool
+		seenPostUpgrade bool
+	)
+	for i := 0; i < config.NumBlocks; i++ {
+		if err := vm.SetPreference(ctx, lastAccepted.ID()); err != nil {
+			return results, err
+		}
+
+		setTime(now.Add(config.BlockInterval))
+
+		blk, err := vm.BuildBlock(ctx)
+		if err != nil {
+			return results, fmt.Errorf("failed to build block %d: %w", i, err)
+		}
+		if err := blk.Verify(ctx); err != nil {
+			return results, fmt.Errorf("failed to verify block %s: %w", blk.ID(), err)
+		}
+
+		result, err := checkBlock(ctx, vm, lastAccepted, blk, now, proposerActivationTime, config.ActivationTime)
+		if err != nil {
+			return results, fmt.Errorf("block %s failed invariant: %w", blk.ID(), err)
+		}
+
+		if err := blk.Accept(ctx); err != nil {
+			return results, fmt.Errorf("failed to accept block %s: %w", blk.ID(), err)
+		}
+		acceptedID, err := vm.LastAccepted(ctx)
+		if err != nil {
+			return results, err
+		}
+		if acceptedID != blk.ID() {
+			return results, fmt.Errorf("%w: expected %s but got %s", errNotLastAccepted, blk.ID(), acceptedID)
+		}
+
+		for _, invariant := range config.Invariants {
+			if err := invariant(ctx, blk, result.Activated); err != nil {
+				return results, fmt.Errorf("block %s failed invariant: %w", blk.ID(), err)
+			}
+		}
+
+		seenPreUpgrade = seenPreUpgrade || !result.Activated
+		seenPostUpgrade = seenPostUpgrade || result.Activated
+		results = append(results, result)
+		lastAccepted = blk
+	}
+
+	if !seenPreUpgrade || !seenPostUpgrade {
+		return results, errNeverCrossedActivation
+	}
+	return results, nil
+}
+
+func checkBlock(
+	ctx context.Context,
+	vm *proposervm.VM,
+	parent snowman.Block,
+	blk snowman.Block,
+	now time.Time,
+	proposerActivationTime time.Time,
+	activationTime time.Time,
+) (BlockResult, error) {
+	result := BlockResult{
+		ID:        blk.ID(),
+		Height:    blk.Height(),
+		Timestamp: blk.Timestamp(),
+		Activated: !now.Before(activationTime),
+	}
+
+	if blk.Parent() != parent.ID() {
+		return result, fmt.Errorf("%w: expected %s but got %s", errWrongParent, parent.ID(), blk.Parent())
+	}
+	if blk.Height() != parent.Height()+1 {
+		return result, fmt.Errorf("%w: parent height %d but got %d", errWrongHeight, parent.Height(), blk.Height())
+	}
+	if blk.Timestamp().Before(parent.Timestamp()) {
+		return result, fmt.Errorf("%w: parent timestamp %s but got %s", errTimestampDecreased, parent.Timestamp(), blk.Timestamp())
+	}
+
+	// Blocks are only wrapped once the parent's timestamp is after the
+	// proposervm activation.
+	_, err := statelessblock.Parse(blk.Bytes())
+	result.PostFork = err == nil
+	if shouldBePostFork := !parent.Timestamp().Before(proposerActivationTime); result.PostFork != shouldBePostFork {
+		return result, fmt.Errorf("%w: expected post fork %t", errUnexpectedForkState, shouldBePostFork)
+	}
+	if result.PostFork && !blk.Timestamp().Equal(now.Truncate(time.Second)) {
+		return result, fmt.Errorf("%w: expected %s but got %s", errWrongTimestamp, now, blk.Timestamp())
+	}
+
+	parsedBlk, err := vm.ParseBlock(ctx, blk.Bytes())
+	if err != nil {
+		return result, err
+	}
+	if parsedBlk.ID() != blk.ID() {
+		return result, fmt.Errorf("%w: expected %s but got %s", errReparsedBlockMismatch, blk.ID(), parsedBlk.ID())
+	}
+	return result, nil
+}
+
+// soloValidatorState reports that [nodeID] is the only validator, so that it
+// is always allowed to propose blocks.
+type soloValidatorState struct {
+	nodeID ids.NodeID
+}
+
+func (*soloValidatorState) GetMinimumHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (*soloValidatorState) GetCurrentHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (*soloValidatorState) GetSubnetID(context.Context, ids.ID) (ids.ID, error) {
+	return ids.Empty, nil
+}
+
+func (s *soloValidatorState) GetValidatorSet(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	return map[ids.NodeID]*validators.GetValidatorOutput{
+		s.nodeID: {
+			NodeID: s.nodeID,
+			Weight: 1,
+		},
+	}, nil
+}
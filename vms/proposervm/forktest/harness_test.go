@@ -0,0 +1,287 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package forktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	preForkVersion  byte = 0
+	postForkVersion byte = 1
+)
+
+var (
+	errUnknownBlock = errors.New("unknown block")
+	errWrongVersion = errors.New("wrong block version")
+)
+
+// forkVM is an inner VM that changes the version of the blocks it builds once
+// [activationTime] has passed.
+type forkVM struct {
+	*block.TestVM
+
+	activationTime time.Time
+	now            time.Time
+	preferred      ids.ID
+	blocks         map[ids.ID]*snowman.TestBlock
+}
+
+func newForkVM(t *testing.T, genesisTime, activationTime time.Time) *forkVM {
+	genesis := newBlock(ids.Empty, 0, genesisTime, preForkVersion)
+	genesis.StatusV = choices.Accepted
+
+	vm := &forkVM{
+		TestVM: &block.TestVM{
+			TestVM: common.TestVM{
+				T: t,
+			},
+		},
+		activationTime: activationTime,
+		preferred:      genesis.ID(),
+		blocks: map[ids.ID]*snowman.TestBlock{
+			genesis.ID(): genesis,
+		},
+	}
+	vm.InitializeF = func(context.Context, *snow.Context, database.Database, []byte, []byte, []byte, chan<- common.Message, []*common.Fx, common.AppSender) error {
+		return nil
+	}
+	vm.SetStateF = func(context.Context, snow.State) error {
+		return nil
+	}
+	vm.ShutdownF = func(context.Context) error {
+		return nil
+	}
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		lastAccepted := genesis
+		for _, blk := range vm.blocks {
+			if blk.Status() == choices.Accepted && blk.Height() > lastAccepted.Height() {
+				lastAccepted = blk
+			}
+		}
+		return lastAccepted.ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		blk, ok := vm.blocks[blkID]
+		if !ok {
+			return nil, errUnknownBlock
+		}
+		return blk, nil
+	}
+	vm.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		blk, ok := vm.blocks[hashing.ComputeHash256Array(b)]
+		if !ok {
+			return nil, errUnknownBlock
+		}
+		return blk, nil
+	}
+	vm.VerifyHeightIndexF = func(context.Context) error {
+		return nil
+	}
+	vm.GetBlockIDAtHeightF = func(_ context.Context, height uint64) (ids.ID, error) {
+		for blkID, blk := range vm.blocks {
+			if blk.Status() == choices.Accepted && blk.Height() == height {
+				return blkID, nil
+			}
+		}
+		return ids.Empty, database.ErrNotFound
+	}
+	vm.SetPreferenceF = func(_ context.Context, blkID ids.ID) error {
+		vm.preferred = blkID
+		return nil
+	}
+	vm.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		parent := vm.blocks[vm.preferred]
+		version := preForkVersion
+		if !vm.now.Before(vm.activationTime) {
+			version = postForkVersion
+		}
+		blk := newBlock(parent.ID(), parent.Height()+1, vm.now, version)
+		vm.blocks[blk.ID()] = blk
+		return blk, nil
+	}
+	return vm
+}
+
+func newBlock(parentID ids.ID, height uint64, timestamp time.Time, version byte) *snowman.TestBlock {
+	p := wrappers.Packer{Bytes: make([]byte, 1+ids.IDLen+wrappers.LongLen*2)}
+	p.PackByte(version)
+	p.PackFixedBytes(parentID[:])
+	p.PackLong(height)
+	p.PackLong(uint64(timestamp.Unix()))
+	return &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     hashing.ComputeHash256Array(p.Bytes),
+			StatusV: choices.Processing,
+		},
+		ParentV:    parentID,
+		HeightV:    height,
+		TimestampV: timestamp,
+		BytesV:     p.Bytes,
+	}
+}
+
+func TestRunAcrossActivation(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		startTime      = time.Unix(1_000_000, 0)
+		activationTime = startTime.Add(5 * time.Second)
+		innerVM        = newForkVM(t, startTime, activationTime)
+	)
+
+	versionInvariant := func(_ context.Context, blk snowman.Block, activated bool) error {
+		var inner *snowman.TestBlock
+		for _, innerBlk := range innerVM.blocks {
+			if innerBlk.Height() == blk.Height() {
+				inner = innerBlk
+			}
+		}
+		if inner == nil {
+			return errUnknownBlock
+		}
+
+		expectedVersion := preForkVersion
+		if activated {
+			expectedVersion = postForkVersion
+		}
+		if inner.Bytes()[0] != expectedVersion {
+			return errWrongVersion
+		}
+		return nil
+	}
+
+	results, err := Run(context.Background(), Config{
+		VM:                     innerVM,
+		StartTime:              startTime,
+		ActivationTime:         activationTime,
+		ProposerActivationTime: startTime.Add(2 * time.Second),
+		NumBlocks:              10,
+		BlockInterval:          time.Second,
+		SetTime: func(t time.Time) {
+			innerVM.now = t
+		},
+		Invariants: []Invariant{
+			versionInvariant,
+		},
+	})
+	require.NoError(err)
+	require.Len(results, 10)
+
+	for i, result := range results {
+		require.Equal(uint64(i+1), result.Height)
+		require.Equal(result.Timestamp.Before(activationTime), !result.Activated)
+	}
+
+	// The proposervm only wraps blocks whose parent was built after the
+	// proposervm activation.
+	require.False(results[0].PostFork)
+	require.False(results[1].PostFork)
+	require.True(results[2].PostFork)
+	require.True(results[9].PostFork)
+}
+
+func TestRunDetectsBrokenInvariant(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		startTime      = time.Unix(1_000_000, 0)
+		activationTime = startTime.Add(3 * time.Second)
+		innerVM        = newForkVM(t, startTime, activationTime)
+		errForked      = errors.New("forked")
+	)
+
+	results, err := Run(context.Background(), Config{
+		VM:             innerVM,
+		StartTime:      startTime,
+		ActivationTime: activationTime,
+		NumBlocks:      5,
+		BlockInterval:  time.Second,
+		SetTime: func(t time.Time) {
+			innerVM.now = t
+		},
+		Invariants: []Invariant{
+			func(_ context.Context, _ snowman.Block, activated bool) error {
+				if activated {
+					return errForked
+				}
+				return nil
+			},
+		},
+	})
+	require.ErrorIs(err, errForked)
+	require.Len(results, 2)
+}
+
+func TestRunRequiresCrossingActivation(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		startTime = time.Unix(1_000_000, 0)
+		innerVM   = newForkVM(t, startTime, startTime.Add(time.Hour))
+	)
+
+	_, err := Run(context.Background(), Config{
+		VM:             innerVM,
+		StartTime:      startTime,
+		ActivationTime: startTime.Add(time.Hour),
+		NumBlocks:      3,
+		BlockInterval:  time.Second,
+		SetTime: func(t time.Time) {
+			innerVM.now = t
+		},
+	})
+	require.ErrorIs(err, errNeverCrossedActivation)
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name:        "no VM",
+			config:      Config{},
+			expectedErr: errNoVM,
+		},
+		{
+			name: "no blocks",
+			config: Config{
+				VM: &block.TestVM{},
+			},
+			expectedErr: errNoBlocks,
+		},
+		{
+			name: "interval too short",
+			config: Config{
+				VM:            &block.TestVM{},
+				NumBlocks:     1,
+				BlockInterval: time.Millisecond,
+			},
+			expectedErr: errBlockIntervalTooShort,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Run(context.Background(), test.config)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
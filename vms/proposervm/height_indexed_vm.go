@@ -136,7 +136,7 @@ func (vm *VM) storeHeightEntry(height uint64, blkID ids.ID) error {
 		zap.Uint64("height", height),
 	)
 
-	if vm.numHistoricalBlocks == 0 {
+	if vm.NumHistoricalBlocks == 0 {
 		return nil
 	}
 
@@ -145,13 +145,13 @@ func (vm *VM) storeHeightEntry(height uint64, blkID ids.ID) error {
 	// is why <= is used rather than <. This prevents the user from only storing
 	// the last accepted block, which can never be safe due to the non-atomic
 	// commits between the proposervm database and the innerVM's database.
-	if blocksSinceFork <= vm.numHistoricalBlocks {
+	if blocksSinceFork <= vm.NumHistoricalBlocks {
 		return nil
 	}
 
 	// Note: heightToDelete is >= forkHeight, so it is guaranteed not to
 	// underflow.
-	heightToDelete := height - vm.numHistoricalBlocks - 1
+	heightToDelete := height - vm.NumHistoricalBlocks - 1
 	blockToDelete, err := vm.State.GetBlockIDAtHeight(heightToDelete)
 	if err == database.ErrNotFound {
 		// Block may have already been deleted. This can happen due to a
@@ -170,6 +170,9 @@ func (vm *VM) storeHeightEntry(height uint64, blkID ids.ID) error {
 	if err := vm.State.DeleteBlock(blockToDelete); err != nil {
 		return err
 	}
+	if err := vm.State.DeleteInnerBlockID(blockToDelete); err != nil {
+		return err
+	}
 
 	vm.ctx.Log.Debug("deleted block",
 		zap.Stringer("blkID", blockToDelete),
@@ -180,32 +183,36 @@ func (vm *VM) storeHeightEntry(height uint64, blkID ids.ID) error {
 
 // TODO: Support async deletion of old blocks.
 func (vm *VM) pruneOldBlocks() error {
-	if vm.numHistoricalBlocks == 0 {
+	if vm.NumHistoricalBlocks == 0 {
 		return nil
 	}
 
-	height, err := vm.State.GetMinimumHeight()
-	if err == database.ErrNotFound {
-		// Chain hasn't forked yet
-		return nil
+	// The iterator reads from a snapshot of the height index, so it is not
+	// affected by the deletions and commits performed below.
+	it, err := vm.State.NewBlockIDIterator()
+	if err != nil {
+		return err
 	}
+	defer it.Release()
 
-	// TODO: Refactor to use DB iterators.
-	//
-	// Note: vm.lastAcceptedHeight is guaranteed to be >= height, so the
-	// subtraction can never underflow.
-	for vm.lastAcceptedHeight-height > vm.numHistoricalBlocks {
-		blockToDelete, err := vm.State.GetBlockIDAtHeight(height)
-		if err != nil {
-			return err
+	for it.Next() {
+		height := it.Height()
+		// Heights are iterated in increasing order, so once a height should be
+		// kept, all the following heights should be kept as well.
+		if height >= vm.lastAcceptedHeight || vm.lastAcceptedHeight-height <= vm.NumHistoricalBlocks {
+			break
 		}
 
+		blockToDelete := it.BlockID()
 		if err := vm.State.DeleteBlockIDAtHeight(height); err != nil {
 			return err
 		}
 		if err := vm.State.DeleteBlock(blockToDelete); err != nil {
 			return err
 		}
+		if err := vm.State.DeleteInnerBlockID(blockToDelete); err != nil {
+			return err
+		}
 
 		vm.ctx.Log.Debug("deleted block",
 			zap.Stringer("blkID", blockToDelete),
@@ -214,8 +221,7 @@ func (vm *VM) pruneOldBlocks() error {
 
 		// Note: height is < vm.lastAcceptedHeight, so it is guaranteed not to
 		// overflow.
-		height++
-		if height%pruneCommitPeriod != 0 {
+		if (height+1)%pruneCommitPeriod != 0 {
 			continue
 		}
 
@@ -223,5 +229,8 @@ func (vm *VM) pruneOldBlocks() error {
 			return err
 		}
 	}
+	if err := it.Error(); err != nil {
+		return err
+	}
 	return vm.db.Commit()
 }
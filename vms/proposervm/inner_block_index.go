@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+)
+
+// GetOuterBlockByInnerID returns the accepted block that wraps the accepted
+// inner block [innerBlkID]. Pre-fork blocks are not wrapped, so they are
+// returned as is.
+//
+// Note: this acquires the context lock and must not be called while holding
+// it.
+func (vm *VM) GetOuterBlockByInnerID(ctx context.Context, innerBlkID ids.ID) (snowman.Block, error) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	// The block cache also contains blocks that haven't been accepted, so
+	// only accepted blocks can be returned from it.
+	if outerBlkID, ok := vm.blockCache.GetOuterID(innerBlkID); ok {
+		outerBlk, err := vm.getPostForkBlock(ctx, outerBlkID)
+		if err == nil && outerBlk.Status() == choices.Accepted && outerBlk.getInnerBlk().ID() == innerBlkID {
+			return outerBlk, nil
+		}
+	}
+
+	outerBlkID, err := vm.State.GetOuterBlockID(innerBlkID)
+	switch err {
+	case nil:
+		return vm.getPostForkBlock(ctx, outerBlkID)
+	case database.ErrNotFound:
+		// The block is either pre-fork or was accepted before the index was
+		// populated.
+	default:
+		return nil, err
+	}
+
+	innerBlk, err := vm.ChainVM.GetBlock(ctx, innerBlkID)
+	if err != nil {
+		return nil, err
+	}
+	if innerBlk.Status() != choices.Accepted {
+		return nil, database.ErrNotFound
+	}
+
+	height := innerBlk.Height()
+	forkHeight, err := vm.getForkHeight()
+	switch {
+	case err == database.ErrNotFound, err == nil && height < forkHeight:
+		return &preForkBlock{
+			Block: innerBlk,
+			vm:    vm,
+		}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	// The outer and inner blocks share the same height, so the height index
+	// can be used to find the block that wraps [innerBlk].
+	outerBlkID, err = vm.State.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	outerBlk, err := vm.getPostForkBlock(ctx, outerBlkID)
+	if err != nil {
+		return nil, err
+	}
+	if outerBlk.getInnerBlk().ID() != innerBlkID {
+		return nil, database.ErrNotFound
+	}
+	return outerBlk, nil
+}
+
+// GetInnerBlockByOuterID returns the inner block wrapped by the accepted block
+// [outerBlkID].
+//
+// Note: this acquires the context lock and must not be called while holding
+// it.
+func (vm *VM) GetInnerBlockByOuterID(ctx context.Context, outerBlkID ids.ID) (snowman.Block, error) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	innerBlkID, err := vm.State.GetInnerBlockID(outerBlkID)
+	switch err {
+	case nil:
+		return vm.ChainVM.GetBlock(ctx, innerBlkID)
+	case database.ErrNotFound:
+		// The block is either pre-fork or was accepted before the index was
+		// populated.
+	default:
+		return nil, err
+	}
+
+	outerBlk, err := vm.getBlock(ctx, outerBlkID)
+	if err != nil {
+		return nil, err
+	}
+	if outerBlk.Status() != choices.Accepted {
+		return nil, database.ErrNotFound
+	}
+	return outerBlk.getInnerBlk(), nil
+}
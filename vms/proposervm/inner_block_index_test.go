@@ -0,0 +1,144 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func TestInnerBlockIndexPreFork(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, mockable.MaxTime, 0) // disable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case coreGenBlk.ID():
+			return coreGenBlk, nil
+		case coreBlk.ID():
+			return coreBlk, nil
+		default:
+			return nil, database.ErrNotFound
+		}
+	}
+
+	// Processing blocks are not indexed.
+	_, err := proVM.GetOuterBlockByInnerID(context.Background(), coreBlk.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	_, err = proVM.GetInnerBlockByOuterID(context.Background(), coreBlk.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(coreBlk.Accept(context.Background()))
+
+	// Pre-fork blocks are not wrapped.
+	outerBlk, err := proVM.GetOuterBlockByInnerID(context.Background(), coreBlk.ID())
+	require.NoError(err)
+	require.IsType(&preForkBlock{}, outerBlk)
+	require.Equal(coreBlk.ID(), outerBlk.ID())
+
+	innerBlk, err := proVM.GetInnerBlockByOuterID(context.Background(), coreBlk.ID())
+	require.NoError(err)
+	require.Equal(coreBlk, innerBlk)
+}
+
+func TestInnerBlockIndexPostFork(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp().Add(proposer.MaxVerifyDelay),
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk, nil
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case coreGenBlk.ID():
+			return coreGenBlk, nil
+		case coreBlk.ID():
+			return coreBlk, nil
+		default:
+			return nil, database.ErrNotFound
+		}
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		case bytes.Equal(b, coreBlk.Bytes()):
+			return coreBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	proBlk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(proBlk.Verify(context.Background()))
+
+	// Processing blocks are not indexed.
+	_, err = proVM.GetOuterBlockByInnerID(context.Background(), coreBlk.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	_, err = proVM.GetInnerBlockByOuterID(context.Background(), proBlk.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(proBlk.Accept(context.Background()))
+
+	outerBlk, err := proVM.GetOuterBlockByInnerID(context.Background(), coreBlk.ID())
+	require.NoError(err)
+	require.Equal(proBlk.ID(), outerBlk.ID())
+
+	innerBlk, err := proVM.GetInnerBlockByOuterID(context.Background(), proBlk.ID())
+	require.NoError(err)
+	require.Equal(coreBlk.ID(), innerBlk.ID())
+
+	// Blocks accepted before the index was populated should still be found.
+	require.NoError(proVM.State.DeleteInnerBlockID(proBlk.ID()))
+
+	outerBlk, err = proVM.GetOuterBlockByInnerID(context.Background(), coreBlk.ID())
+	require.NoError(err)
+	require.Equal(proBlk.ID(), outerBlk.ID())
+
+	innerBlk, err = proVM.GetInnerBlockByOuterID(context.Background(), proBlk.ID())
+	require.NoError(err)
+	require.Equal(coreBlk.ID(), innerBlk.ID())
+}
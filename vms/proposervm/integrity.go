@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var _ block.IntegrityVerifierVM = (*VM)(nil)
+
+// VerifyIntegrity verifies the consistency of the proposervm's indexes and,
+// if supported, of the inner VM's state.
+//
+// The accepted post-fork blocks that are still stored are walked back from the
+// last accepted block, and the height and inner block indexes are checked to
+// reference them. If [repair] is true, missing or incorrect index entries are
+// rewritten from the accepted blocks.
+//
+// This walks every stored block, so the chain is stalled while it runs.
+//
+// vm.ctx.Lock should be held
+func (vm *VM) VerifyIntegrity(ctx context.Context, repair bool) ([]string, error) {
+	discrepancies, err := vm.State.VerifyIntegrity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chainDiscrepancies, repaired, err := vm.verifyAcceptedChain(ctx, repair)
+	if err != nil {
+		return nil, err
+	}
+	discrepancies = append(discrepancies, chainDiscrepancies...)
+
+	if repaired > 0 {
+		if err := vm.db.Commit(); err != nil {
+			return nil, err
+		}
+		vm.ctx.Log.Info("repaired proposervm indexes",
+			zap.Int("numRepaired", repaired),
+		)
+	}
+
+	if vm.integrityVM == nil {
+		return discrepancies, nil
+	}
+	innerDiscrepancies, err := vm.integrityVM.VerifyIntegrity(ctx, repair)
+	if errors.Is(err, block.ErrIntegrityVerifierVMNotImplemented) {
+		// The inner VM is a wrapper around a VM that doesn't support
+		// integrity verification.
+		return discrepancies, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify inner VM integrity: %w", err)
+	}
+	return append(discrepancies, innerDiscrepancies...), nil
+}
+
+// verifyAcceptedChain walks back from the last accepted post-fork block until a
+// block that isn't stored is reached, reporting the blocks that aren't
+// correctly indexed. Returns the number of index entries that were rewritten.
+func (vm *VM) verifyAcceptedChain(ctx context.Context, repair bool) ([]string, int, error) {
+	blkID, err := vm.State.GetLastAccepted()
+	if err == database.ErrNotFound {
+		// No post-fork block has been accepted yet.
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// While the height index is being rebuilt, entries are expected to be
+	// missing.
+	checkHeightIndex := vm.hIndexer.IsRepaired()
+
+	var (
+		discrepancies []string
+		repaired      int
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		blk, err := vm.getPostForkBlock(ctx, blkID)
+		if err == database.ErrNotFound {
+			// Either the fork or the oldest stored block was reached.
+			return discrepancies, repaired, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		height := blk.Height()
+		if checkHeightIndex {
+			indexedID, err := vm.State.GetBlockIDAtHeight(height)
+			if err != nil && err != database.ErrNotFound {
+				return nil, 0, err
+			}
+			if indexedID != blkID {
+				discrepancies = append(discrepancies, fmt.Sprintf(
+					"height index maps height %d to %s rather than %s",
+					height,
+					indexedID,
+					blkID,
+				))
+				if repair {
+					if err := vm.State.SetBlockIDAtHeight(height, blkID); err != nil {
+						return nil, 0, err
+					}
+					repaired++
+				}
+			}
+		}
+
+		innerBlkID := blk.getInnerBlk().ID()
+		indexedInnerID, err := vm.State.GetInnerBlockID(blkID)
+		if err != nil && err != database.ErrNotFound {
+			return nil, 0, err
+		}
+		indexedOuterID, err := vm.State.GetOuterBlockID(innerBlkID)
+		if err != nil && err != database.ErrNotFound {
+			return nil, 0, err
+		}
+		if indexedInnerID != innerBlkID || indexedOuterID != blkID {
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"inner block index maps %s to %s and %s to %s rather than to each other",
+				blkID,
+				indexedInnerID,
+				innerBlkID,
+				indexedOuterID,
+			))
+			if repair {
+				// Remove the stale mapping of the inner block [blkID] was
+				// previously indexed as wrapping.
+				if err := vm.State.DeleteInnerBlockID(blkID); err != nil {
+					return nil, 0, err
+				}
+				if err := vm.State.PutInnerBlockID(blkID, innerBlkID); err != nil {
+					return nil, 0, err
+				}
+				repaired++
+			}
+		}
+
+		blkID = blk.Parent()
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func TestVerifyIntegrityRepairsIndexes(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp().Add(proposer.MaxVerifyDelay),
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk, nil
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case coreGenBlk.ID():
+			return coreGenBlk, nil
+		case coreBlk.ID():
+			return coreBlk, nil
+		default:
+			return nil, database.ErrNotFound
+		}
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		case bytes.Equal(b, coreBlk.Bytes()):
+			return coreBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	proBlk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(proBlk.Verify(context.Background()))
+	require.NoError(proBlk.Accept(context.Background()))
+
+	discrepancies, err := proVM.VerifyIntegrity(context.Background(), false)
+	require.NoError(err)
+	require.Empty(discrepancies)
+
+	// Corrupt the indexes of the accepted block
+	require.NoError(proVM.State.DeleteBlockIDAtHeight(proBlk.Height()))
+	require.NoError(proVM.State.DeleteInnerBlockID(proBlk.ID()))
+
+	discrepancies, err = proVM.VerifyIntegrity(context.Background(), false)
+	require.NoError(err)
+	require.Len(discrepancies, 2)
+
+	// Verifying without repairing doesn't modify the indexes
+	_, err = proVM.State.GetBlockIDAtHeight(proBlk.Height())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	discrepancies, err = proVM.VerifyIntegrity(context.Background(), true)
+	require.NoError(err)
+	require.Len(discrepancies, 2)
+
+	blkID, err := proVM.State.GetBlockIDAtHeight(proBlk.Height())
+	require.NoError(err)
+	require.Equal(proBlk.ID(), blkID)
+
+	innerBlkID, err := proVM.State.GetInnerBlockID(proBlk.ID())
+	require.NoError(err)
+	require.Equal(coreBlk.ID(), innerBlkID)
+
+	discrepancies, err = proVM.VerifyIntegrity(context.Background(), false)
+	require.NoError(err)
+	require.Empty(discrepancies)
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+// missedSlotsWindow is the number of the most recently accepted heights whose
+// missed slots are aggregated.
+const missedSlotsWindow = 4096
+
+// MissedSlotStats are the proposer windows a proposer missed during the most
+// recently accepted [missedSlotsWindow] heights.
+type MissedSlotStats struct {
+	// Missed is the number of proposer windows that passed without the
+	// proposer proposing the accepted block
+	Missed uint64
+	// LastMissedHeight is the most recent height at which a proposer window
+	// was missed
+	LastMissedHeight uint64
+}
+
+// missedSlotTracker aggregates the missed slots of the most recently accepted
+// [missedSlotsWindow] heights by proposer.
+type missedSlotTracker struct {
+	// height -> proposers that missed their windows at that height
+	heights map[uint64][]ids.NodeID
+	stats   map[ids.NodeID]*MissedSlotStats
+}
+
+func newMissedSlotTracker() *missedSlotTracker {
+	return &missedSlotTracker{
+		heights: make(map[uint64][]ids.NodeID),
+		stats:   make(map[ids.NodeID]*MissedSlotStats),
+	}
+}
+
+// Add records that [proposers] missed their windows at [height], replacing
+// any previously recorded missed slots at [height].
+func (t *missedSlotTracker) Add(height uint64, proposers []ids.NodeID) {
+	t.Remove(height)
+	if len(proposers) == 0 {
+		return
+	}
+
+	t.heights[height] = proposers
+	for _, nodeID := range proposers {
+		stats, ok := t.stats[nodeID]
+		if !ok {
+			stats = &MissedSlotStats{}
+			t.stats[nodeID] = stats
+		}
+		stats.Missed++
+		if height > stats.LastMissedHeight {
+			stats.LastMissedHeight = height
+		}
+	}
+}
+
+// Remove drops the missed slots recorded at [height] from the statistics.
+func (t *missedSlotTracker) Remove(height uint64) {
+	proposers, ok := t.heights[height]
+	if !ok {
+		return
+	}
+
+	delete(t.heights, height)
+	for _, nodeID := range proposers {
+		stats := t.stats[nodeID]
+		stats.Missed--
+		if stats.Missed == 0 {
+			delete(t.stats, nodeID)
+		}
+	}
+}
+
+// Stats returns the statistics of [nodeID]. The zero value is returned if
+// [nodeID] didn't miss any proposer window.
+func (t *missedSlotTracker) Stats(nodeID ids.NodeID) MissedSlotStats {
+	stats, ok := t.stats[nodeID]
+	if !ok {
+		return MissedSlotStats{}
+	}
+	return *stats
+}
+
+// Proposers returns the proposers that missed at least one proposer window.
+func (t *missedSlotTracker) Proposers() []ids.NodeID {
+	nodeIDs := make([]ids.NodeID, 0, len(t.stats))
+	for nodeID := range t.stats {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	return nodeIDs
+}
+
+// missedSlotsStartHeight returns the lowest height whose missed slots are
+// aggregated once [height] is accepted.
+func missedSlotsStartHeight(height uint64) uint64 {
+	if height < missedSlotsWindow {
+		return 0
+	}
+	return height - missedSlotsWindow + 1
+}
+
+// loadMissedSlots populates the missed slot statistics from the missed slots
+// persisted for the most recently accepted heights.
+func (vm *VM) loadMissedSlots() error {
+	missedSlots, err := vm.State.GetMissedSlotsInRange(
+		missedSlotsStartHeight(vm.lastAcceptedHeight),
+		vm.lastAcceptedHeight,
+	)
+	if err != nil {
+		return err
+	}
+	for _, missed := range missedSlots {
+		vm.missedSlots.Add(missed.Height, missed.Proposers)
+	}
+	return nil
+}
+
+// trackMissedSlots records the proposers whose windows passed before the
+// accepted block [blk] was proposed, and drops the missed slots of the height
+// that falls out of the aggregated window.
+func (vm *VM) trackMissedSlots(ctx context.Context, blk *postForkBlock) error {
+	height := blk.Height()
+	if startHeight := missedSlotsStartHeight(height); startHeight > 0 {
+		expiredHeight := startHeight - 1
+		vm.missedSlots.Remove(expiredHeight)
+		if err := vm.State.DeleteMissedSlots(expiredHeight); err != nil {
+			return err
+		}
+	}
+
+	missed := vm.missedProposers(ctx, blk)
+	vm.missedSlots.Add(height, missed)
+	if len(missed) == 0 {
+		// A block may be accepted again at this height after a rollback
+		return vm.State.DeleteMissedSlots(height)
+	}
+	vm.metrics.missedSlots.Add(float64(len(missed)))
+	return vm.State.PutMissedSlots(height, missed)
+}
+
+// missedProposers returns the proposers whose windows passed before [blk] was
+// proposed. Failures are only logged, as the tracking of missed slots must
+// never impact consensus.
+func (vm *VM) missedProposers(ctx context.Context, blk *postForkBlock) []ids.NodeID {
+	// If the node is syncing, the P-chain may not have been synced up to the
+	// height the proposers are sampled at.
+	if vm.consensusState != snow.NormalOp {
+		return nil
+	}
+
+	parentBlk, err := vm.getBlock(ctx, blk.Parent())
+	if err != nil {
+		vm.ctx.Log.Warn("failed to track missed slots",
+			zap.String("reason", "failed to get parent block"),
+			zap.Stringer("blkID", blk.ID()),
+			zap.Error(err),
+		)
+		return nil
+	}
+	parent, ok := parentBlk.(PostForkBlock)
+	if !ok {
+		// The parent is a pre-fork block, so there were no proposer windows.
+		return nil
+	}
+
+	delay := blk.Timestamp().Sub(parent.Timestamp())
+	numWindows := int(delay / proposer.WindowDuration)
+	if numWindows == 0 {
+		return nil
+	}
+
+	parentPChainHeight, err := parent.pChainHeight(ctx)
+	if err != nil {
+		vm.ctx.Log.Warn("failed to track missed slots",
+			zap.String("reason", "failed to get parent P-chain height"),
+			zap.Stringer("blkID", blk.ID()),
+			zap.Error(err),
+		)
+		return nil
+	}
+	proposers, err := vm.Windower.Proposers(ctx, blk.Height(), parentPChainHeight, proposer.MaxVerifyWindows)
+	if err != nil {
+		vm.ctx.Log.Warn("failed to track missed slots",
+			zap.String("reason", "failed to calculate proposers"),
+			zap.Stringer("blkID", blk.ID()),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	var (
+		proposerID = blk.Proposer()
+		missed     []ids.NodeID
+	)
+	for i := 0; i < numWindows && i < len(proposers); i++ {
+		if nodeID := proposers[i]; nodeID != proposerID {
+			missed = append(missed, nodeID)
+		}
+	}
+	return missed
+}
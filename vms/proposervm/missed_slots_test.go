@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func TestMissedSlotTracker(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	tracker := newMissedSlotTracker()
+	tracker.Add(1, []ids.NodeID{nodeID0})
+	tracker.Add(2, []ids.NodeID{nodeID0, nodeID1})
+	tracker.Add(3, nil)
+
+	require.Equal(MissedSlotStats{Missed: 2, LastMissedHeight: 2}, tracker.Stats(nodeID0))
+	require.Equal(MissedSlotStats{Missed: 1, LastMissedHeight: 2}, tracker.Stats(nodeID1))
+	require.ElementsMatch([]ids.NodeID{nodeID0, nodeID1}, tracker.Proposers())
+
+	// Adding missed slots at a height replaces the ones previously recorded
+	tracker.Add(2, []ids.NodeID{nodeID1})
+	require.Equal(MissedSlotStats{Missed: 1, LastMissedHeight: 2}, tracker.Stats(nodeID0))
+	require.Equal(MissedSlotStats{Missed: 1, LastMissedHeight: 2}, tracker.Stats(nodeID1))
+
+	tracker.Remove(2)
+	require.Equal(MissedSlotStats{Missed: 1, LastMissedHeight: 2}, tracker.Stats(nodeID0))
+	require.Equal(MissedSlotStats{}, tracker.Stats(nodeID1))
+	require.Equal([]ids.NodeID{nodeID0}, tracker.Proposers())
+
+	// Removing an unknown height is a noop
+	tracker.Remove(2)
+	tracker.Remove(1)
+	require.Empty(tracker.Proposers())
+}
+
+func TestMissedSlotsStartHeight(t *testing.T) {
+	require := require.New(t)
+
+	require.Zero(missedSlotsStartHeight(0))
+	require.Zero(missedSlotsStartHeight(missedSlotsWindow - 1))
+	require.Equal(uint64(1), missedSlotsStartHeight(missedSlotsWindow))
+	require.Equal(uint64(2), missedSlotsStartHeight(missedSlotsWindow+1))
+}
+
+func TestTrackMissedSlots(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	coreBlks := map[ids.ID]snowman.Block{
+		coreGenBlk.ID(): coreGenBlk,
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		blk, ok := coreBlks[blkID]
+		if !ok {
+			return nil, errUnknownBlock
+		}
+		return blk, nil
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		for _, blk := range coreBlks {
+			if bytes.Equal(b, blk.Bytes()) {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	coreParent := snowman.Block(coreGenBlk)
+	buildAndAccept := func(delay func(parent PostForkBlock) time.Duration) *postForkBlock {
+		preferredBlk, err := proVM.getBlock(context.Background(), proVM.preferred)
+		require.NoError(err)
+		if parent, ok := preferredBlk.(PostForkBlock); ok {
+			proVM.Set(parent.Timestamp().Add(delay(parent)))
+		}
+
+		coreBlkID := ids.GenerateTestID()
+		coreBlk := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     coreBlkID,
+				StatusV: choices.Processing,
+			},
+			BytesV:     coreBlkID[:],
+			ParentV:    coreParent.ID(),
+			HeightV:    coreParent.Height() + 1,
+			TimestampV: coreGenBlk.Timestamp(),
+		}
+		coreBlks[coreBlk.ID()] = coreBlk
+		coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+			return coreBlk, nil
+		}
+
+		blk, err := proVM.BuildBlock(context.Background())
+		require.NoError(err)
+		require.IsType(&postForkBlock{}, blk)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(proVM.SetPreference(context.Background(), blk.ID()))
+
+		coreParent = coreBlk
+		return blk.(*postForkBlock)
+	}
+
+	// The parent of the first post-fork block is a pre-fork block, so there
+	// are no proposer windows to miss.
+	proVM.Set(coreGenBlk.Timestamp())
+	buildAndAccept(nil)
+	require.Empty(proVM.missedSlots.Proposers())
+
+	proposersOf := func(parent PostForkBlock) []ids.NodeID {
+		pChainHeight, err := parent.pChainHeight(context.Background())
+		require.NoError(err)
+		proposers, err := proVM.Windower.Proposers(
+			context.Background(),
+			parent.Height()+1,
+			pChainHeight,
+			proposer.MaxVerifyWindows,
+		)
+		require.NoError(err)
+		return proposers
+	}
+
+	// This node proposes the second block during its own window, after the
+	// windows of the proposers before it passed. If this node isn't sampled,
+	// the block is built unsigned once every window passed.
+	var expectedSecondMissed []ids.NodeID
+	secondBlk := buildAndAccept(func(parent PostForkBlock) time.Duration {
+		for _, nodeID := range proposersOf(parent) {
+			if nodeID == proVM.ctx.NodeID {
+				break
+			}
+			expectedSecondMissed = append(expectedSecondMissed, nodeID)
+		}
+		return time.Duration(len(expectedSecondMissed))*proposer.WindowDuration + time.Second
+	})
+
+	missed, err := proVM.State.GetMissedSlots(secondBlk.Height())
+	if len(expectedSecondMissed) == 0 {
+		require.ErrorIs(err, database.ErrNotFound)
+	} else {
+		require.NoError(err)
+		require.Equal(expectedSecondMissed, missed)
+	}
+
+	// The third block is unsigned, so every proposer missed its window.
+	var expectedThirdMissed []ids.NodeID
+	thirdBlk := buildAndAccept(func(parent PostForkBlock) time.Duration {
+		expectedThirdMissed = proposersOf(parent)
+		return proposer.MaxVerifyDelay
+	})
+	require.Equal(ids.EmptyNodeID, thirdBlk.Proposer())
+	require.Len(expectedThirdMissed, proposer.MaxVerifyWindows)
+
+	missed, err = proVM.State.GetMissedSlots(thirdBlk.Height())
+	require.NoError(err)
+	require.Equal(expectedThirdMissed, missed)
+
+	require.Equal(
+		float64(len(expectedSecondMissed)+len(expectedThirdMissed)),
+		testutil.ToFloat64(proVM.metrics.missedSlots),
+	)
+
+	expectedStats := make(map[ids.NodeID]MissedSlotStats)
+	for height, missed := range map[uint64][]ids.NodeID{
+		secondBlk.Height(): expectedSecondMissed,
+		thirdBlk.Height():  expectedThirdMissed,
+	} {
+		for _, nodeID := range missed {
+			stats := expectedStats[nodeID]
+			stats.Missed++
+			if height > stats.LastMissedHeight {
+				stats.LastMissedHeight = height
+			}
+			expectedStats[nodeID] = stats
+		}
+	}
+
+	service := &Service{vm: proVM}
+	reply := GetMissedSlotsReply{}
+	require.NoError(service.GetMissedSlots(nil, &GetMissedSlotsArgs{}, &reply))
+	require.Zero(reply.StartHeight)
+	require.Equal(json.Uint64(thirdBlk.Height()), reply.EndHeight)
+	require.Len(reply.Proposers, len(expectedStats))
+	for i, stats := range reply.Proposers {
+		expected := expectedStats[stats.NodeID]
+		require.Equal(json.Uint64(expected.Missed), stats.Missed)
+		require.Equal(json.Uint64(expected.LastMissedHeight), stats.LastMissedHeight)
+		if i > 0 {
+			// Proposers that missed the most windows are reported first
+			require.GreaterOrEqual(reply.Proposers[i-1].Missed, stats.Missed)
+		}
+	}
+
+	// Proposers that never missed a window are reported on request
+	unknownNodeID := ids.GenerateTestNodeID()
+	require.NoError(service.GetMissedSlots(nil, &GetMissedSlotsArgs{
+		NodeIDs: []ids.NodeID{unknownNodeID},
+	}, &reply))
+	require.Equal([]APIMissedSlots{{NodeID: unknownNodeID}}, reply.Proposers)
+
+	// The statistics are restored from the persisted missed slots
+	persistedStats := proVM.missedSlots.stats
+	proVM.missedSlots = newMissedSlotTracker()
+	require.NoError(proVM.loadMissedSlots())
+	require.Equal(persistedStats, proVM.missedSlots.stats)
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/vms/proposervm/state"
+)
+
+var _ block.DatabaseNamespacesVM = (*VM)(nil)
+
+// DatabaseNamespaces returns the databases of the proposervm's indexes, or nil
+// if the proposervm isn't initialized. The inner VM's databases aren't
+// included.
+//
+// vm.ctx.Lock should be held while the returned databases are used
+func (vm *VM) DatabaseNamespaces() map[string]database.Iteratee {
+	if vm.db == nil {
+		return nil
+	}
+
+	namespaces := state.Namespaces(vm.db)
+	result := make(map[string]database.Iteratee, len(namespaces))
+	for name, db := range namespaces {
+		result["proposervm/"+name] = db
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var _ block.OuterAcceptorChainVM = (*testOuterAcceptorVM)(nil)
+
+type testOuterAcceptorVM struct {
+	onOuterAcceptF func(context.Context, ids.ID, ids.ID, ids.NodeID, time.Time) error
+}
+
+func (vm *testOuterAcceptorVM) OnOuterAccept(
+	ctx context.Context,
+	outerID ids.ID,
+	innerID ids.ID,
+	proposer ids.NodeID,
+	timestamp time.Time,
+) error {
+	return vm.onOuterAcceptF(ctx, outerID, innerID, proposer, timestamp)
+}
+
+func TestOuterAcceptorNotifiedOnAccept(t *testing.T) {
+	require := require.New(t)
+
+	proVM, coreBlks, coreParent := initBatchedBuildTest(t)
+
+	innerBlk := newBatchedTestBlock(coreParent, 2)
+	coreBlks[innerBlk.ID()] = innerBlk
+	proVM.ChainVM.(*fullVM).BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return innerBlk, nil
+	}
+
+	var (
+		numCalls         int
+		acceptedOuterID  ids.ID
+		acceptedInnerID  ids.ID
+		acceptedProposer ids.NodeID
+		acceptedTime     time.Time
+	)
+	proVM.outerAcceptorVM = &testOuterAcceptorVM{
+		onOuterAcceptF: func(_ context.Context, outerID ids.ID, innerID ids.ID, proposer ids.NodeID, timestamp time.Time) error {
+			// The inner VM is notified before the inner block is accepted.
+			require.Equal(choices.Processing, innerBlk.Status())
+
+			numCalls++
+			acceptedOuterID = outerID
+			acceptedInnerID = innerID
+			acceptedProposer = proposer
+			acceptedTime = timestamp
+			return nil
+		},
+	}
+
+	blk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.Zero(numCalls)
+
+	require.NoError(blk.Accept(context.Background()))
+	require.Equal(1, numCalls)
+	require.Equal(blk.ID(), acceptedOuterID)
+	require.Equal(innerBlk.ID(), acceptedInnerID)
+	require.Equal(proVM.ctx.NodeID, acceptedProposer)
+	require.Equal(blk.Timestamp(), acceptedTime)
+	require.Equal(choices.Accepted, innerBlk.Status())
+}
+
+func TestOuterAcceptorErrorPreventsInnerAccept(t *testing.T) {
+	require := require.New(t)
+
+	proVM, coreBlks, coreParent := initBatchedBuildTest(t)
+
+	innerBlk := newBatchedTestBlock(coreParent, 2)
+	coreBlks[innerBlk.ID()] = innerBlk
+	proVM.ChainVM.(*fullVM).BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return innerBlk, nil
+	}
+
+	errTest := errors.New("non-nil error")
+	proVM.outerAcceptorVM = &testOuterAcceptorVM{
+		onOuterAcceptF: func(context.Context, ids.ID, ids.ID, ids.NodeID, time.Time) error {
+			return errTest
+		},
+	}
+
+	blk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+
+	err = blk.Accept(context.Background())
+	require.ErrorIs(err, errTest)
+	require.Equal(choices.Processing, innerBlk.Status())
+}
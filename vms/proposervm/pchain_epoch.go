@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var errPChainHeightNotAligned = errors.New("P-chain height isn't at an epoch boundary")
+
+// alignPChainHeight returns [height] rounded down to the start of its epoch.
+// The returned height is never below [minHeight], so if the start of the epoch
+// is below [minHeight], [minHeight] is returned.
+func (vm *VM) alignPChainHeight(height uint64, minHeight uint64) uint64 {
+	if vm.PChainHeightEpoch == 0 {
+		return height
+	}
+
+	aligned := height - height%vm.PChainHeightEpoch
+	if aligned < minHeight {
+		return minHeight
+	}
+	return aligned
+}
+
+// verifyPChainHeightEpoch verifies that a block with [timestamp] references a
+// P-chain height at an epoch boundary, once epochs are enforced.
+//
+// A block may also reference [parentPChainHeight], which allows blocks to be
+// built on parents that were built before epochs were enforced.
+func (vm *VM) verifyPChainHeightEpoch(
+	timestamp time.Time,
+	parentPChainHeight uint64,
+	pChainHeight uint64,
+) error {
+	if vm.PChainHeightEpoch == 0 || timestamp.Before(vm.PChainHeightEpochActivationTime) {
+		return nil
+	}
+	if pChainHeight == parentPChainHeight || pChainHeight%vm.PChainHeightEpoch == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %d isn't a multiple of %d",
+		errPChainHeightNotAligned,
+		pChainHeight,
+		vm.PChainHeightEpoch,
+	)
+}
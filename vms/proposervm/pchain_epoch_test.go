@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func TestAlignPChainHeight(t *testing.T) {
+	tests := []struct {
+		name           string
+		epoch          uint64
+		height         uint64
+		minHeight      uint64
+		expectedHeight uint64
+	}{
+		{
+			name:           "epochs disabled",
+			epoch:          0,
+			height:         25,
+			minHeight:      0,
+			expectedHeight: 25,
+		},
+		{
+			name:           "rounded down to epoch boundary",
+			epoch:          10,
+			height:         25,
+			minHeight:      0,
+			expectedHeight: 20,
+		},
+		{
+			name:           "at epoch boundary",
+			epoch:          10,
+			height:         30,
+			minHeight:      0,
+			expectedHeight: 30,
+		},
+		{
+			name:           "epoch boundary below min height",
+			epoch:          10,
+			height:         25,
+			minHeight:      22,
+			expectedHeight: 22,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vm := &VM{
+				Config: Config{
+					PChainHeightEpoch: test.epoch,
+				},
+			}
+			require.Equal(t, test.expectedHeight, vm.alignPChainHeight(test.height, test.minHeight))
+		})
+	}
+}
+
+func TestVerifyPChainHeightEpoch(t *testing.T) {
+	activationTime := time.Unix(1000, 0)
+
+	tests := []struct {
+		name               string
+		epoch              uint64
+		timestamp          time.Time
+		parentPChainHeight uint64
+		pChainHeight       uint64
+		expectedErr        error
+	}{
+		{
+			name:               "epochs disabled",
+			epoch:              0,
+			timestamp:          activationTime,
+			parentPChainHeight: 21,
+			pChainHeight:       25,
+			expectedErr:        nil,
+		},
+		{
+			name:               "before activation",
+			epoch:              10,
+			timestamp:          activationTime.Add(-time.Second),
+			parentPChainHeight: 21,
+			pChainHeight:       25,
+			expectedErr:        nil,
+		},
+		{
+			name:               "at epoch boundary",
+			epoch:              10,
+			timestamp:          activationTime,
+			parentPChainHeight: 21,
+			pChainHeight:       30,
+			expectedErr:        nil,
+		},
+		{
+			name:               "parent's height",
+			epoch:              10,
+			timestamp:          activationTime,
+			parentPChainHeight: 21,
+			pChainHeight:       21,
+			expectedErr:        nil,
+		},
+		{
+			name:               "not at epoch boundary",
+			epoch:              10,
+			timestamp:          activationTime,
+			parentPChainHeight: 21,
+			pChainHeight:       25,
+			expectedErr:        errPChainHeightNotAligned,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vm := &VM{
+				Config: Config{
+					PChainHeightEpoch:               test.epoch,
+					PChainHeightEpochActivationTime: activationTime,
+				},
+			}
+			err := vm.verifyPChainHeightEpoch(test.timestamp, test.parentPChainHeight, test.pChainHeight)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestBuildBlockAlignsPChainHeight(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+	proVM.PChainHeightEpoch = 10
+
+	valState.GetMinimumHeightF = func(context.Context) (uint64, error) {
+		return 25, nil
+	}
+	valState.GetCurrentHeightF = func(context.Context) (uint64, error) {
+		return 30, nil
+	}
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp().Add(proposer.MaxVerifyDelay),
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk, nil
+	}
+
+	builtBlk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.IsType(&postForkBlock{}, builtBlk)
+
+	pChainHeight, err := builtBlk.(*postForkBlock).pChainHeight(context.Background())
+	require.NoError(err)
+	require.Equal(uint64(20), pChainHeight)
+	require.NoError(builtBlk.Verify(context.Background()))
+}
@@ -5,11 +5,13 @@ package proposervm
 
 import (
 	"context"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/vms/proposervm/block"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 )
 
 var _ PostForkBlock = (*postForkBlock)(nil)
@@ -24,12 +26,35 @@ type postForkBlock struct {
 // 2) Persists this block in storage
 // 3) Calls Reject() on siblings of this block and their descendants.
 func (b *postForkBlock) Accept(ctx context.Context) error {
+	// The parent must be the last accepted block, so this is the parent's
+	// timestamp if the parent is a post-fork block.
+	parentTimestamp := b.vm.lastAcceptedTime
+	if err := b.vm.trackMissedSlots(ctx, b); err != nil {
+		return err
+	}
 	if err := b.acceptOuterBlk(); err != nil {
 		return err
 	}
+	b.updateAcceptedMetrics(parentTimestamp)
 	return b.acceptInnerBlk(ctx)
 }
 
+func (b *postForkBlock) updateAcceptedMetrics(parentTimestamp time.Time) {
+	timestamp := b.Timestamp()
+	b.vm.metrics.timestampSkew.Observe(float64(b.vm.Time().Sub(timestamp)))
+
+	if b.Proposer() == ids.EmptyNodeID {
+		b.vm.metrics.unsignedBlocks.Inc()
+		return
+	}
+	if parentTimestamp.IsZero() {
+		// The parent is a pre-fork block, so the proposer window is unknown.
+		return
+	}
+	windowIndex := timestamp.Sub(parentTimestamp) / proposer.WindowDuration
+	b.vm.metrics.windowIndex.Observe(float64(windowIndex))
+}
+
 func (b *postForkBlock) acceptOuterBlk() error {
 	// Update in-memory references
 	b.status = choices.Accepted
@@ -39,6 +64,17 @@ func (b *postForkBlock) acceptOuterBlk() error {
 }
 
 func (b *postForkBlock) acceptInnerBlk(ctx context.Context) error {
+	err := b.vm.notifyOuterAccept(
+		ctx,
+		b.ID(),
+		b.innerBlk.ID(),
+		b.Proposer(),
+		b.Timestamp(),
+	)
+	if err != nil {
+		return err
+	}
+
 	// mark the inner block as accepted and all conflicting inner blocks as
 	// rejected
 	return b.vm.Tree.Accept(ctx, b.innerBlk)
@@ -46,7 +82,8 @@ func (b *postForkBlock) acceptInnerBlk(ctx context.Context) error {
 
 func (b *postForkBlock) Reject(context.Context) error {
 	// We do not reject the inner block here because it may be accepted later
-	delete(b.vm.verifiedBlocks, b.ID())
+	b.vm.removeVerifiedBlock(b.ID())
+	b.vm.metrics.rejectedBlocks.Inc()
 	b.status = choices.Rejected
 	return nil
 }
@@ -67,11 +104,16 @@ func (b *postForkBlock) Parent() ids.ID {
 // If Verify() returns nil, Accept() or Reject() will eventually be called on
 // [b] and [b.innerBlk]
 func (b *postForkBlock) Verify(ctx context.Context) error {
+	start := time.Now()
 	parent, err := b.vm.getBlock(ctx, b.ParentID())
 	if err != nil {
 		return err
 	}
-	return parent.verifyPostForkChild(ctx, b)
+	if err := parent.verifyPostForkChild(ctx, b); err != nil {
+		return err
+	}
+	b.vm.metrics.verifyLatency.Observe(float64(time.Since(start)))
+	return nil
 }
 
 // Return the two options for the block that follows [b]
@@ -46,6 +46,30 @@ func (b *postForkOption) acceptOuterBlk() error {
 }
 
 func (b *postForkOption) acceptInnerBlk(ctx context.Context) error {
+	if b.vm.outerAcceptorVM != nil {
+		// Options aren't signed, so they are attributed to the proposer of
+		// their parent.
+		parent, err := b.vm.getPostForkBlock(ctx, b.ParentID())
+		if err != nil {
+			return err
+		}
+		proposer := ids.EmptyNodeID
+		if signedParent, ok := parent.getStatelessBlk().(block.SignedBlock); ok {
+			proposer = signedParent.Proposer()
+		}
+
+		err = b.vm.notifyOuterAccept(
+			ctx,
+			b.ID(),
+			b.innerBlk.ID(),
+			proposer,
+			b.Timestamp(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	// mark the inner block as accepted and all conflicting inner blocks as
 	// rejected
 	return b.vm.Tree.Accept(ctx, b.innerBlk)
@@ -55,7 +79,8 @@ func (b *postForkOption) Reject(context.Context) error {
 	// we do not reject the inner block here because that block may be contained
 	// in the proposer block that causing this block to be rejected.
 
-	delete(b.vm.verifiedBlocks, b.ID())
+	b.vm.removeVerifiedBlock(b.ID())
+	b.vm.metrics.rejectedBlocks.Inc()
 	b.status = choices.Rejected
 	return nil
 }
@@ -74,12 +99,17 @@ func (b *postForkOption) Parent() ids.ID {
 // If Verify returns nil, Accept or Reject is eventually called on [b] and
 // [b.innerBlk].
 func (b *postForkOption) Verify(ctx context.Context) error {
+	start := time.Now()
 	parent, err := b.vm.getBlock(ctx, b.ParentID())
 	if err != nil {
 		return err
 	}
 	b.timestamp = parent.Timestamp()
-	return parent.verifyPostForkOption(ctx, b)
+	if err := parent.verifyPostForkOption(ctx, b); err != nil {
+		return err
+	}
+	b.vm.metrics.verifyLatency.Observe(float64(time.Since(start)))
+	return nil
 }
 
 func (*postForkOption) verifyPreForkChild(context.Context, *preForkBlock) error {
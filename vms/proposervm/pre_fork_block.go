@@ -27,7 +27,11 @@ func (b *preForkBlock) Accept(ctx context.Context) error {
 	if err := b.acceptOuterBlk(); err != nil {
 		return err
 	}
-	return b.acceptInnerBlk(ctx)
+	if err := b.acceptInnerBlk(ctx); err != nil {
+		return err
+	}
+	b.vm.dryRunAccept(ctx, b)
+	return nil
 }
 
 func (*preForkBlock) acceptOuterBlk() error {
@@ -97,7 +101,7 @@ func (b *preForkBlock) getInnerBlk() snowman.Block {
 
 func (b *preForkBlock) verifyPreForkChild(ctx context.Context, child *preForkBlock) error {
 	parentTimestamp := b.Timestamp()
-	if !parentTimestamp.Before(b.vm.activationTime) {
+	if !parentTimestamp.Before(b.vm.ActivationTime) {
 		if err := verifyIsOracleBlock(ctx, b.Block); err != nil {
 			return err
 		}
@@ -119,7 +123,7 @@ func (b *preForkBlock) verifyPostForkChild(ctx context.Context, child *postForkB
 
 	childID := child.ID()
 	childPChainHeight := child.PChainHeight()
-	currentPChainHeight, err := b.vm.ctx.ValidatorState.GetCurrentHeight(ctx)
+	currentPChainHeight, err := b.vm.validatorState.GetCurrentHeight(ctx)
 	if err != nil {
 		b.vm.ctx.Log.Error("block verification failed",
 			zap.String("reason", "failed to get current P-Chain height"),
@@ -135,7 +139,7 @@ func (b *preForkBlock) verifyPostForkChild(ctx context.Context, child *postForkB
 			currentPChainHeight,
 		)
 	}
-	if childPChainHeight < b.vm.minimumPChainHeight {
+	if childPChainHeight < b.vm.MinimumPChainHeight {
 		return errPChainHeightTooLow
 	}
 
@@ -150,7 +154,7 @@ func (b *preForkBlock) verifyPostForkChild(ctx context.Context, child *postForkB
 	// if the *preForkBlock is the last *preForkBlock before activation takes effect
 	// (its timestamp is at or after the activation time)
 	parentTimestamp := b.Timestamp()
-	if parentTimestamp.Before(b.vm.activationTime) {
+	if parentTimestamp.Before(b.vm.ActivationTime) {
 		return errProposersNotActivated
 	}
 
@@ -166,11 +170,25 @@ func (b *preForkBlock) verifyPostForkChild(ctx context.Context, child *postForkB
 		return errTimeTooAdvanced
 	}
 
+	// The first post-fork block has no parent P-chain height, so
+	// [minimumPChainHeight] is treated as its parent's.
+	if err := b.vm.verifyPChainHeightEpoch(childTimestamp, b.vm.MinimumPChainHeight, childPChainHeight); err != nil {
+		return err
+	}
+
+	if err := b.vm.verifyValidatorSetHashActivated(child.SignedBlock); err != nil {
+		return err
+	}
+
 	// Verify the lack of signature on the node
 	if err := child.SignedBlock.Verify(false, b.vm.ctx.ChainID); err != nil {
 		return err
 	}
 
+	if err := b.vm.verifyValidatorSetHash(ctx, child.SignedBlock); err != nil {
+		return err
+	}
+
 	// Verify the inner block and track it as verified
 	return b.vm.verifyAndRecordInnerBlk(ctx, nil, child)
 }
@@ -181,7 +199,7 @@ func (*preForkBlock) verifyPostForkOption(context.Context, *postForkOption) erro
 
 func (b *preForkBlock) buildChild(ctx context.Context) (Block, error) {
 	parentTimestamp := b.Timestamp()
-	if parentTimestamp.Before(b.vm.activationTime) {
+	if parentTimestamp.Before(b.vm.ActivationTime) {
 		// The chain hasn't forked yet
 		innerBlock, err := b.vm.ChainVM.BuildBlock(ctx)
 		if err != nil {
@@ -193,6 +211,7 @@ func (b *preForkBlock) buildChild(ctx context.Context) (Block, error) {
 			zap.Uint64("height", innerBlock.Height()),
 			zap.Time("parentTimestamp", parentTimestamp),
 		)
+		b.vm.dryRunBuild(ctx, b, innerBlock)
 
 		return &preForkBlock{
 			Block: innerBlock,
@@ -210,7 +229,7 @@ func (b *preForkBlock) buildChild(ctx context.Context) (Block, error) {
 
 	// The child's P-Chain height is proposed as the optimal P-Chain height that
 	// is at least the minimum height
-	pChainHeight, err := b.vm.optimalPChainHeight(ctx, b.vm.minimumPChainHeight)
+	pChainHeight, err := b.vm.optimalPChainHeight(ctx, b.vm.MinimumPChainHeight)
 	if err != nil {
 		b.vm.ctx.Log.Error("unexpected build block failure",
 			zap.String("reason", "failed to calculate optimal P-chain height"),
@@ -5,7 +5,11 @@ package proposer
 
 import (
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 var _ utils.Sortable[validatorData] = validatorData{}
@@ -13,8 +17,46 @@ var _ utils.Sortable[validatorData] = validatorData{}
 type validatorData struct {
 	id     ids.NodeID
 	weight uint64
+	// publicKey is the compressed BLS public key of the validator. It is nil
+	// if the validator doesn't have a registered BLS key.
+	publicKey []byte
 }
 
 func (d validatorData) Less(other validatorData) bool {
 	return d.id.Less(other.id)
 }
+
+// ValidatorSetHash returns a hash of the nodeIDs, weights, and BLS public keys
+// of [vdrs]. The hash is independent of the iteration order of [vdrs].
+func ValidatorSetHash(vdrs map[ids.NodeID]*validators.GetValidatorOutput) ids.ID {
+	var (
+		sortedVdrs = make([]validatorData, 0, len(vdrs))
+		size       int
+	)
+	for nodeID, vdr := range vdrs {
+		var publicKey []byte
+		if vdr.PublicKey != nil {
+			publicKey = bls.PublicKeyToBytes(vdr.PublicKey)
+		}
+		sortedVdrs = append(sortedVdrs, validatorData{
+			id:        nodeID,
+			weight:    vdr.Weight,
+			publicKey: publicKey,
+		})
+		size += ids.NodeIDLen + wrappers.LongLen + wrappers.BoolLen + len(publicKey)
+	}
+	utils.Sort(sortedVdrs)
+
+	p := wrappers.Packer{
+		Bytes: make([]byte, size),
+	}
+	for _, vdr := range sortedVdrs {
+		p.PackFixedBytes(vdr.id[:])
+		p.PackLong(vdr.weight)
+		// Public keys have a fixed length, so only their presence needs to be
+		// encoded.
+		p.PackBool(vdr.publicKey != nil)
+		p.PackFixedBytes(vdr.publicKey)
+	}
+	return hashing.ComputeHash256Array(p.Bytes)
+}
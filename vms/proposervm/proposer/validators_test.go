@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 )
 
 func TestValidatorDataLess(t *testing.T) {
@@ -24,3 +26,40 @@ func TestValidatorDataLess(t *testing.T) {
 	require.False(v1.Less(v2))
 	require.True(v2.Less(v1))
 }
+
+func TestValidatorSetHash(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.BuildTestNodeID([]byte{1})
+	nodeID1 := ids.BuildTestNodeID([]byte{2})
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID0: {
+			NodeID: nodeID0,
+			Weight: 1,
+		},
+		nodeID1: {
+			NodeID: nodeID1,
+			Weight: 2,
+		},
+	}
+	hash := ValidatorSetHash(vdrs)
+
+	// The hash must be deterministic
+	require.Equal(hash, ValidatorSetHash(vdrs))
+
+	// The hash must commit to the weights
+	vdrs[nodeID1].Weight = 3
+	require.NotEqual(hash, ValidatorSetHash(vdrs))
+	vdrs[nodeID1].Weight = 2
+
+	// The hash must commit to the BLS keys
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	vdrs[nodeID1].PublicKey = bls.PublicFromSecretKey(sk)
+	require.NotEqual(hash, ValidatorSetHash(vdrs))
+	vdrs[nodeID1].PublicKey = nil
+
+	// The hash must commit to the nodeIDs
+	delete(vdrs, nodeID1)
+	require.NotEqual(hash, ValidatorSetHash(vdrs))
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var _ block.ProposerContextChainVM = (*testProposerContextVM)(nil)
+
+type testProposerContextVM struct {
+	shouldProvide bool
+}
+
+func (vm *testProposerContextVM) ShouldProvideProposerContext() bool {
+	return vm.shouldProvide
+}
+
+// proposerContextTestBlock records the proposer context it was verified with
+type proposerContextTestBlock struct {
+	*snowman.TestBlock
+
+	verifiedProposerCtx *block.ProposerContext
+}
+
+func (b *proposerContextTestBlock) Verify(ctx context.Context) error {
+	b.verifiedProposerCtx, _ = block.GetProposerContext(ctx)
+	return b.TestBlock.Verify(ctx)
+}
+
+func TestProposerContextProvided(t *testing.T) {
+	require := require.New(t)
+
+	proVM, coreBlks, coreParent := initBatchedBuildTest(t)
+	proVM.proposerContextVM = &testProposerContextVM{
+		shouldProvide: true,
+	}
+
+	innerBlk := &proposerContextTestBlock{
+		TestBlock: newBatchedTestBlock(coreParent, 2),
+	}
+	coreBlks[innerBlk.ID()] = innerBlk
+
+	var builtProposerCtx *block.ProposerContext
+	proVM.ChainVM.(*fullVM).BuildBlockF = func(ctx context.Context) (snowman.Block, error) {
+		var ok bool
+		builtProposerCtx, ok = block.GetProposerContext(ctx)
+		require.True(ok)
+		return innerBlk, nil
+	}
+
+	blk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+
+	postForkBlk := blk.(*postForkBlock)
+	expectedProposerCtx := &block.ProposerContext{
+		Proposer:     proVM.ctx.NodeID,
+		WindowIndex:  0,
+		PChainHeight: postForkBlk.PChainHeight(),
+	}
+	require.Equal(expectedProposerCtx, builtProposerCtx)
+	require.Equal(expectedProposerCtx, innerBlk.verifiedProposerCtx)
+}
+
+func TestProposerContextNotRequested(t *testing.T) {
+	require := require.New(t)
+
+	proVM, coreBlks, coreParent := initBatchedBuildTest(t)
+	proVM.proposerContextVM = &testProposerContextVM{
+		shouldProvide: false,
+	}
+
+	innerBlk := &proposerContextTestBlock{
+		TestBlock: newBatchedTestBlock(coreParent, 2),
+	}
+	coreBlks[innerBlk.ID()] = innerBlk
+	proVM.ChainVM.(*fullVM).BuildBlockF = func(ctx context.Context) (snowman.Block, error) {
+		_, ok := block.GetProposerContext(ctx)
+		require.False(ok)
+		return innerBlk, nil
+	}
+
+	blk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.Nil(innerBlk.verifiedProposerCtx)
+}
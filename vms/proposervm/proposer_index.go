@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+// putProposer indexes the proposer of the accepted block [blk] by its height.
+func (vm *VM) putProposer(blk PostForkBlock) error {
+	height := blk.Height()
+	if signedBlk, ok := blk.getStatelessBlk().(block.SignedBlock); ok {
+		return vm.State.PutProposerAt(height, signedBlk.Proposer())
+	}
+
+	// Options aren't signed, so they are attributed to the proposer of their
+	// parent, which is the block accepted at the previous height.
+	proposer, err := vm.State.GetProposerAt(height - 1)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		// The parent was accepted before the index was populated.
+		proposer = ids.EmptyNodeID
+	default:
+		return err
+	}
+	return vm.State.PutProposerAt(height, proposer)
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func TestProposerIndex(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp().Add(proposer.MaxVerifyDelay),
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk, nil
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case coreGenBlk.ID():
+			return coreGenBlk, nil
+		case coreBlk.ID():
+			return coreBlk, nil
+		default:
+			return nil, database.ErrNotFound
+		}
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		case bytes.Equal(b, coreBlk.Bytes()):
+			return coreBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	proBlkIntf, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.IsType(&postForkBlock{}, proBlkIntf)
+	proBlk := proBlkIntf.(*postForkBlock)
+	require.NoError(proBlk.Verify(context.Background()))
+
+	service := &Service{vm: proVM}
+	height := json.Uint64(proBlk.Height())
+
+	// Processing blocks are not indexed.
+	err = service.GetProposerAt(nil, &GetProposerAtArgs{Height: height}, &GetProposerAtReply{})
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(proBlk.Accept(context.Background()))
+
+	proposerReply := GetProposerAtReply{}
+	require.NoError(service.GetProposerAt(nil, &GetProposerAtArgs{Height: height}, &proposerReply))
+	require.Equal(proBlk.Proposer(), proposerReply.Proposer)
+
+	proposersReply := GetProposersReply{}
+	require.NoError(service.GetProposers(nil, &GetProposersArgs{
+		StartHeight: 0,
+		EndHeight:   height,
+	}, &proposersReply))
+	require.Equal([]APIProposerAtHeight{
+		{
+			Height:   height,
+			Proposer: proBlk.Proposer(),
+		},
+	}, proposersReply.Proposers)
+
+	err = service.GetProposers(nil, &GetProposersArgs{
+		StartHeight: height + 1,
+		EndHeight:   height,
+	}, &GetProposersReply{})
+	require.ErrorIs(err, errInvalidHeightRange)
+}
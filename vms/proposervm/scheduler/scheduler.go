@@ -12,15 +12,31 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
+// pendingWorkPollFrequency is how often the pending work is checked while a
+// request to build a block is being held back.
+const pendingWorkPollFrequency = 100 * time.Millisecond
+
 type Scheduler interface {
 	Dispatch(startTime time.Time)
 
-	// Client must guarantee that [SetBuildBlockTime]
-	// is never called after [Close]
+	// Client must guarantee that [SetBuildBlockTime] and [SetBuildBlockWindow]
+	// are never called after [Close]
 	SetBuildBlockTime(t time.Time)
+	// SetBuildBlockWindow is like [SetBuildBlockTime], except that between
+	// [start] and [deadline] the engine is only notified if the VM has enough
+	// pending work.
+	SetBuildBlockWindow(start time.Time, deadline time.Time)
 	Close()
 }
 
+// buildWindow is the period of time during which the engine may be notified
+// to build a block. Until [deadline], the engine is only notified if the VM
+// has enough pending work.
+type buildWindow struct {
+	start    time.Time
+	deadline time.Time
+}
+
 // Scheduler receives notifications from a VM that it wants its engine to call
 // the VM's BuildBlock method, and delivers the notification to the engine only
 // when the engine should call BuildBlock. Namely, when this node is allowed to
@@ -35,27 +51,45 @@ type scheduler struct {
 	toEngine chan<- common.Message
 	// When we receive a message on this channel, it means that we must refrain
 	// from telling the engine to call its VM's BuildBlock method until the
-	// given time
-	newBuildBlockTime chan time.Time
+	// given window starts
+	newBuildWindow chan buildWindow
+	// hasPendingWork reports whether the VM has enough pending work to build
+	// a block before the deadline of the build window. If nil, the engine is
+	// notified as soon as the build window starts.
+	hasPendingWork func() bool
 }
 
-func New(log logging.Logger, toEngine chan<- common.Message) (Scheduler, chan<- common.Message) {
+// New returns a scheduler that notifies [toEngine] of the messages sent on the
+// returned channel. If [hasPendingWork] is non-nil, requests to build a block
+// are held back until it returns true or the deadline of the build window is
+// reached.
+func New(
+	log logging.Logger,
+	toEngine chan<- common.Message,
+	hasPendingWork func() bool,
+) (Scheduler, chan<- common.Message) {
 	vmToEngine := make(chan common.Message, cap(toEngine))
 	return &scheduler{
-		log:               log,
-		fromVM:            vmToEngine,
-		toEngine:          toEngine,
-		newBuildBlockTime: make(chan time.Time),
+		log:            log,
+		fromVM:         vmToEngine,
+		toEngine:       toEngine,
+		newBuildWindow: make(chan buildWindow),
+		hasPendingWork: hasPendingWork,
 	}, vmToEngine
 }
 
 func (s *scheduler) Dispatch(buildBlockTime time.Time) {
+	deadline := buildBlockTime
 	timer := time.NewTimer(time.Until(buildBlockTime))
+	// A request to build a block that is being held back until the VM has
+	// enough pending work. It is kept across build windows so that the VM
+	// doesn't need to repeat it.
+	holding := false
 waitloop:
 	for {
 		select {
 		case <-timer.C: // It's time to tell the engine to try to build a block
-		case buildBlockTime, ok := <-s.newBuildBlockTime:
+		case window, ok := <-s.newBuildWindow:
 			// Stop the timer and clear [timer.C] if needed
 			if !timer.Stop() {
 				<-timer.C
@@ -68,45 +102,105 @@ waitloop:
 
 			// The time at which we should notify the engine that it should try
 			// to build a block has changed
-			timer.Reset(time.Until(buildBlockTime))
+			deadline = window.deadline
+			timer.Reset(time.Until(window.start))
 			continue waitloop
 		}
 
+		// [timer.C] was drained in the first select statement, so [timer] is
+		// stopped. While a request is held, [timer] fires when the pending
+		// work should be checked again.
+		timerStarted := false
+		if holding {
+			timerStarted = s.hold(timer, deadline)
+			holding = timerStarted
+		}
+
 		for {
+			var timerC <-chan time.Time
+			if timerStarted {
+				timerC = timer.C
+			}
+
 			select {
 			case msg := <-s.fromVM:
-				// Give the engine the message from the VM asking the engine to
-				// build a block
-				select {
-				case s.toEngine <- msg:
-				default:
-					// If the channel to the engine is full, drop the message
-					// from the VM to avoid deadlock
-					s.log.Debug("dropping message from VM",
-						zap.String("reason", "channel to engine is full"),
-						zap.Stringer("messageString", msg),
-					)
+				if msg != common.PendingTxs {
+					s.notifyEngine(msg)
+					continue
+				}
+				if holding {
+					// A request is already being held
+					continue
 				}
-			case buildBlockTime, ok := <-s.newBuildBlockTime:
+				timerStarted = s.hold(timer, deadline)
+				holding = timerStarted
+			case <-timerC:
+				timerStarted = s.hold(timer, deadline)
+				holding = timerStarted
+			case window, ok := <-s.newBuildWindow:
 				// The time at which we should notify the engine that it should
 				// try to build a block has changed
+				if timerStarted && !timer.Stop() {
+					<-timer.C
+				}
 				if !ok {
 					// s.Close() was called
 					return
 				}
-				// We know [timer.C] was drained in the first select statement
-				// so its safe to call [timer.Reset]
-				timer.Reset(time.Until(buildBlockTime))
+				deadline = window.deadline
+				timer.Reset(time.Until(window.start))
 				continue waitloop
 			}
 		}
 	}
 }
 
+// hold determines whether a request to build a block should be held back
+// until the VM has more pending work. If so, [timer] is started to check the
+// pending work again and true is returned. Otherwise, the engine is notified
+// and false is returned.
+//
+// Assumes [timer] is stopped and [timer.C] is drained.
+func (s *scheduler) hold(timer *time.Timer, deadline time.Time) bool {
+	untilDeadline := time.Until(deadline)
+	if s.hasPendingWork == nil || untilDeadline <= 0 || s.hasPendingWork() {
+		s.notifyEngine(common.PendingTxs)
+		return false
+	}
+
+	if untilDeadline > pendingWorkPollFrequency {
+		untilDeadline = pendingWorkPollFrequency
+	}
+	timer.Reset(untilDeadline)
+	return true
+}
+
+func (s *scheduler) notifyEngine(msg common.Message) {
+	// Give the engine the message from the VM asking the engine to build a
+	// block
+	select {
+	case s.toEngine <- msg:
+	default:
+		// If the channel to the engine is full, drop the message from the VM
+		// to avoid deadlock
+		s.log.Debug("dropping message from VM",
+			zap.String("reason", "channel to engine is full"),
+			zap.Stringer("messageString", msg),
+		)
+	}
+}
+
 func (s *scheduler) SetBuildBlockTime(t time.Time) {
-	s.newBuildBlockTime <- t
+	s.SetBuildBlockWindow(t, t)
+}
+
+func (s *scheduler) SetBuildBlockWindow(start time.Time, deadline time.Time) {
+	s.newBuildWindow <- buildWindow{
+		start:    start,
+		deadline: deadline,
+	}
 }
 
 func (s *scheduler) Close() {
-	close(s.newBuildBlockTime)
+	close(s.newBuildWindow)
 }
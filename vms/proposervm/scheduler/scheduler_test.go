@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -17,7 +18,7 @@ func TestDelayFromNew(t *testing.T) {
 	toEngine := make(chan common.Message, 10)
 	startTime := time.Now().Add(50 * time.Millisecond)
 
-	s, fromVM := New(logging.NoLog{}, toEngine)
+	s, fromVM := New(logging.NoLog{}, toEngine, nil)
 	defer s.Close()
 	go s.Dispatch(startTime)
 
@@ -32,7 +33,7 @@ func TestDelayFromSetTime(t *testing.T) {
 	now := time.Now()
 	startTime := now.Add(50 * time.Millisecond)
 
-	s, fromVM := New(logging.NoLog{}, toEngine)
+	s, fromVM := New(logging.NoLog{}, toEngine, nil)
 	defer s.Close()
 	go s.Dispatch(now)
 
@@ -49,7 +50,7 @@ func TestReceipt(*testing.T) {
 	now := time.Now()
 	startTime := now.Add(50 * time.Millisecond)
 
-	s, fromVM := New(logging.NoLog{}, toEngine)
+	s, fromVM := New(logging.NoLog{}, toEngine, nil)
 	defer s.Close()
 	go s.Dispatch(now)
 
@@ -59,3 +60,74 @@ func TestReceipt(*testing.T) {
 
 	<-toEngine
 }
+
+func TestHoldUntilPendingWork(t *testing.T) {
+	require := require.New(t)
+
+	toEngine := make(chan common.Message, 10)
+	now := time.Now()
+
+	var hasPendingWork utils.Atomic[bool]
+	s, fromVM := New(logging.NoLog{}, toEngine, hasPendingWork.Get)
+	defer s.Close()
+	go s.Dispatch(now)
+
+	s.SetBuildBlockWindow(now, now.Add(time.Hour))
+
+	fromVM <- common.PendingTxs
+
+	select {
+	case <-toEngine:
+		require.FailNow("engine notified without pending work")
+	case <-time.After(3 * pendingWorkPollFrequency):
+	}
+
+	hasPendingWork.Set(true)
+	require.Equal(common.PendingTxs, <-toEngine)
+}
+
+func TestHoldUntilDeadline(t *testing.T) {
+	require := require.New(t)
+
+	toEngine := make(chan common.Message, 10)
+	now := time.Now()
+	deadline := now.Add(50 * time.Millisecond)
+
+	s, fromVM := New(logging.NoLog{}, toEngine, func() bool {
+		return false
+	})
+	defer s.Close()
+	go s.Dispatch(now)
+
+	s.SetBuildBlockWindow(now, deadline)
+
+	fromVM <- common.PendingTxs
+
+	require.Equal(common.PendingTxs, <-toEngine)
+	require.LessOrEqual(time.Until(deadline), time.Duration(0))
+}
+
+func TestHoldAcrossWindows(t *testing.T) {
+	require := require.New(t)
+
+	toEngine := make(chan common.Message, 10)
+	now := time.Now()
+
+	s, fromVM := New(logging.NoLog{}, toEngine, func() bool {
+		return false
+	})
+	defer s.Close()
+	go s.Dispatch(now)
+
+	s.SetBuildBlockWindow(now, now.Add(time.Hour))
+
+	fromVM <- common.PendingTxs
+
+	// The held request is delivered once the next window's deadline passes,
+	// without the VM repeating it.
+	startTime := now.Add(50 * time.Millisecond)
+	s.SetBuildBlockTime(startTime)
+
+	require.Equal(common.PendingTxs, <-toEngine)
+	require.LessOrEqual(time.Until(startTime), time.Duration(0))
+}
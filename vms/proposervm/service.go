@@ -0,0 +1,290 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// maxGetProposersLimit is the maximum number of proposers returned by a single
+// GetProposers call.
+const maxGetProposersLimit = 1024
+
+var errInvalidHeightRange = errors.New("start height is greater than end height")
+
+// Service defines the API calls that can be made to the proposervm
+type Service struct {
+	vm *VM
+}
+
+// APIEquivocation is the API representation of an equivocation
+type APIEquivocation struct {
+	Proposer    ids.NodeID  `json:"proposer"`
+	ParentID    ids.ID      `json:"parentID"`
+	WindowIndex json.Uint64 `json:"windowIndex"`
+	BlockIDs    []ids.ID    `json:"blockIDs"`
+	// Proof can be parsed with [block.ParseEquivocationProof]
+	Proof    string              `json:"proof"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetEquivocationsReply is the response from GetEquivocations
+type GetEquivocationsReply struct {
+	Equivocations []APIEquivocation `json:"equivocations"`
+}
+
+// GetEquivocations returns the most recent proposer equivocations detected by
+// this node, oldest first.
+func (s *Service) GetEquivocations(_ *http.Request, _ *struct{}, reply *GetEquivocationsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "proposervm"),
+		zap.String("method", "getEquivocations"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	equivocations := s.vm.equivocations.Equivocations()
+	reply.Equivocations = make([]APIEquivocation, len(equivocations))
+	for i, equivocation := range equivocations {
+		proof, err := formatting.Encode(formatting.Hex, equivocation.Proof.Bytes())
+		if err != nil {
+			return err
+		}
+		reply.Equivocations[i] = APIEquivocation{
+			Proposer:    equivocation.Proposer,
+			ParentID:    equivocation.ParentID,
+			WindowIndex: json.Uint64(equivocation.WindowIndex),
+			BlockIDs: []ids.ID{
+				equivocation.Proof.First().ID(),
+				equivocation.Proof.Second().ID(),
+			},
+			Proof:    proof,
+			Encoding: formatting.Hex,
+		}
+	}
+	return nil
+}
+
+// GetProposerAtArgs are the arguments for calling GetProposerAt
+type GetProposerAtArgs struct {
+	Height json.Uint64 `json:"height"`
+}
+
+// GetProposerAtReply is the response from GetProposerAt
+type GetProposerAtReply struct {
+	// Proposer is empty if the block was unsigned
+	Proposer ids.NodeID `json:"proposer"`
+}
+
+// GetProposerAt returns the node that proposed the accepted block at the
+// provided height. Options are attributed to the proposer of their parent.
+func (s *Service) GetProposerAt(_ *http.Request, args *GetProposerAtArgs, reply *GetProposerAtReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "proposervm"),
+		zap.String("method", "getProposerAt"),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	proposer, err := s.vm.State.GetProposerAt(uint64(args.Height))
+	if err != nil {
+		return fmt.Errorf("couldn't get proposer at height %d: %w", args.Height, err)
+	}
+	reply.Proposer = proposer
+	return nil
+}
+
+// GetProposersArgs are the arguments for calling GetProposers
+type GetProposersArgs struct {
+	StartHeight json.Uint64 `json:"startHeight"`
+	EndHeight   json.Uint64 `json:"endHeight"`
+	// Limit defaults to, and is capped at, [maxGetProposersLimit]
+	Limit json.Uint32 `json:"limit"`
+}
+
+// APIProposerAtHeight is the API representation of the proposer of a block
+type APIProposerAtHeight struct {
+	Height json.Uint64 `json:"height"`
+	// Proposer is empty if the block was unsigned
+	Proposer ids.NodeID `json:"proposer"`
+}
+
+// GetProposersReply is the response from GetProposers
+type GetProposersReply struct {
+	Proposers []APIProposerAtHeight `json:"proposers"`
+}
+
+// GetProposers returns the proposers of the indexed accepted blocks with
+// heights in [StartHeight, EndHeight], in increasing height order. Heights
+// accepted before the index was populated are omitted. If more than [Limit]
+// heights are indexed, the request should be repeated starting after the last
+// returned height.
+func (s *Service) GetProposers(_ *http.Request, args *GetProposersArgs, reply *GetProposersReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "proposervm"),
+		zap.String("method", "getProposers"),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+	)
+
+	if args.StartHeight > args.EndHeight {
+		return fmt.Errorf("%w: %d > %d", errInvalidHeightRange, args.StartHeight, args.EndHeight)
+	}
+	limit := int(args.Limit)
+	if limit <= 0 || limit > maxGetProposersLimit {
+		limit = maxGetProposersLimit
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	proposers, err := s.vm.State.GetProposersInRange(uint64(args.StartHeight), uint64(args.EndHeight), limit)
+	if err != nil {
+		return fmt.Errorf("couldn't get proposers: %w", err)
+	}
+	reply.Proposers = make([]APIProposerAtHeight, len(proposers))
+	for i, proposer := range proposers {
+		reply.Proposers[i] = APIProposerAtHeight{
+			Height:   json.Uint64(proposer.Height),
+			Proposer: proposer.Proposer,
+		}
+	}
+	return nil
+}
+
+// GetMissedSlotsArgs are the arguments for calling GetMissedSlots
+type GetMissedSlotsArgs struct {
+	// NodeIDs to report. If empty, every proposer that missed at least one
+	// proposer window is reported.
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+// APIMissedSlots is the API representation of the proposer windows missed by
+// a proposer
+type APIMissedSlots struct {
+	NodeID ids.NodeID  `json:"nodeID"`
+	Missed json.Uint64 `json:"missed"`
+	// LastMissedHeight is 0 if [Missed] is 0
+	LastMissedHeight json.Uint64 `json:"lastMissedHeight"`
+}
+
+// GetMissedSlotsReply is the response from GetMissedSlots
+type GetMissedSlotsReply struct {
+	// The missed slots are aggregated over the accepted heights in
+	// [StartHeight, EndHeight]
+	StartHeight json.Uint64      `json:"startHeight"`
+	EndHeight   json.Uint64      `json:"endHeight"`
+	Proposers   []APIMissedSlots `json:"proposers"`
+}
+
+// GetMissedSlots returns, for each proposer, the number of proposer windows
+// that passed without it proposing the accepted block during the most recently
+// accepted heights. Proposers are sorted by decreasing number of missed slots.
+// Only blocks accepted while this node wasn't syncing are considered.
+func (s *Service) GetMissedSlots(_ *http.Request, args *GetMissedSlotsArgs, reply *GetMissedSlotsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "proposervm"),
+		zap.String("method", "getMissedSlots"),
+		zap.Int("numNodeIDs", len(args.NodeIDs)),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	nodeIDs := args.NodeIDs
+	if len(nodeIDs) == 0 {
+		nodeIDs = s.vm.missedSlots.Proposers()
+	}
+
+	reply.StartHeight = json.Uint64(missedSlotsStartHeight(s.vm.lastAcceptedHeight))
+	reply.EndHeight = json.Uint64(s.vm.lastAcceptedHeight)
+	reply.Proposers = make([]APIMissedSlots, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		stats := s.vm.missedSlots.Stats(nodeID)
+		reply.Proposers[i] = APIMissedSlots{
+			NodeID:           nodeID,
+			Missed:           json.Uint64(stats.Missed),
+			LastMissedHeight: json.Uint64(stats.LastMissedHeight),
+		}
+	}
+	slices.SortFunc(reply.Proposers, func(a, b APIMissedSlots) bool {
+		if a.Missed != b.Missed {
+			return a.Missed > b.Missed
+		}
+		return a.NodeID.Less(b.NodeID)
+	})
+	return nil
+}
+
+// APIStakingKey is the API representation of a staking key of this node
+type APIStakingKey struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// IsValidator is true if [NodeID] is a validator of the subnet at the
+	// current P-chain height
+	IsValidator bool `json:"isValidator"`
+}
+
+// GetStakingKeyRotationReply is the response from GetStakingKeyRotation
+type GetStakingKeyRotationReply struct {
+	// Rotating is true if blocks may be signed with either staking key
+	Rotating bool `json:"rotating"`
+	// PChainHeight is the P-chain height [IsValidator] was checked at
+	PChainHeight json.Uint64   `json:"pChainHeight"`
+	Primary      APIStakingKey `json:"primary"`
+	// Secondary is the key being rotated to. It is nil if [Rotating] is false.
+	Secondary *APIStakingKey `json:"secondary,omitempty"`
+}
+
+// GetStakingKeyRotation returns the staking keys this node signs blocks with.
+// During a key rotation, blocks are built with the secondary key unless the
+// proposer window of the primary key starts earlier.
+func (s *Service) GetStakingKeyRotation(r *http.Request, _ *struct{}, reply *GetStakingKeyRotationReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "proposervm"),
+		zap.String("method", "getStakingKeyRotation"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	ctx := r.Context()
+	pChainHeight, err := s.vm.ctx.ValidatorState.GetCurrentHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't get current P-chain height: %w", err)
+	}
+	vdrs, err := s.vm.validatorState.GetValidatorSet(ctx, pChainHeight, s.vm.ctx.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get validator set at P-chain height %d: %w", pChainHeight, err)
+	}
+
+	_, isValidator := vdrs[s.vm.ctx.NodeID]
+	reply.Rotating = s.vm.isRotatingStakingKey()
+	reply.PChainHeight = json.Uint64(pChainHeight)
+	reply.Primary = APIStakingKey{
+		NodeID:      s.vm.ctx.NodeID,
+		IsValidator: isValidator,
+	}
+	if reply.Rotating {
+		nodeID := s.vm.secondaryStakingKey.nodeID
+		_, isValidator := vdrs[nodeID]
+		reply.Secondary = &APIStakingKey{
+			NodeID:      nodeID,
+			IsValidator: isValidator,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+const (
+	// maxSignatureVerificationWorkers is the maximum number of goroutines
+	// that check the signatures of parsed blocks.
+	maxSignatureVerificationWorkers = 8
+	// signatureVerificationQueueSize is the maximum number of parsed blocks
+	// waiting for their signatures to be checked. The signatures of blocks
+	// parsed while the queue is full are checked when the blocks are
+	// verified.
+	signatureVerificationQueueSize = 1024
+	// signatureChecksCacheSize is the maximum number of signature checks
+	// that are kept until their blocks are verified.
+	signatureChecksCacheSize = 2 * signatureVerificationQueueSize
+)
+
+// signatureVerifier checks the proposer signatures of parsed blocks on a
+// bounded pool of workers.
+//
+// Signatures aren't checked while bootstrapping, so blocks are only submitted
+// during normal operations. The verifier targets catching up after
+// bootstrapping, when blocks are pushed to or fetched by the engine before
+// their ancestors are known. Such blocks are parsed well ahead of their
+// verification, so their signatures are checked concurrently rather than one
+// at a time while each block is verified.
+//
+// Proposers sign blocks with the RSA or ECDSA keys of their staking
+// certificates, which don't support batch verification, so each signature is
+// checked on its own.
+//
+// Only the signature check is moved off of the verification path. Blocks are
+// still verified in order, and a block is only considered signed once it is
+// verified.
+type signatureVerifier struct {
+	chainID ids.ID
+	queue   chan *signatureCheck
+
+	lock sync.Mutex
+	// blkID -> signature check of a block with that ID
+	checks *cache.LRU[ids.ID, *signatureCheck]
+}
+
+type signatureCheck struct {
+	chainID ids.ID
+	blk     statelessblock.SignedBlock
+
+	once sync.Once
+	err  error
+}
+
+// run checks the signature of the block, unless it has already been checked.
+// If the signature is being checked concurrently, run waits for the result.
+func (c *signatureCheck) run() error {
+	c.once.Do(func() {
+		c.err = c.blk.Verify(true, c.chainID)
+	})
+	return c.err
+}
+
+// newSignatureVerifier returns a verifier whose workers run until [ctx] is
+// done.
+func newSignatureVerifier(ctx context.Context, chainID ids.ID) *signatureVerifier {
+	v := &signatureVerifier{
+		chainID: chainID,
+		queue:   make(chan *signatureCheck, signatureVerificationQueueSize),
+		checks:  &cache.LRU[ids.ID, *signatureCheck]{Size: signatureChecksCacheSize},
+	}
+	numWorkers := math.Min(runtime.NumCPU(), maxSignatureVerificationWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go v.work(ctx)
+	}
+	return v
+}
+
+func (v *signatureVerifier) work(ctx context.Context) {
+	for {
+		select {
+		case check := <-v.queue:
+			_ = check.run()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Submit queues the signatures of [blks] to be checked. Blocks without a
+// proposer are ignored, as there is no signature to check.
+//
+// Submit never blocks. If the queue is full, the remaining signatures are
+// checked by Verify.
+func (v *signatureVerifier) Submit(blks ...statelessblock.SignedBlock) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	for _, blk := range blks {
+		if blk.Proposer() == ids.EmptyNodeID {
+			continue
+		}
+
+		blkID := blk.ID()
+		if check, ok := v.checks.Get(blkID); ok && bytes.Equal(check.blk.Bytes(), blk.Bytes()) {
+			continue
+		}
+
+		check := &signatureCheck{
+			chainID: v.chainID,
+			blk:     blk,
+		}
+		select {
+		case v.queue <- check:
+			v.checks.Put(blkID, check)
+		default:
+			return
+		}
+	}
+}
+
+// Verify returns the result of blk.Verify(true, chainID). If the signature of
+// [blk] was submitted, the result of the submitted check is returned.
+func (v *signatureVerifier) Verify(blk statelessblock.SignedBlock) error {
+	blkID := blk.ID()
+
+	v.lock.Lock()
+	check, ok := v.checks.Get(blkID)
+	if ok {
+		v.checks.Evict(blkID)
+	}
+	v.lock.Unlock()
+
+	// The ID of a block doesn't commit to its signature, so the check is only
+	// used if it was for the exact same bytes.
+	if !ok || !bytes.Equal(check.blk.Bytes(), blk.Bytes()) {
+		return blk.Verify(true, v.chainID)
+	}
+	return check.run()
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+func TestSignatureVerifier(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chainID := ids.ID{1}
+	verifier := newSignatureVerifier(ctx, chainID)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	cert := staking.CertificateFromX509(tlsCert.Leaf)
+	key := tlsCert.PrivateKey.(crypto.Signer)
+
+	blks := make([]statelessblock.SignedBlock, 3)
+	for i := range blks {
+		blks[i], err = statelessblock.Build(
+			ids.ID{byte(i)},
+			time.Unix(123, 0),
+			2,
+			cert,
+			[]byte{byte(i)},
+			chainID,
+			key,
+		)
+		require.NoError(err)
+	}
+
+	// A block signed for another chain fails verification
+	wrongChainBlk, err := statelessblock.Build(
+		ids.ID{3},
+		time.Unix(123, 0),
+		2,
+		cert,
+		[]byte{3},
+		ids.ID{2},
+		key,
+	)
+	require.NoError(err)
+
+	// A block without a proposer has no signature to check
+	unsignedBlk, err := statelessblock.BuildUnsigned(
+		ids.ID{4},
+		time.Unix(123, 0),
+		2,
+		[]byte{4},
+	)
+	require.NoError(err)
+
+	verifier.Submit(append(blks, wrongChainBlk, unsignedBlk)...)
+	require.Equal(len(blks)+1, verifier.checks.Len())
+
+	for _, blk := range blks {
+		require.NoError(verifier.Verify(blk))
+	}
+	require.ErrorIs(verifier.Verify(wrongChainBlk), rsa.ErrVerification)
+
+	// Checks are dropped once their blocks are verified
+	require.Zero(verifier.checks.Len())
+
+	// Blocks that weren't submitted are verified inline
+	require.NoError(verifier.Verify(blks[0]))
+}
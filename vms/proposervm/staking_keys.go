@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"crypto"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+// stakingKey is a staking key this node can sign blocks with.
+type stakingKey struct {
+	nodeID ids.NodeID
+	signer crypto.Signer
+	cert   *staking.Certificate
+}
+
+// primaryStakingKey returns the staking key of this node's node ID.
+func (vm *VM) primaryStakingKey() *stakingKey {
+	return &stakingKey{
+		nodeID: vm.ctx.NodeID,
+		signer: vm.StakingLeafSigner,
+		cert:   vm.StakingCertLeaf,
+	}
+}
+
+// isRotatingStakingKey returns true if a secondary staking key was provided,
+// which means the node is rotating from its primary staking key to the
+// secondary staking key.
+func (vm *VM) isRotatingStakingKey() bool {
+	return vm.secondaryStakingKey != nil
+}
+
+// isLocalProposer returns true if [nodeID] is the node ID of one of this node's
+// staking keys.
+func (vm *VM) isLocalProposer(nodeID ids.NodeID) bool {
+	return nodeID == vm.ctx.NodeID ||
+		(vm.secondaryStakingKey != nil && nodeID == vm.secondaryStakingKey.nodeID)
+}
+
+// preferredStakingKey returns the key to sign blocks with when the proposer
+// windows don't restrict who may build. During a key rotation, this is the
+// secondary key.
+func (vm *VM) preferredStakingKey() *stakingKey {
+	if vm.secondaryStakingKey != nil {
+		return vm.secondaryStakingKey
+	}
+	return vm.primaryStakingKey()
+}
+
+// buildStakingKey returns the key to build the block at [blkHeight] with,
+// along with the minimum delay after the parent's timestamp that the key may
+// build the block. During a key rotation, the secondary key is used unless the
+// proposer window of the primary key starts earlier.
+func (vm *VM) buildStakingKey(ctx context.Context, blkHeight, pChainHeight uint64) (*stakingKey, time.Duration, error) {
+	primaryKey := vm.primaryStakingKey()
+	primaryDelay, err := vm.Windower.Delay(ctx, blkHeight, pChainHeight, primaryKey.nodeID, proposer.MaxBuildWindows)
+	if err != nil {
+		return nil, 0, err
+	}
+	if vm.secondaryStakingKey == nil {
+		return primaryKey, primaryDelay, nil
+	}
+
+	secondaryDelay, err := vm.Windower.Delay(ctx, blkHeight, pChainHeight, vm.secondaryStakingKey.nodeID, proposer.MaxBuildWindows)
+	if err != nil {
+		return nil, 0, err
+	}
+	if primaryDelay < secondaryDelay {
+		return primaryKey, primaryDelay, nil
+	}
+	return vm.secondaryStakingKey, secondaryDelay, nil
+}
@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func newTestStakingKey(t *testing.T) *stakingKey {
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(t, err)
+
+	cert := staking.CertificateFromX509(tlsCert.Leaf)
+	return &stakingKey{
+		nodeID: ids.NodeIDFromCert(cert),
+		signer: tlsCert.PrivateKey.(crypto.Signer),
+		cert:   cert,
+	}
+}
+
+func TestBuildStakingKey(t *testing.T) {
+	primaryKey := newTestStakingKey(t)
+	secondaryKey := newTestStakingKey(t)
+
+	tests := []struct {
+		name           string
+		secondaryKey   *stakingKey
+		primaryDelay   time.Duration
+		secondaryDelay time.Duration
+		expectedNodeID ids.NodeID
+		expectedDelay  time.Duration
+	}{
+		{
+			name:           "not rotating",
+			primaryDelay:   proposer.WindowDuration,
+			expectedNodeID: primaryKey.nodeID,
+			expectedDelay:  proposer.WindowDuration,
+		},
+		{
+			name:           "secondary window starts earlier",
+			secondaryKey:   secondaryKey,
+			primaryDelay:   proposer.MaxBuildDelay,
+			secondaryDelay: proposer.WindowDuration,
+			expectedNodeID: secondaryKey.nodeID,
+			expectedDelay:  proposer.WindowDuration,
+		},
+		{
+			name:           "primary window starts earlier",
+			secondaryKey:   secondaryKey,
+			primaryDelay:   0,
+			secondaryDelay: proposer.WindowDuration,
+			expectedNodeID: primaryKey.nodeID,
+			expectedDelay:  0,
+		},
+		{
+			name:           "windows start together",
+			secondaryKey:   secondaryKey,
+			primaryDelay:   proposer.WindowDuration,
+			secondaryDelay: proposer.WindowDuration,
+			expectedNodeID: secondaryKey.nodeID,
+			expectedDelay:  proposer.WindowDuration,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			windower := proposer.NewMockWindower(ctrl)
+			windower.EXPECT().Delay(gomock.Any(), uint64(2), uint64(1), primaryKey.nodeID, proposer.MaxBuildWindows).Return(test.primaryDelay, nil)
+			windower.EXPECT().Delay(gomock.Any(), uint64(2), uint64(1), secondaryKey.nodeID, proposer.MaxBuildWindows).Return(test.secondaryDelay, nil).AnyTimes()
+
+			vm := &VM{
+				ctx: &snow.Context{
+					NodeID: primaryKey.nodeID,
+				},
+				Windower: windower,
+				Config: Config{
+					StakingLeafSigner: primaryKey.signer,
+					StakingCertLeaf:   primaryKey.cert,
+				},
+				secondaryStakingKey: test.secondaryKey,
+			}
+
+			key, delay, err := vm.buildStakingKey(context.Background(), 2, 1)
+			require.NoError(err)
+			require.Equal(test.expectedNodeID, key.nodeID)
+			require.Equal(test.expectedDelay, delay)
+
+			require.True(vm.isLocalProposer(primaryKey.nodeID))
+			require.Equal(test.secondaryKey != nil, vm.isLocalProposer(secondaryKey.nodeID))
+			require.Equal(test.secondaryKey != nil, vm.isRotatingStakingKey())
+		})
+	}
+}
+
+func TestBuildBlockWithSecondaryStakingKey(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(ctx))
+	}()
+
+	secondaryKey := newTestStakingKey(t)
+	proVM.secondaryStakingKey = secondaryKey
+
+	// Build a post fork block. It'll be the parent block of the signed block.
+	parentTime := time.Now().Truncate(time.Second)
+	proVM.Set(parentTime)
+
+	coreParentBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:  []byte{1},
+		ParentV: coreGenBlk.ID(),
+		HeightV: coreGenBlk.Height() + 1,
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreParentBlk, nil
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch {
+		case blkID == coreParentBlk.ID():
+			return coreParentBlk, nil
+		case blkID == coreGenBlk.ID():
+			return coreGenBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreParentBlk.Bytes()):
+			return coreParentBlk, nil
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	parentBlk, err := proVM.BuildBlock(ctx)
+	require.NoError(err)
+	require.NoError(parentBlk.Verify(ctx))
+	require.NoError(parentBlk.Accept(ctx))
+
+	// Only the node ID of the secondary key is a validator, so only the
+	// secondary key may propose the child.
+	valState.GetValidatorSetF = func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+		return map[ids.NodeID]*validators.GetValidatorOutput{
+			secondaryKey.nodeID: {
+				NodeID: secondaryKey.nodeID,
+				Weight: 1,
+			},
+		}, nil
+	}
+	// The validator set was modified at already cached heights
+	proVM.validatorState.validatorSets.Flush()
+
+	require.NoError(proVM.SetPreference(ctx, parentBlk.ID()))
+
+	coreChildBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:  []byte{2},
+		ParentV: coreParentBlk.ID(),
+		HeightV: coreParentBlk.Height() + 1,
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreChildBlk, nil
+	}
+
+	childBlk, err := proVM.BuildBlock(ctx)
+	require.NoError(err)
+	require.IsType(&postForkBlock{}, childBlk)
+	require.Equal(secondaryKey.nodeID, childBlk.(*postForkBlock).SignedBlock.Proposer())
+
+	// Blocks signed with the secondary key are verifiable
+	require.NoError(childBlk.Verify(ctx))
+}
@@ -14,7 +14,8 @@ import (
 const cacheSize = 8192 // max cache entries
 
 var (
-	_ HeightIndex = (*heightIndex)(nil)
+	_ HeightIndex     = (*heightIndex)(nil)
+	_ BlockIDIterator = (*blockIDIterator)(nil)
 
 	heightPrefix   = []byte("height")
 	metadataPrefix = []byte("metadata")
@@ -28,6 +29,10 @@ type HeightIndexGetter interface {
 	// there are no indexed blockIDs, ErrNotFound will be returned.
 	GetMinimumHeight() (uint64, error)
 	GetBlockIDAtHeight(height uint64) (ids.ID, error)
+	// NewBlockIDIterator returns an iterator over the indexed blockIDs in
+	// increasing height order. The iterator reads from a snapshot of the
+	// index, so it does not observe modifications made after its creation.
+	NewBlockIDIterator() (BlockIDIterator, error)
 
 	// Fork height is stored when the first post-fork block/option is accepted.
 	// Before that, fork height won't be found.
@@ -51,6 +56,23 @@ type HeightIndexBatchSupport interface {
 	DeleteCheckpoint() error
 }
 
+// BlockIDIterator iterates over indexed blockIDs in increasing height order.
+//
+// An iterator must be released after use.
+type BlockIDIterator interface {
+	// Next moves the iterator to the next indexed blockID. It returns whether
+	// the iterator successfully moved to a new blockID.
+	Next() bool
+	// Height returns the height of the current blockID.
+	Height() uint64
+	// BlockID returns the current blockID.
+	BlockID() ids.ID
+	// Error returns any accumulated error.
+	Error() error
+	// Release releases associated resources.
+	Release()
+}
+
 // HeightIndex contains mapping of blockHeights to accepted proposer block IDs
 // along with some metadata (fork height and checkpoint).
 type HeightIndex interface {
@@ -108,6 +130,17 @@ func (hi *heightIndex) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
 	return blkID, err
 }
 
+func (hi *heightIndex) NewBlockIDIterator() (BlockIDIterator, error) {
+	snapshot, err := database.NewSnapshot(hi.heightDB)
+	if err != nil {
+		return nil, err
+	}
+	return &blockIDIterator{
+		snapshot: snapshot,
+		it:       snapshot.NewIterator(),
+	}, nil
+}
+
 func (hi *heightIndex) SetBlockIDAtHeight(height uint64, blkID ids.ID) error {
 	hi.heightsCache.Put(height, blkID)
 	key := database.PackUInt64(height)
@@ -139,3 +172,45 @@ func (hi *heightIndex) SetCheckpoint(blkID ids.ID) error {
 func (hi *heightIndex) DeleteCheckpoint() error {
 	return hi.metadataDB.Delete(checkpointKey)
 }
+
+type blockIDIterator struct {
+	snapshot database.Snapshot
+	it       database.Iterator
+
+	height uint64
+	blkID  ids.ID
+	err    error
+}
+
+func (it *blockIDIterator) Next() bool {
+	if it.err != nil || !it.it.Next() {
+		return false
+	}
+
+	it.height, it.err = database.ParseUInt64(it.it.Key())
+	if it.err != nil {
+		return false
+	}
+	it.blkID, it.err = ids.ToID(it.it.Value())
+	return it.err == nil
+}
+
+func (it *blockIDIterator) Height() uint64 {
+	return it.height
+}
+
+func (it *blockIDIterator) BlockID() ids.ID {
+	return it.blkID
+}
+
+func (it *blockIDIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.it.Error()
+}
+
+func (it *blockIDIterator) Release() {
+	it.it.Release()
+	it.snapshot.Release()
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestBlockIDIterator(t *testing.T) {
+	require := require.New(t)
+
+	db := versiondb.New(memdb.New())
+	hi := NewHeightIndex(db, db)
+
+	blkIDs := []ids.ID{
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+	}
+	for i, blkID := range blkIDs {
+		require.NoError(hi.SetBlockIDAtHeight(uint64(i+1), blkID))
+	}
+	require.NoError(hi.Commit())
+
+	it, err := hi.NewBlockIDIterator()
+	require.NoError(err)
+	defer it.Release()
+
+	// Modifications made after the iterator was created should not be
+	// observed by the iterator.
+	require.NoError(hi.DeleteBlockIDAtHeight(2))
+	require.NoError(hi.SetBlockIDAtHeight(4, ids.GenerateTestID()))
+	require.NoError(hi.Commit())
+
+	for i, blkID := range blkIDs {
+		require.True(it.Next())
+		require.Equal(uint64(i+1), it.Height())
+		require.Equal(blkID, it.BlockID())
+	}
+	require.False(it.Next())
+	require.NoError(it.Error())
+}
@@ -4,16 +4,32 @@
 package state
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 )
 
 const (
 	lastAcceptedByte byte = iota
+	activationTimeByte
+	minimumPChainHeightByte
+	pChainHeightEpochActivationTimeByte
+	enforcedMinBlkDelayActivationTimeByte
+	commitValidatorSetActivationTimeByte
+	vrfActivationTimeByte
+	blockExtensionsActivationTimeByte
 )
 
 var (
-	lastAcceptedKey = []byte{lastAcceptedByte}
+	lastAcceptedKey                      = []byte{lastAcceptedByte}
+	activationTimeKey                    = []byte{activationTimeByte}
+	minimumPChainHeightKey               = []byte{minimumPChainHeightByte}
+	pChainHeightEpochActivationTimeKey   = []byte{pChainHeightEpochActivationTimeByte}
+	enforcedMinBlkDelayActivationTimeKey = []byte{enforcedMinBlkDelayActivationTimeByte}
+	commitValidatorSetActivationTimeKey  = []byte{commitValidatorSetActivationTimeByte}
+	vrfActivationTimeKey                 = []byte{vrfActivationTimeByte}
+	blockExtensionsActivationTimeKey     = []byte{blockExtensionsActivationTimeByte}
 
 	_ ChainState = (*chainState)(nil)
 )
@@ -22,6 +38,41 @@ type ChainState interface {
 	SetLastAccepted(blkID ids.ID) error
 	DeleteLastAccepted() error
 	GetLastAccepted() (ids.ID, error)
+
+	// SetActivationTime records the fork time the chain was last started
+	// with.
+	SetActivationTime(activationTime time.Time) error
+	GetActivationTime() (time.Time, error)
+
+	// SetMinimumPChainHeight records the minimum P-chain height of the first
+	// post-fork block the chain was last started with.
+	SetMinimumPChainHeight(height uint64) error
+	GetMinimumPChainHeight() (uint64, error)
+
+	// SetPChainHeightEpochActivationTime records the P-chain height epoch fork
+	// time the chain was last started with.
+	SetPChainHeightEpochActivationTime(activationTime time.Time) error
+	GetPChainHeightEpochActivationTime() (time.Time, error)
+
+	// SetEnforcedMinBlkDelayActivationTime records the enforced minimum block
+	// delay fork time the chain was last started with.
+	SetEnforcedMinBlkDelayActivationTime(activationTime time.Time) error
+	GetEnforcedMinBlkDelayActivationTime() (time.Time, error)
+
+	// SetCommitValidatorSetActivationTime records the validator set
+	// commitment fork time the chain was last started with.
+	SetCommitValidatorSetActivationTime(activationTime time.Time) error
+	GetCommitValidatorSetActivationTime() (time.Time, error)
+
+	// SetVRFActivationTime records the VRF fork time the chain was last
+	// started with.
+	SetVRFActivationTime(activationTime time.Time) error
+	GetVRFActivationTime() (time.Time, error)
+
+	// SetBlockExtensionsActivationTime records the block extensions fork time
+	// the chain was last started with.
+	SetBlockExtensionsActivationTime(activationTime time.Time) error
+	GetBlockExtensionsActivationTime() (time.Time, error)
 }
 
 type chainState struct {
@@ -61,3 +112,59 @@ func (s *chainState) GetLastAccepted() (ids.ID, error) {
 	s.lastAccepted = lastAccepted
 	return lastAccepted, nil
 }
+
+func (s *chainState) SetActivationTime(activationTime time.Time) error {
+	return database.PutTimestamp(s.db, activationTimeKey, activationTime)
+}
+
+func (s *chainState) GetActivationTime() (time.Time, error) {
+	return database.GetTimestamp(s.db, activationTimeKey)
+}
+
+func (s *chainState) SetMinimumPChainHeight(height uint64) error {
+	return database.PutUInt64(s.db, minimumPChainHeightKey, height)
+}
+
+func (s *chainState) GetMinimumPChainHeight() (uint64, error) {
+	return database.GetUInt64(s.db, minimumPChainHeightKey)
+}
+
+func (s *chainState) SetPChainHeightEpochActivationTime(activationTime time.Time) error {
+	return database.PutTimestamp(s.db, pChainHeightEpochActivationTimeKey, activationTime)
+}
+
+func (s *chainState) GetPChainHeightEpochActivationTime() (time.Time, error) {
+	return database.GetTimestamp(s.db, pChainHeightEpochActivationTimeKey)
+}
+
+func (s *chainState) SetEnforcedMinBlkDelayActivationTime(activationTime time.Time) error {
+	return database.PutTimestamp(s.db, enforcedMinBlkDelayActivationTimeKey, activationTime)
+}
+
+func (s *chainState) GetEnforcedMinBlkDelayActivationTime() (time.Time, error) {
+	return database.GetTimestamp(s.db, enforcedMinBlkDelayActivationTimeKey)
+}
+
+func (s *chainState) SetCommitValidatorSetActivationTime(activationTime time.Time) error {
+	return database.PutTimestamp(s.db, commitValidatorSetActivationTimeKey, activationTime)
+}
+
+func (s *chainState) GetCommitValidatorSetActivationTime() (time.Time, error) {
+	return database.GetTimestamp(s.db, commitValidatorSetActivationTimeKey)
+}
+
+func (s *chainState) SetVRFActivationTime(activationTime time.Time) error {
+	return database.PutTimestamp(s.db, vrfActivationTimeKey, activationTime)
+}
+
+func (s *chainState) GetVRFActivationTime() (time.Time, error) {
+	return database.GetTimestamp(s.db, vrfActivationTimeKey)
+}
+
+func (s *chainState) SetBlockExtensionsActivationTime(activationTime time.Time) error {
+	return database.PutTimestamp(s.db, blockExtensionsActivationTimeKey, activationTime)
+}
+
+func (s *chainState) GetBlockExtensionsActivationTime() (time.Time, error) {
+	return database.GetTimestamp(s.db, blockExtensionsActivationTimeKey)
+}
@@ -5,6 +5,7 @@ package state
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -38,6 +39,69 @@ func testChainState(a *require.Assertions, cs ChainState) {
 
 	_, err = cs.GetLastAccepted()
 	a.Equal(database.ErrNotFound, err)
+
+	_, err = cs.GetActivationTime()
+	a.Equal(database.ErrNotFound, err)
+
+	activationTime := time.Unix(1_700_000_000, 0)
+	a.NoError(cs.SetActivationTime(activationTime))
+
+	fetchedActivationTime, err := cs.GetActivationTime()
+	a.NoError(err)
+	a.Equal(activationTime.Unix(), fetchedActivationTime.Unix())
+
+	_, err = cs.GetMinimumPChainHeight()
+	a.Equal(database.ErrNotFound, err)
+
+	minimumPChainHeight := uint64(1337)
+	a.NoError(cs.SetMinimumPChainHeight(minimumPChainHeight))
+
+	fetchedMinimumPChainHeight, err := cs.GetMinimumPChainHeight()
+	a.NoError(err)
+	a.Equal(minimumPChainHeight, fetchedMinimumPChainHeight)
+
+	_, err = cs.GetPChainHeightEpochActivationTime()
+	a.Equal(database.ErrNotFound, err)
+
+	epochActivationTime := activationTime.Add(time.Hour)
+	a.NoError(cs.SetPChainHeightEpochActivationTime(epochActivationTime))
+
+	fetchedEpochActivationTime, err := cs.GetPChainHeightEpochActivationTime()
+	a.NoError(err)
+	a.Equal(epochActivationTime.Unix(), fetchedEpochActivationTime.Unix())
+
+	forks := []struct {
+		get func() (time.Time, error)
+		set func(time.Time) error
+	}{
+		{
+			get: cs.GetEnforcedMinBlkDelayActivationTime,
+			set: cs.SetEnforcedMinBlkDelayActivationTime,
+		},
+		{
+			get: cs.GetCommitValidatorSetActivationTime,
+			set: cs.SetCommitValidatorSetActivationTime,
+		},
+		{
+			get: cs.GetVRFActivationTime,
+			set: cs.SetVRFActivationTime,
+		},
+		{
+			get: cs.GetBlockExtensionsActivationTime,
+			set: cs.SetBlockExtensionsActivationTime,
+		},
+	}
+	for i, fork := range forks {
+		_, err := fork.get()
+		a.Equal(database.ErrNotFound, err)
+
+		forkActivationTime := activationTime.Add(time.Duration(i+2) * time.Hour)
+		a.NoError(fork.set(forkActivationTime))
+
+		fetchedForkActivationTime, err := fork.get()
+		a.NoError(err)
+		a.Equal(forkActivationTime.Unix(), fetchedForkActivationTime.Unix())
+	}
 }
 
 func TestChainState(t *testing.T) {
@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	_ InnerBlockIndex = (*innerBlockIndex)(nil)
+
+	outerToInnerPrefix = []byte("outerToInner")
+	innerToOuterPrefix = []byte("innerToOuter")
+)
+
+// InnerBlockIndex maps accepted proposer block IDs to the IDs of the inner
+// blocks they wrap, and vice versa.
+type InnerBlockIndex interface {
+	// GetOuterBlockID returns the ID of the accepted proposer block that wraps
+	// [innerBlkID].
+	GetOuterBlockID(innerBlkID ids.ID) (ids.ID, error)
+	// GetInnerBlockID returns the ID of the inner block wrapped by the
+	// accepted proposer block [outerBlkID].
+	GetInnerBlockID(outerBlkID ids.ID) (ids.ID, error)
+	PutInnerBlockID(outerBlkID ids.ID, innerBlkID ids.ID) error
+	// DeleteInnerBlockID removes [outerBlkID], and the inner block it wraps,
+	// from the index. It is not an error if [outerBlkID] isn't indexed.
+	DeleteInnerBlockID(outerBlkID ids.ID) error
+}
+
+type innerBlockIndex struct {
+	outerToInnerDB database.Database
+	innerToOuterDB database.Database
+}
+
+func NewInnerBlockIndex(db database.Database) InnerBlockIndex {
+	return &innerBlockIndex{
+		outerToInnerDB: prefixdb.New(outerToInnerPrefix, db),
+		innerToOuterDB: prefixdb.New(innerToOuterPrefix, db),
+	}
+}
+
+func (i *innerBlockIndex) GetOuterBlockID(innerBlkID ids.ID) (ids.ID, error) {
+	return database.GetID(i.innerToOuterDB, innerBlkID[:])
+}
+
+func (i *innerBlockIndex) GetInnerBlockID(outerBlkID ids.ID) (ids.ID, error) {
+	return database.GetID(i.outerToInnerDB, outerBlkID[:])
+}
+
+func (i *innerBlockIndex) PutInnerBlockID(outerBlkID ids.ID, innerBlkID ids.ID) error {
+	if err := database.PutID(i.outerToInnerDB, outerBlkID[:], innerBlkID); err != nil {
+		return err
+	}
+	return database.PutID(i.innerToOuterDB, innerBlkID[:], outerBlkID)
+}
+
+func (i *innerBlockIndex) DeleteInnerBlockID(outerBlkID ids.ID) error {
+	innerBlkID, err := i.GetInnerBlockID(outerBlkID)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := i.outerToInnerDB.Delete(outerBlkID[:]); err != nil {
+		return err
+	}
+	return i.innerToOuterDB.Delete(innerBlkID[:])
+}
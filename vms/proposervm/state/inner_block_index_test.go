@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func testInnerBlockIndex(a *require.Assertions, i InnerBlockIndex) {
+	outerBlkID := ids.GenerateTestID()
+	innerBlkID := ids.GenerateTestID()
+
+	_, err := i.GetOuterBlockID(innerBlkID)
+	a.Equal(database.ErrNotFound, err)
+
+	_, err = i.GetInnerBlockID(outerBlkID)
+	a.Equal(database.ErrNotFound, err)
+
+	a.NoError(i.PutInnerBlockID(outerBlkID, innerBlkID))
+
+	fetchedOuterBlkID, err := i.GetOuterBlockID(innerBlkID)
+	a.NoError(err)
+	a.Equal(outerBlkID, fetchedOuterBlkID)
+
+	fetchedInnerBlkID, err := i.GetInnerBlockID(outerBlkID)
+	a.NoError(err)
+	a.Equal(innerBlkID, fetchedInnerBlkID)
+
+	a.NoError(i.DeleteInnerBlockID(outerBlkID))
+
+	_, err = i.GetOuterBlockID(innerBlkID)
+	a.Equal(database.ErrNotFound, err)
+
+	_, err = i.GetInnerBlockID(outerBlkID)
+	a.Equal(database.ErrNotFound, err)
+
+	// Deleting an unindexed block is a noop.
+	a.NoError(i.DeleteInnerBlockID(outerBlkID))
+}
+
+func TestInnerBlockIndex(t *testing.T) {
+	a := require.New(t)
+
+	db := memdb.New()
+	i := NewInnerBlockIndex(db)
+
+	testInnerBlockIndex(a, i)
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow/choices"
+)
+
+// VerifyIntegrity checks that every block in the height index is an accepted
+// block in the block store and is indexed in the inner block index, and that
+// the indexed heights are contiguous. A description of every discrepancy found
+// is returned.
+func (s *state) VerifyIntegrity(ctx context.Context) ([]string, error) {
+	var discrepancies []string
+
+	lastAcceptedID, err := s.GetLastAccepted()
+	switch err {
+	case nil:
+		if _, _, err := s.GetBlock(lastAcceptedID); err == database.ErrNotFound {
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"last accepted block %s is missing from the block store",
+				lastAcceptedID,
+			))
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get last accepted block %s: %w", lastAcceptedID, err)
+		}
+	case database.ErrNotFound:
+		// No post-fork block has been accepted yet.
+	default:
+		return nil, fmt.Errorf("failed to get last accepted block ID: %w", err)
+	}
+
+	it, err := s.NewBlockIDIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	var (
+		expectedHeight uint64
+		first          = true
+	)
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		height := it.Height()
+		blkID := it.BlockID()
+		if !first && height != expectedHeight {
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"height index is missing heights [%d, %d)",
+				expectedHeight,
+				height,
+			))
+		}
+		first = false
+		expectedHeight = height + 1
+
+		_, status, err := s.GetBlock(blkID)
+		switch err {
+		case nil:
+			if status != choices.Accepted {
+				discrepancies = append(discrepancies, fmt.Sprintf(
+					"block %s at height %d has status %s",
+					blkID,
+					height,
+					status,
+				))
+			}
+		case database.ErrNotFound:
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"block %s at height %d is missing from the block store",
+				blkID,
+				height,
+			))
+		default:
+			return nil, fmt.Errorf("failed to get block %s: %w", blkID, err)
+		}
+
+		innerBlkID, err := s.GetInnerBlockID(blkID)
+		if err == database.ErrNotFound {
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"block %s at height %d is missing from the inner block index",
+				blkID,
+				height,
+			))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inner block ID of %s: %w", blkID, err)
+		}
+
+		outerBlkID, err := s.GetOuterBlockID(innerBlkID)
+		if err != nil && err != database.ErrNotFound {
+			return nil, fmt.Errorf("failed to get outer block ID of %s: %w", innerBlkID, err)
+		}
+		if outerBlkID != blkID {
+			discrepancies = append(discrepancies, fmt.Sprintf(
+				"inner block %s of block %s at height %d is indexed as wrapped by %s",
+				innerBlkID,
+				blkID,
+				height,
+				outerBlkID,
+			))
+		}
+	}
+	return discrepancies, it.Error()
+}
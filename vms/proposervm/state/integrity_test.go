@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+func TestVerifyIntegrity(t *testing.T) {
+	require := require.New(t)
+
+	s := New(versiondb.New(memdb.New()))
+
+	// Index a chain of blocks at heights [10, 15)
+	blkIDs := make([]ids.ID, 5)
+	parentID := ids.GenerateTestID()
+	for i := range blkIDs {
+		blk, err := block.BuildUnsigned(parentID, time.Unix(int64(i), 0), 0, []byte{byte(i)})
+		require.NoError(err)
+
+		blkID := blk.ID()
+		require.NoError(s.PutBlock(blk, choices.Accepted))
+		require.NoError(s.PutInnerBlockID(blkID, ids.GenerateTestID()))
+		require.NoError(s.SetBlockIDAtHeight(uint64(10+i), blkID))
+		require.NoError(s.SetLastAccepted(blkID))
+
+		blkIDs[i] = blkID
+		parentID = blkID
+	}
+
+	ctx := context.Background()
+	discrepancies, err := s.VerifyIntegrity(ctx)
+	require.NoError(err)
+	require.Empty(discrepancies)
+
+	// Remove a height from the middle of the index
+	require.NoError(s.DeleteBlockIDAtHeight(11))
+	// Remove an indexed block from the block store
+	require.NoError(s.DeleteBlock(blkIDs[2]))
+	// Remove an indexed block from the inner block index
+	require.NoError(s.DeleteInnerBlockID(blkIDs[3]))
+	// Map the inner block of an indexed block to a different block
+	innerBlkID, err := s.GetInnerBlockID(blkIDs[4])
+	require.NoError(err)
+	require.NoError(s.PutInnerBlockID(ids.GenerateTestID(), innerBlkID))
+
+	discrepancies, err = s.VerifyIntegrity(ctx)
+	require.NoError(err)
+	require.Len(discrepancies, 4)
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = s.VerifyIntegrity(cancelledCtx)
+	require.ErrorIs(err, context.Canceled)
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	_ MissedSlotIndex = (*missedSlotIndex)(nil)
+
+	errInvalidMissedSlotsLen = errors.New("invalid missed slots length")
+)
+
+// MissedSlotsAtHeight are the proposers that missed their proposer windows
+// before the accepted block at a height was proposed.
+type MissedSlotsAtHeight struct {
+	Height    uint64
+	Proposers []ids.NodeID
+}
+
+// MissedSlotIndex maps the heights of accepted blocks to the proposers whose
+// proposer windows passed before the block was proposed.
+//
+// Heights without any missed slots aren't indexed.
+type MissedSlotIndex interface {
+	// GetMissedSlots returns the proposers that missed their proposer windows
+	// at [height].
+	GetMissedSlots(height uint64) ([]ids.NodeID, error)
+	// GetMissedSlotsInRange returns the missed slots of the indexed heights in
+	// [startHeight, endHeight] in increasing height order.
+	GetMissedSlotsInRange(startHeight, endHeight uint64) ([]MissedSlotsAtHeight, error)
+	PutMissedSlots(height uint64, proposers []ids.NodeID) error
+	DeleteMissedSlots(height uint64) error
+}
+
+type missedSlotIndex struct {
+	db database.Database
+}
+
+func NewMissedSlotIndex(db database.Database) MissedSlotIndex {
+	return &missedSlotIndex{
+		db: db,
+	}
+}
+
+func (m *missedSlotIndex) GetMissedSlots(height uint64) ([]ids.NodeID, error) {
+	proposersBytes, err := m.db.Get(database.PackUInt64(height))
+	if err != nil {
+		return nil, err
+	}
+	return parseMissedSlots(proposersBytes)
+}
+
+func (m *missedSlotIndex) GetMissedSlotsInRange(startHeight, endHeight uint64) ([]MissedSlotsAtHeight, error) {
+	it := m.db.NewIteratorWithStart(database.PackUInt64(startHeight))
+	defer it.Release()
+
+	var missedSlots []MissedSlotsAtHeight
+	for it.Next() {
+		height, err := database.ParseUInt64(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		if height > endHeight {
+			break
+		}
+
+		proposers, err := parseMissedSlots(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		missedSlots = append(missedSlots, MissedSlotsAtHeight{
+			Height:    height,
+			Proposers: proposers,
+		})
+	}
+	return missedSlots, it.Error()
+}
+
+func (m *missedSlotIndex) PutMissedSlots(height uint64, proposers []ids.NodeID) error {
+	proposersBytes := make([]byte, 0, len(proposers)*ids.NodeIDLen)
+	for _, proposer := range proposers {
+		proposersBytes = append(proposersBytes, proposer.Bytes()...)
+	}
+	return m.db.Put(database.PackUInt64(height), proposersBytes)
+}
+
+func (m *missedSlotIndex) DeleteMissedSlots(height uint64) error {
+	return m.db.Delete(database.PackUInt64(height))
+}
+
+// parseMissedSlots parses the concatenated node IDs in [proposersBytes].
+func parseMissedSlots(proposersBytes []byte) ([]ids.NodeID, error) {
+	if len(proposersBytes)%ids.NodeIDLen != 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidMissedSlotsLen, len(proposersBytes))
+	}
+
+	proposers := make([]ids.NodeID, len(proposersBytes)/ids.NodeIDLen)
+	for i := range proposers {
+		proposer, err := ids.ToNodeID(proposersBytes[i*ids.NodeIDLen : (i+1)*ids.NodeIDLen])
+		if err != nil {
+			return nil, err
+		}
+		proposers[i] = proposer
+	}
+	return proposers, nil
+}
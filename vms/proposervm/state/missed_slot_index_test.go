@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func testMissedSlotIndex(a *require.Assertions, m MissedSlotIndex) {
+	proposer1 := ids.GenerateTestNodeID()
+	proposer2 := ids.GenerateTestNodeID()
+
+	_, err := m.GetMissedSlots(1)
+	a.Equal(database.ErrNotFound, err)
+
+	missedSlots, err := m.GetMissedSlotsInRange(0, 10)
+	a.NoError(err)
+	a.Empty(missedSlots)
+
+	a.NoError(m.PutMissedSlots(1, []ids.NodeID{proposer1}))
+	a.NoError(m.PutMissedSlots(3, []ids.NodeID{proposer1, proposer2}))
+	a.NoError(m.PutMissedSlots(5, []ids.NodeID{proposer2}))
+
+	proposers, err := m.GetMissedSlots(3)
+	a.NoError(err)
+	a.Equal([]ids.NodeID{proposer1, proposer2}, proposers)
+
+	// The range is inclusive.
+	missedSlots, err = m.GetMissedSlotsInRange(1, 3)
+	a.NoError(err)
+	a.Equal([]MissedSlotsAtHeight{
+		{Height: 1, Proposers: []ids.NodeID{proposer1}},
+		{Height: 3, Proposers: []ids.NodeID{proposer1, proposer2}},
+	}, missedSlots)
+
+	a.NoError(m.DeleteMissedSlots(1))
+	_, err = m.GetMissedSlots(1)
+	a.Equal(database.ErrNotFound, err)
+
+	missedSlots, err = m.GetMissedSlotsInRange(0, 10)
+	a.NoError(err)
+	a.Equal([]MissedSlotsAtHeight{
+		{Height: 3, Proposers: []ids.NodeID{proposer1, proposer2}},
+		{Height: 5, Proposers: []ids.NodeID{proposer2}},
+	}, missedSlots)
+}
+
+func TestMissedSlotIndex(t *testing.T) {
+	a := require.New(t)
+
+	db := memdb.New()
+	m := NewMissedSlotIndex(db)
+
+	testMissedSlotIndex(a, m)
+}
+
+func TestMissedSlotIndexInvalidLength(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	m := NewMissedSlotIndex(db)
+
+	require.NoError(db.Put(database.PackUInt64(1), []byte{1}))
+	_, err := m.GetMissedSlots(1)
+	require.ErrorIs(err, errInvalidMissedSlotsLen)
+}
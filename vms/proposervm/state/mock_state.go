@@ -8,7 +8,9 @@
 package state
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	ids "github.com/ava-labs/avalanchego/ids"
 	choices "github.com/ava-labs/avalanchego/snow/choices"
@@ -95,6 +97,20 @@ func (mr *MockStateMockRecorder) DeleteCheckpoint() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCheckpoint", reflect.TypeOf((*MockState)(nil).DeleteCheckpoint))
 }
 
+// DeleteInnerBlockID mocks base method.
+func (m *MockState) DeleteInnerBlockID(arg0 ids.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInnerBlockID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInnerBlockID indicates an expected call of DeleteInnerBlockID.
+func (mr *MockStateMockRecorder) DeleteInnerBlockID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInnerBlockID", reflect.TypeOf((*MockState)(nil).DeleteInnerBlockID), arg0)
+}
+
 // DeleteLastAccepted mocks base method.
 func (m *MockState) DeleteLastAccepted() error {
 	m.ctrl.T.Helper()
@@ -109,6 +125,35 @@ func (mr *MockStateMockRecorder) DeleteLastAccepted() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLastAccepted", reflect.TypeOf((*MockState)(nil).DeleteLastAccepted))
 }
 
+// DeleteMissedSlots mocks base method.
+func (m *MockState) DeleteMissedSlots(arg0 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMissedSlots", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMissedSlots indicates an expected call of DeleteMissedSlots.
+func (mr *MockStateMockRecorder) DeleteMissedSlots(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMissedSlots", reflect.TypeOf((*MockState)(nil).DeleteMissedSlots), arg0)
+}
+
+// GetActivationTime mocks base method.
+func (m *MockState) GetActivationTime() (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivationTime")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivationTime indicates an expected call of GetActivationTime.
+func (mr *MockStateMockRecorder) GetActivationTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivationTime", reflect.TypeOf((*MockState)(nil).GetActivationTime))
+}
+
 // GetBlock mocks base method.
 func (m *MockState) GetBlock(arg0 ids.ID) (block.Block, choices.Status, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +170,21 @@ func (mr *MockStateMockRecorder) GetBlock(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockState)(nil).GetBlock), arg0)
 }
 
+// GetBlockExtensionsActivationTime mocks base method.
+func (m *MockState) GetBlockExtensionsActivationTime() (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockExtensionsActivationTime")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockExtensionsActivationTime indicates an expected call of GetBlockExtensionsActivationTime.
+func (mr *MockStateMockRecorder) GetBlockExtensionsActivationTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockExtensionsActivationTime", reflect.TypeOf((*MockState)(nil).GetBlockExtensionsActivationTime))
+}
+
 // GetBlockIDAtHeight mocks base method.
 func (m *MockState) GetBlockIDAtHeight(arg0 uint64) (ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +215,36 @@ func (mr *MockStateMockRecorder) GetCheckpoint() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpoint", reflect.TypeOf((*MockState)(nil).GetCheckpoint))
 }
 
+// GetCommitValidatorSetActivationTime mocks base method.
+func (m *MockState) GetCommitValidatorSetActivationTime() (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitValidatorSetActivationTime")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitValidatorSetActivationTime indicates an expected call of GetCommitValidatorSetActivationTime.
+func (mr *MockStateMockRecorder) GetCommitValidatorSetActivationTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitValidatorSetActivationTime", reflect.TypeOf((*MockState)(nil).GetCommitValidatorSetActivationTime))
+}
+
+// GetEnforcedMinBlkDelayActivationTime mocks base method.
+func (m *MockState) GetEnforcedMinBlkDelayActivationTime() (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnforcedMinBlkDelayActivationTime")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnforcedMinBlkDelayActivationTime indicates an expected call of GetEnforcedMinBlkDelayActivationTime.
+func (mr *MockStateMockRecorder) GetEnforcedMinBlkDelayActivationTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnforcedMinBlkDelayActivationTime", reflect.TypeOf((*MockState)(nil).GetEnforcedMinBlkDelayActivationTime))
+}
+
 // GetForkHeight mocks base method.
 func (m *MockState) GetForkHeight() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -170,6 +260,21 @@ func (mr *MockStateMockRecorder) GetForkHeight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForkHeight", reflect.TypeOf((*MockState)(nil).GetForkHeight))
 }
 
+// GetInnerBlockID mocks base method.
+func (m *MockState) GetInnerBlockID(arg0 ids.ID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInnerBlockID", arg0)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInnerBlockID indicates an expected call of GetInnerBlockID.
+func (mr *MockStateMockRecorder) GetInnerBlockID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInnerBlockID", reflect.TypeOf((*MockState)(nil).GetInnerBlockID), arg0)
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockState) GetLastAccepted() (ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -200,6 +305,141 @@ func (mr *MockStateMockRecorder) GetMinimumHeight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinimumHeight", reflect.TypeOf((*MockState)(nil).GetMinimumHeight))
 }
 
+// GetMinimumPChainHeight mocks base method.
+func (m *MockState) GetMinimumPChainHeight() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMinimumPChainHeight")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMinimumPChainHeight indicates an expected call of GetMinimumPChainHeight.
+func (mr *MockStateMockRecorder) GetMinimumPChainHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinimumPChainHeight", reflect.TypeOf((*MockState)(nil).GetMinimumPChainHeight))
+}
+
+// GetMissedSlots mocks base method.
+func (m *MockState) GetMissedSlots(arg0 uint64) ([]ids.NodeID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissedSlots", arg0)
+	ret0, _ := ret[0].([]ids.NodeID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissedSlots indicates an expected call of GetMissedSlots.
+func (mr *MockStateMockRecorder) GetMissedSlots(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissedSlots", reflect.TypeOf((*MockState)(nil).GetMissedSlots), arg0)
+}
+
+// GetMissedSlotsInRange mocks base method.
+func (m *MockState) GetMissedSlotsInRange(arg0, arg1 uint64) ([]MissedSlotsAtHeight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissedSlotsInRange", arg0, arg1)
+	ret0, _ := ret[0].([]MissedSlotsAtHeight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissedSlotsInRange indicates an expected call of GetMissedSlotsInRange.
+func (mr *MockStateMockRecorder) GetMissedSlotsInRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissedSlotsInRange", reflect.TypeOf((*MockState)(nil).GetMissedSlotsInRange), arg0, arg1)
+}
+
+// GetOuterBlockID mocks base method.
+func (m *MockState) GetOuterBlockID(arg0 ids.ID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOuterBlockID", arg0)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOuterBlockID indicates an expected call of GetOuterBlockID.
+func (mr *MockStateMockRecorder) GetOuterBlockID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOuterBlockID", reflect.TypeOf((*MockState)(nil).GetOuterBlockID), arg0)
+}
+
+// GetPChainHeightEpochActivationTime mocks base method.
+func (m *MockState) GetPChainHeightEpochActivationTime() (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPChainHeightEpochActivationTime")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPChainHeightEpochActivationTime indicates an expected call of GetPChainHeightEpochActivationTime.
+func (mr *MockStateMockRecorder) GetPChainHeightEpochActivationTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPChainHeightEpochActivationTime", reflect.TypeOf((*MockState)(nil).GetPChainHeightEpochActivationTime))
+}
+
+// GetProposerAt mocks base method.
+func (m *MockState) GetProposerAt(arg0 uint64) (ids.NodeID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposerAt", arg0)
+	ret0, _ := ret[0].(ids.NodeID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProposerAt indicates an expected call of GetProposerAt.
+func (mr *MockStateMockRecorder) GetProposerAt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposerAt", reflect.TypeOf((*MockState)(nil).GetProposerAt), arg0)
+}
+
+// GetProposersInRange mocks base method.
+func (m *MockState) GetProposersInRange(arg0, arg1 uint64, arg2 int) ([]ProposerAtHeight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposersInRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]ProposerAtHeight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProposersInRange indicates an expected call of GetProposersInRange.
+func (mr *MockStateMockRecorder) GetProposersInRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposersInRange", reflect.TypeOf((*MockState)(nil).GetProposersInRange), arg0, arg1, arg2)
+}
+
+// GetVRFActivationTime mocks base method.
+func (m *MockState) GetVRFActivationTime() (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVRFActivationTime")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVRFActivationTime indicates an expected call of GetVRFActivationTime.
+func (mr *MockStateMockRecorder) GetVRFActivationTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVRFActivationTime", reflect.TypeOf((*MockState)(nil).GetVRFActivationTime))
+}
+
+// NewBlockIDIterator mocks base method.
+func (m *MockState) NewBlockIDIterator() (BlockIDIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewBlockIDIterator")
+	ret0, _ := ret[0].(BlockIDIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewBlockIDIterator indicates an expected call of NewBlockIDIterator.
+func (mr *MockStateMockRecorder) NewBlockIDIterator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBlockIDIterator", reflect.TypeOf((*MockState)(nil).NewBlockIDIterator))
+}
+
 // PutBlock mocks base method.
 func (m *MockState) PutBlock(arg0 block.Block, arg1 choices.Status) error {
 	m.ctrl.T.Helper()
@@ -214,6 +454,76 @@ func (mr *MockStateMockRecorder) PutBlock(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBlock", reflect.TypeOf((*MockState)(nil).PutBlock), arg0, arg1)
 }
 
+// PutInnerBlockID mocks base method.
+func (m *MockState) PutInnerBlockID(arg0, arg1 ids.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutInnerBlockID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutInnerBlockID indicates an expected call of PutInnerBlockID.
+func (mr *MockStateMockRecorder) PutInnerBlockID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutInnerBlockID", reflect.TypeOf((*MockState)(nil).PutInnerBlockID), arg0, arg1)
+}
+
+// PutMissedSlots mocks base method.
+func (m *MockState) PutMissedSlots(arg0 uint64, arg1 []ids.NodeID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMissedSlots", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutMissedSlots indicates an expected call of PutMissedSlots.
+func (mr *MockStateMockRecorder) PutMissedSlots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMissedSlots", reflect.TypeOf((*MockState)(nil).PutMissedSlots), arg0, arg1)
+}
+
+// PutProposerAt mocks base method.
+func (m *MockState) PutProposerAt(arg0 uint64, arg1 ids.NodeID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutProposerAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutProposerAt indicates an expected call of PutProposerAt.
+func (mr *MockStateMockRecorder) PutProposerAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutProposerAt", reflect.TypeOf((*MockState)(nil).PutProposerAt), arg0, arg1)
+}
+
+// SetActivationTime mocks base method.
+func (m *MockState) SetActivationTime(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActivationTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetActivationTime indicates an expected call of SetActivationTime.
+func (mr *MockStateMockRecorder) SetActivationTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActivationTime", reflect.TypeOf((*MockState)(nil).SetActivationTime), arg0)
+}
+
+// SetBlockExtensionsActivationTime mocks base method.
+func (m *MockState) SetBlockExtensionsActivationTime(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBlockExtensionsActivationTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBlockExtensionsActivationTime indicates an expected call of SetBlockExtensionsActivationTime.
+func (mr *MockStateMockRecorder) SetBlockExtensionsActivationTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBlockExtensionsActivationTime", reflect.TypeOf((*MockState)(nil).SetBlockExtensionsActivationTime), arg0)
+}
+
 // SetBlockIDAtHeight mocks base method.
 func (m *MockState) SetBlockIDAtHeight(arg0 uint64, arg1 ids.ID) error {
 	m.ctrl.T.Helper()
@@ -242,6 +552,34 @@ func (mr *MockStateMockRecorder) SetCheckpoint(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCheckpoint", reflect.TypeOf((*MockState)(nil).SetCheckpoint), arg0)
 }
 
+// SetCommitValidatorSetActivationTime mocks base method.
+func (m *MockState) SetCommitValidatorSetActivationTime(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCommitValidatorSetActivationTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCommitValidatorSetActivationTime indicates an expected call of SetCommitValidatorSetActivationTime.
+func (mr *MockStateMockRecorder) SetCommitValidatorSetActivationTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCommitValidatorSetActivationTime", reflect.TypeOf((*MockState)(nil).SetCommitValidatorSetActivationTime), arg0)
+}
+
+// SetEnforcedMinBlkDelayActivationTime mocks base method.
+func (m *MockState) SetEnforcedMinBlkDelayActivationTime(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnforcedMinBlkDelayActivationTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEnforcedMinBlkDelayActivationTime indicates an expected call of SetEnforcedMinBlkDelayActivationTime.
+func (mr *MockStateMockRecorder) SetEnforcedMinBlkDelayActivationTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnforcedMinBlkDelayActivationTime", reflect.TypeOf((*MockState)(nil).SetEnforcedMinBlkDelayActivationTime), arg0)
+}
+
 // SetForkHeight mocks base method.
 func (m *MockState) SetForkHeight(arg0 uint64) error {
 	m.ctrl.T.Helper()
@@ -269,3 +607,60 @@ func (mr *MockStateMockRecorder) SetLastAccepted(arg0 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAccepted", reflect.TypeOf((*MockState)(nil).SetLastAccepted), arg0)
 }
+
+// SetMinimumPChainHeight mocks base method.
+func (m *MockState) SetMinimumPChainHeight(arg0 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMinimumPChainHeight", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMinimumPChainHeight indicates an expected call of SetMinimumPChainHeight.
+func (mr *MockStateMockRecorder) SetMinimumPChainHeight(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinimumPChainHeight", reflect.TypeOf((*MockState)(nil).SetMinimumPChainHeight), arg0)
+}
+
+// SetPChainHeightEpochActivationTime mocks base method.
+func (m *MockState) SetPChainHeightEpochActivationTime(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPChainHeightEpochActivationTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPChainHeightEpochActivationTime indicates an expected call of SetPChainHeightEpochActivationTime.
+func (mr *MockStateMockRecorder) SetPChainHeightEpochActivationTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPChainHeightEpochActivationTime", reflect.TypeOf((*MockState)(nil).SetPChainHeightEpochActivationTime), arg0)
+}
+
+// SetVRFActivationTime mocks base method.
+func (m *MockState) SetVRFActivationTime(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVRFActivationTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVRFActivationTime indicates an expected call of SetVRFActivationTime.
+func (mr *MockStateMockRecorder) SetVRFActivationTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVRFActivationTime", reflect.TypeOf((*MockState)(nil).SetVRFActivationTime), arg0)
+}
+
+// VerifyIntegrity mocks base method.
+func (m *MockState) VerifyIntegrity(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyIntegrity", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyIntegrity indicates an expected call of VerifyIntegrity.
+func (mr *MockStateMockRecorder) VerifyIntegrity(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyIntegrity", reflect.TypeOf((*MockState)(nil).VerifyIntegrity), arg0)
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+)
+
+// Namespaces returns the databases of the indexes of the state created from
+// [db], keyed by the name of the index.
+func Namespaces(db database.Database) map[string]database.Iteratee {
+	heightDB := prefixdb.New(heightIndexPrefix, db)
+	innerDB := prefixdb.New(innerIndexPrefix, db)
+	return map[string]database.Iteratee{
+		"chain":           prefixdb.New(chainStatePrefix, db),
+		"block":           prefixdb.New(blockStatePrefix, db),
+		"height":          prefixdb.New(heightPrefix, heightDB),
+		"height_metadata": prefixdb.New(metadataPrefix, heightDB),
+		"outer_to_inner":  prefixdb.New(outerToInnerPrefix, innerDB),
+		"inner_to_outer":  prefixdb.New(innerToOuterPrefix, innerDB),
+		"proposer":        prefixdb.New(proposerPrefix, db),
+		"missed_slots":    prefixdb.New(missedSlotsPrefix, db),
+	}
+}
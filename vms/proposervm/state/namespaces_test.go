@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestNamespaces(t *testing.T) {
+	require := require.New(t)
+
+	db := versiondb.New(memdb.New())
+	s := New(db)
+
+	require.NoError(s.SetLastAccepted(ids.GenerateTestID()))
+	require.NoError(s.SetForkHeight(1))
+	require.NoError(s.SetBlockIDAtHeight(1, ids.GenerateTestID()))
+	require.NoError(s.SetBlockIDAtHeight(2, ids.GenerateTestID()))
+	require.NoError(s.PutInnerBlockID(ids.GenerateTestID(), ids.GenerateTestID()))
+	require.NoError(s.PutProposerAt(2, ids.GenerateTestNodeID()))
+	require.NoError(s.PutMissedSlots(2, []ids.NodeID{ids.GenerateTestNodeID()}))
+
+	expectedNumKeys := map[string]uint64{
+		"chain":           1,
+		"block":           0,
+		"height":          2,
+		"height_metadata": 1,
+		"outer_to_inner":  1,
+		"inner_to_outer":  1,
+		"proposer":        1,
+		"missed_slots":    1,
+	}
+
+	namespaces := Namespaces(db)
+	require.Len(namespaces, len(expectedNumKeys))
+
+	var totalNumKeys uint64
+	for name, namespaceDB := range namespaces {
+		usage, err := prefixdb.ComputeUsage(context.Background(), namespaceDB, 0)
+		require.NoError(err)
+		require.Equal(expectedNumKeys[name], usage.NumKeys, name)
+		totalNumKeys += usage.NumKeys
+	}
+
+	// Every key of the state belongs to exactly one namespace
+	usage, err := prefixdb.ComputeUsage(context.Background(), db, 0)
+	require.NoError(err)
+	require.Equal(usage.NumKeys, totalNumKeys)
+}
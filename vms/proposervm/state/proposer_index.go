@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var _ ProposerIndex = (*proposerIndex)(nil)
+
+// ProposerAtHeight is the proposer of the accepted block at a height.
+type ProposerAtHeight struct {
+	Height   uint64
+	Proposer ids.NodeID
+}
+
+// ProposerIndex maps the heights of accepted proposer blocks to the nodes that
+// proposed them.
+//
+// Unsigned blocks are indexed with [ids.EmptyNodeID] as their proposer.
+type ProposerIndex interface {
+	// GetProposerAt returns the proposer of the accepted block at [height].
+	GetProposerAt(height uint64) (ids.NodeID, error)
+	// GetProposersInRange returns the proposers of the indexed heights in
+	// [startHeight, endHeight] in increasing height order. At most [limit]
+	// proposers are returned.
+	GetProposersInRange(startHeight, endHeight uint64, limit int) ([]ProposerAtHeight, error)
+	PutProposerAt(height uint64, proposer ids.NodeID) error
+}
+
+type proposerIndex struct {
+	db database.Database
+}
+
+func NewProposerIndex(db database.Database) ProposerIndex {
+	return &proposerIndex{
+		db: db,
+	}
+}
+
+func (p *proposerIndex) GetProposerAt(height uint64) (ids.NodeID, error) {
+	proposerBytes, err := p.db.Get(database.PackUInt64(height))
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	return ids.ToNodeID(proposerBytes)
+}
+
+func (p *proposerIndex) GetProposersInRange(startHeight, endHeight uint64, limit int) ([]ProposerAtHeight, error) {
+	it := p.db.NewIteratorWithStart(database.PackUInt64(startHeight))
+	defer it.Release()
+
+	var proposers []ProposerAtHeight
+	for len(proposers) < limit && it.Next() {
+		height, err := database.ParseUInt64(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		if height > endHeight {
+			break
+		}
+
+		proposer, err := ids.ToNodeID(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		proposers = append(proposers, ProposerAtHeight{
+			Height:   height,
+			Proposer: proposer,
+		})
+	}
+	return proposers, it.Error()
+}
+
+func (p *proposerIndex) PutProposerAt(height uint64, proposer ids.NodeID) error {
+	return p.db.Put(database.PackUInt64(height), proposer.Bytes())
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func testProposerIndex(a *require.Assertions, p ProposerIndex) {
+	proposer1 := ids.GenerateTestNodeID()
+	proposer2 := ids.GenerateTestNodeID()
+
+	_, err := p.GetProposerAt(1)
+	a.Equal(database.ErrNotFound, err)
+
+	proposers, err := p.GetProposersInRange(0, 10, 10)
+	a.NoError(err)
+	a.Empty(proposers)
+
+	a.NoError(p.PutProposerAt(1, proposer1))
+	a.NoError(p.PutProposerAt(2, ids.EmptyNodeID))
+	a.NoError(p.PutProposerAt(4, proposer2))
+
+	fetchedProposer, err := p.GetProposerAt(1)
+	a.NoError(err)
+	a.Equal(proposer1, fetchedProposer)
+
+	fetchedProposer, err = p.GetProposerAt(2)
+	a.NoError(err)
+	a.Equal(ids.EmptyNodeID, fetchedProposer)
+
+	_, err = p.GetProposerAt(3)
+	a.Equal(database.ErrNotFound, err)
+
+	proposers, err = p.GetProposersInRange(0, 10, 10)
+	a.NoError(err)
+	a.Equal([]ProposerAtHeight{
+		{Height: 1, Proposer: proposer1},
+		{Height: 2, Proposer: ids.EmptyNodeID},
+		{Height: 4, Proposer: proposer2},
+	}, proposers)
+
+	// The range is inclusive.
+	proposers, err = p.GetProposersInRange(2, 4, 10)
+	a.NoError(err)
+	a.Equal([]ProposerAtHeight{
+		{Height: 2, Proposer: ids.EmptyNodeID},
+		{Height: 4, Proposer: proposer2},
+	}, proposers)
+
+	proposers, err = p.GetProposersInRange(2, 3, 10)
+	a.NoError(err)
+	a.Equal([]ProposerAtHeight{
+		{Height: 2, Proposer: ids.EmptyNodeID},
+	}, proposers)
+
+	proposers, err = p.GetProposersInRange(0, 10, 1)
+	a.NoError(err)
+	a.Equal([]ProposerAtHeight{
+		{Height: 1, Proposer: proposer1},
+	}, proposers)
+}
+
+func TestProposerIndex(t *testing.T) {
+	a := require.New(t)
+
+	db := memdb.New()
+	p := NewProposerIndex(db)
+
+	testProposerIndex(a, p)
+}
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"context"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/database/prefixdb"
@@ -14,29 +16,48 @@ var (
 	chainStatePrefix  = []byte("chain")
 	blockStatePrefix  = []byte("block")
 	heightIndexPrefix = []byte("height")
+	innerIndexPrefix  = []byte("inner")
+	proposerPrefix    = []byte("proposer")
+	missedSlotsPrefix = []byte("missed")
 )
 
 type State interface {
 	ChainState
 	BlockState
 	HeightIndex
+	InnerBlockIndex
+	ProposerIndex
+	MissedSlotIndex
+
+	// VerifyIntegrity checks the consistency of the indexes and returns a
+	// description of every discrepancy found.
+	VerifyIntegrity(ctx context.Context) ([]string, error)
 }
 
 type state struct {
 	ChainState
 	BlockState
 	HeightIndex
+	InnerBlockIndex
+	ProposerIndex
+	MissedSlotIndex
 }
 
 func New(db *versiondb.Database) State {
 	chainDB := prefixdb.New(chainStatePrefix, db)
 	blockDB := prefixdb.New(blockStatePrefix, db)
 	heightDB := prefixdb.New(heightIndexPrefix, db)
+	innerDB := prefixdb.New(innerIndexPrefix, db)
+	proposerDB := prefixdb.New(proposerPrefix, db)
+	missedSlotsDB := prefixdb.New(missedSlotsPrefix, db)
 
 	return &state{
-		ChainState:  NewChainState(chainDB),
-		BlockState:  NewBlockState(blockDB),
-		HeightIndex: NewHeightIndex(heightDB, db),
+		ChainState:      NewChainState(chainDB),
+		BlockState:      NewBlockState(blockDB),
+		HeightIndex:     NewHeightIndex(heightDB, db),
+		InnerBlockIndex: NewInnerBlockIndex(innerDB),
+		ProposerIndex:   NewProposerIndex(proposerDB),
+		MissedSlotIndex: NewMissedSlotIndex(missedSlotsDB),
 	}
 }
 
@@ -44,6 +65,9 @@ func NewMetered(db *versiondb.Database, namespace string, metrics prometheus.Reg
 	chainDB := prefixdb.New(chainStatePrefix, db)
 	blockDB := prefixdb.New(blockStatePrefix, db)
 	heightDB := prefixdb.New(heightIndexPrefix, db)
+	innerDB := prefixdb.New(innerIndexPrefix, db)
+	proposerDB := prefixdb.New(proposerPrefix, db)
+	missedSlotsDB := prefixdb.New(missedSlotsPrefix, db)
 
 	blockState, err := NewMeteredBlockState(blockDB, namespace, metrics)
 	if err != nil {
@@ -51,8 +75,11 @@ func NewMetered(db *versiondb.Database, namespace string, metrics prometheus.Reg
 	}
 
 	return &state{
-		ChainState:  NewChainState(chainDB),
-		BlockState:  blockState,
-		HeightIndex: NewHeightIndex(heightDB, db),
+		ChainState:      NewChainState(chainDB),
+		BlockState:      blockState,
+		HeightIndex:     NewHeightIndex(heightDB, db),
+		InnerBlockIndex: NewInnerBlockIndex(innerDB),
+		ProposerIndex:   NewProposerIndex(proposerDB),
+		MissedSlotIndex: NewMissedSlotIndex(missedSlotsDB),
 	}, nil
 }
@@ -23,6 +23,9 @@ func TestState(t *testing.T) {
 
 	testBlockState(a, s)
 	testChainState(a, s)
+	testInnerBlockIndex(a, s)
+	testProposerIndex(a, s)
+	testMissedSlotIndex(a, s)
 }
 
 func TestMeteredState(t *testing.T) {
@@ -35,4 +38,7 @@ func TestMeteredState(t *testing.T) {
 
 	testBlockState(a, s)
 	testChainState(a, s)
+	testInnerBlockIndex(a, s)
+	testProposerIndex(a, s)
+	testMissedSlotIndex(a, s)
 }
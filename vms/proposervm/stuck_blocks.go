@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// stuckBlockChecksPerTimeout is the number of times processing blocks are
+// checked per stuck block timeout.
+const stuckBlockChecksPerTimeout = 4
+
+// monitorStuckBlocks periodically checks for blocks that have been processing
+// for longer than [stuckBlockTimeout] until the VM is shutdown.
+func (vm *VM) monitorStuckBlocks() {
+	ticker := time.NewTicker(vm.StuckBlockTimeout / stuckBlockChecksPerTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-vm.context.Done():
+			return
+		case <-ticker.C:
+		}
+
+		vm.ctx.Lock.Lock()
+		vm.checkStuckBlocks(vm.context)
+		vm.ctx.Lock.Unlock()
+	}
+}
+
+// checkStuckBlocks reports the verified blocks that have been processing for
+// longer than [stuckBlockTimeout]. Each stuck block is only logged once, but
+// the engine is notified to build a block on every check while blocks are
+// stuck if [rebuildOnStuckBlock] is set.
+//
+// Assumes [vm.ctx.Lock] is held.
+func (vm *VM) checkStuckBlocks(ctx context.Context) {
+	now := vm.Time()
+	numStuck := 0
+	for blkID, verifiedTime := range vm.verifiedTimes {
+		processingTime := now.Sub(verifiedTime)
+		if processingTime < vm.StuckBlockTimeout {
+			continue
+		}
+
+		numStuck++
+		if vm.stuckBlocks.Contains(blkID) {
+			continue
+		}
+		vm.stuckBlocks.Add(blkID)
+		vm.logStuckBlock(ctx, vm.verifiedBlocks[blkID], processingTime)
+	}
+	vm.metrics.stuckBlocks.Set(float64(numStuck))
+
+	if numStuck > 0 && vm.RebuildOnStuckBlock {
+		vm.notifyInnerBlockReady()
+	}
+}
+
+// logStuckBlock logs the state needed to diagnose why [blk] hasn't been
+// decided.
+func (vm *VM) logStuckBlock(ctx context.Context, blk PostForkBlock, processingTime time.Duration) {
+	fields := []zap.Field{
+		zap.Stringer("blkID", blk.ID()),
+		zap.Uint64("height", blk.Height()),
+		zap.Duration("processingTime", processingTime),
+		zap.Stringer("parentID", blk.Parent()),
+		zap.Uint64("lastAcceptedHeight", vm.lastAcceptedHeight),
+		zap.Stringer("preferredID", vm.preferred),
+		zap.Bool("preferred", blk.ID() == vm.preferred),
+		zap.Time("nextBuildTime", vm.nextBuildTime),
+		zap.Int("numProcessingBlocks", len(vm.verifiedBlocks)),
+	}
+
+	if parent, err := vm.getBlock(ctx, blk.Parent()); err == nil {
+		fields = append(fields, zap.Stringer("parentStatus", parent.Status()))
+	} else {
+		fields = append(fields, zap.NamedError("parentErr", err))
+	}
+
+	pChainHeight, err := blk.pChainHeight(ctx)
+	if err != nil {
+		fields = append(fields, zap.NamedError("pChainHeightErr", err))
+	} else {
+		fields = append(fields, zap.Uint64("pChainHeight", pChainHeight))
+	}
+	if currentPChainHeight, err := vm.validatorState.GetCurrentHeight(ctx); err != nil {
+		fields = append(fields, zap.NamedError("currentPChainHeightErr", err))
+	} else {
+		fields = append(fields, zap.Uint64("currentPChainHeight", currentPChainHeight))
+		if pChainHeight <= currentPChainHeight {
+			fields = append(fields, zap.Uint64("pChainHeightLag", currentPChainHeight-pChainHeight))
+		}
+	}
+
+	vm.ctx.Log.Warn("block stuck in processing", fields...)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCheckStuckBlocks(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+	proVM.StuckBlockTimeout = time.Minute
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk, nil
+	}
+
+	proBlk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(proBlk.Verify(context.Background()))
+
+	stuckBlocks := func() float64 {
+		metric := &dto.Metric{}
+		require.NoError(proVM.metrics.stuckBlocks.Write(metric))
+		return metric.Gauge.GetValue()
+	}
+
+	// The block hasn't been processing for long enough to be reported
+	proVM.checkStuckBlocks(context.Background())
+	require.False(proVM.stuckBlocks.Contains(proBlk.ID()))
+	require.Zero(stuckBlocks())
+
+	// Once the timeout has passed, the block is reported as stuck
+	proVM.Set(proVM.Time().Add(proVM.StuckBlockTimeout))
+	proVM.checkStuckBlocks(context.Background())
+	require.True(proVM.stuckBlocks.Contains(proBlk.ID()))
+	require.Equal(float64(1), stuckBlocks())
+
+	// Rejecting the block stops it from being reported
+	require.NoError(proBlk.Reject(context.Background()))
+	require.False(proVM.stuckBlocks.Contains(proBlk.ID()))
+	require.NotContains(proVM.verifiedTimes, proBlk.ID())
+
+	rejected := &dto.Metric{}
+	require.NoError(proVM.metrics.rejectedBlocks.Write(rejected))
+	require.Equal(float64(1), rejected.Counter.GetValue())
+
+	proVM.checkStuckBlocks(context.Background())
+	require.Zero(stuckBlocks())
+}
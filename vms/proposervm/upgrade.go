@@ -0,0 +1,213 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	errActivationTimeChanged      = errors.New("activation time changed after the fork activated")
+	errActivationTimeInPast       = errors.New("rescheduled activation time isn't in the future")
+	errMinimumPChainHeightChanged = errors.New("minimum P-chain height changed after the fork activated")
+)
+
+// UpgradeOverrides marks the fork settings of a [Config] that were scheduled
+// at runtime rather than compiled into the node. An override that the chain
+// wasn't previously started with must schedule a fork that hasn't activated
+// yet.
+type UpgradeOverrides struct {
+	ActivationTime                    bool
+	MinimumPChainHeight               bool
+	PChainHeightEpochActivationTime   bool
+	EnforcedMinBlkDelayActivationTime bool
+	CommitValidatorSetActivationTime  bool
+	VRFActivationTime                 bool
+	BlockExtensionsActivationTime     bool
+}
+
+// forkTime is a fork of the proposervm that is scheduled after the
+// proposervm's activation.
+type forkTime struct {
+	name           string
+	activationTime time.Time
+	overridden     bool
+	get            func() (time.Time, error)
+	set            func(time.Time) error
+}
+
+// verifyUpgrade verifies that the fork settings are consistent with the
+// settings the chain was previously started with, and records them.
+//
+// A fork may only be rescheduled if neither the previous nor the new fork time
+// has passed, as blocks may otherwise have been built or verified under
+// different rules.
+func (vm *VM) verifyUpgrade() error {
+	now := vm.Time()
+
+	prevActivationTime, err := vm.State.GetActivationTime()
+	activationTimeRecorded, err := isRecorded(err)
+	if err != nil {
+		return err
+	}
+	if err := vm.verifyForkTime(
+		"activation",
+		prevActivationTime,
+		activationTimeRecorded,
+		vm.ActivationTime,
+		vm.UpgradeOverrides.ActivationTime,
+		now,
+	); err != nil {
+		return err
+	}
+	if err := vm.State.SetActivationTime(vm.ActivationTime); err != nil {
+		return err
+	}
+
+	prevMinimumPChainHeight, err := vm.State.GetMinimumPChainHeight()
+	minimumPChainHeightRecorded, err := isRecorded(err)
+	if err != nil {
+		return err
+	}
+	// The minimum P-chain height can only change along with a fork that
+	// hasn't activated yet.
+	changed := minimumPChainHeightRecorded && prevMinimumPChainHeight != vm.MinimumPChainHeight
+	firstOverride := !minimumPChainHeightRecorded && vm.UpgradeOverrides.MinimumPChainHeight
+	if (changed || firstOverride) && (!now.Before(vm.ActivationTime) || (activationTimeRecorded && !now.Before(prevActivationTime))) {
+		return fmt.Errorf("%w: from %d to %d",
+			errMinimumPChainHeightChanged,
+			prevMinimumPChainHeight,
+			vm.MinimumPChainHeight,
+		)
+	}
+	if err := vm.State.SetMinimumPChainHeight(vm.MinimumPChainHeight); err != nil {
+		return err
+	}
+
+	forks := []forkTime{
+		{
+			name:           "commit validator set",
+			activationTime: vm.CommitValidatorSetActivationTime,
+			overridden:     vm.UpgradeOverrides.CommitValidatorSetActivationTime,
+			get:            vm.State.GetCommitValidatorSetActivationTime,
+			set:            vm.State.SetCommitValidatorSetActivationTime,
+		},
+		{
+			name:           "VRF",
+			activationTime: vm.VRFActivationTime,
+			overridden:     vm.UpgradeOverrides.VRFActivationTime,
+			get:            vm.State.GetVRFActivationTime,
+			set:            vm.State.SetVRFActivationTime,
+		},
+		{
+			name:           "block extensions",
+			activationTime: vm.BlockExtensionsActivationTime,
+			overridden:     vm.UpgradeOverrides.BlockExtensionsActivationTime,
+			get:            vm.State.GetBlockExtensionsActivationTime,
+			set:            vm.State.SetBlockExtensionsActivationTime,
+		},
+	}
+	// The enforced minimum block delay activation time only affects
+	// verification if the delay is enforced.
+	if vm.EnforcedMinBlkDelay != 0 {
+		forks = append(forks, forkTime{
+			name:           "enforced min block delay",
+			activationTime: vm.EnforcedMinBlkDelayActivationTime,
+			overridden:     vm.UpgradeOverrides.EnforcedMinBlkDelayActivationTime,
+			get:            vm.State.GetEnforcedMinBlkDelayActivationTime,
+			set:            vm.State.SetEnforcedMinBlkDelayActivationTime,
+		})
+	}
+	// The epoch activation time only affects verification if epochs are
+	// enabled.
+	if vm.PChainHeightEpoch != 0 {
+		forks = append(forks, forkTime{
+			name:           "P-chain height epoch",
+			activationTime: vm.PChainHeightEpochActivationTime,
+			overridden:     vm.UpgradeOverrides.PChainHeightEpochActivationTime,
+			get:            vm.State.GetPChainHeightEpochActivationTime,
+			set:            vm.State.SetPChainHeightEpochActivationTime,
+		})
+	}
+	for _, fork := range forks {
+		prevActivationTime, err := fork.get()
+		recorded, err := isRecorded(err)
+		if err != nil {
+			return err
+		}
+		if err := vm.verifyForkTime(
+			fork.name,
+			prevActivationTime,
+			recorded,
+			fork.activationTime,
+			fork.overridden,
+			now,
+		); err != nil {
+			return err
+		}
+		if err := fork.set(fork.activationTime); err != nil {
+			return err
+		}
+	}
+	return vm.db.Commit()
+}
+
+// verifyForkTime verifies that the fork [name] can be scheduled at
+// [activationTime], given the time [prevActivationTime] it was previously
+// scheduled at, if [recorded].
+func (vm *VM) verifyForkTime(
+	name string,
+	prevActivationTime time.Time,
+	recorded bool,
+	activationTime time.Time,
+	overridden bool,
+	now time.Time,
+) error {
+	switch {
+	case !recorded && !overridden:
+		return nil
+	case recorded && prevActivationTime.Equal(activationTime):
+		return nil
+	case recorded && !now.Before(prevActivationTime):
+		return fmt.Errorf("%w: %s from %s to %s",
+			errActivationTimeChanged,
+			name,
+			prevActivationTime,
+			activationTime,
+		)
+	case !now.Before(activationTime):
+		return fmt.Errorf("%w: %s at %s is before %s",
+			errActivationTimeInPast,
+			name,
+			activationTime,
+			now,
+		)
+	}
+
+	vm.ctx.Log.Info("rescheduled proposervm fork",
+		zap.String("fork", name),
+		zap.Time("prevActivationTime", prevActivationTime),
+		zap.Time("activationTime", activationTime),
+	)
+	return nil
+}
+
+// isRecorded returns true if [err] indicates that a value was read from the
+// database.
+func isRecorded(err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case err == database.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/proposervm/state"
+)
+
+func TestVerifyUpgrade(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	farFuture := now.Add(2 * time.Hour)
+	minPChainHeight := uint64(1)
+	otherMinPChainHeight := uint64(2)
+
+	tests := []struct {
+		name                    string
+		prevActivationTime      *time.Time
+		prevMinPChainHeight     *uint64
+		prevEpochActivationTime *time.Time
+		activationTime          time.Time
+		epochActivationTime     time.Time
+		overrides               UpgradeOverrides
+		expectedErr             error
+	}{
+		{
+			name:                "first start",
+			activationTime:      past,
+			epochActivationTime: past,
+		},
+		{
+			name:           "first start with overrides",
+			activationTime: future,
+			overrides: UpgradeOverrides{
+				ActivationTime:      true,
+				MinimumPChainHeight: true,
+			},
+		},
+		{
+			name:           "first start with activation time override in the past",
+			activationTime: past,
+			overrides: UpgradeOverrides{
+				ActivationTime: true,
+			},
+			expectedErr: errActivationTimeInPast,
+		},
+		{
+			name:                "first start with epoch activation time override in the past",
+			activationTime:      past,
+			epochActivationTime: past,
+			overrides: UpgradeOverrides{
+				PChainHeightEpochActivationTime: true,
+			},
+			expectedErr: errActivationTimeInPast,
+		},
+		{
+			name:           "first start with min P-chain height override after activation",
+			activationTime: past,
+			overrides: UpgradeOverrides{
+				MinimumPChainHeight: true,
+			},
+			expectedErr: errMinimumPChainHeightChanged,
+		},
+		{
+			name:                    "unchanged after activation",
+			prevActivationTime:      &past,
+			prevMinPChainHeight:     &minPChainHeight,
+			prevEpochActivationTime: &past,
+			activationTime:          past,
+			epochActivationTime:     past,
+		},
+		{
+			name:                    "rescheduled before activation",
+			prevActivationTime:      &future,
+			prevMinPChainHeight:     &otherMinPChainHeight,
+			prevEpochActivationTime: &future,
+			activationTime:          farFuture,
+			epochActivationTime:     farFuture,
+		},
+		{
+			name:               "rescheduled after activation",
+			prevActivationTime: &past,
+			activationTime:     future,
+			expectedErr:        errActivationTimeChanged,
+		},
+		{
+			name:               "rescheduled into the past",
+			prevActivationTime: &future,
+			activationTime:     past,
+			expectedErr:        errActivationTimeInPast,
+		},
+		{
+			name:                    "epoch rescheduled after activation",
+			prevActivationTime:      &past,
+			prevEpochActivationTime: &past,
+			activationTime:          past,
+			epochActivationTime:     future,
+			expectedErr:             errActivationTimeChanged,
+		},
+		{
+			name:                "min P-chain height changed after activation",
+			prevActivationTime:  &past,
+			prevMinPChainHeight: &otherMinPChainHeight,
+			activationTime:      past,
+			expectedErr:         errMinimumPChainHeightChanged,
+		},
+		{
+			name:                "min P-chain height changed when rescheduling after activation",
+			prevActivationTime:  &past,
+			prevMinPChainHeight: &otherMinPChainHeight,
+			activationTime:      future,
+			expectedErr:         errActivationTimeChanged,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := versiondb.New(memdb.New())
+			s := state.New(db)
+			if test.prevActivationTime != nil {
+				require.NoError(s.SetActivationTime(*test.prevActivationTime))
+			}
+			if test.prevMinPChainHeight != nil {
+				require.NoError(s.SetMinimumPChainHeight(*test.prevMinPChainHeight))
+			}
+			if test.prevEpochActivationTime != nil {
+				require.NoError(s.SetPChainHeightEpochActivationTime(*test.prevEpochActivationTime))
+			}
+
+			vm := &VM{
+				Config: Config{
+					ActivationTime:                  test.activationTime,
+					MinimumPChainHeight:             minPChainHeight,
+					UpgradeOverrides:                test.overrides,
+					PChainHeightEpoch:               10,
+					PChainHeightEpochActivationTime: test.epochActivationTime,
+				},
+				ctx:   snow.DefaultContextTest(),
+				db:    db,
+				State: s,
+			}
+			vm.Set(now)
+
+			err := vm.verifyUpgrade()
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			activationTime, err := s.GetActivationTime()
+			require.NoError(err)
+			require.True(test.activationTime.Equal(activationTime))
+
+			height, err := s.GetMinimumPChainHeight()
+			require.NoError(err)
+			require.Equal(minPChainHeight, height)
+
+			epochActivationTime, err := s.GetPChainHeightEpochActivationTime()
+			require.NoError(err)
+			require.True(test.epochActivationTime.Equal(epochActivationTime))
+		})
+	}
+}
+
+func TestVerifyUpgradeIgnoresDisabledEpochs(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_700_000_000, 0)
+	past := now.Add(-time.Hour)
+
+	db := versiondb.New(memdb.New())
+	s := state.New(db)
+	require.NoError(s.SetPChainHeightEpochActivationTime(past))
+
+	vm := &VM{
+		Config: Config{
+			ActivationTime:                  past,
+			PChainHeightEpochActivationTime: now.Add(time.Hour),
+		},
+		ctx:   snow.DefaultContextTest(),
+		db:    db,
+		State: s,
+	}
+	vm.Set(now)
+
+	require.NoError(vm.verifyUpgrade())
+
+	// The epoch activation time isn't recorded while epochs are disabled.
+	epochActivationTime, err := s.GetPChainHeightEpochActivationTime()
+	require.NoError(err)
+	require.True(past.Equal(epochActivationTime))
+}
+
+func TestVerifyUpgradeForkTimesAcrossRestarts(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	farFuture := now.Add(2 * time.Hour)
+
+	forks := []struct {
+		name     string
+		schedule func(*Config, time.Time, bool)
+		get      func(state.State) (time.Time, error)
+	}{
+		{
+			name: "enforced min block delay",
+			schedule: func(c *Config, activationTime time.Time, overridden bool) {
+				c.EnforcedMinBlkDelay = time.Second
+				c.EnforcedMinBlkDelayActivationTime = activationTime
+				c.UpgradeOverrides.EnforcedMinBlkDelayActivationTime = overridden
+			},
+			get: state.State.GetEnforcedMinBlkDelayActivationTime,
+		},
+		{
+			name: "commit validator set",
+			schedule: func(c *Config, activationTime time.Time, overridden bool) {
+				c.CommitValidatorSetActivationTime = activationTime
+				c.UpgradeOverrides.CommitValidatorSetActivationTime = overridden
+			},
+			get: state.State.GetCommitValidatorSetActivationTime,
+		},
+		{
+			name: "VRF",
+			schedule: func(c *Config, activationTime time.Time, overridden bool) {
+				c.VRFActivationTime = activationTime
+				c.UpgradeOverrides.VRFActivationTime = overridden
+			},
+			get: state.State.GetVRFActivationTime,
+		},
+		{
+			name: "block extensions",
+			schedule: func(c *Config, activationTime time.Time, overridden bool) {
+				c.BlockExtensionsActivationTime = activationTime
+				c.UpgradeOverrides.BlockExtensionsActivationTime = overridden
+			},
+			get: state.State.GetBlockExtensionsActivationTime,
+		},
+	}
+	restarts := []struct {
+		name               string
+		prevActivationTime time.Time
+		activationTime     time.Time
+		overridden         bool
+		expectedErr        error
+	}{
+		{
+			name:               "unchanged after activation",
+			prevActivationTime: past,
+			activationTime:     past,
+		},
+		{
+			name:               "rescheduled before activation",
+			prevActivationTime: future,
+			activationTime:     farFuture,
+			overridden:         true,
+		},
+		{
+			name:               "scheduled from never",
+			prevActivationTime: mockable.MaxTime,
+			activationTime:     future,
+			overridden:         true,
+		},
+		{
+			name:               "rescheduled after activation",
+			prevActivationTime: past,
+			activationTime:     future,
+			overridden:         true,
+			expectedErr:        errActivationTimeChanged,
+		},
+		{
+			name:               "rescheduled into the past",
+			prevActivationTime: future,
+			activationTime:     past,
+			overridden:         true,
+			expectedErr:        errActivationTimeInPast,
+		},
+	}
+	for _, fork := range forks {
+		for _, restart := range restarts {
+			t.Run(fork.name+"/"+restart.name, func(t *testing.T) {
+				require := require.New(t)
+
+				db := versiondb.New(memdb.New())
+				newVM := func(activationTime time.Time, overridden bool) *VM {
+					vm := &VM{
+						Config: Config{
+							ActivationTime:                   past,
+							CommitValidatorSetActivationTime: mockable.MaxTime,
+							VRFActivationTime:                mockable.MaxTime,
+							BlockExtensionsActivationTime:    mockable.MaxTime,
+						},
+						ctx:   snow.DefaultContextTest(),
+						db:    db,
+						State: state.New(db),
+					}
+					fork.schedule(&vm.Config, activationTime, overridden)
+					return vm
+				}
+
+				// The chain is first started before [past], so that the
+				// previous activation time is accepted.
+				vm := newVM(restart.prevActivationTime, false)
+				vm.Set(past.Add(-time.Hour))
+				require.NoError(vm.verifyUpgrade())
+
+				vm = newVM(restart.activationTime, restart.overridden)
+				vm.Set(now)
+				err := vm.verifyUpgrade()
+				require.ErrorIs(err, restart.expectedErr)
+
+				expectedActivationTime := restart.activationTime
+				if restart.expectedErr != nil {
+					expectedActivationTime = restart.prevActivationTime
+				}
+				activationTime, err := fork.get(state.New(db))
+				require.NoError(err)
+				require.True(expectedActivationTime.Equal(activationTime))
+			})
+		}
+	}
+}
+
+func TestVerifyUpgradeForkTimeOverrideInPast(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_700_000_000, 0)
+	past := now.Add(-time.Hour)
+
+	db := versiondb.New(memdb.New())
+	vm := &VM{
+		Config: Config{
+			ActivationTime:                past,
+			VRFActivationTime:             mockable.MaxTime,
+			BlockExtensionsActivationTime: past,
+			UpgradeOverrides: UpgradeOverrides{
+				BlockExtensionsActivationTime: true,
+			},
+		},
+		ctx:   snow.DefaultContextTest(),
+		db:    db,
+		State: state.New(db),
+	}
+	vm.Set(now)
+
+	err := vm.verifyUpgrade()
+	require.ErrorIs(err, errActivationTimeInPast)
+}
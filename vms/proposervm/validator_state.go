@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+const validatorSetCacheSize = 64
+
+var (
+	_ validators.State = (*validatorStateCache)(nil)
+	_ snow.Acceptor    = (*validatorStateCache)(nil)
+)
+
+type validatorSetKey struct {
+	height   uint64
+	subnetID ids.ID
+}
+
+// validatorStateCache caches the results of validator set and P-chain height
+// lookups.
+//
+// Validator sets are cached by height, as the validator set at an accepted
+// P-chain height never changes. The current P-chain height is cached until a
+// P-chain block is accepted.
+type validatorStateCache struct {
+	validators.State
+
+	validatorSets cache.Cacher[validatorSetKey, map[ids.NodeID]*validators.GetValidatorOutput]
+
+	lock sync.RWMutex
+	// epoch is incremented every time the current height is invalidated. It
+	// prevents a height fetched before an invalidation from being cached
+	// after it.
+	epoch            uint64
+	hasCurrentHeight bool
+	currentHeight    uint64
+}
+
+func newValidatorStateCache(state validators.State) *validatorStateCache {
+	return &validatorStateCache{
+		State: state,
+		validatorSets: &cache.LRU[validatorSetKey, map[ids.NodeID]*validators.GetValidatorOutput]{
+			Size: validatorSetCacheSize,
+		},
+	}
+}
+
+func (s *validatorStateCache) GetCurrentHeight(ctx context.Context) (uint64, error) {
+	s.lock.RLock()
+	epoch := s.epoch
+	if s.hasCurrentHeight {
+		height := s.currentHeight
+		s.lock.RUnlock()
+		return height, nil
+	}
+	s.lock.RUnlock()
+
+	height, err := s.State.GetCurrentHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.epoch == epoch {
+		s.hasCurrentHeight = true
+		s.currentHeight = height
+	}
+	return height, nil
+}
+
+func (s *validatorStateCache) GetValidatorSet(
+	ctx context.Context,
+	height uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	key := validatorSetKey{
+		height:   height,
+		subnetID: subnetID,
+	}
+	if vdrs, ok := s.validatorSets.Get(key); ok {
+		return vdrs, nil
+	}
+
+	vdrs, err := s.State.GetValidatorSet(ctx, height, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	s.validatorSets.Put(key, vdrs)
+	return vdrs, nil
+}
+
+// Accept invalidates the cached current height. It should be called whenever
+// a P-chain block is accepted.
+func (s *validatorStateCache) Accept(*snow.ConsensusContext, ids.ID, []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.epoch++
+	s.hasCurrentHeight = false
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+var errTest = errors.New("non-nil error")
+
+func TestValidatorStateCacheGetCurrentHeight(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		currentHeight uint64 = 10
+		numCalls      int
+	)
+	cachedState := newValidatorStateCache(&validators.TestState{
+		T: t,
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			numCalls++
+			return currentHeight, nil
+		},
+	})
+
+	height, err := cachedState.GetCurrentHeight(context.Background())
+	require.NoError(err)
+	require.Equal(uint64(10), height)
+	require.Equal(1, numCalls)
+
+	// The height should be cached until a P-chain block is accepted.
+	currentHeight = 11
+	height, err = cachedState.GetCurrentHeight(context.Background())
+	require.NoError(err)
+	require.Equal(uint64(10), height)
+	require.Equal(1, numCalls)
+
+	require.NoError(cachedState.Accept(nil, ids.GenerateTestID(), nil))
+
+	height, err = cachedState.GetCurrentHeight(context.Background())
+	require.NoError(err)
+	require.Equal(uint64(11), height)
+	require.Equal(2, numCalls)
+}
+
+func TestValidatorStateCacheGetValidatorSet(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		nodeID    = ids.GenerateTestNodeID()
+		numCalls  int
+		returnErr error
+	)
+	cachedState := newValidatorStateCache(&validators.TestState{
+		T: t,
+		GetValidatorSetF: func(_ context.Context, height uint64, _ ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			numCalls++
+			if returnErr != nil {
+				return nil, returnErr
+			}
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {
+					NodeID: nodeID,
+					Weight: height,
+				},
+			}, nil
+		},
+	})
+
+	// Failed lookups should not be cached.
+	returnErr = errTest
+	_, err := cachedState.GetValidatorSet(context.Background(), 1, subnetID)
+	require.ErrorIs(err, errTest)
+	require.Equal(1, numCalls)
+
+	returnErr = nil
+	vdrs, err := cachedState.GetValidatorSet(context.Background(), 1, subnetID)
+	require.NoError(err)
+	require.Equal(uint64(1), vdrs[nodeID].Weight)
+	require.Equal(2, numCalls)
+
+	// Successful lookups should be cached by height and subnet.
+	vdrs, err = cachedState.GetValidatorSet(context.Background(), 1, subnetID)
+	require.NoError(err)
+	require.Equal(uint64(1), vdrs[nodeID].Weight)
+	require.Equal(2, numCalls)
+
+	vdrs, err = cachedState.GetValidatorSet(context.Background(), 2, subnetID)
+	require.NoError(err)
+	require.Equal(uint64(2), vdrs[nodeID].Weight)
+	require.Equal(3, numCalls)
+
+	_, err = cachedState.GetValidatorSet(context.Background(), 1, ids.GenerateTestID())
+	require.NoError(err)
+	require.Equal(4, numCalls)
+
+	// Accepting a P-chain block should not evict validator sets.
+	require.NoError(cachedState.Accept(nil, ids.GenerateTestID(), nil))
+	_, err = cachedState.GetValidatorSet(context.Background(), 1, subnetID)
+	require.NoError(err)
+	require.Equal(4, numCalls)
+}
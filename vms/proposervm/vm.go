@@ -5,31 +5,33 @@ package proposervm
 
 import (
 	"context"
-	"crypto"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/gorilla/rpc/v2"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/api/metrics"
-	"github.com/ava-labs/avalanchego/cache"
-	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
-	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/proposervm/indexer"
@@ -49,8 +51,14 @@ const (
 	// blocks.
 	DefaultNumHistoricalBlocks uint64 = 0
 
+	// apiEndpoint is the endpoint, relative to the chain's endpoint, that the
+	// proposervm's API is served at
+	apiEndpoint = "/proposervm"
+
 	checkIndexedFrequency = 10 * time.Second
-	innerBlkCacheSize     = 64 * units.MiB
+	// maxCachedBlockHeightDiff is the maximum height difference between a
+	// block and the last accepted block for the block to be cached.
+	maxCachedBlockHeightDiff = 64 * units.MiB
 )
 
 var (
@@ -65,6 +73,10 @@ var (
 	dbPrefix = []byte("proposervm")
 
 	errHeightIndexInvalidWhilePruning = errors.New("height index invalid while pruning old blocks")
+	errValidatorSetMismatch           = errors.New("committed validator set doesn't match the local validator set")
+	errValidatorSetHashNotActivated   = errors.New("validator set commitments aren't activated")
+	errUnsupportedBlockVersion        = errors.New("unsupported block codec version")
+	errDuplicateEndpoint              = errors.New("inner VM registered the proposervm endpoint")
 )
 
 func init() {
@@ -80,28 +92,36 @@ func init() {
 	}
 }
 
-func cachedBlockSize(_ ids.ID, blk snowman.Block) int {
-	return ids.IDLen + len(blk.Bytes()) + constants.PointerOverhead
-}
-
 type VM struct {
 	block.ChainVM
 	blockBuilderVM block.BuildBlockWithContextChainVM
 	batchedVM      block.BatchedChainVM
+	batchedBuildVM block.BatchedBuildChainVM
 	ssVM           block.StateSyncableVM
-
-	activationTime      time.Time
-	minimumPChainHeight uint64
-	minBlkDelay         time.Duration
-	numHistoricalBlocks uint64
-	// block signer
-	stakingLeafSigner crypto.Signer
-	// block certificate
-	stakingCertLeaf *staking.Certificate
+	// outerAcceptorVM is notified of the accepted blocks wrapping its blocks
+	outerAcceptorVM block.OuterAcceptorChainVM
+	// integrityVM verifies the consistency of the inner VM's persisted state
+	integrityVM block.IntegrityVerifierVM
+	// proposerContextVM is provided the proposer of the blocks it builds and
+	// verifies
+	proposerContextVM block.ProposerContextChainVM
+	// pendingWorkVM reports how much work is waiting to be included in the
+	// inner VM's next block
+	pendingWorkVM block.PendingWorkChainVM
+
+	Config
+
+	// secondaryStakingKey is the key being rotated to, if a staking key
+	// rotation is in progress. Blocks are built with whichever of the primary
+	// and secondary keys may propose first.
+	secondaryStakingKey *stakingKey
 
 	state.State
 	hIndexer indexer.HeightIndexer
 
+	// validatorState caches lookups made against the P-chain validator state
+	validatorState *validatorStateCache
+
 	proposer.Windower
 	tree.Tree
 	scheduler.Scheduler
@@ -110,16 +130,54 @@ type VM struct {
 	ctx         *snow.Context
 	db          *versiondb.Database
 	toScheduler chan<- common.Message
+	appSender   common.AppSender
+	metrics     *blockMetrics
+	clockSkew   *clockSkewMonitor
+
+	// appRequests multiplexes the app requests of the inner VM and [network]
+	appRequests *appRequestMux
+	// prioritizer sheds the proposervm's p2p messages under load. It also
+	// counts the app messages handled by the inner VM, which are never shed.
+	// If nil, messages are never shed.
+	prioritizer *p2p.Prioritizer
+	// network serves the proposervm's p2p protocols
+	network *p2p.Network
+	// backfillClient sends requests for the backfill protocol
+	backfillClient *p2p.Client
+	// backfillStarted is true once blocks have started being backfilled
+	backfillStarted bool
+
+	// equivocations tracks the signed blocks verified by this node to detect
+	// proposers that sign conflicting blocks
+	equivocations *equivocationTracker
+
+	// missedSlots tracks the proposer windows that passed without their
+	// proposer proposing the accepted block
+	missedSlots *missedSlotTracker
+
+	// signatureVerifier checks the signatures of parsed blocks ahead of their
+	// verification
+	signatureVerifier *signatureVerifier
 
 	// Block ID --> Block
 	// Each element is a block that passed verification but
 	// hasn't yet been accepted/rejected
 	verifiedBlocks map[ids.ID]PostForkBlock
-	// Stateless block ID --> inner block.
+	// Block ID --> Time the block was first verified
+	// Contains the same blocks as [verifiedBlocks]
+	verifiedTimes map[ids.ID]time.Time
+	// Blocks in [verifiedBlocks] that have been reported as stuck
+	stuckBlocks set.Set[ids.ID]
+	// nextBuildTime is the time the scheduler was last told to notify the
+	// engine to build a block at
+	nextBuildTime time.Time
+	// Blocks that were built as part of a batch but haven't been returned
+	// from BuildBlock yet. Each block is a child of the block before it.
+	pendingBatch []*postForkBlock
 	// Only contains post-fork blocks near the tip so that the cache doesn't get
 	// filled with random blocks every time this node parses blocks while
 	// processing a GetAncestors message from a bootstrapping node.
-	innerBlkCache  cache.Cacher[ids.ID, snowman.Block]
+	blockCache     *blockCache
 	preferred      ids.ID
 	consensusState snow.State
 	context        context.Context
@@ -134,32 +192,45 @@ type VM struct {
 	lastAcceptedHeight uint64
 }
 
-// New performs best when [minBlkDelay] is whole seconds. This is because block
-// timestamps are only specific to the second.
 func New(
 	vm block.ChainVM,
-	activationTime time.Time,
-	minimumPChainHeight uint64,
-	minBlkDelay time.Duration,
-	numHistoricalBlocks uint64,
-	stakingLeafSigner crypto.Signer,
-	stakingCertLeaf *staking.Certificate,
+	config Config,
 ) *VM {
+	var secondaryStakingKey *stakingKey
+	if config.SecondaryStakingLeafSigner != nil && config.SecondaryStakingCertLeaf != nil {
+		secondaryStakingKey = &stakingKey{
+			nodeID: ids.NodeIDFromCert(config.SecondaryStakingCertLeaf),
+			signer: config.SecondaryStakingLeafSigner,
+			cert:   config.SecondaryStakingCertLeaf,
+		}
+	}
+
 	blockBuilderVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
+	batchedBuildVM, _ := vm.(block.BatchedBuildChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	outerAcceptorVM, _ := vm.(block.OuterAcceptorChainVM)
+	integrityVM, _ := vm.(block.IntegrityVerifierVM)
+	proposerContextVM, _ := vm.(block.ProposerContextChainVM)
+	pendingWorkVM, _ := vm.(block.PendingWorkChainVM)
 	return &VM{
-		ChainVM:        vm,
-		blockBuilderVM: blockBuilderVM,
-		batchedVM:      batchedVM,
-		ssVM:           ssVM,
+		ChainVM:         vm,
+		blockBuilderVM:  blockBuilderVM,
+		batchedVM:       batchedVM,
+		batchedBuildVM:  batchedBuildVM,
+		ssVM:            ssVM,
+		outerAcceptorVM: outerAcceptorVM,
+		integrityVM:     integrityVM,
 
-		activationTime:      activationTime,
-		minimumPChainHeight: minimumPChainHeight,
-		minBlkDelay:         minBlkDelay,
-		numHistoricalBlocks: numHistoricalBlocks,
-		stakingLeafSigner:   stakingLeafSigner,
-		stakingCertLeaf:     stakingCertLeaf,
+		proposerContextVM: proposerContextVM,
+		pendingWorkVM:     pendingWorkVM,
+
+		Config:              config,
+		secondaryStakingKey: secondaryStakingKey,
+
+		validatorState: newValidatorStateCache(nil),
+		equivocations:  newEquivocationTracker(),
+		missedSlots:    newMissedSlotTracker(),
 	}
 }
 
@@ -192,32 +263,79 @@ func (vm *VM) Initialize(
 	chainCtx.Metrics = optionalGatherer
 
 	vm.ctx = chainCtx
-	vm.db = versiondb.New(prefixdb.New(dbPrefix, db))
+	var (
+		stateDB = database.Database(prefixdb.New(dbPrefix, db))
+		innerDB = db
+	)
+	if vm.WriteMetrics != nil {
+		stateDB = vm.WriteMetrics.Wrap("proposervm", stateDB)
+		innerDB = vm.WriteMetrics.Wrap("vm", innerDB)
+	}
+	vm.db = versiondb.New(stateDB)
+	vm.appSender = appSender
 	baseState, err := state.NewMetered(vm.db, "state", registerer)
 	if err != nil {
 		return err
 	}
 	vm.State = baseState
-	vm.Windower = proposer.New(chainCtx.ValidatorState, chainCtx.SubnetID, chainCtx.ChainID)
-	vm.Tree = tree.New()
-	innerBlkCache, err := metercacher.New(
-		"inner_block_cache",
+	if err := vm.verifyUpgrade(); err != nil {
+		return err
+	}
+	vm.metrics, err = newBlockMetrics(registerer)
+	if err != nil {
+		return err
+	}
+	vm.appRequests = newAppRequestMux()
+	var networkOptions []p2p.NetworkOption
+	if vm.AppConcurrency > 0 {
+		vm.prioritizer, err = p2p.NewPrioritizer(
+			p2p.NewPriorityConfig(vm.AppConcurrency),
+			"p2p",
+			registerer,
+		)
+		if err != nil {
+			return err
+		}
+		networkOptions = append(networkOptions, p2p.WithPrioritizer(vm.prioritizer))
+	}
+	vm.network = p2p.NewNetwork(
+		chainCtx.Log,
+		vm.appRequests.Sender(appSender, false),
 		registerer,
-		cache.NewSizedLRU(
-			innerBlkCacheSize,
-			cachedBlockSize,
-		),
+		"p2p",
+		networkOptions...,
+	)
+	vm.backfillClient, err = vm.network.NewAppProtocol(
+		backfillHandlerID,
+		&backfillHandler{vm: vm},
+		p2p.WithPriority(p2p.SyncPriority),
 	)
 	if err != nil {
 		return err
 	}
-	vm.innerBlkCache = innerBlkCache
+	vm.clockSkew = newClockSkewMonitor(vm.metrics.clockSkew)
+	vm.validatorState.State = chainCtx.ValidatorState
+	vm.Windower = proposer.New(vm.validatorState, chainCtx.SubnetID, chainCtx.ChainID)
+	vm.Tree = tree.New()
+	vm.blockCache, err = newBlockCache(vm.BlockCacheSize, registerer)
+	if err != nil {
+		return err
+	}
 
 	indexerDB := versiondb.New(vm.db)
 	indexerState := state.New(indexerDB)
 	vm.hIndexer = indexer.NewHeightIndexer(vm, vm.ctx.Log, indexerState)
 
-	scheduler, vmToEngine := scheduler.New(vm.ctx.Log, toEngine)
+	detachedCtx := utils.Detach(ctx)
+	context, cancel := context.WithCancel(detachedCtx)
+	vm.context = context
+	vm.onShutdown = cancel
+
+	var hasPendingWork func() bool
+	if vm.pacingEnabled() {
+		hasPendingWork = vm.hasPendingWork
+	}
+	scheduler, vmToEngine := scheduler.New(vm.ctx.Log, toEngine, hasPendingWork)
 	vm.Scheduler = scheduler
 	vm.toScheduler = vmToEngine
 
@@ -226,21 +344,19 @@ func (vm *VM) Initialize(
 	})
 
 	vm.verifiedBlocks = make(map[ids.ID]PostForkBlock)
-	detachedCtx := utils.Detach(ctx)
-	context, cancel := context.WithCancel(detachedCtx)
-	vm.context = context
-	vm.onShutdown = cancel
+	vm.verifiedTimes = make(map[ids.ID]time.Time)
+	vm.signatureVerifier = newSignatureVerifier(context, chainCtx.ChainID)
 
 	err = vm.ChainVM.Initialize(
 		ctx,
 		chainCtx,
-		db,
+		innerDB,
 		genesisBytes,
 		upgradeBytes,
 		configBytes,
 		vmToEngine,
 		fxs,
-		appSender,
+		vm.appRequests.Sender(appSender, true),
 	)
 	if err != nil {
 		return err
@@ -258,6 +374,14 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	if err := vm.loadMissedSlots(); err != nil {
+		return err
+	}
+
+	if vm.StuckBlockTimeout > 0 {
+		go chainCtx.Log.RecoverAndPanic(vm.monitorStuckBlocks)
+	}
+
 	forkHeight, err := vm.getForkHeight()
 	switch err {
 	case nil:
@@ -276,6 +400,37 @@ func (vm *VM) Initialize(
 	return nil
 }
 
+// CreateHandlers returns the inner VM's handlers along with the proposervm's
+// API, which is served at [apiEndpoint].
+func (vm *VM) CreateHandlers(ctx context.Context) (map[string]http.Handler, error) {
+	handlers, err := vm.ChainVM.CreateHandlers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := handlers[apiEndpoint]; ok {
+		return nil, fmt.Errorf("%w: %q", errDuplicateEndpoint, apiEndpoint)
+	}
+
+	server := rpc.NewServer()
+	server.RegisterCodec(json.NewCodec(), "application/json")
+	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	if err := server.RegisterService(&Service{vm: vm}, "proposervm"); err != nil {
+		return nil, err
+	}
+
+	if handlers == nil {
+		handlers = make(map[string]http.Handler, 1)
+	}
+	handlers[apiEndpoint] = server
+	return handlers, nil
+}
+
+// PChainAcceptor returns the acceptor that must be notified of accepted P-chain
+// blocks to invalidate the cached P-chain height.
+func (vm *VM) PChainAcceptor() snow.Acceptor {
+	return vm.validatorState
+}
+
 // shutdown ops then propagate shutdown to innerVM
 func (vm *VM) Shutdown(ctx context.Context) error {
 	vm.onShutdown()
@@ -295,6 +450,10 @@ func (vm *VM) SetState(ctx context.Context, newState snow.State) error {
 
 	oldState := vm.consensusState
 	vm.consensusState = newState
+	if newState == snow.NormalOp && vm.BackfillBlocks && !vm.backfillStarted {
+		vm.backfillStarted = true
+		go vm.ctx.Log.RecoverAndPanic(vm.backfill)
+	}
 	if oldState != snow.StateSyncing {
 		return nil
 	}
@@ -310,6 +469,10 @@ func (vm *VM) SetState(ctx context.Context, newState snow.State) error {
 }
 
 func (vm *VM) BuildBlock(ctx context.Context) (snowman.Block, error) {
+	if blk, ok := vm.nextBatchedBlock(); ok {
+		return blk, nil
+	}
+
 	preferredBlock, err := vm.getBlock(ctx, vm.preferred)
 	if err != nil {
 		vm.ctx.Log.Error("unexpected build block failure",
@@ -320,7 +483,13 @@ func (vm *VM) BuildBlock(ctx context.Context) (snowman.Block, error) {
 		return nil, err
 	}
 
-	return preferredBlock.buildChild(ctx)
+	start := time.Now()
+	blk, err := preferredBlock.buildChild(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vm.metrics.buildLatency.Observe(float64(time.Since(start)))
+	return blk, nil
 }
 
 func (vm *VM) ParseBlock(ctx context.Context, b []byte) (snowman.Block, error) {
@@ -355,7 +524,7 @@ func (vm *VM) SetPreference(ctx context.Context, preferred ids.ID) error {
 	}
 
 	// reset scheduler
-	minDelay, err := vm.Windower.Delay(ctx, blk.Height()+1, pChainHeight, vm.ctx.NodeID, proposer.MaxBuildWindows)
+	_, proposerDelay, err := vm.buildStakingKey(ctx, blk.Height()+1, pChainHeight)
 	if err != nil {
 		vm.ctx.Log.Debug("failed to fetch the expected delay",
 			zap.Error(err),
@@ -373,18 +542,25 @@ func (vm *VM) SetPreference(ctx context.Context, preferred ids.ID) error {
 	// validators can specify. This delay may be an issue for high performance,
 	// custom VMs. Until the P-chain is modified to target a specific block
 	// time, ProposerMinBlockDelay can be configured in the subnet config.
-	if minDelay < vm.minBlkDelay {
-		minDelay = vm.minBlkDelay
+	minDelay := proposerDelay
+	if minDelay < vm.MinBlkDelay {
+		minDelay = vm.MinBlkDelay
+	}
+	if enforcedMinDelay := vm.enforcedMinBlkDelay(vm.Time()); minDelay < enforcedMinDelay {
+		minDelay = enforcedMinDelay
 	}
 
 	preferredTime := blk.Timestamp()
 	nextStartTime := preferredTime.Add(minDelay)
-	vm.Scheduler.SetBuildBlockTime(nextStartTime)
+	buildDeadline := vm.buildDeadline(preferredTime, proposerDelay, nextStartTime)
+	vm.Scheduler.SetBuildBlockWindow(nextStartTime, buildDeadline)
+	vm.nextBuildTime = nextStartTime
 
 	vm.ctx.Log.Debug("set preference",
 		zap.Stringer("blkID", blk.ID()),
 		zap.Time("blockTimestamp", preferredTime),
 		zap.Time("nextStartTime", nextStartTime),
+		zap.Time("buildDeadline", buildDeadline),
 	)
 	return nil
 }
@@ -418,7 +594,7 @@ func (vm *VM) repair(ctx context.Context) error {
 		return err
 	}
 
-	if vm.numHistoricalBlocks != 0 {
+	if vm.NumHistoricalBlocks != 0 {
 		vm.ctx.Log.Fatal("block height index must be valid when pruning historical blocks")
 		return errHeightIndexInvalidWhilePruning
 	}
@@ -591,7 +767,9 @@ func (vm *VM) repairAcceptedChainByHeight(ctx context.Context) error {
 	proLastAcceptedHeight := proLastAccepted.Height()
 	innerLastAcceptedHeight := innerLastAccepted.Height()
 	if proLastAcceptedHeight < innerLastAcceptedHeight {
-		return fmt.Errorf("proposervm height index (%d) should never be lower than the inner height index (%d)", proLastAcceptedHeight, innerLastAcceptedHeight)
+		// The inner vm is ahead of the proposer vm, so we must roll the
+		// proposervm forward.
+		return vm.repairAcceptedChainGap(ctx, proLastAccepted, innerLastAccepted)
 	}
 	if proLastAcceptedHeight == innerLastAcceptedHeight {
 		// There is nothing to repair - as the heights match
@@ -616,6 +794,7 @@ func (vm *VM) repairAcceptedChainByHeight(ctx context.Context) error {
 		if err := vm.State.DeleteLastAccepted(); err != nil {
 			return err
 		}
+		vm.metrics.rolledBackBlocks.Add(float64(proLastAcceptedHeight - forkHeight + 1))
 		return vm.db.Commit()
 	}
 
@@ -630,6 +809,7 @@ func (vm *VM) repairAcceptedChainByHeight(ctx context.Context) error {
 	if err := vm.State.SetLastAccepted(newProLastAcceptedID); err != nil {
 		return err
 	}
+	vm.metrics.rolledBackBlocks.Add(float64(proLastAcceptedHeight - innerLastAcceptedHeight))
 	return vm.db.Commit()
 }
 
@@ -669,7 +849,7 @@ func (vm *VM) setLastAcceptedMetadata(ctx context.Context) error {
 }
 
 func (vm *VM) parsePostForkBlock(ctx context.Context, b []byte) (PostForkBlock, error) {
-	statelessBlock, err := statelessblock.Parse(b)
+	statelessBlock, err := vm.parseStatelessBlock(b)
 	if err != nil {
 		return nil, err
 	}
@@ -691,6 +871,12 @@ func (vm *VM) parsePostForkBlock(ctx context.Context, b []byte) (PostForkBlock,
 	}
 
 	if statelessSignedBlock, ok := statelessBlock.(statelessblock.SignedBlock); ok {
+		// Blocks pushed to the engine while catching up are parsed before
+		// their ancestors are verified, so their signatures are checked ahead
+		// of time.
+		if vm.consensusState == snow.NormalOp {
+			vm.signatureVerifier.Submit(statelessSignedBlock)
+		}
 		blk = &postForkBlock{
 			SignedBlock: statelessSignedBlock,
 			postForkCommonComponents: postForkCommonComponents{
@@ -712,6 +898,35 @@ func (vm *VM) parsePostForkBlock(ctx context.Context, b []byte) (PostForkBlock,
 	return blk, nil
 }
 
+// parseStatelessBlock parses [b] and rejects blocks that are encoded with a
+// codec version that hasn't been activated at the block's timestamp.
+func (vm *VM) parseStatelessBlock(b []byte) (statelessblock.Block, error) {
+	blk, err := statelessblock.Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	signedBlk, ok := blk.(statelessblock.SignedBlock)
+	if !ok {
+		return blk, nil
+	}
+	switch version := signedBlk.Version(); version {
+	case statelessblock.CodecVersion0:
+		return blk, nil
+	case statelessblock.CodecVersion1:
+		if !vm.blockExtensionsActivated(signedBlk.Timestamp()) {
+			return nil, fmt.Errorf("%w: %d at block timestamp %s is before %s",
+				errUnsupportedBlockVersion,
+				version,
+				signedBlk.Timestamp(),
+				vm.BlockExtensionsActivationTime,
+			)
+		}
+		return blk, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnsupportedBlockVersion, version)
+	}
+}
+
 func (vm *VM) parsePreForkBlock(ctx context.Context, b []byte) (*preForkBlock, error) {
 	blk, err := vm.ChainVM.ParseBlock(ctx, b)
 	return &preForkBlock{
@@ -797,7 +1012,7 @@ func (vm *VM) acceptPostForkBlock(blk PostForkBlock) error {
 	blkID := blk.ID()
 
 	vm.lastAcceptedHeight = height
-	delete(vm.verifiedBlocks, blkID)
+	vm.removeVerifiedBlock(blkID)
 
 	// Persist this block, its height index, and its status
 	if err := vm.State.SetLastAccepted(blkID); err != nil {
@@ -806,12 +1021,51 @@ func (vm *VM) acceptPostForkBlock(blk PostForkBlock) error {
 	if err := vm.State.PutBlock(blk.getStatelessBlk(), choices.Accepted); err != nil {
 		return err
 	}
+	if err := vm.State.PutInnerBlockID(blkID, blk.getInnerBlk().ID()); err != nil {
+		return err
+	}
+	if err := vm.putProposer(blk); err != nil {
+		return err
+	}
 	if err := vm.updateHeightIndex(height, blkID); err != nil {
 		return err
 	}
 	return vm.db.Commit()
 }
 
+// notifyOuterAccept notifies the inner VM, if it implements
+// block.OuterAcceptorChainVM, that [outerID] was accepted.
+func (vm *VM) notifyOuterAccept(
+	ctx context.Context,
+	outerID ids.ID,
+	innerID ids.ID,
+	proposer ids.NodeID,
+	timestamp time.Time,
+) error {
+	if vm.outerAcceptorVM == nil {
+		return nil
+	}
+	return vm.outerAcceptorVM.OnOuterAccept(ctx, outerID, innerID, proposer, timestamp)
+}
+
+// withProposerContext returns [ctx] carrying the proposer context of a
+// post-fork block if the inner VM requested it.
+func (vm *VM) withProposerContext(
+	ctx context.Context,
+	proposerID ids.NodeID,
+	delay time.Duration,
+	pChainHeight uint64,
+) context.Context {
+	if vm.proposerContextVM == nil || !vm.proposerContextVM.ShouldProvideProposerContext() {
+		return ctx
+	}
+	return block.WithProposerContext(ctx, &block.ProposerContext{
+		Proposer:     proposerID,
+		WindowIndex:  uint64(delay / proposer.WindowDuration),
+		PChainHeight: pChainHeight,
+	})
+}
+
 func (vm *VM) verifyAndRecordInnerBlk(ctx context.Context, blockCtx *block.Context, postFork PostForkBlock) error {
 	innerBlk := postFork.getInnerBlk()
 	postForkID := postFork.ID()
@@ -821,7 +1075,7 @@ func (vm *VM) verifyAndRecordInnerBlk(ctx context.Context, blockCtx *block.Conte
 		// We must update all of the mappings from postFork -> innerBlock to
 		// now point to originalInnerBlock.
 		postFork.setInnerBlk(originalInnerBlock)
-		vm.innerBlkCache.Put(postForkID, originalInnerBlock)
+		vm.blockCache.Put(postForkID, originalInnerBlock)
 	}
 
 	var (
@@ -862,9 +1116,20 @@ func (vm *VM) verifyAndRecordInnerBlk(ctx context.Context, blockCtx *block.Conte
 		vm.Tree.Add(innerBlk)
 	}
 	vm.verifiedBlocks[postForkID] = postFork
+	if _, ok := vm.verifiedTimes[postForkID]; !ok {
+		vm.verifiedTimes[postForkID] = vm.Time()
+	}
 	return nil
 }
 
+// removeVerifiedBlock removes [blkID] from the processing blocks once it has
+// been decided.
+func (vm *VM) removeVerifiedBlock(blkID ids.ID) {
+	delete(vm.verifiedBlocks, blkID)
+	delete(vm.verifiedTimes, blkID)
+	vm.stuckBlocks.Remove(blkID)
+}
+
 // notifyInnerBlockReady tells the scheduler that the inner VM is ready to build
 // a new block
 func (vm *VM) notifyInnerBlockReady() {
@@ -875,20 +1140,161 @@ func (vm *VM) notifyInnerBlockReady() {
 	}
 }
 
+// enforcedMinBlkDelay returns the minimum delay between the timestamps of a
+// block with [timestamp] and its parent. Blocks built before
+// [EnforcedMinBlkDelayActivationTime] aren't delayed, so that the delay can be
+// enabled on chains that already accepted blocks built sooner.
+func (vm *VM) enforcedMinBlkDelay(timestamp time.Time) time.Duration {
+	if timestamp.Before(vm.EnforcedMinBlkDelayActivationTime) {
+		return 0
+	}
+	return vm.EnforcedMinBlkDelay
+}
+
 func (vm *VM) optimalPChainHeight(ctx context.Context, minPChainHeight uint64) (uint64, error) {
-	minimumHeight, err := vm.ctx.ValidatorState.GetMinimumHeight(ctx)
+	minimumHeight, err := vm.validatorState.GetMinimumHeight(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	return math.Max(minimumHeight, minPChainHeight), nil
+	height := math.Max(minimumHeight, minPChainHeight)
+	return vm.alignPChainHeight(height, minPChainHeight), nil
+}
+
+// validatorSetHash returns the hash of the validator set at [pChainHeight].
+func (vm *VM) validatorSetHash(ctx context.Context, pChainHeight uint64) (ids.ID, error) {
+	vdrs, err := vm.validatorState.GetValidatorSet(ctx, pChainHeight, vm.ctx.SubnetID)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return proposer.ValidatorSetHash(vdrs), nil
+}
+
+// buildStatelessBlock builds the header of a child of [parentID]. If [signed]
+// is false, the block is built without a proposer. Otherwise, the block is
+// signed with [key]. If [vrfProof] is non-empty, the block must be signed.
+func (vm *VM) buildStatelessBlock(
+	ctx context.Context,
+	parentID ids.ID,
+	timestamp time.Time,
+	pChainHeight uint64,
+	key *stakingKey,
+	signed bool,
+	vrfProof []byte,
+	innerBlkBytes []byte,
+) (statelessblock.SignedBlock, error) {
+	// Blocks built before extensions are activated are encoded with the
+	// original block format.
+	var extensions statelessblock.Extensions
+	if vm.blockExtensionsActivated(timestamp) {
+		extensions.VRFProof = vrfProof
+		extensions.Compress = vm.CompressInnerBlocks
+		if vm.CommitValidatorSet && !timestamp.Before(vm.CommitValidatorSetActivationTime) {
+			var err error
+			extensions.ValidatorSetHash, err = vm.validatorSetHash(ctx, pChainHeight)
+			if err != nil {
+				return nil, fmt.Errorf("failed to calculate validator set hash: %w", err)
+			}
+		}
+	}
+
+	extended := len(extensions.Bytes()) != 0
+	switch {
+	case !signed && !extended:
+		return statelessblock.BuildUnsigned(
+			parentID,
+			timestamp,
+			pChainHeight,
+			innerBlkBytes,
+		)
+	case !signed:
+		return statelessblock.BuildUnsignedExtended(
+			parentID,
+			timestamp,
+			pChainHeight,
+			extensions,
+			innerBlkBytes,
+		)
+	case !extended:
+		return statelessblock.Build(
+			parentID,
+			timestamp,
+			pChainHeight,
+			key.cert,
+			innerBlkBytes,
+			vm.ctx.ChainID,
+			key.signer,
+		)
+	default:
+		return statelessblock.BuildExtended(
+			parentID,
+			timestamp,
+			pChainHeight,
+			extensions,
+			key.cert,
+			innerBlkBytes,
+			vm.ctx.ChainID,
+			key.signer,
+		)
+	}
+}
+
+// blockExtensionsActivated returns true if blocks built at [timestamp] may be
+// encoded with [statelessblock.CodecVersion1], which carries validator set
+// commitments, VRF proofs, and compressed inner blocks.
+func (vm *VM) blockExtensionsActivated(timestamp time.Time) bool {
+	return !timestamp.Before(vm.BlockExtensionsActivationTime)
+}
+
+// verifyValidatorSetHashActivated verifies that [blk] only commits to a
+// validator set if it was built after [CommitValidatorSetActivationTime].
+func (vm *VM) verifyValidatorSetHashActivated(blk statelessblock.SignedBlock) error {
+	if blk.ValidatorSetHash() == ids.Empty || !blk.Timestamp().Before(vm.CommitValidatorSetActivationTime) {
+		return nil
+	}
+	return fmt.Errorf("%w: block timestamp %s is before %s",
+		errValidatorSetHashNotActivated,
+		blk.Timestamp(),
+		vm.CommitValidatorSetActivationTime,
+	)
+}
+
+// verifyValidatorSetHash verifies that, if [blk] commits to a validator set,
+// the committed validator set matches the local view of the validator set.
+func (vm *VM) verifyValidatorSetHash(ctx context.Context, blk statelessblock.SignedBlock) error {
+	expectedHash := blk.ValidatorSetHash()
+	if expectedHash == ids.Empty {
+		return nil
+	}
+
+	pChainHeight := blk.PChainHeight()
+	hash, err := vm.validatorSetHash(ctx, pChainHeight)
+	if err != nil {
+		return err
+	}
+	if hash != expectedHash {
+		vm.ctx.Log.Warn("block verification failed",
+			zap.String("reason", "validator set mismatch"),
+			zap.Stringer("blkID", blk.ID()),
+			zap.Uint64("pChainHeight", pChainHeight),
+			zap.Stringer("expectedValidatorSetHash", expectedHash),
+			zap.Stringer("validatorSetHash", hash),
+		)
+		return fmt.Errorf("%w at P-chain height %d: expected %s but got %s",
+			errValidatorSetMismatch,
+			pChainHeight,
+			expectedHash,
+			hash,
+		)
+	}
+	return nil
 }
 
 // parseInnerBlock attempts to parse the provided bytes as an inner block. If
 // the inner block happens to be cached, then the inner block will not be
 // parsed.
 func (vm *VM) parseInnerBlock(ctx context.Context, outerBlkID ids.ID, innerBlkBytes []byte) (snowman.Block, error) {
-	if innerBlk, ok := vm.innerBlkCache.Get(outerBlkID); ok {
+	if innerBlk, ok := vm.blockCache.Get(outerBlkID); ok {
 		return innerBlk, nil
 	}
 
@@ -901,10 +1307,10 @@ func (vm *VM) parseInnerBlock(ctx context.Context, outerBlkID ids.ID, innerBlkBy
 }
 
 // Caches proposervm block ID --> inner block if the inner block's height
-// is within [innerBlkCacheSize] of the last accepted block's height.
+// is within [maxCachedBlockHeightDiff] of the last accepted block's height.
 func (vm *VM) cacheInnerBlock(outerBlkID ids.ID, innerBlk snowman.Block) {
 	diff := math.AbsDiff(innerBlk.Height(), vm.lastAcceptedHeight)
-	if diff < innerBlkCacheSize {
-		vm.innerBlkCache.Put(outerBlkID, innerBlk)
+	if diff < maxCachedBlockHeightDiff {
+		vm.blockCache.Put(outerBlkID, innerBlk)
 	}
 }
@@ -12,12 +12,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/meterdb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
@@ -134,12 +137,15 @@ func initTestProposerVM(
 
 	proVM := New(
 		coreVM,
-		proBlkStartTime,
-		minPChainHeight,
-		DefaultMinBlockDelay,
-		DefaultNumHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			ActivationTime:      proBlkStartTime,
+			MinimumPChainHeight: minPChainHeight,
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	valState := &validators.TestState{
@@ -526,10 +532,10 @@ func TestCoreBlockFailureCauseProposerBlockParseFailure(t *testing.T) {
 		proVM.preferred,
 		innerBlk.Timestamp(),
 		100, // pChainHeight,
-		proVM.stakingCertLeaf,
+		proVM.StakingCertLeaf,
 		innerBlk.Bytes(),
 		proVM.ctx.ChainID,
-		proVM.stakingLeafSigner,
+		proVM.StakingLeafSigner,
 	)
 	require.NoError(err)
 	proBlk := postForkBlock{
@@ -570,10 +576,10 @@ func TestTwoProBlocksWrappingSameCoreBlockCanBeParsed(t *testing.T) {
 		proVM.preferred,
 		innerBlk.Timestamp(),
 		100, // pChainHeight,
-		proVM.stakingCertLeaf,
+		proVM.StakingCertLeaf,
 		innerBlk.Bytes(),
 		proVM.ctx.ChainID,
-		proVM.stakingLeafSigner,
+		proVM.StakingLeafSigner,
 	)
 	require.NoError(err)
 	proBlk1 := postForkBlock{
@@ -589,10 +595,10 @@ func TestTwoProBlocksWrappingSameCoreBlockCanBeParsed(t *testing.T) {
 		proVM.preferred,
 		innerBlk.Timestamp(),
 		200, // pChainHeight,
-		proVM.stakingCertLeaf,
+		proVM.StakingCertLeaf,
 		innerBlk.Bytes(),
 		proVM.ctx.ChainID,
-		proVM.stakingLeafSigner,
+		proVM.StakingLeafSigner,
 	)
 	require.NoError(err)
 	proBlk2 := postForkBlock{
@@ -880,12 +886,13 @@ func TestExpiredBuildBlock(t *testing.T) {
 
 	proVM := New(
 		coreVM,
-		time.Time{},
-		0,
-		DefaultMinBlockDelay,
-		DefaultNumHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	valState := &validators.TestState{
@@ -1224,12 +1231,13 @@ func TestInnerVMRollback(t *testing.T) {
 
 	proVM := New(
 		coreVM,
-		time.Time{},
-		0,
-		DefaultMinBlockDelay,
-		DefaultNumHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	require.NoError(proVM.Initialize(
@@ -1311,12 +1319,13 @@ func TestInnerVMRollback(t *testing.T) {
 
 	proVM = New(
 		coreVM,
-		time.Time{},
-		0,
-		DefaultMinBlockDelay,
-		DefaultNumHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	require.NoError(proVM.Initialize(
@@ -1624,6 +1633,98 @@ func TestTooFarAdvanced(t *testing.T) {
 	require.ErrorIs(err, errTimeTooAdvanced)
 }
 
+func TestEnforcedMinBlockDelay(t *testing.T) {
+	require := require.New(t)
+
+	forkTime := time.Unix(0, 0)
+	coreVM, _, proVM, gBlock, _ := initTestProposerVM(t, forkTime, 0)
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	xBlock := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    gBlock.ID(),
+		HeightV:    gBlock.Height() + 1,
+		TimestampV: gBlock.Timestamp(),
+	}
+
+	yBlock := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{2},
+		ParentV:    xBlock.ID(),
+		HeightV:    xBlock.Height() + 1,
+		TimestampV: xBlock.Timestamp(),
+	}
+
+	// The transition block isn't subject to the enforced delay.
+	proVM.EnforcedMinBlkDelay = 5 * time.Second
+	proVM.Set(time.Now().Truncate(time.Second))
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return xBlock, nil
+	}
+	aBlock, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(aBlock.Verify(context.Background()))
+	require.NoError(proVM.SetPreference(context.Background(), aBlock.ID()))
+
+	// A child can't be verified before the delay has passed.
+	ySlb, err := statelessblock.BuildUnsigned(
+		aBlock.ID(),
+		aBlock.Timestamp().Add(time.Second),
+		defaultPChainHeight,
+		yBlock.Bytes(),
+	)
+	require.NoError(err)
+
+	bBlock := postForkBlock{
+		SignedBlock: ySlb,
+		postForkCommonComponents: postForkCommonComponents{
+			vm:       proVM,
+			innerBlk: yBlock,
+			status:   choices.Processing,
+		},
+	}
+	err = bBlock.Verify(context.Background())
+	require.ErrorIs(err, errBlockDelayTooShort)
+
+	// A child can't be built before the delay has passed.
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return yBlock, nil
+	}
+	proVM.Set(aBlock.Timestamp().Add(time.Second))
+	_, err = proVM.BuildBlock(context.Background())
+	require.ErrorIs(err, errBlockDelayTooShort)
+
+	// Once the delay and the proposer windows have passed, the child can be
+	// built and verified.
+	proVM.Set(aBlock.Timestamp().Add(proposer.MaxBuildDelay))
+	builtBlk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(builtBlk.Verify(context.Background()))
+}
+
+func TestEnforcedMinBlockDelayActivation(t *testing.T) {
+	activationTime := time.Unix(1000, 0)
+	vm := &VM{
+		Config: Config{
+			EnforcedMinBlkDelay:               5 * time.Second,
+			EnforcedMinBlkDelayActivationTime: activationTime,
+		},
+	}
+
+	require.Zero(t, vm.enforcedMinBlkDelay(activationTime.Add(-time.Second)))
+	require.Equal(t, 5*time.Second, vm.enforcedMinBlkDelay(activationTime))
+	require.Equal(t, 5*time.Second, vm.enforcedMinBlkDelay(activationTime.Add(time.Second)))
+}
+
 // Ensure that Accepting a PostForkOption (B) causes both the other option and
 // the core block in the other option to be rejected.
 //
@@ -1803,12 +1904,13 @@ func TestRejectedHeightNotIndexed(t *testing.T) {
 
 	proVM := New(
 		coreVM,
-		time.Time{},
-		0,
-		DefaultMinBlockDelay,
-		DefaultNumHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	valState := &validators.TestState{
@@ -2010,12 +2112,13 @@ func TestRejectedOptionHeightNotIndexed(t *testing.T) {
 
 	proVM := New(
 		coreVM,
-		time.Time{},
-		0,
-		DefaultMinBlockDelay,
-		DefaultNumHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	valState := &validators.TestState{
@@ -2173,12 +2276,13 @@ func TestVMInnerBlkCache(t *testing.T) {
 	innerVM := mocks.NewMockChainVM(ctrl)
 	vm := New(
 		innerVM,
-		time.Time{}, // fork is active
-		0,           // minimum P-Chain height
-		DefaultMinBlockDelay,
-		DefaultNumHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	innerVM.EXPECT().Initialize(
@@ -2229,10 +2333,10 @@ func TestVMInnerBlkCache(t *testing.T) {
 		ids.GenerateTestID(), // parent
 		time.Time{},          // timestamp
 		1,                    // pChainHeight,
-		vm.stakingCertLeaf,   // cert
+		vm.StakingCertLeaf,   // cert
 		blkNearTipInnerBytes, // inner blk bytes
 		vm.ctx.ChainID,       // chain ID
-		vm.stakingLeafSigner, // key
+		vm.StakingLeafSigner, // key
 	)
 	require.NoError(err)
 
@@ -2243,6 +2347,7 @@ func TestVMInnerBlkCache(t *testing.T) {
 	mockInnerBlkNearTip := snowman.NewMockBlock(ctrl)
 	mockInnerBlkNearTip.EXPECT().Height().Return(uint64(1)).Times(2)
 	mockInnerBlkNearTip.EXPECT().Bytes().Return(blkNearTipInnerBytes).Times(1)
+	mockInnerBlkNearTip.EXPECT().ID().Return(ids.GenerateTestID()).Times(1)
 
 	innerVM.EXPECT().ParseBlock(gomock.Any(), blkNearTipInnerBytes).Return(mockInnerBlkNearTip, nil).Times(2)
 	_, err = vm.ParseBlock(context.Background(), blkNearTip.Bytes())
@@ -2250,23 +2355,23 @@ func TestVMInnerBlkCache(t *testing.T) {
 
 	// Block should now be in cache because it's a post-fork block
 	// and close to the tip.
-	gotBlk, ok := vm.innerBlkCache.Get(blkNearTip.ID())
+	gotBlk, ok := vm.blockCache.Get(blkNearTip.ID())
 	require.True(ok)
 	require.Equal(mockInnerBlkNearTip, gotBlk)
 	require.Zero(vm.lastAcceptedHeight)
 
 	// Clear the cache
-	vm.innerBlkCache.Flush()
+	vm.blockCache.Flush()
 
 	// Advance the tip height
-	vm.lastAcceptedHeight = innerBlkCacheSize + 1
+	vm.lastAcceptedHeight = maxCachedBlockHeightDiff + 1
 
 	// Parse the block again. This time it shouldn't be cached
 	// because it's not close to the tip.
 	_, err = vm.ParseBlock(context.Background(), blkNearTip.Bytes())
 	require.NoError(err)
 
-	_, ok = vm.innerBlkCache.Get(blkNearTip.ID())
+	_, ok = vm.blockCache.Get(blkNearTip.ID())
 	require.False(ok)
 }
 
@@ -2338,7 +2443,7 @@ func TestVMInnerBlkCacheDeduplicationRegression(t *testing.T) {
 		bBlock.(*postForkBlock).innerBlk.Status(),
 	)
 
-	cachedXBlock, ok := proVM.innerBlkCache.Get(bBlock.ID())
+	cachedXBlock, ok := proVM.blockCache.Get(bBlock.ID())
 	require.True(ok)
 	require.Equal(
 		choices.Accepted,
@@ -2402,12 +2507,13 @@ func TestVM_VerifyBlockWithContext(t *testing.T) {
 	innerVM := mocks.NewMockChainVM(ctrl)
 	vm := New(
 		innerVM,
-		time.Time{}, // fork is active
-		0,           // minimum P-Chain height
-		DefaultMinBlockDelay,
-		DefaultNumHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	// make sure that DBs are compressed correctly
@@ -2613,12 +2719,13 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 
 	proVM := New(
 		coreVM,
-		time.Time{},
-		0,
-		DefaultMinBlockDelay,
-		DefaultNumHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	require.NoError(proVM.Initialize(
@@ -2711,12 +2818,13 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 	numHistoricalBlocks := uint64(2)
 	proVM = New(
 		coreVM,
-		time.Time{},
-		0,
-		DefaultMinBlockDelay,
-		numHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: numHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	require.NoError(proVM.Initialize(
@@ -2753,12 +2861,13 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 	newNumHistoricalBlocks := numHistoricalBlocks + 2
 	proVM = New(
 		coreVM,
-		time.Time{},
-		0,
-		DefaultMinBlockDelay,
-		newNumHistoricalBlocks,
-		pTestSigner,
-		pTestCert,
+		Config{
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: newNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
 	)
 
 	require.NoError(proVM.Initialize(
@@ -2797,3 +2906,384 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 	issueBlock()
 	requireNumHeights(newNumHistoricalBlocks)
 }
+
+func TestVerifyValidatorSetCommitment(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		case bytes.Equal(b, coreBlk.Bytes()):
+			return coreBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	vdrs, err := valState.GetValidatorSet(context.Background(), defaultPChainHeight, ids.Empty)
+	require.NoError(err)
+	validatorSetHash := proposer.ValidatorSetHash(vdrs)
+
+	validBlk, err := statelessblock.BuildUnsignedExtended(
+		coreGenBlk.ID(),
+		coreGenBlk.Timestamp(),
+		defaultPChainHeight,
+		statelessblock.Extensions{
+			ValidatorSetHash: validatorSetHash,
+		},
+		coreBlk.Bytes(),
+	)
+	require.NoError(err)
+
+	blk, err := proVM.ParseBlock(context.Background(), validBlk.Bytes())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+
+	invalidBlk, err := statelessblock.BuildUnsignedExtended(
+		coreGenBlk.ID(),
+		coreGenBlk.Timestamp(),
+		defaultPChainHeight,
+		statelessblock.Extensions{
+			ValidatorSetHash: ids.GenerateTestID(),
+		},
+		coreBlk.Bytes(),
+	)
+	require.NoError(err)
+
+	blk, err = proVM.ParseBlock(context.Background(), invalidBlk.Bytes())
+	require.NoError(err)
+	err = blk.Verify(context.Background())
+	require.ErrorIs(err, errValidatorSetMismatch)
+}
+
+func TestVerifyValidatorSetCommitmentBeforeActivation(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+	proVM.CommitValidatorSetActivationTime = coreGenBlk.Timestamp().Add(time.Second)
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		case bytes.Equal(b, coreBlk.Bytes()):
+			return coreBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	vdrs, err := valState.GetValidatorSet(context.Background(), defaultPChainHeight, ids.Empty)
+	require.NoError(err)
+
+	statelessBlk, err := statelessblock.BuildUnsignedExtended(
+		coreGenBlk.ID(),
+		coreGenBlk.Timestamp(),
+		defaultPChainHeight,
+		statelessblock.Extensions{
+			ValidatorSetHash: proposer.ValidatorSetHash(vdrs),
+		},
+		coreBlk.Bytes(),
+	)
+	require.NoError(err)
+
+	blk, err := proVM.ParseBlock(context.Background(), statelessBlk.Bytes())
+	require.NoError(err)
+	err = blk.Verify(context.Background())
+	require.ErrorIs(err, errValidatorSetHashNotActivated)
+}
+
+func TestBuildBlockCommitsToValidatorSet(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+	proVM.CommitValidatorSet = true
+
+	// The first post-fork block follows the pre-fork genesis block
+	coreBlk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk1, nil
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case coreGenBlk.ID():
+			return coreGenBlk, nil
+		case coreBlk1.ID():
+			return coreBlk1, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		case bytes.Equal(b, coreBlk1.Bytes()):
+			return coreBlk1, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	proBlk1, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(proBlk1.Verify(context.Background()))
+	require.NoError(proBlk1.Accept(context.Background()))
+	require.NoError(proVM.SetPreference(context.Background(), proBlk1.ID()))
+
+	coreBlk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{2},
+		ParentV:    coreBlk1.ID(),
+		HeightV:    coreBlk1.Height() + 1,
+		TimestampV: coreBlk1.Timestamp(),
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk2, nil
+	}
+
+	proVM.Set(proBlk1.Timestamp().Add(proposer.MaxVerifyDelay))
+	proBlk2, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+
+	statelessBlk := proBlk2.(*postForkBlock).SignedBlock
+	vdrs, err := valState.GetValidatorSet(context.Background(), statelessBlk.PChainHeight(), ids.Empty)
+	require.NoError(err)
+	require.Equal(proposer.ValidatorSetHash(vdrs), statelessBlk.ValidatorSetHash())
+	require.NoError(proBlk2.Verify(context.Background()))
+}
+
+func TestParseBlockRejectsUnactivatedCodecVersion(t *testing.T) {
+	require := require.New(t)
+
+	_, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+	proVM.BlockExtensionsActivationTime = coreGenBlk.Timestamp().Add(time.Second)
+
+	statelessBlk, err := statelessblock.BuildUnsignedVersioned(
+		statelessblock.CodecVersion1,
+		coreGenBlk.ID(),
+		coreGenBlk.Timestamp(),
+		0,
+		nil,
+		[]byte{1},
+	)
+	require.NoError(err)
+
+	_, err = proVM.parsePostForkBlock(context.Background(), statelessBlk.Bytes())
+	require.ErrorIs(err, errUnsupportedBlockVersion)
+}
+
+func TestBuildBlockCompressesInnerBlock(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+	proVM.CompressInnerBlocks = true
+
+	// The first post-fork block follows the pre-fork genesis block
+	coreBlk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreBlk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     bytes.Repeat([]byte("inner block "), 100),
+		ParentV:    coreBlk1.ID(),
+		HeightV:    coreBlk1.Height() + 1,
+		TimestampV: coreBlk1.Timestamp(),
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk1, nil
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case coreGenBlk.ID():
+			return coreGenBlk, nil
+		case coreBlk1.ID():
+			return coreBlk1, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		case bytes.Equal(b, coreBlk1.Bytes()):
+			return coreBlk1, nil
+		case bytes.Equal(b, coreBlk2.Bytes()):
+			return coreBlk2, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	proBlk1, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(proBlk1.Verify(context.Background()))
+	require.NoError(proBlk1.Accept(context.Background()))
+	require.NoError(proVM.SetPreference(context.Background(), proBlk1.ID()))
+
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk2, nil
+	}
+
+	proVM.Set(proBlk1.Timestamp().Add(proposer.MaxVerifyDelay))
+	proBlk2, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+
+	statelessBlk := proBlk2.(*postForkBlock).SignedBlock
+	require.Equal(statelessblock.CodecVersion1, statelessBlk.Version())
+	require.Equal(coreBlk2.Bytes(), statelessBlk.Block())
+
+	// Compressed blocks are parsed even if this node doesn't compress the
+	// blocks it builds.
+	proVM.CompressInnerBlocks = false
+	parsedBlk, err := proVM.ParseBlock(context.Background(), proBlk2.Bytes())
+	require.NoError(err)
+	require.Equal(proBlk2.ID(), parsedBlk.ID())
+	require.Equal(coreBlk2, parsedBlk.(*postForkBlock).getInnerBlk())
+	require.NoError(parsedBlk.Verify(context.Background()))
+}
+
+func TestWriteMetricsAttributeInnerVMWrites(t *testing.T) {
+	require := require.New(t)
+
+	coreGenBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV:    0,
+		TimestampV: genesisTimestamp,
+		BytesV:     []byte{0},
+	}
+
+	coreVM := &block.TestVM{
+		TestVM: common.TestVM{
+			T: t,
+			InitializeF: func(_ context.Context, _ *snow.Context, db database.Database, _ []byte, _ []byte, _ []byte, _ chan<- common.Message, _ []*common.Fx, _ common.AppSender) error {
+				return db.Put([]byte{1}, []byte{2})
+			},
+		},
+		LastAcceptedF: func(context.Context) (ids.ID, error) {
+			return coreGenBlk.ID(), nil
+		},
+		GetBlockF: func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+			if blkID != coreGenBlk.ID() {
+				return nil, errUnknownBlock
+			}
+			return coreGenBlk, nil
+		},
+		VerifyHeightIndexF: func(context.Context) error {
+			return nil
+		},
+	}
+
+	registerer := prometheus.NewRegistry()
+	writeMetrics, err := meterdb.NewWriteMetrics("", registerer)
+	require.NoError(err)
+
+	proVM := New(
+		coreVM,
+		Config{
+			ActivationTime:      mockable.MaxTime,
+			MinBlkDelay:         DefaultMinBlockDelay,
+			NumHistoricalBlocks: DefaultNumHistoricalBlocks,
+			BlockCacheSize:      DefaultBlockCacheSize,
+			WriteMetrics:        writeMetrics,
+			StakingLeafSigner:   pTestSigner,
+			StakingCertLeaf:     pTestCert,
+		},
+	)
+	require.NoError(proVM.Initialize(
+		context.Background(),
+		snow.DefaultContextTest(),
+		memdb.New(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	))
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	metrics, err := registerer.Gather()
+	require.NoError(err)
+
+	writes := make(map[string]float64)
+	for _, family := range metrics {
+		if family.GetName() != "writes" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				writes[label.GetValue()] = metric.GetCounter().GetValue()
+			}
+		}
+	}
+	// The proposervm records its fork times in its state
+	require.Positive(writes["proposervm"])
+	require.Equal(1.0, writes["vm"])
+}
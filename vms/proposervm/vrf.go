@@ -0,0 +1,162 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+var (
+	// vrfPrefix separates VRF inputs from other messages that are signed with
+	// the staking BLS key. Warp messages start with their codec version, which
+	// never matches this prefix.
+	vrfPrefix = []byte("proposervm vrf")
+
+	errUnexpectedVRFProof = errors.New("unexpected VRF proof")
+	errVRFNotActivated    = errors.New("VRF proofs aren't activated")
+	errMissingVRFProof    = errors.New("missing VRF proof")
+	errMissingVRFKey      = errors.New("proposer doesn't have a registered BLS key")
+	errInvalidVRFProof    = errors.New("invalid VRF proof")
+)
+
+// vrfInput returns the message that the proposer of a child of [parentID]
+// signs to prove its VRF output.
+func vrfInput(chainID ids.ID, parentID ids.ID) []byte {
+	msg := make([]byte, 0, len(vrfPrefix)+2*ids.IDLen)
+	msg = append(msg, vrfPrefix...)
+	msg = append(msg, chainID[:]...)
+	return append(msg, parentID[:]...)
+}
+
+// vrfOutput returns the randomness provided by [blk]. BLS signatures are
+// unique, so the output is fully determined by the proposer's key and the
+// parent of [blk]. If [blk] doesn't provide a VRF proof, ids.Empty is
+// returned.
+func vrfOutput(blk statelessblock.SignedBlock) ids.ID {
+	return vrfOutputFromProof(blk.VRFProof())
+}
+
+func vrfOutputFromProof(proof []byte) ids.ID {
+	if len(proof) == 0 {
+		return ids.Empty
+	}
+	return hashing.ComputeHash256Array(proof)
+}
+
+// vrfProof returns the VRF proof of [nodeID] for a child of [parentID] built
+// at [timestamp]. If VRF proofs are disabled or not yet activated, or this
+// node's BLS key isn't registered to [nodeID] at [pChainHeight], nil is
+// returned.
+func (vm *VM) vrfProof(ctx context.Context, nodeID ids.NodeID, parentID ids.ID, pChainHeight uint64, timestamp time.Time) ([]byte, error) {
+	if vm.VRFKey == nil || !vm.vrfActivated(timestamp) {
+		return nil, nil
+	}
+
+	vdrs, err := vm.validatorState.GetValidatorSet(ctx, pChainHeight, vm.ctx.SubnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	vdr, ok := vdrs[nodeID]
+	if !ok || vdr.PublicKey == nil {
+		vm.ctx.Log.Debug("building block without VRF proof",
+			zap.String("reason", "BLS key isn't registered"),
+			zap.Stringer("parentID", parentID),
+			zap.Uint64("pChainHeight", pChainHeight),
+		)
+		return nil, nil
+	}
+
+	pk := bls.PublicFromSecretKey(vm.VRFKey)
+	if !bytes.Equal(bls.PublicKeyToBytes(pk), bls.PublicKeyToBytes(vdr.PublicKey)) {
+		vm.ctx.Log.Warn("building block without VRF proof",
+			zap.String("reason", "BLS key doesn't match the registered key"),
+			zap.Stringer("parentID", parentID),
+			zap.Uint64("pChainHeight", pChainHeight),
+		)
+		return nil, nil
+	}
+
+	sig := bls.Sign(vm.VRFKey, vrfInput(vm.ctx.ChainID, parentID))
+	return bls.SignatureToBytes(sig), nil
+}
+
+// verifyVRFProof verifies that, once VRF proofs are activated, [blk] provides
+// a VRF proof if its proposer had a registered BLS key at [pChainHeight], and
+// that the proof was generated with that key. Blocks without a proposer, and
+// blocks built before VRF proofs are activated, must not provide a proof.
+func (vm *VM) verifyVRFProof(ctx context.Context, pChainHeight uint64, blk statelessblock.SignedBlock) error {
+	proof := blk.VRFProof()
+	proposerID := blk.Proposer()
+	if proposerID == ids.EmptyNodeID {
+		if len(proof) != 0 {
+			return errUnexpectedVRFProof
+		}
+		return nil
+	}
+
+	timestamp := blk.Timestamp()
+	if !vm.vrfActivated(timestamp) {
+		if len(proof) != 0 {
+			return fmt.Errorf("%w: block timestamp %s is before %s",
+				errVRFNotActivated,
+				timestamp,
+				vm.VRFActivationTime,
+			)
+		}
+		return nil
+	}
+
+	vdrs, err := vm.validatorState.GetValidatorSet(ctx, pChainHeight, vm.ctx.SubnetID)
+	if err != nil {
+		return err
+	}
+
+	vdr, ok := vdrs[proposerID]
+	hasKey := ok && vdr.PublicKey != nil
+	switch {
+	case len(proof) == 0 && hasKey:
+		return fmt.Errorf("%w: %s has a registered BLS key at P-chain height %d",
+			errMissingVRFProof,
+			proposerID,
+			pChainHeight,
+		)
+	case len(proof) == 0:
+		return nil
+	case !hasKey:
+		return fmt.Errorf("%w: %s at P-chain height %d",
+			errMissingVRFKey,
+			proposerID,
+			pChainHeight,
+		)
+	}
+
+	sig, err := bls.SignatureFromBytes(proof)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidVRFProof, err)
+	}
+	if !bls.Verify(vdr.PublicKey, sig, vrfInput(vm.ctx.ChainID, blk.ParentID())) {
+		return fmt.Errorf("%w: signature verification failed", errInvalidVRFProof)
+	}
+	return nil
+}
+
+// vrfActivated returns true if signed blocks built at [timestamp] must provide
+// VRF proofs. Proofs are carried as block extensions, so they can't be
+// required before extensions are activated.
+func (vm *VM) vrfActivated(timestamp time.Time) bool {
+	return !timestamp.Before(vm.VRFActivationTime) && vm.blockExtensionsActivated(timestamp)
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+func TestPostForkCommonComponents_buildChildWithVRF(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	pChainHeight := uint64(1337)
+	parentID := ids.GenerateTestID()
+	parentTimestamp := time.Now()
+	innerBlk := snowman.NewMockBlock(ctrl)
+	innerBlk.EXPECT().ID().Return(ids.GenerateTestID()).AnyTimes()
+	innerBlk.EXPECT().Height().Return(pChainHeight - 1).AnyTimes()
+	builtBlk := snowman.NewMockBlock(ctrl)
+	builtBlk.EXPECT().Bytes().Return([]byte{1, 2, 3}).AnyTimes()
+	builtBlk.EXPECT().ID().Return(ids.GenerateTestID()).AnyTimes()
+	builtBlk.EXPECT().Height().Return(pChainHeight).AnyTimes()
+
+	var blockCtx *block.Context
+	innerVM := mocks.NewMockChainVM(ctrl)
+	innerBlockBuilderVM := mocks.NewMockBuildBlockWithContextChainVM(ctrl)
+	innerBlockBuilderVM.EXPECT().BuildBlockWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, ctx *block.Context) (snowman.Block, error) {
+			blockCtx = ctx
+			return builtBlk, nil
+		},
+	).AnyTimes()
+	windower := proposer.NewMockWindower(ctrl)
+	windower.EXPECT().Delay(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).AnyTimes()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	nodeID := ids.NodeIDFromCert(pTestCert)
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID: {
+			NodeID:    nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    1,
+		},
+	}
+	vdrState := &validators.TestState{
+		T: t,
+		GetMinimumHeightF: func(context.Context) (uint64, error) {
+			return pChainHeight, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return vdrs, nil
+		},
+	}
+
+	vm := &VM{
+		ChainVM:        innerVM,
+		blockBuilderVM: innerBlockBuilderVM,
+		ctx: &snow.Context{
+			ChainID:        ids.GenerateTestID(),
+			NodeID:         nodeID,
+			ValidatorState: vdrState,
+			Log:            logging.NoLog{},
+		},
+		validatorState: newValidatorStateCache(vdrState),
+		Windower:       windower,
+		Config: Config{
+			VRFKey:            sk,
+			StakingCertLeaf:   pTestCert,
+			StakingLeafSigner: pTestSigner,
+		},
+	}
+
+	blk := &postForkCommonComponents{
+		innerBlk: innerBlk,
+		vm:       vm,
+	}
+
+	gotChild, err := blk.buildChild(
+		context.Background(),
+		parentID,
+		parentTimestamp,
+		pChainHeight-1,
+	)
+	require.NoError(err)
+
+	child := gotChild.(*postForkBlock).SignedBlock
+	require.Equal(statelessblock.CodecVersion1, child.Version())
+	require.NotEmpty(child.VRFProof())
+
+	// The output provided to the inner VM must be committed to by the block.
+	require.NotNil(blockCtx)
+	require.NotEqual(ids.Empty, blockCtx.VRFOutput)
+	require.Equal(vrfOutput(child), blockCtx.VRFOutput)
+	require.NoError(vm.verifyVRFProof(context.Background(), pChainHeight-1, child))
+
+	// The proof must be generated for the parent of the block.
+	otherProof, err := vm.vrfProof(context.Background(), vm.ctx.NodeID, ids.GenerateTestID(), pChainHeight-1, parentTimestamp)
+	require.NoError(err)
+	invalidChild, err := statelessblock.BuildExtended(
+		parentID,
+		parentTimestamp,
+		pChainHeight,
+		statelessblock.Extensions{
+			VRFProof: otherProof,
+		},
+		pTestCert,
+		[]byte{1, 2, 3},
+		vm.ctx.ChainID,
+		pTestSigner,
+	)
+	require.NoError(err)
+	err = vm.verifyVRFProof(context.Background(), pChainHeight-1, invalidChild)
+	require.ErrorIs(err, errInvalidVRFProof)
+
+	// The proposer must have a registered BLS key.
+	vdrs[nodeID].PublicKey = nil
+	err = vm.verifyVRFProof(context.Background(), pChainHeight-1, child)
+	require.ErrorIs(err, errMissingVRFKey)
+
+	// Without a registered BLS key, blocks are built without a VRF proof.
+	gotChild, err = blk.buildChild(
+		context.Background(),
+		parentID,
+		parentTimestamp,
+		pChainHeight-1,
+	)
+	require.NoError(err)
+
+	child = gotChild.(*postForkBlock).SignedBlock
+	require.Equal(statelessblock.CodecVersion0, child.Version())
+	require.Empty(child.VRFProof())
+	require.Equal(ids.Empty, blockCtx.VRFOutput)
+	require.NoError(vm.verifyVRFProof(context.Background(), pChainHeight-1, child))
+}
+
+func TestVerifyVRFProofActivation(t *testing.T) {
+	require := require.New(t)
+
+	pChainHeight := uint64(1337)
+	parentID := ids.GenerateTestID()
+	activationTime := time.Unix(1000, 0)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	nodeID := ids.NodeIDFromCert(pTestCert)
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID: {
+			NodeID:    nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    1,
+		},
+	}
+	vdrState := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return vdrs, nil
+		},
+	}
+
+	vm := &VM{
+		ctx: &snow.Context{
+			ChainID:        ids.GenerateTestID(),
+			ValidatorState: vdrState,
+			Log:            logging.NoLog{},
+		},
+		validatorState: newValidatorStateCache(vdrState),
+		Config: Config{
+			VRFActivationTime: activationTime,
+		},
+	}
+
+	buildBlock := func(timestamp time.Time, vrfProof []byte) statelessblock.SignedBlock {
+		if len(vrfProof) == 0 {
+			blk, err := statelessblock.Build(
+				parentID,
+				timestamp,
+				pChainHeight,
+				pTestCert,
+				[]byte{1, 2, 3},
+				vm.ctx.ChainID,
+				pTestSigner,
+			)
+			require.NoError(err)
+			return blk
+		}
+		blk, err := statelessblock.BuildExtended(
+			parentID,
+			timestamp,
+			pChainHeight,
+			statelessblock.Extensions{
+				VRFProof: vrfProof,
+			},
+			pTestCert,
+			[]byte{1, 2, 3},
+			vm.ctx.ChainID,
+			pTestSigner,
+		)
+		require.NoError(err)
+		return blk
+	}
+
+	// Before activation, blocks must not provide a VRF proof.
+	beforeActivation := activationTime.Add(-time.Second)
+	require.NoError(vm.verifyVRFProof(context.Background(), pChainHeight, buildBlock(beforeActivation, nil)))
+	err = vm.verifyVRFProof(context.Background(), pChainHeight, buildBlock(beforeActivation, []byte{1}))
+	require.ErrorIs(err, errVRFNotActivated)
+
+	// After activation, proposers with a registered BLS key must provide a VRF
+	// proof.
+	err = vm.verifyVRFProof(context.Background(), pChainHeight, buildBlock(activationTime, nil))
+	require.ErrorIs(err, errMissingVRFProof)
+
+	// VRF proofs aren't required until block extensions, which carry them,
+	// are activated.
+	vm.BlockExtensionsActivationTime = activationTime.Add(time.Second)
+	require.NoError(vm.verifyVRFProof(context.Background(), pChainHeight, buildBlock(activationTime, nil)))
+	vm.BlockExtensionsActivationTime = time.Time{}
+
+	// Proposers without a registered BLS key can't provide a VRF proof.
+	vdrs[nodeID].PublicKey = nil
+	require.NoError(vm.verifyVRFProof(context.Background(), pChainHeight, buildBlock(activationTime, nil)))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
 )
 
 var (
@@ -40,6 +41,9 @@ type VMGetterConfig struct {
 	PluginDirectory string
 	CPUTracker      resource.ProcessTracker
 	RuntimeTracker  runtime.Tracker
+	ConnPoolConfig  rpcchainvm.ConnPoolConfig
+	CallTimeouts    rpcchainvm.CallTimeoutConfig
+	Sandbox         subprocess.SandboxConfig
 }
 
 type vmGetter struct {
@@ -103,6 +107,9 @@ func (getter *vmGetter) Get() (map[ids.ID]vms.Factory, map[ids.ID]vms.Factory, e
 			filepath.Join(getter.config.PluginDirectory, file.Name()),
 			getter.config.CPUTracker,
 			getter.config.RuntimeTracker,
+			getter.config.ConnPoolConfig,
+			getter.config.CallTimeouts,
+			getter.config.Sandbox,
 		)
 	}
 	return registeredVMs, unregisteredVMs, nil
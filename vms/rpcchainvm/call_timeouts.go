@@ -0,0 +1,179 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+const methodLabel = "method"
+
+var (
+	_ grpc.ClientConnInterface = (*deadlineConn)(nil)
+
+	ErrInvalidCallTimeout = errors.New("call timeout must be non-negative")
+	ErrUnknownVMMethod    = errors.New("unknown VM method")
+	ErrNonIdempotentCall  = errors.New("can't time out non-idempotent VM method")
+
+	// nonIdempotentMethods are the VM RPCs that change the state of the
+	// plugin and can't be retried. If one of them were cancelled while the
+	// plugin completed it, the node and the plugin would disagree on the
+	// plugin's state, so they are never given a timeout.
+	nonIdempotentMethods = set.Of(
+		"Initialize",
+		"SetState",
+		"Shutdown",
+		"BlockAccept",
+		"BlockReject",
+		"StateSummaryAccept",
+	)
+
+	DefaultCallTimeoutConfig = CallTimeoutConfig{
+		MethodTimeouts: map[string]time.Duration{
+			// The engine holds the chain's lock while a block is being built,
+			// so a BuildBlock call that never returns stalls the chain.
+			"BuildBlock": 30 * time.Second,
+		},
+	}
+)
+
+// CallTimeoutConfig configures the deadlines of the RPCs made to a VM plugin.
+//
+// A timeout only ever shortens the deadline of the context an RPC is made
+// with. Once the deadline passes, or the context is cancelled, the RPC is
+// cancelled in the plugin as well.
+type CallTimeoutConfig struct {
+	// DefaultTimeout bounds the RPCs whose method isn't in [MethodTimeouts].
+	// If 0, those RPCs are only bounded by their context. Non-idempotent RPCs,
+	// such as BlockAccept, are never bounded by the default timeout.
+	DefaultTimeout time.Duration `json:"defaultTimeout"`
+	// MethodTimeouts maps the name of a VM RPC, such as "BuildBlock", to the
+	// timeout of that RPC. A timeout of 0 means the RPC is only bounded by its
+	// context. Non-idempotent RPCs can't be given a timeout.
+	MethodTimeouts map[string]time.Duration `json:"methodTimeouts"`
+}
+
+func (c *CallTimeoutConfig) Verify() error {
+	if c.DefaultTimeout < 0 {
+		return fmt.Errorf("%w: default timeout is %s", ErrInvalidCallTimeout, c.DefaultTimeout)
+	}
+	for method, timeout := range c.MethodTimeouts {
+		if !isVMMethod(method) {
+			return fmt.Errorf("%w: %q", ErrUnknownVMMethod, method)
+		}
+		if timeout < 0 {
+			return fmt.Errorf("%w: %s timeout is %s", ErrInvalidCallTimeout, method, timeout)
+		}
+		if timeout != 0 && nonIdempotentMethods.Contains(method) {
+			return fmt.Errorf("%w: %s", ErrNonIdempotentCall, method)
+		}
+	}
+	return nil
+}
+
+func isVMMethod(method string) bool {
+	for _, desc := range vmpb.VM_ServiceDesc.Methods {
+		if desc.MethodName == method {
+			return true
+		}
+	}
+	return false
+}
+
+// deadlineConn applies the configured timeouts to the unary RPCs made to a VM
+// plugin and counts the RPCs that were cancelled or that timed out.
+type deadlineConn struct {
+	grpc.ClientConnInterface
+
+	defaultTimeout time.Duration
+	methodTimeouts map[string]time.Duration // full method name -> timeout
+
+	cancelled *prometheus.CounterVec
+	timedOut  *prometheus.CounterVec
+}
+
+func newDeadlineConn(conn grpc.ClientConnInterface, config CallTimeoutConfig) *deadlineConn {
+	methodTimeouts := make(map[string]time.Duration, len(config.MethodTimeouts)+nonIdempotentMethods.Len())
+	for method, timeout := range config.MethodTimeouts {
+		methodTimeouts[fullMethodName(method)] = timeout
+	}
+	// Non-idempotent RPCs are exempt from the default timeout.
+	for method := range nonIdempotentMethods {
+		methodTimeouts[fullMethodName(method)] = 0
+	}
+	return &deadlineConn{
+		ClientConnInterface: conn,
+		defaultTimeout:      config.DefaultTimeout,
+		methodTimeouts:      methodTimeouts,
+		cancelled: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "calls_cancelled",
+				Help: "number of RPCs that were cancelled before the plugin responded",
+			},
+			[]string{methodLabel},
+		),
+		timedOut: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "calls_timed_out",
+				Help: "number of RPCs whose deadline passed before the plugin responded",
+			},
+			[]string{methodLabel},
+		),
+	}
+}
+
+func (c *deadlineConn) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	timeout, ok := c.methodTimeouts[method]
+	if !ok {
+		timeout = c.defaultTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	switch status.Code(err) {
+	case codes.Canceled:
+		c.cancelled.With(methodLabels(method)).Inc()
+	case codes.DeadlineExceeded:
+		c.timedOut.With(methodLabels(method)).Inc()
+	}
+	return err
+}
+
+func (c *deadlineConn) Register(registerer prometheus.Registerer) error {
+	return utils.Err(
+		registerer.Register(c.cancelled),
+		registerer.Register(c.timedOut),
+	)
+}
+
+func fullMethodName(method string) string {
+	return fmt.Sprintf("/%s/%s", vmpb.VM_ServiceDesc.ServiceName, method)
+}
+
+// methodLabels labels metrics with the name of [fullMethod] without its
+// service prefix.
+func methodLabels(fullMethod string) prometheus.Labels {
+	return prometheus.Labels{
+		methodLabel: fullMethod[strings.LastIndex(fullMethod, "/")+1:],
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+func TestCallTimeoutConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      CallTimeoutConfig
+		expectedErr error
+	}{
+		{
+			name:        "default",
+			config:      DefaultCallTimeoutConfig,
+			expectedErr: nil,
+		},
+		{
+			name:        "no timeouts",
+			config:      CallTimeoutConfig{},
+			expectedErr: nil,
+		},
+		{
+			name: "negative default timeout",
+			config: CallTimeoutConfig{
+				DefaultTimeout: -time.Second,
+			},
+			expectedErr: ErrInvalidCallTimeout,
+		},
+		{
+			name: "negative method timeout",
+			config: CallTimeoutConfig{
+				MethodTimeouts: map[string]time.Duration{
+					"ParseBlock": -time.Second,
+				},
+			},
+			expectedErr: ErrInvalidCallTimeout,
+		},
+		{
+			name: "unknown method",
+			config: CallTimeoutConfig{
+				MethodTimeouts: map[string]time.Duration{
+					"NotAMethod": time.Second,
+				},
+			},
+			expectedErr: ErrUnknownVMMethod,
+		},
+		{
+			name: "non-idempotent method timeout",
+			config: CallTimeoutConfig{
+				MethodTimeouts: map[string]time.Duration{
+					"BlockAccept": time.Second,
+				},
+			},
+			expectedErr: ErrNonIdempotentCall,
+		},
+		{
+			name: "non-idempotent method without timeout",
+			config: CallTimeoutConfig{
+				MethodTimeouts: map[string]time.Duration{
+					"BlockAccept": 0,
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+// blockingConn blocks every RPC until its context is done.
+type blockingConn struct {
+	grpc.ClientConnInterface
+}
+
+func (blockingConn) Invoke(ctx context.Context, _ string, _ interface{}, _ interface{}, _ ...grpc.CallOption) error {
+	<-ctx.Done()
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+func TestDeadlineConn(t *testing.T) {
+	require := require.New(t)
+
+	conn := newDeadlineConn(blockingConn{}, CallTimeoutConfig{
+		DefaultTimeout: time.Millisecond,
+		MethodTimeouts: map[string]time.Duration{
+			"BuildBlock": time.Millisecond,
+			"ParseBlock": 0,
+		},
+	})
+
+	// The configured timeout is applied to RPCs without a deadline.
+	err := conn.Invoke(context.Background(), vmpb.VM_BuildBlock_FullMethodName, nil, nil)
+	require.Equal(codes.DeadlineExceeded, status.Code(err))
+	require.Equal(1.0, testutil.ToFloat64(conn.timedOut.With(methodLabels(vmpb.VM_BuildBlock_FullMethodName))))
+
+	// RPCs without a configured timeout are cancelled with their context.
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+	err = conn.Invoke(ctx, vmpb.VM_ParseBlock_FullMethodName, nil, nil)
+	require.Equal(codes.Canceled, status.Code(err))
+	require.Equal(1.0, testutil.ToFloat64(conn.cancelled.With(methodLabels(vmpb.VM_ParseBlock_FullMethodName))))
+	require.Zero(testutil.ToFloat64(conn.timedOut.With(methodLabels(vmpb.VM_ParseBlock_FullMethodName))))
+
+	// Non-idempotent RPCs aren't bounded by the default timeout.
+	ctx, cancel = context.WithCancel(context.Background())
+	go cancel()
+	err = conn.Invoke(ctx, vmpb.VM_BlockAccept_FullMethodName, nil, nil)
+	require.Equal(codes.Canceled, status.Code(err))
+	require.Zero(testutil.ToFloat64(conn.timedOut.With(methodLabels(vmpb.VM_BlockAccept_FullMethodName))))
+
+	// Other RPCs are bounded by the default timeout.
+	err = conn.Invoke(context.Background(), vmpb.VM_GetBlock_FullMethodName, nil, nil)
+	require.Equal(codes.DeadlineExceeded, status.Code(err))
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"google.golang.org/grpc"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+const (
+	defaultRoute   = "default"
+	blockRoute     = "block"
+	stateSyncRoute = "state_sync"
+	routeLabel     = "route"
+)
+
+var (
+	_ grpc.ClientConnInterface = (*connRouter)(nil)
+
+	ErrInvalidNumConns          = errors.New("number of connections must be positive")
+	ErrInvalidNumBlockConns     = errors.New("number of block connections must be non-negative")
+	ErrInvalidNumStateSyncConns = errors.New("number of state sync connections must be non-negative")
+
+	DefaultConnPoolConfig = ConnPoolConfig{
+		NumConns:          1,
+		NumBlockConns:     1,
+		NumStateSyncConns: 1,
+	}
+
+	// blockMethods are the RPCs that are on the critical path of consensus.
+	blockMethods = []string{
+		vmpb.VM_BuildBlock_FullMethodName,
+		vmpb.VM_ParseBlock_FullMethodName,
+		vmpb.VM_BatchedParseBlock_FullMethodName,
+		vmpb.VM_GetBlock_FullMethodName,
+		vmpb.VM_GetAncestors_FullMethodName,
+		vmpb.VM_SetPreference_FullMethodName,
+		vmpb.VM_BlockVerify_FullMethodName,
+		vmpb.VM_BlockAccept_FullMethodName,
+		vmpb.VM_BlockReject_FullMethodName,
+		vmpb.VM_GetBlockIDAtHeight_FullMethodName,
+	}
+	stateSyncMethods = []string{
+		vmpb.VM_StateSyncEnabled_FullMethodName,
+		vmpb.VM_GetOngoingSyncStateSummary_FullMethodName,
+		vmpb.VM_GetLastStateSummary_FullMethodName,
+		vmpb.VM_ParseStateSummary_FullMethodName,
+		vmpb.VM_GetStateSummary_FullMethodName,
+		vmpb.VM_StateSummaryAccept_FullMethodName,
+	}
+)
+
+// ConnPoolConfig configures the connections used to call into a VM plugin.
+//
+// Block and state sync RPCs can be given dedicated connections so that a
+// backlog of one kind of RPC doesn't delay the other.
+type ConnPoolConfig struct {
+	// NumConns is the number of connections used for RPCs that aren't routed
+	// to a dedicated pool.
+	NumConns int `json:"numConns"`
+	// NumBlockConns is the number of connections dedicated to block RPCs. If
+	// 0, block RPCs use the default connections.
+	NumBlockConns int `json:"numBlockConns"`
+	// NumStateSyncConns is the number of connections dedicated to state sync
+	// RPCs. If 0, state sync RPCs use the default connections.
+	NumStateSyncConns int `json:"numStateSyncConns"`
+}
+
+func (c *ConnPoolConfig) Verify() error {
+	switch {
+	case c.NumConns <= 0:
+		return ErrInvalidNumConns
+	case c.NumBlockConns < 0:
+		return ErrInvalidNumBlockConns
+	case c.NumStateSyncConns < 0:
+		return ErrInvalidNumStateSyncConns
+	default:
+		return nil
+	}
+}
+
+// connRouter routes each RPC to a pool of connections based on the RPC's
+// method name.
+type connRouter struct {
+	defaultPool *grpcutils.ConnPool
+	pools       []*grpcutils.ConnPool
+	routes      map[string]route // method -> route
+
+	inFlight     *prometheus.GaugeVec
+	calls        *prometheus.CounterVec
+	callDuration *prometheus.CounterVec
+}
+
+type route struct {
+	name string
+	pool *grpcutils.ConnPool
+}
+
+// newConnRouter returns a router that sends every RPC to [pool].
+func newConnRouter(pool *grpcutils.ConnPool) *connRouter {
+	return &connRouter{
+		defaultPool: pool,
+		pools:       []*grpcutils.ConnPool{pool},
+		routes:      make(map[string]route),
+		inFlight: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "conn_pool_in_flight",
+				Help: "number of RPCs currently being processed",
+			},
+			[]string{routeLabel},
+		),
+		calls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "conn_pool_calls",
+				Help: "number of RPCs made",
+			},
+			[]string{routeLabel},
+		),
+		callDuration: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "conn_pool_call_duration",
+				Help: "time spent on RPCs (in ns)",
+			},
+			[]string{routeLabel},
+		),
+	}
+}
+
+// dialConnRouter dials the connections described by [config] to [addr].
+func dialConnRouter(addr string, config ConnPoolConfig) (*connRouter, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	defaultPool, err := grpcutils.DialPool(addr, config.NumConns)
+	if err != nil {
+		return nil, err
+	}
+	r := newConnRouter(defaultPool)
+
+	dedicatedPools := []struct {
+		name     string
+		numConns int
+		methods  []string
+	}{
+		{
+			name:     blockRoute,
+			numConns: config.NumBlockConns,
+			methods:  blockMethods,
+		},
+		{
+			name:     stateSyncRoute,
+			numConns: config.NumStateSyncConns,
+			methods:  stateSyncMethods,
+		},
+	}
+	for _, dedicatedPool := range dedicatedPools {
+		if dedicatedPool.numConns == 0 {
+			continue
+		}
+
+		pool, err := grpcutils.DialPool(addr, dedicatedPool.numConns)
+		if err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+		r.addRoute(dedicatedPool.name, pool, dedicatedPool.methods...)
+	}
+	return r, nil
+}
+
+// addRoute sends the RPCs for [methods] to [pool]. The router takes ownership
+// of [pool].
+func (r *connRouter) addRoute(name string, pool *grpcutils.ConnPool, methods ...string) {
+	r.pools = append(r.pools, pool)
+	for _, method := range methods {
+		r.routes[method] = route{
+			name: name,
+			pool: pool,
+		}
+	}
+}
+
+func (r *connRouter) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	route := r.route(method)
+	defer r.track(route.name)()
+
+	return route.pool.Invoke(ctx, method, args, reply, opts...)
+}
+
+func (r *connRouter) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	route := r.route(method)
+	defer r.track(route.name)()
+
+	return route.pool.NewStream(ctx, desc, method, opts...)
+}
+
+func (r *connRouter) Register(registerer prometheus.Registerer) error {
+	return utils.Err(
+		registerer.Register(r.inFlight),
+		registerer.Register(r.calls),
+		registerer.Register(r.callDuration),
+	)
+}
+
+func (r *connRouter) Close() error {
+	errs := wrappers.Errs{}
+	for _, pool := range r.pools {
+		errs.Add(pool.Close())
+	}
+	return errs.Err
+}
+
+func (r *connRouter) route(method string) route {
+	if route, ok := r.routes[method]; ok {
+		return route
+	}
+	return route{
+		name: defaultRoute,
+		pool: r.defaultPool,
+	}
+}
+
+// track records the start of an RPC on [routeName] and returns the function
+// to call once the RPC has finished.
+func (r *connRouter) track(routeName string) func() {
+	var (
+		labels   = prometheus.Labels{routeLabel: routeName}
+		inFlight = r.inFlight.With(labels)
+		start    = time.Now()
+	)
+	inFlight.Inc()
+	return func() {
+		inFlight.Dec()
+		r.calls.With(labels).Inc()
+		r.callDuration.With(labels).Add(float64(time.Since(start)))
+	}
+}
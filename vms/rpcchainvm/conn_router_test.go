@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+func TestConnPoolConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      ConnPoolConfig
+		expectedErr error
+	}{
+		{
+			name:        "default",
+			config:      DefaultConnPoolConfig,
+			expectedErr: nil,
+		},
+		{
+			name: "no dedicated conns",
+			config: ConnPoolConfig{
+				NumConns: 1,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "no conns",
+			config: ConnPoolConfig{
+				NumBlockConns:     1,
+				NumStateSyncConns: 1,
+			},
+			expectedErr: ErrInvalidNumConns,
+		},
+		{
+			name: "negative block conns",
+			config: ConnPoolConfig{
+				NumConns:      1,
+				NumBlockConns: -1,
+			},
+			expectedErr: ErrInvalidNumBlockConns,
+		},
+		{
+			name: "negative state sync conns",
+			config: ConnPoolConfig{
+				NumConns:          1,
+				NumStateSyncConns: -1,
+			},
+			expectedErr: ErrInvalidNumStateSyncConns,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestConnRouterRoutes(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	defer listener.Close()
+
+	router, err := dialConnRouter(
+		listener.Addr().String(),
+		ConnPoolConfig{
+			NumConns:      2,
+			NumBlockConns: 1,
+		},
+	)
+	require.NoError(err)
+	defer router.Close()
+
+	// Without dedicated state sync connections, state sync RPCs share the
+	// default pool.
+	require.Len(router.pools, 2)
+
+	blkRoute := router.route(vmpb.VM_BuildBlock_FullMethodName)
+	require.Equal(blkRoute, router.route(vmpb.VM_BlockAccept_FullMethodName))
+	require.NotEqual(router.defaultPool, blkRoute.pool)
+	require.Equal(1, blkRoute.pool.Len())
+
+	otherRoute := router.route(vmpb.VM_StateSyncEnabled_FullMethodName)
+	require.Equal(router.defaultPool, otherRoute.pool)
+	require.Equal(2, otherRoute.pool.Len())
+	require.Equal(otherRoute, router.route(vmpb.VM_Health_FullMethodName))
+}
@@ -4,6 +4,21 @@
 package rpcchainvm
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/exp/slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 
@@ -23,6 +38,15 @@ var (
 		block.ErrIndexIncomplete:               vmpb.Error_ERROR_HEIGHT_INDEX_INCOMPLETE,
 		block.ErrStateSyncableVMNotImplemented: vmpb.Error_ERROR_STATE_SYNC_NOT_IMPLEMENTED,
 	}
+
+	errEmptyErrorName     = errors.New("empty error name")
+	errNilRegisteredError = errors.New("nil registered error")
+	errDuplicateErrorName = errors.New("duplicate error name")
+
+	registeredErrorsLock sync.RWMutex
+	// Key: name of the error
+	// Value: registered error
+	registeredErrors = make(map[string]error)
 )
 
 func errorToRPCError(err error) error {
@@ -31,3 +55,161 @@ func errorToRPCError(err error) error {
 	}
 	return err
 }
+
+// RegisterError allows [err] to be recovered, with errors.Is, from the errors
+// returned by a VM over gRPC. [name] must uniquely identify [err] and the error
+// must be registered with the same name by both the VM and the node.
+//
+// Errors are typically registered when initializing the package that defines
+// them.
+func RegisterError(name string, err error) error {
+	switch {
+	case name == "":
+		return errEmptyErrorName
+	case err == nil:
+		return fmt.Errorf("%w: %q", errNilRegisteredError, name)
+	}
+
+	registeredErrorsLock.Lock()
+	defer registeredErrorsLock.Unlock()
+
+	if _, ok := registeredErrors[name]; ok {
+		return fmt.Errorf("%w: %q", errDuplicateErrorName, name)
+	}
+	registeredErrors[name] = err
+	return nil
+}
+
+// errorDetails returns the details that allow the errors in the tree of [err]
+// to be recovered by the client. Returns false if [err] wasn't caused by a known
+// error.
+func errorDetails(err error) (*vmpb.ErrorDetails, bool) {
+	details := &vmpb.ErrorDetails{}
+	for knownErr, code := range errorToErrEnum {
+		if errors.Is(err, knownErr) {
+			details.Code = code
+			break
+		}
+	}
+
+	registeredErrorsLock.RLock()
+	for name, registeredErr := range registeredErrors {
+		if errors.Is(err, registeredErr) {
+			details.Names = append(details.Names, name)
+		}
+	}
+	registeredErrorsLock.RUnlock()
+
+	slices.Sort(details.Names)
+	return details, details.Code != vmpb.Error_ERROR_UNSPECIFIED || len(details.Names) > 0
+}
+
+// errorToStatusError attaches the details of [err] to the gRPC status returned
+// to the client. If [err] is already a gRPC status error, or isn't caused by a
+// known error, [err] is returned.
+func errorToStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	details, ok := errorDetails(err)
+	if !ok {
+		return err
+	}
+	detailsAny, err2 := anypb.New(details)
+	if err2 != nil {
+		return err
+	}
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(codes.Unknown),
+		Message: err.Error(),
+		Details: []*anypb.Any{detailsAny},
+	})
+}
+
+// statusErrorToError recovers the known errors that caused the gRPC status
+// error [err]. If [err] doesn't provide error details, [err] is returned.
+func statusErrorToError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, detailsAny := range s.Proto().Details {
+		var details vmpb.ErrorDetails
+		if err := anypb.UnmarshalTo(detailsAny, &details, proto.UnmarshalOptions{}); err != nil {
+			continue
+		}
+
+		rpcErr := &rpcError{
+			status: s,
+		}
+		if knownErr, ok := errEnumToError[details.Code]; ok {
+			rpcErr.errs = append(rpcErr.errs, knownErr)
+		}
+
+		registeredErrorsLock.RLock()
+		for _, name := range details.Names {
+			if registeredErr, ok := registeredErrors[name]; ok {
+				rpcErr.errs = append(rpcErr.errs, registeredErr)
+			}
+		}
+		registeredErrorsLock.RUnlock()
+		return rpcErr
+	}
+	return err
+}
+
+// rpcError is an error returned by a VM over gRPC whose known causes were
+// recovered.
+type rpcError struct {
+	status *status.Status
+	errs   []error
+}
+
+func (e *rpcError) Error() string {
+	return e.status.Message()
+}
+
+func (e *rpcError) Unwrap() []error {
+	return e.errs
+}
+
+// GRPCStatus allows the status of the failed RPC to still be inspected with
+// status.FromError.
+func (e *rpcError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// errorDetailsUnaryServerInterceptor attaches error details to the errors
+// returned by the VM service.
+func errorDetailsUnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if _, ok := info.Server.(*VMServer); !ok {
+		return resp, err
+	}
+	return resp, errorToStatusError(err)
+}
+
+var _ grpc.ClientConnInterface = (*errorDetailsConn)(nil)
+
+// errorDetailsConn recovers the known errors that caused failed RPCs.
+type errorDetailsConn struct {
+	grpc.ClientConnInterface
+}
+
+func (c errorDetailsConn) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	return statusErrorToError(c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...))
+}
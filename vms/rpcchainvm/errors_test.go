@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	errTestRegistered      = errors.New("test registered error")
+	errTestOtherRegistered = errors.New("other test registered error")
+	errTestUnregistered    = errors.New("test unregistered error")
+)
+
+func init() {
+	if err := RegisterError("rpcchainvm.errTestRegistered", errTestRegistered); err != nil {
+		panic(err)
+	}
+	if err := RegisterError("rpcchainvm.errTestOtherRegistered", errTestOtherRegistered); err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisterError(t *testing.T) {
+	require := require.New(t)
+
+	err := RegisterError("", errTestUnregistered)
+	require.ErrorIs(err, errEmptyErrorName)
+
+	err = RegisterError("rpcchainvm.errTestUnregistered", nil)
+	require.ErrorIs(err, errNilRegisteredError)
+
+	err = RegisterError("rpcchainvm.errTestRegistered", errTestUnregistered)
+	require.ErrorIs(err, errDuplicateErrorName)
+}
+
+func TestStatusErrorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedErrs   []error
+		unexpectedErrs []error
+	}{
+		{
+			name:         "enum error",
+			err:          fmt.Errorf("failed to get block: %w", database.ErrNotFound),
+			expectedErrs: []error{database.ErrNotFound},
+			unexpectedErrs: []error{
+				database.ErrClosed,
+				errTestRegistered,
+			},
+		},
+		{
+			name:         "registered error",
+			err:          fmt.Errorf("failed to verify block: %w", errTestRegistered),
+			expectedErrs: []error{errTestRegistered},
+			unexpectedErrs: []error{
+				database.ErrNotFound,
+				errTestOtherRegistered,
+			},
+		},
+		{
+			name: "multiple errors",
+			err:  fmt.Errorf("%w: %w: %w", errTestRegistered, errTestOtherRegistered, database.ErrClosed),
+			expectedErrs: []error{
+				errTestRegistered,
+				errTestOtherRegistered,
+				database.ErrClosed,
+			},
+			unexpectedErrs: []error{database.ErrNotFound},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			// Errors sent over gRPC are flattened into a status
+			s, _ := status.FromError(errorToStatusError(test.err))
+			require.Equal(test.err.Error(), s.Message())
+
+			err := statusErrorToError(s.Err())
+			require.Equal(test.err.Error(), err.Error())
+			for _, expectedErr := range test.expectedErrs {
+				require.ErrorIs(err, expectedErr)
+			}
+			for _, unexpectedErr := range test.unexpectedErrs {
+				require.NotErrorIs(err, unexpectedErr)
+			}
+
+			// The status of the failed RPC is still available
+			s, ok := status.FromError(err)
+			require.True(ok)
+			require.Equal(codes.Unknown, s.Code())
+		})
+	}
+}
+
+func TestStatusErrorUnknownError(t *testing.T) {
+	require := require.New(t)
+
+	// Errors that weren't caused by a known error are only described by their
+	// message
+	unknownErr := fmt.Errorf("failed to build block: %w", errTestUnregistered)
+	s, _ := status.FromError(errorToStatusError(unknownErr))
+	require.Empty(s.Details())
+
+	err := statusErrorToError(s.Err())
+	require.NotErrorIs(err, errTestUnregistered)
+	require.Equal(s.Err(), err)
+}
+
+func TestErrorToStatusErrorPreservesStatus(t *testing.T) {
+	require := require.New(t)
+
+	statusErr := status.Error(codes.Unavailable, database.ErrClosed.Error())
+	require.Equal(statusErr, errorToStatusError(statusErr))
+	require.Equal(statusErr, statusErrorToError(statusErr))
+}
@@ -21,13 +21,26 @@ type factory struct {
 	path           string
 	processTracker resource.ProcessTracker
 	runtimeTracker runtime.Tracker
+	connPoolConfig ConnPoolConfig
+	callTimeouts   CallTimeoutConfig
+	sandbox        subprocess.SandboxConfig
 }
 
-func NewFactory(path string, processTracker resource.ProcessTracker, runtimeTracker runtime.Tracker) vms.Factory {
+func NewFactory(
+	path string,
+	processTracker resource.ProcessTracker,
+	runtimeTracker runtime.Tracker,
+	connPoolConfig ConnPoolConfig,
+	callTimeouts CallTimeoutConfig,
+	sandbox subprocess.SandboxConfig,
+) vms.Factory {
 	return &factory{
 		path:           path,
 		processTracker: processTracker,
 		runtimeTracker: runtimeTracker,
+		connPoolConfig: connPoolConfig,
+		callTimeouts:   callTimeouts,
+		sandbox:        sandbox,
 	}
 }
 
@@ -36,6 +49,7 @@ func (f *factory) New(log logging.Logger) (interface{}, error) {
 		Stderr:           log,
 		Stdout:           log,
 		HandshakeTimeout: runtime.DefaultHandshakeTimeout,
+		Sandbox:          f.sandbox,
 		Log:              log,
 	}
 
@@ -54,12 +68,12 @@ func (f *factory) New(log logging.Logger) (interface{}, error) {
 		return nil, err
 	}
 
-	clientConn, err := grpcutils.Dial(status.Addr)
+	router, err := dialConnRouter(status.Addr, f.connPoolConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	vm := NewClient(clientConn)
+	vm := newClient(router, f.callTimeouts)
 	vm.SetProcess(stopper, status.Pid, f.processTracker)
 
 	f.runtimeTracker.TrackRuntime(stopper)
@@ -53,6 +53,10 @@ var DefaultDialOptions = []grpc.DialOption{
 		PermitWithoutStream: defaultPermitWithoutStream,
 	}),
 	grpc.WithTransportCredentials(insecure.NewCredentials()),
+	// Propagate the span context of requests so that spans started across
+	// the plugin boundary are part of the same trace.
+	grpc.WithChainUnaryInterceptor(traceUnaryClientInterceptor),
+	grpc.WithChainStreamInterceptor(traceStreamClientInterceptor),
 }
 
 // gRPC clients created from this ClientConn will wait forever for the Server to
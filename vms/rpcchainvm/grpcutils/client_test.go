@@ -28,13 +28,13 @@ func TestDialOptsSmoke(t *testing.T) {
 	require := require.New(t)
 
 	opts := newDialOpts()
-	require.Len(opts, 3)
+	require.Len(opts, 5)
 
 	opts = newDialOpts(
 		WithChainUnaryInterceptor(grpc_prometheus.UnaryClientInterceptor),
 		WithChainStreamInterceptor(grpc_prometheus.StreamClientInterceptor),
 	)
-	require.Len(opts, 5)
+	require.Len(opts, 7)
 }
 
 // Test_WaitForReady shows the expected results from the DialOption during
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcutils
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	_ grpc.ClientConnInterface = (*ConnPool)(nil)
+
+	ErrEmptyConnPool = errors.New("conn pool must contain at least one connection")
+)
+
+// ConnPool is a gRPC client connection that spreads RPCs across a fixed set of
+// underlying connections in a round-robin fashion. Each underlying connection
+// has its own transport, so a slow or large RPC only delays the RPCs that were
+// assigned to the same connection.
+type ConnPool struct {
+	conns []*grpc.ClientConn
+	next  atomic.Uint64
+}
+
+// NewConnPool returns a pool of the provided connections. The pool takes
+// ownership of [conns].
+func NewConnPool(conns ...*grpc.ClientConn) (*ConnPool, error) {
+	if len(conns) == 0 {
+		return nil, ErrEmptyConnPool
+	}
+	return &ConnPool{
+		conns: conns,
+	}, nil
+}
+
+// DialPool dials [size] connections to [addr] and returns them as a pool.
+func DialPool(addr string, size int, opts ...DialOption) (*ConnPool, error) {
+	if size <= 0 {
+		return nil, ErrEmptyConnPool
+	}
+
+	conns := make([]*grpc.ClientConn, 0, size)
+	for i := 0; i < size; i++ {
+		conn, err := Dial(addr, opts...)
+		if err != nil {
+			for _, conn := range conns {
+				_ = conn.Close()
+			}
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+	return NewConnPool(conns...)
+}
+
+func (p *ConnPool) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	return p.pick().Invoke(ctx, method, args, reply, opts...)
+}
+
+func (p *ConnPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return p.pick().NewStream(ctx, desc, method, opts...)
+}
+
+// Len returns the number of connections in the pool.
+func (p *ConnPool) Len() int {
+	return len(p.conns)
+}
+
+// Close closes all the connections in the pool.
+func (p *ConnPool) Close() error {
+	errs := wrappers.Errs{}
+	for _, conn := range p.conns {
+		errs.Add(conn.Close())
+	}
+	return errs.Err
+}
+
+func (p *ConnPool) pick() *grpc.ClientConn {
+	index := (p.next.Add(1) - 1) % uint64(len(p.conns))
+	return p.conns[index]
+}
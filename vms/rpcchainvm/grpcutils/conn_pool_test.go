@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcutils
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/rpcdb"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
+)
+
+func TestNewConnPoolEmpty(t *testing.T) {
+	_, err := NewConnPool()
+	require.ErrorIs(t, err, ErrEmptyConnPool)
+}
+
+func TestDialPoolEmpty(t *testing.T) {
+	_, err := DialPool("127.0.0.1:0", 0)
+	require.ErrorIs(t, err, ErrEmptyConnPool)
+}
+
+func TestConnPoolRoundRobin(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := NewListener()
+	require.NoError(err)
+	defer listener.Close()
+
+	conns := make([]*grpc.ClientConn, 3)
+	for i := range conns {
+		conns[i], err = Dial(listener.Addr().String())
+		require.NoError(err)
+	}
+
+	pool, err := NewConnPool(conns...)
+	require.NoError(err)
+	defer pool.Close()
+
+	require.Equal(len(conns), pool.Len())
+	for i := 0; i < 2*len(conns); i++ {
+		require.Equal(conns[i%len(conns)], pool.pick())
+	}
+}
+
+func TestConnPoolInvoke(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := NewListener()
+	require.NoError(err)
+
+	server := NewServer()
+	defer server.Stop()
+	pb.RegisterDatabaseServer(server, rpcdb.NewServer(memdb.New()))
+	go Serve(listener, server)
+
+	pool, err := DialPool(listener.Addr().String(), 2)
+	require.NoError(err)
+	defer pool.Close()
+
+	// Writes made over one connection must be visible over the others.
+	db := rpcdb.NewClient(pb.NewDatabaseClient(pool))
+	require.NoError(db.Put([]byte("foo"), []byte("bar")))
+	for i := 0; i < pool.Len(); i++ {
+		value, err := db.Get([]byte("foo"))
+		require.NoError(err)
+		require.Equal([]byte("bar"), value)
+	}
+}
@@ -39,6 +39,11 @@ var DefaultServerOptions = []grpc.ServerOption{
 		Time:    defaultServerKeepAliveInterval,
 		Timeout: defaultServerKeepAliveTimeout,
 	}),
+	// Continue the trace of requests whose span context was propagated by
+	// the client. Interceptors provided with WithUnaryInterceptor and
+	// WithStreamInterceptor are run before these.
+	grpc.ChainUnaryInterceptor(traceUnaryServerInterceptor),
+	grpc.ChainStreamInterceptor(traceStreamServerInterceptor),
 }
 
 // NewServer will return a gRPC server with server options as defined by
@@ -78,6 +83,16 @@ func WithUnaryInterceptor(unaryInterceptor grpc.UnaryServerInterceptor) ServerOp
 	}
 }
 
+// WithChainUnaryServerInterceptor adds unary interceptors to the gRPC server
+// options. Unlike WithUnaryInterceptor, providing it multiple times doesn't
+// replace the previously provided interceptors. The chained interceptors are
+// run after the interceptor provided with WithUnaryInterceptor.
+func WithChainUnaryServerInterceptor(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(s *ServerOptions) {
+		s.opts = append(s.opts, grpc.ChainUnaryInterceptor(interceptors...))
+	}
+}
+
 // WithStreamInterceptor adds a single stream interceptor to the gRPC server
 // options.
 func WithStreamInterceptor(streamInterceptor grpc.StreamServerInterceptor) ServerOption {
@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcutils
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+var (
+	_ propagation.TextMapCarrier = metadataCarrier(nil)
+	_ grpc.ServerStream          = (*tracedServerStream)(nil)
+
+	// traceContext propagates the span context of a request in the W3C Trace
+	// Context format, allowing spans started by the receiver of a request to
+	// be children of the sender's span.
+	traceContext = propagation.TraceContext{}
+)
+
+// metadataCarrier allows gRPC metadata to carry a span context.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// injectTraceContext adds the span context of [ctx], if any, to the outgoing
+// metadata of [ctx].
+func injectTraceContext(ctx context.Context) context.Context {
+	if !oteltrace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	traceContext.Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// extractTraceContext returns [ctx] with the span context provided in the
+// incoming metadata of [ctx], if any, as the remote parent span.
+func extractTraceContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return traceContext.Extract(ctx, metadataCarrier(md))
+}
+
+func traceUnaryClientInterceptor(
+	ctx context.Context,
+	method string,
+	req interface{},
+	reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	return invoker(injectTraceContext(ctx), method, req, reply, cc, opts...)
+}
+
+func traceStreamClientInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return streamer(injectTraceContext(ctx), desc, cc, method, opts...)
+}
+
+func traceUnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	return handler(extractTraceContext(ctx), req)
+}
+
+func traceStreamServerInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, &tracedServerStream{
+		ServerStream: stream,
+		ctx:          extractTraceContext(stream.Context()),
+	})
+}
+
+// tracedServerStream overrides the context of a stream to include the span
+// context provided by the client.
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
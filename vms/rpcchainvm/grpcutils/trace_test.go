@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcutils
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/stretchr/testify/require"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceContextPropagation(t *testing.T) {
+	require := require.New(t)
+
+	spanContext := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1},
+		SpanID:     oteltrace.SpanID{2},
+		TraceFlags: oteltrace.FlagsSampled,
+	})
+	ctx := oteltrace.ContextWithSpanContext(context.Background(), spanContext)
+	ctx = metadata.AppendToOutgoingContext(ctx, "key", "value")
+
+	// Capture the metadata sent by the client.
+	var sentMD metadata.MD
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		var ok bool
+		sentMD, ok = metadata.FromOutgoingContext(ctx)
+		require.True(ok)
+		return nil
+	}
+	require.NoError(traceUnaryClientInterceptor(ctx, "method", nil, nil, nil, invoker))
+	require.Equal([]string{"value"}, sentMD.Get("key"))
+
+	// Provide the sent metadata to the server.
+	var receivedSpanContext oteltrace.SpanContext
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		receivedSpanContext = oteltrace.SpanContextFromContext(ctx)
+		return nil, nil
+	}
+	serverCtx := metadata.NewIncomingContext(context.Background(), sentMD)
+	_, err := traceUnaryServerInterceptor(serverCtx, nil, nil, handler)
+	require.NoError(err)
+
+	require.True(receivedSpanContext.IsRemote())
+	require.Equal(spanContext.TraceID(), receivedSpanContext.TraceID())
+	require.Equal(spanContext.SpanID(), receivedSpanContext.SpanID())
+	require.True(receivedSpanContext.IsSampled())
+}
+
+func TestTraceContextNotPropagatedWithoutSpan(t *testing.T) {
+	require := require.New(t)
+
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		_, ok := metadata.FromOutgoingContext(ctx)
+		require.False(ok)
+		return nil
+	}
+	require.NoError(traceUnaryClientInterceptor(context.Background(), "method", nil, nil, nil, invoker))
+}
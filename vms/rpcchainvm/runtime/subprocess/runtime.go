@@ -30,7 +30,9 @@ type Config struct {
 	Stdout io.Writer
 	// Duration engine server will wait for handshake success.
 	HandshakeTimeout time.Duration
-	Log              logging.Logger
+	// Resource limits enforced on the VM process.
+	Sandbox SandboxConfig
+	Log     logging.Logger
 }
 
 type Status struct {
@@ -63,6 +65,9 @@ func Bootstrap(
 	case config.Stderr == nil, config.Stdout == nil:
 		return nil, nil, fmt.Errorf("%w: stderr and stdout required", runtime.ErrInvalidConfig)
 	}
+	if err := config.Sandbox.Verify(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", runtime.ErrInvalidConfig, err)
+	}
 
 	intitializer := newInitializer()
 
@@ -90,13 +95,24 @@ func Bootstrap(
 		return nil, nil, fmt.Errorf("failed to create stderr pipe: %w", err)
 	}
 
+	sandbox, err := newSandbox(&config.Sandbox, cmd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create sandbox: %w", err)
+	}
+
 	// start subproccess
 	if err := cmd.Start(); err != nil {
+		_ = sandbox.Close()
 		return nil, nil, fmt.Errorf("failed to start process: %w", err)
 	}
 
 	log := config.Log
-	stopper := NewStopper(log, cmd)
+	stopper := newStopper(log, cmd, sandbox)
+
+	if err := sandbox.started(cmd.Process.Pid); err != nil {
+		stopper.Stop(ctx)
+		return nil, nil, fmt.Errorf("failed to sandbox process: %w", err)
+	}
 
 	// start stdout collector
 	go func() {
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subprocess
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	minCPUShares = 2
+	maxCPUShares = 262144
+)
+
+var (
+	ErrInvalidCPUShares   = errors.New("invalid cpu shares")
+	ErrCgroupDirRequired  = errors.New("cgroup dir required")
+	ErrSandboxUnsupported = errors.New("sandboxing isn't supported on this platform")
+)
+
+// SandboxConfig limits the resources available to a VM process, so that a VM
+// exhausting them can't starve the rest of the node.
+//
+// The zero value doesn't limit the process.
+//
+// Restricting the syscalls or the network access of the process isn't
+// supported. The VM and the node talk gRPC over loopback TCP in both
+// directions, so the process can't be placed in its own network namespace, and
+// os/exec can't install a seccomp filter between fork and exec.
+type SandboxConfig struct {
+	// MaxMemory is the maximum number of bytes of memory of the process. If
+	// [CgroupDir] is set, the memory usage of the process' cgroup is limited
+	// before the process starts. Otherwise, the address space of the process
+	// is limited right after it starts, so memory the process allocates before
+	// then isn't limited. If 0, memory isn't limited.
+	MaxMemory uint64 `json:"maxMemory"`
+	// CPUShares is the CPU weight of the process relative to the other
+	// processes under [CgroupDir], on the scale of cgroup v1 shares where 1024
+	// is the default weight. If 0, the default weight is used.
+	CPUShares uint64 `json:"cpuShares"`
+	// MaxOpenFiles is the maximum number of file descriptors the process can
+	// have open. The limit is applied right after the process starts, so
+	// files the process opens before then aren't limited. If 0, the limit is
+	// inherited from the node.
+	MaxOpenFiles uint64 `json:"maxOpenFiles"`
+	// CgroupDir is a cgroup v2 directory delegated to the node, under which a
+	// cgroup is created for every VM process. The directory must not contain
+	// any processes itself. If empty, VM processes aren't placed in a cgroup.
+	CgroupDir string `json:"cgroupDir"`
+}
+
+func (c *SandboxConfig) Verify() error {
+	if c.CPUShares != 0 {
+		if c.CgroupDir == "" {
+			return fmt.Errorf("%w: to limit cpu shares", ErrCgroupDirRequired)
+		}
+		if c.CPUShares < minCPUShares || c.CPUShares > maxCPUShares {
+			return fmt.Errorf("%w: %d isn't in [%d, %d]", ErrInvalidCPUShares, c.CPUShares, minCPUShares, maxCPUShares)
+		}
+	}
+	if !sandboxSupported && c.isSandboxed() {
+		return ErrSandboxUnsupported
+	}
+	return nil
+}
+
+// isSandboxed returns true if the process is limited in any way.
+func (c *SandboxConfig) isSandboxed() bool {
+	return c.MaxMemory != 0 || c.CPUShares != 0 || c.MaxOpenFiles != 0 || c.CgroupDir != ""
+}
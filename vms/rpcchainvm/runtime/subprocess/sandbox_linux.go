@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build linux
+// +build linux
+
+package subprocess
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const sandboxSupported = true
+
+// sandbox enforces a SandboxConfig on a VM process.
+type sandbox struct {
+	config *SandboxConfig
+	// cgroupDir is the cgroup of the process. Empty if the process isn't
+	// placed in a cgroup.
+	cgroupDir string
+	// cgroup is open until the process is started in [cgroupDir].
+	cgroup *os.File
+}
+
+// newSandbox prepares [cmd] to be started in the cgroup described by
+// [config], if any.
+func newSandbox(config *SandboxConfig, cmd *exec.Cmd) (*sandbox, error) {
+	s := &sandbox{
+		config: config,
+	}
+	if config.CgroupDir == "" {
+		return s, nil
+	}
+
+	// The controllers must be enabled in the parent cgroup for their interface
+	// files to exist in the VM's cgroup.
+	if err := enableControllers(config); err != nil {
+		return nil, err
+	}
+
+	cgroupDir, err := os.MkdirTemp(config.CgroupDir, "vm-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cgroup: %w", err)
+	}
+	s.cgroupDir = cgroupDir
+
+	if config.MaxMemory != 0 {
+		if err := writeCgroupFile(cgroupDir, "memory.max", config.MaxMemory); err != nil {
+			_ = s.Close()
+			return nil, err
+		}
+	}
+	if config.CPUShares != 0 {
+		if err := writeCgroupFile(cgroupDir, "cpu.weight", cpuWeight(config.CPUShares)); err != nil {
+			_ = s.Close()
+			return nil, err
+		}
+	}
+
+	s.cgroup, err = os.Open(cgroupDir)
+	if err != nil {
+		_ = s.Close()
+		return nil, fmt.Errorf("failed to open cgroup: %w", err)
+	}
+
+	// Starting the process directly in the cgroup ensures it is never running
+	// without its limits.
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(s.cgroup.Fd())
+	return s, nil
+}
+
+// started applies the limits of the process that can only be set once the
+// process exists.
+//
+// os/exec doesn't allow setting the rlimits of the child between fork and
+// exec, so they are applied right after the process is started. Until then,
+// the process runs with the rlimits of the node, so code the VM runs at
+// startup may briefly exceed them. Setting [SandboxConfig.CgroupDir] limits
+// memory from the first instruction of the process.
+func (s *sandbox) started(pid int) error {
+	if s.cgroup != nil {
+		if err := s.cgroup.Close(); err != nil {
+			return fmt.Errorf("failed to close cgroup: %w", err)
+		}
+		s.cgroup = nil
+	}
+
+	if s.config.MaxOpenFiles != 0 {
+		if err := setRlimit(pid, unix.RLIMIT_NOFILE, s.config.MaxOpenFiles); err != nil {
+			return fmt.Errorf("failed to limit open files: %w", err)
+		}
+	}
+	if s.config.MaxMemory != 0 && s.cgroupDir == "" {
+		if err := setRlimit(pid, unix.RLIMIT_AS, s.config.MaxMemory); err != nil {
+			return fmt.Errorf("failed to limit memory: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close removes the cgroup of the process. The cgroup can only be removed once
+// the process exited.
+func (s *sandbox) Close() error {
+	errs := wrappers.Errs{}
+	if s.cgroup != nil {
+		errs.Add(s.cgroup.Close())
+		s.cgroup = nil
+	}
+	if s.cgroupDir != "" {
+		errs.Add(os.Remove(s.cgroupDir))
+		s.cgroupDir = ""
+	}
+	return errs.Err
+}
+
+func enableControllers(config *SandboxConfig) error {
+	var controllers []string
+	if config.MaxMemory != 0 {
+		controllers = append(controllers, "+memory")
+	}
+	if config.CPUShares != 0 {
+		controllers = append(controllers, "+cpu")
+	}
+	for _, controller := range controllers {
+		path := filepath.Join(config.CgroupDir, "cgroup.subtree_control")
+		if err := os.WriteFile(path, []byte(controller), 0); err != nil {
+			return fmt.Errorf("failed to enable %q controller in %q: %w", controller, config.CgroupDir, err)
+		}
+	}
+	return nil
+}
+
+func writeCgroupFile(cgroupDir, name string, value uint64) error {
+	path := filepath.Join(cgroupDir, name)
+	if err := os.WriteFile(path, []byte(strconv.FormatUint(value, 10)), 0); err != nil {
+		return fmt.Errorf("failed to write %q: %w", path, err)
+	}
+	return nil
+}
+
+// cpuWeight converts cgroup v1 cpu shares, in [2, 262144], to a cgroup v2 cpu
+// weight, in [1, 10000].
+func cpuWeight(shares uint64) uint64 {
+	return 1 + ((shares-minCPUShares)*9999)/(maxCPUShares-minCPUShares)
+}
+
+func setRlimit(pid int, resource int, limit uint64) error {
+	rlimit := unix.Rlimit{
+		Cur: limit,
+		Max: limit,
+	}
+	return unix.Prlimit(pid, resource, &rlimit, nil)
+}
@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !linux
+// +build !linux
+
+package subprocess
+
+import "os/exec"
+
+const sandboxSupported = false
+
+// sandbox is a noop, as SandboxConfig.Verify rejects any limits on this
+// platform.
+type sandbox struct{}
+
+func newSandbox(*SandboxConfig, *exec.Cmd) (*sandbox, error) {
+	return &sandbox{}, nil
+}
+
+func (*sandbox) started(int) error {
+	return nil
+}
+
+func (*sandbox) Close() error {
+	return nil
+}
@@ -8,25 +8,43 @@ import (
 	"os/exec"
 	"sync"
 
+	"go.uber.org/zap"
+
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 )
 
 func NewStopper(logger logging.Logger, cmd *exec.Cmd) runtime.Stopper {
+	return newStopper(logger, cmd, nil)
+}
+
+// newStopper returns a stopper that releases [sandbox], if non-nil, once the
+// process is stopped.
+func newStopper(logger logging.Logger, cmd *exec.Cmd, sandbox *sandbox) *stopper {
 	return &stopper{
-		cmd:    cmd,
-		logger: logger,
+		cmd:     cmd,
+		sandbox: sandbox,
+		logger:  logger,
 	}
 }
 
 type stopper struct {
-	once   sync.Once
-	cmd    *exec.Cmd
-	logger logging.Logger
+	once    sync.Once
+	cmd     *exec.Cmd
+	sandbox *sandbox
+	logger  logging.Logger
 }
 
 func (s *stopper) Stop(ctx context.Context) {
 	s.once.Do(func() {
 		stop(ctx, s.logger, s.cmd)
+		if s.sandbox == nil {
+			return
+		}
+		if err := s.sandbox.Close(); err != nil {
+			s.logger.Warn("failed to release subprocess sandbox",
+				zap.Error(err),
+			)
+		}
 	})
 }
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build linux
+// +build linux
+
+package rpcchainvm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
+)
+
+func TestRuntimeSubprocessSandboxRlimits(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	vm := mocks.NewMockChainVM(ctrl)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+
+	require.NoError(os.Setenv(runtime.EngineAddressKey, listener.Addr().String()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = Serve(ctx, vm)
+	}()
+
+	const (
+		maxOpenFiles = 512
+		maxMemory    = 64 * units.GiB
+	)
+	status, stopper, err := subprocess.Bootstrap(
+		context.Background(),
+		listener,
+		helperProcess("dummy"),
+		&subprocess.Config{
+			Stderr:           logging.NoLog{},
+			Stdout:           logging.NoLog{},
+			Log:              logging.NoLog{},
+			HandshakeTimeout: runtime.DefaultHandshakeTimeout,
+			Sandbox: subprocess.SandboxConfig{
+				MaxMemory:    maxMemory,
+				MaxOpenFiles: maxOpenFiles,
+			},
+		},
+	)
+	require.NoError(err)
+	defer stopper.Stop(ctx)
+
+	limits, err := os.ReadFile(fmt.Sprintf("/proc/%d/limits", status.Pid))
+	require.NoError(err)
+
+	requireLimit(require, string(limits), "Max open files", maxOpenFiles)
+	requireLimit(require, string(limits), "Max address space", maxMemory)
+}
+
+// requireLimit asserts that both the soft and hard limits of [name] in the
+// contents of a /proc/<pid>/limits file are [expected].
+func requireLimit(require *require.Assertions, limits string, name string, expected uint64) {
+	for _, line := range strings.Split(limits, "\n") {
+		if !strings.HasPrefix(line, name) {
+			continue
+		}
+
+		fields := strings.Fields(strings.TrimPrefix(line, name))
+		require.GreaterOrEqual(len(fields), 2)
+		expectedStr := strconv.FormatUint(expected, 10)
+		require.Equal(expectedStr, fields[0])
+		require.Equal(expectedStr, fields[1])
+		return
+	}
+	require.FailNow("missing limit", name)
+}
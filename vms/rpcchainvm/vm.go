@@ -18,11 +18,13 @@ import (
 
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/profiler/gprofiler"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gruntime"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 
+	profilerpb "github.com/ava-labs/avalanchego/proto/pb/profiler"
 	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
 	runtimepb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
 )
@@ -103,10 +105,12 @@ func Serve(ctx context.Context, vm block.ChainVM, opts ...grpcutils.ServerOption
 	return nil
 }
 
-// Returns an RPC Chain VM server serving health and VM services.
+// Returns an RPC Chain VM server serving health, profiler, and VM services.
 func newVMServer(vm block.ChainVM, allowShutdown *utils.Atomic[bool], opts ...grpcutils.ServerOption) *grpc.Server {
+	opts = append(opts, grpcutils.WithChainUnaryServerInterceptor(errorDetailsUnaryServerInterceptor))
 	server := grpcutils.NewServer(opts...)
 	vmpb.RegisterVMServer(server, NewServer(vm, allowShutdown))
+	profilerpb.RegisterProfilerServer(server, gprofiler.NewServer())
 
 	health := health.NewServer()
 	health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
@@ -40,6 +40,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/validators/gvalidators"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging/glogging"
+	"github.com/ava-labs/avalanchego/utils/profiler/gprofiler"
 	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -55,7 +57,9 @@ import (
 	appsenderpb "github.com/ava-labs/avalanchego/proto/pb/appsender"
 	httppb "github.com/ava-labs/avalanchego/proto/pb/http"
 	keystorepb "github.com/ava-labs/avalanchego/proto/pb/keystore"
+	logpb "github.com/ava-labs/avalanchego/proto/pb/log"
 	messengerpb "github.com/ava-labs/avalanchego/proto/pb/messenger"
+	profilerpb "github.com/ava-labs/avalanchego/proto/pb/profiler"
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
 	sharedmemorypb "github.com/ava-labs/avalanchego/proto/pb/sharedmemory"
 	validatorstatepb "github.com/ava-labs/avalanchego/proto/pb/validatorstate"
@@ -79,6 +83,7 @@ var (
 	_ block.BuildBlockWithContextChainVM = (*VMClient)(nil)
 	_ block.BatchedChainVM               = (*VMClient)(nil)
 	_ block.StateSyncableVM              = (*VMClient)(nil)
+	_ block.ProfilerVM                   = (*VMClient)(nil)
 	_ prometheus.Gatherer                = (*VMClient)(nil)
 
 	_ snowman.Block           = (*blockClient)(nil)
@@ -91,6 +96,7 @@ var (
 type VMClient struct {
 	*chain.State
 	client         vmpb.VMClient
+	profiler       *gprofiler.Client
 	runtime        runtime.Stopper
 	pid            int
 	processTracker resource.ProcessTracker
@@ -102,8 +108,11 @@ type VMClient struct {
 	appSender            *appsender.Server
 	validatorStateServer *gvalidators.Server
 	warpSignerServer     *gwarp.Server
+	logServer            *glogging.Server
 
 	serverCloser grpcutils.ServerCloser
+	router       *connRouter
+	deadlineConn *deadlineConn
 	conns        []*grpc.ClientConn
 
 	grpcServerMetrics *grpc_prometheus.ServerMetrics
@@ -111,9 +120,20 @@ type VMClient struct {
 
 // NewClient returns a VM connected to a remote VM
 func NewClient(clientConn *grpc.ClientConn) *VMClient {
+	// A pool of a single connection can't be invalid.
+	pool, _ := grpcutils.NewConnPool(clientConn)
+	return newClient(newConnRouter(pool), DefaultCallTimeoutConfig)
+}
+
+func newClient(router *connRouter, callTimeoutConfig CallTimeoutConfig) *VMClient {
+	deadlineConn := newDeadlineConn(router, callTimeoutConfig)
 	return &VMClient{
-		client: vmpb.NewVMClient(clientConn),
-		conns:  []*grpc.ClientConn{clientConn},
+		client: vmpb.NewVMClient(errorDetailsConn{ClientConnInterface: deadlineConn}),
+		// Profiles are collected over a caller-provided duration, so they
+		// aren't bounded by the timeouts of the VM RPCs.
+		profiler:     gprofiler.NewClient(profilerpb.NewProfilerClient(router)),
+		router:       router,
+		deadlineConn: deadlineConn,
 	}
 }
 
@@ -147,6 +167,12 @@ func (vm *VMClient) Initialize(
 	if err := registerer.Register(vm.grpcServerMetrics); err != nil {
 		return err
 	}
+	if err := vm.router.Register(registerer); err != nil {
+		return err
+	}
+	if err := vm.deadlineConn.Register(registerer); err != nil {
+		return err
+	}
 	if err := multiGatherer.Register("rpcchainvm", registerer); err != nil {
 		return err
 	}
@@ -173,6 +199,7 @@ func (vm *VMClient) Initialize(
 	vm.appSender = appsender.NewServer(appSender)
 	vm.validatorStateServer = gvalidators.NewServer(chainCtx.ValidatorState)
 	vm.warpSignerServer = gwarp.NewServer(chainCtx.WarpSigner)
+	vm.logServer = glogging.NewServer(chainCtx.Log)
 
 	serverListener, err := grpcutils.NewListener()
 	if err != nil {
@@ -297,6 +324,7 @@ func (vm *VMClient) newInitServer() *grpc.Server {
 	healthpb.RegisterHealthServer(server, grpcHealth)
 	validatorstatepb.RegisterValidatorStateServer(server, vm.validatorStateServer)
 	warppb.RegisterSignerServer(server, vm.warpSignerServer)
+	logpb.RegisterLogServer(server, vm.logServer)
 
 	// Ensure metric counters are zeroed on restart
 	grpc_prometheus.Register(server)
@@ -346,6 +374,7 @@ func (vm *VMClient) Shutdown(ctx context.Context) error {
 	errs.Add(err)
 
 	vm.serverCloser.Stop()
+	errs.Add(vm.router.Close())
 	for _, conn := range vm.conns {
 		errs.Add(conn.Close())
 	}
@@ -619,6 +648,14 @@ func (vm *VMClient) Gather() ([]*dto.MetricFamily, error) {
 	return resp.MetricFamilies, nil
 }
 
+func (vm *VMClient) Profile(ctx context.Context, name string, duration time.Duration) ([]byte, error) {
+	return vm.profiler.Profile(ctx, name, duration)
+}
+
+func (vm *VMClient) RuntimeMetrics(ctx context.Context) ([]*dto.MetricFamily, error) {
+	return vm.profiler.RuntimeMetrics(ctx)
+}
+
 func (vm *VMClient) GetAncestors(
 	ctx context.Context,
 	blkID ids.ID,
@@ -16,6 +16,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 
+	"go.uber.org/zap"
+
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/ava-labs/avalanchego/api/keystore/gkeystore"
@@ -35,6 +37,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/logging/glogging"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/gwarp"
@@ -46,6 +49,7 @@ import (
 	appsenderpb "github.com/ava-labs/avalanchego/proto/pb/appsender"
 	httppb "github.com/ava-labs/avalanchego/proto/pb/http"
 	keystorepb "github.com/ava-labs/avalanchego/proto/pb/keystore"
+	logpb "github.com/ava-labs/avalanchego/proto/pb/log"
 	messengerpb "github.com/ava-labs/avalanchego/proto/pb/messenger"
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
 	sharedmemorypb "github.com/ava-labs/avalanchego/proto/pb/sharedmemory"
@@ -62,6 +66,18 @@ var (
 	errExpectedBlockWithVerifyContext = errors.New("expected block.WithVerifyContext")
 )
 
+// DatabaseConfigurer is implemented by VMs that configure the batching of
+// the requests they make to the database of the node.
+//
+// By default, every write is sent to the node before it is acknowledged. A VM
+// that enables write coalescing must tolerate losing the acknowledged writes
+// that weren't flushed if the VM or the node crashes.
+type DatabaseConfigurer interface {
+	// DatabaseConfig returns the database config of the chain configured by
+	// [configBytes]. It is called before the VM is initialized.
+	DatabaseConfig(configBytes []byte) (rpcdb.Config, error)
+}
+
 // VMServer is a VM that is managed over RPC.
 type VMServer struct {
 	vmpb.UnsafeVMServer
@@ -75,8 +91,11 @@ type VMServer struct {
 	allowShutdown *utils.Atomic[bool]
 
 	processMetrics prometheus.Gatherer
-	db             database.Database
-	log            logging.Logger
+	// dbClient may coalesce the VM's writes, so it must be flushed before the
+	// connection to the database is closed.
+	dbClient *rpcdb.DatabaseClient
+	db       database.Database
+	log      logging.Logger
 
 	serverCloser grpcutils.ServerCloser
 	connCloser   wrappers.Closer
@@ -150,6 +169,14 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 	// Register metrics for each Go plugin processes
 	vm.processMetrics = registerer
 
+	dbConfig := rpcdb.DefaultConfig
+	if configurer, ok := vm.vm.(DatabaseConfigurer); ok {
+		dbConfig, err = configurer.DatabaseConfig(req.ConfigBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database config: %w", err)
+		}
+	}
+
 	// Dial the database
 	dbClientConn, err := grpcutils.Dial(
 		req.DbServerAddr,
@@ -160,19 +187,18 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 		return nil, err
 	}
 	vm.connCloser.Add(dbClientConn)
-	vm.db = corruptabledb.New(
-		rpcdb.NewClient(rpcdbpb.NewDatabaseClient(dbClientConn)),
-	)
-
-	// TODO: Allow the logger to be configured by the client
-	vm.log = logging.NewLogger(
-		fmt.Sprintf("<%s Chain>", chainID),
-		logging.NewWrappedCore(
-			logging.Info,
-			originalStderr,
-			logging.Colors.ConsoleEncoder(),
-		),
+	vm.dbClient, err = rpcdb.NewClientWithConfig(
+		rpcdbpb.NewDatabaseClient(dbClientConn),
+		dbConfig,
+		"rpcdb",
+		registerer,
 	)
+	if err != nil {
+		// Ignore closing errors to return the original error
+		_ = vm.connCloser.Close()
+		return nil, err
+	}
+	vm.db = corruptabledb.New(vm.dbClient)
 
 	clientConn, err := grpcutils.Dial(
 		req.ServerAddr,
@@ -187,6 +213,25 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 
 	vm.connCloser.Add(clientConn)
 
+	// Log records are written to the logger of the chain on the client. If the
+	// client doesn't support this, they are written to stderr instead.
+	logClient := glogging.NewClient(logpb.NewLogClient(clientConn))
+	if level, err := logClient.Level(ctx); err == nil {
+		vm.log = logging.NewLogger("", glogging.NewWrappedCore(level, logClient))
+	} else {
+		vm.log = logging.NewLogger(
+			fmt.Sprintf("<%s Chain>", chainID),
+			logging.NewWrappedCore(
+				logging.Info,
+				originalStderr,
+				logging.Colors.ConsoleEncoder(),
+			),
+		)
+		vm.log.Debug("failed to fetch log level",
+			zap.Error(err),
+		)
+	}
+
 	msgClient := messenger.NewClient(messengerpb.NewMessengerClient(clientConn))
 	keystoreClient := gkeystore.NewClient(keystorepb.NewKeystoreClient(clientConn))
 	sharedMemoryClient := gsharedmemory.NewClient(sharedmemorypb.NewSharedMemoryClient(clientConn))
@@ -304,8 +349,12 @@ func (vm *VMServer) Shutdown(ctx context.Context, _ *emptypb.Empty) (*emptypb.Em
 		return &emptypb.Empty{}, nil
 	}
 	errs := wrappers.Errs{}
-	errs.Add(vm.vm.Shutdown(ctx))
+	errs.Add(
+		vm.vm.Shutdown(ctx),
+		vm.dbClient.Flush(),
+	)
 	close(vm.closed)
+	vm.log.Stop()
 	vm.serverCloser.Stop()
 	errs.Add(vm.connCloser.Close())
 	return &emptypb.Empty{}, errs.Err
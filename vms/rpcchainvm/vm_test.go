@@ -153,6 +153,22 @@ func TestRuntimeSubprocessBootstrap(t *testing.T) {
 			},
 			serveVM: true,
 		},
+		{
+			name: "invalid sandbox",
+			config: &subprocess.Config{
+				Stderr:           logging.NoLog{},
+				Stdout:           logging.NoLog{},
+				Log:              logging.NoLog{},
+				HandshakeTimeout: runtime.DefaultHandshakeTimeout,
+				Sandbox: subprocess.SandboxConfig{
+					CPUShares: 1024,
+				},
+			},
+			assertErr: func(require *require.Assertions, err error) {
+				require.ErrorIs(err, subprocess.ErrCgroupDirRequired)
+			},
+			serveVM: true,
+		},
 		{
 			name: "handshake timeout",
 			config: &subprocess.Config{
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var errNilExtendLocktimeOperation = errors.New("nil extend locktime operation")
+
+// ExtendLocktimeOperation consumes a TransferOutput and recreates it with the
+// same amount and owners, but with a locktime that is no earlier than the
+// locktime of the consumed output.
+//
+// Unlike a transfer, the consumed output may still be locked.
+type ExtendLocktimeOperation struct {
+	Input          Input          `serialize:"true" json:"input"`
+	TransferOutput TransferOutput `serialize:"true" json:"transferOutput"`
+}
+
+func (op *ExtendLocktimeOperation) InitCtx(ctx *snow.Context) {
+	op.TransferOutput.OutputOwners.InitCtx(ctx)
+}
+
+func (op *ExtendLocktimeOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *ExtendLocktimeOperation) Outs() []verify.State {
+	return []verify.State{&op.TransferOutput}
+}
+
+func (op *ExtendLocktimeOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilExtendLocktimeOperation
+	default:
+		return verify.All(&op.Input, &op.TransferOutput)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestExtendLocktimeOperationVerify(t *testing.T) {
+	var (
+		validInput = Input{
+			SigIndices: []uint32{0},
+		}
+		validTransferOutput = TransferOutput{
+			Amt: 1,
+			OutputOwners: OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{{1}, {2}},
+			},
+		}
+	)
+
+	tests := []struct {
+		name        string
+		op          *ExtendLocktimeOperation
+		expectedErr error
+	}{
+		{
+			name:        "nil",
+			op:          nil,
+			expectedErr: errNilExtendLocktimeOperation,
+		},
+		{
+			name: "invalid input",
+			op: &ExtendLocktimeOperation{
+				Input: Input{
+					SigIndices: []uint32{0, 0},
+				},
+				TransferOutput: validTransferOutput,
+			},
+			expectedErr: ErrInputIndicesNotSortedUnique,
+		},
+		{
+			name: "unspendable output",
+			op: &ExtendLocktimeOperation{
+				Input: validInput,
+				TransferOutput: TransferOutput{
+					Amt: 1,
+					OutputOwners: OutputOwners{
+						Threshold: 2,
+						Addrs:     []ids.ShortID{{1}},
+					},
+				},
+			},
+			expectedErr: ErrOutputUnspendable,
+		},
+		{
+			name: "addresses not sorted",
+			op: &ExtendLocktimeOperation{
+				Input: validInput,
+				TransferOutput: TransferOutput{
+					Amt: 1,
+					OutputOwners: OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{{2}, {1}},
+					},
+				},
+			},
+			expectedErr: ErrAddrsNotSortedUnique,
+		},
+		{
+			name: "passes verification",
+			op: &ExtendLocktimeOperation{
+				Input:          validInput,
+				TransferOutput: validTransferOutput,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.op.Verify()
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestExtendLocktimeOperationOuts(t *testing.T) {
+	require := require.New(t)
+	op := &ExtendLocktimeOperation{
+		Input: Input{
+			SigIndices: []uint32{0},
+		},
+		TransferOutput: TransferOutput{
+			Amt: 1,
+			OutputOwners: OutputOwners{
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					addr,
+				},
+			},
+		},
+	}
+
+	outs := op.Outs()
+	require.Len(outs, 1)
+	require.Equal(&op.TransferOutput, outs[0])
+}
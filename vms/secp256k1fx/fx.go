@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
 
@@ -36,6 +37,9 @@ var (
 	ErrInputOutputIndexOutOfBounds    = errors.New("input referenced a nonexistent address in the output")
 	ErrInputCredentialSignersMismatch = errors.New("input expected a different number of signers than provided in the credential")
 	ErrWrongSig                       = errors.New("wrong signature")
+	ErrLocktimeShortened              = errors.New("output locktime can't be shortened")
+	ErrOwnersChanged                  = errors.New("output owners can't be changed")
+	ErrExtensionNotActivated          = errors.New("operation type isn't activated")
 )
 
 // Fx describes the secp256k1 feature extension
@@ -60,13 +64,30 @@ func (fx *Fx) Initialize(vmIntf interface{}) error {
 		},
 	}
 	c := fx.VM.CodecRegistry()
-	return utils.Err(
+	err := utils.Err(
 		c.RegisterType(&TransferInput{}),
 		c.RegisterType(&MintOutput{}),
 		c.RegisterType(&TransferOutput{}),
 		c.RegisterType(&MintOperation{}),
 		c.RegisterType(&Credential{}),
 	)
+	if err != nil {
+		return err
+	}
+
+	// The types added after the fx was first deployed are only registered
+	// once they are scheduled, so that the codec of the VM doesn't change
+	// until it is upgraded.
+	vm, ok := fx.VM.(ExtensionsVM)
+	if !ok || vm.ExtensionsActivationTime().Equal(mockable.MaxTime) {
+		return nil
+	}
+	c = vm.ExtensionsCodecRegistry()
+	return utils.Err(
+		c.RegisterType(&RotateOwnersOperation{}),
+		c.RegisterType(&UpdateMetadataOperation{}),
+		c.RegisterType(&ExtendLocktimeOperation{}),
+	)
 }
 
 func (fx *Fx) InitializeVM(vmIntf interface{}) error {
@@ -116,10 +137,6 @@ func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []
 	if !ok {
 		return ErrWrongTxType
 	}
-	op, ok := opIntf.(*MintOperation)
-	if !ok {
-		return ErrWrongOpType
-	}
 	cred, ok := credIntf.(*Credential)
 	if !ok {
 		return ErrWrongCredentialType
@@ -127,11 +144,58 @@ func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []
 	if len(utxosIntf) != 1 {
 		return ErrWrongNumberOfUTXOs
 	}
-	out, ok := utxosIntf[0].(*MintOutput)
+
+	switch op := opIntf.(type) {
+	case *MintOperation:
+		out, ok := utxosIntf[0].(*MintOutput)
+		if !ok {
+			return ErrWrongUTXOType
+		}
+		return fx.verifyOperation(tx, op, cred, out)
+	case *RotateOwnersOperation:
+		if err := fx.verifyExtensionActivated(); err != nil {
+			return err
+		}
+		out, ok := utxosIntf[0].(*TransferOutput)
+		if !ok {
+			return ErrWrongUTXOType
+		}
+		return fx.verifyRotateOwnersOperation(tx, op, cred, out)
+	case *ExtendLocktimeOperation:
+		if err := fx.verifyExtensionActivated(); err != nil {
+			return err
+		}
+		out, ok := utxosIntf[0].(*TransferOutput)
+		if !ok {
+			return ErrWrongUTXOType
+		}
+		return fx.verifyExtendLocktimeOperation(tx, op, cred, out)
+	case *UpdateMetadataOperation:
+		if err := fx.verifyExtensionActivated(); err != nil {
+			return err
+		}
+		out, ok := utxosIntf[0].(*MintOutput)
+		if !ok {
+			return ErrWrongUTXOType
+		}
+		return fx.verifyUpdateMetadataOperation(tx, op, cred, out)
+	default:
+		return ErrWrongOpType
+	}
+}
+
+// verifyExtensionActivated returns nil if the types that were added to the fx
+// after it was first deployed may be used. Like locktimes, the activation time
+// is compared against the clock of the VM.
+func (fx *Fx) verifyExtensionActivated() error {
+	vm, ok := fx.VM.(ExtensionsVM)
 	if !ok {
-		return ErrWrongUTXOType
+		return ErrWrongOpType
+	}
+	if activationTime := vm.ExtensionsActivationTime(); fx.VM.Clock().Time().Before(activationTime) {
+		return fmt.Errorf("%w: activated at %s", ErrExtensionNotActivated, activationTime)
 	}
-	return fx.verifyOperation(tx, op, cred, out)
+	return nil
 }
 
 func (fx *Fx) verifyOperation(tx UnsignedTx, op *MintOperation, cred *Credential, utxo *MintOutput) error {
@@ -144,6 +208,52 @@ func (fx *Fx) verifyOperation(tx UnsignedTx, op *MintOperation, cred *Credential
 	return fx.VerifyCredentials(tx, &op.MintInput, cred, &utxo.OutputOwners)
 }
 
+// verifyRotateOwnersOperation ensures that [utxo] can be spent by [op] and
+// that [op] recreates it with the same amount.
+func (fx *Fx) verifyRotateOwnersOperation(tx UnsignedTx, op *RotateOwnersOperation, cred *Credential, utxo *TransferOutput) error {
+	if err := verify.All(op, cred, utxo); err != nil {
+		return err
+	}
+	if utxo.Amt != op.TransferOutput.Amt {
+		return fmt.Errorf("%w: %d != %d", ErrMismatchedAmounts, utxo.Amt, op.TransferOutput.Amt)
+	}
+	return fx.VerifyCredentials(tx, &op.Input, cred, &utxo.OutputOwners)
+}
+
+// verifyExtendLocktimeOperation ensures that [utxo] is signed for by its
+// owners and that [op] recreates it with the same amount and owners, without
+// shortening its locktime. The locktime of [utxo] isn't enforced, so that
+// locked outputs can be extended.
+func (fx *Fx) verifyExtendLocktimeOperation(tx UnsignedTx, op *ExtendLocktimeOperation, cred *Credential, utxo *TransferOutput) error {
+	if err := verify.All(op, cred, utxo); err != nil {
+		return err
+	}
+	if utxo.Amt != op.TransferOutput.Amt {
+		return fmt.Errorf("%w: %d != %d", ErrMismatchedAmounts, utxo.Amt, op.TransferOutput.Amt)
+	}
+	if op.TransferOutput.Locktime < utxo.Locktime {
+		return fmt.Errorf("%w: %d < %d", ErrLocktimeShortened, op.TransferOutput.Locktime, utxo.Locktime)
+	}
+	owners := op.TransferOutput.OutputOwners
+	owners.Locktime = utxo.Locktime
+	if !utxo.OutputOwners.Equals(&owners) {
+		return ErrOwnersChanged
+	}
+	return fx.verifySignatures(tx, &op.Input, cred, &utxo.OutputOwners)
+}
+
+// verifyUpdateMetadataOperation ensures that [utxo] can be spent by [op] and
+// that [op] recreates it with the same owners.
+func (fx *Fx) verifyUpdateMetadataOperation(tx UnsignedTx, op *UpdateMetadataOperation, cred *Credential, utxo *MintOutput) error {
+	if err := verify.All(op, cred, utxo); err != nil {
+		return err
+	}
+	if !utxo.Equals(&op.MintOutput.OutputOwners) {
+		return ErrWrongMintCreated
+	}
+	return fx.VerifyCredentials(tx, &op.MintInput, cred, &utxo.OutputOwners)
+}
+
 func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) error {
 	tx, ok := txIntf.(UnsignedTx)
 	if !ok {
@@ -178,10 +288,17 @@ func (fx *Fx) VerifySpend(utx UnsignedTx, in *TransferInput, cred *Credential, u
 // VerifyCredentials ensures that the output can be spent by the input with the
 // credential. A nil return values means the output can be spent.
 func (fx *Fx) VerifyCredentials(utx UnsignedTx, in *Input, cred *Credential, out *OutputOwners) error {
+	if out.Locktime > fx.VM.Clock().Unix() {
+		return ErrTimelocked
+	}
+	return fx.verifySignatures(utx, in, cred, out)
+}
+
+// verifySignatures ensures that the input's credential is signed by the
+// owners of the output, regardless of the output's locktime.
+func (fx *Fx) verifySignatures(utx UnsignedTx, in *Input, cred *Credential, out *OutputOwners) error {
 	numSigs := len(in.SigIndices)
 	switch {
-	case out.Locktime > fx.VM.Clock().Unix():
-		return ErrTimelocked
 	case out.Threshold < uint32(numSigs):
 		return ErrTooManySigners
 	case out.Threshold > uint32(numSigs):
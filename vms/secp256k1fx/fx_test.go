@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/cb58"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 var (
@@ -950,6 +951,580 @@ func TestFxVerifyOperationMismatchedMintOutputs(t *testing.T) {
 	require.ErrorIs(err, ErrWrongMintCreated)
 }
 
+func TestFxVerifyRotateOwnersOperation(t *testing.T) {
+	newOwners := OutputOwners{
+		Threshold: 2,
+		Addrs:     []ids.ShortID{addr, addr2},
+	}
+
+	tests := []struct {
+		name        string
+		utxo        interface{}
+		op          *RotateOwnersOperation
+		cred        *Credential
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			utxo: &TransferOutput{
+				Amt: 1,
+				OutputOwners: OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+			op: &RotateOwnersOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt:          1,
+					OutputOwners: newOwners,
+				},
+			},
+			cred: &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "wrong utxo type",
+			utxo: &MintOutput{
+				OutputOwners: OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+			op: &RotateOwnersOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt:          1,
+					OutputOwners: newOwners,
+				},
+			},
+			cred: &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			},
+			expectedErr: ErrWrongUTXOType,
+		},
+		{
+			name: "mismatched amounts",
+			utxo: &TransferOutput{
+				Amt: 1,
+				OutputOwners: OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+			op: &RotateOwnersOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt:          2,
+					OutputOwners: newOwners,
+				},
+			},
+			cred: &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			},
+			expectedErr: ErrMismatchedAmounts,
+		},
+		{
+			name: "too few signers",
+			utxo: &TransferOutput{
+				Amt:          1,
+				OutputOwners: newOwners,
+			},
+			op: &RotateOwnersOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt: 1,
+					OutputOwners: OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr},
+					},
+				},
+			},
+			cred: &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			},
+			expectedErr: ErrTooFewSigners,
+		},
+		{
+			name: "wrong signer",
+			utxo: &TransferOutput{
+				Amt: 1,
+				OutputOwners: OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr2},
+				},
+			},
+			op: &RotateOwnersOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt:          1,
+					OutputOwners: newOwners,
+				},
+			},
+			cred: &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			},
+			expectedErr: ErrWrongSig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vm := TestExtensionsVM{
+				TestVM: TestVM{
+					Codec: linearcodec.NewDefault(),
+					Log:   logging.NoLog{},
+				},
+				ExtensionsCodec: linearcodec.NewDefault(),
+			}
+			fx := Fx{}
+			require.NoError(fx.Initialize(&vm))
+			require.NoError(fx.Bootstrapped())
+
+			tx := &TestTx{UnsignedBytes: txBytes}
+			err := fx.VerifyOperation(tx, tt.op, tt.cred, []interface{}{tt.utxo})
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestFxExtensionsNotScheduled(t *testing.T) {
+	require := require.New(t)
+
+	extensionsCodec := linearcodec.NewDefault()
+	vm := TestExtensionsVM{
+		TestVM: TestVM{
+			Codec: linearcodec.NewDefault(),
+			Log:   logging.NoLog{},
+		},
+		ExtensionsCodec:      extensionsCodec,
+		ExtensionsActivation: mockable.MaxTime,
+	}
+	fx := Fx{}
+	require.NoError(fx.Initialize(&vm))
+
+	// The extensions weren't registered, so registering them again succeeds.
+	require.NoError(extensionsCodec.RegisterType(&RotateOwnersOperation{}))
+	require.NoError(extensionsCodec.RegisterType(&UpdateMetadataOperation{}))
+	require.NoError(extensionsCodec.RegisterType(&ExtendLocktimeOperation{}))
+}
+
+func TestFxVerifyExtensionNotActivated(t *testing.T) {
+	activationTime := time.Unix(1_000, 0)
+
+	tests := []struct {
+		name string
+		utxo interface{}
+		op   interface{}
+	}{
+		{
+			name: "rotate owners",
+			utxo: &TransferOutput{
+				Amt: 1,
+				OutputOwners: OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+			op: &RotateOwnersOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt: 1,
+					OutputOwners: OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr2},
+					},
+				},
+			},
+		},
+		{
+			name: "extend locktime",
+			utxo: &TransferOutput{
+				Amt: 1,
+				OutputOwners: OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+			op: &ExtendLocktimeOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt: 1,
+					OutputOwners: OutputOwners{
+						Locktime:  1,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr},
+					},
+				},
+			},
+		},
+		{
+			name: "update metadata",
+			utxo: &MintOutput{
+				OutputOwners: OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+			op: &UpdateMetadataOperation{
+				MintInput: Input{
+					SigIndices: []uint32{0},
+				},
+				MintOutput: MintOutput{
+					OutputOwners: OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr},
+					},
+				},
+				Name:   "Team Rocket",
+				Symbol: "TR",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vm := TestExtensionsVM{
+				TestVM: TestVM{
+					Codec: linearcodec.NewDefault(),
+					Log:   logging.NoLog{},
+				},
+				ExtensionsCodec:      linearcodec.NewDefault(),
+				ExtensionsActivation: activationTime,
+			}
+			fx := Fx{}
+			require.NoError(fx.Initialize(&vm))
+			require.NoError(fx.Bootstrapped())
+
+			tx := &TestTx{UnsignedBytes: txBytes}
+			cred := &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			}
+
+			vm.Clk.Set(activationTime.Add(-time.Second))
+			err := fx.VerifyOperation(tx, tt.op, cred, []interface{}{tt.utxo})
+			require.ErrorIs(err, ErrExtensionNotActivated)
+
+			vm.Clk.Set(activationTime)
+			require.NoError(fx.VerifyOperation(tx, tt.op, cred, []interface{}{tt.utxo}))
+		})
+	}
+}
+
+func TestFxVerifyExtendLocktimeOperation(t *testing.T) {
+	owners := OutputOwners{
+		Locktime:  2,
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+	extendedOwners := OutputOwners{
+		Locktime:  3,
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+
+	tests := []struct {
+		name        string
+		utxo        interface{}
+		op          *ExtendLocktimeOperation
+		expectedErr error
+	}{
+		{
+			name: "valid locked output",
+			utxo: &TransferOutput{
+				Amt:          1,
+				OutputOwners: owners,
+			},
+			op: &ExtendLocktimeOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt:          1,
+					OutputOwners: extendedOwners,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "unchanged locktime",
+			utxo: &TransferOutput{
+				Amt:          1,
+				OutputOwners: owners,
+			},
+			op: &ExtendLocktimeOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt:          1,
+					OutputOwners: owners,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "wrong utxo type",
+			utxo: &MintOutput{
+				OutputOwners: owners,
+			},
+			op: &ExtendLocktimeOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt:          1,
+					OutputOwners: extendedOwners,
+				},
+			},
+			expectedErr: ErrWrongUTXOType,
+		},
+		{
+			name: "mismatched amounts",
+			utxo: &TransferOutput{
+				Amt:          1,
+				OutputOwners: owners,
+			},
+			op: &ExtendLocktimeOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt:          2,
+					OutputOwners: extendedOwners,
+				},
+			},
+			expectedErr: ErrMismatchedAmounts,
+		},
+		{
+			name: "shortened locktime",
+			utxo: &TransferOutput{
+				Amt:          1,
+				OutputOwners: extendedOwners,
+			},
+			op: &ExtendLocktimeOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt:          1,
+					OutputOwners: owners,
+				},
+			},
+			expectedErr: ErrLocktimeShortened,
+		},
+		{
+			name: "changed owners",
+			utxo: &TransferOutput{
+				Amt:          1,
+				OutputOwners: owners,
+			},
+			op: &ExtendLocktimeOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt: 1,
+					OutputOwners: OutputOwners{
+						Locktime:  3,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr2},
+					},
+				},
+			},
+			expectedErr: ErrOwnersChanged,
+		},
+		{
+			name: "wrong signer",
+			utxo: &TransferOutput{
+				Amt: 1,
+				OutputOwners: OutputOwners{
+					Locktime:  2,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr2},
+				},
+			},
+			op: &ExtendLocktimeOperation{
+				Input: Input{
+					SigIndices: []uint32{0},
+				},
+				TransferOutput: TransferOutput{
+					Amt: 1,
+					OutputOwners: OutputOwners{
+						Locktime:  3,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr2},
+					},
+				},
+			},
+			expectedErr: ErrWrongSig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vm := TestExtensionsVM{
+				TestVM: TestVM{
+					Codec: linearcodec.NewDefault(),
+					Log:   logging.NoLog{},
+				},
+				ExtensionsCodec: linearcodec.NewDefault(),
+			}
+			// The consumed outputs are still locked.
+			vm.Clk.Set(time.Unix(1, 0))
+			fx := Fx{}
+			require.NoError(fx.Initialize(&vm))
+			require.NoError(fx.Bootstrapped())
+
+			tx := &TestTx{UnsignedBytes: txBytes}
+			cred := &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			}
+			err := fx.VerifyOperation(tx, tt.op, cred, []interface{}{tt.utxo})
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestFxVerifyUpdateMetadataOperation(t *testing.T) {
+	minters := OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+
+	tests := []struct {
+		name        string
+		utxo        interface{}
+		op          *UpdateMetadataOperation
+		cred        *Credential
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			utxo: &MintOutput{
+				OutputOwners: minters,
+			},
+			op: &UpdateMetadataOperation{
+				MintInput: Input{
+					SigIndices: []uint32{0},
+				},
+				MintOutput: MintOutput{
+					OutputOwners: minters,
+				},
+				Name:   "Team Rocket",
+				Symbol: "TR",
+			},
+			cred: &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "wrong utxo type",
+			utxo: &TransferOutput{
+				Amt:          1,
+				OutputOwners: minters,
+			},
+			op: &UpdateMetadataOperation{
+				MintInput: Input{
+					SigIndices: []uint32{0},
+				},
+				MintOutput: MintOutput{
+					OutputOwners: minters,
+				},
+			},
+			cred: &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			},
+			expectedErr: ErrWrongUTXOType,
+		},
+		{
+			name: "wrong mint created",
+			utxo: &MintOutput{
+				OutputOwners: minters,
+			},
+			op: &UpdateMetadataOperation{
+				MintInput: Input{
+					SigIndices: []uint32{0},
+				},
+				MintOutput: MintOutput{
+					OutputOwners: OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr2},
+					},
+				},
+			},
+			cred: &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			},
+			expectedErr: ErrWrongMintCreated,
+		},
+		{
+			name: "wrong signer",
+			utxo: &MintOutput{
+				OutputOwners: OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr2},
+				},
+			},
+			op: &UpdateMetadataOperation{
+				MintInput: Input{
+					SigIndices: []uint32{0},
+				},
+				MintOutput: MintOutput{
+					OutputOwners: OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr2},
+					},
+				},
+			},
+			cred: &Credential{
+				Sigs: [][secp256k1.SignatureLen]byte{sigBytes},
+			},
+			expectedErr: ErrWrongSig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vm := TestExtensionsVM{
+				TestVM: TestVM{
+					Codec: linearcodec.NewDefault(),
+					Log:   logging.NoLog{},
+				},
+				ExtensionsCodec: linearcodec.NewDefault(),
+			}
+			fx := Fx{}
+			require.NoError(fx.Initialize(&vm))
+			require.NoError(fx.Bootstrapped())
+
+			tx := &TestTx{UnsignedBytes: txBytes}
+			err := fx.VerifyOperation(tx, tt.op, tt.cred, []interface{}{tt.utxo})
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
 func TestVerifyPermission(t *testing.T) {
 	vm := TestVM{
 		Codec: linearcodec.NewDefault(),
@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var errNilRotateOwnersOperation = errors.New("nil rotate owners operation")
+
+// RotateOwnersOperation consumes a TransferOutput and recreates it with the
+// same amount under a new threshold and address set.
+type RotateOwnersOperation struct {
+	Input          Input          `serialize:"true" json:"input"`
+	TransferOutput TransferOutput `serialize:"true" json:"transferOutput"`
+}
+
+func (op *RotateOwnersOperation) InitCtx(ctx *snow.Context) {
+	op.TransferOutput.OutputOwners.InitCtx(ctx)
+}
+
+func (op *RotateOwnersOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *RotateOwnersOperation) Outs() []verify.State {
+	return []verify.State{&op.TransferOutput}
+}
+
+func (op *RotateOwnersOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilRotateOwnersOperation
+	default:
+		return verify.All(&op.Input, &op.TransferOutput)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+// MaxURILen is the maximum length of the URI provided by an
+// UpdateMetadataOperation.
+const MaxURILen = 1024
+
+var (
+	errNilUpdateMetadataOperation = errors.New("nil update metadata operation")
+	ErrURITooLong                 = fmt.Errorf("uri is too long, maximum size is %d", MaxURILen)
+)
+
+// UpdateMetadataOperation consumes a MintOutput to update the on-chain
+// metadata of its asset. The MintOutput is recreated with the same owners, so
+// only the minters of an asset are able to update its metadata.
+type UpdateMetadataOperation struct {
+	MintInput    Input      `serialize:"true" json:"mintInput"`
+	MintOutput   MintOutput `serialize:"true" json:"mintOutput"`
+	Name         string     `serialize:"true" json:"name"`
+	Symbol       string     `serialize:"true" json:"symbol"`
+	Denomination byte       `serialize:"true" json:"denomination"`
+	URI          string     `serialize:"true" json:"uri"`
+}
+
+func (op *UpdateMetadataOperation) InitCtx(ctx *snow.Context) {
+	op.MintOutput.OutputOwners.InitCtx(ctx)
+}
+
+func (op *UpdateMetadataOperation) Cost() (uint64, error) {
+	return op.MintInput.Cost()
+}
+
+func (op *UpdateMetadataOperation) Outs() []verify.State {
+	return []verify.State{&op.MintOutput}
+}
+
+func (op *UpdateMetadataOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilUpdateMetadataOperation
+	case len(op.URI) > MaxURILen:
+		return ErrURITooLong
+	default:
+		return verify.All(&op.MintInput, &op.MintOutput)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestUpdateMetadataOperationVerify(t *testing.T) {
+	var (
+		validInput = Input{
+			SigIndices: []uint32{0},
+		}
+		validMintOutput = MintOutput{
+			OutputOwners: OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{{1}},
+			},
+		}
+	)
+
+	tests := []struct {
+		name        string
+		op          *UpdateMetadataOperation
+		expectedErr error
+	}{
+		{
+			name:        "nil",
+			op:          nil,
+			expectedErr: errNilUpdateMetadataOperation,
+		},
+		{
+			name: "invalid input",
+			op: &UpdateMetadataOperation{
+				MintInput: Input{
+					SigIndices: []uint32{0, 0},
+				},
+				MintOutput: validMintOutput,
+			},
+			expectedErr: ErrInputIndicesNotSortedUnique,
+		},
+		{
+			name: "unspendable output",
+			op: &UpdateMetadataOperation{
+				MintInput: validInput,
+				MintOutput: MintOutput{
+					OutputOwners: OutputOwners{
+						Threshold: 2,
+						Addrs:     []ids.ShortID{{1}},
+					},
+				},
+			},
+			expectedErr: ErrOutputUnspendable,
+		},
+		{
+			name: "uri too long",
+			op: &UpdateMetadataOperation{
+				MintInput:  validInput,
+				MintOutput: validMintOutput,
+				URI:        strings.Repeat("a", MaxURILen+1),
+			},
+			expectedErr: ErrURITooLong,
+		},
+		{
+			name: "passes verification",
+			op: &UpdateMetadataOperation{
+				MintInput:    validInput,
+				MintOutput:   validMintOutput,
+				Name:         "Team Rocket",
+				Symbol:       "TR",
+				Denomination: 9,
+				URI:          "https://example.com/tr.json",
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.op.Verify()
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestUpdateMetadataOperationOuts(t *testing.T) {
+	require := require.New(t)
+	op := &UpdateMetadataOperation{
+		MintInput: Input{
+			SigIndices: []uint32{0},
+		},
+		MintOutput: MintOutput{
+			OutputOwners: OutputOwners{
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					addr,
+				},
+			},
+		},
+	}
+
+	outs := op.Outs()
+	require.Len(outs, 1)
+	require.Equal(&op.MintOutput, outs[0])
+}
@@ -4,6 +4,8 @@
 package secp256k1fx
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -16,7 +18,27 @@ type VM interface {
 	Logger() logging.Logger
 }
 
-var _ VM = (*TestVM)(nil)
+// ExtensionsVM is a VM that supports the types that were added to this Fx
+// after it was first deployed.
+type ExtensionsVM interface {
+	VM
+
+	// ExtensionsCodecRegistry returns the registry of the types that were
+	// added to this Fx after it was first deployed. The VM must register these
+	// types after all the types registered into CodecRegistry, so that adding
+	// them doesn't change the type IDs of previously registered types.
+	ExtensionsCodecRegistry() codec.Registry
+
+	// ExtensionsActivationTime returns the time from which the types that
+	// were added to this Fx after it was first deployed may be used. If it is
+	// [mockable.MaxTime], the types aren't registered at all.
+	ExtensionsActivationTime() time.Time
+}
+
+var (
+	_ VM           = (*TestVM)(nil)
+	_ ExtensionsVM = (*TestExtensionsVM)(nil)
+)
 
 // TestVM is a minimal implementation of a VM
 type TestVM struct {
@@ -36,3 +58,18 @@ func (vm *TestVM) CodecRegistry() codec.Registry {
 func (vm *TestVM) Logger() logging.Logger {
 	return vm.Log
 }
+
+// TestExtensionsVM is a minimal implementation of an ExtensionsVM
+type TestExtensionsVM struct {
+	TestVM
+	ExtensionsCodec      codec.Registry
+	ExtensionsActivation time.Time
+}
+
+func (vm *TestExtensionsVM) ExtensionsCodecRegistry() codec.Registry {
+	return vm.ExtensionsCodec
+}
+
+func (vm *TestExtensionsVM) ExtensionsActivationTime() time.Time {
+	return vm.ExtensionsActivation
+}
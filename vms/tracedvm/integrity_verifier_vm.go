@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracedvm
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) VerifyIntegrity(ctx context.Context, repair bool) ([]string, error) {
+	if vm.integrityVM == nil {
+		return nil, block.ErrIntegrityVerifierVMNotImplemented
+	}
+
+	ctx, span := vm.tracer.Start(ctx, vm.verifyIntegrityTag, oteltrace.WithAttributes(
+		attribute.Bool("repair", repair),
+	))
+	defer span.End()
+
+	return vm.integrityVM.VerifyIntegrity(ctx, repair)
+}
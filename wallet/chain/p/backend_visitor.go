@@ -53,6 +53,10 @@ func (b *backendVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx
 	return b.baseTx(&tx.BaseTx)
 }
 
+func (b *backendVisitor) ScheduleSubnetValidatorRemovalTx(tx *txs.ScheduleSubnetValidatorRemovalTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
 func (b *backendVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
 	// TODO: Correctly track subnet owners in [getSubnetSigners]
 	return b.baseTx(&tx.BaseTx)
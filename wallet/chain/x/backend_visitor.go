@@ -28,10 +28,18 @@ func (*backendVisitor) CreateAssetTx(*txs.CreateAssetTx) error {
 	return nil
 }
 
+func (*backendVisitor) CreateCappedAssetTx(*txs.CreateCappedAssetTx) error {
+	return nil
+}
+
 func (*backendVisitor) OperationTx(*txs.OperationTx) error {
 	return nil
 }
 
+func (*backendVisitor) FeeRateTx(*txs.FeeRateTx) error {
+	return nil
+}
+
 func (b *backendVisitor) ImportTx(tx *txs.ImportTx) error {
 	for _, in := range tx.ImportedIns {
 		utxoID := in.UTXOID.InputID()
@@ -125,6 +125,53 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.OperationTx, error)
 
+	// NewOperationTxRotateOwners performs state changes that move all the
+	// outputs of the requested asset that are owned by [from] to be owned by
+	// [to], without changing their amounts.
+	//
+	// - [assetID] specifies the asset of the outputs to rotate.
+	// - [from] specifies the current owners of the outputs.
+	// - [to] specifies the new owners of the outputs.
+	NewOperationTxRotateOwners(
+		assetID ids.ID,
+		from *secp256k1fx.OutputOwners,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.OperationTx, error)
+
+	// NewOperationTxUpdateMetadata performs a state change that updates the
+	// on-chain metadata of the requested asset. The metadata can only be
+	// updated by a minter of the asset.
+	//
+	// - [assetID] specifies the asset to update the metadata of.
+	// - [name] specifies the new human readable name of the asset.
+	// - [symbol] specifies the new shorthand representation of the asset.
+	// - [denomination] specifies the new number of digits after the decimal
+	//   point of the asset.
+	// - [uri] specifies where additional information about the asset can be
+	//   found.
+	NewOperationTxUpdateMetadata(
+		assetID ids.ID,
+		name string,
+		symbol string,
+		denomination byte,
+		uri string,
+		options ...common.Option,
+	) (*txs.OperationTx, error)
+
+	// NewOperationTxExtendLocktime performs state changes that extend the
+	// locktime of all the outputs of the requested asset that are owned by
+	// this wallet to [locktime], without changing their amounts or owners.
+	// Outputs that are locked until after [locktime] are left unchanged.
+	//
+	// - [assetID] specifies the asset of the outputs to extend.
+	// - [locktime] specifies the new locktime of the outputs.
+	NewOperationTxExtendLocktime(
+		assetID ids.ID,
+		locktime uint64,
+		options ...common.Option,
+	) (*txs.OperationTx, error)
+
 	// NewImportTx creates an import transaction that attempts to consume all
 	// the available UTXOs and import the funds to [to].
 	//
@@ -343,6 +390,49 @@ func (b *builder) NewOperationTxBurnProperty(
 	return b.NewOperationTx(operations, options...)
 }
 
+func (b *builder) NewOperationTxRotateOwners(
+	assetID ids.ID,
+	from *secp256k1fx.OutputOwners,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.OperationTx, error) {
+	ops := common.NewOptions(options)
+	operations, err := b.rotateOwners(assetID, from, to, ops)
+	if err != nil {
+		return nil, err
+	}
+	return b.NewOperationTx(operations, options...)
+}
+
+func (b *builder) NewOperationTxUpdateMetadata(
+	assetID ids.ID,
+	name string,
+	symbol string,
+	denomination byte,
+	uri string,
+	options ...common.Option,
+) (*txs.OperationTx, error) {
+	ops := common.NewOptions(options)
+	operations, err := b.updateMetadata(assetID, name, symbol, denomination, uri, ops)
+	if err != nil {
+		return nil, err
+	}
+	return b.NewOperationTx(operations, options...)
+}
+
+func (b *builder) NewOperationTxExtendLocktime(
+	assetID ids.ID,
+	locktime uint64,
+	options ...common.Option,
+) (*txs.OperationTx, error) {
+	ops := common.NewOptions(options)
+	operations, err := b.extendLocktime(assetID, locktime, ops)
+	if err != nil {
+		return nil, err
+	}
+	return b.NewOperationTx(operations, options...)
+}
+
 func (b *builder) NewImportTx(
 	chainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -847,3 +937,191 @@ func (b *builder) burnProperty(
 	}
 	return operations, nil
 }
+
+func (b *builder) rotateOwners(
+	assetID ids.ID,
+	from *secp256k1fx.OutputOwners,
+	to *secp256k1fx.OutputOwners,
+	options *common.Options,
+) (
+	operations []*txs.Operation,
+	err error,
+) {
+	utxos, err := b.backend.UTXOs(options.Context(), b.backend.BlockchainID())
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := options.Addresses(b.addrs)
+	minIssuanceTime := options.MinIssuanceTime()
+
+	for _, utxo := range utxos {
+		if assetID != utxo.AssetID() {
+			continue
+		}
+
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			// wrong output type
+			continue
+		}
+
+		if !out.OutputOwners.Equals(from) {
+			continue
+		}
+
+		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		if !ok {
+			continue
+		}
+
+		// add the operation to the array
+		operations = append(operations, &txs.Operation{
+			Asset: avax.Asset{ID: assetID},
+			UTXOIDs: []*avax.UTXOID{
+				&utxo.UTXOID,
+			},
+			Op: &secp256k1fx.RotateOwnersOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: inputSigIndices,
+				},
+				TransferOutput: secp256k1fx.TransferOutput{
+					Amt:          out.Amt,
+					OutputOwners: *to,
+				},
+			},
+		})
+	}
+	if len(operations) == 0 {
+		return nil, fmt.Errorf(
+			"%w: provided UTXOs not able to rotate owners of asset %q",
+			errInsufficientFunds,
+			assetID,
+		)
+	}
+	return operations, nil
+}
+
+func (b *builder) updateMetadata(
+	assetID ids.ID,
+	name string,
+	symbol string,
+	denomination byte,
+	uri string,
+	options *common.Options,
+) (
+	operations []*txs.Operation,
+	err error,
+) {
+	utxos, err := b.backend.UTXOs(options.Context(), b.backend.BlockchainID())
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := options.Addresses(b.addrs)
+	minIssuanceTime := options.MinIssuanceTime()
+
+	for _, utxo := range utxos {
+		if assetID != utxo.AssetID() {
+			continue
+		}
+
+		out, ok := utxo.Out.(*secp256k1fx.MintOutput)
+		if !ok {
+			continue
+		}
+
+		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		if !ok {
+			continue
+		}
+
+		return []*txs.Operation{
+			{
+				Asset:   utxo.Asset,
+				UTXOIDs: []*avax.UTXOID{&utxo.UTXOID},
+				Op: &secp256k1fx.UpdateMetadataOperation{
+					MintInput: secp256k1fx.Input{
+						SigIndices: inputSigIndices,
+					},
+					MintOutput:   *out,
+					Name:         name,
+					Symbol:       symbol,
+					Denomination: denomination,
+					URI:          uri,
+				},
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf(
+		"%w: provided UTXOs not able to update metadata of asset %q",
+		errInsufficientFunds,
+		assetID,
+	)
+}
+
+func (b *builder) extendLocktime(
+	assetID ids.ID,
+	locktime uint64,
+	options *common.Options,
+) (
+	operations []*txs.Operation,
+	err error,
+) {
+	utxos, err := b.backend.UTXOs(options.Context(), b.backend.BlockchainID())
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := options.Addresses(b.addrs)
+
+	for _, utxo := range utxos {
+		if assetID != utxo.AssetID() {
+			continue
+		}
+
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			// wrong output type
+			continue
+		}
+
+		if out.Locktime >= locktime {
+			continue
+		}
+
+		// The output may still be locked, so its locktime isn't enforced.
+		inputSigIndices, ok := common.MatchOwners(&out.OutputOwners, addrs, out.Locktime)
+		if !ok {
+			continue
+		}
+
+		owners := out.OutputOwners
+		owners.Locktime = locktime
+
+		// add the operation to the array
+		operations = append(operations, &txs.Operation{
+			Asset: avax.Asset{ID: assetID},
+			UTXOIDs: []*avax.UTXOID{
+				&utxo.UTXOID,
+			},
+			Op: &secp256k1fx.ExtendLocktimeOperation{
+				Input: secp256k1fx.Input{
+					SigIndices: inputSigIndices,
+				},
+				TransferOutput: secp256k1fx.TransferOutput{
+					Amt:          out.Amt,
+					OutputOwners: owners,
+				},
+			},
+		})
+	}
+	if len(operations) == 0 {
+		return nil, fmt.Errorf(
+			"%w: provided UTXOs not able to extend locktime of asset %q",
+			errInsufficientFunds,
+			assetID,
+		)
+	}
+	return operations, nil
+}
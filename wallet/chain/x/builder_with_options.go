@@ -133,6 +133,50 @@ func (b *builderWithOptions) NewOperationTxBurnProperty(
 	)
 }
 
+func (b *builderWithOptions) NewOperationTxRotateOwners(
+	assetID ids.ID,
+	from *secp256k1fx.OutputOwners,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.OperationTx, error) {
+	return b.Builder.NewOperationTxRotateOwners(
+		assetID,
+		from,
+		to,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
+func (b *builderWithOptions) NewOperationTxUpdateMetadata(
+	assetID ids.ID,
+	name string,
+	symbol string,
+	denomination byte,
+	uri string,
+	options ...common.Option,
+) (*txs.OperationTx, error) {
+	return b.Builder.NewOperationTxUpdateMetadata(
+		assetID,
+		name,
+		symbol,
+		denomination,
+		uri,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
+func (b *builderWithOptions) NewOperationTxExtendLocktime(
+	assetID ids.ID,
+	locktime uint64,
+	options ...common.Option,
+) (*txs.OperationTx, error) {
+	return b.Builder.NewOperationTxExtendLocktime(
+		assetID,
+		locktime,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewImportTx(
 	chainID ids.ID,
 	to *secp256k1fx.OutputOwners,
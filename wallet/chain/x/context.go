@@ -8,7 +8,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var _ Context = (*context)(nil)
@@ -19,6 +21,10 @@ type Context interface {
 	AVAXAssetID() ids.ID
 	BaseTxFee() uint64
 	CreateAssetTxFee() uint64
+	// FeeRateOracle returns the owners authorized to publish the rate at
+	// which fees may be paid in the fee conversion asset. If nil, fees can't
+	// be converted.
+	FeeRateOracle() *secp256k1fx.OutputOwners
 }
 
 type context struct {
@@ -27,6 +33,7 @@ type context struct {
 	avaxAssetID      ids.ID
 	baseTxFee        uint64
 	createAssetTxFee uint64
+	feeRateOracle    *secp256k1fx.OutputOwners
 }
 
 func NewContextFromURI(ctx stdcontext.Context, uri string) (Context, error) {
@@ -60,12 +67,31 @@ func NewContextFromClients(
 		return nil, err
 	}
 
+	feeConversion, err := xChainClient.GetFeeConversion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var feeRateOracle *secp256k1fx.OutputOwners
+	if feeConversion.Enabled {
+		oracleAddrs, err := address.ParseToIDs(feeConversion.OracleAddresses)
+		if err != nil {
+			return nil, err
+		}
+		feeRateOracle = &secp256k1fx.OutputOwners{
+			Locktime:  uint64(feeConversion.OracleLocktime),
+			Threshold: uint32(feeConversion.OracleThreshold),
+			Addrs:     oracleAddrs,
+		}
+	}
+
 	return NewContext(
 		networkID,
 		chainID,
 		asset.AssetID,
 		uint64(txFees.TxFee),
 		uint64(txFees.CreateAssetTxFee),
+		feeRateOracle,
 	), nil
 }
 
@@ -75,6 +101,7 @@ func NewContext(
 	avaxAssetID ids.ID,
 	baseTxFee uint64,
 	createAssetTxFee uint64,
+	feeRateOracle *secp256k1fx.OutputOwners,
 ) Context {
 	return &context{
 		networkID:        networkID,
@@ -82,6 +109,7 @@ func NewContext(
 		avaxAssetID:      avaxAssetID,
 		baseTxFee:        baseTxFee,
 		createAssetTxFee: createAssetTxFee,
+		feeRateOracle:    feeRateOracle,
 	}
 }
 
@@ -104,3 +132,7 @@ func (c *context) BaseTxFee() uint64 {
 func (c *context) CreateAssetTxFee() uint64 {
 	return c.createAssetTxFee
 }
+
+func (c *context) FeeRateOracle() *secp256k1fx.OutputOwners {
+	return c.feeRateOracle
+}
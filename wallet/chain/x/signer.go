@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var _ Signer = (*signer)(nil)
@@ -21,6 +22,9 @@ type Signer interface {
 
 type SignerBackend interface {
 	GetUTXO(ctx stdcontext.Context, chainID, utxoID ids.ID) (*avax.UTXO, error)
+	// FeeRateOracle returns the owners authorized to issue FeeRateTxs. If
+	// nil, fees can't be converted.
+	FeeRateOracle() *secp256k1fx.OutputOwners
 }
 
 type signer struct {
@@ -31,6 +31,7 @@ var (
 	errUnknownCredentialType = errors.New("unknown credential type")
 	errUnknownOutputType     = errors.New("unknown output type")
 	errInvalidUTXOSigIndex   = errors.New("invalid UTXO signature index")
+	errNoFeeRateOracle       = errors.New("fee conversion isn't enabled")
 
 	emptySig [secp256k1.SignatureLen]byte
 )
@@ -59,6 +60,10 @@ func (s *signerVisitor) CreateAssetTx(tx *txs.CreateAssetTx) error {
 	return sign(s.tx, txCreds, txSigners)
 }
 
+func (s *signerVisitor) CreateCappedAssetTx(tx *txs.CreateCappedAssetTx) error {
+	return s.CreateAssetTx(&tx.CreateAssetTx)
+}
+
 func (s *signerVisitor) OperationTx(tx *txs.OperationTx) error {
 	txCreds, txSigners, err := s.getSigners(s.ctx, tx.BlockchainID, tx.Ins)
 	if err != nil {
@@ -95,6 +100,20 @@ func (s *signerVisitor) ExportTx(tx *txs.ExportTx) error {
 	return sign(s.tx, txCreds, txSigners)
 }
 
+func (s *signerVisitor) FeeRateTx(tx *txs.FeeRateTx) error {
+	txCreds, txSigners, err := s.getSigners(s.ctx, tx.BlockchainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	oracleSigners, err := s.getOracleSigners(&tx.OracleAuth)
+	if err != nil {
+		return err
+	}
+	txCreds = append(txCreds, &secp256k1fx.Credential{})
+	txSigners = append(txSigners, oracleSigners)
+	return sign(s.tx, txCreds, txSigners)
+}
+
 func (s *signerVisitor) getSigners(ctx stdcontext.Context, sourceChainID ids.ID, ins []*avax.TransferableInput) ([]verify.Verifiable, [][]keychain.Signer, error) {
 	txCreds := make([]verify.Verifiable, len(ins))
 	txSigners := make([][]keychain.Signer, len(ins))
@@ -142,6 +161,30 @@ func (s *signerVisitor) getSigners(ctx stdcontext.Context, sourceChainID ids.ID,
 	return txCreds, txSigners, nil
 }
 
+func (s *signerVisitor) getOracleSigners(oracleAuth *secp256k1fx.Input) ([]keychain.Signer, error) {
+	oracle := s.backend.FeeRateOracle()
+	if oracle == nil {
+		return nil, errNoFeeRateOracle
+	}
+
+	oracleSigners := make([]keychain.Signer, len(oracleAuth.SigIndices))
+	for sigIndex, addrIndex := range oracleAuth.SigIndices {
+		if addrIndex >= uint32(len(oracle.Addrs)) {
+			return nil, errInvalidUTXOSigIndex
+		}
+
+		addr := oracle.Addrs[addrIndex]
+		key, ok := s.kc.Get(addr)
+		if !ok {
+			// If we don't have access to the key, then we can't sign this
+			// transaction. However, we can attempt to partially sign it.
+			continue
+		}
+		oracleSigners[sigIndex] = key
+	}
+	return oracleSigners, nil
+}
+
 func (s *signerVisitor) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.ID, ops []*txs.Operation) ([]verify.Verifiable, [][]keychain.Signer, error) {
 	txCreds := make([]verify.Verifiable, len(ops))
 	txSigners := make([][]keychain.Signer, len(ops))
@@ -151,6 +194,15 @@ func (s *signerVisitor) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.
 		case *secp256k1fx.MintOperation:
 			txCreds[credIndex] = &secp256k1fx.Credential{}
 			input = &op.MintInput
+		case *secp256k1fx.RotateOwnersOperation:
+			txCreds[credIndex] = &secp256k1fx.Credential{}
+			input = &op.Input
+		case *secp256k1fx.UpdateMetadataOperation:
+			txCreds[credIndex] = &secp256k1fx.Credential{}
+			input = &op.MintInput
+		case *secp256k1fx.ExtendLocktimeOperation:
+			txCreds[credIndex] = &secp256k1fx.Credential{}
+			input = &op.Input
 		case *nftfx.MintOperation:
 			txCreds[credIndex] = &nftfx.Credential{}
 			input = &op.MintInput
@@ -188,6 +240,8 @@ func (s *signerVisitor) getOpsSigners(ctx stdcontext.Context, sourceChainID ids.
 		switch out := utxo.Out.(type) {
 		case *secp256k1fx.MintOutput:
 			addrs = out.Addrs
+		case *secp256k1fx.TransferOutput:
+			addrs = out.Addrs
 		case *nftfx.MintOutput:
 			addrs = out.Addrs
 		case *nftfx.TransferOutput:
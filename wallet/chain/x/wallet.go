@@ -109,6 +109,54 @@ type Wallet interface {
 		options ...common.Option,
 	) (*txs.Tx, error)
 
+	// IssueOperationTxRotateOwners creates, signs, and issues state changes
+	// that move all the outputs of the requested asset that are owned by
+	// [from] to be owned by [to], without changing their amounts.
+	//
+	// - [assetID] specifies the asset of the outputs to rotate.
+	// - [from] specifies the current owners of the outputs.
+	// - [to] specifies the new owners of the outputs.
+	IssueOperationTxRotateOwners(
+		assetID ids.ID,
+		from *secp256k1fx.OutputOwners,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.Tx, error)
+
+	// IssueOperationTxUpdateMetadata creates, signs, and issues a state
+	// change that updates the on-chain metadata of the requested asset. The
+	// metadata can only be updated by a minter of the asset.
+	//
+	// - [assetID] specifies the asset to update the metadata of.
+	// - [name] specifies the new human readable name of the asset.
+	// - [symbol] specifies the new shorthand representation of the asset.
+	// - [denomination] specifies the new number of digits after the decimal
+	//   point of the asset.
+	// - [uri] specifies where additional information about the asset can be
+	//   found.
+	IssueOperationTxUpdateMetadata(
+		assetID ids.ID,
+		name string,
+		symbol string,
+		denomination byte,
+		uri string,
+		options ...common.Option,
+	) (*txs.Tx, error)
+
+	// IssueOperationTxExtendLocktime creates, signs, and issues state changes
+	// that extend the locktime of all the outputs of the requested asset that
+	// are owned by this wallet to [locktime], without changing their amounts
+	// or owners. Outputs that are locked until after [locktime] are left
+	// unchanged.
+	//
+	// - [assetID] specifies the asset of the outputs to extend.
+	// - [locktime] specifies the new locktime of the outputs.
+	IssueOperationTxExtendLocktime(
+		assetID ids.ID,
+		locktime uint64,
+		options ...common.Option,
+	) (*txs.Tx, error)
+
 	// IssueImportTx creates, signs, and issues an import transaction that
 	// attempts to consume all the available UTXOs and import the funds to [to].
 	//
@@ -256,6 +304,46 @@ func (w *wallet) IssueOperationTxBurnProperty(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueOperationTxRotateOwners(
+	assetID ids.ID,
+	from *secp256k1fx.OutputOwners,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	utx, err := w.builder.NewOperationTxRotateOwners(assetID, from, to, options...)
+	if err != nil {
+		return nil, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueOperationTxUpdateMetadata(
+	assetID ids.ID,
+	name string,
+	symbol string,
+	denomination byte,
+	uri string,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	utx, err := w.builder.NewOperationTxUpdateMetadata(assetID, name, symbol, denomination, uri, options...)
+	if err != nil {
+		return nil, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueOperationTxExtendLocktime(
+	assetID ids.ID,
+	locktime uint64,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	utx, err := w.builder.NewOperationTxExtendLocktime(assetID, locktime, options...)
+	if err != nil {
+		return nil, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueImportTx(
 	chainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -118,6 +118,50 @@ func (w *walletWithOptions) IssueOperationTxBurnProperty(
 	)
 }
 
+func (w *walletWithOptions) IssueOperationTxRotateOwners(
+	assetID ids.ID,
+	from *secp256k1fx.OutputOwners,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.Wallet.IssueOperationTxRotateOwners(
+		assetID,
+		from,
+		to,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueOperationTxUpdateMetadata(
+	assetID ids.ID,
+	name string,
+	symbol string,
+	denomination byte,
+	uri string,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.Wallet.IssueOperationTxUpdateMetadata(
+		assetID,
+		name,
+		symbol,
+		denomination,
+		uri,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueOperationTxExtendLocktime(
+	assetID ids.ID,
+	locktime uint64,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.Wallet.IssueOperationTxExtendLocktime(
+		assetID,
+		locktime,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueImportTx(
 	chainID ids.ID,
 	to *secp256k1fx.OutputOwners,
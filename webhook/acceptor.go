@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package webhook
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+var _ snow.Acceptor = (*Acceptor)(nil)
+
+// TxActivity is the UTXOs a transaction consumed and produced.
+type TxActivity struct {
+	TxID ids.ID
+	// InputIDs are the IDs of the UTXOs the transaction consumed.
+	InputIDs set.Set[ids.ID]
+	// UTXOs are the UTXOs the transaction produced.
+	UTXOs []*avax.UTXO
+}
+
+// ActivityParser extracts the address activity from an accepted container.
+type ActivityParser func(container []byte) ([]TxActivity, error)
+
+// Acceptor dispatches an event for every accepted transaction that consumed or
+// produced a UTXO owned by a watched address.
+//
+// The owners of consumed UTXOs are resolved from the UTXOs owned by watched
+// addresses that the acceptor previously accepted. Spends of UTXOs that were
+// produced before the address was watched, or that were imported from another
+// chain, aren't reported.
+type Acceptor struct {
+	dispatcher *Dispatcher
+	parser     ActivityParser
+	// utxos maps the ID of an unspent UTXO to the watched addresses that own
+	// it.
+	utxos      database.Database
+	chainAlias string
+	hrp        string
+	// watched maps an address to the endpoints that are watching it.
+	watched map[ids.ShortID][]*Endpoint
+}
+
+// NewAcceptor returns an acceptor for the chain with [chainAlias]. Only the
+// addresses of [endpoints] that are prefixed with [chainAlias] are watched.
+// The UTXOs owned by watched addresses are tracked in [utxos].
+func NewAcceptor(
+	dispatcher *Dispatcher,
+	parser ActivityParser,
+	utxos database.Database,
+	chainAlias string,
+	hrp string,
+	endpoints []Endpoint,
+) (*Acceptor, error) {
+	watched := make(map[ids.ShortID][]*Endpoint)
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		for _, addrStr := range endpoint.Addresses {
+			alias, _, addrBytes, err := address.Parse(addrStr)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+			}
+			if alias != chainAlias {
+				continue
+			}
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+			}
+			watched[addr] = append(watched[addr], endpoint)
+		}
+	}
+	return &Acceptor{
+		dispatcher: dispatcher,
+		parser:     parser,
+		utxos:      utxos,
+		chainAlias: chainAlias,
+		hrp:        hrp,
+		watched:    watched,
+	}, nil
+}
+
+// Watching returns true if any address is watched on this chain.
+func (a *Acceptor) Watching() bool {
+	return len(a.watched) > 0
+}
+
+func (a *Acceptor) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	activity, err := a.parser(container)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, tx := range activity {
+		addrs, err := a.spend(tx.InputIDs)
+		if err != nil {
+			return err
+		}
+		produced, err := a.produce(tx.UTXOs)
+		if err != nil {
+			return err
+		}
+		addrs.Union(produced)
+
+		for addr := range addrs {
+			addrStr, err := address.Format(a.chainAlias, a.hrp, addr[:])
+			if err != nil {
+				return err
+			}
+			for _, endpoint := range a.watched[addr] {
+				err := a.dispatcher.Dispatch(endpoint, &Event{
+					ChainID:   ctx.ChainID,
+					BlockID:   containerID,
+					TxID:      tx.TxID,
+					Address:   addrStr,
+					Timestamp: now,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// spend removes the UTXOs with [utxoIDs] and returns the watched addresses
+// that owned them.
+func (a *Acceptor) spend(utxoIDs set.Set[ids.ID]) (set.Set[ids.ShortID], error) {
+	addrs := set.Set[ids.ShortID]{}
+	for utxoID := range utxoIDs {
+		ownersBytes, err := a.utxos.Get(utxoID[:])
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for len(ownersBytes) >= ids.ShortIDLen {
+			addrs.Add(ids.ShortID(ownersBytes[:ids.ShortIDLen]))
+			ownersBytes = ownersBytes[ids.ShortIDLen:]
+		}
+		if err := a.utxos.Delete(utxoID[:]); err != nil {
+			return nil, err
+		}
+	}
+	return addrs, nil
+}
+
+// produce tracks the [utxos] that are owned by watched addresses and returns
+// the watched addresses that own them.
+func (a *Acceptor) produce(utxos []*avax.UTXO) (set.Set[ids.ShortID], error) {
+	addrs := set.Set[ids.ShortID]{}
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+
+		var ownersBytes []byte
+		for _, addrBytes := range out.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				continue
+			}
+			if _, ok := a.watched[addr]; !ok {
+				continue
+			}
+			addrs.Add(addr)
+			ownersBytes = append(ownersBytes, addr[:]...)
+		}
+		if len(ownersBytes) == 0 {
+			continue
+		}
+
+		utxoID := utxo.InputID()
+		if err := a.utxos.Put(utxoID[:], ownersBytes); err != nil {
+			return nil, err
+		}
+	}
+	return addrs, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+)
+
+var (
+	errNoURL                 = errors.New("webhook endpoint is missing a url")
+	errNoAddresses           = errors.New("webhook endpoint is not watching any addresses")
+	errInvalidMaxAttempts    = errors.New("max attempts must be positive")
+	errInvalidNumWorkers     = errors.New("number of workers must be positive")
+	errInvalidQueueSize      = errors.New("queue size must be positive")
+	errInvalidRetryDelay     = errors.New("initial retry delay must not exceed max retry delay")
+	errInvalidRequestTimeout = errors.New("request timeout must be positive")
+)
+
+var DefaultConfig = Config{
+	MaxAttempts:       5,
+	NumWorkers:        4,
+	QueueSize:         1024,
+	InitialRetryDelay: time.Second,
+	MaxRetryDelay:     time.Minute,
+	RequestTimeout:    10 * time.Second,
+}
+
+// Endpoint is a destination that events are POSTed to.
+type Endpoint struct {
+	// URL that events are POSTed to.
+	URL string `json:"url"`
+	// Secret is used as the HMAC-SHA256 key to sign every request. If empty,
+	// requests are not signed.
+	Secret string `json:"secret"`
+	// Addresses are the chain-prefixed bech32 addresses (e.g. "X-avax1...")
+	// whose activity is reported to this endpoint.
+	Addresses []string `json:"addresses"`
+}
+
+type Config struct {
+	Endpoints []Endpoint `json:"endpoints"`
+
+	// MaxAttempts is the number of times delivery of an event is attempted
+	// before it is moved to the dead-letter queue.
+	MaxAttempts int `json:"maxAttempts"`
+	// NumWorkers is the number of concurrent deliveries.
+	NumWorkers int `json:"numWorkers"`
+	// QueueSize is the number of events that can be pending delivery. If the
+	// queue is full, new events are moved directly to the dead-letter queue.
+	QueueSize int `json:"queueSize"`
+	// InitialRetryDelay is the delay before the first retry. The delay doubles
+	// after every failed attempt, up to MaxRetryDelay.
+	InitialRetryDelay time.Duration `json:"initialRetryDelay"`
+	MaxRetryDelay     time.Duration `json:"maxRetryDelay"`
+	// RequestTimeout bounds the duration of a single delivery attempt.
+	RequestTimeout time.Duration `json:"requestTimeout"`
+}
+
+// Enabled returns true if at least one endpoint is configured.
+func (c *Config) Enabled() bool {
+	return len(c.Endpoints) > 0
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.MaxAttempts <= 0:
+		return errInvalidMaxAttempts
+	case c.NumWorkers <= 0:
+		return errInvalidNumWorkers
+	case c.QueueSize <= 0:
+		return errInvalidQueueSize
+	case c.InitialRetryDelay > c.MaxRetryDelay:
+		return errInvalidRetryDelay
+	case c.RequestTimeout <= 0:
+		return errInvalidRequestTimeout
+	}
+
+	for i, endpoint := range c.Endpoints {
+		if endpoint.URL == "" {
+			return fmt.Errorf("%w: endpoint %d", errNoURL, i)
+		}
+		if _, err := url.ParseRequestURI(endpoint.URL); err != nil {
+			return fmt.Errorf("endpoint %d has invalid url: %w", i, err)
+		}
+		if len(endpoint.Addresses) == 0 {
+			return fmt.Errorf("%w: %s", errNoAddresses, endpoint.URL)
+		}
+		for _, addr := range endpoint.Addresses {
+			if _, _, _, err := address.Parse(addr); err != nil {
+				return fmt.Errorf("endpoint %s has invalid address %q: %w", endpoint.URL, addr, err)
+			}
+		}
+	}
+	return nil
+}
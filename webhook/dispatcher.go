@@ -0,0 +1,325 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const (
+	EventIDHeader   = "X-Webhook-Event-Id"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	errQueueFull         = errors.New("delivery queue is full")
+	errShutdown          = errors.New("dispatcher is shutting down")
+	errUnexpectedStatus  = errors.New("unexpected response status")
+	errDispatcherStopped = errors.New("dispatcher is stopped")
+)
+
+// Event describes activity of a watched address.
+type Event struct {
+	ChainID ids.ID `json:"chainID"`
+	BlockID ids.ID `json:"blockID"`
+	TxID    ids.ID `json:"txID"`
+	// Address is the watched address, formatted with its chain alias.
+	Address string `json:"address"`
+	// Timestamp is the local time that the block was accepted.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ID uniquely identifies the event, so that receivers can de-duplicate
+// retried deliveries.
+func (e *Event) ID() ids.ID {
+	return hashing.ComputeHash256Array(
+		[]byte(e.ChainID.String() + e.BlockID.String() + e.TxID.String() + e.Address),
+	)
+}
+
+// DeadLetter is an event that could not be delivered.
+type DeadLetter struct {
+	ID       ids.ID `json:"id"`
+	URL      string `json:"url"`
+	Event    *Event `json:"event"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error"`
+}
+
+type delivery struct {
+	id       ids.ID
+	endpoint *Endpoint
+	event    *Event
+	payload  []byte
+}
+
+// Dispatcher asynchronously POSTs events to endpoints. Events that can not be
+// delivered after the configured number of attempts are persisted into a
+// dead-letter queue.
+type Dispatcher struct {
+	log    logging.Logger
+	config Config
+	client *http.Client
+	clock  mockable.Clock
+
+	// deadLetters maps delivery ID to a json encoded DeadLetter.
+	deadLettersLock sync.Mutex
+	deadLetters     database.Database
+
+	queue chan *delivery
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+func NewDispatcher(
+	log logging.Logger,
+	config Config,
+	deadLetters database.Database,
+) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		log:         log,
+		config:      config,
+		client:      &http.Client{Timeout: config.RequestTimeout},
+		deadLetters: deadLetters,
+		queue:       make(chan *delivery, config.QueueSize),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Start spawns the workers that deliver events.
+func (d *Dispatcher) Start() {
+	d.startOnce.Do(func() {
+		d.wg.Add(d.config.NumWorkers)
+		for i := 0; i < d.config.NumWorkers; i++ {
+			go d.work()
+		}
+	})
+}
+
+// Shutdown stops delivering events and waits for in-flight attempts to
+// finish. Events that have not been delivered are moved to the dead-letter
+// queue.
+func (d *Dispatcher) Shutdown() {
+	d.stopOnce.Do(func() {
+		d.cancel()
+		d.wg.Wait()
+
+		for {
+			select {
+			case dl := <-d.queue:
+				d.deadLetter(dl, 0, errShutdown)
+			default:
+				return
+			}
+		}
+	})
+}
+
+// Dispatch schedules [event] to be delivered to [endpoint]. Dispatch never
+// blocks; if the queue is full the event is moved to the dead-letter queue.
+func (d *Dispatcher) Dispatch(endpoint *Endpoint, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	eventID := event.ID()
+	dl := &delivery{
+		id:       hashing.ComputeHash256Array(append(eventID[:], endpoint.URL...)),
+		endpoint: endpoint,
+		event:    event,
+		payload:  payload,
+	}
+	if d.ctx.Err() != nil {
+		d.deadLetter(dl, 0, errDispatcherStopped)
+		return nil
+	}
+
+	select {
+	case d.queue <- dl:
+	default:
+		d.deadLetter(dl, 0, errQueueFull)
+	}
+	return nil
+}
+
+// DeadLetters returns all events that failed to be delivered.
+func (d *Dispatcher) DeadLetters() ([]*DeadLetter, error) {
+	d.deadLettersLock.Lock()
+	defer d.deadLettersLock.Unlock()
+
+	it := d.deadLetters.NewIterator()
+	defer it.Release()
+
+	var deadLetters []*DeadLetter
+	for it.Next() {
+		deadLetter := &DeadLetter{}
+		if err := json.Unmarshal(it.Value(), deadLetter); err != nil {
+			return nil, err
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+	return deadLetters, it.Error()
+}
+
+// RemoveDeadLetter removes the dead letter with [id] from the queue.
+func (d *Dispatcher) RemoveDeadLetter(id ids.ID) error {
+	d.deadLettersLock.Lock()
+	defer d.deadLettersLock.Unlock()
+
+	return d.deadLetters.Delete(id[:])
+}
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case dl := <-d.queue:
+			d.deliver(dl)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(dl *delivery) {
+	var (
+		delay = d.config.InitialRetryDelay
+		err   error
+	)
+	for attempt := 1; attempt <= d.config.MaxAttempts; attempt++ {
+		err = d.post(dl)
+		if err == nil {
+			return
+		}
+
+		d.log.Debug("failed to deliver webhook event",
+			zap.String("url", dl.endpoint.URL),
+			zap.Stringer("deliveryID", dl.id),
+			zap.Int("attempt", attempt),
+			zap.Error(err),
+		)
+		if attempt == d.config.MaxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-d.ctx.Done():
+			timer.Stop()
+			d.deadLetter(dl, attempt, errShutdown)
+			return
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > d.config.MaxRetryDelay {
+			delay = d.config.MaxRetryDelay
+		}
+	}
+	d.deadLetter(dl, d.config.MaxAttempts, err)
+}
+
+func (d *Dispatcher) post(dl *delivery) error {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, dl.endpoint.URL, bytes.NewReader(dl.payload))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatUint(d.clock.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, dl.id.String())
+	req.Header.Set(TimestampHeader, timestamp)
+	if dl.endpoint.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign([]byte(dl.endpoint.Secret), timestamp, dl.payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", errUnexpectedStatus, resp.StatusCode)
+	}
+	return nil
+}
+
+func (d *Dispatcher) deadLetter(dl *delivery, attempts int, cause error) {
+	d.log.Warn("moving webhook event to dead-letter queue",
+		zap.String("url", dl.endpoint.URL),
+		zap.Stringer("deliveryID", dl.id),
+		zap.Int("attempts", attempts),
+		zap.Error(cause),
+	)
+
+	deadLetterBytes, err := json.Marshal(&DeadLetter{
+		ID:       dl.id,
+		URL:      dl.endpoint.URL,
+		Event:    dl.event,
+		Attempts: attempts,
+		Error:    cause.Error(),
+	})
+	if err != nil {
+		d.log.Error("failed to marshal dead letter",
+			zap.Stringer("deliveryID", dl.id),
+			zap.Error(err),
+		)
+		return
+	}
+
+	d.deadLettersLock.Lock()
+	defer d.deadLettersLock.Unlock()
+
+	if err := d.deadLetters.Put(dl.id[:], deadLetterBytes); err != nil {
+		d.log.Error("failed to persist dead letter",
+			zap.Stringer("deliveryID", dl.id),
+			zap.Error(err),
+		)
+	}
+}
+
+// Sign returns the value of the signature header for a request with [payload]
+// that was sent at [timestamp].
+func Sign(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(timestamp))
+	_, _ = mac.Write([]byte{'.'})
+	_, _ = mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,260 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newTestConfig() Config {
+	config := DefaultConfig
+	config.MaxAttempts = 3
+	config.InitialRetryDelay = time.Millisecond
+	config.MaxRetryDelay = time.Millisecond
+	return config
+}
+
+func TestDispatcherDelivers(t *testing.T) {
+	require := require.New(t)
+
+	secret := []byte("secret")
+	received := make(chan *Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+
+		timestamp := r.Header.Get(TimestampHeader)
+		require.Equal(Sign(secret, timestamp, body), r.Header.Get(SignatureHeader))
+
+		event := &Event{}
+		require.NoError(json.Unmarshal(body, event))
+		received <- event
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(logging.NoLog{}, newTestConfig(), memdb.New())
+	dispatcher.Start()
+	defer dispatcher.Shutdown()
+
+	event := &Event{
+		ChainID: ids.GenerateTestID(),
+		BlockID: ids.GenerateTestID(),
+		TxID:    ids.GenerateTestID(),
+		Address: "X-custom1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqd9zm0x",
+	}
+	endpoint := &Endpoint{
+		URL:    server.URL,
+		Secret: string(secret),
+	}
+	require.NoError(dispatcher.Dispatch(endpoint, event))
+
+	select {
+	case got := <-received:
+		require.Equal(event.TxID, got.TxID)
+		require.Equal(event.Address, got.Address)
+	case <-time.After(5 * time.Second):
+		require.FailNow("event was not delivered")
+	}
+}
+
+func TestDispatcherDeadLetters(t *testing.T) {
+	require := require.New(t)
+
+	attempts := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts <- struct{}{}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := newTestConfig()
+	dispatcher := NewDispatcher(logging.NoLog{}, config, memdb.New())
+	dispatcher.Start()
+
+	event := &Event{
+		TxID: ids.GenerateTestID(),
+	}
+	require.NoError(dispatcher.Dispatch(&Endpoint{URL: server.URL}, event))
+
+	for i := 0; i < config.MaxAttempts; i++ {
+		select {
+		case <-attempts:
+		case <-time.After(5 * time.Second):
+			require.FailNow("delivery was not retried")
+		}
+	}
+
+	// Shutdown waits for the in-flight delivery to be moved to the
+	// dead-letter queue.
+	dispatcher.Shutdown()
+
+	deadLetters, err := dispatcher.DeadLetters()
+	require.NoError(err)
+	require.Len(deadLetters, 1)
+	require.Equal(server.URL, deadLetters[0].URL)
+	require.Equal(event.TxID, deadLetters[0].Event.TxID)
+	require.Equal(config.MaxAttempts, deadLetters[0].Attempts)
+
+	require.NoError(dispatcher.RemoveDeadLetter(deadLetters[0].ID))
+	deadLetters, err = dispatcher.DeadLetters()
+	require.NoError(err)
+	require.Empty(deadLetters)
+}
+
+func newTestUTXO(txID ids.ID, addrs ...ids.ShortID) *avax.UTXO {
+	return &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: txID,
+		},
+		Asset: avax.Asset{
+			ID: ids.GenerateTestID(),
+		},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     addrs,
+			},
+		},
+	}
+}
+
+func TestAcceptorDispatchesWatchedAddresses(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		hrp         = constants.GetHRP(constants.UnitTestID)
+		watchedAddr = ids.GenerateTestShortID()
+		ignoredAddr = ids.GenerateTestShortID()
+		txID        = ids.GenerateTestID()
+		blkID       = ids.GenerateTestID()
+	)
+	watchedStr, err := address.Format("X", hrp, watchedAddr[:])
+	require.NoError(err)
+	// Addresses on other chains are not watched by this acceptor.
+	otherChainStr, err := address.Format("P", hrp, ignoredAddr[:])
+	require.NoError(err)
+
+	// The dispatcher isn't started, so dispatched events remain queued.
+	dispatcher := NewDispatcher(logging.NoLog{}, newTestConfig(), memdb.New())
+	parser := func([]byte) ([]TxActivity, error) {
+		return []TxActivity{
+			{
+				TxID:  txID,
+				UTXOs: []*avax.UTXO{newTestUTXO(txID, watchedAddr, ignoredAddr)},
+			},
+		}, nil
+	}
+	acceptor, err := NewAcceptor(
+		dispatcher,
+		parser,
+		memdb.New(),
+		"X",
+		hrp,
+		[]Endpoint{
+			{
+				URL:       "http://localhost",
+				Addresses: []string{watchedStr, otherChainStr},
+			},
+		},
+	)
+	require.NoError(err)
+	require.True(acceptor.Watching())
+
+	ctx := snow.DefaultConsensusContextTest()
+	require.NoError(acceptor.Accept(ctx, blkID, nil))
+
+	require.Len(dispatcher.queue, 1)
+	dl := <-dispatcher.queue
+	require.Equal(txID, dl.event.TxID)
+	require.Equal(blkID, dl.event.BlockID)
+	require.Equal(watchedStr, dl.event.Address)
+}
+
+func TestAcceptorDispatchesSpends(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		hrp         = constants.GetHRP(constants.UnitTestID)
+		watchedAddr = ids.GenerateTestShortID()
+		produceTxID = ids.GenerateTestID()
+		spendTxID   = ids.GenerateTestID()
+		utxo        = newTestUTXO(produceTxID, watchedAddr)
+		utxoID      = utxo.InputID()
+	)
+	watchedStr, err := address.Format("X", hrp, watchedAddr[:])
+	require.NoError(err)
+
+	activity := map[string][]TxActivity{
+		"produce": {{
+			TxID:  produceTxID,
+			UTXOs: []*avax.UTXO{utxo},
+		}},
+		// The spend only produces outputs for unwatched addresses.
+		"spend": {{
+			TxID:     spendTxID,
+			InputIDs: set.Of(utxoID, ids.GenerateTestID()),
+			UTXOs:    []*avax.UTXO{newTestUTXO(spendTxID, ids.GenerateTestShortID())},
+		}},
+	}
+	parser := func(container []byte) ([]TxActivity, error) {
+		return activity[string(container)], nil
+	}
+
+	// The dispatcher isn't started, so dispatched events remain queued.
+	dispatcher := NewDispatcher(logging.NoLog{}, newTestConfig(), memdb.New())
+	utxos := memdb.New()
+	acceptor, err := NewAcceptor(
+		dispatcher,
+		parser,
+		utxos,
+		"X",
+		hrp,
+		[]Endpoint{
+			{
+				URL:       "http://localhost",
+				Addresses: []string{watchedStr},
+			},
+		},
+	)
+	require.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	require.NoError(acceptor.Accept(ctx, ids.GenerateTestID(), []byte("produce")))
+	require.Len(dispatcher.queue, 1)
+	dl := <-dispatcher.queue
+	require.Equal(produceTxID, dl.event.TxID)
+
+	has, err := utxos.Has(utxoID[:])
+	require.NoError(err)
+	require.True(has)
+
+	require.NoError(acceptor.Accept(ctx, ids.GenerateTestID(), []byte("spend")))
+	require.Len(dispatcher.queue, 1)
+	dl = <-dispatcher.queue
+	require.Equal(spendTxID, dl.event.TxID)
+	require.Equal(watchedStr, dl.event.Address)
+
+	// Spent UTXOs are no longer tracked.
+	has, err = utxos.Has(utxoID[:])
+	require.NoError(err)
+	require.False(has)
+}
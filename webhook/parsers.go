@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package webhook
+
+import (
+	avmblock "github.com/ava-labs/avalanchego/vms/avm/block"
+	platformblock "github.com/ava-labs/avalanchego/vms/platformvm/block"
+	proposerblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+// NewXChainParser returns a parser of accepted X-chain blocks. [parser] must
+// support the fxs that the X-chain is run with.
+func NewXChainParser(parser avmblock.Parser) ActivityParser {
+	return func(container []byte) ([]TxActivity, error) {
+		blk, err := parser.ParseBlock(innerBlock(container))
+		if err != nil {
+			return nil, err
+		}
+
+		txs := blk.Txs()
+		activity := make([]TxActivity, len(txs))
+		for i, tx := range txs {
+			activity[i] = TxActivity{
+				TxID:     tx.ID(),
+				InputIDs: tx.Unsigned.InputIDs(),
+				UTXOs:    tx.UTXOs(),
+			}
+		}
+		return activity, nil
+	}
+}
+
+// PChainParser parses accepted P-chain blocks.
+func PChainParser(container []byte) ([]TxActivity, error) {
+	blk, err := platformblock.Parse(platformblock.Codec, innerBlock(container))
+	if err != nil {
+		return nil, err
+	}
+
+	txs := blk.Txs()
+	activity := make([]TxActivity, len(txs))
+	for i, tx := range txs {
+		activity[i] = TxActivity{
+			TxID:     tx.ID(),
+			InputIDs: tx.Unsigned.InputIDs(),
+			UTXOs:    tx.UTXOs(),
+		}
+	}
+	return activity, nil
+}
+
+// innerBlock returns the block wrapped by the proposervm block [container].
+// Blocks accepted before the proposervm fork aren't wrapped, so [container] is
+// returned if it isn't a proposervm block.
+func innerBlock(container []byte) []byte {
+	blk, err := proposerblock.Parse(container)
+	if err != nil {
+		return container
+	}
+	return blk.Block()
+}
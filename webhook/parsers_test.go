@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	avmblock "github.com/ava-labs/avalanchego/vms/avm/block"
+	avmtxs "github.com/ava-labs/avalanchego/vms/avm/txs"
+	platformblock "github.com/ava-labs/avalanchego/vms/platformvm/block"
+	platformtxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	proposerblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+func newTestBaseTx(addr ids.ShortID) (avax.BaseTx, *avax.UTXOID) {
+	input := &avax.UTXOID{
+		TxID: ids.GenerateTestID(),
+	}
+	assetID := ids.GenerateTestID()
+	return avax.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: ids.GenerateTestID(),
+		Ins: []*avax.TransferableInput{{
+			UTXOID: *input,
+			Asset:  avax.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: 2,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}},
+	}, input
+}
+
+// wrap returns [blkBytes] as a pre-fork container and as a post-fork container
+// that is wrapped by the proposervm.
+func wrap(t *testing.T, blkBytes []byte) map[string][]byte {
+	proposerBlk, err := proposerblock.BuildUnsigned(
+		ids.GenerateTestID(),
+		time.Unix(1_700_000_000, 0),
+		1,
+		blkBytes,
+	)
+	require.NoError(t, err)
+	return map[string][]byte{
+		"pre-fork":  blkBytes,
+		"post-fork": proposerBlk.Bytes(),
+	}
+}
+
+func requireActivity(t *testing.T, activity []TxActivity, txID ids.ID, input *avax.UTXOID, addr ids.ShortID) {
+	require := require.New(t)
+
+	require.Len(activity, 1)
+	require.Equal(txID, activity[0].TxID)
+	require.True(activity[0].InputIDs.Contains(input.InputID()))
+	require.Len(activity[0].UTXOs, 1)
+	out, ok := activity[0].UTXOs[0].Out.(avax.Addressable)
+	require.True(ok)
+	require.Equal([][]byte{addr[:]}, out.Addresses())
+}
+
+func TestXChainParser(t *testing.T) {
+	parser, err := avmblock.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+	})
+	require.NoError(t, err)
+
+	addr := ids.GenerateTestShortID()
+	baseTx, input := newTestBaseTx(addr)
+	tx := &avmtxs.Tx{
+		Unsigned: &avmtxs.BaseTx{BaseTx: baseTx},
+		Creds: []*fxs.FxCredential{{
+			Credential: &secp256k1fx.Credential{},
+		}},
+	}
+	require.NoError(t, tx.Initialize(parser.Codec()))
+
+	blk, err := avmblock.NewStandardBlock(
+		ids.GenerateTestID(),
+		1,
+		time.Unix(1_700_000_000, 0),
+		[]*avmtxs.Tx{tx},
+		parser.Codec(),
+	)
+	require.NoError(t, err)
+
+	parse := NewXChainParser(parser)
+	for name, container := range wrap(t, blk.Bytes()) {
+		t.Run(name, func(t *testing.T) {
+			activity, err := parse(container)
+			require.NoError(t, err)
+			requireActivity(t, activity, tx.ID(), input, addr)
+		})
+	}
+}
+
+func TestPChainParser(t *testing.T) {
+	addr := ids.GenerateTestShortID()
+	baseTx, input := newTestBaseTx(addr)
+	tx := &platformtxs.Tx{
+		Unsigned: &platformtxs.CreateSubnetTx{
+			BaseTx: platformtxs.BaseTx{BaseTx: baseTx},
+			Owner: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{},
+		},
+	}
+	require.NoError(t, tx.Initialize(platformtxs.Codec))
+
+	blk, err := platformblock.NewBanffStandardBlock(
+		time.Unix(1_700_000_000, 0),
+		ids.GenerateTestID(),
+		1,
+		[]*platformtxs.Tx{tx},
+	)
+	require.NoError(t, err)
+
+	for name, container := range wrap(t, blk.Bytes()) {
+		t.Run(name, func(t *testing.T) {
+			activity, err := PChainParser(container)
+			require.NoError(t, err)
+			requireActivity(t, activity, tx.ID(), input, addr)
+		})
+	}
+}
@@ -40,7 +40,9 @@ type NetworkClient interface {
 	// RequestAny synchronously sends request to an arbitrary peer with a
 	// node version greater than or equal to minVersion.
 	// Returns response bytes, the ID of the chosen peer, and ErrRequestFailed if
-	// the request should be retried.
+	// the request should be retried. Peers with too many requests in flight
+	// are skipped. If every selected peer had too many requests in flight,
+	// p2p.ErrPeerBusy is returned.
 	RequestAny(
 		ctx context.Context,
 		minVersion *version.Application,
@@ -50,6 +52,8 @@ type NetworkClient interface {
 	// Sends [request] to [nodeID] and returns the response.
 	// Blocks until the number of outstanding requests is
 	// below the limit before sending the request.
+	// Returns p2p.ErrPeerBusy without sending the request if [nodeID] has too
+	// many requests in flight, so that the caller can pick another peer.
 	Request(
 		ctx context.Context,
 		nodeID ids.NodeID,
@@ -89,6 +93,8 @@ type networkClient struct {
 	activeRequests *semaphore.Weighted
 	// tracking of peers & bandwidth usage
 	peers *p2p.PeerTracker
+	// limits the number of requests in flight to each peer
+	limiter *p2p.OutboundLimiter
 	// For sending messages to peers
 	appSender common.AppSender
 }
@@ -97,21 +103,38 @@ func NewNetworkClient(
 	appSender common.AppSender,
 	myNodeID ids.NodeID,
 	maxActiveRequests int64,
+	limiterConfig p2p.OutboundLimiterConfig,
 	log logging.Logger,
 	metricsNamespace string,
 	registerer prometheus.Registerer,
 ) (NetworkClient, error) {
-	peerTracker, err := p2p.NewPeerTracker(log, metricsNamespace, registerer)
+	peerTracker, err := p2p.NewPeerTracker(
+		p2p.DefaultPeerTrackerConfig,
+		log,
+		metricsNamespace,
+		registerer,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create peer tracker: %w", err)
 	}
 
+	limiter, err := p2p.NewOutboundLimiter(
+		limiterConfig,
+		peerTracker,
+		metricsNamespace,
+		registerer,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create outbound limiter: %w", err)
+	}
+
 	return &networkClient{
 		appSender:                  appSender,
 		myNodeID:                   myNodeID,
 		outstandingRequestHandlers: make(map[uint32]ResponseHandler),
 		activeRequests:             semaphore.NewWeighted(maxActiveRequests),
 		peers:                      peerTracker,
+		limiter:                    limiter,
 		log:                        log,
 	}, nil
 }
@@ -202,13 +225,13 @@ func (c *networkClient) RequestAny(
 	}
 	defer c.activeRequests.Release(1)
 
-	nodeID, ok := c.peers.GetAnyPeer(minVersion)
-	if !ok {
-		return ids.EmptyNodeID, nil, fmt.Errorf(
-			"no peers found matching version %s out of %d peers",
-			minVersion, c.peers.Size(),
-		)
+	// Spread requests across network groups so that syncing doesn't depend on
+	// peers hosted by a single operator.
+	nodeID, err := c.limiter.AcquireAnyPeer(minVersion, p2p.WithNetworkDiversity())
+	if err != nil {
+		return ids.EmptyNodeID, nil, err
 	}
+	defer c.limiter.Release(nodeID)
 
 	response, err := c.request(ctx, nodeID, request)
 	return nodeID, response, err
@@ -227,6 +250,11 @@ func (c *networkClient) Request(
 	}
 	defer c.activeRequests.Release(1)
 
+	if err := c.limiter.Acquire(nodeID); err != nil {
+		return nil, err
+	}
+	defer c.limiter.Release(nodeID)
+
 	return c.request(ctx, nodeID, request)
 }
 
@@ -279,7 +307,7 @@ func (c *networkClient) request(
 
 	select {
 	case <-ctx.Done():
-		c.peers.TrackBandwidth(nodeID, 0)
+		c.peers.RegisterTimeout(nodeID)
 		return nil, ctx.Err()
 	case response = <-handler.responseChan:
 		elapsedSeconds := time.Since(startTime).Seconds()